@@ -7,13 +7,32 @@ const (
 	LabelCacheEnabled     = "cache.kyverno.io/enabled"
 	LabelCertManagedBy    = "cert.kyverno.io/managed-by"
 	LabelCleanupTtl       = "cleanup.kyverno.io/ttl"
+	LabelPolicySet        = "policyset.kyverno.io/name"
 	LabelWebhookManagedBy = "webhook.kyverno.io/managed-by"
 	// Well known annotations
-	AnnotationAutogenControllers = "pod-policies.kyverno.io/autogen-controllers"
-	AnnotationImageVerify        = "kyverno.io/verify-images"
-	AnnotationPolicyCategory     = "policies.kyverno.io/category"
-	AnnotationPolicyScored       = "policies.kyverno.io/scored"
-	AnnotationPolicySeverity     = "policies.kyverno.io/severity"
+	AnnotationAppliedPatches             = "policies.kyverno.io/applied-patches"
+	AnnotationAutogenControllers         = "pod-policies.kyverno.io/autogen-controllers"
+	AnnotationAutogenCustomControllers   = "pod-policies.kyverno.io/autogen-custom-controllers"
+	AnnotationCanaryNonce                = "kyverno.io/canary-nonce"
+	AnnotationCanaryResponse             = "kyverno.io/canary-response"
+	AnnotationConversionData             = "kyverno.io/conversion-data"
+	AnnotationDenyTrace                  = "kyverno.io/deny-trace"
+	AnnotationImageVerify                = "kyverno.io/verify-images"
+	AnnotationManagedResourcesBreakGlass = "kyverno.io/managed-resources-break-glass-until"
+	AnnotationMutationPrecedence         = "policies.kyverno.io/mutation-precedence"
+	AnnotationPolicyCategory             = "policies.kyverno.io/category"
+	AnnotationPolicyDescription          = "policies.kyverno.io/description"
+	AnnotationPolicyLatencyBudget        = "policies.kyverno.io/latency-budget"
+	AnnotationPolicyScored               = "policies.kyverno.io/scored"
+	AnnotationPolicySetDigest            = "policyset.kyverno.io/digest"
+	AnnotationPolicySeverity             = "policies.kyverno.io/severity"
+	AnnotationPolicyStatus               = "policies.kyverno.io/status"
+	AnnotationPolicyTitle                = "policies.kyverno.io/title"
+	AnnotationValidationFailureActions   = "kyverno.io/validation-failure-actions"
+	AnnotationWebhookAnnotations         = "webhook.kyverno.io/annotations"
+	AnnotationWebhookLabels              = "webhook.kyverno.io/labels"
+	AnnotationWebhookOwnedAnnotations    = "webhook.kyverno.io/owned-annotations"
+	AnnotationWebhookOwnedLabels         = "webhook.kyverno.io/owned-labels"
 	// Well known values
 	ValueKyvernoApp        = "kyverno"
 	ValueTtlDateTimeLayout = "2006-01-02T150405Z"
@@ -3,8 +3,11 @@ package v1
 import (
 	"encoding/json"
 	"fmt"
+	"hash/fnv"
+	"time"
 
 	"github.com/kyverno/kyverno/pkg/engine/variables/regex"
+	"github.com/robfig/cron"
 	"github.com/sigstore/k8s-manifest-sigstore/pkg/k8smanifest"
 	admissionv1 "k8s.io/api/admission/v1"
 	"k8s.io/api/admissionregistration/v1alpha1"
@@ -87,6 +90,51 @@ type ContextEntry struct {
 
 	// Variable defines an arbitrary JMESPath context variable that can be defined inline.
 	Variable *Variable `json:"variable,omitempty" yaml:"variable,omitempty"`
+
+	// ResourceUsage sums the resource requests and limits of the existing pods in a namespace.
+	// The data is read from an informer cache when pod caching is enabled.
+	ResourceUsage *ResourceUsage `json:"resourceUsage,omitempty" yaml:"resourceUsage,omitempty"`
+
+	// ImageDigest resolves an image tag to its current digest.
+	// Resolved digests are kept in a cache shared by all policies until their TTL expires.
+	ImageDigest *ImageDigest `json:"imageDigest,omitempty" yaml:"imageDigest,omitempty"`
+
+	// Fallback defines the behavior of APICall, ImageRegistry and ImageDigest context entries when their destination
+	// is considered unavailable (the circuit breaker of the destination is open).
+	// When not set the rule fails to load its context and the policy failure policy applies.
+	// +optional
+	Fallback *ContextFallback `json:"fallback,omitempty" yaml:"fallback,omitempty"`
+}
+
+// ContextFallback defines the behavior of a context entry when its destination is unavailable.
+type ContextFallback struct {
+	// Default is an arbitrary JSON object stored in the context instead of the unavailable data.
+	// +optional
+	Default *apiextv1.JSON `json:"default,omitempty" yaml:"default,omitempty"`
+
+	// Skip skips the rule instead of failing it, it is ignored when a default is set.
+	// +optional
+	Skip bool `json:"skip,omitempty" yaml:"skip,omitempty"`
+}
+
+// ResourceUsage sums the resource requests and limits of the existing pods in a namespace.
+// The context entry contains the number of pods along with the summed requests and limits,
+// pods in a terminal phase are not accounted for.
+type ResourceUsage struct {
+	// Namespace is the namespace of the pods to sum, it can contain variables.
+	Namespace string `json:"namespace" yaml:"namespace"`
+}
+
+// ImageDigest resolves an image reference to its current digest.
+// The context entry contains the image, the digest and the resolved image (the image pinned to the digest).
+type ImageDigest struct {
+	// Reference is the image reference to resolve, it can contain variables.
+	// Example: ghcr.io/kyverno/kyverno:latest
+	Reference string `json:"reference" yaml:"reference"`
+
+	// ImageRegistryCredentials provides credentials that will be used for authentication with registry
+	// +kubebuilder:validation:Optional
+	ImageRegistryCredentials *ImageRegistryCredentials `json:"imageRegistryCredentials,omitempty" yaml:"imageRegistryCredentials,omitempty"`
 }
 
 // Variable defines an arbitrary JMESPath context variable that can be defined inline.
@@ -161,6 +209,45 @@ type APICall struct {
 	// of deployments across all namespaces.
 	// +kubebuilder:validation:Optional
 	JMESPath string `json:"jmesPath,omitempty" yaml:"jmesPath,omitempty"`
+
+	// List configures list requests to the Kubernetes API server, resources can be filtered
+	// with label and field selectors, fetched in pages and projected before being stored in the context.
+	// It can only be used with GET requests to a URLPath returning a list.
+	// +kubebuilder:validation:Optional
+	List *APICallList `json:"list,omitempty" yaml:"list,omitempty"`
+}
+
+// APICallList configures a list request to the Kubernetes API server.
+type APICallList struct {
+	// LabelSelector restricts the list of returned resources by their labels,
+	// it uses the same syntax as the `kubectl get --selector` flag.
+	// +kubebuilder:validation:Optional
+	LabelSelector string `json:"labelSelector,omitempty" yaml:"labelSelector,omitempty"`
+
+	// FieldSelector restricts the list of returned resources by their fields,
+	// it uses the same syntax as the `kubectl get --field-selector` flag.
+	// +kubebuilder:validation:Optional
+	FieldSelector string `json:"fieldSelector,omitempty" yaml:"fieldSelector,omitempty"`
+
+	// PageSize is the maximum number of resources returned by the API server in a single response,
+	// pages are requested until the list is complete or MaxItems is reached.
+	// By default the list is fetched in a single request.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Optional
+	PageSize *int64 `json:"pageSize,omitempty" yaml:"pageSize,omitempty"`
+
+	// MaxItems is the maximum number of resources kept in the list, no more pages are requested
+	// once it is reached.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Optional
+	MaxItems *int64 `json:"maxItems,omitempty" yaml:"maxItems,omitempty"`
+
+	// Projection is an optional JMESPath expression applied to every listed resource as pages are received,
+	// only the projected values are kept in the `items` of the list. For example a projection of
+	// "metadata.name" stores the names of the resources only. The JMESPath of the API call is applied
+	// to the projected list.
+	// +kubebuilder:validation:Optional
+	Projection string `json:"projection,omitempty" yaml:"projection,omitempty"`
 }
 
 type ServiceCall struct {
@@ -291,6 +378,13 @@ type Mutation struct {
 	// +optional
 	Targets []TargetResourceSpec `json:"targets,omitempty" yaml:"targets,omitempty"`
 
+	// AllowStatusMutation allows targets to select the status subresource (e.g. `MyResource/status`)
+	// so that the status of existing resources can be mutated. Defaults to true, set it to false to
+	// reject targets selecting the status subresource. The background controller must be allowed to
+	// get and update the status subresource of the targets.
+	// +optional
+	AllowStatusMutation *bool `json:"allowStatusMutation,omitempty" yaml:"allowStatusMutation,omitempty"`
+
 	// PatchStrategicMerge is a strategic merge patch used to modify resources.
 	// See https://kubernetes.io/docs/tasks/manage-kubernetes-objects/update-api-object-kubectl-patch/
 	// and https://kubectl.docs.kubernetes.io/references/kustomize/patchesstrategicmerge/.
@@ -307,6 +401,11 @@ type Mutation struct {
 	ForEachMutation []ForEachMutation `json:"foreach,omitempty" yaml:"foreach,omitempty"`
 }
 
+// IsStatusMutationAllowed returns true if targets can select the status subresource
+func (m *Mutation) IsStatusMutationAllowed() bool {
+	return m.AllowStatusMutation == nil || *m.AllowStatusMutation
+}
+
 func (m *Mutation) GetPatchStrategicMerge() apiextensions.JSON {
 	return FromJSON(m.RawPatchStrategicMerge)
 }
@@ -384,6 +483,11 @@ type Validation struct {
 	// +optional
 	RawAnyPattern *apiextv1.JSON `json:"anyPattern,omitempty" yaml:"anyPattern,omitempty"`
 
+	// AnyPatternMessages are the failure messages of the AnyPattern patterns, in the same order.
+	// The message of each failed pattern is reported with the path it failed at.
+	// +optional
+	AnyPatternMessages []string `json:"anyPatternMessages,omitempty" yaml:"anyPatternMessages,omitempty"`
+
 	// Deny defines conditions used to pass or fail a validation rule.
 	// +optional
 	Deny *Deny `json:"deny,omitempty" yaml:"deny,omitempty"`
@@ -396,6 +500,57 @@ type Validation struct {
 	// CEL allows validation checks using the Common Expression Language (https://kubernetes.io/docs/reference/using-api/cel/).
 	// +optional
 	CEL *CEL `json:"cel,omitempty" yaml:"cel,omitempty"`
+
+	// Assert defines assertion trees used to validate resources.
+	// +optional
+	Assert *Assertion `json:"assert,omitempty" yaml:"assert,omitempty"`
+
+	// Severity overrides the severity of the policy (policies.kyverno.io/severity annotation) in the report results of failed validations.
+	// It can contain variables and must resolve to one of critical, high, medium, low or info, other values are ignored.
+	// +optional
+	Severity string `json:"severity,omitempty" yaml:"severity,omitempty"`
+
+	// Properties are custom properties added to the report results of the rule, values can contain variables.
+	// +optional
+	Properties map[string]string `json:"properties,omitempty" yaml:"properties,omitempty"`
+
+	// AnnotateResource adds the failures of the rule to the compliance summary annotation (policies.kyverno.io/status)
+	// of the evaluated resources, e.g. "2 failing", when the policy runs in Audit mode.
+	// The annotation is patched by the reports controller, which needs permission to patch the resources.
+	// +optional
+	AnnotateResource bool `json:"annotateResource,omitempty" yaml:"annotateResource,omitempty"`
+}
+
+// Assertion defines assertion trees used to validate resources.
+// All the `all` checks and at least one of the `any` checks (when specified) must pass.
+type Assertion struct {
+	// Any contains checks, at least one of them must pass.
+	// +optional
+	Any []AssertionCheck `json:"any,omitempty" yaml:"any,omitempty"`
+
+	// All contains checks, all of them must pass.
+	// +optional
+	All []AssertionCheck `json:"all,omitempty" yaml:"all,omitempty"`
+}
+
+// AssertionCheck is an assertion tree evaluated against the resource.
+// Keys of the tree are field names or JMESPath expressions enclosed in parentheses used to project the current value,
+// a key prefixed with `~` asserts its value against every element of the projected array and a key suffixed
+// with `->name` binds the projected value to `$name` in nested expressions.
+// Leaves are compared with the projected values, a leaf enclosed in parentheses is a JMESPath expression.
+type AssertionCheck struct {
+	// Message specifies a custom message to be displayed when the check fails.
+	// +optional
+	Message string `json:"message,omitempty" yaml:"message,omitempty"`
+
+	// Check is the assertion tree.
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:pruning:PreserveUnknownFields
+	RawCheck *apiextv1.JSON `json:"check" yaml:"check"`
+}
+
+func (a *AssertionCheck) GetCheck() apiextensions.JSON {
+	return FromJSON(a.RawCheck)
 }
 
 // PodSecurity applies exemptions for Kubernetes Pod Security admission
@@ -407,8 +562,9 @@ type PodSecurity struct {
 	Level api.Level `json:"level,omitempty" yaml:"level,omitempty"`
 
 	// Version defines the Pod Security Standard versions that Kubernetes supports.
-	// Allowed values are v1.19, v1.20, v1.21, v1.22, v1.23, v1.24, v1.25, v1.26, latest. Defaults to latest.
-	// +kubebuilder:validation:Enum=v1.19;v1.20;v1.21;v1.22;v1.23;v1.24;v1.25;v1.26;latest
+	// Allowed values are v1.19 and later minor versions (e.g. v1.26), or latest. Defaults to latest.
+	// Versions newer than the latest version known to Kyverno are evaluated as latest.
+	// +kubebuilder:validation:Pattern=`^(latest|v1\.(19|[2-9][0-9]))$`
 	// +optional
 	Version string `json:"version,omitempty" yaml:"version,omitempty"`
 
@@ -429,6 +585,13 @@ type PodSecurityStandard struct {
 	// Wildcards ('*' and '?') are allowed. See: https://kubernetes.io/docs/concepts/containers/images.
 	// +optional
 	Images []string `json:"images,omitempty" yaml:"images,omitempty"`
+
+	// ContainerNames selects matching containers by name and applies the container level PSS.
+	// A container is exempted when either its image matches one of the images or its name
+	// matches one of the container names.
+	// Wildcards ('*' and '?') are allowed.
+	// +optional
+	ContainerNames []string `json:"containerNames,omitempty" yaml:"containerNames,omitempty"`
 }
 
 // CEL allows validation checks using the Common Expression Language (https://kubernetes.io/docs/reference/using-api/cel/).
@@ -557,6 +720,11 @@ type ForEachValidation struct {
 	// +optional
 	RawAnyPattern *apiextv1.JSON `json:"anyPattern,omitempty" yaml:"anyPattern,omitempty"`
 
+	// AnyPatternMessages are the failure messages of the AnyPattern patterns, in the same order.
+	// The message of each failed pattern is reported with the path it failed at.
+	// +optional
+	AnyPatternMessages []string `json:"anyPatternMessages,omitempty" yaml:"anyPatternMessages,omitempty"`
+
 	// Deny defines conditions used to pass or fail a validation rule.
 	// +optional
 	Deny *Deny `json:"deny,omitempty" yaml:"deny,omitempty"`
@@ -594,6 +762,27 @@ type Generation struct {
 	// +optional
 	Synchronize bool `json:"synchronize,omitempty" yaml:"synchronize,omitempty"`
 
+	// OrphanDownstreamOnPolicyDelete controls if generated resources are kept when the policy is deleted.
+	// If OrphanDownstreamOnPolicyDelete is set to "false" a finalizer is added to the policy and the resources
+	// generated by the rule are deleted before the policy is removed. If they can't be deleted within 5 minutes
+	// the finalizer is removed and they are orphaned, the finalizer can also be removed manually to release the policy.
+	// Optional. Defaults to "true" if not specified.
+	// +optional
+	OrphanDownstreamOnPolicyDelete *bool `json:"orphanDownstreamOnPolicyDelete,omitempty" yaml:"orphanDownstreamOnPolicyDelete,omitempty"`
+
+	// ServiceAccount is the service account impersonated when creating, updating and deleting the
+	// generated resources. When set, the background controller only acts with the permissions granted
+	// to the service account and must be allowed to impersonate it.
+	// Optional. Defaults to the background controller service account if not specified.
+	// +optional
+	ServiceAccount *ServiceAccountReference `json:"serviceAccount,omitempty" yaml:"serviceAccount,omitempty"`
+
+	// Cluster is the remote cluster in which the resources are generated, only resources declared with Data
+	// can be generated in a remote cluster.
+	// Optional. Defaults to the cluster Kyverno runs in if not specified.
+	// +optional
+	Cluster *ClusterReference `json:"cluster,omitempty" yaml:"cluster,omitempty"`
+
 	// Data provides the resource declaration used to populate each generated resource.
 	// At most one of Data or Clone must be specified. If neither are provided, the generated
 	// resource will be created with default data only.
@@ -611,6 +800,37 @@ type Generation struct {
 	CloneList CloneList `json:"cloneList,omitempty" yaml:"cloneList,omitempty"`
 }
 
+// ServiceAccountReference identifies a service account.
+type ServiceAccountReference struct {
+	// Name is the name of the service account.
+	Name string `json:"name" yaml:"name"`
+
+	// Namespace is the namespace of the service account.
+	// Optional for namespaced policies, defaults to the policy namespace.
+	// +optional
+	Namespace string `json:"namespace,omitempty" yaml:"namespace,omitempty"`
+}
+
+// ClusterReference identifies a remote cluster by the Secret holding its kubeconfig.
+type ClusterReference struct {
+	// Secret is the name of the Secret holding the kubeconfig of the remote cluster.
+	// The Secret must be in the Kyverno namespace and be allowed by the generateClusters key of the Kyverno config map.
+	Secret string `json:"secret" yaml:"secret"`
+
+	// Key is the key of the kubeconfig in the Secret data.
+	// Optional. Defaults to "kubeconfig" if not specified.
+	// +optional
+	Key string `json:"key,omitempty" yaml:"key,omitempty"`
+}
+
+// GetKey returns the key of the kubeconfig in the Secret data
+func (c *ClusterReference) GetKey() string {
+	if c.Key == "" {
+		return "kubeconfig"
+	}
+	return c.Key
+}
+
 type CloneList struct {
 	// Namespace specifies source resource namespace.
 	Namespace string `json:"namespace,omitempty" yaml:"namespace,omitempty"`
@@ -643,6 +863,14 @@ func (g *Generation) Validate(path *field.Path, namespaced bool, policyNamespace
 		}
 	}
 
+	if g.ServiceAccount != nil {
+		errs = append(errs, g.validateServiceAccount(path.Child("generate").Child("serviceAccount"), namespaced, policyNamespace)...)
+	}
+
+	if g.Cluster != nil {
+		errs = append(errs, g.validateCluster(path.Child("generate").Child("cluster"), namespaced)...)
+	}
+
 	generateType, _ := g.GetTypeAndSync()
 	if generateType == Data {
 		return errs
@@ -711,6 +939,61 @@ func (g *Generation) ValidateCloneList(path *field.Path, namespaced bool, policy
 	return errs
 }
 
+func (g *Generation) validateServiceAccount(path *field.Path, namespaced bool, policyNamespace string) (errs field.ErrorList) {
+	sa := g.ServiceAccount
+	if err := regex.ObjectHasVariables(*sa); err != nil {
+		errs = append(errs, field.Forbidden(path, "service account should not have variables"))
+	}
+	if sa.Name == "" {
+		errs = append(errs, field.Required(path.Child("name"), "service account name is required"))
+	}
+	if namespaced {
+		if sa.Namespace != "" && sa.Namespace != policyNamespace {
+			errs = append(errs, field.Forbidden(path.Child("namespace"), fmt.Sprintf("a namespaced policy cannot impersonate service accounts from other namespaces, expected: %v, received: %v", policyNamespace, sa.Namespace)))
+		}
+	} else if sa.Namespace == "" {
+		errs = append(errs, field.Required(path.Child("namespace"), "service account namespace is required for a cluster-wide policy"))
+	}
+	return errs
+}
+
+func (g *Generation) validateCluster(path *field.Path, namespaced bool) (errs field.ErrorList) {
+	cluster := g.Cluster
+	// the kubeconfig secrets are in the Kyverno namespace, tenants must not be able to use them
+	if namespaced {
+		errs = append(errs, field.Forbidden(path, "resources cannot be generated in a remote cluster by namespaced policies"))
+	}
+	if err := regex.ObjectHasVariables(*cluster); err != nil {
+		errs = append(errs, field.Forbidden(path, "cluster should not have variables"))
+	}
+	if cluster.Secret == "" {
+		errs = append(errs, field.Required(path.Child("secret"), "cluster secret is required"))
+	}
+	if generateType, _ := g.GetTypeAndSync(); generateType != Data {
+		errs = append(errs, field.Forbidden(path, "only resources declared with data can be generated in a remote cluster"))
+	}
+	if g.ServiceAccount != nil {
+		errs = append(errs, field.Forbidden(path, "a service account cannot be impersonated in a remote cluster"))
+	}
+	if !g.IsOrphanDownstreamOnPolicyDelete() {
+		errs = append(errs, field.Forbidden(path, "resources generated in a remote cluster cannot be deleted with the policy, orphanDownstreamOnPolicyDelete must not be false"))
+	}
+	return errs
+}
+
+// GetServiceAccountUser returns the user name of the service account impersonated when managing
+// the generated resources, it returns an empty string if no service account is configured
+func (g *Generation) GetServiceAccountUser(policyNamespace string) string {
+	if g.ServiceAccount == nil {
+		return ""
+	}
+	namespace := g.ServiceAccount.Namespace
+	if namespace == "" {
+		namespace = policyNamespace
+	}
+	return "system:serviceaccount:" + namespace + ":" + g.ServiceAccount.Name
+}
+
 func (g *Generation) GetData() apiextensions.JSON {
 	return FromJSON(g.RawData)
 }
@@ -751,6 +1034,11 @@ func (g *Generation) GetTypeAndSync() (GenerateType, bool) {
 	return Clone, g.Synchronize
 }
 
+// IsOrphanDownstreamOnPolicyDelete returns true if generated resources are kept when the policy is deleted
+func (g *Generation) IsOrphanDownstreamOnPolicyDelete() bool {
+	return g.OrphanDownstreamOnPolicyDelete == nil || *g.OrphanDownstreamOnPolicyDelete
+}
+
 // CloneFrom provides the location of the source resource used to generate target resources.
 // The resource kind is derived from the match criteria.
 type CloneFrom struct {
@@ -807,3 +1095,139 @@ const (
 	Delete  AdmissionOperation = AdmissionOperation(admissionv1.Delete)
 	Connect AdmissionOperation = AdmissionOperation(admissionv1.Connect)
 )
+
+// Schedule restricts the time windows during which a policy or a rule is active.
+// When several fields are set, all of them must be satisfied for the policy or rule to be active.
+type Schedule struct {
+	// Cron is a standard cron expression (minute, hour, day of month, month, day of week),
+	// the policy or rule is active during every minute matched by the expression.
+	// For example `* 9-17 * * 1-5` is active during business hours.
+	// The expression is evaluated in the time zone configured for Kyverno (UTC by default).
+	// +optional
+	Cron string `json:"cron,omitempty" yaml:"cron,omitempty"`
+
+	// NotBefore is the time (RFC3339) from which the policy or rule is active.
+	// +optional
+	NotBefore *metav1.Time `json:"notBefore,omitempty" yaml:"notBefore,omitempty"`
+
+	// NotAfter is the time (RFC3339) after which the policy or rule is no longer active.
+	// +optional
+	NotAfter *metav1.Time `json:"notAfter,omitempty" yaml:"notAfter,omitempty"`
+}
+
+// IsActive checks whether the schedule is active at the given time, the cron expression is evaluated in the given location
+func (s *Schedule) IsActive(now time.Time, location *time.Location) (bool, error) {
+	if s == nil {
+		return true, nil
+	}
+	if s.NotBefore != nil && now.Before(s.NotBefore.Time) {
+		return false, nil
+	}
+	if s.NotAfter != nil && now.After(s.NotAfter.Time) {
+		return false, nil
+	}
+	if s.Cron == "" {
+		return true, nil
+	}
+	schedule, err := cron.ParseStandard(s.Cron)
+	if err != nil {
+		return false, err
+	}
+	if location == nil {
+		location = time.UTC
+	}
+	minute := now.In(location).Truncate(time.Minute)
+	return schedule.Next(minute.Add(-time.Second)).Equal(minute), nil
+}
+
+// Validate implements programmatic validation
+func (s *Schedule) Validate(path *field.Path) (errs field.ErrorList) {
+	if s == nil {
+		return errs
+	}
+	if s.Cron == "" && s.NotBefore == nil && s.NotAfter == nil {
+		errs = append(errs, field.Required(path, "at least one of cron, notBefore or notAfter must be specified"))
+	}
+	if s.Cron != "" {
+		if _, err := cron.ParseStandard(s.Cron); err != nil {
+			errs = append(errs, field.Invalid(path.Child("cron"), s.Cron, fmt.Sprintf("invalid cron expression: %s", err)))
+		}
+	}
+	if s.NotBefore != nil && s.NotAfter != nil && !s.NotBefore.Before(s.NotAfter) {
+		errs = append(errs, field.Invalid(path.Child("notAfter"), s.NotAfter, "notAfter must be after notBefore"))
+	}
+	return errs
+}
+
+// RolloutMode defines how the validation failures of a policy are enforced during a rollout.
+// +kubebuilder:validation:Enum=Enforce;Shadow
+type RolloutMode string
+
+const (
+	// RolloutEnforce means validation failures are enforced for the configured percentage of requests.
+	RolloutEnforce RolloutMode = "Enforce"
+	// RolloutShadow means the policy is evaluated and reported but never blocks requests.
+	RolloutShadow RolloutMode = "Shadow"
+)
+
+// Rollout controls the progressive enforcement of a policy.
+// It only applies to policies with validationFailureAction set to Enforce,
+// requests that are not enforced are still evaluated and reported.
+type Rollout struct {
+	// Mode is the rollout mode, Enforce (default) enforces validation failures for the configured
+	// percentage of requests, Shadow evaluates and reports the policy but never blocks requests.
+	// +optional
+	Mode RolloutMode `json:"mode,omitempty" yaml:"mode,omitempty"`
+
+	// Percentage is the percentage of matching requests for which validation failures are enforced.
+	// Requests are selected by hashing the policy and the resource identity, a given resource is
+	// consistently enforced or not for a given percentage. Defaults to 100.
+	// +kubebuilder:validation:Minimum:=0
+	// +kubebuilder:validation:Maximum:=100
+	// +optional
+	Percentage *int `json:"percentage,omitempty" yaml:"percentage,omitempty"`
+}
+
+// IsShadow returns true if the policy never blocks requests
+func (r *Rollout) IsShadow() bool {
+	return r != nil && r.Mode == RolloutShadow
+}
+
+// GetPercentage returns the percentage of matching requests for which validation failures are enforced
+func (r *Rollout) GetPercentage() int {
+	if r == nil || r.Percentage == nil {
+		return 100
+	}
+	return *r.Percentage
+}
+
+// Enforces returns true if validation failures are enforced for the request identified by the given key
+func (r *Rollout) Enforces(key string) bool {
+	if r.IsShadow() {
+		return false
+	}
+	percentage := r.GetPercentage()
+	if percentage >= 100 {
+		return true
+	}
+	if percentage <= 0 {
+		return false
+	}
+	hash := fnv.New32a()
+	_, _ = hash.Write([]byte(key))
+	return int(hash.Sum32()%100) < percentage
+}
+
+// Validate implements programmatic validation
+func (r *Rollout) Validate(path *field.Path) (errs field.ErrorList) {
+	if r == nil {
+		return errs
+	}
+	if r.Mode != "" && r.Mode != RolloutEnforce && r.Mode != RolloutShadow {
+		errs = append(errs, field.NotSupported(path.Child("mode"), r.Mode, []string{string(RolloutEnforce), string(RolloutShadow)}))
+	}
+	if r.Percentage != nil && (*r.Percentage < 0 || *r.Percentage > 100) {
+		errs = append(errs, field.Invalid(path.Child("percentage"), *r.Percentage, "percentage must be between 0 and 100"))
+	}
+	return errs
+}
@@ -136,6 +136,45 @@ func Test_ImageVerification(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "kms static key attestor",
+			subject: ImageVerification{
+				ImageReferences: []string{"*"},
+				Attestors: []AttestorSet{
+					{Entries: []Attestor{{
+						Keys: &StaticKeyAttestor{KMS: "awskms:///arn:aws:kms:us-east-1:123456789012:alias/cosign"},
+					}}},
+				},
+			},
+		},
+		{
+			name: "kms static key attestor with variable",
+			subject: ImageVerification{
+				ImageReferences: []string{"*"},
+				Attestors: []AttestorSet{
+					{Entries: []Attestor{{
+						Keys: &StaticKeyAttestor{KMS: "{{ keys.data.kms }}"},
+					}}},
+				},
+			},
+		},
+		{
+			name: "unsupported kms static key attestor",
+			subject: ImageVerification{
+				ImageReferences: []string{"*"},
+				Attestors: []AttestorSet{
+					{Entries: []Attestor{{
+						Keys: &StaticKeyAttestor{KMS: "foo://bar"},
+					}}},
+				},
+			},
+			errors: func(i *ImageVerification) field.ErrorList {
+				return field.ErrorList{
+					field.Invalid(path.Child("attestors").Index(0).Child("entries").Index(0).Child("keys").Child("kms"),
+						"foo://bar", "Unsupported KMS key reference, supported schemes are awskms://, gcpkms://, azurekms:// and hashivault://"),
+				}
+			},
+		},
 		{
 			name: "invalid keyless attestor",
 			subject: ImageVerification{
@@ -3,7 +3,9 @@ package v1
 import (
 	"encoding/json"
 	"fmt"
+	"strings"
 
+	"github.com/kyverno/kyverno/pkg/engine/variables/regex"
 	apiextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 )
@@ -114,6 +116,24 @@ type ImageVerification struct {
 	// +kubebuilder:default=true
 	// +kubebuilder:validation:Optional
 	UseCache bool `json:"useCache" yaml:"useCache"`
+
+	// Strict requires every image of the resource to be covered by the imageReferences of a verifyImages
+	// entry in the rule, images that are not covered fail the rule. Covered images must have a digest and
+	// must have been verified, regardless of the verifyDigest and required settings.
+	// +kubebuilder:validation:Optional
+	Strict bool `json:"strict,omitempty" yaml:"strict,omitempty"`
+
+	// Platforms selects the platform manifests verified for multi-arch images, in the os/arch[/variant]
+	// form (e.g. linux/amd64). When empty the image reference is verified as is, i.e. the index of a
+	// multi-arch image. When set, the manifest of every platform is resolved from the image index and
+	// verified in place of the index, the image fails verification if a platform is missing from the index.
+	// The digests of the verified manifests are available to subsequent rules in the `platformDigests` variable.
+	// +kubebuilder:validation:Optional
+	Platforms []string `json:"platforms,omitempty" yaml:"platforms,omitempty"`
+
+	// VerifyIndex also verifies the image index of multi-arch images when platforms are selected.
+	// +kubebuilder:validation:Optional
+	VerifyIndex bool `json:"verifyIndex,omitempty" yaml:"verifyIndex,omitempty"`
 }
 
 type AttestorSet struct {
@@ -182,6 +202,9 @@ type StaticKeyAttestor struct {
 
 	// KMS provides the URI to the public key stored in a Key Management System. See:
 	// https://github.com/sigstore/cosign/blob/main/KMS.md
+	// Supported schemes are awskms://, gcpkms://, azurekms:// and hashivault://. Credentials are
+	// resolved from the Kyverno pods environment, workload identities bound to the Kyverno service
+	// accounts can be used so that no credentials are stored in Secrets.
 	KMS string `json:"kms,omitempty" yaml:"kms,omitempty"`
 
 	// Reference to a Secret resource that contains a public key
@@ -322,6 +345,8 @@ func (iv *ImageVerification) Validate(isAuditFailureAction bool, path *field.Pat
 		errs = append(errs, field.Invalid(path, iv, "An image reference is required"))
 	}
 
+	errs = append(errs, ValidateImagePlatforms(path.Child("platforms"), iv.Platforms)...)
+
 	asPath := path.Child("attestations")
 	for i, attestation := range copy.Attestations {
 		attestationErrors := attestation.Validate(asPath.Index(i))
@@ -445,9 +470,22 @@ func (ska *StaticKeyAttestor) Validate(path *field.Path) (errs field.ErrorList)
 	if ska.PublicKeys != "" && ska.SignatureAlgorithm != "" && ska.SignatureAlgorithm != "sha256" && ska.SignatureAlgorithm != "sha512" {
 		errs = append(errs, field.Invalid(path, ska, "Invalid signature algorithm provided"))
 	}
+	if ska.KMS != "" && !regex.IsVariable(ska.KMS) && !isSupportedKMS(ska.KMS) {
+		errs = append(errs, field.Invalid(path.Child("kms"), ska.KMS, "Unsupported KMS key reference, supported schemes are awskms://, gcpkms://, azurekms:// and hashivault://"))
+	}
 	return errs
 }
 
+// isSupportedKMS checks the scheme of a KMS key reference, k8s:// is accepted for backwards compatibility
+func isSupportedKMS(keyRef string) bool {
+	for _, scheme := range []string{"awskms://", "gcpkms://", "azurekms://", "hashivault://", "k8s://"} {
+		if strings.HasPrefix(keyRef, scheme) {
+			return true
+		}
+	}
+	return false
+}
+
 func (ca *CertificateAttestor) Validate(path *field.Path) (errs field.ErrorList) {
 	if ca.Certificate == "" && ca.CertificateChain == "" {
 		errs = append(errs, field.Invalid(path, ca, "cert or certChain required"))
@@ -1,6 +1,8 @@
 package v1
 
 import (
+	"fmt"
+
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -8,6 +10,8 @@ import (
 const (
 	// PolicyConditionReady means that the policy is ready
 	PolicyConditionReady = "Ready"
+	// PolicyConditionGenerateSynchronized means that the resources generated by the policy are in sync
+	PolicyConditionGenerateSynchronized = "GenerateSynchronized"
 )
 
 const (
@@ -15,6 +19,8 @@ const (
 	PolicyReasonSucceeded = "Succeeded"
 	// PolicyReasonSucceeded is the reason set when the policy is not ready
 	PolicyReasonFailed = "Failed"
+	// PolicyReasonPending is the reason set when generated resources are waiting to be synchronized
+	PolicyReasonPending = "Pending"
 )
 
 // PolicyStatus mostly contains runtime information related to policy execution.
@@ -33,6 +39,20 @@ type PolicyStatus struct {
 	// RuleCount describes total number of rules in a policy
 	// +optional
 	RuleCount RuleCountStatus `json:"rulecount" yaml:"rulecount"`
+	// Generate contains the synchronization status of the resources generated by the policy
+	// +optional
+	Generate *GenerateStatus `json:"generate,omitempty" yaml:"generate,omitempty"`
+}
+
+// GenerateStatus contains the synchronization status of the resources generated by a policy
+type GenerateStatus struct {
+	// InSync is the number of generated resources matching the state applied by Kyverno
+	InSync int `json:"inSync" yaml:"inSync"`
+	// OutOfSync is the number of resources waiting to be generated or synchronized, and of resources of
+	// synchronized rules changed by another field manager since Kyverno applied them
+	OutOfSync int `json:"outOfSync" yaml:"outOfSync"`
+	// Failed is the number of resources that could not be generated or synchronized
+	Failed int `json:"failed" yaml:"failed"`
 }
 
 // RuleCountStatus contains four variables which describes counts for
@@ -64,6 +84,32 @@ func (status *PolicyStatus) SetReady(ready bool, message string) {
 	meta.SetStatusCondition(&status.Conditions, condition)
 }
 
+// SetGenerateStatus sets the generate status and the GenerateSynchronized condition,
+// a nil status removes both
+func (status *PolicyStatus) SetGenerateStatus(generate *GenerateStatus) {
+	status.Generate = generate
+	if generate == nil {
+		meta.RemoveStatusCondition(&status.Conditions, PolicyConditionGenerateSynchronized)
+		return
+	}
+	condition := metav1.Condition{
+		Type:    PolicyConditionGenerateSynchronized,
+		Message: fmt.Sprintf("%d in sync, %d out of sync, %d failed", generate.InSync, generate.OutOfSync, generate.Failed),
+	}
+	switch {
+	case generate.Failed > 0:
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = PolicyReasonFailed
+	case generate.OutOfSync > 0:
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = PolicyReasonPending
+	default:
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = PolicyReasonSucceeded
+	}
+	meta.SetStatusCondition(&status.Conditions, condition)
+}
+
 // IsReady indicates if the policy is ready to serve the admission request
 func (status *PolicyStatus) IsReady() bool {
 	condition := meta.FindStatusCondition(status.Conditions, PolicyConditionReady)
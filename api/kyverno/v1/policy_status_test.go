@@ -0,0 +1,51 @@
+package v1
+
+import (
+	"testing"
+
+	"gotest.tools/assert"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestPolicyStatus_SetGenerateStatus(t *testing.T) {
+	tests := []struct {
+		name       string
+		generate   *GenerateStatus
+		wantStatus metav1.ConditionStatus
+		wantReason string
+	}{{
+		name:       "in sync",
+		generate:   &GenerateStatus{InSync: 3},
+		wantStatus: metav1.ConditionTrue,
+		wantReason: PolicyReasonSucceeded,
+	}, {
+		name:       "out of sync",
+		generate:   &GenerateStatus{InSync: 3, OutOfSync: 1},
+		wantStatus: metav1.ConditionFalse,
+		wantReason: PolicyReasonPending,
+	}, {
+		name:       "failed",
+		generate:   &GenerateStatus{InSync: 3, OutOfSync: 1, Failed: 1},
+		wantStatus: metav1.ConditionFalse,
+		wantReason: PolicyReasonFailed,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var status PolicyStatus
+			status.SetReady(true, "Ready")
+			status.SetGenerateStatus(tt.generate)
+			assert.DeepEqual(t, status.Generate, tt.generate)
+			condition := meta.FindStatusCondition(status.Conditions, PolicyConditionGenerateSynchronized)
+			assert.Assert(t, condition != nil)
+			assert.Equal(t, condition.Status, tt.wantStatus)
+			assert.Equal(t, condition.Reason, tt.wantReason)
+			// the ready condition is left untouched
+			assert.Assert(t, status.IsReady())
+			status.SetGenerateStatus(nil)
+			assert.Assert(t, status.Generate == nil)
+			assert.Assert(t, meta.FindStatusCondition(status.Conditions, PolicyConditionGenerateSynchronized) == nil)
+			assert.Assert(t, status.IsReady())
+		})
+	}
+}
@@ -0,0 +1,96 @@
+package v1
+
+import (
+	"fmt"
+	"testing"
+
+	"gotest.tools/assert"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+func Test_Rollout_Enforces(t *testing.T) {
+	percentage := func(value int) *int { return &value }
+	tests := []struct {
+		name    string
+		rollout *Rollout
+		want    bool
+	}{{
+		name: "nil",
+		want: true,
+	}, {
+		name:    "default",
+		rollout: &Rollout{},
+		want:    true,
+	}, {
+		name:    "enforce all",
+		rollout: &Rollout{Mode: RolloutEnforce, Percentage: percentage(100)},
+		want:    true,
+	}, {
+		name:    "enforce none",
+		rollout: &Rollout{Mode: RolloutEnforce, Percentage: percentage(0)},
+		want:    false,
+	}, {
+		name:    "shadow",
+		rollout: &Rollout{Mode: RolloutShadow},
+		want:    false,
+	}, {
+		name:    "shadow ignores percentage",
+		rollout: &Rollout{Mode: RolloutShadow, Percentage: percentage(100)},
+		want:    false,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.rollout.Enforces("default/policy/Pod/default/pod"), tt.want)
+		})
+	}
+}
+
+func Test_Rollout_Enforces_Percentage(t *testing.T) {
+	rollout := func(value int) *Rollout { return &Rollout{Percentage: &value} }
+	enforced := map[int]int{}
+	for _, percentage := range []int{10, 50, 90} {
+		for i := 0; i < 1000; i++ {
+			key := fmt.Sprintf("policy/Pod/default/pod-%d", i)
+			if rollout(percentage).Enforces(key) {
+				enforced[percentage]++
+				// a request enforced at a given percentage is enforced at every higher percentage
+				assert.Assert(t, rollout(percentage+10).Enforces(key))
+			}
+			// the decision is stable for a given key
+			assert.Equal(t, rollout(percentage).Enforces(key), rollout(percentage).Enforces(key))
+		}
+		assert.Assert(t, enforced[percentage] > (percentage-10)*10 && enforced[percentage] < (percentage+10)*10, "percentage %d enforced %d", percentage, enforced[percentage])
+	}
+}
+
+func Test_Rollout_Validate(t *testing.T) {
+	percentage := func(value int) *int { return &value }
+	tests := []struct {
+		name       string
+		rollout    *Rollout
+		wantErrors int
+	}{{
+		name: "nil",
+	}, {
+		name:    "valid",
+		rollout: &Rollout{Mode: RolloutShadow, Percentage: percentage(50)},
+	}, {
+		name:       "invalid mode",
+		rollout:    &Rollout{Mode: "Dry"},
+		wantErrors: 1,
+	}, {
+		name:       "negative percentage",
+		rollout:    &Rollout{Percentage: percentage(-1)},
+		wantErrors: 1,
+	}, {
+		name:       "percentage too high",
+		rollout:    &Rollout{Percentage: percentage(101)},
+		wantErrors: 1,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := tt.rollout.Validate(field.NewPath("spec", "rollout"))
+			assert.Equal(t, len(errs), tt.wantErrors, errs)
+		})
+	}
+}
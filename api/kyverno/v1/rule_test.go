@@ -524,7 +524,7 @@ func Test_ValidatePSaControlNames(t *testing.T) {
 			}`),
 			errors: func(r *Rule) (errs field.ErrorList) {
 				return append(errs,
-					field.Invalid(path.Child("podSecurity").Child("exclude").Index(0).Child("controlName"), "Privilege Escalation", "exclude.images must be specified for the container level control"),
+					field.Invalid(path.Child("podSecurity").Child("exclude").Index(0).Child("controlName"), "Privilege Escalation", "exclude.images or exclude.containerNames must be specified for the container level control"),
 				)
 			},
 		},
@@ -595,7 +595,7 @@ func Test_ValidatePSaControlNames(t *testing.T) {
 			}`),
 			errors: func(r *Rule) (errs field.ErrorList) {
 				return append(errs,
-					field.Invalid(path.Child("podSecurity").Child("exclude").Index(0).Child("controlName"), "Host Namespaces", "exclude.images must not be specified for the pod level control"),
+					field.Invalid(path.Child("podSecurity").Child("exclude").Index(0).Child("controlName"), "Host Namespaces", "exclude.images and exclude.containerNames must not be specified for the pod level control"),
 				)
 			},
 		},
@@ -1137,3 +1137,152 @@ func Test_Validate_ClusterPolicy_Generate_Variables(t *testing.T) {
 		assert.Equal(t, len(errs) != 0, testcase.shouldFail, testcase.name)
 	}
 }
+
+func Test_Validate_Generate_ServiceAccount(t *testing.T) {
+	path := field.NewPath("dummy")
+	testcases := []struct {
+		name            string
+		serviceAccount  *ServiceAccountReference
+		policyNamespace string
+		errors          []string
+	}{{
+		name:           "cluster-policy",
+		serviceAccount: &ServiceAccountReference{Name: "generator", Namespace: "kyverno"},
+	}, {
+		name:           "cluster-policy-missing-namespace",
+		serviceAccount: &ServiceAccountReference{Name: "generator"},
+		errors:         []string{"dummy.generate.serviceAccount.namespace"},
+	}, {
+		name:           "missing-name",
+		serviceAccount: &ServiceAccountReference{Namespace: "kyverno"},
+		errors:         []string{"dummy.generate.serviceAccount.name"},
+	}, {
+		name:           "variables",
+		serviceAccount: &ServiceAccountReference{Name: "{{request.object.metadata.name}}", Namespace: "kyverno"},
+		errors:         []string{"dummy.generate.serviceAccount"},
+	}, {
+		name:            "namespaced-policy",
+		serviceAccount:  &ServiceAccountReference{Name: "generator"},
+		policyNamespace: "test",
+	}, {
+		name:            "namespaced-policy-same-namespace",
+		serviceAccount:  &ServiceAccountReference{Name: "generator", Namespace: "test"},
+		policyNamespace: "test",
+	}, {
+		name:            "namespaced-policy-other-namespace",
+		serviceAccount:  &ServiceAccountReference{Name: "generator", Namespace: "kyverno"},
+		policyNamespace: "test",
+		errors:          []string{"dummy.generate.serviceAccount.namespace"},
+	}}
+	for _, testcase := range testcases {
+		t.Run(testcase.name, func(t *testing.T) {
+			generation := Generation{
+				ResourceSpec: ResourceSpec{
+					APIVersion: "v1",
+					Kind:       "ConfigMap",
+					Name:       "config",
+					Namespace:  "test",
+				},
+				RawData:        ToJSON(map[string]interface{}{"data": map[string]interface{}{"key": "value"}}),
+				ServiceAccount: testcase.serviceAccount,
+			}
+			errs := generation.Validate(path, testcase.policyNamespace != "", testcase.policyNamespace, nil)
+			var fields []string
+			for _, err := range errs {
+				fields = append(fields, err.Field)
+			}
+			assert.DeepEqual(t, fields, testcase.errors)
+		})
+	}
+}
+
+func Test_Generation_GetServiceAccountUser(t *testing.T) {
+	assert.Equal(t, (&Generation{}).GetServiceAccountUser("test"), "")
+	assert.Equal(t, (&Generation{ServiceAccount: &ServiceAccountReference{Name: "generator", Namespace: "kyverno"}}).GetServiceAccountUser(""), "system:serviceaccount:kyverno:generator")
+	assert.Equal(t, (&Generation{ServiceAccount: &ServiceAccountReference{Name: "generator"}}).GetServiceAccountUser("test"), "system:serviceaccount:test:generator")
+}
+
+func Test_Validate_Generate_Cluster(t *testing.T) {
+	path := field.NewPath("dummy")
+	data := ToJSON(map[string]interface{}{"data": map[string]interface{}{"key": "value"}})
+	orphan := false
+	testcases := []struct {
+		name       string
+		generation Generation
+		namespaced bool
+		errors     []string
+	}{{
+		name: "data",
+		generation: Generation{
+			Cluster: &ClusterReference{Secret: "spoke"},
+			RawData: data,
+		},
+	}, {
+		name: "namespaced",
+		generation: Generation{
+			Cluster: &ClusterReference{Secret: "spoke"},
+			RawData: data,
+		},
+		namespaced: true,
+		errors:     []string{"dummy.generate.cluster"},
+	}, {
+		name: "missing-secret",
+		generation: Generation{
+			Cluster: &ClusterReference{Key: "config"},
+			RawData: data,
+		},
+		errors: []string{"dummy.generate.cluster.secret"},
+	}, {
+		name: "variables",
+		generation: Generation{
+			Cluster: &ClusterReference{Secret: "{{request.object.metadata.name}}"},
+			RawData: data,
+		},
+		errors: []string{"dummy.generate.cluster"},
+	}, {
+		name: "clone",
+		generation: Generation{
+			Cluster: &ClusterReference{Secret: "spoke"},
+			Clone:   CloneFrom{Namespace: "default", Name: "config"},
+		},
+		errors: []string{"dummy.generate.cluster"},
+	}, {
+		name: "service-account",
+		generation: Generation{
+			Cluster:        &ClusterReference{Secret: "spoke"},
+			ServiceAccount: &ServiceAccountReference{Name: "generator", Namespace: "kyverno"},
+			RawData:        data,
+		},
+		errors: []string{"dummy.generate.cluster"},
+	}, {
+		name: "delete-downstream",
+		generation: Generation{
+			Cluster:                        &ClusterReference{Secret: "spoke"},
+			OrphanDownstreamOnPolicyDelete: &orphan,
+			RawData:                        data,
+		},
+		errors: []string{"dummy.generate.cluster"},
+	}}
+	for _, testcase := range testcases {
+		t.Run(testcase.name, func(t *testing.T) {
+			generation := testcase.generation
+			generation.ResourceSpec = ResourceSpec{
+				APIVersion: "v1",
+				Kind:       "ConfigMap",
+				Name:       "config",
+				Namespace:  "test",
+			}
+			errs := generation.Validate(path, testcase.namespaced, "test", nil)
+			var fields []string
+			for _, err := range errs {
+				fields = append(fields, err.Field)
+			}
+			assert.DeepEqual(t, fields, testcase.errors)
+		})
+	}
+}
+
+func Test_ClusterReference_GetKey(t *testing.T) {
+	assert.Equal(t, (&ClusterReference{Secret: "spoke"}).GetKey(), "kubeconfig")
+	assert.Equal(t, (&ClusterReference{Secret: "spoke", Key: "config"}).GetKey(), "config")
+}
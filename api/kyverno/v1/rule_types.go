@@ -98,6 +98,10 @@ type Rule struct {
 	// VerifyImages is used to verify image signatures and mutate them to add a digest
 	// +optional
 	VerifyImages []ImageVerification `json:"verifyImages,omitempty" yaml:"verifyImages,omitempty"`
+
+	// Schedule restricts when the rule is active, the rule is skipped outside of its schedule.
+	// +optional
+	Schedule *Schedule `json:"schedule,omitempty" yaml:"schedule,omitempty"`
 }
 
 // HasMutate checks for mutate rule
@@ -118,7 +122,7 @@ func (r *Rule) HasVerifyImages() bool {
 // HasVerifyImageChecks checks whether the verifyImages rule has validation checks
 func (r *Rule) HasVerifyImageChecks() bool {
 	for _, verifyImage := range r.VerifyImages {
-		if verifyImage.VerifyDigest || verifyImage.Required {
+		if verifyImage.VerifyDigest || verifyImage.Required || verifyImage.Strict {
 			return true
 		}
 	}
@@ -140,6 +144,11 @@ func (r *Rule) HasValidateCEL() bool {
 	return r.Validation.CEL != nil && !datautils.DeepEqual(r.Validation.CEL, &CEL{})
 }
 
+// HasValidateAssert checks for validate.assert rule
+func (r *Rule) HasValidateAssert() bool {
+	return r.Validation.Assert != nil && !datautils.DeepEqual(r.Validation.Assert, &Assertion{})
+}
+
 // HasValidate checks for validate rule
 func (r *Rule) HasValidate() bool {
 	return !datautils.DeepEqual(r.Validation, Validation{})
@@ -381,14 +390,14 @@ func (r *Rule) ValidatePSaControlNames(path *field.Path) (errs field.ErrorList)
 		}
 
 		for idx, exclude := range podSecurity.Exclude {
-			// container level control must specify images
+			// container level control must specify images or container names
 			if containsString(utils.PSS_container_level_control, exclude.ControlName) {
-				if len(exclude.Images) == 0 {
-					errs = append(errs, field.Invalid(path.Child("podSecurity").Child("exclude").Index(idx).Child("controlName"), exclude.ControlName, "exclude.images must be specified for the container level control"))
+				if len(exclude.Images) == 0 && len(exclude.ContainerNames) == 0 {
+					errs = append(errs, field.Invalid(path.Child("podSecurity").Child("exclude").Index(idx).Child("controlName"), exclude.ControlName, "exclude.images or exclude.containerNames must be specified for the container level control"))
 				}
 			} else if containsString(utils.PSS_pod_level_control, exclude.ControlName) {
-				if len(exclude.Images) != 0 {
-					errs = append(errs, field.Invalid(path.Child("podSecurity").Child("exclude").Index(idx).Child("controlName"), exclude.ControlName, "exclude.images must not be specified for the pod level control"))
+				if len(exclude.Images) != 0 || len(exclude.ContainerNames) != 0 {
+					errs = append(errs, field.Invalid(path.Child("podSecurity").Child("exclude").Index(idx).Child("controlName"), exclude.ControlName, "exclude.images and exclude.containerNames must not be specified for the pod level control"))
 				}
 			}
 
@@ -421,5 +430,6 @@ func (r *Rule) Validate(path *field.Path, namespaced bool, policyNamespace strin
 	errs = append(errs, r.ValidateMutationRuleTargetNamespace(path, namespaced, policyNamespace)...)
 	errs = append(errs, r.ValidatePSaControlNames(path)...)
 	errs = append(errs, r.ValidateGenerate(path, namespaced, policyNamespace, clusterResources)...)
+	errs = append(errs, r.Schedule.Validate(path.Child("schedule"))...)
 	return errs
 }
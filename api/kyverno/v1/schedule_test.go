@@ -0,0 +1,117 @@
+package v1
+
+import (
+	"testing"
+	"time"
+
+	"gotest.tools/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+func Test_Schedule_IsActive(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	assert.NilError(t, err)
+	paris, err := time.LoadLocation("Europe/Paris")
+	assert.NilError(t, err)
+	// a wednesday
+	now := time.Date(2023, time.June, 14, 16, 30, 15, 0, time.UTC)
+	notBefore := metav1.NewTime(now.Add(-time.Hour))
+	notAfter := metav1.NewTime(now.Add(time.Hour))
+	testCases := []struct {
+		name     string
+		schedule *Schedule
+		location *time.Location
+		active   bool
+	}{{
+		name:   "nil",
+		active: true,
+	}, {
+		name:     "business hours",
+		schedule: &Schedule{Cron: "* 9-17 * * 1-5"},
+		active:   true,
+	}, {
+		name:     "business hours in another time zone",
+		schedule: &Schedule{Cron: "* 9-17 * * 1-5"},
+		location: newYork,
+		active:   true,
+	}, {
+		name:     "outside of business hours in another time zone",
+		schedule: &Schedule{Cron: "* 9-17 * * 1-5"},
+		location: paris,
+		active:   false,
+	}, {
+		name:     "week end",
+		schedule: &Schedule{Cron: "* * * * 0,6"},
+		active:   false,
+	}, {
+		name:     "exact minute",
+		schedule: &Schedule{Cron: "30 16 * * *"},
+		active:   true,
+	}, {
+		name:     "other minute",
+		schedule: &Schedule{Cron: "31 16 * * *"},
+		active:   false,
+	}, {
+		name:     "within window",
+		schedule: &Schedule{NotBefore: &notBefore, NotAfter: &notAfter},
+		active:   true,
+	}, {
+		name:     "before window",
+		schedule: &Schedule{NotBefore: &notAfter},
+		active:   false,
+	}, {
+		name:     "after window",
+		schedule: &Schedule{NotAfter: &notBefore},
+		active:   false,
+	}, {
+		name:     "within window but not scheduled",
+		schedule: &Schedule{Cron: "* 0-8 * * *", NotBefore: &notBefore, NotAfter: &notAfter},
+		active:   false,
+	}}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			active, err := tc.schedule.IsActive(now, tc.location)
+			assert.NilError(t, err)
+			assert.Equal(t, active, tc.active)
+		})
+	}
+}
+
+func Test_Schedule_Validate(t *testing.T) {
+	now := time.Now()
+	notBefore := metav1.NewTime(now)
+	notAfter := metav1.NewTime(now.Add(time.Hour))
+	testCases := []struct {
+		name     string
+		schedule *Schedule
+		fields   []string
+	}{{
+		name: "nil",
+	}, {
+		name:     "empty",
+		schedule: &Schedule{},
+		fields:   []string{"schedule"},
+	}, {
+		name:     "valid",
+		schedule: &Schedule{Cron: "* 9-17 * * 1-5", NotBefore: &notBefore, NotAfter: &notAfter},
+	}, {
+		name:     "invalid cron",
+		schedule: &Schedule{Cron: "* 9-17 * *"},
+		fields:   []string{"schedule.cron"},
+	}, {
+		name:     "invalid window",
+		schedule: &Schedule{NotBefore: &notAfter, NotAfter: &notBefore},
+		fields:   []string{"schedule.notAfter"},
+	}}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			errs := tc.schedule.Validate(field.NewPath("schedule"))
+			var fields []string
+			for _, err := range errs {
+				fields = append(fields, err.Field)
+			}
+			assert.DeepEqual(t, fields, tc.fields)
+		})
+	}
+}
@@ -5,6 +5,7 @@ import (
 
 	"gotest.tools/assert"
 	apiextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 )
 
@@ -49,3 +50,82 @@ func Test_Validate_UniqueRuleName(t *testing.T) {
 	assert.Equal(t, errs[0].Type, field.ErrorTypeInvalid)
 	assert.Equal(t, errs[0].Detail, "Duplicate rule name: 'deny-privileged-disallowpriviligedescalation'")
 }
+
+func Test_Validate_Params(t *testing.T) {
+	testCases := []struct {
+		name       string
+		paramKind  *ParamKind
+		paramRef   *ParamRef
+		namespaced bool
+		errorCount int
+		field      string
+	}{{
+		name:       "no-params",
+		errorCount: 0,
+	}, {
+		name:       "valid-params",
+		paramKind:  &ParamKind{APIVersion: "v1", Kind: "ConfigMap"},
+		paramRef:   &ParamRef{Name: "limits"},
+		errorCount: 0,
+	}, {
+		name:       "missing-param-ref",
+		paramKind:  &ParamKind{APIVersion: "v1", Kind: "ConfigMap"},
+		errorCount: 1,
+		field:      "spec.paramRef",
+	}, {
+		name:       "missing-param-kind",
+		paramRef:   &ParamRef{Name: "limits"},
+		errorCount: 1,
+		field:      "spec.paramKind",
+	}, {
+		name:       "invalid-api-version",
+		paramKind:  &ParamKind{APIVersion: "a/b/c", Kind: "ConfigMap"},
+		paramRef:   &ParamRef{Name: "limits"},
+		errorCount: 1,
+		field:      "spec.paramKind.apiVersion",
+	}, {
+		name:       "missing-name",
+		paramKind:  &ParamKind{APIVersion: "v1", Kind: "ConfigMap"},
+		paramRef:   &ParamRef{},
+		errorCount: 1,
+		field:      "spec.paramRef.name",
+	}, {
+		name:       "namespaced-same-namespace",
+		paramKind:  &ParamKind{APIVersion: "v1", Kind: "ConfigMap"},
+		paramRef:   &ParamRef{Name: "limits", Namespace: "test"},
+		namespaced: true,
+		errorCount: 0,
+	}, {
+		name:       "namespaced-other-namespace",
+		paramKind:  &ParamKind{APIVersion: "v1", Kind: "Secret"},
+		paramRef:   &ParamRef{Name: "limits", Namespace: "kyverno"},
+		namespaced: true,
+		errorCount: 1,
+		field:      "spec.paramRef.namespace",
+	}, {
+		name:       "namespaced-cluster-wide-kind",
+		paramKind:  &ParamKind{APIVersion: "v1", Kind: "Namespace"},
+		paramRef:   &ParamRef{Name: "limits"},
+		namespaced: true,
+		errorCount: 1,
+		field:      "spec.paramKind",
+	}, {
+		name:       "cluster-wide-kind",
+		paramKind:  &ParamKind{APIVersion: "v1", Kind: "Namespace"},
+		paramRef:   &ParamRef{Name: "limits"},
+		errorCount: 0,
+	}}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			subject := Spec{
+				ParamKind: testCase.paramKind,
+				ParamRef:  testCase.paramRef,
+			}
+			errs := subject.validateParams(field.NewPath("spec"), testCase.namespaced, "test", sets.New("v1/Namespace"))
+			assert.Equal(t, len(errs), testCase.errorCount)
+			if testCase.errorCount > 0 {
+				assert.Equal(t, errs[0].Field, testCase.field)
+			}
+		})
+	}
+}
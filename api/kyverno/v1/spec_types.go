@@ -6,6 +6,7 @@ import (
 
 	"github.com/kyverno/kyverno/pkg/toggle"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 )
@@ -46,6 +47,51 @@ type ValidationFailureActionOverride struct {
 	NamespaceSelector *metav1.LabelSelector   `json:"namespaceSelector,omitempty" yaml:"namespaceSelector,omitempty"`
 }
 
+// ParameterNotFoundActionType specifies the action taken when the parameter resource of a policy can't be found.
+// +kubebuilder:validation:Enum=Allow;Deny
+type ParameterNotFoundActionType string
+
+const (
+	// AllowAction skips the policy rules when the parameter resource is not found.
+	AllowAction ParameterNotFoundActionType = "Allow"
+	// DenyAction fails the policy rules when the parameter resource is not found.
+	DenyAction ParameterNotFoundActionType = "Deny"
+)
+
+// ParamKind identifies the kind of resource used to parameterize a policy.
+type ParamKind struct {
+	// APIVersion is the API group version of the parameter resource, e.g. "v1" or "example.com/v1".
+	APIVersion string `json:"apiVersion" yaml:"apiVersion"`
+
+	// Kind is the API kind of the parameter resource, e.g. "ConfigMap".
+	Kind string `json:"kind" yaml:"kind"`
+}
+
+// ParamRef references the parameter resource bound to a policy.
+type ParamRef struct {
+	// Name is the name of the parameter resource. Variables are supported.
+	Name string `json:"name" yaml:"name"`
+
+	// Namespace is the namespace of the parameter resource. Variables are supported.
+	// When empty and the parameter kind is namespaced, the namespace of the resource
+	// being processed is used, allowing each namespace to bind its own parameter values.
+	// +optional
+	Namespace string `json:"namespace,omitempty" yaml:"namespace,omitempty"`
+
+	// ParameterNotFoundAction controls the behavior when the parameter resource can't be found.
+	// Allowed values are Allow (rules are skipped) or Deny (rules fail). The default value is "Deny".
+	// +optional
+	ParameterNotFoundAction *ParameterNotFoundActionType `json:"parameterNotFoundAction,omitempty" yaml:"parameterNotFoundAction,omitempty"`
+}
+
+// GetParameterNotFoundAction returns the action to be applied when the parameter resource is not found
+func (p *ParamRef) GetParameterNotFoundAction() ParameterNotFoundActionType {
+	if p.ParameterNotFoundAction == nil {
+		return DenyAction
+	}
+	return *p.ParameterNotFoundAction
+}
+
 // Spec contains a list of Rule instances and other policy controls.
 type Spec struct {
 	// Rules is a list of Rule instances. A Policy contains multiple rules and
@@ -123,6 +169,27 @@ type Spec struct {
 	// Defaults to "false" if not specified.
 	// +optional
 	UseServerSideApply bool `json:"useServerSideApply,omitempty" yaml:"useServerSideApply,omitempty"`
+
+	// ParamKind specifies the kind of resources used to parameterize this policy.
+	// When set, ParamRef must reference the parameter resource, which is made
+	// available to rules under the `params` variable.
+	// +optional
+	ParamKind *ParamKind `json:"paramKind,omitempty" yaml:"paramKind,omitempty"`
+
+	// ParamRef references the parameter resource bound to this policy.
+	// It is required when ParamKind is set.
+	// +optional
+	ParamRef *ParamRef `json:"paramRef,omitempty" yaml:"paramRef,omitempty"`
+
+	// Schedule restricts when the policy is active, all rules are skipped outside of the policy schedule.
+	// Rules can define their own schedule to further restrict when they are active.
+	// +optional
+	Schedule *Schedule `json:"schedule,omitempty" yaml:"schedule,omitempty"`
+
+	// Rollout controls the progressive enforcement of validation failures, the policy can be enforced
+	// for a percentage of matching requests only or run in shadow mode where it never blocks requests.
+	// +optional
+	Rollout *Rollout `json:"rollout,omitempty" yaml:"rollout,omitempty"`
 }
 
 func (s *Spec) SetRules(rules []Rule) {
@@ -257,6 +324,11 @@ func (s *Spec) GetApplyRules() ApplyRulesType {
 	return *s.ApplyRules
 }
 
+// HasParams checks if the policy is parameterized
+func (s *Spec) HasParams() bool {
+	return s.ParamKind != nil && s.ParamRef != nil
+}
+
 func (s *Spec) ValidateSchema() bool {
 	if s.SchemaValidation != nil {
 		return *s.SchemaValidation
@@ -308,6 +380,38 @@ func (s *Spec) validateMutateTargets(path *field.Path) (errs field.ErrorList) {
 	return errs
 }
 
+func (s *Spec) validateParams(path *field.Path, namespaced bool, policyNamespace string, clusterResources sets.Set[string]) (errs field.ErrorList) {
+	if s.ParamKind == nil && s.ParamRef == nil {
+		return errs
+	}
+	if s.ParamKind == nil {
+		return append(errs, field.Required(path.Child("paramKind"), "paramKind must be specified when paramRef is set"))
+	}
+	if s.ParamRef == nil {
+		return append(errs, field.Required(path.Child("paramRef"), "paramRef must be specified when paramKind is set"))
+	}
+	if s.ParamKind.APIVersion == "" {
+		errs = append(errs, field.Required(path.Child("paramKind", "apiVersion"), "apiVersion must be specified"))
+	} else if _, err := schema.ParseGroupVersion(s.ParamKind.APIVersion); err != nil {
+		errs = append(errs, field.Invalid(path.Child("paramKind", "apiVersion"), s.ParamKind.APIVersion, err.Error()))
+	}
+	if s.ParamKind.Kind == "" {
+		errs = append(errs, field.Required(path.Child("paramKind", "kind"), "kind must be specified"))
+	}
+	if s.ParamRef.Name == "" {
+		errs = append(errs, field.Required(path.Child("paramRef", "name"), "name must be specified"))
+	}
+	if namespaced {
+		if clusterResources.Has(s.ParamKind.APIVersion + "/" + s.ParamKind.Kind) {
+			errs = append(errs, field.Forbidden(path.Child("paramKind"), fmt.Sprintf("a namespaced policy cannot use cluster-wide parameter resources: %v/%v", s.ParamKind.APIVersion, s.ParamKind.Kind)))
+		}
+		if s.ParamRef.Namespace != "" && s.ParamRef.Namespace != policyNamespace {
+			errs = append(errs, field.Forbidden(path.Child("paramRef", "namespace"), fmt.Sprintf("a namespaced policy cannot use parameter resources from other namespace, expected: %v, received: %v", policyNamespace, s.ParamRef.Namespace)))
+		}
+	}
+	return errs
+}
+
 // Validate implements programmatic validation
 func (s *Spec) Validate(path *field.Path, namespaced bool, policyNamespace string, clusterResources sets.Set[string]) (errs field.ErrorList) {
 	if err := s.validateDeprecatedFields(path); err != nil {
@@ -316,6 +420,9 @@ func (s *Spec) Validate(path *field.Path, namespaced bool, policyNamespace strin
 	if err := s.validateMutateTargets(path); err != nil {
 		errs = append(errs, err...)
 	}
+	errs = append(errs, s.validateParams(path, namespaced, policyNamespace, clusterResources)...)
+	errs = append(errs, s.Schedule.Validate(path.Child("schedule"))...)
+	errs = append(errs, s.Rollout.Validate(path.Child("rollout"))...)
 	if s.WebhookTimeoutSeconds != nil && (*s.WebhookTimeoutSeconds < 1 || *s.WebhookTimeoutSeconds > 30) {
 		errs = append(errs, field.Invalid(path.Child("webhookTimeoutSeconds"), s.WebhookTimeoutSeconds, "the timeout value must be between 1 and 30 seconds"))
 	}
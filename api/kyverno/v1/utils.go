@@ -1,8 +1,11 @@
 package v1
 
 import (
+	"strings"
+
 	"github.com/kyverno/kyverno/api/kyverno"
 	log "github.com/kyverno/kyverno/pkg/logging"
+	"github.com/kyverno/kyverno/pkg/utils/wildcard"
 	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
 	apiextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	"k8s.io/apimachinery/pkg/util/validation/field"
@@ -47,6 +50,49 @@ func ValidatePolicyName(path *field.Path, name string) (errs field.ErrorList) {
 	return errs
 }
 
+// ValidateImagePlatforms validates the platforms selected for image verification, they must be of the os/arch[/variant] form
+func ValidateImagePlatforms(path *field.Path, platforms []string) (errs field.ErrorList) {
+	for i, platform := range platforms {
+		parts := strings.Split(platform, "/")
+		valid := len(parts) == 2 || len(parts) == 3
+		for _, part := range parts {
+			if part == "" {
+				valid = false
+			}
+		}
+		if !valid {
+			errs = append(errs, field.Invalid(path.Index(i), platform, "platform must be of the form os/arch[/variant]"))
+		}
+	}
+	return errs
+}
+
+// GetNamespaceValidationFailureAction returns the validation failure action a namespace sets for a policy.
+// The kyverno.io/validation-failure-actions annotation of the namespace holds a comma separated list of
+// <policy>=<action> entries, the policy name can contain wildcards and the first matching entry wins.
+// Entries with an invalid action are ignored.
+func GetNamespaceValidationFailureAction(annotations map[string]string, policyName string) (ValidationFailureAction, bool) {
+	value, ok := annotations[kyverno.AnnotationValidationFailureActions]
+	if !ok {
+		return "", false
+	}
+	for _, entry := range strings.Split(value, ",") {
+		pattern, action, found := strings.Cut(entry, "=")
+		if !found {
+			continue
+		}
+		pattern = strings.TrimSpace(pattern)
+		action = strings.TrimSpace(action)
+		if !ValidationFailureAction(action).IsValid() {
+			continue
+		}
+		if wildcard.Match(pattern, policyName) {
+			return ValidationFailureAction(action), true
+		}
+	}
+	return "", false
+}
+
 func containsString(list []string, key string) bool {
 	for _, val := range list {
 		if val == key {
@@ -0,0 +1,79 @@
+package v1
+
+import (
+	"testing"
+
+	"gotest.tools/assert"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+func Test_GetNamespaceValidationFailureAction(t *testing.T) {
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		policy      string
+		want        ValidationFailureAction
+		wantOk      bool
+	}{{
+		name:   "no annotations",
+		policy: "require-labels",
+	}, {
+		name:        "no match",
+		annotations: map[string]string{"kyverno.io/validation-failure-actions": "disallow-latest=Enforce"},
+		policy:      "require-labels",
+	}, {
+		name:        "match",
+		annotations: map[string]string{"kyverno.io/validation-failure-actions": "disallow-latest=Enforce, require-labels = Audit"},
+		policy:      "require-labels",
+		want:        Audit,
+		wantOk:      true,
+	}, {
+		name:        "wildcard",
+		annotations: map[string]string{"kyverno.io/validation-failure-actions": "require-*=Enforce"},
+		policy:      "require-labels",
+		want:        Enforce,
+		wantOk:      true,
+	}, {
+		name:        "first match wins",
+		annotations: map[string]string{"kyverno.io/validation-failure-actions": "require-labels=Audit,*=Enforce"},
+		policy:      "require-labels",
+		want:        Audit,
+		wantOk:      true,
+	}, {
+		name:        "invalid entries are ignored",
+		annotations: map[string]string{"kyverno.io/validation-failure-actions": "require-labels,require-labels=Block,*=Enforce"},
+		policy:      "require-labels",
+		want:        Enforce,
+		wantOk:      true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := GetNamespaceValidationFailureAction(tt.annotations, tt.policy)
+			assert.Equal(t, got, tt.want)
+			assert.Equal(t, ok, tt.wantOk)
+		})
+	}
+}
+
+func Test_ValidateImagePlatforms(t *testing.T) {
+	tests := []struct {
+		name      string
+		platforms []string
+		wantErrs  int
+	}{{
+		name: "none",
+	}, {
+		name:      "valid",
+		platforms: []string{"linux/amd64", "linux/arm64/v8"},
+	}, {
+		name:      "invalid",
+		platforms: []string{"linux", "linux//v8", "linux/arm/v7/extra", "linux/arm64"},
+		wantErrs:  3,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := ValidateImagePlatforms(field.NewPath("platforms"), tt.platforms)
+			assert.Equal(t, len(errs), tt.wantErrs)
+		})
+	}
+}
@@ -46,6 +46,11 @@ func (in *APICall) DeepCopyInto(out *APICall) {
 		*out = new(ServiceCall)
 		**out = **in
 	}
+	if in.List != nil {
+		in, out := &in.List, &out.List
+		*out = new(APICallList)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -59,6 +64,32 @@ func (in *APICall) DeepCopy() *APICall {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *APICallList) DeepCopyInto(out *APICallList) {
+	*out = *in
+	if in.PageSize != nil {
+		in, out := &in.PageSize, &out.PageSize
+		*out = new(int64)
+		**out = **in
+	}
+	if in.MaxItems != nil {
+		in, out := &in.MaxItems, &out.MaxItems
+		*out = new(int64)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APICallList.
+func (in *APICallList) DeepCopy() *APICallList {
+	if in == nil {
+		return nil
+	}
+	out := new(APICallList)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AnyAllConditions) DeepCopyInto(out *AnyAllConditions) {
 	*out = *in
@@ -89,6 +120,57 @@ func (in *AnyAllConditions) DeepCopy() *AnyAllConditions {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Assertion) DeepCopyInto(out *Assertion) {
+	*out = *in
+	if in.Any != nil {
+		in, out := &in.Any, &out.Any
+		*out = make([]AssertionCheck, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.All != nil {
+		in, out := &in.All, &out.All
+		*out = make([]AssertionCheck, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Assertion.
+func (in *Assertion) DeepCopy() *Assertion {
+	if in == nil {
+		return nil
+	}
+	out := new(Assertion)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AssertionCheck) DeepCopyInto(out *AssertionCheck) {
+	*out = *in
+	if in.RawCheck != nil {
+		in, out := &in.RawCheck, &out.RawCheck
+		*out = new(apiextensionsv1.JSON)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AssertionCheck.
+func (in *AssertionCheck) DeepCopy() *AssertionCheck {
+	if in == nil {
+		return nil
+	}
+	out := new(AssertionCheck)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Attestation) DeepCopyInto(out *Attestation) {
 	*out = *in
@@ -391,6 +473,22 @@ func (in *ClusterPolicyList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterReference) DeepCopyInto(out *ClusterReference) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterReference.
+func (in *ClusterReference) DeepCopy() *ClusterReference {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Condition) DeepCopyInto(out *Condition) {
 	*out = *in
@@ -456,6 +554,21 @@ func (in *ContextEntry) DeepCopyInto(out *ContextEntry) {
 		*out = new(Variable)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.ResourceUsage != nil {
+		in, out := &in.ResourceUsage, &out.ResourceUsage
+		*out = new(ResourceUsage)
+		**out = **in
+	}
+	if in.ImageDigest != nil {
+		in, out := &in.ImageDigest, &out.ImageDigest
+		*out = new(ImageDigest)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Fallback != nil {
+		in, out := &in.Fallback, &out.Fallback
+		*out = new(ContextFallback)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -469,6 +582,27 @@ func (in *ContextEntry) DeepCopy() *ContextEntry {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ContextFallback) DeepCopyInto(out *ContextFallback) {
+	*out = *in
+	if in.Default != nil {
+		in, out := &in.Default, &out.Default
+		*out = new(apiextensionsv1.JSON)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContextFallback.
+func (in *ContextFallback) DeepCopy() *ContextFallback {
+	if in == nil {
+		return nil
+	}
+	out := new(ContextFallback)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Deny) DeepCopyInto(out *Deny) {
 	*out = *in
@@ -579,6 +713,11 @@ func (in *ForEachValidation) DeepCopyInto(out *ForEachValidation) {
 		*out = new(apiextensionsv1.JSON)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.AnyPatternMessages != nil {
+		in, out := &in.AnyPatternMessages, &out.AnyPatternMessages
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.Deny != nil {
 		in, out := &in.Deny, &out.Deny
 		*out = new(Deny)
@@ -602,10 +741,41 @@ func (in *ForEachValidation) DeepCopy() *ForEachValidation {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GenerateStatus) DeepCopyInto(out *GenerateStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GenerateStatus.
+func (in *GenerateStatus) DeepCopy() *GenerateStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(GenerateStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Generation) DeepCopyInto(out *Generation) {
 	*out = *in
 	out.ResourceSpec = in.ResourceSpec
+	if in.OrphanDownstreamOnPolicyDelete != nil {
+		in, out := &in.OrphanDownstreamOnPolicyDelete, &out.OrphanDownstreamOnPolicyDelete
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ServiceAccount != nil {
+		in, out := &in.ServiceAccount, &out.ServiceAccount
+		*out = new(ServiceAccountReference)
+		**out = **in
+	}
+	if in.Cluster != nil {
+		in, out := &in.Cluster, &out.Cluster
+		*out = new(ClusterReference)
+		**out = **in
+	}
 	if in.RawData != nil {
 		in, out := &in.RawData, &out.RawData
 		*out = new(apiextensionsv1.JSON)
@@ -648,6 +818,27 @@ func (in IgnoreFieldList) DeepCopy() IgnoreFieldList {
 	return *out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImageDigest) DeepCopyInto(out *ImageDigest) {
+	*out = *in
+	if in.ImageRegistryCredentials != nil {
+		in, out := &in.ImageRegistryCredentials, &out.ImageRegistryCredentials
+		*out = new(ImageRegistryCredentials)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageDigest.
+func (in *ImageDigest) DeepCopy() *ImageDigest {
+	if in == nil {
+		return nil
+	}
+	out := new(ImageDigest)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ImageExtractorConfig) DeepCopyInto(out *ImageExtractorConfig) {
 	*out = *in
@@ -782,6 +973,11 @@ func (in *ImageVerification) DeepCopyInto(out *ImageVerification) {
 		*out = new(ImageRegistryCredentials)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Platforms != nil {
+		in, out := &in.Platforms, &out.Platforms
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -896,6 +1092,11 @@ func (in *Mutation) DeepCopyInto(out *Mutation) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.AllowStatusMutation != nil {
+		in, out := &in.AllowStatusMutation, &out.AllowStatusMutation
+		*out = new(bool)
+		**out = **in
+	}
 	if in.RawPatchStrategicMerge != nil {
 		in, out := &in.RawPatchStrategicMerge, &out.RawPatchStrategicMerge
 		*out = new(apiextensionsv1.JSON)
@@ -947,6 +1148,43 @@ func (in *ObjectFieldBinding) DeepCopy() *ObjectFieldBinding {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ParamKind) DeepCopyInto(out *ParamKind) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ParamKind.
+func (in *ParamKind) DeepCopy() *ParamKind {
+	if in == nil {
+		return nil
+	}
+	out := new(ParamKind)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ParamRef) DeepCopyInto(out *ParamRef) {
+	*out = *in
+	if in.ParameterNotFoundAction != nil {
+		in, out := &in.ParameterNotFoundAction, &out.ParameterNotFoundAction
+		*out = new(ParameterNotFoundActionType)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ParamRef.
+func (in *ParamRef) DeepCopy() *ParamRef {
+	if in == nil {
+		return nil
+	}
+	out := new(ParamRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PodSecurity) DeepCopyInto(out *PodSecurity) {
 	*out = *in
@@ -978,6 +1216,11 @@ func (in *PodSecurityStandard) DeepCopyInto(out *PodSecurityStandard) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.ContainerNames != nil {
+		in, out := &in.ContainerNames, &out.ContainerNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -1064,6 +1307,11 @@ func (in *PolicyStatus) DeepCopyInto(out *PolicyStatus) {
 	}
 	in.Autogen.DeepCopyInto(&out.Autogen)
 	out.RuleCount = in.RuleCount
+	if in.Generate != nil {
+		in, out := &in.Generate, &out.Generate
+		*out = new(GenerateStatus)
+		**out = **in
+	}
 	return
 }
 
@@ -1207,6 +1455,43 @@ func (in *ResourceSpec) DeepCopy() *ResourceSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceUsage) DeepCopyInto(out *ResourceUsage) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceUsage.
+func (in *ResourceUsage) DeepCopy() *ResourceUsage {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceUsage)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Rollout) DeepCopyInto(out *Rollout) {
+	*out = *in
+	if in.Percentage != nil {
+		in, out := &in.Percentage, &out.Percentage
+		*out = new(int)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Rollout.
+func (in *Rollout) DeepCopy() *Rollout {
+	if in == nil {
+		return nil
+	}
+	out := new(Rollout)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Rule) DeepCopyInto(out *Rule) {
 	*out = *in
@@ -1254,6 +1539,11 @@ func (in *Rule) DeepCopyInto(out *Rule) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Schedule != nil {
+		in, out := &in.Schedule, &out.Schedule
+		*out = new(Schedule)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -1283,6 +1573,30 @@ func (in *RuleCountStatus) DeepCopy() *RuleCountStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Schedule) DeepCopyInto(out *Schedule) {
+	*out = *in
+	if in.NotBefore != nil {
+		in, out := &in.NotBefore, &out.NotBefore
+		*out = (*in).DeepCopy()
+	}
+	if in.NotAfter != nil {
+		in, out := &in.NotAfter, &out.NotAfter
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Schedule.
+func (in *Schedule) DeepCopy() *Schedule {
+	if in == nil {
+		return nil
+	}
+	out := new(Schedule)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SecretReference) DeepCopyInto(out *SecretReference) {
 	*out = *in
@@ -1299,6 +1613,22 @@ func (in *SecretReference) DeepCopy() *SecretReference {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceAccountReference) DeepCopyInto(out *ServiceAccountReference) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceAccountReference.
+func (in *ServiceAccountReference) DeepCopy() *ServiceAccountReference {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceAccountReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ServiceCall) DeepCopyInto(out *ServiceCall) {
 	*out = *in
@@ -1367,6 +1697,26 @@ func (in *Spec) DeepCopyInto(out *Spec) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.ParamKind != nil {
+		in, out := &in.ParamKind, &out.ParamKind
+		*out = new(ParamKind)
+		**out = **in
+	}
+	if in.ParamRef != nil {
+		in, out := &in.ParamRef, &out.ParamRef
+		*out = new(ParamRef)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Schedule != nil {
+		in, out := &in.Schedule, &out.Schedule
+		*out = new(Schedule)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Rollout != nil {
+		in, out := &in.Rollout, &out.Rollout
+		*out = new(Rollout)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -1491,6 +1841,11 @@ func (in *Validation) DeepCopyInto(out *Validation) {
 		*out = new(apiextensionsv1.JSON)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.AnyPatternMessages != nil {
+		in, out := &in.AnyPatternMessages, &out.AnyPatternMessages
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.Deny != nil {
 		in, out := &in.Deny, &out.Deny
 		*out = new(Deny)
@@ -1506,6 +1861,18 @@ func (in *Validation) DeepCopyInto(out *Validation) {
 		*out = new(CEL)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Assert != nil {
+		in, out := &in.Assert, &out.Assert
+		*out = new(Assertion)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Properties != nil {
+		in, out := &in.Properties, &out.Properties
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 	return
 }
 
@@ -36,6 +36,14 @@ type UpdateRequestStatus struct {
 	// +optional
 	Message string `json:"message,omitempty" yaml:"message,omitempty"`
 
+	// RetryCount is the number of times the request was retried after a failure.
+	// +optional
+	RetryCount int `json:"retryCount,omitempty" yaml:"retryCount,omitempty"`
+
+	// NextRetryTime is the time after which a failed request will be retried.
+	// +optional
+	NextRetryTime *metav1.Time `json:"nextRetryTime,omitempty" yaml:"nextRetryTime,omitempty"`
+
 	// This will track the resources that are updated by the generate Policy.
 	// Will be used during clean up resources.
 	GeneratedResources []kyvernov1.ResourceSpec `json:"generatedResources,omitempty" yaml:"generatedResources,omitempty"`
@@ -137,10 +145,10 @@ type AdmissionRequestInfoObject struct {
 type UpdateRequestState string
 
 const (
-	// Pending - the Request is yet to be processed or resource has not been created.
+	// Pending - the Request is yet to be processed, resource has not been created or a failed request is waiting to be retried.
 	Pending UpdateRequestState = "Pending"
 
-	// Failed - the Update Request Controller failed to process the rules.
+	// Failed - the Update Request Controller failed to process the rules and exhausted its retries, the request is not retried anymore.
 	Failed UpdateRequestState = "Failed"
 
 	// Completed - the Update Request Controller created resources defined in the policy.
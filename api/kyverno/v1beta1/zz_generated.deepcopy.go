@@ -175,6 +175,10 @@ func (in *UpdateRequestSpecContext) DeepCopy() *UpdateRequestSpecContext {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *UpdateRequestStatus) DeepCopyInto(out *UpdateRequestStatus) {
 	*out = *in
+	if in.NextRetryTime != nil {
+		in, out := &in.NextRetryTime, &out.NextRetryTime
+		*out = (*in).DeepCopy()
+	}
 	if in.GeneratedResources != nil {
 		in, out := &in.GeneratedResources, &out.GeneratedResources
 		*out = make([]kyvernov1.ResourceSpec, len(*in))
@@ -0,0 +1,130 @@
+/*
+Copyright 2023 The Kubernetes authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v2alpha1
+
+import (
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:object:root=true
+// +kubebuilder:storageversion
+// +kubebuilder:resource:scope=Cluster,shortName=cpolc,categories=kyverno
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// ClusterPolicyConstraint restricts the rules namespaced policies can declare, it is defined by cluster
+// admins and enforced when namespaced policies are created or updated.
+type ClusterPolicyConstraint struct {
+	metav1.TypeMeta   `json:",inline,omitempty"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Spec declares the namespaces the constraint applies to and what their policies can use.
+	Spec ClusterPolicyConstraintSpec `json:"spec"`
+}
+
+// +kubebuilder:object:root=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ClusterPolicyConstraintList is a list of ClusterPolicyConstraint instances.
+type ClusterPolicyConstraintList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+	Items           []ClusterPolicyConstraint `json:"items"`
+}
+
+// ConstraintRuleType is a kind of rule namespaced policies can declare.
+// +kubebuilder:validation:Enum=Mutate;MutateExisting;Validate;Generate;VerifyImages
+type ConstraintRuleType string
+
+const (
+	// ConstraintRuleMutate selects mutate rules applied to admission requests
+	ConstraintRuleMutate ConstraintRuleType = "Mutate"
+	// ConstraintRuleMutateExisting selects mutate rules declaring targets
+	ConstraintRuleMutateExisting ConstraintRuleType = "MutateExisting"
+	// ConstraintRuleValidate selects validate rules
+	ConstraintRuleValidate ConstraintRuleType = "Validate"
+	// ConstraintRuleGenerate selects generate rules
+	ConstraintRuleGenerate ConstraintRuleType = "Generate"
+	// ConstraintRuleVerifyImages selects verifyImages rules
+	ConstraintRuleVerifyImages ConstraintRuleType = "VerifyImages"
+)
+
+// ConstraintContextEntryType is a kind of context entry namespaced policies can declare.
+// +kubebuilder:validation:Enum=ConfigMap;APICall;ImageRegistry;ImageDigest;Variable;ResourceUsage
+type ConstraintContextEntryType string
+
+const (
+	// ConstraintContextConfigMap selects configMap context entries
+	ConstraintContextConfigMap ConstraintContextEntryType = "ConfigMap"
+	// ConstraintContextAPICall selects apiCall context entries
+	ConstraintContextAPICall ConstraintContextEntryType = "APICall"
+	// ConstraintContextImageRegistry selects imageRegistry context entries
+	ConstraintContextImageRegistry ConstraintContextEntryType = "ImageRegistry"
+	// ConstraintContextImageDigest selects imageDigest context entries
+	ConstraintContextImageDigest ConstraintContextEntryType = "ImageDigest"
+	// ConstraintContextVariable selects variable context entries
+	ConstraintContextVariable ConstraintContextEntryType = "Variable"
+	// ConstraintContextResourceUsage selects resourceUsage context entries
+	ConstraintContextResourceUsage ConstraintContextEntryType = "ResourceUsage"
+)
+
+// ClusterPolicyConstraintSpec declares the namespaces a constraint applies to and what their policies can use.
+type ClusterPolicyConstraintSpec struct {
+	// NamespaceSelector selects the namespaces whose policies are constrained.
+	// Policies in all namespaces are constrained when not set.
+	// +optional
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+
+	// RuleTypes lists the types of rules namespaced policies can declare.
+	// All rule types are allowed when empty.
+	// +optional
+	RuleTypes []ConstraintRuleType `json:"ruleTypes,omitempty"`
+
+	// ContextEntryTypes lists the types of context entries namespaced policies can declare,
+	// including the context entries of foreach declarations and mutate targets.
+	// All context entry types are allowed when empty.
+	// +optional
+	ContextEntryTypes []ConstraintContextEntryType `json:"contextEntryTypes,omitempty"`
+
+	// ValidationFailureActions lists the validation failure actions namespaced policies
+	// declaring validate or verifyImages rules can use.
+	// All validation failure actions are allowed when empty.
+	// +optional
+	ValidationFailureActions []ConstraintValidationFailureAction `json:"validationFailureActions,omitempty"`
+}
+
+// ConstraintValidationFailureAction is a validation failure action namespaced policies can use.
+// +kubebuilder:validation:Enum=Audit;Enforce
+type ConstraintValidationFailureAction string
+
+const (
+	// ConstraintAudit allows policies to audit violations
+	ConstraintAudit ConstraintValidationFailureAction = "Audit"
+	// ConstraintEnforce allows policies to block violations
+	ConstraintEnforce ConstraintValidationFailureAction = "Enforce"
+)
+
+// Allows returns true if the validation failure action is allowed by the constraint action
+func (a ConstraintValidationFailureAction) Allows(action kyvernov1.ValidationFailureAction) bool {
+	if a == ConstraintEnforce {
+		return action.Enforce()
+	}
+	return action.Audit()
+}
@@ -0,0 +1,346 @@
+/*
+Copyright 2023 The Kubernetes authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v2alpha1
+
+import (
+	"fmt"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:object:root=true
+// +kubebuilder:storageversion
+// +kubebuilder:resource:scope=Cluster,shortName=notif,categories=kyverno
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Sent",type=integer,JSONPath=".status.sent"
+// +kubebuilder:printcolumn:name="Last Sent",type="date",JSONPath=".status.lastSentTime"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// Notification sends alerts to external sinks when policies deny admission requests
+// or report new audit violations.
+type Notification struct {
+	metav1.TypeMeta   `json:",inline,omitempty"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Spec declares the violations to alert on and the sinks alerts are sent to.
+	Spec NotificationSpec `json:"spec"`
+
+	// Status contains the alerts sent for this notification.
+	// +optional
+	Status NotificationStatus `json:"status,omitempty"`
+}
+
+// Validate implements programmatic validation
+func (n *Notification) Validate() (errs field.ErrorList) {
+	return n.Spec.Validate(field.NewPath("spec"))
+}
+
+// +kubebuilder:object:root=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// NotificationList is a list of Notification instances.
+type NotificationList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+	Items           []Notification `json:"items"`
+}
+
+// NotificationAction is the kind of violation a notification alerts on.
+// +kubebuilder:validation:Enum=Enforce;Audit
+type NotificationAction string
+
+const (
+	// NotificationEnforce selects admission requests denied by enforce policies
+	NotificationEnforce NotificationAction = "Enforce"
+	// NotificationAudit selects violations of audit policies
+	NotificationAudit NotificationAction = "Audit"
+)
+
+const (
+	defaultNotificationThresholdCount  = 1
+	defaultNotificationThresholdWindow = 5 * time.Minute
+	defaultNotificationDeduplication   = time.Hour
+)
+
+// NotificationSpec stores the violations to alert on and the sinks alerts are sent to.
+type NotificationSpec struct {
+	// Actions selects the violations to alert on, `Enforce` for denied admission requests
+	// and `Audit` for violations of audit policies. Defaults to both.
+	// +optional
+	Actions []NotificationAction `json:"actions,omitempty"`
+
+	// Policies selects the violations of the given policies, `<name>` for a ClusterPolicy
+	// and `<namespace>/<name>` for a Policy. Wildcards are supported. Defaults to all policies.
+	// +optional
+	Policies []string `json:"policies,omitempty"`
+
+	// Namespaces selects the violations of resources in the given namespaces.
+	// Wildcards are supported. Defaults to all namespaces.
+	// +optional
+	Namespaces []string `json:"namespaces,omitempty"`
+
+	// Threshold is the number of violations within a time window required to send an alert.
+	// +optional
+	Threshold NotificationThreshold `json:"threshold,omitempty"`
+
+	// DeduplicationWindow is the period during which the same violation (same action, policy, rule and resource)
+	// is counted only once. Defaults to 1h.
+	// +optional
+	DeduplicationWindow *metav1.Duration `json:"deduplicationWindow,omitempty"`
+
+	// Sinks are the destinations alerts are sent to.
+	// +kubebuilder:validation:MinItems=1
+	Sinks []NotificationSink `json:"sinks"`
+}
+
+// NotificationThreshold is the number of violations within a time window required to send an alert.
+type NotificationThreshold struct {
+	// Count is the number of new violations required to send an alert. Defaults to 1.
+	// +optional
+	Count int `json:"count,omitempty"`
+
+	// Window is the period violations are counted over. Defaults to 5m.
+	// +optional
+	Window *metav1.Duration `json:"window,omitempty"`
+}
+
+// NotificationSink is a destination alerts are sent to, exactly one sink type must be set.
+type NotificationSink struct {
+	// Name identifies the sink in logs and status.
+	Name string `json:"name"`
+
+	// Webhook posts alerts as JSON to an HTTP endpoint.
+	// +optional
+	Webhook *WebhookSink `json:"webhook,omitempty"`
+
+	// Slack posts alerts to a Slack incoming webhook.
+	// +optional
+	Slack *SlackSink `json:"slack,omitempty"`
+
+	// PagerDuty triggers PagerDuty incidents using the Events API v2.
+	// +optional
+	PagerDuty *PagerDutySink `json:"pagerDuty,omitempty"`
+}
+
+// SecretKeyRef references a key of a secret in the Kyverno namespace.
+type SecretKeyRef struct {
+	// Name is the name of the secret.
+	Name string `json:"name"`
+
+	// Key is the key of the secret data.
+	Key string `json:"key"`
+}
+
+// WebhookSink posts alerts as JSON to an HTTP endpoint.
+type WebhookSink struct {
+	// URL is the endpoint alerts are posted to.
+	// +optional
+	URL string `json:"url,omitempty"`
+
+	// URLSecretRef references the endpoint alerts are posted to, it takes precedence over URL.
+	// +optional
+	URLSecretRef *SecretKeyRef `json:"urlSecretRef,omitempty"`
+
+	// Headers are added to the requests.
+	// +optional
+	Headers map[string]string `json:"headers,omitempty"`
+}
+
+// SlackSink posts alerts to a Slack incoming webhook.
+type SlackSink struct {
+	// URLSecretRef references the Slack incoming webhook URL.
+	URLSecretRef SecretKeyRef `json:"urlSecretRef"`
+
+	// Channel overrides the default channel of the incoming webhook.
+	// +optional
+	Channel string `json:"channel,omitempty"`
+}
+
+// PagerDutySink triggers PagerDuty incidents using the Events API v2.
+type PagerDutySink struct {
+	// RoutingKeySecretRef references the integration key of the PagerDuty service.
+	RoutingKeySecretRef SecretKeyRef `json:"routingKeySecretRef"`
+
+	// Severity is the severity of the incidents. Defaults to `error`.
+	// +kubebuilder:validation:Enum=critical;error;warning;info
+	// +optional
+	Severity string `json:"severity,omitempty"`
+
+	// URL overrides the PagerDuty Events API endpoint.
+	// +optional
+	URL string `json:"url,omitempty"`
+}
+
+// Validate implements programmatic validation
+func (s *NotificationSpec) Validate(path *field.Path) (errs field.ErrorList) {
+	if s.Threshold.Count < 0 {
+		errs = append(errs, field.Invalid(path.Child("threshold", "count"), s.Threshold.Count, "count must not be negative"))
+	}
+	if s.Threshold.Window != nil && s.Threshold.Window.Duration <= 0 {
+		errs = append(errs, field.Invalid(path.Child("threshold", "window"), s.Threshold.Window.Duration.String(), "window must be positive"))
+	}
+	if s.DeduplicationWindow != nil && s.DeduplicationWindow.Duration < 0 {
+		errs = append(errs, field.Invalid(path.Child("deduplicationWindow"), s.DeduplicationWindow.Duration.String(), "deduplication window must not be negative"))
+	}
+	if len(s.Sinks) == 0 {
+		errs = append(errs, field.Required(path.Child("sinks"), "at least one sink is required"))
+	}
+	names := map[string]bool{}
+	for i, sink := range s.Sinks {
+		path := path.Child("sinks").Index(i)
+		if sink.Name == "" {
+			errs = append(errs, field.Required(path.Child("name"), "name is required"))
+		} else if names[sink.Name] {
+			errs = append(errs, field.Duplicate(path.Child("name"), sink.Name))
+		}
+		names[sink.Name] = true
+		errs = append(errs, sink.Validate(path)...)
+	}
+	return errs
+}
+
+// Validate implements programmatic validation
+func (s *NotificationSink) Validate(path *field.Path) (errs field.ErrorList) {
+	count := 0
+	if s.Webhook != nil {
+		count++
+		if s.Webhook.URL == "" && s.Webhook.URLSecretRef == nil {
+			errs = append(errs, field.Required(path.Child("webhook"), "url or urlSecretRef is required"))
+		}
+		if s.Webhook.URLSecretRef != nil {
+			errs = append(errs, s.Webhook.URLSecretRef.Validate(path.Child("webhook", "urlSecretRef"))...)
+		}
+	}
+	if s.Slack != nil {
+		count++
+		errs = append(errs, s.Slack.URLSecretRef.Validate(path.Child("slack", "urlSecretRef"))...)
+	}
+	if s.PagerDuty != nil {
+		count++
+		errs = append(errs, s.PagerDuty.RoutingKeySecretRef.Validate(path.Child("pagerDuty", "routingKeySecretRef"))...)
+	}
+	if count != 1 {
+		errs = append(errs, field.Invalid(path, s.Name, fmt.Sprintf("exactly one of webhook, slack or pagerDuty must be set, found %d", count)))
+	}
+	return errs
+}
+
+// Validate implements programmatic validation
+func (r *SecretKeyRef) Validate(path *field.Path) (errs field.ErrorList) {
+	if r.Name == "" {
+		errs = append(errs, field.Required(path.Child("name"), "name is required"))
+	}
+	if r.Key == "" {
+		errs = append(errs, field.Required(path.Child("key"), "key is required"))
+	}
+	return errs
+}
+
+// GetActions returns the actions selected by the notification
+func (s *NotificationSpec) GetActions() []NotificationAction {
+	if len(s.Actions) == 0 {
+		return []NotificationAction{NotificationEnforce, NotificationAudit}
+	}
+	return s.Actions
+}
+
+// GetThresholdCount returns the number of violations required to send an alert
+func (s *NotificationSpec) GetThresholdCount() int {
+	if s.Threshold.Count <= 0 {
+		return defaultNotificationThresholdCount
+	}
+	return s.Threshold.Count
+}
+
+// GetThresholdWindow returns the period violations are counted over
+func (s *NotificationSpec) GetThresholdWindow() time.Duration {
+	if s.Threshold.Window == nil || s.Threshold.Window.Duration <= 0 {
+		return defaultNotificationThresholdWindow
+	}
+	return s.Threshold.Window.Duration
+}
+
+// GetDeduplicationWindow returns the period during which the same violation is counted only once
+func (s *NotificationSpec) GetDeduplicationWindow() time.Duration {
+	if s.DeduplicationWindow == nil {
+		return defaultNotificationDeduplication
+	}
+	return s.DeduplicationWindow.Duration
+}
+
+// NotificationStatus stores the alerts sent for a notification and the violations counted for it.
+// Violations are counted in the status so that all the admission controller replicas share the same count.
+type NotificationStatus struct {
+	// Sent is the number of alerts sent.
+	// +optional
+	Sent int `json:"sent,omitempty"`
+
+	// LastSentTime is the last time an alert was sent.
+	// +optional
+	LastSentTime *metav1.Time `json:"lastSentTime,omitempty"`
+
+	// LastError is the last error returned by a sink, it is cleared when all sinks succeed.
+	// +optional
+	LastError string `json:"lastError,omitempty"`
+
+	// ObservedGeneration is the generation of the notification the violations were counted for,
+	// the counted violations are discarded when the spec changes.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Pending are the violations counted in the current threshold window.
+	// +optional
+	Pending []NotificationViolation `json:"pending,omitempty"`
+
+	// Seen stores the last time a violation was counted, by violation key, for deduplication.
+	// +optional
+	Seen map[string]metav1.Time `json:"seen,omitempty"`
+}
+
+// NotificationViolation is a policy rule failing for a resource, counted for a notification.
+type NotificationViolation struct {
+	// Action is the validation failure action of the policy.
+	Action NotificationAction `json:"action"`
+
+	// Policy is the name of the policy.
+	Policy string `json:"policy"`
+
+	// Rule is the name of the rule.
+	Rule string `json:"rule"`
+
+	// Kind is the kind of the resource.
+	Kind string `json:"kind"`
+
+	// Namespace is the namespace of the resource.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// Name is the name of the resource.
+	Name string `json:"name"`
+
+	// Message is the message of the rule.
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// Timestamp is the time the violation was reported.
+	Timestamp metav1.Time `json:"timestamp"`
+}
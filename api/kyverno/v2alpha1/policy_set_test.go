@@ -0,0 +1,72 @@
+package v2alpha1
+
+import (
+	"testing"
+	"time"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"gotest.tools/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func Test_PolicySet_Validate(t *testing.T) {
+	keys := &kyvernov1.StaticKeyAttestor{PublicKeys: "-----BEGIN PUBLIC KEY-----"}
+	keyless := &kyvernov1.KeylessAttestor{Subject: "https://github.com/acme/*", Issuer: "https://token.actions.githubusercontent.com"}
+	tests := []struct {
+		name   string
+		spec   PolicySetSpec
+		errors []string
+	}{{
+		name: "keys",
+		spec: PolicySetSpec{Image: "ghcr.io/acme/policies:v1", Verify: PolicySetVerification{Keys: keys}},
+	}, {
+		name: "keyless",
+		spec: PolicySetSpec{Image: "ghcr.io/acme/policies:v1", Verify: PolicySetVerification{Keyless: keyless}},
+	}, {
+		name:   "missing image",
+		spec:   PolicySetSpec{Verify: PolicySetVerification{Keys: keys}},
+		errors: []string{"spec.image: Required value: image is required"},
+	}, {
+		name: "negative interval",
+		spec: PolicySetSpec{
+			Image:    "ghcr.io/acme/policies:v1",
+			Interval: &metav1.Duration{Duration: -time.Minute},
+			Verify:   PolicySetVerification{Keys: keys},
+		},
+		errors: []string{`spec.interval: Invalid value: "-1m0s": interval must be positive`},
+	}, {
+		name:   "missing verification",
+		spec:   PolicySetSpec{Image: "ghcr.io/acme/policies:v1"},
+		errors: []string{"spec.verify: Required value: one of keys or keyless is required"},
+	}, {
+		name:   "keys and keyless",
+		spec:   PolicySetSpec{Image: "ghcr.io/acme/policies:v1", Verify: PolicySetVerification{Keys: keys, Keyless: keyless}},
+		errors: []string{"spec.verify: Forbidden: only one of keys or keyless can be specified"},
+	}, {
+		name:   "empty keys",
+		spec:   PolicySetSpec{Image: "ghcr.io/acme/policies:v1", Verify: PolicySetVerification{Keys: &kyvernov1.StaticKeyAttestor{}}},
+		errors: []string{"spec.verify.keys: Required value: one of publicKeys, secret or kms is required"},
+	}, {
+		name: "keyless without subject and issuer",
+		spec: PolicySetSpec{Image: "ghcr.io/acme/policies:v1", Verify: PolicySetVerification{Keyless: &kyvernov1.KeylessAttestor{}}},
+		errors: []string{
+			"spec.verify.keyless.subject: Required value: subject is required",
+			"spec.verify.keyless.issuer: Required value: issuer is required",
+		},
+	}, {
+		name:   "keyless without issuer",
+		spec:   PolicySetSpec{Image: "ghcr.io/acme/policies:v1", Verify: PolicySetVerification{Keyless: &kyvernov1.KeylessAttestor{Subject: "https://github.com/acme/*"}}},
+		errors: []string{"spec.verify.keyless.issuer: Required value: issuer is required"},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			subject := PolicySet{Spec: tt.spec}
+			var errors []string
+			for _, err := range subject.Validate() {
+				errors = append(errors, err.Error())
+			}
+			assert.DeepEqual(t, errors, tt.errors)
+		})
+	}
+	assert.Equal(t, (&PolicySetSpec{}).GetInterval(), 10*time.Minute)
+}
@@ -0,0 +1,156 @@
+/*
+Copyright 2023 The Kubernetes authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v2alpha1
+
+import (
+	"time"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:object:root=true
+// +kubebuilder:storageversion
+// +kubebuilder:resource:scope=Cluster,shortName=polset,categories=kyverno
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Image",type=string,JSONPath=".spec.image"
+// +kubebuilder:printcolumn:name="Digest",type=string,JSONPath=".status.digest"
+// +kubebuilder:printcolumn:name="Last Sync",type="date",JSONPath=".status.lastSyncTime"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// PolicySet installs the policies contained in a signed OCI artifact and keeps them in sync
+// with the digest the artifact reference resolves to.
+type PolicySet struct {
+	metav1.TypeMeta   `json:",inline,omitempty"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Spec declares the OCI artifact containing the policies and how its signature is verified.
+	Spec PolicySetSpec `json:"spec"`
+
+	// Status contains the digest and the policies installed from the artifact.
+	// +optional
+	Status PolicySetStatus `json:"status,omitempty"`
+}
+
+// Validate implements programmatic validation
+func (p *PolicySet) Validate() (errs field.ErrorList) {
+	return p.Spec.Validate(field.NewPath("spec"))
+}
+
+// +kubebuilder:object:root=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// PolicySetList is a list of PolicySet instances.
+type PolicySetList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+	Items           []PolicySet `json:"items"`
+}
+
+const defaultPolicySetInterval = 10 * time.Minute
+
+// PolicySetSpec declares the OCI artifact containing the policies and how its signature is verified.
+type PolicySetSpec struct {
+	// Image is the reference of the OCI artifact containing the policies, as pushed with `kyverno oci push`.
+	// When the reference is a tag, the policies are updated every time the tag resolves to a new digest.
+	Image string `json:"image"`
+
+	// Interval is the period at which the digest of the image is checked.
+	// Defaults to 10 minutes if not specified.
+	// +optional
+	Interval *metav1.Duration `json:"interval,omitempty"`
+
+	// Verify declares how the signature of the image is verified, policies are only installed
+	// from images with a valid signature.
+	Verify PolicySetVerification `json:"verify"`
+}
+
+// PolicySetVerification declares how the signature of a policy set image is verified.
+// Exactly one of Keys or Keyless must be specified.
+type PolicySetVerification struct {
+	// Keys verifies the signature with public keys.
+	// +optional
+	Keys *kyvernov1.StaticKeyAttestor `json:"keys,omitempty"`
+
+	// Keyless verifies the signature with the identity of a keyless signer.
+	// +optional
+	Keyless *kyvernov1.KeylessAttestor `json:"keyless,omitempty"`
+
+	// Repository is an optional alternate OCI repository to use for signatures.
+	// +optional
+	Repository string `json:"repository,omitempty"`
+}
+
+// Validate implements programmatic validation
+func (s *PolicySetSpec) Validate(path *field.Path) (errs field.ErrorList) {
+	if s.Image == "" {
+		errs = append(errs, field.Required(path.Child("image"), "image is required"))
+	}
+	if s.Interval != nil && s.Interval.Duration <= 0 {
+		errs = append(errs, field.Invalid(path.Child("interval"), s.Interval.Duration.String(), "interval must be positive"))
+	}
+	verify := path.Child("verify")
+	if s.Verify.Keys == nil && s.Verify.Keyless == nil {
+		errs = append(errs, field.Required(verify, "one of keys or keyless is required"))
+	} else if s.Verify.Keys != nil && s.Verify.Keyless != nil {
+		errs = append(errs, field.Forbidden(verify, "only one of keys or keyless can be specified"))
+	} else if s.Verify.Keys != nil && s.Verify.Keys.PublicKeys == "" && s.Verify.Keys.Secret == nil && s.Verify.Keys.KMS == "" {
+		errs = append(errs, field.Required(verify.Child("keys"), "one of publicKeys, secret or kms is required"))
+	} else if s.Verify.Keyless != nil {
+		// any identity of any issuer would be trusted otherwise
+		if s.Verify.Keyless.Subject == "" {
+			errs = append(errs, field.Required(verify.Child("keyless", "subject"), "subject is required"))
+		}
+		if s.Verify.Keyless.Issuer == "" {
+			errs = append(errs, field.Required(verify.Child("keyless", "issuer"), "issuer is required"))
+		}
+	}
+	return errs
+}
+
+// GetInterval returns the period at which the digest of the image is checked
+func (s *PolicySetSpec) GetInterval() time.Duration {
+	if s.Interval == nil {
+		return defaultPolicySetInterval
+	}
+	return s.Interval.Duration
+}
+
+// PolicySetStatus stores the digest and the policies installed from a policy set image.
+type PolicySetStatus struct {
+	// Digest is the digest of the image the installed policies were pulled from.
+	// +optional
+	Digest string `json:"digest,omitempty"`
+
+	// Policies lists the installed policies, cluster policies are listed by name and
+	// namespaced policies by namespace and name.
+	// +optional
+	Policies []string `json:"policies,omitempty"`
+
+	// LastSyncTime is the last time the digest of the image was checked.
+	// +optional
+	LastSyncTime *metav1.Time `json:"lastSyncTime,omitempty"`
+
+	// LastError is the last error encountered when pulling, verifying or installing the policies,
+	// it is cleared when the policies are in sync.
+	// +optional
+	LastError string `json:"lastError,omitempty"`
+}
@@ -0,0 +1,42 @@
+package v2alpha1
+
+import (
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+func Test_ScanRequest_Validate(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy string
+		errors []string
+	}{{
+		name:   "cluster policy",
+		policy: "require-labels",
+	}, {
+		name:   "policy",
+		policy: "default/require-labels",
+	}, {
+		name:   "missing policy",
+		errors: []string{"spec.policy: Required value: policy is required"},
+	}, {
+		name:   "missing name",
+		policy: "default/",
+		errors: []string{`spec.policy: Invalid value: "default/": policy must be <name> for a ClusterPolicy or <namespace>/<name> for a Policy`},
+	}, {
+		name:   "too many segments",
+		policy: "default/require-labels/rule",
+		errors: []string{`spec.policy: Invalid value: "default/require-labels/rule": policy must be <name> for a ClusterPolicy or <namespace>/<name> for a Policy`},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			subject := ScanRequest{Spec: ScanRequestSpec{Policy: tt.policy}}
+			var errors []string
+			for _, err := range subject.Validate() {
+				errors = append(errors, err.Error())
+			}
+			assert.DeepEqual(t, errors, tt.errors)
+		})
+	}
+}
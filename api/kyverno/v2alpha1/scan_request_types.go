@@ -0,0 +1,140 @@
+/*
+Copyright 2023 The Kubernetes authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v2alpha1
+
+import (
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:object:root=true
+// +kubebuilder:storageversion
+// +kubebuilder:resource:scope=Cluster,shortName=scanreq,categories=kyverno
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Policy",type=string,JSONPath=".spec.policy"
+// +kubebuilder:printcolumn:name="Phase",type=string,JSONPath=".status.phase"
+// +kubebuilder:printcolumn:name="Scanned",type=integer,JSONPath=".status.scanned"
+// +kubebuilder:printcolumn:name="Total",type=integer,JSONPath=".status.total"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// ScanRequest requests an immediate background scan of the existing resources against a policy.
+type ScanRequest struct {
+	metav1.TypeMeta   `json:",inline,omitempty"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Spec declares the policy to scan existing resources against.
+	Spec ScanRequestSpec `json:"spec"`
+
+	// Status contains the progress of the scan.
+	// +optional
+	Status ScanRequestStatus `json:"status,omitempty"`
+}
+
+// Validate implements programmatic validation
+func (r *ScanRequest) Validate() (errs field.ErrorList) {
+	return r.Spec.Validate(field.NewPath("spec"))
+}
+
+// +kubebuilder:object:root=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ScanRequestList is a list of ScanRequest instances.
+type ScanRequestList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+	Items           []ScanRequest `json:"items"`
+}
+
+// ScanRequestSpec stores the policy to scan existing resources against.
+type ScanRequestSpec struct {
+	// Policy is the key of the policy to scan existing resources against,
+	// `<name>` for a ClusterPolicy and `<namespace>/<name>` for a Policy.
+	// The policy must have background processing enabled.
+	Policy string `json:"policy"`
+}
+
+// Validate implements programmatic validation
+func (s *ScanRequestSpec) Validate(path *field.Path) (errs field.ErrorList) {
+	if s.Policy == "" {
+		return append(errs, field.Required(path.Child("policy"), "policy is required"))
+	}
+	parts := strings.Split(s.Policy, "/")
+	if len(parts) > 2 || parts[0] == "" || parts[len(parts)-1] == "" {
+		errs = append(errs, field.Invalid(path.Child("policy"), s.Policy, "policy must be <name> for a ClusterPolicy or <namespace>/<name> for a Policy"))
+	}
+	return errs
+}
+
+// ScanRequestPhase is the phase of a scan request.
+// +kubebuilder:validation:Enum=Pending;Running;Completed;Failed
+type ScanRequestPhase string
+
+const (
+	// ScanRequestPending means the scan has not started yet
+	ScanRequestPending ScanRequestPhase = "Pending"
+	// ScanRequestRunning means the existing resources are being scanned
+	ScanRequestRunning ScanRequestPhase = "Running"
+	// ScanRequestCompleted means all existing resources were scanned and the results written to reports
+	ScanRequestCompleted ScanRequestPhase = "Completed"
+	// ScanRequestFailed means the scan could not run
+	ScanRequestFailed ScanRequestPhase = "Failed"
+)
+
+// ScanRequestStatus stores the progress of a scan request.
+type ScanRequestStatus struct {
+	// Phase is the phase of the scan.
+	// +optional
+	Phase ScanRequestPhase `json:"phase,omitempty"`
+
+	// Message provides details about the phase of the scan.
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// PolicyResourceVersion is the resource version of the policy that was scanned.
+	// +optional
+	PolicyResourceVersion string `json:"policyResourceVersion,omitempty"`
+
+	// Total is the number of existing resources to scan.
+	// +optional
+	Total int `json:"total,omitempty"`
+
+	// Scanned is the number of existing resources scanned so far.
+	// +optional
+	Scanned int `json:"scanned,omitempty"`
+
+	// Errors is the number of existing resources that could not be scanned.
+	// +optional
+	Errors int `json:"errors,omitempty"`
+
+	// StartTime is the time the scan started.
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// CompletionTime is the time the scan completed or failed.
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+}
+
+// IsDone returns true if the scan completed or failed
+func (s *ScanRequestStatus) IsDone() bool {
+	return s.Phase == ScanRequestCompleted || s.Phase == ScanRequestFailed
+}
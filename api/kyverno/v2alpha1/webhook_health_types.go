@@ -0,0 +1,81 @@
+/*
+Copyright 2023 The Kubernetes authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v2alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:object:root=true
+// +kubebuilder:storageversion
+// +kubebuilder:resource:scope=Cluster,shortName=whhealth,categories=kyverno
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Healthy",type=boolean,JSONPath=".status.healthy"
+// +kubebuilder:printcolumn:name="Latency",type=string,JSONPath=".status.latency"
+// +kubebuilder:printcolumn:name="Last Probe",type="date",JSONPath=".status.lastProbeTime"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// WebhookHealth reports the health of the admission webhook data plane, as observed by the canary
+// admission requests Kyverno periodically sends to its own webhook service.
+type WebhookHealth struct {
+	metav1.TypeMeta   `json:",inline,omitempty"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Status contains the outcome of the canary admission requests.
+	// +optional
+	Status WebhookHealthStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// WebhookHealthList is a list of WebhookHealth instances.
+type WebhookHealthList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+	Items           []WebhookHealth `json:"items"`
+}
+
+// WebhookHealthStatus stores the outcome of the canary admission requests.
+type WebhookHealthStatus struct {
+	// Healthy is true when the last canary request was answered in time with the expected response.
+	Healthy bool `json:"healthy"`
+
+	// LastProbeTime is the last time a canary request was sent.
+	// +optional
+	LastProbeTime *metav1.Time `json:"lastProbeTime,omitempty"`
+
+	// LastSuccessTime is the last time a canary request was answered with the expected response.
+	// +optional
+	LastSuccessTime *metav1.Time `json:"lastSuccessTime,omitempty"`
+
+	// Latency is the end to end latency of the last canary request, from sending the request
+	// through the webhook service to receiving the response.
+	// +optional
+	Latency *metav1.Duration `json:"latency,omitempty"`
+
+	// ConsecutiveFailures is the number of canary requests that failed since the last success.
+	// +optional
+	ConsecutiveFailures int32 `json:"consecutiveFailures,omitempty"`
+
+	// LastError is the error of the last canary request, it is cleared when the request succeeds.
+	// +optional
+	LastError string `json:"lastError,omitempty"`
+}
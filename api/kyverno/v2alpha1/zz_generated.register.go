@@ -62,8 +62,18 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 		&CleanupPolicyList{},
 		&ClusterCleanupPolicy{},
 		&ClusterCleanupPolicyList{},
+		&ClusterPolicyConstraint{},
+		&ClusterPolicyConstraintList{},
+		&Notification{},
+		&NotificationList{},
 		&PolicyException{},
 		&PolicyExceptionList{},
+		&PolicySet{},
+		&PolicySetList{},
+		&ScanRequest{},
+		&ScanRequestList{},
+		&WebhookHealth{},
+		&WebhookHealthList{},
 	)
 	// AddToGroupVersion allows the serialization of client types like ListOptions.
 	v1.AddToGroupVersion(scheme, SchemeGroupVersion)
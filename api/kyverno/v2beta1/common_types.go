@@ -29,6 +29,11 @@ type Validation struct {
 	// +optional
 	RawAnyPattern *apiextv1.JSON `json:"anyPattern,omitempty" yaml:"anyPattern,omitempty"`
 
+	// AnyPatternMessages are the failure messages of the AnyPattern patterns, in the same order.
+	// The message of each failed pattern is reported with the path it failed at.
+	// +optional
+	AnyPatternMessages []string `json:"anyPatternMessages,omitempty" yaml:"anyPatternMessages,omitempty"`
+
 	// Deny defines conditions used to pass or fail a validation rule.
 	// +optional
 	Deny *Deny `json:"deny,omitempty" yaml:"deny,omitempty"`
@@ -41,6 +46,15 @@ type Validation struct {
 	// CEL allows validation checks using the Common Expression Language (https://kubernetes.io/docs/reference/using-api/cel/).
 	// +optional
 	CEL *kyvernov1.CEL `json:"cel,omitempty" yaml:"cel,omitempty"`
+
+	// Severity overrides the severity of the policy (policies.kyverno.io/severity annotation) in the report results of failed validations.
+	// It can contain variables and must resolve to one of critical, high, medium, low or info, other values are ignored.
+	// +optional
+	Severity string `json:"severity,omitempty" yaml:"severity,omitempty"`
+
+	// Properties are custom properties added to the report results of the rule, values can contain variables.
+	// +optional
+	Properties map[string]string `json:"properties,omitempty" yaml:"properties,omitempty"`
 }
 
 // ConditionOperator is the operation performed on condition key and value.
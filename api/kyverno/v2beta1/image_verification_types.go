@@ -59,6 +59,24 @@ type ImageVerification struct {
 	// +kubebuilder:default=true
 	// +kubebuilder:validation:Optional
 	UseCache bool `json:"useCache" yaml:"useCache"`
+
+	// Strict requires every image of the resource to be covered by the imageReferences of a verifyImages
+	// entry in the rule, images that are not covered fail the rule. Covered images must have a digest and
+	// must have been verified, regardless of the verifyDigest and required settings.
+	// +kubebuilder:validation:Optional
+	Strict bool `json:"strict,omitempty" yaml:"strict,omitempty"`
+
+	// Platforms selects the platform manifests verified for multi-arch images, in the os/arch[/variant]
+	// form (e.g. linux/amd64). When empty the image reference is verified as is, i.e. the index of a
+	// multi-arch image. When set, the manifest of every platform is resolved from the image index and
+	// verified in place of the index, the image fails verification if a platform is missing from the index.
+	// The digests of the verified manifests are available to subsequent rules in the `platformDigests` variable.
+	// +kubebuilder:validation:Optional
+	Platforms []string `json:"platforms,omitempty" yaml:"platforms,omitempty"`
+
+	// VerifyIndex also verifies the image index of multi-arch images when platforms are selected.
+	// +kubebuilder:validation:Optional
+	VerifyIndex bool `json:"verifyIndex,omitempty" yaml:"verifyIndex,omitempty"`
 }
 
 // Validate implements programmatic validation
@@ -73,6 +91,8 @@ func (iv *ImageVerification) Validate(isAuditFailureAction bool, path *field.Pat
 		errs = append(errs, field.Invalid(path, iv, "An image reference is required"))
 	}
 
+	errs = append(errs, kyvernov1.ValidateImagePlatforms(path.Child("platforms"), iv.Platforms)...)
+
 	asPath := path.Child("attestations")
 	for i, attestation := range copy.Attestations {
 		attestationErrors := attestation.Validate(asPath.Index(i))
@@ -86,7 +86,7 @@ func (r *Rule) HasVerifyImages() bool {
 // HasVerifyImageChecks checks whether the verifyImages rule has validation checks
 func (r *Rule) HasVerifyImageChecks() bool {
 	for _, v := range r.VerifyImages {
-		if v.VerifyDigest || v.Required {
+		if v.VerifyDigest || v.Required || v.Strict {
 			return true
 		}
 	}
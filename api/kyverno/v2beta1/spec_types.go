@@ -84,6 +84,17 @@ type Spec struct {
 	// Defaults to "false" if not specified.
 	// +optional
 	UseServerSideApply bool `json:"useServerSideApply,omitempty" yaml:"useServerSideApply,omitempty"`
+
+	// ParamKind specifies the kind of resources used to parameterize this policy.
+	// When set, ParamRef must reference the parameter resource, which is made
+	// available to rules under the `params` variable.
+	// +optional
+	ParamKind *kyvernov1.ParamKind `json:"paramKind,omitempty" yaml:"paramKind,omitempty"`
+
+	// ParamRef references the parameter resource bound to this policy.
+	// It is required when ParamKind is set.
+	// +optional
+	ParamRef *kyvernov1.ParamRef `json:"paramRef,omitempty" yaml:"paramRef,omitempty"`
 }
 
 func (s *Spec) SetRules(rules []Rule) {
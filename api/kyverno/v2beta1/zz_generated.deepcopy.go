@@ -194,6 +194,11 @@ func (in *ImageVerification) DeepCopyInto(out *ImageVerification) {
 		*out = new(kyvernov1.ImageRegistryCredentials)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Platforms != nil {
+		in, out := &in.Platforms, &out.Platforms
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -503,6 +508,16 @@ func (in *Spec) DeepCopyInto(out *Spec) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.ParamKind != nil {
+		in, out := &in.ParamKind, &out.ParamKind
+		*out = new(kyvernov1.ParamKind)
+		**out = **in
+	}
+	if in.ParamRef != nil {
+		in, out := &in.ParamRef, &out.ParamRef
+		*out = new(kyvernov1.ParamRef)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -541,6 +556,11 @@ func (in *Validation) DeepCopyInto(out *Validation) {
 		*out = new(v1.JSON)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.AnyPatternMessages != nil {
+		in, out := &in.AnyPatternMessages, &out.AnyPatternMessages
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.Deny != nil {
 		in, out := &in.Deny, &out.Deny
 		*out = new(Deny)
@@ -556,6 +576,13 @@ func (in *Validation) DeepCopyInto(out *Validation) {
 		*out = new(kyvernov1.CEL)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Properties != nil {
+		in, out := &in.Properties, &out.Properties
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 	return
 }
 
@@ -27,6 +27,7 @@
   {{- $flags = append $flags (print "--backgroundScanWorkers=" .backgroundScanWorkers) -}}
   {{- $flags = append $flags (print "--backgroundScanInterval=" .backgroundScanInterval) -}}
   {{- $flags = append $flags (print "--skipResourceFilters=" .skipResourceFilters) -}}
+  {{- $flags = append $flags (print "--backgroundScanSharding=" .sharding) -}}
 {{- end -}}
 {{- with .configMapCaching -}}
   {{- $flags = append $flags (print "--enableConfigMapCaching=" .enabled) -}}
@@ -37,6 +38,12 @@
 {{- with .dumpPayload -}}
   {{- $flags = append $flags (print "--dumpPayload=" .enabled) -}}
 {{- end -}}
+{{- with .events -}}
+  {{- $flags = append $flags (print "--eventsVerbosity=" .verbosity) -}}
+  {{- $flags = append $flags (print "--eventsAggregationWindow=" .aggregationWindow) -}}
+  {{- $flags = append $flags (print "--eventsRateLimitQPS=" .rateLimitQPS) -}}
+  {{- $flags = append $flags (print "--eventsRateLimitBurst=" .rateLimitBurst) -}}
+{{- end -}}
 {{- with .forceFailurePolicyIgnore -}}
   {{- $flags = append $flags (print "--forceFailurePolicyIgnore=" .enabled) -}}
 {{- end -}}
@@ -60,6 +67,12 @@
 {{- end -}}
 {{- with .reports -}}
   {{- $flags = append $flags (print "--reportsChunkSize=" .chunkSize) -}}
+  {{- $flags = append $flags (print "--reportsMaxSize=" (int .maxSize)) -}}
+  {{- $flags = append $flags (print "--reportsSummary=" .summary) -}}
+  {{- $flags = append $flags (print "--admissionReportsAggregationDelay=" .admissionReportsAggregationDelay) -}}
+  {{- $flags = append $flags (print "--admissionReportsDeletionGrace=" .admissionReportsDeletionGrace) -}}
+  {{- $flags = append $flags (print "--admissionReportsGCInterval=" .admissionReportsGCInterval) -}}
+  {{- $flags = append $flags (print "--admissionReportsBatchSize=" (int .admissionReportsBatchSize)) -}}
 {{- end -}}
 {{- with .registryClient -}}
   {{- $flags = append $flags (print "--allowInsecureRegistry=" .allowInsecure) -}}
@@ -16,6 +16,14 @@
 {{- end -}}
 {{- end -}}
 
+{{- define "kyverno.config.globalValuesConfigMapName" -}}
+{{- if .Values.globalValuesConfig.create -}}
+    {{ default (printf "%s-global-values" (include "kyverno.fullname" .)) .Values.globalValuesConfig.name }}
+{{- else -}}
+    {{ required "A configmap name is required when `globalValuesConfig.create` is set to `false`" .Values.globalValuesConfig.name }}
+{{- end -}}
+{{- end -}}
+
 {{- define "kyverno.config.labels" -}}
 {{- template "kyverno.labels.merge" (list
   (include "kyverno.labels.common" .)
@@ -18,6 +18,24 @@
 ) -}}
 {{- end -}}
 
+{{- define "kyverno.reports-controller.background-scan.name" -}}
+{{ template "kyverno.reports-controller.name" . }}-background-scan
+{{- end -}}
+
+{{- define "kyverno.reports-controller.background-scan.labels" -}}
+{{- template "kyverno.labels.merge" (list
+  (include "kyverno.labels.common" .)
+  (include "kyverno.reports-controller.background-scan.matchLabels" .)
+) -}}
+{{- end -}}
+
+{{- define "kyverno.reports-controller.background-scan.matchLabels" -}}
+{{- template "kyverno.labels.merge" (list
+  (include "kyverno.matchLabels.common" .)
+  (include "kyverno.labels.component" "background-scan-controller")
+) -}}
+{{- end -}}
+
 {{- define "kyverno.reports-controller.image" -}}
 {{- if .image.registry -}}
   {{ .image.registry }}/{{ required "An image repository is required" .image.repository }}:{{ default .defaultTag .image.tag }}
@@ -11,10 +11,14 @@ import (
 
 	"github.com/kyverno/kyverno/cmd/internal"
 	"github.com/kyverno/kyverno/pkg/background"
+	"github.com/kyverno/kyverno/pkg/background/common"
 	"github.com/kyverno/kyverno/pkg/client/clientset/versioned"
 	kyvernoinformer "github.com/kyverno/kyverno/pkg/client/informers/externalversions"
 	"github.com/kyverno/kyverno/pkg/clients/dclient"
 	"github.com/kyverno/kyverno/pkg/config"
+	"github.com/kyverno/kyverno/pkg/controllers/eventtrigger"
+	"github.com/kyverno/kyverno/pkg/controllers/generatedrift"
+	"github.com/kyverno/kyverno/pkg/controllers/generatestatus"
 	policymetricscontroller "github.com/kyverno/kyverno/pkg/controllers/metrics/policy"
 	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
 	"github.com/kyverno/kyverno/pkg/engine/jmespath"
@@ -29,24 +33,35 @@ import (
 
 const (
 	resyncPeriod = 15 * time.Minute
+	// generateStatusResyncPeriod is the period after which generated resources are counted again
+	generateStatusResyncPeriod = 5 * time.Minute
 )
 
 func createrLeaderControllers(
 	eng engineapi.Engine,
 	genWorkers int,
+	batchSize int,
 	kubeInformer kubeinformers.SharedInformerFactory,
 	kyvernoInformer kyvernoinformer.SharedInformerFactory,
 	kyvernoClient versioned.Interface,
 	dynamicClient dclient.Interface,
+	impersonatingClient dclient.ImpersonatingClientFactory,
+	remoteClient dclient.RemoteClientFactory,
 	configuration config.Configuration,
 	metricsConfig metrics.MetricsConfigManager,
 	eventGenerator event.Interface,
 	jp jmespath.Interface,
 	backgroundScanInterval time.Duration,
+	retryPolicy common.RetryPolicy,
+	enableEventTriggers bool,
+	serverSideApply bool,
+	driftCheckInterval time.Duration,
 ) ([]internal.Controller, error) {
 	policyCtrl, err := policy.NewPolicyController(
 		kyvernoClient,
 		dynamicClient,
+		impersonatingClient,
+		remoteClient,
 		eng,
 		kyvernoInformer.Kyverno().V1().ClusterPolicies(),
 		kyvernoInformer.Kyverno().V1().Policies(),
@@ -65,6 +80,8 @@ func createrLeaderControllers(
 	backgroundController := background.NewController(
 		kyvernoClient,
 		dynamicClient,
+		impersonatingClient,
+		remoteClient,
 		eng,
 		kyvernoInformer.Kyverno().V1().ClusterPolicies(),
 		kyvernoInformer.Kyverno().V1().Policies(),
@@ -73,22 +90,72 @@ func createrLeaderControllers(
 		eventGenerator,
 		configuration,
 		jp,
+		retryPolicy,
+		serverSideApply,
+		batchSize,
 	)
-	return []internal.Controller{
+	generateStatusController := generatestatus.NewController(
+		dynamicClient,
+		kyvernoClient,
+		kyvernoInformer.Kyverno().V1().ClusterPolicies(),
+		kyvernoInformer.Kyverno().V1().Policies(),
+		kyvernoInformer.Kyverno().V1beta1().UpdateRequests(),
+		generateStatusResyncPeriod,
+	)
+	leaderControllers := []internal.Controller{
 		internal.NewController("policy-controller", policyCtrl, 2),
 		internal.NewController("background-controller", backgroundController, genWorkers),
-	}, err
+		internal.NewController(generatestatus.ControllerName, generateStatusController, generatestatus.Workers),
+	}
+	if driftCheckInterval > 0 {
+		generateDriftController := generatedrift.NewController(
+			dynamicClient,
+			kyvernoClient,
+			kyvernoInformer.Kyverno().V1().ClusterPolicies(),
+			kyvernoInformer.Kyverno().V1().Policies(),
+			kyvernoInformer.Kyverno().V1beta1().UpdateRequests(),
+			driftCheckInterval,
+		)
+		leaderControllers = append(leaderControllers, internal.NewController(generatedrift.ControllerName, generateDriftController, generatedrift.Workers))
+	}
+	if enableEventTriggers {
+		eventTriggerController := eventtrigger.NewController(
+			dynamicClient,
+			kyvernoClient,
+			eng,
+			kyvernoInformer.Kyverno().V1().ClusterPolicies(),
+			kyvernoInformer.Kyverno().V1().Policies(),
+			kubeInformer.Core().V1().Events(),
+			kubeInformer.Core().V1().Namespaces(),
+			configuration,
+			jp,
+		)
+		leaderControllers = append(leaderControllers, internal.NewController(eventtrigger.ControllerName, eventTriggerController, eventtrigger.Workers))
+	}
+	return leaderControllers, err
 }
 
 func main() {
 	var (
-		genWorkers      int
-		maxQueuedEvents int
-		omitEvents      string
+		genWorkers          int
+		batchSize           int
+		maxQueuedEvents     int
+		omitEvents          string
+		retryPolicy         common.RetryPolicy
+		enableEventTriggers bool
+		serverSideApply     bool
+		driftCheckInterval  time.Duration
 	)
 	flagset := flag.NewFlagSet("updaterequest-controller", flag.ExitOnError)
 	flagset.IntVar(&genWorkers, "genWorkers", 10, "Workers for the background controller.")
+	flagset.IntVar(&batchSize, "updateRequestBatchSize", 10, "Maximum number of queued update requests processed together by a background controller worker, equivalent update requests of a batch are compacted.")
 	flagset.IntVar(&maxQueuedEvents, "maxQueuedEvents", 1000, "Maximum events to be queued.")
+	flagset.IntVar(&retryPolicy.MaxRetries, "updateRequestMaxRetries", common.DefaultMaxRetries, "Maximum number of retries of a failing update request before it is marked as failed.")
+	flagset.DurationVar(&retryPolicy.BaseDelay, "updateRequestRetryBaseDelay", common.DefaultRetryBaseDelay, "Delay before the first retry of a failing update request, the delay doubles with every retry.")
+	flagset.DurationVar(&retryPolicy.MaxDelay, "updateRequestRetryMaxDelay", common.DefaultRetryMaxDelay, "Maximum delay between two retries of a failing update request.")
+	flagset.BoolVar(&enableEventTriggers, "enableEventTriggers", false, "Enable generate rules matching the Event kind to be triggered by Kubernetes Events (requires permissions to list and watch events).")
+	flagset.BoolVar(&serverSideApply, "generateServerSideApply", true, "Apply generated resources with server-side apply for all policies, Kyverno owns the generated fields and changes made by other field managers are reported.")
+	flagset.DurationVar(&driftCheckInterval, "generateDriftCheckInterval", 10*time.Minute, "Interval at which resources generated by synchronized rules are checked for changes made by other field managers, 0 disables the check.")
 	flagset.StringVar(&omitEvents, "omit-events", "", "Set this flag to a comma sperated list of PolicyViolation, PolicyApplied, PolicyError, PolicySkipped to disable events, e.g. --omit-events=PolicyApplied,PolicyViolation")
 
 	// config
@@ -99,8 +166,12 @@ func main() {
 		internal.WithKubeconfig(),
 		internal.WithPolicyExceptions(),
 		internal.WithConfigMapCaching(),
+		internal.WithPodCaching(),
 		internal.WithDeferredLoading(),
+		internal.WithCircuitBreaker(),
+		internal.WithImageDigestCache(),
 		internal.WithRegistryClient(),
+		internal.WithEvents(),
 		internal.WithLeaderElection(),
 		internal.WithKyvernoClient(),
 		internal.WithDynamicClient(),
@@ -113,6 +184,15 @@ func main() {
 	signalCtx, setup, sdown := internal.Setup(appConfig, "kyverno-background-controller", false)
 	defer sdown()
 
+	if retryPolicy.MaxRetries < 0 || retryPolicy.BaseDelay <= 0 || retryPolicy.MaxDelay < retryPolicy.BaseDelay {
+		setup.Logger.Error(errors.New("update request retries must be positive and the max delay must be greater than the base delay"), "invalid update request retry configuration",
+			"maxRetries", retryPolicy.MaxRetries, "baseDelay", retryPolicy.BaseDelay, "maxDelay", retryPolicy.MaxDelay)
+		os.Exit(1)
+	}
+	if batchSize < 1 {
+		setup.Logger.Error(errors.New("update request batch size must be positive"), "invalid update request batch size", "batchSize", batchSize)
+		os.Exit(1)
+	}
 	var err error
 	bgscanInterval := time.Hour
 	val := os.Getenv("BACKGROUND_SCAN_INTERVAL")
@@ -139,6 +219,7 @@ func main() {
 		kyvernoInformer.Kyverno().V1().Policies(),
 		maxQueuedEvents,
 		emitEventsValues,
+		internal.EventsThrottleConfig(),
 		logging.WithName("EventGenerator"),
 	)
 	// this controller only subscribe to events, nothing is returned...
@@ -172,11 +253,11 @@ func main() {
 	// setup leader election
 	le, err := leaderelection.New(
 		setup.Logger.WithName("leader-election"),
-		"kyverno-background-controller",
-		config.KyvernoNamespace(),
+		internal.LeaderElectionName("kyverno-background-controller"),
+		internal.LeaderElectionNamespace(),
 		setup.LeaderElectionClient,
 		config.KyvernoPodName(),
-		internal.LeaderElectionRetryPeriod(),
+		internal.LeaderElectionConfig(),
 		func(ctx context.Context) {
 			logger := setup.Logger.WithName("leader")
 			// create leader factories
@@ -186,15 +267,22 @@ func main() {
 			leaderControllers, err := createrLeaderControllers(
 				engine,
 				genWorkers,
+				batchSize,
 				kubeInformer,
 				kyvernoInformer,
 				setup.KyvernoClient,
 				setup.KyvernoDynamicClient,
+				internal.CreateImpersonatingClientFactory(logger, setup.KyvernoDynamicClient),
+				internal.CreateRemoteClientFactory(signalCtx, logger, setup.KyvernoDynamicClient, resyncPeriod),
 				setup.Configuration,
 				setup.MetricsManager,
 				eventGenerator,
 				setup.Jp,
 				bgscanInterval,
+				retryPolicy,
+				enableEventTriggers,
+				serverSideApply,
+				driftCheckInterval,
 			)
 			if err != nil {
 				logger.Error(err, "failed to create leader controllers")
@@ -74,7 +74,9 @@ func main() {
 		internal.WithMetrics(),
 		internal.WithTracing(),
 		internal.WithKubeconfig(),
+		internal.WithEvents(),
 		internal.WithLeaderElection(),
+		internal.WithCertificates(),
 		internal.WithKyvernoClient(),
 		internal.WithKyvernoDynamicClient(),
 		internal.WithConfigMapCaching(),
@@ -97,33 +99,37 @@ func main() {
 	// setup leader election
 	le, err := leaderelection.New(
 		setup.Logger.WithName("leader-election"),
-		"kyverno-cleanup-controller",
-		config.KyvernoNamespace(),
+		internal.LeaderElectionName("kyverno-cleanup-controller"),
+		internal.LeaderElectionNamespace(),
 		setup.LeaderElectionClient,
 		config.KyvernoPodName(),
-		internal.LeaderElectionRetryPeriod(),
+		internal.LeaderElectionConfig(),
 		func(ctx context.Context) {
 			logger := setup.Logger.WithName("leader")
 			// informer factories
 			kubeInformer := kubeinformers.NewSharedInformerFactoryWithOptions(setup.KubeClient, resyncPeriod)
 			kyvernoInformer := kyvernoinformer.NewSharedInformerFactory(setup.KyvernoClient, resyncPeriod)
 			// controllers
-			renewer := tls.NewCertRenewer(
-				setup.KubeClient.CoreV1().Secrets(config.KyvernoNamespace()),
-				tls.CertRenewalInterval,
-				tls.CAValidityDuration,
-				tls.TLSValidityDuration,
-				serverIP,
-			)
-			certController := internal.NewController(
-				certmanager.ControllerName,
-				certmanager.NewController(
-					caSecret,
-					tlsSecret,
-					renewer,
-				),
-				certmanager.Workers,
-			)
+			var certController internal.Controller
+			// externally managed certificates are consumed as is, they are never generated nor renewed
+			if !tls.IsExternallyManaged() {
+				renewer := tls.NewCertRenewer(
+					setup.KubeClient.CoreV1().Secrets(config.KyvernoNamespace()),
+					tls.CertRenewalInterval,
+					internal.CertificateConfig(),
+					serverIP,
+				)
+				certController = internal.NewController(
+					certmanager.ControllerName,
+					certmanager.NewController(
+						caSecret,
+						tlsSecret,
+						renewer,
+						setup.KubeClient.CoreV1().ConfigMaps(config.KyvernoNamespace()),
+					),
+					certmanager.Workers,
+				)
+			}
 			policyValidatingWebhookController := internal.NewController(
 				policyWebhookControllerName,
 				genericwebhookcontroller.NewController(
@@ -224,7 +230,9 @@ func main() {
 			}
 			// start leader controllers
 			var wg sync.WaitGroup
-			certController.Run(ctx, logger, &wg)
+			if certController != nil {
+				certController.Run(ctx, logger, &wg)
+			}
 			policyValidatingWebhookController.Run(ctx, logger, &wg)
 			ttlWebhookController.Run(ctx, logger, &wg)
 			cleanupController.Run(ctx, logger, &wg)
@@ -262,6 +270,7 @@ func main() {
 		kyvernoInformer.Kyverno().V2alpha1().ClusterCleanupPolicies(),
 		kyvernoInformer.Kyverno().V2alpha1().CleanupPolicies(),
 		maxQueuedEvents,
+		internal.EventsThrottleConfig(),
 		logging.WithName("EventGenerator"),
 	)
 	// start informers and wait for cache sync
@@ -3,6 +3,7 @@ package apply
 import (
 	"context"
 	"fmt"
+	"io"
 	"net/url"
 	"os"
 	"path/filepath"
@@ -12,8 +13,10 @@ import (
 	"github.com/go-git/go-billy/v5/memfs"
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
 	"github.com/kyverno/kyverno/api/kyverno/v1beta1"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/snapshot"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/utils/color"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/utils/common"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/utils/output/format"
 	sanitizederror "github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/utils/sanitizedError"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/utils/store"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/utils/values"
@@ -21,6 +24,7 @@ import (
 	"github.com/kyverno/kyverno/pkg/clients/dclient"
 	"github.com/kyverno/kyverno/pkg/config"
 	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	"github.com/kyverno/kyverno/pkg/engine/context/resolvers"
 	"github.com/kyverno/kyverno/pkg/openapi"
 	gitutils "github.com/kyverno/kyverno/pkg/utils/git"
 	policyvalidation "github.com/kyverno/kyverno/pkg/validation/policy"
@@ -53,6 +57,7 @@ type ApplyCommandConfig struct {
 	ValuesFile     string
 	UserInfoPath   string
 	Cluster        bool
+	ClusterContext bool
 	PolicyReport   bool
 	Stdin          bool
 	RegistryAccess bool
@@ -60,8 +65,22 @@ type ApplyCommandConfig struct {
 	ResourcePaths  []string
 	PolicyPaths    []string
 	GitBranch      string
+	OutputFormat   string
 	warnExitCode   int
 	warnNoPassed   bool
+	// out receives the messages printed while applying policies, stdout is used when not set
+	out io.Writer
+	// severityExitCodes maps policy severities to the exit code used when a validation fails
+	severityExitCodes map[string]int
+	// HelmChart is a chart rendered with `helm template` whose manifests are added to the resources
+	HelmChart       string
+	HelmReleaseName string
+	HelmValues      []string
+	HelmSet         []string
+	// simulate evaluates policies against the cluster resources without printing mutated resources
+	simulate bool
+	// Snapshot is a cluster snapshot (kyverno snapshot) used to resolve context entries and namespace labels
+	Snapshot string
 }
 
 var (
@@ -72,9 +91,21 @@ To apply on a resource:
 To apply on a folder of resources:
         kyverno apply /path/to/policy.yaml /path/to/folderOfPolicies --resource=/path/to/resources/
 
+To apply on resources piped through stdin:
+        helm template my-release ./my-chart | kyverno apply /path/to/policy.yaml --resource -
+
+To apply on the manifests rendered from a Helm chart (requires the helm binary):
+        kyverno apply /path/to/policy.yaml --helm-chart ./my-chart --helm-values values.yaml --helm-set image.tag=1.0.0
+
 To apply on a cluster:
         kyverno apply /path/to/policy.yaml /path/to/folderOfPolicies --cluster
 
+To apply on a resource and resolve context entries (configMap, apiCall and imageRegistry) against the cluster:
+        kyverno apply /path/to/policy.yaml --resource /path/to/resource.yaml --cluster-context
+
+To apply on a resource and resolve context entries and namespace labels from a cluster snapshot (see kyverno snapshot):
+        kyverno apply /path/to/policy.yaml --resource /path/to/resource.yaml --snapshot /path/to/snapshot.yaml
+
 To apply policies from a gitSourceURL on a cluster:
     Example: Taking github.com as a gitSourceURL here. Some other standards  gitSourceURL are: gitlab.com , bitbucket.org , etc.
         kyverno apply https://github.com/kyverno/policies/openshift/ --git-branch main --cluster
@@ -167,24 +198,45 @@ func Command() *cobra.Command {
 				}
 			}()
 			applyCommandConfig.PolicyPaths = policyPaths
+			if err := format.Check(applyCommandConfig.OutputFormat); err != nil {
+				return err
+			}
+			applyCommandConfig.out = cmd.OutOrStdout()
+			if applyCommandConfig.OutputFormat != "" {
+				// keep stdout for the structured report, other messages go to stderr
+				applyCommandConfig.out = cmd.ErrOrStderr()
+			}
 			rc, _, skipInvalidPolicies, responses, err := applyCommandConfig.applyCommandHelper()
 			if err != nil {
 				return err
 			}
+			if applyCommandConfig.OutputFormat != "" {
+				if err := applyCommandConfig.printOutput(cmd.OutOrStdout(), responses); err != nil {
+					return sanitizederror.NewWithError("failed to print results", err)
+				}
+				exit(rc, applyCommandConfig.warnExitCode, applyCommandConfig.warnNoPassed, applyCommandConfig.severityExitCodes, responses, applyCommandConfig.AuditWarn)
+				return nil
+			}
 			printSkippedAndInvalidPolicies(skipInvalidPolicies)
 			if applyCommandConfig.PolicyReport {
 				printReport(responses, applyCommandConfig.AuditWarn)
 			} else if table {
-				printTable(detailedResults, applyCommandConfig.AuditWarn, responses...)
+				printTable(cmd.OutOrStdout(), detailedResults, applyCommandConfig.AuditWarn, responses...)
 			} else {
 				printViolations(rc)
 			}
-			exit(rc, applyCommandConfig.warnExitCode, applyCommandConfig.warnNoPassed)
+			exit(rc, applyCommandConfig.warnExitCode, applyCommandConfig.warnNoPassed, applyCommandConfig.severityExitCodes, responses, applyCommandConfig.AuditWarn)
 			return nil
 		},
 	}
-	cmd.Flags().StringSliceVarP(&applyCommandConfig.ResourcePaths, "resource", "r", []string{}, "Path to resource files")
+	cmd.Flags().StringSliceVarP(&applyCommandConfig.ResourcePaths, "resource", "r", []string{}, "Path to resource files, use - to read resources from stdin")
+	cmd.Flags().StringVar(&applyCommandConfig.HelmChart, "helm-chart", "", "Helm chart (path or reference) rendered with helm template, rendered manifests are added to the resources")
+	cmd.Flags().StringVar(&applyCommandConfig.HelmReleaseName, "helm-release-name", defaultHelmReleaseName, "Release name used to render the Helm chart")
+	cmd.Flags().StringSliceVar(&applyCommandConfig.HelmValues, "helm-values", nil, "Values files used to render the Helm chart")
+	cmd.Flags().StringArrayVar(&applyCommandConfig.HelmSet, "helm-set", nil, "Values (key=value) used to render the Helm chart")
 	cmd.Flags().BoolVarP(&applyCommandConfig.Cluster, "cluster", "c", false, "Checks if policies should be applied to cluster in the current context")
+	cmd.Flags().BoolVar(&applyCommandConfig.ClusterContext, "cluster-context", false, "Resolve context entries (configMap, apiCall and imageRegistry) against the cluster in the current context")
+	cmd.Flags().StringVar(&applyCommandConfig.Snapshot, "snapshot", "", "Resolve context entries (configMap and apiCall) and namespace labels from a cluster snapshot created with kyverno snapshot")
 	cmd.Flags().StringVarP(&applyCommandConfig.MutateLogPath, "output", "o", "", "Prints the mutated resources in provided file/directory")
 	// currently `set` flag supports variable for single policy applied on single resource
 	cmd.Flags().StringVarP(&applyCommandConfig.UserInfoPath, "userinfo", "u", "", "Admission Info including Roles, Cluster Roles and Subjects")
@@ -200,12 +252,22 @@ func Command() *cobra.Command {
 	cmd.Flags().BoolVar(&applyCommandConfig.AuditWarn, "audit-warn", false, "If set to true, will flag audit policies as warnings instead of failures")
 	cmd.Flags().IntVar(&applyCommandConfig.warnExitCode, "warn-exit-code", 0, "Set the exit code for warnings; if failures or errors are found, will exit 1")
 	cmd.Flags().BoolVar(&applyCommandConfig.warnNoPassed, "warn-no-pass", false, "Specify if warning exit code should be raised if no objects satisfied a policy; can be used together with --warn-exit-code flag")
+	cmd.Flags().StringToIntVar(&applyCommandConfig.severityExitCodes, "severity-exit-codes", nil, "Set the exit code for validation failures per policy severity (critical, high, medium, low, info or none), failures of other severities don't change the exit code (e.g. critical=2,high=1)")
+	cmd.Flags().StringVar(&applyCommandConfig.OutputFormat, "output-format", "", "Print results in the given format (sarif or junit) instead of the default output")
 	cmd.Flags().BoolVar(&removeColor, "remove-color", false, "Remove any color from output")
 	cmd.Flags().BoolVar(&detailedResults, "detailed-results", false, "If set to true, display detailed results")
 	cmd.Flags().BoolVarP(&table, "table", "t", false, "Show results in table format")
 	return cmd
 }
 
+// output returns the writer messages are printed to while applying policies
+func (c *ApplyCommandConfig) output() io.Writer {
+	if c.out == nil {
+		return os.Stdout
+	}
+	return c.out
+}
+
 func (c *ApplyCommandConfig) applyCommandHelper() (*common.ResultCounts, []*unstructured.Unstructured, SkippedInvalidPolicies, []engineapi.EngineResponse, error) {
 	rc, uu, skipInvalidPolicies, er, err := c.checkArguments()
 	if err != nil {
@@ -223,7 +285,7 @@ func (c *ApplyCommandConfig) applyCommandHelper() (*common.ResultCounts, []*unst
 	if c.UserInfoPath != "" {
 		userInfo, err = common.GetUserInfoFromPath(nil, c.UserInfoPath, false, "")
 		if err != nil {
-			fmt.Printf("Error: failed to load request info\nCause: %s\n", err)
+			fmt.Fprintf(c.output(), "Error: failed to load request info\nCause: %s\n", err)
 			osExit(1)
 		}
 	}
@@ -242,6 +304,10 @@ func (c *ApplyCommandConfig) applyCommandHelper() (*common.ResultCounts, []*unst
 	if err != nil {
 		return rc, uu, skipInvalidPolicies, er, err
 	}
+	namespaceSelectorMap, err = c.loadSnapshot(namespaceSelectorMap)
+	if err != nil {
+		return nil, nil, skipInvalidPolicies, nil, sanitizederror.NewWithError("failed to load snapshot", err)
+	}
 	rc, uu, skipInvalidPolicies, er, err, policies, validatingAdmissionPolicies := c.loadPolicies(skipInvalidPolicies)
 	if err != nil {
 		return rc, uu, skipInvalidPolicies, er, err
@@ -281,6 +347,7 @@ func (c *ApplyCommandConfig) applyValidatingAdmissionPolicytoResource(validating
 				Client:                    dClient,
 				AuditWarn:                 c.AuditWarn,
 				Subresources:              subresources,
+				Out:                       c.output(),
 			}
 			ers, err := validatingAdmissionPolicy.ApplyPolicyOnResource(applyPolicyConfig)
 			if err != nil {
@@ -303,7 +370,7 @@ func (c *ApplyCommandConfig) applyPolicytoResource(variables map[string]string,
 			policyRulesCount += len(autogen.ComputeRules(policy))
 		}
 		policyRulesCount += len(validatingAdmissionPolicies)
-		fmt.Printf("\nApplying %d policy rule(s) to %d resource(s)...\n", policyRulesCount, len(resources))
+		fmt.Fprintf(c.output(), "\nApplying %d policy rule(s) to %d resource(s)...\n", policyRulesCount, len(resources))
 	}
 
 	var rc common.ResultCounts
@@ -348,10 +415,11 @@ func (c *ApplyCommandConfig) applyPolicytoResource(variables map[string]string,
 				NamespaceSelectorMap: namespaceSelectorMap,
 				Stdin:                c.Stdin,
 				Rc:                   &rc,
-				PrintPatchResource:   true,
+				PrintPatchResource:   !c.simulate,
 				Client:               dClient,
 				AuditWarn:            c.AuditWarn,
 				Subresources:         subresources,
+				Out:                  c.output(),
 			}
 			ers, err := common.ApplyPolicyOnResource(applyPolicyConfig)
 			if err != nil {
@@ -364,10 +432,22 @@ func (c *ApplyCommandConfig) applyPolicytoResource(variables map[string]string,
 }
 
 func (c *ApplyCommandConfig) loadResources(policies []kyvernov1.PolicyInterface, validatingAdmissionPolicies []v1alpha1.ValidatingAdmissionPolicy, dClient dclient.Interface) []*unstructured.Unstructured {
-	resources, err := common.GetResourceAccordingToResourcePath(nil, c.ResourcePaths, c.Cluster, policies, validatingAdmissionPolicies, dClient, c.Namespace, c.PolicyReport, false, "")
-	if err != nil {
-		fmt.Printf("Error: failed to load resources\nCause: %s\n", err)
-		osExit(1)
+	var resources []*unstructured.Unstructured
+	if len(c.ResourcePaths) > 0 || c.Cluster {
+		var err error
+		resources, err = common.GetResourceAccordingToResourcePath(nil, c.ResourcePaths, c.Cluster, policies, validatingAdmissionPolicies, dClient, c.Namespace, c.PolicyReport, false, "")
+		if err != nil {
+			fmt.Fprintf(c.output(), "Error: failed to load resources\nCause: %s\n", err)
+			osExit(1)
+		}
+	}
+	if c.HelmChart != "" {
+		rendered, err := c.renderHelmChart()
+		if err != nil {
+			fmt.Fprintf(c.output(), "Error: failed to load resources\nCause: %s\n", err)
+			osExit(1)
+		}
+		resources = append(resources, rendered...)
 	}
 	return resources
 }
@@ -385,14 +465,14 @@ func (c *ApplyCommandConfig) loadPolicies(skipInvalidPolicies SkippedInvalidPoli
 		if isGit {
 			gitSourceURL, err := url.Parse(policyPaths[0])
 			if err != nil {
-				fmt.Printf("Error: failed to load policies\nCause: %s\n", err)
+				fmt.Fprintf(c.output(), "Error: failed to load policies\nCause: %s\n", err)
 				osExit(1)
 			}
 
 			pathElems := strings.Split(gitSourceURL.Path[1:], "/")
 			if len(pathElems) <= 1 {
 				err := fmt.Errorf("invalid URL path %s - expected https://<any_git_source_domain>/:owner/:repository/:branch (without --git-branch flag) OR https://<any_git_source_domain>/:owner/:repository/:directory (with --git-branch flag)", gitSourceURL.Path)
-				fmt.Printf("Error: failed to parse URL \nCause: %s\n", err)
+				fmt.Fprintf(c.output(), "Error: failed to parse URL \nCause: %s\n", err)
 				osExit(1)
 			}
 			gitSourceURL.Path = strings.Join([]string{pathElems[0], pathElems[1]}, "/")
@@ -401,7 +481,7 @@ func (c *ApplyCommandConfig) loadPolicies(skipInvalidPolicies SkippedInvalidPoli
 			c.GitBranch, gitPathToYamls = common.GetGitBranchOrPolicyPaths(c.GitBranch, repoURL, policyPaths)
 			_, cloneErr := gitutils.Clone(repoURL, fs, c.GitBranch)
 			if cloneErr != nil {
-				fmt.Printf("Error: failed to clone repository \nCause: %s\n", cloneErr)
+				fmt.Fprintf(c.output(), "Error: failed to clone repository \nCause: %s\n", cloneErr)
 				log.Log.V(3).Info(fmt.Sprintf("failed to clone repository  %v as it is not valid", repoURL), "error", cloneErr)
 				osExit(1)
 			}
@@ -415,7 +495,7 @@ func (c *ApplyCommandConfig) loadPolicies(skipInvalidPolicies SkippedInvalidPoli
 
 		policiesFromFile, admissionPoliciesFromFile, err := common.GetPoliciesFromPaths(fs, policyPaths, isGit, "")
 		if err != nil {
-			fmt.Printf("Error: failed to load policies\nCause: %s\n", err)
+			fmt.Fprintf(c.output(), "Error: failed to load policies\nCause: %s\n", err)
 			osExit(1)
 		}
 
@@ -429,12 +509,15 @@ func (c *ApplyCommandConfig) loadPolicies(skipInvalidPolicies SkippedInvalidPoli
 func (c *ApplyCommandConfig) initStoreAndClusterClient(skipInvalidPolicies SkippedInvalidPolicies) (*common.ResultCounts, []*unstructured.Unstructured, SkippedInvalidPolicies, []engineapi.EngineResponse, error, dclient.Interface) {
 	store.SetLocal(true)
 	store.SetRegistryAccess(c.RegistryAccess)
-	if c.Cluster {
+	if c.Cluster || c.ClusterContext {
 		store.AllowApiCall(true)
 	}
+	if c.ClusterContext {
+		store.SetRegistryAccess(true)
+	}
 	var err error
 	var dClient dclient.Interface
-	if c.Cluster {
+	if c.Cluster || c.ClusterContext {
 		restConfig, err := config.CreateClientConfigWithContext(c.KubeConfig, c.Context)
 		if err != nil {
 			return nil, nil, skipInvalidPolicies, nil, err, nil
@@ -451,10 +534,41 @@ func (c *ApplyCommandConfig) initStoreAndClusterClient(skipInvalidPolicies Skipp
 		if err != nil {
 			return nil, nil, skipInvalidPolicies, nil, err, nil
 		}
+		if c.ClusterContext {
+			cmResolver, err := resolvers.NewClientBasedResolver(kubeClient)
+			if err != nil {
+				return nil, nil, skipInvalidPolicies, nil, err, nil
+			}
+			store.SetConfigMapResolver(cmResolver)
+		}
 	}
 	return nil, nil, skipInvalidPolicies, nil, err, dClient
 }
 
+// loadSnapshot resolves context entries from the snapshot and completes the namespace labels,
+// labels passed in the values file take precedence
+func (c *ApplyCommandConfig) loadSnapshot(namespaceSelectorMap map[string]map[string]string) (map[string]map[string]string, error) {
+	if c.Snapshot == "" {
+		return namespaceSelectorMap, nil
+	}
+	s, err := snapshot.Load(nil, c.Snapshot)
+	if err != nil {
+		return nil, err
+	}
+	store.AllowApiCall(true)
+	store.SetRawClient(s)
+	store.SetConfigMapResolver(s)
+	if namespaceSelectorMap == nil {
+		namespaceSelectorMap = map[string]map[string]string{}
+	}
+	for namespace, labels := range s.NamespaceLabels() {
+		if _, ok := namespaceSelectorMap[namespace]; !ok {
+			namespaceSelectorMap[namespace] = labels
+		}
+	}
+	return namespaceSelectorMap, nil
+}
+
 func (c *ApplyCommandConfig) cleanPreviousContent(mutateLogPathIsDir bool, skipInvalidPolicies SkippedInvalidPolicies) (*common.ResultCounts, []*unstructured.Unstructured, SkippedInvalidPolicies, []engineapi.EngineResponse, error) {
 	// empty the previous contents of the file just in case if the file already existed before with some content(so as to perform overwrites)
 	// the truncation of files for the case when mutateLogPath is dir, is handled under pkg/kyverno/apply/common.go
@@ -483,8 +597,14 @@ func (c *ApplyCommandConfig) checkArguments() (*common.ResultCounts, []*unstruct
 	if (len(c.PolicyPaths) > 0 && c.PolicyPaths[0] == "-") && len(c.ResourcePaths) > 0 && c.ResourcePaths[0] == "-" {
 		return nil, nil, skipInvalidPolicies, nil, sanitizederror.New("a stdin pipe can be used for either policies or resources, not both")
 	}
-	if len(c.ResourcePaths) == 0 && !c.Cluster {
-		return nil, nil, skipInvalidPolicies, nil, sanitizederror.New("resource file(s) or cluster required")
+	if c.Snapshot != "" && (c.Cluster || c.ClusterContext) {
+		return nil, nil, skipInvalidPolicies, nil, sanitizederror.New("a snapshot can't be used together with cluster or cluster-context")
+	}
+	if c.HelmChart != "" && c.Cluster {
+		return nil, nil, skipInvalidPolicies, nil, sanitizederror.New("a helm chart can't be used together with cluster")
+	}
+	if len(c.ResourcePaths) == 0 && !c.Cluster && c.HelmChart == "" {
+		return nil, nil, skipInvalidPolicies, nil, sanitizederror.New("resource file(s), helm chart or cluster required")
 	}
 	return nil, nil, skipInvalidPolicies, nil, nil
 }
@@ -527,9 +647,13 @@ func printViolations(rc *common.ResultCounts) {
 	fmt.Printf("\npass: %d, fail: %d, warn: %d, error: %d, skip: %d \n", rc.Pass, rc.Fail, rc.Warn, rc.Error, rc.Skip)
 }
 
-func exit(rc *common.ResultCounts, warnExitCode int, warnNoPassed bool) {
-	if rc.Fail > 0 || rc.Error > 0 {
+func exit(rc *common.ResultCounts, warnExitCode int, warnNoPassed bool, severityExitCodes map[string]int, responses []engineapi.EngineResponse, auditWarn bool) {
+	if rc.Error > 0 {
+		osExit(1)
+	} else if rc.Fail > 0 && len(severityExitCodes) == 0 {
 		osExit(1)
+	} else if code := severityExitCode(severityExitCodes, auditWarn, responses...); code != 0 {
+		osExit(code)
 	} else if rc.Warn > 0 && warnExitCode != 0 {
 		osExit(warnExitCode)
 	} else if rc.Pass == 0 && warnNoPassed {
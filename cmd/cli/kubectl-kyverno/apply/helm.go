@@ -0,0 +1,54 @@
+package apply
+
+import (
+	"bytes"
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/utils/common"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+const defaultHelmReleaseName = "release-name"
+
+// helmCommand is the helm binary used to render charts, it can be overwritten during unit tests
+var helmCommand = "helm"
+
+// helmTemplateArgs returns the arguments passed to `helm template` to render the configured chart
+func (c *ApplyCommandConfig) helmTemplateArgs() []string {
+	releaseName := c.HelmReleaseName
+	if releaseName == "" {
+		releaseName = defaultHelmReleaseName
+	}
+	args := []string{"template", releaseName, c.HelmChart}
+	if c.Namespace != "" {
+		args = append(args, "--namespace", c.Namespace)
+	}
+	for _, values := range c.HelmValues {
+		args = append(args, "--values", values)
+	}
+	for _, value := range c.HelmSet {
+		args = append(args, "--set", value)
+	}
+	return args
+}
+
+// renderHelmChart renders the configured chart with the helm binary and returns the rendered manifests
+func (c *ApplyCommandConfig) renderHelmChart() ([]*unstructured.Unstructured, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command(helmCommand, c.helmTemplateArgs()...) // #nosec G204
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if message := strings.TrimSpace(stderr.String()); message != "" {
+			return nil, fmt.Errorf("failed to render helm chart %s: %w: %s", c.HelmChart, err, message)
+		}
+		return nil, fmt.Errorf("failed to render helm chart %s: %w", c.HelmChart, err)
+	}
+	resources, err := common.GetResource(stdout.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("failed to load manifests rendered from helm chart %s: %w", c.HelmChart, err)
+	}
+	return resources, nil
+}
@@ -0,0 +1,100 @@
+package apply
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+func Test_helmTemplateArgs(t *testing.T) {
+	c := ApplyCommandConfig{
+		HelmChart:  "./chart",
+		Namespace:  "apps",
+		HelmValues: []string{"values.yaml", "prod.yaml"},
+		HelmSet:    []string{"image.tag=1.0.0"},
+	}
+	assert.DeepEqual(t, c.helmTemplateArgs(), []string{
+		"template", "release-name", "./chart",
+		"--namespace", "apps",
+		"--values", "values.yaml",
+		"--values", "prod.yaml",
+		"--set", "image.tag=1.0.0",
+	})
+	c = ApplyCommandConfig{HelmChart: "oci://registry/chart", HelmReleaseName: "my-release"}
+	assert.DeepEqual(t, c.helmTemplateArgs(), []string{"template", "my-release", "oci://registry/chart"})
+}
+
+// fakeHelm installs a script printing the given output (or failing when fail is set) as the helm binary
+func fakeHelm(t *testing.T, output string, fail bool) {
+	if runtime.GOOS == "windows" {
+		t.Skip("fake helm binary is a shell script")
+	}
+	script := "#!/bin/sh\ncat <<'EOF'\n" + output + "EOF\n"
+	if fail {
+		script = "#!/bin/sh\necho 'Error: chart not found' >&2\nexit 1\n"
+	}
+	path := filepath.Join(t.TempDir(), "helm")
+	assert.NilError(t, os.WriteFile(path, []byte(script), 0o700)) // #nosec G306
+	previous := helmCommand
+	helmCommand = path
+	t.Cleanup(func() { helmCommand = previous })
+}
+
+func Test_renderHelmChart(t *testing.T) {
+	fakeHelm(t, `---
+# Source: chart/templates/service.yaml
+apiVersion: v1
+kind: Service
+metadata:
+  name: release-name-svc
+---
+# Source: chart/templates/pod.yaml
+apiVersion: v1
+kind: Pod
+metadata:
+  name: release-name-pod
+spec:
+  containers:
+  - name: nginx
+    image: nginx:latest
+`, false)
+	c := ApplyCommandConfig{HelmChart: "./chart"}
+	resources, err := c.renderHelmChart()
+	assert.NilError(t, err)
+	assert.Equal(t, len(resources), 2)
+	assert.Equal(t, resources[0].GetKind(), "Service")
+	assert.Equal(t, resources[1].GetName(), "release-name-pod")
+}
+
+func Test_renderHelmChart_error(t *testing.T) {
+	fakeHelm(t, "", true)
+	c := ApplyCommandConfig{HelmChart: "./missing"}
+	_, err := c.renderHelmChart()
+	assert.ErrorContains(t, err, "Error: chart not found")
+}
+
+func Test_Apply_helmChart(t *testing.T) {
+	fakeHelm(t, `---
+apiVersion: v1
+kind: Pod
+metadata:
+  name: release-name-pod
+spec:
+  containers:
+  - name: nginx
+    image: nginx:1.12
+`, false)
+	c := ApplyCommandConfig{
+		PolicyPaths:  []string{"../../../../test/best_practices/disallow_latest_tag.yaml"},
+		HelmChart:    "./chart",
+		PolicyReport: true,
+	}
+	rc, resources, _, _, err := c.applyCommandHelper()
+	assert.NilError(t, err)
+	assert.Equal(t, len(resources), 1)
+	assert.Equal(t, rc.Pass, 2)
+	assert.Equal(t, rc.Fail, 0)
+}
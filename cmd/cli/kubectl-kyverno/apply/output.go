@@ -0,0 +1,118 @@
+package apply
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+
+	policyreportv1alpha2 "github.com/kyverno/kyverno/api/policyreport/v1alpha2"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/utils/output/format"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/utils/output/junit"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/utils/output/sarif"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+)
+
+// severityNone is used in severity exit codes for policies without severity
+const severityNone = "none"
+
+// sortedPolicyResults returns the validation results of the engine responses in a stable order
+func sortedPolicyResults(auditWarn bool, responses ...engineapi.EngineResponse) []policyreportv1alpha2.PolicyReportResult {
+	resultsMap := buildPolicyResults(auditWarn, responses...)
+	scopes := make([]string, 0, len(resultsMap))
+	for scope := range resultsMap {
+		scopes = append(scopes, scope)
+	}
+	sort.Strings(scopes)
+	var results []policyreportv1alpha2.PolicyReportResult
+	for _, scope := range scopes {
+		results = append(results, resultsMap[scope]...)
+	}
+	return results
+}
+
+func resourceName(result policyreportv1alpha2.PolicyReportResult) string {
+	if len(result.Resources) == 0 {
+		return ""
+	}
+	resource := result.Resources[0]
+	if resource.Namespace != "" {
+		return fmt.Sprintf("%s/%s/%s", resource.Kind, resource.Namespace, resource.Name)
+	}
+	return fmt.Sprintf("%s/%s", resource.Kind, resource.Name)
+}
+
+// resourceLocation returns the file resources were loaded from when it is not ambiguous
+func (c *ApplyCommandConfig) resourceLocation() string {
+	if len(c.ResourcePaths) != 1 || c.ResourcePaths[0] == "-" {
+		return ""
+	}
+	if info, err := os.Stat(c.ResourcePaths[0]); err != nil || info.IsDir() {
+		return ""
+	}
+	return c.ResourcePaths[0]
+}
+
+func (c *ApplyCommandConfig) printOutput(w io.Writer, responses []engineapi.EngineResponse) error {
+	switch c.OutputFormat {
+	case format.Sarif:
+		return printSarif(w, responses, c.AuditWarn, c.resourceLocation())
+	case format.JUnit:
+		return printJUnit(w, responses, c.AuditWarn)
+	}
+	return nil
+}
+
+func printSarif(w io.Writer, responses []engineapi.EngineResponse, auditWarn bool, location string) error {
+	var results []sarif.Result
+	for _, result := range sortedPolicyResults(auditWarn, responses...) {
+		results = append(results, sarif.Result{
+			Policy:   result.Policy,
+			Rule:     result.Rule,
+			Message:  result.Message,
+			Severity: result.Severity,
+			Status:   result.Result,
+			Resource: resourceName(result),
+			Location: location,
+		})
+	}
+	return sarif.Print(w, results...)
+}
+
+func printJUnit(w io.Writer, responses []engineapi.EngineResponse, auditWarn bool) error {
+	var cases []junit.TestCase
+	for _, result := range sortedPolicyResults(auditWarn, responses...) {
+		name := resourceName(result)
+		if result.Rule != "" {
+			name = result.Rule + " " + name
+		}
+		cases = append(cases, junit.TestCase{
+			Suite:   result.Policy,
+			Name:    name,
+			Message: result.Message,
+			Status:  result.Result,
+		})
+	}
+	return junit.Print(w, "kyverno apply", cases...)
+}
+
+// severityExitCode returns the highest exit code configured for the severities of the failed validations
+func severityExitCode(exitCodes map[string]int, auditWarn bool, responses ...engineapi.EngineResponse) int {
+	if len(exitCodes) == 0 {
+		return 0
+	}
+	code := 0
+	for _, result := range sortedPolicyResults(auditWarn, responses...) {
+		if result.Result != policyreportv1alpha2.StatusFail {
+			continue
+		}
+		severity := string(result.Severity)
+		if severity == "" {
+			severity = severityNone
+		}
+		if c, ok := exitCodes[severity]; ok && c > code {
+			code = c
+		}
+	}
+	return code
+}
@@ -0,0 +1,132 @@
+package apply
+
+import (
+	"os"
+	"testing"
+
+	"github.com/kyverno/kyverno/api/kyverno"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/utils/common"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	"gotest.tools/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func newSeverityResponse(severity string, rules ...engineapi.RuleResponse) engineapi.EngineResponse {
+	policy := &kyvernov1.ClusterPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "policy-" + severity},
+	}
+	if severity != "" {
+		policy.SetAnnotations(map[string]string{kyverno.AnnotationPolicySeverity: severity})
+	}
+	var resource unstructured.Unstructured
+	resource.SetKind("Pod")
+	resource.SetNamespace("default")
+	resource.SetName("pod")
+	er := engineapi.NewEngineResponse(resource, engineapi.NewKyvernoPolicy(policy), nil)
+	er.PolicyResponse.Add(engineapi.ExecutionStats{}, rules...)
+	return er
+}
+
+func Test_severityExitCode(t *testing.T) {
+	critical := newSeverityResponse("critical", *engineapi.RuleFail("rule", engineapi.Validation, "failed"))
+	low := newSeverityResponse("low", *engineapi.RuleFail("rule", engineapi.Validation, "failed"))
+	none := newSeverityResponse("", *engineapi.RuleFail("rule", engineapi.Validation, "failed"))
+	passed := newSeverityResponse("high", *engineapi.RulePass("rule", engineapi.Validation, "passed"))
+	tests := []struct {
+		name      string
+		exitCodes map[string]int
+		responses []engineapi.EngineResponse
+		want      int
+	}{{
+		name:      "no exit codes",
+		responses: []engineapi.EngineResponse{critical},
+		want:      0,
+	}, {
+		name:      "highest code wins",
+		exitCodes: map[string]int{"critical": 3, "low": 1},
+		responses: []engineapi.EngineResponse{low, critical},
+		want:      3,
+	}, {
+		name:      "severity without exit code",
+		exitCodes: map[string]int{"critical": 3},
+		responses: []engineapi.EngineResponse{low},
+		want:      0,
+	}, {
+		name:      "policy without severity",
+		exitCodes: map[string]int{severityNone: 4},
+		responses: []engineapi.EngineResponse{none},
+		want:      4,
+	}, {
+		name:      "passed validations",
+		exitCodes: map[string]int{"high": 2},
+		responses: []engineapi.EngineResponse{passed},
+		want:      0,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, severityExitCode(tt.exitCodes, false, tt.responses...), tt.want)
+		})
+	}
+}
+
+func Test_exit(t *testing.T) {
+	critical := newSeverityResponse("critical", *engineapi.RuleFail("rule", engineapi.Validation, "failed"))
+	low := newSeverityResponse("low", *engineapi.RuleFail("rule", engineapi.Validation, "failed"))
+	tests := []struct {
+		name         string
+		rc           common.ResultCounts
+		warnExitCode int
+		warnNoPassed bool
+		exitCodes    map[string]int
+		responses    []engineapi.EngineResponse
+		want         int
+	}{{
+		name: "passed",
+		rc:   common.ResultCounts{Pass: 1},
+		want: 0,
+	}, {
+		name:      "errors",
+		rc:        common.ResultCounts{Error: 1},
+		exitCodes: map[string]int{"critical": 3},
+		want:      1,
+	}, {
+		name:      "failures",
+		rc:        common.ResultCounts{Fail: 1},
+		responses: []engineapi.EngineResponse{critical},
+		want:      1,
+	}, {
+		name:      "failures with severity exit code",
+		rc:        common.ResultCounts{Fail: 1},
+		exitCodes: map[string]int{"critical": 3},
+		responses: []engineapi.EngineResponse{critical},
+		want:      3,
+	}, {
+		name:      "failures without severity exit code",
+		rc:        common.ResultCounts{Fail: 1},
+		exitCodes: map[string]int{"critical": 3},
+		responses: []engineapi.EngineResponse{low},
+		want:      0,
+	}, {
+		name:         "warnings",
+		rc:           common.ResultCounts{Pass: 1, Warn: 1},
+		warnExitCode: 2,
+		want:         2,
+	}, {
+		name:         "no passed",
+		rc:           common.ResultCounts{Skip: 1},
+		warnExitCode: 2,
+		warnNoPassed: true,
+		want:         2,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code := 0
+			osExit = func(c int) { code = c }
+			defer func() { osExit = os.Exit }()
+			exit(&tt.rc, tt.warnExitCode, tt.warnNoPassed, tt.exitCodes, tt.responses, false)
+			assert.Equal(t, code, tt.want)
+		})
+	}
+}
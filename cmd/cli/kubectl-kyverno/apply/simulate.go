@@ -0,0 +1,117 @@
+package apply
+
+import (
+	"fmt"
+
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/utils/color"
+	sanitizederror "github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/utils/sanitizedError"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+var simulateHelp = `
+To simulate policies against the resources of the cluster in the current context:
+        kyverno simulate /path/to/policy.yaml /path/to/folderOfPolicies
+
+To simulate policies against the resources of a namespace:
+        kyverno simulate /path/to/policy.yaml --namespace default
+
+To print the would-be violations as a policy report:
+        kyverno simulate /path/to/policy.yaml --policy-report
+
+To fail a pipeline when enforcing the policies would block existing resources:
+        kyverno simulate /path/to/policy.yaml --violations-exit-code 2
+
+More info: https://kyverno.io/docs/kyverno-cli/
+`
+
+// SimulateCommand evaluates policies that are not installed yet against the existing resources of the cluster
+// and reports the violations they would cause once enforced
+func SimulateCommand() *cobra.Command {
+	var removeColor, detailedResults, policyReport bool
+	var violationsExitCode int
+	applyCommandConfig := &ApplyCommandConfig{
+		Cluster:        true,
+		ClusterContext: true,
+		simulate:       true,
+	}
+	cmd := &cobra.Command{
+		Use:     "simulate",
+		Short:   "Reports the violations policies would cause on the existing cluster resources.",
+		Example: simulateHelp,
+		RunE: func(cmd *cobra.Command, policyPaths []string) (err error) {
+			color.InitColors(removeColor)
+			defer func() {
+				if err != nil {
+					if !sanitizederror.IsErrorSanitized(err) {
+						log.Log.Error(err, "failed to sanitize")
+						err = fmt.Errorf("internal error")
+					}
+				}
+			}()
+			applyCommandConfig.PolicyPaths = policyPaths
+			rc, resources, skipInvalidPolicies, responses, err := applyCommandConfig.applyCommandHelper()
+			if err != nil {
+				return err
+			}
+			printSkippedAndInvalidPolicies(skipInvalidPolicies)
+			violations := getViolations(responses...)
+			if policyReport {
+				printReport(violations, false)
+			} else if len(violations) != 0 {
+				printTable(cmd.OutOrStdout(), detailedResults, false, violations...)
+			}
+			violationsCount, violatingResources := countViolations(violations...)
+			fmt.Printf("\n%d violation(s) in %d resource(s) out of %d scanned, error: %d\n", violationsCount, violatingResources, len(resources), rc.Error)
+			if rc.Error > 0 {
+				osExit(1)
+			} else if len(violations) != 0 && violationsExitCode != 0 {
+				osExit(violationsExitCode)
+			}
+			return nil
+		},
+	}
+	cmd.Flags().StringVarP(&applyCommandConfig.Namespace, "namespace", "n", "", "Only simulate policies against the resources of the given namespace")
+	cmd.Flags().StringVar(&applyCommandConfig.KubeConfig, "kubeconfig", "", "path to kubeconfig file with authorization and master location information")
+	cmd.Flags().StringVar(&applyCommandConfig.Context, "context", "", "The name of the kubeconfig context to use")
+	cmd.Flags().StringVarP(&applyCommandConfig.GitBranch, "git-branch", "b", "", "test git repository branch")
+	cmd.Flags().StringSliceVarP(&applyCommandConfig.Variables, "set", "s", nil, "Variables that are required")
+	cmd.Flags().StringVarP(&applyCommandConfig.ValuesFile, "values-file", "f", "", "File containing values for policy variables")
+	cmd.Flags().StringVarP(&applyCommandConfig.UserInfoPath, "userinfo", "u", "", "Admission Info including Roles, Cluster Roles and Subjects")
+	cmd.Flags().BoolVarP(&policyReport, "policy-report", "p", false, "Print the would-be violations as a policy report")
+	cmd.Flags().IntVar(&violationsExitCode, "violations-exit-code", 0, "Set the exit code used when policies would cause violations; if errors are found, will exit 1")
+	cmd.Flags().BoolVar(&removeColor, "remove-color", false, "Remove any color from output")
+	cmd.Flags().BoolVar(&detailedResults, "detailed-results", false, "If set to true, display detailed results")
+	return cmd
+}
+
+// getViolations returns the engine responses with failed validation rules, keeping only the failed rules
+func getViolations(responses ...engineapi.EngineResponse) []engineapi.EngineResponse {
+	var violations []engineapi.EngineResponse
+	for _, response := range responses {
+		var failed []engineapi.RuleResponse
+		for _, rule := range response.PolicyResponse.Rules {
+			if rule.RuleType() == engineapi.Validation && rule.Status() == engineapi.RuleStatusFail {
+				failed = append(failed, rule)
+			}
+		}
+		if len(failed) != 0 {
+			response.PolicyResponse.Rules = failed
+			violations = append(violations, response)
+		}
+	}
+	return violations
+}
+
+// countViolations returns the number of failed rules and the number of resources they failed on
+func countViolations(violations ...engineapi.EngineResponse) (int, int) {
+	var count int
+	resources := sets.New[string]()
+	for _, violation := range violations {
+		count += len(violation.PolicyResponse.Rules)
+		resources.Insert(string(violation.Resource.GetUID()) + "/" + violation.Resource.GetKind() + "/" + violation.Resource.GetNamespace() + "/" + violation.Resource.GetName())
+	}
+	return count, resources.Len()
+}
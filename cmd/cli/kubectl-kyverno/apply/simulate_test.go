@@ -0,0 +1,55 @@
+package apply
+
+import (
+	"encoding/json"
+	"testing"
+
+	kyverno "github.com/kyverno/kyverno/api/kyverno/v1"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	"gotest.tools/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func Test_getViolations(t *testing.T) {
+	var policy kyverno.ClusterPolicy
+	err := json.Unmarshal(rawPolicy, &policy)
+	assert.NilError(t, err)
+
+	newResponse := func(name string, rules ...engineapi.RuleResponse) engineapi.EngineResponse {
+		var resource unstructured.Unstructured
+		resource.SetKind("Pod")
+		resource.SetNamespace("default")
+		resource.SetName(name)
+		er := engineapi.NewEngineResponse(resource, engineapi.NewKyvernoPolicy(&policy), nil)
+		er.PolicyResponse.Add(engineapi.ExecutionStats{}, rules...)
+		return er
+	}
+	responses := []engineapi.EngineResponse{
+		newResponse("failing",
+			*engineapi.RuleFail("pods-require-account", engineapi.Validation, "validation error: User pods must include an account for charging"),
+			*engineapi.RuleFail("pods-require-limits", engineapi.Validation, "validation error: CPU and memory resource requests and limits are required for user pods"),
+		),
+		newResponse("partially-failing",
+			*engineapi.RulePass("pods-require-account", engineapi.Validation, "validation rule 'pods-require-account' passed."),
+			*engineapi.RuleFail("pods-require-limits", engineapi.Validation, "validation error: CPU and memory resource requests and limits are required for user pods"),
+		),
+		newResponse("passing",
+			*engineapi.RulePass("pods-require-account", engineapi.Validation, "validation rule 'pods-require-account' passed."),
+			*engineapi.RuleSkip("pods-require-limits", engineapi.Validation, "rule skipped"),
+		),
+	}
+
+	violations := getViolations(responses...)
+	assert.Equal(t, len(violations), 2)
+	assert.Equal(t, violations[0].Resource.GetName(), "failing")
+	assert.Equal(t, len(violations[0].PolicyResponse.Rules), 2)
+	assert.Equal(t, violations[1].Resource.GetName(), "partially-failing")
+	assert.Equal(t, len(violations[1].PolicyResponse.Rules), 1)
+	assert.Equal(t, violations[1].PolicyResponse.Rules[0].Name(), "pods-require-limits")
+	// the engine responses are not modified
+	assert.Equal(t, len(responses[1].PolicyResponse.Rules), 2)
+
+	count, resources := countViolations(violations...)
+	assert.Equal(t, count, 3)
+	assert.Equal(t, resources, 2)
+}
@@ -0,0 +1,145 @@
+package catalog
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/utils/common"
+	sanitizederror "github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/utils/sanitizedError"
+	"github.com/kyverno/kyverno/pkg/catalog"
+	"github.com/kyverno/kyverno/pkg/client/clientset/versioned"
+	"github.com/kyverno/kyverno/pkg/config"
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+var description = []string{
+	"Lists policies with their match scopes, modes and webhook coverage as json.",
+	"Policies are read from files or from the cluster, the output is the same as the one of the policy catalog endpoint of the admission controller.",
+	"When a namespace is given only the policies applying to the namespace are listed, with the mode effective in the namespace.",
+}
+
+var examples = []string{
+	"  # List the policies of a folder                       \n  kyverno catalog policies/",
+	"  # List the policies of the cluster applying to a namespace\n  kyverno catalog --cluster --namespace apps",
+}
+
+type options struct {
+	cluster    bool
+	namespace  string
+	kubeConfig string
+	context    string
+}
+
+func Command() *cobra.Command {
+	var opts options
+	cmd := &cobra.Command{
+		Use:          "catalog [policy]...",
+		Short:        description[0],
+		Long:         strings.Join(description, "\n"),
+		Example:      strings.Join(examples, "\n\n"),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if !opts.cluster && len(args) == 0 {
+				return sanitizederror.NewWithError("no policies", fmt.Errorf("policy paths are required when --cluster is not set"))
+			}
+			var policies []kyvernov1.PolicyInterface
+			var namespace *catalog.Namespace
+			if opts.namespace != "" {
+				namespace = &catalog.Namespace{Name: opts.namespace}
+			}
+			if len(args) != 0 {
+				loaded, _, err := common.GetPoliciesFromPaths(nil, args, false, "")
+				if err != nil {
+					return sanitizederror.NewWithError("failed to load policies", err)
+				}
+				policies = append(policies, loaded...)
+			}
+			if opts.cluster {
+				loaded, ns, err := loadFromCluster(cmd.Context(), opts)
+				if err != nil {
+					return sanitizederror.NewWithError("failed to load policies from the cluster", err)
+				}
+				policies = append(policies, loaded...)
+				if ns != nil {
+					namespace = ns
+				}
+			}
+			return printCatalog(cmd.Context(), cmd.OutOrStdout(), policies, namespace)
+		},
+	}
+	cmd.Flags().BoolVar(&opts.cluster, "cluster", false, "List the policies installed in the cluster")
+	cmd.Flags().StringVarP(&opts.namespace, "namespace", "n", "", "List only the policies applying to the namespace")
+	cmd.Flags().StringVar(&opts.kubeConfig, "kubeconfig", "", "path to kubeconfig file with authorization and master location information")
+	cmd.Flags().StringVar(&opts.context, "context", "", "The name of the kubeconfig context to use")
+	return cmd
+}
+
+// loadFromCluster returns the policies of the cluster and, when filtering with a namespace,
+// the namespace with its labels and annotations
+func loadFromCluster(ctx context.Context, opts options) ([]kyvernov1.PolicyInterface, *catalog.Namespace, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	restConfig, err := config.CreateClientConfigWithContext(opts.kubeConfig, opts.context)
+	if err != nil {
+		return nil, nil, err
+	}
+	kyvernoClient, err := versioned.NewForConfig(restConfig)
+	if err != nil {
+		return nil, nil, err
+	}
+	var policies []kyvernov1.PolicyInterface
+	cpols, err := kyvernoClient.KyvernoV1().ClusterPolicies().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, nil, err
+	}
+	for i := range cpols.Items {
+		policies = append(policies, &cpols.Items[i])
+	}
+	pols, err := kyvernoClient.KyvernoV1().Policies(opts.namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, nil, err
+	}
+	for i := range pols.Items {
+		policies = append(policies, &pols.Items[i])
+	}
+	if opts.namespace == "" {
+		return policies, nil, nil
+	}
+	kubeClient, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return nil, nil, err
+	}
+	ns, err := kubeClient.CoreV1().Namespaces().Get(ctx, opts.namespace, metav1.GetOptions{})
+	if err != nil {
+		return nil, nil, err
+	}
+	namespace := &catalog.Namespace{
+		Name:        ns.GetName(),
+		Labels:      ns.GetLabels(),
+		Annotations: ns.GetAnnotations(),
+	}
+	// namespace labels must not be nil for namespace selectors to be evaluated
+	if namespace.Labels == nil {
+		namespace.Labels = map[string]string{}
+	}
+	return policies, namespace, nil
+}
+
+func printCatalog(ctx context.Context, out io.Writer, policies []kyvernov1.PolicyInterface, namespace *catalog.Namespace) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	data, err := json.MarshalIndent(catalog.Build(ctx, policies, namespace), "", "  ")
+	if err != nil {
+		return sanitizederror.NewWithError("failed to marshal catalog", err)
+	}
+	fmt.Fprintln(out, string(data))
+	return nil
+}
@@ -0,0 +1,133 @@
+package explain
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/utils/common"
+	sanitizederror "github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/utils/sanitizedError"
+	"github.com/spf13/cobra"
+)
+
+var description = []string{
+	"Explains which policy rules match resources and why, without evaluating the rules.",
+	"For every rule the match and exclude blocks are evaluated, and the preconditions when the rule matches.",
+	"Rules not matching a resource come with the reasons they don't, to debug policies that don't fire.",
+	"Context entries are not loaded, preconditions using them can't be evaluated.",
+}
+
+var examples = []string{
+	"  # Explain which rules match a resource         \n  kyverno explain policies/ --resource deployment.yaml",
+	"  # Explain a delete request in a labelled namespace\n  kyverno explain policy.yaml --resource pod.yaml --operation DELETE --namespace-labels env=prod",
+	"  # Print the explanation as json                \n  kyverno explain policies/ --resource pod.yaml --output json",
+}
+
+type commandOptions struct {
+	resources       []string
+	operation       string
+	namespaceLabels map[string]string
+	output          string
+}
+
+func Command() *cobra.Command {
+	var opts commandOptions
+	cmd := &cobra.Command{
+		Use:          "explain [policy]...",
+		Short:        description[0],
+		Long:         strings.Join(description, "\n"),
+		Example:      strings.Join(examples, "\n\n"),
+		Args:         cobra.MinimumNArgs(1),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if opts.output != "text" && opts.output != "json" {
+				return sanitizederror.NewWithError("invalid output format", fmt.Errorf("%s is not supported, use text or json", opts.output))
+			}
+			operation := kyvernov1.AdmissionOperation(strings.ToUpper(opts.operation))
+			switch operation {
+			case kyvernov1.Create, kyvernov1.Update, kyvernov1.Delete, kyvernov1.Connect:
+			default:
+				return sanitizederror.NewWithError("invalid operation", fmt.Errorf("%s is not supported, use CREATE, UPDATE, DELETE or CONNECT", opts.operation))
+			}
+			policies, _, err := common.GetPoliciesFromPaths(nil, args, false, "")
+			if err != nil {
+				return sanitizederror.NewWithError("failed to load policies", err)
+			}
+			var explanations []Explanation
+			for _, path := range opts.resources {
+				data, err := os.ReadFile(path) // #nosec G304
+				if err != nil {
+					return sanitizederror.NewWithError("failed to read resource", err)
+				}
+				resources, err := common.GetResource(data)
+				if err != nil {
+					return sanitizederror.NewWithError(fmt.Sprintf("failed to load resources from %s", path), err)
+				}
+				for _, resource := range resources {
+					explanation, err := explain(policies, *resource, options{
+						operation:       operation,
+						namespaceLabels: opts.namespaceLabels,
+					})
+					if err != nil {
+						return sanitizederror.NewWithError(fmt.Sprintf("failed to explain %s", resourceKey(*resource)), err)
+					}
+					explanations = append(explanations, explanation)
+				}
+			}
+			return printExplanations(cmd.OutOrStdout(), opts.output, explanations...)
+		},
+	}
+	cmd.Flags().StringSliceVarP(&opts.resources, "resource", "r", nil, "Path to the resource files")
+	cmd.Flags().StringVar(&opts.operation, "operation", string(kyvernov1.Create), "Operation of the admission request (CREATE, UPDATE, DELETE or CONNECT)")
+	cmd.Flags().StringToStringVar(&opts.namespaceLabels, "namespace-labels", nil, "Labels of the resource namespace, used to evaluate namespace selectors")
+	cmd.Flags().StringVarP(&opts.output, "output", "o", "text", "Output format (text or json)")
+	_ = cmd.MarkFlagRequired("resource")
+	return cmd
+}
+
+func printExplanations(out io.Writer, output string, explanations ...Explanation) error {
+	if output == "json" {
+		if explanations == nil {
+			explanations = []Explanation{}
+		}
+		data, err := json.MarshalIndent(explanations, "", "  ")
+		if err != nil {
+			return sanitizederror.NewWithError("failed to marshal explanations", err)
+		}
+		fmt.Fprintln(out, string(data))
+		return nil
+	}
+	for _, explanation := range explanations {
+		fmt.Fprintf(out, "%s\n", explanation.Resource)
+		for _, rule := range explanation.Rules {
+			name := rule.Policy + "/" + rule.Rule
+			if rule.Namespace != "" {
+				name = rule.Namespace + "/" + name
+			}
+			switch {
+			case !rule.Matched:
+				fmt.Fprintf(out, "  SKIP %s: not matched\n", name)
+				for _, reason := range rule.Reasons {
+					fmt.Fprintf(out, "       - %s\n", reason)
+				}
+			case rule.Preconditions == nil:
+				fmt.Fprintf(out, "  MATCH %s\n", name)
+			case rule.Preconditions.Error != "":
+				fmt.Fprintf(out, "  ERROR %s: matched, preconditions can't be evaluated: %s\n", name, rule.Preconditions.Error)
+			case rule.Preconditions.Passed:
+				fmt.Fprintf(out, "  MATCH %s: preconditions passed\n", name)
+			default:
+				fmt.Fprintf(out, "  SKIP %s: matched, preconditions failed\n", name)
+			}
+			if rule.Preconditions != nil {
+				for _, trace := range rule.Preconditions.Trace {
+					fmt.Fprintf(out, "       - %s\n", trace)
+				}
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,181 @@
+package explain
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/go-logr/logr"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov1beta1 "github.com/kyverno/kyverno/api/kyverno/v1beta1"
+	"github.com/kyverno/kyverno/pkg/autogen"
+	"github.com/kyverno/kyverno/pkg/config"
+	enginecontext "github.com/kyverno/kyverno/pkg/engine/context"
+	"github.com/kyverno/kyverno/pkg/engine/jmespath"
+	"github.com/kyverno/kyverno/pkg/engine/policycontext"
+	engineutils "github.com/kyverno/kyverno/pkg/engine/utils"
+	"github.com/kyverno/kyverno/pkg/engine/variables"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// Explanation lists the rules evaluated against a resource and why they match or not
+type Explanation struct {
+	Resource string `json:"resource"`
+	Rules    []Rule `json:"rules"`
+}
+
+// Rule explains why a rule matches a resource or not
+type Rule struct {
+	Policy    string `json:"policy"`
+	Namespace string `json:"namespace,omitempty"`
+	Rule      string `json:"rule"`
+	// Applies is true when the rule matches and its preconditions pass
+	Applies bool `json:"applies"`
+	// Matched is true when the resource is selected by the match block and not excluded by the exclude block
+	Matched bool `json:"matched"`
+	// Reasons lists why the match or exclude blocks don't select the resource
+	Reasons []string `json:"reasons,omitempty"`
+	// Preconditions is the evaluation of the preconditions, only set when the rule matched and declares preconditions
+	Preconditions *Preconditions `json:"preconditions,omitempty"`
+}
+
+// Preconditions is the evaluation of the preconditions of a rule
+type Preconditions struct {
+	Passed bool `json:"passed"`
+	// Trace lists the evaluated conditions with their substituted key and value
+	Trace []string `json:"trace,omitempty"`
+	// Error is set when the preconditions can't be evaluated, e.g. when they reference context entries
+	Error string `json:"error,omitempty"`
+}
+
+// noResourceMatched is the reason reported by the engine when no filter of an any block matches
+const noResourceMatched = "no resource matched"
+
+type options struct {
+	operation       kyvernov1.AdmissionOperation
+	namespaceLabels map[string]string
+	userInfo        kyvernov1beta1.RequestInfo
+}
+
+func resourceKey(resource unstructured.Unstructured) string {
+	key := resource.GetAPIVersion() + "/" + resource.GetKind() + "/"
+	if resource.GetNamespace() != "" {
+		key += resource.GetNamespace() + "/"
+	}
+	return key + resource.GetName()
+}
+
+// reasons splits the error returned when a rule doesn't match into the individual reasons
+func reasons(err error) []string {
+	lines := strings.Split(err.Error(), "\n")
+	if len(lines) == 1 {
+		return lines
+	}
+	var reasons []string
+	for _, line := range lines[1:] {
+		line = strings.TrimSpace(line)
+		if _, reason, found := strings.Cut(line, ". "); found {
+			line = reason
+		}
+		if line != "" {
+			reasons = append(reasons, line)
+		}
+	}
+	return reasons
+}
+
+// explain evaluates the match, exclude and preconditions blocks of the rules against the resource,
+// validations, mutations and generations are not evaluated
+func explain(policies []kyvernov1.PolicyInterface, resource unstructured.Unstructured, opts options) (Explanation, error) {
+	explanation := Explanation{Resource: resourceKey(resource), Rules: []Rule{}}
+	cfg := config.NewDefaultConfiguration(false)
+	policyContext, err := policycontext.NewPolicyContext(jmespath.New(cfg), resource, opts.operation, &opts.userInfo, cfg)
+	if err != nil {
+		return explanation, fmt.Errorf("failed to create policy context: %w", err)
+	}
+	jsonContext := policyContext.JSONContext()
+	for _, policy := range policies {
+		policyNamespace := ""
+		if policy.IsNamespaced() {
+			policyNamespace = policy.GetNamespace()
+		}
+		for _, rule := range autogen.ComputeRules(policy) {
+			result := Rule{
+				Policy:    policy.GetName(),
+				Namespace: policyNamespace,
+				Rule:      rule.Name,
+			}
+			err := matches(resource, rule, policyNamespace, opts)
+			if err != nil {
+				result.Reasons = matchReasons(resource, rule, policyNamespace, opts, err)
+			} else {
+				result.Matched = true
+				if rule.GetAnyAllConditions() != nil {
+					result.Preconditions = evaluatePreconditions(jsonContext, rule)
+				}
+				result.Applies = result.Preconditions == nil || result.Preconditions.Passed
+			}
+			explanation.Rules = append(explanation.Rules, result)
+		}
+	}
+	return explanation, nil
+}
+
+func matches(resource unstructured.Unstructured, rule kyvernov1.Rule, policyNamespace string, opts options) error {
+	return engineutils.MatchesResourceDescription(
+		resource,
+		rule,
+		opts.userInfo,
+		opts.namespaceLabels,
+		policyNamespace,
+		resource.GroupVersionKind(),
+		"",
+		opts.operation,
+	)
+}
+
+// matchReasons returns the reasons a rule doesn't match, the reasons of the filters of an any block
+// are not reported by the engine so the filters are evaluated one by one
+func matchReasons(resource unstructured.Unstructured, rule kyvernov1.Rule, policyNamespace string, opts options, err error) []string {
+	var result []string
+	for _, reason := range reasons(err) {
+		if reason != noResourceMatched {
+			result = append(result, reason)
+			continue
+		}
+		for i, filter := range rule.MatchResources.Any {
+			single := rule.DeepCopy()
+			// a single filter in an all block reports its reasons
+			single.MatchResources.Any = nil
+			single.MatchResources.All = kyvernov1.ResourceFilters{filter}
+			single.ExcludeResources = kyvernov1.MatchResources{}
+			if err := matches(resource, *single, policyNamespace, opts); err != nil {
+				for _, reason := range reasons(err) {
+					result = append(result, fmt.Sprintf("any[%d]: %s", i, reason))
+				}
+			}
+		}
+	}
+	return result
+}
+
+func evaluatePreconditions(jsonContext enginecontext.EvalInterface, rule kyvernov1.Rule) *Preconditions {
+	preconditions := &Preconditions{}
+	conditions, err := engineutils.TransformConditions(rule.GetAnyAllConditions())
+	if err != nil {
+		preconditions.Error = fmt.Sprintf("failed to parse preconditions: %s", err)
+		return preconditions
+	}
+	passed, _, trace, err := variables.EvaluateConditionsWithTrace(logr.Discard(), jsonContext, conditions)
+	if err != nil {
+		preconditions.Error = err.Error()
+		if len(rule.Context) != 0 {
+			preconditions.Error += " (context entries are not loaded, preconditions using them can't be evaluated)"
+		}
+		return preconditions
+	}
+	preconditions.Passed = passed
+	for _, t := range trace {
+		preconditions.Trace = append(preconditions.Trace, t.String())
+	}
+	return preconditions
+}
@@ -0,0 +1,140 @@
+package explain
+
+import (
+	"testing"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/utils/common"
+	yamlutils "github.com/kyverno/kyverno/pkg/utils/yaml"
+	"gotest.tools/assert"
+)
+
+const policy = `
+apiVersion: kyverno.io/v1
+kind: ClusterPolicy
+metadata:
+  name: require-labels
+  annotations:
+    pod-policies.kyverno.io/autogen-controllers: none
+spec:
+  rules:
+  - name: check-team
+    match:
+      any:
+      - resources:
+          kinds:
+          - Pod
+    exclude:
+      any:
+      - resources:
+          namespaces:
+          - kube-system
+    preconditions:
+      all:
+      - key: "{{ request.object.metadata.labels.app || '' }}"
+        operator: NotEquals
+        value: ""
+    validate:
+      message: label team is required
+      pattern:
+        metadata:
+          labels:
+            team: "?*"
+  - name: check-tenant
+    match:
+      any:
+      - resources:
+          kinds:
+          - ConfigMap
+      - resources:
+          kinds:
+          - Pod
+          namespaceSelector:
+            matchLabels:
+              tenant: "true"
+    validate:
+      message: label tenant is required
+      pattern:
+        metadata:
+          labels:
+            tenant: "?*"
+`
+
+func newResource(namespace string, labels string) []byte {
+	return []byte(`
+apiVersion: v1
+kind: Pod
+metadata:
+  name: nginx
+  namespace: ` + namespace + `
+  labels: ` + labels + `
+spec:
+  containers:
+  - name: nginx
+    image: nginx
+`)
+}
+
+func Test_explain(t *testing.T) {
+	policies, _, err := yamlutils.GetPolicy([]byte(policy))
+	assert.NilError(t, err)
+	tests := []struct {
+		name            string
+		resource        []byte
+		namespaceLabels map[string]string
+		want            []Rule
+	}{{
+		name:     "matched",
+		resource: newResource("default", "{app: nginx}"),
+		want: []Rule{{
+			Policy:        "require-labels",
+			Rule:          "check-team",
+			Applies:       true,
+			Matched:       true,
+			Preconditions: &Preconditions{Passed: true, Trace: []string{`all[0] "nginx" NotEquals "" = true`}},
+		}, {
+			Policy:  "require-labels",
+			Rule:    "check-tenant",
+			Reasons: []string{"any[0]: kind does not match [ConfigMap]", "any[1]: namespace selector does not match labels"},
+		}},
+	}, {
+		name:            "preconditions failed",
+		resource:        newResource("default", "{}"),
+		namespaceLabels: map[string]string{"tenant": "true"},
+		want: []Rule{{
+			Policy:        "require-labels",
+			Rule:          "check-team",
+			Matched:       true,
+			Preconditions: &Preconditions{Trace: []string{`all[0] "" NotEquals "" = false`}},
+		}, {
+			Policy:  "require-labels",
+			Rule:    "check-tenant",
+			Applies: true,
+			Matched: true,
+		}},
+	}, {
+		name:     "excluded",
+		resource: newResource("kube-system", "{app: nginx}"),
+		want: []Rule{{
+			Policy:  "require-labels",
+			Rule:    "check-team",
+			Reasons: []string{"resource excluded since one of the criteria excluded it"},
+		}, {
+			Policy:  "require-labels",
+			Rule:    "check-tenant",
+			Reasons: []string{"any[0]: kind does not match [ConfigMap]", "any[1]: namespace selector does not match labels"},
+		}},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resources, err := common.GetResource(tt.resource)
+			assert.NilError(t, err)
+			explanation, err := explain(policies, *resources[0], options{
+				operation:       kyvernov1.Create,
+				namespaceLabels: tt.namespaceLabels,
+			})
+			assert.NilError(t, err)
+			assert.DeepEqual(t, explanation.Rules, tt.want)
+		})
+	}
+}
@@ -0,0 +1,104 @@
+package resource
+
+import (
+	"bytes"
+	"encoding/json"
+	"sort"
+
+	"gopkg.in/yaml.v3"
+)
+
+// mergeNode updates the node in place so that it encodes value, the nodes of unchanged values are kept
+// as they are to preserve their comments and style, it returns true if the node was modified
+func mergeNode(node *yaml.Node, value interface{}) bool {
+	if equalValues(node, value) {
+		return false
+	}
+	switch value := value.(type) {
+	case map[string]interface{}:
+		if node.Kind == yaml.MappingNode {
+			mergeMapping(node, value)
+			return true
+		}
+	case []interface{}:
+		if node.Kind == yaml.SequenceNode {
+			mergeSequence(node, value)
+			return true
+		}
+	}
+	replaceNode(node, value)
+	return true
+}
+
+// mergeMapping keeps the existing keys in their original order, removes the deleted ones
+// and appends the added ones
+func mergeMapping(node *yaml.Node, value map[string]interface{}) {
+	content := make([]*yaml.Node, 0, len(node.Content))
+	existing := map[string]bool{}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, child := node.Content[i], node.Content[i+1]
+		v, ok := value[key.Value]
+		if !ok {
+			continue
+		}
+		existing[key.Value] = true
+		mergeNode(child, v)
+		content = append(content, key, child)
+	}
+	var added []string
+	for key := range value {
+		if !existing[key] {
+			added = append(added, key)
+		}
+	}
+	sort.Strings(added)
+	for _, key := range added {
+		child := &yaml.Node{}
+		replaceNode(child, value[key])
+		content = append(content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key}, child)
+	}
+	node.Content = content
+}
+
+// mergeSequence merges the items by index, extra items are appended or removed at the end
+func mergeSequence(node *yaml.Node, value []interface{}) {
+	content := make([]*yaml.Node, 0, len(value))
+	for i, v := range value {
+		if i < len(node.Content) {
+			mergeNode(node.Content[i], v)
+			content = append(content, node.Content[i])
+		} else {
+			child := &yaml.Node{}
+			replaceNode(child, v)
+			content = append(content, child)
+		}
+	}
+	node.Content = content
+}
+
+// replaceNode encodes value into the node, keeping the comments attached to the node
+func replaceNode(node *yaml.Node, value interface{}) {
+	var replacement yaml.Node
+	if err := replacement.Encode(value); err != nil {
+		return
+	}
+	replacement.HeadComment, replacement.LineComment, replacement.FootComment = node.HeadComment, node.LineComment, node.FootComment
+	*node = replacement
+}
+
+// equalValues compares the value decoded from the node with value, numbers of different types are compared through their json encoding
+func equalValues(node *yaml.Node, value interface{}) bool {
+	var decoded interface{}
+	if err := node.Decode(&decoded); err != nil {
+		return false
+	}
+	left, err := json.Marshal(decoded)
+	if err != nil {
+		return false
+	}
+	right, err := json.Marshal(value)
+	if err != nil {
+		return false
+	}
+	return bytes.Equal(left, right)
+}
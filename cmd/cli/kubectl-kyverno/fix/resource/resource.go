@@ -0,0 +1,298 @@
+package resource
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov1beta1 "github.com/kyverno/kyverno/api/kyverno/v1beta1"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/utils/common"
+	sanitizederror "github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/utils/sanitizedError"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/utils/store"
+	"github.com/kyverno/kyverno/pkg/clients/dclient"
+	"github.com/kyverno/kyverno/pkg/config"
+	"github.com/kyverno/kyverno/pkg/engine"
+	"github.com/kyverno/kyverno/pkg/engine/adapters"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	"github.com/kyverno/kyverno/pkg/engine/factories"
+	"github.com/kyverno/kyverno/pkg/engine/jmespath"
+	"github.com/kyverno/kyverno/pkg/imageverifycache"
+	"github.com/kyverno/kyverno/pkg/registryclient"
+	kubeutils "github.com/kyverno/kyverno/pkg/utils/kube"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+var description = []string{
+	"Applies the mutate rules of policies to local manifests and writes the mutated manifests back.",
+	"Comments, key order and formatting of the fields that are not mutated are preserved where possible, only files with mutated resources are rewritten.",
+	"Rules mutating existing resources and rules other than mutate rules are ignored.",
+	"For more information visit: https://kyverno.io/docs/kyverno-cli/.",
+}
+
+var examples = []string{
+	"  # Fix manifests in place                 \n  kyverno fix resource policy.yaml --resource deployment.yaml",
+	"  # Fix a folder of manifests in place     \n  kyverno fix resource policies/ --resource manifests/",
+	"  # Print the fixed manifests without writing\n  kyverno fix resource policy.yaml --resource deployment.yaml --dry-run",
+}
+
+type options struct {
+	resourcePaths []string
+	variables     []string
+	valuesFile    string
+	dryRun        bool
+}
+
+func Command() *cobra.Command {
+	var o options
+	cmd := &cobra.Command{
+		Use:          "resource [policy]...",
+		Short:        description[0],
+		Long:         strings.Join(description, "\n"),
+		Example:      strings.Join(examples, "\n\n"),
+		Args:         cobra.MinimumNArgs(1),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return o.run(cmd.OutOrStdout(), args)
+		},
+	}
+	cmd.Flags().StringSliceVarP(&o.resourcePaths, "resource", "r", nil, "Path to manifests (files or folders) to fix")
+	cmd.Flags().StringSliceVarP(&o.variables, "set", "s", nil, "Variables that are required")
+	cmd.Flags().StringVarP(&o.valuesFile, "values-file", "f", "", "File containing values for policy variables")
+	cmd.Flags().BoolVar(&o.dryRun, "dry-run", false, "Print the fixed manifests instead of writing them")
+	if err := cmd.MarkFlagRequired("resource"); err != nil {
+		panic(err)
+	}
+	return cmd
+}
+
+func (o options) run(out io.Writer, policyPaths []string) error {
+	policies, _, err := common.GetPoliciesFromPaths(nil, policyPaths, false, "")
+	if err != nil {
+		return sanitizederror.NewWithError("failed to load policies", err)
+	}
+	policies = mutatePolicies(policies...)
+	if len(policies) == 0 {
+		return sanitizederror.NewWithError("no policy with mutate rules found", nil)
+	}
+	variables, globalValues, _, namespaceSelectors, _, err := common.GetVariable(o.variables, o.valuesFile, nil, false, "")
+	if err != nil {
+		if !sanitizederror.IsErrorSanitized(err) {
+			return sanitizederror.NewWithError("failed to decode yaml", err)
+		}
+		return err
+	}
+	f := &fixer{
+		engine:             newEngine(),
+		policies:           policies,
+		variables:          mergeVariables(globalValues, variables),
+		namespaceSelectors: namespaceSelectors,
+	}
+	files, err := getManifestFiles(o.resourcePaths...)
+	if err != nil {
+		return sanitizederror.NewWithError("failed to load resources", err)
+	}
+	var fixed int
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return sanitizederror.NewWithError(fmt.Sprintf("failed to read %s", file), err)
+		}
+		result, changed, err := f.fixManifest(data)
+		if err != nil {
+			return sanitizederror.NewWithError(fmt.Sprintf("failed to fix %s", file), err)
+		}
+		if !changed {
+			continue
+		}
+		fixed++
+		if o.dryRun {
+			fmt.Fprintf(out, "# %s\n%s", file, result)
+			continue
+		}
+		info, err := os.Stat(file)
+		if err != nil {
+			return sanitizederror.NewWithError(fmt.Sprintf("failed to stat %s", file), err)
+		}
+		if err := os.WriteFile(file, result, info.Mode()); err != nil {
+			return sanitizederror.NewWithError(fmt.Sprintf("failed to write %s", file), err)
+		}
+		fmt.Fprintf(out, "fixed %s\n", file)
+	}
+	fmt.Fprintf(out, "\n%d file(s) fixed out of %d\n", fixed, len(files))
+	return nil
+}
+
+// mutatePolicies returns copies of the policies keeping only the rules mutating the incoming resource
+func mutatePolicies(policies ...kyvernov1.PolicyInterface) []kyvernov1.PolicyInterface {
+	var results []kyvernov1.PolicyInterface
+	for _, policy := range policies {
+		var rules []kyvernov1.Rule
+		for _, rule := range policy.GetSpec().Rules {
+			if rule.HasMutate() && !rule.IsMutateExisting() {
+				rules = append(rules, rule)
+			}
+		}
+		if len(rules) == 0 {
+			continue
+		}
+		policy = policy.CreateDeepCopy()
+		policy.GetSpec().Rules = rules
+		results = append(results, policy)
+	}
+	return results
+}
+
+func mergeVariables(globalValues map[string]string, variables map[string]string) map[string]interface{} {
+	results := map[string]interface{}{}
+	for key, value := range globalValues {
+		results[key] = value
+	}
+	for key, value := range variables {
+		results[key] = value
+	}
+	return results
+}
+
+func getManifestFiles(paths ...string) ([]string, error) {
+	var files []string
+	for _, path := range paths {
+		err := filepath.Walk(path, func(file string, info os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+			if info.IsDir() {
+				return nil
+			}
+			if ext := filepath.Ext(file); ext == ".yaml" || ext == ".yml" || file == path {
+				files = append(files, file)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return files, nil
+}
+
+func newEngine() engineapi.Engine {
+	cfg := config.NewDefaultConfiguration(false)
+	rclient := store.GetRegistryClient()
+	if rclient == nil {
+		rclient = registryclient.NewOrDie()
+	}
+	return engine.NewEngine(
+		cfg,
+		config.NewDefaultMetricsConfiguration(),
+		jmespath.New(cfg),
+		adapters.Client(dclient.Interface(nil)),
+		factories.DefaultRegistryClientFactory(adapters.RegistryClient(rclient), nil),
+		imageverifycache.DisabledImageVerifyCache(),
+		store.ContextLoaderFactory(store.GetConfigMapResolver()),
+		nil,
+		nil,
+		nil,
+		"",
+	)
+}
+
+type fixer struct {
+	engine             engineapi.Engine
+	policies           []kyvernov1.PolicyInterface
+	variables          map[string]interface{}
+	namespaceSelectors map[string]map[string]string
+}
+
+// fixManifest applies the policies to every resource of a multi document manifest,
+// the mutated resources are merged back into the parsed documents to preserve comments
+func (f *fixer) fixManifest(data []byte) ([]byte, bool, error) {
+	var documents []*yaml.Node
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	for {
+		var document yaml.Node
+		if err := decoder.Decode(&document); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, false, err
+		}
+		documents = append(documents, &document)
+	}
+	var changed bool
+	for _, document := range documents {
+		if len(document.Content) == 0 {
+			continue
+		}
+		var object map[string]interface{}
+		if err := document.Content[0].Decode(&object); err != nil || object["kind"] == nil || object["apiVersion"] == nil {
+			continue
+		}
+		raw, err := json.Marshal(object)
+		if err != nil {
+			return nil, false, err
+		}
+		resource, err := kubeutils.BytesToUnstructured(raw)
+		if err != nil {
+			return nil, false, err
+		}
+		patched, err := f.fixResource(*resource)
+		if err != nil {
+			return nil, false, err
+		}
+		if mergeNode(document.Content[0], patched.Object) {
+			changed = true
+		}
+	}
+	if !changed {
+		return data, false, nil
+	}
+	var buffer bytes.Buffer
+	encoder := yaml.NewEncoder(&buffer)
+	encoder.SetIndent(2)
+	for _, document := range documents {
+		if err := encoder.Encode(document); err != nil {
+			return nil, false, err
+		}
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, false, err
+	}
+	return buffer.Bytes(), true, nil
+}
+
+// fixResource applies the policies in sequence, each policy mutating the output of the previous one
+func (f *fixer) fixResource(resource unstructured.Unstructured) (unstructured.Unstructured, error) {
+	cfg := config.NewDefaultConfiguration(false)
+	for _, policy := range f.policies {
+		policyContext, err := engine.NewPolicyContext(jmespath.New(cfg), resource, kyvernov1.Create, &kyvernov1beta1.RequestInfo{}, cfg)
+		if err != nil {
+			return resource, err
+		}
+		policyContext = policyContext.
+			WithPolicy(policy).
+			WithNamespaceLabels(f.namespaceSelectors[resource.GetNamespace()]).
+			WithResourceKind(resource.GroupVersionKind(), "")
+		for key, value := range f.variables {
+			if err := policyContext.JSONContext().AddVariable(key, value); err != nil {
+				return resource, err
+			}
+		}
+		response := f.engine.Mutate(context.Background(), policyContext)
+		for _, rule := range response.PolicyResponse.Rules {
+			if rule.Status() == engineapi.RuleStatusError {
+				return resource, fmt.Errorf("failed to apply rule %s of policy %s to %s/%s: %s", rule.Name(), policy.GetName(), resource.GetKind(), resource.GetName(), rule.Message())
+			}
+		}
+		resource = response.PatchedResource
+	}
+	return resource, nil
+}
@@ -0,0 +1,143 @@
+package resource
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+	"gotest.tools/assert"
+)
+
+func Test_mergeNode(t *testing.T) {
+	var document yaml.Node
+	assert.NilError(t, yaml.Unmarshal([]byte(`# deployment
+metadata:
+  name: test # name
+  labels:
+    app: test
+spec:
+  replicas: 1 # will be mutated
+  paused: true
+  containers:
+  - name: nginx # main container
+    image: "nginx"
+`), &document))
+	changed := mergeNode(document.Content[0], map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"name":   "test",
+			"labels": map[string]interface{}{"app": "test", "team": "kyverno"},
+		},
+		"spec": map[string]interface{}{
+			"replicas": int64(2),
+			"containers": []interface{}{
+				map[string]interface{}{"name": "nginx", "image": "nginx"},
+				map[string]interface{}{"name": "sidecar", "image": "busybox"},
+			},
+		},
+	})
+	assert.Assert(t, changed)
+	data, err := yaml.Marshal(&document)
+	assert.NilError(t, err)
+	assert.Equal(t, string(data), `# deployment
+metadata:
+    name: test # name
+    labels:
+        app: test
+        team: kyverno
+spec:
+    replicas: 2 # will be mutated
+    containers:
+        - name: nginx # main container
+          image: "nginx"
+        - image: busybox
+          name: sidecar
+`)
+	assert.Assert(t, !mergeNode(document.Content[0], map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"name":   "test",
+			"labels": map[string]interface{}{"app": "test", "team": "kyverno"},
+		},
+		"spec": map[string]interface{}{
+			"replicas": int64(2),
+			"containers": []interface{}{
+				map[string]interface{}{"name": "nginx", "image": "nginx"},
+				map[string]interface{}{"name": "sidecar", "image": "busybox"},
+			},
+		},
+	}))
+}
+
+func Test_Command(t *testing.T) {
+	dir := t.TempDir()
+	policy := filepath.Join(dir, "policy.yaml")
+	assert.NilError(t, os.WriteFile(policy, []byte(`apiVersion: kyverno.io/v1
+kind: ClusterPolicy
+metadata:
+  name: add-labels
+spec:
+  rules:
+  - name: add-team
+    match:
+      any:
+      - resources:
+          kinds:
+          - ConfigMap
+    mutate:
+      patchStrategicMerge:
+        metadata:
+          labels:
+            +(team): kyverno
+`), 0o600))
+	manifests := filepath.Join(dir, "manifests")
+	assert.NilError(t, os.Mkdir(manifests, 0o700))
+	configmap := filepath.Join(manifests, "configmap.yaml")
+	assert.NilError(t, os.WriteFile(configmap, []byte(`# application config
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: config # keep me
+  labels:
+    app: test
+data:
+  key: value
+---
+apiVersion: v1
+kind: Secret
+metadata:
+  name: secret
+`), 0o600))
+	secret := filepath.Join(manifests, "secret.yaml")
+	secretData := []byte("apiVersion: v1\nkind: Secret\nmetadata:\n    name: secret\n")
+	assert.NilError(t, os.WriteFile(secret, secretData, 0o600))
+
+	cmd := Command()
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{policy, "--resource", manifests})
+	assert.NilError(t, cmd.Execute())
+	assert.Equal(t, out.String(), "fixed "+configmap+"\n\n1 file(s) fixed out of 2\n")
+
+	data, err := os.ReadFile(configmap)
+	assert.NilError(t, err)
+	assert.Equal(t, string(data), `# application config
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: config # keep me
+  labels:
+    app: test
+    team: kyverno
+data:
+  key: value
+---
+apiVersion: v1
+kind: Secret
+metadata:
+  name: secret
+`)
+	data, err = os.ReadFile(secret)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, data, secretData)
+}
@@ -0,0 +1,127 @@
+package lint
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/utils/common"
+	sanitizederror "github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/utils/sanitizedError"
+	"github.com/kyverno/kyverno/pkg/config"
+	"github.com/kyverno/kyverno/pkg/openapi"
+	"github.com/kyverno/kyverno/pkg/validation/lint"
+	policyvalidation "github.com/kyverno/kyverno/pkg/validation/policy"
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+var description = []string{
+	"Lints policies and reports validation errors and rule level warnings.",
+	"Warnings have stable codes: KL0xx for deprecated fields, KL1xx for unreachable rules and KL2xx for expensive context calls.",
+	"The same checks run when policies are admitted in the cluster.",
+}
+
+var examples = []string{
+	"  # Lint a policy                   \n  kyverno lint policy.yaml",
+	"  # Lint a folder of policies as json\n  kyverno lint policies/ --output json",
+	"  # Ignore some warnings            \n  kyverno lint policies/ --ignore KL001,KL202",
+}
+
+type result struct {
+	Policy    string         `json:"policy"`
+	Namespace string         `json:"namespace,omitempty"`
+	Error     string         `json:"error,omitempty"`
+	Warnings  []lint.Warning `json:"warnings,omitempty"`
+}
+
+func Command() *cobra.Command {
+	var output string
+	var ignore []string
+	cmd := &cobra.Command{
+		Use:          "lint [policy]...",
+		Short:        description[0],
+		Long:         strings.Join(description, "\n"),
+		Example:      strings.Join(examples, "\n\n"),
+		Args:         cobra.MinimumNArgs(1),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if output != "text" && output != "json" {
+				return sanitizederror.NewWithError("invalid output format", fmt.Errorf("%s is not supported, use text or json", output))
+			}
+			policies, _, err := common.GetPoliciesFromPaths(nil, args, false, "")
+			if err != nil {
+				return sanitizederror.NewWithError("failed to load policies", err)
+			}
+			openApiManager, err := openapi.NewManager(log.Log)
+			if err != nil {
+				return sanitizederror.NewWithError("failed to create openapi manager", err)
+			}
+			results := lintPolicies(openApiManager, sets.New(ignore...), policies...)
+			if err := printResults(cmd.OutOrStdout(), output, results...); err != nil {
+				return err
+			}
+			for _, result := range results {
+				if result.Error != "" {
+					return fmt.Errorf("found invalid policies")
+				}
+			}
+			return nil
+		},
+	}
+	cmd.Flags().StringVarP(&output, "output", "o", "text", "Output format (text or json)")
+	cmd.Flags().StringSliceVar(&ignore, "ignore", nil, "Warning codes to ignore")
+	return cmd
+}
+
+func lintPolicies(openApiManager openapi.Manager, ignore sets.Set[string], policies ...kyvernov1.PolicyInterface) []result {
+	var results []result
+	for _, policy := range policies {
+		result := result{
+			Policy:    policy.GetName(),
+			Namespace: policy.GetNamespace(),
+		}
+		if _, err := policyvalidation.Validate(policy, nil, nil, true, openApiManager, config.KyvernoUserName(config.KyvernoServiceAccountName())); err != nil {
+			result.Error = err.Error()
+		}
+		for _, warning := range lint.Lint(policy) {
+			if !ignore.Has(string(warning.Code)) {
+				result.Warnings = append(result.Warnings, warning)
+			}
+		}
+		results = append(results, result)
+	}
+	return results
+}
+
+func printResults(out io.Writer, output string, results ...result) error {
+	if output == "json" {
+		data, err := json.MarshalIndent(results, "", "  ")
+		if err != nil {
+			return sanitizederror.NewWithError("failed to marshal results", err)
+		}
+		fmt.Fprintln(out, string(data))
+		return nil
+	}
+	for _, result := range results {
+		name := result.Policy
+		if result.Namespace != "" {
+			name = result.Namespace + "/" + name
+		}
+		switch {
+		case result.Error != "":
+			fmt.Fprintf(out, "%s: FAIL\n", name)
+			fmt.Fprintf(out, "  error: %s\n", result.Error)
+		case len(result.Warnings) != 0:
+			fmt.Fprintf(out, "%s: WARN\n", name)
+		default:
+			fmt.Fprintf(out, "%s: OK\n", name)
+		}
+		for _, warning := range result.Warnings {
+			fmt.Fprintf(out, "  %s\n", warning)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,81 @@
+package lint
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/kyverno/kyverno/pkg/openapi"
+	"github.com/kyverno/kyverno/pkg/utils/yaml"
+	"gotest.tools/assert"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+var policies = []byte(`
+apiVersion: kyverno.io/v1
+kind: ClusterPolicy
+metadata:
+  name: valid
+spec:
+  rules:
+  - name: check-team
+    match:
+      any:
+      - resources:
+          kinds:
+          - Pod
+    validate:
+      message: label team is required
+      pattern:
+        metadata:
+          labels:
+            team: "?*"
+---
+apiVersion: kyverno.io/v1
+kind: ClusterPolicy
+metadata:
+  name: deprecated
+spec:
+  validationFailureAction: audit
+  rules:
+  - name: check-team
+    match:
+      any:
+      - resources:
+          kinds:
+          - Pod
+          name: nginx
+    validate:
+      message: label team is required
+      pattern:
+        metadata:
+          labels:
+            team: "?*"
+`)
+
+func Test_lintPolicies(t *testing.T) {
+	loaded, _, err := yaml.GetPolicy(policies)
+	assert.NilError(t, err)
+	openApiManager, err := openapi.NewManager(logr.Discard())
+	assert.NilError(t, err)
+	results := lintPolicies(openApiManager, sets.New[string](), loaded...)
+	assert.Equal(t, len(results), 2)
+	assert.Equal(t, len(results[0].Warnings), 0)
+	assert.Equal(t, len(results[1].Warnings), 2)
+	var out bytes.Buffer
+	assert.NilError(t, printResults(&out, "text", results...))
+	assert.Equal(t, out.String(), `valid: OK
+deprecated: WARN
+  [KL001] spec.validationFailureAction: Validation failure actions enforce/audit are deprecated, use Enforce/Audit instead.
+  [KL002] spec.rules[0].match.any[0].resources.name: name has been deprecated, use names instead
+`)
+	// ignored codes are not reported
+	results = lintPolicies(openApiManager, sets.New("KL001"), loaded...)
+	assert.Equal(t, len(results[1].Warnings), 1)
+	out.Reset()
+	assert.NilError(t, printResults(&out, "json", results...))
+	var decoded []result
+	assert.NilError(t, json.Unmarshal(out.Bytes(), &decoded))
+	assert.DeepEqual(t, decoded, results)
+}
@@ -6,9 +6,15 @@ import (
 	"strconv"
 
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/apply"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/catalog"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/create"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/explain"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/fix"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/jp"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/lint"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/oci"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/policy"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/snapshot"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/test"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/version"
 	"github.com/kyverno/kyverno/pkg/logging"
@@ -49,7 +55,7 @@ func enableExperimental() bool {
 }
 
 func registerCommands(cli *cobra.Command) {
-	cli.AddCommand(version.Command(), create.Command(), apply.Command(), test.Command(), jp.Command())
+	cli.AddCommand(version.Command(), create.Command(), apply.Command(), apply.SimulateCommand(), test.Command(), jp.Command(), policy.Command(), lint.Command(), fix.Command(), snapshot.Command(), catalog.Command(), explain.Command())
 	if enableExperimental() {
 		cli.AddCommand(oci.Command())
 	}
@@ -0,0 +1,26 @@
+package policy
+
+import (
+	"strings"
+
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/policy/render"
+	"github.com/spf13/cobra"
+)
+
+var description = []string{
+	"Provides commands to inspect Kyverno policies.",
+	"For more information visit: https://kyverno.io/docs/kyverno-cli/.",
+}
+
+func Command() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "policy",
+		Short: description[0],
+		Long:  strings.Join(description, "\n"),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmd.Help()
+		},
+	}
+	cmd.AddCommand(render.Command())
+	return cmd
+}
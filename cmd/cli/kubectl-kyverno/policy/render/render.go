@@ -0,0 +1,163 @@
+package render
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-logr/logr"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/utils/common"
+	sanitizederror "github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/utils/sanitizedError"
+	"github.com/kyverno/kyverno/pkg/autogen"
+	"github.com/kyverno/kyverno/pkg/engine/mutate/patch"
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
+)
+
+var description = []string{
+	"Prints policies as they are evaluated by the engine.",
+	"Autogen rules are computed for pod controllers (with patterns and anchors rewritten for the controller templates) and defaults are applied to unset fields.",
+	"When a resource is given, the anchors of the strategic merge patches of the rules matching its kind are resolved against it, an empty patch is printed when the conditions of a patch are not met.",
+	"For more information visit: https://kyverno.io/docs/writing-policies/autogen/.",
+}
+
+var examples = []string{
+	"  # Render a policy            \n  kyverno policy render policy.yaml",
+	"  # Render a folder of policies\n  kyverno policy render policies/",
+	"  # Render a policy with the anchors of its patches resolved against a resource\n  kyverno policy render policy.yaml --resource pod.yaml",
+}
+
+func Command() *cobra.Command {
+	var resourcePath string
+	cmd := &cobra.Command{
+		Use:          "render [policy]...",
+		Short:        description[0],
+		Long:         strings.Join(description, "\n"),
+		Example:      strings.Join(examples, "\n\n"),
+		Args:         cobra.MinimumNArgs(1),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			policies, _, err := common.GetPoliciesFromPaths(nil, args, false, "")
+			if err != nil {
+				return sanitizederror.NewWithError("failed to load policies", err)
+			}
+			var resource *unstructured.Unstructured
+			if resourcePath != "" {
+				if resource, err = loadResource(resourcePath); err != nil {
+					return sanitizederror.NewWithError("failed to load resource", err)
+				}
+			}
+			return printPolicies(cmd.OutOrStdout(), resource, policies...)
+		},
+	}
+	cmd.Flags().StringVarP(&resourcePath, "resource", "r", "", "Path to a resource file, the anchors of strategic merge patches are resolved against the resource")
+	return cmd
+}
+
+func loadResource(path string) (*unstructured.Unstructured, error) {
+	data, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, err
+	}
+	resources, err := common.GetResource(data)
+	if err != nil {
+		return nil, err
+	}
+	if len(resources) != 1 {
+		return nil, fmt.Errorf("expected a single resource in %s, found %d", path, len(resources))
+	}
+	return resources[0], nil
+}
+
+func printPolicies(out io.Writer, resource *unstructured.Unstructured, policies ...kyvernov1.PolicyInterface) error {
+	for i, policy := range policies {
+		rendered := render(policy)
+		if resource != nil {
+			if err := resolveAnchors(rendered, *resource); err != nil {
+				return sanitizederror.NewWithError(fmt.Sprintf("failed to resolve anchors of policy %s", policy.GetName()), err)
+			}
+		}
+		data, err := yaml.Marshal(rendered)
+		if err != nil {
+			return sanitizederror.NewWithError(fmt.Sprintf("failed to marshal policy %s", policy.GetName()), err)
+		}
+		if i > 0 {
+			fmt.Fprintln(out, "---")
+		}
+		fmt.Fprint(out, string(data))
+	}
+	return nil
+}
+
+// render returns a copy of the policy with the computed autogen rules and defaults applied
+func render(policy kyvernov1.PolicyInterface) kyvernov1.PolicyInterface {
+	rules := autogen.ComputeRules(policy)
+	out := policy.CreateDeepCopy()
+	spec := out.GetSpec()
+	spec.SetRules(rules)
+	if spec.ValidationFailureAction == "" {
+		spec.ValidationFailureAction = kyvernov1.Audit
+	}
+	if spec.FailurePolicy == nil {
+		failurePolicy := kyvernov1.Fail
+		spec.FailurePolicy = &failurePolicy
+	}
+	if spec.ApplyRules == nil {
+		applyRules := kyvernov1.ApplyAll
+		spec.ApplyRules = &applyRules
+	}
+	if spec.Admission == nil {
+		admission := spec.AdmissionProcessingEnabled()
+		spec.Admission = &admission
+	}
+	if spec.Background == nil {
+		background := spec.BackgroundProcessingEnabled()
+		spec.Background = &background
+	}
+	if spec.SchemaValidation == nil {
+		schemaValidation := spec.ValidateSchema()
+		spec.SchemaValidation = &schemaValidation
+	}
+	return out
+}
+
+// resolveAnchors resolves the anchors of the strategic merge patches of the rules matching the kind of the resource,
+// the patch is emptied when its conditions are not met as the engine does
+func resolveAnchors(policy kyvernov1.PolicyInterface, resource unstructured.Unstructured) error {
+	data, err := resource.MarshalJSON()
+	if err != nil {
+		return err
+	}
+	rules := policy.GetSpec().Rules
+	for i := range rules {
+		rule := &rules[i]
+		if rule.Mutation.RawPatchStrategicMerge == nil || !matchesKind(rule, resource.GetKind()) {
+			continue
+		}
+		resolved, err := patch.ResolveAnchors(logr.Discard(), rule.Mutation.GetPatchStrategicMerge(), data)
+		if err != nil {
+			var conditionError patch.ConditionError
+			var globalConditionError patch.GlobalConditionError
+			if !errors.As(err, &conditionError) && !errors.As(err, &globalConditionError) {
+				return fmt.Errorf("rule %s: %w", rule.Name, err)
+			}
+			resolved = map[string]interface{}{}
+		}
+		rule.Mutation.SetPatchStrategicMerge(resolved)
+	}
+	return nil
+}
+
+func matchesKind(rule *kyvernov1.Rule, kind string) bool {
+	for _, k := range rule.MatchResources.GetKinds() {
+		if k == "*" || k == kind || strings.HasSuffix(k, "/"+kind) {
+			return true
+		}
+	}
+	return false
+}
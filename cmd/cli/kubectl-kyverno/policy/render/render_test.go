@@ -0,0 +1,152 @@
+package render
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/utils/common"
+	"github.com/kyverno/kyverno/pkg/utils/yaml"
+	"gotest.tools/assert"
+)
+
+var policy = []byte(`
+apiVersion: kyverno.io/v1
+kind: ClusterPolicy
+metadata:
+  name: require-labels
+spec:
+  background: false
+  rules:
+  - name: check-team
+    match:
+      any:
+      - resources:
+          kinds:
+          - Pod
+    validate:
+      message: label team is required
+      pattern:
+        metadata:
+          labels:
+            team: "?*"
+`)
+
+var anchoredPolicy = []byte(`
+apiVersion: kyverno.io/v1
+kind: ClusterPolicy
+metadata:
+  name: add-defaults
+spec:
+  rules:
+  - name: add-team
+    match:
+      any:
+      - resources:
+          kinds:
+          - Pod
+    mutate:
+      patchStrategicMerge:
+        metadata:
+          labels:
+            +(team): platform
+        spec:
+          containers:
+          - (image): "nginx*"
+            imagePullPolicy: Always
+  - name: prod-only
+    match:
+      any:
+      - resources:
+          kinds:
+          - Pod
+    mutate:
+      patchStrategicMerge:
+        metadata:
+          (name): "prod-*"
+          labels:
+            env: prod
+`)
+
+var pod = []byte(`
+apiVersion: v1
+kind: Pod
+metadata:
+  name: test
+  labels:
+    app: test
+spec:
+  containers:
+  - name: nginx
+    image: nginx:1.25
+  - name: sidecar
+    image: busybox
+`)
+
+func loadPolicy(t *testing.T) kyvernov1.PolicyInterface {
+	return loadPolicyFrom(t, policy)
+}
+
+func loadPolicyFrom(t *testing.T, data []byte) kyvernov1.PolicyInterface {
+	policies, _, err := yaml.GetPolicy(data)
+	assert.NilError(t, err)
+	assert.Equal(t, len(policies), 1)
+	return policies[0]
+}
+
+func Test_render(t *testing.T) {
+	in := loadPolicy(t)
+	out := render(in)
+	spec := out.GetSpec()
+	var names []string
+	for _, rule := range spec.Rules {
+		names = append(names, rule.Name)
+	}
+	assert.DeepEqual(t, names, []string{"check-team", "autogen-check-team", "autogen-cronjob-check-team"})
+	assert.Equal(t, spec.ValidationFailureAction, kyvernov1.Audit)
+	assert.Equal(t, *spec.FailurePolicy, kyvernov1.Fail)
+	assert.Equal(t, *spec.ApplyRules, kyvernov1.ApplyAll)
+	assert.Equal(t, *spec.Admission, true)
+	assert.Equal(t, *spec.Background, false)
+	assert.Equal(t, *spec.SchemaValidation, true)
+	// the input policy is left untouched
+	assert.Equal(t, len(in.GetSpec().Rules), 1)
+	assert.Assert(t, in.GetSpec().FailurePolicy == nil)
+}
+
+func Test_printPolicies(t *testing.T) {
+	var out bytes.Buffer
+	assert.NilError(t, printPolicies(&out, nil, loadPolicy(t), loadPolicy(t)))
+	assert.Equal(t, strings.Count(out.String(), "\n---\n"), 1)
+	assert.Assert(t, strings.Contains(out.String(), "name: autogen-cronjob-check-team"))
+	assert.Assert(t, strings.Contains(out.String(), "validationFailureAction: Audit"))
+}
+
+func Test_resolveAnchors(t *testing.T) {
+	resources, err := common.GetResource(pod)
+	assert.NilError(t, err)
+	rendered := render(loadPolicyFrom(t, anchoredPolicy))
+	assert.NilError(t, resolveAnchors(rendered, *resources[0]))
+	patches := map[string]string{}
+	for _, rule := range rendered.GetSpec().Rules {
+		if rule.Mutation.RawPatchStrategicMerge != nil {
+			patches[rule.Name] = string(rule.Mutation.RawPatchStrategicMerge.Raw)
+		}
+	}
+	// rules matching the resource kind have their anchors resolved
+	assert.Equal(t, patches["add-team"], `{"metadata":{"labels":{"team":"platform"}},"spec":{"containers":[{"imagePullPolicy":"Always","name":"nginx"}]}}`)
+	// the patch is emptied when the conditions are not met
+	assert.Equal(t, patches["prod-only"], `{}`)
+	// autogen rules don't match the resource kind and keep their anchors
+	assert.Assert(t, strings.Contains(patches["autogen-add-team"], `"+(team)":"platform"`))
+}
+
+func Test_printPoliciesWithResource(t *testing.T) {
+	resources, err := common.GetResource(pod)
+	assert.NilError(t, err)
+	var out bytes.Buffer
+	assert.NilError(t, printPolicies(&out, resources[0], loadPolicyFrom(t, anchoredPolicy)))
+	assert.Assert(t, strings.Contains(out.String(), "team: platform"))
+	assert.Assert(t, strings.Contains(out.String(), "name: nginx"))
+}
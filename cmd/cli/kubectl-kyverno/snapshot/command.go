@@ -0,0 +1,201 @@
+package snapshot
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/utils/common"
+	sanitizederror "github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/utils/sanitizedError"
+	"github.com/kyverno/kyverno/pkg/autogen"
+	"github.com/kyverno/kyverno/pkg/clients/dclient"
+	"github.com/kyverno/kyverno/pkg/config"
+	"github.com/kyverno/kyverno/pkg/engine/variables/regex"
+	kubeutils "github.com/kyverno/kyverno/pkg/utils/kube"
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+)
+
+var description = []string{
+	"Exports the cluster state referenced by policy contexts to a snapshot file.",
+	"The snapshot holds namespaces, the config maps and api calls used by context entries and the resources of the included kinds.",
+	"Snapshots are consumed by the apply (--snapshot flag) and test (snapshot field) commands to evaluate policies without a cluster.",
+}
+
+var examples = []string{
+	"  # Snapshot the cluster state used by policies\n  kyverno snapshot policies/ --output snapshot.yaml",
+	"  # Include deployments of a namespace          \n  kyverno snapshot policies/ --include apps/v1/Deployment --namespace apps --output snapshot.yaml",
+	"  # Apply policies against the snapshot         \n  kyverno apply policies/ --resource pod.yaml --snapshot snapshot.yaml",
+}
+
+type options struct {
+	kubeConfig string
+	context    string
+	namespace  string
+	output     string
+	include    []string
+}
+
+func Command() *cobra.Command {
+	var opts options
+	cmd := &cobra.Command{
+		Use:          "snapshot [policy]...",
+		Short:        description[0],
+		Long:         strings.Join(description, "\n"),
+		Example:      strings.Join(examples, "\n\n"),
+		Args:         cobra.MinimumNArgs(1),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			policies, _, err := common.GetPoliciesFromPaths(nil, args, false, "")
+			if err != nil {
+				return sanitizederror.NewWithError("failed to load policies", err)
+			}
+			restConfig, err := config.CreateClientConfigWithContext(opts.kubeConfig, opts.context)
+			if err != nil {
+				return sanitizederror.NewWithError("failed to create client config", err)
+			}
+			kubeClient, err := kubernetes.NewForConfig(restConfig)
+			if err != nil {
+				return sanitizederror.NewWithError("failed to create kubernetes client", err)
+			}
+			dynamicClient, err := dynamic.NewForConfig(restConfig)
+			if err != nil {
+				return sanitizederror.NewWithError("failed to create dynamic client", err)
+			}
+			client, err := dclient.NewClient(cmd.Context(), dynamicClient, kubeClient, 15*time.Minute)
+			if err != nil {
+				return sanitizederror.NewWithError("failed to create client", err)
+			}
+			snapshot, err := capture(cmd.Context(), cmd.ErrOrStderr(), client, policies, opts)
+			if err != nil {
+				return sanitizederror.NewWithError("failed to capture snapshot", err)
+			}
+			out := cmd.OutOrStdout()
+			if opts.output != "" {
+				file, err := os.Create(opts.output) // #nosec G304
+				if err != nil {
+					return sanitizederror.NewWithError("failed to create output file", err)
+				}
+				defer file.Close()
+				out = file
+			}
+			if err := snapshot.Write(out); err != nil {
+				return sanitizederror.NewWithError("failed to write snapshot", err)
+			}
+			return nil
+		},
+	}
+	cmd.Flags().StringVarP(&opts.output, "output", "o", "", "Path of the snapshot file, the snapshot is printed when not set")
+	cmd.Flags().StringSliceVar(&opts.include, "include", nil, "Kinds of the resources to include in the snapshot ([group/]version/Kind), they are used to resolve api calls with variables")
+	cmd.Flags().StringVarP(&opts.namespace, "namespace", "n", "", "Namespace of the included resources, all namespaces when not set")
+	cmd.Flags().StringVar(&opts.kubeConfig, "kubeconfig", "", "path to kubeconfig file with authorization and master location information")
+	cmd.Flags().StringVar(&opts.context, "context", "", "The name of the kubeconfig context to use")
+	return cmd
+}
+
+func capture(ctx context.Context, warnings io.Writer, client dclient.Interface, policies []kyvernov1.PolicyInterface, opts options) (*Snapshot, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	snapshot := New()
+	// namespace labels are needed to evaluate namespace selectors
+	namespaces, err := client.ListResource(ctx, "v1", "Namespace", "", nil)
+	if err != nil {
+		return nil, err
+	}
+	for _, namespace := range namespaces.Items {
+		obj := unstructured.Unstructured{}
+		obj.SetAPIVersion("v1")
+		obj.SetKind("Namespace")
+		obj.SetName(namespace.GetName())
+		obj.SetLabels(namespace.GetLabels())
+		snapshot.Add(obj)
+	}
+	for _, kind := range opts.include {
+		group, version, kind, _ := kubeutils.ParseKindSelector(kind)
+		if version == "*" || kind == "*" {
+			return nil, fmt.Errorf("included kinds must be of the form [group/]version/Kind")
+		}
+		apiVersion := version
+		if group != "" && group != "*" {
+			apiVersion = group + "/" + version
+		}
+		list, err := client.ListResource(ctx, apiVersion, kind, opts.namespace, nil)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range list.Items {
+			snapshot.Add(item)
+		}
+	}
+	for _, policy := range policies {
+		for _, rule := range autogen.ComputeRules(policy) {
+			for _, entry := range rule.Context {
+				warning, err := captureEntry(ctx, client, snapshot, entry)
+				if err != nil {
+					return nil, fmt.Errorf("failed to capture context entry %s of rule %s in policy %s: %w", entry.Name, rule.Name, policy.GetName(), err)
+				}
+				if warning != "" {
+					fmt.Fprintf(warnings, "Warning: context entry %s of rule %s in policy %s %s\n", entry.Name, rule.Name, policy.GetName(), warning)
+				}
+			}
+		}
+	}
+	return snapshot, nil
+}
+
+func captureEntry(ctx context.Context, client dclient.Interface, snapshot *Snapshot, entry kyvernov1.ContextEntry) (string, error) {
+	if entry.ConfigMap != nil {
+		if regex.IsVariable(entry.ConfigMap.Name) || regex.IsVariable(entry.ConfigMap.Namespace) {
+			return "uses variables, the config map can't be captured", nil
+		}
+		namespace := entry.ConfigMap.Namespace
+		if namespace == "" {
+			namespace = metav1.NamespaceDefault
+		}
+		cm, err := client.GetKubeClient().CoreV1().ConfigMaps(namespace).Get(ctx, entry.ConfigMap.Name, metav1.GetOptions{})
+		if err != nil {
+			return "", err
+		}
+		data, err := runtime.DefaultUnstructuredConverter.ToUnstructured(cm)
+		if err != nil {
+			return "", err
+		}
+		obj := unstructured.Unstructured{Object: data}
+		obj.SetAPIVersion("v1")
+		obj.SetKind("ConfigMap")
+		snapshot.Add(obj)
+	} else if entry.APICall != nil {
+		if entry.APICall.Service != nil {
+			return "calls a service, the response can't be captured", nil
+		}
+		if entry.APICall.Method != "" && entry.APICall.Method != "GET" {
+			return "uses the POST method, the response can't be captured", nil
+		}
+		if regex.IsVariable(entry.APICall.URLPath) {
+			return "uses variables, it will be resolved from the included resources", nil
+		}
+		data, err := client.RawAbsPath(ctx, entry.APICall.URLPath, "GET", nil)
+		if err != nil {
+			return "", err
+		}
+		var response interface{}
+		if err := json.Unmarshal(data, &response); err != nil {
+			return "", err
+		}
+		if snapshot.APICalls == nil {
+			snapshot.APICalls = map[string]interface{}{}
+		}
+		snapshot.APICalls[entry.APICall.URLPath] = response
+	}
+	return "", nil
+}
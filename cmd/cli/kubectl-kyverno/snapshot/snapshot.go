@@ -0,0 +1,210 @@
+package snapshot
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/go-git/go-billy/v5"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	apiVersion = "cli.kyverno.io/v1alpha1"
+	kind       = "Snapshot"
+)
+
+// Snapshot holds the cluster state referenced by policy contexts so that policies can be evaluated without a cluster.
+// It resolves configMap context entries (ConfigmapResolver) and GET apiCall context entries (RawClient).
+type Snapshot struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	// Objects are the captured namespaces, config maps and selected resources
+	Objects []unstructured.Unstructured `json:"objects,omitempty"`
+	// APICalls maps the URL paths of the captured api calls to their response
+	APICalls map[string]interface{} `json:"apiCalls,omitempty"`
+}
+
+func New() *Snapshot {
+	return &Snapshot{
+		APIVersion: apiVersion,
+		Kind:       kind,
+	}
+}
+
+// Load reads a snapshot from the given filesystem, or from the local filesystem when fs is nil
+func Load(fs billy.Filesystem, path string) (*Snapshot, error) {
+	var data []byte
+	var err error
+	if fs != nil {
+		var file billy.File
+		if file, err = fs.Open(path); err == nil {
+			defer file.Close()
+			data, err = io.ReadAll(file)
+		}
+	} else {
+		data, err = os.ReadFile(path) // #nosec G304
+	}
+	if err != nil {
+		return nil, err
+	}
+	var snapshot Snapshot
+	if err := yaml.Unmarshal(data, &snapshot); err != nil {
+		return nil, err
+	}
+	if snapshot.APIVersion != apiVersion || snapshot.Kind != kind {
+		return nil, fmt.Errorf("unsupported snapshot %s %s, expected %s %s", snapshot.APIVersion, snapshot.Kind, apiVersion, kind)
+	}
+	return &snapshot, nil
+}
+
+// Write writes the snapshot in yaml format
+func (s *Snapshot) Write(w io.Writer) error {
+	data, err := yaml.Marshal(s)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(data)
+	return err
+}
+
+// Add adds an object to the snapshot, replacing the object with the same kind, namespace and name
+func (s *Snapshot) Add(obj unstructured.Unstructured) {
+	compact(&obj)
+	for i := range s.Objects {
+		existing := &s.Objects[i]
+		if existing.GroupVersionKind() == obj.GroupVersionKind() && existing.GetNamespace() == obj.GetNamespace() && existing.GetName() == obj.GetName() {
+			*existing = obj
+			return
+		}
+	}
+	s.Objects = append(s.Objects, obj)
+}
+
+// NamespaceLabels returns the labels of the captured namespaces
+func (s *Snapshot) NamespaceLabels() map[string]map[string]string {
+	out := map[string]map[string]string{}
+	for _, obj := range s.Objects {
+		if obj.GetAPIVersion() == "v1" && obj.GetKind() == "Namespace" {
+			out[obj.GetName()] = obj.GetLabels()
+		}
+	}
+	return out
+}
+
+// Get returns a captured config map
+func (s *Snapshot) Get(_ context.Context, namespace, name string) (*corev1.ConfigMap, error) {
+	for _, obj := range s.Objects {
+		if obj.GetAPIVersion() == "v1" && obj.GetKind() == "ConfigMap" && obj.GetNamespace() == namespace && obj.GetName() == name {
+			var cm corev1.ConfigMap
+			if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, &cm); err != nil {
+				return nil, err
+			}
+			return &cm, nil
+		}
+	}
+	return nil, fmt.Errorf("config map %s/%s not found in snapshot", namespace, name)
+}
+
+// RawAbsPath returns the captured response of an api call, paths that were not captured are resolved
+// from the captured objects (get and list, with an optional label selector)
+func (s *Snapshot) RawAbsPath(_ context.Context, path string, method string, _ io.Reader) ([]byte, error) {
+	if method != "GET" {
+		return nil, fmt.Errorf("method %s is not supported by snapshots, only GET api calls can be resolved", method)
+	}
+	if response, ok := s.APICalls[path]; ok {
+		return json.Marshal(response)
+	}
+	response, err := s.query(path)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(response)
+}
+
+func (s *Snapshot) query(path string) (interface{}, error) {
+	u, err := url.Parse(path)
+	if err != nil {
+		return nil, err
+	}
+	segments := strings.Split(strings.Trim(u.Path, "/"), "/")
+	var gv schema.GroupVersion
+	if len(segments) >= 2 && segments[0] == "api" {
+		gv, segments = schema.GroupVersion{Version: segments[1]}, segments[2:]
+	} else if len(segments) >= 3 && segments[0] == "apis" {
+		gv, segments = schema.GroupVersion{Group: segments[1], Version: segments[2]}, segments[3:]
+	} else {
+		return nil, fmt.Errorf("api call %s not found in snapshot", path)
+	}
+	var namespace string
+	if len(segments) >= 3 && segments[0] == "namespaces" {
+		namespace, segments = segments[1], segments[2:]
+	}
+	if len(segments) != 1 && len(segments) != 2 {
+		return nil, fmt.Errorf("api call %s not found in snapshot", path)
+	}
+	selector := labels.Everything()
+	if raw := u.Query().Get("labelSelector"); raw != "" {
+		if selector, err = labels.Parse(raw); err != nil {
+			return nil, err
+		}
+	}
+	listKind := ""
+	items := []interface{}{}
+	for _, obj := range s.Objects {
+		gvk := obj.GroupVersionKind()
+		if gvk.GroupVersion() != gv {
+			continue
+		}
+		if resource, _ := meta.UnsafeGuessKindToResource(gvk); resource.Resource != segments[0] {
+			continue
+		}
+		listKind = gvk.Kind + "List"
+		if namespace != "" && obj.GetNamespace() != namespace {
+			continue
+		}
+		if len(segments) == 2 {
+			if obj.GetName() == segments[1] {
+				return obj.Object, nil
+			}
+			continue
+		}
+		if selector.Matches(labels.Set(obj.GetLabels())) {
+			items = append(items, obj.Object)
+		}
+	}
+	if len(segments) == 2 || listKind == "" {
+		return nil, fmt.Errorf("api call %s not found in snapshot", path)
+	}
+	return map[string]interface{}{
+		"apiVersion": gv.String(),
+		"kind":       listKind,
+		"metadata":   map[string]interface{}{},
+		"items":      items,
+	}, nil
+}
+
+// compact removes the fields that are not relevant to policies to keep snapshots small and stable
+func compact(obj *unstructured.Unstructured) {
+	for _, field := range []string{"managedFields", "resourceVersion", "uid", "creationTimestamp", "generation", "selfLink"} {
+		unstructured.RemoveNestedField(obj.Object, "metadata", field)
+	}
+	annotations := obj.GetAnnotations()
+	if _, ok := annotations[corev1.LastAppliedConfigAnnotation]; ok {
+		delete(annotations, corev1.LastAppliedConfigAnnotation)
+		if len(annotations) == 0 {
+			annotations = nil
+		}
+		obj.SetAnnotations(annotations)
+	}
+}
@@ -0,0 +1,163 @@
+package snapshot
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"gotest.tools/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func object(apiVersion, kind, namespace, name string, labels map[string]string) unstructured.Unstructured {
+	obj := unstructured.Unstructured{}
+	obj.SetAPIVersion(apiVersion)
+	obj.SetKind(kind)
+	obj.SetNamespace(namespace)
+	obj.SetName(name)
+	obj.SetLabels(labels)
+	obj.SetUID("uid")
+	return obj
+}
+
+func testSnapshot(t *testing.T) *Snapshot {
+	s := New()
+	s.Add(object("v1", "Namespace", "", "apps", map[string]string{"team": "a"}))
+	s.Add(object("v1", "Namespace", "", "infra", nil))
+	s.Add(object("apps/v1", "Deployment", "apps", "web", map[string]string{"tier": "frontend"}))
+	s.Add(object("apps/v1", "Deployment", "apps", "db", map[string]string{"tier": "backend"}))
+	s.Add(object("apps/v1", "Deployment", "infra", "proxy", map[string]string{"tier": "frontend"}))
+	cm := object("v1", "ConfigMap", "apps", "settings", nil)
+	assert.NilError(t, unstructured.SetNestedStringMap(cm.Object, map[string]string{"key": "value"}, "data"))
+	s.Add(cm)
+	s.APICalls = map[string]interface{}{
+		"/version": map[string]interface{}{"major": "1"},
+	}
+	return s
+}
+
+func Test_Add(t *testing.T) {
+	s := testSnapshot(t)
+	count := len(s.Objects)
+	s.Add(object("apps/v1", "Deployment", "apps", "web", map[string]string{"tier": "other"}))
+	assert.Equal(t, count, len(s.Objects))
+	assert.Equal(t, "other", s.Objects[2].GetLabels()["tier"])
+	assert.Equal(t, "", string(s.Objects[2].GetUID()))
+}
+
+func Test_NamespaceLabels(t *testing.T) {
+	s := testSnapshot(t)
+	assert.DeepEqual(t, map[string]map[string]string{
+		"apps":  {"team": "a"},
+		"infra": nil,
+	}, s.NamespaceLabels())
+}
+
+func Test_Get(t *testing.T) {
+	s := testSnapshot(t)
+	cm, err := s.Get(context.TODO(), "apps", "settings")
+	assert.NilError(t, err)
+	assert.Equal(t, "value", cm.Data["key"])
+	_, err = s.Get(context.TODO(), "infra", "settings")
+	assert.Error(t, err, "config map infra/settings not found in snapshot")
+}
+
+func Test_RawAbsPath(t *testing.T) {
+	tests := []struct {
+		name    string
+		path    string
+		method  string
+		want    []string
+		wantErr string
+	}{{
+		name:   "recorded",
+		path:   "/version",
+		method: "GET",
+	}, {
+		name:   "get",
+		path:   "/apis/apps/v1/namespaces/apps/deployments/web",
+		method: "GET",
+		want:   []string{"web"},
+	}, {
+		name:   "list namespaced",
+		path:   "/apis/apps/v1/namespaces/apps/deployments",
+		method: "GET",
+		want:   []string{"web", "db"},
+	}, {
+		name:   "list all namespaces",
+		path:   "/apis/apps/v1/deployments",
+		method: "GET",
+		want:   []string{"web", "db", "proxy"},
+	}, {
+		name:   "label selector",
+		path:   "/apis/apps/v1/deployments?labelSelector=tier%3Dfrontend",
+		method: "GET",
+		want:   []string{"web", "proxy"},
+	}, {
+		name:   "core group",
+		path:   "/api/v1/namespaces",
+		method: "GET",
+		want:   []string{"apps", "infra"},
+	}, {
+		name:    "not found",
+		path:    "/apis/apps/v1/namespaces/apps/deployments/api",
+		method:  "GET",
+		wantErr: "api call /apis/apps/v1/namespaces/apps/deployments/api not found in snapshot",
+	}, {
+		name:    "unknown resource",
+		path:    "/apis/batch/v1/jobs",
+		method:  "GET",
+		wantErr: "api call /apis/batch/v1/jobs not found in snapshot",
+	}, {
+		name:    "post",
+		path:    "/version",
+		method:  "POST",
+		wantErr: "method POST is not supported by snapshots, only GET api calls can be resolved",
+	}}
+	s := testSnapshot(t)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := s.RawAbsPath(context.TODO(), tt.path, tt.method, nil)
+			if tt.wantErr != "" {
+				assert.Error(t, err, tt.wantErr)
+				return
+			}
+			assert.NilError(t, err)
+			var response unstructured.Unstructured
+			assert.NilError(t, json.Unmarshal(data, &response.Object))
+			if tt.want == nil {
+				assert.DeepEqual(t, map[string]interface{}{"major": "1"}, response.Object)
+				return
+			}
+			var names []string
+			if response.IsList() {
+				list, err := response.ToList()
+				assert.NilError(t, err)
+				for _, item := range list.Items {
+					names = append(names, item.GetName())
+				}
+			} else {
+				names = append(names, response.GetName())
+			}
+			assert.DeepEqual(t, tt.want, names)
+		})
+	}
+}
+
+func Test_Load(t *testing.T) {
+	s := testSnapshot(t)
+	var buf bytes.Buffer
+	assert.NilError(t, s.Write(&buf))
+	path := filepath.Join(t.TempDir(), "snapshot.yaml")
+	assert.NilError(t, os.WriteFile(path, buf.Bytes(), 0o600))
+	loaded, err := Load(nil, path)
+	assert.NilError(t, err)
+	assert.Equal(t, len(s.Objects), len(loaded.Objects))
+	assert.DeepEqual(t, s.NamespaceLabels(), loaded.NamespaceLabels())
+	assert.NilError(t, os.WriteFile(path, []byte("apiVersion: v1\nkind: ConfigMap\n"), 0o600))
+	_, err = Load(nil, path)
+	assert.Error(t, err, "unsupported snapshot v1 ConfigMap, expected cli.kyverno.io/v1alpha1 Snapshot")
+}
@@ -6,12 +6,16 @@ import (
 )
 
 type Test struct {
-	Name      string        `json:"name"`
-	Policies  []string      `json:"policies"`
-	Resources []string      `json:"resources"`
-	Variables string        `json:"variables"`
-	UserInfo  string        `json:"userinfo"`
-	Results   []TestResults `json:"results"`
+	Name      string   `json:"name"`
+	Policies  []string `json:"policies"`
+	Resources []string `json:"resources"`
+	Variables string   `json:"variables"`
+	UserInfo  string   `json:"userinfo"`
+	// Snapshot is the path of a cluster snapshot (kyverno snapshot) used to resolve
+	// context entries (configMap and apiCall) and namespace labels.
+	// +optional
+	Snapshot string        `json:"snapshot,omitempty"`
+	Results  []TestResults `json:"results"`
 }
 
 type TestResults struct {
@@ -42,6 +46,11 @@ type TestResults struct {
 	// PatchedResource takes a resource configuration file in yaml format from
 	// the user to compare it against the Kyverno mutated resource configuration.
 	PatchedResource string `json:"patchedResource"`
+	// GoldenPatchedResource takes the path of a golden file in yaml format holding the
+	// expected Kyverno mutated resource configuration, the mutated resource must be equal to it.
+	// Golden files are (re)generated when running the test command with the --update flag.
+	// +optional
+	GoldenPatchedResource string `json:"goldenPatchedResource,omitempty"`
 	// AutoGeneratedRule is internally set by the CLI command. It takes values either
 	// autogen or autogen-cronjob.
 	AutoGeneratedRule string `json:"auto_generated_rule"`
@@ -2,6 +2,7 @@ package test
 
 import (
 	"fmt"
+	"io"
 	"strings"
 
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/test/api"
@@ -13,13 +14,13 @@ func noFilter(api.TestResults) bool {
 	return true
 }
 
-func parseFilter(in string) filter {
+func parseFilter(out io.Writer, in string) filter {
 	var filters []filter
 	if in != "" {
 		for _, t := range strings.Split(in, ",") {
 			parts := strings.Split(t, "=")
 			if len(parts) != 2 {
-				fmt.Printf("\n Invalid test-case-selector argument (%s). Selecting all test cases. \n", t)
+				fmt.Fprintf(out, "\n Invalid test-case-selector argument (%s). Selecting all test cases. \n", t)
 				return noFilter
 			}
 			key := strings.TrimSpace(parts[0])
@@ -38,7 +39,7 @@ func parseFilter(in string) filter {
 					return r.Resource == "" || r.Resource == value
 				})
 			default:
-				fmt.Printf("\n Invalid parameter. Parameter can only be policy, rule or resource. Selecting all test cases \n")
+				fmt.Fprintf(out, "\n Invalid parameter. Parameter can only be policy, rule or resource. Selecting all test cases \n")
 				return noFilter
 			}
 		}
@@ -0,0 +1,77 @@
+package test
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/go-git/go-billy/v5"
+	"github.com/pmezard/go-difflib/difflib"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
+)
+
+// compareGoldenResource compares the mutated resource with the golden file stored at path.
+// When update is true the golden file is (re)written with the mutated resource instead.
+// It returns a unified diff between the golden file and the mutated resource if they don't match.
+func compareGoldenResource(path string, resource unstructured.Unstructured, isGit bool, policyResourcePath string, fs billy.Filesystem, update bool) (bool, string, error) {
+	actual, err := yaml.Marshal(resource.Object)
+	if err != nil {
+		return false, "", err
+	}
+	if update {
+		if isGit {
+			return false, "", errors.New("golden files can't be updated in a git repository")
+		}
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			return false, "", err
+		}
+		if err := os.WriteFile(path, actual, 0o600); err != nil {
+			return false, "", err
+		}
+		return true, "", nil
+	}
+	golden, err := readGoldenFile(path, isGit, policyResourcePath, fs)
+	if err != nil {
+		return false, "", err
+	}
+	// normalize the golden file so that formatting and key order don't matter
+	var object interface{}
+	if err := yaml.Unmarshal(golden, &object); err != nil {
+		return false, "", fmt.Errorf("failed to decode golden file %s: %w", path, err)
+	}
+	expected, err := yaml.Marshal(object)
+	if err != nil {
+		return false, "", err
+	}
+	if bytes.Equal(expected, actual) {
+		return true, "", nil
+	}
+	diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(string(expected)),
+		B:        difflib.SplitLines(string(actual)),
+		FromFile: path,
+		ToFile:   "mutated resource",
+		Context:  3,
+	})
+	if err != nil {
+		return false, "", err
+	}
+	return false, diff, nil
+}
+
+func readGoldenFile(path string, isGit bool, policyResourcePath string, fs billy.Filesystem) ([]byte, error) {
+	if !isGit {
+		// #nosec G304
+		return os.ReadFile(path)
+	}
+	file, err := fs.Open(filepath.Join(policyResourcePath, path))
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return io.ReadAll(file)
+}
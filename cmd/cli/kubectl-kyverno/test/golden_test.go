@@ -0,0 +1,51 @@
+package test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"gotest.tools/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func Test_compareGoldenResource(t *testing.T) {
+	resource := unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "Pod",
+		"metadata": map[string]interface{}{
+			"name": "nginx",
+			"labels": map[string]interface{}{
+				"app": "nginx",
+			},
+		},
+	}}
+	path := filepath.Join(t.TempDir(), "golden", "pod.yaml")
+	// missing golden file
+	_, _, err := compareGoldenResource(path, resource, false, "", nil, false)
+	assert.Assert(t, err != nil)
+	// update writes the golden file
+	matched, _, err := compareGoldenResource(path, resource, false, "", nil, true)
+	assert.NilError(t, err)
+	assert.Assert(t, matched)
+	matched, diff, err := compareGoldenResource(path, resource, false, "", nil, false)
+	assert.NilError(t, err)
+	assert.Assert(t, matched)
+	assert.Equal(t, diff, "")
+	// formatting and key order don't matter
+	assert.NilError(t, os.WriteFile(path, []byte("kind: Pod\napiVersion: v1\nmetadata:\n    labels: {app: nginx}\n    name: nginx\n"), 0o600))
+	matched, _, err = compareGoldenResource(path, resource, false, "", nil, false)
+	assert.NilError(t, err)
+	assert.Assert(t, matched)
+	// mismatch returns a diff
+	resource.SetLabels(map[string]string{"app": "httpd"})
+	matched, diff, err = compareGoldenResource(path, resource, false, "", nil, false)
+	assert.NilError(t, err)
+	assert.Assert(t, !matched)
+	assert.Assert(t, strings.Contains(diff, "-    app: nginx"), diff)
+	assert.Assert(t, strings.Contains(diff, "+    app: httpd"), diff)
+	// golden files can't be updated in git repositories
+	_, _, err = compareGoldenResource(path, resource, true, "", nil, true)
+	assert.Assert(t, err != nil)
+}
@@ -82,6 +82,7 @@ results:
   namespace: <name> (OPTIONAL)
   kind: <name>
   patchedResource: <path/to/patched/resource.yaml> (For mutate policies/rules only)
+  goldenPatchedResource: <path/to/golden/resource.yaml> (For mutate policies/rules only, must match exactly, regenerated with --update)
   result: <pass|fail|skip>
 
 **VARIABLES FILE FORMAT**:
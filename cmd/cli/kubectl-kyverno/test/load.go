@@ -24,6 +24,7 @@ type policy struct {
 }
 
 func loadTests(
+	out io.Writer,
 	dirPath []string,
 	fileName string,
 	gitBranch string,
@@ -38,7 +39,7 @@ func loadTests(
 			pathElems := strings.Split(gitURL.Path[1:], "/")
 			if len(pathElems) <= 1 {
 				err := fmt.Errorf("invalid URL path %s - expected https://github.com/:owner/:repository/:branch (without --git-branch flag) OR https://github.com/:owner/:repository/:directory (with --git-branch flag)", gitURL.Path)
-				fmt.Printf("Error: failed to parse URL \nCause: %s\n", err)
+				fmt.Fprintf(out, "Error: failed to parse URL \nCause: %s\n", err)
 				os.Exit(1)
 			}
 			gitURL.Path = strings.Join([]string{pathElems[0], pathElems[1]}, "/")
@@ -65,7 +66,7 @@ func loadTests(
 			}
 			_, cloneErr := gitutils.Clone(repoURL, fs, gitBranch)
 			if cloneErr != nil {
-				fmt.Printf("Error: failed to clone repository \nCause: %s\n", cloneErr)
+				fmt.Fprintf(out, "Error: failed to clone repository \nCause: %s\n", cloneErr)
 				log.Log.V(3).Info(fmt.Sprintf("failed to clone repository  %v as it is not valid", repoURL), "error", cloneErr)
 				os.Exit(1)
 			}
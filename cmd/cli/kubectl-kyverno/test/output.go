@@ -0,0 +1,60 @@
+package test
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	policyreportv1alpha2 "github.com/kyverno/kyverno/api/policyreport/v1alpha2"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/utils/output/format"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/utils/output/junit"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/utils/output/sarif"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/utils/output/table"
+)
+
+func rowStatus(row table.Row) policyreportv1alpha2.PolicyResult {
+	if row.IsFailure {
+		return policyreportv1alpha2.StatusFail
+	}
+	return policyreportv1alpha2.StatusPass
+}
+
+// printOutput prints the test results in the given format, rows are expected to be printed without colors
+func printOutput(w io.Writer, outputFormat string, results table.Table) error {
+	switch outputFormat {
+	case format.Sarif:
+		var out []sarif.Result
+		for _, row := range results.RawRows {
+			out = append(out, sarif.Result{
+				Policy:   row.Policy,
+				Rule:     row.Rule,
+				Message:  testMessage(row),
+				Status:   rowStatus(row),
+				Resource: row.Resource,
+			})
+		}
+		return sarif.Print(w, out...)
+	case format.JUnit:
+		var cases []junit.TestCase
+		for _, row := range results.RawRows {
+			cases = append(cases, junit.TestCase{
+				Suite:   row.Policy,
+				Name:    strings.TrimSpace(row.Rule + " " + row.Resource),
+				Message: testMessage(row),
+				Status:  rowStatus(row),
+			})
+		}
+		return junit.Print(w, "kyverno test", cases...)
+	}
+	return nil
+}
+
+func testMessage(row table.Row) string {
+	if !row.IsFailure {
+		return row.Message
+	}
+	if row.Message == "" {
+		return fmt.Sprintf("test failed: got %s", row.Result)
+	}
+	return fmt.Sprintf("test failed: got %s (%s)", row.Result, row.Message)
+}
@@ -3,6 +3,7 @@ package test
 import (
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"regexp"
@@ -13,6 +14,7 @@ import (
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
 	"github.com/kyverno/kyverno/api/kyverno/v1beta1"
 	policyreportv1alpha2 "github.com/kyverno/kyverno/api/policyreport/v1alpha2"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/snapshot"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/test/api"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/utils/common"
 	sanitizederror "github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/utils/sanitizedError"
@@ -32,6 +34,7 @@ import (
 )
 
 func applyPoliciesFromPath(
+	out io.Writer,
 	fs billy.Filesystem,
 	policyBytes []byte,
 	isGit bool,
@@ -40,6 +43,7 @@ func applyPoliciesFromPath(
 	openApiManager openapi.Manager,
 	filter filter,
 	auditWarn bool,
+	update bool,
 ) (map[string]policyreportv1alpha2.PolicyReportResult, []api.TestResults, error) {
 	engineResponses := make([]engineapi.EngineResponse, 0)
 	var dClient dclient.Interface
@@ -63,7 +67,7 @@ func applyPoliciesFromPath(
 		return nil, nil, nil
 	}
 
-	fmt.Printf("\nExecuting %s...\n", values.Name)
+	fmt.Fprintf(out, "\nExecuting %s...\n", values.Name)
 	valuesFile := values.Variables
 	userInfoFile := values.UserInfo
 
@@ -75,13 +79,18 @@ func applyPoliciesFromPath(
 		return nil, nil, err
 	}
 
+	namespaceSelectorMap, err = loadSnapshot(fs, values.Snapshot, isGit, policyResourcePath, namespaceSelectorMap)
+	if err != nil {
+		return nil, nil, sanitizederror.NewWithError("failed to load snapshot", err)
+	}
+
 	// get the user info as request info from a different file
 	var userInfo v1beta1.RequestInfo
 
 	if userInfoFile != "" {
 		userInfo, err = common.GetUserInfoFromPath(fs, userInfoFile, isGit, policyResourcePath)
 		if err != nil {
-			fmt.Printf("Error: failed to load request info\nCause: %s\n", err)
+			fmt.Fprintf(out, "Error: failed to load request info\nCause: %s\n", err)
 			os.Exit(1)
 		}
 	}
@@ -91,21 +100,26 @@ func applyPoliciesFromPath(
 
 	for i, result := range values.Results {
 		arrPatchedResource := []string{result.PatchedResource}
+		arrGoldenPatchedResource := []string{result.GoldenPatchedResource}
 		arrGeneratedResource := []string{result.GeneratedResource}
 		arrCloneSourceResource := []string{result.CloneSourceResource}
 
 		patchedResourceFullPath := getFullPath(arrPatchedResource, policyResourcePath, isGit)
+		goldenPatchedResourceFullPath := getFullPath(arrGoldenPatchedResource, policyResourcePath, isGit)
 		generatedResourceFullPath := getFullPath(arrGeneratedResource, policyResourcePath, isGit)
 		CloneSourceResourceFullPath := getFullPath(arrCloneSourceResource, policyResourcePath, isGit)
 
 		values.Results[i].PatchedResource = patchedResourceFullPath[0]
+		if result.GoldenPatchedResource != "" {
+			values.Results[i].GoldenPatchedResource = goldenPatchedResourceFullPath[0]
+		}
 		values.Results[i].GeneratedResource = generatedResourceFullPath[0]
 		values.Results[i].CloneSourceResource = CloneSourceResourceFullPath[0]
 	}
 
 	policies, validatingAdmissionPolicies, err := common.GetPoliciesFromPaths(fs, policyFullPath, isGit, policyResourcePath)
 	if err != nil {
-		fmt.Printf("Error: failed to load policies\nCause: %s\n", err)
+		fmt.Fprintf(out, "Error: failed to load policies\nCause: %s\n", err)
 		os.Exit(1)
 	}
 
@@ -145,13 +159,13 @@ func applyPoliciesFromPath(
 					if rule.HasGenerate() {
 						ruleUnstr, err := generate.GetUnstrRule(rule.Generation.DeepCopy())
 						if err != nil {
-							fmt.Printf("Error: failed to get unstructured rule\nCause: %s\n", err)
+							fmt.Fprintf(out, "Error: failed to get unstructured rule\nCause: %s\n", err)
 							break
 						}
 
 						genClone, _, err := unstructured.NestedMap(ruleUnstr.Object, "clone")
 						if err != nil {
-							fmt.Printf("Error: failed to read data\nCause: %s\n", err)
+							fmt.Fprintf(out, "Error: failed to read data\nCause: %s\n", err)
 							break
 						}
 
@@ -169,11 +183,11 @@ func applyPoliciesFromPath(
 
 	resources, err := common.GetResourceAccordingToResourcePath(fs, resourceFullPath, false, policies, validatingAdmissionPolicies, dClient, "", false, isGit, policyResourcePath)
 	if err != nil {
-		fmt.Printf("Error: failed to load resources\nCause: %s\n", err)
+		fmt.Fprintf(out, "Error: failed to load resources\nCause: %s\n", err)
 		os.Exit(1)
 	}
 
-	checkableResources := selectResourcesForCheck(resources, values)
+	checkableResources := selectResourcesForCheck(out, resources, values)
 
 	msgPolicies := "1 policy"
 	if len(policies)+len(validatingAdmissionPolicies) > 1 {
@@ -186,7 +200,7 @@ func applyPoliciesFromPath(
 	}
 
 	if len(policies) > 0 && len(checkableResources) > 0 {
-		fmt.Printf("applying %s to %s... \n", msgPolicies, msgResources)
+		fmt.Fprintf(out, "applying %s to %s... \n", msgPolicies, msgResources)
 	}
 
 	for _, policy := range policies {
@@ -203,7 +217,7 @@ func applyPoliciesFromPath(
 			if len(variables) == 0 {
 				// check policy in variable file
 				if valuesFile == "" || valuesMap[policy.GetName()] == nil {
-					fmt.Printf("test skipped for policy  %v  (as required variables are not provided by the users) \n \n", policy.GetName())
+					fmt.Fprintf(out, "test skipped for policy  %v  (as required variables are not provided by the users) \n \n", policy.GetName())
 				}
 			}
 		}
@@ -227,6 +241,7 @@ func applyPoliciesFromPath(
 				RuleToCloneSourceResource: ruleToCloneSourceResource,
 				Client:                    dClient,
 				Subresources:              subresources,
+				Out:                       out,
 			}
 			ers, err := common.ApplyPolicyOnResource(applyPolicyConfig)
 			if err != nil {
@@ -246,6 +261,7 @@ func applyPoliciesFromPath(
 				Rc:                        &resultCounts,
 				Client:                    dClient,
 				Subresources:              subresources,
+				Out:                       out,
 			}
 			ers, err := validatingAdmissionPolicy.ApplyPolicyOnResource(applyPolicyConfig)
 			if err != nil {
@@ -254,10 +270,39 @@ func applyPoliciesFromPath(
 			engineResponses = append(engineResponses, ers...)
 		}
 	}
-	resultsMap, testResults := buildPolicyResults(engineResponses, values.Results, policyResourcePath, fs, isGit, auditWarn)
+	resultsMap, testResults := buildPolicyResults(out, engineResponses, values.Results, policyResourcePath, fs, isGit, auditWarn, update)
 	return resultsMap, testResults, nil
 }
 
+// loadSnapshot resolves context entries from the test snapshot and completes the namespace labels,
+// labels passed in the values file take precedence
+func loadSnapshot(fs billy.Filesystem, path string, isGit bool, policyResourcePath string, namespaceSelectorMap map[string]map[string]string) (map[string]map[string]string, error) {
+	// the store is shared by all tests, the previous snapshot must not leak
+	store.SetRawClient(nil)
+	store.SetConfigMapResolver(nil)
+	if path == "" {
+		return namespaceSelectorMap, nil
+	}
+	if !isGit {
+		fs = nil
+	}
+	s, err := snapshot.Load(fs, filepath.Join(policyResourcePath, path))
+	if err != nil {
+		return nil, err
+	}
+	store.SetRawClient(s)
+	store.SetConfigMapResolver(s)
+	if namespaceSelectorMap == nil {
+		namespaceSelectorMap = map[string]map[string]string{}
+	}
+	for namespace, labels := range s.NamespaceLabels() {
+		if _, ok := namespaceSelectorMap[namespace]; !ok {
+			namespaceSelectorMap[namespace] = labels
+		}
+	}
+	return namespaceSelectorMap, nil
+}
+
 func getFullPath(paths []string, policyResourcePath string, isGit bool) []string {
 	var pols []string
 	var pol string
@@ -271,13 +316,13 @@ func getFullPath(paths []string, policyResourcePath string, isGit bool) []string
 	return paths
 }
 
-func selectResourcesForCheck(resources []*unstructured.Unstructured, values *api.Test) []*unstructured.Unstructured {
-	res, _, _ := selectResourcesForCheckInternal(resources, values)
+func selectResourcesForCheck(out io.Writer, resources []*unstructured.Unstructured, values *api.Test) []*unstructured.Unstructured {
+	res, _, _ := selectResourcesForCheckInternal(out, resources, values)
 	return res
 }
 
 // selectResourcesForCheckInternal internal method to test duplicates and unused
-func selectResourcesForCheckInternal(resources []*unstructured.Unstructured, values *api.Test) ([]*unstructured.Unstructured, int, int) {
+func selectResourcesForCheckInternal(out io.Writer, resources []*unstructured.Unstructured, values *api.Test) ([]*unstructured.Unstructured, int, int) {
 	var duplicates int
 	var unused int
 	uniqResources := make(map[string]*unstructured.Unstructured)
@@ -286,7 +331,7 @@ func selectResourcesForCheckInternal(resources []*unstructured.Unstructured, val
 		r := resources[i]
 		key := fmt.Sprintf("%s/%s/%s", r.GetKind(), r.GetName(), r.GetNamespace())
 		if _, ok := uniqResources[key]; ok {
-			fmt.Println("skipping duplicate resource, resource :", r)
+			fmt.Fprintln(out, "skipping duplicate resource, resource :", r)
 			duplicates++
 		} else {
 			uniqResources[key] = r
@@ -317,19 +362,21 @@ func selectResourcesForCheckInternal(resources []*unstructured.Unstructured, val
 		delete(uniqResources, key)
 	}
 	for _, r := range uniqResources {
-		fmt.Println("skipping unused resource, resource :", r)
+		fmt.Fprintln(out, "skipping unused resource, resource :", r)
 		unused++
 	}
 	return checkableResources, duplicates, unused
 }
 
 func buildPolicyResults(
+	out io.Writer,
 	engineResponses []engineapi.EngineResponse,
 	testResults []api.TestResults,
 	policyResourcePath string,
 	fs billy.Filesystem,
 	isGit bool,
 	auditWarn bool,
+	update bool,
 ) (map[string]policyreportv1alpha2.PolicyReportResult, []api.TestResults) {
 	results := map[string]policyreportv1alpha2.PolicyReportResult{}
 
@@ -476,7 +523,7 @@ func buildPolicyResults(
 					} else {
 						var x string
 						result.Result = policyreportv1alpha2.StatusFail
-						x = getAndCompareResource(test.GeneratedResource, rule.GeneratedResource(), isGit, policyResourcePath, fs, true)
+						x = getAndCompareResource(out, test.GeneratedResource, rule.GeneratedResource(), isGit, policyResourcePath, fs, true)
 						if x == "pass" {
 							result.Result = policyreportv1alpha2.StatusPass
 						}
@@ -506,11 +553,21 @@ func buildPolicyResults(
 						result.Result = policyreportv1alpha2.StatusSkip
 					} else if rule.Status() == engineapi.RuleStatusError {
 						result.Result = policyreportv1alpha2.StatusError
+					} else if test.GoldenPatchedResource != "" {
+						result.Result = policyreportv1alpha2.StatusFail
+						matched, diff, err := compareGoldenResource(test.GoldenPatchedResource, resp.PatchedResource, isGit, policyResourcePath, fs, update)
+						if err != nil {
+							fmt.Fprintf(out, "Error: failed to compare golden file %s\nCause: %s\n", test.GoldenPatchedResource, err)
+						} else if matched {
+							result.Result = policyreportv1alpha2.StatusPass
+						} else {
+							fmt.Fprintf(out, "\nMutated resource %s/%s does not match golden file (policy %s, rule %s):\n%s", resourceKind, resourceName, policyName, rule.Name(), diff)
+						}
 					} else {
 						var x string
 						for _, path := range patchedResourcePath {
 							result.Result = policyreportv1alpha2.StatusFail
-							x = getAndCompareResource(path, resp.PatchedResource, isGit, policyResourcePath, fs, false)
+							x = getAndCompareResource(out, path, resp.PatchedResource, isGit, policyResourcePath, fs, false)
 							if x == "pass" {
 								result.Result = policyreportv1alpha2.StatusPass
 								break
@@ -554,7 +611,7 @@ func buildPolicyResults(
 							result.Result = policyreportv1alpha2.StatusFail
 						}
 					} else {
-						fmt.Println(rule)
+						fmt.Fprintln(out, rule)
 					}
 
 					results[resultKey] = result
@@ -618,7 +675,7 @@ func isNamespacedPolicy(policyNames string) (bool, error) {
 
 // getAndCompareResource --> Get the patchedResource or generatedResource from the path provided by user
 // And compare this resource with engine generated resource.
-func getAndCompareResource(path string, engineResource unstructured.Unstructured, isGit bool, policyResourcePath string, fs billy.Filesystem, isGenerate bool) string {
+func getAndCompareResource(out io.Writer, path string, engineResource unstructured.Unstructured, isGit bool, policyResourcePath string, fs billy.Filesystem, isGenerate bool) string {
 	var status string
 	resourceType := "patchedResource"
 	if isGenerate {
@@ -627,7 +684,7 @@ func getAndCompareResource(path string, engineResource unstructured.Unstructured
 
 	userResource, err := common.GetResourceFromPath(fs, path, isGit, policyResourcePath, resourceType)
 	if err != nil {
-		fmt.Printf("Error: failed to load resources\nCause: %s\n", err)
+		fmt.Fprintf(out, "Error: failed to load resources\nCause: %s\n", err)
 		return ""
 	}
 	matched, err := generate.ValidateResourceWithPattern(log.Log, engineResource.UnstructuredContent(), userResource.UnstructuredContent())
@@ -2,12 +2,14 @@ package test
 
 import (
 	"fmt"
+	"io"
 	"os"
 
 	policyreportv1alpha2 "github.com/kyverno/kyverno/api/policyreport/v1alpha2"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/test/api"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/test/manifest"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/utils/color"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/utils/output/format"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/utils/output/table"
 	sanitizederror "github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/utils/sanitizedError"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/utils/store"
@@ -20,8 +22,8 @@ import (
 func Command() *cobra.Command {
 	var cmd *cobra.Command
 	var testCase string
-	var fileName, gitBranch string
-	var registryAccess, failOnly, removeColor, manifestValidate, manifestMutate, detailedResults bool
+	var fileName, gitBranch, outputFormat string
+	var registryAccess, failOnly, removeColor, manifestValidate, manifestMutate, detailedResults, update bool
 	cmd = &cobra.Command{
 		Use: "test <path_to_folder_Containing_test.yamls> [flags]\n  kyverno test <path_to_gitRepository_with_dir> --git-branch <branchName>\n  kyverno test --manifest-mutate > kyverno-test.yaml\n  kyverno test --manifest-validate > kyverno-test.yaml",
 		// Args:    cobra.ExactArgs(1),
@@ -29,7 +31,10 @@ func Command() *cobra.Command {
 		Long:    longHelp,
 		Example: exampleHelp,
 		RunE: func(cmd *cobra.Command, dirPath []string) (err error) {
-			color.InitColors(removeColor)
+			if err := format.Check(outputFormat); err != nil {
+				return err
+			}
+			color.InitColors(removeColor || outputFormat != "")
 			defer func() {
 				if err != nil {
 					if !sanitizederror.IsErrorSanitized(err) {
@@ -44,7 +49,7 @@ func Command() *cobra.Command {
 				manifest.PrintValidate()
 			} else {
 				store.SetRegistryAccess(registryAccess)
-				_, err = testCommandExecute(dirPath, fileName, gitBranch, testCase, failOnly, false, detailedResults)
+				_, err = testCommandExecute(cmd.OutOrStdout(), cmd.ErrOrStderr(), dirPath, fileName, gitBranch, testCase, failOnly, false, detailedResults, update, outputFormat)
 				if err != nil {
 					log.Log.V(3).Info("a directory is required")
 					return err
@@ -62,6 +67,8 @@ func Command() *cobra.Command {
 	cmd.Flags().BoolVar(&failOnly, "fail-only", false, "If set to true, display all the failing test only as output for the test command")
 	cmd.Flags().BoolVar(&removeColor, "remove-color", false, "Remove any color from output")
 	cmd.Flags().BoolVar(&detailedResults, "detailed-results", false, "If set to true, display detailed results")
+	cmd.Flags().StringVar(&outputFormat, "output-format", "", "Print test results in the given format (sarif or junit) after running the tests, other messages are printed to stderr")
+	cmd.Flags().BoolVar(&update, "update", false, "If set to true, regenerate golden files (goldenPatchedResource) from the mutated resources")
 	return cmd
 }
 
@@ -72,6 +79,8 @@ type resultCounts struct {
 }
 
 func testCommandExecute(
+	out io.Writer,
+	errOut io.Writer,
 	dirPath []string,
 	fileName string,
 	gitBranch string,
@@ -79,27 +88,35 @@ func testCommandExecute(
 	failOnly bool,
 	auditWarn bool,
 	detailedResults bool,
+	update bool,
+	outputFormat string,
 ) (rc *resultCounts, err error) {
 	// check input dir
 	if len(dirPath) == 0 {
 		return rc, sanitizederror.NewWithError("a directory is required", err)
 	}
+	report := out
+	if outputFormat != "" {
+		// keep stdout for the structured report, other messages go to stderr
+		out = errOut
+	}
 	// parse filter
-	filter := parseFilter(testCase)
+	filter := parseFilter(out, testCase)
 	// init openapi manager
 	openApiManager, err := openapi.NewManager(log.Log)
 	if err != nil {
 		return rc, fmt.Errorf("unable to create open api controller, %w", err)
 	}
 	// load tests
-	fs, policies, errors := loadTests(dirPath, fileName, gitBranch)
+	fs, policies, errors := loadTests(out, dirPath, fileName, gitBranch)
 	if len(policies) == 0 {
-		fmt.Printf("\n No test yamls available \n")
+		fmt.Fprintf(out, "\n No test yamls available \n")
 	}
 	rc = &resultCounts{}
-	var table table.Table
+	var table, results table.Table
 	for _, p := range policies {
 		if reports, tests, err := applyPoliciesFromPath(
+			out,
 			fs,
 			p.bytes,
 			fs != nil,
@@ -108,29 +125,34 @@ func testCommandExecute(
 			openApiManager,
 			filter,
 			auditWarn,
+			update,
 		); err != nil {
 			return rc, sanitizederror.NewWithError("failed to apply test command", err)
-		} else if t, err := printTestResult(reports, tests, rc, failOnly, detailedResults); err != nil {
+		} else if t, err := printTestResult(out, reports, tests, rc, failOnly, detailedResults); err != nil {
 			return rc, sanitizederror.NewWithError("failed to print test result:", err)
 		} else {
 			table.AddFailed(t.RawRows...)
+			results.Add(t.RawRows...)
 		}
 	}
 	if len(errors) > 0 && log.Log.V(1).Enabled() {
-		fmt.Println("test errors:")
+		fmt.Fprintln(out, "test errors:")
 		for _, e := range errors {
-			fmt.Printf("    %v \n", e.Error())
+			fmt.Fprintf(out, "    %v \n", e.Error())
 		}
 	}
 	if !failOnly {
-		fmt.Printf("\nTest Summary: %d tests passed and %d tests failed\n", rc.Pass+rc.Skip, rc.Fail)
+		fmt.Fprintf(out, "\nTest Summary: %d tests passed and %d tests failed\n", rc.Pass+rc.Skip, rc.Fail)
 	} else {
-		fmt.Printf("\nTest Summary: %d out of %d tests failed\n", rc.Fail, rc.Pass+rc.Skip+rc.Fail)
+		fmt.Fprintf(out, "\nTest Summary: %d out of %d tests failed\n", rc.Fail, rc.Pass+rc.Skip+rc.Fail)
+	}
+	fmt.Fprintln(out)
+	if err := printOutput(report, outputFormat, results); err != nil {
+		return rc, sanitizederror.NewWithError("failed to print test results", err)
 	}
-	fmt.Println()
 	if rc.Fail > 0 {
 		if !failOnly {
-			printFailedTestResult(table, detailedResults)
+			printFailedTestResult(out, table, detailedResults)
 		}
 		os.Exit(1)
 	}
@@ -138,8 +160,8 @@ func testCommandExecute(
 	return rc, nil
 }
 
-func printTestResult(resps map[string]policyreportv1alpha2.PolicyReportResult, testResults []api.TestResults, rc *resultCounts, failOnly bool, detailedResults bool) (table.Table, error) {
-	printer := table.NewTablePrinter()
+func printTestResult(out io.Writer, resps map[string]policyreportv1alpha2.PolicyReportResult, testResults []api.TestResults, rc *resultCounts, failOnly bool, detailedResults bool) (table.Table, error) {
+	printer := table.NewTablePrinter(out)
 	var resultsTable table.Table
 	var countDeprecatedResource int
 	testCount := 1
@@ -326,17 +348,17 @@ func printTestResult(resps map[string]policyreportv1alpha2.PolicyReportResult, t
 			}
 		}
 	}
-	fmt.Printf("\n")
+	fmt.Fprintf(out, "\n")
 	printer.Print(resultsTable.Rows(detailedResults))
 	return resultsTable, nil
 }
 
-func printFailedTestResult(resultsTable table.Table, detailedResults bool) {
-	printer := table.NewTablePrinter()
+func printFailedTestResult(out io.Writer, resultsTable table.Table, detailedResults bool) {
+	printer := table.NewTablePrinter(out)
 	for i := range resultsTable.RawRows {
 		resultsTable.RawRows[i].ID = i + 1
 	}
-	fmt.Printf("Aggregated Failed Test Cases : ")
-	fmt.Println()
+	fmt.Fprintf(out, "Aggregated Failed Test Cases : ")
+	fmt.Fprintln(out)
 	printer.Print(resultsTable.Rows(detailedResults))
 }
@@ -1,6 +1,7 @@
 package test
 
 import (
+	"io"
 	"os"
 	"path/filepath"
 	"testing"
@@ -82,7 +83,7 @@ func Test_selectResourcesForCheck(t *testing.T) {
 		)
 		assert.NilError(t, err)
 
-		selected, duplicates, unused := selectResourcesForCheckInternal(resources, values)
+		selected, duplicates, unused := selectResourcesForCheckInternal(io.Discard, resources, values)
 		assert.Equal(t, len(selected), tc.expectedResources,
 			"Did not get the expected number of resources for test %s", tc.testFile)
 		assert.Equal(t, duplicates, tc.expectedDuplicates,
@@ -68,12 +68,21 @@ type ApplyPolicyConfig struct {
 	Client                    dclient.Interface
 	AuditWarn                 bool
 	Subresources              []values.Subresource
+	// Out receives the messages printed while applying the policy, stdout is used when not set
+	Out io.Writer
+}
+
+func (c ApplyPolicyConfig) output() io.Writer {
+	if c.Out == nil {
+		return os.Stdout
+	}
+	return c.Out
 }
 
 // HasVariables - check for variables in the policy
 func HasVariables(policy kyvernov1.PolicyInterface) [][]string {
 	policyRaw, _ := json.Marshal(policy)
-	matches := regex.RegexVariables.FindAllStringSubmatch(string(policyRaw), -1)
+	matches := regex.RegexVariables.FindAllStringSubmatch(regex.RemoveLiterals(string(policyRaw)), -1)
 	return matches
 }
 
@@ -229,7 +238,7 @@ func GetVariable(
 	if valuesFile != "" {
 		vals, err := values.Load(fs, filepath.Join(policyResourcePath, valuesFile))
 		if err != nil {
-			fmt.Printf("Unable to load variable file: %s. error: %s \n", valuesFile, err)
+			fmt.Fprintf(os.Stderr, "Unable to load variable file: %s. error: %s \n", valuesFile, err)
 			return variables, globalValMap, valuesMapResource, namespaceSelectorMap, subresources, sanitizederror.NewWithError("unable to read yaml", err)
 		}
 
@@ -287,7 +296,7 @@ func GetVariable(
 	}
 
 	if reqObjVars != "" {
-		fmt.Printf("\nNOTICE: request.object.* variables are automatically parsed from the supplied resource. Ignoring value of variables `%v`.\n", reqObjVars)
+		fmt.Fprintf(os.Stderr, "\nNOTICE: request.object.* variables are automatically parsed from the supplied resource. Ignoring value of variables `%v`.\n", reqObjVars)
 	}
 
 	if globalValMap != nil {
@@ -355,22 +364,22 @@ func GetPoliciesFromPaths(fs billy.Filesystem, dirPath []string, isGit bool, pol
 		for _, pp := range dirPath {
 			filep, err := fs.Open(filepath.Join(policyResourcePath, pp))
 			if err != nil {
-				fmt.Printf("Error: file not available with path %s: %v", filep.Name(), err.Error())
+				fmt.Fprintf(os.Stderr, "Error: file not available with path %s: %v", filep.Name(), err.Error())
 				continue
 			}
 			bytes, err := io.ReadAll(filep)
 			if err != nil {
-				fmt.Printf("Error: failed to read file %s: %v", filep.Name(), err.Error())
+				fmt.Fprintf(os.Stderr, "Error: failed to read file %s: %v", filep.Name(), err.Error())
 				continue
 			}
 			policyBytes, err := yaml.ToJSON(bytes)
 			if err != nil {
-				fmt.Printf("failed to convert to JSON: %v", err)
+				fmt.Fprintf(os.Stderr, "failed to convert to JSON: %v", err)
 				continue
 			}
 			policiesFromFile, admissionPoliciesFromFile, errFromFile := yamlutils.GetPolicy(policyBytes)
 			if errFromFile != nil {
-				fmt.Printf("failed to process : %v", errFromFile.Error())
+				fmt.Fprintf(os.Stderr, "failed to process : %v", errFromFile.Error())
 				continue
 			}
 			policies = append(policies, policiesFromFile...)
@@ -400,9 +409,9 @@ func GetPoliciesFromPaths(fs billy.Filesystem, dirPath []string, isGit bool, pol
 				return nil, nil, sanitizederror.New(fmt.Sprintf("no file found in paths %v", dirPath))
 			}
 			if len(errors) > 0 && log.V(1).Enabled() {
-				fmt.Printf("ignoring errors: \n")
+				fmt.Fprintf(os.Stderr, "ignoring errors: \n")
 				for _, e := range errors {
-					fmt.Printf("    %v \n", e.Error())
+					fmt.Fprintf(os.Stderr, "    %v \n", e.Error())
 				}
 			}
 		}
@@ -422,13 +431,11 @@ func GetResourceAccordingToResourcePath(fs billy.Filesystem, resourcePaths []str
 	} else {
 		if len(resourcePaths) > 0 && resourcePaths[0] == "-" {
 			if IsInputFromPipe() {
-				resourceStr := ""
-				scanner := bufio.NewScanner(os.Stdin)
-				for scanner.Scan() {
-					resourceStr = resourceStr + scanner.Text() + "\n"
+				// read stdin at once, scanning lines fails on lines longer than the scanner buffer
+				yamlBytes, err := io.ReadAll(os.Stdin)
+				if err != nil {
+					return nil, sanitizederror.NewWithError("failed to read resources from stdin", err)
 				}
-
-				yamlBytes := []byte(resourceStr)
 				resources, err = GetResource(yamlBytes)
 				if err != nil {
 					return nil, sanitizederror.NewWithError("failed to extract the resources", err)
@@ -465,7 +472,7 @@ func GetResourceAccordingToResourcePath(fs billy.Filesystem, resourcePaths []str
 	return resources, err
 }
 
-func updateResultCounts(policy kyvernov1.PolicyInterface, engineResponse *engineapi.EngineResponse, resPath string, rc *ResultCounts, auditWarn bool) {
+func updateResultCounts(out io.Writer, policy kyvernov1.PolicyInterface, engineResponse *engineapi.EngineResponse, resPath string, rc *ResultCounts, auditWarn bool) {
 	printCount := 0
 	for _, policyRule := range autogen.ComputeRules(policy) {
 		ruleFoundInEngineResponse := false
@@ -477,10 +484,10 @@ func updateResultCounts(policy kyvernov1.PolicyInterface, engineResponse *engine
 					rc.Pass++
 				} else {
 					if printCount < 1 {
-						fmt.Println("\ninvalid resource", "policy", policy.GetName(), "resource", resPath)
+						fmt.Fprintln(out, "\ninvalid resource", "policy", policy.GetName(), "resource", resPath)
 						printCount++
 					}
-					fmt.Printf("%d. %s - %s\n", i+1, ruleResponse.Name(), ruleResponse.Message())
+					fmt.Fprintf(out, "%d. %s - %s\n", i+1, ruleResponse.Name(), ruleResponse.Message())
 
 					if auditWarn && engineResponse.GetValidationFailureAction().Audit() {
 						rc.Warn++
@@ -552,17 +559,17 @@ func processMutateEngineResponse(c ApplyPolicyConfig, mutateResponse *engineapi.
 					c.Rc.Pass++
 					printMutatedRes = true
 				} else if mutateResponseRule.Status() == engineapi.RuleStatusSkip {
-					fmt.Printf("\nskipped mutate policy %s -> resource %s", c.Policy.GetName(), resPath)
+					fmt.Fprintf(c.output(), "\nskipped mutate policy %s -> resource %s", c.Policy.GetName(), resPath)
 					c.Rc.Skip++
 				} else if mutateResponseRule.Status() == engineapi.RuleStatusError {
-					fmt.Printf("\nerror while applying mutate policy %s -> resource %s\nerror: %s", c.Policy.GetName(), resPath, mutateResponseRule.Message())
+					fmt.Fprintf(c.output(), "\nerror while applying mutate policy %s -> resource %s\nerror: %s", c.Policy.GetName(), resPath, mutateResponseRule.Message())
 					c.Rc.Error++
 				} else {
 					if printCount < 1 {
-						fmt.Printf("\nfailed to apply mutate policy %s -> resource %s", c.Policy.GetName(), resPath)
+						fmt.Fprintf(c.output(), "\nfailed to apply mutate policy %s -> resource %s", c.Policy.GetName(), resPath)
 						printCount++
 					}
-					fmt.Printf("%d. %s - %s \n", i+1, mutateResponseRule.Name(), mutateResponseRule.Message())
+					fmt.Fprintf(c.output(), "%d. %s - %s \n", i+1, mutateResponseRule.Name(), mutateResponseRule.Message())
 					c.Rc.Fail++
 				}
 				continue
@@ -583,16 +590,16 @@ func processMutateEngineResponse(c ApplyPolicyConfig, mutateResponse *engineapi.
 			mutatedResource := string(yamlEncodedResource) + string("\n---")
 			if len(strings.TrimSpace(mutatedResource)) > 0 {
 				if !c.Stdin {
-					fmt.Printf("\nmutate policy %s applied to %s:", c.Policy.GetName(), resPath)
+					fmt.Fprintf(c.output(), "\nmutate policy %s applied to %s:", c.Policy.GetName(), resPath)
 				}
-				fmt.Printf("\n" + mutatedResource + "\n")
+				fmt.Fprint(c.output(), "\n"+mutatedResource+"\n")
 			}
 		} else {
 			err := PrintMutatedOutput(c.MutateLogPath, c.MutateLogPathIsDir, string(yamlEncodedResource), c.Resource.GetName()+"-mutated")
 			if err != nil {
 				return sanitizederror.NewWithError("failed to print mutated result", err)
 			}
-			fmt.Printf("\n\nMutation:\nMutation has been applied successfully. Check the files.")
+			fmt.Fprintf(c.output(), "\n\nMutation:\nMutation has been applied successfully. Check the files.")
 		}
 	}
 
@@ -635,7 +642,7 @@ func GetKindsFromPolicy(policy kyvernov1.PolicyInterface, subresources []values.
 		for _, kind := range rule.MatchResources.ResourceDescription.Kinds {
 			k, err := getKind(kind, subresources, dClient)
 			if err != nil {
-				fmt.Printf("Error: %s", err.Error())
+				fmt.Fprintf(os.Stderr, "Error: %s", err.Error())
 				continue
 			}
 			kindOnwhichPolicyIsApplied[k] = struct{}{}
@@ -643,7 +650,7 @@ func GetKindsFromPolicy(policy kyvernov1.PolicyInterface, subresources []values.
 		for _, kind := range rule.ExcludeResources.ResourceDescription.Kinds {
 			k, err := getKind(kind, subresources, dClient)
 			if err != nil {
-				fmt.Printf("Error: %s", err.Error())
+				fmt.Fprintf(os.Stderr, "Error: %s", err.Error())
 				continue
 			}
 			kindOnwhichPolicyIsApplied[k] = struct{}{}
@@ -700,7 +707,7 @@ func GetResourceFromPath(fs billy.Filesystem, path string, isGit bool, policyRes
 		if len(path) > 0 {
 			filep, fileErr := fs.Open(filepath.Join(policyResourcePath, path))
 			if fileErr != nil {
-				fmt.Printf("Unable to open %s file: %s. \nerror: %s", resourceType, path, err)
+				fmt.Fprintf(os.Stderr, "Unable to open %s file: %s. \nerror: %s", resourceType, path, err)
 			}
 			resourceBytes, err = io.ReadAll(filep)
 		}
@@ -709,7 +716,7 @@ func GetResourceFromPath(fs billy.Filesystem, path string, isGit bool, policyRes
 	}
 
 	if err != nil {
-		fmt.Printf("\n----------------------------------------------------------------------\nfailed to load %s: %s. \nerror: %s\n----------------------------------------------------------------------\n", resourceType, path, err)
+		fmt.Fprintf(os.Stderr, "\n----------------------------------------------------------------------\nfailed to load %s: %s. \nerror: %s\n----------------------------------------------------------------------\n", resourceType, path, err)
 		return resource, err
 	}
 
@@ -725,7 +732,7 @@ func GetResourceFromPath(fs billy.Filesystem, path string, isGit bool, policyRes
 func initializeMockController(objects []runtime.Object) (*generate.GenerateController, error) {
 	client, err := dclient.NewFakeClient(runtime.NewScheme(), nil, objects...)
 	if err != nil {
-		fmt.Printf("Failed to mock dynamic client")
+		fmt.Fprintf(os.Stderr, "Failed to mock dynamic client")
 		return nil, err
 	}
 	client.SetDiscovery(dclient.NewFakeDiscoveryClient(nil))
@@ -737,7 +744,9 @@ func initializeMockController(objects []runtime.Object) (*generate.GenerateContr
 		adapters.Client(client),
 		nil,
 		imageverifycache.DisabledImageVerifyCache(),
-		store.ContextLoaderFactory(nil),
+		store.ContextLoaderFactory(store.GetConfigMapResolver()),
+		nil,
+		nil,
 		nil,
 		"",
 	))
@@ -753,11 +762,11 @@ func handleGeneratePolicy(generateResponse *engineapi.EngineResponse, policyCont
 		if path, ok := ruleToCloneSourceResource[rule.Name()]; ok {
 			resourceBytes, err := getFileBytes(path)
 			if err != nil {
-				fmt.Printf("failed to get resource bytes\n")
+				fmt.Fprintf(os.Stderr, "failed to get resource bytes\n")
 			} else {
 				resources, err = GetResource(resourceBytes)
 				if err != nil {
-					fmt.Printf("failed to convert resource bytes to unstructured format\n")
+					fmt.Fprintf(os.Stderr, "failed to convert resource bytes to unstructured format\n")
 				}
 			}
 		}
@@ -769,7 +778,7 @@ func handleGeneratePolicy(generateResponse *engineapi.EngineResponse, policyCont
 
 	c, err := initializeMockController(objects)
 	if err != nil {
-		fmt.Println("error at controller")
+		fmt.Fprintln(os.Stderr, "error at controller")
 		return nil, err
 	}
 
@@ -812,19 +821,19 @@ func GetUserInfoFromPath(fs billy.Filesystem, path string, isGit bool, policyRes
 	if isGit {
 		filep, err := fs.Open(filepath.Join(policyResourcePath, path))
 		if err != nil {
-			fmt.Printf("Unable to open userInfo file: %s. \nerror: %s", path, err)
+			fmt.Fprintf(os.Stderr, "Unable to open userInfo file: %s. \nerror: %s", path, err)
 		}
 		bytes, err := io.ReadAll(filep)
 		if err != nil {
-			fmt.Printf("Error: failed to read file %s: %v", filep.Name(), err.Error())
+			fmt.Fprintf(os.Stderr, "Error: failed to read file %s: %v", filep.Name(), err.Error())
 		}
 		userInfoBytes, err := yaml.ToJSON(bytes)
 		if err != nil {
-			fmt.Printf("failed to convert to JSON: %v", err)
+			fmt.Fprintf(os.Stderr, "failed to convert to JSON: %v", err)
 		}
 
 		if err := json.Unmarshal(userInfoBytes, userInfo); err != nil {
-			fmt.Printf("failed to decode yaml: %v", err)
+			fmt.Fprintf(os.Stderr, "failed to decode yaml: %v", err)
 		}
 	} else {
 		var errors []error
@@ -841,9 +850,9 @@ func GetUserInfoFromPath(fs billy.Filesystem, path string, isGit bool, policyRes
 			errors = append(errors, sanitizederror.NewWithError("failed to decode yaml", err))
 		}
 		if len(errors) > 0 && log.V(1).Enabled() {
-			fmt.Printf("ignoring errors: \n")
+			fmt.Fprintf(os.Stderr, "ignoring errors: \n")
 			for _, e := range errors {
-				fmt.Printf("    %v \n", e.Error())
+				fmt.Fprintf(os.Stderr, "    %v \n", e.Error())
 			}
 		}
 	}
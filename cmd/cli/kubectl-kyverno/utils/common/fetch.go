@@ -91,7 +91,7 @@ func whenClusterIsTrue(resourceTypes []schema.GroupVersionKind, subresourceMap m
 				if policyReport {
 					log.V(3).Info(fmt.Sprintf("%s not found in cluster", resourcePath))
 				} else {
-					fmt.Printf("\n----------------------------------------------------------------------\nresource %s not found in cluster\n----------------------------------------------------------------------\n", resourcePath)
+					fmt.Fprintf(os.Stderr, "\n----------------------------------------------------------------------\nresource %s not found in cluster\n----------------------------------------------------------------------\n", resourcePath)
 				}
 				return nil, fmt.Errorf("%s not found in cluster", resourcePath)
 			}
@@ -108,7 +108,7 @@ func whenClusterIsFalse(resourcePaths []string, policyReport bool) ([]*unstructu
 			if policyReport {
 				log.V(3).Info(fmt.Sprintf("failed to load resources: %s.", resourcePath), "error", err)
 			} else {
-				fmt.Printf("\n----------------------------------------------------------------------\nfailed to load resources: %s. \nerror: %s\n----------------------------------------------------------------------\n", resourcePath, err)
+				fmt.Fprintf(os.Stderr, "\n----------------------------------------------------------------------\nfailed to load resources: %s. \nerror: %s\n----------------------------------------------------------------------\n", resourcePath, err)
 			}
 			continue
 		}
@@ -141,7 +141,7 @@ func GetResourcesWithTest(fs billy.Filesystem, policies []kyvernov1.PolicyInterf
 			if isGit {
 				filep, err := fs.Open(filepath.Join(policyResourcePath, resourcePath))
 				if err != nil {
-					fmt.Printf("Unable to open resource file: %s. error: %s", resourcePath, err)
+					fmt.Fprintf(os.Stderr, "Unable to open resource file: %s. error: %s", resourcePath, err)
 					continue
 				}
 				resourceBytes, _ = io.ReadAll(filep)
@@ -149,7 +149,7 @@ func GetResourcesWithTest(fs billy.Filesystem, policies []kyvernov1.PolicyInterf
 				resourceBytes, err = getFileBytes(resourcePath)
 			}
 			if err != nil {
-				fmt.Printf("\n----------------------------------------------------------------------\nfailed to load resources: %s. \nerror: %s\n----------------------------------------------------------------------\n", resourcePath, err)
+				fmt.Fprintf(os.Stderr, "\n----------------------------------------------------------------------\nfailed to load resources: %s. \nerror: %s\n----------------------------------------------------------------------\n", resourcePath, err)
 				continue
 			}
 
@@ -225,7 +225,7 @@ func getResourcesOfTypeFromCluster(resourceTypes []schema.GroupVersionKind, subr
 			subresourceName := strings.Split(subresource.APIResource.Name, "/")[1]
 			resource, err := dClient.GetResource(context.TODO(), parentGV.String(), subresource.ParentResource.Kind, namespace, parentResourceName, subresourceName)
 			if err != nil {
-				fmt.Printf("Error: %s", err.Error())
+				fmt.Fprintf(os.Stderr, "Error: %s", err.Error())
 				continue
 			}
 			key := subresource.APIResource.Kind + "-" + resource.GetNamespace() + "-" + resource.GetName()
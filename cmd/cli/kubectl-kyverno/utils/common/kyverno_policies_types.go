@@ -13,6 +13,7 @@ import (
 	"github.com/kyverno/kyverno/pkg/engine"
 	"github.com/kyverno/kyverno/pkg/engine/adapters"
 	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	"github.com/kyverno/kyverno/pkg/engine/context/resolvers"
 	"github.com/kyverno/kyverno/pkg/engine/factories"
 	"github.com/kyverno/kyverno/pkg/engine/jmespath"
 	"github.com/kyverno/kyverno/pkg/imageverifycache"
@@ -113,7 +114,14 @@ OuterLoop:
 			}
 		}
 	}
-	rclient := registryclient.NewOrDie()
+	rclient := store.GetRegistryClient()
+	if rclient == nil {
+		rclient = registryclient.NewOrDie()
+	}
+	var paramsResolver engineapi.ParamsResolver
+	if c.Client != nil {
+		paramsResolver, _ = resolvers.NewClientBasedParamsResolver(c.Client)
+	}
 	eng := engine.NewEngine(
 		cfg,
 		config.NewDefaultMetricsConfiguration(),
@@ -121,8 +129,10 @@ OuterLoop:
 		adapters.Client(c.Client),
 		factories.DefaultRegistryClientFactory(adapters.RegistryClient(rclient), nil),
 		imageverifycache.DisabledImageVerifyCache(),
-		store.ContextLoaderFactory(nil),
+		store.ContextLoaderFactory(store.GetConfigMapResolver()),
+		nil,
 		nil,
+		paramsResolver,
 		"",
 	)
 	policyContext, err := engine.NewPolicyContext(
@@ -203,7 +213,7 @@ OuterLoop:
 			combineRuleResponses(generateResponse)
 			engineResponses = append(engineResponses, generateResponse)
 		}
-		updateResultCounts(c.Policy, &generateResponse, resPath, c.Rc, c.AuditWarn)
+		updateResultCounts(c.output(), c.Policy, &generateResponse, resPath, c.Rc, c.AuditWarn)
 	}
 
 	processEngineResponses(engineResponses, c)
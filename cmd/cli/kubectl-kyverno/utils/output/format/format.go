@@ -0,0 +1,21 @@
+package format
+
+import (
+	"fmt"
+
+	sanitizederror "github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/utils/sanitizedError"
+)
+
+const (
+	Sarif = "sarif"
+	JUnit = "junit"
+)
+
+// Check returns an error if the output format is not supported, an empty format selects the default output
+func Check(format string) error {
+	switch format {
+	case "", Sarif, JUnit:
+		return nil
+	}
+	return sanitizederror.New(fmt.Sprintf("unsupported output format %q, supported formats are %q and %q", format, Sarif, JUnit))
+}
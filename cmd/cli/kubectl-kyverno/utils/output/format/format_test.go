@@ -0,0 +1,14 @@
+package format
+
+import (
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+func TestCheck(t *testing.T) {
+	assert.NilError(t, Check(""))
+	assert.NilError(t, Check(Sarif))
+	assert.NilError(t, Check(JUnit))
+	assert.ErrorContains(t, Check("json"), `unsupported output format "json"`)
+}
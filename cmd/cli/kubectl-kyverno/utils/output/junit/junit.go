@@ -0,0 +1,109 @@
+package junit
+
+import (
+	"encoding/xml"
+	"io"
+
+	policyreportv1alpha2 "github.com/kyverno/kyverno/api/policyreport/v1alpha2"
+)
+
+// TestCase is a result reported as a JUnit test case
+type TestCase struct {
+	// Suite groups test cases, usually the policy name
+	Suite   string
+	Name    string
+	Message string
+	Status  policyreportv1alpha2.PolicyResult
+}
+
+type TestSuites struct {
+	XMLName  xml.Name    `xml:"testsuites"`
+	Name     string      `xml:"name,attr"`
+	Tests    int         `xml:"tests,attr"`
+	Failures int         `xml:"failures,attr"`
+	Errors   int         `xml:"errors,attr"`
+	Skipped  int         `xml:"skipped,attr"`
+	Suites   []TestSuite `xml:"testsuite"`
+}
+
+type TestSuite struct {
+	Name     string          `xml:"name,attr"`
+	Tests    int             `xml:"tests,attr"`
+	Failures int             `xml:"failures,attr"`
+	Errors   int             `xml:"errors,attr"`
+	Skipped  int             `xml:"skipped,attr"`
+	Cases    []TestCaseEntry `xml:"testcase"`
+}
+
+type TestCaseEntry struct {
+	Name      string   `xml:"name,attr"`
+	ClassName string   `xml:"classname,attr"`
+	Failure   *Problem `xml:"failure,omitempty"`
+	Error     *Problem `xml:"error,omitempty"`
+	Skipped   *Skipped `xml:"skipped,omitempty"`
+	SystemOut string   `xml:"system-out,omitempty"`
+}
+
+type Problem struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+}
+
+type Skipped struct {
+	Message string `xml:"message,attr,omitempty"`
+}
+
+// Build builds JUnit test suites from the test cases, test cases are grouped by suite in order of appearance
+func Build(name string, cases ...TestCase) TestSuites {
+	out := TestSuites{Name: name}
+	index := map[string]int{}
+	for _, c := range cases {
+		i, ok := index[c.Suite]
+		if !ok {
+			i = len(out.Suites)
+			index[c.Suite] = i
+			out.Suites = append(out.Suites, TestSuite{Name: c.Suite})
+		}
+		suite := &out.Suites[i]
+		entry := TestCaseEntry{
+			Name:      c.Name,
+			ClassName: c.Suite,
+		}
+		switch c.Status {
+		case policyreportv1alpha2.StatusFail:
+			entry.Failure = &Problem{Message: c.Message, Type: string(c.Status)}
+			suite.Failures++
+		case policyreportv1alpha2.StatusError:
+			entry.Error = &Problem{Message: c.Message, Type: string(c.Status)}
+			suite.Errors++
+		case policyreportv1alpha2.StatusSkip:
+			entry.Skipped = &Skipped{Message: c.Message}
+			suite.Skipped++
+		default:
+			entry.SystemOut = c.Message
+		}
+		suite.Tests++
+		suite.Cases = append(suite.Cases, entry)
+	}
+	for _, suite := range out.Suites {
+		out.Tests += suite.Tests
+		out.Failures += suite.Failures
+		out.Errors += suite.Errors
+		out.Skipped += suite.Skipped
+	}
+	return out
+}
+
+// Print writes the JUnit XML report built from the test cases
+func Print(w io.Writer, name string, cases ...TestCase) error {
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(Build(name, cases...)); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
@@ -0,0 +1,40 @@
+package junit
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	policyreportv1alpha2 "github.com/kyverno/kyverno/api/policyreport/v1alpha2"
+	"gotest.tools/assert"
+)
+
+func TestBuild(t *testing.T) {
+	suites := Build("kyverno apply",
+		TestCase{Suite: "require-labels", Name: "check-team Pod/default/nginx", Message: "label team is required", Status: policyreportv1alpha2.StatusFail},
+		TestCase{Suite: "disallow-latest", Name: "latest Pod/default/nginx", Status: policyreportv1alpha2.StatusPass},
+		TestCase{Suite: "require-labels", Name: "check-team Pod/default/httpd", Status: policyreportv1alpha2.StatusSkip},
+		TestCase{Suite: "require-labels", Name: "check-team Pod/default/redis", Message: "variable not found", Status: policyreportv1alpha2.StatusError},
+	)
+	assert.Equal(t, suites.Tests, 4)
+	assert.Equal(t, suites.Failures, 1)
+	assert.Equal(t, suites.Errors, 1)
+	assert.Equal(t, suites.Skipped, 1)
+	assert.Equal(t, len(suites.Suites), 2)
+	assert.Equal(t, suites.Suites[0].Name, "require-labels")
+	assert.Equal(t, suites.Suites[0].Tests, 3)
+	assert.Equal(t, suites.Suites[0].Cases[0].Failure.Message, "label team is required")
+	assert.Assert(t, suites.Suites[0].Cases[1].Skipped != nil)
+	assert.Equal(t, suites.Suites[0].Cases[2].Error.Message, "variable not found")
+	assert.Equal(t, suites.Suites[1].Name, "disallow-latest")
+	assert.Assert(t, suites.Suites[1].Cases[0].Failure == nil)
+}
+
+func TestPrint(t *testing.T) {
+	var buf bytes.Buffer
+	assert.NilError(t, Print(&buf, "kyverno test", TestCase{Suite: "policy", Name: "rule", Message: "failed", Status: policyreportv1alpha2.StatusFail}))
+	out := buf.String()
+	assert.Assert(t, strings.HasPrefix(out, "<?xml"), out)
+	assert.Assert(t, strings.Contains(out, `<testsuites name="kyverno test" tests="1" failures="1" errors="0" skipped="0">`), out)
+	assert.Assert(t, strings.Contains(out, `<failure message="failed" type="fail"></failure>`), out)
+}
@@ -0,0 +1,166 @@
+package sarif
+
+import (
+	"encoding/json"
+	"io"
+	"sort"
+
+	policyreportv1alpha2 "github.com/kyverno/kyverno/api/policyreport/v1alpha2"
+	"github.com/kyverno/kyverno/pkg/version"
+)
+
+const (
+	schema       = "https://json.schemastore.org/sarif-2.1.0.json"
+	sarifVersion = "2.1.0"
+	toolName     = "kyverno"
+	toolURI      = "https://kyverno.io"
+)
+
+// Result is a policy result to be reported
+type Result struct {
+	Policy   string
+	Rule     string
+	Message  string
+	Severity policyreportv1alpha2.PolicySeverity
+	Status   policyreportv1alpha2.PolicyResult
+	// Resource identifies the resource the result applies to (`kind/namespace/name`)
+	Resource string
+	// Location is the path of the file the resource was loaded from, if known
+	Location string
+}
+
+type Log struct {
+	Schema  string `json:"$schema"`
+	Version string `json:"version"`
+	Runs    []Run  `json:"runs"`
+}
+
+type Run struct {
+	Tool    Tool          `json:"tool"`
+	Results []ResultEntry `json:"results"`
+}
+
+type Tool struct {
+	Driver Driver `json:"driver"`
+}
+
+type Driver struct {
+	Name           string          `json:"name"`
+	Version        string          `json:"version,omitempty"`
+	InformationURI string          `json:"informationUri"`
+	Rules          []ReportingRule `json:"rules"`
+}
+
+type ReportingRule struct {
+	ID               string  `json:"id"`
+	ShortDescription Message `json:"shortDescription"`
+}
+
+type Message struct {
+	Text string `json:"text"`
+}
+
+type ResultEntry struct {
+	RuleID    string     `json:"ruleId"`
+	Level     string     `json:"level"`
+	Message   Message    `json:"message"`
+	Locations []Location `json:"locations,omitempty"`
+}
+
+type Location struct {
+	PhysicalLocation *PhysicalLocation `json:"physicalLocation,omitempty"`
+	LogicalLocations []LogicalLocation `json:"logicalLocations,omitempty"`
+}
+
+type PhysicalLocation struct {
+	ArtifactLocation ArtifactLocation `json:"artifactLocation"`
+}
+
+type ArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type LogicalLocation struct {
+	FullyQualifiedName string `json:"fullyQualifiedName"`
+	Kind               string `json:"kind"`
+}
+
+// Level returns the SARIF level of a result, failures are mapped according to the policy severity
+func Level(status policyreportv1alpha2.PolicyResult, severity policyreportv1alpha2.PolicySeverity) string {
+	switch status {
+	case policyreportv1alpha2.StatusError:
+		return "error"
+	case policyreportv1alpha2.StatusWarn:
+		return "warning"
+	case policyreportv1alpha2.StatusFail:
+		switch severity {
+		case policyreportv1alpha2.SeverityMedium:
+			return "warning"
+		case policyreportv1alpha2.SeverityLow, policyreportv1alpha2.SeverityInfo:
+			return "note"
+		default:
+			return "error"
+		}
+	}
+	return "none"
+}
+
+// Build builds a SARIF log from the failed, warned and errored results
+func Build(results ...Result) Log {
+	rules := map[string]ReportingRule{}
+	entries := []ResultEntry{}
+	for _, result := range results {
+		level := Level(result.Status, result.Severity)
+		if level == "none" {
+			continue
+		}
+		id := result.Policy
+		if result.Rule != "" {
+			id += "/" + result.Rule
+		}
+		rules[id] = ReportingRule{ID: id, ShortDescription: Message{Text: id}}
+		location := Location{}
+		if result.Location != "" {
+			location.PhysicalLocation = &PhysicalLocation{ArtifactLocation: ArtifactLocation{URI: result.Location}}
+		}
+		if result.Resource != "" {
+			location.LogicalLocations = []LogicalLocation{{FullyQualifiedName: result.Resource, Kind: "resource"}}
+		}
+		entry := ResultEntry{
+			RuleID:  id,
+			Level:   level,
+			Message: Message{Text: result.Message},
+		}
+		if location.PhysicalLocation != nil || len(location.LogicalLocations) != 0 {
+			entry.Locations = []Location{location}
+		}
+		entries = append(entries, entry)
+	}
+	driver := Driver{
+		Name:           toolName,
+		Version:        version.Version(),
+		InformationURI: toolURI,
+		Rules:          []ReportingRule{},
+	}
+	for _, rule := range rules {
+		driver.Rules = append(driver.Rules, rule)
+	}
+	sort.Slice(driver.Rules, func(i, j int) bool {
+		return driver.Rules[i].ID < driver.Rules[j].ID
+	})
+	return Log{
+		Schema:  schema,
+		Version: sarifVersion,
+		Runs: []Run{{
+			Tool:    Tool{Driver: driver},
+			Results: entries,
+		}},
+	}
+}
+
+// Print writes the SARIF log built from the results
+func Print(w io.Writer, results ...Result) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(Build(results...))
+}
@@ -0,0 +1,66 @@
+package sarif
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	policyreportv1alpha2 "github.com/kyverno/kyverno/api/policyreport/v1alpha2"
+	"gotest.tools/assert"
+)
+
+func TestLevel(t *testing.T) {
+	tests := []struct {
+		status   policyreportv1alpha2.PolicyResult
+		severity policyreportv1alpha2.PolicySeverity
+		want     string
+	}{
+		{policyreportv1alpha2.StatusPass, policyreportv1alpha2.SeverityHigh, "none"},
+		{policyreportv1alpha2.StatusSkip, "", "none"},
+		{policyreportv1alpha2.StatusError, "", "error"},
+		{policyreportv1alpha2.StatusWarn, policyreportv1alpha2.SeverityHigh, "warning"},
+		{policyreportv1alpha2.StatusFail, "", "error"},
+		{policyreportv1alpha2.StatusFail, policyreportv1alpha2.SeverityCritical, "error"},
+		{policyreportv1alpha2.StatusFail, policyreportv1alpha2.SeverityHigh, "error"},
+		{policyreportv1alpha2.StatusFail, policyreportv1alpha2.SeverityMedium, "warning"},
+		{policyreportv1alpha2.StatusFail, policyreportv1alpha2.SeverityLow, "note"},
+		{policyreportv1alpha2.StatusFail, policyreportv1alpha2.SeverityInfo, "note"},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.status)+"-"+string(tt.severity), func(t *testing.T) {
+			assert.Equal(t, Level(tt.status, tt.severity), tt.want)
+		})
+	}
+}
+
+func TestBuild(t *testing.T) {
+	log := Build(
+		Result{Policy: "require-labels", Rule: "check-team", Message: "label team is required", Status: policyreportv1alpha2.StatusFail, Resource: "Pod/default/nginx", Location: "resources.yaml"},
+		Result{Policy: "require-labels", Rule: "check-team", Status: policyreportv1alpha2.StatusPass, Resource: "Pod/default/httpd"},
+		Result{Policy: "disallow-latest", Rule: "latest", Message: "latest tag is not allowed", Status: policyreportv1alpha2.StatusFail, Severity: policyreportv1alpha2.SeverityLow},
+	)
+	assert.Equal(t, log.Version, "2.1.0")
+	assert.Equal(t, len(log.Runs), 1)
+	run := log.Runs[0]
+	assert.Equal(t, run.Tool.Driver.Name, "kyverno")
+	assert.Equal(t, len(run.Tool.Driver.Rules), 2)
+	assert.Equal(t, run.Tool.Driver.Rules[0].ID, "disallow-latest/latest")
+	assert.Equal(t, run.Tool.Driver.Rules[1].ID, "require-labels/check-team")
+	assert.Equal(t, len(run.Results), 2)
+	assert.Equal(t, run.Results[0].RuleID, "require-labels/check-team")
+	assert.Equal(t, run.Results[0].Level, "error")
+	assert.Equal(t, run.Results[0].Locations[0].PhysicalLocation.ArtifactLocation.URI, "resources.yaml")
+	assert.Equal(t, run.Results[0].Locations[0].LogicalLocations[0].FullyQualifiedName, "Pod/default/nginx")
+	assert.Equal(t, run.Results[1].Level, "note")
+	assert.Assert(t, run.Results[1].Locations == nil)
+}
+
+func TestPrint(t *testing.T) {
+	var buf bytes.Buffer
+	assert.NilError(t, Print(&buf))
+	var log map[string]interface{}
+	assert.NilError(t, json.Unmarshal(buf.Bytes(), &log))
+	assert.Equal(t, log["version"], "2.1.0")
+	runs := log["runs"].([]interface{})
+	assert.Equal(t, len(runs[0].(map[string]interface{})["results"].([]interface{})), 0)
+}
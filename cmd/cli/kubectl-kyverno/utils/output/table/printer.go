@@ -1,14 +1,14 @@
 package table
 
 import (
-	"os"
+	"io"
 
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/utils/color"
 	"github.com/lensesio/tableprinter"
 )
 
-func NewTablePrinter() *tableprinter.Printer {
-	printer := tableprinter.New(os.Stdout)
+func NewTablePrinter(out io.Writer) *tableprinter.Printer {
+	printer := tableprinter.New(out)
 	printer.BorderTop, printer.BorderBottom, printer.BorderLeft, printer.BorderRight = true, true, true, true
 	printer.CenterSeparator = "│"
 	printer.ColumnSeparator = "│"
@@ -53,7 +53,9 @@ func (w wrapper) Load(
 	contextEntries []kyvernov1.ContextEntry,
 	jsonContext enginecontext.Interface,
 ) error {
-	if !IsApiCallAllowed() {
+	if rawClient := GetRawClient(); rawClient != nil {
+		client = rawClient
+	} else if !IsApiCallAllowed() {
 		client = nil
 	}
 	if !GetRegistryAccess() {
@@ -0,0 +1,71 @@
+package store
+
+import (
+	"context"
+	"testing"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/config"
+	enginecontext "github.com/kyverno/kyverno/pkg/engine/context"
+	"github.com/kyverno/kyverno/pkg/engine/context/resolvers"
+	"github.com/kyverno/kyverno/pkg/engine/jmespath"
+	"gotest.tools/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func Test_ContextLoaderFactory_ConfigMap(t *testing.T) {
+	entries := []kyvernov1.ContextEntry{{
+		Name:      "cm",
+		ConfigMap: &kyvernov1.ConfigMapReference{Name: "settings", Namespace: "default"},
+	}}
+	policy := &kyvernov1.ClusterPolicy{ObjectMeta: metav1.ObjectMeta{Name: "policy"}}
+	rule := kyvernov1.Rule{Name: "rule", Context: entries}
+	cmResolver, err := resolvers.NewClientBasedResolver(fake.NewSimpleClientset(&corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "settings", Namespace: "default"},
+		Data:       map[string]string{"registry": "ghcr.io"},
+	}))
+	assert.NilError(t, err)
+	tests := []struct {
+		name       string
+		local      bool
+		cmResolver bool
+		want       interface{}
+	}{{
+		name:       "local with cluster context",
+		local:      true,
+		cmResolver: true,
+		want:       "ghcr.io",
+	}, {
+		name:  "local without cluster context",
+		local: true,
+		want:  nil,
+	}, {
+		name:       "not local",
+		cmResolver: true,
+		want:       "ghcr.io",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			SetLocal(tt.local)
+			defer SetLocal(false)
+			if tt.cmResolver {
+				SetConfigMapResolver(cmResolver)
+				defer SetConfigMapResolver(nil)
+			}
+			jp := jmespath.New(config.NewDefaultConfiguration(false))
+			jsonContext := enginecontext.NewContext(jp)
+			loader := ContextLoaderFactory(GetConfigMapResolver())(policy, rule)
+			assert.NilError(t, loader.Load(context.TODO(), jp, nil, nil, nil, entries, jsonContext))
+			got, err := jsonContext.Query("cm.data.registry")
+			if tt.want == nil {
+				// the context entry is skipped when configMaps are not resolved
+				assert.ErrorContains(t, err, `Unknown key "cm"`)
+			} else {
+				assert.NilError(t, err)
+				assert.Equal(t, got, tt.want)
+			}
+		})
+	}
+}
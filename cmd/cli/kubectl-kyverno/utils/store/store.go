@@ -1,6 +1,7 @@
 package store
 
 import (
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
 	"github.com/kyverno/kyverno/pkg/registryclient"
 )
 
@@ -23,6 +24,8 @@ var (
 	local          bool
 	registryClient registryclient.Client
 	allowApiCalls  bool
+	cmResolver     engineapi.ConfigmapResolver
+	rawClient      engineapi.RawClient
 	policies       []Policy
 	foreachElement int
 )
@@ -96,3 +99,23 @@ func AllowApiCall(allow bool) {
 func IsApiCallAllowed() bool {
 	return allowApiCalls
 }
+
+// SetConfigMapResolver sets the resolver used to load configMap context entries
+func SetConfigMapResolver(resolver engineapi.ConfigmapResolver) {
+	cmResolver = resolver
+}
+
+// GetConfigMapResolver returns the resolver used to load configMap context entries, nil if configMap context entries are not resolved
+func GetConfigMapResolver() engineapi.ConfigmapResolver {
+	return cmResolver
+}
+
+// SetRawClient sets the client used to resolve apiCall context entries instead of the cluster client
+func SetRawClient(client engineapi.RawClient) {
+	rawClient = client
+}
+
+// GetRawClient returns the client used to resolve apiCall context entries, nil if the cluster client is used
+func GetRawClient() engineapi.RawClient {
+	return rawClient
+}
@@ -16,6 +16,12 @@ func check(logger logr.Logger) {
 	checkEnvVar(logger, "METRICS_CONFIG")
 }
 
+func checkCertificates(config Configuration, logger logr.Logger) {
+	if config.UsesCertificates() {
+		checkError(logger, CertificateConfig().Validate(), "invalid certificates configuration")
+	}
+}
+
 func checkEnvVar(logger logr.Logger, name string) {
 	checkError(logger, validateEnvVar(name), "please define the environment variable", "name", name)
 }
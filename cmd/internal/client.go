@@ -91,3 +91,15 @@ func CreateAggregatorClient(logger logr.Logger, opts ...agg.NewOption) aggregato
 	checkError(logger, err, "failed to create aggregator client")
 	return client
 }
+
+func CreateImpersonatingClientFactory(logger logr.Logger, client dclient.Interface) dclient.ImpersonatingClientFactory {
+	logger = logger.WithName("impersonating-client-factory")
+	logger.Info("create impersonating client factory...", "kubeconfig", kubeconfig, "qps", clientRateLimitQPS, "burst", clientRateLimitBurst)
+	return dclient.NewImpersonatingClientFactory(createClientConfig(logger), client)
+}
+
+func CreateRemoteClientFactory(ctx context.Context, logger logr.Logger, client dclient.Interface, resync time.Duration) dclient.RemoteClientFactory {
+	logger = logger.WithName("remote-client-factory")
+	logger.Info("create remote client factory...", "qps", clientRateLimitQPS, "burst", clientRateLimitBurst)
+	return dclient.NewRemoteClientFactory(ctx, client.GetKubeClient(), float32(clientRateLimitQPS), clientRateLimitBurst, resync)
+}
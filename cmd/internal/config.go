@@ -11,11 +11,16 @@ type Configuration interface {
 	UsesKubeconfig() bool
 	UsesPolicyExceptions() bool
 	UsesConfigMapCaching() bool
+	UsesPodCaching() bool
 	UsesDeferredLoading() bool
+	UsesCircuitBreaker() bool
+	UsesImageDigestCache() bool
 	UsesCosign() bool
 	UsesRegistryClient() bool
 	UsesImageVerifyCache() bool
+	UsesEvents() bool
 	UsesLeaderElection() bool
+	UsesCertificates() bool
 	UsesKyvernoClient() bool
 	UsesDynamicClient() bool
 	UsesApiServerClient() bool
@@ -70,12 +75,30 @@ func WithConfigMapCaching() ConfigurationOption {
 	}
 }
 
+func WithPodCaching() ConfigurationOption {
+	return func(c *configuration) {
+		c.usesPodCaching = true
+	}
+}
+
 func WithDeferredLoading() ConfigurationOption {
 	return func(c *configuration) {
 		c.usesDeferredLoading = true
 	}
 }
 
+func WithCircuitBreaker() ConfigurationOption {
+	return func(c *configuration) {
+		c.usesCircuitBreaker = true
+	}
+}
+
+func WithImageDigestCache() ConfigurationOption {
+	return func(c *configuration) {
+		c.usesImageDigestCache = true
+	}
+}
+
 func WithCosign() ConfigurationOption {
 	return func(c *configuration) {
 		c.usesCosign = true
@@ -94,12 +117,24 @@ func WithImageVerifyCache() ConfigurationOption {
 	}
 }
 
+func WithEvents() ConfigurationOption {
+	return func(c *configuration) {
+		c.usesEvents = true
+	}
+}
+
 func WithLeaderElection() ConfigurationOption {
 	return func(c *configuration) {
 		c.usesLeaderElection = true
 	}
 }
 
+func WithCertificates() ConfigurationOption {
+	return func(c *configuration) {
+		c.usesCertificates = true
+	}
+}
+
 func WithKyvernoClient() ConfigurationOption {
 	return func(c *configuration) {
 		c.usesKyvernoClient = true
@@ -145,11 +180,16 @@ type configuration struct {
 	usesKubeconfig           bool
 	usesPolicyExceptions     bool
 	usesConfigMapCaching     bool
+	usesPodCaching           bool
 	usesDeferredLoading      bool
+	usesCircuitBreaker       bool
+	usesImageDigestCache     bool
 	usesCosign               bool
 	usesRegistryClient       bool
 	usesImageVerifyCache     bool
+	usesEvents               bool
 	usesLeaderElection       bool
+	usesCertificates         bool
 	usesKyvernoClient        bool
 	usesDynamicClient        bool
 	usesApiServerClient      bool
@@ -182,10 +222,22 @@ func (c *configuration) UsesConfigMapCaching() bool {
 	return c.usesConfigMapCaching
 }
 
+func (c *configuration) UsesPodCaching() bool {
+	return c.usesPodCaching
+}
+
 func (c *configuration) UsesDeferredLoading() bool {
 	return c.usesDeferredLoading
 }
 
+func (c *configuration) UsesCircuitBreaker() bool {
+	return c.usesCircuitBreaker
+}
+
+func (c *configuration) UsesImageDigestCache() bool {
+	return c.usesImageDigestCache
+}
+
 func (c *configuration) UsesCosign() bool {
 	return c.usesCosign
 }
@@ -198,10 +250,18 @@ func (c *configuration) UsesImageVerifyCache() bool {
 	return c.usesImageVerifyCache
 }
 
+func (c *configuration) UsesEvents() bool {
+	return c.usesEvents
+}
+
 func (c *configuration) UsesLeaderElection() bool {
 	return c.usesLeaderElection
 }
 
+func (c *configuration) UsesCertificates() bool {
+	return c.usesCertificates
+}
+
 func (c *configuration) UsesKyvernoClient() bool {
 	return c.usesKyvernoClient
 }
@@ -30,6 +30,19 @@ func startConfigController(ctx context.Context, logger logr.Logger, client kuber
 	)
 	checkError(logger, configurationController.WarmUp(ctx), "failed to init config controller")
 	go configurationController.Run(ctx, 1)
+	globalValuesController := genericconfigmapcontroller.NewController(
+		"global-values-controller",
+		client,
+		resyncPeriod,
+		config.KyvernoNamespace(),
+		config.KyvernoGlobalValuesConfigMapName(),
+		func(ctx context.Context, cm *corev1.ConfigMap) error {
+			configuration.LoadGlobalValues(cm)
+			return nil
+		},
+	)
+	checkError(logger, globalValuesController.WarmUp(ctx), "failed to init global values controller")
+	go globalValuesController.Run(ctx, 1)
 	return configuration
 }
 
@@ -0,0 +1,14 @@
+package internal
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	"github.com/kyverno/kyverno/pkg/cosign"
+)
+
+func setupSigstoreTUF(ctx context.Context, logger logr.Logger) {
+	logger = logger.WithName("sigstore-tuf").WithValues("mirror", tufMirror, "root", tufRoot, "offline", sigstoreOffline)
+	logger.Info("setup sigstore TUF client...")
+	checkError(logger, cosign.InitializeTUF(ctx, tufMirror, tufRoot, sigstoreOffline), "failed to initialize sigstore TUF client")
+}
@@ -13,11 +13,14 @@ import (
 	"github.com/kyverno/kyverno/pkg/engine"
 	"github.com/kyverno/kyverno/pkg/engine/adapters"
 	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	"github.com/kyverno/kyverno/pkg/engine/circuitbreaker"
 	"github.com/kyverno/kyverno/pkg/engine/context/resolvers"
+	"github.com/kyverno/kyverno/pkg/engine/digestcache"
 	"github.com/kyverno/kyverno/pkg/engine/factories"
 	"github.com/kyverno/kyverno/pkg/engine/jmespath"
 	"github.com/kyverno/kyverno/pkg/imageverifycache"
 	"github.com/kyverno/kyverno/pkg/registryclient"
+	kubeinformers "k8s.io/client-go/informers"
 	"k8s.io/client-go/kubernetes"
 	corev1listers "k8s.io/client-go/listers/core/v1"
 )
@@ -36,7 +39,12 @@ func NewEngine(
 	secretLister corev1listers.SecretNamespaceLister,
 ) engineapi.Engine {
 	configMapResolver := NewConfigMapResolver(ctx, logger, kubeClient, 15*time.Minute)
+	podLister := NewPodLister(ctx, logger, kubeClient, 15*time.Minute)
 	exceptionsSelector := NewExceptionSelector(ctx, logger, kyvernoClient, 15*time.Minute)
+	schemaResolver := NewSchemaResolver(logger, client, time.Minute)
+	paramsResolver := NewParamsResolver(ctx, logger, client, 15*time.Minute)
+	circuitBreaker := NewCircuitBreaker(logger)
+	digestCache := NewImageDigestCache(logger)
 	logger = logger.WithName("engine")
 	logger.Info("setup engine...")
 	return engine.NewEngine(
@@ -46,8 +54,15 @@ func NewEngine(
 		adapters.Client(client),
 		factories.DefaultRegistryClientFactory(adapters.RegistryClient(rclient), secretLister),
 		ivCache,
-		factories.DefaultContextLoaderFactory(configMapResolver),
+		factories.DefaultContextLoaderFactory(
+			configMapResolver,
+			factories.WithPodLister(podLister),
+			factories.WithCircuitBreaker(circuitBreaker),
+			factories.WithImageDigestCache(digestCache),
+		),
 		exceptionsSelector,
+		schemaResolver,
+		paramsResolver,
 		imageSignatureRepository,
 	)
 }
@@ -77,6 +92,31 @@ func NewExceptionSelector(
 	return exceptionsLister
 }
 
+func NewSchemaResolver(
+	logger logr.Logger,
+	client dclient.Interface,
+	ttl time.Duration,
+) engineapi.SchemaResolver {
+	logger = logger.WithName("schema-resolver")
+	logger.Info("setup schema resolver...")
+	schemaResolver, err := resolvers.NewClientBasedSchemaResolver(client, ttl)
+	checkError(logger, err, "failed to create client based schema resolver")
+	return schemaResolver
+}
+
+func NewParamsResolver(
+	ctx context.Context,
+	logger logr.Logger,
+	client dclient.Interface,
+	resyncPeriod time.Duration,
+) engineapi.ParamsResolver {
+	logger = logger.WithName("params-resolver")
+	logger.Info("setup params resolver...")
+	paramsResolver, err := resolvers.NewInformerBasedParamsResolver(ctx, client, resyncPeriod)
+	checkError(logger, err, "failed to create informer based params resolver")
+	return paramsResolver
+}
+
 func NewConfigMapResolver(
 	ctx context.Context,
 	logger logr.Logger,
@@ -102,3 +142,38 @@ func NewConfigMapResolver(
 	}
 	return configMapResolver
 }
+
+func NewPodLister(
+	ctx context.Context,
+	logger logr.Logger,
+	kubeClient kubernetes.Interface,
+	resyncPeriod time.Duration,
+) engineapi.PodLister {
+	logger = logger.WithName("pod-lister").WithValues("enablePodCaching", enablePodCaching)
+	logger.Info("setup pod lister...")
+	if !enablePodCaching {
+		clientBasedLister, err := resolvers.NewClientBasedPodLister(kubeClient)
+		checkError(logger, err, "failed to create client based pod lister")
+		return clientBasedLister
+	}
+	factory := kubeinformers.NewSharedInformerFactory(kubeClient, resyncPeriod)
+	informerBasedLister, err := resolvers.NewInformerBasedPodLister(factory.Core().V1().Pods().Lister())
+	checkError(logger, err, "failed to create informer based pod lister")
+	// start informers and wait for cache sync
+	if !StartInformersAndWaitForCacheSync(ctx, logger, factory) {
+		checkError(logger, errors.New("failed to wait for cache sync"), "failed to wait for cache sync")
+	}
+	return informerBasedLister
+}
+
+func NewCircuitBreaker(logger logr.Logger) circuitbreaker.CircuitBreaker {
+	logger = logger.WithName("circuit-breaker").WithValues("failureThreshold", circuitBreakerFailureThreshold, "openDuration", circuitBreakerOpenDuration)
+	logger.Info("setup circuit breaker...")
+	return circuitbreaker.New(circuitBreakerFailureThreshold, circuitBreakerOpenDuration)
+}
+
+func NewImageDigestCache(logger logr.Logger) digestcache.Cache {
+	logger = logger.WithName("image-digest-cache").WithValues("ttl", imageDigestCacheTTL, "maxSize", imageDigestCacheMaxSize)
+	logger.Info("setup image digest cache...")
+	return digestcache.New(imageDigestCacheTTL, imageDigestCacheMaxSize)
+}
@@ -0,0 +1,46 @@
+package internal
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+
+	"github.com/go-logr/logr"
+	osutils "github.com/kyverno/kyverno/pkg/utils/os"
+	"github.com/sigstore/sigstore/pkg/tuf"
+)
+
+// setupFilesystem validates that the directories kyverno writes to are usable, kyverno can run
+// with a read-only root filesystem as long as the temporary directory is writable
+func setupFilesystem(config Configuration, logger logr.Logger) {
+	logger = logger.WithName("filesystem").WithValues("os", runtime.GOOS, "arch", runtime.GOARCH)
+	logger.Info("setup filesystem...")
+	if tempDir != "" {
+		// libraries create their temporary files in os.TempDir(), TMP and TEMP are used on windows
+		for _, name := range []string{"TMPDIR", "TMP", "TEMP"} {
+			checkError(logger, os.Setenv(name, tempDir), "failed to set temporary directory", "name", name)
+		}
+		checkError(logger, osutils.CheckWritableDir(tempDir), "temporary directory is not writable", "path", tempDir)
+	} else if err := osutils.CheckWritableDir(os.TempDir()); err != nil {
+		logger.Error(err, "temporary directory is not writable, mount a writable volume and configure it with --tempDir", "path", os.TempDir())
+	}
+	if config.UsesCosign() && os.Getenv(tuf.SigstoreNoCache) == "" {
+		dir := sigstoreCacheDir()
+		if err := osutils.CheckWritableDir(dir); err != nil {
+			logger.Info("sigstore TUF cache directory is not writable, trust roots are kept in memory", "path", dir, "error", err.Error())
+			checkError(logger, os.Setenv(tuf.SigstoreNoCache, "true"), "failed to disable sigstore TUF cache")
+		}
+	}
+}
+
+// sigstoreCacheDir returns the directory where the sigstore library caches the TUF metadata
+func sigstoreCacheDir() string {
+	if dir := os.Getenv(tuf.TufRootEnv); dir != "" {
+		return dir
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		home = ""
+	}
+	return filepath.Join(home, ".sigstore", "root")
+}
@@ -5,14 +5,19 @@ import (
 	"time"
 
 	"github.com/go-logr/logr"
+	"github.com/kyverno/kyverno/pkg/config"
+	"github.com/kyverno/kyverno/pkg/event"
 	"github.com/kyverno/kyverno/pkg/leaderelection"
 	"github.com/kyverno/kyverno/pkg/logging"
+	"github.com/kyverno/kyverno/pkg/tls"
 	"github.com/kyverno/kyverno/pkg/toggle"
 )
 
 var (
 	// logging
 	loggingFormat string
+	// filesystem
+	tempDir string
 	// profiling
 	profilingEnabled bool
 	profilingAddress string
@@ -36,18 +41,42 @@ var (
 	enablePolicyException  bool
 	exceptionNamespace     string
 	enableConfigMapCaching bool
+	enablePodCaching       bool
+	// circuit breaker
+	circuitBreakerFailureThreshold int
+	circuitBreakerOpenDuration     time.Duration
+	// image digest cache
+	imageDigestCacheTTL     time.Duration
+	imageDigestCacheMaxSize int
 	// cosign
 	imageSignatureRepository string
+	tufMirror                string
+	tufRoot                  string
+	sigstoreOffline          bool
 	// registry client
 	imagePullSecrets          string
 	allowInsecureRegistry     bool
 	registryCredentialHelpers string
 	// leader election
-	leaderElectionRetryPeriod time.Duration
+	leaderElectionLeaseDuration time.Duration
+	leaderElectionRenewDeadline time.Duration
+	leaderElectionRetryPeriod   time.Duration
+	leaderElectionNamespace     string
+	leaderElectionName          string
 	// image verify cache
 	imageVerifyCacheEnabled     bool
 	imageVerifyCacheTTLDuration int64
 	imageVerifyCacheMaxSize     int64
+	// events
+	eventsVerbosity         = event.VerbosityAll
+	eventsAggregationWindow time.Duration
+	eventsRateLimitQPS      float64
+	eventsRateLimitBurst    int
+	// certificates
+	caValidityDuration  time.Duration
+	tlsValidityDuration time.Duration
+	certRenewBefore     time.Duration
+	certKeyAlgorithm    = tls.KeyAlgorithmRSA
 )
 
 func initLoggingFlags() {
@@ -56,6 +85,10 @@ func initLoggingFlags() {
 	checkErr(flag.Set("v", "2"), "failed to init flags")
 }
 
+func initFilesystemFlags() {
+	flag.StringVar(&tempDir, "tempDir", "", "Writable directory used for temporary files, defaults to the system temporary directory. Configure it when running with a read-only root filesystem.")
+}
+
 func initProfilingFlags() {
 	flag.BoolVar(&profilingEnabled, "profile", false, "Set this flag to 'true', to enable profiling.")
 	flag.StringVar(&profilingPort, "profilePort", "6060", "Profiling server port, defaults to '6060'.")
@@ -92,12 +125,29 @@ func initConfigMapCachingFlags() {
 	flag.BoolVar(&enableConfigMapCaching, "enableConfigMapCaching", true, "Enable config maps caching.")
 }
 
+func initPodCachingFlags() {
+	flag.BoolVar(&enablePodCaching, "enablePodCaching", false, "Enable pods caching, used to compute the resource usage of namespaces from an informer cache.")
+}
+
 func initDeferredLoadingFlags() {
 	flag.Func(toggle.EnableDeferredLoadingFlagName, toggle.EnableDeferredLoadingDescription, toggle.EnableDeferredLoading.Parse)
 }
 
+func initCircuitBreakerFlags() {
+	flag.IntVar(&circuitBreakerFailureThreshold, "contextCircuitBreakerFailureThreshold", 0, "Number of consecutive failures after which apiCall, imageRegistry and imageDigest context entries stop calling a destination, 0 disables the circuit breaker.")
+	flag.DurationVar(&circuitBreakerOpenDuration, "contextCircuitBreakerOpenDuration", 30*time.Second, "Duration during which calls to a failing destination are rejected before it is probed again.")
+}
+
+func initImageDigestCacheFlags() {
+	flag.DurationVar(&imageDigestCacheTTL, "imageDigestCacheTTL", 5*time.Minute, "Duration during which the digests resolved by imageDigest context entries are cached, 0 disables the cache.")
+	flag.IntVar(&imageDigestCacheMaxSize, "imageDigestCacheMaxSize", 1000, "Max number of digests in the image digest cache, 0 means no size limit.")
+}
+
 func initCosignFlags() {
 	flag.StringVar(&imageSignatureRepository, "imageSignatureRepository", "", "(DEPRECATED, will be removed in 1.12) Alternate repository for image signatures. Can be overridden per rule via `verifyImages.Repository`.")
+	flag.StringVar(&tufMirror, "tufMirror", "", "Alternate TUF mirror used to fetch the sigstore trust roots, defaults to the public good TUF repository.")
+	flag.StringVar(&tufRoot, "tufRoot", "", "Path or URL of the trusted TUF root.json used to fetch the sigstore trust roots, defaults to the root embedded in Kyverno.")
+	flag.BoolVar(&sigstoreOffline, "sigstoreOffline", false, "Set this flag to 'true' to never contact the public good sigstore instances, requires a TUF mirror and private Rekor instances (or ignoring the transparency log).")
 }
 
 func initRegistryClientFlags() {
@@ -112,8 +162,40 @@ func initImageVerifyCacheFlags() {
 	flag.Int64Var(&imageVerifyCacheTTLDuration, "imageVerifyCacheTTLDuration", 0, "Max TTL value for a cache, 0 means no TTL.")
 }
 
+func initEventsFlags() {
+	flag.Func("eventsVerbosity", "Set this flag to 'all', 'warning' (only policy violations and errors) or 'none' to control which events are emitted, defaults to 'all'.", func(value string) error {
+		verbosity, err := event.ParseVerbosity(value)
+		if err != nil {
+			return err
+		}
+		eventsVerbosity = verbosity
+		return nil
+	})
+	flag.DurationVar(&eventsAggregationWindow, "eventsAggregationWindow", 0, "Period during which duplicate events (same policy, rule and resource) are aggregated into a single event, 0 disables aggregation.")
+	flag.Float64Var(&eventsRateLimitQPS, "eventsRateLimitQPS", 0, "Maximum number of events per second sent to the API server, 0 disables rate limiting.")
+	flag.IntVar(&eventsRateLimitBurst, "eventsRateLimitBurst", 10, "Maximum burst of events sent to the API server when rate limiting is enabled.")
+}
+
 func initLeaderElectionFlags() {
+	flag.DurationVar(&leaderElectionLeaseDuration, "leaderElectionLeaseDuration", 0, "Configure leader election lease duration, defaults to 6 times the retry period.")
+	flag.DurationVar(&leaderElectionRenewDeadline, "leaderElectionRenewDeadline", 0, "Configure leader election renew deadline, defaults to 5 times the retry period.")
 	flag.DurationVar(&leaderElectionRetryPeriod, "leaderElectionRetryPeriod", leaderelection.DefaultRetryPeriod, "Configure leader election retry period.")
+	flag.StringVar(&leaderElectionNamespace, "leaderElectionNamespace", "", "Configure the namespace of the leader election lease, defaults to the Kyverno namespace.")
+	flag.StringVar(&leaderElectionName, "leaderElectionName", "", "Configure the name of the leader election lease, defaults to the controller name.")
+}
+
+func initCertificatesFlags() {
+	flag.DurationVar(&caValidityDuration, "caValidityDuration", tls.CAValidityDuration, "Configure the validity duration of the self-signed CA certificate.")
+	flag.DurationVar(&tlsValidityDuration, "tlsValidityDuration", tls.TLSValidityDuration, "Configure the validity duration of the TLS certificate.")
+	flag.DurationVar(&certRenewBefore, "certRenewBefore", tls.RenewBefore, "Configure how long before expiry the self-signed certificates are renewed.")
+	flag.Func("certKeyAlgorithm", "Set this flag to 'RSA' or 'ECDSA' (P-256) to configure the algorithm of generated private keys, defaults to 'RSA'.", func(value string) error {
+		algorithm, err := tls.ParseKeyAlgorithm(value)
+		if err != nil {
+			return err
+		}
+		certKeyAlgorithm = algorithm
+		return nil
+	})
 }
 
 type options struct {
@@ -151,6 +233,8 @@ func initFlags(config Configuration, opts ...Option) {
 	}
 	// logging
 	initLoggingFlags()
+	// filesystem
+	initFilesystemFlags()
 	// profiling
 	if config.UsesProfiling() {
 		initProfilingFlags()
@@ -175,10 +259,22 @@ func initFlags(config Configuration, opts ...Option) {
 	if config.UsesConfigMapCaching() {
 		initConfigMapCachingFlags()
 	}
+	// pod caching
+	if config.UsesPodCaching() {
+		initPodCachingFlags()
+	}
 	// deferred loading
 	if config.UsesDeferredLoading() {
 		initDeferredLoadingFlags()
 	}
+	// circuit breaker
+	if config.UsesCircuitBreaker() {
+		initCircuitBreakerFlags()
+	}
+	// image digest cache
+	if config.UsesImageDigestCache() {
+		initImageDigestCacheFlags()
+	}
 	// cosign
 	if config.UsesCosign() {
 		initCosignFlags()
@@ -191,10 +287,18 @@ func initFlags(config Configuration, opts ...Option) {
 	if config.UsesImageVerifyCache() {
 		initImageVerifyCacheFlags()
 	}
+	// events
+	if config.UsesEvents() {
+		initEventsFlags()
+	}
 	// leader election
 	if config.UsesLeaderElection() {
 		initLeaderElectionFlags()
 	}
+	// certificates
+	if config.UsesCertificates() {
+		initCertificatesFlags()
+	}
 	for _, flagset := range config.FlagSets() {
 		flagset.VisitAll(func(f *flag.Flag) {
 			flag.CommandLine.Var(f.Value, f.Name, f.Usage)
@@ -223,8 +327,44 @@ func PolicyExceptionEnabled() bool {
 	return enablePolicyException
 }
 
-func LeaderElectionRetryPeriod() time.Duration {
-	return leaderElectionRetryPeriod
+func EventsThrottleConfig() event.ThrottleConfig {
+	return event.ThrottleConfig{
+		Verbosity:         eventsVerbosity,
+		AggregationWindow: eventsAggregationWindow,
+		QPS:               float32(eventsRateLimitQPS),
+		Burst:             eventsRateLimitBurst,
+	}
+}
+
+func LeaderElectionConfig() leaderelection.Config {
+	return leaderelection.Config{
+		LeaseDuration: leaderElectionLeaseDuration,
+		RenewDeadline: leaderElectionRenewDeadline,
+		RetryPeriod:   leaderElectionRetryPeriod,
+	}
+}
+
+func LeaderElectionNamespace() string {
+	if leaderElectionNamespace != "" {
+		return leaderElectionNamespace
+	}
+	return config.KyvernoNamespace()
+}
+
+func LeaderElectionName(defaultName string) string {
+	if leaderElectionName != "" {
+		return leaderElectionName
+	}
+	return defaultName
+}
+
+func CertificateConfig() tls.CertificateConfig {
+	return tls.CertificateConfig{
+		CAValidityDuration:  caValidityDuration,
+		TLSValidityDuration: tlsValidityDuration,
+		RenewBefore:         certRenewBefore,
+		KeyAlgorithm:        certKeyAlgorithm,
+	}
 }
 
 func printFlagSettings(logger logr.Logger) {
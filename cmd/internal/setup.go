@@ -54,6 +54,8 @@ func Setup(config Configuration, name string, skipResourceFilters bool) (context
 	printFlagSettings(logger)
 	showWarnings(config, logger)
 	check(logger)
+	checkCertificates(config, logger)
+	setupFilesystem(config, logger)
 	sdownMaxProcs := setupMaxProcs(logger)
 	setupProfiling(logger)
 	ctx, sdownSignals := setupSignals(logger)
@@ -63,6 +65,9 @@ func Setup(config Configuration, name string, skipResourceFilters bool) (context
 	client = client.WithMetrics(metricsManager, metrics.KubeClient)
 	configuration := startConfigController(ctx, logger, client, skipResourceFilters)
 	sdownTracing := SetupTracing(logger, name, client)
+	if config.UsesCosign() {
+		setupSigstoreTUF(ctx, logger)
+	}
 	var registryClient registryclient.Client
 	var registrySecretLister corev1listers.SecretNamespaceLister
 	if config.UsesRegistryClient() {
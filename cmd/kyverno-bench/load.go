@@ -0,0 +1,124 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// loadOptions configures the traffic sent to a target
+type loadOptions struct {
+	qps         int
+	duration    time.Duration
+	concurrency int
+}
+
+// newAdmissionReviews builds the synthetic admission reviews replayed against the targets, one per resource
+func newAdmissionReviews(resources []*unstructured.Unstructured, operation admissionv1.Operation) ([][]byte, error) {
+	var reviews [][]byte
+	for i, resource := range resources {
+		raw, err := resource.MarshalJSON()
+		if err != nil {
+			return nil, err
+		}
+		gvk := resource.GroupVersionKind()
+		gvr, _ := meta.UnsafeGuessKindToResource(gvk)
+		request := &admissionv1.AdmissionRequest{
+			UID:       types.UID(fmt.Sprintf("kyverno-bench-%d", i)),
+			Kind:      metav1.GroupVersionKind{Group: gvk.Group, Version: gvk.Version, Kind: gvk.Kind},
+			Resource:  metav1.GroupVersionResource{Group: gvr.Group, Version: gvr.Version, Resource: gvr.Resource},
+			Name:      resource.GetName(),
+			Namespace: resource.GetNamespace(),
+			Operation: operation,
+			UserInfo: authenticationv1.UserInfo{
+				Username: "kyverno-bench",
+				Groups:   []string{"system:authenticated"},
+			},
+		}
+		requestKind, requestResource := request.Kind, request.Resource
+		request.RequestKind, request.RequestResource = &requestKind, &requestResource
+		switch operation {
+		case admissionv1.Delete:
+			request.OldObject = runtime.RawExtension{Raw: raw}
+		case admissionv1.Update:
+			request.Object = runtime.RawExtension{Raw: raw}
+			request.OldObject = runtime.RawExtension{Raw: raw}
+		default:
+			request.Object = runtime.RawExtension{Raw: raw}
+		}
+		review, err := json.Marshal(admissionv1.AdmissionReview{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: admissionv1.SchemeGroupVersion.String(),
+				Kind:       "AdmissionReview",
+			},
+			Request: request,
+		})
+		if err != nil {
+			return nil, err
+		}
+		reviews = append(reviews, review)
+	}
+	return reviews, nil
+}
+
+// run sends the reviews round robin at a constant rate for the configured duration,
+// requests that can't be sent because all workers are busy are counted as skipped
+func run(ctx context.Context, target target, policySet string, reviews [][]byte, options loadOptions) result {
+	ctx, cancel := context.WithTimeout(ctx, options.duration)
+	defer cancel()
+	var lock sync.Mutex
+	var latencies []time.Duration
+	var errors, denied, skipped int
+	jobs := make(chan []byte, options.concurrency)
+	var wg sync.WaitGroup
+	for i := 0; i < options.concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for review := range jobs {
+				start := time.Now()
+				allowed, err := target.Send(context.Background(), review)
+				latency := time.Since(start)
+				lock.Lock()
+				if err != nil {
+					errors++
+				} else {
+					latencies = append(latencies, latency)
+					if !allowed {
+						denied++
+					}
+				}
+				lock.Unlock()
+			}
+		}()
+	}
+	start := time.Now()
+	ticker := time.NewTicker(time.Second / time.Duration(options.qps))
+	defer ticker.Stop()
+	for i := 0; ; i++ {
+		select {
+		case <-ctx.Done():
+			close(jobs)
+			wg.Wait()
+			return newResult(policySet, latencies, errors, denied, skipped, time.Since(start))
+		case <-ticker.C:
+			select {
+			case jobs <- reviews[i%len(reviews)]:
+			default:
+				lock.Lock()
+				skipped++
+				lock.Unlock()
+			}
+		}
+	}
+}
@@ -0,0 +1,210 @@
+/*
+Replays synthetic admission reviews at a constant rate against a running kyverno server
+or the in-process admission handler chain and reports latency percentiles per policy set
+*/
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/go-logr/logr"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kubeutils "github.com/kyverno/kyverno/pkg/utils/kube"
+	yamlutils "github.com/kyverno/kyverno/pkg/utils/yaml"
+	admissionv1 "k8s.io/api/admission/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
+)
+
+// defaultResource is replayed when no resources are given
+const defaultResource = `
+apiVersion: v1
+kind: Pod
+metadata:
+  name: kyverno-bench
+  namespace: default
+  labels:
+    app: kyverno-bench
+spec:
+  containers:
+  - name: nginx
+    image: nginx:latest
+`
+
+func main() {
+	var (
+		policySets    []string
+		resourcePaths []string
+		targetURL     string
+		webhook       string
+		operation     string
+		output        string
+		insecure      bool
+		timeout       time.Duration
+		options       loadOptions
+	)
+	flagset := flag.NewFlagSet("kyverno-bench", flag.ExitOnError)
+	flagset.Func("policies", "Path to a policy file or folder making a policy set, can be repeated to compare policy sets (in-process mode only).", func(value string) error {
+		policySets = append(policySets, value)
+		return nil
+	})
+	flagset.Func("resources", "Path to a resource file or folder replayed in admission reviews, can be repeated. A pod is replayed by default.", func(value string) error {
+		resourcePaths = append(resourcePaths, value)
+		return nil
+	})
+	flagset.StringVar(&targetURL, "target", "", "URL of a running kyverno webhook (e.g. https://localhost:9443/validate), the in-process handler chain is used when empty.")
+	flagset.StringVar(&webhook, "webhook", "validate", "Webhook invoked in-process, validate or mutate.")
+	flagset.StringVar(&operation, "operation", string(admissionv1.Create), "Admission operation of the replayed requests.")
+	flagset.IntVar(&options.qps, "qps", 50, "Number of admission reviews sent per second.")
+	flagset.DurationVar(&options.duration, "duration", 30*time.Second, "Duration of the run for each policy set.")
+	flagset.IntVar(&options.concurrency, "concurrency", 10, "Maximum number of in flight admission reviews.")
+	flagset.DurationVar(&timeout, "timeout", 10*time.Second, "Timeout of a request sent to the target.")
+	flagset.BoolVar(&insecure, "insecure", false, "Skip the verification of the target certificate.")
+	flagset.StringVar(&output, "output", "text", "Output format, text or json.")
+	if err := flagset.Parse(os.Args[1:]); err != nil {
+		exit(err)
+	}
+	if options.qps <= 0 || options.concurrency <= 0 || options.duration <= 0 {
+		exit(fmt.Errorf("qps, concurrency and duration must be positive"))
+	}
+	if webhook != "validate" && webhook != "mutate" {
+		exit(fmt.Errorf("unsupported webhook %s, use validate or mutate", webhook))
+	}
+	if output != "text" && output != "json" {
+		exit(fmt.Errorf("unsupported output %s, use text or json", output))
+	}
+	if targetURL != "" && len(policySets) != 0 {
+		exit(fmt.Errorf("policy sets can only be used in-process, the policies of a target are the ones installed in its cluster"))
+	}
+	resources, err := loadResources(resourcePaths...)
+	if err != nil {
+		exit(err)
+	}
+	reviews, err := newAdmissionReviews(resources, admissionv1.Operation(strings.ToUpper(operation)))
+	if err != nil {
+		exit(err)
+	}
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+	var results []result
+	if targetURL != "" {
+		results = append(results, run(ctx, newHttpTarget(targetURL, insecure, timeout), targetURL, reviews, options))
+	} else {
+		for _, policySet := range policySets {
+			policies, err := loadPolicies(policySet)
+			if err != nil {
+				exit(err)
+			}
+			target, err := newInProcessTarget(ctx, logr.Discard(), webhook, policies, resources)
+			if err != nil {
+				exit(err)
+			}
+			results = append(results, run(ctx, target, policySet, reviews, options))
+		}
+		if len(policySets) == 0 {
+			exit(fmt.Errorf("at least one policy set or a target is required"))
+		}
+	}
+	if err := printResults(os.Stdout, output, results...); err != nil {
+		exit(err)
+	}
+}
+
+func exit(err error) {
+	fmt.Fprintln(os.Stderr, "error:", err)
+	os.Exit(1)
+}
+
+// readDocuments returns the yaml documents of a file or of the yaml and json files of a folder
+func readDocuments(path string) ([][]byte, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	files := []string{path}
+	if info.IsDir() {
+		files = nil
+		entries, err := os.ReadDir(path)
+		if err != nil {
+			return nil, err
+		}
+		for _, entry := range entries {
+			switch filepath.Ext(entry.Name()) {
+			case ".yaml", ".yml", ".json":
+				if !entry.IsDir() {
+					files = append(files, filepath.Join(path, entry.Name()))
+				}
+			}
+		}
+	}
+	var documents [][]byte
+	for _, file := range files {
+		data, err := os.ReadFile(filepath.Clean(file))
+		if err != nil {
+			return nil, err
+		}
+		docs, err := yamlutils.SplitDocuments(data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", file, err)
+		}
+		documents = append(documents, docs...)
+	}
+	return documents, nil
+}
+
+func loadPolicies(path string) ([]kyvernov1.PolicyInterface, error) {
+	documents, err := readDocuments(path)
+	if err != nil {
+		return nil, err
+	}
+	var policies []kyvernov1.PolicyInterface
+	for _, document := range documents {
+		loaded, _, err := yamlutils.GetPolicy(document)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load policies from %s: %w", path, err)
+		}
+		policies = append(policies, loaded...)
+	}
+	if len(policies) == 0 {
+		return nil, fmt.Errorf("no policies found in %s", path)
+	}
+	return policies, nil
+}
+
+func loadResources(paths ...string) ([]*unstructured.Unstructured, error) {
+	documents := [][]byte{[]byte(defaultResource)}
+	if len(paths) != 0 {
+		documents = nil
+		for _, path := range paths {
+			docs, err := readDocuments(path)
+			if err != nil {
+				return nil, err
+			}
+			documents = append(documents, docs...)
+		}
+	}
+	var resources []*unstructured.Unstructured
+	for _, document := range documents {
+		data, err := yaml.YAMLToJSON(document)
+		if err != nil {
+			return nil, err
+		}
+		resource, err := kubeutils.BytesToUnstructured(data)
+		if err != nil {
+			return nil, err
+		}
+		resources = append(resources, resource)
+	}
+	if len(resources) == 0 {
+		return nil, fmt.Errorf("no resources to replay")
+	}
+	return resources, nil
+}
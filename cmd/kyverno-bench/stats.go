@@ -0,0 +1,77 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"text/tabwriter"
+	"time"
+)
+
+// result holds the outcome of a benchmark run against a policy set
+type result struct {
+	PolicySet string        `json:"policySet"`
+	Requests  int           `json:"requests"`
+	Errors    int           `json:"errors"`
+	Denied    int           `json:"denied"`
+	Skipped   int           `json:"skipped"`
+	QPS       float64       `json:"qps"`
+	P50       time.Duration `json:"p50"`
+	P95       time.Duration `json:"p95"`
+	P99       time.Duration `json:"p99"`
+	Max       time.Duration `json:"max"`
+}
+
+// newResult computes the latency percentiles of the successful requests of a run
+func newResult(policySet string, latencies []time.Duration, errors, denied, skipped int, elapsed time.Duration) result {
+	sorted := make([]time.Duration, len(latencies))
+	copy(sorted, latencies)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	r := result{
+		PolicySet: policySet,
+		Requests:  len(latencies) + errors,
+		Errors:    errors,
+		Denied:    denied,
+		Skipped:   skipped,
+		P50:       percentile(sorted, 50),
+		P95:       percentile(sorted, 95),
+		P99:       percentile(sorted, 99),
+	}
+	if len(sorted) != 0 {
+		r.Max = sorted[len(sorted)-1]
+	}
+	if elapsed > 0 {
+		r.QPS = float64(r.Requests) / elapsed.Seconds()
+	}
+	return r
+}
+
+// percentile returns the nearest rank percentile of sorted latencies
+func percentile(sorted []time.Duration, p int) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := (p*len(sorted) + 99) / 100
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
+
+func printResults(out io.Writer, format string, results ...result) error {
+	if format == "json" {
+		data, err := json.MarshalIndent(results, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(out, string(data))
+		return err
+	}
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "POLICY SET\tREQUESTS\tERRORS\tDENIED\tSKIPPED\tQPS\tP50\tP95\tP99\tMAX")
+	for _, r := range results {
+		fmt.Fprintf(w, "%s\t%d\t%d\t%d\t%d\t%.1f\t%s\t%s\t%s\t%s\n", r.PolicySet, r.Requests, r.Errors, r.Denied, r.Skipped, r.QPS, r.P50, r.P95, r.P99, r.Max)
+	}
+	return w.Flush()
+}
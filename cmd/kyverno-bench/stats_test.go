@@ -0,0 +1,48 @@
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"gotest.tools/assert"
+)
+
+func Test_percentile(t *testing.T) {
+	var latencies []time.Duration
+	for i := 100; i > 0; i-- {
+		latencies = append(latencies, time.Duration(i)*time.Millisecond)
+	}
+	result := newResult("test", latencies, 2, 3, 4, time.Second)
+	assert.Equal(t, result.Requests, 102)
+	assert.Equal(t, result.Errors, 2)
+	assert.Equal(t, result.Denied, 3)
+	assert.Equal(t, result.Skipped, 4)
+	assert.Equal(t, result.QPS, 102.0)
+	assert.Equal(t, result.P50, 50*time.Millisecond)
+	assert.Equal(t, result.P95, 95*time.Millisecond)
+	assert.Equal(t, result.P99, 99*time.Millisecond)
+	assert.Equal(t, result.Max, 100*time.Millisecond)
+	// latencies are left untouched
+	assert.Equal(t, latencies[0], 100*time.Millisecond)
+	assert.Equal(t, percentile(nil, 99), time.Duration(0))
+}
+
+type fakeTarget struct{}
+
+func (fakeTarget) Send(context.Context, []byte) (bool, error) {
+	time.Sleep(time.Millisecond)
+	return false, nil
+}
+
+func Test_run(t *testing.T) {
+	resources, err := loadResources()
+	assert.NilError(t, err)
+	reviews, err := newAdmissionReviews(resources, "CREATE")
+	assert.NilError(t, err)
+	assert.Equal(t, len(reviews), 1)
+	result := run(context.TODO(), fakeTarget{}, "fake", reviews, loadOptions{qps: 100, duration: 500 * time.Millisecond, concurrency: 2})
+	assert.Assert(t, result.Requests > 0)
+	assert.Equal(t, result.Denied, result.Requests)
+	assert.Equal(t, result.Errors, 0)
+}
@@ -0,0 +1,149 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/clients/dclient"
+	"github.com/kyverno/kyverno/pkg/policycache"
+	"github.com/kyverno/kyverno/pkg/webhooks/handlers"
+	"github.com/kyverno/kyverno/pkg/webhooks/resource"
+	admissionv1 "k8s.io/api/admission/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// target sends an admission review and returns whether the request was allowed
+type target interface {
+	Send(context.Context, []byte) (bool, error)
+}
+
+type httpTarget struct {
+	client *http.Client
+	url    string
+}
+
+func newHttpTarget(url string, insecure bool, timeout time.Duration) target {
+	return &httpTarget{
+		client: &http.Client{
+			Timeout: timeout,
+			Transport: &http.Transport{
+				// #nosec G402 -- skipping verification is opt-in, the server usually uses a self signed certificate
+				TLSClientConfig:     &tls.Config{InsecureSkipVerify: insecure},
+				MaxIdleConnsPerHost: 100,
+			},
+		},
+		url: url,
+	}
+}
+
+func (t *httpTarget) Send(ctx context.Context, body []byte) (bool, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, t.url, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	request.Header.Set("Content-Type", "application/json")
+	response, err := t.client.Do(request)
+	if err != nil {
+		return false, err
+	}
+	defer response.Body.Close()
+	data, err := io.ReadAll(response.Body)
+	if err != nil {
+		return false, err
+	}
+	return decodeResponse(response.StatusCode, data)
+}
+
+// inProcessTarget runs the admission handler chain of the server in the benchmark process with fake clients
+type inProcessTarget struct {
+	path    string
+	handler http.HandlerFunc
+}
+
+func newInProcessTarget(ctx context.Context, logger logr.Logger, webhook string, policies []kyvernov1.PolicyInterface, resources []*unstructured.Unstructured) (target, error) {
+	policyCache := policycache.NewCache()
+	finder := newResourceFinder(resources)
+	for _, policy := range policies {
+		key := policy.GetName()
+		if policy.GetNamespace() != "" {
+			key = policy.GetNamespace() + "/" + key
+		}
+		if err := policyCache.Set(key, policy, finder); err != nil {
+			return nil, fmt.Errorf("failed to add policy %s to the cache: %w", key, err)
+		}
+	}
+	resourceHandlers := resource.NewFakeHandlers(ctx, policyCache)
+	handlerFunc := resourceHandlers.Validate
+	if webhook == "mutate" {
+		handlerFunc = resourceHandlers.Mutate
+	}
+	handler := handlers.FromAdmissionFunc(
+		strings.ToUpper(webhook),
+		func(ctx context.Context, logger logr.Logger, request handlers.AdmissionRequest, startTime time.Time) admissionv1.AdmissionResponse {
+			return handlerFunc(ctx, logger, request, "all", startTime)
+		},
+	).WithAdmission(logger)
+	return &inProcessTarget{path: "/" + webhook, handler: handler.ToHandlerFunc()}, nil
+}
+
+func (t *inProcessTarget) Send(ctx context.Context, body []byte) (bool, error) {
+	request := httptest.NewRequest(http.MethodPost, t.path, bytes.NewReader(body)).WithContext(ctx)
+	request.Header.Set("Content-Type", "application/json")
+	recorder := httptest.NewRecorder()
+	t.handler(recorder, request)
+	return decodeResponse(recorder.Code, recorder.Body.Bytes())
+}
+
+func decodeResponse(code int, data []byte) (bool, error) {
+	if code != http.StatusOK {
+		return false, fmt.Errorf("unexpected status code %d: %s", code, strings.TrimSpace(string(data)))
+	}
+	var review admissionv1.AdmissionReview
+	if err := json.Unmarshal(data, &review); err != nil {
+		return false, err
+	}
+	if review.Response == nil {
+		return false, fmt.Errorf("admission review has no response")
+	}
+	return review.Response.Allowed, nil
+}
+
+// resourceFinder resolves the kinds of the replayed resources for the policy cache, without discovery
+type resourceFinder map[string]dclient.TopLevelApiDescription
+
+func newResourceFinder(resources []*unstructured.Unstructured) resourceFinder {
+	finder := resourceFinder{}
+	for _, obj := range resources {
+		gvk := obj.GroupVersionKind()
+		gvr, _ := meta.UnsafeGuessKindToResource(gvk)
+		finder[gvk.Kind] = dclient.TopLevelApiDescription{
+			GroupVersion: gvk.GroupVersion(),
+			Kind:         gvk.Kind,
+			Resource:     gvr.Resource,
+		}
+	}
+	return finder
+}
+
+func (f resourceFinder) FindResources(group, version, kind, subresource string) (map[dclient.TopLevelApiDescription]metav1.APIResource, error) {
+	out := map[dclient.TopLevelApiDescription]metav1.APIResource{}
+	if subresource != "" {
+		return out, nil
+	}
+	if description, ok := f[kind]; ok {
+		out[description] = metav1.APIResource{}
+	}
+	return out, nil
+}
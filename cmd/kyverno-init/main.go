@@ -114,7 +114,7 @@ func main() {
 		config.KyvernoNamespace(),
 		setup.KubeClient,
 		config.KyvernoPodName(),
-		leaderelection.DefaultRetryPeriod,
+		leaderelection.Config{},
 		run,
 		nil,
 	)
@@ -20,23 +20,34 @@ import (
 	genericloggingcontroller "github.com/kyverno/kyverno/pkg/controllers/generic/logging"
 	genericwebhookcontroller "github.com/kyverno/kyverno/pkg/controllers/generic/webhook"
 	policymetricscontroller "github.com/kyverno/kyverno/pkg/controllers/metrics/policy"
+	notificationcontroller "github.com/kyverno/kyverno/pkg/controllers/notification"
 	openapicontroller "github.com/kyverno/kyverno/pkg/controllers/openapi"
 	policycachecontroller "github.com/kyverno/kyverno/pkg/controllers/policycache"
+	policysetcontroller "github.com/kyverno/kyverno/pkg/controllers/policyset"
 	webhookcontroller "github.com/kyverno/kyverno/pkg/controllers/webhook"
+	webhookhealthcontroller "github.com/kyverno/kyverno/pkg/controllers/webhookhealth"
 	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	"github.com/kyverno/kyverno/pkg/engine/precompile"
+	"github.com/kyverno/kyverno/pkg/evaluation"
 	"github.com/kyverno/kyverno/pkg/event"
+	"github.com/kyverno/kyverno/pkg/extauthz"
 	"github.com/kyverno/kyverno/pkg/informers"
 	"github.com/kyverno/kyverno/pkg/leaderelection"
 	"github.com/kyverno/kyverno/pkg/logging"
 	"github.com/kyverno/kyverno/pkg/openapi"
 	"github.com/kyverno/kyverno/pkg/policycache"
+	"github.com/kyverno/kyverno/pkg/registryclient"
 	"github.com/kyverno/kyverno/pkg/tls"
 	"github.com/kyverno/kyverno/pkg/toggle"
 	kubeutils "github.com/kyverno/kyverno/pkg/utils/kube"
 	runtimeutils "github.com/kyverno/kyverno/pkg/utils/runtime"
 	"github.com/kyverno/kyverno/pkg/validation/exception"
 	"github.com/kyverno/kyverno/pkg/webhooks"
+	webhooksauthorization "github.com/kyverno/kyverno/pkg/webhooks/authorization"
+	webhookscatalog "github.com/kyverno/kyverno/pkg/webhooks/catalog"
+	webhooksconversion "github.com/kyverno/kyverno/pkg/webhooks/conversion"
 	webhooksexception "github.com/kyverno/kyverno/pkg/webhooks/exception"
+	webhookspayload "github.com/kyverno/kyverno/pkg/webhooks/payload"
 	webhookspolicy "github.com/kyverno/kyverno/pkg/webhooks/policy"
 	webhooksresource "github.com/kyverno/kyverno/pkg/webhooks/resource"
 	webhookgenerate "github.com/kyverno/kyverno/pkg/webhooks/updaterequest"
@@ -52,6 +63,8 @@ import (
 const (
 	resyncPeriod                   = 15 * time.Minute
 	exceptionWebhookControllerName = "exception-webhook-controller"
+	payloadReportsQueueSize        = 1000
+	payloadReportsWorkers          = 2
 )
 
 func showWarnings(ctx context.Context, logger logr.Logger) {
@@ -116,12 +129,38 @@ func createrLeaderControllers(
 	runtime runtimeutils.Runtime,
 	servicePort int32,
 	configuration config.Configuration,
+	rclient registryclient.Client,
+	enablePolicySets bool,
+	webhookCanaryInterval time.Duration,
 ) ([]internal.Controller, func(context.Context) error, error) {
-	certManager := certmanager.NewController(
-		caInformer,
-		tlsInformer,
-		certRenewer,
-	)
+	var controllers []internal.Controller
+	if enablePolicySets {
+		policySetController := policysetcontroller.NewController(
+			kyvernoClient,
+			rclient,
+			kyvernoInformer.Kyverno().V2alpha1().PolicySets(),
+		)
+		controllers = append(controllers, internal.NewController(policysetcontroller.ControllerName, policySetController, policysetcontroller.Workers))
+	}
+	if webhookCanaryInterval > 0 {
+		webhookHealthController := webhookhealthcontroller.NewController(
+			kubeClient.CoordinationV1().Leases(config.KyvernoNamespace()),
+			kyvernoClient,
+			webhookCanaryInterval,
+			time.Duration(webhookTimeout)*time.Second,
+		)
+		controllers = append(controllers, internal.NewController(webhookhealthcontroller.ControllerName, webhookHealthController, webhookhealthcontroller.Workers))
+	}
+	// externally managed certificates are consumed as is, they are never generated nor renewed
+	if !tls.IsExternallyManaged() {
+		certManager := certmanager.NewController(
+			caInformer,
+			tlsInformer,
+			certRenewer,
+			kubeClient.CoreV1().ConfigMaps(config.KyvernoNamespace()),
+		)
+		controllers = append(controllers, internal.NewController(certmanager.ControllerName, certManager, certmanager.Workers))
+	}
 	webhookController := webhookcontroller.NewController(
 		dynamicClient.Discovery(),
 		kubeClient.AdmissionregistrationV1().MutatingWebhookConfigurations(),
@@ -168,13 +207,11 @@ func createrLeaderControllers(
 		genericwebhookcontroller.None,
 		configuration,
 	)
-	return []internal.Controller{
-			internal.NewController(certmanager.ControllerName, certManager, certmanager.Workers),
-			internal.NewController(webhookcontroller.ControllerName, webhookController, webhookcontroller.Workers),
-			internal.NewController(exceptionWebhookControllerName, exceptionWebhookController, 1),
-		},
-		nil,
-		nil
+	controllers = append(controllers,
+		internal.NewController(webhookcontroller.ControllerName, webhookController, webhookcontroller.Workers),
+		internal.NewController(exceptionWebhookControllerName, exceptionWebhookController, 1),
+	)
+	return controllers, nil, nil
 }
 
 func main() {
@@ -191,6 +228,26 @@ func main() {
 		dumpPayload                  bool
 		servicePort                  int
 		backgroundServiceAccountName string
+		grpcAddress                  string
+		extAuthzAddress              string
+		extAuthzClientCAFile         string
+		probesAddress                string
+		authorizationWebhook         bool
+		conversionWebhook            bool
+		jsonValidation               bool
+		policyCatalog                bool
+		auditWarn                    bool
+		policyParallelism            int
+		maxRequestBytes              int64
+		maxRequestBytesPerPath       map[string]int64
+		accessLogSampleRate          float64
+		accessLogSampleRatePerPath   map[string]float64
+		accessLogLatencyThreshold    time.Duration
+		webhookDeadlineMargin        time.Duration
+		deduplicateRequests          bool
+		enableNotifications          bool
+		enablePolicySets             bool
+		webhookCanaryInterval        time.Duration
 	)
 	flagset := flag.NewFlagSet("kyverno", flag.ExitOnError)
 	flagset.BoolVar(&dumpPayload, "dumpPayload", false, "Set this flag to activate/deactivate debug mode.")
@@ -205,6 +262,40 @@ func main() {
 	flagset.BoolVar(&admissionReports, "admissionReports", true, "Enable or disable admission reports.")
 	flagset.IntVar(&servicePort, "servicePort", 443, "Port used by the Kyverno Service resource and for webhook configurations.")
 	flagset.StringVar(&backgroundServiceAccountName, "backgroundServiceAccountName", "", "Background service account name.")
+	flagset.BoolVar(&auditWarn, "auditWarn", false, "Set this flag to 'true' to return violations of audit mode validate rules as admission warnings.")
+	flagset.IntVar(&policyParallelism, "policyEvaluationParallelism", 1, "Maximum number of validate policies evaluated concurrently for an admission request, rules of a policy are always evaluated sequentially.")
+	flagset.BoolVar(&authorizationWebhook, "authorizationWebhook", false, "Serve an authorization webhook denying subject access reviews that fail enforced policies matching SubjectAccessReview resources.")
+	flagset.BoolVar(&conversionWebhook, "conversionWebhook", false, "Serve a CRD conversion webhook converting policies between the kyverno.io/v1 and kyverno.io/v2beta1 api versions.")
+	flagset.BoolVar(&jsonValidation, "jsonValidation", false, "Serve a JSON validation endpoint validating arbitrary JSON payloads against the validate rules matching json.kyverno.io/v1alpha1 kinds.")
+	flagset.BoolVar(&policyCatalog, "policyCatalog", false, "Serve a policy catalog endpoint listing the installed policies with their match scopes, modes and webhook coverage, the namespace query parameter restricts the catalog to the policies applying to a namespace.")
+	flagset.Int64Var(&maxRequestBytes, "maxAdmissionRequestBytes", webhooks.DefaultMaxRequestBytes, "Maximum size in bytes of an admission request body, larger requests are rejected with a 413 status. Set to 0 to disable the limit.")
+	flagset.Func("maxAdmissionRequestBytesPerPath", "Comma separated list of path=bytes pairs overriding the maximum admission request size for specific webhook paths, e.g. /validate=1048576,/mutate=2097152.", func(value string) error {
+		limits, err := webhooks.ParseMaxRequestBytesPerPath(value)
+		if err != nil {
+			return err
+		}
+		maxRequestBytesPerPath = limits
+		return nil
+	})
+	flagset.Float64Var(&accessLogSampleRate, "accessLogSampleRate", 0, "Fraction (between 0 and 1) of admission requests logged by the access log. Set to 0 to disable sampling.")
+	flagset.Func("accessLogSampleRatePerPath", "Comma separated list of path=rate pairs overriding the access log sample rate for specific webhook paths, e.g. /validate=0.1,/mutate=0.01.", func(value string) error {
+		rates, err := webhooks.ParseSampleRatePerPath(value)
+		if err != nil {
+			return err
+		}
+		accessLogSampleRatePerPath = rates
+		return nil
+	})
+	flagset.DurationVar(&webhookDeadlineMargin, "webhookDeadlineMargin", 0, "Admission requests still evaluated this long before the API server timeout (e.g. 1s) are answered according to the webhook failure policy and their evaluation is cancelled. Set to 0 to disable.")
+	flagset.BoolVar(&deduplicateRequests, "deduplicateAdmissionRequests", false, "Evaluate identical admission requests received concurrently (e.g. API server retries) once and return the same response to all of them.")
+	flagset.DurationVar(&accessLogLatencyThreshold, "accessLogLatencyThreshold", 0, "Admission requests slower than this threshold are always logged by the access log, e.g. 500ms. Set to 0 to disable.")
+	flagset.BoolVar(&enableNotifications, "enableNotifications", false, "Enable sending alerts to the sinks declared by Notification resources when policies deny admission requests or report audit violations.")
+	flagset.BoolVar(&enablePolicySets, "enablePolicySets", false, "Enable installing the signed policies of the OCI images referenced by PolicySet resources.")
+	flagset.DurationVar(&webhookCanaryInterval, "webhookCanaryInterval", 0, "Interval between the canary admission requests sent to the verify webhook through the API server, results are recorded in metrics and in the WebhookHealth status. Set to 0 to disable.")
+	flagset.StringVar(&grpcAddress, "grpcAddress", "", "Address (e.g. :9444) of the gRPC evaluation server, the server is disabled when empty. Calls are authenticated with the bearer token they carry using a TokenReview.")
+	flagset.StringVar(&extAuthzAddress, "extAuthzAddress", "", "Address (e.g. :9191) of the Envoy ext_authz gRPC server authorizing requests against the validate rules matching json.kyverno.io/v1alpha1/CheckRequest, the server is disabled when empty. The server is served over TLS with the Kyverno certificate.")
+	flagset.StringVar(&extAuthzClientCAFile, "extAuthzClientCAFile", "", "Path of the PEM encoded certificates of the authorities signing the client certificates Envoy authenticates to the ext_authz server with, required when the server is enabled. The file is read for every connection so that it can be rotated.")
+	flagset.StringVar(&probesAddress, "probesAddress", ":9080", "Address of the plain HTTP listener serving the liveness, readiness and metrics endpoints, probes are served by the webhook TLS listener when empty.")
 	// config
 	appConfig := internal.NewConfiguration(
 		internal.WithProfiling(),
@@ -213,11 +304,16 @@ func main() {
 		internal.WithKubeconfig(),
 		internal.WithPolicyExceptions(),
 		internal.WithConfigMapCaching(),
+		internal.WithPodCaching(),
 		internal.WithDeferredLoading(),
+		internal.WithCircuitBreaker(),
+		internal.WithImageDigestCache(),
 		internal.WithCosign(),
 		internal.WithRegistryClient(),
 		internal.WithImageVerifyCache(),
+		internal.WithEvents(),
 		internal.WithLeaderElection(),
+		internal.WithCertificates(),
 		internal.WithKyvernoClient(),
 		internal.WithDynamicClient(),
 		internal.WithKyvernoDynamicClient(),
@@ -229,6 +325,10 @@ func main() {
 	// setup
 	signalCtx, setup, sdown := internal.Setup(appConfig, "kyverno-admission-controller", false)
 	defer sdown()
+	if extAuthzAddress != "" && extAuthzClientCAFile == "" {
+		setup.Logger.Error(errors.New("--extAuthzClientCAFile is required when --extAuthzAddress is set"), "invalid ext_authz configuration")
+		os.Exit(1)
+	}
 	caSecret := informers.NewSecretInformer(setup.KubeClient, config.KyvernoNamespace(), tls.GenerateRootCASecretName(), resyncPeriod)
 	tlsSecret := informers.NewSecretInformer(setup.KubeClient, config.KyvernoNamespace(), tls.GenerateTLSPairSecretName(), resyncPeriod)
 	if !informers.StartInformersAndWaitForCacheSync(signalCtx, setup.Logger, caSecret, tlsSecret) {
@@ -258,11 +358,10 @@ func main() {
 	certRenewer := tls.NewCertRenewer(
 		setup.KubeClient.CoreV1().Secrets(config.KyvernoNamespace()),
 		tls.CertRenewalInterval,
-		tls.CAValidityDuration,
-		tls.TLSValidityDuration,
+		internal.CertificateConfig(),
 		serverIP,
 	)
-	policyCache := policycache.NewCache()
+	policyCache := policycache.NewCacheWithCompiler(precompile.New(setup.Jp), kubeInformer.Core().V1().Namespaces().Lister())
 	omitEventsValues := strings.Split(omitEvents, ",")
 	if omitEvents == "" {
 		omitEventsValues = []string{}
@@ -273,6 +372,7 @@ func main() {
 		kyvernoInformer.Kyverno().V1().Policies(),
 		maxQueuedEvents,
 		omitEventsValues,
+		internal.EventsThrottleConfig(),
 		logging.WithName("EventGenerator"),
 	)
 	// this controller only subscribe to events, nothing is returned...
@@ -327,6 +427,16 @@ func main() {
 		policyCache,
 		openApiManager,
 	)
+	var notifier notificationcontroller.Notifier
+	if enableNotifications {
+		notificationController := notificationcontroller.NewController(
+			setup.KyvernoClient,
+			kyvernoInformer.Kyverno().V2alpha1().Notifications(),
+			setup.KubeClient.CoreV1().Secrets(config.KyvernoNamespace()),
+		)
+		notifier = notificationController
+		nonLeaderControllers = append(nonLeaderControllers, internal.NewController(notificationcontroller.ControllerName, notificationController, notificationcontroller.Workers))
+	}
 	// start informers and wait for cache sync
 	if !internal.StartInformersAndWaitForCacheSync(signalCtx, setup.Logger, kyvernoInformer, kubeInformer, kubeKyvernoInformer) {
 		setup.Logger.Error(errors.New("failed to wait for cache sync"), "failed to wait for cache sync")
@@ -344,11 +454,11 @@ func main() {
 	// setup leader election
 	le, err := leaderelection.New(
 		setup.Logger.WithName("leader-election"),
-		"kyverno",
-		config.KyvernoNamespace(),
+		internal.LeaderElectionName("kyverno"),
+		internal.LeaderElectionNamespace(),
 		setup.LeaderElectionClient,
 		config.KyvernoPodName(),
-		internal.LeaderElectionRetryPeriod(),
+		internal.LeaderElectionConfig(),
 		func(ctx context.Context) {
 			logger := setup.Logger.WithName("leader")
 			// create leader factories
@@ -372,6 +482,9 @@ func main() {
 				runtime,
 				int32(servicePort),
 				setup.Configuration,
+				setup.RegistryClient,
+				enablePolicySets,
+				webhookCanaryInterval,
 			)
 			if err != nil {
 				logger.Error(err, "failed to create leader controllers")
@@ -424,6 +537,8 @@ func main() {
 		setup.KyvernoDynamicClient,
 		openApiManager,
 		backgroundServiceAccountName,
+		kyvernoInformer.Kyverno().V2alpha1().ClusterPolicyConstraints().Lister(),
+		kubeInformer.Core().V1().Namespaces().Lister(),
 	)
 	resourceHandlers := webhooksresource.NewHandlers(
 		engine,
@@ -433,6 +548,7 @@ func main() {
 		setup.MetricsManager,
 		policyCache,
 		kubeInformer.Core().V1().Namespaces().Lister(),
+		kubeInformer.Core().V1().ServiceAccounts().Lister(),
 		kyvernoInformer.Kyverno().V1beta1().UpdateRequests().Lister().UpdateRequests(config.KyvernoNamespace()),
 		kyvernoInformer.Kyverno().V1().ClusterPolicies(),
 		kyvernoInformer.Kyverno().V1().Policies(),
@@ -442,21 +558,81 @@ func main() {
 		admissionReports,
 		backgroundServiceAccountName,
 		setup.Jp,
+		auditWarn,
+		policyParallelism,
+		notifier,
 	)
 	exceptionHandlers := webhooksexception.NewHandlers(exception.ValidationOptions{
 		Enabled:   internal.PolicyExceptionEnabled(),
 		Namespace: internal.ExceptionNamespace(),
 	})
+	var authorizationHandlers webhooks.AuthorizationHandlers
+	if authorizationWebhook {
+		authorizationHandlers = webhooksauthorization.NewHandlers(
+			engine,
+			setup.Configuration,
+			setup.Jp,
+			policyCache,
+			kubeInformer.Rbac().V1().RoleBindings().Lister(),
+			kubeInformer.Rbac().V1().ClusterRoleBindings().Lister(),
+		)
+	}
+	var conversionHandlers webhooks.ConversionHandlers
+	if conversionWebhook {
+		conversionHandlers = webhooksconversion.NewHandlers()
+	}
+	var payloadHandlers webhooks.PayloadHandlers
+	if jsonValidation {
+		// the json validation endpoint is not authenticated, its results are not recorded in reports
+		payloadHandlers = webhookspayload.NewHandlers(
+			engine,
+			setup.Configuration,
+			setup.Jp,
+			policyCache,
+			nil,
+		)
+	}
+	var catalogHandlers webhooks.CatalogHandlers
+	if policyCatalog {
+		catalogHandlers = webhookscatalog.NewHandlers(
+			kyvernoInformer.Kyverno().V1().ClusterPolicies().Lister(),
+			kyvernoInformer.Kyverno().V1().Policies().Lister(),
+			kubeInformer.Core().V1().Namespaces().Lister(),
+		)
+	}
 	server := webhooks.NewServer(
 		signalCtx,
 		policyHandlers,
 		resourceHandlers,
 		exceptionHandlers,
+		authorizationHandlers,
+		conversionHandlers,
+		payloadHandlers,
+		catalogHandlers,
 		setup.Configuration,
 		setup.MetricsManager,
 		webhooks.DebugModeOptions{
 			DumpPayload: dumpPayload,
 		},
+		webhooks.RequestLimitOptions{
+			MaxRequestBytes:        maxRequestBytes,
+			MaxRequestBytesPerPath: maxRequestBytesPerPath,
+		},
+		webhooks.AccessLogOptions{
+			SampleRate:        accessLogSampleRate,
+			SampleRatePerPath: accessLogSampleRatePerPath,
+			LatencyThreshold:  accessLogLatencyThreshold,
+		},
+		webhooks.DeadlineOptions{
+			Timeout: time.Duration(webhookTimeout) * time.Second,
+			Margin:  webhookDeadlineMargin,
+		},
+		webhooks.DeduplicationOptions{
+			Enabled: deduplicateRequests,
+		},
+		webhooks.ProbeOptions{
+			Address: probesAddress,
+		},
 		func() ([]byte, []byte, error) {
 			secret, err := tlsSecret.Lister().Secrets(config.KyvernoNamespace()).Get(tls.GenerateTLSPairSecretName())
 			if err != nil {
@@ -470,6 +646,7 @@ func main() {
 		runtime,
 		kubeInformer.Rbac().V1().RoleBindings().Lister(),
 		kubeInformer.Rbac().V1().ClusterRoleBindings().Lister(),
+		kubeInformer.Core().V1().Namespaces().Lister(),
 		setup.KyvernoDynamicClient.Discovery(),
 	)
 	// start informers and wait for cache sync
@@ -478,6 +655,75 @@ func main() {
 		setup.Logger.Error(errors.New("failed to wait for cache sync"), "failed to wait for cache sync")
 		os.Exit(1)
 	}
+	// start gRPC evaluation server
+	if grpcAddress != "" {
+		evaluationServer := evaluation.NewServer(
+			grpcAddress,
+			evaluation.NewHandler(
+				engine,
+				setup.Configuration,
+				setup.Jp,
+				policyCache,
+				setup.KyvernoDynamicClient.Discovery(),
+				kubeInformer.Core().V1().Namespaces().Lister(),
+				kubeInformer.Core().V1().ServiceAccounts().Lister(),
+				kubeInformer.Rbac().V1().RoleBindings().Lister(),
+				kubeInformer.Rbac().V1().ClusterRoleBindings().Lister(),
+			),
+			func() ([]byte, []byte, error) {
+				secret, err := tlsSecret.Lister().Secrets(config.KyvernoNamespace()).Get(tls.GenerateTLSPairSecretName())
+				if err != nil {
+					return nil, nil, err
+				}
+				return secret.Data[corev1.TLSCertKey], secret.Data[corev1.TLSPrivateKeyKey], nil
+			},
+			setup.KubeClient.AuthenticationV1().TokenReviews(),
+		)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			evaluationServer.Run(signalCtx.Done())
+		}()
+	}
+	// start envoy ext_authz server
+	if extAuthzAddress != "" {
+		var payloadReporter webhookspayload.Reporter
+		if admissionReports {
+			payloadReporter = webhookspayload.NewReporter(setup.Configuration, setup.KyvernoClient, payloadReportsQueueSize)
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				payloadReporter.Run(signalCtx, payloadReportsWorkers)
+			}()
+		}
+		extAuthzServer := extauthz.NewServer(
+			extAuthzAddress,
+			extauthz.NewHandler(
+				webhookspayload.NewHandlers(
+					engine,
+					setup.Configuration,
+					setup.Jp,
+					policyCache,
+					payloadReporter,
+				),
+			),
+			func() ([]byte, []byte, error) {
+				secret, err := tlsSecret.Lister().Secrets(config.KyvernoNamespace()).Get(tls.GenerateTLSPairSecretName())
+				if err != nil {
+					return nil, nil, err
+				}
+				return secret.Data[corev1.TLSCertKey], secret.Data[corev1.TLSPrivateKeyKey], nil
+			},
+			func() ([]byte, error) {
+				return os.ReadFile(extAuthzClientCAFile)
+			},
+		)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			extAuthzServer.Run(signalCtx.Done())
+		}()
+	}
 	// start webhooks server
 	server.Run(signalCtx.Done())
 	wg.Wait()
@@ -21,8 +21,10 @@ import (
 	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
 	"github.com/kyverno/kyverno/pkg/engine/jmespath"
 	"github.com/kyverno/kyverno/pkg/event"
+	"github.com/kyverno/kyverno/pkg/exporter"
 	"github.com/kyverno/kyverno/pkg/leaderelection"
 	"github.com/kyverno/kyverno/pkg/logging"
+	"github.com/kyverno/kyverno/pkg/sharding"
 	kubeinformers "k8s.io/client-go/informers"
 	metadatainformers "k8s.io/client-go/metadata/metadatainformer"
 	kyamlopenapi "sigs.k8s.io/kustomize/kyaml/openapi"
@@ -32,13 +34,25 @@ const (
 	resyncPeriod = 15 * time.Minute
 )
 
+type admissionReportsConfig struct {
+	aggregationDelay time.Duration
+	deletionGrace    time.Duration
+	gcInterval       time.Duration
+	batchSize        int
+}
+
 func createReportControllers(
 	eng engineapi.Engine,
 	backgroundScan bool,
+	scanRequests bool,
 	admissionReports bool,
 	aggregateReports bool,
 	policyReports bool,
 	reportsChunkSize int,
+	reportsMaxSize int,
+	reportsSummary bool,
+	admissionReportsConfig admissionReportsConfig,
+	resultsExporter exporter.Controller,
 	backgroundScanWorkers int,
 	client dclient.Interface,
 	kyvernoClient versioned.Interface,
@@ -49,11 +63,14 @@ func createReportControllers(
 	configuration config.Configuration,
 	jp jmespath.Interface,
 	eventGenerator event.Interface,
+	sharder sharding.Sharder,
 ) ([]internal.Controller, func(context.Context) error) {
 	var ctrls []internal.Controller
 	var warmups []func(context.Context) error
 	kyvernoV1 := kyvernoInformer.Kyverno().V1()
-	if backgroundScan || admissionReports {
+	// background scan, scan requests, admission reports and reports aggregation can run in separate deployments,
+	// each of them depends on the resource report controller
+	if backgroundScan || scanRequests || admissionReports || aggregateReports {
 		resourceReportController := resourcereportcontroller.NewController(
 			client,
 			kyvernoV1.Policies(),
@@ -72,11 +89,14 @@ func createReportControllers(
 				aggregatereportcontroller.ControllerName,
 				aggregatereportcontroller.NewController(
 					kyvernoClient,
+					client,
 					metadataFactory,
 					kyvernoV1.Policies(),
 					kyvernoV1.ClusterPolicies(),
 					resourceReportController,
 					reportsChunkSize,
+					reportsMaxSize,
+					reportsSummary,
 				),
 				aggregatereportcontroller.Workers,
 			))
@@ -88,6 +108,11 @@ func createReportControllers(
 					kyvernoClient,
 					client,
 					metadataFactory,
+					admissionReportsConfig.aggregationDelay,
+					admissionReportsConfig.deletionGrace,
+					admissionReportsConfig.gcInterval,
+					admissionReportsConfig.batchSize,
+					resultsExporter,
 				),
 				admissionreportcontroller.Workers,
 			))
@@ -109,10 +134,33 @@ func createReportControllers(
 					jp,
 					eventGenerator,
 					policyReports,
+					resultsExporter,
+					sharder,
 				),
 				backgroundScanWorkers,
 			))
 		}
+		if scanRequests {
+			ctrls = append(ctrls, internal.NewController(
+				backgroundscancontroller.ScanRequestControllerName,
+				backgroundscancontroller.NewScanRequestController(
+					client,
+					kyvernoClient,
+					eng,
+					kyvernoV1.Policies(),
+					kyvernoV1.ClusterPolicies(),
+					kyvernoInformer.Kyverno().V2alpha1().ScanRequests(),
+					kubeInformer.Core().V1().Namespaces(),
+					resourceReportController,
+					configuration,
+					jp,
+					eventGenerator,
+					policyReports,
+					resultsExporter,
+				),
+				backgroundscancontroller.ScanRequestWorkers,
+			))
+		}
 	}
 	return ctrls, func(ctx context.Context) error {
 		for _, warmup := range warmups {
@@ -127,10 +175,15 @@ func createReportControllers(
 func createrLeaderControllers(
 	eng engineapi.Engine,
 	backgroundScan bool,
+	scanRequests bool,
 	admissionReports bool,
 	aggregateReports bool,
 	policyReports bool,
 	reportsChunkSize int,
+	reportsMaxSize int,
+	reportsSummary bool,
+	admissionReportsConfig admissionReportsConfig,
+	resultsExporter exporter.Controller,
 	backgroundScanWorkers int,
 	kubeInformer kubeinformers.SharedInformerFactory,
 	kyvernoInformer kyvernoinformer.SharedInformerFactory,
@@ -141,14 +194,20 @@ func createrLeaderControllers(
 	jp jmespath.Interface,
 	eventGenerator event.Interface,
 	backgroundScanInterval time.Duration,
+	runExporter bool,
 ) ([]internal.Controller, func(context.Context) error, error) {
 	reportControllers, warmup := createReportControllers(
 		eng,
 		backgroundScan,
+		scanRequests,
 		admissionReports,
 		aggregateReports,
 		policyReports,
 		reportsChunkSize,
+		reportsMaxSize,
+		reportsSummary,
+		admissionReportsConfig,
+		resultsExporter,
 		backgroundScanWorkers,
 		dynamicClient,
 		kyvernoClient,
@@ -159,10 +218,70 @@ func createrLeaderControllers(
 		configuration,
 		jp,
 		eventGenerator,
+		nil,
 	)
+	if runExporter && resultsExporter != nil {
+		reportControllers = append(reportControllers, internal.NewController(
+			exporter.ControllerName,
+			resultsExporter,
+			exporter.Workers,
+		))
+	}
 	return reportControllers, warmup, nil
 }
 
+// createShardedControllers creates the background scan controllers running on every replica,
+// each replica only scans the resources assigned to it in the shard group.
+func createShardedControllers(
+	eng engineapi.Engine,
+	policyReports bool,
+	resultsExporter exporter.Controller,
+	backgroundScanWorkers int,
+	kubeInformer kubeinformers.SharedInformerFactory,
+	kyvernoInformer kyvernoinformer.SharedInformerFactory,
+	metadataInformer metadatainformers.SharedInformerFactory,
+	kyvernoClient versioned.Interface,
+	dynamicClient dclient.Interface,
+	configuration config.Configuration,
+	jp jmespath.Interface,
+	eventGenerator event.Interface,
+	backgroundScanInterval time.Duration,
+	sharder sharding.Sharder,
+) ([]internal.Controller, func(context.Context) error) {
+	controllers, warmup := createReportControllers(
+		eng,
+		true,
+		false,
+		false,
+		false,
+		policyReports,
+		0,
+		0,
+		false,
+		admissionReportsConfig{},
+		resultsExporter,
+		backgroundScanWorkers,
+		dynamicClient,
+		kyvernoClient,
+		metadataInformer,
+		kubeInformer,
+		kyvernoInformer,
+		backgroundScanInterval,
+		configuration,
+		jp,
+		eventGenerator,
+		sharder,
+	)
+	if resultsExporter != nil {
+		controllers = append(controllers, internal.NewController(
+			exporter.ControllerName,
+			resultsExporter,
+			exporter.Workers,
+		))
+	}
+	return controllers, warmup
+}
+
 func main() {
 	var (
 		backgroundScan         bool
@@ -170,8 +289,14 @@ func main() {
 		aggregateReports       bool
 		policyReports          bool
 		reportsChunkSize       int
+		reportsMaxSize         int
+		reportsSummary         bool
+		admissionReportsConfig admissionReportsConfig
+		resultsExporterConfig  string
 		backgroundScanWorkers  int
 		backgroundScanInterval time.Duration
+		backgroundScanSharding bool
+		shardLeaseDuration     time.Duration
 		maxQueuedEvents        int
 		omitEvents             string
 		skipResourceFilters    bool
@@ -182,8 +307,17 @@ func main() {
 	flagset.BoolVar(&aggregateReports, "aggregateReports", true, "Enable or disable aggregated policy reports.")
 	flagset.BoolVar(&policyReports, "policyReports", true, "Enable or disable policy reports.")
 	flagset.IntVar(&reportsChunkSize, "reportsChunkSize", 1000, "Max number of results in generated reports, reports will be split accordingly if there are more results to be stored.")
+	flagset.IntVar(&reportsMaxSize, "reportsMaxSize", 1024*1024, "Max estimated size in bytes of the results in generated reports, reports will be split accordingly to stay below the etcd object size limit.")
+	flagset.BoolVar(&reportsSummary, "reportsSummary", false, "Enable or disable the generation of a summary policy report per namespace aggregating the results of all reports.")
+	flagset.DurationVar(&admissionReportsConfig.aggregationDelay, "admissionReportsAggregationDelay", 0, "Delay before aggregating intermediate admission reports, increasing it reduces the load on the API server on high churn clusters.")
+	flagset.DurationVar(&admissionReportsConfig.deletionGrace, "admissionReportsDeletionGrace", admissionreportcontroller.DeletionGrace, "Delay after which intermediate admission reports that couldn't be aggregated are deleted.")
+	flagset.DurationVar(&admissionReportsConfig.gcInterval, "admissionReportsGCInterval", 10*time.Minute, "Interval at which orphaned intermediate admission reports are garbage collected, set to 0 to disable.")
+	flagset.IntVar(&admissionReportsConfig.batchSize, "admissionReportsBatchSize", admissionreportcontroller.BatchSize, "Max number of admission reports fetched per list call when aggregating reports.")
+	flagset.StringVar(&resultsExporterConfig, "resultsExporterConfig", "", "Path to the results exporter configuration file, results are exported to the configured sinks when set.")
 	flagset.IntVar(&backgroundScanWorkers, "backgroundScanWorkers", backgroundscancontroller.Workers, "Configure the number of background scan workers.")
 	flagset.DurationVar(&backgroundScanInterval, "backgroundScanInterval", time.Hour, "Configure background scan interval.")
+	flagset.BoolVar(&backgroundScanSharding, "backgroundScanSharding", false, "Enable or disable sharding of background scans, when enabled every replica scans the resources assigned to it instead of only the leader.")
+	flagset.DurationVar(&shardLeaseDuration, "shardLeaseDuration", sharding.DefaultLeaseDuration, "Duration after which a replica that stopped renewing its shard lease is removed from the background scan shard group.")
 	flagset.IntVar(&maxQueuedEvents, "maxQueuedEvents", 1000, "Maximum events to be queued.")
 	flagset.StringVar(&omitEvents, "omit-events", "", "Set this flag to a comma separated list of PolicyViolation, PolicyApplied, PolicyError, PolicySkipped to disable events, e.g. --omit-events=PolicyApplied,PolicyViolation")
 	flagset.BoolVar(&skipResourceFilters, "skipResourceFilters", true, "If true, resource filters wont be considered.")
@@ -195,10 +329,14 @@ func main() {
 		internal.WithKubeconfig(),
 		internal.WithPolicyExceptions(),
 		internal.WithConfigMapCaching(),
+		internal.WithPodCaching(),
 		internal.WithDeferredLoading(),
+		internal.WithCircuitBreaker(),
+		internal.WithImageDigestCache(),
 		internal.WithCosign(),
 		internal.WithRegistryClient(),
 		internal.WithImageVerifyCache(),
+		internal.WithEvents(),
 		internal.WithLeaderElection(),
 		internal.WithKyvernoClient(),
 		internal.WithDynamicClient(),
@@ -219,6 +357,21 @@ func main() {
 	// ELSE KYAML IS NOT THREAD SAFE
 	kyamlopenapi.Schema()
 	setup.Logger.Info("background scan interval", "duration", backgroundScanInterval.String())
+	setup.Logger.Info("enabled components", "backgroundScan", backgroundScan, "admissionReports", admissionReports, "aggregateReports", aggregateReports)
+	// results exporter
+	var resultsExporter exporter.Controller
+	if resultsExporterConfig != "" {
+		exporterConfig, err := exporter.LoadConfig(resultsExporterConfig)
+		if err != nil {
+			setup.Logger.Error(err, "failed to load results exporter configuration")
+			os.Exit(1)
+		}
+		resultsExporter, err = exporter.NewExporter(ctx, *exporterConfig)
+		if err != nil {
+			setup.Logger.Error(err, "failed to create results exporter")
+			os.Exit(1)
+		}
+	}
 	// informer factories
 	kyvernoInformer := kyvernoinformer.NewSharedInformerFactory(setup.KyvernoClient, resyncPeriod)
 	omitEventsValues := strings.Split(omitEvents, ",")
@@ -231,6 +384,7 @@ func main() {
 		kyvernoInformer.Kyverno().V1().Policies(),
 		maxQueuedEvents,
 		omitEventsValues,
+		internal.EventsThrottleConfig(),
 		logging.WithName("EventGenerator"),
 	)
 	// engine
@@ -247,22 +401,73 @@ func main() {
 		setup.KyvernoClient,
 		setup.RegistrySecretLister,
 	)
+	// sharded background scan runs on every replica
+	sharded := backgroundScan && backgroundScanSharding
+	var sharder sharding.Sharder
+	var shardedControllers []internal.Controller
+	var shardedWarmup func(context.Context) error
+	kubeInformer := kubeinformers.NewSharedInformerFactory(setup.KubeClient, resyncPeriod)
+	metadataInformer := metadatainformers.NewSharedInformerFactory(setup.MetadataClient, 15*time.Minute)
+	if sharded {
+		sharder = sharding.NewSharder(
+			setup.KubeClient.CoordinationV1().Leases(internal.LeaderElectionNamespace()),
+			internal.LeaderElectionName("kyverno-reports-controller")+"-shard",
+			config.KyvernoPodName(),
+			shardLeaseDuration,
+		)
+		shardedControllers, shardedWarmup = createShardedControllers(
+			engine,
+			policyReports,
+			resultsExporter,
+			backgroundScanWorkers,
+			kubeInformer,
+			kyvernoInformer,
+			metadataInformer,
+			setup.KyvernoClient,
+			setup.KyvernoDynamicClient,
+			setup.Configuration,
+			setup.Jp,
+			eventGenerator,
+			backgroundScanInterval,
+			sharder,
+		)
+	}
 	// start informers and wait for cache sync
-	if !internal.StartInformersAndWaitForCacheSync(ctx, setup.Logger, kyvernoInformer) {
+	if !internal.StartInformersAndWaitForCacheSync(ctx, setup.Logger, kyvernoInformer, kubeInformer) {
 		setup.Logger.Error(errors.New("failed to wait for cache sync"), "failed to wait for cache sync")
 		os.Exit(1)
 	}
 	// start event generator
 	var wg sync.WaitGroup
 	go eventGenerator.Run(ctx, 3, &wg)
+	// start sharded controllers
+	if sharded {
+		internal.StartInformers(ctx, metadataInformer)
+		if !internal.CheckCacheSync(setup.Logger, metadataInformer.WaitForCacheSync(ctx.Done())) {
+			setup.Logger.Error(errors.New("failed to wait for cache sync"), "failed to wait for cache sync")
+			os.Exit(1)
+		}
+		if err := shardedWarmup(ctx); err != nil {
+			setup.Logger.Error(err, "failed to run warmup")
+			os.Exit(1)
+		}
+		for _, controller := range shardedControllers {
+			controller.Run(ctx, setup.Logger.WithName("controllers"), &wg)
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sharder.Run(ctx)
+		}()
+	}
 	// setup leader election
 	le, err := leaderelection.New(
 		setup.Logger.WithName("leader-election"),
-		"kyverno-reports-controller",
-		config.KyvernoNamespace(),
+		internal.LeaderElectionName("kyverno-reports-controller"),
+		internal.LeaderElectionNamespace(),
 		setup.LeaderElectionClient,
 		config.KyvernoPodName(),
-		internal.LeaderElectionRetryPeriod(),
+		internal.LeaderElectionConfig(),
 		func(ctx context.Context) {
 			logger := setup.Logger.WithName("leader")
 			// create leader factories
@@ -273,11 +478,16 @@ func main() {
 			// create leader controllers
 			leaderControllers, warmup, err := createrLeaderControllers(
 				engine,
+				backgroundScan && !sharded,
 				backgroundScan,
 				admissionReports,
 				aggregateReports,
 				policyReports,
 				reportsChunkSize,
+				reportsMaxSize,
+				reportsSummary,
+				admissionReportsConfig,
+				resultsExporter,
 				backgroundScanWorkers,
 				kubeInformer,
 				kyvernoInformer,
@@ -288,6 +498,7 @@ func main() {
 				setup.Jp,
 				eventGenerator,
 				backgroundScanInterval,
+				!sharded,
 			)
 			if err != nil {
 				logger.Error(err, "failed to create leader controllers")
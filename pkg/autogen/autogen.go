@@ -214,7 +214,11 @@ func convertRule(rule kyvernoRule, kind string) (*kyvernov1.Rule, error) {
 			}
 		}
 	}
+	return rule.toRule(), nil
+}
 
+// toRule converts the temporary kyverno rule back to a kyverno rule
+func (rule kyvernoRule) toRule() *kyvernov1.Rule {
 	out := kyvernov1.Rule{
 		Name:         rule.Name,
 		VerifyImages: rule.VerifyImages,
@@ -237,7 +241,7 @@ func convertRule(rule kyvernoRule, kind string) (*kyvernov1.Rule, error) {
 	if rule.Validation != nil {
 		out.Validation = *rule.Validation
 	}
-	return &out, nil
+	return &out
 }
 
 func ComputeRules(p kyvernov1.PolicyInterface) []kyvernov1.Rule {
@@ -259,10 +263,17 @@ func computeRules(p kyvernov1.PolicyInterface) []kyvernov1.Rule {
 			actualControllers = desiredControllers
 		}
 	}
-	if actualControllers == "none" {
-		return spec.Rules
+	var genRules []kyvernov1.Rule
+	if actualControllers != "none" {
+		genRules = generateRules(spec.DeepCopy(), actualControllers)
+	}
+	if applyAutoGen {
+		if customControllers, err := GetCustomControllers(ann); err != nil {
+			logger.Error(err, "failed to parse custom controllers", "annotation", kyverno.AnnotationAutogenCustomControllers)
+		} else {
+			genRules = append(genRules, generateCustomRules(spec.DeepCopy(), customControllers)...)
+		}
 	}
-	genRules := generateRules(spec.DeepCopy(), actualControllers)
 	if len(genRules) == 0 {
 		return spec.Rules
 	}
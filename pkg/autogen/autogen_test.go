@@ -11,6 +11,8 @@ import (
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
 	yamlutils "github.com/kyverno/kyverno/pkg/utils/yaml"
 	"gotest.tools/assert"
+	admissionregistrationv1alpha1 "k8s.io/api/admissionregistration/v1alpha1"
+	apiextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -635,3 +637,171 @@ func Test_ValidateWithCELExpressions(t *testing.T) {
 	rules := computeRules(policies[0])
 	assert.Equal(t, 3, len(rules))
 }
+
+func Test_GetCustomControllers(t *testing.T) {
+	testCases := []struct {
+		name        string
+		annotations map[string]string
+		expected    []CustomController
+		wantErr     bool
+	}{{
+		name: "no-annotation",
+	}, {
+		name: "single",
+		annotations: map[string]string{
+			kyverno.AnnotationAutogenCustomControllers: "argoproj.io/v1alpha1/Rollout=spec.template",
+		},
+		expected: []CustomController{{Kind: "argoproj.io/v1alpha1/Rollout", Path: "spec.template"}},
+	}, {
+		name: "multiple",
+		annotations: map[string]string{
+			kyverno.AnnotationAutogenCustomControllers: "Rollout=spec.template, Foo=spec.workload.podTemplate",
+		},
+		expected: []CustomController{
+			{Kind: "Rollout", Path: "spec.template"},
+			{Kind: "Foo", Path: "spec.workload.podTemplate"},
+		},
+	}, {
+		name: "missing-path",
+		annotations: map[string]string{
+			kyverno.AnnotationAutogenCustomControllers: "Rollout",
+		},
+		wantErr: true,
+	}, {
+		name: "invalid-path",
+		annotations: map[string]string{
+			kyverno.AnnotationAutogenCustomControllers: "Rollout=spec..template",
+		},
+		wantErr: true,
+	}, {
+		name: "built-in",
+		annotations: map[string]string{
+			kyverno.AnnotationAutogenCustomControllers: "Deployment=spec.template",
+		},
+		wantErr: true,
+	}}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			controllers, err := GetCustomControllers(test.annotations)
+			if test.wantErr {
+				assert.Assert(t, err != nil)
+			} else {
+				assert.NilError(t, err)
+				assert.DeepEqual(t, test.expected, controllers)
+			}
+		})
+	}
+}
+
+func Test_ComputeRulesWithCustomControllers(t *testing.T) {
+	policy := []byte(`{
+		"apiVersion": "kyverno.io/v1",
+		"kind": "ClusterPolicy",
+		"metadata": {
+			"name": "require-labels",
+			"annotations": {
+				"pod-policies.kyverno.io/autogen-controllers": "none",
+				"pod-policies.kyverno.io/autogen-custom-controllers": "argoproj.io/v1alpha1/Rollout=spec.template"
+			}
+		},
+		"spec": {
+			"rules": [{
+				"name": "require-team",
+				"match": {"any": [{"resources": {"kinds": ["Pod"]}}]},
+				"validate": {
+					"message": "label team is required in {{request.object.metadata.name}}",
+					"pattern": {"metadata": {"labels": {"team": "?*"}}}
+				}
+			}]
+		}
+	}`)
+	var cpol kyvernov1.ClusterPolicy
+	assert.NilError(t, json.Unmarshal(policy, &cpol))
+	rules := computeRules(&cpol)
+	assert.Equal(t, len(rules), 2)
+	rule := rules[1]
+	assert.Equal(t, rule.Name, "autogen-rollout-require-team")
+	assert.DeepEqual(t, rule.MatchResources.Any[0].Kinds, []string{"argoproj.io/v1alpha1/Rollout"})
+	assert.Equal(t, rule.Validation.Message, "label team is required in {{request.object.spec.template.metadata.name}}")
+	pattern, err := json.Marshal(rule.Validation.GetPattern())
+	assert.NilError(t, err)
+	assert.Equal(t, string(pattern), `{"spec":{"template":{"metadata":{"labels":{"team":"?*"}}}}}`)
+}
+
+func Test_convertCustomRule(t *testing.T) {
+	tests := []struct {
+		name       string
+		path       string
+		message    string
+		pattern    string
+		expression string
+		want       string
+	}{{
+		name:    "variables",
+		path:    "spec.template",
+		message: "{{request.object.metadata.name}} {{request.oldObject.spec.replicas}}",
+		want:    "{{request.object.spec.template.metadata.name}} {{request.oldObject.spec.template.spec.replicas}}",
+	}, {
+		name:    "adjacent variables",
+		path:    "spec.template",
+		message: "{{request.object.spec}}{{request.object.metadata}}",
+		want:    "{{request.object.spec.template.spec}}{{request.object.spec.template.metadata}}",
+	}, {
+		name:    "partial matches",
+		path:    "spec.template",
+		message: "request.object.specification myrequest.object.spec request.object.metadatas metadata spec",
+		want:    "request.object.specification myrequest.object.spec request.object.metadatas metadata spec",
+	}, {
+		name:    "field names are not shifted",
+		path:    "spec.template",
+		message: "test",
+		pattern: `{"spec":{"metadata":"request.object.spec"}}`,
+		want:    "test",
+	}, {
+		name:    "dollar sign in path",
+		path:    "spec.$template",
+		message: "{{request.object.metadata.name}}",
+		want:    "{{request.object.spec.$template.metadata.name}}",
+	}, {
+		name:       "cel expressions",
+		path:       "spec.template",
+		expression: "object.spec.replicas > oldObject.spec.replicas && has(object.metadata.labels) && !has(myobject.spec)",
+		want:       "object.spec.template.spec.replicas > oldObject.spec.template.spec.replicas && has(object.spec.template.metadata.labels) && !has(myobject.spec)",
+	}, {
+		name:       "cel request expressions",
+		path:       "spec.template",
+		expression: "request.object.spec.replicas > request.oldObject.spec.replicas",
+		want:       "request.object.spec.template.spec.replicas > request.oldObject.spec.template.spec.replicas",
+	}}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			validation := &kyvernov1.Validation{Message: test.message}
+			if test.pattern != "" {
+				validation.RawPattern = &apiextv1.JSON{Raw: []byte(test.pattern)}
+			}
+			if test.expression != "" {
+				validation.CEL = &kyvernov1.CEL{Expressions: []admissionregistrationv1alpha1.Validation{{Expression: test.expression}}}
+			}
+			rule, err := convertCustomRule(kyvernoRule{Name: "test", Validation: validation}, test.path)
+			assert.NilError(t, err)
+			if test.expression != "" {
+				assert.Equal(t, rule.Validation.CEL.Expressions[0].Expression, test.want)
+			} else {
+				assert.Equal(t, rule.Validation.Message, test.want)
+			}
+			if test.pattern != "" {
+				pattern, err := json.Marshal(rule.Validation.GetPattern())
+				assert.NilError(t, err)
+				assert.Equal(t, string(pattern), `{"spec":{"metadata":"request.object.spec.template.spec"}}`)
+			}
+		})
+	}
+}
+
+func Test_shiftRestrictedFields(t *testing.T) {
+	var obj interface{}
+	assert.NilError(t, json.Unmarshal([]byte(`{"exclude":[{"controlName":"SELinux","restrictedField":"spec.containers[*].securityContext.seLinuxOptions.role","values":["spec"]}]}`), &obj))
+	shifted, err := json.Marshal(shiftRestrictedFields(obj, "spec.template"))
+	assert.NilError(t, err)
+	assert.Equal(t, string(shifted), `{"exclude":[{"controlName":"SELinux","restrictedField":"spec.template.spec.containers[*].securityContext.seLinuxOptions.role","values":["spec"]}]}`)
+}
@@ -0,0 +1,171 @@
+package autogen
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/kyverno/kyverno/api/kyverno"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kubeutils "github.com/kyverno/kyverno/pkg/utils/kube"
+)
+
+// CustomController describes a custom pod controller kind and the path to its pod template.
+// Custom controllers are configured with the `pod-policies.kyverno.io/autogen-custom-controllers`
+// annotation using a comma separated list of `kind=path` entries, for example:
+// `argoproj.io/v1alpha1/Rollout=spec.template,Foo=spec.workload.podTemplate`.
+type CustomController struct {
+	// Kind is the controller kind, it supports the same formats as kinds in match/exclude blocks
+	Kind string
+	// Path is the dot separated path to the pod template in the controller resource
+	Path string
+}
+
+// name returns a lowercase name suitable for building autogen rule names
+func (c CustomController) name() string {
+	parts := strings.Split(c.Kind, "/")
+	return strings.ToLower(parts[len(parts)-1])
+}
+
+// GetCustomControllers returns the custom controllers configured in the given annotations.
+func GetCustomControllers(annotations map[string]string) ([]CustomController, error) {
+	value, ok := annotations[kyverno.AnnotationAutogenCustomControllers]
+	if !ok || value == "" {
+		return nil, nil
+	}
+	var controllers []CustomController
+	for _, entry := range strings.Split(value, ",") {
+		entry = strings.TrimSpace(entry)
+		kind, path, ok := strings.Cut(entry, "=")
+		if !ok || kind == "" || path == "" {
+			return nil, fmt.Errorf("invalid custom controller %q, expected format is kind=path", entry)
+		}
+		for _, segment := range strings.Split(path, ".") {
+			if segment == "" {
+				return nil, fmt.Errorf("invalid pod template path %q for custom controller %s", path, kind)
+			}
+		}
+		if podControllersKindsSet.Has(kind) {
+			return nil, fmt.Errorf("custom controller %s is a built-in pod controller", kind)
+		}
+		controllers = append(controllers, CustomController{Kind: kind, Path: path})
+	}
+	return controllers, nil
+}
+
+// generateCustomRules generates rules for custom controllers
+func generateCustomRules(spec *kyvernov1.Spec, controllers []CustomController) []kyvernov1.Rule {
+	var rules []kyvernov1.Rule
+	for i := range spec.Rules {
+		for _, controller := range controllers {
+			if genRule := createRule(generateRuleForCustomController(&spec.Rules[i], controller)); genRule != nil {
+				if convRule, err := convertCustomRule(*genRule, controller.Path); err == nil {
+					rules = append(rules, *convRule)
+				} else {
+					logger.Error(err, "failed to create custom controller rule", "kind", controller.Kind)
+				}
+			}
+		}
+	}
+	return rules
+}
+
+func generateRuleForCustomController(rule *kyvernov1.Rule, controller CustomController) *kyvernov1.Rule {
+	if isAutogenRuleName(rule.Name) {
+		return nil
+	}
+	match, exclude := rule.MatchResources, rule.ExcludeResources
+	matchKinds, excludeKinds := match.GetKinds(), exclude.GetKinds()
+	if !kubeutils.ContainsKind(matchKinds, "Pod") || (len(excludeKinds) != 0 && !kubeutils.ContainsKind(excludeKinds, "Pod")) {
+		return nil
+	}
+	debug.Info("generating rule for custom controller", "rule", rule.Name, "kind", controller.Kind)
+	path := strings.Split(controller.Path, ".")
+	return generateRule(
+		getAutogenRuleName("autogen-"+controller.name(), rule.Name),
+		rule,
+		path,
+		strings.Join(path, "/"),
+		[]string{controller.Kind},
+		func(r kyvernov1.ResourceFilters, kinds []string) kyvernov1.ResourceFilters {
+			return getAnyAllAutogenRule(r, "Pod", kinds)
+		},
+	)
+}
+
+// celReferenceRegex matches references to the object being validated in CEL expressions
+var celReferenceRegex = regexp.MustCompile(`(^|[^\w.$])((?:request\.)?(?:object|oldObject)\.)(spec|metadata)\b`)
+
+// variableReferenceRegex matches references to the object being admitted in variables
+var variableReferenceRegex = regexp.MustCompile(`(^|[^\w.$])(request\.(?:object|oldObject)\.)(spec|metadata)\b`)
+
+// convertCustomRule shifts references to the pod in the generated rule to the pod template path,
+// only string values are rewritten and references are matched as a whole so that field names
+// and values merely containing spec or metadata are left untouched
+func convertCustomRule(rule kyvernoRule, path string) (*kyvernov1.Rule, error) {
+	bytes, err := json.Marshal(rule)
+	if err != nil {
+		return nil, err
+	}
+	var obj interface{}
+	if err := json.Unmarshal(bytes, &obj); err != nil {
+		return nil, err
+	}
+	regex := variableReferenceRegex
+	if rule.Validation != nil && rule.Validation.CEL != nil {
+		regex = celReferenceRegex
+	}
+	// the path is escaped because the replacement template expands $ signs
+	replacement := "${1}${2}" + strings.ReplaceAll(path, "$", "$$") + ".${3}"
+	obj = shiftReferences(obj, func(value string) string {
+		return regex.ReplaceAllString(value, replacement)
+	})
+	if rule.Validation != nil && rule.Validation.PodSecurity != nil {
+		obj = shiftRestrictedFields(obj, path)
+	}
+	if bytes, err = json.Marshal(obj); err != nil {
+		return nil, err
+	}
+	rule = kyvernoRule{}
+	if err := json.Unmarshal(bytes, &rule); err != nil {
+		return nil, err
+	}
+	return rule.toRule(), nil
+}
+
+// shiftReferences applies shift to all string values of a decoded json document, keys are not modified
+func shiftReferences(obj interface{}, shift func(string) string) interface{} {
+	switch typed := obj.(type) {
+	case string:
+		return shift(typed)
+	case []interface{}:
+		for i := range typed {
+			typed[i] = shiftReferences(typed[i], shift)
+		}
+	case map[string]interface{}:
+		for key := range typed {
+			typed[key] = shiftReferences(typed[key], shift)
+		}
+	}
+	return obj
+}
+
+// shiftRestrictedFields shifts the restricted fields of pod security excludes to the pod template path
+func shiftRestrictedFields(obj interface{}, path string) interface{} {
+	switch typed := obj.(type) {
+	case []interface{}:
+		for i := range typed {
+			typed[i] = shiftRestrictedFields(typed[i], path)
+		}
+	case map[string]interface{}:
+		for key, value := range typed {
+			if field, ok := value.(string); ok && key == "restrictedField" && strings.HasPrefix(field, "spec") {
+				typed[key] = path + "." + field
+			} else {
+				typed[key] = shiftRestrictedFields(value, path)
+			}
+		}
+	}
+	return obj
+}
@@ -29,6 +29,7 @@ type kyvernoRule struct {
 	Mutation         *kyvernov1.Mutation           `json:"mutate,omitempty"`
 	Validation       *kyvernov1.Validation         `json:"validate,omitempty"`
 	VerifyImages     []kyvernov1.ImageVerification `json:"verifyImages,omitempty" yaml:"verifyImages,omitempty"`
+	Schedule         *kyvernov1.Schedule           `json:"schedule,omitempty"`
 }
 
 func createRule(rule *kyvernov1.Rule) *kyvernoRule {
@@ -65,12 +66,27 @@ func createRule(rule *kyvernov1.Rule) *kyvernoRule {
 	if len(rule.Context) > 0 {
 		jsonFriendlyStruct.Context = &rule.DeepCopy().Context
 	}
+	if rule.Schedule != nil {
+		jsonFriendlyStruct.Schedule = rule.Schedule.DeepCopy()
+	}
 	return &jsonFriendlyStruct
 }
 
 type generateResourceFilters func(kyvernov1.ResourceFilters, []string) kyvernov1.ResourceFilters
 
-func generateRule(name string, rule *kyvernov1.Rule, tplKey, shift string, kinds []string, grf generateResourceFilters) *kyvernov1.Rule {
+// nestUnder wraps the given value in nested maps following the given path
+func nestUnder(path []string, value interface{}) map[string]interface{} {
+	for i := len(path) - 1; i > 0; i-- {
+		value = map[string]interface{}{
+			path[i]: value,
+		}
+	}
+	return map[string]interface{}{
+		path[0]: value,
+	}
+}
+
+func generateRule(name string, rule *kyvernov1.Rule, tplPath []string, shift string, kinds []string, grf generateResourceFilters) *kyvernov1.Rule {
 	if rule == nil {
 		return nil
 	}
@@ -96,11 +112,7 @@ func generateRule(name string, rule *kyvernov1.Rule, tplKey, shift string, kinds
 	if target := rule.Mutation.GetPatchStrategicMerge(); target != nil {
 		newMutation := kyvernov1.Mutation{}
 		newMutation.SetPatchStrategicMerge(
-			map[string]interface{}{
-				"spec": map[string]interface{}{
-					tplKey: target,
-				},
-			},
+			nestUnder(tplPath, target),
 		)
 		rule.Mutation = newMutation
 		return rule
@@ -114,11 +126,7 @@ func generateRule(name string, rule *kyvernov1.Rule, tplKey, shift string, kinds
 				AnyAllConditions: foreach.AnyAllConditions,
 			}
 			temp.SetPatchStrategicMerge(
-				map[string]interface{}{
-					"spec": map[string]interface{}{
-						tplKey: foreach.GetPatchStrategicMerge(),
-					},
-				},
+				nestUnder(tplPath, foreach.GetPatchStrategicMerge()),
 			)
 			newForEachMutation = append(newForEachMutation, temp)
 		}
@@ -129,22 +137,22 @@ func generateRule(name string, rule *kyvernov1.Rule, tplKey, shift string, kinds
 	}
 	if target := rule.Validation.GetPattern(); target != nil {
 		newValidate := kyvernov1.Validation{
-			Message: variables.FindAndShiftReferences(logger, rule.Validation.Message, shift, "pattern"),
+			Message:    variables.FindAndShiftReferences(logger, rule.Validation.Message, shift, "pattern"),
+			Severity:   rule.Validation.Severity,
+			Properties: rule.Validation.Properties,
 		}
 		newValidate.SetPattern(
-			map[string]interface{}{
-				"spec": map[string]interface{}{
-					tplKey: target,
-				},
-			},
+			nestUnder(tplPath, target),
 		)
 		rule.Validation = newValidate
 		return rule
 	}
 	if rule.Validation.Deny != nil {
 		deny := kyvernov1.Validation{
-			Message: variables.FindAndShiftReferences(logger, rule.Validation.Message, shift, "deny"),
-			Deny:    rule.Validation.Deny,
+			Message:    variables.FindAndShiftReferences(logger, rule.Validation.Message, shift, "deny"),
+			Deny:       rule.Validation.Deny,
+			Severity:   rule.Validation.Severity,
+			Properties: rule.Validation.Properties,
 		}
 		rule.Validation = deny
 		return rule
@@ -159,6 +167,8 @@ func generateRule(name string, rule *kyvernov1.Rule, tplKey, shift string, kinds
 				Version: rule.Validation.PodSecurity.Version,
 				Exclude: newExclude,
 			},
+			Severity:   rule.Validation.Severity,
+			Properties: rule.Validation.Properties,
 		}
 		rule.Validation = podSecurity
 		return rule
@@ -170,15 +180,17 @@ func generateRule(name string, rule *kyvernov1.Rule, tplKey, shift string, kinds
 		}
 		var patterns []interface{}
 		for _, pattern := range anyPatterns {
-			newPattern := map[string]interface{}{
-				"spec": map[string]interface{}{
-					tplKey: pattern,
-				},
-			}
-			patterns = append(patterns, newPattern)
+			patterns = append(patterns, nestUnder(tplPath, pattern))
+		}
+		var messages []string
+		for _, message := range rule.Validation.AnyPatternMessages {
+			messages = append(messages, variables.FindAndShiftReferences(logger, message, shift, "anyPattern"))
 		}
 		rule.Validation = kyvernov1.Validation{
-			Message: variables.FindAndShiftReferences(logger, rule.Validation.Message, shift, "anyPattern"),
+			Message:            variables.FindAndShiftReferences(logger, rule.Validation.Message, shift, "anyPattern"),
+			AnyPatternMessages: messages,
+			Severity:           rule.Validation.Severity,
+			Properties:         rule.Validation.Properties,
 		}
 		rule.Validation.SetAnyPattern(patterns)
 		return rule
@@ -189,6 +201,31 @@ func generateRule(name string, rule *kyvernov1.Rule, tplKey, shift string, kinds
 		rule.Validation = kyvernov1.Validation{
 			Message:           variables.FindAndShiftReferences(logger, rule.Validation.Message, shift, "pattern"),
 			ForEachValidation: newForeachValidate,
+			Severity:          rule.Validation.Severity,
+			Properties:        rule.Validation.Properties,
+		}
+		return rule
+	}
+	if rule.Validation.Assert != nil {
+		nestChecks := func(checks []kyvernov1.AssertionCheck) []kyvernov1.AssertionCheck {
+			var out []kyvernov1.AssertionCheck
+			for _, check := range checks {
+				check := *check.DeepCopy()
+				if tree := check.GetCheck(); tree != nil {
+					check.RawCheck = kyvernov1.ToJSON(nestUnder(tplPath, tree))
+				}
+				out = append(out, check)
+			}
+			return out
+		}
+		rule.Validation = kyvernov1.Validation{
+			Message: variables.FindAndShiftReferences(logger, rule.Validation.Message, shift, "assert"),
+			Assert: &kyvernov1.Assertion{
+				Any: nestChecks(rule.Validation.Assert.Any),
+				All: nestChecks(rule.Validation.Assert.All),
+			},
+			Severity:   rule.Validation.Severity,
+			Properties: rule.Validation.Properties,
 		}
 		return rule
 	}
@@ -274,7 +311,7 @@ func generateRuleForControllers(rule *kyvernov1.Rule, controllers string) *kyver
 	return generateRule(
 		getAutogenRuleName("autogen", rule.Name),
 		rule,
-		"template",
+		[]string{"spec", "template"},
 		"spec/template",
 		strings.Split(controllers, ","),
 		func(r kyvernov1.ResourceFilters, kinds []string) kyvernov1.ResourceFilters {
@@ -292,7 +329,7 @@ func generateCronJobRule(rule *kyvernov1.Rule, controllers string) *kyvernov1.Ru
 	return generateRule(
 		getAutogenRuleName("autogen-cronjob", rule.Name),
 		generateRuleForControllers(rule, controllers),
-		"jobTemplate",
+		[]string{"spec", "jobTemplate"},
 		"spec/jobTemplate/spec/template",
 		[]string{PodControllerCronJob},
 		func(r kyvernov1.ResourceFilters, kinds []string) kyvernov1.ResourceFilters {
@@ -16,3 +16,8 @@ const (
 	GenerateSourceGroupLabel     = "generate.kyverno.io/source-group"
 	GenerateTypeCloneSourceLabel = "generate.kyverno.io/clone-source"
 )
+
+const (
+	// GenerateDownstreamCleanupFinalizer is set on policies whose generated resources are deleted with the policy
+	GenerateDownstreamCleanupFinalizer = "generate.kyverno.io/downstream-cleanup"
+)
@@ -0,0 +1,54 @@
+package common
+
+import (
+	"math"
+	"time"
+)
+
+const (
+	// DefaultMaxRetries is the default number of retries of a failed update request
+	DefaultMaxRetries = 10
+	// DefaultRetryBaseDelay is the default delay before the first retry of a failed update request
+	DefaultRetryBaseDelay = 5 * time.Second
+	// DefaultRetryMaxDelay is the default maximum delay between two retries of a failed update request
+	DefaultRetryMaxDelay = 5 * time.Minute
+)
+
+// RetryPolicy controls how failed update requests are retried
+type RetryPolicy struct {
+	// MaxRetries is the number of retries after which a failed update request is not retried anymore
+	MaxRetries int
+	// BaseDelay is the delay before the first retry, it doubles with every retry
+	BaseDelay time.Duration
+	// MaxDelay caps the delay between two retries
+	MaxDelay time.Duration
+}
+
+// DefaultRetryPolicy returns the default retry policy
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxRetries: DefaultMaxRetries,
+		BaseDelay:  DefaultRetryBaseDelay,
+		MaxDelay:   DefaultRetryMaxDelay,
+	}
+}
+
+// Delay returns the delay before the given retry, retries are counted from zero
+func (p RetryPolicy) Delay(retry int) time.Duration {
+	delay := p.BaseDelay
+	for i := 0; i < retry; i++ {
+		if (p.MaxDelay > 0 && delay >= p.MaxDelay) || delay > math.MaxInt64/2 {
+			break
+		}
+		delay *= 2
+	}
+	if p.MaxDelay > 0 && delay > p.MaxDelay {
+		return p.MaxDelay
+	}
+	return delay
+}
+
+// Exhausted returns true if no retry is left after the given number of retries
+func (p RetryPolicy) Exhausted(retryCount int) bool {
+	return retryCount >= p.MaxRetries
+}
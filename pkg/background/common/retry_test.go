@@ -0,0 +1,38 @@
+package common
+
+import (
+	"testing"
+	"time"
+
+	"gotest.tools/assert"
+)
+
+func TestRetryPolicy_Delay(t *testing.T) {
+	policy := RetryPolicy{
+		MaxRetries: 10,
+		BaseDelay:  5 * time.Second,
+		MaxDelay:   time.Minute,
+	}
+	tests := []struct {
+		retry int
+		want  time.Duration
+	}{
+		{retry: 0, want: 5 * time.Second},
+		{retry: 1, want: 10 * time.Second},
+		{retry: 2, want: 20 * time.Second},
+		{retry: 3, want: 40 * time.Second},
+		{retry: 4, want: time.Minute},
+		{retry: 100, want: time.Minute},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, policy.Delay(tt.retry), tt.want)
+	}
+}
+
+func TestRetryPolicy_Exhausted(t *testing.T) {
+	policy := RetryPolicy{MaxRetries: 2}
+	assert.Assert(t, !policy.Exhausted(0))
+	assert.Assert(t, !policy.Exhausted(1))
+	assert.Assert(t, policy.Exhausted(2))
+	assert.Assert(t, RetryPolicy{}.Exhausted(0))
+}
@@ -1,10 +1,18 @@
 package common
 
 import (
+	"context"
+	"fmt"
+
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
 	kyvernov1beta1 "github.com/kyverno/kyverno/api/kyverno/v1beta1"
 	"github.com/kyverno/kyverno/pkg/client/clientset/versioned"
 	kyvernov1beta1listers "github.com/kyverno/kyverno/pkg/client/listers/kyverno/v1beta1"
+	"github.com/kyverno/kyverno/pkg/config"
+	"github.com/kyverno/kyverno/pkg/logging"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/clock"
 )
 
 // StatusControlInterface provides interface to update status subresource
@@ -16,20 +24,49 @@ type StatusControlInterface interface {
 
 // statusControl is default implementaation of GRStatusControlInterface
 type statusControl struct {
-	client   versioned.Interface
-	urLister kyvernov1beta1listers.UpdateRequestNamespaceLister
+	client      versioned.Interface
+	urLister    kyvernov1beta1listers.UpdateRequestNamespaceLister
+	retryPolicy RetryPolicy
+	clock       clock.PassiveClock
 }
 
-func NewStatusControl(client versioned.Interface, urLister kyvernov1beta1listers.UpdateRequestNamespaceLister) StatusControlInterface {
+func NewStatusControl(client versioned.Interface, urLister kyvernov1beta1listers.UpdateRequestNamespaceLister, retryPolicy RetryPolicy) StatusControlInterface {
 	return &statusControl{
-		client:   client,
-		urLister: urLister,
+		client:      client,
+		urLister:    urLister,
+		retryPolicy: retryPolicy,
+		clock:       clock.RealClock{},
 	}
 }
 
-// Failed sets ur status.state to failed with message
+// Failed schedules a retry of the ur with exponential backoff, keeping status.state pending with message,
+// once the retries are exhausted it sets ur status.state to failed with message
 func (sc *statusControl) Failed(name, message string, genResources []kyvernov1.ResourceSpec) (*kyvernov1beta1.UpdateRequest, error) {
-	return UpdateStatus(sc.client, sc.urLister, name, kyvernov1beta1.Failed, message, genResources)
+	ur, err := sc.client.KyvernoV1beta1().UpdateRequests(config.KyvernoNamespace()).Get(context.TODO(), name, metav1.GetOptions{})
+	if err != nil {
+		return ur, errors.Wrapf(err, "failed to fetch update request")
+	}
+	latest := ur.DeepCopy()
+	latest.Status.Message = message
+	if genResources != nil {
+		latest.Status.GeneratedResources = genResources
+	}
+	if sc.retryPolicy.Exhausted(latest.Status.RetryCount) {
+		latest.Status.State = kyvernov1beta1.Failed
+		latest.Status.Message = fmt.Sprintf("failed after %d retries: %s", latest.Status.RetryCount, message)
+		latest.Status.NextRetryTime = nil
+	} else {
+		nextRetryTime := metav1.NewTime(sc.clock.Now().Add(sc.retryPolicy.Delay(latest.Status.RetryCount)))
+		latest.Status.State = kyvernov1beta1.Pending
+		latest.Status.RetryCount++
+		latest.Status.NextRetryTime = &nextRetryTime
+	}
+	new, err := sc.client.KyvernoV1beta1().UpdateRequests(config.KyvernoNamespace()).UpdateStatus(context.TODO(), latest, metav1.UpdateOptions{})
+	if err != nil {
+		return ur, errors.Wrapf(err, "failed to update ur status to %s", string(latest.Status.State))
+	}
+	logging.V(3).Info("updated update request status", "name", name, "status", string(latest.Status.State), "retryCount", new.Status.RetryCount, "nextRetryTime", new.Status.NextRetryTime)
+	return ur, nil
 }
 
 // Success sets the ur status.state to completed and clears message
@@ -0,0 +1,60 @@
+package common
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	kyvernov1beta1 "github.com/kyverno/kyverno/api/kyverno/v1beta1"
+	"github.com/kyverno/kyverno/pkg/client/clientset/versioned/fake"
+	"github.com/kyverno/kyverno/pkg/config"
+	"gotest.tools/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clocktesting "k8s.io/utils/clock/testing"
+)
+
+func TestStatusControl_Failed(t *testing.T) {
+	now := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	client := fake.NewSimpleClientset(&kyvernov1beta1.UpdateRequest{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "ur-test",
+			Namespace: config.KyvernoNamespace(),
+		},
+		Status: kyvernov1beta1.UpdateRequestStatus{
+			State: kyvernov1beta1.Pending,
+		},
+	})
+	sc := &statusControl{
+		client: client,
+		retryPolicy: RetryPolicy{
+			MaxRetries: 2,
+			BaseDelay:  time.Second,
+			MaxDelay:   time.Minute,
+		},
+		clock: clocktesting.NewFakePassiveClock(now),
+	}
+	get := func() kyvernov1beta1.UpdateRequestStatus {
+		ur, err := client.KyvernoV1beta1().UpdateRequests(config.KyvernoNamespace()).Get(context.TODO(), "ur-test", metav1.GetOptions{})
+		assert.NilError(t, err)
+		return ur.Status
+	}
+	// the first failures schedule retries with exponential backoff
+	for retry, delay := range []time.Duration{time.Second, 2 * time.Second} {
+		_, err := sc.Failed("ur-test", "boom", nil)
+		assert.NilError(t, err)
+		status := get()
+		assert.Equal(t, status.State, kyvernov1beta1.Pending)
+		assert.Equal(t, status.Message, "boom")
+		assert.Equal(t, status.RetryCount, retry+1)
+		assert.Assert(t, status.NextRetryTime != nil)
+		assert.Equal(t, status.NextRetryTime.Time, now.Add(delay))
+	}
+	// once the retries are exhausted the request is marked as failed
+	_, err := sc.Failed("ur-test", "boom", nil)
+	assert.NilError(t, err)
+	status := get()
+	assert.Equal(t, status.State, kyvernov1beta1.Failed)
+	assert.Equal(t, status.Message, "failed after 2 retries: boom")
+	assert.Equal(t, status.RetryCount, 2)
+	assert.Assert(t, status.NextRetryTime == nil)
+}
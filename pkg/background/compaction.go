@@ -0,0 +1,92 @@
+package background
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	kyvernov1beta1 "github.com/kyverno/kyverno/api/kyverno/v1beta1"
+	common "github.com/kyverno/kyverno/pkg/background/common"
+	"github.com/kyverno/kyverno/pkg/config"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// compactionKey identifies the update requests producing the same result when processed,
+// the trigger is fetched when the request is processed so only the oldest one needs to be processed
+func compactionKey(ur *kyvernov1beta1.UpdateRequest) string {
+	return strings.Join([]string{
+		string(ur.Spec.GetRequestType()),
+		ur.Spec.GetPolicyKey(),
+		ur.Spec.GetRuleName(),
+		ur.Spec.GetResource().String(),
+		string(ur.Spec.Context.AdmissionRequestInfo.Operation),
+		ur.Spec.Context.UserRequestInfo.AdmissionUserInfo.Username,
+		strconv.FormatBool(ur.Spec.DeleteDownstream),
+	}, "|")
+}
+
+// olderThan returns true if a was created before b, the name breaks ties
+func olderThan(a, b *kyvernov1beta1.UpdateRequest) bool {
+	ta, tb := a.GetCreationTimestamp(), b.GetCreationTimestamp()
+	if !ta.Equal(&tb) {
+		return ta.Before(&tb)
+	}
+	return a.GetName() < b.GetName()
+}
+
+func waitingForRetry(ur *kyvernov1beta1.UpdateRequest, now time.Time) bool {
+	return ur.Status.NextRetryTime != nil && ur.Status.NextRetryTime.After(now)
+}
+
+// findDuplicates returns the pending update requests equivalent to ur, it returns false if one of them
+// should be processed instead of ur (an older one that is not waiting for a retry)
+func findDuplicates(ur *kyvernov1beta1.UpdateRequest, urs []*kyvernov1beta1.UpdateRequest, now time.Time) ([]*kyvernov1beta1.UpdateRequest, bool) {
+	key := compactionKey(ur)
+	var duplicates []*kyvernov1beta1.UpdateRequest
+	for _, other := range urs {
+		if other.GetName() == ur.GetName() || other.Status.State != kyvernov1beta1.Pending || compactionKey(other) != key {
+			continue
+		}
+		if olderThan(other, ur) && !waitingForRetry(other, now) {
+			return nil, false
+		}
+		duplicates = append(duplicates, other)
+	}
+	return duplicates, true
+}
+
+// compact deletes the pending update requests equivalent to ur before it is processed, the names of the deleted
+// update requests are added to compacted. It returns false when ur must not be processed because an older
+// equivalent update request will be processed instead.
+func (c *controller) compact(ur *kyvernov1beta1.UpdateRequest, compacted sets.Set[string]) (bool, error) {
+	var selector labels.Selector
+	if ur.Spec.GetRequestType() == kyvernov1beta1.Mutate {
+		selector = labels.SelectorFromSet(common.MutateLabelsSet(ur.Spec.GetPolicyKey(), ur.Spec.GetResource()))
+	} else {
+		selector = labels.SelectorFromSet(common.GenerateLabelsSet(ur.Spec.GetPolicyKey(), ur.Spec.GetResource()))
+	}
+	urs, err := c.urLister.List(selector)
+	if err != nil {
+		return false, err
+	}
+	duplicates, process := findDuplicates(ur, urs, time.Now())
+	if !process {
+		return false, nil
+	}
+	for _, duplicate := range duplicates {
+		if compacted.Has(duplicate.GetName()) {
+			continue
+		}
+		err := c.kyvernoClient.KyvernoV1beta1().UpdateRequests(config.KyvernoNamespace()).Delete(context.TODO(), duplicate.GetName(), metav1.DeleteOptions{})
+		if err != nil && !apierrors.IsNotFound(err) {
+			return false, err
+		}
+		compacted.Insert(duplicate.GetName())
+		logger.V(3).Info("compacted update request", "name", duplicate.GetName(), "into", ur.GetName())
+	}
+	return true, nil
+}
@@ -0,0 +1,70 @@
+package background
+
+import (
+	"testing"
+	"time"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov1beta1 "github.com/kyverno/kyverno/api/kyverno/v1beta1"
+	"gotest.tools/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func newUR(name string, created time.Time, namespace string, state kyvernov1beta1.UpdateRequestState) *kyvernov1beta1.UpdateRequest {
+	return &kyvernov1beta1.UpdateRequest{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              name,
+			CreationTimestamp: metav1.NewTime(created),
+		},
+		Spec: kyvernov1beta1.UpdateRequestSpec{
+			Type:   kyvernov1beta1.Generate,
+			Policy: "policy",
+			Rule:   "rule",
+			Resource: kyvernov1.ResourceSpec{
+				APIVersion: "v1",
+				Kind:       "Namespace",
+				Name:       namespace,
+			},
+		},
+		Status: kyvernov1beta1.UpdateRequestStatus{
+			State: state,
+		},
+	}
+}
+
+func Test_findDuplicates(t *testing.T) {
+	now := time.Now()
+	ur := newUR("ur-2", now, "foo", kyvernov1beta1.Pending)
+	older := newUR("ur-1", now.Add(-time.Second), "foo", kyvernov1beta1.Pending)
+	newer := newUR("ur-3", now.Add(time.Second), "foo", kyvernov1beta1.Pending)
+	otherTrigger := newUR("ur-4", now.Add(time.Second), "bar", kyvernov1beta1.Pending)
+	failed := newUR("ur-5", now.Add(time.Second), "foo", kyvernov1beta1.Failed)
+	retrying := newUR("ur-6", now.Add(-time.Second), "foo", kyvernov1beta1.Pending)
+	nextRetry := metav1.NewTime(now.Add(time.Minute))
+	retrying.Status.NextRetryTime = &nextRetry
+
+	duplicates, process := findDuplicates(ur, []*kyvernov1beta1.UpdateRequest{ur, newer, otherTrigger, failed, retrying}, now)
+	assert.Assert(t, process)
+	assert.DeepEqual(t, duplicates, []*kyvernov1beta1.UpdateRequest{newer, retrying})
+
+	duplicates, process = findDuplicates(ur, []*kyvernov1beta1.UpdateRequest{older, ur, newer}, now)
+	assert.Assert(t, !process)
+	assert.Equal(t, len(duplicates), 0)
+}
+
+func Test_nextBatch(t *testing.T) {
+	c := &controller{batchSize: 2}
+	keys := make(chan interface{}, 3)
+	keys <- "a"
+	keys <- "b"
+	keys <- "c"
+	batch, ok := c.nextBatch(keys)
+	assert.Assert(t, ok)
+	assert.DeepEqual(t, batch, []interface{}{"a", "b"})
+	batch, ok = c.nextBatch(keys)
+	assert.Assert(t, ok)
+	assert.DeepEqual(t, batch, []interface{}{"c"})
+	close(keys)
+	_, ok = c.nextBatch(keys)
+	assert.Assert(t, !ok)
+}
@@ -8,6 +8,7 @@ import (
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
 	kyvernov1beta1 "github.com/kyverno/kyverno/api/kyverno/v1beta1"
 	"github.com/kyverno/kyverno/pkg/background/common"
+	"github.com/kyverno/kyverno/pkg/clients/dclient"
 	kubeutils "github.com/kyverno/kyverno/pkg/utils/kube"
 	"go.uber.org/multierr"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
@@ -22,10 +23,15 @@ func (c *GenerateController) deleteDownstream(policy kyvernov1.PolicyInterface,
 	// handle data policy/rule deletion
 	if ur.Status.GeneratedResources != nil {
 		c.log.V(4).Info("policy/rule no longer exists, deleting the downstream resource based on synchronize", "ur", ur.Name, "policy", ur.Spec.Policy, "rule", ur.Spec.Rule)
+		client, clientErr := c.downstreamClient(policy, ur.Spec.Rule)
+		if clientErr != nil {
+			_, err = c.statusControl.Failed(ur.GetName(), fmt.Sprintf("failed to clean up downstream resources on policy deletion: %v", clientErr), ur.Status.GeneratedResources)
+			return
+		}
 		var errs []error
 		failedDownstreams := []kyvernov1.ResourceSpec{}
 		for _, e := range ur.Status.GeneratedResources {
-			if err := c.client.DeleteResource(context.TODO(), e.GetAPIVersion(), e.GetKind(), e.GetNamespace(), e.GetName(), false); err != nil && !apierrors.IsNotFound(err) {
+			if err := client.DeleteResource(context.TODO(), e.GetAPIVersion(), e.GetKind(), e.GetNamespace(), e.GetName(), false); err != nil && !apierrors.IsNotFound(err) {
 				failedDownstreams = append(failedDownstreams, e)
 				errs = append(errs, err)
 			}
@@ -49,6 +55,19 @@ func (c *GenerateController) deleteDownstream(policy kyvernov1.PolicyInterface,
 	return c.handleNonPolicyChanges(policy, ur)
 }
 
+// downstreamClient returns the client of the rule that generated the downstream resources,
+// the kyverno client is used when the policy or the rule no longer exists
+func (c *GenerateController) downstreamClient(policy kyvernov1.PolicyInterface, ruleName string) (dclient.Interface, error) {
+	if policy != nil {
+		for _, rule := range policy.GetSpec().Rules {
+			if rule.Name == ruleName {
+				return c.getRuleClient(c.log, policy, rule)
+			}
+		}
+	}
+	return c.client, nil
+}
+
 func (c *GenerateController) handleNonPolicyChanges(policy kyvernov1.PolicyInterface, ur *kyvernov1beta1.UpdateRequest) error {
 	if !ur.Spec.DeleteDownstream {
 		return nil
@@ -65,7 +84,11 @@ func (c *GenerateController) handleNonPolicyChanges(policy kyvernov1.PolicyInter
 			kyverno.LabelAppManagedBy:           kyverno.ValueKyvernoApp,
 		}
 
-		downstreams, err := c.getDownstreams(rule, labels, ur)
+		client, err := c.getRuleClient(c.log, policy, rule)
+		if err != nil {
+			return fmt.Errorf("failed to get the client of the rule: %v", err)
+		}
+		downstreams, err := c.getDownstreams(client, rule, labels, ur)
 		if err != nil {
 			return fmt.Errorf("failed to fetch downstream resources: %v", err)
 		}
@@ -73,7 +96,7 @@ func (c *GenerateController) handleNonPolicyChanges(policy kyvernov1.PolicyInter
 		failedDownstreams := []kyvernov1.ResourceSpec{}
 		for _, downstream := range downstreams.Items {
 			spec := common.ResourceSpecFromUnstructured(downstream)
-			if err := c.client.DeleteResource(context.TODO(), downstream.GetAPIVersion(), downstream.GetKind(), downstream.GetNamespace(), downstream.GetName(), false); err != nil && !apierrors.IsNotFound(err) {
+			if err := client.DeleteResource(context.TODO(), downstream.GetAPIVersion(), downstream.GetKind(), downstream.GetNamespace(), downstream.GetName(), false); err != nil && !apierrors.IsNotFound(err) {
 				failedDownstreams = append(failedDownstreams, spec)
 				errs = append(errs, err)
 			} else {
@@ -95,7 +118,7 @@ func (c *GenerateController) handleNonPolicyChanges(policy kyvernov1.PolicyInter
 	return nil
 }
 
-func (c *GenerateController) getDownstreams(rule kyvernov1.Rule, selector map[string]string, ur *kyvernov1beta1.UpdateRequest) (*unstructured.UnstructuredList, error) {
+func (c *GenerateController) getDownstreams(client dclient.Interface, rule kyvernov1.Rule, selector map[string]string, ur *kyvernov1beta1.UpdateRequest) (*unstructured.UnstructuredList, error) {
 	gv, err := ur.Spec.GetResource().GetGroupVersion()
 	if err != nil {
 		return nil, err
@@ -108,14 +131,14 @@ func (c *GenerateController) getDownstreams(rule kyvernov1.Rule, selector map[st
 	selector[common.GenerateTriggerVersionLabel] = gv.Version
 	if rule.Generation.GetKind() != "" {
 		c.log.V(4).Info("fetching downstream resources", "APIVersion", rule.Generation.GetAPIVersion(), "kind", rule.Generation.GetKind(), "selector", selector)
-		return FindDownstream(c.client, rule.Generation.GetAPIVersion(), rule.Generation.GetKind(), selector)
+		return FindDownstream(client, rule.Generation.GetAPIVersion(), rule.Generation.GetKind(), selector)
 	}
 
 	dsList := &unstructured.UnstructuredList{}
 	for _, kind := range rule.Generation.CloneList.Kinds {
 		apiVersion, kind := kubeutils.GetKindFromGVK(kind)
 		c.log.V(4).Info("fetching downstream resources", "APIVersion", apiVersion, "kind", kind, "selector", selector)
-		dsList, err = FindDownstream(c.client, apiVersion, kind, selector)
+		dsList, err = FindDownstream(client, apiVersion, kind, selector)
 		if err != nil {
 			return nil, err
 		} else {
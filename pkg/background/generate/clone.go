@@ -14,7 +14,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
-func manageClone(log logr.Logger, target, sourceSpec kyvernov1.ResourceSpec, policy kyvernov1.PolicyInterface, ur kyvernov1beta1.UpdateRequest, rule kyvernov1.Rule, client dclient.Interface) generateResponse {
+func manageClone(log logr.Logger, target, sourceSpec kyvernov1.ResourceSpec, policy kyvernov1.PolicyInterface, ur kyvernov1beta1.UpdateRequest, rule kyvernov1.Rule, client dclient.Interface, serverSideApply bool) generateResponse {
 	source := sourceSpec
 	clone := rule.Generation
 	if clone.Clone.Name != "" {
@@ -72,7 +72,7 @@ func manageClone(log logr.Logger, target, sourceSpec kyvernov1.ResourceSpec, pol
 	}
 
 	if targetObj != nil {
-		if !policy.GetSpec().UseServerSideApply {
+		if !serverSideApply {
 			sourceObjCopy.SetUID(targetObj.GetUID())
 			sourceObjCopy.SetSelfLink(targetObj.GetSelfLink())
 			sourceObjCopy.SetCreationTimestamp(targetObj.GetCreationTimestamp())
@@ -88,7 +88,7 @@ func manageClone(log logr.Logger, target, sourceSpec kyvernov1.ResourceSpec, pol
 	return newCreateGenerateResponse(sourceObjCopy.UnstructuredContent(), target, nil)
 }
 
-func manageCloneList(log logr.Logger, targetNamespace string, ur kyvernov1beta1.UpdateRequest, policy kyvernov1.PolicyInterface, rule kyvernov1.Rule, client dclient.Interface) []generateResponse {
+func manageCloneList(log logr.Logger, targetNamespace string, ur kyvernov1beta1.UpdateRequest, policy kyvernov1.PolicyInterface, rule kyvernov1.Rule, client dclient.Interface, serverSideApply bool) []generateResponse {
 	var responses []generateResponse
 	cloneList := rule.Generation.CloneList
 	sourceNamespace := cloneList.Namespace
@@ -109,7 +109,7 @@ func manageCloneList(log logr.Logger, targetNamespace string, ur kyvernov1beta1.
 		for _, source := range sources.Items {
 			target := newResourceSpec(source.GetAPIVersion(), source.GetKind(), targetNamespace, source.GetName())
 			responses = append(responses,
-				manageClone(log, target, newResourceSpec(source.GetAPIVersion(), source.GetKind(), source.GetNamespace(), source.GetName()), policy, ur, rule, client))
+				manageClone(log, target, newResourceSpec(source.GetAPIVersion(), source.GetKind(), source.GetNamespace(), source.GetName()), policy, ur, rule, client, serverSideApply))
 		}
 	}
 	return responses
@@ -0,0 +1,208 @@
+package generate
+
+import (
+	"bytes"
+	"context"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/go-logr/logr"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/metrics"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/structured-merge-diff/v4/fieldpath"
+	"sigs.k8s.io/structured-merge-diff/v4/value"
+)
+
+const (
+	// fieldManager is the field manager used to apply generated resources, the client prefixes it with kyverno-
+	fieldManager = "generate"
+	// appliedFieldManager is the field manager recorded in the managed fields of generated resources
+	appliedFieldManager = "kyverno-" + fieldManager
+)
+
+// FieldConflict holds the fields of a generated resource set by another field manager with a different value
+type FieldConflict struct {
+	Manager string
+	Fields  []string
+}
+
+// FindFieldConflicts returns, per field manager, the fields of the desired resource owned by another field manager
+// than Kyverno and whose value differs in the existing resource
+func FindFieldConflicts(existing, desired *unstructured.Unstructured) ([]FieldConflict, error) {
+	desiredFields := fieldpath.SetFromValue(value.NewValueInterface(desired.Object))
+	var conflicts []FieldConflict
+	for _, entry := range existing.GetManagedFields() {
+		if entry.Manager == appliedFieldManager || entry.Subresource != "" || entry.FieldsV1 == nil {
+			continue
+		}
+		owned := &fieldpath.Set{}
+		if err := owned.FromJSON(bytes.NewReader(entry.FieldsV1.Raw)); err != nil {
+			return nil, err
+		}
+		var fields []string
+		desiredFields.Iterate(func(path fieldpath.Path) {
+			if !ownsPath(owned, path) {
+				return
+			}
+			existingValue, found := lookupPath(existing.Object, path)
+			if !found {
+				return
+			}
+			desiredValue, _ := lookupPath(desired.Object, path)
+			if !value.Equals(value.NewValueInterface(existingValue), value.NewValueInterface(desiredValue)) {
+				fields = append(fields, path.String())
+			}
+		})
+		if len(fields) != 0 {
+			sort.Strings(fields)
+			conflicts = append(conflicts, FieldConflict{Manager: entry.Manager, Fields: fields})
+		}
+	}
+	sort.Slice(conflicts, func(i, j int) bool {
+		return conflicts[i].Manager < conflicts[j].Manager
+	})
+	return conflicts, nil
+}
+
+// ModifiedSinceApplied returns true when another field manager than Kyverno changed the resource
+// after it was last applied by Kyverno, resources not applied by Kyverno are never considered modified
+func ModifiedSinceApplied(resource *unstructured.Unstructured) bool {
+	var applied *metav1.Time
+	for _, entry := range resource.GetManagedFields() {
+		if entry.Manager == appliedFieldManager && entry.Subresource == "" {
+			applied = entry.Time
+		}
+	}
+	if applied == nil {
+		return false
+	}
+	for _, entry := range resource.GetManagedFields() {
+		if strings.HasPrefix(entry.Manager, "kyverno") || entry.Subresource != "" || entry.Time == nil {
+			continue
+		}
+		if entry.Time.After(applied.Time) {
+			return true
+		}
+	}
+	return false
+}
+
+// ownsPath returns true if the path or one of its parent fields (atomic maps and lists) is owned
+func ownsPath(owned *fieldpath.Set, path fieldpath.Path) bool {
+	if owned.Has(path) {
+		return true
+	}
+	for i := len(path) - 1; i > 0; i-- {
+		if path[i-1].FieldName != nil && owned.Has(path[:i]) {
+			return true
+		}
+	}
+	return false
+}
+
+func lookupPath(obj interface{}, path fieldpath.Path) (interface{}, bool) {
+	for _, element := range path {
+		switch {
+		case element.FieldName != nil:
+			m, ok := obj.(map[string]interface{})
+			if !ok {
+				return nil, false
+			}
+			if obj, ok = m[*element.FieldName]; !ok {
+				return nil, false
+			}
+		case element.Index != nil:
+			l, ok := obj.([]interface{})
+			if !ok || *element.Index >= len(l) {
+				return nil, false
+			}
+			obj = l[*element.Index]
+		case element.Key != nil:
+			l, ok := obj.([]interface{})
+			if !ok {
+				return nil, false
+			}
+			found := false
+			for _, item := range l {
+				if m, ok := item.(map[string]interface{}); ok && matchesKey(m, *element.Key) {
+					obj, found = item, true
+					break
+				}
+			}
+			if !found {
+				return nil, false
+			}
+		case element.Value != nil:
+			l, ok := obj.([]interface{})
+			if !ok {
+				return nil, false
+			}
+			found := false
+			for _, item := range l {
+				if value.Equals(value.NewValueInterface(item), *element.Value) {
+					obj, found = item, true
+					break
+				}
+			}
+			if !found {
+				return nil, false
+			}
+		default:
+			return nil, false
+		}
+	}
+	return obj, true
+}
+
+func matchesKey(m map[string]interface{}, key value.FieldList) bool {
+	for _, field := range key {
+		v, ok := m[field.Name]
+		if !ok || !value.Equals(value.NewValueInterface(v), field.Value) {
+			return false
+		}
+	}
+	return true
+}
+
+var (
+	driftOnce    sync.Once
+	driftCounter metric.Int64Counter
+)
+
+func getDriftCounter(logger logr.Logger) metric.Int64Counter {
+	driftOnce.Do(func() {
+		meter := otel.GetMeterProvider().Meter(metrics.MeterName)
+		counter, err := meter.Int64Counter(
+			"kyverno_generate_drift",
+			metric.WithDescription("can be used to track the fields of generated resources changed by other field managers and restored by Kyverno"),
+		)
+		if err != nil {
+			logger.Error(err, "Failed to create instrument, kyverno_generate_drift")
+			return
+		}
+		driftCounter = counter
+	})
+	return driftCounter
+}
+
+func recordDrift(logger logr.Logger, policy kyvernov1.PolicyInterface, rule string, target kyvernov1.ResourceSpec, conflicts []FieldConflict) {
+	counter := getDriftCounter(logger)
+	if counter == nil {
+		return
+	}
+	for _, conflict := range conflicts {
+		counter.Add(context.TODO(), int64(len(conflict.Fields)), metric.WithAttributes(
+			attribute.String("policy_name", policy.GetName()),
+			attribute.String("policy_namespace", policy.GetNamespace()),
+			attribute.String("rule_name", rule),
+			attribute.String("resource_kind", target.GetKind()),
+			attribute.String("field_manager", conflict.Manager),
+		))
+	}
+}
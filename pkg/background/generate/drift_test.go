@@ -0,0 +1,91 @@
+package generate
+
+import (
+	"testing"
+	"time"
+
+	"gotest.tools/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func managedFields(manager string, at time.Time, fields string) metav1.ManagedFieldsEntry {
+	t := metav1.NewTime(at)
+	return metav1.ManagedFieldsEntry{
+		Manager:    manager,
+		Operation:  metav1.ManagedFieldsOperationUpdate,
+		Time:       &t,
+		FieldsType: "FieldsV1",
+		FieldsV1:   &metav1.FieldsV1{Raw: []byte(fields)},
+	}
+}
+
+func configMap(data map[string]interface{}, entries ...metav1.ManagedFieldsEntry) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "ConfigMap",
+		"metadata": map[string]interface{}{
+			"name":      "test",
+			"namespace": "default",
+		},
+		"data": data,
+	}}
+	obj.SetManagedFields(entries)
+	return obj
+}
+
+func Test_FindFieldConflicts(t *testing.T) {
+	now := time.Now()
+	existing := configMap(
+		map[string]interface{}{"a": "changed", "b": "same", "c": "other"},
+		managedFields(appliedFieldManager, now, `{"f:data":{"f:b":{}}}`),
+		managedFields("foo-controller", now, `{"f:data":{"f:a":{},"f:b":{},"f:c":{}}}`),
+	)
+	desired := configMap(map[string]interface{}{"a": "expected", "b": "same"})
+	conflicts, err := FindFieldConflicts(existing, desired)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, conflicts, []FieldConflict{{Manager: "foo-controller", Fields: []string{".data.a"}}})
+
+	desired = configMap(map[string]interface{}{"a": "changed", "b": "same"})
+	conflicts, err = FindFieldConflicts(existing, desired)
+	assert.NilError(t, err)
+	assert.Equal(t, len(conflicts), 0)
+}
+
+func Test_ModifiedSinceApplied(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name    string
+		entries []metav1.ManagedFieldsEntry
+		want    bool
+	}{{
+		name: "not applied",
+		entries: []metav1.ManagedFieldsEntry{
+			managedFields("foo-controller", now, `{}`),
+		},
+	}, {
+		name: "applied last",
+		entries: []metav1.ManagedFieldsEntry{
+			managedFields("foo-controller", now.Add(-time.Minute), `{}`),
+			managedFields(appliedFieldManager, now, `{}`),
+		},
+	}, {
+		name: "modified by kyverno",
+		entries: []metav1.ManagedFieldsEntry{
+			managedFields(appliedFieldManager, now.Add(-time.Minute), `{}`),
+			managedFields("kyverno", now, `{}`),
+		},
+	}, {
+		name: "modified after apply",
+		entries: []metav1.ManagedFieldsEntry{
+			managedFields(appliedFieldManager, now.Add(-time.Minute), `{}`),
+			managedFields("foo-controller", now, `{}`),
+		},
+		want: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, ModifiedSinceApplied(configMap(nil, tt.entries...)), tt.want)
+		})
+	}
+}
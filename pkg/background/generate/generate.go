@@ -40,10 +40,12 @@ import (
 
 type GenerateController struct {
 	// clients
-	client        dclient.Interface
-	kyvernoClient versioned.Interface
-	statusControl common.StatusControlInterface
-	engine        engineapi.Engine
+	client              dclient.Interface
+	impersonatingClient dclient.ImpersonatingClientFactory
+	remoteClient        dclient.RemoteClientFactory
+	kyvernoClient       versioned.Interface
+	statusControl       common.StatusControlInterface
+	engine              engineapi.Engine
 
 	// listers
 	urLister      kyvernov1beta1listers.UpdateRequestNamespaceLister
@@ -56,11 +58,16 @@ type GenerateController struct {
 
 	log logr.Logger
 	jp  jmespath.Interface
+
+	// serverSideApply applies generated resources with server-side apply for all policies
+	serverSideApply bool
 }
 
 // NewGenerateController returns an instance of the Generate-Request Controller
 func NewGenerateController(
 	client dclient.Interface,
+	impersonatingClient dclient.ImpersonatingClientFactory,
+	remoteClient dclient.RemoteClientFactory,
 	kyvernoClient versioned.Interface,
 	statusControl common.StatusControlInterface,
 	engine engineapi.Engine,
@@ -72,20 +79,24 @@ func NewGenerateController(
 	eventGen event.Interface,
 	log logr.Logger,
 	jp jmespath.Interface,
+	serverSideApply bool,
 ) *GenerateController {
 	c := GenerateController{
-		client:        client,
-		kyvernoClient: kyvernoClient,
-		statusControl: statusControl,
-		engine:        engine,
-		policyLister:  policyLister,
-		npolicyLister: npolicyLister,
-		urLister:      urLister,
-		nsLister:      nsLister,
-		configuration: dynamicConfig,
-		eventGen:      eventGen,
-		log:           log,
-		jp:            jp,
+		client:              client,
+		impersonatingClient: impersonatingClient,
+		remoteClient:        remoteClient,
+		kyvernoClient:       kyvernoClient,
+		statusControl:       statusControl,
+		engine:              engine,
+		policyLister:        policyLister,
+		npolicyLister:       npolicyLister,
+		urLister:            urLister,
+		nsLister:            nsLister,
+		configuration:       dynamicConfig,
+		eventGen:            eventGen,
+		log:                 log,
+		jp:                  jp,
+		serverSideApply:     serverSideApply,
 	}
 	return &c
 }
@@ -342,7 +353,17 @@ func (c *GenerateController) ApplyGeneratePolicy(log logr.Logger, policyContext
 			return nil, err
 		}
 
-		genResource, err = applyRule(log, c.client, rule, resource, jsonContext, policy, ur)
+		client, err := c.getRuleClient(log, policy, rule)
+		if err != nil {
+			log.Error(err, "failed to create the client of the generate rule", "policy", policy.GetName(), "rule", rule.Name)
+			return nil, err
+		}
+
+		serverSideApply := c.serverSideApply || policy.GetSpec().UseServerSideApply
+		reportDrift := func(target kyvernov1.ResourceSpec, conflicts []FieldConflict) {
+			c.reportDrift(log, policy, rule.Name, target, conflicts)
+		}
+		genResource, err = applyRule(log, client, rule, resource, jsonContext, policy, ur, serverSideApply, reportDrift)
 		if err != nil {
 			log.Error(err, "failed to apply generate rule", "policy", policy.GetName(),
 				"rule", rule.Name, "resource", resource.GetName(), "suggestion", "users need to grant Kyverno's service account additional privileges")
@@ -356,7 +377,56 @@ func (c *GenerateController) ApplyGeneratePolicy(log logr.Logger, policyContext
 	return genResources, nil
 }
 
-func applyRule(log logr.Logger, client dclient.Interface, rule kyvernov1.Rule, trigger unstructured.Unstructured, ctx enginecontext.EvalInterface, policy kyvernov1.PolicyInterface, ur kyvernov1beta1.UpdateRequest) ([]kyvernov1.ResourceSpec, error) {
+// getRuleClient returns the client used to manage the resources generated by the rule
+func (c *GenerateController) getRuleClient(log logr.Logger, policy kyvernov1.PolicyInterface, rule kyvernov1.Rule) (dclient.Interface, error) {
+	return RuleClient(log, c.client, c.impersonatingClient, c.remoteClient, c.configuration, policy, rule)
+}
+
+// RuleClient returns the client used to manage the resources generated by the rule, it targets the remote
+// cluster of the rule or impersonates the service account of the rule when one is configured.
+// It fails when the rule requires a client that is not available instead of falling back to the kyverno client.
+func RuleClient(
+	log logr.Logger,
+	client dclient.Interface,
+	impersonatingClient dclient.ImpersonatingClientFactory,
+	remoteClient dclient.RemoteClientFactory,
+	configuration config.Configuration,
+	policy kyvernov1.PolicyInterface,
+	rule kyvernov1.Rule,
+) (dclient.Interface, error) {
+	if cluster := rule.Generation.Cluster; cluster != nil {
+		if !configuration.IsGenerateClusterAllowed(cluster.Secret) {
+			return nil, fmt.Errorf("cluster secret %s is not allowed by the generateClusters configuration", cluster.Secret)
+		}
+		if remoteClient == nil {
+			return nil, fmt.Errorf("generating resources in remote clusters is not supported")
+		}
+		log.V(4).Info("generating resources in remote cluster", "rule", rule.Name, "secret", cluster.Secret)
+		return remoteClient.ForSecret(context.TODO(), config.KyvernoNamespace(), cluster.Secret, cluster.GetKey())
+	}
+	user := rule.Generation.GetServiceAccountUser(policy.GetNamespace())
+	if user == "" {
+		return client, nil
+	}
+	if impersonatingClient == nil {
+		return nil, fmt.Errorf("impersonation is not supported, cannot act as the service account %s of the generate rule %s", user, rule.Name)
+	}
+	log.V(4).Info("impersonating the service account of the generate rule", "rule", rule.Name, "user", user)
+	return impersonatingClient.ForUser(user)
+}
+
+// reportDrift emits events and records metrics for the fields of a generated resource changed by other field managers
+func (c *GenerateController) reportDrift(log logr.Logger, policy kyvernov1.PolicyInterface, rule string, target kyvernov1.ResourceSpec, conflicts []FieldConflict) {
+	recordDrift(log, policy, rule, target, conflicts)
+	for _, conflict := range conflicts {
+		log.Info("generated resource drift detected", "target", target.String(), "manager", conflict.Manager, "fields", conflict.Fields)
+		if c.eventGen != nil {
+			c.eventGen.Add(event.NewGeneratedResourceDriftEvent(policy, rule, target, conflict.Manager, conflict.Fields))
+		}
+	}
+}
+
+func applyRule(log logr.Logger, client dclient.Interface, rule kyvernov1.Rule, trigger unstructured.Unstructured, ctx enginecontext.EvalInterface, policy kyvernov1.PolicyInterface, ur kyvernov1beta1.UpdateRequest, serverSideApply bool, reportDrift func(kyvernov1.ResourceSpec, []FieldConflict)) ([]kyvernov1.ResourceSpec, error) {
 	responses := []generateResponse{}
 	var err error
 	var newGenResources []kyvernov1.ResourceSpec
@@ -365,10 +435,10 @@ func applyRule(log logr.Logger, client dclient.Interface, rule kyvernov1.Rule, t
 	logger := log.WithValues("target", target.String())
 
 	if rule.Generation.Clone.Name != "" {
-		resp := manageClone(logger.WithValues("type", "clone"), target, kyvernov1.ResourceSpec{}, policy, ur, rule, client)
+		resp := manageClone(logger.WithValues("type", "clone"), target, kyvernov1.ResourceSpec{}, policy, ur, rule, client, serverSideApply)
 		responses = append(responses, resp)
 	} else if len(rule.Generation.CloneList.Kinds) != 0 {
-		responses = manageCloneList(logger.WithValues("type", "cloneList"), target.GetNamespace(), ur, policy, rule, client)
+		responses = manageCloneList(logger.WithValues("type", "cloneList"), target.GetNamespace(), ur, policy, rule, client, serverSideApply)
 	} else {
 		resp := manageData(logger.WithValues("type", "data"), target, rule.Generation.RawData, rule.Generation.Synchronize, ur, client)
 		responses = append(responses, resp)
@@ -401,10 +471,14 @@ func applyRule(log logr.Logger, client dclient.Interface, rule kyvernov1.Rule, t
 
 		newResource.SetAPIVersion(targetMeta.GetAPIVersion())
 		common.ManageLabels(newResource, trigger, policy, rule.Name)
+		if serverSideApply {
+			// field ownership replaces optimistic concurrency for applied resources
+			newResource.SetResourceVersion("")
+		}
 		if response.GetAction() == Create {
 			newResource.SetResourceVersion("")
-			if policy.GetSpec().UseServerSideApply {
-				_, err = client.ApplyResource(context.TODO(), targetMeta.GetAPIVersion(), targetMeta.GetKind(), targetMeta.GetNamespace(), targetMeta.GetName(), newResource, false, "generate")
+			if serverSideApply {
+				_, err = client.ApplyResource(context.TODO(), targetMeta.GetAPIVersion(), targetMeta.GetKind(), targetMeta.GetNamespace(), targetMeta.GetName(), newResource, false, fieldManager)
 			} else {
 				_, err = client.CreateResource(context.TODO(), targetMeta.GetAPIVersion(), targetMeta.GetKind(), targetMeta.GetNamespace(), newResource, false)
 			}
@@ -419,8 +493,8 @@ func applyRule(log logr.Logger, client dclient.Interface, rule kyvernov1.Rule, t
 			generatedObj, err := client.GetResource(context.TODO(), targetMeta.GetAPIVersion(), targetMeta.GetKind(), targetMeta.GetNamespace(), targetMeta.GetName())
 			if err != nil {
 				logger.V(2).Info("target resource not found, creating new target")
-				if policy.GetSpec().UseServerSideApply {
-					_, err = client.ApplyResource(context.TODO(), targetMeta.GetAPIVersion(), targetMeta.GetKind(), targetMeta.GetNamespace(), targetMeta.GetName(), newResource, false, "generate")
+				if serverSideApply {
+					_, err = client.ApplyResource(context.TODO(), targetMeta.GetAPIVersion(), targetMeta.GetKind(), targetMeta.GetNamespace(), targetMeta.GetName(), newResource, false, fieldManager)
 				} else {
 					_, err = client.CreateResource(context.TODO(), targetMeta.GetAPIVersion(), targetMeta.GetKind(), targetMeta.GetNamespace(), newResource, false)
 				}
@@ -441,8 +515,13 @@ func applyRule(log logr.Logger, client dclient.Interface, rule kyvernov1.Rule, t
 					newResource.SetNamespace("default")
 				}
 
-				if policy.GetSpec().UseServerSideApply {
-					_, err = client.ApplyResource(context.TODO(), targetMeta.GetAPIVersion(), targetMeta.GetKind(), targetMeta.GetNamespace(), targetMeta.GetName(), newResource, false, "generate")
+				if serverSideApply {
+					if conflicts, err := FindFieldConflicts(generatedObj, newResource); err != nil {
+						logger.Error(err, "failed to detect field conflicts")
+					} else if len(conflicts) != 0 && reportDrift != nil {
+						reportDrift(targetMeta, conflicts)
+					}
+					_, err = client.ApplyResource(context.TODO(), targetMeta.GetAPIVersion(), targetMeta.GetKind(), targetMeta.GetNamespace(), targetMeta.GetName(), newResource, false, fieldManager)
 				} else {
 					_, err = client.UpdateResource(context.TODO(), targetMeta.GetAPIVersion(), targetMeta.GetKind(), targetMeta.GetNamespace(), newResource, false)
 				}
@@ -0,0 +1,68 @@
+package generate
+
+import (
+	"testing"
+
+	"github.com/go-logr/logr"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/clients/dclient"
+	"gotest.tools/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+type userClient struct {
+	dclient.Interface
+	user string
+}
+
+type fakeImpersonatingClientFactory struct{}
+
+func (fakeImpersonatingClientFactory) ForUser(username string) (dclient.Interface, error) {
+	return userClient{user: username}, nil
+}
+
+func Test_RuleClient(t *testing.T) {
+	client, err := dclient.NewFakeClient(runtime.NewScheme(), map[schema.GroupVersionResource]string{})
+	assert.NilError(t, err)
+	policy := &kyvernov1.Policy{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "apps"}}
+	rule := func(serviceAccount *kyvernov1.ServiceAccountReference) kyvernov1.Rule {
+		return kyvernov1.Rule{Name: "generate", Generation: kyvernov1.Generation{ServiceAccount: serviceAccount}}
+	}
+	tests := []struct {
+		name                string
+		impersonatingClient dclient.ImpersonatingClientFactory
+		rule                kyvernov1.Rule
+		wantUser            string
+		wantErr             bool
+	}{{
+		name:                "no service account",
+		impersonatingClient: fakeImpersonatingClientFactory{},
+		rule:                rule(nil),
+	}, {
+		name:                "service account",
+		impersonatingClient: fakeImpersonatingClientFactory{},
+		rule:                rule(&kyvernov1.ServiceAccountReference{Name: "generator"}),
+		wantUser:            "system:serviceaccount:apps:generator",
+	}, {
+		name:    "service account without impersonation",
+		rule:    rule(&kyvernov1.ServiceAccountReference{Name: "generator"}),
+		wantErr: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := RuleClient(logr.Discard(), client, tt.impersonatingClient, nil, nil, policy, tt.rule)
+			if tt.wantErr {
+				assert.ErrorContains(t, err, "impersonation is not supported")
+				return
+			}
+			assert.NilError(t, err)
+			if tt.wantUser == "" {
+				assert.Equal(t, got, client)
+			} else {
+				assert.Equal(t, got.(userClient).user, tt.wantUser)
+			}
+		})
+	}
+}
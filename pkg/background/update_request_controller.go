@@ -23,6 +23,7 @@ import (
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/wait"
 	corev1informers "k8s.io/client-go/informers/core/v1"
 	corev1listers "k8s.io/client-go/listers/core/v1"
@@ -42,9 +43,11 @@ type Controller interface {
 // controller manages the life-cycle for Generate-Requests and applies generate rule
 type controller struct {
 	// clients
-	client        dclient.Interface
-	kyvernoClient versioned.Interface
-	engine        engineapi.Engine
+	client              dclient.Interface
+	impersonatingClient dclient.ImpersonatingClientFactory
+	remoteClient        dclient.RemoteClientFactory
+	kyvernoClient       versioned.Interface
+	engine              engineapi.Engine
 
 	// listers
 	cpolLister kyvernov1listers.ClusterPolicyLister
@@ -60,12 +63,19 @@ type controller struct {
 	eventGen      event.Interface
 	configuration config.Configuration
 	jp            jmespath.Interface
+	retryPolicy   common.RetryPolicy
+	// serverSideApply applies generated resources with server-side apply
+	serverSideApply bool
+	// batchSize is the maximum number of update requests processed together by a worker
+	batchSize int
 }
 
 // NewController returns an instance of the Generate-Request Controller
 func NewController(
 	kyvernoClient versioned.Interface,
 	client dclient.Interface,
+	impersonatingClient dclient.ImpersonatingClientFactory,
+	remoteClient dclient.RemoteClientFactory,
 	engine engineapi.Engine,
 	cpolInformer kyvernov1informers.ClusterPolicyInformer,
 	polInformer kyvernov1informers.PolicyInformer,
@@ -74,20 +84,28 @@ func NewController(
 	eventGen event.Interface,
 	configuration config.Configuration,
 	jp jmespath.Interface,
+	retryPolicy common.RetryPolicy,
+	serverSideApply bool,
+	batchSize int,
 ) Controller {
 	urLister := urInformer.Lister().UpdateRequests(config.KyvernoNamespace())
 	c := controller{
-		client:        client,
-		kyvernoClient: kyvernoClient,
-		engine:        engine,
-		cpolLister:    cpolInformer.Lister(),
-		polLister:     polInformer.Lister(),
-		urLister:      urLister,
-		nsLister:      namespaceInformer.Lister(),
-		queue:         workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "background"),
-		eventGen:      eventGen,
-		configuration: configuration,
-		jp:            jp,
+		client:              client,
+		impersonatingClient: impersonatingClient,
+		remoteClient:        remoteClient,
+		kyvernoClient:       kyvernoClient,
+		engine:              engine,
+		cpolLister:          cpolInformer.Lister(),
+		polLister:           polInformer.Lister(),
+		urLister:            urLister,
+		nsLister:            namespaceInformer.Lister(),
+		queue:               workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "background"),
+		eventGen:            eventGen,
+		configuration:       configuration,
+		jp:                  jp,
+		retryPolicy:         retryPolicy,
+		serverSideApply:     serverSideApply,
+		batchSize:           batchSize,
 	}
 	_, _ = urInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
 		AddFunc:    c.addUR,
@@ -110,29 +128,72 @@ func (c *controller) Run(ctx context.Context, workers int) {
 		return
 	}
 
+	keys := make(chan interface{})
+	go c.dequeue(ctx, keys)
 	for i := 0; i < workers; i++ {
-		go wait.UntilWithContext(ctx, c.worker, time.Second)
+		go wait.UntilWithContext(ctx, func(ctx context.Context) { c.worker(keys) }, time.Second)
 	}
 
 	<-ctx.Done()
 }
 
-// worker runs a worker thread that just dequeues items, processes them, and marks them done.
+// dequeue feeds the workers with the keys of the queue until it is shut down
+func (c *controller) dequeue(ctx context.Context, keys chan<- interface{}) {
+	defer close(keys)
+	for {
+		key, quit := c.queue.Get()
+		if quit {
+			return
+		}
+		select {
+		case keys <- key:
+		case <-ctx.Done():
+			c.queue.Done(key)
+			return
+		}
+	}
+}
+
+// worker runs a worker thread that just dequeues batches of items, processes them, and marks them done.
 // It enforces that the syncHandler is never invoked concurrently with the same key.
-func (c *controller) worker(ctx context.Context) {
-	for c.processNextWorkItem() {
+func (c *controller) worker(keys <-chan interface{}) {
+	for c.processNextBatch(keys) {
 	}
 }
 
-func (c *controller) processNextWorkItem() bool {
-	key, quit := c.queue.Get()
-	if quit {
-		return false
+// nextBatch waits for a key and adds the keys immediately available, up to the batch size
+func (c *controller) nextBatch(keys <-chan interface{}) ([]interface{}, bool) {
+	key, ok := <-keys
+	if !ok {
+		return nil, false
 	}
+	batch := []interface{}{key}
+	for len(batch) < c.batchSize {
+		select {
+		case key, ok := <-keys:
+			if !ok {
+				return batch, true
+			}
+			batch = append(batch, key)
+		default:
+			return batch, true
+		}
+	}
+	return batch, true
+}
 
-	defer c.queue.Done(key)
-	err := c.syncUpdateRequest(key.(string))
-	c.handleErr(err, key)
+func (c *controller) processNextBatch(keys <-chan interface{}) bool {
+	batch, ok := c.nextBatch(keys)
+	if !ok {
+		return false
+	}
+	// update requests deleted by the compaction of the batch may still be in the cache
+	compacted := sets.New[string]()
+	for _, key := range batch {
+		err := c.syncUpdateRequest(key.(string), compacted)
+		c.handleErr(err, key)
+		c.queue.Done(key)
+	}
 	return true
 }
 
@@ -150,7 +211,7 @@ func (c *controller) handleErr(err error, key interface{}) {
 
 	if c.queue.NumRequeues(key) < maxRetries {
 		logger.V(3).Info("retrying update request", "key", key, "error", err.Error())
-		c.queue.AddAfter(key, time.Second)
+		c.queue.AddRateLimited(key)
 		return
 	}
 
@@ -158,13 +219,17 @@ func (c *controller) handleErr(err error, key interface{}) {
 	c.queue.Forget(key)
 }
 
-func (c *controller) syncUpdateRequest(key string) error {
+func (c *controller) syncUpdateRequest(key string, compacted sets.Set[string]) error {
 	startTime := time.Now()
 	logger.V(4).Info("started sync", "key", key, "startTime", startTime)
 	_, urName, err := cache.SplitMetaNamespaceKey(key)
 	if err != nil {
 		return err
 	}
+	if compacted.Has(urName) {
+		logger.V(4).Info("skipping compacted update request", "key", key)
+		return nil
+	}
 	ur, err := c.urLister.Get(urName)
 	if err != nil {
 		return err
@@ -178,18 +243,37 @@ func (c *controller) syncUpdateRequest(key string) error {
 		}
 	}
 
+	processed := false
 	if ur.Status.State == kyvernov1beta1.Pending {
+		// a failed request waits for its backoff to expire before being processed again
+		if ur.Status.NextRetryTime != nil {
+			if delay := ur.Status.NextRetryTime.Sub(startTime); delay > 0 {
+				logger.V(4).Info("update request is waiting for retry", "key", key, "retryCount", ur.Status.RetryCount, "delay", delay.String())
+				c.queue.AddAfter(key, delay)
+				return nil
+			}
+		}
+		if process, err := c.compact(ur, compacted); err != nil {
+			return err
+		} else if !process {
+			logger.V(4).Info("an older equivalent update request will be processed instead", "key", key)
+			return nil
+		}
 		if err := c.processUR(ur); err != nil {
 			return fmt.Errorf("failed to process UR %s: %v", key, err)
 		}
+		processed = true
 	}
 
-	urStatus, err := c.reconcileURStatus(ur)
+	latest, err := c.reconcileURStatus(ur)
 	if err != nil {
 		return err
 	}
+	if processed && latest.Status.State == kyvernov1beta1.Failed {
+		c.recordFailure(latest)
+	}
 
-	logger.V(4).Info("synced update request", "key", key, "processingTime", time.Since(startTime).String(), "ur status", urStatus)
+	logger.V(4).Info("synced update request", "key", key, "processingTime", time.Since(startTime).String(), "ur status", latest.Status.State)
 	return nil
 }
 
@@ -210,26 +294,27 @@ func (c *controller) addUR(obj interface{}) {
 
 func (c *controller) updateUR(_, cur interface{}) {
 	curUr := cur.(*kyvernov1beta1.UpdateRequest)
-	if curUr.Status.State == kyvernov1beta1.Skip || curUr.Status.State == kyvernov1beta1.Completed {
+	if curUr.Status.State == kyvernov1beta1.Skip || curUr.Status.State == kyvernov1beta1.Completed || curUr.Status.State == kyvernov1beta1.Failed {
 		return
 	}
 	c.enqueueUpdateRequest(curUr)
 }
 
 func (c *controller) processUR(ur *kyvernov1beta1.UpdateRequest) error {
-	statusControl := common.NewStatusControl(c.kyvernoClient, c.urLister)
+	statusControl := common.NewStatusControl(c.kyvernoClient, c.urLister, c.retryPolicy)
 	switch ur.Spec.GetRequestType() {
 	case kyvernov1beta1.Mutate:
 		ctrl := mutate.NewMutateExistingController(c.client, statusControl, c.engine, c.cpolLister, c.polLister, c.nsLister, c.configuration, c.eventGen, logger, c.jp)
 		return ctrl.ProcessUR(ur)
 	case kyvernov1beta1.Generate:
-		ctrl := generate.NewGenerateController(c.client, c.kyvernoClient, statusControl, c.engine, c.cpolLister, c.polLister, c.urLister, c.nsLister, c.configuration, c.eventGen, logger, c.jp)
+		ctrl := generate.NewGenerateController(c.client, c.impersonatingClient, c.remoteClient, c.kyvernoClient, statusControl, c.engine, c.cpolLister, c.polLister, c.urLister, c.nsLister, c.configuration, c.eventGen, logger, c.jp, c.serverSideApply)
 		return ctrl.ProcessUR(ur)
 	}
 	return nil
 }
 
-func (c *controller) reconcileURStatus(ur *kyvernov1beta1.UpdateRequest) (kyvernov1beta1.UpdateRequestState, error) {
+// reconcileURStatus deletes completed update requests, failed update requests are kept until they are cleaned up with their policy
+func (c *controller) reconcileURStatus(ur *kyvernov1beta1.UpdateRequest) (*kyvernov1beta1.UpdateRequest, error) {
 	new, err := c.kyvernoClient.KyvernoV1beta1().UpdateRequests(config.KyvernoNamespace()).Get(context.TODO(), ur.GetName(), metav1.GetOptions{})
 	if err != nil {
 		logger.V(2).Info("cannot fetch latest UR, fallback to the existing one", "reason", err.Error())
@@ -237,14 +322,24 @@ func (c *controller) reconcileURStatus(ur *kyvernov1beta1.UpdateRequest) (kyvern
 	}
 
 	var errUpdate error
-	switch new.Status.State {
-	case kyvernov1beta1.Completed:
+	if new.Status.State == kyvernov1beta1.Completed {
 		errUpdate = c.kyvernoClient.KyvernoV1beta1().UpdateRequests(config.KyvernoNamespace()).Delete(context.TODO(), ur.GetName(), metav1.DeleteOptions{})
-	case kyvernov1beta1.Failed:
-		new.Status.State = kyvernov1beta1.Pending
-		_, errUpdate = c.kyvernoClient.KyvernoV1beta1().UpdateRequests(config.KyvernoNamespace()).UpdateStatus(context.TODO(), new, metav1.UpdateOptions{})
 	}
-	return new.Status.State, errUpdate
+	return new, errUpdate
+}
+
+// recordFailure emits an event on the policy of an update request that exhausted its retries
+func (c *controller) recordFailure(ur *kyvernov1beta1.UpdateRequest) {
+	logger.Info("update request failed and will not be retried", "name", ur.GetName(), "policy", ur.Spec.GetPolicyKey(), "retryCount", ur.Status.RetryCount, "message", ur.Status.Message)
+	policy, err := c.getPolicy(ur.Spec.Policy)
+	if err != nil {
+		return
+	}
+	source := event.GeneratePolicyController
+	if ur.Spec.GetRequestType() == kyvernov1beta1.Mutate {
+		source = event.MutateExistingController
+	}
+	c.eventGen.Add(event.NewBackgroundFailedEvent(fmt.Errorf("update request %s %s", ur.GetName(), ur.Status.Message), policy, ur.Spec.Rule, source, ur.Spec.GetResource())...)
 }
 
 func (c *controller) getPolicy(key string) (kyvernov1.PolicyInterface, error) {
@@ -0,0 +1,288 @@
+// Package catalog describes the installed policies in a machine-readable form, with their match scopes,
+// modes and webhook coverage, so that tools like developer portals can show which policies apply
+// to a namespace without parsing the policy resources.
+package catalog
+
+import (
+	"context"
+	"sort"
+
+	"github.com/kyverno/kyverno/api/kyverno"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/autogen"
+	"github.com/kyverno/kyverno/pkg/utils/match"
+	"github.com/kyverno/kyverno/pkg/utils/wildcard"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Catalog lists the installed policies
+type Catalog struct {
+	// Namespace is the namespace the catalog was filtered with, empty when not filtered
+	Namespace string `json:"namespace,omitempty"`
+	// Policies are the policies sorted by namespace and name
+	Policies []Policy `json:"policies"`
+}
+
+// Policy describes an installed policy
+type Policy struct {
+	// Kind is either ClusterPolicy or Policy
+	Kind string `json:"kind"`
+	// Namespace is the namespace of a Policy, empty for a ClusterPolicy
+	Namespace string `json:"namespace,omitempty"`
+	// Name is the name of the policy
+	Name string `json:"name"`
+	// Title, Category, Severity and Description come from the policies.kyverno.io annotations
+	Title       string `json:"title,omitempty"`
+	Category    string `json:"category,omitempty"`
+	Severity    string `json:"severity,omitempty"`
+	Description string `json:"description,omitempty"`
+	// Ready is true when the policy is ready to be applied
+	Ready bool `json:"ready"`
+	// Mode is the validation failure action of the policy, or its effective action in the namespace
+	// the catalog was filtered with
+	Mode kyvernov1.ValidationFailureAction `json:"mode"`
+	// ModeOverrides are the per namespace overrides of the validation failure action
+	ModeOverrides []kyvernov1.ValidationFailureActionOverride `json:"modeOverrides,omitempty"`
+	// Background is true when the policy is applied to existing resources by background scans
+	Background bool `json:"background"`
+	// Webhooks describes the admission webhooks the policy is served by
+	Webhooks Webhooks `json:"webhooks"`
+	// Rules are the rules of the policy, including the rules generated for pod controllers
+	Rules []Rule `json:"rules"`
+}
+
+// Webhooks describes the admission webhooks a policy is served by
+type Webhooks struct {
+	// Mutating is true when the policy is registered in the mutating webhook configuration
+	Mutating bool `json:"mutating"`
+	// Validating is true when the policy is registered in the validating webhook configuration
+	Validating bool `json:"validating"`
+	// FailurePolicy is the failure policy of the webhooks serving the policy
+	FailurePolicy kyvernov1.FailurePolicyType `json:"failurePolicy,omitempty"`
+	// TimeoutSeconds is the timeout of the webhooks serving the policy, the default timeout applies when empty
+	TimeoutSeconds *int32 `json:"timeoutSeconds,omitempty"`
+}
+
+// Rule describes the type and match scope of a rule
+type Rule struct {
+	// Name is the name of the rule
+	Name string `json:"name"`
+	// Type is one of mutate, validate, generate or verifyImages
+	Type string `json:"type"`
+	// Match lists the resources selected by the rule
+	Match Scope `json:"match"`
+	// Exclude lists the resources excluded by the rule
+	Exclude *Scope `json:"exclude,omitempty"`
+}
+
+// Scope lists resource filters, Any filters are ORed and All filters are ANDed
+type Scope struct {
+	Any []Filter `json:"any,omitempty"`
+	All []Filter `json:"all,omitempty"`
+}
+
+// Filter is the part of a resource filter describing the resources it selects
+type Filter struct {
+	Kinds             []string                       `json:"kinds,omitempty"`
+	Names             []string                       `json:"names,omitempty"`
+	Namespaces        []string                       `json:"namespaces,omitempty"`
+	NamespaceSelector *metav1.LabelSelector          `json:"namespaceSelector,omitempty"`
+	Operations        []kyvernov1.AdmissionOperation `json:"operations,omitempty"`
+}
+
+// Namespace is the namespace a catalog is filtered with, the policies that can't match
+// resources in the namespace are left out of the catalog
+type Namespace struct {
+	Name string
+	// Labels are used to evaluate namespace selectors, selectors are considered matching when nil
+	Labels map[string]string
+	// Annotations are used to evaluate validation failure action overrides
+	Annotations map[string]string
+}
+
+// Build returns the catalog of the given policies, filtered with the namespace when not nil
+func Build(ctx context.Context, policies []kyvernov1.PolicyInterface, namespace *Namespace) Catalog {
+	catalog := Catalog{Policies: []Policy{}}
+	if namespace != nil {
+		catalog.Namespace = namespace.Name
+	}
+	for _, policy := range policies {
+		if namespace != nil && !appliesTo(policy, *namespace) {
+			continue
+		}
+		catalog.Policies = append(catalog.Policies, describe(ctx, policy, namespace))
+	}
+	sort.Slice(catalog.Policies, func(i, j int) bool {
+		if catalog.Policies[i].Namespace != catalog.Policies[j].Namespace {
+			return catalog.Policies[i].Namespace < catalog.Policies[j].Namespace
+		}
+		return catalog.Policies[i].Name < catalog.Policies[j].Name
+	})
+	return catalog
+}
+
+func describe(ctx context.Context, policy kyvernov1.PolicyInterface, namespace *Namespace) Policy {
+	spec := policy.GetSpec()
+	annotations := policy.GetAnnotations()
+	description := Policy{
+		Kind:          policy.GetKind(),
+		Namespace:     policy.GetNamespace(),
+		Name:          policy.GetName(),
+		Title:         annotations[kyverno.AnnotationPolicyTitle],
+		Category:      annotations[kyverno.AnnotationPolicyCategory],
+		Severity:      annotations[kyverno.AnnotationPolicySeverity],
+		Description:   annotations[kyverno.AnnotationPolicyDescription],
+		Ready:         policy.IsReady(),
+		Mode:          spec.ValidationFailureAction,
+		ModeOverrides: spec.ValidationFailureActionOverrides,
+		Background:    spec.BackgroundProcessingEnabled(),
+		Rules:         []Rule{},
+	}
+	if description.Kind == "" {
+		if policy.IsNamespaced() {
+			description.Kind = "Policy"
+		} else {
+			description.Kind = "ClusterPolicy"
+		}
+	}
+	if namespace != nil {
+		description.Mode = mode(policy, *namespace)
+		description.ModeOverrides = nil
+	}
+	// mirrors how the webhook controller registers policies in the webhook configurations
+	if spec.AdmissionProcessingEnabled() {
+		description.Webhooks.Mutating = spec.HasMutate() || spec.HasVerifyImages()
+		description.Webhooks.Validating = spec.HasValidate() || spec.HasGenerate() || spec.HasMutate() || spec.HasVerifyImageChecks() || spec.HasVerifyManifests()
+	}
+	if description.Webhooks.Mutating || description.Webhooks.Validating {
+		description.Webhooks.FailurePolicy = spec.GetFailurePolicy(ctx)
+		description.Webhooks.TimeoutSeconds = spec.WebhookTimeoutSeconds
+	}
+	for _, rule := range autogen.ComputeRules(policy) {
+		r := Rule{
+			Name:  rule.Name,
+			Type:  ruleType(rule),
+			Match: scope(rule.MatchResources),
+		}
+		if exclude := scope(rule.ExcludeResources); len(exclude.Any) != 0 || len(exclude.All) != 0 {
+			r.Exclude = &exclude
+		}
+		description.Rules = append(description.Rules, r)
+	}
+	return description
+}
+
+func ruleType(rule kyvernov1.Rule) string {
+	switch {
+	case rule.HasMutate():
+		return "mutate"
+	case rule.HasValidate():
+		return "validate"
+	case rule.HasGenerate():
+		return "generate"
+	case rule.HasVerifyImages():
+		return "verifyImages"
+	}
+	return ""
+}
+
+func filter(description kyvernov1.ResourceDescription) Filter {
+	names := description.Names
+	if description.Name != "" {
+		names = append([]string{description.Name}, names...)
+	}
+	return Filter{
+		Kinds:             description.Kinds,
+		Names:             names,
+		Namespaces:        description.Namespaces,
+		NamespaceSelector: description.NamespaceSelector,
+		Operations:        description.Operations,
+	}
+}
+
+// scope flattens the deprecated resource description into the any filters
+func scope(resources kyvernov1.MatchResources) Scope {
+	var s Scope
+	if !resources.ResourceDescription.IsEmpty() {
+		s.Any = append(s.Any, filter(resources.ResourceDescription))
+	}
+	for _, f := range resources.Any {
+		s.Any = append(s.Any, filter(f.ResourceDescription))
+	}
+	for _, f := range resources.All {
+		s.All = append(s.All, filter(f.ResourceDescription))
+	}
+	return s
+}
+
+// appliesTo returns true if a rule of the policy can match resources in the namespace
+func appliesTo(policy kyvernov1.PolicyInterface, namespace Namespace) bool {
+	if policy.IsNamespaced() {
+		return policy.GetNamespace() == namespace.Name
+	}
+	for _, rule := range autogen.ComputeRules(policy) {
+		if matchesNamespace(rule.MatchResources, namespace) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchesNamespace(resources kyvernov1.MatchResources, namespace Namespace) bool {
+	if !resources.ResourceDescription.IsEmpty() {
+		return filterMatchesNamespace(resources.ResourceDescription, namespace)
+	}
+	for _, f := range resources.Any {
+		if filterMatchesNamespace(f.ResourceDescription, namespace) {
+			return true
+		}
+	}
+	if len(resources.All) == 0 {
+		return false
+	}
+	for _, f := range resources.All {
+		if !filterMatchesNamespace(f.ResourceDescription, namespace) {
+			return false
+		}
+	}
+	return true
+}
+
+func filterMatchesNamespace(description kyvernov1.ResourceDescription, namespace Namespace) bool {
+	if len(description.Namespaces) != 0 && !wildcard.CheckPatterns(description.Namespaces, namespace.Name) {
+		return false
+	}
+	if description.NamespaceSelector != nil && namespace.Labels != nil {
+		// wildcards are replaced in the selector, don't modify the cached policy
+		matched, err := match.CheckSelector(description.NamespaceSelector.DeepCopy(), namespace.Labels)
+		return err == nil && matched
+	}
+	return true
+}
+
+// mode returns the validation failure action of the policy in the namespace, the namespace annotation
+// takes precedence over the policy overrides
+func mode(policy kyvernov1.PolicyInterface, namespace Namespace) kyvernov1.ValidationFailureAction {
+	if action, ok := kyvernov1.GetNamespaceValidationFailureAction(namespace.Annotations, policy.GetName()); ok {
+		return action
+	}
+	spec := policy.GetSpec()
+	for _, override := range spec.ValidationFailureActionOverrides {
+		if !override.Action.IsValid() {
+			continue
+		}
+		if len(override.Namespaces) != 0 && !wildcard.CheckPatterns(override.Namespaces, namespace.Name) {
+			continue
+		}
+		if override.NamespaceSelector != nil {
+			if namespace.Labels == nil {
+				continue
+			}
+			if matched, err := match.CheckSelector(override.NamespaceSelector.DeepCopy(), namespace.Labels); err != nil || !matched {
+				continue
+			}
+		}
+		return override.Action
+	}
+	return spec.ValidationFailureAction
+}
@@ -0,0 +1,147 @@
+package catalog
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kyverno/kyverno/api/kyverno"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"gotest.tools/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func validateRule(name string, filters ...kyvernov1.ResourceFilter) kyvernov1.Rule {
+	return kyvernov1.Rule{
+		Name:           name,
+		MatchResources: kyvernov1.MatchResources{Any: filters},
+		Validation:     kyvernov1.Validation{Message: "invalid"},
+	}
+}
+
+func newPolicies() []kyvernov1.PolicyInterface {
+	background := false
+	return []kyvernov1.PolicyInterface{
+		&kyvernov1.ClusterPolicy{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "require-labels",
+				Annotations: map[string]string{
+					kyverno.AnnotationPolicyTitle:    "Require Labels",
+					kyverno.AnnotationPolicyCategory: "Best Practices",
+				},
+			},
+			Spec: kyvernov1.Spec{
+				ValidationFailureAction: kyvernov1.Audit,
+				ValidationFailureActionOverrides: []kyvernov1.ValidationFailureActionOverride{
+					{Action: kyvernov1.Enforce, Namespaces: []string{"prod-*"}},
+				},
+				Rules: []kyvernov1.Rule{
+					validateRule("check-team", kyvernov1.ResourceFilter{
+						ResourceDescription: kyvernov1.ResourceDescription{Kinds: []string{"ConfigMap"}},
+					}),
+				},
+			},
+		},
+		&kyvernov1.ClusterPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "restrict-tenants"},
+			Spec: kyvernov1.Spec{
+				ValidationFailureAction: kyvernov1.Enforce,
+				Background:              &background,
+				Rules: []kyvernov1.Rule{
+					validateRule("check-tenant", kyvernov1.ResourceFilter{
+						ResourceDescription: kyvernov1.ResourceDescription{
+							Kinds:             []string{"ConfigMap"},
+							NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"tenant": "true"}},
+						},
+					}),
+				},
+			},
+		},
+		&kyvernov1.Policy{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "team-a", Name: "require-env"},
+			Spec: kyvernov1.Spec{
+				Rules: []kyvernov1.Rule{
+					validateRule("check-env", kyvernov1.ResourceFilter{
+						ResourceDescription: kyvernov1.ResourceDescription{Kinds: []string{"ConfigMap"}},
+					}),
+				},
+			},
+		},
+	}
+}
+
+func names(catalog Catalog) []string {
+	var names []string
+	for _, policy := range catalog.Policies {
+		names = append(names, policy.Name)
+	}
+	return names
+}
+
+func Test_Build(t *testing.T) {
+	catalog := Build(context.TODO(), newPolicies(), nil)
+	assert.DeepEqual(t, names(catalog), []string{"require-labels", "restrict-tenants", "require-env"})
+	policy := catalog.Policies[0]
+	assert.Equal(t, policy.Kind, "ClusterPolicy")
+	assert.Equal(t, policy.Title, "Require Labels")
+	assert.Equal(t, policy.Category, "Best Practices")
+	assert.Equal(t, policy.Mode, kyvernov1.Audit)
+	assert.Equal(t, len(policy.ModeOverrides), 1)
+	assert.Equal(t, policy.Background, true)
+	assert.Equal(t, policy.Webhooks.Validating, true)
+	assert.Equal(t, policy.Webhooks.Mutating, false)
+	assert.Equal(t, policy.Webhooks.FailurePolicy, kyvernov1.Fail)
+	assert.Equal(t, len(policy.Rules), 1)
+	assert.Equal(t, policy.Rules[0].Type, "validate")
+	assert.DeepEqual(t, policy.Rules[0].Match.Any[0].Kinds, []string{"ConfigMap"})
+	assert.Equal(t, catalog.Policies[1].Background, false)
+	assert.Equal(t, catalog.Policies[2].Kind, "Policy")
+	assert.Equal(t, catalog.Policies[2].Namespace, "team-a")
+}
+
+func Test_Build_Namespace(t *testing.T) {
+	tests := []struct {
+		name      string
+		namespace Namespace
+		want      []string
+		wantMode  kyvernov1.ValidationFailureAction
+	}{{
+		name:      "namespace selector not matching",
+		namespace: Namespace{Name: "team-a", Labels: map[string]string{}},
+		want:      []string{"require-labels", "require-env"},
+		wantMode:  kyvernov1.Audit,
+	}, {
+		name:      "namespace selector matching",
+		namespace: Namespace{Name: "team-b", Labels: map[string]string{"tenant": "true"}},
+		want:      []string{"require-labels", "restrict-tenants"},
+		wantMode:  kyvernov1.Audit,
+	}, {
+		name:      "unknown labels",
+		namespace: Namespace{Name: "team-b"},
+		want:      []string{"require-labels", "restrict-tenants"},
+		wantMode:  kyvernov1.Audit,
+	}, {
+		name:      "mode override",
+		namespace: Namespace{Name: "prod-a", Labels: map[string]string{}},
+		want:      []string{"require-labels"},
+		wantMode:  kyvernov1.Enforce,
+	}, {
+		name: "namespace annotation",
+		namespace: Namespace{
+			Name:        "prod-a",
+			Labels:      map[string]string{},
+			Annotations: map[string]string{kyverno.AnnotationValidationFailureActions: "require-*=Audit"},
+		},
+		want:     []string{"require-labels"},
+		wantMode: kyvernov1.Audit,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			namespace := tt.namespace
+			catalog := Build(context.TODO(), newPolicies(), &namespace)
+			assert.Equal(t, catalog.Namespace, tt.namespace.Name)
+			assert.DeepEqual(t, names(catalog), tt.want)
+			assert.Equal(t, catalog.Policies[0].Mode, tt.wantMode)
+			assert.Assert(t, catalog.Policies[0].ModeOverrides == nil)
+		})
+	}
+}
@@ -30,6 +30,7 @@ type APICallApplyConfiguration struct {
 	Data     []RequestDataApplyConfiguration `json:"data,omitempty"`
 	Service  *ServiceCallApplyConfiguration  `json:"service,omitempty"`
 	JMESPath *string                         `json:"jmesPath,omitempty"`
+	List     *APICallListApplyConfiguration  `json:"list,omitempty"`
 }
 
 // APICallApplyConfiguration constructs an declarative configuration of the APICall type for use with
@@ -82,3 +83,11 @@ func (b *APICallApplyConfiguration) WithJMESPath(value string) *APICallApplyConf
 	b.JMESPath = &value
 	return b
 }
+
+// WithList sets the List field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the List field is set to the value of the last call.
+func (b *APICallApplyConfiguration) WithList(value *APICallListApplyConfiguration) *APICallApplyConfiguration {
+	b.List = value
+	return b
+}
@@ -0,0 +1,75 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1
+
+// APICallListApplyConfiguration represents an declarative configuration of the APICallList type for use
+// with apply.
+type APICallListApplyConfiguration struct {
+	LabelSelector *string `json:"labelSelector,omitempty"`
+	FieldSelector *string `json:"fieldSelector,omitempty"`
+	PageSize      *int64  `json:"pageSize,omitempty"`
+	MaxItems      *int64  `json:"maxItems,omitempty"`
+	Projection    *string `json:"projection,omitempty"`
+}
+
+// APICallListApplyConfiguration constructs an declarative configuration of the APICallList type for use with
+// apply.
+func APICallList() *APICallListApplyConfiguration {
+	return &APICallListApplyConfiguration{}
+}
+
+// WithLabelSelector sets the LabelSelector field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the LabelSelector field is set to the value of the last call.
+func (b *APICallListApplyConfiguration) WithLabelSelector(value string) *APICallListApplyConfiguration {
+	b.LabelSelector = &value
+	return b
+}
+
+// WithFieldSelector sets the FieldSelector field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the FieldSelector field is set to the value of the last call.
+func (b *APICallListApplyConfiguration) WithFieldSelector(value string) *APICallListApplyConfiguration {
+	b.FieldSelector = &value
+	return b
+}
+
+// WithPageSize sets the PageSize field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the PageSize field is set to the value of the last call.
+func (b *APICallListApplyConfiguration) WithPageSize(value int64) *APICallListApplyConfiguration {
+	b.PageSize = &value
+	return b
+}
+
+// WithMaxItems sets the MaxItems field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the MaxItems field is set to the value of the last call.
+func (b *APICallListApplyConfiguration) WithMaxItems(value int64) *APICallListApplyConfiguration {
+	b.MaxItems = &value
+	return b
+}
+
+// WithProjection sets the Projection field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Projection field is set to the value of the last call.
+func (b *APICallListApplyConfiguration) WithProjection(value string) *APICallListApplyConfiguration {
+	b.Projection = &value
+	return b
+}
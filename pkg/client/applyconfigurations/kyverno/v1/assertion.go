@@ -0,0 +1,58 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1
+
+// AssertionApplyConfiguration represents an declarative configuration of the Assertion type for use
+// with apply.
+type AssertionApplyConfiguration struct {
+	Any []AssertionCheckApplyConfiguration `json:"any,omitempty"`
+	All []AssertionCheckApplyConfiguration `json:"all,omitempty"`
+}
+
+// AssertionApplyConfiguration constructs an declarative configuration of the Assertion type for use with
+// apply.
+func Assertion() *AssertionApplyConfiguration {
+	return &AssertionApplyConfiguration{}
+}
+
+// WithAny adds the given value to the Any field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Any field.
+func (b *AssertionApplyConfiguration) WithAny(values ...*AssertionCheckApplyConfiguration) *AssertionApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithAny")
+		}
+		b.Any = append(b.Any, *values[i])
+	}
+	return b
+}
+
+// WithAll adds the given value to the All field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the All field.
+func (b *AssertionApplyConfiguration) WithAll(values ...*AssertionCheckApplyConfiguration) *AssertionApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithAll")
+		}
+		b.All = append(b.All, *values[i])
+	}
+	return b
+}
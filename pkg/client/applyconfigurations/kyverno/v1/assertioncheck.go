@@ -0,0 +1,52 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	v1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+)
+
+// AssertionCheckApplyConfiguration represents an declarative configuration of the AssertionCheck type for use
+// with apply.
+type AssertionCheckApplyConfiguration struct {
+	Message  *string  `json:"message,omitempty"`
+	RawCheck *v1.JSON `json:"check,omitempty"`
+}
+
+// AssertionCheckApplyConfiguration constructs an declarative configuration of the AssertionCheck type for use with
+// apply.
+func AssertionCheck() *AssertionCheckApplyConfiguration {
+	return &AssertionCheckApplyConfiguration{}
+}
+
+// WithMessage sets the Message field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Message field is set to the value of the last call.
+func (b *AssertionCheckApplyConfiguration) WithMessage(value string) *AssertionCheckApplyConfiguration {
+	b.Message = &value
+	return b
+}
+
+// WithRawCheck sets the RawCheck field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the RawCheck field is set to the value of the last call.
+func (b *AssertionCheckApplyConfiguration) WithRawCheck(value v1.JSON) *AssertionCheckApplyConfiguration {
+	b.RawCheck = &value
+	return b
+}
@@ -26,6 +26,9 @@ type ContextEntryApplyConfiguration struct {
 	APICall       *APICallApplyConfiguration            `json:"apiCall,omitempty"`
 	ImageRegistry *ImageRegistryApplyConfiguration      `json:"imageRegistry,omitempty"`
 	Variable      *VariableApplyConfiguration           `json:"variable,omitempty"`
+	ResourceUsage *ResourceUsageApplyConfiguration      `json:"resourceUsage,omitempty"`
+	ImageDigest   *ImageDigestApplyConfiguration        `json:"imageDigest,omitempty"`
+	Fallback      *ContextFallbackApplyConfiguration    `json:"fallback,omitempty"`
 }
 
 // ContextEntryApplyConfiguration constructs an declarative configuration of the ContextEntry type for use with
@@ -73,3 +76,27 @@ func (b *ContextEntryApplyConfiguration) WithVariable(value *VariableApplyConfig
 	b.Variable = value
 	return b
 }
+
+// WithResourceUsage sets the ResourceUsage field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ResourceUsage field is set to the value of the last call.
+func (b *ContextEntryApplyConfiguration) WithResourceUsage(value *ResourceUsageApplyConfiguration) *ContextEntryApplyConfiguration {
+	b.ResourceUsage = value
+	return b
+}
+
+// WithImageDigest sets the ImageDigest field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ImageDigest field is set to the value of the last call.
+func (b *ContextEntryApplyConfiguration) WithImageDigest(value *ImageDigestApplyConfiguration) *ContextEntryApplyConfiguration {
+	b.ImageDigest = value
+	return b
+}
+
+// WithFallback sets the Fallback field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Fallback field is set to the value of the last call.
+func (b *ContextEntryApplyConfiguration) WithFallback(value *ContextFallbackApplyConfiguration) *ContextEntryApplyConfiguration {
+	b.Fallback = value
+	return b
+}
@@ -25,14 +25,15 @@ import (
 // ForEachValidationApplyConfiguration represents an declarative configuration of the ForEachValidation type for use
 // with apply.
 type ForEachValidationApplyConfiguration struct {
-	List              *string                             `json:"list,omitempty"`
-	ElementScope      *bool                               `json:"elementScope,omitempty"`
-	Context           []ContextEntryApplyConfiguration    `json:"context,omitempty"`
-	AnyAllConditions  *AnyAllConditionsApplyConfiguration `json:"preconditions,omitempty"`
-	RawPattern        *apiextensionsv1.JSON               `json:"pattern,omitempty"`
-	RawAnyPattern     *apiextensionsv1.JSON               `json:"anyPattern,omitempty"`
-	Deny              *DenyApplyConfiguration             `json:"deny,omitempty"`
-	ForEachValidation *apiextensionsv1.JSON               `json:"foreach,omitempty"`
+	List               *string                             `json:"list,omitempty"`
+	ElementScope       *bool                               `json:"elementScope,omitempty"`
+	Context            []ContextEntryApplyConfiguration    `json:"context,omitempty"`
+	AnyAllConditions   *AnyAllConditionsApplyConfiguration `json:"preconditions,omitempty"`
+	RawPattern         *apiextensionsv1.JSON               `json:"pattern,omitempty"`
+	RawAnyPattern      *apiextensionsv1.JSON               `json:"anyPattern,omitempty"`
+	AnyPatternMessages []string                            `json:"anyPatternMessages,omitempty"`
+	Deny               *DenyApplyConfiguration             `json:"deny,omitempty"`
+	ForEachValidation  *apiextensionsv1.JSON               `json:"foreach,omitempty"`
 }
 
 // ForEachValidationApplyConfiguration constructs an declarative configuration of the ForEachValidation type for use with
@@ -94,6 +95,16 @@ func (b *ForEachValidationApplyConfiguration) WithRawAnyPattern(value apiextensi
 	return b
 }
 
+// WithAnyPatternMessages adds the given value to the AnyPatternMessages field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the AnyPatternMessages field.
+func (b *ForEachValidationApplyConfiguration) WithAnyPatternMessages(values ...string) *ForEachValidationApplyConfiguration {
+	for i := range values {
+		b.AnyPatternMessages = append(b.AnyPatternMessages, values[i])
+	}
+	return b
+}
+
 // WithDeny sets the Deny field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the Deny field is set to the value of the last call.
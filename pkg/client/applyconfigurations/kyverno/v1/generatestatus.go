@@ -0,0 +1,57 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1
+
+// GenerateStatusApplyConfiguration represents an declarative configuration of the GenerateStatus type for use
+// with apply.
+type GenerateStatusApplyConfiguration struct {
+	InSync    *int `json:"inSync,omitempty"`
+	OutOfSync *int `json:"outOfSync,omitempty"`
+	Failed    *int `json:"failed,omitempty"`
+}
+
+// GenerateStatusApplyConfiguration constructs an declarative configuration of the GenerateStatus type for use with
+// apply.
+func GenerateStatus() *GenerateStatusApplyConfiguration {
+	return &GenerateStatusApplyConfiguration{}
+}
+
+// WithInSync sets the InSync field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the InSync field is set to the value of the last call.
+func (b *GenerateStatusApplyConfiguration) WithInSync(value int) *GenerateStatusApplyConfiguration {
+	b.InSync = &value
+	return b
+}
+
+// WithOutOfSync sets the OutOfSync field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the OutOfSync field is set to the value of the last call.
+func (b *GenerateStatusApplyConfiguration) WithOutOfSync(value int) *GenerateStatusApplyConfiguration {
+	b.OutOfSync = &value
+	return b
+}
+
+// WithFailed sets the Failed field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Failed field is set to the value of the last call.
+func (b *GenerateStatusApplyConfiguration) WithFailed(value int) *GenerateStatusApplyConfiguration {
+	b.Failed = &value
+	return b
+}
@@ -26,10 +26,13 @@ import (
 // with apply.
 type GenerationApplyConfiguration struct {
 	*ResourceSpecApplyConfiguration `json:"ResourceSpec,omitempty"`
-	Synchronize                     *bool                        `json:"synchronize,omitempty"`
-	RawData                         *apiextensionsv1.JSON        `json:"data,omitempty"`
-	Clone                           *CloneFromApplyConfiguration `json:"clone,omitempty"`
-	CloneList                       *CloneListApplyConfiguration `json:"cloneList,omitempty"`
+	Synchronize                     *bool                                      `json:"synchronize,omitempty"`
+	OrphanDownstreamOnPolicyDelete  *bool                                      `json:"orphanDownstreamOnPolicyDelete,omitempty"`
+	ServiceAccount                  *ServiceAccountReferenceApplyConfiguration `json:"serviceAccount,omitempty"`
+	Cluster                         *ClusterReferenceApplyConfiguration        `json:"cluster,omitempty"`
+	RawData                         *apiextensionsv1.JSON                      `json:"data,omitempty"`
+	Clone                           *CloneFromApplyConfiguration               `json:"clone,omitempty"`
+	CloneList                       *CloneListApplyConfiguration               `json:"cloneList,omitempty"`
 }
 
 // GenerationApplyConfiguration constructs an declarative configuration of the Generation type for use with
@@ -88,6 +91,30 @@ func (b *GenerationApplyConfiguration) WithSynchronize(value bool) *GenerationAp
 	return b
 }
 
+// WithOrphanDownstreamOnPolicyDelete sets the OrphanDownstreamOnPolicyDelete field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the OrphanDownstreamOnPolicyDelete field is set to the value of the last call.
+func (b *GenerationApplyConfiguration) WithOrphanDownstreamOnPolicyDelete(value bool) *GenerationApplyConfiguration {
+	b.OrphanDownstreamOnPolicyDelete = &value
+	return b
+}
+
+// WithServiceAccount sets the ServiceAccount field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ServiceAccount field is set to the value of the last call.
+func (b *GenerationApplyConfiguration) WithServiceAccount(value *ServiceAccountReferenceApplyConfiguration) *GenerationApplyConfiguration {
+	b.ServiceAccount = value
+	return b
+}
+
+// WithCluster sets the Cluster field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Cluster field is set to the value of the last call.
+func (b *GenerationApplyConfiguration) WithCluster(value *ClusterReferenceApplyConfiguration) *GenerationApplyConfiguration {
+	b.Cluster = value
+	return b
+}
+
 // WithRawData sets the RawData field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the RawData field is set to the value of the last call.
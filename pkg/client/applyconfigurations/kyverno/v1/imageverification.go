@@ -42,6 +42,9 @@ type ImageVerificationApplyConfiguration struct {
 	Required                 *bool                                       `json:"required,omitempty"`
 	ImageRegistryCredentials *ImageRegistryCredentialsApplyConfiguration `json:"imageRegistryCredentials,omitempty"`
 	UseCache                 *bool                                       `json:"useCache,omitempty"`
+	Strict                   *bool                                       `json:"strict,omitempty"`
+	Platforms                []string                                    `json:"platforms,omitempty"`
+	VerifyIndex              *bool                                       `json:"verifyIndex,omitempty"`
 }
 
 // ImageVerificationApplyConfiguration constructs an declarative configuration of the ImageVerification type for use with
@@ -209,3 +212,29 @@ func (b *ImageVerificationApplyConfiguration) WithUseCache(value bool) *ImageVer
 	b.UseCache = &value
 	return b
 }
+
+// WithStrict sets the Strict field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Strict field is set to the value of the last call.
+func (b *ImageVerificationApplyConfiguration) WithStrict(value bool) *ImageVerificationApplyConfiguration {
+	b.Strict = &value
+	return b
+}
+
+// WithPlatforms adds the given value to the Platforms field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Platforms field.
+func (b *ImageVerificationApplyConfiguration) WithPlatforms(values ...string) *ImageVerificationApplyConfiguration {
+	for i := range values {
+		b.Platforms = append(b.Platforms, values[i])
+	}
+	return b
+}
+
+// WithVerifyIndex sets the VerifyIndex field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the VerifyIndex field is set to the value of the last call.
+func (b *ImageVerificationApplyConfiguration) WithVerifyIndex(value bool) *ImageVerificationApplyConfiguration {
+	b.VerifyIndex = &value
+	return b
+}
@@ -26,6 +26,7 @@ import (
 // with apply.
 type MutationApplyConfiguration struct {
 	Targets                []TargetResourceSpecApplyConfiguration `json:"targets,omitempty"`
+	AllowStatusMutation    *bool                                  `json:"allowStatusMutation,omitempty"`
 	RawPatchStrategicMerge *apiextensionsv1.JSON                  `json:"patchStrategicMerge,omitempty"`
 	PatchesJSON6902        *string                                `json:"patchesJson6902,omitempty"`
 	ForEachMutation        []ForEachMutationApplyConfiguration    `json:"foreach,omitempty"`
@@ -50,6 +51,14 @@ func (b *MutationApplyConfiguration) WithTargets(values ...*TargetResourceSpecAp
 	return b
 }
 
+// WithAllowStatusMutation sets the AllowStatusMutation field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the AllowStatusMutation field is set to the value of the last call.
+func (b *MutationApplyConfiguration) WithAllowStatusMutation(value bool) *MutationApplyConfiguration {
+	b.AllowStatusMutation = &value
+	return b
+}
+
 // WithRawPatchStrategicMerge sets the RawPatchStrategicMerge field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the RawPatchStrategicMerge field is set to the value of the last call.
@@ -21,8 +21,9 @@ package v1
 // PodSecurityStandardApplyConfiguration represents an declarative configuration of the PodSecurityStandard type for use
 // with apply.
 type PodSecurityStandardApplyConfiguration struct {
-	ControlName *string  `json:"controlName,omitempty"`
-	Images      []string `json:"images,omitempty"`
+	ControlName    *string  `json:"controlName,omitempty"`
+	Images         []string `json:"images,omitempty"`
+	ContainerNames []string `json:"containerNames,omitempty"`
 }
 
 // PodSecurityStandardApplyConfiguration constructs an declarative configuration of the PodSecurityStandard type for use with
@@ -48,3 +49,13 @@ func (b *PodSecurityStandardApplyConfiguration) WithImages(values ...string) *Po
 	}
 	return b
 }
+
+// WithContainerNames adds the given value to the ContainerNames field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the ContainerNames field.
+func (b *PodSecurityStandardApplyConfiguration) WithContainerNames(values ...string) *PodSecurityStandardApplyConfiguration {
+	for i := range values {
+		b.ContainerNames = append(b.ContainerNames, values[i])
+	}
+	return b
+}
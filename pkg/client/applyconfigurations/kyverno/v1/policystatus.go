@@ -29,6 +29,7 @@ type PolicyStatusApplyConfiguration struct {
 	Conditions []v1.Condition                     `json:"conditions,omitempty"`
 	Autogen    *AutogenStatusApplyConfiguration   `json:"autogen,omitempty"`
 	RuleCount  *RuleCountStatusApplyConfiguration `json:"rulecount,omitempty"`
+	Generate   *GenerateStatusApplyConfiguration  `json:"generate,omitempty"`
 }
 
 // PolicyStatusApplyConfiguration constructs an declarative configuration of the PolicyStatus type for use with
@@ -70,3 +71,11 @@ func (b *PolicyStatusApplyConfiguration) WithRuleCount(value *RuleCountStatusApp
 	b.RuleCount = value
 	return b
 }
+
+// WithGenerate sets the Generate field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Generate field is set to the value of the last call.
+func (b *PolicyStatusApplyConfiguration) WithGenerate(value *GenerateStatusApplyConfiguration) *PolicyStatusApplyConfiguration {
+	b.Generate = value
+	return b
+}
@@ -0,0 +1,52 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	v1 "github.com/kyverno/kyverno/api/kyverno/v1"
+)
+
+// RolloutApplyConfiguration represents an declarative configuration of the Rollout type for use
+// with apply.
+type RolloutApplyConfiguration struct {
+	Mode       *v1.RolloutMode `json:"mode,omitempty"`
+	Percentage *int            `json:"percentage,omitempty"`
+}
+
+// RolloutApplyConfiguration constructs an declarative configuration of the Rollout type for use with
+// apply.
+func Rollout() *RolloutApplyConfiguration {
+	return &RolloutApplyConfiguration{}
+}
+
+// WithMode sets the Mode field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Mode field is set to the value of the last call.
+func (b *RolloutApplyConfiguration) WithMode(value v1.RolloutMode) *RolloutApplyConfiguration {
+	b.Mode = &value
+	return b
+}
+
+// WithPercentage sets the Percentage field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Percentage field is set to the value of the last call.
+func (b *RolloutApplyConfiguration) WithPercentage(value int) *RolloutApplyConfiguration {
+	b.Percentage = &value
+	return b
+}
@@ -38,6 +38,7 @@ type RuleApplyConfiguration struct {
 	Validation          *ValidationApplyConfiguration            `json:"validate,omitempty"`
 	Generation          *GenerationApplyConfiguration            `json:"generate,omitempty"`
 	VerifyImages        []ImageVerificationApplyConfiguration    `json:"verifyImages,omitempty"`
+	Schedule            *ScheduleApplyConfiguration              `json:"schedule,omitempty"`
 }
 
 // RuleApplyConfiguration constructs an declarative configuration of the Rule type for use with
@@ -145,3 +146,11 @@ func (b *RuleApplyConfiguration) WithVerifyImages(values ...*ImageVerificationAp
 	}
 	return b
 }
+
+// WithSchedule sets the Schedule field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Schedule field is set to the value of the last call.
+func (b *RuleApplyConfiguration) WithSchedule(value *ScheduleApplyConfiguration) *RuleApplyConfiguration {
+	b.Schedule = value
+	return b
+}
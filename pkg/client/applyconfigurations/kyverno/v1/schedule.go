@@ -0,0 +1,61 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ScheduleApplyConfiguration represents an declarative configuration of the Schedule type for use
+// with apply.
+type ScheduleApplyConfiguration struct {
+	Cron      *string      `json:"cron,omitempty"`
+	NotBefore *metav1.Time `json:"notBefore,omitempty"`
+	NotAfter  *metav1.Time `json:"notAfter,omitempty"`
+}
+
+// ScheduleApplyConfiguration constructs an declarative configuration of the Schedule type for use with
+// apply.
+func Schedule() *ScheduleApplyConfiguration {
+	return &ScheduleApplyConfiguration{}
+}
+
+// WithCron sets the Cron field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Cron field is set to the value of the last call.
+func (b *ScheduleApplyConfiguration) WithCron(value string) *ScheduleApplyConfiguration {
+	b.Cron = &value
+	return b
+}
+
+// WithNotBefore sets the NotBefore field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the NotBefore field is set to the value of the last call.
+func (b *ScheduleApplyConfiguration) WithNotBefore(value metav1.Time) *ScheduleApplyConfiguration {
+	b.NotBefore = &value
+	return b
+}
+
+// WithNotAfter sets the NotAfter field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the NotAfter field is set to the value of the last call.
+func (b *ScheduleApplyConfiguration) WithNotAfter(value metav1.Time) *ScheduleApplyConfiguration {
+	b.NotAfter = &value
+	return b
+}
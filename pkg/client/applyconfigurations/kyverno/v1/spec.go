@@ -38,6 +38,10 @@ type SpecApplyConfiguration struct {
 	GenerateExistingOnPolicyUpdate   *bool                                               `json:"generateExistingOnPolicyUpdate,omitempty"`
 	GenerateExisting                 *bool                                               `json:"generateExisting,omitempty"`
 	UseServerSideApply               *bool                                               `json:"useServerSideApply,omitempty"`
+	ParamKind                        *ParamKindApplyConfiguration                        `json:"paramKind,omitempty"`
+	ParamRef                         *ParamRefApplyConfiguration                         `json:"paramRef,omitempty"`
+	Schedule                         *ScheduleApplyConfiguration                         `json:"schedule,omitempty"`
+	Rollout                          *RolloutApplyConfiguration                          `json:"rollout,omitempty"`
 }
 
 // SpecApplyConfiguration constructs an declarative configuration of the Spec type for use with
@@ -159,3 +163,35 @@ func (b *SpecApplyConfiguration) WithUseServerSideApply(value bool) *SpecApplyCo
 	b.UseServerSideApply = &value
 	return b
 }
+
+// WithParamKind sets the ParamKind field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ParamKind field is set to the value of the last call.
+func (b *SpecApplyConfiguration) WithParamKind(value *ParamKindApplyConfiguration) *SpecApplyConfiguration {
+	b.ParamKind = value
+	return b
+}
+
+// WithParamRef sets the ParamRef field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ParamRef field is set to the value of the last call.
+func (b *SpecApplyConfiguration) WithParamRef(value *ParamRefApplyConfiguration) *SpecApplyConfiguration {
+	b.ParamRef = value
+	return b
+}
+
+// WithSchedule sets the Schedule field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Schedule field is set to the value of the last call.
+func (b *SpecApplyConfiguration) WithSchedule(value *ScheduleApplyConfiguration) *SpecApplyConfiguration {
+	b.Schedule = value
+	return b
+}
+
+// WithRollout sets the Rollout field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Rollout field is set to the value of the last call.
+func (b *SpecApplyConfiguration) WithRollout(value *RolloutApplyConfiguration) *SpecApplyConfiguration {
+	b.Rollout = value
+	return b
+}
@@ -25,14 +25,18 @@ import (
 // ValidationApplyConfiguration represents an declarative configuration of the Validation type for use
 // with apply.
 type ValidationApplyConfiguration struct {
-	Message           *string                               `json:"message,omitempty"`
-	Manifests         *ManifestsApplyConfiguration          `json:"manifests,omitempty"`
-	ForEachValidation []ForEachValidationApplyConfiguration `json:"foreach,omitempty"`
-	RawPattern        *apiextensionsv1.JSON                 `json:"pattern,omitempty"`
-	RawAnyPattern     *apiextensionsv1.JSON                 `json:"anyPattern,omitempty"`
-	Deny              *DenyApplyConfiguration               `json:"deny,omitempty"`
-	PodSecurity       *PodSecurityApplyConfiguration        `json:"podSecurity,omitempty"`
-	CEL               *CELApplyConfiguration                `json:"cel,omitempty"`
+	Message            *string                               `json:"message,omitempty"`
+	Manifests          *ManifestsApplyConfiguration          `json:"manifests,omitempty"`
+	ForEachValidation  []ForEachValidationApplyConfiguration `json:"foreach,omitempty"`
+	RawPattern         *apiextensionsv1.JSON                 `json:"pattern,omitempty"`
+	RawAnyPattern      *apiextensionsv1.JSON                 `json:"anyPattern,omitempty"`
+	AnyPatternMessages []string                              `json:"anyPatternMessages,omitempty"`
+	Deny               *DenyApplyConfiguration               `json:"deny,omitempty"`
+	PodSecurity        *PodSecurityApplyConfiguration        `json:"podSecurity,omitempty"`
+	CEL                *CELApplyConfiguration                `json:"cel,omitempty"`
+	Assert             *AssertionApplyConfiguration          `json:"assert,omitempty"`
+	Severity           *string                               `json:"severity,omitempty"`
+	Properties         map[string]string                     `json:"properties,omitempty"`
 }
 
 // ValidationApplyConfiguration constructs an declarative configuration of the Validation type for use with
@@ -86,6 +90,16 @@ func (b *ValidationApplyConfiguration) WithRawAnyPattern(value apiextensionsv1.J
 	return b
 }
 
+// WithAnyPatternMessages adds the given value to the AnyPatternMessages field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the AnyPatternMessages field.
+func (b *ValidationApplyConfiguration) WithAnyPatternMessages(values ...string) *ValidationApplyConfiguration {
+	for i := range values {
+		b.AnyPatternMessages = append(b.AnyPatternMessages, values[i])
+	}
+	return b
+}
+
 // WithDeny sets the Deny field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the Deny field is set to the value of the last call.
@@ -109,3 +123,33 @@ func (b *ValidationApplyConfiguration) WithCEL(value *CELApplyConfiguration) *Va
 	b.CEL = value
 	return b
 }
+
+// WithAssert sets the Assert field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Assert field is set to the value of the last call.
+func (b *ValidationApplyConfiguration) WithAssert(value *AssertionApplyConfiguration) *ValidationApplyConfiguration {
+	b.Assert = value
+	return b
+}
+
+// WithSeverity sets the Severity field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Severity field is set to the value of the last call.
+func (b *ValidationApplyConfiguration) WithSeverity(value string) *ValidationApplyConfiguration {
+	b.Severity = &value
+	return b
+}
+
+// WithProperties puts the entries into the Properties field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, the entries provided by each call will be put on the Properties field,
+// overwriting an existing map entries in Properties field with the same key.
+func (b *ValidationApplyConfiguration) WithProperties(entries map[string]string) *ValidationApplyConfiguration {
+	if b.Properties == nil && len(entries) > 0 {
+		b.Properties = make(map[string]string, len(entries))
+	}
+	for k, v := range entries {
+		b.Properties[k] = v
+	}
+	return b
+}
@@ -21,6 +21,7 @@ package v1beta1
 import (
 	v1beta1 "github.com/kyverno/kyverno/api/kyverno/v1beta1"
 	v1 "github.com/kyverno/kyverno/pkg/client/applyconfigurations/kyverno/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 // UpdateRequestStatusApplyConfiguration represents an declarative configuration of the UpdateRequestStatus type for use
@@ -29,6 +30,8 @@ type UpdateRequestStatusApplyConfiguration struct {
 	Handler            *string                             `json:"handler,omitempty"`
 	State              *v1beta1.UpdateRequestState         `json:"state,omitempty"`
 	Message            *string                             `json:"message,omitempty"`
+	RetryCount         *int                                `json:"retryCount,omitempty"`
+	NextRetryTime      *metav1.Time                        `json:"nextRetryTime,omitempty"`
 	GeneratedResources []v1.ResourceSpecApplyConfiguration `json:"generatedResources,omitempty"`
 }
 
@@ -62,6 +65,22 @@ func (b *UpdateRequestStatusApplyConfiguration) WithMessage(value string) *Updat
 	return b
 }
 
+// WithRetryCount sets the RetryCount field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the RetryCount field is set to the value of the last call.
+func (b *UpdateRequestStatusApplyConfiguration) WithRetryCount(value int) *UpdateRequestStatusApplyConfiguration {
+	b.RetryCount = &value
+	return b
+}
+
+// WithNextRetryTime sets the NextRetryTime field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the NextRetryTime field is set to the value of the last call.
+func (b *UpdateRequestStatusApplyConfiguration) WithNextRetryTime(value metav1.Time) *UpdateRequestStatusApplyConfiguration {
+	b.NextRetryTime = &value
+	return b
+}
+
 // WithGeneratedResources adds the given value to the GeneratedResources field in the declarative configuration
 // and returns the receiver, so that objects can be build by chaining "With" function invocations.
 // If called multiple times, values provided by each call will be appended to the GeneratedResources field.
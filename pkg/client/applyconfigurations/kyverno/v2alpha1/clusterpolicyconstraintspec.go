@@ -0,0 +1,77 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v2alpha1
+
+import (
+	v2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ClusterPolicyConstraintSpecApplyConfiguration represents an declarative configuration of the ClusterPolicyConstraintSpec type for use
+// with apply.
+type ClusterPolicyConstraintSpecApplyConfiguration struct {
+	NamespaceSelector        *v1.LabelSelector                            `json:"namespaceSelector,omitempty"`
+	RuleTypes                []v2alpha1.ConstraintRuleType                `json:"ruleTypes,omitempty"`
+	ContextEntryTypes        []v2alpha1.ConstraintContextEntryType        `json:"contextEntryTypes,omitempty"`
+	ValidationFailureActions []v2alpha1.ConstraintValidationFailureAction `json:"validationFailureActions,omitempty"`
+}
+
+// ClusterPolicyConstraintSpecApplyConfiguration constructs an declarative configuration of the ClusterPolicyConstraintSpec type for use with
+// apply.
+func ClusterPolicyConstraintSpec() *ClusterPolicyConstraintSpecApplyConfiguration {
+	return &ClusterPolicyConstraintSpecApplyConfiguration{}
+}
+
+// WithNamespaceSelector sets the NamespaceSelector field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the NamespaceSelector field is set to the value of the last call.
+func (b *ClusterPolicyConstraintSpecApplyConfiguration) WithNamespaceSelector(value v1.LabelSelector) *ClusterPolicyConstraintSpecApplyConfiguration {
+	b.NamespaceSelector = &value
+	return b
+}
+
+// WithRuleTypes adds the given value to the RuleTypes field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the RuleTypes field.
+func (b *ClusterPolicyConstraintSpecApplyConfiguration) WithRuleTypes(values ...v2alpha1.ConstraintRuleType) *ClusterPolicyConstraintSpecApplyConfiguration {
+	for i := range values {
+		b.RuleTypes = append(b.RuleTypes, values[i])
+	}
+	return b
+}
+
+// WithContextEntryTypes adds the given value to the ContextEntryTypes field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the ContextEntryTypes field.
+func (b *ClusterPolicyConstraintSpecApplyConfiguration) WithContextEntryTypes(values ...v2alpha1.ConstraintContextEntryType) *ClusterPolicyConstraintSpecApplyConfiguration {
+	for i := range values {
+		b.ContextEntryTypes = append(b.ContextEntryTypes, values[i])
+	}
+	return b
+}
+
+// WithValidationFailureActions adds the given value to the ValidationFailureActions field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the ValidationFailureActions field.
+func (b *ClusterPolicyConstraintSpecApplyConfiguration) WithValidationFailureActions(values ...v2alpha1.ConstraintValidationFailureAction) *ClusterPolicyConstraintSpecApplyConfiguration {
+	for i := range values {
+		b.ValidationFailureActions = append(b.ValidationFailureActions, values[i])
+	}
+	return b
+}
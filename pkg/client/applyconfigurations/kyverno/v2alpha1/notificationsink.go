@@ -0,0 +1,66 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v2alpha1
+
+// NotificationSinkApplyConfiguration represents an declarative configuration of the NotificationSink type for use
+// with apply.
+type NotificationSinkApplyConfiguration struct {
+	Name      *string                          `json:"name,omitempty"`
+	Webhook   *WebhookSinkApplyConfiguration   `json:"webhook,omitempty"`
+	Slack     *SlackSinkApplyConfiguration     `json:"slack,omitempty"`
+	PagerDuty *PagerDutySinkApplyConfiguration `json:"pagerDuty,omitempty"`
+}
+
+// NotificationSinkApplyConfiguration constructs an declarative configuration of the NotificationSink type for use with
+// apply.
+func NotificationSink() *NotificationSinkApplyConfiguration {
+	return &NotificationSinkApplyConfiguration{}
+}
+
+// WithName sets the Name field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Name field is set to the value of the last call.
+func (b *NotificationSinkApplyConfiguration) WithName(value string) *NotificationSinkApplyConfiguration {
+	b.Name = &value
+	return b
+}
+
+// WithWebhook sets the Webhook field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Webhook field is set to the value of the last call.
+func (b *NotificationSinkApplyConfiguration) WithWebhook(value *WebhookSinkApplyConfiguration) *NotificationSinkApplyConfiguration {
+	b.Webhook = value
+	return b
+}
+
+// WithSlack sets the Slack field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Slack field is set to the value of the last call.
+func (b *NotificationSinkApplyConfiguration) WithSlack(value *SlackSinkApplyConfiguration) *NotificationSinkApplyConfiguration {
+	b.Slack = value
+	return b
+}
+
+// WithPagerDuty sets the PagerDuty field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the PagerDuty field is set to the value of the last call.
+func (b *NotificationSinkApplyConfiguration) WithPagerDuty(value *PagerDutySinkApplyConfiguration) *NotificationSinkApplyConfiguration {
+	b.PagerDuty = value
+	return b
+}
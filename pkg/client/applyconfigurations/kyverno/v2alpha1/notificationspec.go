@@ -0,0 +1,100 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v2alpha1
+
+import (
+	v2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// NotificationSpecApplyConfiguration represents an declarative configuration of the NotificationSpec type for use
+// with apply.
+type NotificationSpecApplyConfiguration struct {
+	Actions             []v2alpha1.NotificationAction            `json:"actions,omitempty"`
+	Policies            []string                                 `json:"policies,omitempty"`
+	Namespaces          []string                                 `json:"namespaces,omitempty"`
+	Threshold           *NotificationThresholdApplyConfiguration `json:"threshold,omitempty"`
+	DeduplicationWindow *v1.Duration                             `json:"deduplicationWindow,omitempty"`
+	Sinks               []NotificationSinkApplyConfiguration     `json:"sinks,omitempty"`
+}
+
+// NotificationSpecApplyConfiguration constructs an declarative configuration of the NotificationSpec type for use with
+// apply.
+func NotificationSpec() *NotificationSpecApplyConfiguration {
+	return &NotificationSpecApplyConfiguration{}
+}
+
+// WithActions adds the given value to the Actions field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Actions field.
+func (b *NotificationSpecApplyConfiguration) WithActions(values ...v2alpha1.NotificationAction) *NotificationSpecApplyConfiguration {
+	for i := range values {
+		b.Actions = append(b.Actions, values[i])
+	}
+	return b
+}
+
+// WithPolicies adds the given value to the Policies field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Policies field.
+func (b *NotificationSpecApplyConfiguration) WithPolicies(values ...string) *NotificationSpecApplyConfiguration {
+	for i := range values {
+		b.Policies = append(b.Policies, values[i])
+	}
+	return b
+}
+
+// WithNamespaces adds the given value to the Namespaces field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Namespaces field.
+func (b *NotificationSpecApplyConfiguration) WithNamespaces(values ...string) *NotificationSpecApplyConfiguration {
+	for i := range values {
+		b.Namespaces = append(b.Namespaces, values[i])
+	}
+	return b
+}
+
+// WithThreshold sets the Threshold field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Threshold field is set to the value of the last call.
+func (b *NotificationSpecApplyConfiguration) WithThreshold(value *NotificationThresholdApplyConfiguration) *NotificationSpecApplyConfiguration {
+	b.Threshold = value
+	return b
+}
+
+// WithDeduplicationWindow sets the DeduplicationWindow field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the DeduplicationWindow field is set to the value of the last call.
+func (b *NotificationSpecApplyConfiguration) WithDeduplicationWindow(value v1.Duration) *NotificationSpecApplyConfiguration {
+	b.DeduplicationWindow = &value
+	return b
+}
+
+// WithSinks adds the given value to the Sinks field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Sinks field.
+func (b *NotificationSpecApplyConfiguration) WithSinks(values ...*NotificationSinkApplyConfiguration) *NotificationSpecApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithSinks")
+		}
+		b.Sinks = append(b.Sinks, *values[i])
+	}
+	return b
+}
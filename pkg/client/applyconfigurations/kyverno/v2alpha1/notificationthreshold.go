@@ -0,0 +1,52 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v2alpha1
+
+import (
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// NotificationThresholdApplyConfiguration represents an declarative configuration of the NotificationThreshold type for use
+// with apply.
+type NotificationThresholdApplyConfiguration struct {
+	Count  *int         `json:"count,omitempty"`
+	Window *v1.Duration `json:"window,omitempty"`
+}
+
+// NotificationThresholdApplyConfiguration constructs an declarative configuration of the NotificationThreshold type for use with
+// apply.
+func NotificationThreshold() *NotificationThresholdApplyConfiguration {
+	return &NotificationThresholdApplyConfiguration{}
+}
+
+// WithCount sets the Count field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Count field is set to the value of the last call.
+func (b *NotificationThresholdApplyConfiguration) WithCount(value int) *NotificationThresholdApplyConfiguration {
+	b.Count = &value
+	return b
+}
+
+// WithWindow sets the Window field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Window field is set to the value of the last call.
+func (b *NotificationThresholdApplyConfiguration) WithWindow(value v1.Duration) *NotificationThresholdApplyConfiguration {
+	b.Window = &value
+	return b
+}
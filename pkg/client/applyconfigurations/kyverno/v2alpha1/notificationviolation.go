@@ -0,0 +1,107 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v2alpha1
+
+import (
+	v2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// NotificationViolationApplyConfiguration represents an declarative configuration of the NotificationViolation type for use
+// with apply.
+type NotificationViolationApplyConfiguration struct {
+	Action    *v2alpha1.NotificationAction `json:"action,omitempty"`
+	Policy    *string                      `json:"policy,omitempty"`
+	Rule      *string                      `json:"rule,omitempty"`
+	Kind      *string                      `json:"kind,omitempty"`
+	Namespace *string                      `json:"namespace,omitempty"`
+	Name      *string                      `json:"name,omitempty"`
+	Message   *string                      `json:"message,omitempty"`
+	Timestamp *v1.Time                     `json:"timestamp,omitempty"`
+}
+
+// NotificationViolationApplyConfiguration constructs an declarative configuration of the NotificationViolation type for use with
+// apply.
+func NotificationViolation() *NotificationViolationApplyConfiguration {
+	return &NotificationViolationApplyConfiguration{}
+}
+
+// WithAction sets the Action field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Action field is set to the value of the last call.
+func (b *NotificationViolationApplyConfiguration) WithAction(value v2alpha1.NotificationAction) *NotificationViolationApplyConfiguration {
+	b.Action = &value
+	return b
+}
+
+// WithPolicy sets the Policy field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Policy field is set to the value of the last call.
+func (b *NotificationViolationApplyConfiguration) WithPolicy(value string) *NotificationViolationApplyConfiguration {
+	b.Policy = &value
+	return b
+}
+
+// WithRule sets the Rule field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Rule field is set to the value of the last call.
+func (b *NotificationViolationApplyConfiguration) WithRule(value string) *NotificationViolationApplyConfiguration {
+	b.Rule = &value
+	return b
+}
+
+// WithKind sets the Kind field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Kind field is set to the value of the last call.
+func (b *NotificationViolationApplyConfiguration) WithKind(value string) *NotificationViolationApplyConfiguration {
+	b.Kind = &value
+	return b
+}
+
+// WithNamespace sets the Namespace field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Namespace field is set to the value of the last call.
+func (b *NotificationViolationApplyConfiguration) WithNamespace(value string) *NotificationViolationApplyConfiguration {
+	b.Namespace = &value
+	return b
+}
+
+// WithName sets the Name field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Name field is set to the value of the last call.
+func (b *NotificationViolationApplyConfiguration) WithName(value string) *NotificationViolationApplyConfiguration {
+	b.Name = &value
+	return b
+}
+
+// WithMessage sets the Message field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Message field is set to the value of the last call.
+func (b *NotificationViolationApplyConfiguration) WithMessage(value string) *NotificationViolationApplyConfiguration {
+	b.Message = &value
+	return b
+}
+
+// WithTimestamp sets the Timestamp field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Timestamp field is set to the value of the last call.
+func (b *NotificationViolationApplyConfiguration) WithTimestamp(value v1.Time) *NotificationViolationApplyConfiguration {
+	b.Timestamp = &value
+	return b
+}
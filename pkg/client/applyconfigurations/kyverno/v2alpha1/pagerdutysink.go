@@ -0,0 +1,57 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v2alpha1
+
+// PagerDutySinkApplyConfiguration represents an declarative configuration of the PagerDutySink type for use
+// with apply.
+type PagerDutySinkApplyConfiguration struct {
+	RoutingKeySecretRef *SecretKeyRefApplyConfiguration `json:"routingKeySecretRef,omitempty"`
+	Severity            *string                         `json:"severity,omitempty"`
+	URL                 *string                         `json:"url,omitempty"`
+}
+
+// PagerDutySinkApplyConfiguration constructs an declarative configuration of the PagerDutySink type for use with
+// apply.
+func PagerDutySink() *PagerDutySinkApplyConfiguration {
+	return &PagerDutySinkApplyConfiguration{}
+}
+
+// WithRoutingKeySecretRef sets the RoutingKeySecretRef field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the RoutingKeySecretRef field is set to the value of the last call.
+func (b *PagerDutySinkApplyConfiguration) WithRoutingKeySecretRef(value *SecretKeyRefApplyConfiguration) *PagerDutySinkApplyConfiguration {
+	b.RoutingKeySecretRef = value
+	return b
+}
+
+// WithSeverity sets the Severity field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Severity field is set to the value of the last call.
+func (b *PagerDutySinkApplyConfiguration) WithSeverity(value string) *PagerDutySinkApplyConfiguration {
+	b.Severity = &value
+	return b
+}
+
+// WithURL sets the URL field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the URL field is set to the value of the last call.
+func (b *PagerDutySinkApplyConfiguration) WithURL(value string) *PagerDutySinkApplyConfiguration {
+	b.URL = &value
+	return b
+}
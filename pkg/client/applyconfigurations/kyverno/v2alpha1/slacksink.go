@@ -0,0 +1,48 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v2alpha1
+
+// SlackSinkApplyConfiguration represents an declarative configuration of the SlackSink type for use
+// with apply.
+type SlackSinkApplyConfiguration struct {
+	URLSecretRef *SecretKeyRefApplyConfiguration `json:"urlSecretRef,omitempty"`
+	Channel      *string                         `json:"channel,omitempty"`
+}
+
+// SlackSinkApplyConfiguration constructs an declarative configuration of the SlackSink type for use with
+// apply.
+func SlackSink() *SlackSinkApplyConfiguration {
+	return &SlackSinkApplyConfiguration{}
+}
+
+// WithURLSecretRef sets the URLSecretRef field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the URLSecretRef field is set to the value of the last call.
+func (b *SlackSinkApplyConfiguration) WithURLSecretRef(value *SecretKeyRefApplyConfiguration) *SlackSinkApplyConfiguration {
+	b.URLSecretRef = value
+	return b
+}
+
+// WithChannel sets the Channel field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Channel field is set to the value of the last call.
+func (b *SlackSinkApplyConfiguration) WithChannel(value string) *SlackSinkApplyConfiguration {
+	b.Channel = &value
+	return b
+}
@@ -0,0 +1,63 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v2alpha1
+
+// WebhookSinkApplyConfiguration represents an declarative configuration of the WebhookSink type for use
+// with apply.
+type WebhookSinkApplyConfiguration struct {
+	URL          *string                         `json:"url,omitempty"`
+	URLSecretRef *SecretKeyRefApplyConfiguration `json:"urlSecretRef,omitempty"`
+	Headers      map[string]string               `json:"headers,omitempty"`
+}
+
+// WebhookSinkApplyConfiguration constructs an declarative configuration of the WebhookSink type for use with
+// apply.
+func WebhookSink() *WebhookSinkApplyConfiguration {
+	return &WebhookSinkApplyConfiguration{}
+}
+
+// WithURL sets the URL field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the URL field is set to the value of the last call.
+func (b *WebhookSinkApplyConfiguration) WithURL(value string) *WebhookSinkApplyConfiguration {
+	b.URL = &value
+	return b
+}
+
+// WithURLSecretRef sets the URLSecretRef field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the URLSecretRef field is set to the value of the last call.
+func (b *WebhookSinkApplyConfiguration) WithURLSecretRef(value *SecretKeyRefApplyConfiguration) *WebhookSinkApplyConfiguration {
+	b.URLSecretRef = value
+	return b
+}
+
+// WithHeaders puts the entries into the Headers field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, the entries provided by each call will be put on the Headers field,
+// overwriting an existing map entries in Headers field with the same key.
+func (b *WebhookSinkApplyConfiguration) WithHeaders(entries map[string]string) *WebhookSinkApplyConfiguration {
+	if b.Headers == nil && len(entries) > 0 {
+		b.Headers = make(map[string]string, len(entries))
+	}
+	for k, v := range entries {
+		b.Headers[k] = v
+	}
+	return b
+}
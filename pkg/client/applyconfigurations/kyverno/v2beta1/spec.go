@@ -39,6 +39,8 @@ type SpecApplyConfiguration struct {
 	GenerateExistingOnPolicyUpdate   *bool                                                         `json:"generateExistingOnPolicyUpdate,omitempty"`
 	GenerateExisting                 *bool                                                         `json:"generateExisting,omitempty"`
 	UseServerSideApply               *bool                                                         `json:"useServerSideApply,omitempty"`
+	ParamKind                        *kyvernov1.ParamKindApplyConfiguration                        `json:"paramKind,omitempty"`
+	ParamRef                         *kyvernov1.ParamRefApplyConfiguration                         `json:"paramRef,omitempty"`
 }
 
 // SpecApplyConfiguration constructs an declarative configuration of the Spec type for use with
@@ -160,3 +162,19 @@ func (b *SpecApplyConfiguration) WithUseServerSideApply(value bool) *SpecApplyCo
 	b.UseServerSideApply = &value
 	return b
 }
+
+// WithParamKind sets the ParamKind field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ParamKind field is set to the value of the last call.
+func (b *SpecApplyConfiguration) WithParamKind(value *kyvernov1.ParamKindApplyConfiguration) *SpecApplyConfiguration {
+	b.ParamKind = value
+	return b
+}
+
+// WithParamRef sets the ParamRef field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ParamRef field is set to the value of the last call.
+func (b *SpecApplyConfiguration) WithParamRef(value *kyvernov1.ParamRefApplyConfiguration) *SpecApplyConfiguration {
+	b.ParamRef = value
+	return b
+}
@@ -43,6 +43,12 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &kyvernov1.AnyAllConditionsApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("APICall"):
 		return &kyvernov1.APICallApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("APICallList"):
+		return &kyvernov1.APICallListApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("Assertion"):
+		return &kyvernov1.AssertionApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("AssertionCheck"):
+		return &kyvernov1.AssertionCheckApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("Attestation"):
 		return &kyvernov1.AttestationApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("Attestor"):
@@ -61,12 +67,16 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &kyvernov1.CloneListApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("ClusterPolicy"):
 		return &kyvernov1.ClusterPolicyApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("ClusterReference"):
+		return &kyvernov1.ClusterReferenceApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("Condition"):
 		return &kyvernov1.ConditionApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("ConfigMapReference"):
 		return &kyvernov1.ConfigMapReferenceApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("ContextEntry"):
 		return &kyvernov1.ContextEntryApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("ContextFallback"):
+		return &kyvernov1.ContextFallbackApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("CTLog"):
 		return &kyvernov1.CTLogApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("Deny"):
@@ -77,8 +87,12 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &kyvernov1.ForEachMutationApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("ForEachValidation"):
 		return &kyvernov1.ForEachValidationApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("GenerateStatus"):
+		return &kyvernov1.GenerateStatusApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("Generation"):
 		return &kyvernov1.GenerationApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("ImageDigest"):
+		return &kyvernov1.ImageDigestApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("ImageExtractorConfig"):
 		return &kyvernov1.ImageExtractorConfigApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("ImageRegistry"):
@@ -97,6 +111,10 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &kyvernov1.MutationApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("ObjectFieldBinding"):
 		return &kyvernov1.ObjectFieldBindingApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("ParamKind"):
+		return &kyvernov1.ParamKindApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("ParamRef"):
+		return &kyvernov1.ParamRefApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("PodSecurity"):
 		return &kyvernov1.PodSecurityApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("PodSecurityStandard"):
@@ -113,12 +131,20 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &kyvernov1.ResourceFilterApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("ResourceSpec"):
 		return &kyvernov1.ResourceSpecApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("ResourceUsage"):
+		return &kyvernov1.ResourceUsageApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("Rollout"):
+		return &kyvernov1.RolloutApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("Rule"):
 		return &kyvernov1.RuleApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("RuleCountStatus"):
 		return &kyvernov1.RuleCountStatusApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("Schedule"):
+		return &kyvernov1.ScheduleApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("SecretReference"):
 		return &kyvernov1.SecretReferenceApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("ServiceAccountReference"):
+		return &kyvernov1.ServiceAccountReferenceApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("ServiceCall"):
 		return &kyvernov1.ServiceCallApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("Spec"):
@@ -173,12 +199,54 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &kyvernov2alpha1.CleanupPolicyStatusApplyConfiguration{}
 	case v2alpha1.SchemeGroupVersion.WithKind("ClusterCleanupPolicy"):
 		return &kyvernov2alpha1.ClusterCleanupPolicyApplyConfiguration{}
+	case v2alpha1.SchemeGroupVersion.WithKind("ClusterPolicyConstraint"):
+		return &kyvernov2alpha1.ClusterPolicyConstraintApplyConfiguration{}
+	case v2alpha1.SchemeGroupVersion.WithKind("ClusterPolicyConstraintSpec"):
+		return &kyvernov2alpha1.ClusterPolicyConstraintSpecApplyConfiguration{}
 	case v2alpha1.SchemeGroupVersion.WithKind("Exception"):
 		return &kyvernov2alpha1.ExceptionApplyConfiguration{}
+	case v2alpha1.SchemeGroupVersion.WithKind("Notification"):
+		return &kyvernov2alpha1.NotificationApplyConfiguration{}
+	case v2alpha1.SchemeGroupVersion.WithKind("NotificationSink"):
+		return &kyvernov2alpha1.NotificationSinkApplyConfiguration{}
+	case v2alpha1.SchemeGroupVersion.WithKind("NotificationSpec"):
+		return &kyvernov2alpha1.NotificationSpecApplyConfiguration{}
+	case v2alpha1.SchemeGroupVersion.WithKind("NotificationStatus"):
+		return &kyvernov2alpha1.NotificationStatusApplyConfiguration{}
+	case v2alpha1.SchemeGroupVersion.WithKind("NotificationThreshold"):
+		return &kyvernov2alpha1.NotificationThresholdApplyConfiguration{}
+	case v2alpha1.SchemeGroupVersion.WithKind("NotificationViolation"):
+		return &kyvernov2alpha1.NotificationViolationApplyConfiguration{}
+	case v2alpha1.SchemeGroupVersion.WithKind("PagerDutySink"):
+		return &kyvernov2alpha1.PagerDutySinkApplyConfiguration{}
 	case v2alpha1.SchemeGroupVersion.WithKind("PolicyException"):
 		return &kyvernov2alpha1.PolicyExceptionApplyConfiguration{}
 	case v2alpha1.SchemeGroupVersion.WithKind("PolicyExceptionSpec"):
 		return &kyvernov2alpha1.PolicyExceptionSpecApplyConfiguration{}
+	case v2alpha1.SchemeGroupVersion.WithKind("PolicySet"):
+		return &kyvernov2alpha1.PolicySetApplyConfiguration{}
+	case v2alpha1.SchemeGroupVersion.WithKind("PolicySetSpec"):
+		return &kyvernov2alpha1.PolicySetSpecApplyConfiguration{}
+	case v2alpha1.SchemeGroupVersion.WithKind("PolicySetStatus"):
+		return &kyvernov2alpha1.PolicySetStatusApplyConfiguration{}
+	case v2alpha1.SchemeGroupVersion.WithKind("PolicySetVerification"):
+		return &kyvernov2alpha1.PolicySetVerificationApplyConfiguration{}
+	case v2alpha1.SchemeGroupVersion.WithKind("ScanRequest"):
+		return &kyvernov2alpha1.ScanRequestApplyConfiguration{}
+	case v2alpha1.SchemeGroupVersion.WithKind("ScanRequestSpec"):
+		return &kyvernov2alpha1.ScanRequestSpecApplyConfiguration{}
+	case v2alpha1.SchemeGroupVersion.WithKind("ScanRequestStatus"):
+		return &kyvernov2alpha1.ScanRequestStatusApplyConfiguration{}
+	case v2alpha1.SchemeGroupVersion.WithKind("SecretKeyRef"):
+		return &kyvernov2alpha1.SecretKeyRefApplyConfiguration{}
+	case v2alpha1.SchemeGroupVersion.WithKind("SlackSink"):
+		return &kyvernov2alpha1.SlackSinkApplyConfiguration{}
+	case v2alpha1.SchemeGroupVersion.WithKind("WebhookHealth"):
+		return &kyvernov2alpha1.WebhookHealthApplyConfiguration{}
+	case v2alpha1.SchemeGroupVersion.WithKind("WebhookHealthStatus"):
+		return &kyvernov2alpha1.WebhookHealthStatusApplyConfiguration{}
+	case v2alpha1.SchemeGroupVersion.WithKind("WebhookSink"):
+		return &kyvernov2alpha1.WebhookSinkApplyConfiguration{}
 
 		// Group=kyverno.io, Version=v2beta1
 	case v2beta1.SchemeGroupVersion.WithKind("AnyAllConditions"):
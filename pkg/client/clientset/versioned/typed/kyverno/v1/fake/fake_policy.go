@@ -20,8 +20,11 @@ package fake
 
 import (
 	"context"
+	json "encoding/json"
+	"fmt"
 
 	v1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov1 "github.com/kyverno/kyverno/pkg/client/applyconfigurations/kyverno/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	labels "k8s.io/apimachinery/pkg/labels"
 	types "k8s.io/apimachinery/pkg/types"
@@ -139,3 +142,48 @@ func (c *FakePolicies) Patch(ctx context.Context, name string, pt types.PatchTyp
 	}
 	return obj.(*v1.Policy), err
 }
+
+// Apply takes the given apply declarative configuration, applies it and returns the applied policy.
+func (c *FakePolicies) Apply(ctx context.Context, policy *kyvernov1.PolicyApplyConfiguration, opts metav1.ApplyOptions) (result *v1.Policy, err error) {
+	if policy == nil {
+		return nil, fmt.Errorf("policy provided to Apply must not be nil")
+	}
+	data, err := json.Marshal(policy)
+	if err != nil {
+		return nil, err
+	}
+	name := policy.Name
+	if name == nil {
+		return nil, fmt.Errorf("policy.Name must be provided to Apply")
+	}
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceAction(policiesResource, c.ns, *name, types.ApplyPatchType, data), &v1.Policy{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1.Policy), err
+}
+
+// ApplyStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating ApplyStatus().
+func (c *FakePolicies) ApplyStatus(ctx context.Context, policy *kyvernov1.PolicyApplyConfiguration, opts metav1.ApplyOptions) (result *v1.Policy, err error) {
+	if policy == nil {
+		return nil, fmt.Errorf("policy provided to Apply must not be nil")
+	}
+	data, err := json.Marshal(policy)
+	if err != nil {
+		return nil, err
+	}
+	name := policy.Name
+	if name == nil {
+		return nil, fmt.Errorf("policy.Name must be provided to Apply")
+	}
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceAction(policiesResource, c.ns, *name, types.ApplyPatchType, data, "status"), &v1.Policy{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1.Policy), err
+}
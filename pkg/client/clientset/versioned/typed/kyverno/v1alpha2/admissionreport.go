@@ -20,9 +20,12 @@ package v1alpha2
 
 import (
 	"context"
+	json "encoding/json"
+	"fmt"
 	"time"
 
 	v1alpha2 "github.com/kyverno/kyverno/api/kyverno/v1alpha2"
+	kyvernov1alpha2 "github.com/kyverno/kyverno/pkg/client/applyconfigurations/kyverno/v1alpha2"
 	scheme "github.com/kyverno/kyverno/pkg/client/clientset/versioned/scheme"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	types "k8s.io/apimachinery/pkg/types"
@@ -46,6 +49,7 @@ type AdmissionReportInterface interface {
 	List(ctx context.Context, opts v1.ListOptions) (*v1alpha2.AdmissionReportList, error)
 	Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error)
 	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha2.AdmissionReport, err error)
+	Apply(ctx context.Context, admissionReport *kyvernov1alpha2.AdmissionReportApplyConfiguration, opts v1.ApplyOptions) (result *v1alpha2.AdmissionReport, err error)
 	AdmissionReportExpansion
 }
 
@@ -176,3 +180,29 @@ func (c *admissionReports) Patch(ctx context.Context, name string, pt types.Patc
 		Into(result)
 	return
 }
+
+// Apply takes the given apply declarative configuration, applies it and returns the applied admissionReport.
+func (c *admissionReports) Apply(ctx context.Context, admissionReport *kyvernov1alpha2.AdmissionReportApplyConfiguration, opts v1.ApplyOptions) (result *v1alpha2.AdmissionReport, err error) {
+	if admissionReport == nil {
+		return nil, fmt.Errorf("admissionReport provided to Apply must not be nil")
+	}
+	patchOpts := opts.ToPatchOptions()
+	data, err := json.Marshal(admissionReport)
+	if err != nil {
+		return nil, err
+	}
+	name := admissionReport.Name
+	if name == nil {
+		return nil, fmt.Errorf("admissionReport.Name must be provided to Apply")
+	}
+	result = &v1alpha2.AdmissionReport{}
+	err = c.client.Patch(types.ApplyPatchType).
+		Namespace(c.ns).
+		Resource("admissionreports").
+		Name(*name).
+		VersionedParams(&patchOpts, scheme.ParameterCodec).
+		Body(data).
+		Do(ctx).
+		Into(result)
+	return
+}
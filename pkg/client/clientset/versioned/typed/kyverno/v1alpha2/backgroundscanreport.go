@@ -20,9 +20,12 @@ package v1alpha2
 
 import (
 	"context"
+	json "encoding/json"
+	"fmt"
 	"time"
 
 	v1alpha2 "github.com/kyverno/kyverno/api/kyverno/v1alpha2"
+	kyvernov1alpha2 "github.com/kyverno/kyverno/pkg/client/applyconfigurations/kyverno/v1alpha2"
 	scheme "github.com/kyverno/kyverno/pkg/client/clientset/versioned/scheme"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	types "k8s.io/apimachinery/pkg/types"
@@ -46,6 +49,7 @@ type BackgroundScanReportInterface interface {
 	List(ctx context.Context, opts v1.ListOptions) (*v1alpha2.BackgroundScanReportList, error)
 	Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error)
 	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha2.BackgroundScanReport, err error)
+	Apply(ctx context.Context, backgroundScanReport *kyvernov1alpha2.BackgroundScanReportApplyConfiguration, opts v1.ApplyOptions) (result *v1alpha2.BackgroundScanReport, err error)
 	BackgroundScanReportExpansion
 }
 
@@ -176,3 +180,29 @@ func (c *backgroundScanReports) Patch(ctx context.Context, name string, pt types
 		Into(result)
 	return
 }
+
+// Apply takes the given apply declarative configuration, applies it and returns the applied backgroundScanReport.
+func (c *backgroundScanReports) Apply(ctx context.Context, backgroundScanReport *kyvernov1alpha2.BackgroundScanReportApplyConfiguration, opts v1.ApplyOptions) (result *v1alpha2.BackgroundScanReport, err error) {
+	if backgroundScanReport == nil {
+		return nil, fmt.Errorf("backgroundScanReport provided to Apply must not be nil")
+	}
+	patchOpts := opts.ToPatchOptions()
+	data, err := json.Marshal(backgroundScanReport)
+	if err != nil {
+		return nil, err
+	}
+	name := backgroundScanReport.Name
+	if name == nil {
+		return nil, fmt.Errorf("backgroundScanReport.Name must be provided to Apply")
+	}
+	result = &v1alpha2.BackgroundScanReport{}
+	err = c.client.Patch(types.ApplyPatchType).
+		Namespace(c.ns).
+		Resource("backgroundscanreports").
+		Name(*name).
+		VersionedParams(&patchOpts, scheme.ParameterCodec).
+		Body(data).
+		Do(ctx).
+		Into(result)
+	return
+}
@@ -20,9 +20,12 @@ package v1alpha2
 
 import (
 	"context"
+	json "encoding/json"
+	"fmt"
 	"time"
 
 	v1alpha2 "github.com/kyverno/kyverno/api/kyverno/v1alpha2"
+	kyvernov1alpha2 "github.com/kyverno/kyverno/pkg/client/applyconfigurations/kyverno/v1alpha2"
 	scheme "github.com/kyverno/kyverno/pkg/client/clientset/versioned/scheme"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	types "k8s.io/apimachinery/pkg/types"
@@ -46,6 +49,7 @@ type ClusterAdmissionReportInterface interface {
 	List(ctx context.Context, opts v1.ListOptions) (*v1alpha2.ClusterAdmissionReportList, error)
 	Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error)
 	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha2.ClusterAdmissionReport, err error)
+	Apply(ctx context.Context, clusterAdmissionReport *kyvernov1alpha2.ClusterAdmissionReportApplyConfiguration, opts v1.ApplyOptions) (result *v1alpha2.ClusterAdmissionReport, err error)
 	ClusterAdmissionReportExpansion
 }
 
@@ -166,3 +170,28 @@ func (c *clusterAdmissionReports) Patch(ctx context.Context, name string, pt typ
 		Into(result)
 	return
 }
+
+// Apply takes the given apply declarative configuration, applies it and returns the applied clusterAdmissionReport.
+func (c *clusterAdmissionReports) Apply(ctx context.Context, clusterAdmissionReport *kyvernov1alpha2.ClusterAdmissionReportApplyConfiguration, opts v1.ApplyOptions) (result *v1alpha2.ClusterAdmissionReport, err error) {
+	if clusterAdmissionReport == nil {
+		return nil, fmt.Errorf("clusterAdmissionReport provided to Apply must not be nil")
+	}
+	patchOpts := opts.ToPatchOptions()
+	data, err := json.Marshal(clusterAdmissionReport)
+	if err != nil {
+		return nil, err
+	}
+	name := clusterAdmissionReport.Name
+	if name == nil {
+		return nil, fmt.Errorf("clusterAdmissionReport.Name must be provided to Apply")
+	}
+	result = &v1alpha2.ClusterAdmissionReport{}
+	err = c.client.Patch(types.ApplyPatchType).
+		Resource("clusteradmissionreports").
+		Name(*name).
+		VersionedParams(&patchOpts, scheme.ParameterCodec).
+		Body(data).
+		Do(ctx).
+		Into(result)
+	return
+}
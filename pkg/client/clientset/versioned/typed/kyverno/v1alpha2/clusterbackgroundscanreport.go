@@ -20,9 +20,12 @@ package v1alpha2
 
 import (
 	"context"
+	json "encoding/json"
+	"fmt"
 	"time"
 
 	v1alpha2 "github.com/kyverno/kyverno/api/kyverno/v1alpha2"
+	kyvernov1alpha2 "github.com/kyverno/kyverno/pkg/client/applyconfigurations/kyverno/v1alpha2"
 	scheme "github.com/kyverno/kyverno/pkg/client/clientset/versioned/scheme"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	types "k8s.io/apimachinery/pkg/types"
@@ -46,6 +49,7 @@ type ClusterBackgroundScanReportInterface interface {
 	List(ctx context.Context, opts v1.ListOptions) (*v1alpha2.ClusterBackgroundScanReportList, error)
 	Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error)
 	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha2.ClusterBackgroundScanReport, err error)
+	Apply(ctx context.Context, clusterBackgroundScanReport *kyvernov1alpha2.ClusterBackgroundScanReportApplyConfiguration, opts v1.ApplyOptions) (result *v1alpha2.ClusterBackgroundScanReport, err error)
 	ClusterBackgroundScanReportExpansion
 }
 
@@ -166,3 +170,28 @@ func (c *clusterBackgroundScanReports) Patch(ctx context.Context, name string, p
 		Into(result)
 	return
 }
+
+// Apply takes the given apply declarative configuration, applies it and returns the applied clusterBackgroundScanReport.
+func (c *clusterBackgroundScanReports) Apply(ctx context.Context, clusterBackgroundScanReport *kyvernov1alpha2.ClusterBackgroundScanReportApplyConfiguration, opts v1.ApplyOptions) (result *v1alpha2.ClusterBackgroundScanReport, err error) {
+	if clusterBackgroundScanReport == nil {
+		return nil, fmt.Errorf("clusterBackgroundScanReport provided to Apply must not be nil")
+	}
+	patchOpts := opts.ToPatchOptions()
+	data, err := json.Marshal(clusterBackgroundScanReport)
+	if err != nil {
+		return nil, err
+	}
+	name := clusterBackgroundScanReport.Name
+	if name == nil {
+		return nil, fmt.Errorf("clusterBackgroundScanReport.Name must be provided to Apply")
+	}
+	result = &v1alpha2.ClusterBackgroundScanReport{}
+	err = c.client.Patch(types.ApplyPatchType).
+		Resource("clusterbackgroundscanreports").
+		Name(*name).
+		VersionedParams(&patchOpts, scheme.ParameterCodec).
+		Body(data).
+		Do(ctx).
+		Into(result)
+	return
+}
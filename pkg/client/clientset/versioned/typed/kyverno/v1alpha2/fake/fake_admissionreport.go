@@ -20,8 +20,11 @@ package fake
 
 import (
 	"context"
+	json "encoding/json"
+	"fmt"
 
 	v1alpha2 "github.com/kyverno/kyverno/api/kyverno/v1alpha2"
+	kyvernov1alpha2 "github.com/kyverno/kyverno/pkg/client/applyconfigurations/kyverno/v1alpha2"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	labels "k8s.io/apimachinery/pkg/labels"
 	types "k8s.io/apimachinery/pkg/types"
@@ -127,3 +130,25 @@ func (c *FakeAdmissionReports) Patch(ctx context.Context, name string, pt types.
 	}
 	return obj.(*v1alpha2.AdmissionReport), err
 }
+
+// Apply takes the given apply declarative configuration, applies it and returns the applied admissionReport.
+func (c *FakeAdmissionReports) Apply(ctx context.Context, admissionReport *kyvernov1alpha2.AdmissionReportApplyConfiguration, opts v1.ApplyOptions) (result *v1alpha2.AdmissionReport, err error) {
+	if admissionReport == nil {
+		return nil, fmt.Errorf("admissionReport provided to Apply must not be nil")
+	}
+	data, err := json.Marshal(admissionReport)
+	if err != nil {
+		return nil, err
+	}
+	name := admissionReport.Name
+	if name == nil {
+		return nil, fmt.Errorf("admissionReport.Name must be provided to Apply")
+	}
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceAction(admissionreportsResource, c.ns, *name, types.ApplyPatchType, data), &v1alpha2.AdmissionReport{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha2.AdmissionReport), err
+}
@@ -20,8 +20,11 @@ package fake
 
 import (
 	"context"
+	json "encoding/json"
+	"fmt"
 
 	v1alpha2 "github.com/kyverno/kyverno/api/kyverno/v1alpha2"
+	kyvernov1alpha2 "github.com/kyverno/kyverno/pkg/client/applyconfigurations/kyverno/v1alpha2"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	labels "k8s.io/apimachinery/pkg/labels"
 	types "k8s.io/apimachinery/pkg/types"
@@ -127,3 +130,25 @@ func (c *FakeBackgroundScanReports) Patch(ctx context.Context, name string, pt t
 	}
 	return obj.(*v1alpha2.BackgroundScanReport), err
 }
+
+// Apply takes the given apply declarative configuration, applies it and returns the applied backgroundScanReport.
+func (c *FakeBackgroundScanReports) Apply(ctx context.Context, backgroundScanReport *kyvernov1alpha2.BackgroundScanReportApplyConfiguration, opts v1.ApplyOptions) (result *v1alpha2.BackgroundScanReport, err error) {
+	if backgroundScanReport == nil {
+		return nil, fmt.Errorf("backgroundScanReport provided to Apply must not be nil")
+	}
+	data, err := json.Marshal(backgroundScanReport)
+	if err != nil {
+		return nil, err
+	}
+	name := backgroundScanReport.Name
+	if name == nil {
+		return nil, fmt.Errorf("backgroundScanReport.Name must be provided to Apply")
+	}
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceAction(backgroundscanreportsResource, c.ns, *name, types.ApplyPatchType, data), &v1alpha2.BackgroundScanReport{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha2.BackgroundScanReport), err
+}
@@ -20,8 +20,11 @@ package fake
 
 import (
 	"context"
+	json "encoding/json"
+	"fmt"
 
 	v1alpha2 "github.com/kyverno/kyverno/api/kyverno/v1alpha2"
+	kyvernov1alpha2 "github.com/kyverno/kyverno/pkg/client/applyconfigurations/kyverno/v1alpha2"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	labels "k8s.io/apimachinery/pkg/labels"
 	types "k8s.io/apimachinery/pkg/types"
@@ -119,3 +122,24 @@ func (c *FakeClusterAdmissionReports) Patch(ctx context.Context, name string, pt
 	}
 	return obj.(*v1alpha2.ClusterAdmissionReport), err
 }
+
+// Apply takes the given apply declarative configuration, applies it and returns the applied clusterAdmissionReport.
+func (c *FakeClusterAdmissionReports) Apply(ctx context.Context, clusterAdmissionReport *kyvernov1alpha2.ClusterAdmissionReportApplyConfiguration, opts v1.ApplyOptions) (result *v1alpha2.ClusterAdmissionReport, err error) {
+	if clusterAdmissionReport == nil {
+		return nil, fmt.Errorf("clusterAdmissionReport provided to Apply must not be nil")
+	}
+	data, err := json.Marshal(clusterAdmissionReport)
+	if err != nil {
+		return nil, err
+	}
+	name := clusterAdmissionReport.Name
+	if name == nil {
+		return nil, fmt.Errorf("clusterAdmissionReport.Name must be provided to Apply")
+	}
+	obj, err := c.Fake.
+		Invokes(testing.NewRootPatchSubresourceAction(clusteradmissionreportsResource, *name, types.ApplyPatchType, data), &v1alpha2.ClusterAdmissionReport{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha2.ClusterAdmissionReport), err
+}
@@ -20,8 +20,11 @@ package fake
 
 import (
 	"context"
+	json "encoding/json"
+	"fmt"
 
 	v1alpha2 "github.com/kyverno/kyverno/api/kyverno/v1alpha2"
+	kyvernov1alpha2 "github.com/kyverno/kyverno/pkg/client/applyconfigurations/kyverno/v1alpha2"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	labels "k8s.io/apimachinery/pkg/labels"
 	types "k8s.io/apimachinery/pkg/types"
@@ -119,3 +122,24 @@ func (c *FakeClusterBackgroundScanReports) Patch(ctx context.Context, name strin
 	}
 	return obj.(*v1alpha2.ClusterBackgroundScanReport), err
 }
+
+// Apply takes the given apply declarative configuration, applies it and returns the applied clusterBackgroundScanReport.
+func (c *FakeClusterBackgroundScanReports) Apply(ctx context.Context, clusterBackgroundScanReport *kyvernov1alpha2.ClusterBackgroundScanReportApplyConfiguration, opts v1.ApplyOptions) (result *v1alpha2.ClusterBackgroundScanReport, err error) {
+	if clusterBackgroundScanReport == nil {
+		return nil, fmt.Errorf("clusterBackgroundScanReport provided to Apply must not be nil")
+	}
+	data, err := json.Marshal(clusterBackgroundScanReport)
+	if err != nil {
+		return nil, err
+	}
+	name := clusterBackgroundScanReport.Name
+	if name == nil {
+		return nil, fmt.Errorf("clusterBackgroundScanReport.Name must be provided to Apply")
+	}
+	obj, err := c.Fake.
+		Invokes(testing.NewRootPatchSubresourceAction(clusterbackgroundscanreportsResource, *name, types.ApplyPatchType, data), &v1alpha2.ClusterBackgroundScanReport{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha2.ClusterBackgroundScanReport), err
+}
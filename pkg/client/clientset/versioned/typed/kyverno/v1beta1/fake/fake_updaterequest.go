@@ -20,8 +20,11 @@ package fake
 
 import (
 	"context"
+	json "encoding/json"
+	"fmt"
 
 	v1beta1 "github.com/kyverno/kyverno/api/kyverno/v1beta1"
+	kyvernov1beta1 "github.com/kyverno/kyverno/pkg/client/applyconfigurations/kyverno/v1beta1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	labels "k8s.io/apimachinery/pkg/labels"
 	types "k8s.io/apimachinery/pkg/types"
@@ -139,3 +142,48 @@ func (c *FakeUpdateRequests) Patch(ctx context.Context, name string, pt types.Pa
 	}
 	return obj.(*v1beta1.UpdateRequest), err
 }
+
+// Apply takes the given apply declarative configuration, applies it and returns the applied updateRequest.
+func (c *FakeUpdateRequests) Apply(ctx context.Context, updateRequest *kyvernov1beta1.UpdateRequestApplyConfiguration, opts v1.ApplyOptions) (result *v1beta1.UpdateRequest, err error) {
+	if updateRequest == nil {
+		return nil, fmt.Errorf("updateRequest provided to Apply must not be nil")
+	}
+	data, err := json.Marshal(updateRequest)
+	if err != nil {
+		return nil, err
+	}
+	name := updateRequest.Name
+	if name == nil {
+		return nil, fmt.Errorf("updateRequest.Name must be provided to Apply")
+	}
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceAction(updaterequestsResource, c.ns, *name, types.ApplyPatchType, data), &v1beta1.UpdateRequest{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1beta1.UpdateRequest), err
+}
+
+// ApplyStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating ApplyStatus().
+func (c *FakeUpdateRequests) ApplyStatus(ctx context.Context, updateRequest *kyvernov1beta1.UpdateRequestApplyConfiguration, opts v1.ApplyOptions) (result *v1beta1.UpdateRequest, err error) {
+	if updateRequest == nil {
+		return nil, fmt.Errorf("updateRequest provided to Apply must not be nil")
+	}
+	data, err := json.Marshal(updateRequest)
+	if err != nil {
+		return nil, err
+	}
+	name := updateRequest.Name
+	if name == nil {
+		return nil, fmt.Errorf("updateRequest.Name must be provided to Apply")
+	}
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceAction(updaterequestsResource, c.ns, *name, types.ApplyPatchType, data, "status"), &v1beta1.UpdateRequest{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1beta1.UpdateRequest), err
+}
@@ -20,9 +20,12 @@ package v1beta1
 
 import (
 	"context"
+	json "encoding/json"
+	"fmt"
 	"time"
 
 	v1beta1 "github.com/kyverno/kyverno/api/kyverno/v1beta1"
+	kyvernov1beta1 "github.com/kyverno/kyverno/pkg/client/applyconfigurations/kyverno/v1beta1"
 	scheme "github.com/kyverno/kyverno/pkg/client/clientset/versioned/scheme"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	types "k8s.io/apimachinery/pkg/types"
@@ -47,6 +50,8 @@ type UpdateRequestInterface interface {
 	List(ctx context.Context, opts v1.ListOptions) (*v1beta1.UpdateRequestList, error)
 	Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error)
 	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1beta1.UpdateRequest, err error)
+	Apply(ctx context.Context, updateRequest *kyvernov1beta1.UpdateRequestApplyConfiguration, opts v1.ApplyOptions) (result *v1beta1.UpdateRequest, err error)
+	ApplyStatus(ctx context.Context, updateRequest *kyvernov1beta1.UpdateRequestApplyConfiguration, opts v1.ApplyOptions) (result *v1beta1.UpdateRequest, err error)
 	UpdateRequestExpansion
 }
 
@@ -193,3 +198,59 @@ func (c *updateRequests) Patch(ctx context.Context, name string, pt types.PatchT
 		Into(result)
 	return
 }
+
+// Apply takes the given apply declarative configuration, applies it and returns the applied updateRequest.
+func (c *updateRequests) Apply(ctx context.Context, updateRequest *kyvernov1beta1.UpdateRequestApplyConfiguration, opts v1.ApplyOptions) (result *v1beta1.UpdateRequest, err error) {
+	if updateRequest == nil {
+		return nil, fmt.Errorf("updateRequest provided to Apply must not be nil")
+	}
+	patchOpts := opts.ToPatchOptions()
+	data, err := json.Marshal(updateRequest)
+	if err != nil {
+		return nil, err
+	}
+	name := updateRequest.Name
+	if name == nil {
+		return nil, fmt.Errorf("updateRequest.Name must be provided to Apply")
+	}
+	result = &v1beta1.UpdateRequest{}
+	err = c.client.Patch(types.ApplyPatchType).
+		Namespace(c.ns).
+		Resource("updaterequests").
+		Name(*name).
+		VersionedParams(&patchOpts, scheme.ParameterCodec).
+		Body(data).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// ApplyStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating ApplyStatus().
+func (c *updateRequests) ApplyStatus(ctx context.Context, updateRequest *kyvernov1beta1.UpdateRequestApplyConfiguration, opts v1.ApplyOptions) (result *v1beta1.UpdateRequest, err error) {
+	if updateRequest == nil {
+		return nil, fmt.Errorf("updateRequest provided to Apply must not be nil")
+	}
+	patchOpts := opts.ToPatchOptions()
+	data, err := json.Marshal(updateRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	name := updateRequest.Name
+	if name == nil {
+		return nil, fmt.Errorf("updateRequest.Name must be provided to Apply")
+	}
+
+	result = &v1beta1.UpdateRequest{}
+	err = c.client.Patch(types.ApplyPatchType).
+		Namespace(c.ns).
+		Resource("updaterequests").
+		Name(*name).
+		SubResource("status").
+		VersionedParams(&patchOpts, scheme.ParameterCodec).
+		Body(data).
+		Do(ctx).
+		Into(result)
+	return
+}
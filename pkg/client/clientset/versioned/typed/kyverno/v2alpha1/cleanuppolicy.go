@@ -20,9 +20,12 @@ package v2alpha1
 
 import (
 	"context"
+	json "encoding/json"
+	"fmt"
 	"time"
 
 	v2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	kyvernov2alpha1 "github.com/kyverno/kyverno/pkg/client/applyconfigurations/kyverno/v2alpha1"
 	scheme "github.com/kyverno/kyverno/pkg/client/clientset/versioned/scheme"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	types "k8s.io/apimachinery/pkg/types"
@@ -47,6 +50,8 @@ type CleanupPolicyInterface interface {
 	List(ctx context.Context, opts v1.ListOptions) (*v2alpha1.CleanupPolicyList, error)
 	Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error)
 	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v2alpha1.CleanupPolicy, err error)
+	Apply(ctx context.Context, cleanupPolicy *kyvernov2alpha1.CleanupPolicyApplyConfiguration, opts v1.ApplyOptions) (result *v2alpha1.CleanupPolicy, err error)
+	ApplyStatus(ctx context.Context, cleanupPolicy *kyvernov2alpha1.CleanupPolicyApplyConfiguration, opts v1.ApplyOptions) (result *v2alpha1.CleanupPolicy, err error)
 	CleanupPolicyExpansion
 }
 
@@ -193,3 +198,59 @@ func (c *cleanupPolicies) Patch(ctx context.Context, name string, pt types.Patch
 		Into(result)
 	return
 }
+
+// Apply takes the given apply declarative configuration, applies it and returns the applied cleanupPolicy.
+func (c *cleanupPolicies) Apply(ctx context.Context, cleanupPolicy *kyvernov2alpha1.CleanupPolicyApplyConfiguration, opts v1.ApplyOptions) (result *v2alpha1.CleanupPolicy, err error) {
+	if cleanupPolicy == nil {
+		return nil, fmt.Errorf("cleanupPolicy provided to Apply must not be nil")
+	}
+	patchOpts := opts.ToPatchOptions()
+	data, err := json.Marshal(cleanupPolicy)
+	if err != nil {
+		return nil, err
+	}
+	name := cleanupPolicy.Name
+	if name == nil {
+		return nil, fmt.Errorf("cleanupPolicy.Name must be provided to Apply")
+	}
+	result = &v2alpha1.CleanupPolicy{}
+	err = c.client.Patch(types.ApplyPatchType).
+		Namespace(c.ns).
+		Resource("cleanuppolicies").
+		Name(*name).
+		VersionedParams(&patchOpts, scheme.ParameterCodec).
+		Body(data).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// ApplyStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating ApplyStatus().
+func (c *cleanupPolicies) ApplyStatus(ctx context.Context, cleanupPolicy *kyvernov2alpha1.CleanupPolicyApplyConfiguration, opts v1.ApplyOptions) (result *v2alpha1.CleanupPolicy, err error) {
+	if cleanupPolicy == nil {
+		return nil, fmt.Errorf("cleanupPolicy provided to Apply must not be nil")
+	}
+	patchOpts := opts.ToPatchOptions()
+	data, err := json.Marshal(cleanupPolicy)
+	if err != nil {
+		return nil, err
+	}
+
+	name := cleanupPolicy.Name
+	if name == nil {
+		return nil, fmt.Errorf("cleanupPolicy.Name must be provided to Apply")
+	}
+
+	result = &v2alpha1.CleanupPolicy{}
+	err = c.client.Patch(types.ApplyPatchType).
+		Namespace(c.ns).
+		Resource("cleanuppolicies").
+		Name(*name).
+		SubResource("status").
+		VersionedParams(&patchOpts, scheme.ParameterCodec).
+		Body(data).
+		Do(ctx).
+		Into(result)
+	return
+}
@@ -20,9 +20,12 @@ package v2alpha1
 
 import (
 	"context"
+	json "encoding/json"
+	"fmt"
 	"time"
 
 	v2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	kyvernov2alpha1 "github.com/kyverno/kyverno/pkg/client/applyconfigurations/kyverno/v2alpha1"
 	scheme "github.com/kyverno/kyverno/pkg/client/clientset/versioned/scheme"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	types "k8s.io/apimachinery/pkg/types"
@@ -47,6 +50,8 @@ type ClusterCleanupPolicyInterface interface {
 	List(ctx context.Context, opts v1.ListOptions) (*v2alpha1.ClusterCleanupPolicyList, error)
 	Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error)
 	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v2alpha1.ClusterCleanupPolicy, err error)
+	Apply(ctx context.Context, clusterCleanupPolicy *kyvernov2alpha1.ClusterCleanupPolicyApplyConfiguration, opts v1.ApplyOptions) (result *v2alpha1.ClusterCleanupPolicy, err error)
+	ApplyStatus(ctx context.Context, clusterCleanupPolicy *kyvernov2alpha1.ClusterCleanupPolicyApplyConfiguration, opts v1.ApplyOptions) (result *v2alpha1.ClusterCleanupPolicy, err error)
 	ClusterCleanupPolicyExpansion
 }
 
@@ -182,3 +187,57 @@ func (c *clusterCleanupPolicies) Patch(ctx context.Context, name string, pt type
 		Into(result)
 	return
 }
+
+// Apply takes the given apply declarative configuration, applies it and returns the applied clusterCleanupPolicy.
+func (c *clusterCleanupPolicies) Apply(ctx context.Context, clusterCleanupPolicy *kyvernov2alpha1.ClusterCleanupPolicyApplyConfiguration, opts v1.ApplyOptions) (result *v2alpha1.ClusterCleanupPolicy, err error) {
+	if clusterCleanupPolicy == nil {
+		return nil, fmt.Errorf("clusterCleanupPolicy provided to Apply must not be nil")
+	}
+	patchOpts := opts.ToPatchOptions()
+	data, err := json.Marshal(clusterCleanupPolicy)
+	if err != nil {
+		return nil, err
+	}
+	name := clusterCleanupPolicy.Name
+	if name == nil {
+		return nil, fmt.Errorf("clusterCleanupPolicy.Name must be provided to Apply")
+	}
+	result = &v2alpha1.ClusterCleanupPolicy{}
+	err = c.client.Patch(types.ApplyPatchType).
+		Resource("clustercleanuppolicies").
+		Name(*name).
+		VersionedParams(&patchOpts, scheme.ParameterCodec).
+		Body(data).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// ApplyStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating ApplyStatus().
+func (c *clusterCleanupPolicies) ApplyStatus(ctx context.Context, clusterCleanupPolicy *kyvernov2alpha1.ClusterCleanupPolicyApplyConfiguration, opts v1.ApplyOptions) (result *v2alpha1.ClusterCleanupPolicy, err error) {
+	if clusterCleanupPolicy == nil {
+		return nil, fmt.Errorf("clusterCleanupPolicy provided to Apply must not be nil")
+	}
+	patchOpts := opts.ToPatchOptions()
+	data, err := json.Marshal(clusterCleanupPolicy)
+	if err != nil {
+		return nil, err
+	}
+
+	name := clusterCleanupPolicy.Name
+	if name == nil {
+		return nil, fmt.Errorf("clusterCleanupPolicy.Name must be provided to Apply")
+	}
+
+	result = &v2alpha1.ClusterCleanupPolicy{}
+	err = c.client.Patch(types.ApplyPatchType).
+		Resource("clustercleanuppolicies").
+		Name(*name).
+		SubResource("status").
+		VersionedParams(&patchOpts, scheme.ParameterCodec).
+		Body(data).
+		Do(ctx).
+		Into(result)
+	return
+}
@@ -0,0 +1,197 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package v2alpha1
+
+import (
+	"context"
+	json "encoding/json"
+	"fmt"
+	"time"
+
+	v2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	kyvernov2alpha1 "github.com/kyverno/kyverno/pkg/client/applyconfigurations/kyverno/v2alpha1"
+	scheme "github.com/kyverno/kyverno/pkg/client/clientset/versioned/scheme"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	rest "k8s.io/client-go/rest"
+)
+
+// ClusterPolicyConstraintsGetter has a method to return a ClusterPolicyConstraintInterface.
+// A group's client should implement this interface.
+type ClusterPolicyConstraintsGetter interface {
+	ClusterPolicyConstraints() ClusterPolicyConstraintInterface
+}
+
+// ClusterPolicyConstraintInterface has methods to work with ClusterPolicyConstraint resources.
+type ClusterPolicyConstraintInterface interface {
+	Create(ctx context.Context, clusterPolicyConstraint *v2alpha1.ClusterPolicyConstraint, opts v1.CreateOptions) (*v2alpha1.ClusterPolicyConstraint, error)
+	Update(ctx context.Context, clusterPolicyConstraint *v2alpha1.ClusterPolicyConstraint, opts v1.UpdateOptions) (*v2alpha1.ClusterPolicyConstraint, error)
+	Delete(ctx context.Context, name string, opts v1.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error
+	Get(ctx context.Context, name string, opts v1.GetOptions) (*v2alpha1.ClusterPolicyConstraint, error)
+	List(ctx context.Context, opts v1.ListOptions) (*v2alpha1.ClusterPolicyConstraintList, error)
+	Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v2alpha1.ClusterPolicyConstraint, err error)
+	Apply(ctx context.Context, clusterPolicyConstraint *kyvernov2alpha1.ClusterPolicyConstraintApplyConfiguration, opts v1.ApplyOptions) (result *v2alpha1.ClusterPolicyConstraint, err error)
+	ClusterPolicyConstraintExpansion
+}
+
+// clusterPolicyConstraints implements ClusterPolicyConstraintInterface
+type clusterPolicyConstraints struct {
+	client rest.Interface
+}
+
+// newClusterPolicyConstraints returns a ClusterPolicyConstraints
+func newClusterPolicyConstraints(c *KyvernoV2alpha1Client) *clusterPolicyConstraints {
+	return &clusterPolicyConstraints{
+		client: c.RESTClient(),
+	}
+}
+
+// Get takes name of the clusterPolicyConstraint, and returns the corresponding clusterPolicyConstraint object, and an error if there is any.
+func (c *clusterPolicyConstraints) Get(ctx context.Context, name string, options v1.GetOptions) (result *v2alpha1.ClusterPolicyConstraint, err error) {
+	result = &v2alpha1.ClusterPolicyConstraint{}
+	err = c.client.Get().
+		Resource("clusterpolicyconstraints").
+		Name(name).
+		VersionedParams(&options, scheme.ParameterCodec).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// List takes label and field selectors, and returns the list of ClusterPolicyConstraints that match those selectors.
+func (c *clusterPolicyConstraints) List(ctx context.Context, opts v1.ListOptions) (result *v2alpha1.ClusterPolicyConstraintList, err error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	result = &v2alpha1.ClusterPolicyConstraintList{}
+	err = c.client.Get().
+		Resource("clusterpolicyconstraints").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Watch returns a watch.Interface that watches the requested clusterPolicyConstraints.
+func (c *clusterPolicyConstraints) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	opts.Watch = true
+	return c.client.Get().
+		Resource("clusterpolicyconstraints").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Watch(ctx)
+}
+
+// Create takes the representation of a clusterPolicyConstraint and creates it.  Returns the server's representation of the clusterPolicyConstraint, and an error, if there is any.
+func (c *clusterPolicyConstraints) Create(ctx context.Context, clusterPolicyConstraint *v2alpha1.ClusterPolicyConstraint, opts v1.CreateOptions) (result *v2alpha1.ClusterPolicyConstraint, err error) {
+	result = &v2alpha1.ClusterPolicyConstraint{}
+	err = c.client.Post().
+		Resource("clusterpolicyconstraints").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(clusterPolicyConstraint).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Update takes the representation of a clusterPolicyConstraint and updates it. Returns the server's representation of the clusterPolicyConstraint, and an error, if there is any.
+func (c *clusterPolicyConstraints) Update(ctx context.Context, clusterPolicyConstraint *v2alpha1.ClusterPolicyConstraint, opts v1.UpdateOptions) (result *v2alpha1.ClusterPolicyConstraint, err error) {
+	result = &v2alpha1.ClusterPolicyConstraint{}
+	err = c.client.Put().
+		Resource("clusterpolicyconstraints").
+		Name(clusterPolicyConstraint.Name).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(clusterPolicyConstraint).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Delete takes name of the clusterPolicyConstraint and deletes it. Returns an error if one occurs.
+func (c *clusterPolicyConstraints) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	return c.client.Delete().
+		Resource("clusterpolicyconstraints").
+		Name(name).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *clusterPolicyConstraints) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	var timeout time.Duration
+	if listOpts.TimeoutSeconds != nil {
+		timeout = time.Duration(*listOpts.TimeoutSeconds) * time.Second
+	}
+	return c.client.Delete().
+		Resource("clusterpolicyconstraints").
+		VersionedParams(&listOpts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// Patch applies the patch and returns the patched clusterPolicyConstraint.
+func (c *clusterPolicyConstraints) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v2alpha1.ClusterPolicyConstraint, err error) {
+	result = &v2alpha1.ClusterPolicyConstraint{}
+	err = c.client.Patch(pt).
+		Resource("clusterpolicyconstraints").
+		Name(name).
+		SubResource(subresources...).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(data).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Apply takes the given apply declarative configuration, applies it and returns the applied clusterPolicyConstraint.
+func (c *clusterPolicyConstraints) Apply(ctx context.Context, clusterPolicyConstraint *kyvernov2alpha1.ClusterPolicyConstraintApplyConfiguration, opts v1.ApplyOptions) (result *v2alpha1.ClusterPolicyConstraint, err error) {
+	if clusterPolicyConstraint == nil {
+		return nil, fmt.Errorf("clusterPolicyConstraint provided to Apply must not be nil")
+	}
+	patchOpts := opts.ToPatchOptions()
+	data, err := json.Marshal(clusterPolicyConstraint)
+	if err != nil {
+		return nil, err
+	}
+	name := clusterPolicyConstraint.Name
+	if name == nil {
+		return nil, fmt.Errorf("clusterPolicyConstraint.Name must be provided to Apply")
+	}
+	result = &v2alpha1.ClusterPolicyConstraint{}
+	err = c.client.Patch(types.ApplyPatchType).
+		Resource("clusterpolicyconstraints").
+		Name(*name).
+		VersionedParams(&patchOpts, scheme.ParameterCodec).
+		Body(data).
+		Do(ctx).
+		Into(result)
+	return
+}
@@ -20,8 +20,11 @@ package fake
 
 import (
 	"context"
+	json "encoding/json"
+	"fmt"
 
 	v2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	kyvernov2alpha1 "github.com/kyverno/kyverno/pkg/client/applyconfigurations/kyverno/v2alpha1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	labels "k8s.io/apimachinery/pkg/labels"
 	types "k8s.io/apimachinery/pkg/types"
@@ -139,3 +142,48 @@ func (c *FakeCleanupPolicies) Patch(ctx context.Context, name string, pt types.P
 	}
 	return obj.(*v2alpha1.CleanupPolicy), err
 }
+
+// Apply takes the given apply declarative configuration, applies it and returns the applied cleanupPolicy.
+func (c *FakeCleanupPolicies) Apply(ctx context.Context, cleanupPolicy *kyvernov2alpha1.CleanupPolicyApplyConfiguration, opts v1.ApplyOptions) (result *v2alpha1.CleanupPolicy, err error) {
+	if cleanupPolicy == nil {
+		return nil, fmt.Errorf("cleanupPolicy provided to Apply must not be nil")
+	}
+	data, err := json.Marshal(cleanupPolicy)
+	if err != nil {
+		return nil, err
+	}
+	name := cleanupPolicy.Name
+	if name == nil {
+		return nil, fmt.Errorf("cleanupPolicy.Name must be provided to Apply")
+	}
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceAction(cleanuppoliciesResource, c.ns, *name, types.ApplyPatchType, data), &v2alpha1.CleanupPolicy{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v2alpha1.CleanupPolicy), err
+}
+
+// ApplyStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating ApplyStatus().
+func (c *FakeCleanupPolicies) ApplyStatus(ctx context.Context, cleanupPolicy *kyvernov2alpha1.CleanupPolicyApplyConfiguration, opts v1.ApplyOptions) (result *v2alpha1.CleanupPolicy, err error) {
+	if cleanupPolicy == nil {
+		return nil, fmt.Errorf("cleanupPolicy provided to Apply must not be nil")
+	}
+	data, err := json.Marshal(cleanupPolicy)
+	if err != nil {
+		return nil, err
+	}
+	name := cleanupPolicy.Name
+	if name == nil {
+		return nil, fmt.Errorf("cleanupPolicy.Name must be provided to Apply")
+	}
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceAction(cleanuppoliciesResource, c.ns, *name, types.ApplyPatchType, data, "status"), &v2alpha1.CleanupPolicy{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v2alpha1.CleanupPolicy), err
+}
@@ -20,8 +20,11 @@ package fake
 
 import (
 	"context"
+	json "encoding/json"
+	"fmt"
 
 	v2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	kyvernov2alpha1 "github.com/kyverno/kyverno/pkg/client/applyconfigurations/kyverno/v2alpha1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	labels "k8s.io/apimachinery/pkg/labels"
 	types "k8s.io/apimachinery/pkg/types"
@@ -130,3 +133,46 @@ func (c *FakeClusterCleanupPolicies) Patch(ctx context.Context, name string, pt
 	}
 	return obj.(*v2alpha1.ClusterCleanupPolicy), err
 }
+
+// Apply takes the given apply declarative configuration, applies it and returns the applied clusterCleanupPolicy.
+func (c *FakeClusterCleanupPolicies) Apply(ctx context.Context, clusterCleanupPolicy *kyvernov2alpha1.ClusterCleanupPolicyApplyConfiguration, opts v1.ApplyOptions) (result *v2alpha1.ClusterCleanupPolicy, err error) {
+	if clusterCleanupPolicy == nil {
+		return nil, fmt.Errorf("clusterCleanupPolicy provided to Apply must not be nil")
+	}
+	data, err := json.Marshal(clusterCleanupPolicy)
+	if err != nil {
+		return nil, err
+	}
+	name := clusterCleanupPolicy.Name
+	if name == nil {
+		return nil, fmt.Errorf("clusterCleanupPolicy.Name must be provided to Apply")
+	}
+	obj, err := c.Fake.
+		Invokes(testing.NewRootPatchSubresourceAction(clustercleanuppoliciesResource, *name, types.ApplyPatchType, data), &v2alpha1.ClusterCleanupPolicy{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v2alpha1.ClusterCleanupPolicy), err
+}
+
+// ApplyStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating ApplyStatus().
+func (c *FakeClusterCleanupPolicies) ApplyStatus(ctx context.Context, clusterCleanupPolicy *kyvernov2alpha1.ClusterCleanupPolicyApplyConfiguration, opts v1.ApplyOptions) (result *v2alpha1.ClusterCleanupPolicy, err error) {
+	if clusterCleanupPolicy == nil {
+		return nil, fmt.Errorf("clusterCleanupPolicy provided to Apply must not be nil")
+	}
+	data, err := json.Marshal(clusterCleanupPolicy)
+	if err != nil {
+		return nil, err
+	}
+	name := clusterCleanupPolicy.Name
+	if name == nil {
+		return nil, fmt.Errorf("clusterCleanupPolicy.Name must be provided to Apply")
+	}
+	obj, err := c.Fake.
+		Invokes(testing.NewRootPatchSubresourceAction(clustercleanuppoliciesResource, *name, types.ApplyPatchType, data, "status"), &v2alpha1.ClusterCleanupPolicy{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v2alpha1.ClusterCleanupPolicy), err
+}
@@ -0,0 +1,145 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	"context"
+	json "encoding/json"
+	"fmt"
+
+	v2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	kyvernov2alpha1 "github.com/kyverno/kyverno/pkg/client/applyconfigurations/kyverno/v2alpha1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakeClusterPolicyConstraints implements ClusterPolicyConstraintInterface
+type FakeClusterPolicyConstraints struct {
+	Fake *FakeKyvernoV2alpha1
+}
+
+var clusterpolicyconstraintsResource = v2alpha1.SchemeGroupVersion.WithResource("clusterpolicyconstraints")
+
+var clusterpolicyconstraintsKind = v2alpha1.SchemeGroupVersion.WithKind("ClusterPolicyConstraint")
+
+// Get takes name of the clusterPolicyConstraint, and returns the corresponding clusterPolicyConstraint object, and an error if there is any.
+func (c *FakeClusterPolicyConstraints) Get(ctx context.Context, name string, options v1.GetOptions) (result *v2alpha1.ClusterPolicyConstraint, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootGetAction(clusterpolicyconstraintsResource, name), &v2alpha1.ClusterPolicyConstraint{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v2alpha1.ClusterPolicyConstraint), err
+}
+
+// List takes label and field selectors, and returns the list of ClusterPolicyConstraints that match those selectors.
+func (c *FakeClusterPolicyConstraints) List(ctx context.Context, opts v1.ListOptions) (result *v2alpha1.ClusterPolicyConstraintList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootListAction(clusterpolicyconstraintsResource, clusterpolicyconstraintsKind, opts), &v2alpha1.ClusterPolicyConstraintList{})
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &v2alpha1.ClusterPolicyConstraintList{ListMeta: obj.(*v2alpha1.ClusterPolicyConstraintList).ListMeta}
+	for _, item := range obj.(*v2alpha1.ClusterPolicyConstraintList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested clusterPolicyConstraints.
+func (c *FakeClusterPolicyConstraints) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewRootWatchAction(clusterpolicyconstraintsResource, opts))
+}
+
+// Create takes the representation of a clusterPolicyConstraint and creates it.  Returns the server's representation of the clusterPolicyConstraint, and an error, if there is any.
+func (c *FakeClusterPolicyConstraints) Create(ctx context.Context, clusterPolicyConstraint *v2alpha1.ClusterPolicyConstraint, opts v1.CreateOptions) (result *v2alpha1.ClusterPolicyConstraint, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootCreateAction(clusterpolicyconstraintsResource, clusterPolicyConstraint), &v2alpha1.ClusterPolicyConstraint{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v2alpha1.ClusterPolicyConstraint), err
+}
+
+// Update takes the representation of a clusterPolicyConstraint and updates it. Returns the server's representation of the clusterPolicyConstraint, and an error, if there is any.
+func (c *FakeClusterPolicyConstraints) Update(ctx context.Context, clusterPolicyConstraint *v2alpha1.ClusterPolicyConstraint, opts v1.UpdateOptions) (result *v2alpha1.ClusterPolicyConstraint, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootUpdateAction(clusterpolicyconstraintsResource, clusterPolicyConstraint), &v2alpha1.ClusterPolicyConstraint{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v2alpha1.ClusterPolicyConstraint), err
+}
+
+// Delete takes name of the clusterPolicyConstraint and deletes it. Returns an error if one occurs.
+func (c *FakeClusterPolicyConstraints) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewRootDeleteActionWithOptions(clusterpolicyconstraintsResource, name, opts), &v2alpha1.ClusterPolicyConstraint{})
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeClusterPolicyConstraints) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	action := testing.NewRootDeleteCollectionAction(clusterpolicyconstraintsResource, listOpts)
+
+	_, err := c.Fake.Invokes(action, &v2alpha1.ClusterPolicyConstraintList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched clusterPolicyConstraint.
+func (c *FakeClusterPolicyConstraints) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v2alpha1.ClusterPolicyConstraint, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootPatchSubresourceAction(clusterpolicyconstraintsResource, name, pt, data, subresources...), &v2alpha1.ClusterPolicyConstraint{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v2alpha1.ClusterPolicyConstraint), err
+}
+
+// Apply takes the given apply declarative configuration, applies it and returns the applied clusterPolicyConstraint.
+func (c *FakeClusterPolicyConstraints) Apply(ctx context.Context, clusterPolicyConstraint *kyvernov2alpha1.ClusterPolicyConstraintApplyConfiguration, opts v1.ApplyOptions) (result *v2alpha1.ClusterPolicyConstraint, err error) {
+	if clusterPolicyConstraint == nil {
+		return nil, fmt.Errorf("clusterPolicyConstraint provided to Apply must not be nil")
+	}
+	data, err := json.Marshal(clusterPolicyConstraint)
+	if err != nil {
+		return nil, err
+	}
+	name := clusterPolicyConstraint.Name
+	if name == nil {
+		return nil, fmt.Errorf("clusterPolicyConstraint.Name must be provided to Apply")
+	}
+	obj, err := c.Fake.
+		Invokes(testing.NewRootPatchSubresourceAction(clusterpolicyconstraintsResource, *name, types.ApplyPatchType, data), &v2alpha1.ClusterPolicyConstraint{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v2alpha1.ClusterPolicyConstraint), err
+}
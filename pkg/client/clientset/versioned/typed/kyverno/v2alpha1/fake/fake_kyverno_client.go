@@ -36,10 +36,30 @@ func (c *FakeKyvernoV2alpha1) ClusterCleanupPolicies() v2alpha1.ClusterCleanupPo
 	return &FakeClusterCleanupPolicies{c}
 }
 
+func (c *FakeKyvernoV2alpha1) ClusterPolicyConstraints() v2alpha1.ClusterPolicyConstraintInterface {
+	return &FakeClusterPolicyConstraints{c}
+}
+
+func (c *FakeKyvernoV2alpha1) Notifications() v2alpha1.NotificationInterface {
+	return &FakeNotifications{c}
+}
+
 func (c *FakeKyvernoV2alpha1) PolicyExceptions(namespace string) v2alpha1.PolicyExceptionInterface {
 	return &FakePolicyExceptions{c, namespace}
 }
 
+func (c *FakeKyvernoV2alpha1) PolicySets() v2alpha1.PolicySetInterface {
+	return &FakePolicySets{c}
+}
+
+func (c *FakeKyvernoV2alpha1) ScanRequests() v2alpha1.ScanRequestInterface {
+	return &FakeScanRequests{c}
+}
+
+func (c *FakeKyvernoV2alpha1) WebhookHealths() v2alpha1.WebhookHealthInterface {
+	return &FakeWebhookHealths{c}
+}
+
 // RESTClient returns a RESTClient that is used to communicate
 // with API server by this client implementation.
 func (c *FakeKyvernoV2alpha1) RESTClient() rest.Interface {
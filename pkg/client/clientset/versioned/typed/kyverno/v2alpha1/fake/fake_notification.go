@@ -0,0 +1,178 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	"context"
+	json "encoding/json"
+	"fmt"
+
+	v2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	kyvernov2alpha1 "github.com/kyverno/kyverno/pkg/client/applyconfigurations/kyverno/v2alpha1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakeNotifications implements NotificationInterface
+type FakeNotifications struct {
+	Fake *FakeKyvernoV2alpha1
+}
+
+var notificationsResource = v2alpha1.SchemeGroupVersion.WithResource("notifications")
+
+var notificationsKind = v2alpha1.SchemeGroupVersion.WithKind("Notification")
+
+// Get takes name of the notification, and returns the corresponding notification object, and an error if there is any.
+func (c *FakeNotifications) Get(ctx context.Context, name string, options v1.GetOptions) (result *v2alpha1.Notification, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootGetAction(notificationsResource, name), &v2alpha1.Notification{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v2alpha1.Notification), err
+}
+
+// List takes label and field selectors, and returns the list of Notifications that match those selectors.
+func (c *FakeNotifications) List(ctx context.Context, opts v1.ListOptions) (result *v2alpha1.NotificationList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootListAction(notificationsResource, notificationsKind, opts), &v2alpha1.NotificationList{})
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &v2alpha1.NotificationList{ListMeta: obj.(*v2alpha1.NotificationList).ListMeta}
+	for _, item := range obj.(*v2alpha1.NotificationList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested notifications.
+func (c *FakeNotifications) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewRootWatchAction(notificationsResource, opts))
+}
+
+// Create takes the representation of a notification and creates it.  Returns the server's representation of the notification, and an error, if there is any.
+func (c *FakeNotifications) Create(ctx context.Context, notification *v2alpha1.Notification, opts v1.CreateOptions) (result *v2alpha1.Notification, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootCreateAction(notificationsResource, notification), &v2alpha1.Notification{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v2alpha1.Notification), err
+}
+
+// Update takes the representation of a notification and updates it. Returns the server's representation of the notification, and an error, if there is any.
+func (c *FakeNotifications) Update(ctx context.Context, notification *v2alpha1.Notification, opts v1.UpdateOptions) (result *v2alpha1.Notification, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootUpdateAction(notificationsResource, notification), &v2alpha1.Notification{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v2alpha1.Notification), err
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *FakeNotifications) UpdateStatus(ctx context.Context, notification *v2alpha1.Notification, opts v1.UpdateOptions) (*v2alpha1.Notification, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootUpdateSubresourceAction(notificationsResource, "status", notification), &v2alpha1.Notification{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v2alpha1.Notification), err
+}
+
+// Delete takes name of the notification and deletes it. Returns an error if one occurs.
+func (c *FakeNotifications) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewRootDeleteActionWithOptions(notificationsResource, name, opts), &v2alpha1.Notification{})
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeNotifications) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	action := testing.NewRootDeleteCollectionAction(notificationsResource, listOpts)
+
+	_, err := c.Fake.Invokes(action, &v2alpha1.NotificationList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched notification.
+func (c *FakeNotifications) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v2alpha1.Notification, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootPatchSubresourceAction(notificationsResource, name, pt, data, subresources...), &v2alpha1.Notification{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v2alpha1.Notification), err
+}
+
+// Apply takes the given apply declarative configuration, applies it and returns the applied notification.
+func (c *FakeNotifications) Apply(ctx context.Context, notification *kyvernov2alpha1.NotificationApplyConfiguration, opts v1.ApplyOptions) (result *v2alpha1.Notification, err error) {
+	if notification == nil {
+		return nil, fmt.Errorf("notification provided to Apply must not be nil")
+	}
+	data, err := json.Marshal(notification)
+	if err != nil {
+		return nil, err
+	}
+	name := notification.Name
+	if name == nil {
+		return nil, fmt.Errorf("notification.Name must be provided to Apply")
+	}
+	obj, err := c.Fake.
+		Invokes(testing.NewRootPatchSubresourceAction(notificationsResource, *name, types.ApplyPatchType, data), &v2alpha1.Notification{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v2alpha1.Notification), err
+}
+
+// ApplyStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating ApplyStatus().
+func (c *FakeNotifications) ApplyStatus(ctx context.Context, notification *kyvernov2alpha1.NotificationApplyConfiguration, opts v1.ApplyOptions) (result *v2alpha1.Notification, err error) {
+	if notification == nil {
+		return nil, fmt.Errorf("notification provided to Apply must not be nil")
+	}
+	data, err := json.Marshal(notification)
+	if err != nil {
+		return nil, err
+	}
+	name := notification.Name
+	if name == nil {
+		return nil, fmt.Errorf("notification.Name must be provided to Apply")
+	}
+	obj, err := c.Fake.
+		Invokes(testing.NewRootPatchSubresourceAction(notificationsResource, *name, types.ApplyPatchType, data, "status"), &v2alpha1.Notification{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v2alpha1.Notification), err
+}
@@ -20,8 +20,11 @@ package fake
 
 import (
 	"context"
+	json "encoding/json"
+	"fmt"
 
 	v2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	kyvernov2alpha1 "github.com/kyverno/kyverno/pkg/client/applyconfigurations/kyverno/v2alpha1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	labels "k8s.io/apimachinery/pkg/labels"
 	types "k8s.io/apimachinery/pkg/types"
@@ -127,3 +130,25 @@ func (c *FakePolicyExceptions) Patch(ctx context.Context, name string, pt types.
 	}
 	return obj.(*v2alpha1.PolicyException), err
 }
+
+// Apply takes the given apply declarative configuration, applies it and returns the applied policyException.
+func (c *FakePolicyExceptions) Apply(ctx context.Context, policyException *kyvernov2alpha1.PolicyExceptionApplyConfiguration, opts v1.ApplyOptions) (result *v2alpha1.PolicyException, err error) {
+	if policyException == nil {
+		return nil, fmt.Errorf("policyException provided to Apply must not be nil")
+	}
+	data, err := json.Marshal(policyException)
+	if err != nil {
+		return nil, err
+	}
+	name := policyException.Name
+	if name == nil {
+		return nil, fmt.Errorf("policyException.Name must be provided to Apply")
+	}
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceAction(policyexceptionsResource, c.ns, *name, types.ApplyPatchType, data), &v2alpha1.PolicyException{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v2alpha1.PolicyException), err
+}
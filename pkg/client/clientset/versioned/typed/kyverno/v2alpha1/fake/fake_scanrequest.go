@@ -0,0 +1,178 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	"context"
+	json "encoding/json"
+	"fmt"
+
+	v2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	kyvernov2alpha1 "github.com/kyverno/kyverno/pkg/client/applyconfigurations/kyverno/v2alpha1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakeScanRequests implements ScanRequestInterface
+type FakeScanRequests struct {
+	Fake *FakeKyvernoV2alpha1
+}
+
+var scanrequestsResource = v2alpha1.SchemeGroupVersion.WithResource("scanrequests")
+
+var scanrequestsKind = v2alpha1.SchemeGroupVersion.WithKind("ScanRequest")
+
+// Get takes name of the scanRequest, and returns the corresponding scanRequest object, and an error if there is any.
+func (c *FakeScanRequests) Get(ctx context.Context, name string, options v1.GetOptions) (result *v2alpha1.ScanRequest, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootGetAction(scanrequestsResource, name), &v2alpha1.ScanRequest{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v2alpha1.ScanRequest), err
+}
+
+// List takes label and field selectors, and returns the list of ScanRequests that match those selectors.
+func (c *FakeScanRequests) List(ctx context.Context, opts v1.ListOptions) (result *v2alpha1.ScanRequestList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootListAction(scanrequestsResource, scanrequestsKind, opts), &v2alpha1.ScanRequestList{})
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &v2alpha1.ScanRequestList{ListMeta: obj.(*v2alpha1.ScanRequestList).ListMeta}
+	for _, item := range obj.(*v2alpha1.ScanRequestList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested scanRequests.
+func (c *FakeScanRequests) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewRootWatchAction(scanrequestsResource, opts))
+}
+
+// Create takes the representation of a scanRequest and creates it.  Returns the server's representation of the scanRequest, and an error, if there is any.
+func (c *FakeScanRequests) Create(ctx context.Context, scanRequest *v2alpha1.ScanRequest, opts v1.CreateOptions) (result *v2alpha1.ScanRequest, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootCreateAction(scanrequestsResource, scanRequest), &v2alpha1.ScanRequest{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v2alpha1.ScanRequest), err
+}
+
+// Update takes the representation of a scanRequest and updates it. Returns the server's representation of the scanRequest, and an error, if there is any.
+func (c *FakeScanRequests) Update(ctx context.Context, scanRequest *v2alpha1.ScanRequest, opts v1.UpdateOptions) (result *v2alpha1.ScanRequest, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootUpdateAction(scanrequestsResource, scanRequest), &v2alpha1.ScanRequest{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v2alpha1.ScanRequest), err
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *FakeScanRequests) UpdateStatus(ctx context.Context, scanRequest *v2alpha1.ScanRequest, opts v1.UpdateOptions) (*v2alpha1.ScanRequest, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootUpdateSubresourceAction(scanrequestsResource, "status", scanRequest), &v2alpha1.ScanRequest{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v2alpha1.ScanRequest), err
+}
+
+// Delete takes name of the scanRequest and deletes it. Returns an error if one occurs.
+func (c *FakeScanRequests) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewRootDeleteActionWithOptions(scanrequestsResource, name, opts), &v2alpha1.ScanRequest{})
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeScanRequests) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	action := testing.NewRootDeleteCollectionAction(scanrequestsResource, listOpts)
+
+	_, err := c.Fake.Invokes(action, &v2alpha1.ScanRequestList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched scanRequest.
+func (c *FakeScanRequests) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v2alpha1.ScanRequest, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootPatchSubresourceAction(scanrequestsResource, name, pt, data, subresources...), &v2alpha1.ScanRequest{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v2alpha1.ScanRequest), err
+}
+
+// Apply takes the given apply declarative configuration, applies it and returns the applied scanRequest.
+func (c *FakeScanRequests) Apply(ctx context.Context, scanRequest *kyvernov2alpha1.ScanRequestApplyConfiguration, opts v1.ApplyOptions) (result *v2alpha1.ScanRequest, err error) {
+	if scanRequest == nil {
+		return nil, fmt.Errorf("scanRequest provided to Apply must not be nil")
+	}
+	data, err := json.Marshal(scanRequest)
+	if err != nil {
+		return nil, err
+	}
+	name := scanRequest.Name
+	if name == nil {
+		return nil, fmt.Errorf("scanRequest.Name must be provided to Apply")
+	}
+	obj, err := c.Fake.
+		Invokes(testing.NewRootPatchSubresourceAction(scanrequestsResource, *name, types.ApplyPatchType, data), &v2alpha1.ScanRequest{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v2alpha1.ScanRequest), err
+}
+
+// ApplyStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating ApplyStatus().
+func (c *FakeScanRequests) ApplyStatus(ctx context.Context, scanRequest *kyvernov2alpha1.ScanRequestApplyConfiguration, opts v1.ApplyOptions) (result *v2alpha1.ScanRequest, err error) {
+	if scanRequest == nil {
+		return nil, fmt.Errorf("scanRequest provided to Apply must not be nil")
+	}
+	data, err := json.Marshal(scanRequest)
+	if err != nil {
+		return nil, err
+	}
+	name := scanRequest.Name
+	if name == nil {
+		return nil, fmt.Errorf("scanRequest.Name must be provided to Apply")
+	}
+	obj, err := c.Fake.
+		Invokes(testing.NewRootPatchSubresourceAction(scanrequestsResource, *name, types.ApplyPatchType, data, "status"), &v2alpha1.ScanRequest{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v2alpha1.ScanRequest), err
+}
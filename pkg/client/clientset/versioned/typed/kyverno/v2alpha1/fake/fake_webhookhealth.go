@@ -0,0 +1,178 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	"context"
+	json "encoding/json"
+	"fmt"
+
+	v2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	kyvernov2alpha1 "github.com/kyverno/kyverno/pkg/client/applyconfigurations/kyverno/v2alpha1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakeWebhookHealths implements WebhookHealthInterface
+type FakeWebhookHealths struct {
+	Fake *FakeKyvernoV2alpha1
+}
+
+var webhookHealthsResource = v2alpha1.SchemeGroupVersion.WithResource("webhookhealths")
+
+var webhookHealthsKind = v2alpha1.SchemeGroupVersion.WithKind("WebhookHealth")
+
+// Get takes name of the webhookHealth, and returns the corresponding webhookHealth object, and an error if there is any.
+func (c *FakeWebhookHealths) Get(ctx context.Context, name string, options v1.GetOptions) (result *v2alpha1.WebhookHealth, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootGetAction(webhookHealthsResource, name), &v2alpha1.WebhookHealth{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v2alpha1.WebhookHealth), err
+}
+
+// List takes label and field selectors, and returns the list of WebhookHealths that match those selectors.
+func (c *FakeWebhookHealths) List(ctx context.Context, opts v1.ListOptions) (result *v2alpha1.WebhookHealthList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootListAction(webhookHealthsResource, webhookHealthsKind, opts), &v2alpha1.WebhookHealthList{})
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &v2alpha1.WebhookHealthList{ListMeta: obj.(*v2alpha1.WebhookHealthList).ListMeta}
+	for _, item := range obj.(*v2alpha1.WebhookHealthList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested webhookHealths.
+func (c *FakeWebhookHealths) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewRootWatchAction(webhookHealthsResource, opts))
+}
+
+// Create takes the representation of a webhookHealth and creates it.  Returns the server's representation of the webhookHealth, and an error, if there is any.
+func (c *FakeWebhookHealths) Create(ctx context.Context, webhookHealth *v2alpha1.WebhookHealth, opts v1.CreateOptions) (result *v2alpha1.WebhookHealth, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootCreateAction(webhookHealthsResource, webhookHealth), &v2alpha1.WebhookHealth{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v2alpha1.WebhookHealth), err
+}
+
+// Update takes the representation of a webhookHealth and updates it. Returns the server's representation of the webhookHealth, and an error, if there is any.
+func (c *FakeWebhookHealths) Update(ctx context.Context, webhookHealth *v2alpha1.WebhookHealth, opts v1.UpdateOptions) (result *v2alpha1.WebhookHealth, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootUpdateAction(webhookHealthsResource, webhookHealth), &v2alpha1.WebhookHealth{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v2alpha1.WebhookHealth), err
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *FakeWebhookHealths) UpdateStatus(ctx context.Context, webhookHealth *v2alpha1.WebhookHealth, opts v1.UpdateOptions) (*v2alpha1.WebhookHealth, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootUpdateSubresourceAction(webhookHealthsResource, "status", webhookHealth), &v2alpha1.WebhookHealth{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v2alpha1.WebhookHealth), err
+}
+
+// Delete takes name of the webhookHealth and deletes it. Returns an error if one occurs.
+func (c *FakeWebhookHealths) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewRootDeleteActionWithOptions(webhookHealthsResource, name, opts), &v2alpha1.WebhookHealth{})
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeWebhookHealths) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	action := testing.NewRootDeleteCollectionAction(webhookHealthsResource, listOpts)
+
+	_, err := c.Fake.Invokes(action, &v2alpha1.WebhookHealthList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched webhookHealth.
+func (c *FakeWebhookHealths) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v2alpha1.WebhookHealth, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootPatchSubresourceAction(webhookHealthsResource, name, pt, data, subresources...), &v2alpha1.WebhookHealth{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v2alpha1.WebhookHealth), err
+}
+
+// Apply takes the given apply declarative configuration, applies it and returns the applied webhookHealth.
+func (c *FakeWebhookHealths) Apply(ctx context.Context, webhookHealth *kyvernov2alpha1.WebhookHealthApplyConfiguration, opts v1.ApplyOptions) (result *v2alpha1.WebhookHealth, err error) {
+	if webhookHealth == nil {
+		return nil, fmt.Errorf("webhookHealth provided to Apply must not be nil")
+	}
+	data, err := json.Marshal(webhookHealth)
+	if err != nil {
+		return nil, err
+	}
+	name := webhookHealth.Name
+	if name == nil {
+		return nil, fmt.Errorf("webhookHealth.Name must be provided to Apply")
+	}
+	obj, err := c.Fake.
+		Invokes(testing.NewRootPatchSubresourceAction(webhookHealthsResource, *name, types.ApplyPatchType, data), &v2alpha1.WebhookHealth{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v2alpha1.WebhookHealth), err
+}
+
+// ApplyStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating ApplyStatus().
+func (c *FakeWebhookHealths) ApplyStatus(ctx context.Context, webhookHealth *kyvernov2alpha1.WebhookHealthApplyConfiguration, opts v1.ApplyOptions) (result *v2alpha1.WebhookHealth, err error) {
+	if webhookHealth == nil {
+		return nil, fmt.Errorf("webhookHealth provided to Apply must not be nil")
+	}
+	data, err := json.Marshal(webhookHealth)
+	if err != nil {
+		return nil, err
+	}
+	name := webhookHealth.Name
+	if name == nil {
+		return nil, fmt.Errorf("webhookHealth.Name must be provided to Apply")
+	}
+	obj, err := c.Fake.
+		Invokes(testing.NewRootPatchSubresourceAction(webhookHealthsResource, *name, types.ApplyPatchType, data, "status"), &v2alpha1.WebhookHealth{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v2alpha1.WebhookHealth), err
+}
@@ -22,4 +22,14 @@ type CleanupPolicyExpansion interface{}
 
 type ClusterCleanupPolicyExpansion interface{}
 
+type ClusterPolicyConstraintExpansion interface{}
+
+type NotificationExpansion interface{}
+
 type PolicyExceptionExpansion interface{}
+
+type PolicySetExpansion interface{}
+
+type ScanRequestExpansion interface{}
+
+type WebhookHealthExpansion interface{}
@@ -30,7 +30,12 @@ type KyvernoV2alpha1Interface interface {
 	RESTClient() rest.Interface
 	CleanupPoliciesGetter
 	ClusterCleanupPoliciesGetter
+	ClusterPolicyConstraintsGetter
+	NotificationsGetter
 	PolicyExceptionsGetter
+	PolicySetsGetter
+	ScanRequestsGetter
+	WebhookHealthsGetter
 }
 
 // KyvernoV2alpha1Client is used to interact with features provided by the kyverno.io group.
@@ -46,10 +51,30 @@ func (c *KyvernoV2alpha1Client) ClusterCleanupPolicies() ClusterCleanupPolicyInt
 	return newClusterCleanupPolicies(c)
 }
 
+func (c *KyvernoV2alpha1Client) ClusterPolicyConstraints() ClusterPolicyConstraintInterface {
+	return newClusterPolicyConstraints(c)
+}
+
+func (c *KyvernoV2alpha1Client) Notifications() NotificationInterface {
+	return newNotifications(c)
+}
+
 func (c *KyvernoV2alpha1Client) PolicyExceptions(namespace string) PolicyExceptionInterface {
 	return newPolicyExceptions(c, namespace)
 }
 
+func (c *KyvernoV2alpha1Client) PolicySets() PolicySetInterface {
+	return newPolicySets(c)
+}
+
+func (c *KyvernoV2alpha1Client) ScanRequests() ScanRequestInterface {
+	return newScanRequests(c)
+}
+
+func (c *KyvernoV2alpha1Client) WebhookHealths() WebhookHealthInterface {
+	return newWebhookHealths(c)
+}
+
 // NewForConfig creates a new KyvernoV2alpha1Client for the given config.
 // NewForConfig is equivalent to NewForConfigAndClient(c, httpClient),
 // where httpClient was generated with rest.HTTPClientFor(c).
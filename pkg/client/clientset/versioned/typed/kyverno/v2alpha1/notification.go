@@ -0,0 +1,243 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package v2alpha1
+
+import (
+	"context"
+	json "encoding/json"
+	"fmt"
+	"time"
+
+	v2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	kyvernov2alpha1 "github.com/kyverno/kyverno/pkg/client/applyconfigurations/kyverno/v2alpha1"
+	scheme "github.com/kyverno/kyverno/pkg/client/clientset/versioned/scheme"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	rest "k8s.io/client-go/rest"
+)
+
+// NotificationsGetter has a method to return a NotificationInterface.
+// A group's client should implement this interface.
+type NotificationsGetter interface {
+	Notifications() NotificationInterface
+}
+
+// NotificationInterface has methods to work with Notification resources.
+type NotificationInterface interface {
+	Create(ctx context.Context, notification *v2alpha1.Notification, opts v1.CreateOptions) (*v2alpha1.Notification, error)
+	Update(ctx context.Context, notification *v2alpha1.Notification, opts v1.UpdateOptions) (*v2alpha1.Notification, error)
+	UpdateStatus(ctx context.Context, notification *v2alpha1.Notification, opts v1.UpdateOptions) (*v2alpha1.Notification, error)
+	Delete(ctx context.Context, name string, opts v1.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error
+	Get(ctx context.Context, name string, opts v1.GetOptions) (*v2alpha1.Notification, error)
+	List(ctx context.Context, opts v1.ListOptions) (*v2alpha1.NotificationList, error)
+	Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v2alpha1.Notification, err error)
+	Apply(ctx context.Context, notification *kyvernov2alpha1.NotificationApplyConfiguration, opts v1.ApplyOptions) (result *v2alpha1.Notification, err error)
+	ApplyStatus(ctx context.Context, notification *kyvernov2alpha1.NotificationApplyConfiguration, opts v1.ApplyOptions) (result *v2alpha1.Notification, err error)
+	NotificationExpansion
+}
+
+// notifications implements NotificationInterface
+type notifications struct {
+	client rest.Interface
+}
+
+// newNotifications returns a Notifications
+func newNotifications(c *KyvernoV2alpha1Client) *notifications {
+	return &notifications{
+		client: c.RESTClient(),
+	}
+}
+
+// Get takes name of the notification, and returns the corresponding notification object, and an error if there is any.
+func (c *notifications) Get(ctx context.Context, name string, options v1.GetOptions) (result *v2alpha1.Notification, err error) {
+	result = &v2alpha1.Notification{}
+	err = c.client.Get().
+		Resource("notifications").
+		Name(name).
+		VersionedParams(&options, scheme.ParameterCodec).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// List takes label and field selectors, and returns the list of Notifications that match those selectors.
+func (c *notifications) List(ctx context.Context, opts v1.ListOptions) (result *v2alpha1.NotificationList, err error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	result = &v2alpha1.NotificationList{}
+	err = c.client.Get().
+		Resource("notifications").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Watch returns a watch.Interface that watches the requested notifications.
+func (c *notifications) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	opts.Watch = true
+	return c.client.Get().
+		Resource("notifications").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Watch(ctx)
+}
+
+// Create takes the representation of a notification and creates it.  Returns the server's representation of the notification, and an error, if there is any.
+func (c *notifications) Create(ctx context.Context, notification *v2alpha1.Notification, opts v1.CreateOptions) (result *v2alpha1.Notification, err error) {
+	result = &v2alpha1.Notification{}
+	err = c.client.Post().
+		Resource("notifications").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(notification).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Update takes the representation of a notification and updates it. Returns the server's representation of the notification, and an error, if there is any.
+func (c *notifications) Update(ctx context.Context, notification *v2alpha1.Notification, opts v1.UpdateOptions) (result *v2alpha1.Notification, err error) {
+	result = &v2alpha1.Notification{}
+	err = c.client.Put().
+		Resource("notifications").
+		Name(notification.Name).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(notification).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *notifications) UpdateStatus(ctx context.Context, notification *v2alpha1.Notification, opts v1.UpdateOptions) (result *v2alpha1.Notification, err error) {
+	result = &v2alpha1.Notification{}
+	err = c.client.Put().
+		Resource("notifications").
+		Name(notification.Name).
+		SubResource("status").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(notification).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Delete takes name of the notification and deletes it. Returns an error if one occurs.
+func (c *notifications) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	return c.client.Delete().
+		Resource("notifications").
+		Name(name).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *notifications) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	var timeout time.Duration
+	if listOpts.TimeoutSeconds != nil {
+		timeout = time.Duration(*listOpts.TimeoutSeconds) * time.Second
+	}
+	return c.client.Delete().
+		Resource("notifications").
+		VersionedParams(&listOpts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// Patch applies the patch and returns the patched notification.
+func (c *notifications) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v2alpha1.Notification, err error) {
+	result = &v2alpha1.Notification{}
+	err = c.client.Patch(pt).
+		Resource("notifications").
+		Name(name).
+		SubResource(subresources...).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(data).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Apply takes the given apply declarative configuration, applies it and returns the applied notification.
+func (c *notifications) Apply(ctx context.Context, notification *kyvernov2alpha1.NotificationApplyConfiguration, opts v1.ApplyOptions) (result *v2alpha1.Notification, err error) {
+	if notification == nil {
+		return nil, fmt.Errorf("notification provided to Apply must not be nil")
+	}
+	patchOpts := opts.ToPatchOptions()
+	data, err := json.Marshal(notification)
+	if err != nil {
+		return nil, err
+	}
+	name := notification.Name
+	if name == nil {
+		return nil, fmt.Errorf("notification.Name must be provided to Apply")
+	}
+	result = &v2alpha1.Notification{}
+	err = c.client.Patch(types.ApplyPatchType).
+		Resource("notifications").
+		Name(*name).
+		VersionedParams(&patchOpts, scheme.ParameterCodec).
+		Body(data).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// ApplyStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating ApplyStatus().
+func (c *notifications) ApplyStatus(ctx context.Context, notification *kyvernov2alpha1.NotificationApplyConfiguration, opts v1.ApplyOptions) (result *v2alpha1.Notification, err error) {
+	if notification == nil {
+		return nil, fmt.Errorf("notification provided to Apply must not be nil")
+	}
+	patchOpts := opts.ToPatchOptions()
+	data, err := json.Marshal(notification)
+	if err != nil {
+		return nil, err
+	}
+
+	name := notification.Name
+	if name == nil {
+		return nil, fmt.Errorf("notification.Name must be provided to Apply")
+	}
+
+	result = &v2alpha1.Notification{}
+	err = c.client.Patch(types.ApplyPatchType).
+		Resource("notifications").
+		Name(*name).
+		SubResource("status").
+		VersionedParams(&patchOpts, scheme.ParameterCodec).
+		Body(data).
+		Do(ctx).
+		Into(result)
+	return
+}
@@ -20,9 +20,12 @@ package v2alpha1
 
 import (
 	"context"
+	json "encoding/json"
+	"fmt"
 	"time"
 
 	v2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	kyvernov2alpha1 "github.com/kyverno/kyverno/pkg/client/applyconfigurations/kyverno/v2alpha1"
 	scheme "github.com/kyverno/kyverno/pkg/client/clientset/versioned/scheme"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	types "k8s.io/apimachinery/pkg/types"
@@ -46,6 +49,7 @@ type PolicyExceptionInterface interface {
 	List(ctx context.Context, opts v1.ListOptions) (*v2alpha1.PolicyExceptionList, error)
 	Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error)
 	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v2alpha1.PolicyException, err error)
+	Apply(ctx context.Context, policyException *kyvernov2alpha1.PolicyExceptionApplyConfiguration, opts v1.ApplyOptions) (result *v2alpha1.PolicyException, err error)
 	PolicyExceptionExpansion
 }
 
@@ -176,3 +180,29 @@ func (c *policyExceptions) Patch(ctx context.Context, name string, pt types.Patc
 		Into(result)
 	return
 }
+
+// Apply takes the given apply declarative configuration, applies it and returns the applied policyException.
+func (c *policyExceptions) Apply(ctx context.Context, policyException *kyvernov2alpha1.PolicyExceptionApplyConfiguration, opts v1.ApplyOptions) (result *v2alpha1.PolicyException, err error) {
+	if policyException == nil {
+		return nil, fmt.Errorf("policyException provided to Apply must not be nil")
+	}
+	patchOpts := opts.ToPatchOptions()
+	data, err := json.Marshal(policyException)
+	if err != nil {
+		return nil, err
+	}
+	name := policyException.Name
+	if name == nil {
+		return nil, fmt.Errorf("policyException.Name must be provided to Apply")
+	}
+	result = &v2alpha1.PolicyException{}
+	err = c.client.Patch(types.ApplyPatchType).
+		Namespace(c.ns).
+		Resource("policyexceptions").
+		Name(*name).
+		VersionedParams(&patchOpts, scheme.ParameterCodec).
+		Body(data).
+		Do(ctx).
+		Into(result)
+	return
+}
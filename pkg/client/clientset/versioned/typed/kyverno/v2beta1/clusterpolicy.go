@@ -20,9 +20,12 @@ package v2beta1
 
 import (
 	"context"
+	json "encoding/json"
+	"fmt"
 	"time"
 
 	v2beta1 "github.com/kyverno/kyverno/api/kyverno/v2beta1"
+	kyvernov2beta1 "github.com/kyverno/kyverno/pkg/client/applyconfigurations/kyverno/v2beta1"
 	scheme "github.com/kyverno/kyverno/pkg/client/clientset/versioned/scheme"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	types "k8s.io/apimachinery/pkg/types"
@@ -47,6 +50,8 @@ type ClusterPolicyInterface interface {
 	List(ctx context.Context, opts v1.ListOptions) (*v2beta1.ClusterPolicyList, error)
 	Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error)
 	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v2beta1.ClusterPolicy, err error)
+	Apply(ctx context.Context, clusterPolicy *kyvernov2beta1.ClusterPolicyApplyConfiguration, opts v1.ApplyOptions) (result *v2beta1.ClusterPolicy, err error)
+	ApplyStatus(ctx context.Context, clusterPolicy *kyvernov2beta1.ClusterPolicyApplyConfiguration, opts v1.ApplyOptions) (result *v2beta1.ClusterPolicy, err error)
 	ClusterPolicyExpansion
 }
 
@@ -182,3 +187,57 @@ func (c *clusterPolicies) Patch(ctx context.Context, name string, pt types.Patch
 		Into(result)
 	return
 }
+
+// Apply takes the given apply declarative configuration, applies it and returns the applied clusterPolicy.
+func (c *clusterPolicies) Apply(ctx context.Context, clusterPolicy *kyvernov2beta1.ClusterPolicyApplyConfiguration, opts v1.ApplyOptions) (result *v2beta1.ClusterPolicy, err error) {
+	if clusterPolicy == nil {
+		return nil, fmt.Errorf("clusterPolicy provided to Apply must not be nil")
+	}
+	patchOpts := opts.ToPatchOptions()
+	data, err := json.Marshal(clusterPolicy)
+	if err != nil {
+		return nil, err
+	}
+	name := clusterPolicy.Name
+	if name == nil {
+		return nil, fmt.Errorf("clusterPolicy.Name must be provided to Apply")
+	}
+	result = &v2beta1.ClusterPolicy{}
+	err = c.client.Patch(types.ApplyPatchType).
+		Resource("clusterpolicies").
+		Name(*name).
+		VersionedParams(&patchOpts, scheme.ParameterCodec).
+		Body(data).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// ApplyStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating ApplyStatus().
+func (c *clusterPolicies) ApplyStatus(ctx context.Context, clusterPolicy *kyvernov2beta1.ClusterPolicyApplyConfiguration, opts v1.ApplyOptions) (result *v2beta1.ClusterPolicy, err error) {
+	if clusterPolicy == nil {
+		return nil, fmt.Errorf("clusterPolicy provided to Apply must not be nil")
+	}
+	patchOpts := opts.ToPatchOptions()
+	data, err := json.Marshal(clusterPolicy)
+	if err != nil {
+		return nil, err
+	}
+
+	name := clusterPolicy.Name
+	if name == nil {
+		return nil, fmt.Errorf("clusterPolicy.Name must be provided to Apply")
+	}
+
+	result = &v2beta1.ClusterPolicy{}
+	err = c.client.Patch(types.ApplyPatchType).
+		Resource("clusterpolicies").
+		Name(*name).
+		SubResource("status").
+		VersionedParams(&patchOpts, scheme.ParameterCodec).
+		Body(data).
+		Do(ctx).
+		Into(result)
+	return
+}
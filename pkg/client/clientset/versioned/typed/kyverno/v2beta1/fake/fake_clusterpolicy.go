@@ -20,8 +20,11 @@ package fake
 
 import (
 	"context"
+	json "encoding/json"
+	"fmt"
 
 	v2beta1 "github.com/kyverno/kyverno/api/kyverno/v2beta1"
+	kyvernov2beta1 "github.com/kyverno/kyverno/pkg/client/applyconfigurations/kyverno/v2beta1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	labels "k8s.io/apimachinery/pkg/labels"
 	types "k8s.io/apimachinery/pkg/types"
@@ -130,3 +133,46 @@ func (c *FakeClusterPolicies) Patch(ctx context.Context, name string, pt types.P
 	}
 	return obj.(*v2beta1.ClusterPolicy), err
 }
+
+// Apply takes the given apply declarative configuration, applies it and returns the applied clusterPolicy.
+func (c *FakeClusterPolicies) Apply(ctx context.Context, clusterPolicy *kyvernov2beta1.ClusterPolicyApplyConfiguration, opts v1.ApplyOptions) (result *v2beta1.ClusterPolicy, err error) {
+	if clusterPolicy == nil {
+		return nil, fmt.Errorf("clusterPolicy provided to Apply must not be nil")
+	}
+	data, err := json.Marshal(clusterPolicy)
+	if err != nil {
+		return nil, err
+	}
+	name := clusterPolicy.Name
+	if name == nil {
+		return nil, fmt.Errorf("clusterPolicy.Name must be provided to Apply")
+	}
+	obj, err := c.Fake.
+		Invokes(testing.NewRootPatchSubresourceAction(clusterpoliciesResource, *name, types.ApplyPatchType, data), &v2beta1.ClusterPolicy{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v2beta1.ClusterPolicy), err
+}
+
+// ApplyStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating ApplyStatus().
+func (c *FakeClusterPolicies) ApplyStatus(ctx context.Context, clusterPolicy *kyvernov2beta1.ClusterPolicyApplyConfiguration, opts v1.ApplyOptions) (result *v2beta1.ClusterPolicy, err error) {
+	if clusterPolicy == nil {
+		return nil, fmt.Errorf("clusterPolicy provided to Apply must not be nil")
+	}
+	data, err := json.Marshal(clusterPolicy)
+	if err != nil {
+		return nil, err
+	}
+	name := clusterPolicy.Name
+	if name == nil {
+		return nil, fmt.Errorf("clusterPolicy.Name must be provided to Apply")
+	}
+	obj, err := c.Fake.
+		Invokes(testing.NewRootPatchSubresourceAction(clusterpoliciesResource, *name, types.ApplyPatchType, data, "status"), &v2beta1.ClusterPolicy{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v2beta1.ClusterPolicy), err
+}
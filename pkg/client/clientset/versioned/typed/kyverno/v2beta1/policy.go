@@ -20,9 +20,12 @@ package v2beta1
 
 import (
 	"context"
+	json "encoding/json"
+	"fmt"
 	"time"
 
 	v2beta1 "github.com/kyverno/kyverno/api/kyverno/v2beta1"
+	kyvernov2beta1 "github.com/kyverno/kyverno/pkg/client/applyconfigurations/kyverno/v2beta1"
 	scheme "github.com/kyverno/kyverno/pkg/client/clientset/versioned/scheme"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	types "k8s.io/apimachinery/pkg/types"
@@ -47,6 +50,8 @@ type PolicyInterface interface {
 	List(ctx context.Context, opts v1.ListOptions) (*v2beta1.PolicyList, error)
 	Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error)
 	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v2beta1.Policy, err error)
+	Apply(ctx context.Context, policy *kyvernov2beta1.PolicyApplyConfiguration, opts v1.ApplyOptions) (result *v2beta1.Policy, err error)
+	ApplyStatus(ctx context.Context, policy *kyvernov2beta1.PolicyApplyConfiguration, opts v1.ApplyOptions) (result *v2beta1.Policy, err error)
 	PolicyExpansion
 }
 
@@ -193,3 +198,59 @@ func (c *policies) Patch(ctx context.Context, name string, pt types.PatchType, d
 		Into(result)
 	return
 }
+
+// Apply takes the given apply declarative configuration, applies it and returns the applied policy.
+func (c *policies) Apply(ctx context.Context, policy *kyvernov2beta1.PolicyApplyConfiguration, opts v1.ApplyOptions) (result *v2beta1.Policy, err error) {
+	if policy == nil {
+		return nil, fmt.Errorf("policy provided to Apply must not be nil")
+	}
+	patchOpts := opts.ToPatchOptions()
+	data, err := json.Marshal(policy)
+	if err != nil {
+		return nil, err
+	}
+	name := policy.Name
+	if name == nil {
+		return nil, fmt.Errorf("policy.Name must be provided to Apply")
+	}
+	result = &v2beta1.Policy{}
+	err = c.client.Patch(types.ApplyPatchType).
+		Namespace(c.ns).
+		Resource("policies").
+		Name(*name).
+		VersionedParams(&patchOpts, scheme.ParameterCodec).
+		Body(data).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// ApplyStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating ApplyStatus().
+func (c *policies) ApplyStatus(ctx context.Context, policy *kyvernov2beta1.PolicyApplyConfiguration, opts v1.ApplyOptions) (result *v2beta1.Policy, err error) {
+	if policy == nil {
+		return nil, fmt.Errorf("policy provided to Apply must not be nil")
+	}
+	patchOpts := opts.ToPatchOptions()
+	data, err := json.Marshal(policy)
+	if err != nil {
+		return nil, err
+	}
+
+	name := policy.Name
+	if name == nil {
+		return nil, fmt.Errorf("policy.Name must be provided to Apply")
+	}
+
+	result = &v2beta1.Policy{}
+	err = c.client.Patch(types.ApplyPatchType).
+		Namespace(c.ns).
+		Resource("policies").
+		Name(*name).
+		SubResource("status").
+		VersionedParams(&patchOpts, scheme.ParameterCodec).
+		Body(data).
+		Do(ctx).
+		Into(result)
+	return
+}
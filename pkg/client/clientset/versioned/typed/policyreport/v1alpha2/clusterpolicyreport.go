@@ -20,9 +20,12 @@ package v1alpha2
 
 import (
 	"context"
+	json "encoding/json"
+	"fmt"
 	"time"
 
 	v1alpha2 "github.com/kyverno/kyverno/api/policyreport/v1alpha2"
+	policyreportv1alpha2 "github.com/kyverno/kyverno/pkg/client/applyconfigurations/policyreport/v1alpha2"
 	scheme "github.com/kyverno/kyverno/pkg/client/clientset/versioned/scheme"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	types "k8s.io/apimachinery/pkg/types"
@@ -46,6 +49,7 @@ type ClusterPolicyReportInterface interface {
 	List(ctx context.Context, opts v1.ListOptions) (*v1alpha2.ClusterPolicyReportList, error)
 	Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error)
 	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha2.ClusterPolicyReport, err error)
+	Apply(ctx context.Context, clusterPolicyReport *policyreportv1alpha2.ClusterPolicyReportApplyConfiguration, opts v1.ApplyOptions) (result *v1alpha2.ClusterPolicyReport, err error)
 	ClusterPolicyReportExpansion
 }
 
@@ -166,3 +170,28 @@ func (c *clusterPolicyReports) Patch(ctx context.Context, name string, pt types.
 		Into(result)
 	return
 }
+
+// Apply takes the given apply declarative configuration, applies it and returns the applied clusterPolicyReport.
+func (c *clusterPolicyReports) Apply(ctx context.Context, clusterPolicyReport *policyreportv1alpha2.ClusterPolicyReportApplyConfiguration, opts v1.ApplyOptions) (result *v1alpha2.ClusterPolicyReport, err error) {
+	if clusterPolicyReport == nil {
+		return nil, fmt.Errorf("clusterPolicyReport provided to Apply must not be nil")
+	}
+	patchOpts := opts.ToPatchOptions()
+	data, err := json.Marshal(clusterPolicyReport)
+	if err != nil {
+		return nil, err
+	}
+	name := clusterPolicyReport.Name
+	if name == nil {
+		return nil, fmt.Errorf("clusterPolicyReport.Name must be provided to Apply")
+	}
+	result = &v1alpha2.ClusterPolicyReport{}
+	err = c.client.Patch(types.ApplyPatchType).
+		Resource("clusterpolicyreports").
+		Name(*name).
+		VersionedParams(&patchOpts, scheme.ParameterCodec).
+		Body(data).
+		Do(ctx).
+		Into(result)
+	return
+}
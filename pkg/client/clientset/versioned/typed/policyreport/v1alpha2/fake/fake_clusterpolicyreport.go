@@ -20,8 +20,11 @@ package fake
 
 import (
 	"context"
+	json "encoding/json"
+	"fmt"
 
 	v1alpha2 "github.com/kyverno/kyverno/api/policyreport/v1alpha2"
+	policyreportv1alpha2 "github.com/kyverno/kyverno/pkg/client/applyconfigurations/policyreport/v1alpha2"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	labels "k8s.io/apimachinery/pkg/labels"
 	types "k8s.io/apimachinery/pkg/types"
@@ -119,3 +122,24 @@ func (c *FakeClusterPolicyReports) Patch(ctx context.Context, name string, pt ty
 	}
 	return obj.(*v1alpha2.ClusterPolicyReport), err
 }
+
+// Apply takes the given apply declarative configuration, applies it and returns the applied clusterPolicyReport.
+func (c *FakeClusterPolicyReports) Apply(ctx context.Context, clusterPolicyReport *policyreportv1alpha2.ClusterPolicyReportApplyConfiguration, opts v1.ApplyOptions) (result *v1alpha2.ClusterPolicyReport, err error) {
+	if clusterPolicyReport == nil {
+		return nil, fmt.Errorf("clusterPolicyReport provided to Apply must not be nil")
+	}
+	data, err := json.Marshal(clusterPolicyReport)
+	if err != nil {
+		return nil, err
+	}
+	name := clusterPolicyReport.Name
+	if name == nil {
+		return nil, fmt.Errorf("clusterPolicyReport.Name must be provided to Apply")
+	}
+	obj, err := c.Fake.
+		Invokes(testing.NewRootPatchSubresourceAction(clusterpolicyreportsResource, *name, types.ApplyPatchType, data), &v1alpha2.ClusterPolicyReport{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha2.ClusterPolicyReport), err
+}
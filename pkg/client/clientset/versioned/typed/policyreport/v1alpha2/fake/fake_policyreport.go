@@ -20,8 +20,11 @@ package fake
 
 import (
 	"context"
+	json "encoding/json"
+	"fmt"
 
 	v1alpha2 "github.com/kyverno/kyverno/api/policyreport/v1alpha2"
+	policyreportv1alpha2 "github.com/kyverno/kyverno/pkg/client/applyconfigurations/policyreport/v1alpha2"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	labels "k8s.io/apimachinery/pkg/labels"
 	types "k8s.io/apimachinery/pkg/types"
@@ -127,3 +130,25 @@ func (c *FakePolicyReports) Patch(ctx context.Context, name string, pt types.Pat
 	}
 	return obj.(*v1alpha2.PolicyReport), err
 }
+
+// Apply takes the given apply declarative configuration, applies it and returns the applied policyReport.
+func (c *FakePolicyReports) Apply(ctx context.Context, policyReport *policyreportv1alpha2.PolicyReportApplyConfiguration, opts v1.ApplyOptions) (result *v1alpha2.PolicyReport, err error) {
+	if policyReport == nil {
+		return nil, fmt.Errorf("policyReport provided to Apply must not be nil")
+	}
+	data, err := json.Marshal(policyReport)
+	if err != nil {
+		return nil, err
+	}
+	name := policyReport.Name
+	if name == nil {
+		return nil, fmt.Errorf("policyReport.Name must be provided to Apply")
+	}
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceAction(policyreportsResource, c.ns, *name, types.ApplyPatchType, data), &v1alpha2.PolicyReport{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha2.PolicyReport), err
+}
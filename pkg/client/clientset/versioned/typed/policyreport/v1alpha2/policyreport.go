@@ -20,9 +20,12 @@ package v1alpha2
 
 import (
 	"context"
+	json "encoding/json"
+	"fmt"
 	"time"
 
 	v1alpha2 "github.com/kyverno/kyverno/api/policyreport/v1alpha2"
+	policyreportv1alpha2 "github.com/kyverno/kyverno/pkg/client/applyconfigurations/policyreport/v1alpha2"
 	scheme "github.com/kyverno/kyverno/pkg/client/clientset/versioned/scheme"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	types "k8s.io/apimachinery/pkg/types"
@@ -46,6 +49,7 @@ type PolicyReportInterface interface {
 	List(ctx context.Context, opts v1.ListOptions) (*v1alpha2.PolicyReportList, error)
 	Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error)
 	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha2.PolicyReport, err error)
+	Apply(ctx context.Context, policyReport *policyreportv1alpha2.PolicyReportApplyConfiguration, opts v1.ApplyOptions) (result *v1alpha2.PolicyReport, err error)
 	PolicyReportExpansion
 }
 
@@ -176,3 +180,29 @@ func (c *policyReports) Patch(ctx context.Context, name string, pt types.PatchTy
 		Into(result)
 	return
 }
+
+// Apply takes the given apply declarative configuration, applies it and returns the applied policyReport.
+func (c *policyReports) Apply(ctx context.Context, policyReport *policyreportv1alpha2.PolicyReportApplyConfiguration, opts v1.ApplyOptions) (result *v1alpha2.PolicyReport, err error) {
+	if policyReport == nil {
+		return nil, fmt.Errorf("policyReport provided to Apply must not be nil")
+	}
+	patchOpts := opts.ToPatchOptions()
+	data, err := json.Marshal(policyReport)
+	if err != nil {
+		return nil, err
+	}
+	name := policyReport.Name
+	if name == nil {
+		return nil, fmt.Errorf("policyReport.Name must be provided to Apply")
+	}
+	result = &v1alpha2.PolicyReport{}
+	err = c.client.Patch(types.ApplyPatchType).
+		Namespace(c.ns).
+		Resource("policyreports").
+		Name(*name).
+		VersionedParams(&patchOpts, scheme.ParameterCodec).
+		Body(data).
+		Do(ctx).
+		Into(result)
+	return
+}
@@ -82,8 +82,18 @@ func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Kyverno().V2alpha1().CleanupPolicies().Informer()}, nil
 	case v2alpha1.SchemeGroupVersion.WithResource("clustercleanuppolicies"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Kyverno().V2alpha1().ClusterCleanupPolicies().Informer()}, nil
+	case v2alpha1.SchemeGroupVersion.WithResource("clusterpolicyconstraints"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Kyverno().V2alpha1().ClusterPolicyConstraints().Informer()}, nil
+	case v2alpha1.SchemeGroupVersion.WithResource("notifications"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Kyverno().V2alpha1().Notifications().Informer()}, nil
 	case v2alpha1.SchemeGroupVersion.WithResource("policyexceptions"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Kyverno().V2alpha1().PolicyExceptions().Informer()}, nil
+	case v2alpha1.SchemeGroupVersion.WithResource("policysets"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Kyverno().V2alpha1().PolicySets().Informer()}, nil
+	case v2alpha1.SchemeGroupVersion.WithResource("scanrequests"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Kyverno().V2alpha1().ScanRequests().Informer()}, nil
+	case v2alpha1.SchemeGroupVersion.WithResource("webhookhealths"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Kyverno().V2alpha1().WebhookHealths().Informer()}, nil
 
 		// Group=kyverno.io, Version=v2beta1
 	case v2beta1.SchemeGroupVersion.WithResource("clusterpolicies"):
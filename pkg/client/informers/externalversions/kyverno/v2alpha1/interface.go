@@ -28,8 +28,18 @@ type Interface interface {
 	CleanupPolicies() CleanupPolicyInformer
 	// ClusterCleanupPolicies returns a ClusterCleanupPolicyInformer.
 	ClusterCleanupPolicies() ClusterCleanupPolicyInformer
+	// ClusterPolicyConstraints returns a ClusterPolicyConstraintInformer.
+	ClusterPolicyConstraints() ClusterPolicyConstraintInformer
+	// Notifications returns a NotificationInformer.
+	Notifications() NotificationInformer
 	// PolicyExceptions returns a PolicyExceptionInformer.
 	PolicyExceptions() PolicyExceptionInformer
+	// PolicySets returns a PolicySetInformer.
+	PolicySets() PolicySetInformer
+	// ScanRequests returns a ScanRequestInformer.
+	ScanRequests() ScanRequestInformer
+	// WebhookHealths returns a WebhookHealthInformer.
+	WebhookHealths() WebhookHealthInformer
 }
 
 type version struct {
@@ -53,7 +63,32 @@ func (v *version) ClusterCleanupPolicies() ClusterCleanupPolicyInformer {
 	return &clusterCleanupPolicyInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
 }
 
+// ClusterPolicyConstraints returns a ClusterPolicyConstraintInformer.
+func (v *version) ClusterPolicyConstraints() ClusterPolicyConstraintInformer {
+	return &clusterPolicyConstraintInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
+// Notifications returns a NotificationInformer.
+func (v *version) Notifications() NotificationInformer {
+	return &notificationInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
 // PolicyExceptions returns a PolicyExceptionInformer.
 func (v *version) PolicyExceptions() PolicyExceptionInformer {
 	return &policyExceptionInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
+
+// PolicySets returns a PolicySetInformer.
+func (v *version) PolicySets() PolicySetInformer {
+	return &policySetInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
+// ScanRequests returns a ScanRequestInformer.
+func (v *version) ScanRequests() ScanRequestInformer {
+	return &scanRequestInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
+// WebhookHealths returns a WebhookHealthInformer.
+func (v *version) WebhookHealths() WebhookHealthInformer {
+	return &webhookHealthInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
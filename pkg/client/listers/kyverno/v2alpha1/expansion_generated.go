@@ -30,6 +30,14 @@ type CleanupPolicyNamespaceListerExpansion interface{}
 // ClusterCleanupPolicyLister.
 type ClusterCleanupPolicyListerExpansion interface{}
 
+// ClusterPolicyConstraintListerExpansion allows custom methods to be added to
+// ClusterPolicyConstraintLister.
+type ClusterPolicyConstraintListerExpansion interface{}
+
+// NotificationListerExpansion allows custom methods to be added to
+// NotificationLister.
+type NotificationListerExpansion interface{}
+
 // PolicyExceptionListerExpansion allows custom methods to be added to
 // PolicyExceptionLister.
 type PolicyExceptionListerExpansion interface{}
@@ -37,3 +45,15 @@ type PolicyExceptionListerExpansion interface{}
 // PolicyExceptionNamespaceListerExpansion allows custom methods to be added to
 // PolicyExceptionNamespaceLister.
 type PolicyExceptionNamespaceListerExpansion interface{}
+
+// PolicySetListerExpansion allows custom methods to be added to
+// PolicySetLister.
+type PolicySetListerExpansion interface{}
+
+// ScanRequestListerExpansion allows custom methods to be added to
+// ScanRequestLister.
+type ScanRequestListerExpansion interface{}
+
+// WebhookHealthListerExpansion allows custom methods to be added to
+// WebhookHealthLister.
+type WebhookHealthListerExpansion interface{}
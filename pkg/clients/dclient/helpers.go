@@ -77,9 +77,9 @@ func GetResources(ctx context.Context, c Interface, group, version, kind, subres
 					var obj *unstructured.Unstructured
 					var err error
 					if parentObject.GetNamespace() == "" {
-						obj, err = dyn.Get(ctx, name, metav1.GetOptions{}, sub...)
+						obj, err = dyn.Get(ctx, parentObject.GetName(), metav1.GetOptions{}, sub...)
 					} else {
-						obj, err = dyn.Namespace(parentObject.GetNamespace()).Get(ctx, name, metav1.GetOptions{}, sub...)
+						obj, err = dyn.Namespace(parentObject.GetNamespace()).Get(ctx, parentObject.GetName(), metav1.GetOptions{}, sub...)
 					}
 					if err != nil {
 						return nil, err
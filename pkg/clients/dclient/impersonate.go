@@ -0,0 +1,62 @@
+package dclient
+
+import (
+	"fmt"
+	"sync"
+
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+// ImpersonatingClientFactory creates clients acting on behalf of another user
+type ImpersonatingClientFactory interface {
+	// ForUser returns a client impersonating the given user
+	ForUser(username string) (Interface, error)
+}
+
+type impersonatingClientFactory struct {
+	config  *rest.Config
+	base    Interface
+	lock    sync.Mutex
+	clients map[string]Interface
+}
+
+// NewImpersonatingClientFactory creates a factory building clients from the given rest config,
+// the clients share the discovery client of the base client and are cached per user
+func NewImpersonatingClientFactory(config *rest.Config, base Interface) ImpersonatingClientFactory {
+	return &impersonatingClientFactory{
+		config:  config,
+		base:    base,
+		clients: map[string]Interface{},
+	}
+}
+
+func (f *impersonatingClientFactory) ForUser(username string) (Interface, error) {
+	if username == "" {
+		return nil, fmt.Errorf("a user name is required for impersonation")
+	}
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	if client, ok := f.clients[username]; ok {
+		return client, nil
+	}
+	config := rest.CopyConfig(f.config)
+	config.Impersonate = rest.ImpersonationConfig{UserName: username}
+	dyn, err := dynamic.NewForConfig(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create dynamic client impersonating %s: %w", username, err)
+	}
+	kube, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create kubernetes client impersonating %s: %w", username, err)
+	}
+	client := &client{
+		dyn:   dyn,
+		kube:  kube,
+		rest:  kube.Discovery().RESTClient(),
+		disco: f.base.Discovery(),
+	}
+	f.clients[username] = client
+	return client, nil
+}
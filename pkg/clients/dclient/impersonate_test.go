@@ -0,0 +1,52 @@
+package dclient
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"gotest.tools/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/rest"
+)
+
+func Test_impersonatingClientFactory_ForUser(t *testing.T) {
+	var lock sync.Mutex
+	var users []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lock.Lock()
+		users = append(users, r.Header.Get("Impersonate-User"))
+		lock.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"apiVersion":"v1","kind":"Namespace","metadata":{"name":"default"}}`))
+	}))
+	defer server.Close()
+	base, err := NewFakeClient(runtime.NewScheme(), map[schema.GroupVersionResource]string{})
+	assert.NilError(t, err)
+	base.SetDiscovery(NewFakeDiscoveryClient(nil))
+	factory := NewImpersonatingClientFactory(&rest.Config{Host: server.URL}, base)
+
+	_, err = factory.ForUser("")
+	assert.ErrorContains(t, err, "a user name is required")
+
+	alice, err := factory.ForUser("system:serviceaccount:default:alice")
+	assert.NilError(t, err)
+	// clients are cached per user and share the discovery client of the base client
+	cached, err := factory.ForUser("system:serviceaccount:default:alice")
+	assert.NilError(t, err)
+	assert.Equal(t, alice, cached)
+	assert.Equal(t, alice.Discovery(), base.Discovery())
+	bob, err := factory.ForUser("system:serviceaccount:default:bob")
+	assert.NilError(t, err)
+	assert.Assert(t, alice != bob)
+
+	_, err = alice.GetKubeClient().CoreV1().Namespaces().Get(context.TODO(), "default", metav1.GetOptions{})
+	assert.NilError(t, err)
+	_, err = bob.GetKubeClient().CoreV1().Namespaces().Get(context.TODO(), "default", metav1.GetOptions{})
+	assert.NilError(t, err)
+	assert.DeepEqual(t, users, []string{"system:serviceaccount:default:alice", "system:serviceaccount:default:bob"})
+}
@@ -0,0 +1,120 @@
+package dclient
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+// RemoteClientFactory creates clients acting on remote clusters
+type RemoteClientFactory interface {
+	// ForSecret returns a client for the cluster whose kubeconfig is stored under the given key of the Secret
+	ForSecret(ctx context.Context, namespace, name, key string) (Interface, error)
+}
+
+// secretRefreshInterval is the interval the kubeconfig Secret of a cached client is read again at
+const secretRefreshInterval = time.Minute
+
+type remoteClient struct {
+	resourceVersion string
+	client          Interface
+	cancel          context.CancelFunc
+	checked         time.Time
+}
+
+type remoteClientFactory struct {
+	ctx     context.Context
+	kube    kubernetes.Interface
+	qps     float32
+	burst   int
+	resync  time.Duration
+	refresh time.Duration
+	lock    sync.Mutex
+	clients map[string]remoteClient
+}
+
+// NewRemoteClientFactory creates a factory building clients from the kubeconfig Secrets read with the given client,
+// the clients are cached per Secret and rebuilt when the Secret changes, the Secret is read again at most every minute
+func NewRemoteClientFactory(ctx context.Context, kube kubernetes.Interface, qps float32, burst int, resync time.Duration) RemoteClientFactory {
+	return &remoteClientFactory{
+		ctx:     ctx,
+		kube:    kube,
+		qps:     qps,
+		burst:   burst,
+		resync:  resync,
+		refresh: secretRefreshInterval,
+		clients: map[string]remoteClient{},
+	}
+}
+
+func (f *remoteClientFactory) ForSecret(ctx context.Context, namespace, name, key string) (Interface, error) {
+	cacheKey := namespace + "/" + name + "/" + key
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	cached, ok := f.clients[cacheKey]
+	if ok && time.Since(cached.checked) < f.refresh {
+		return cached.client, nil
+	}
+	secret, err := f.kube.CoreV1().Secrets(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get kubeconfig secret %s/%s: %w", namespace, name, err)
+	}
+	if ok {
+		if cached.resourceVersion == secret.ResourceVersion {
+			cached.checked = time.Now()
+			f.clients[cacheKey] = cached
+			return cached.client, nil
+		}
+		// the secret changed, stop the discovery of the previous client
+		cached.cancel()
+		delete(f.clients, cacheKey)
+	}
+	client, cancel, err := f.newClient(secret, key)
+	if err != nil {
+		return nil, err
+	}
+	f.clients[cacheKey] = remoteClient{
+		resourceVersion: secret.ResourceVersion,
+		client:          client,
+		cancel:          cancel,
+		checked:         time.Now(),
+	}
+	return client, nil
+}
+
+// newClient builds a client from the kubeconfig stored under the given key of the Secret
+func (f *remoteClientFactory) newClient(secret *corev1.Secret, key string) (Interface, context.CancelFunc, error) {
+	namespace, name := secret.Namespace, secret.Name
+	kubeconfig, ok := secret.Data[key]
+	if !ok {
+		return nil, nil, fmt.Errorf("kubeconfig secret %s/%s has no %s key", namespace, name, key)
+	}
+	config, err := clientcmd.RESTConfigFromKubeConfig(kubeconfig)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load kubeconfig from secret %s/%s: %w", namespace, name, err)
+	}
+	config.QPS = f.qps
+	config.Burst = f.burst
+	dyn, err := dynamic.NewForConfig(config)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create dynamic client for secret %s/%s: %w", namespace, name, err)
+	}
+	kube, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create kubernetes client for secret %s/%s: %w", namespace, name, err)
+	}
+	clientCtx, cancel := context.WithCancel(f.ctx)
+	client, err := NewClient(clientCtx, dyn, kube, f.resync)
+	if err != nil {
+		cancel()
+		return nil, nil, fmt.Errorf("failed to create client for secret %s/%s: %w", namespace, name, err)
+	}
+	return client, cancel, nil
+}
@@ -0,0 +1,78 @@
+package dclient
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"gotest.tools/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+const kubeconfig = `
+apiVersion: v1
+kind: Config
+clusters:
+- name: remote
+  cluster:
+    server: https://remote.example.com
+contexts:
+- name: remote
+  context:
+    cluster: remote
+    user: remote
+current-context: remote
+users:
+- name: remote
+  user:
+    token: token
+`
+
+func countSecretGets(kube *fake.Clientset) int {
+	count := 0
+	for _, action := range kube.Actions() {
+		if action.GetVerb() == "get" && action.GetResource().Resource == "secrets" {
+			count++
+		}
+	}
+	return count
+}
+
+func Test_remoteClientFactory_ForSecret(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.TODO())
+	defer cancel()
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "remote", Namespace: "kyverno", ResourceVersion: "1"},
+		Data:       map[string][]byte{"kubeconfig": []byte(kubeconfig)},
+	}
+	kube := fake.NewSimpleClientset(secret)
+	factory := NewRemoteClientFactory(ctx, kube, 0, 0, time.Hour).(*remoteClientFactory)
+
+	client, err := factory.ForSecret(ctx, "kyverno", "remote", "kubeconfig")
+	assert.NilError(t, err)
+	// the secret is not read again before the refresh interval
+	cached, err := factory.ForSecret(ctx, "kyverno", "remote", "kubeconfig")
+	assert.NilError(t, err)
+	assert.Equal(t, client, cached)
+	assert.Equal(t, countSecretGets(kube), 1)
+
+	// the client is kept while the secret doesn't change
+	factory.refresh = 0
+	cached, err = factory.ForSecret(ctx, "kyverno", "remote", "kubeconfig")
+	assert.NilError(t, err)
+	assert.Equal(t, client, cached)
+	assert.Equal(t, countSecretGets(kube), 2)
+
+	// the client is rebuilt when the secret changes
+	secret.ResourceVersion = "2"
+	_, err = kube.CoreV1().Secrets("kyverno").Update(ctx, secret, metav1.UpdateOptions{})
+	assert.NilError(t, err)
+	rebuilt, err := factory.ForSecret(ctx, "kyverno", "remote", "kubeconfig")
+	assert.NilError(t, err)
+	assert.Assert(t, rebuilt != client)
+
+	_, err = factory.ForSecret(ctx, "kyverno", "remote", "missing")
+	assert.ErrorContains(t, err, "has no missing key")
+}
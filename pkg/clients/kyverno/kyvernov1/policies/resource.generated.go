@@ -7,6 +7,7 @@ import (
 
 	"github.com/go-logr/logr"
 	github_com_kyverno_kyverno_api_kyverno_v1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	github_com_kyverno_kyverno_pkg_client_applyconfigurations_kyverno_v1 "github.com/kyverno/kyverno/pkg/client/applyconfigurations/kyverno/v1"
 	github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v1 "github.com/kyverno/kyverno/pkg/client/clientset/versioned/typed/kyverno/v1"
 	"github.com/kyverno/kyverno/pkg/metrics"
 	"github.com/kyverno/kyverno/pkg/tracing"
@@ -34,6 +35,28 @@ type withLogging struct {
 	logger logr.Logger
 }
 
+func (c *withLogging) Apply(arg0 context.Context, arg1 *github_com_kyverno_kyverno_pkg_client_applyconfigurations_kyverno_v1.PolicyApplyConfiguration, arg2 k8s_io_apimachinery_pkg_apis_meta_v1.ApplyOptions) (*github_com_kyverno_kyverno_api_kyverno_v1.Policy, error) {
+	start := time.Now()
+	logger := c.logger.WithValues("operation", "Apply")
+	ret0, ret1 := c.inner.Apply(arg0, arg1, arg2)
+	if err := multierr.Combine(ret1); err != nil {
+		logger.Error(err, "Apply failed", "duration", time.Since(start))
+	} else {
+		logger.Info("Apply done", "duration", time.Since(start))
+	}
+	return ret0, ret1
+}
+func (c *withLogging) ApplyStatus(arg0 context.Context, arg1 *github_com_kyverno_kyverno_pkg_client_applyconfigurations_kyverno_v1.PolicyApplyConfiguration, arg2 k8s_io_apimachinery_pkg_apis_meta_v1.ApplyOptions) (*github_com_kyverno_kyverno_api_kyverno_v1.Policy, error) {
+	start := time.Now()
+	logger := c.logger.WithValues("operation", "ApplyStatus")
+	ret0, ret1 := c.inner.ApplyStatus(arg0, arg1, arg2)
+	if err := multierr.Combine(ret1); err != nil {
+		logger.Error(err, "ApplyStatus failed", "duration", time.Since(start))
+	} else {
+		logger.Info("ApplyStatus done", "duration", time.Since(start))
+	}
+	return ret0, ret1
+}
 func (c *withLogging) Create(arg0 context.Context, arg1 *github_com_kyverno_kyverno_api_kyverno_v1.Policy, arg2 k8s_io_apimachinery_pkg_apis_meta_v1.CreateOptions) (*github_com_kyverno_kyverno_api_kyverno_v1.Policy, error) {
 	start := time.Now()
 	logger := c.logger.WithValues("operation", "Create")
@@ -139,6 +162,14 @@ type withMetrics struct {
 	recorder metrics.Recorder
 }
 
+func (c *withMetrics) Apply(arg0 context.Context, arg1 *github_com_kyverno_kyverno_pkg_client_applyconfigurations_kyverno_v1.PolicyApplyConfiguration, arg2 k8s_io_apimachinery_pkg_apis_meta_v1.ApplyOptions) (*github_com_kyverno_kyverno_api_kyverno_v1.Policy, error) {
+	defer c.recorder.RecordWithContext(arg0, "apply")
+	return c.inner.Apply(arg0, arg1, arg2)
+}
+func (c *withMetrics) ApplyStatus(arg0 context.Context, arg1 *github_com_kyverno_kyverno_pkg_client_applyconfigurations_kyverno_v1.PolicyApplyConfiguration, arg2 k8s_io_apimachinery_pkg_apis_meta_v1.ApplyOptions) (*github_com_kyverno_kyverno_api_kyverno_v1.Policy, error) {
+	defer c.recorder.RecordWithContext(arg0, "apply_status")
+	return c.inner.ApplyStatus(arg0, arg1, arg2)
+}
 func (c *withMetrics) Create(arg0 context.Context, arg1 *github_com_kyverno_kyverno_api_kyverno_v1.Policy, arg2 k8s_io_apimachinery_pkg_apis_meta_v1.CreateOptions) (*github_com_kyverno_kyverno_api_kyverno_v1.Policy, error) {
 	defer c.recorder.RecordWithContext(arg0, "create")
 	return c.inner.Create(arg0, arg1, arg2)
@@ -182,6 +213,48 @@ type withTracing struct {
 	kind   string
 }
 
+func (c *withTracing) Apply(arg0 context.Context, arg1 *github_com_kyverno_kyverno_pkg_client_applyconfigurations_kyverno_v1.PolicyApplyConfiguration, arg2 k8s_io_apimachinery_pkg_apis_meta_v1.ApplyOptions) (*github_com_kyverno_kyverno_api_kyverno_v1.Policy, error) {
+	var span trace.Span
+	if tracing.IsInSpan(arg0) {
+		arg0, span = tracing.StartChildSpan(
+			arg0,
+			"",
+			fmt.Sprintf("KUBE %s/%s/%s", c.client, c.kind, "Apply"),
+			trace.WithAttributes(
+				tracing.KubeClientGroupKey.String(c.client),
+				tracing.KubeClientKindKey.String(c.kind),
+				tracing.KubeClientOperationKey.String("Apply"),
+			),
+		)
+		defer span.End()
+	}
+	ret0, ret1 := c.inner.Apply(arg0, arg1, arg2)
+	if span != nil {
+		tracing.SetSpanStatus(span, ret1)
+	}
+	return ret0, ret1
+}
+func (c *withTracing) ApplyStatus(arg0 context.Context, arg1 *github_com_kyverno_kyverno_pkg_client_applyconfigurations_kyverno_v1.PolicyApplyConfiguration, arg2 k8s_io_apimachinery_pkg_apis_meta_v1.ApplyOptions) (*github_com_kyverno_kyverno_api_kyverno_v1.Policy, error) {
+	var span trace.Span
+	if tracing.IsInSpan(arg0) {
+		arg0, span = tracing.StartChildSpan(
+			arg0,
+			"",
+			fmt.Sprintf("KUBE %s/%s/%s", c.client, c.kind, "ApplyStatus"),
+			trace.WithAttributes(
+				tracing.KubeClientGroupKey.String(c.client),
+				tracing.KubeClientKindKey.String(c.kind),
+				tracing.KubeClientOperationKey.String("ApplyStatus"),
+			),
+		)
+		defer span.End()
+	}
+	ret0, ret1 := c.inner.ApplyStatus(arg0, arg1, arg2)
+	if span != nil {
+		tracing.SetSpanStatus(span, ret1)
+	}
+	return ret0, ret1
+}
 func (c *withTracing) Create(arg0 context.Context, arg1 *github_com_kyverno_kyverno_api_kyverno_v1.Policy, arg2 k8s_io_apimachinery_pkg_apis_meta_v1.CreateOptions) (*github_com_kyverno_kyverno_api_kyverno_v1.Policy, error) {
 	var span trace.Span
 	if tracing.IsInSpan(arg0) {
@@ -5,7 +5,12 @@ import (
 	github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2alpha1 "github.com/kyverno/kyverno/pkg/client/clientset/versioned/typed/kyverno/v2alpha1"
 	cleanuppolicies "github.com/kyverno/kyverno/pkg/clients/kyverno/kyvernov2alpha1/cleanuppolicies"
 	clustercleanuppolicies "github.com/kyverno/kyverno/pkg/clients/kyverno/kyvernov2alpha1/clustercleanuppolicies"
+	clusterpolicyconstraints "github.com/kyverno/kyverno/pkg/clients/kyverno/kyvernov2alpha1/clusterpolicyconstraints"
+	notifications "github.com/kyverno/kyverno/pkg/clients/kyverno/kyvernov2alpha1/notifications"
 	policyexceptions "github.com/kyverno/kyverno/pkg/clients/kyverno/kyvernov2alpha1/policyexceptions"
+	policysets "github.com/kyverno/kyverno/pkg/clients/kyverno/kyvernov2alpha1/policysets"
+	scanrequests "github.com/kyverno/kyverno/pkg/clients/kyverno/kyvernov2alpha1/scanrequests"
+	webhookhealths "github.com/kyverno/kyverno/pkg/clients/kyverno/kyvernov2alpha1/webhookhealths"
 	"github.com/kyverno/kyverno/pkg/metrics"
 	"k8s.io/client-go/rest"
 )
@@ -39,10 +44,30 @@ func (c *withMetrics) ClusterCleanupPolicies() github_com_kyverno_kyverno_pkg_cl
 	recorder := metrics.ClusteredClientQueryRecorder(c.metrics, "ClusterCleanupPolicy", c.clientType)
 	return clustercleanuppolicies.WithMetrics(c.inner.ClusterCleanupPolicies(), recorder)
 }
+func (c *withMetrics) ClusterPolicyConstraints() github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2alpha1.ClusterPolicyConstraintInterface {
+	recorder := metrics.ClusteredClientQueryRecorder(c.metrics, "ClusterPolicyConstraint", c.clientType)
+	return clusterpolicyconstraints.WithMetrics(c.inner.ClusterPolicyConstraints(), recorder)
+}
+func (c *withMetrics) Notifications() github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2alpha1.NotificationInterface {
+	recorder := metrics.ClusteredClientQueryRecorder(c.metrics, "Notification", c.clientType)
+	return notifications.WithMetrics(c.inner.Notifications(), recorder)
+}
 func (c *withMetrics) PolicyExceptions(namespace string) github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2alpha1.PolicyExceptionInterface {
 	recorder := metrics.NamespacedClientQueryRecorder(c.metrics, namespace, "PolicyException", c.clientType)
 	return policyexceptions.WithMetrics(c.inner.PolicyExceptions(namespace), recorder)
 }
+func (c *withMetrics) PolicySets() github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2alpha1.PolicySetInterface {
+	recorder := metrics.ClusteredClientQueryRecorder(c.metrics, "PolicySet", c.clientType)
+	return policysets.WithMetrics(c.inner.PolicySets(), recorder)
+}
+func (c *withMetrics) ScanRequests() github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2alpha1.ScanRequestInterface {
+	recorder := metrics.ClusteredClientQueryRecorder(c.metrics, "ScanRequest", c.clientType)
+	return scanrequests.WithMetrics(c.inner.ScanRequests(), recorder)
+}
+func (c *withMetrics) WebhookHealths() github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2alpha1.WebhookHealthInterface {
+	recorder := metrics.ClusteredClientQueryRecorder(c.metrics, "WebhookHealth", c.clientType)
+	return webhookhealths.WithMetrics(c.inner.WebhookHealths(), recorder)
+}
 
 type withTracing struct {
 	inner  github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2alpha1.KyvernoV2alpha1Interface
@@ -58,9 +83,24 @@ func (c *withTracing) CleanupPolicies(namespace string) github_com_kyverno_kyver
 func (c *withTracing) ClusterCleanupPolicies() github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2alpha1.ClusterCleanupPolicyInterface {
 	return clustercleanuppolicies.WithTracing(c.inner.ClusterCleanupPolicies(), c.client, "ClusterCleanupPolicy")
 }
+func (c *withTracing) ClusterPolicyConstraints() github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2alpha1.ClusterPolicyConstraintInterface {
+	return clusterpolicyconstraints.WithTracing(c.inner.ClusterPolicyConstraints(), c.client, "ClusterPolicyConstraint")
+}
+func (c *withTracing) Notifications() github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2alpha1.NotificationInterface {
+	return notifications.WithTracing(c.inner.Notifications(), c.client, "Notification")
+}
 func (c *withTracing) PolicyExceptions(namespace string) github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2alpha1.PolicyExceptionInterface {
 	return policyexceptions.WithTracing(c.inner.PolicyExceptions(namespace), c.client, "PolicyException")
 }
+func (c *withTracing) PolicySets() github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2alpha1.PolicySetInterface {
+	return policysets.WithTracing(c.inner.PolicySets(), c.client, "PolicySet")
+}
+func (c *withTracing) ScanRequests() github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2alpha1.ScanRequestInterface {
+	return scanrequests.WithTracing(c.inner.ScanRequests(), c.client, "ScanRequest")
+}
+func (c *withTracing) WebhookHealths() github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2alpha1.WebhookHealthInterface {
+	return webhookhealths.WithTracing(c.inner.WebhookHealths(), c.client, "WebhookHealth")
+}
 
 type withLogging struct {
 	inner  github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2alpha1.KyvernoV2alpha1Interface
@@ -76,6 +116,21 @@ func (c *withLogging) CleanupPolicies(namespace string) github_com_kyverno_kyver
 func (c *withLogging) ClusterCleanupPolicies() github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2alpha1.ClusterCleanupPolicyInterface {
 	return clustercleanuppolicies.WithLogging(c.inner.ClusterCleanupPolicies(), c.logger.WithValues("resource", "ClusterCleanupPolicies"))
 }
+func (c *withLogging) ClusterPolicyConstraints() github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2alpha1.ClusterPolicyConstraintInterface {
+	return clusterpolicyconstraints.WithLogging(c.inner.ClusterPolicyConstraints(), c.logger.WithValues("resource", "ClusterPolicyConstraints"))
+}
+func (c *withLogging) Notifications() github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2alpha1.NotificationInterface {
+	return notifications.WithLogging(c.inner.Notifications(), c.logger.WithValues("resource", "Notifications"))
+}
 func (c *withLogging) PolicyExceptions(namespace string) github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2alpha1.PolicyExceptionInterface {
 	return policyexceptions.WithLogging(c.inner.PolicyExceptions(namespace), c.logger.WithValues("resource", "PolicyExceptions").WithValues("namespace", namespace))
 }
+func (c *withLogging) PolicySets() github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2alpha1.PolicySetInterface {
+	return policysets.WithLogging(c.inner.PolicySets(), c.logger.WithValues("resource", "PolicySets"))
+}
+func (c *withLogging) ScanRequests() github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2alpha1.ScanRequestInterface {
+	return scanrequests.WithLogging(c.inner.ScanRequests(), c.logger.WithValues("resource", "ScanRequests"))
+}
+func (c *withLogging) WebhookHealths() github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2alpha1.WebhookHealthInterface {
+	return webhookhealths.WithLogging(c.inner.WebhookHealths(), c.logger.WithValues("resource", "WebhookHealths"))
+}
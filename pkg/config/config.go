@@ -4,11 +4,17 @@ import (
 	"errors"
 	"fmt"
 	"strconv"
+	"strings"
 	"sync"
+	"time"
 
 	valid "github.com/asaskevich/govalidator"
+	"github.com/go-logr/logr"
+	"github.com/kyverno/kyverno/api/kyverno"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
 	osutils "github.com/kyverno/kyverno/pkg/utils/os"
 	"github.com/kyverno/kyverno/pkg/utils/wildcard"
+	"golang.org/x/exp/slices"
 	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -68,29 +74,58 @@ const (
 	MutatingWebhookServicePath = "/mutate"
 	// VerifyMutatingWebhookServicePath is the path for verify webhook(used to veryfing if admission control is enabled and active)
 	VerifyMutatingWebhookServicePath = "/verifymutate"
+	// AuthorizationWebhookServicePath is the path for the authorization webhook(used to deny subject access reviews)
+	AuthorizationWebhookServicePath = "/authorize"
+	// ConversionWebhookServicePath is the path for the policy CRD conversion webhook(used to convert policies between api versions)
+	ConversionWebhookServicePath = "/convert"
+	// JSONValidationServicePath is the path for the json validation endpoint(used to validate arbitrary json payloads)
+	JSONValidationServicePath = "/json/validate"
+	// PolicyCatalogServicePath is the path for the policy catalog endpoint(used to list the installed policies and their scopes)
+	PolicyCatalogServicePath = "/policies/catalog"
 	// LivenessServicePath is the path for check liveness health
 	LivenessServicePath = "/health/liveness"
 	// ReadinessServicePath is the path for check readness health
 	ReadinessServicePath = "/health/readiness"
 	// MetricsPath is the path for exposing metrics
 	MetricsPath = "/metrics"
+	// ConfigStatusPath is the path for exposing the status of the configuration
+	ConfigStatusPath = "/config/status"
 )
 
 // keys in config map
 const (
-	resourceFilters               = "resourceFilters"
-	defaultRegistry               = "defaultRegistry"
-	enableDefaultRegistryMutation = "enableDefaultRegistryMutation"
-	excludeGroups                 = "excludeGroups"
-	excludeUsernames              = "excludeUsernames"
-	excludeRoles                  = "excludeRoles"
-	excludeClusterRoles           = "excludeClusterRoles"
-	generateSuccessEvents         = "generateSuccessEvents"
-	webhooks                      = "webhooks"
-	webhookAnnotations            = "webhookAnnotations"
-	matchConditions               = "matchConditions"
+	resourceFilters                        = "resourceFilters"
+	resourceFilterSelectors                = "resourceFilterSelectors"
+	defaultRegistry                        = "defaultRegistry"
+	enableDefaultRegistryMutation          = "enableDefaultRegistryMutation"
+	excludeGroups                          = "excludeGroups"
+	excludeUsernames                       = "excludeUsernames"
+	excludeRoles                           = "excludeRoles"
+	excludeClusterRoles                    = "excludeClusterRoles"
+	generateSuccessEvents                  = "generateSuccessEvents"
+	webhooks                               = "webhooks"
+	webhookAnnotations                     = "webhookAnnotations"
+	matchConditions                        = "matchConditions"
+	excludeFromReports                     = "excludeFromReports"
+	managedResourcesAllowedUsernames       = "managedResourcesAllowedUsernames"
+	managedResourcesAllowedGroups          = "managedResourcesAllowedGroups"
+	managedResourcesAllowedServiceAccounts = "managedResourcesAllowedServiceAccounts"
+	scheduleTimeZone                       = "scheduleTimeZone"
+	imageExtractors                        = "imageExtractors"
+	groupMappings                          = "groupMappings"
+	dumpPayload                            = "dumpPayload"
+	annotateAppliedPatches                 = "annotateAppliedPatches"
+	rejectMutationConflicts                = "rejectMutationConflicts"
+	userAttributes                         = "userAttributes"
+	generateClusters                       = "generateClusters"
 )
 
+// maxExpandedGroupsCacheSize is the number of expanded groups entries kept in cache
+const maxExpandedGroupsCacheSize = 10000
+
+// MaxManagedResourcesBreakGlassDuration is the maximum duration of a managed resources break-glass window
+const MaxManagedResourcesBreakGlassDuration = 24 * time.Hour
+
 var (
 	// kyvernoNamespace is the Kyverno namespace
 	kyvernoNamespace = osutils.GetEnvWithFallback("KYVERNO_NAMESPACE", "kyverno")
@@ -106,8 +141,14 @@ var (
 	kyvernoConfigMapName = osutils.GetEnvWithFallback("INIT_CONFIG", "kyverno")
 	// kyvernoMetricsConfigMapName is the Kyverno metrics configmap name
 	kyvernoMetricsConfigMapName = osutils.GetEnvWithFallback("METRICS_CONFIG", "kyverno-metrics")
+	// kyvernoGlobalValuesConfigMapName is the Kyverno global values configmap name
+	kyvernoGlobalValuesConfigMapName = osutils.GetEnvWithFallback("GLOBAL_VALUES_CONFIG", "kyverno-global-values")
 	// kyvernoDryRunNamespace is the namespace for DryRun option of YAML verification
 	kyvernoDryrunNamespace = osutils.GetEnvWithFallback("KYVERNO_DRYRUN_NAMESPACE", "kyverno-dryrun")
+	// kyvernoTLSSecretName is the name of an externally managed secret holding the serving certificate
+	kyvernoTLSSecretName = osutils.GetEnvWithFallback("KYVERNO_TLS_SECRET_NAME", "")
+	// kyvernoCASecretName is the name of an externally managed secret holding the CA bundle
+	kyvernoCASecretName = osutils.GetEnvWithFallback("KYVERNO_CA_SECRET_NAME", "")
 )
 
 func KyvernoNamespace() string {
@@ -142,6 +183,18 @@ func KyvernoMetricsConfigMapName() string {
 	return kyvernoMetricsConfigMapName
 }
 
+func KyvernoGlobalValuesConfigMapName() string {
+	return kyvernoGlobalValuesConfigMapName
+}
+
+func KyvernoTLSSecretName() string {
+	return kyvernoTLSSecretName
+}
+
+func KyvernoCASecretName() string {
+	return kyvernoCASecretName
+}
+
 func KyvernoUserName(serviceaccount string) string {
 	return fmt.Sprintf("system:serviceaccount:%s:%s", kyvernoNamespace, serviceaccount)
 }
@@ -156,6 +209,10 @@ type Configuration interface {
 	IsExcluded(username string, groups []string, roles []string, clusterroles []string) bool
 	// ToFilter checks if the given resource is set to be filtered in the configuration
 	ToFilter(kind schema.GroupVersionKind, subresource, namespace, name string) bool
+	// ToFilterRequest checks if the given admission request is set to be filtered by the resource filter selectors in the configuration
+	ToFilterRequest(kind schema.GroupVersionKind, subresource, operation, namespace, name string, namespaceLabels, objectLabels map[string]string) bool
+	// HasResourceFilterSelectors returns true if resource filter selectors are configured and enabled
+	HasResourceFilterSelectors() bool
 	// GetGenerateSuccessEvents return if should generate success events
 	GetGenerateSuccessEvents() bool
 	// GetWebhooks returns the webhook configs
@@ -164,8 +221,36 @@ type Configuration interface {
 	GetWebhookAnnotations() map[string]string
 	// GetMatchConditions returns match conditions to set on webhook configs
 	GetMatchConditions() []admissionregistrationv1.MatchCondition
+	// IsAllowedToModifyManagedResources checks if the given user is allowed to modify kyverno managed resources
+	IsAllowedToModifyManagedResources(username string, groups []string) bool
+	// GetManagedResourcesBreakGlassUntil returns the end of the managed resources break-glass window, zero if not set
+	GetManagedResourcesBreakGlassUntil() time.Time
+	// IsExcludedFromReports checks if results for the given policy, rule and namespace should be excluded from reports
+	IsExcludedFromReports(policy, rule, namespace string) bool
+	// GetScheduleTimeZone returns the time zone used to evaluate policy and rule schedules
+	GetScheduleTimeZone() *time.Location
+	// GetImageExtractors returns the image extractors used for kinds not configured in rules
+	GetImageExtractors() kyvernov1.ImageExtractorConfigs
+	// ExpandGroups returns the groups of a user completed with the groups mapped to the user or its groups
+	ExpandGroups(username string, groups []string) []string
+	// GetUserAttributes returns the mappings used to extract user attributes from admission requests
+	GetUserAttributes() UserAttributes
+	// GetDumpPayload returns true if admission requests and responses should be dumped
+	GetDumpPayload() bool
+	// GetAnnotateAppliedPatches returns true if mutated resources should be annotated with the provenance of the applied patches
+	GetAnnotateAppliedPatches() bool
+	// GetRejectMutationConflicts returns true if admission requests should be rejected when mutate rules set the same path to different values
+	GetRejectMutationConflicts() bool
+	// IsGenerateClusterAllowed checks if generate rules can create resources in the remote cluster whose kubeconfig is stored in the given Secret
+	IsGenerateClusterAllowed(secretName string) bool
+	// GetStatus returns the status of the last configuration load
+	GetStatus() Status
+	// GetGlobalValues returns the cluster wide values available in policies under the `global` variable
+	GetGlobalValues() map[string]interface{}
 	// Load loads configuration from a configmap
 	Load(*corev1.ConfigMap)
+	// LoadGlobalValues loads the global values from a configmap
+	LoadGlobalValues(*corev1.ConfigMap)
 	// OnChanged adds a callback to be invoked when the configuration is reloaded
 	OnChanged(func())
 }
@@ -178,10 +263,26 @@ type configuration struct {
 	exclusions                    match
 	inclusions                    match
 	filters                       []filter
+	filterSelectors               []resourceFilterSelector
 	generateSuccessEvents         bool
 	webhooks                      []WebhookConfig
 	webhookAnnotations            map[string]string
 	matchConditions               []admissionregistrationv1.MatchCondition
+	reportsExclusions             []ReportsExclusion
+	managedResourcesAllowed       match
+	breakGlassUntil               time.Time
+	scheduleTimeZone              *time.Location
+	imageExtractors               kyvernov1.ImageExtractorConfigs
+	groupMappings                 map[string]GroupMapping
+	expandedGroups                map[string][]string
+	expandedGroupsMux             sync.Mutex
+	userAttributes                UserAttributes
+	dumpPayload                   bool
+	annotateAppliedPatches        bool
+	rejectMutationConflicts       bool
+	generateClusters              []string
+	status                        Status
+	globalValues                  map[string]interface{}
 	mux                           sync.RWMutex
 	callbacks                     []func()
 }
@@ -233,6 +334,7 @@ func NewDefaultConfiguration(skipResourceFilters bool) *configuration {
 		skipResourceFilters:           skipResourceFilters,
 		defaultRegistry:               "docker.io",
 		enableDefaultRegistryMutation: true,
+		scheduleTimeZone:              time.UTC,
 	}
 }
 
@@ -259,7 +361,7 @@ func (cd *configuration) ToFilter(gvk schema.GroupVersionKind, subresource, name
 					return true
 				}
 				// [Namespace,kube-system,*] || [*,kube-system,*]
-				if gvk.Group == "" && gvk.Version == "v1" && gvk.Kind == "Namespace" {
+				if isNamespace(gvk) {
 					if wildcard.Match(f.Namespace, name) {
 						return true
 					}
@@ -270,6 +372,25 @@ func (cd *configuration) ToFilter(gvk schema.GroupVersionKind, subresource, name
 	return false
 }
 
+func (cd *configuration) ToFilterRequest(gvk schema.GroupVersionKind, subresource, operation, namespace, name string, namespaceLabels, objectLabels map[string]string) bool {
+	cd.mux.RLock()
+	defer cd.mux.RUnlock()
+	if !cd.skipResourceFilters {
+		for _, selector := range cd.filterSelectors {
+			if selector.matches(gvk, subresource, operation, namespace, name, namespaceLabels, objectLabels) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (cd *configuration) HasResourceFilterSelectors() bool {
+	cd.mux.RLock()
+	defer cd.mux.RUnlock()
+	return !cd.skipResourceFilters && len(cd.filterSelectors) != 0
+}
+
 func (cd *configuration) GetDefaultRegistry() string {
 	cd.mux.RLock()
 	defer cd.mux.RUnlock()
@@ -306,6 +427,99 @@ func (cd *configuration) GetMatchConditions() []admissionregistrationv1.MatchCon
 	return cd.matchConditions
 }
 
+func (cd *configuration) IsAllowedToModifyManagedResources(username string, groups []string) bool {
+	cd.mux.RLock()
+	defer cd.mux.RUnlock()
+	return cd.managedResourcesAllowed.matches(username, groups, nil, nil)
+}
+
+func (cd *configuration) GetManagedResourcesBreakGlassUntil() time.Time {
+	cd.mux.RLock()
+	defer cd.mux.RUnlock()
+	return cd.breakGlassUntil
+}
+
+func (cd *configuration) IsExcludedFromReports(policy, rule, namespace string) bool {
+	cd.mux.RLock()
+	defer cd.mux.RUnlock()
+	for _, exclusion := range cd.reportsExclusions {
+		if exclusion.matches(policy, rule, namespace) {
+			return true
+		}
+	}
+	return false
+}
+
+func (cd *configuration) GetScheduleTimeZone() *time.Location {
+	cd.mux.RLock()
+	defer cd.mux.RUnlock()
+	return cd.scheduleTimeZone
+}
+
+func (cd *configuration) GetImageExtractors() kyvernov1.ImageExtractorConfigs {
+	cd.mux.RLock()
+	defer cd.mux.RUnlock()
+	return cd.imageExtractors
+}
+
+func (cd *configuration) ExpandGroups(username string, groups []string) []string {
+	cd.mux.RLock()
+	defer cd.mux.RUnlock()
+	if len(cd.groupMappings) == 0 {
+		return groups
+	}
+	key := username + "\n" + strings.Join(groups, "\n")
+	cd.expandedGroupsMux.Lock()
+	defer cd.expandedGroupsMux.Unlock()
+	if expanded, ok := cd.expandedGroups[key]; ok {
+		return expanded
+	}
+	expanded := expandGroups(cd.groupMappings, username, groups)
+	if cd.expandedGroups == nil || len(cd.expandedGroups) >= maxExpandedGroupsCacheSize {
+		cd.expandedGroups = map[string][]string{}
+	}
+	cd.expandedGroups[key] = expanded
+	return expanded
+}
+
+func (cd *configuration) GetUserAttributes() UserAttributes {
+	cd.mux.RLock()
+	defer cd.mux.RUnlock()
+	return cd.userAttributes
+}
+
+func (cd *configuration) IsGenerateClusterAllowed(secretName string) bool {
+	cd.mux.RLock()
+	defer cd.mux.RUnlock()
+	return slices.Contains(cd.generateClusters, secretName)
+}
+
+func (cd *configuration) GetDumpPayload() bool {
+	cd.mux.RLock()
+	defer cd.mux.RUnlock()
+	return cd.dumpPayload
+}
+
+func (cd *configuration) GetAnnotateAppliedPatches() bool {
+	cd.mux.RLock()
+	defer cd.mux.RUnlock()
+	return cd.annotateAppliedPatches
+}
+
+func (cd *configuration) GetRejectMutationConflicts() bool {
+	cd.mux.RLock()
+	defer cd.mux.RUnlock()
+	return cd.rejectMutationConflicts
+}
+
+func (cd *configuration) GetStatus() Status {
+	cd.mux.RLock()
+	defer cd.mux.RUnlock()
+	status := cd.status
+	status.Errors = append([]string(nil), cd.status.Errors...)
+	return status
+}
+
 func (cd *configuration) Load(cm *corev1.ConfigMap) {
 	if cm != nil {
 		cd.load(cm)
@@ -329,13 +543,54 @@ func (cd *configuration) load(cm *corev1.ConfigMap) {
 	cd.exclusions = match{}
 	cd.inclusions = match{}
 	cd.filters = []filter{}
+	cd.filterSelectors = nil
 	cd.generateSuccessEvents = false
 	cd.webhooks = nil
 	cd.webhookAnnotations = nil
 	cd.matchConditions = nil
+	cd.reportsExclusions = nil
+	cd.managedResourcesAllowed = match{}
+	cd.breakGlassUntil = time.Time{}
+	cd.scheduleTimeZone = time.UTC
+	cd.imageExtractors = nil
+	cd.groupMappings = nil
+	cd.expandedGroups = nil
+	cd.userAttributes = nil
+	cd.dumpPayload = false
+	cd.annotateAppliedPatches = false
+	cd.rejectMutationConflicts = false
+	cd.generateClusters = nil
+	var loadErrors []string
+	loadError := func(logger logr.Logger, err error, msg string) {
+		logger.Error(err, msg)
+		loadErrors = append(loadErrors, fmt.Sprintf("%s: %s", msg, err))
+	}
+	defer func() {
+		cd.status = Status{
+			Source:          cm.Namespace + "/" + cm.Name,
+			ResourceVersion: cm.ResourceVersion,
+			Generation:      cd.status.Generation + 1,
+			LoadedAt:        time.Now(),
+			Errors:          loadErrors,
+		}
+	}()
 	// load filters
 	cd.filters = parseKinds(data[resourceFilters])
 	logger.Info("filters configured", "filters", cd.filters)
+	// load filter selectors
+	filterSelectors, ok := data[resourceFilterSelectors]
+	if !ok {
+		logger.Info("resourceFilterSelectors not set")
+	} else {
+		logger := logger.WithValues("resourceFilterSelectors", filterSelectors)
+		filterSelectors, err := parseResourceFilterSelectors(filterSelectors)
+		if err != nil {
+			loadError(logger, err, "failed to parse resource filter selectors")
+		} else {
+			cd.filterSelectors = filterSelectors
+			logger.Info("resourceFilterSelectors configured")
+		}
+	}
 	// load defaultRegistry
 	defaultRegistry, ok := data[defaultRegistry]
 	if !ok {
@@ -346,7 +601,7 @@ func (cd *configuration) load(cm *corev1.ConfigMap) {
 			cd.defaultRegistry = defaultRegistry
 			logger.Info("defaultRegistry configured")
 		} else {
-			logger.Error(errors.New("defaultRegistry is not a valid DNS hostname"), "failed to configure defaultRegistry")
+			loadError(logger, errors.New("defaultRegistry is not a valid DNS hostname"), "failed to configure defaultRegistry")
 		}
 	}
 	// load enableDefaultRegistryMutation
@@ -357,7 +612,7 @@ func (cd *configuration) load(cm *corev1.ConfigMap) {
 		logger := logger.WithValues("enableDefaultRegistryMutation", enableDefaultRegistryMutation)
 		enableDefaultRegistryMutation, err := strconv.ParseBool(enableDefaultRegistryMutation)
 		if err != nil {
-			logger.Error(err, "enableDefaultRegistryMutation is not a boolean")
+			loadError(logger, err, "enableDefaultRegistryMutation is not a boolean")
 		} else {
 			cd.enableDefaultRegistryMutation = enableDefaultRegistryMutation
 			logger.Info("enableDefaultRegistryMutation configured")
@@ -403,7 +658,7 @@ func (cd *configuration) load(cm *corev1.ConfigMap) {
 		logger := logger.WithValues("generateSuccessEvents", generateSuccessEvents)
 		generateSuccessEvents, err := strconv.ParseBool(generateSuccessEvents)
 		if err != nil {
-			logger.Error(err, "generateSuccessEvents is not a boolean")
+			loadError(logger, err, "generateSuccessEvents is not a boolean")
 		} else {
 			cd.generateSuccessEvents = generateSuccessEvents
 			logger.Info("generateSuccessEvents configured")
@@ -417,7 +672,7 @@ func (cd *configuration) load(cm *corev1.ConfigMap) {
 		logger := logger.WithValues("webhooks", webhooks)
 		webhooks, err := parseWebhooks(webhooks)
 		if err != nil {
-			logger.Error(err, "failed to parse webhooks")
+			loadError(logger, err, "failed to parse webhooks")
 		} else {
 			cd.webhooks = webhooks
 			logger.Info("webhooks configured")
@@ -431,7 +686,7 @@ func (cd *configuration) load(cm *corev1.ConfigMap) {
 		logger := logger.WithValues("webhookAnnotations", webhookAnnotations)
 		webhookAnnotations, err := parseWebhookAnnotations(webhookAnnotations)
 		if err != nil {
-			logger.Error(err, "failed to parse webhook annotations")
+			loadError(logger, err, "failed to parse webhook annotations")
 		} else {
 			cd.webhookAnnotations = webhookAnnotations
 			logger.Info("webhookAnnotations configured")
@@ -445,12 +700,169 @@ func (cd *configuration) load(cm *corev1.ConfigMap) {
 		logger := logger.WithValues("matchConditions", matchConditions)
 		matchConditions, err := parseMatchConditions(matchConditions)
 		if err != nil {
-			logger.Error(err, "failed to parse match conditions")
+			loadError(logger, err, "failed to parse match conditions")
 		} else {
 			cd.matchConditions = matchConditions
 			logger.Info("matchConditions configured")
 		}
 	}
+	// load reports exclusions
+	reportsExclusions, ok := data[excludeFromReports]
+	if !ok {
+		logger.Info("excludeFromReports not set")
+	} else {
+		logger := logger.WithValues("excludeFromReports", reportsExclusions)
+		reportsExclusions, err := parseReportsExclusions(reportsExclusions)
+		if err != nil {
+			loadError(logger, err, "failed to parse reports exclusions")
+		} else {
+			cd.reportsExclusions = reportsExclusions
+			logger.Info("excludeFromReports configured")
+		}
+	}
+	// load managed resources allowed users
+	allowedUsernames, ok := data[managedResourcesAllowedUsernames]
+	if !ok {
+		logger.Info("managedResourcesAllowedUsernames not set")
+	} else {
+		cd.managedResourcesAllowed.usernames, _ = parseExclusions(allowedUsernames)
+		logger.Info("managedResourcesAllowedUsernames configured", "managedResourcesAllowedUsernames", cd.managedResourcesAllowed.usernames)
+	}
+	allowedServiceAccounts, ok := data[managedResourcesAllowedServiceAccounts]
+	if !ok {
+		logger.Info("managedResourcesAllowedServiceAccounts not set")
+	} else {
+		serviceAccounts, _ := parseExclusions(allowedServiceAccounts)
+		for _, serviceAccount := range serviceAccounts {
+			cd.managedResourcesAllowed.usernames = append(cd.managedResourcesAllowed.usernames, "system:serviceaccount:"+serviceAccount)
+		}
+		logger.Info("managedResourcesAllowedServiceAccounts configured", "managedResourcesAllowedServiceAccounts", serviceAccounts)
+	}
+	allowedGroups, ok := data[managedResourcesAllowedGroups]
+	if !ok {
+		logger.Info("managedResourcesAllowedGroups not set")
+	} else {
+		cd.managedResourcesAllowed.groups, _ = parseExclusions(allowedGroups)
+		logger.Info("managedResourcesAllowedGroups configured", "managedResourcesAllowedGroups", cd.managedResourcesAllowed.groups)
+	}
+	// load schedule time zone
+	timeZone, ok := data[scheduleTimeZone]
+	if !ok {
+		logger.Info("scheduleTimeZone not set")
+	} else {
+		logger := logger.WithValues("scheduleTimeZone", timeZone)
+		location, err := time.LoadLocation(timeZone)
+		if err != nil {
+			loadError(logger, err, "failed to load schedule time zone")
+		} else {
+			cd.scheduleTimeZone = location
+			logger.Info("scheduleTimeZone configured")
+		}
+	}
+	// load image extractors
+	extractors, ok := data[imageExtractors]
+	if !ok {
+		logger.Info("imageExtractors not set")
+	} else {
+		logger := logger.WithValues("imageExtractors", extractors)
+		extractors, err := parseImageExtractors(extractors)
+		if err != nil {
+			loadError(logger, err, "failed to parse image extractors")
+		} else {
+			cd.imageExtractors = extractors
+			logger.Info("imageExtractors configured")
+		}
+	}
+	// load group mappings
+	mappings, ok := data[groupMappings]
+	if !ok {
+		logger.Info("groupMappings not set")
+	} else {
+		logger := logger.WithValues("groupMappings", mappings)
+		mappings, err := parseGroupMappings(mappings)
+		if err != nil {
+			loadError(logger, err, "failed to parse group mappings")
+		} else {
+			cd.groupMappings = mappings
+			logger.Info("groupMappings configured")
+		}
+	}
+	// load user attributes
+	attributes, ok := data[userAttributes]
+	if !ok {
+		logger.Info("userAttributes not set")
+	} else {
+		logger := logger.WithValues("userAttributes", attributes)
+		attributes, err := parseUserAttributes(attributes)
+		if err != nil {
+			loadError(logger, err, "failed to parse user attributes")
+		} else {
+			cd.userAttributes = attributes
+			logger.Info("userAttributes configured")
+		}
+	}
+	// load generate clusters
+	clusters, ok := data[generateClusters]
+	if !ok {
+		logger.Info("generateClusters not set")
+	} else {
+		cd.generateClusters, _ = parseExclusions(clusters)
+		logger.Info("generateClusters configured", "generateClusters", cd.generateClusters)
+	}
+	// load managed resources break-glass window
+	breakGlassUntil, ok := cm.Annotations[kyverno.AnnotationManagedResourcesBreakGlass]
+	if ok {
+		logger := logger.WithValues("breakGlassUntil", breakGlassUntil)
+		breakGlassUntil, err := parseBreakGlassUntil(breakGlassUntil, time.Now())
+		if err != nil {
+			loadError(logger, err, "failed to parse managed resources break-glass annotation")
+		} else {
+			cd.breakGlassUntil = breakGlassUntil
+			logger.Info("managed resources break-glass window configured")
+		}
+	}
+	// load dumpPayload
+	dump, ok := data[dumpPayload]
+	if !ok {
+		logger.Info("dumpPayload not set")
+	} else {
+		logger := logger.WithValues("dumpPayload", dump)
+		dump, err := strconv.ParseBool(dump)
+		if err != nil {
+			loadError(logger, err, "dumpPayload is not a boolean")
+		} else {
+			cd.dumpPayload = dump
+			logger.Info("dumpPayload configured")
+		}
+	}
+	// load annotateAppliedPatches
+	annotate, ok := data[annotateAppliedPatches]
+	if !ok {
+		logger.Info("annotateAppliedPatches not set")
+	} else {
+		logger := logger.WithValues("annotateAppliedPatches", annotate)
+		annotate, err := strconv.ParseBool(annotate)
+		if err != nil {
+			loadError(logger, err, "annotateAppliedPatches is not a boolean")
+		} else {
+			cd.annotateAppliedPatches = annotate
+			logger.Info("annotateAppliedPatches configured")
+		}
+	}
+	// load rejectMutationConflicts
+	reject, ok := data[rejectMutationConflicts]
+	if !ok {
+		logger.Info("rejectMutationConflicts not set")
+	} else {
+		logger := logger.WithValues("rejectMutationConflicts", reject)
+		reject, err := strconv.ParseBool(reject)
+		if err != nil {
+			loadError(logger, err, "rejectMutationConflicts is not a boolean")
+		} else {
+			cd.rejectMutationConflicts = reject
+			logger.Info("rejectMutationConflicts configured")
+		}
+	}
 }
 
 func (cd *configuration) unload() {
@@ -462,9 +874,26 @@ func (cd *configuration) unload() {
 	cd.exclusions = match{}
 	cd.inclusions = match{}
 	cd.filters = []filter{}
+	cd.filterSelectors = nil
 	cd.generateSuccessEvents = false
 	cd.webhooks = nil
 	cd.webhookAnnotations = nil
+	cd.reportsExclusions = nil
+	cd.managedResourcesAllowed = match{}
+	cd.breakGlassUntil = time.Time{}
+	cd.scheduleTimeZone = time.UTC
+	cd.imageExtractors = nil
+	cd.groupMappings = nil
+	cd.expandedGroups = nil
+	cd.userAttributes = nil
+	cd.dumpPayload = false
+	cd.annotateAppliedPatches = false
+	cd.rejectMutationConflicts = false
+	cd.generateClusters = nil
+	cd.status = Status{
+		Generation: cd.status.Generation + 1,
+		LoadedAt:   time.Now(),
+	}
 	logger.Info("configuration unloaded")
 }
 
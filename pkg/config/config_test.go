@@ -0,0 +1,78 @@
+package config
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func Test_configuration_Load_Status(t *testing.T) {
+	cfg := NewDefaultConfiguration(false)
+	notified := 0
+	cfg.OnChanged(func() { notified++ })
+	cfg.Load(&corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "kyverno",
+			Namespace:       "kyverno",
+			ResourceVersion: "42",
+		},
+		Data: map[string]string{
+			dumpPayload:             "true",
+			annotateAppliedPatches:  "true",
+			rejectMutationConflicts: "true",
+			generateSuccessEvents:   "not-a-bool",
+			generateClusters:        "spoke-a, spoke-b",
+		},
+	})
+	if !cfg.GetDumpPayload() {
+		t.Errorf("GetDumpPayload() = false, want true")
+	}
+	if !cfg.GetAnnotateAppliedPatches() {
+		t.Errorf("GetAnnotateAppliedPatches() = false, want true")
+	}
+	if !cfg.GetRejectMutationConflicts() {
+		t.Errorf("GetRejectMutationConflicts() = false, want true")
+	}
+	if !cfg.IsGenerateClusterAllowed("spoke-b") || cfg.IsGenerateClusterAllowed("spoke-c") {
+		t.Errorf("IsGenerateClusterAllowed() does not match generateClusters")
+	}
+	status := cfg.GetStatus()
+	if status.Source != "kyverno/kyverno" || status.ResourceVersion != "42" || status.Generation != 1 {
+		t.Errorf("GetStatus() = %v", status)
+	}
+	if len(status.Errors) != 1 {
+		t.Errorf("GetStatus().Errors = %v, want one error", status.Errors)
+	}
+	if status.LoadedAt.IsZero() {
+		t.Errorf("GetStatus().LoadedAt is zero")
+	}
+	cfg.Load(&corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "kyverno",
+			Namespace:       "kyverno",
+			ResourceVersion: "43",
+		},
+	})
+	if cfg.GetDumpPayload() {
+		t.Errorf("GetDumpPayload() = true, want false")
+	}
+	if cfg.GetAnnotateAppliedPatches() {
+		t.Errorf("GetAnnotateAppliedPatches() = true, want false")
+	}
+	if cfg.IsGenerateClusterAllowed("spoke-a") {
+		t.Errorf("IsGenerateClusterAllowed() = true, want false")
+	}
+	status = cfg.GetStatus()
+	if status.ResourceVersion != "43" || status.Generation != 2 || len(status.Errors) != 0 {
+		t.Errorf("GetStatus() = %v", status)
+	}
+	cfg.Load(nil)
+	status = cfg.GetStatus()
+	if status.Source != "" || status.Generation != 3 {
+		t.Errorf("GetStatus() = %v", status)
+	}
+	if notified != 3 {
+		t.Errorf("callbacks notified %d times, want 3", notified)
+	}
+}
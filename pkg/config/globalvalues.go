@@ -0,0 +1,48 @@
+package config
+
+import (
+	"encoding/json"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/yaml"
+)
+
+func (cd *configuration) GetGlobalValues() map[string]interface{} {
+	cd.mux.RLock()
+	defer cd.mux.RUnlock()
+	return cd.globalValues
+}
+
+func (cd *configuration) LoadGlobalValues(cm *corev1.ConfigMap) {
+	var values map[string]interface{}
+	if cm != nil {
+		values = parseGlobalValues(cm)
+	}
+	cd.mux.Lock()
+	defer cd.mux.Unlock()
+	defer cd.notify()
+	cd.globalValues = values
+	if cm != nil {
+		logger.Info("global values loaded", "name", cm.Name, "namespace", cm.Namespace, "count", len(values))
+	} else {
+		logger.Info("global values unloaded")
+	}
+}
+
+// parseGlobalValues parses the data of the global values configmap, each key holds a yaml (or json) document
+// so that lists and objects can be shared, values that can't be parsed are kept as plain strings
+func parseGlobalValues(cm *corev1.ConfigMap) map[string]interface{} {
+	values := make(map[string]interface{}, len(cm.Data))
+	for key, raw := range cm.Data {
+		var value interface{}
+		if data, err := yaml.YAMLToJSON([]byte(raw)); err != nil {
+			logger.Error(err, "failed to parse global value, it will be used as a string", "key", key)
+			value = raw
+		} else if err := json.Unmarshal(data, &value); err != nil {
+			logger.Error(err, "failed to parse global value, it will be used as a string", "key", key)
+			value = raw
+		}
+		values[key] = value
+	}
+	return values
+}
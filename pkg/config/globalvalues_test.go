@@ -0,0 +1,48 @@
+package config
+
+import (
+	"reflect"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func Test_configuration_LoadGlobalValues(t *testing.T) {
+	cfg := NewDefaultConfiguration(false)
+	notified := 0
+	cfg.OnChanged(func() { notified++ })
+	cfg.LoadGlobalValues(&corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "kyverno-global-values",
+			Namespace: "kyverno",
+		},
+		Data: map[string]string{
+			"domain":     "example.com",
+			"registries": "- ghcr.io\n- docker.io\n",
+			"limits":     `{"cpu": "2", "replicas": 3}`,
+			"invalid":    "a: b: c",
+		},
+	})
+	want := map[string]interface{}{
+		"domain":     "example.com",
+		"registries": []interface{}{"ghcr.io", "docker.io"},
+		"limits":     map[string]interface{}{"cpu": "2", "replicas": float64(3)},
+		"invalid":    "a: b: c",
+	}
+	if got := cfg.GetGlobalValues(); !reflect.DeepEqual(got, want) {
+		t.Errorf("GetGlobalValues() = %v, want %v", got, want)
+	}
+	if notified != 1 {
+		t.Errorf("notified = %d, want 1", notified)
+	}
+	// global values are not reset when the kyverno configmap is reloaded
+	cfg.Load(&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "kyverno", Namespace: "kyverno"}})
+	if got := cfg.GetGlobalValues(); !reflect.DeepEqual(got, want) {
+		t.Errorf("GetGlobalValues() = %v, want %v", got, want)
+	}
+	cfg.LoadGlobalValues(nil)
+	if got := cfg.GetGlobalValues(); got != nil {
+		t.Errorf("GetGlobalValues() = %v, want nil", got)
+	}
+}
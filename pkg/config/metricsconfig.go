@@ -16,6 +16,8 @@ type MetricsConfiguration interface {
 	GetIncludeNamespaces() []string
 	// GetMetricsRefreshInterval returns the refresh interval for the metrics
 	GetMetricsRefreshInterval() time.Duration
+	// GetMetricsExposure returns the exposure configuration of metric families, indexed by metric name
+	GetMetricsExposure() map[string]MetricExposureConfig
 	// CheckNamespace returns `true` if the namespace has to be considered
 	CheckNamespace(string) bool
 	// Load loads configuration from a configmap
@@ -28,6 +30,7 @@ type MetricsConfiguration interface {
 type metricsConfig struct {
 	namespaces             namespacesConfig
 	metricsRefreshInterval time.Duration
+	metricsExposure        map[string]MetricExposureConfig
 	mux                    sync.RWMutex
 	callbacks              []func()
 }
@@ -70,6 +73,13 @@ func (mcd *metricsConfig) GetMetricsRefreshInterval() time.Duration {
 	return mcd.metricsRefreshInterval
 }
 
+// GetMetricsExposure returns the exposure configuration of metric families, indexed by metric name
+func (mcd *metricsConfig) GetMetricsExposure() map[string]MetricExposureConfig {
+	mcd.mux.RLock()
+	defer mcd.mux.RUnlock()
+	return mcd.metricsExposure
+}
+
 // CheckNamespace returns `true` if the namespace has to be considered
 func (mcd *metricsConfig) CheckNamespace(namespace string) bool {
 	mcd.mux.RLock()
@@ -106,6 +116,7 @@ func (cd *metricsConfig) load(cm *corev1.ConfigMap) {
 	}
 	// reset
 	cd.metricsRefreshInterval = 0
+	cd.metricsExposure = nil
 	cd.namespaces = namespacesConfig{
 		IncludeNamespaces: []string{},
 		ExcludeNamespaces: []string{},
@@ -138,6 +149,20 @@ func (cd *metricsConfig) load(cm *corev1.ConfigMap) {
 			logger.Info("namespaces configured")
 		}
 	}
+	// load metricsExposure
+	metricsExposure, ok := data["metricsExposure"]
+	if !ok {
+		logger.Info("metricsExposure not set")
+	} else {
+		logger := logger.WithValues("metricsExposure", metricsExposure)
+		metricsExposure, err := parseMetricsExposure(metricsExposure)
+		if err != nil {
+			logger.Error(err, "failed to parse metricsExposure")
+		} else {
+			cd.metricsExposure = metricsExposure
+			logger.Info("metricsExposure configured")
+		}
+	}
 }
 
 func (mcd *metricsConfig) unload() {
@@ -145,6 +170,7 @@ func (mcd *metricsConfig) unload() {
 	defer mcd.mux.Unlock()
 	defer mcd.notify()
 	mcd.metricsRefreshInterval = 0
+	mcd.metricsExposure = nil
 	mcd.namespaces = namespacesConfig{
 		IncludeNamespaces: []string{},
 		ExcludeNamespaces: []string{},
@@ -2,12 +2,19 @@ package config
 
 import (
 	"encoding/json"
+	"fmt"
 	"regexp"
 	"strings"
+	"time"
 
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
 	kubeutils "github.com/kyverno/kyverno/pkg/utils/kube"
+	"github.com/kyverno/kyverno/pkg/utils/wildcard"
 	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/sets"
 )
 
 type WebhookConfig struct {
@@ -61,6 +68,213 @@ func parseMatchConditions(in string) ([]admissionregistrationv1.MatchCondition,
 	return out, nil
 }
 
+func parseImageExtractors(in string) (kyvernov1.ImageExtractorConfigs, error) {
+	var out kyvernov1.ImageExtractorConfigs
+	if err := json.Unmarshal([]byte(in), &out); err != nil {
+		return nil, err
+	}
+	for kind, extractors := range out {
+		for i, extractor := range extractors {
+			if strings.Trim(extractor.Path, "/ ") == "" {
+				return nil, fmt.Errorf("image extractor %d of kind %s has no path", i, kind)
+			}
+		}
+	}
+	return out, nil
+}
+
+// GroupMapping adds a group to the users matching one of the users or groups patterns, values support wildcards.
+// Mappings are evaluated transitively, a group can be mapped to another mapped group.
+type GroupMapping struct {
+	Users  []string `json:"users,omitempty"`
+	Groups []string `json:"groups,omitempty"`
+}
+
+func (m GroupMapping) matches(username string, groups sets.Set[string]) bool {
+	if wildcard.CheckPatterns(m.Users, username) {
+		return true
+	}
+	for group := range groups {
+		if wildcard.CheckPatterns(m.Groups, group) {
+			return true
+		}
+	}
+	return false
+}
+
+func parseGroupMappings(in string) (map[string]GroupMapping, error) {
+	var out map[string]GroupMapping
+	if err := json.Unmarshal([]byte(in), &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// expandGroups returns the groups followed by the sorted list of groups mapped to the user or its groups
+func expandGroups(mappings map[string]GroupMapping, username string, groups []string) []string {
+	all := sets.New(groups...)
+	added := sets.New[string]()
+	for changed := true; changed; {
+		changed = false
+		for group, mapping := range mappings {
+			if !all.Has(group) && mapping.matches(username, all) {
+				all.Insert(group)
+				added.Insert(group)
+				changed = true
+			}
+		}
+	}
+	if added.Len() == 0 {
+		return groups
+	}
+	expanded := make([]string, 0, len(groups)+added.Len())
+	expanded = append(expanded, groups...)
+	return append(expanded, sets.List(added)...)
+}
+
+// UserAttributeMapping extracts the value of a user attribute from the requester of an admission request.
+// Exactly one of Username, Group or ServiceAccountAnnotation must be set.
+type UserAttributeMapping struct {
+	// Username is a regular expression matched against the username
+	Username string `json:"username,omitempty"`
+	// Group is a regular expression matched against the groups of the user
+	Group string `json:"group,omitempty"`
+	// ServiceAccountAnnotation is the annotation of the requesting service account holding the value
+	ServiceAccountAnnotation string `json:"serviceAccountAnnotation,omitempty"`
+	// Value is the value of the attribute when the regular expression matches, it can reference capture groups ($1, ${name}).
+	// The whole match is used when empty.
+	Value string `json:"value,omitempty"`
+	regex *regexp.Regexp
+}
+
+func (m UserAttributeMapping) extract(value string) (string, bool) {
+	match := m.regex.FindStringSubmatchIndex(value)
+	if match == nil {
+		return "", false
+	}
+	if m.Value == "" {
+		return value[match[0]:match[1]], true
+	}
+	return string(m.regex.ExpandString(nil, m.Value, value, match)), true
+}
+
+// UserAttributes maps attribute names to the mappings used to extract their value, mappings are evaluated in order.
+type UserAttributes map[string][]UserAttributeMapping
+
+// Resolve returns the attributes of a user, the value of an attribute comes from the first matching mapping.
+// The annotations of the requesting service account are only fetched when a mapping needs them.
+func (a UserAttributes) Resolve(username string, groups []string, serviceAccountAnnotations func() map[string]string) map[string]string {
+	var annotations map[string]string
+	var fetched bool
+	out := map[string]string{}
+	for name, mappings := range a {
+		for _, mapping := range mappings {
+			var value string
+			var found bool
+			if mapping.ServiceAccountAnnotation != "" {
+				if !fetched && serviceAccountAnnotations != nil {
+					annotations, fetched = serviceAccountAnnotations(), true
+				}
+				value, found = annotations[mapping.ServiceAccountAnnotation]
+			} else if mapping.Username != "" {
+				value, found = mapping.extract(username)
+			} else {
+				for _, group := range groups {
+					if value, found = mapping.extract(group); found {
+						break
+					}
+				}
+			}
+			if found {
+				out[name] = value
+				break
+			}
+		}
+	}
+	return out
+}
+
+func parseUserAttributes(in string) (UserAttributes, error) {
+	var out UserAttributes
+	if err := json.Unmarshal([]byte(in), &out); err != nil {
+		return nil, err
+	}
+	for name, mappings := range out {
+		for i := range mappings {
+			mapping := &mappings[i]
+			set := 0
+			for _, field := range []string{mapping.Username, mapping.Group, mapping.ServiceAccountAnnotation} {
+				if field != "" {
+					set++
+				}
+			}
+			if set != 1 {
+				return nil, fmt.Errorf("mapping %d of attribute %s must set exactly one of username, group or serviceAccountAnnotation", i, name)
+			}
+			if mapping.ServiceAccountAnnotation != "" {
+				continue
+			}
+			pattern := mapping.Username
+			if pattern == "" {
+				pattern = mapping.Group
+			}
+			regex, err := regexp.Compile(pattern)
+			if err != nil {
+				return nil, fmt.Errorf("mapping %d of attribute %s has an invalid regular expression: %w", i, name, err)
+			}
+			mapping.regex = regex
+		}
+	}
+	return out, nil
+}
+
+// ReportsExclusion selects policy results that must not be stored in reports.
+// Empty lists match everything, values support wildcards.
+type ReportsExclusion struct {
+	// Policies are matched against the policy key (`name` for cluster policies, `namespace/name` for policies)
+	Policies   []string `json:"policies,omitempty"`
+	Rules      []string `json:"rules,omitempty"`
+	Namespaces []string `json:"namespaces,omitempty"`
+}
+
+func (e ReportsExclusion) matches(policy, rule, namespace string) bool {
+	matches := func(patterns []string, value string) bool {
+		return len(patterns) == 0 || wildcard.CheckPatterns(patterns, value)
+	}
+	return matches(e.Policies, policy) && matches(e.Rules, rule) && matches(e.Namespaces, namespace)
+}
+
+func parseReportsExclusions(in string) ([]ReportsExclusion, error) {
+	var out []ReportsExclusion
+	if err := json.Unmarshal([]byte(in), &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// MetricExposureConfig controls how a metric family is exposed
+type MetricExposureConfig struct {
+	// Enabled enables or disables the metric family, metrics are enabled when not set
+	Enabled *bool `json:"enabled,omitempty"`
+	// DisabledLabelDimensions are the label dimensions dropped from the metric family (e.g. `resource_namespace` or `rule_name`)
+	DisabledLabelDimensions []string `json:"disabledLabelDimensions,omitempty"`
+	// BucketBoundaries overrides the default bucket boundaries of histograms
+	BucketBoundaries []float64 `json:"bucketBoundaries,omitempty"`
+}
+
+// IsEnabled returns false if the metric family has been disabled
+func (c MetricExposureConfig) IsEnabled() bool {
+	return c.Enabled == nil || *c.Enabled
+}
+
+func parseMetricsExposure(in string) (map[string]MetricExposureConfig, error) {
+	var out map[string]MetricExposureConfig
+	if err := json.Unmarshal([]byte(in), &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 type namespacesConfig struct {
 	IncludeNamespaces []string `json:"include,omitempty"`
 	ExcludeNamespaces []string `json:"exclude,omitempty"`
@@ -124,3 +338,128 @@ func parseKinds(in string) []filter {
 	}
 	return resources
 }
+
+// ResourceFilterSelector excludes resources from processing using label selectors and operations.
+// All set fields must match for a resource to be filtered, empty fields match everything and
+// kinds, namespaces, names and operations support wildcards.
+type ResourceFilterSelector struct {
+	// Kinds are kind selectors (`Kind`, `version/Kind`, `group/version/Kind` or `Kind/subresource`)
+	Kinds []string `json:"kinds,omitempty"`
+	// Namespaces are matched against the namespace of the resource
+	Namespaces []string `json:"namespaces,omitempty"`
+	// Names are matched against the name of the resource
+	Names []string `json:"names,omitempty"`
+	// Operations are matched against the admission operation (`CREATE`, `UPDATE`, `DELETE` or `CONNECT`)
+	Operations []string `json:"operations,omitempty"`
+	// NamespaceSelector is matched against the labels of the namespace of the resource,
+	// it matches the labels of the resource itself for namespaces and never matches other cluster wide resources
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+	// ObjectSelector is matched against the labels of the resource
+	ObjectSelector *metav1.LabelSelector `json:"objectSelector,omitempty"`
+}
+
+type resourceFilterSelector struct {
+	kinds             []filter
+	namespaces        []string
+	names             []string
+	operations        []string
+	namespaceSelector labels.Selector
+	objectSelector    labels.Selector
+}
+
+func (s resourceFilterSelector) matches(gvk schema.GroupVersionKind, subresource, operation, namespace, name string, namespaceLabels, objectLabels map[string]string) bool {
+	matches := func(patterns []string, value string) bool {
+		return len(patterns) == 0 || wildcard.CheckPatterns(patterns, value)
+	}
+	if len(s.kinds) != 0 {
+		matched := false
+		for _, f := range s.kinds {
+			if wildcard.Match(f.Group, gvk.Group) && wildcard.Match(f.Version, gvk.Version) && wildcard.Match(f.Kind, gvk.Kind) && wildcard.Match(f.Subresource, subresource) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	if !matches(s.namespaces, namespace) || !matches(s.names, name) || !matches(s.operations, operation) {
+		return false
+	}
+	if s.namespaceSelector != nil {
+		if namespace == "" && !isNamespace(gvk) {
+			return false
+		}
+		if !s.namespaceSelector.Matches(labels.Set(namespaceLabels)) {
+			return false
+		}
+	}
+	if s.objectSelector != nil && !s.objectSelector.Matches(labels.Set(objectLabels)) {
+		return false
+	}
+	return true
+}
+
+func isNamespace(gvk schema.GroupVersionKind) bool {
+	return gvk.Group == "" && gvk.Version == "v1" && gvk.Kind == "Namespace"
+}
+
+func parseResourceFilterSelectors(in string) ([]resourceFilterSelector, error) {
+	var selectors []ResourceFilterSelector
+	if err := json.Unmarshal([]byte(in), &selectors); err != nil {
+		return nil, err
+	}
+	out := make([]resourceFilterSelector, 0, len(selectors))
+	for _, selector := range selectors {
+		parsed := resourceFilterSelector{
+			namespaces: selector.Namespaces,
+			names:      selector.Names,
+			operations: selector.Operations,
+		}
+		for _, kind := range selector.Kinds {
+			parsed.kinds = append(parsed.kinds, newFilter(kind, "", ""))
+		}
+		if selector.NamespaceSelector != nil {
+			namespaceSelector, err := metav1.LabelSelectorAsSelector(selector.NamespaceSelector)
+			if err != nil {
+				return nil, err
+			}
+			parsed.namespaceSelector = namespaceSelector
+		}
+		if selector.ObjectSelector != nil {
+			objectSelector, err := metav1.LabelSelectorAsSelector(selector.ObjectSelector)
+			if err != nil {
+				return nil, err
+			}
+			parsed.objectSelector = objectSelector
+		}
+		out = append(out, parsed)
+	}
+	return out, nil
+}
+
+// parseBreakGlassUntil parses the end of a break-glass window, the window must not exceed MaxManagedResourcesBreakGlassDuration
+func parseBreakGlassUntil(in string, now time.Time) (time.Time, error) {
+	until, err := time.Parse(time.RFC3339, strings.TrimSpace(in))
+	if err != nil {
+		return time.Time{}, err
+	}
+	if until.Sub(now) > MaxManagedResourcesBreakGlassDuration {
+		return time.Time{}, fmt.Errorf("break-glass window must not exceed %s", MaxManagedResourcesBreakGlassDuration)
+	}
+	return until, nil
+}
+
+// Status reports the configuration currently applied, it is updated every time the configuration is reloaded
+type Status struct {
+	// Source is the namespace/name of the config map the configuration was loaded from, empty when the config map doesn't exist
+	Source string `json:"source,omitempty"`
+	// ResourceVersion is the resource version of the config map the configuration was loaded from
+	ResourceVersion string `json:"resourceVersion,omitempty"`
+	// Generation is incremented every time the configuration is reloaded
+	Generation int64 `json:"generation"`
+	// LoadedAt is the time the configuration was last reloaded
+	LoadedAt time.Time `json:"loadedAt"`
+	// Errors are the invalid config map entries that were ignored
+	Errors []string `json:"errors,omitempty"`
+}
@@ -3,6 +3,10 @@ package config
 import (
 	"reflect"
 	"testing"
+	"time"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 )
 
 func Test_parseExclusions(t *testing.T) {
@@ -256,3 +260,488 @@ func Test_parseWebhookAnnotations(t *testing.T) {
 		})
 	}
 }
+
+func Test_parseReportsExclusions(t *testing.T) {
+	type args struct {
+		in string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    []ReportsExclusion
+		wantErr bool
+	}{{
+		args:    args{"hello"},
+		wantErr: true,
+	}, {
+		args: args{"null"},
+	}, {
+		args: args{`[{"policies": ["require-labels"], "rules": ["check-*"]}, {"namespaces": ["kube-system"]}]`},
+		want: []ReportsExclusion{{
+			Policies: []string{"require-labels"},
+			Rules:    []string{"check-*"},
+		}, {
+			Namespaces: []string{"kube-system"},
+		}},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseReportsExclusions(tt.args.in)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("parseReportsExclusions() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseReportsExclusions() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_parseImageExtractors(t *testing.T) {
+	type args struct {
+		in string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    kyvernov1.ImageExtractorConfigs
+		wantErr bool
+	}{{
+		args:    args{"hello"},
+		wantErr: true,
+	}, {
+		args: args{"null"},
+	}, {
+		args:    args{`{"Task": [{"value": "image"}]}`},
+		wantErr: true,
+	}, {
+		args: args{`{"Task": [{"path": "/spec/steps/*/image", "name": "steps"}], "Workflow": [{"path": "/spec/templates/*/container/image"}]}`},
+		want: kyvernov1.ImageExtractorConfigs{
+			"Task":     {{Path: "/spec/steps/*/image", Name: "steps"}},
+			"Workflow": {{Path: "/spec/templates/*/container/image"}},
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseImageExtractors(tt.args.in)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("parseImageExtractors() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseImageExtractors() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_parseGroupMappings(t *testing.T) {
+	got, err := parseGroupMappings(`{"cluster-admins": {"users": ["alice"], "groups": ["oidc:platform-*"]}}`)
+	if err != nil {
+		t.Fatalf("parseGroupMappings() error = %v", err)
+	}
+	want := map[string]GroupMapping{
+		"cluster-admins": {Users: []string{"alice"}, Groups: []string{"oidc:platform-*"}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("parseGroupMappings() = %v, want %v", got, want)
+	}
+	if _, err := parseGroupMappings("hello"); err == nil {
+		t.Errorf("parseGroupMappings() expected an error")
+	}
+}
+
+func Test_expandGroups(t *testing.T) {
+	mappings := map[string]GroupMapping{
+		"cluster-admins": {Groups: []string{"oidc:platform-*", "sre"}},
+		"sre":            {Users: []string{"bob", "system:serviceaccount:ops:*"}},
+		"auditors":       {Users: []string{"carol"}},
+	}
+	tests := []struct {
+		name     string
+		username string
+		groups   []string
+		want     []string
+	}{{
+		name:     "no mapping",
+		username: "dave",
+		groups:   []string{"system:authenticated"},
+		want:     []string{"system:authenticated"},
+	}, {
+		name:     "group mapping",
+		username: "alice",
+		groups:   []string{"system:authenticated", "oidc:platform-admins"},
+		want:     []string{"system:authenticated", "oidc:platform-admins", "cluster-admins"},
+	}, {
+		name:     "transitive mapping",
+		username: "system:serviceaccount:ops:deployer",
+		groups:   []string{"system:serviceaccounts"},
+		want:     []string{"system:serviceaccounts", "cluster-admins", "sre"},
+	}, {
+		name:     "already member",
+		username: "bob",
+		groups:   []string{"sre"},
+		want:     []string{"sre", "cluster-admins"},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := expandGroups(mappings, tt.username, tt.groups); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("expandGroups() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_parseUserAttributes(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      string
+		wantErr bool
+	}{{
+		name: "valid",
+		in:   `{"team": [{"group": "^oidc:team-(.+)$", "value": "$1"}, {"serviceAccountAnnotation": "example.com/team"}]}`,
+	}, {
+		name:    "invalid json",
+		in:      "hello",
+		wantErr: true,
+	}, {
+		name:    "no source",
+		in:      `{"team": [{"value": "payments"}]}`,
+		wantErr: true,
+	}, {
+		name:    "several sources",
+		in:      `{"team": [{"username": "alice", "group": "payments"}]}`,
+		wantErr: true,
+	}, {
+		name:    "invalid regex",
+		in:      `{"team": [{"group": "oidc:team-(.+"}]}`,
+		wantErr: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := parseUserAttributes(tt.in); (err != nil) != tt.wantErr {
+				t.Errorf("parseUserAttributes() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestUserAttributes_Resolve(t *testing.T) {
+	attributes, err := parseUserAttributes(`{
+		"team": [
+			{"group": "^oidc:team-(.+)$", "value": "$1"},
+			{"serviceAccountAnnotation": "example.com/team"}
+		],
+		"costCenter": [
+			{"username": "^.+@(?P<unit>finance|sales)\\.example\\.com$", "value": "cc-${unit}"}
+		],
+		"user": [
+			{"username": "^[^@]+"}
+		]
+	}`)
+	if err != nil {
+		t.Fatalf("parseUserAttributes() error = %v", err)
+	}
+	tests := []struct {
+		name        string
+		username    string
+		groups      []string
+		annotations map[string]string
+		want        map[string]string
+		wantFetched bool
+	}{{
+		name:     "group and username",
+		username: "alice@finance.example.com",
+		groups:   []string{"system:authenticated", "oidc:team-payments"},
+		want:     map[string]string{"team": "payments", "costCenter": "cc-finance", "user": "alice"},
+	}, {
+		name:        "service account annotation",
+		username:    "system:serviceaccount:ci:deployer",
+		groups:      []string{"system:serviceaccounts"},
+		annotations: map[string]string{"example.com/team": "platform"},
+		want:        map[string]string{"team": "platform", "user": "system:serviceaccount:ci:deployer"},
+		wantFetched: true,
+	}, {
+		name:        "no match",
+		username:    "",
+		wantFetched: true,
+		want:        map[string]string{},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fetched := false
+			got := attributes.Resolve(tt.username, tt.groups, func() map[string]string {
+				fetched = true
+				return tt.annotations
+			})
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("UserAttributes.Resolve() = %v, want %v", got, tt.want)
+			}
+			if fetched != tt.wantFetched {
+				t.Errorf("UserAttributes.Resolve() fetched annotations = %v, want %v", fetched, tt.wantFetched)
+			}
+		})
+	}
+}
+
+func TestReportsExclusion_matches(t *testing.T) {
+	exclusion := ReportsExclusion{
+		Policies:   []string{"require-labels", "default/*"},
+		Rules:      []string{"check-*"},
+		Namespaces: []string{"kube-*"},
+	}
+	tests := []struct {
+		name      string
+		exclusion ReportsExclusion
+		policy    string
+		rule      string
+		namespace string
+		want      bool
+	}{{
+		name:      "empty exclusion matches everything",
+		policy:    "require-labels",
+		rule:      "check-team",
+		namespace: "default",
+		want:      true,
+	}, {
+		name:      "cluster policy",
+		exclusion: exclusion,
+		policy:    "require-labels",
+		rule:      "check-team",
+		namespace: "kube-system",
+		want:      true,
+	}, {
+		name:      "namespaced policy",
+		exclusion: exclusion,
+		policy:    "default/require-labels",
+		rule:      "check-team",
+		namespace: "kube-public",
+		want:      true,
+	}, {
+		name:      "rule does not match",
+		exclusion: exclusion,
+		policy:    "require-labels",
+		rule:      "autogen-check-team",
+		namespace: "kube-system",
+		want:      false,
+	}, {
+		name:      "namespace does not match",
+		exclusion: exclusion,
+		policy:    "require-labels",
+		rule:      "check-team",
+		namespace: "default",
+		want:      false,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.exclusion.matches(tt.policy, tt.rule, tt.namespace); got != tt.want {
+				t.Errorf("ReportsExclusion.matches() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_parseMetricsExposure(t *testing.T) {
+	disabled := false
+	tests := []struct {
+		name    string
+		in      string
+		want    map[string]MetricExposureConfig
+		wantErr bool
+	}{{
+		in:      "",
+		wantErr: true,
+	}, {
+		in:   "{}",
+		want: map[string]MetricExposureConfig{},
+	}, {
+		in:      `{"kyverno_policy_results": []}`,
+		wantErr: true,
+	}, {
+		in: `{"kyverno_policy_results": {"disabledLabelDimensions": ["resource_namespace"]}, "kyverno_client_queries": {"enabled": false}, "kyverno_policy_execution_duration_seconds": {"bucketBoundaries": [0.1, 1]}}`,
+		want: map[string]MetricExposureConfig{
+			"kyverno_policy_results":                    {DisabledLabelDimensions: []string{"resource_namespace"}},
+			"kyverno_client_queries":                    {Enabled: &disabled},
+			"kyverno_policy_execution_duration_seconds": {BucketBoundaries: []float64{0.1, 1}},
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseMetricsExposure(tt.in)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("parseMetricsExposure() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseMetricsExposure() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_parseResourceFilterSelectors(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      string
+		want    int
+		wantErr bool
+	}{{
+		in:      "",
+		wantErr: true,
+	}, {
+		in:   "[]",
+		want: 0,
+	}, {
+		in:   `[{"kinds": ["Pod"], "operations": ["CREATE"], "namespaceSelector": {"matchLabels": {"team": "platform"}}}, {"objectSelector": {"matchExpressions": [{"key": "managed", "operator": "Exists"}]}}]`,
+		want: 2,
+	}, {
+		in:      `[{"namespaceSelector": {"matchExpressions": [{"key": "team", "operator": "Bad"}]}}]`,
+		wantErr: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseResourceFilterSelectors(tt.in)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("parseResourceFilterSelectors() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if len(got) != tt.want {
+				t.Errorf("parseResourceFilterSelectors() = %v, want %v selectors", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_resourceFilterSelector_matches(t *testing.T) {
+	selectors, err := parseResourceFilterSelectors(`[
+		{"kinds": ["Pod"], "operations": ["CREATE", "UPDATE"], "namespaceSelector": {"matchLabels": {"operator.io/system": "true"}}},
+		{"kinds": ["ConfigMap"], "namespaces": ["team-*"], "objectSelector": {"matchLabels": {"skip": "true"}}}
+	]`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pod := schema.GroupVersionKind{Version: "v1", Kind: "Pod"}
+	configMap := schema.GroupVersionKind{Version: "v1", Kind: "ConfigMap"}
+	namespace := schema.GroupVersionKind{Version: "v1", Kind: "Namespace"}
+	node := schema.GroupVersionKind{Version: "v1", Kind: "Node"}
+	system := map[string]string{"operator.io/system": "true"}
+	tests := []struct {
+		name            string
+		selector        resourceFilterSelector
+		gvk             schema.GroupVersionKind
+		operation       string
+		namespace       string
+		namespaceLabels map[string]string
+		objectLabels    map[string]string
+		want            bool
+	}{{
+		name:            "pod in system namespace",
+		selector:        selectors[0],
+		gvk:             pod,
+		operation:       "CREATE",
+		namespace:       "operator-ns",
+		namespaceLabels: system,
+		want:            true,
+	}, {
+		name:            "pod deleted in system namespace",
+		selector:        selectors[0],
+		gvk:             pod,
+		operation:       "DELETE",
+		namespace:       "operator-ns",
+		namespaceLabels: system,
+		want:            false,
+	}, {
+		name:      "pod in other namespace",
+		selector:  selectors[0],
+		gvk:       pod,
+		operation: "CREATE",
+		namespace: "default",
+		want:      false,
+	}, {
+		name:            "configmap does not match pod selector",
+		selector:        selectors[0],
+		gvk:             configMap,
+		operation:       "CREATE",
+		namespace:       "operator-ns",
+		namespaceLabels: system,
+		want:            false,
+	}, {
+		name:         "labelled configmap in team namespace",
+		selector:     selectors[1],
+		gvk:          configMap,
+		operation:    "UPDATE",
+		namespace:    "team-a",
+		objectLabels: map[string]string{"skip": "true"},
+		want:         true,
+	}, {
+		name:      "unlabelled configmap in team namespace",
+		selector:  selectors[1],
+		gvk:       configMap,
+		operation: "UPDATE",
+		namespace: "team-a",
+		want:      false,
+	}, {
+		name:            "namespace selector matches namespaces",
+		selector:        resourceFilterSelector{namespaceSelector: selectors[0].namespaceSelector},
+		gvk:             namespace,
+		operation:       "CREATE",
+		namespaceLabels: system,
+		want:            true,
+	}, {
+		name:      "namespace selector does not match cluster wide resources",
+		selector:  resourceFilterSelector{namespaceSelector: selectors[0].namespaceSelector},
+		gvk:       node,
+		operation: "CREATE",
+		want:      false,
+	}, {
+		name:      "empty selector matches everything",
+		gvk:       node,
+		operation: "CREATE",
+		want:      true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.selector.matches(tt.gvk, "", tt.operation, tt.namespace, "name", tt.namespaceLabels, tt.objectLabels); got != tt.want {
+				t.Errorf("resourceFilterSelector.matches() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_parseBreakGlassUntil(t *testing.T) {
+	now := time.Date(2023, 9, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name    string
+		in      string
+		want    time.Time
+		wantErr bool
+	}{{
+		in:      "",
+		wantErr: true,
+	}, {
+		in:      "tomorrow",
+		wantErr: true,
+	}, {
+		in:   "2023-09-01T14:00:00Z",
+		want: time.Date(2023, 9, 1, 14, 0, 0, 0, time.UTC),
+	}, {
+		in:   " 2023-09-01T10:00:00Z ",
+		want: time.Date(2023, 9, 1, 10, 0, 0, 0, time.UTC),
+	}, {
+		in:      "2023-09-03T12:00:00Z",
+		wantErr: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseBreakGlassUntil(tt.in, now)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("parseBreakGlassUntil() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("parseBreakGlassUntil() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -5,12 +5,15 @@ import (
 	"time"
 
 	"github.com/go-logr/logr"
+	"github.com/kyverno/kyverno/api/kyverno"
 	"github.com/kyverno/kyverno/pkg/config"
 	"github.com/kyverno/kyverno/pkg/controllers"
 	"github.com/kyverno/kyverno/pkg/tls"
 	controllerutils "github.com/kyverno/kyverno/pkg/utils/controller"
+	datautils "github.com/kyverno/kyverno/pkg/utils/data"
 	retryutils "github.com/kyverno/kyverno/pkg/utils/retry"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	corev1informers "k8s.io/client-go/informers/core/v1"
@@ -28,6 +31,9 @@ const (
 type controller struct {
 	renewer tls.CertRenewer
 
+	// clients
+	statusClient controllerutils.ObjectClient[*corev1.ConfigMap]
+
 	// listers
 	caLister  corev1listers.SecretLister
 	tlsLister corev1listers.SecretLister
@@ -42,15 +48,17 @@ func NewController(
 	caInformer corev1informers.SecretInformer,
 	tlsInformer corev1informers.SecretInformer,
 	certRenewer tls.CertRenewer,
+	statusClient controllerutils.ObjectClient[*corev1.ConfigMap],
 ) controllers.Controller {
 	queue := workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), ControllerName)
 	c := controller{
-		renewer:    certRenewer,
-		caLister:   caInformer.Lister(),
-		tlsLister:  tlsInformer.Lister(),
-		queue:      queue,
-		caEnqueue:  controllerutils.AddDefaultEventHandlers(logger, caInformer.Informer(), queue),
-		tlsEnqueue: controllerutils.AddDefaultEventHandlers(logger, tlsInformer.Informer(), queue),
+		renewer:      certRenewer,
+		statusClient: statusClient,
+		caLister:     caInformer.Lister(),
+		tlsLister:    tlsInformer.Lister(),
+		queue:        queue,
+		caEnqueue:    controllerutils.AddDefaultEventHandlers(logger, caInformer.Informer(), queue),
+		tlsEnqueue:   controllerutils.AddDefaultEventHandlers(logger, tlsInformer.Informer(), queue),
 	}
 	return &c
 }
@@ -84,7 +92,10 @@ func (c *controller) reconcile(ctx context.Context, logger logr.Logger, key, nam
 	if name != tls.GenerateTLSPairSecretName() && name != tls.GenerateRootCASecretName() {
 		return nil
 	}
-	return c.renewCertificates(ctx)
+	if err := c.renewCertificates(ctx); err != nil {
+		return err
+	}
+	return c.updateStatus(ctx)
 }
 
 func (c *controller) ticker(ctx context.Context, logger logr.Logger) {
@@ -132,3 +143,55 @@ func (c *controller) renewCertificates(ctx context.Context) error {
 	}
 	return nil
 }
+
+// updateStatus reports the current certificates chain in the status configmap,
+// renewed secrets trigger a new reconciliation so the status eventually reflects the latest certificates
+func (c *controller) updateStatus(ctx context.Context) error {
+	if c.statusClient == nil {
+		return nil
+	}
+	caSecret, err := c.caLister.Secrets(config.KyvernoNamespace()).Get(tls.GenerateRootCASecretName())
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	tlsSecret, err := c.tlsLister.Secrets(config.KyvernoNamespace()).Get(tls.GenerateTLSPairSecretName())
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	data, err := tls.CertificatesStatus(caSecret, tlsSecret)
+	if err != nil {
+		return err
+	}
+	name := tls.GenerateStatusConfigMapName()
+	status, err := c.statusClient.Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		if !apierrors.IsNotFound(err) {
+			return err
+		}
+		status = &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: config.KyvernoNamespace(),
+				Labels: map[string]string{
+					kyverno.LabelCertManagedBy: kyverno.ValueKyvernoApp,
+				},
+			},
+			Data: data,
+		}
+		_, err := c.statusClient.Create(ctx, status, metav1.CreateOptions{})
+		return err
+	}
+	if datautils.DeepEqual(status.Data, data) {
+		return nil
+	}
+	status = status.DeepCopy()
+	status.Data = data
+	_, err = c.statusClient.Update(ctx, status, metav1.UpdateOptions{})
+	return err
+}
@@ -0,0 +1,227 @@
+package eventtrigger
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov1beta1 "github.com/kyverno/kyverno/api/kyverno/v1beta1"
+	"github.com/kyverno/kyverno/pkg/background/common"
+	"github.com/kyverno/kyverno/pkg/client/clientset/versioned"
+	kyvernov1informers "github.com/kyverno/kyverno/pkg/client/informers/externalversions/kyverno/v1"
+	kyvernov1listers "github.com/kyverno/kyverno/pkg/client/listers/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/clients/dclient"
+	"github.com/kyverno/kyverno/pkg/config"
+	"github.com/kyverno/kyverno/pkg/controllers"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	"github.com/kyverno/kyverno/pkg/engine/jmespath"
+	controllerutils "github.com/kyverno/kyverno/pkg/utils/controller"
+	engineutils "github.com/kyverno/kyverno/pkg/utils/engine"
+	"go.uber.org/multierr"
+	admissionv1 "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	corev1informers "k8s.io/client-go/informers/core/v1"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/util/workqueue"
+)
+
+const (
+	// Workers is the number of workers for this controller
+	Workers        = 2
+	ControllerName = "event-trigger-controller"
+	maxRetries     = 5
+)
+
+type controller struct {
+	// clients
+	client        dclient.Interface
+	kyvernoClient versioned.Interface
+	engine        engineapi.Engine
+
+	// listers
+	cpolLister  kyvernov1listers.ClusterPolicyLister
+	polLister   kyvernov1listers.PolicyLister
+	eventLister corev1listers.EventLister
+	nsLister    corev1listers.NamespaceLister
+
+	// queue
+	queue workqueue.RateLimitingInterface
+
+	// config
+	configuration config.Configuration
+	jp            jmespath.Interface
+	startTime     time.Time
+}
+
+// NewController returns a controller creating update requests for the generate rules matching Kubernetes Events,
+// only events observed after the controller started are processed
+func NewController(
+	client dclient.Interface,
+	kyvernoClient versioned.Interface,
+	engine engineapi.Engine,
+	cpolInformer kyvernov1informers.ClusterPolicyInformer,
+	polInformer kyvernov1informers.PolicyInformer,
+	eventInformer corev1informers.EventInformer,
+	nsInformer corev1informers.NamespaceInformer,
+	configuration config.Configuration,
+	jp jmespath.Interface,
+) controllers.Controller {
+	c := controller{
+		client:        client,
+		kyvernoClient: kyvernoClient,
+		engine:        engine,
+		cpolLister:    cpolInformer.Lister(),
+		polLister:     polInformer.Lister(),
+		eventLister:   eventInformer.Lister(),
+		nsLister:      nsInformer.Lister(),
+		queue:         workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), ControllerName),
+		configuration: configuration,
+		jp:            jp,
+		startTime:     time.Now(),
+	}
+	enqueue := controllerutils.LogError(logger, controllerutils.Parse(controllerutils.MetaNamespaceKey, controllerutils.Queue(c.queue)))
+	controllerutils.AddEventHandlersT(
+		eventInformer.Informer(),
+		func(obj *corev1.Event) {
+			if !lastObserved(obj).Before(c.startTime) {
+				_ = enqueue(obj)
+			}
+		},
+		func(old, obj *corev1.Event) {
+			// a repeated event increments the count of the existing one
+			if obj.Count > old.Count {
+				_ = enqueue(obj)
+			}
+		},
+		func(obj *corev1.Event) {},
+	)
+	return &c
+}
+
+func (c *controller) Run(ctx context.Context, workers int) {
+	controllerutils.Run(ctx, logger, ControllerName, time.Second, c.queue, workers, maxRetries, c.reconcile)
+}
+
+func (c *controller) reconcile(ctx context.Context, logger logr.Logger, key, namespace, name string) error {
+	event, err := c.eventLister.Events(namespace).Get(name)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	policies, err := c.getPolicies(namespace)
+	if err != nil {
+		return err
+	}
+	if len(policies) == 0 {
+		return nil
+	}
+	trigger, err := toUnstructured(event)
+	if err != nil {
+		return err
+	}
+	namespaceLabels := engineutils.GetNamespaceSelectorsFromNamespaceLister(trigger.GetKind(), trigger.GetNamespace(), c.nsLister, logger)
+	var errs []error
+	for _, policy := range policies {
+		if err := c.applyPolicy(ctx, logger, policy, trigger, namespaceLabels); err != nil {
+			logger.Error(err, "failed to create update requests for event", "policy", policy.GetName())
+			errs = append(errs, err)
+		}
+	}
+	return multierr.Combine(errs...)
+}
+
+// getPolicies returns the policies with generate rules matching events in the given namespace
+func (c *controller) getPolicies(namespace string) ([]kyvernov1.PolicyInterface, error) {
+	var policies []kyvernov1.PolicyInterface
+	cpols, err := c.cpolLister.List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	for _, cpol := range cpols {
+		if len(eventRules(cpol)) != 0 {
+			policies = append(policies, cpol)
+		}
+	}
+	pols, err := c.polLister.Policies(namespace).List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	for _, pol := range pols {
+		if len(eventRules(pol)) != 0 {
+			policies = append(policies, pol)
+		}
+	}
+	return policies, nil
+}
+
+func (c *controller) applyPolicy(ctx context.Context, logger logr.Logger, policy kyvernov1.PolicyInterface, trigger *unstructured.Unstructured, namespaceLabels map[string]string) error {
+	policyKey := common.PolicyKey(policy.GetNamespace(), policy.GetName())
+	spec := kyvernov1beta1.UpdateRequestSpec{
+		Type:     kyvernov1beta1.Generate,
+		Policy:   policyKey,
+		Resource: common.ResourceSpecFromUnstructured(*trigger),
+		Context: kyvernov1beta1.UpdateRequestSpecContext{
+			AdmissionRequestInfo: kyvernov1beta1.AdmissionRequestInfoObject{
+				Operation: admissionv1.Create,
+			},
+		},
+	}
+	policyContext, err := common.NewBackgroundContext(logger, c.client, &kyvernov1beta1.UpdateRequest{Spec: spec}, policy, trigger, c.configuration, c.jp, namespaceLabels)
+	if err != nil {
+		return err
+	}
+	engineResponse := c.engine.ApplyBackgroundChecks(ctx, policyContext)
+	rules := eventRules(policy)
+	var errs []error
+	for _, ruleResponse := range engineResponse.PolicyResponse.Rules {
+		if ruleResponse.RuleType() != engineapi.Generation || ruleResponse.Status() != engineapi.RuleStatusPass || !rules.Has(ruleResponse.Name()) {
+			continue
+		}
+		spec := spec
+		spec.Rule = ruleResponse.Name()
+		logger.V(2).Info("creating update request for event", "policy", policyKey, "rule", spec.Rule, "event", trigger.GetName())
+		if err := c.createUpdateRequest(ctx, spec); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return multierr.Combine(errs...)
+}
+
+func (c *controller) createUpdateRequest(ctx context.Context, spec kyvernov1beta1.UpdateRequestSpec) error {
+	ur := &kyvernov1beta1.UpdateRequest{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: "ur-",
+			Namespace:    config.KyvernoNamespace(),
+			Labels:       common.GenerateLabelsSet(spec.Policy, spec.Resource),
+		},
+		Spec: spec,
+	}
+	created, err := c.kyvernoClient.KyvernoV1beta1().UpdateRequests(config.KyvernoNamespace()).Create(ctx, ur, metav1.CreateOptions{})
+	if err != nil {
+		return err
+	}
+	updated := created.DeepCopy()
+	updated.Status.State = kyvernov1beta1.Pending
+	_, err = c.kyvernoClient.KyvernoV1beta1().UpdateRequests(config.KyvernoNamespace()).UpdateStatus(ctx, updated, metav1.UpdateOptions{})
+	return err
+}
+
+func toUnstructured(event *corev1.Event) (*unstructured.Unstructured, error) {
+	data, err := runtime.DefaultUnstructuredConverter.ToUnstructured(event)
+	if err != nil {
+		return nil, err
+	}
+	trigger := &unstructured.Unstructured{Object: data}
+	// objects returned by informers have no type meta
+	trigger.SetAPIVersion("v1")
+	trigger.SetKind("Event")
+	return trigger, nil
+}
@@ -0,0 +1,5 @@
+package eventtrigger
+
+import "github.com/kyverno/kyverno/pkg/logging"
+
+var logger = logging.WithName(ControllerName)
@@ -0,0 +1,48 @@
+package eventtrigger
+
+import (
+	"time"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kubeutils "github.com/kyverno/kyverno/pkg/utils/kube"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// eventRules returns the names of the generate rules of the policy explicitly matching the Event kind,
+// wildcard kinds are ignored to avoid processing every event of the cluster
+func eventRules(policy kyvernov1.PolicyInterface) sets.Set[string] {
+	rules := sets.New[string]()
+	for _, rule := range policy.GetSpec().Rules {
+		if !rule.HasGenerate() {
+			continue
+		}
+		kinds := rule.MatchResources.Kinds
+		for _, filter := range append(rule.MatchResources.Any, rule.MatchResources.All...) {
+			kinds = append(kinds, filter.Kinds...)
+		}
+		for _, kind := range kinds {
+			if matchesEventKind(kind) {
+				rules.Insert(rule.Name)
+				break
+			}
+		}
+	}
+	return rules
+}
+
+func matchesEventKind(selector string) bool {
+	group, version, kind, subresource := kubeutils.ParseKindSelector(selector)
+	return kind == "Event" && subresource == "" && (group == "*" || group == "") && (version == "*" || version == "v1")
+}
+
+// lastObserved returns the last time the event was observed
+func lastObserved(event *corev1.Event) time.Time {
+	if !event.LastTimestamp.IsZero() {
+		return event.LastTimestamp.Time
+	}
+	if !event.EventTime.IsZero() {
+		return event.EventTime.Time
+	}
+	return event.CreationTimestamp.Time
+}
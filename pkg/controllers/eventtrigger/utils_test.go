@@ -0,0 +1,83 @@
+package eventtrigger
+
+import (
+	"testing"
+	"time"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"gotest.tools/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+func Test_matchesEventKind(t *testing.T) {
+	tests := []struct {
+		selector string
+		want     bool
+	}{
+		{selector: "Event", want: true},
+		{selector: "v1/Event", want: true},
+		{selector: "*/Event", want: true},
+		{selector: "events.k8s.io/v1/Event", want: false},
+		{selector: "Pod", want: false},
+		{selector: "*", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.selector, func(t *testing.T) {
+			assert.Equal(t, matchesEventKind(tt.selector), tt.want)
+		})
+	}
+}
+
+func Test_eventRules(t *testing.T) {
+	generate := kyvernov1.Generation{
+		ResourceSpec: kyvernov1.ResourceSpec{Kind: "ConfigMap", Name: "foo"},
+	}
+	policy := &kyvernov1.ClusterPolicy{
+		Spec: kyvernov1.Spec{
+			Rules: []kyvernov1.Rule{{
+				Name: "kinds",
+				MatchResources: kyvernov1.MatchResources{
+					ResourceDescription: kyvernov1.ResourceDescription{Kinds: []string{"Event"}},
+				},
+				Generation: generate,
+			}, {
+				Name: "any",
+				MatchResources: kyvernov1.MatchResources{
+					Any: kyvernov1.ResourceFilters{{
+						ResourceDescription: kyvernov1.ResourceDescription{Kinds: []string{"Pod", "v1/Event"}},
+					}},
+				},
+				Generation: generate,
+			}, {
+				Name: "pods",
+				MatchResources: kyvernov1.MatchResources{
+					ResourceDescription: kyvernov1.ResourceDescription{Kinds: []string{"Pod"}},
+				},
+				Generation: generate,
+			}, {
+				Name: "validate",
+				MatchResources: kyvernov1.MatchResources{
+					ResourceDescription: kyvernov1.ResourceDescription{Kinds: []string{"Event"}},
+				},
+				Validation: kyvernov1.Validation{Message: "foo"},
+			}},
+		},
+	}
+	assert.DeepEqual(t, eventRules(policy), sets.New("kinds", "any"))
+}
+
+func Test_lastObserved(t *testing.T) {
+	created := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	eventTime := created.Add(time.Minute)
+	lastTimestamp := created.Add(time.Hour)
+	event := &corev1.Event{
+		ObjectMeta: metav1.ObjectMeta{CreationTimestamp: metav1.NewTime(created)},
+	}
+	assert.Equal(t, lastObserved(event), created)
+	event.EventTime = metav1.NewMicroTime(eventTime)
+	assert.Equal(t, lastObserved(event), eventTime)
+	event.LastTimestamp = metav1.NewTime(lastTimestamp)
+	assert.Equal(t, lastObserved(event), lastTimestamp)
+}
@@ -0,0 +1,252 @@
+package generatedrift
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/kyverno/kyverno/api/kyverno"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov1beta1 "github.com/kyverno/kyverno/api/kyverno/v1beta1"
+	"github.com/kyverno/kyverno/pkg/background/common"
+	"github.com/kyverno/kyverno/pkg/background/generate"
+	"github.com/kyverno/kyverno/pkg/client/clientset/versioned"
+	kyvernov1informers "github.com/kyverno/kyverno/pkg/client/informers/externalversions/kyverno/v1"
+	kyvernov1beta1informers "github.com/kyverno/kyverno/pkg/client/informers/externalversions/kyverno/v1beta1"
+	kyvernov1listers "github.com/kyverno/kyverno/pkg/client/listers/kyverno/v1"
+	kyvernov1beta1listers "github.com/kyverno/kyverno/pkg/client/listers/kyverno/v1beta1"
+	"github.com/kyverno/kyverno/pkg/clients/dclient"
+	"github.com/kyverno/kyverno/pkg/config"
+	"github.com/kyverno/kyverno/pkg/controllers"
+	controllerutils "github.com/kyverno/kyverno/pkg/utils/controller"
+	kubeutils "github.com/kyverno/kyverno/pkg/utils/kube"
+	"go.uber.org/multierr"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+)
+
+const (
+	// Workers is the number of workers for this controller
+	Workers        = 1
+	ControllerName = "generate-drift-controller"
+	maxRetries     = 10
+)
+
+type controller struct {
+	// clients
+	client        dclient.Interface
+	kyvernoClient versioned.Interface
+
+	// listers
+	cpolLister kyvernov1listers.ClusterPolicyLister
+	polLister  kyvernov1listers.PolicyLister
+	urLister   kyvernov1beta1listers.UpdateRequestNamespaceLister
+
+	// queue
+	queue workqueue.RateLimitingInterface
+
+	// config
+	interval time.Duration
+
+	// checked holds the resource version of the downstream resources already synchronized
+	lock    sync.Mutex
+	checked map[types.UID]string
+}
+
+// NewController returns a controller detecting the resources generated by synchronized rules that were changed
+// by other field managers since Kyverno applied them, an update request is created to synchronize them again
+// and report the conflicting fields
+func NewController(
+	client dclient.Interface,
+	kyvernoClient versioned.Interface,
+	cpolInformer kyvernov1informers.ClusterPolicyInformer,
+	polInformer kyvernov1informers.PolicyInformer,
+	urInformer kyvernov1beta1informers.UpdateRequestInformer,
+	interval time.Duration,
+) controllers.Controller {
+	c := controller{
+		client:        client,
+		kyvernoClient: kyvernoClient,
+		cpolLister:    cpolInformer.Lister(),
+		polLister:     polInformer.Lister(),
+		urLister:      urInformer.Lister().UpdateRequests(config.KyvernoNamespace()),
+		queue:         workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), ControllerName),
+		interval:      interval,
+		checked:       map[types.UID]string{},
+	}
+	return &c
+}
+
+func (c *controller) Run(ctx context.Context, workers int) {
+	controllerutils.Run(ctx, logger, ControllerName, time.Second, c.queue, workers, maxRetries, c.reconcile, c.resync)
+}
+
+// resync periodically enqueues the policies with synchronized generate rules
+func (c *controller) resync(ctx context.Context, logger logr.Logger) {
+	wait.UntilWithContext(ctx, func(ctx context.Context) {
+		policies, err := c.getAllPolicies()
+		if err != nil {
+			logger.Error(err, "failed to list policies")
+			return
+		}
+		for _, policy := range policies {
+			if len(synchronizedRules(policy)) == 0 {
+				continue
+			}
+			key, err := cache.MetaNamespaceKeyFunc(policy)
+			if err != nil {
+				logger.Error(err, "failed to compute policy key")
+				continue
+			}
+			c.queue.Add(key)
+		}
+	}, c.interval)
+}
+
+func (c *controller) getAllPolicies() ([]kyvernov1.PolicyInterface, error) {
+	var policies []kyvernov1.PolicyInterface
+	cpols, err := c.cpolLister.List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	for _, cpol := range cpols {
+		policies = append(policies, cpol)
+	}
+	pols, err := c.polLister.List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	for _, pol := range pols {
+		policies = append(policies, pol)
+	}
+	return policies, nil
+}
+
+func synchronizedRules(policy kyvernov1.PolicyInterface) []kyvernov1.Rule {
+	var rules []kyvernov1.Rule
+	for _, rule := range policy.GetSpec().Rules {
+		if rule.HasGenerate() && rule.Generation.Synchronize {
+			rules = append(rules, rule)
+		}
+	}
+	return rules
+}
+
+func (c *controller) reconcile(ctx context.Context, logger logr.Logger, key, namespace, name string) error {
+	var policy kyvernov1.PolicyInterface
+	var err error
+	if namespace == "" {
+		policy, err = c.cpolLister.Get(name)
+	} else {
+		policy, err = c.polLister.Policies(namespace).Get(name)
+	}
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	var errs []error
+	for _, rule := range synchronizedRules(policy) {
+		selector := map[string]string{
+			common.GeneratePolicyLabel:          policy.GetName(),
+			common.GeneratePolicyNamespaceLabel: policy.GetNamespace(),
+			common.GenerateRuleLabel:            rule.Name,
+			kyverno.LabelAppManagedBy:           kyverno.ValueKyvernoApp,
+		}
+		check := func(apiVersion, kind string) {
+			downstreams, err := generate.FindDownstream(c.client, apiVersion, kind, selector)
+			if err != nil {
+				errs = append(errs, err)
+				return
+			}
+			for i := range downstreams.Items {
+				if err := c.checkDownstream(ctx, logger, key, rule.Name, &downstreams.Items[i]); err != nil {
+					errs = append(errs, err)
+				}
+			}
+		}
+		if rule.Generation.GetKind() != "" {
+			check(rule.Generation.GetAPIVersion(), rule.Generation.GetKind())
+			continue
+		}
+		for _, kind := range rule.Generation.CloneList.Kinds {
+			check(kubeutils.GetKindFromGVK(kind))
+		}
+	}
+	return multierr.Combine(errs...)
+}
+
+// checkDownstream creates an update request synchronizing the downstream resource when it was changed by another
+// field manager, a resource version is synchronized only once to avoid looping on changes that don't conflict
+func (c *controller) checkDownstream(ctx context.Context, logger logr.Logger, policyKey, rule string, downstream *unstructured.Unstructured) error {
+	if !generate.ModifiedSinceApplied(downstream) || !c.shouldCheck(downstream) {
+		return nil
+	}
+	trigger := generate.TriggerFromLabels(downstream.GetLabels())
+	urs, err := c.urLister.List(labels.SelectorFromSet(common.GenerateLabelsSet(policyKey, trigger)))
+	if err != nil {
+		return err
+	}
+	for _, ur := range urs {
+		if ur.Status.State == kyvernov1beta1.Pending || ur.Status.State == "" {
+			return nil
+		}
+	}
+	logger.V(2).Info("generated resource changed by another field manager, synchronizing", "policy", policyKey, "rule", rule,
+		"kind", downstream.GetKind(), "namespace", downstream.GetNamespace(), "name", downstream.GetName())
+	ur := newUpdateRequest(policyKey, rule, trigger)
+	created, err := c.kyvernoClient.KyvernoV1beta1().UpdateRequests(config.KyvernoNamespace()).Create(ctx, ur, metav1.CreateOptions{})
+	if err != nil {
+		return err
+	}
+	updated := created.DeepCopy()
+	updated.Status = kyvernov1beta1.UpdateRequestStatus{
+		State:              kyvernov1beta1.Pending,
+		GeneratedResources: []kyvernov1.ResourceSpec{common.ResourceSpecFromUnstructured(*downstream)},
+	}
+	if _, err := c.kyvernoClient.KyvernoV1beta1().UpdateRequests(config.KyvernoNamespace()).UpdateStatus(ctx, updated, metav1.UpdateOptions{}); err != nil {
+		return err
+	}
+	c.markChecked(downstream)
+	return nil
+}
+
+func (c *controller) shouldCheck(downstream *unstructured.Unstructured) bool {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.checked[downstream.GetUID()] != downstream.GetResourceVersion()
+}
+
+func (c *controller) markChecked(downstream *unstructured.Unstructured) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.checked[downstream.GetUID()] = downstream.GetResourceVersion()
+}
+
+func newUpdateRequest(policyKey, rule string, trigger kyvernov1.ResourceSpec) *kyvernov1beta1.UpdateRequest {
+	return &kyvernov1beta1.UpdateRequest{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: kyvernov1beta1.SchemeGroupVersion.String(),
+			Kind:       "UpdateRequest",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: "ur-",
+			Namespace:    config.KyvernoNamespace(),
+			Labels:       common.GenerateLabelsSet(policyKey, trigger),
+		},
+		Spec: kyvernov1beta1.UpdateRequestSpec{
+			Type:     kyvernov1beta1.Generate,
+			Policy:   policyKey,
+			Rule:     rule,
+			Resource: trigger,
+		},
+	}
+}
@@ -0,0 +1,244 @@
+package generatestatus
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov1beta1 "github.com/kyverno/kyverno/api/kyverno/v1beta1"
+	"github.com/kyverno/kyverno/pkg/background/common"
+	"github.com/kyverno/kyverno/pkg/background/generate"
+	"github.com/kyverno/kyverno/pkg/client/clientset/versioned"
+	kyvernov1informers "github.com/kyverno/kyverno/pkg/client/informers/externalversions/kyverno/v1"
+	kyvernov1beta1informers "github.com/kyverno/kyverno/pkg/client/informers/externalversions/kyverno/v1beta1"
+	kyvernov1listers "github.com/kyverno/kyverno/pkg/client/listers/kyverno/v1"
+	kyvernov1beta1listers "github.com/kyverno/kyverno/pkg/client/listers/kyverno/v1beta1"
+	"github.com/kyverno/kyverno/pkg/clients/dclient"
+	"github.com/kyverno/kyverno/pkg/config"
+	"github.com/kyverno/kyverno/pkg/controllers"
+	controllerutils "github.com/kyverno/kyverno/pkg/utils/controller"
+	kubeutils "github.com/kyverno/kyverno/pkg/utils/kube"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+)
+
+const (
+	// Workers is the number of workers for this controller
+	Workers        = 2
+	ControllerName = "generate-status-controller"
+	maxRetries     = 10
+	// enqueueDelay batches update request changes before refreshing a policy status
+	enqueueDelay = 10 * time.Second
+)
+
+type controller struct {
+	// clients
+	client        dclient.Interface
+	kyvernoClient versioned.Interface
+
+	// listers
+	cpolLister kyvernov1listers.ClusterPolicyLister
+	polLister  kyvernov1listers.PolicyLister
+	urLister   kyvernov1beta1listers.UpdateRequestNamespaceLister
+
+	// queue
+	queue workqueue.RateLimitingInterface
+
+	// config
+	resyncPeriod time.Duration
+}
+
+// NewController returns a controller maintaining the synchronization status of generate policies,
+// downstream resources are not watched and are counted again every resync period
+func NewController(
+	client dclient.Interface,
+	kyvernoClient versioned.Interface,
+	cpolInformer kyvernov1informers.ClusterPolicyInformer,
+	polInformer kyvernov1informers.PolicyInformer,
+	urInformer kyvernov1beta1informers.UpdateRequestInformer,
+	resyncPeriod time.Duration,
+) controllers.Controller {
+	c := controller{
+		client:        client,
+		kyvernoClient: kyvernoClient,
+		cpolLister:    cpolInformer.Lister(),
+		polLister:     polInformer.Lister(),
+		urLister:      urInformer.Lister().UpdateRequests(config.KyvernoNamespace()),
+		queue:         workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), ControllerName),
+		resyncPeriod:  resyncPeriod,
+	}
+	// status updates, including the ones made by this controller, don't change the generation and are ignored
+	enqueue := controllerutils.LogError(logger, controllerutils.Parse(controllerutils.MetaNamespaceKey, controllerutils.Queue(c.queue)))
+	addPolicyEventHandlers := func(informer cache.SharedInformer) {
+		controllerutils.AddEventHandlersT(
+			informer,
+			func(obj kyvernov1.PolicyInterface) { _ = enqueue(obj) },
+			func(old, obj kyvernov1.PolicyInterface) {
+				if specChanged(old, obj) {
+					_ = enqueue(obj)
+				}
+			},
+			func(obj kyvernov1.PolicyInterface) { _ = enqueue(obj) },
+		)
+	}
+	addPolicyEventHandlers(cpolInformer.Informer())
+	addPolicyEventHandlers(polInformer.Informer())
+	controllerutils.AddDelayedKeyedEventHandlers(logger, urInformer.Informer(), c.queue, enqueueDelay, func(obj interface{}) (interface{}, error) {
+		return obj.(*kyvernov1beta1.UpdateRequest).Spec.Policy, nil
+	})
+	return &c
+}
+
+// specChanged returns true if the policy spec changed, the generation is only incremented on spec changes
+func specChanged(old, obj kyvernov1.PolicyInterface) bool {
+	return old.GetGeneration() != obj.GetGeneration()
+}
+
+func (c *controller) Run(ctx context.Context, workers int) {
+	controllerutils.Run(ctx, logger, ControllerName, time.Second, c.queue, workers, maxRetries, c.reconcile, c.resync)
+}
+
+// resync periodically enqueues all generate policies to refresh their downstream resources count
+func (c *controller) resync(ctx context.Context, logger logr.Logger) {
+	wait.UntilWithContext(ctx, func(ctx context.Context) {
+		policies, err := c.getAllPolicies()
+		if err != nil {
+			logger.Error(err, "failed to list policies")
+			return
+		}
+		for _, policy := range policies {
+			if !policy.GetSpec().HasGenerate() {
+				continue
+			}
+			key, err := cache.MetaNamespaceKeyFunc(policy)
+			if err != nil {
+				logger.Error(err, "failed to compute policy key")
+				continue
+			}
+			c.queue.Add(key)
+		}
+	}, c.resyncPeriod)
+}
+
+func (c *controller) getAllPolicies() ([]kyvernov1.PolicyInterface, error) {
+	var policies []kyvernov1.PolicyInterface
+	cpols, err := c.cpolLister.List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	for _, cpol := range cpols {
+		policies = append(policies, cpol)
+	}
+	pols, err := c.polLister.List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	for _, pol := range pols {
+		policies = append(policies, pol)
+	}
+	return policies, nil
+}
+
+func (c *controller) reconcile(ctx context.Context, logger logr.Logger, key, namespace, name string) error {
+	if namespace == "" {
+		policy, err := c.cpolLister.Get(name)
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				return nil
+			}
+			return err
+		}
+		status, err := c.computeStatus(key, policy)
+		if err != nil {
+			return err
+		}
+		_, err = controllerutils.UpdateStatus(ctx, policy, c.kyvernoClient.KyvernoV1().ClusterPolicies(), func(policy *kyvernov1.ClusterPolicy) error {
+			policy.Status.SetGenerateStatus(status)
+			return nil
+		})
+		return err
+	}
+	policy, err := c.polLister.Policies(namespace).Get(name)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	status, err := c.computeStatus(key, policy)
+	if err != nil {
+		return err
+	}
+	_, err = controllerutils.UpdateStatus(ctx, policy, c.kyvernoClient.KyvernoV1().Policies(namespace), func(policy *kyvernov1.Policy) error {
+		policy.Status.SetGenerateStatus(status)
+		return nil
+	})
+	return err
+}
+
+// computeStatus counts the resources generated by the policy as in sync, pending update requests and resources
+// of synchronized rules changed by another field manager since Kyverno applied them as out of sync, and update
+// requests that exhausted their retries as failed
+func (c *controller) computeStatus(key string, policy kyvernov1.PolicyInterface) (*kyvernov1.GenerateStatus, error) {
+	spec := policy.GetSpec()
+	if !spec.HasGenerate() {
+		return nil, nil
+	}
+	var status kyvernov1.GenerateStatus
+	urs, err := c.urLister.List(labels.SelectorFromSet(common.GenerateLabelsSet(key, nil)))
+	if err != nil {
+		return nil, err
+	}
+	for _, ur := range urs {
+		if ur.Spec.Policy != key || ur.Spec.GetRequestType() != kyvernov1beta1.Generate {
+			continue
+		}
+		switch ur.Status.State {
+		case kyvernov1beta1.Pending, "":
+			status.OutOfSync++
+		case kyvernov1beta1.Failed:
+			status.Failed++
+		}
+	}
+	for _, rule := range spec.Rules {
+		if !rule.HasGenerate() {
+			continue
+		}
+		selector := map[string]string{
+			common.GeneratePolicyLabel:          policy.GetName(),
+			common.GeneratePolicyNamespaceLabel: policy.GetNamespace(),
+			common.GenerateRuleLabel:            rule.Name,
+		}
+		count := func(apiVersion, kind string) error {
+			downstreams, err := generate.FindDownstream(c.client, apiVersion, kind, selector)
+			if err != nil {
+				return err
+			}
+			for i := range downstreams.Items {
+				// downstream resources of rules that are not synchronized are expected to diverge
+				if rule.Generation.Synchronize && generate.ModifiedSinceApplied(&downstreams.Items[i]) {
+					status.OutOfSync++
+				} else {
+					status.InSync++
+				}
+			}
+			return nil
+		}
+		if rule.Generation.GetKind() != "" {
+			if err := count(rule.Generation.GetAPIVersion(), rule.Generation.GetKind()); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		for _, kind := range rule.Generation.CloneList.Kinds {
+			if err := count(kubeutils.GetKindFromGVK(kind)); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return &status, nil
+}
@@ -0,0 +1,126 @@
+package generatestatus
+
+import (
+	"testing"
+	"time"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov1beta1 "github.com/kyverno/kyverno/api/kyverno/v1beta1"
+	"github.com/kyverno/kyverno/pkg/background/common"
+	"github.com/kyverno/kyverno/pkg/client/clientset/versioned/fake"
+	kyvernoinformers "github.com/kyverno/kyverno/pkg/client/informers/externalversions"
+	"github.com/kyverno/kyverno/pkg/clients/dclient"
+	"github.com/kyverno/kyverno/pkg/config"
+	"gotest.tools/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func newPolicy(synchronize bool) *kyvernov1.ClusterPolicy {
+	return &kyvernov1.ClusterPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "policy", Generation: 1},
+		Spec: kyvernov1.Spec{
+			Rules: []kyvernov1.Rule{{
+				Name: "rule",
+				Generation: kyvernov1.Generation{
+					ResourceSpec: kyvernov1.ResourceSpec{APIVersion: "v1", Kind: "ConfigMap", Name: "generated"},
+					Synchronize:  synchronize,
+				},
+			}},
+		},
+	}
+}
+
+func newDownstream(name string, modified bool) *unstructured.Unstructured {
+	applied := metav1.NewTime(time.Now().Add(-time.Hour))
+	managedFields := []metav1.ManagedFieldsEntry{{Manager: "kyverno-generate", Time: &applied}}
+	if modified {
+		now := metav1.Now()
+		managedFields = append(managedFields, metav1.ManagedFieldsEntry{Manager: "kubectl-edit", Time: &now})
+	}
+	downstream := &unstructured.Unstructured{}
+	downstream.SetAPIVersion("v1")
+	downstream.SetKind("ConfigMap")
+	downstream.SetNamespace(name)
+	downstream.SetName("generated")
+	downstream.SetLabels(map[string]string{
+		common.GeneratePolicyLabel:          "policy",
+		common.GeneratePolicyNamespaceLabel: "",
+		common.GenerateRuleLabel:            "rule",
+	})
+	downstream.SetManagedFields(managedFields)
+	return downstream
+}
+
+func newUpdateRequest(name string, state kyvernov1beta1.UpdateRequestState) *kyvernov1beta1.UpdateRequest {
+	return &kyvernov1beta1.UpdateRequest{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: config.KyvernoNamespace(),
+			Labels:    common.GenerateLabelsSet("policy", nil),
+		},
+		Spec: kyvernov1beta1.UpdateRequestSpec{
+			Type:   kyvernov1beta1.Generate,
+			Policy: "policy",
+		},
+		Status: kyvernov1beta1.UpdateRequestStatus{State: state},
+	}
+}
+
+func Test_specChanged(t *testing.T) {
+	old := newPolicy(true)
+	// status updates don't change the generation
+	status := old.DeepCopy()
+	status.Status.SetGenerateStatus(&kyvernov1.GenerateStatus{InSync: 1})
+	assert.Assert(t, !specChanged(old, status))
+	spec := old.DeepCopy()
+	spec.Spec.Rules[0].Generation.Synchronize = false
+	spec.Generation++
+	assert.Assert(t, specChanged(old, spec))
+}
+
+func Test_computeStatus(t *testing.T) {
+	tests := []struct {
+		name        string
+		synchronize bool
+		want        kyvernov1.GenerateStatus
+	}{{
+		name:        "synchronized rule",
+		synchronize: true,
+		want:        kyvernov1.GenerateStatus{InSync: 1, OutOfSync: 2, Failed: 1},
+	}, {
+		name:        "not synchronized rule",
+		synchronize: false,
+		want:        kyvernov1.GenerateStatus{InSync: 2, OutOfSync: 1, Failed: 1},
+	}}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			client, err := dclient.NewFakeClient(
+				runtime.NewScheme(),
+				map[schema.GroupVersionResource]string{{Version: "v1", Resource: "configmaps"}: "ConfigMapList"},
+				newDownstream("in-sync", false),
+				newDownstream("drifted", true),
+			)
+			assert.NilError(t, err)
+			client.SetDiscovery(dclient.NewFakeDiscoveryClient(nil))
+			factory := kyvernoinformers.NewSharedInformerFactory(fake.NewSimpleClientset(), 0)
+			urInformer := factory.Kyverno().V1beta1().UpdateRequests()
+			for _, ur := range []*kyvernov1beta1.UpdateRequest{
+				newUpdateRequest("pending", kyvernov1beta1.Pending),
+				newUpdateRequest("failed", kyvernov1beta1.Failed),
+				newUpdateRequest("completed", kyvernov1beta1.Completed),
+			} {
+				assert.NilError(t, urInformer.Informer().GetIndexer().Add(ur))
+			}
+			c := controller{
+				client:   client,
+				urLister: urInformer.Lister().UpdateRequests(config.KyvernoNamespace()),
+			}
+			status, err := c.computeStatus("policy", newPolicy(test.synchronize))
+			assert.NilError(t, err)
+			assert.DeepEqual(t, *status, test.want)
+		})
+	}
+}
@@ -0,0 +1,5 @@
+package generatestatus
+
+import "github.com/kyverno/kyverno/pkg/logging"
+
+var logger = logging.WithName(ControllerName)
@@ -0,0 +1,239 @@
+package notification
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	"github.com/kyverno/kyverno/pkg/client/clientset/versioned"
+	kyvernov2alpha1informers "github.com/kyverno/kyverno/pkg/client/informers/externalversions/kyverno/v2alpha1"
+	kyvernov2alpha1listers "github.com/kyverno/kyverno/pkg/client/listers/kyverno/v2alpha1"
+	"github.com/kyverno/kyverno/pkg/controllers"
+	controllerutils "github.com/kyverno/kyverno/pkg/utils/controller"
+	"go.uber.org/multierr"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/client-go/util/workqueue"
+)
+
+const (
+	// Workers is the number of workers for this controller
+	Workers        = 2
+	ControllerName = "notification-controller"
+	maxRetries     = 5
+	// queueSize is the maximum number of violations waiting to be counted
+	queueSize = 1000
+	// sendTimeout is the timeout of the requests sent to sinks
+	sendTimeout = 10 * time.Second
+)
+
+// Controller sends alerts to the sinks of notifications when the violations they select reach their threshold
+type Controller interface {
+	controllers.Controller
+	Notifier
+}
+
+type controller struct {
+	// clients
+	kyvernoClient versioned.Interface
+	sender        sender
+
+	// listers
+	notificationLister kyvernov2alpha1listers.NotificationLister
+
+	// queue
+	queue      workqueue.RateLimitingInterface
+	violations chan Violation
+
+	// ready stores the violations to send by notification, the counted violations are stored in the notification status
+	lock  sync.Mutex
+	ready map[string][]Violation
+}
+
+// NewController returns a notification controller, secrets referenced by sinks are read with the given client
+func NewController(
+	kyvernoClient versioned.Interface,
+	notificationInformer kyvernov2alpha1informers.NotificationInformer,
+	secrets corev1client.SecretInterface,
+) Controller {
+	c := &controller{
+		kyvernoClient: kyvernoClient,
+		sender: sender{
+			client:  &http.Client{Timeout: sendTimeout},
+			secrets: secrets,
+		},
+		notificationLister: notificationInformer.Lister(),
+		queue:              workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), ControllerName),
+		violations:         make(chan Violation, queueSize),
+		ready:              map[string][]Violation{},
+	}
+	controllerutils.AddEventHandlersT(
+		notificationInformer.Informer(),
+		func(obj *kyvernov2alpha1.Notification) {},
+		func(old, obj *kyvernov2alpha1.Notification) {},
+		func(obj *kyvernov2alpha1.Notification) {
+			c.lock.Lock()
+			defer c.lock.Unlock()
+			delete(c.ready, obj.GetName())
+		},
+	)
+	return c
+}
+
+func (c *controller) Notify(violations ...Violation) {
+	for _, violation := range violations {
+		if violation.Timestamp.IsZero() {
+			violation.Timestamp = time.Now()
+		}
+		select {
+		case c.violations <- violation:
+		default:
+			logger.V(2).Info("notification queue is full, dropping violation", "violation", violation.String())
+		}
+	}
+}
+
+func (c *controller) Run(ctx context.Context, workers int) {
+	controllerutils.Run(ctx, logger, ControllerName, time.Second, c.queue, workers, maxRetries, c.reconcile, c.count)
+}
+
+// count counts the queued violations against the notifications selecting them,
+// violations queued together are counted with a single status update per notification
+func (c *controller) count(ctx context.Context, logger logr.Logger) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case violation := <-c.violations:
+			violations := c.drain(violation)
+			notifications, err := c.notificationLister.List(labels.Everything())
+			if err != nil {
+				logger.Error(err, "failed to list notifications")
+				continue
+			}
+			for _, notification := range notifications {
+				var selected []Violation
+				for _, violation := range violations {
+					if matches(&notification.Spec, violation) {
+						selected = append(selected, violation)
+					}
+				}
+				if len(selected) == 0 {
+					continue
+				}
+				if err := c.add(ctx, notification.GetName(), selected...); err != nil {
+					logger.Error(err, "failed to count violations", "notification", notification.GetName(), "violations", len(selected))
+				}
+			}
+		}
+	}
+}
+
+// drain returns the given violation and the violations already queued after it
+func (c *controller) drain(violation Violation) []Violation {
+	violations := []Violation{violation}
+	for len(violations) < queueSize {
+		select {
+		case violation := <-c.violations:
+			violations = append(violations, violation)
+		default:
+			return violations
+		}
+	}
+	return violations
+}
+
+// add counts violations in the status of a notification, when the threshold is reached the violations are
+// removed from the status and queued for sending by the replica that updated the status
+func (c *controller) add(ctx context.Context, name string, violations ...Violation) error {
+	var ready []Violation
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		latest, err := c.kyvernoClient.KyvernoV2alpha1().Notifications().Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		s := newState(latest)
+		for _, violation := range violations {
+			s.add(&latest.Spec, violation, violation.Timestamp)
+		}
+		ready = s.take()
+		_, err = controllerutils.UpdateStatus(ctx, latest, c.kyvernoClient.KyvernoV2alpha1().Notifications(), func(notification *kyvernov2alpha1.Notification) error {
+			s.store(notification)
+			return nil
+		})
+		return err
+	})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	if len(ready) != 0 {
+		c.lock.Lock()
+		c.ready[name] = append(c.ready[name], ready...)
+		c.lock.Unlock()
+		c.queue.Add(name)
+	}
+	return nil
+}
+
+// take returns and clears the violations of a notification that must be sent
+func (c *controller) take(name string) []Violation {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	violations := c.ready[name]
+	delete(c.ready, name)
+	return violations
+}
+
+func (c *controller) reconcile(ctx context.Context, logger logr.Logger, key, _, name string) error {
+	notification, err := c.notificationLister.Get(name)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	violations := c.take(name)
+	if len(violations) == 0 {
+		return nil
+	}
+	alert := newAlert(name, violations)
+	var errs []error
+	for _, sink := range notification.Spec.Sinks {
+		// violations are consumed, failed alerts are reported in the status but never resent
+		if err := c.sender.send(ctx, sink, alert); err != nil {
+			logger.Error(err, "failed to send alert", "sink", sink.Name)
+			errs = append(errs, err)
+		} else {
+			logger.V(2).Info("alert sent", "sink", sink.Name, "violations", len(violations))
+		}
+	}
+	sendErr := multierr.Combine(errs...)
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		latest, err := c.kyvernoClient.KyvernoV2alpha1().Notifications().Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		_, err = controllerutils.UpdateStatus(ctx, latest, c.kyvernoClient.KyvernoV2alpha1().Notifications(), func(notification *kyvernov2alpha1.Notification) error {
+			if len(errs) < len(notification.Spec.Sinks) {
+				now := metav1.Now()
+				notification.Status.Sent++
+				notification.Status.LastSentTime = &now
+			}
+			notification.Status.LastError = ""
+			if sendErr != nil {
+				notification.Status.LastError = sendErr.Error()
+			}
+			return nil
+		})
+		return err
+	})
+}
@@ -0,0 +1,47 @@
+package notification
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	"github.com/kyverno/kyverno/pkg/client/clientset/versioned/fake"
+	kyvernoinformers "github.com/kyverno/kyverno/pkg/client/informers/externalversions"
+	"gotest.tools/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func Test_controller_add(t *testing.T) {
+	now := time.Now()
+	kyvernoClient := fake.NewSimpleClientset(&kyvernov2alpha1.Notification{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Generation: 1},
+		Spec: kyvernov2alpha1.NotificationSpec{
+			Threshold: kyvernov2alpha1.NotificationThreshold{Count: 2},
+		},
+	})
+	newReplica := func() *controller {
+		informers := kyvernoinformers.NewSharedInformerFactory(kyvernoClient, 0)
+		c := NewController(kyvernoClient, informers.Kyverno().V2alpha1().Notifications(), nil).(*controller)
+		t.Cleanup(c.queue.ShutDown)
+		return c
+	}
+	// violations reported to different replicas are counted together
+	first, second := newReplica(), newReplica()
+	assert.NilError(t, first.add(context.TODO(), "test", newViolation(kyvernov2alpha1.NotificationEnforce, "policy", "default", "a", now)))
+	assert.Equal(t, first.queue.Len(), 0)
+	assert.NilError(t, second.add(context.TODO(), "test", newViolation(kyvernov2alpha1.NotificationEnforce, "policy", "default", "b", now)))
+	assert.Equal(t, second.queue.Len(), 1)
+	// the alert is only sent by the replica that reached the threshold
+	assert.Equal(t, len(first.take("test")), 0)
+	ready := second.take("test")
+	assert.Equal(t, len(ready), 2)
+	assert.Equal(t, ready[0].Resource.Name, "a")
+	assert.Equal(t, ready[1].Resource.Name, "b")
+	notification, err := kyvernoClient.KyvernoV2alpha1().Notifications().Get(context.TODO(), "test", metav1.GetOptions{})
+	assert.NilError(t, err)
+	assert.Equal(t, len(notification.Status.Pending), 0)
+	assert.Equal(t, notification.Status.ObservedGeneration, int64(1))
+	// deleted notifications are ignored
+	assert.NilError(t, first.add(context.TODO(), "missing", newViolation(kyvernov2alpha1.NotificationEnforce, "policy", "default", "c", now)))
+}
@@ -0,0 +1,133 @@
+package notification
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
+)
+
+const (
+	pagerDutyEventsURL       = "https://events.pagerduty.com/v2/enqueue"
+	defaultPagerDutySeverity = "error"
+	// maxSlackViolations is the maximum number of violations listed in a Slack message
+	maxSlackViolations = 10
+)
+
+type sender struct {
+	client  *http.Client
+	secrets corev1client.SecretInterface
+}
+
+// send posts the alert to the sink
+func (s *sender) send(ctx context.Context, sink kyvernov2alpha1.NotificationSink, alert Alert) error {
+	switch {
+	case sink.Webhook != nil:
+		url := sink.Webhook.URL
+		if sink.Webhook.URLSecretRef != nil {
+			value, err := s.secretValue(ctx, *sink.Webhook.URLSecretRef)
+			if err != nil {
+				return err
+			}
+			url = value
+		}
+		return s.post(ctx, url, sink.Webhook.Headers, alert)
+	case sink.Slack != nil:
+		url, err := s.secretValue(ctx, sink.Slack.URLSecretRef)
+		if err != nil {
+			return err
+		}
+		return s.post(ctx, url, nil, slackMessage(sink.Slack, alert))
+	case sink.PagerDuty != nil:
+		routingKey, err := s.secretValue(ctx, sink.PagerDuty.RoutingKeySecretRef)
+		if err != nil {
+			return err
+		}
+		url := sink.PagerDuty.URL
+		if url == "" {
+			url = pagerDutyEventsURL
+		}
+		return s.post(ctx, url, nil, pagerDutyEvent(sink.PagerDuty, routingKey, alert))
+	}
+	return fmt.Errorf("sink %s has no type", sink.Name)
+}
+
+func (s *sender) secretValue(ctx context.Context, ref kyvernov2alpha1.SecretKeyRef) (string, error) {
+	secret, err := s.secrets.Get(ctx, ref.Name, metav1.GetOptions{})
+	if err != nil {
+		return "", err
+	}
+	value, ok := secret.Data[ref.Key]
+	if !ok {
+		return "", fmt.Errorf("key %s not found in secret %s", ref.Key, ref.Name)
+	}
+	return strings.TrimSpace(string(value)), nil
+}
+
+func (s *sender) post(ctx context.Context, url string, headers map[string]string, payload interface{}) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+	return nil
+}
+
+func slackMessage(sink *kyvernov2alpha1.SlackSink, alert Alert) map[string]interface{} {
+	lines := []string{alert.Summary}
+	for i, violation := range alert.Violations {
+		if i == maxSlackViolations {
+			lines = append(lines, fmt.Sprintf("... and %d more", len(alert.Violations)-maxSlackViolations))
+			break
+		}
+		lines = append(lines, "• "+violation.String())
+	}
+	message := map[string]interface{}{
+		"text": strings.Join(lines, "\n"),
+	}
+	if sink.Channel != "" {
+		message["channel"] = sink.Channel
+	}
+	return message
+}
+
+func pagerDutyEvent(sink *kyvernov2alpha1.PagerDutySink, routingKey string, alert Alert) map[string]interface{} {
+	severity := sink.Severity
+	if severity == "" {
+		severity = defaultPagerDutySeverity
+	}
+	return map[string]interface{}{
+		"routing_key":  routingKey,
+		"event_action": "trigger",
+		"dedup_key":    "kyverno/" + alert.Notification,
+		"payload": map[string]interface{}{
+			"summary":        alert.Summary,
+			"source":         "kyverno",
+			"severity":       severity,
+			"custom_details": alert,
+		},
+	}
+}
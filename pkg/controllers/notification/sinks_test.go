@@ -0,0 +1,77 @@
+package notification
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	"gotest.tools/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func Test_sender_send(t *testing.T) {
+	var received map[string]interface{}
+	var header string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header = r.Header.Get("X-Token")
+		assert.NilError(t, json.NewDecoder(r.Body).Decode(&received))
+	}))
+	defer server.Close()
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "sinks", Namespace: "kyverno"},
+		Data: map[string][]byte{
+			"url": []byte(server.URL + "\n"),
+			"key": []byte("routing-key"),
+		},
+	}
+	s := sender{
+		client:  server.Client(),
+		secrets: fake.NewSimpleClientset(secret).CoreV1().Secrets("kyverno"),
+	}
+	alert := newAlert("notification", []Violation{
+		newViolation(kyvernov2alpha1.NotificationEnforce, "policy", "default", "pod", time.Now()),
+	})
+	ref := kyvernov2alpha1.SecretKeyRef{Name: "sinks", Key: "url"}
+	t.Run("webhook", func(t *testing.T) {
+		err := s.send(context.TODO(), kyvernov2alpha1.NotificationSink{
+			Name:    "webhook",
+			Webhook: &kyvernov2alpha1.WebhookSink{URLSecretRef: &ref, Headers: map[string]string{"X-Token": "token"}},
+		}, alert)
+		assert.NilError(t, err)
+		assert.Equal(t, header, "token")
+		assert.Equal(t, received["notification"], "notification")
+		assert.Equal(t, len(received["violations"].([]interface{})), 1)
+	})
+	t.Run("slack", func(t *testing.T) {
+		err := s.send(context.TODO(), kyvernov2alpha1.NotificationSink{
+			Name:  "slack",
+			Slack: &kyvernov2alpha1.SlackSink{URLSecretRef: ref, Channel: "#alerts"},
+		}, alert)
+		assert.NilError(t, err)
+		assert.Equal(t, received["channel"], "#alerts")
+		assert.Equal(t, received["text"], "1 policy violation(s) reported for notification notification\n• [Enforce] policy/rule Pod/default/pod: ")
+	})
+	t.Run("pagerduty", func(t *testing.T) {
+		err := s.send(context.TODO(), kyvernov2alpha1.NotificationSink{
+			Name:      "pagerduty",
+			PagerDuty: &kyvernov2alpha1.PagerDutySink{RoutingKeySecretRef: kyvernov2alpha1.SecretKeyRef{Name: "sinks", Key: "key"}, URL: server.URL},
+		}, alert)
+		assert.NilError(t, err)
+		assert.Equal(t, received["routing_key"], "routing-key")
+		assert.Equal(t, received["event_action"], "trigger")
+		assert.Equal(t, received["payload"].(map[string]interface{})["severity"], "error")
+	})
+	t.Run("missing key", func(t *testing.T) {
+		err := s.send(context.TODO(), kyvernov2alpha1.NotificationSink{
+			Name:  "slack",
+			Slack: &kyvernov2alpha1.SlackSink{URLSecretRef: kyvernov2alpha1.SecretKeyRef{Name: "sinks", Key: "missing"}},
+		}, alert)
+		assert.Error(t, err, "key missing not found in secret sinks")
+	})
+}
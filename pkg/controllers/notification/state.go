@@ -0,0 +1,131 @@
+package notification
+
+import (
+	"time"
+
+	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	"github.com/kyverno/kyverno/pkg/utils/wildcard"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// matches returns true when the notification selects the violation
+func matches(spec *kyvernov2alpha1.NotificationSpec, violation Violation) bool {
+	found := false
+	for _, action := range spec.GetActions() {
+		if action == violation.Action {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return false
+	}
+	if len(spec.Policies) != 0 && !wildcard.CheckPatterns(spec.Policies, violation.Policy) {
+		return false
+	}
+	if len(spec.Namespaces) != 0 && !wildcard.CheckPatterns(spec.Namespaces, violation.Resource.Namespace) {
+		return false
+	}
+	return true
+}
+
+// state tracks the violations counted for a notification, it is stored in the notification status
+// so that the violations reported by all the replicas are counted together
+type state struct {
+	// seen stores the last time a violation was counted, by violation key
+	seen map[string]time.Time
+	// pending stores the violations counted in the current threshold window
+	pending []Violation
+	// ready stores the violations that reached the threshold and must be sent
+	ready []Violation
+}
+
+// newState loads the state of a notification from its status,
+// the counted violations are discarded when the notification spec changed since they were counted
+func newState(notification *kyvernov2alpha1.Notification) *state {
+	s := &state{
+		seen: map[string]time.Time{},
+	}
+	status := &notification.Status
+	if status.ObservedGeneration != notification.GetGeneration() {
+		return s
+	}
+	for key, last := range status.Seen {
+		s.seen[key] = last.Time
+	}
+	for _, violation := range status.Pending {
+		s.pending = append(s.pending, Violation{
+			Action:    violation.Action,
+			Policy:    violation.Policy,
+			Rule:      violation.Rule,
+			Resource:  Resource{Kind: violation.Kind, Namespace: violation.Namespace, Name: violation.Name},
+			Message:   violation.Message,
+			Timestamp: violation.Timestamp.Time,
+		})
+	}
+	return s
+}
+
+// store writes the counted violations in the notification status, the ready violations are not stored
+func (s *state) store(notification *kyvernov2alpha1.Notification) {
+	status := &notification.Status
+	status.ObservedGeneration = notification.GetGeneration()
+	status.Seen = nil
+	if len(s.seen) != 0 {
+		status.Seen = make(map[string]metav1.Time, len(s.seen))
+		for key, last := range s.seen {
+			status.Seen[key] = metav1.NewTime(last)
+		}
+	}
+	status.Pending = nil
+	for _, violation := range s.pending {
+		status.Pending = append(status.Pending, kyvernov2alpha1.NotificationViolation{
+			Action:    violation.Action,
+			Policy:    violation.Policy,
+			Rule:      violation.Rule,
+			Kind:      violation.Resource.Kind,
+			Namespace: violation.Resource.Namespace,
+			Name:      violation.Resource.Name,
+			Message:   violation.Message,
+			Timestamp: metav1.NewTime(violation.Timestamp),
+		})
+	}
+}
+
+// add counts a violation and returns true when the threshold is reached
+func (s *state) add(spec *kyvernov2alpha1.NotificationSpec, violation Violation, now time.Time) bool {
+	dedup := spec.GetDeduplicationWindow()
+	for key, last := range s.seen {
+		if now.Sub(last) >= dedup {
+			delete(s.seen, key)
+		}
+	}
+	key := violation.key()
+	if _, ok := s.seen[key]; ok {
+		return false
+	}
+	if dedup > 0 {
+		s.seen[key] = now
+	}
+	window := spec.GetThresholdWindow()
+	var pending []Violation
+	for _, v := range s.pending {
+		if now.Sub(v.Timestamp) < window {
+			pending = append(pending, v)
+		}
+	}
+	s.pending = append(pending, violation)
+	if len(s.pending) < spec.GetThresholdCount() {
+		return false
+	}
+	s.ready = append(s.ready, s.pending...)
+	s.pending = nil
+	return true
+}
+
+// take returns and clears the violations that must be sent
+func (s *state) take() []Violation {
+	ready := s.ready
+	s.ready = nil
+	return ready
+}
@@ -0,0 +1,108 @@
+package notification
+
+import (
+	"testing"
+	"time"
+
+	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	"gotest.tools/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func newViolation(action kyvernov2alpha1.NotificationAction, policy, namespace, name string, timestamp time.Time) Violation {
+	return Violation{
+		Action:    action,
+		Policy:    policy,
+		Rule:      "rule",
+		Resource:  Resource{Kind: "Pod", Namespace: namespace, Name: name},
+		Timestamp: timestamp,
+	}
+}
+
+func Test_matches(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name      string
+		spec      kyvernov2alpha1.NotificationSpec
+		violation Violation
+		want      bool
+	}{{
+		name:      "defaults",
+		violation: newViolation(kyvernov2alpha1.NotificationAudit, "policy", "default", "pod", now),
+		want:      true,
+	}, {
+		name:      "action not selected",
+		spec:      kyvernov2alpha1.NotificationSpec{Actions: []kyvernov2alpha1.NotificationAction{kyvernov2alpha1.NotificationEnforce}},
+		violation: newViolation(kyvernov2alpha1.NotificationAudit, "policy", "default", "pod", now),
+	}, {
+		name:      "policy wildcard",
+		spec:      kyvernov2alpha1.NotificationSpec{Policies: []string{"require-*"}},
+		violation: newViolation(kyvernov2alpha1.NotificationEnforce, "require-labels", "default", "pod", now),
+		want:      true,
+	}, {
+		name:      "policy not selected",
+		spec:      kyvernov2alpha1.NotificationSpec{Policies: []string{"require-*"}},
+		violation: newViolation(kyvernov2alpha1.NotificationEnforce, "disallow-latest", "default", "pod", now),
+	}, {
+		name:      "namespace not selected",
+		spec:      kyvernov2alpha1.NotificationSpec{Namespaces: []string{"prod-*"}},
+		violation: newViolation(kyvernov2alpha1.NotificationEnforce, "policy", "default", "pod", now),
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, matches(&tt.spec, tt.violation), tt.want)
+		})
+	}
+}
+
+func Test_state(t *testing.T) {
+	now := time.Now()
+	spec := &kyvernov2alpha1.NotificationSpec{
+		Threshold: kyvernov2alpha1.NotificationThreshold{
+			Count:  2,
+			Window: &metav1.Duration{Duration: time.Minute},
+		},
+		DeduplicationWindow: &metav1.Duration{Duration: time.Hour},
+	}
+	s := newState(&kyvernov2alpha1.Notification{})
+	// first violation is below the threshold
+	assert.Assert(t, !s.add(spec, newViolation(kyvernov2alpha1.NotificationEnforce, "policy", "default", "a", now), now))
+	// duplicate violation is not counted
+	assert.Assert(t, !s.add(spec, newViolation(kyvernov2alpha1.NotificationEnforce, "policy", "default", "a", now), now))
+	// a violation outside the window resets the count
+	later := now.Add(2 * time.Minute)
+	assert.Assert(t, !s.add(spec, newViolation(kyvernov2alpha1.NotificationEnforce, "policy", "default", "b", later), later))
+	assert.Assert(t, s.add(spec, newViolation(kyvernov2alpha1.NotificationEnforce, "policy", "default", "c", later), later))
+	ready := s.take()
+	assert.Equal(t, len(ready), 2)
+	assert.Equal(t, ready[0].Resource.Name, "b")
+	assert.Equal(t, ready[1].Resource.Name, "c")
+	assert.Equal(t, len(s.take()), 0)
+	// the duplicate is counted again once the deduplication window elapsed
+	expired := now.Add(time.Hour)
+	assert.Assert(t, !s.add(spec, newViolation(kyvernov2alpha1.NotificationEnforce, "policy", "default", "a", expired), expired))
+	assert.Equal(t, len(s.pending), 1)
+}
+
+func Test_state_store(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	spec := &kyvernov2alpha1.NotificationSpec{
+		Threshold:           kyvernov2alpha1.NotificationThreshold{Count: 3},
+		DeduplicationWindow: &metav1.Duration{Duration: time.Hour},
+	}
+	notification := &kyvernov2alpha1.Notification{ObjectMeta: metav1.ObjectMeta{Name: "test", Generation: 1}}
+	s := newState(notification)
+	assert.Assert(t, !s.add(spec, newViolation(kyvernov2alpha1.NotificationEnforce, "policy", "default", "a", now), now))
+	s.store(notification)
+	assert.Equal(t, notification.Status.ObservedGeneration, int64(1))
+	assert.Equal(t, len(notification.Status.Pending), 1)
+	assert.Equal(t, len(notification.Status.Seen), 1)
+	// the state is loaded back from the status
+	loaded := newState(notification)
+	assert.DeepEqual(t, loaded.pending, s.pending)
+	assert.Assert(t, !loaded.add(spec, newViolation(kyvernov2alpha1.NotificationEnforce, "policy", "default", "a", now), now))
+	assert.Equal(t, len(loaded.pending), 1)
+	// violations counted for a previous spec are discarded
+	notification.Generation = 2
+	assert.Equal(t, len(newState(notification).pending), 0)
+}
@@ -0,0 +1,63 @@
+package notification
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+)
+
+// Notifier receives the policy violations notifications alert on
+type Notifier interface {
+	// Notify queues violations, it never blocks and drops violations when the queue is full
+	Notify(...Violation)
+}
+
+// Violation is a policy rule failing for a resource
+type Violation struct {
+	Action    kyvernov2alpha1.NotificationAction `json:"action"`
+	Policy    string                             `json:"policy"`
+	Rule      string                             `json:"rule"`
+	Resource  Resource                           `json:"resource"`
+	Message   string                             `json:"message,omitempty"`
+	Timestamp time.Time                          `json:"timestamp"`
+}
+
+// Resource identifies the resource a violation was reported for
+type Resource struct {
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name"`
+}
+
+func (r Resource) String() string {
+	if r.Namespace == "" {
+		return r.Kind + "/" + r.Name
+	}
+	return r.Kind + "/" + r.Namespace + "/" + r.Name
+}
+
+// key identifies the same violation reported several times
+func (v Violation) key() string {
+	return strings.Join([]string{string(v.Action), v.Policy, v.Rule, v.Resource.String()}, "|")
+}
+
+func (v Violation) String() string {
+	return fmt.Sprintf("[%s] %s/%s %s: %s", v.Action, v.Policy, v.Rule, v.Resource, v.Message)
+}
+
+// Alert is the payload sent to webhook sinks
+type Alert struct {
+	Notification string      `json:"notification"`
+	Summary      string      `json:"summary"`
+	Violations   []Violation `json:"violations"`
+}
+
+func newAlert(notification string, violations []Violation) Alert {
+	return Alert{
+		Notification: notification,
+		Summary:      fmt.Sprintf("%d policy violation(s) reported for notification %s", len(violations), notification),
+		Violations:   violations,
+	}
+}
@@ -0,0 +1,89 @@
+package policyset
+
+import (
+	"fmt"
+	"io"
+
+	gcrv1 "github.com/google/go-containerregistry/pkg/v1"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	"github.com/kyverno/kyverno/pkg/images"
+	yamlutils "github.com/kyverno/kyverno/pkg/utils/yaml"
+)
+
+const (
+	// policyLayerMediaType is the media type of the layers pushed with `kyverno oci push`
+	policyLayerMediaType = "application/vnd.cncf.kyverno.policy.layer.v1+yaml"
+	defaultRekorURL      = "https://rekor.sigstore.dev"
+)
+
+// loadPolicies returns the policies contained in the policy layers of an image
+func loadPolicies(img gcrv1.Image) ([]kyvernov1.PolicyInterface, error) {
+	layers, err := img.Layers()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get image layers: %w", err)
+	}
+	var policies []kyvernov1.PolicyInterface
+	for _, layer := range layers {
+		mediaType, err := layer.MediaType()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get layer media type: %w", err)
+		}
+		if mediaType != policyLayerMediaType {
+			continue
+		}
+		blob, err := layer.Compressed()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get layer blob: %w", err)
+		}
+		data, err := io.ReadAll(blob)
+		blob.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read layer blob: %w", err)
+		}
+		layerPolicies, _, err := yamlutils.GetPolicy(data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to unmarshal layer blob: %w", err)
+		}
+		policies = append(policies, layerPolicies...)
+	}
+	if len(policies) == 0 {
+		return nil, fmt.Errorf("image contains no policy layer")
+	}
+	return policies, nil
+}
+
+// verifyOptions returns the options used to verify the signature of a policy set image
+func verifyOptions(verify kyvernov2alpha1.PolicySetVerification, imageRef string, client images.Client) images.Options {
+	opts := images.Options{
+		ImageRef:   imageRef,
+		Repository: verify.Repository,
+		Client:     client,
+		RekorURL:   defaultRekorURL,
+	}
+	var rekor *kyvernov1.CTLog
+	if keys := verify.Keys; keys != nil {
+		if keys.PublicKeys != "" {
+			opts.Key = keys.PublicKeys
+		} else if keys.Secret != nil {
+			opts.Key = fmt.Sprintf("k8s://%s/%s", keys.Secret.Namespace, keys.Secret.Name)
+		} else {
+			opts.Key = keys.KMS
+		}
+		opts.SignatureAlgorithm = keys.SignatureAlgorithm
+		rekor = keys.Rekor
+	} else if keyless := verify.Keyless; keyless != nil {
+		opts.Roots = keyless.Roots
+		opts.Issuer = keyless.Issuer
+		opts.Subject = keyless.Subject
+		opts.AdditionalExtensions = keyless.AdditionalExtensions
+		rekor = keyless.Rekor
+	}
+	if rekor != nil {
+		opts.RekorURL = rekor.URL
+		opts.RekorPubKey = rekor.RekorPubKey
+		opts.IgnoreSCT = rekor.IgnoreSCT
+		opts.IgnoreTlog = rekor.IgnoreTlog
+	}
+	return opts
+}
@@ -0,0 +1,96 @@
+package policyset
+
+import (
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/static"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	"gotest.tools/assert"
+)
+
+const policies = `
+apiVersion: kyverno.io/v1
+kind: ClusterPolicy
+metadata:
+  name: require-labels
+spec:
+  rules:
+  - name: check-team
+    match:
+      any:
+      - resources:
+          kinds:
+          - Pod
+    validate:
+      message: label team is required
+      pattern:
+        metadata:
+          labels:
+            team: "?*"
+---
+apiVersion: kyverno.io/v1
+kind: Policy
+metadata:
+  name: require-env
+  namespace: test
+spec:
+  rules:
+  - name: check-env
+    match:
+      any:
+      - resources:
+          kinds:
+          - Pod
+    validate:
+      message: label env is required
+      pattern:
+        metadata:
+          labels:
+            env: "?*"
+`
+
+func Test_loadPolicies(t *testing.T) {
+	img, err := mutate.Append(mutate.MediaType(empty.Image, types.OCIManifestSchema1),
+		mutate.Addendum{Layer: static.NewLayer([]byte(policies), policyLayerMediaType)},
+		mutate.Addendum{Layer: static.NewLayer([]byte("ignored"), "application/octet-stream")},
+	)
+	assert.NilError(t, err)
+	loaded, err := loadPolicies(img)
+	assert.NilError(t, err)
+	assert.Equal(t, len(loaded), 2)
+	assert.Equal(t, policyKey(loaded[0]), "require-labels")
+	assert.Equal(t, policyKey(loaded[1]), "test/require-env")
+
+	_, err = loadPolicies(empty.Image)
+	assert.ErrorContains(t, err, "no policy layer")
+}
+
+func Test_verifyOptions(t *testing.T) {
+	opts := verifyOptions(kyvernov2alpha1.PolicySetVerification{
+		Keys: &kyvernov1.StaticKeyAttestor{
+			Secret: &kyvernov1.SecretReference{Namespace: "kyverno", Name: "cosign"},
+		},
+	}, "ghcr.io/acme/policies@sha256:abc", nil)
+	assert.Equal(t, opts.ImageRef, "ghcr.io/acme/policies@sha256:abc")
+	assert.Equal(t, opts.Key, "k8s://kyverno/cosign")
+	assert.Equal(t, opts.RekorURL, defaultRekorURL)
+
+	opts = verifyOptions(kyvernov2alpha1.PolicySetVerification{
+		Keyless: &kyvernov1.KeylessAttestor{
+			Subject: "https://github.com/acme/policies/*",
+			Issuer:  "https://token.actions.githubusercontent.com",
+			Rekor:   &kyvernov1.CTLog{URL: "https://rekor.acme.io", IgnoreTlog: true},
+		},
+		Repository: "ghcr.io/acme/signatures",
+	}, "ghcr.io/acme/policies@sha256:abc", nil)
+	assert.Equal(t, opts.Key, "")
+	assert.Equal(t, opts.Subject, "https://github.com/acme/policies/*")
+	assert.Equal(t, opts.Issuer, "https://token.actions.githubusercontent.com")
+	assert.Equal(t, opts.RekorURL, "https://rekor.acme.io")
+	assert.Equal(t, opts.IgnoreTlog, true)
+	assert.Equal(t, opts.Repository, "ghcr.io/acme/signatures")
+}
@@ -0,0 +1,166 @@
+package policyset
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/google/go-containerregistry/pkg/name"
+	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	"github.com/kyverno/kyverno/pkg/client/clientset/versioned"
+	kyvernov2alpha1informers "github.com/kyverno/kyverno/pkg/client/informers/externalversions/kyverno/v2alpha1"
+	kyvernov2alpha1listers "github.com/kyverno/kyverno/pkg/client/listers/kyverno/v2alpha1"
+	"github.com/kyverno/kyverno/pkg/controllers"
+	"github.com/kyverno/kyverno/pkg/cosign"
+	"github.com/kyverno/kyverno/pkg/images"
+	"github.com/kyverno/kyverno/pkg/registryclient"
+	controllerutils "github.com/kyverno/kyverno/pkg/utils/controller"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/client-go/util/workqueue"
+)
+
+const (
+	// Workers is the number of workers for this controller
+	Workers        = 1
+	ControllerName = "policyset-controller"
+	maxRetries     = 5
+)
+
+type controller struct {
+	// clients
+	kyvernoClient versioned.Interface
+	rclient       registryclient.Client
+	verifier      images.ImageVerifier
+
+	// listers
+	policySetLister kyvernov2alpha1listers.PolicySetLister
+
+	// queue
+	queue workqueue.RateLimitingInterface
+}
+
+// NewController returns a controller installing the policies of policy sets, images are pulled and their
+// signature verified with the given registry client
+func NewController(
+	kyvernoClient versioned.Interface,
+	rclient registryclient.Client,
+	policySetInformer kyvernov2alpha1informers.PolicySetInformer,
+) controllers.Controller {
+	c := &controller{
+		kyvernoClient:   kyvernoClient,
+		rclient:         rclient,
+		verifier:        cosign.NewVerifier(),
+		policySetLister: policySetInformer.Lister(),
+		queue:           workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), ControllerName),
+	}
+	enqueue := controllerutils.LogError(logger, controllerutils.Parse(controllerutils.MetaNamespaceKeyT[*kyvernov2alpha1.PolicySet], controllerutils.Queue(c.queue)))
+	controllerutils.AddEventHandlersT(
+		policySetInformer.Informer(),
+		func(obj *kyvernov2alpha1.PolicySet) { enqueue(obj) },
+		func(old, obj *kyvernov2alpha1.PolicySet) {
+			// status updates don't change the generation and must not trigger a new sync
+			if old.GetGeneration() != obj.GetGeneration() {
+				enqueue(obj)
+			}
+		},
+		func(obj *kyvernov2alpha1.PolicySet) {},
+	)
+	return c
+}
+
+func (c *controller) Run(ctx context.Context, workers int) {
+	controllerutils.Run(ctx, logger, ControllerName, time.Second, c.queue, workers, maxRetries, c.reconcile)
+}
+
+// sync installs the policies of the image if its digest changed or the installed policies drifted,
+// it returns the digest of the image and the installed policies
+func (c *controller) sync(ctx context.Context, logger logr.Logger, policySet *kyvernov2alpha1.PolicySet) (string, []string, error) {
+	desc, err := c.rclient.FetchImageDescriptor(ctx, policySet.Spec.Image)
+	if err != nil {
+		return "", nil, err
+	}
+	digest := desc.Digest.String()
+	if digest == policySet.Status.Digest && policySet.Status.LastError == "" {
+		keys, err := installed(ctx, c.kyvernoClient, policySet, digest)
+		if err != nil {
+			return "", nil, err
+		}
+		if keys.Len() == len(policySet.Status.Policies) && keys.HasAll(policySet.Status.Policies...) {
+			logger.V(4).Info("policies are in sync", "digest", digest)
+			return digest, policySet.Status.Policies, nil
+		}
+	}
+	ref, err := name.ParseReference(policySet.Spec.Image)
+	if err != nil {
+		return "", nil, err
+	}
+	// verify the digest that was resolved, not the tag that may have moved since
+	pinned := ref.Context().Digest(digest).String()
+	if _, err := c.verifier.VerifySignature(ctx, verifyOptions(policySet.Spec.Verify, pinned, c.rclient)); err != nil {
+		return "", nil, fmt.Errorf("failed to verify the signature of %s: %w", pinned, err)
+	}
+	img, err := desc.Image()
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to get image %s: %w", pinned, err)
+	}
+	policies, err := loadPolicies(img)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to load policies from %s: %w", pinned, err)
+	}
+	keys, err := install(ctx, c.kyvernoClient, policySet, digest, policies)
+	if err != nil {
+		return "", nil, err
+	}
+	logger.V(2).Info("policies installed", "digest", digest, "policies", keys)
+	return digest, keys, nil
+}
+
+func (c *controller) reconcile(ctx context.Context, logger logr.Logger, key, _, name string) error {
+	policySet, err := c.policySetLister.Get(name)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			// installed policies are garbage collected through their owner reference
+			return nil
+		}
+		return err
+	}
+	if errs := policySet.Validate(); len(errs) != 0 {
+		return c.updateStatus(ctx, name, policySet.Status.Digest, policySet.Status.Policies, errs.ToAggregate())
+	}
+	digest, policies, syncErr := c.sync(ctx, logger, policySet)
+	if syncErr != nil {
+		logger.Error(syncErr, "failed to sync policy set")
+		// keep the policies of the last successful sync
+		digest, policies = policySet.Status.Digest, policySet.Status.Policies
+	}
+	if err := c.updateStatus(ctx, name, digest, policies, syncErr); err != nil {
+		return err
+	}
+	// the digest is checked again after the interval, sync errors are reported in the status and retried then
+	c.queue.AddAfter(key, policySet.Spec.GetInterval())
+	return nil
+}
+
+func (c *controller) updateStatus(ctx context.Context, name string, digest string, policies []string, syncErr error) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		latest, err := c.kyvernoClient.KyvernoV2alpha1().PolicySets().Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		_, err = controllerutils.UpdateStatus(ctx, latest, c.kyvernoClient.KyvernoV2alpha1().PolicySets(), func(policySet *kyvernov2alpha1.PolicySet) error {
+			now := metav1.Now()
+			policySet.Status.Digest = digest
+			policySet.Status.Policies = policies
+			policySet.Status.LastSyncTime = &now
+			policySet.Status.LastError = ""
+			if syncErr != nil {
+				policySet.Status.LastError = syncErr.Error()
+			}
+			return nil
+		})
+		return err
+	})
+}
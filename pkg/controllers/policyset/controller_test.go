@@ -0,0 +1,139 @@
+package policyset
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/static"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	"github.com/kyverno/kyverno/pkg/client/clientset/versioned/fake"
+	kyvernoinformers "github.com/kyverno/kyverno/pkg/client/informers/externalversions"
+	"github.com/kyverno/kyverno/pkg/images"
+	"github.com/kyverno/kyverno/pkg/registryclient"
+	"gotest.tools/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/workqueue"
+)
+
+type fakeVerifier struct {
+	err   error
+	calls []string
+}
+
+func (v *fakeVerifier) VerifySignature(_ context.Context, opts images.Options) (*images.Response, error) {
+	v.calls = append(v.calls, opts.ImageRef)
+	return &images.Response{}, v.err
+}
+
+func (v *fakeVerifier) FetchAttestations(context.Context, images.Options) (*images.Response, error) {
+	return nil, errors.New("not implemented")
+}
+
+// pushPolicies pushes an image containing the test policies to an in memory registry and returns its reference
+func pushPolicies(t *testing.T) string {
+	server := httptest.NewServer(registry.New())
+	t.Cleanup(server.Close)
+	ref, err := name.ParseReference(strings.TrimPrefix(server.URL, "http://") + "/acme/policies:v1")
+	assert.NilError(t, err)
+	img, err := mutate.Append(mutate.MediaType(empty.Image, types.OCIManifestSchema1),
+		mutate.Addendum{Layer: static.NewLayer([]byte(policies), policyLayerMediaType)},
+	)
+	assert.NilError(t, err)
+	assert.NilError(t, remote.Write(ref, img))
+	return ref.String()
+}
+
+func newTestController(t *testing.T, verifier *fakeVerifier, policySet *kyvernov2alpha1.PolicySet) *controller {
+	client := fake.NewSimpleClientset(policySet)
+	informers := kyvernoinformers.NewSharedInformerFactory(client, 0)
+	policySetInformer := informers.Kyverno().V2alpha1().PolicySets()
+	assert.NilError(t, policySetInformer.Informer().GetIndexer().Add(policySet))
+	rclient, err := registryclient.New()
+	assert.NilError(t, err)
+	return &controller{
+		kyvernoClient:   client,
+		rclient:         rclient,
+		verifier:        verifier,
+		policySetLister: policySetInformer.Lister(),
+		queue:           workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter()),
+	}
+}
+
+func newPolicySet(image string) *kyvernov2alpha1.PolicySet {
+	return &kyvernov2alpha1.PolicySet{
+		ObjectMeta: metav1.ObjectMeta{Name: "baseline", UID: "uid"},
+		Spec: kyvernov2alpha1.PolicySetSpec{
+			Image:    image,
+			Interval: &metav1.Duration{Duration: time.Hour},
+			Verify: kyvernov2alpha1.PolicySetVerification{
+				Keyless: &kyvernov1.KeylessAttestor{Subject: "https://github.com/acme/*", Issuer: "https://token.actions.githubusercontent.com"},
+			},
+		},
+	}
+}
+
+func Test_sync(t *testing.T) {
+	ctx := context.TODO()
+	image := pushPolicies(t)
+	verifier := &fakeVerifier{}
+	policySet := newPolicySet(image)
+	c := newTestController(t, verifier, policySet)
+	defer c.queue.ShutDown()
+
+	digest, keys, err := c.sync(ctx, logr.Discard(), policySet)
+	assert.NilError(t, err)
+	assert.Assert(t, strings.HasPrefix(digest, "sha256:"))
+	assert.DeepEqual(t, keys, []string{"require-labels", "test/require-env"})
+	// the resolved digest is verified, not the tag
+	assert.DeepEqual(t, verifier.calls, []string{strings.TrimSuffix(image, ":v1") + "@" + digest})
+	_, err = c.kyvernoClient.KyvernoV1().ClusterPolicies().Get(ctx, "require-labels", metav1.GetOptions{})
+	assert.NilError(t, err)
+
+	// policies in sync with the digest are not installed again
+	policySet.Status.Digest = digest
+	policySet.Status.Policies = keys
+	synced, syncedKeys, err := c.sync(ctx, logr.Discard(), policySet)
+	assert.NilError(t, err)
+	assert.Equal(t, synced, digest)
+	assert.DeepEqual(t, syncedKeys, keys)
+	assert.Equal(t, len(verifier.calls), 1)
+
+	// drifted policies are installed again
+	assert.NilError(t, c.kyvernoClient.KyvernoV1().ClusterPolicies().Delete(ctx, "require-labels", metav1.DeleteOptions{}))
+	_, _, err = c.sync(ctx, logr.Discard(), policySet)
+	assert.NilError(t, err)
+	assert.Equal(t, len(verifier.calls), 2)
+	_, err = c.kyvernoClient.KyvernoV1().ClusterPolicies().Get(ctx, "require-labels", metav1.GetOptions{})
+	assert.NilError(t, err)
+}
+
+func Test_reconcileInvalidSignature(t *testing.T) {
+	ctx := context.TODO()
+	verifier := &fakeVerifier{err: errors.New("invalid signature")}
+	c := newTestController(t, verifier, newPolicySet(pushPolicies(t)))
+	defer c.queue.ShutDown()
+
+	// sync errors are reported in the status and retried after the interval only
+	assert.NilError(t, c.reconcile(ctx, logr.Discard(), "baseline", "", "baseline"))
+	assert.Equal(t, c.queue.Len(), 0)
+	assert.Equal(t, c.queue.NumRequeues("baseline"), 0)
+	policySet, err := c.kyvernoClient.KyvernoV2alpha1().PolicySets().Get(ctx, "baseline", metav1.GetOptions{})
+	assert.NilError(t, err)
+	assert.ErrorContains(t, errors.New(policySet.Status.LastError), "invalid signature")
+	assert.Equal(t, policySet.Status.Digest, "")
+	policies, err := c.kyvernoClient.KyvernoV1().ClusterPolicies().List(ctx, metav1.ListOptions{})
+	assert.NilError(t, err)
+	assert.Equal(t, len(policies.Items), 0)
+}
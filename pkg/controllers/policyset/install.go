@@ -0,0 +1,146 @@
+package policyset
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kyverno/kyverno/api/kyverno"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	"github.com/kyverno/kyverno/pkg/client/clientset/versioned"
+	controllerutils "github.com/kyverno/kyverno/pkg/utils/controller"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// policyKey returns the name of a cluster policy or the namespace and name of a policy
+func policyKey(policy kyvernov1.PolicyInterface) string {
+	if policy.IsNamespaced() {
+		return policy.GetNamespace() + "/" + policy.GetName()
+	}
+	return policy.GetName()
+}
+
+func selector(policySet *kyvernov2alpha1.PolicySet) string {
+	return labels.SelectorFromSet(labels.Set{kyverno.LabelPolicySet: policySet.GetName()}).String()
+}
+
+// installed returns the policies installed from a policy set image with the given digest
+func installed(ctx context.Context, client versioned.Interface, policySet *kyvernov2alpha1.PolicySet, digest string) (sets.Set[string], error) {
+	options := metav1.ListOptions{LabelSelector: selector(policySet)}
+	cpols, err := client.KyvernoV1().ClusterPolicies().List(ctx, options)
+	if err != nil {
+		return nil, err
+	}
+	pols, err := client.KyvernoV1().Policies(metav1.NamespaceAll).List(ctx, options)
+	if err != nil {
+		return nil, err
+	}
+	keys := sets.New[string]()
+	for i := range cpols.Items {
+		if cpols.Items[i].GetAnnotations()[kyverno.AnnotationPolicySetDigest] == digest {
+			keys.Insert(policyKey(&cpols.Items[i]))
+		}
+	}
+	for i := range pols.Items {
+		if pols.Items[i].GetAnnotations()[kyverno.AnnotationPolicySetDigest] == digest {
+			keys.Insert(policyKey(&pols.Items[i]))
+		}
+	}
+	return keys, nil
+}
+
+// install creates or updates the policies of a policy set and deletes the policies it no longer contains,
+// it returns the sorted keys of the installed policies
+func install(ctx context.Context, client versioned.Interface, policySet *kyvernov2alpha1.PolicySet, digest string, policies []kyvernov1.PolicyInterface) ([]string, error) {
+	expected := sets.New[string]()
+	for _, policy := range policies {
+		if policy.IsNamespaced() && policy.GetNamespace() == "" {
+			return nil, fmt.Errorf("policy %s has no namespace", policy.GetName())
+		}
+		key := policyKey(policy)
+		if expected.Has(key) {
+			return nil, fmt.Errorf("policy %s is declared more than once", key)
+		}
+		expected.Insert(key)
+		controllerutils.SetLabel(policy, kyverno.LabelPolicySet, policySet.GetName())
+		controllerutils.SetAnnotation(policy, kyverno.AnnotationPolicySetDigest, digest)
+		controllerutils.SetOwner(policy, kyvernov2alpha1.SchemeGroupVersion.String(), "PolicySet", policySet.GetName(), policySet.GetUID())
+		var err error
+		switch policy := policy.(type) {
+		case *kyvernov1.ClusterPolicy:
+			err = installClusterPolicy(ctx, client, policySet, policy)
+		case *kyvernov1.Policy:
+			err = installPolicy(ctx, client, policySet, policy)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to install policy %s: %w", key, err)
+		}
+	}
+	if err := uninstall(ctx, client, policySet, expected); err != nil {
+		return nil, err
+	}
+	return sets.List(expected), nil
+}
+
+func installClusterPolicy(ctx context.Context, client versioned.Interface, policySet *kyvernov2alpha1.PolicySet, policy *kyvernov1.ClusterPolicy) error {
+	existing, err := client.KyvernoV1().ClusterPolicies().Get(ctx, policy.GetName(), metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			_, err = client.KyvernoV1().ClusterPolicies().Create(ctx, policy, metav1.CreateOptions{})
+		}
+		return err
+	}
+	if !controllerutils.CheckLabel(existing, kyverno.LabelPolicySet, policySet.GetName()) {
+		return fmt.Errorf("cluster policy already exists and is not managed by the policy set")
+	}
+	policy.SetResourceVersion(existing.GetResourceVersion())
+	_, err = client.KyvernoV1().ClusterPolicies().Update(ctx, policy, metav1.UpdateOptions{})
+	return err
+}
+
+func installPolicy(ctx context.Context, client versioned.Interface, policySet *kyvernov2alpha1.PolicySet, policy *kyvernov1.Policy) error {
+	existing, err := client.KyvernoV1().Policies(policy.GetNamespace()).Get(ctx, policy.GetName(), metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			_, err = client.KyvernoV1().Policies(policy.GetNamespace()).Create(ctx, policy, metav1.CreateOptions{})
+		}
+		return err
+	}
+	if !controllerutils.CheckLabel(existing, kyverno.LabelPolicySet, policySet.GetName()) {
+		return fmt.Errorf("policy already exists and is not managed by the policy set")
+	}
+	policy.SetResourceVersion(existing.GetResourceVersion())
+	_, err = client.KyvernoV1().Policies(policy.GetNamespace()).Update(ctx, policy, metav1.UpdateOptions{})
+	return err
+}
+
+// uninstall deletes the policies of a policy set that are not expected anymore
+func uninstall(ctx context.Context, client versioned.Interface, policySet *kyvernov2alpha1.PolicySet, expected sets.Set[string]) error {
+	options := metav1.ListOptions{LabelSelector: selector(policySet)}
+	cpols, err := client.KyvernoV1().ClusterPolicies().List(ctx, options)
+	if err != nil {
+		return err
+	}
+	for i := range cpols.Items {
+		if !expected.Has(policyKey(&cpols.Items[i])) {
+			if err := client.KyvernoV1().ClusterPolicies().Delete(ctx, cpols.Items[i].GetName(), metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+				return fmt.Errorf("failed to delete cluster policy %s: %w", cpols.Items[i].GetName(), err)
+			}
+		}
+	}
+	pols, err := client.KyvernoV1().Policies(metav1.NamespaceAll).List(ctx, options)
+	if err != nil {
+		return err
+	}
+	for i := range pols.Items {
+		if !expected.Has(policyKey(&pols.Items[i])) {
+			if err := client.KyvernoV1().Policies(pols.Items[i].GetNamespace()).Delete(ctx, pols.Items[i].GetName(), metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+				return fmt.Errorf("failed to delete policy %s: %w", policyKey(&pols.Items[i]), err)
+			}
+		}
+	}
+	return nil
+}
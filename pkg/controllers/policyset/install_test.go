@@ -0,0 +1,75 @@
+package policyset
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kyverno/kyverno/api/kyverno"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	"github.com/kyverno/kyverno/pkg/client/clientset/versioned/fake"
+	"gotest.tools/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func newClusterPolicy(name string) kyvernov1.PolicyInterface {
+	return &kyvernov1.ClusterPolicy{ObjectMeta: metav1.ObjectMeta{Name: name}}
+}
+
+func newPolicy(namespace, name string) kyvernov1.PolicyInterface {
+	return &kyvernov1.Policy{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name}}
+}
+
+func Test_install(t *testing.T) {
+	ctx := context.TODO()
+	policySet := &kyvernov2alpha1.PolicySet{ObjectMeta: metav1.ObjectMeta{Name: "baseline", UID: "uid"}}
+	client := fake.NewSimpleClientset(
+		// not managed by the policy set
+		&kyvernov1.ClusterPolicy{ObjectMeta: metav1.ObjectMeta{Name: "unmanaged"}},
+	)
+
+	keys, err := install(ctx, client, policySet, "sha256:1", []kyvernov1.PolicyInterface{
+		newClusterPolicy("require-labels"),
+		newClusterPolicy("disallow-latest"),
+		newPolicy("test", "require-env"),
+	})
+	assert.NilError(t, err)
+	assert.DeepEqual(t, keys, []string{"disallow-latest", "require-labels", "test/require-env"})
+	cpol, err := client.KyvernoV1().ClusterPolicies().Get(ctx, "require-labels", metav1.GetOptions{})
+	assert.NilError(t, err)
+	assert.Equal(t, cpol.GetLabels()[kyverno.LabelPolicySet], "baseline")
+	assert.Equal(t, cpol.GetAnnotations()[kyverno.AnnotationPolicySetDigest], "sha256:1")
+	assert.Equal(t, cpol.GetOwnerReferences()[0].Kind, "PolicySet")
+
+	current, err := installed(ctx, client, policySet, "sha256:1")
+	assert.NilError(t, err)
+	assert.Equal(t, current.Len(), 3)
+
+	// a new digest updates the policies and deletes the ones removed from the image
+	keys, err = install(ctx, client, policySet, "sha256:2", []kyvernov1.PolicyInterface{
+		newClusterPolicy("require-labels"),
+	})
+	assert.NilError(t, err)
+	assert.DeepEqual(t, keys, []string{"require-labels"})
+	cpol, err = client.KyvernoV1().ClusterPolicies().Get(ctx, "require-labels", metav1.GetOptions{})
+	assert.NilError(t, err)
+	assert.Equal(t, cpol.GetAnnotations()[kyverno.AnnotationPolicySetDigest], "sha256:2")
+	_, err = client.KyvernoV1().ClusterPolicies().Get(ctx, "disallow-latest", metav1.GetOptions{})
+	assert.Assert(t, err != nil)
+	_, err = client.KyvernoV1().Policies("test").Get(ctx, "require-env", metav1.GetOptions{})
+	assert.Assert(t, err != nil)
+	current, err = installed(ctx, client, policySet, "sha256:1")
+	assert.NilError(t, err)
+	assert.Equal(t, current.Len(), 0)
+
+	// policies not managed by the policy set are never overwritten
+	_, err = install(ctx, client, policySet, "sha256:3", []kyvernov1.PolicyInterface{
+		newClusterPolicy("unmanaged"),
+	})
+	assert.ErrorContains(t, err, "not managed by the policy set")
+
+	_, err = install(ctx, client, policySet, "sha256:3", []kyvernov1.PolicyInterface{
+		newPolicy("", "require-env"),
+	})
+	assert.ErrorContains(t, err, "has no namespace")
+}
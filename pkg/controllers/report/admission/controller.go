@@ -11,13 +11,20 @@ import (
 	"github.com/kyverno/kyverno/pkg/clients/dclient"
 	"github.com/kyverno/kyverno/pkg/controllers"
 	"github.com/kyverno/kyverno/pkg/controllers/report/utils"
+	"github.com/kyverno/kyverno/pkg/exporter"
+	"github.com/kyverno/kyverno/pkg/metrics"
 	controllerutils "github.com/kyverno/kyverno/pkg/utils/controller"
 	reportutils "github.com/kyverno/kyverno/pkg/utils/report"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
 	"go.uber.org/multierr"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/selection"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/dynamic"
@@ -31,7 +38,13 @@ const (
 	Workers        = 10
 	ControllerName = "admission-report-controller"
 	maxRetries     = 10
-	deletionGrace  = time.Minute * 2
+	// DeletionGrace is the default delay after which intermediate reports that couldn't be aggregated are deleted
+	DeletionGrace = time.Minute * 2
+	// BatchSize is the default number of reports fetched per list call
+	BatchSize    = 1000
+	requeueDelay = time.Second * 15
+	// fetchThreshold is the number of reports under which reports are fetched individually instead of being listed
+	fetchThreshold = 5
 )
 
 type controller struct {
@@ -45,40 +58,153 @@ type controller struct {
 
 	// queue
 	queue workqueue.RateLimitingInterface
+
+	// config
+	aggregationDelay time.Duration
+	deletionGrace    time.Duration
+	gcInterval       time.Duration
+	batchSize        int
+	exporter         exporter.Exporter
+
+	// metrics
+	backlogMetric metric.Int64ObservableGauge
 }
 
 func NewController(
 	client versioned.Interface,
 	dclient dclient.Interface,
 	metadataFactory metadatainformers.SharedInformerFactory,
+	aggregationDelay time.Duration,
+	deletionGrace time.Duration,
+	gcInterval time.Duration,
+	batchSize int,
+	resultsExporter exporter.Exporter,
 ) controllers.Controller {
 	admrInformer := metadataFactory.ForResource(kyvernov1alpha2.SchemeGroupVersion.WithResource("admissionreports"))
 	cadmrInformer := metadataFactory.ForResource(kyvernov1alpha2.SchemeGroupVersion.WithResource("clusteradmissionreports"))
 	queue := workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), ControllerName)
 	c := controller{
-		client:      client,
-		dclient:     dclient,
-		admrLister:  admrInformer.Lister(),
-		cadmrLister: cadmrInformer.Lister(),
-		queue:       queue,
+		client:           client,
+		dclient:          dclient,
+		admrLister:       admrInformer.Lister(),
+		cadmrLister:      cadmrInformer.Lister(),
+		queue:            queue,
+		aggregationDelay: aggregationDelay,
+		deletionGrace:    deletionGrace,
+		gcInterval:       gcInterval,
+		batchSize:        batchSize,
+		exporter:         resultsExporter,
+	}
+	enqueue := func(obj metav1.Object) {
+		queue.AddAfter(cache.ExplicitKey(reportutils.GetResourceUid(obj)), c.aggregationDelay)
 	}
 	controllerutils.AddEventHandlersT(
 		admrInformer.Informer(),
-		func(obj metav1.Object) { queue.Add(cache.ExplicitKey(reportutils.GetResourceUid(obj))) },
-		func(old, obj metav1.Object) { queue.Add(cache.ExplicitKey(reportutils.GetResourceUid(old))) },
-		func(obj metav1.Object) { queue.Add(cache.ExplicitKey(reportutils.GetResourceUid(obj))) },
+		func(obj metav1.Object) { enqueue(obj) },
+		func(old, obj metav1.Object) { enqueue(old) },
+		func(obj metav1.Object) { enqueue(obj) },
 	)
 	controllerutils.AddEventHandlersT(
 		cadmrInformer.Informer(),
-		func(obj metav1.Object) { queue.Add(cache.ExplicitKey(reportutils.GetResourceUid(obj))) },
-		func(old, obj metav1.Object) { queue.Add(cache.ExplicitKey(reportutils.GetResourceUid(old))) },
-		func(obj metav1.Object) { queue.Add(cache.ExplicitKey(reportutils.GetResourceUid(obj))) },
+		func(obj metav1.Object) { enqueue(obj) },
+		func(old, obj metav1.Object) { enqueue(old) },
+		func(obj metav1.Object) { enqueue(obj) },
+	)
+	meter := otel.GetMeterProvider().Meter(metrics.MeterName)
+	backlogMetric, err := meter.Int64ObservableGauge(
+		"kyverno_admission_reports_backlog",
+		metric.WithDescription("can be used to track the number of intermediate admission reports waiting to be aggregated"),
 	)
+	if err != nil {
+		logger.Error(err, "Failed to create instrument, kyverno_admission_reports_backlog")
+	} else {
+		c.backlogMetric = backlogMetric
+		if _, err := meter.RegisterCallback(c.reportBacklog, c.backlogMetric); err != nil {
+			logger.Error(err, "Failed to register callback")
+		}
+	}
 	return &c
 }
 
 func (c *controller) Run(ctx context.Context, workers int) {
-	controllerutils.Run(ctx, logger, ControllerName, time.Second, c.queue, workers, maxRetries, c.reconcile)
+	controllerutils.Run(ctx, logger, ControllerName, time.Second, c.queue, workers, maxRetries, c.reconcile, c.collectGarbage)
+}
+
+// intermediateReportsSelector selects reports that were not aggregated yet
+func intermediateReportsSelector() (labels.Selector, error) {
+	requirement, err := labels.NewRequirement(reportutils.LabelAggregatedReport, selection.DoesNotExist, nil)
+	if err != nil {
+		return nil, err
+	}
+	return labels.NewSelector().Add(*requirement), nil
+}
+
+func (c *controller) getIntermediateReports() ([]metav1.Object, []metav1.Object, error) {
+	selector, err := intermediateReportsSelector()
+	if err != nil {
+		return nil, nil, err
+	}
+	admrs, err := c.admrLister.List(selector)
+	if err != nil {
+		return nil, nil, err
+	}
+	cadmrs, err := c.cadmrLister.List(selector)
+	if err != nil {
+		return nil, nil, err
+	}
+	var namespaced, clustered []metav1.Object
+	for _, admr := range admrs {
+		namespaced = append(namespaced, admr.(metav1.Object))
+	}
+	for _, cadmr := range cadmrs {
+		clustered = append(clustered, cadmr.(metav1.Object))
+	}
+	return namespaced, clustered, nil
+}
+
+func (c *controller) reportBacklog(ctx context.Context, observer metric.Observer) error {
+	namespaced, clustered, err := c.getIntermediateReports()
+	if err != nil {
+		logger.Error(err, "failed to list intermediate admission reports")
+		return err
+	}
+	observer.ObserveInt64(c.backlogMetric, int64(len(namespaced)), metric.WithAttributes(attribute.String("report_kind", "AdmissionReport")))
+	observer.ObserveInt64(c.backlogMetric, int64(len(clustered)), metric.WithAttributes(attribute.String("report_kind", "ClusterAdmissionReport")))
+	return nil
+}
+
+// collectGarbage periodically enqueues intermediate reports older than the deletion grace period,
+// this ensures orphaned reports are eventually aggregated or deleted
+func (c *controller) collectGarbage(ctx context.Context, logger logr.Logger) {
+	if c.gcInterval <= 0 {
+		return
+	}
+	ticker := time.NewTicker(c.gcInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			namespaced, clustered, err := c.getIntermediateReports()
+			if err != nil {
+				logger.Error(err, "failed to list intermediate admission reports")
+				continue
+			}
+			uids := sets.New[types.UID]()
+			for _, report := range append(namespaced, clustered...) {
+				if report.GetCreationTimestamp().Add(c.deletionGrace).Before(time.Now()) {
+					uids.Insert(reportutils.GetResourceUid(report))
+				}
+			}
+			if len(uids) > 0 {
+				logger.V(2).Info("enqueueing outdated intermediate admission reports", "count", len(uids))
+			}
+			for uid := range uids {
+				c.queue.Add(cache.ExplicitKey(uid))
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
 }
 
 func (c *controller) getReports(uid types.UID) ([]metav1.Object, error) {
@@ -118,8 +244,7 @@ func (c *controller) fetchReports(ctx context.Context, uid types.UID) ([]kyverno
 	if reports, err := c.getReports(uid); err != nil {
 		return nil, err
 	} else {
-		// TODO: threshold here ?
-		if len(reports) < 5 {
+		if len(reports) < fetchThreshold {
 			for _, report := range reports {
 				if result, err := c.fetchReport(ctx, report.GetNamespace(), report.GetName()); err != nil {
 					return nil, err
@@ -137,21 +262,37 @@ func (c *controller) fetchReports(ctx context.Context, uid types.UID) ([]kyverno
 		return nil, err
 	} else {
 		for n := range ns {
-			if n == "" {
-				cadmrs, err := c.client.KyvernoV1alpha2().ClusterAdmissionReports().List(ctx, metav1.ListOptions{LabelSelector: selector.String()})
-				if err != nil {
-					return nil, err
-				}
-				for i := range cadmrs.Items {
-					results = append(results, &cadmrs.Items[i])
-				}
-			} else {
-				admrs, err := c.client.KyvernoV1alpha2().AdmissionReports(n).List(ctx, metav1.ListOptions{LabelSelector: selector.String()})
-				if err != nil {
-					return nil, err
+			next := ""
+			for {
+				if n == "" {
+					cadmrs, err := c.client.KyvernoV1alpha2().ClusterAdmissionReports().List(ctx, metav1.ListOptions{
+						LabelSelector: selector.String(),
+						Limit:         int64(c.batchSize),
+						Continue:      next,
+					})
+					if err != nil {
+						return nil, err
+					}
+					for i := range cadmrs.Items {
+						results = append(results, &cadmrs.Items[i])
+					}
+					next = cadmrs.Continue
+				} else {
+					admrs, err := c.client.KyvernoV1alpha2().AdmissionReports(n).List(ctx, metav1.ListOptions{
+						LabelSelector: selector.String(),
+						Limit:         int64(c.batchSize),
+						Continue:      next,
+					})
+					if err != nil {
+						return nil, err
+					}
+					for i := range admrs.Items {
+						results = append(results, &admrs.Items[i])
+					}
+					next = admrs.Continue
 				}
-				for i := range admrs.Items {
-					results = append(results, &admrs.Items[i])
+				if next == "" {
+					break
 				}
 			}
 		}
@@ -197,8 +338,16 @@ func (c *controller) aggregateReports(ctx context.Context, uid types.UID) (kyver
 			break
 		}
 	}
-	// if we dont, try to fetch the associated resource
-	if aggregated == nil || len(aggregated.GetOwnerReferences()) == 0 {
+	// payloads are not stored in the cluster, their aggregated report is built from the report labels
+	payload := reportutils.IsPayloadReport(reports[0])
+	if payload {
+		if aggregated == nil {
+			reference := reportutils.PayloadReference(reports[0])
+			aggregated = reportutils.NewPayloadReport(reference.Kind, reference.Namespace, reference.Name, string(uid))
+			controllerutils.SetLabel(aggregated, reportutils.LabelAggregatedReport, string(uid))
+		}
+	} else if aggregated == nil || len(aggregated.GetOwnerReferences()) == 0 {
+		// if we dont, try to fetch the associated resource
 		var res *unstructured.Unstructured
 		var gvr schema.GroupVersionResource
 		for _, report := range reports {
@@ -228,19 +377,36 @@ func (c *controller) aggregateReports(ctx context.Context, uid types.UID) (kyver
 	}
 	// if we have an aggregated report available, compute results
 	var errs []error
-	if aggregated != nil && len(aggregated.GetOwnerReferences()) != 0 {
-		owner := aggregated.GetOwnerReferences()[0]
-		resource := corev1.ObjectReference{
-			APIVersion: owner.APIVersion,
-			Kind:       owner.Kind,
-			Namespace:  aggregated.GetNamespace(),
-			Name:       owner.Name,
-			UID:        owner.UID,
+	if aggregated != nil && (payload || len(aggregated.GetOwnerReferences()) != 0) {
+		var resource corev1.ObjectReference
+		if payload {
+			resource = reportutils.PayloadReference(aggregated)
+		} else {
+			owner := aggregated.GetOwnerReferences()[0]
+			resource = corev1.ObjectReference{
+				APIVersion: owner.APIVersion,
+				Kind:       owner.Kind,
+				Namespace:  aggregated.GetNamespace(),
+				Name:       owner.Name,
+				UID:        owner.UID,
+			}
 		}
 		merged := map[string]policyreportv1alpha2.PolicyReportResult{}
 		for _, report := range reports {
 			mergeReports(resource, merged, report)
 		}
+		// export results of intermediate reports, they are deleted once aggregated
+		if c.exporter != nil {
+			exported := map[string]policyreportv1alpha2.PolicyReportResult{}
+			for _, report := range reports {
+				if report != aggregated {
+					mergeReports(resource, exported, report)
+				}
+			}
+			for _, result := range exported {
+				c.exporter.Export(result)
+			}
+		}
 		var results []policyreportv1alpha2.PolicyReportResult
 		for _, result := range merged {
 			results = append(results, result)
@@ -308,11 +474,15 @@ func (c *controller) reconcile(ctx context.Context, logger logr.Logger, key, _,
 	} else {
 		// we didn't create an aggregated report, still we had some individual reports, let's requeue
 		if reports != nil {
-			c.queue.AddAfter(cache.ExplicitKey(uid), time.Second*15)
+			delay := requeueDelay
+			if c.aggregationDelay > delay {
+				delay = c.aggregationDelay
+			}
+			c.queue.AddAfter(cache.ExplicitKey(uid), delay)
 		}
 		// delete outdated reports
 		for _, report := range reports {
-			if report.GetCreationTimestamp().Add(deletionGrace).Before(time.Now()) {
+			if report.GetCreationTimestamp().Add(c.deletionGrace).Before(time.Now()) {
 				if err := c.deleteReport(ctx, report.GetNamespace(), report.GetName()); err != nil {
 					errs = append(errs, err)
 				}
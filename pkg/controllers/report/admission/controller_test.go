@@ -0,0 +1,166 @@
+package admission
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	kyvernov1alpha2 "github.com/kyverno/kyverno/api/kyverno/v1alpha2"
+	policyreportv1alpha2 "github.com/kyverno/kyverno/api/policyreport/v1alpha2"
+	"github.com/kyverno/kyverno/pkg/client/clientset/versioned/fake"
+	"github.com/kyverno/kyverno/pkg/clients/dclient"
+	controllerutils "github.com/kyverno/kyverno/pkg/utils/controller"
+	reportutils "github.com/kyverno/kyverno/pkg/utils/report"
+	"gotest.tools/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+)
+
+var podsGVR = schema.GroupVersionResource{Version: "v1", Resource: "pods"}
+
+func newReport(name string, uid types.UID, age time.Duration, aggregated bool) *kyvernov1alpha2.AdmissionReport {
+	report := &kyvernov1alpha2.AdmissionReport{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              name,
+			Namespace:         "test",
+			CreationTimestamp: metav1.NewTime(time.Now().Add(-age)),
+		},
+	}
+	reportutils.SetResourceUid(report, uid)
+	reportutils.SetResourceGVR(report, podsGVR)
+	reportutils.SetResourceNamespaceAndName(report, "test", "pod")
+	if aggregated {
+		controllerutils.SetLabel(report, reportutils.LabelAggregatedReport, string(uid))
+	}
+	return report
+}
+
+func newTestController(t *testing.T, reports ...*kyvernov1alpha2.AdmissionReport) *controller {
+	admrs := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	var objs []runtime.Object
+	for _, report := range reports {
+		assert.NilError(t, admrs.Add(&metav1.PartialObjectMetadata{ObjectMeta: report.ObjectMeta}))
+		objs = append(objs, report)
+	}
+	client, err := dclient.NewFakeClient(runtime.NewScheme(), map[schema.GroupVersionResource]string{podsGVR: "PodList"})
+	assert.NilError(t, err)
+	return &controller{
+		client:        fake.NewSimpleClientset(objs...),
+		dclient:       client,
+		admrLister:    cache.NewGenericLister(admrs, kyvernov1alpha2.Resource("admissionreports")),
+		cadmrLister:   cache.NewGenericLister(cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{}), kyvernov1alpha2.Resource("clusteradmissionreports")),
+		queue:         workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter()),
+		deletionGrace: DeletionGrace,
+		gcInterval:    10 * time.Millisecond,
+		batchSize:     BatchSize,
+	}
+}
+
+func Test_intermediateReportsSelector(t *testing.T) {
+	selector, err := intermediateReportsSelector()
+	assert.NilError(t, err)
+	assert.Assert(t, selector.Matches(labels.Set(newReport("a", "uid", 0, false).GetLabels())))
+	assert.Assert(t, !selector.Matches(labels.Set(newReport("b", "uid", 0, true).GetLabels())))
+}
+
+func Test_collectGarbage(t *testing.T) {
+	c := newTestController(t,
+		newReport("outdated", "outdated-uid", time.Hour, false),
+		newReport("recent", "recent-uid", 0, false),
+		newReport("aggregated", "aggregated-uid", time.Hour, true),
+	)
+	defer c.queue.ShutDown()
+	ctx, cancel := context.WithCancel(context.TODO())
+	defer cancel()
+	go c.collectGarbage(ctx, logger)
+	assert.NilError(t, wait.PollImmediate(10*time.Millisecond, 5*time.Second, func() (bool, error) {
+		return c.queue.Len() > 0, nil
+	}))
+	key, _ := c.queue.Get()
+	assert.Equal(t, key, cache.ExplicitKey("outdated-uid"))
+	assert.Equal(t, c.queue.Len(), 0)
+}
+
+func Test_collectGarbageDisabled(t *testing.T) {
+	c := newTestController(t, newReport("outdated", "outdated-uid", time.Hour, false))
+	defer c.queue.ShutDown()
+	c.gcInterval = 0
+	// returns immediately when garbage collection is disabled
+	c.collectGarbage(context.TODO(), logger)
+	assert.Equal(t, c.queue.Len(), 0)
+}
+
+func Test_fetchReports(t *testing.T) {
+	var reports []*kyvernov1alpha2.AdmissionReport
+	for _, name := range []string{"a", "b", "c", "d", "e", "f"} {
+		reports = append(reports, newReport(name, "uid", 0, false))
+	}
+	reports = append(reports, newReport("other", "other-uid", 0, false))
+	c := newTestController(t, reports...)
+	defer c.queue.ShutDown()
+	c.batchSize = 2
+	// above the fetch threshold reports are listed
+	results, err := c.fetchReports(context.TODO(), "uid")
+	assert.NilError(t, err)
+	assert.Equal(t, len(results), 6)
+	// under the fetch threshold reports are fetched individually
+	results, err = c.fetchReports(context.TODO(), "other-uid")
+	assert.NilError(t, err)
+	assert.Equal(t, len(results), 1)
+	assert.Equal(t, results[0].GetName(), "other")
+}
+
+func Test_reconcileDeletesOutdatedReports(t *testing.T) {
+	c := newTestController(t,
+		newReport("outdated", "uid", time.Hour, false),
+		newReport("recent", "uid", 0, false),
+	)
+	defer c.queue.ShutDown()
+	// the resource doesn't exist, reports can't be aggregated
+	assert.NilError(t, c.reconcile(context.TODO(), logger, "uid", "", ""))
+	list, err := c.client.KyvernoV1alpha2().AdmissionReports("test").List(context.TODO(), metav1.ListOptions{})
+	assert.NilError(t, err)
+	assert.Equal(t, len(list.Items), 1)
+	assert.Equal(t, list.Items[0].GetName(), "recent")
+}
+
+func Test_reconcileAggregatesPayloadReports(t *testing.T) {
+	uid := reportutils.PayloadUid("CheckRequest", "test", "frontend")
+	var reports []*kyvernov1alpha2.AdmissionReport
+	for i, name := range []string{"a", "b"} {
+		report := reportutils.NewPayloadReport("CheckRequest", "test", "frontend", name).(*kyvernov1alpha2.AdmissionReport)
+		report.SetResults([]policyreportv1alpha2.PolicyReportResult{{
+			Policy:    "policy",
+			Rule:      "rule",
+			Result:    policyreportv1alpha2.StatusFail,
+			Timestamp: metav1.Timestamp{Seconds: int64(i)},
+		}})
+		reports = append(reports, report)
+	}
+	c := newTestController(t, reports...)
+	defer c.queue.ShutDown()
+	// payloads are not stored in the cluster, reports are aggregated without fetching them
+	assert.NilError(t, c.reconcile(context.TODO(), logger, string(uid), "", ""))
+	list, err := c.client.KyvernoV1alpha2().AdmissionReports("test").List(context.TODO(), metav1.ListOptions{})
+	assert.NilError(t, err)
+	assert.Equal(t, len(list.Items), 1)
+	aggregated := &list.Items[0]
+	assert.Equal(t, aggregated.GetName(), string(uid))
+	assert.Equal(t, controllerutils.GetLabel(aggregated, reportutils.LabelAggregatedReport), string(uid))
+	assert.Equal(t, len(aggregated.GetResults()), 1)
+	assert.Equal(t, aggregated.GetResults()[0].Timestamp.Seconds, int64(1))
+	assert.DeepEqual(t, aggregated.GetResults()[0].Resources, []corev1.ObjectReference{{
+		APIVersion: "json.kyverno.io/v1alpha1",
+		Kind:       "CheckRequest",
+		Namespace:  "test",
+		Name:       "frontend",
+		UID:        uid,
+	}})
+}
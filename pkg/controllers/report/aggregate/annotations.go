@@ -0,0 +1,143 @@
+package aggregate
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	"github.com/kyverno/kyverno/api/kyverno"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	policyreportv1alpha2 "github.com/kyverno/kyverno/api/policyreport/v1alpha2"
+	"github.com/kyverno/kyverno/pkg/autogen"
+	jsonutils "github.com/kyverno/kyverno/pkg/utils/json"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// annotatedResource is a resource carrying the compliance summary annotation
+type annotatedResource struct {
+	ref   corev1.ObjectReference
+	value string
+}
+
+// annotatedRules returns the rules of the policy adding their failures to the compliance summary annotation,
+// only policies in audit mode are considered
+func annotatedRules(policy kyvernov1.PolicyInterface) sets.Set[string] {
+	rules := sets.New[string]()
+	if !policy.GetSpec().ValidationFailureAction.Audit() {
+		return rules
+	}
+	for _, rule := range autogen.ComputeRules(policy) {
+		if rule.HasValidate() && rule.Validation.AnnotateResource {
+			rules.Insert(rule.Name)
+		}
+	}
+	return rules
+}
+
+// statusAnnotations computes the compliance summary annotation of the resources evaluated by annotated rules
+func statusAnnotations(policyMap map[string]policyMapEntry, results []policyreportv1alpha2.PolicyReportResult) map[types.UID]annotatedResource {
+	failures := map[types.UID]int{}
+	refs := map[types.UID]corev1.ObjectReference{}
+	for _, result := range results {
+		if len(result.Resources) != 1 {
+			continue
+		}
+		entry := policyMap[result.Policy]
+		if entry.annotatedRules == nil || !entry.annotatedRules.Has(result.Rule) {
+			continue
+		}
+		ref := result.Resources[0]
+		refs[ref.UID] = ref
+		if result.Result == policyreportv1alpha2.StatusFail {
+			failures[ref.UID]++
+		}
+	}
+	annotations := make(map[types.UID]annotatedResource, len(refs))
+	for uid, ref := range refs {
+		annotations[uid] = annotatedResource{
+			ref:   ref,
+			value: fmt.Sprintf("%d failing", failures[uid]),
+		}
+	}
+	return annotations
+}
+
+// statusPatch returns the json patch setting the compliance summary annotation to the given value,
+// the annotation is removed when the value is empty and nil is returned when there is nothing to change
+func statusPatch(annotations map[string]string, value string) ([]byte, error) {
+	current, exists := annotations[kyverno.AnnotationPolicyStatus]
+	var patch jsonutils.PatchOperation
+	switch {
+	case value == "" && !exists, exists && current == value:
+		return nil, nil
+	case value == "":
+		patch = jsonutils.NewPatchOperation("/metadata/annotations/policies.kyverno.io~1status", "remove", nil)
+	case annotations == nil:
+		patch = jsonutils.NewPatchOperation("/metadata/annotations", "add", map[string]string{kyverno.AnnotationPolicyStatus: value})
+	default:
+		patch = jsonutils.NewPatchOperation("/metadata/annotations/policies.kyverno.io~1status", "add", value)
+	}
+	return patch.ToPatchBytes()
+}
+
+// annotateResources patches the compliance summary annotation onto the resources of the namespace, the annotation
+// is removed from resources no longer evaluated by annotated rules
+func (c *controller) annotateResources(ctx context.Context, logger logr.Logger, namespace string, policyMap map[string]policyMapEntry, results []policyreportv1alpha2.PolicyReportResult) {
+	desired := statusAnnotations(policyMap, results)
+	c.annotationsLock.Lock()
+	previous := c.annotations[namespace]
+	c.annotationsLock.Unlock()
+	applied := make(map[types.UID]annotatedResource, len(desired))
+	for uid, resource := range previous {
+		if _, ok := desired[uid]; !ok {
+			if err := c.patchStatusAnnotation(ctx, resource.ref, ""); err != nil {
+				logger.Error(err, "failed to remove compliance annotation", "kind", resource.ref.Kind, "namespace", resource.ref.Namespace, "name", resource.ref.Name)
+				applied[uid] = resource
+			}
+		}
+	}
+	for uid, resource := range desired {
+		if last, ok := previous[uid]; ok && last.value == resource.value {
+			applied[uid] = resource
+			continue
+		}
+		if err := c.patchStatusAnnotation(ctx, resource.ref, resource.value); err != nil {
+			logger.Error(err, "failed to patch compliance annotation", "kind", resource.ref.Kind, "namespace", resource.ref.Namespace, "name", resource.ref.Name)
+			continue
+		}
+		applied[uid] = resource
+	}
+	c.annotationsLock.Lock()
+	defer c.annotationsLock.Unlock()
+	if len(applied) == 0 {
+		delete(c.annotations, namespace)
+	} else {
+		c.annotations[namespace] = applied
+	}
+}
+
+func (c *controller) patchStatusAnnotation(ctx context.Context, ref corev1.ObjectReference, value string) error {
+	obj, err := c.dclient.GetResource(ctx, ref.APIVersion, ref.Kind, ref.Namespace, ref.Name)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	// the resource was recreated with the same name
+	if obj.GetUID() != ref.UID {
+		return nil
+	}
+	patch, err := statusPatch(obj.GetAnnotations(), value)
+	if err != nil || patch == nil {
+		return err
+	}
+	_, err = c.dclient.PatchResource(ctx, ref.APIVersion, ref.Kind, ref.Namespace, ref.Name, patch)
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+	return err
+}
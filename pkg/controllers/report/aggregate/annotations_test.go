@@ -0,0 +1,83 @@
+package aggregate
+
+import (
+	"testing"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	policyreportv1alpha2 "github.com/kyverno/kyverno/api/policyreport/v1alpha2"
+	"gotest.tools/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+func Test_annotatedRules(t *testing.T) {
+	policy := &kyvernov1.ClusterPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "require-labels"},
+		Spec: kyvernov1.Spec{
+			ValidationFailureAction: kyvernov1.Audit,
+			Rules: []kyvernov1.Rule{{
+				Name:       "check-team",
+				Validation: kyvernov1.Validation{Message: "team is required", AnnotateResource: true},
+			}, {
+				Name:       "check-env",
+				Validation: kyvernov1.Validation{Message: "env is required"},
+			}},
+		},
+	}
+	assert.DeepEqual(t, sets.List(annotatedRules(policy)), []string{"check-team"})
+	policy.Spec.ValidationFailureAction = kyvernov1.Enforce
+	assert.Equal(t, annotatedRules(policy).Len(), 0)
+}
+
+func Test_statusAnnotations(t *testing.T) {
+	policyMap := map[string]policyMapEntry{
+		"require-labels": {annotatedRules: sets.New("check-team", "check-env")},
+	}
+	results := []policyreportv1alpha2.PolicyReportResult{
+		newResult("check-team", "a", policyreportv1alpha2.StatusFail, 100),
+		newResult("check-env", "a", policyreportv1alpha2.StatusFail, 100),
+		newResult("check-team", "b", policyreportv1alpha2.StatusPass, 100),
+		newResult("check-other", "c", policyreportv1alpha2.StatusFail, 100),
+	}
+	annotations := statusAnnotations(policyMap, results)
+	assert.Equal(t, len(annotations), 2)
+	assert.Equal(t, annotations["a"].value, "2 failing")
+	assert.Equal(t, annotations["a"].ref.UID, types.UID("a"))
+	assert.Equal(t, annotations["b"].value, "0 failing")
+}
+
+func Test_statusPatch(t *testing.T) {
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		value       string
+		want        string
+	}{{
+		name:  "no annotations",
+		value: "1 failing",
+		want:  `[{"path":"/metadata/annotations","op":"add","value":{"policies.kyverno.io/status":"1 failing"}}]`,
+	}, {
+		name:        "other annotations",
+		annotations: map[string]string{"foo": "bar"},
+		value:       "1 failing",
+		want:        `[{"path":"/metadata/annotations/policies.kyverno.io~1status","op":"add","value":"1 failing"}]`,
+	}, {
+		name:        "unchanged",
+		annotations: map[string]string{"policies.kyverno.io/status": "1 failing"},
+		value:       "1 failing",
+	}, {
+		name:        "remove",
+		annotations: map[string]string{"policies.kyverno.io/status": "1 failing"},
+		want:        `[{"path":"/metadata/annotations/policies.kyverno.io~1status","op":"remove"}]`,
+	}, {
+		name: "remove missing",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			patch, err := statusPatch(tt.annotations, tt.value)
+			assert.NilError(t, err)
+			assert.Equal(t, string(patch), tt.want)
+		})
+	}
+}
@@ -3,6 +3,7 @@ package aggregate
 import (
 	"context"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/go-logr/logr"
@@ -13,14 +14,19 @@ import (
 	"github.com/kyverno/kyverno/pkg/client/clientset/versioned"
 	kyvernov1informers "github.com/kyverno/kyverno/pkg/client/informers/externalversions/kyverno/v1"
 	kyvernov1listers "github.com/kyverno/kyverno/pkg/client/listers/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/clients/dclient"
 	"github.com/kyverno/kyverno/pkg/controllers"
 	"github.com/kyverno/kyverno/pkg/controllers/report/resource"
+	"github.com/kyverno/kyverno/pkg/metrics"
 	controllerutils "github.com/kyverno/kyverno/pkg/utils/controller"
 	datautils "github.com/kyverno/kyverno/pkg/utils/data"
 	reportutils "github.com/kyverno/kyverno/pkg/utils/report"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/metric"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/sets"
 	metadatainformers "k8s.io/client-go/metadata/metadatainformer"
 	"k8s.io/client-go/tools/cache"
@@ -37,11 +43,14 @@ const (
 	maxRetries     = 10
 	mergeLimit     = 1000
 	enqueueDelay   = 30 * time.Second
+	// summaryReportName is the name of the report holding the summary of all reports in a namespace
+	summaryReportName = "kyverno-summary"
 )
 
 type controller struct {
 	// clients
-	client versioned.Interface
+	client  versioned.Interface
+	dclient dclient.Interface
 
 	// listers
 	polLister      kyvernov1listers.PolicyLister
@@ -57,12 +66,26 @@ type controller struct {
 	// cache
 	metadataCache resource.MetadataCache
 
-	chunkSize int
+	chunkSize     int
+	maxSize       int
+	summaryReport bool
+
+	// metrics
+	violationAgeMetric metric.Float64ObservableGauge
+	// violations holds the first seen time of the oldest violation of each policy rule, per namespace
+	violations map[string]map[policyRule]time.Time
+	lock       sync.Mutex
+
+	// annotations holds the compliance summary annotations applied to resources, per namespace
+	annotations     map[string]map[types.UID]annotatedResource
+	annotationsLock sync.Mutex
 }
 
 type policyMapEntry struct {
 	policy kyvernov1.PolicyInterface
 	rules  sets.Set[string]
+	// annotatedRules are the rules adding their failures to the compliance summary annotation of resources
+	annotatedRules sets.Set[string]
 }
 
 func keyFunc(obj metav1.Object) cache.ExplicitKey {
@@ -71,11 +94,14 @@ func keyFunc(obj metav1.Object) cache.ExplicitKey {
 
 func NewController(
 	client versioned.Interface,
+	dclient dclient.Interface,
 	metadataFactory metadatainformers.SharedInformerFactory,
 	polInformer kyvernov1informers.PolicyInformer,
 	cpolInformer kyvernov1informers.ClusterPolicyInformer,
 	metadataCache resource.MetadataCache,
 	chunkSize int,
+	maxSize int,
+	summaryReport bool,
 ) controllers.Controller {
 	admrInformer := metadataFactory.ForResource(kyvernov1alpha2.SchemeGroupVersion.WithResource("admissionreports"))
 	cadmrInformer := metadataFactory.ForResource(kyvernov1alpha2.SchemeGroupVersion.WithResource("clusteradmissionreports"))
@@ -85,6 +111,7 @@ func NewController(
 	cpolrInformer := metadataFactory.ForResource(policyreportv1alpha2.SchemeGroupVersion.WithResource("clusterpolicyreports"))
 	c := controller{
 		client:         client,
+		dclient:        dclient,
 		polLister:      polInformer.Lister(),
 		cpolLister:     cpolInformer.Lister(),
 		admrLister:     admrInformer.Lister(),
@@ -94,6 +121,10 @@ func NewController(
 		queue:          workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), ControllerName),
 		metadataCache:  metadataCache,
 		chunkSize:      chunkSize,
+		maxSize:        maxSize,
+		summaryReport:  summaryReport,
+		violations:     map[string]map[policyRule]time.Time{},
+		annotations:    map[string]map[types.UID]annotatedResource{},
 	}
 	controllerutils.AddDelayedExplicitEventHandlers(logger, polrInformer.Informer(), c.queue, enqueueDelay, keyFunc)
 	controllerutils.AddDelayedExplicitEventHandlers(logger, cpolrInformer.Informer(), c.queue, enqueueDelay, keyFunc)
@@ -117,6 +148,19 @@ func NewController(
 		func(_, obj metav1.Object) { enqueueFromAdmr(obj) },
 		func(obj metav1.Object) { enqueueFromAdmr(obj) },
 	)
+	meter := otel.GetMeterProvider().Meter(metrics.MeterName)
+	violationAgeMetric, err := meter.Float64ObservableGauge(
+		"kyverno_policy_violation_age_seconds",
+		metric.WithDescription("can be used to track the age of the oldest violation of each policy rule in policy reports"),
+	)
+	if err != nil {
+		logger.Error(err, "Failed to create instrument, kyverno_policy_violation_age_seconds")
+	} else {
+		c.violationAgeMetric = violationAgeMetric
+		if _, err := meter.RegisterCallback(c.reportViolationAge, c.violationAgeMetric); err != nil {
+			logger.Error(err, "Failed to register callback")
+		}
+	}
 	return &c
 }
 
@@ -236,6 +280,24 @@ func (c *controller) reconcileReport(ctx context.Context, policyMap map[string]p
 	return reportutils.UpdateReport(ctx, after, c.client)
 }
 
+func (c *controller) reconcileSummaryReport(ctx context.Context, report kyvernov1alpha2.ReportInterface, namespace string, results []policyreportv1alpha2.PolicyReportResult) (kyvernov1alpha2.ReportInterface, error) {
+	summary := reportutils.CalculateSummary(results)
+	if report == nil {
+		return reportutils.CreateReport(ctx, reportutils.NewSummaryPolicyReport(namespace, summaryReportName, summary), c.client)
+	}
+	after := reportutils.DeepCopy(report)
+	// hold custom labels
+	reportutils.CleanupKyvernoLabels(after)
+	reportutils.SetManagedByKyvernoLabel(after)
+	controllerutils.SetLabel(after, reportutils.LabelSummaryReport, "")
+	after.SetResults(nil)
+	after.SetSummary(summary)
+	if datautils.DeepEqual(report, after) {
+		return after, nil
+	}
+	return reportutils.UpdateReport(ctx, after, c.client)
+}
+
 func (c *controller) cleanReports(ctx context.Context, actual map[string]kyvernov1alpha2.ReportInterface, expected []kyvernov1alpha2.ReportInterface) error {
 	keep := sets.New[string]()
 	for _, obj := range expected {
@@ -254,19 +316,26 @@ func (c *controller) cleanReports(ctx context.Context, actual map[string]kyverno
 
 func mergeReports(policyMap map[string]policyMapEntry, accumulator map[string]policyreportv1alpha2.PolicyReportResult, reports ...kyvernov1alpha2.ReportInterface) {
 	for _, report := range reports {
+		var objectRef corev1.ObjectReference
 		if len(report.GetOwnerReferences()) == 1 {
 			ownerRef := report.GetOwnerReferences()[0]
-			objectRefs := []corev1.ObjectReference{{
+			objectRef = corev1.ObjectReference{
 				APIVersion: ownerRef.APIVersion,
 				Kind:       ownerRef.Kind,
 				Namespace:  report.GetNamespace(),
 				Name:       ownerRef.Name,
 				UID:        ownerRef.UID,
-			}}
+			}
+		} else if reportutils.IsPayloadReport(report) {
+			// payloads are not stored in the cluster, their reports have no owner
+			objectRef = reportutils.PayloadReference(report)
+		}
+		if objectRef.UID != "" {
+			objectRefs := []corev1.ObjectReference{objectRef}
 			for _, result := range report.GetResults() {
 				currentPolicy := policyMap[result.Policy]
 				if currentPolicy.rules != nil && currentPolicy.rules.Has(result.Rule) {
-					key := result.Policy + "/" + result.Rule + "/" + string(ownerRef.UID)
+					key := result.Policy + "/" + result.Rule + "/" + string(objectRef.UID)
 					result.Resources = objectRefs
 					if rule, exists := accumulator[key]; !exists {
 						accumulator[key] = result
@@ -291,8 +360,9 @@ func (c *controller) createPolicyMap() (map[string]policyMapEntry, error) {
 			return nil, err
 		}
 		results[key] = policyMapEntry{
-			policy: cpol,
-			rules:  sets.New[string](),
+			policy:         cpol,
+			rules:          sets.New[string](),
+			annotatedRules: annotatedRules(cpol),
 		}
 		for _, rule := range autogen.ComputeRules(cpol) {
 			results[key].rules.Insert(rule.Name)
@@ -308,8 +378,9 @@ func (c *controller) createPolicyMap() (map[string]policyMapEntry, error) {
 			return nil, err
 		}
 		results[key] = policyMapEntry{
-			policy: pol,
-			rules:  sets.New[string](),
+			policy:         pol,
+			rules:          sets.New[string](),
+			annotatedRules: annotatedRules(pol),
 		}
 		for _, rule := range autogen.ComputeRules(pol) {
 			results[key].rules.Insert(rule.Name)
@@ -376,28 +447,35 @@ func (c *controller) reconcile(ctx context.Context, logger logr.Logger, key, _,
 	for _, report := range policyReports {
 		actual[report.GetName()] = report
 	}
+	trackViolations(policyReports, results, time.Now())
+	c.recordViolations(key, results)
 	splitReports := reportutils.SplitResultsByPolicy(logger, results)
 	var expected []kyvernov1alpha2.ReportInterface
-	chunkSize := c.chunkSize
-	if chunkSize <= 0 {
-		chunkSize = len(results)
-	}
 	for name, results := range splitReports {
-		for i := 0; i < len(results); i += chunkSize {
-			end := i + chunkSize
-			if end > len(results) {
-				end = len(results)
-			}
+		// shards are sorted, the first shard uses the policy report name and following
+		// ones are suffixed with their index to keep names stable across reconciliations
+		for i, shard := range reportutils.ShardResults(results, c.chunkSize, c.maxSize) {
 			name := name
 			if i > 0 {
-				name = fmt.Sprintf("%s-%d", name, i/chunkSize)
+				name = fmt.Sprintf("%s-%d", name, i)
 			}
-			report, err := c.reconcileReport(ctx, policyMap, actual[name], key, name, results[i:end]...)
+			report, err := c.reconcileReport(ctx, policyMap, actual[name], key, name, shard...)
 			if err != nil {
 				return err
 			}
 			expected = append(expected, report)
 		}
 	}
-	return c.cleanReports(ctx, actual, expected)
+	if c.summaryReport {
+		report, err := c.reconcileSummaryReport(ctx, actual[summaryReportName], key, results)
+		if err != nil {
+			return err
+		}
+		expected = append(expected, report)
+	}
+	if err := c.cleanReports(ctx, actual, expected); err != nil {
+		return err
+	}
+	c.annotateResources(ctx, logger, key, policyMap, results)
+	return nil
 }
@@ -0,0 +1,120 @@
+package aggregate
+
+import (
+	"context"
+	"time"
+
+	kyvernov1alpha2 "github.com/kyverno/kyverno/api/kyverno/v1alpha2"
+	policyreportv1alpha2 "github.com/kyverno/kyverno/api/policyreport/v1alpha2"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+const (
+	// propertyFirstSeen is the result property holding the time a violation was first reported
+	propertyFirstSeen = "firstSeen"
+	// propertyLastSeen is the result property holding the time a violation was last reported
+	propertyLastSeen = "lastSeen"
+)
+
+type policyRule struct {
+	policy string
+	rule   string
+}
+
+func isViolation(result policyreportv1alpha2.PolicyReportResult) bool {
+	return result.Result == policyreportv1alpha2.StatusFail || result.Result == policyreportv1alpha2.StatusWarn
+}
+
+func violationKey(result policyreportv1alpha2.PolicyReportResult) string {
+	key := result.Policy + "/" + result.Rule
+	for _, resource := range result.Resources {
+		key += "/" + string(resource.UID)
+	}
+	return key
+}
+
+// trackViolations sets the first seen and last seen properties of violations.
+// The first seen time is carried over from the current policy reports as long as the violation is still reported,
+// it is reset when the violation is remediated.
+func trackViolations(reports []kyvernov1alpha2.ReportInterface, results []policyreportv1alpha2.PolicyReportResult, now time.Time) {
+	firstSeen := map[string]string{}
+	for _, report := range reports {
+		for _, result := range report.GetResults() {
+			if isViolation(result) && result.Properties[propertyFirstSeen] != "" {
+				firstSeen[violationKey(result)] = result.Properties[propertyFirstSeen]
+			}
+		}
+	}
+	for i := range results {
+		result := &results[i]
+		if !isViolation(*result) {
+			continue
+		}
+		lastSeen := now
+		if result.Timestamp.Seconds != 0 {
+			lastSeen = time.Unix(result.Timestamp.Seconds, int64(result.Timestamp.Nanos)).UTC()
+		}
+		properties := make(map[string]string, len(result.Properties)+2)
+		for key, value := range result.Properties {
+			properties[key] = value
+		}
+		properties[propertyLastSeen] = lastSeen.Format(time.RFC3339)
+		if first, ok := firstSeen[violationKey(*result)]; ok {
+			properties[propertyFirstSeen] = first
+		} else {
+			properties[propertyFirstSeen] = properties[propertyLastSeen]
+		}
+		result.Properties = properties
+	}
+}
+
+// oldestViolations returns the first seen time of the oldest violation of each policy rule
+func oldestViolations(results []policyreportv1alpha2.PolicyReportResult) map[policyRule]time.Time {
+	oldest := map[policyRule]time.Time{}
+	for _, result := range results {
+		if !isViolation(result) {
+			continue
+		}
+		firstSeen, err := time.Parse(time.RFC3339, result.Properties[propertyFirstSeen])
+		if err != nil {
+			continue
+		}
+		key := policyRule{policy: result.Policy, rule: result.Rule}
+		if current, ok := oldest[key]; !ok || firstSeen.Before(current) {
+			oldest[key] = firstSeen
+		}
+	}
+	return oldest
+}
+
+func (c *controller) recordViolations(namespace string, results []policyreportv1alpha2.PolicyReportResult) {
+	oldest := oldestViolations(results)
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if len(oldest) == 0 {
+		delete(c.violations, namespace)
+	} else {
+		c.violations[namespace] = oldest
+	}
+}
+
+func (c *controller) reportViolationAge(ctx context.Context, observer metric.Observer) error {
+	now := time.Now()
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	for namespace, oldest := range c.violations {
+		for key, firstSeen := range oldest {
+			observer.ObserveFloat64(
+				c.violationAgeMetric,
+				now.Sub(firstSeen).Seconds(),
+				metric.WithAttributes(
+					attribute.String("policy_name", key.policy),
+					attribute.String("rule_name", key.rule),
+					attribute.String("resource_namespace", namespace),
+				),
+			)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+package aggregate
+
+import (
+	"testing"
+	"time"
+
+	kyvernov1alpha2 "github.com/kyverno/kyverno/api/kyverno/v1alpha2"
+	policyreportv1alpha2 "github.com/kyverno/kyverno/api/policyreport/v1alpha2"
+	"gotest.tools/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func newResult(rule string, uid string, status policyreportv1alpha2.PolicyResult, seconds int64) policyreportv1alpha2.PolicyReportResult {
+	return policyreportv1alpha2.PolicyReportResult{
+		Policy:    "require-labels",
+		Rule:      rule,
+		Result:    status,
+		Timestamp: metav1.Timestamp{Seconds: seconds},
+		Resources: []corev1.ObjectReference{{UID: types.UID(uid)}},
+	}
+}
+
+func Test_trackViolations(t *testing.T) {
+	previous := &policyreportv1alpha2.PolicyReport{
+		Results: []policyreportv1alpha2.PolicyReportResult{
+			newResult("check-team", "a", policyreportv1alpha2.StatusFail, 100),
+			newResult("check-team", "b", policyreportv1alpha2.StatusFail, 100),
+			newResult("check-env", "a", policyreportv1alpha2.StatusPass, 100),
+		},
+	}
+	trackViolations(nil, previous.Results, time.Unix(1000, 0))
+	assert.Equal(t, previous.Results[0].Properties[propertyFirstSeen], "1970-01-01T00:01:40Z")
+	assert.Assert(t, previous.Results[2].Properties == nil)
+
+	results := []policyreportv1alpha2.PolicyReportResult{
+		// still failing, first seen is kept
+		newResult("check-team", "a", policyreportv1alpha2.StatusFail, 200),
+		// remediated
+		newResult("check-team", "b", policyreportv1alpha2.StatusPass, 200),
+		// new violation
+		newResult("check-env", "a", policyreportv1alpha2.StatusWarn, 200),
+		// no timestamp
+		newResult("check-env", "b", policyreportv1alpha2.StatusFail, 0),
+	}
+	trackViolations([]kyvernov1alpha2.ReportInterface{previous}, results, time.Unix(1000, 0))
+	assert.DeepEqual(t, results[0].Properties, map[string]string{
+		propertyFirstSeen: "1970-01-01T00:01:40Z",
+		propertyLastSeen:  "1970-01-01T00:03:20Z",
+	})
+	assert.Assert(t, results[1].Properties == nil)
+	assert.DeepEqual(t, results[2].Properties, map[string]string{
+		propertyFirstSeen: "1970-01-01T00:03:20Z",
+		propertyLastSeen:  "1970-01-01T00:03:20Z",
+	})
+	assert.DeepEqual(t, results[3].Properties, map[string]string{
+		propertyFirstSeen: "1970-01-01T00:16:40Z",
+		propertyLastSeen:  "1970-01-01T00:16:40Z",
+	})
+
+	oldest := oldestViolations(results)
+	assert.DeepEqual(t, oldest, map[policyRule]time.Time{
+		{policy: "require-labels", rule: "check-team"}: time.Unix(100, 0).UTC(),
+		{policy: "require-labels", rule: "check-env"}:  time.Unix(200, 0).UTC(),
+	})
+}
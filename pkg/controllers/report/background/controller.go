@@ -19,11 +19,15 @@ import (
 	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
 	"github.com/kyverno/kyverno/pkg/engine/jmespath"
 	"github.com/kyverno/kyverno/pkg/event"
+	"github.com/kyverno/kyverno/pkg/exporter"
+	"github.com/kyverno/kyverno/pkg/sharding"
 	controllerutils "github.com/kyverno/kyverno/pkg/utils/controller"
 	datautils "github.com/kyverno/kyverno/pkg/utils/data"
 	reportutils "github.com/kyverno/kyverno/pkg/utils/report"
+	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	corev1informers "k8s.io/client-go/informers/core/v1"
@@ -67,6 +71,10 @@ type controller struct {
 	jp            jmespath.Interface
 	eventGen      event.Interface
 	policyReports bool
+	exporter      exporter.Exporter
+
+	// sharding
+	sharder sharding.Sharder
 }
 
 func NewController(
@@ -83,6 +91,8 @@ func NewController(
 	jp jmespath.Interface,
 	eventGen event.Interface,
 	policyReports bool,
+	resultsExporter exporter.Exporter,
+	sharder sharding.Sharder,
 ) controllers.Controller {
 	bgscanr := metadataFactory.ForResource(kyvernov1alpha2.SchemeGroupVersion.WithResource("backgroundscanreports"))
 	cbgscanr := metadataFactory.ForResource(kyvernov1alpha2.SchemeGroupVersion.WithResource("clusterbackgroundscanreports"))
@@ -103,11 +113,17 @@ func NewController(
 		jp:             jp,
 		eventGen:       eventGen,
 		policyReports:  policyReports,
+		exporter:       resultsExporter,
+		sharder:        sharder,
 	}
 	controllerutils.AddDefaultEventHandlers(logger, bgscanr.Informer(), queue)
 	controllerutils.AddDefaultEventHandlers(logger, cbgscanr.Informer(), queue)
 	controllerutils.AddEventHandlersT(polInformer.Informer(), c.addPolicy, c.updatePolicy, c.deletePolicy)
 	controllerutils.AddEventHandlersT(cpolInformer.Informer(), c.addPolicy, c.updatePolicy, c.deletePolicy)
+	if sharder != nil {
+		// keys move between members when the shard group changes, requeue everything and let reconcile filter owned keys
+		sharder.AddHandler(c.enqueueResources)
+	}
 	c.metadataCache.AddEventHandler(func(eventType resource.EventType, uid types.UID, _ schema.GroupVersionKind, res resource.Resource) {
 		// if it's a deletion, nothing to do
 		if eventType == resource.Deleted {
@@ -221,6 +237,7 @@ func (c *controller) reconcileReport(
 	namespace string,
 	name string,
 	full bool,
+	forcedPolicy string,
 	uid types.UID,
 	gvk schema.GroupVersionKind,
 	resource resource.Resource,
@@ -248,6 +265,10 @@ func (c *controller) reconcileReport(
 		}
 		observed = reportutils.NewBackgroundScanReport(namespace, name, gvk, resource.Name, uid)
 	}
+	// if the resource changed since the report was computed, results of all policies are stale
+	if observed.GetResourceVersion() != "" && !reportutils.CompareHash(observed, resource.Hash) {
+		full = true
+	}
 	// build desired report
 	expected := map[string]string{}
 	for _, policy := range backgroundPolicies {
@@ -273,25 +294,31 @@ func (c *controller) reconcileReport(
 		for _, result := range observed.GetResults() {
 			// if the policy did not change, keep the result
 			label := policyNameToLabel[result.Policy]
-			if label != "" && expected[label] == actual[label] {
+			if label != "" && label != forcedPolicy && expected[label] == actual[label] {
 				ruleResults = append(ruleResults, result)
 			}
 		}
 	}
 	// calculate necessary results
+	var newResults []policyreportv1alpha2.PolicyReportResult
 	for _, policy := range backgroundPolicies {
-		if full || actual[reportutils.PolicyLabel(policy)] != policy.GetResourceVersion() {
+		label := reportutils.PolicyLabel(policy)
+		if full || label == forcedPolicy || actual[label] != policy.GetResourceVersion() {
 			scanner := utils.NewScanner(logger, c.engine, c.config, c.jp)
 			for _, result := range scanner.ScanResource(ctx, *target, nsLabels, policy) {
 				if result.Error != nil {
 					return result.Error
 				} else if result.EngineResponse != nil {
-					ruleResults = append(ruleResults, reportutils.EngineResponseToReportResults(*result.EngineResponse)...)
+					newResults = append(newResults, reportutils.EngineResponseToReportResults(*result.EngineResponse)...)
 					utils.GenerateEvents(logger, c.eventGen, c.config, *result.EngineResponse)
 				}
 			}
 		}
 	}
+	ruleResults = append(ruleResults, newResults...)
+	if c.exporter != nil {
+		c.exportResults(target, reportutils.ExcludeResults(c.config, target.GetNamespace(), newResults))
+	}
 	desired := reportutils.DeepCopy(observed)
 	for key := range desired.GetLabels() {
 		if reportutils.IsPolicyLabel(key) {
@@ -302,7 +329,7 @@ func (c *controller) reconcileReport(
 		reportutils.SetPolicyLabel(desired, policy)
 	}
 	reportutils.SetResourceVersionLabels(desired, target)
-	reportutils.SetResults(desired, ruleResults...)
+	reportutils.SetResults(desired, reportutils.ExcludeResults(c.config, target.GetNamespace(), ruleResults)...)
 	if full || !controllerutils.HasAnnotation(desired, annotationLastScanTime) {
 		controllerutils.SetAnnotation(desired, annotationLastScanTime, time.Now().Format(time.RFC3339))
 	}
@@ -312,6 +339,21 @@ func (c *controller) reconcileReport(
 	return nil
 }
 
+// exportResults sends newly computed results to the results exporter
+func (c *controller) exportResults(target *unstructured.Unstructured, results []policyreportv1alpha2.PolicyReportResult) {
+	resource := corev1.ObjectReference{
+		APIVersion: target.GetAPIVersion(),
+		Kind:       target.GetKind(),
+		Namespace:  target.GetNamespace(),
+		Name:       target.GetName(),
+		UID:        target.GetUID(),
+	}
+	for i := range results {
+		results[i].Resources = []corev1.ObjectReference{resource}
+	}
+	c.exporter.Export(results...)
+}
+
 func (c *controller) storeReport(ctx context.Context, observed, desired kyvernov1alpha2.ReportInterface) error {
 	var err error
 	hasReport := observed.GetResourceVersion() != ""
@@ -337,6 +379,10 @@ func (c *controller) storeReport(ctx context.Context, observed, desired kyvernov
 }
 
 func (c *controller) reconcile(ctx context.Context, log logr.Logger, key, namespace, name string) error {
+	// resources owned by other members of the shard group are scanned by them
+	if c.sharder != nil && !c.sharder.Owns(key) {
+		return nil
+	}
 	// try to find resource from the cache
 	uid := types.UID(name)
 	resource, gvk, exists := c.metadataCache.GetResourceHash(uid)
@@ -382,7 +428,7 @@ func (c *controller) reconcile(ctx context.Context, log logr.Logger, key, namesp
 			c.queue.AddAfter(key, c.forceDelay)
 		}()
 		if needsReconcile {
-			return c.reconcileReport(ctx, namespace, name, full, uid, gvk, resource, backgroundPolicies...)
+			return c.reconcileReport(ctx, namespace, name, full, "", uid, gvk, resource, backgroundPolicies...)
 		}
 	}
 	return nil
@@ -0,0 +1,282 @@
+package background
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	"github.com/kyverno/kyverno/pkg/client/clientset/versioned"
+	kyvernov1informers "github.com/kyverno/kyverno/pkg/client/informers/externalversions/kyverno/v1"
+	kyvernov2alpha1informers "github.com/kyverno/kyverno/pkg/client/informers/externalversions/kyverno/v2alpha1"
+	kyvernov1listers "github.com/kyverno/kyverno/pkg/client/listers/kyverno/v1"
+	kyvernov2alpha1listers "github.com/kyverno/kyverno/pkg/client/listers/kyverno/v2alpha1"
+	"github.com/kyverno/kyverno/pkg/clients/dclient"
+	"github.com/kyverno/kyverno/pkg/config"
+	"github.com/kyverno/kyverno/pkg/controllers"
+	"github.com/kyverno/kyverno/pkg/controllers/report/resource"
+	"github.com/kyverno/kyverno/pkg/controllers/report/utils"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	"github.com/kyverno/kyverno/pkg/engine/jmespath"
+	"github.com/kyverno/kyverno/pkg/event"
+	"github.com/kyverno/kyverno/pkg/exporter"
+	controllerutils "github.com/kyverno/kyverno/pkg/utils/controller"
+	reportutils "github.com/kyverno/kyverno/pkg/utils/report"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	corev1informers "k8s.io/client-go/informers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+)
+
+const (
+	// ScanRequestWorkers is the number of workers for the scan request controller
+	ScanRequestWorkers = 1
+	// ScanRequestControllerName is the name of the scan request controller
+	ScanRequestControllerName = "scan-request-controller"
+	// scanRequestProgressInterval is the number of scanned resources between two status updates
+	scanRequestProgressInterval = 100
+)
+
+type scanRequestController struct {
+	// clients
+	kyvernoClient versioned.Interface
+
+	// listers
+	polLister   kyvernov1listers.PolicyLister
+	cpolLister  kyvernov1listers.ClusterPolicyLister
+	scanrLister kyvernov2alpha1listers.ScanRequestLister
+
+	// queue
+	queue workqueue.RateLimitingInterface
+
+	// cache
+	metadataCache resource.MetadataCache
+
+	// background scan controller used to reconcile the reports
+	reports *controller
+}
+
+// NewScanRequestController creates a controller processing scan requests, every existing resource is scanned
+// against the requested policy regardless of the background scan interval and the results are written to the
+// background scan reports
+func NewScanRequestController(
+	client dclient.Interface,
+	kyvernoClient versioned.Interface,
+	engine engineapi.Engine,
+	polInformer kyvernov1informers.PolicyInformer,
+	cpolInformer kyvernov1informers.ClusterPolicyInformer,
+	scanrInformer kyvernov2alpha1informers.ScanRequestInformer,
+	nsInformer corev1informers.NamespaceInformer,
+	metadataCache resource.MetadataCache,
+	config config.Configuration,
+	jp jmespath.Interface,
+	eventGen event.Interface,
+	policyReports bool,
+	resultsExporter exporter.Exporter,
+) controllers.Controller {
+	queue := workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), ScanRequestControllerName)
+	c := scanRequestController{
+		kyvernoClient: kyvernoClient,
+		polLister:     polInformer.Lister(),
+		cpolLister:    cpolInformer.Lister(),
+		scanrLister:   scanrInformer.Lister(),
+		queue:         queue,
+		metadataCache: metadataCache,
+		reports: &controller{
+			client:        client,
+			kyvernoClient: kyvernoClient,
+			engine:        engine,
+			nsLister:      nsInformer.Lister(),
+			config:        config,
+			jp:            jp,
+			eventGen:      eventGen,
+			policyReports: policyReports,
+			exporter:      resultsExporter,
+		},
+	}
+	// status updates, including the progress reported by this controller, don't change the generation and are ignored
+	enqueue := controllerutils.LogError(logger, controllerutils.Parse(controllerutils.MetaNamespaceKey, controllerutils.Queue(queue)))
+	controllerutils.AddEventHandlersT(
+		scanrInformer.Informer(),
+		func(obj *kyvernov2alpha1.ScanRequest) { _ = enqueue(obj) },
+		func(old, obj *kyvernov2alpha1.ScanRequest) {
+			if scanRequestChanged(old, obj) {
+				_ = enqueue(obj)
+			}
+		},
+		func(obj *kyvernov2alpha1.ScanRequest) { _ = enqueue(obj) },
+	)
+	return &c
+}
+
+// scanRequestChanged returns true if the scan request spec changed, the generation is only incremented on spec changes
+func scanRequestChanged(old, obj *kyvernov2alpha1.ScanRequest) bool {
+	return old.GetGeneration() != obj.GetGeneration()
+}
+
+func (c *scanRequestController) Run(ctx context.Context, workers int) {
+	controllerutils.Run(ctx, logger.WithName(ScanRequestControllerName), ScanRequestControllerName, time.Second, c.queue, workers, maxRetries, c.reconcile)
+}
+
+func (c *scanRequestController) getPolicy(key string) (kyvernov1.PolicyInterface, error) {
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		return nil, err
+	}
+	if namespace == "" {
+		return c.cpolLister.Get(name)
+	}
+	return c.polLister.Policies(namespace).Get(name)
+}
+
+// getResourceKeys returns the keys of the existing resources the policy can apply to
+func (c *scanRequestController) getResourceKeys(policy kyvernov1.PolicyInterface) []string {
+	keys := c.metadataCache.GetAllResourceKeys()
+	if !policy.IsNamespaced() {
+		return keys
+	}
+	var filtered []string
+	for _, key := range keys {
+		if namespace, _, err := cache.SplitMetaNamespaceKey(key); err == nil && namespace == policy.GetNamespace() {
+			filtered = append(filtered, key)
+		}
+	}
+	return filtered
+}
+
+// scanResource reconciles the report of a resource, the results of the policy are always recomputed
+func (c *scanRequestController) scanResource(ctx context.Context, key string, policy kyvernov1.PolicyInterface) error {
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		return err
+	}
+	uid := types.UID(name)
+	resource, gvk, exists := c.metadataCache.GetResourceHash(uid)
+	// the resource was deleted in the meantime, nothing to scan
+	if !exists {
+		return nil
+	}
+	policies, err := utils.FetchClusterPolicies(c.cpolLister)
+	if err != nil {
+		return err
+	}
+	if namespace != "" {
+		pols, err := utils.FetchPolicies(c.polLister, namespace)
+		if err != nil {
+			return err
+		}
+		policies = append(policies, pols...)
+	}
+	err = c.reports.reconcileReport(ctx, namespace, name, false, reportutils.PolicyLabel(policy), uid, gvk, resource, utils.RemoveNonBackgroundPolicies(policies...)...)
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+	return err
+}
+
+func (c *scanRequestController) updateStatus(ctx context.Context, request *kyvernov2alpha1.ScanRequest, build func(*kyvernov2alpha1.ScanRequestStatus)) (*kyvernov2alpha1.ScanRequest, error) {
+	return controllerutils.UpdateStatus(ctx, request, c.kyvernoClient.KyvernoV2alpha1().ScanRequests(), func(request *kyvernov2alpha1.ScanRequest) error {
+		build(&request.Status)
+		return nil
+	})
+}
+
+func (c *scanRequestController) fail(ctx context.Context, request *kyvernov2alpha1.ScanRequest, message string) error {
+	_, err := c.updateStatus(ctx, request, func(status *kyvernov2alpha1.ScanRequestStatus) {
+		now := metav1.Now()
+		status.Phase = kyvernov2alpha1.ScanRequestFailed
+		status.Message = message
+		status.CompletionTime = &now
+	})
+	return err
+}
+
+func (c *scanRequestController) reconcile(ctx context.Context, logger logr.Logger, key, _, name string) error {
+	request, err := c.scanrLister.Get(name)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	// a scan interrupted while running is started again
+	if request.Status.IsDone() {
+		return nil
+	}
+	// the lister can lag behind the status updates of a scan that just completed,
+	// the phase is checked against the latest version before the scan is started again
+	request, err = c.kyvernoClient.KyvernoV2alpha1().ScanRequests().Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	if request.Status.IsDone() {
+		return nil
+	}
+	if errs := request.Validate(); len(errs) != 0 {
+		return c.fail(ctx, request, errs.ToAggregate().Error())
+	}
+	policy, err := c.getPolicy(request.Spec.Policy)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return c.fail(ctx, request, fmt.Sprintf("policy %s not found", request.Spec.Policy))
+		}
+		return err
+	}
+	if !utils.CanBackgroundProcess(policy) {
+		return c.fail(ctx, request, fmt.Sprintf("policy %s does not have background processing enabled", request.Spec.Policy))
+	}
+	keys := c.getResourceKeys(policy)
+	request, err = c.updateStatus(ctx, request, func(status *kyvernov2alpha1.ScanRequestStatus) {
+		now := metav1.Now()
+		status.Phase = kyvernov2alpha1.ScanRequestRunning
+		status.Message = ""
+		status.PolicyResourceVersion = policy.GetResourceVersion()
+		status.Total = len(keys)
+		status.Scanned = 0
+		status.Errors = 0
+		status.StartTime = &now
+		status.CompletionTime = nil
+	})
+	if err != nil {
+		return err
+	}
+	logger.Info("scanning existing resources", "policy", request.Spec.Policy, "total", len(keys))
+	scanned, errors := 0, 0
+	for _, resourceKey := range keys {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := c.scanResource(ctx, resourceKey, policy); err != nil {
+			logger.Error(err, "failed to scan resource", "policy", request.Spec.Policy, "resource", resourceKey)
+			errors++
+		}
+		scanned++
+		if scanned%scanRequestProgressInterval == 0 {
+			updated, err := c.updateStatus(ctx, request, func(status *kyvernov2alpha1.ScanRequestStatus) {
+				status.Scanned = scanned
+				status.Errors = errors
+			})
+			if err != nil {
+				logger.Error(err, "failed to update scan request progress")
+			} else {
+				request = updated
+			}
+		}
+	}
+	_, err = c.updateStatus(ctx, request, func(status *kyvernov2alpha1.ScanRequestStatus) {
+		now := metav1.Now()
+		status.Phase = kyvernov2alpha1.ScanRequestCompleted
+		status.Message = fmt.Sprintf("scanned %d resources, %d errors", scanned, errors)
+		status.Scanned = scanned
+		status.Errors = errors
+		status.CompletionTime = &now
+	})
+	return err
+}
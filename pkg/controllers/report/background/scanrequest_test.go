@@ -0,0 +1,81 @@
+package background
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	"github.com/kyverno/kyverno/pkg/client/clientset/versioned/fake"
+	kyvernoinformers "github.com/kyverno/kyverno/pkg/client/informers/externalversions"
+	"gotest.tools/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func newScanRequest(generation int64, phase kyvernov2alpha1.ScanRequestPhase) *kyvernov2alpha1.ScanRequest {
+	return &kyvernov2alpha1.ScanRequest{
+		ObjectMeta: metav1.ObjectMeta{Name: "scan", Generation: generation},
+		Spec:       kyvernov2alpha1.ScanRequestSpec{Policy: "policy"},
+		Status:     kyvernov2alpha1.ScanRequestStatus{Phase: phase},
+	}
+}
+
+func Test_scanRequestChanged(t *testing.T) {
+	progress := newScanRequest(1, kyvernov2alpha1.ScanRequestRunning)
+	progress.Status.Scanned = 100
+	assert.Assert(t, !scanRequestChanged(newScanRequest(1, kyvernov2alpha1.ScanRequestRunning), progress))
+	assert.Assert(t, !scanRequestChanged(newScanRequest(1, kyvernov2alpha1.ScanRequestRunning), newScanRequest(1, kyvernov2alpha1.ScanRequestCompleted)))
+	assert.Assert(t, scanRequestChanged(newScanRequest(1, kyvernov2alpha1.ScanRequestCompleted), newScanRequest(2, kyvernov2alpha1.ScanRequestCompleted)))
+}
+
+func Test_scanRequestController_reconcile(t *testing.T) {
+	tests := []struct {
+		name        string
+		cached      *kyvernov2alpha1.ScanRequest
+		latest      *kyvernov2alpha1.ScanRequest
+		wantPhase   kyvernov2alpha1.ScanRequestPhase
+		wantUpdated bool
+	}{{
+		name:      "done",
+		cached:    newScanRequest(1, kyvernov2alpha1.ScanRequestCompleted),
+		latest:    newScanRequest(1, kyvernov2alpha1.ScanRequestCompleted),
+		wantPhase: kyvernov2alpha1.ScanRequestCompleted,
+	}, {
+		name:      "done but lister not up to date",
+		cached:    newScanRequest(1, kyvernov2alpha1.ScanRequestRunning),
+		latest:    newScanRequest(1, kyvernov2alpha1.ScanRequestCompleted),
+		wantPhase: kyvernov2alpha1.ScanRequestCompleted,
+	}, {
+		name:        "pending",
+		cached:      newScanRequest(1, kyvernov2alpha1.ScanRequestPending),
+		latest:      newScanRequest(1, kyvernov2alpha1.ScanRequestPending),
+		wantPhase:   kyvernov2alpha1.ScanRequestFailed,
+		wantUpdated: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kyvernoClient := fake.NewSimpleClientset(tt.latest)
+			informers := kyvernoinformers.NewSharedInformerFactory(kyvernoClient, 0)
+			scanrInformer := informers.Kyverno().V2alpha1().ScanRequests()
+			assert.NilError(t, scanrInformer.Informer().GetIndexer().Add(tt.cached))
+			c := &scanRequestController{
+				kyvernoClient: kyvernoClient,
+				polLister:     informers.Kyverno().V1().Policies().Lister(),
+				cpolLister:    informers.Kyverno().V1().ClusterPolicies().Lister(),
+				scanrLister:   scanrInformer.Lister(),
+			}
+			kyvernoClient.ClearActions()
+			assert.NilError(t, c.reconcile(context.TODO(), logr.Discard(), "scan", "", "scan"))
+			updated := false
+			for _, action := range kyvernoClient.Actions() {
+				if action.GetVerb() == "update" {
+					updated = true
+				}
+			}
+			assert.Equal(t, updated, tt.wantUpdated)
+			request, err := kyvernoClient.KyvernoV2alpha1().ScanRequests().Get(context.TODO(), "scan", metav1.GetOptions{})
+			assert.NilError(t, err)
+			assert.Equal(t, request.Status.Phase, tt.wantPhase)
+		})
+	}
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/kyverno/kyverno/pkg/clients/dclient"
 	"github.com/kyverno/kyverno/pkg/controllers"
 	"github.com/kyverno/kyverno/pkg/controllers/report/utils"
+	"github.com/kyverno/kyverno/pkg/jsonpayload"
 	controllerutils "github.com/kyverno/kyverno/pkg/utils/controller"
 	kubeutils "github.com/kyverno/kyverno/pkg/utils/kube"
 	reportutils "github.com/kyverno/kyverno/pkg/utils/report"
@@ -222,6 +223,10 @@ func (c *controller) updateDynamicWatchers(ctx context.Context) error {
 	gvkToGvr := map[schema.GroupVersionKind]schema.GroupVersionResource{}
 	for _, policyKind := range sets.List(kinds) {
 		group, version, kind, subresource := kubeutils.ParseKindSelector(policyKind)
+		// payloads are not stored in the cluster, there is nothing to report on
+		if jsonpayload.IsPayloadGroup(group) {
+			continue
+		}
 		gvrss, err := c.client.Discovery().FindResources(group, version, kind, subresource)
 		if err != nil {
 			logger.Error(err, "failed to get gvr from kind", "kind", kind)
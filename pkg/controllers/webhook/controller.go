@@ -17,11 +17,16 @@ import (
 	"github.com/kyverno/kyverno/pkg/clients/dclient"
 	"github.com/kyverno/kyverno/pkg/config"
 	"github.com/kyverno/kyverno/pkg/controllers"
+	"github.com/kyverno/kyverno/pkg/jsonpayload"
+	"github.com/kyverno/kyverno/pkg/metrics"
 	"github.com/kyverno/kyverno/pkg/tls"
 	controllerutils "github.com/kyverno/kyverno/pkg/utils/controller"
 	kubeutils "github.com/kyverno/kyverno/pkg/utils/kube"
+	policyutils "github.com/kyverno/kyverno/pkg/utils/policy"
 	runtimeutils "github.com/kyverno/kyverno/pkg/utils/runtime"
 	"github.com/kyverno/kyverno/pkg/utils/wildcard"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/metric"
 	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
 	coordinationv1 "k8s.io/api/coordination/v1"
 	corev1 "k8s.io/api/core/v1"
@@ -100,9 +105,13 @@ type controller struct {
 	runtime            runtimeutils.Runtime
 	configuration      config.Configuration
 
+	// metrics
+	driftMetric metric.Int64Counter
+
 	// state
 	lock        sync.Mutex
 	policyState map[string]sets.Set[string]
+	applied     map[string]appliedWebhookConfiguration
 }
 
 func NewController(
@@ -152,6 +161,17 @@ func NewController(
 			config.MutatingWebhookConfigurationName:   sets.New[string](),
 			config.ValidatingWebhookConfigurationName: sets.New[string](),
 		},
+		applied: map[string]appliedWebhookConfiguration{},
+	}
+	meter := otel.GetMeterProvider().Meter(metrics.MeterName)
+	driftMetric, err := meter.Int64Counter(
+		"kyverno_webhook_configuration_drift",
+		metric.WithDescription("can be used to track changes made outside of Kyverno to the fields it owns in webhook configurations (repaired automatically)"),
+	)
+	if err != nil {
+		logger.Error(err, "Failed to create instrument, kyverno_webhook_configuration_drift")
+	} else {
+		c.driftMetric = driftMetric
 	}
 	controllerutils.AddDefaultEventHandlers(logger, mwcInformer.Informer(), queue)
 	controllerutils.AddDefaultEventHandlers(logger, vwcInformer.Informer(), queue)
@@ -351,22 +371,30 @@ func (c *controller) reconcileValidatingWebhookConfiguration(ctx context.Context
 	observed, err := c.vwcLister.Get(desired.Name)
 	if err != nil {
 		if apierrors.IsNotFound(err) {
-			_, err := c.vwcClient.Create(ctx, desired, metav1.CreateOptions{})
-			return err
+			created, err := c.vwcClient.Create(ctx, desired, metav1.CreateOptions{})
+			if err != nil {
+				return err
+			}
+			c.recordApplied(created.Name, "", created.ResourceVersion, validatingWebhookFields(created.Webhooks))
+			return nil
 		}
 		return err
 	}
 	if !autoUpdateWebhooks {
 		return nil
 	}
-	_, err = controllerutils.Update(ctx, observed, c.vwcClient, func(w *admissionregistrationv1.ValidatingWebhookConfiguration) error {
-		w.Labels = desired.Labels
-		w.Annotations = desired.Annotations
+	c.checkDrift(ctx, observed.Name, observed.ResourceVersion, validatingWebhookFields(observed.Webhooks))
+	updated, err := controllerutils.Update(ctx, observed, c.vwcClient, func(w *admissionregistrationv1.ValidatingWebhookConfiguration) error {
+		mergeMetadata(w, desired)
 		w.OwnerReferences = desired.OwnerReferences
 		w.Webhooks = desired.Webhooks
 		return nil
 	})
-	return err
+	if err != nil {
+		return err
+	}
+	c.recordApplied(updated.Name, observed.ResourceVersion, updated.ResourceVersion, validatingWebhookFields(updated.Webhooks))
+	return nil
 }
 
 func (c *controller) reconcileMutatingWebhookConfiguration(ctx context.Context, autoUpdateWebhooks bool, build func(context.Context, config.Configuration, []byte) (*admissionregistrationv1.MutatingWebhookConfiguration, error)) error {
@@ -381,22 +409,30 @@ func (c *controller) reconcileMutatingWebhookConfiguration(ctx context.Context,
 	observed, err := c.mwcLister.Get(desired.Name)
 	if err != nil {
 		if apierrors.IsNotFound(err) {
-			_, err := c.mwcClient.Create(ctx, desired, metav1.CreateOptions{})
-			return err
+			created, err := c.mwcClient.Create(ctx, desired, metav1.CreateOptions{})
+			if err != nil {
+				return err
+			}
+			c.recordApplied(created.Name, "", created.ResourceVersion, mutatingWebhookFields(created.Webhooks))
+			return nil
 		}
 		return err
 	}
 	if !autoUpdateWebhooks {
 		return nil
 	}
-	_, err = controllerutils.Update(ctx, observed, c.mwcClient, func(w *admissionregistrationv1.MutatingWebhookConfiguration) error {
-		w.Labels = desired.Labels
-		w.Annotations = desired.Annotations
+	c.checkDrift(ctx, observed.Name, observed.ResourceVersion, mutatingWebhookFields(observed.Webhooks))
+	updated, err := controllerutils.Update(ctx, observed, c.mwcClient, func(w *admissionregistrationv1.MutatingWebhookConfiguration) error {
+		mergeMetadata(w, desired)
 		w.OwnerReferences = desired.OwnerReferences
 		w.Webhooks = desired.Webhooks
 		return nil
 	})
-	return err
+	if err != nil {
+		return err
+	}
+	c.recordApplied(updated.Name, observed.ResourceVersion, updated.ResourceVersion, mutatingWebhookFields(updated.Webhooks))
+	return nil
 }
 
 func (c *controller) updatePolicyStatuses(ctx context.Context) error {
@@ -498,7 +534,7 @@ func (c *controller) reconcile(ctx context.Context, logger logr.Logger, key, nam
 
 func (c *controller) buildVerifyMutatingWebhookConfiguration(_ context.Context, cfg config.Configuration, caBundle []byte) (*admissionregistrationv1.MutatingWebhookConfiguration, error) {
 	return &admissionregistrationv1.MutatingWebhookConfiguration{
-			ObjectMeta: objectMeta(config.VerifyMutatingWebhookConfigurationName, cfg.GetWebhookAnnotations(), c.buildOwner()...),
+			ObjectMeta: objectMeta(config.VerifyMutatingWebhookConfigurationName, cfg.GetWebhookAnnotations(), nil, c.buildOwner()...),
 			Webhooks: []admissionregistrationv1.MutatingWebhook{{
 				Name:         config.VerifyMutatingWebhookName,
 				ClientConfig: c.clientConfig(caBundle, config.VerifyMutatingWebhookServicePath),
@@ -525,7 +561,7 @@ func (c *controller) buildVerifyMutatingWebhookConfiguration(_ context.Context,
 
 func (c *controller) buildPolicyMutatingWebhookConfiguration(_ context.Context, cfg config.Configuration, caBundle []byte) (*admissionregistrationv1.MutatingWebhookConfiguration, error) {
 	return &admissionregistrationv1.MutatingWebhookConfiguration{
-			ObjectMeta: objectMeta(config.PolicyMutatingWebhookConfigurationName, cfg.GetWebhookAnnotations(), c.buildOwner()...),
+			ObjectMeta: objectMeta(config.PolicyMutatingWebhookConfigurationName, cfg.GetWebhookAnnotations(), nil, c.buildOwner()...),
 			Webhooks: []admissionregistrationv1.MutatingWebhook{{
 				Name:         config.PolicyMutatingWebhookName,
 				ClientConfig: c.clientConfig(caBundle, config.PolicyMutatingWebhookServicePath),
@@ -548,7 +584,7 @@ func (c *controller) buildPolicyMutatingWebhookConfiguration(_ context.Context,
 
 func (c *controller) buildPolicyValidatingWebhookConfiguration(_ context.Context, cfg config.Configuration, caBundle []byte) (*admissionregistrationv1.ValidatingWebhookConfiguration, error) {
 	return &admissionregistrationv1.ValidatingWebhookConfiguration{
-			ObjectMeta: objectMeta(config.PolicyValidatingWebhookConfigurationName, cfg.GetWebhookAnnotations(), c.buildOwner()...),
+			ObjectMeta: objectMeta(config.PolicyValidatingWebhookConfigurationName, cfg.GetWebhookAnnotations(), nil, c.buildOwner()...),
 			Webhooks: []admissionregistrationv1.ValidatingWebhook{{
 				Name:         config.PolicyValidatingWebhookName,
 				ClientConfig: c.clientConfig(caBundle, config.PolicyValidatingWebhookServicePath),
@@ -570,7 +606,7 @@ func (c *controller) buildPolicyValidatingWebhookConfiguration(_ context.Context
 
 func (c *controller) buildDefaultResourceMutatingWebhookConfiguration(_ context.Context, cfg config.Configuration, caBundle []byte) (*admissionregistrationv1.MutatingWebhookConfiguration, error) {
 	return &admissionregistrationv1.MutatingWebhookConfiguration{
-			ObjectMeta: objectMeta(config.MutatingWebhookConfigurationName, cfg.GetWebhookAnnotations(), c.buildOwner()...),
+			ObjectMeta: objectMeta(config.MutatingWebhookConfigurationName, cfg.GetWebhookAnnotations(), nil, c.buildOwner()...),
 			Webhooks: []admissionregistrationv1.MutatingWebhook{{
 				Name:         config.MutatingWebhookName + "-ignore",
 				ClientConfig: c.clientConfig(caBundle, config.MutatingWebhookServicePath+"/ignore"),
@@ -616,7 +652,7 @@ func (c *controller) buildDefaultResourceMutatingWebhookConfiguration(_ context.
 
 func (c *controller) buildResourceMutatingWebhookConfiguration(ctx context.Context, cfg config.Configuration, caBundle []byte) (*admissionregistrationv1.MutatingWebhookConfiguration, error) {
 	result := admissionregistrationv1.MutatingWebhookConfiguration{
-		ObjectMeta: objectMeta(config.MutatingWebhookConfigurationName, cfg.GetWebhookAnnotations(), c.buildOwner()...),
+		ObjectMeta: objectMeta(config.MutatingWebhookConfigurationName, cfg.GetWebhookAnnotations(), nil, c.buildOwner()...),
 		Webhooks:   []admissionregistrationv1.MutatingWebhook{},
 	}
 	if c.watchdogCheck() {
@@ -627,6 +663,7 @@ func (c *controller) buildResourceMutatingWebhookConfiguration(ctx context.Conte
 			return nil, err
 		}
 		c.recordPolicyState(config.MutatingWebhookConfigurationName, policies...)
+		var registered []kyvernov1.PolicyInterface
 		for _, p := range policies {
 			if p.AdmissionProcessingEnabled() {
 				spec := p.GetSpec()
@@ -636,9 +673,16 @@ func (c *controller) buildResourceMutatingWebhookConfiguration(ctx context.Conte
 					} else {
 						c.mergeWebhook(fail, p, false)
 					}
+					registered = append(registered, p)
 				}
 			}
 		}
+		result.ObjectMeta = objectMeta(
+			config.MutatingWebhookConfigurationName,
+			policyMetadata(cfg.GetWebhookAnnotations(), registered, policyutils.GetWebhookAnnotations),
+			policyMetadata(nil, registered, policyutils.GetWebhookLabels),
+			c.buildOwner()...,
+		)
 		webhookCfg := config.WebhookConfig{}
 		webhookCfgs := cfg.GetWebhooks()
 		if len(webhookCfgs) > 0 {
@@ -694,7 +738,7 @@ func (c *controller) buildDefaultResourceValidatingWebhookConfiguration(_ contex
 		sideEffects = &noneOnDryRun
 	}
 	return &admissionregistrationv1.ValidatingWebhookConfiguration{
-			ObjectMeta: objectMeta(config.ValidatingWebhookConfigurationName, cfg.GetWebhookAnnotations(), c.buildOwner()...),
+			ObjectMeta: objectMeta(config.ValidatingWebhookConfigurationName, cfg.GetWebhookAnnotations(), nil, c.buildOwner()...),
 			Webhooks: []admissionregistrationv1.ValidatingWebhook{{
 				Name:         config.ValidatingWebhookName + "-ignore",
 				ClientConfig: c.clientConfig(caBundle, config.ValidatingWebhookServicePath+"/ignore"),
@@ -742,7 +786,7 @@ func (c *controller) buildDefaultResourceValidatingWebhookConfiguration(_ contex
 
 func (c *controller) buildResourceValidatingWebhookConfiguration(ctx context.Context, cfg config.Configuration, caBundle []byte) (*admissionregistrationv1.ValidatingWebhookConfiguration, error) {
 	result := admissionregistrationv1.ValidatingWebhookConfiguration{
-		ObjectMeta: objectMeta(config.ValidatingWebhookConfigurationName, cfg.GetWebhookAnnotations(), c.buildOwner()...),
+		ObjectMeta: objectMeta(config.ValidatingWebhookConfigurationName, cfg.GetWebhookAnnotations(), nil, c.buildOwner()...),
 		Webhooks:   []admissionregistrationv1.ValidatingWebhook{},
 	}
 	if c.watchdogCheck() {
@@ -753,6 +797,7 @@ func (c *controller) buildResourceValidatingWebhookConfiguration(ctx context.Con
 			return nil, err
 		}
 		c.recordPolicyState(config.ValidatingWebhookConfigurationName, policies...)
+		var registered []kyvernov1.PolicyInterface
 		for _, p := range policies {
 			if p.AdmissionProcessingEnabled() {
 				spec := p.GetSpec()
@@ -762,9 +807,16 @@ func (c *controller) buildResourceValidatingWebhookConfiguration(ctx context.Con
 					} else {
 						c.mergeWebhook(fail, p, true)
 					}
+					registered = append(registered, p)
 				}
 			}
 		}
+		result.ObjectMeta = objectMeta(
+			config.ValidatingWebhookConfigurationName,
+			policyMetadata(cfg.GetWebhookAnnotations(), registered, policyutils.GetWebhookAnnotations),
+			policyMetadata(nil, registered, policyutils.GetWebhookLabels),
+			c.buildOwner()...,
+		)
 		webhookCfg := config.WebhookConfig{}
 		webhookCfgs := cfg.GetWebhooks()
 		if len(webhookCfgs) > 0 {
@@ -868,6 +920,9 @@ func (c *controller) mergeWebhook(dst *webhook, policy kyvernov1.PolicyInterface
 			gvrsList = append(gvrsList, schema.GroupVersionResource{Group: group, Version: version, Resource: "*"})
 		} else if kind == "*" && subresource != "" {
 			gvrsList = append(gvrsList, schema.GroupVersionResource{Group: group, Version: version, Resource: "*/" + subresource})
+		} else if jsonpayload.IsPayloadGroup(group) {
+			// payloads are validated by the json validation endpoint, not by admission webhooks
+			continue
 		} else {
 			gvrss, err := c.discoveryClient.FindResources(group, version, kind, subresource)
 			if err != nil {
@@ -0,0 +1,134 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+
+	datautils "github.com/kyverno/kyverno/pkg/utils/data"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+const (
+	driftFieldCABundle      = "caBundle"
+	driftFieldRules         = "rules"
+	driftFieldFailurePolicy = "failurePolicy"
+	// driftFieldWebhooks covers added or removed webhooks and changes in other webhook fields
+	driftFieldWebhooks = "webhooks"
+)
+
+// webhookFields holds the fields of a webhook owned by kyverno
+type webhookFields struct {
+	caBundle      []byte
+	rules         []admissionregistrationv1.RuleWithOperations
+	failurePolicy *admissionregistrationv1.FailurePolicyType
+	// other holds the webhook without the fields above
+	other interface{}
+}
+
+// appliedWebhookConfiguration is the state of a webhook configuration last written by kyverno
+type appliedWebhookConfiguration struct {
+	// resourceVersion is the resource version written by kyverno
+	resourceVersion string
+	// previousResourceVersion is the resource version replaced by kyverno, used to ignore stale cache entries
+	previousResourceVersion string
+	webhooks                map[string]webhookFields
+}
+
+func validatingWebhookFields(webhooks []admissionregistrationv1.ValidatingWebhook) map[string]webhookFields {
+	out := make(map[string]webhookFields, len(webhooks))
+	for i := range webhooks {
+		webhook := webhooks[i].DeepCopy()
+		fields := webhookFields{
+			caBundle:      webhook.ClientConfig.CABundle,
+			rules:         webhook.Rules,
+			failurePolicy: webhook.FailurePolicy,
+		}
+		webhook.ClientConfig.CABundle, webhook.Rules, webhook.FailurePolicy = nil, nil, nil
+		fields.other = *webhook
+		out[webhook.Name] = fields
+	}
+	return out
+}
+
+func mutatingWebhookFields(webhooks []admissionregistrationv1.MutatingWebhook) map[string]webhookFields {
+	out := make(map[string]webhookFields, len(webhooks))
+	for i := range webhooks {
+		webhook := webhooks[i].DeepCopy()
+		fields := webhookFields{
+			caBundle:      webhook.ClientConfig.CABundle,
+			rules:         webhook.Rules,
+			failurePolicy: webhook.FailurePolicy,
+		}
+		webhook.ClientConfig.CABundle, webhook.Rules, webhook.FailurePolicy = nil, nil, nil
+		fields.other = *webhook
+		out[webhook.Name] = fields
+	}
+	return out
+}
+
+// driftedFields returns the kyverno owned fields that differ between the expected and observed webhooks
+func driftedFields(expected, observed map[string]webhookFields) []string {
+	drifted := sets.New[string]()
+	for name, e := range expected {
+		o, ok := observed[name]
+		if !ok {
+			drifted.Insert(driftFieldWebhooks)
+			continue
+		}
+		if !bytes.Equal(e.caBundle, o.caBundle) {
+			drifted.Insert(driftFieldCABundle)
+		}
+		if !datautils.DeepEqual(e.rules, o.rules) {
+			drifted.Insert(driftFieldRules)
+		}
+		if !datautils.DeepEqual(e.failurePolicy, o.failurePolicy) {
+			drifted.Insert(driftFieldFailurePolicy)
+		}
+		if !datautils.DeepEqual(e.other, o.other) {
+			drifted.Insert(driftFieldWebhooks)
+		}
+	}
+	for name := range observed {
+		if _, ok := expected[name]; !ok {
+			drifted.Insert(driftFieldWebhooks)
+		}
+	}
+	return sets.List(drifted)
+}
+
+// checkDrift compares the observed webhook configuration with the one last written by kyverno and reports drifted fields
+func (c *controller) checkDrift(ctx context.Context, name, resourceVersion string, observed map[string]webhookFields) []string {
+	c.lock.Lock()
+	applied, ok := c.applied[name]
+	c.lock.Unlock()
+	if !ok || resourceVersion == applied.resourceVersion || resourceVersion == applied.previousResourceVersion {
+		return nil
+	}
+	drifted := driftedFields(applied.webhooks, observed)
+	if len(drifted) > 0 {
+		logger.Info("webhook configuration drift detected, repairing", "name", name, "fields", drifted)
+		if c.driftMetric != nil {
+			for _, field := range drifted {
+				c.driftMetric.Add(ctx, 1, metric.WithAttributes(
+					attribute.String("webhook_configuration", name),
+					attribute.String("field", field),
+				))
+			}
+		}
+	}
+	return drifted
+}
+
+// recordApplied records the state of a webhook configuration written by kyverno
+func (c *controller) recordApplied(name, previousResourceVersion, resourceVersion string, webhooks map[string]webhookFields) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.applied[name] = appliedWebhookConfiguration{
+		resourceVersion:         resourceVersion,
+		previousResourceVersion: previousResourceVersion,
+		webhooks:                webhooks,
+	}
+}
@@ -0,0 +1,65 @@
+package webhook
+
+import (
+	"testing"
+
+	"gotest.tools/assert"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+)
+
+func Test_driftedFields(t *testing.T) {
+	webhook := admissionregistrationv1.ValidatingWebhook{
+		Name: "validate.kyverno.svc",
+		ClientConfig: admissionregistrationv1.WebhookClientConfig{
+			CABundle: []byte("ca"),
+		},
+		Rules: []admissionregistrationv1.RuleWithOperations{{
+			Operations: []admissionregistrationv1.OperationType{admissionregistrationv1.Create},
+		}},
+		FailurePolicy: &fail,
+		SideEffects:   &none,
+	}
+	expected := validatingWebhookFields([]admissionregistrationv1.ValidatingWebhook{webhook})
+	tests := []struct {
+		name   string
+		mutate func(*admissionregistrationv1.ValidatingWebhook)
+		want   []string
+	}{{
+		name:   "no drift",
+		mutate: func(*admissionregistrationv1.ValidatingWebhook) {},
+		want:   []string{},
+	}, {
+		name: "ca bundle",
+		mutate: func(w *admissionregistrationv1.ValidatingWebhook) {
+			w.ClientConfig.CABundle = []byte("other")
+		},
+		want: []string{driftFieldCABundle},
+	}, {
+		name: "rules and failure policy",
+		mutate: func(w *admissionregistrationv1.ValidatingWebhook) {
+			w.Rules = nil
+			w.FailurePolicy = &ignore
+		},
+		want: []string{driftFieldFailurePolicy, driftFieldRules},
+	}, {
+		name: "other fields",
+		mutate: func(w *admissionregistrationv1.ValidatingWebhook) {
+			w.SideEffects = &noneOnDryRun
+		},
+		want: []string{driftFieldWebhooks},
+	}, {
+		name: "renamed webhook",
+		mutate: func(w *admissionregistrationv1.ValidatingWebhook) {
+			w.Name = "other.kyverno.svc"
+		},
+		want: []string{driftFieldWebhooks},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			observed := *webhook.DeepCopy()
+			tt.mutate(&observed)
+			got := driftedFields(expected, validatingWebhookFields([]admissionregistrationv1.ValidatingWebhook{observed}))
+			assert.DeepEqual(t, got, tt.want)
+		})
+	}
+}
@@ -84,17 +84,122 @@ func (wh *webhook) isEmpty() bool {
 	return len(wh.rules) == 0
 }
 
-func objectMeta(name string, annotations map[string]string, owner ...metav1.OwnerReference) metav1.ObjectMeta {
+func objectMeta(name string, annotations map[string]string, labels map[string]string, owner ...metav1.OwnerReference) metav1.ObjectMeta {
+	var ownedAnnotations map[string]string
+	if len(annotations) > 0 {
+		// keep track of the annotations set by kyverno so that they can be removed when not configured anymore
+		ownedAnnotations = make(map[string]string, len(annotations)+2)
+		for key, value := range annotations {
+			ownedAnnotations[key] = value
+		}
+		ownedAnnotations[kyverno.AnnotationWebhookOwnedAnnotations] = joinKeys(annotations)
+	}
+	ownedLabels := map[string]string{
+		kyverno.LabelWebhookManagedBy: kyverno.ValueKyvernoApp,
+	}
+	if len(labels) > 0 {
+		for key, value := range labels {
+			ownedLabels[key] = value
+		}
+		// keep track of the labels set by kyverno so that they can be removed when not configured anymore
+		if ownedAnnotations == nil {
+			ownedAnnotations = make(map[string]string, 1)
+		}
+		ownedAnnotations[kyverno.AnnotationWebhookOwnedLabels] = joinKeys(labels)
+	}
 	return metav1.ObjectMeta{
-		Name: name,
-		Labels: map[string]string{
-			kyverno.LabelWebhookManagedBy: kyverno.ValueKyvernoApp,
-		},
-		Annotations:     annotations,
+		Name:            name,
+		Labels:          ownedLabels,
+		Annotations:     ownedAnnotations,
 		OwnerReferences: owner,
 	}
 }
 
+func joinKeys(m map[string]string) string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	return strings.Join(keys, ",")
+}
+
+// policyMetadata merges the annotations or labels declared by the policies onto the configured ones,
+// configured values take precedence and policies are merged in order of their namespace and name (the first value wins)
+func policyMetadata(configured map[string]string, policies []kyvernov1.PolicyInterface, get func(kyvernov1.PolicyInterface) (map[string]string, error)) map[string]string {
+	policies = slices.Clone(policies)
+	slices.SortFunc(policies, func(a, b kyvernov1.PolicyInterface) bool {
+		if a.GetNamespace() != b.GetNamespace() {
+			return a.GetNamespace() < b.GetNamespace()
+		}
+		return a.GetName() < b.GetName()
+	})
+	var out map[string]string
+	if len(configured) > 0 {
+		out = make(map[string]string, len(configured))
+		for key, value := range configured {
+			out[key] = value
+		}
+	}
+	for _, policy := range policies {
+		metadata, err := get(policy)
+		if err != nil {
+			logger.Error(err, "failed to get webhook metadata of policy", "namespace", policy.GetNamespace(), "name", policy.GetName())
+			continue
+		}
+		for key, value := range metadata {
+			if out == nil {
+				out = map[string]string{}
+			}
+			if _, ok := out[key]; !ok {
+				out[key] = value
+			}
+		}
+	}
+	return out
+}
+
+// mergeMetadata sets the labels and annotations owned by kyverno on the observed object.
+// Labels and annotations added by users are preserved, labels and annotations previously set by kyverno
+// that are not desired anymore are removed.
+func mergeMetadata(observed, desired metav1.Object) {
+	desiredLabels := desired.GetLabels()
+	desiredAnnotations := desired.GetAnnotations()
+	labels := observed.GetLabels()
+	annotations := observed.GetAnnotations()
+	if owned, ok := annotations[kyverno.AnnotationWebhookOwnedLabels]; ok {
+		for _, key := range strings.Split(owned, ",") {
+			if _, ok := desiredLabels[key]; !ok {
+				delete(labels, key)
+			}
+		}
+	}
+	if labels == nil && len(desiredLabels) > 0 {
+		labels = make(map[string]string, len(desiredLabels))
+	}
+	for key, value := range desiredLabels {
+		labels[key] = value
+	}
+	observed.SetLabels(labels)
+	if owned, ok := annotations[kyverno.AnnotationWebhookOwnedAnnotations]; ok {
+		for _, key := range append(strings.Split(owned, ","), kyverno.AnnotationWebhookOwnedAnnotations) {
+			if _, ok := desiredAnnotations[key]; !ok {
+				delete(annotations, key)
+			}
+		}
+	}
+	if _, ok := desiredAnnotations[kyverno.AnnotationWebhookOwnedLabels]; !ok {
+		delete(annotations, kyverno.AnnotationWebhookOwnedLabels)
+	}
+	if annotations == nil && len(desiredAnnotations) > 0 {
+		annotations = make(map[string]string, len(desiredAnnotations))
+	}
+	for key, value := range desiredAnnotations {
+		annotations[key] = value
+	}
+	observed.SetAnnotations(annotations)
+}
+
 func setRuleCount(rules []kyvernov1.Rule, status *kyvernov1.PolicyStatus) {
 	validateCount, generateCount, mutateCount, verifyImagesCount := 0, 0, 0, 0
 	for _, rule := range rules {
@@ -4,10 +4,13 @@ import (
 	"encoding/json"
 	"testing"
 
+	kyvernoapi "github.com/kyverno/kyverno/api/kyverno"
 	kyverno "github.com/kyverno/kyverno/api/kyverno/v1"
 	"github.com/kyverno/kyverno/pkg/autogen"
+	policyutils "github.com/kyverno/kyverno/pkg/utils/policy"
 	"gotest.tools/assert"
 	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 )
 
@@ -159,3 +162,130 @@ func Test_RuleCount(t *testing.T) {
 	assert.Equal(t, status.RuleCount.Mutate, 1)
 	assert.Equal(t, status.RuleCount.VerifyImages, 2)
 }
+
+func Test_mergeMetadata(t *testing.T) {
+	tests := []struct {
+		name            string
+		observed        metav1.ObjectMeta
+		desired         metav1.ObjectMeta
+		wantLabels      map[string]string
+		wantAnnotations map[string]string
+	}{{
+		name: "user metadata is preserved",
+		observed: metav1.ObjectMeta{
+			Labels:      map[string]string{"team": "platform"},
+			Annotations: map[string]string{"owner": "platform"},
+		},
+		desired: objectMeta("test", map[string]string{"foo": "bar"}, nil),
+		wantLabels: map[string]string{
+			"team":                           "platform",
+			kyvernoapi.LabelWebhookManagedBy: kyvernoapi.ValueKyvernoApp,
+		},
+		wantAnnotations: map[string]string{
+			"owner": "platform",
+			"foo":   "bar",
+			kyvernoapi.AnnotationWebhookOwnedAnnotations: "foo",
+		},
+	}, {
+		name: "annotations not configured anymore are removed",
+		observed: metav1.ObjectMeta{
+			Labels: map[string]string{kyvernoapi.LabelWebhookManagedBy: kyvernoapi.ValueKyvernoApp},
+			Annotations: map[string]string{
+				"owner": "platform",
+				"foo":   "bar",
+				"baz":   "qux",
+				kyvernoapi.AnnotationWebhookOwnedAnnotations: "baz,foo",
+			},
+		},
+		desired:    objectMeta("test", map[string]string{"foo": "bar"}, nil),
+		wantLabels: map[string]string{kyvernoapi.LabelWebhookManagedBy: kyvernoapi.ValueKyvernoApp},
+		wantAnnotations: map[string]string{
+			"owner": "platform",
+			"foo":   "bar",
+			kyvernoapi.AnnotationWebhookOwnedAnnotations: "foo",
+		},
+	}, {
+		name: "no annotations configured",
+		observed: metav1.ObjectMeta{
+			Annotations: map[string]string{
+				"foo": "bar",
+				kyvernoapi.AnnotationWebhookOwnedAnnotations: "foo",
+			},
+		},
+		desired:         objectMeta("test", nil, nil),
+		wantLabels:      map[string]string{kyvernoapi.LabelWebhookManagedBy: kyvernoapi.ValueKyvernoApp},
+		wantAnnotations: map[string]string{},
+	}, {
+		name: "labels not configured anymore are removed",
+		observed: metav1.ObjectMeta{
+			Labels: map[string]string{
+				"team":                           "platform",
+				"cost-center":                    "platform",
+				"tier":                           "system",
+				kyvernoapi.LabelWebhookManagedBy: kyvernoapi.ValueKyvernoApp,
+			},
+			Annotations: map[string]string{
+				kyvernoapi.AnnotationWebhookOwnedLabels: "cost-center,tier",
+			},
+		},
+		desired: objectMeta("test", nil, map[string]string{"tier": "critical"}),
+		wantLabels: map[string]string{
+			"team":                           "platform",
+			"tier":                           "critical",
+			kyvernoapi.LabelWebhookManagedBy: kyvernoapi.ValueKyvernoApp,
+		},
+		wantAnnotations: map[string]string{
+			kyvernoapi.AnnotationWebhookOwnedLabels: "tier",
+		},
+	}, {
+		name: "no labels configured",
+		observed: metav1.ObjectMeta{
+			Labels: map[string]string{
+				"tier":                           "system",
+				kyvernoapi.LabelWebhookManagedBy: kyvernoapi.ValueKyvernoApp,
+			},
+			Annotations: map[string]string{
+				kyvernoapi.AnnotationWebhookOwnedLabels: "tier",
+			},
+		},
+		desired:         objectMeta("test", nil, nil),
+		wantLabels:      map[string]string{kyvernoapi.LabelWebhookManagedBy: kyvernoapi.ValueKyvernoApp},
+		wantAnnotations: map[string]string{},
+	}, {
+		name:            "nothing to merge",
+		observed:        metav1.ObjectMeta{},
+		desired:         metav1.ObjectMeta{},
+		wantLabels:      nil,
+		wantAnnotations: nil,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mergeMetadata(&tt.observed, &tt.desired)
+			assert.DeepEqual(t, tt.observed.Labels, tt.wantLabels)
+			assert.DeepEqual(t, tt.observed.Annotations, tt.wantAnnotations)
+		})
+	}
+}
+
+func Test_policyMetadata(t *testing.T) {
+	newPolicy := func(name string, annotations string) kyverno.PolicyInterface {
+		return &kyverno.ClusterPolicy{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        name,
+				Annotations: map[string]string{kyvernoapi.AnnotationWebhookAnnotations: annotations},
+			},
+		}
+	}
+	policies := []kyverno.PolicyInterface{
+		newPolicy("b", `{"argocd.argoproj.io/compare-options":"IgnoreExtraneous","owner":"b"}`),
+		newPolicy("a", `{"owner":"a"}`),
+		newPolicy("c", `not json`),
+	}
+	annotations := policyMetadata(map[string]string{"team": "platform"}, policies, policyutils.GetWebhookAnnotations)
+	assert.DeepEqual(t, annotations, map[string]string{
+		"team":                               "platform",
+		"owner":                              "a",
+		"argocd.argoproj.io/compare-options": "IgnoreExtraneous",
+	})
+	assert.Assert(t, policyMetadata(nil, nil, policyutils.GetWebhookLabels) == nil)
+}
@@ -0,0 +1,183 @@
+package webhookhealth
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/kyverno/kyverno/api/kyverno"
+	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	"github.com/kyverno/kyverno/pkg/client/clientset/versioned"
+	"github.com/kyverno/kyverno/pkg/config"
+	"github.com/kyverno/kyverno/pkg/controllers"
+	"github.com/kyverno/kyverno/pkg/metrics"
+	controllerutils "github.com/kyverno/kyverno/pkg/utils/controller"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	coordinationv1 "k8s.io/api/coordination/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/uuid"
+	"k8s.io/apimachinery/pkg/util/wait"
+	coordinationv1client "k8s.io/client-go/kubernetes/typed/coordination/v1"
+	"k8s.io/client-go/util/retry"
+)
+
+const (
+	// Workers is the number of workers for this controller
+	Workers        = 1
+	ControllerName = "webhook-health-controller"
+	leaseName      = "kyverno-health"
+)
+
+var errNoCanaryResponse = errors.New("the canary request was not answered by the webhook")
+
+type controller struct {
+	// clients
+	leaseClient   coordinationv1client.LeaseInterface
+	kyvernoClient versioned.Interface
+
+	// config
+	interval time.Duration
+	timeout  time.Duration
+
+	// metrics
+	durationMetric metric.Float64Histogram
+	checksMetric   metric.Int64Counter
+}
+
+// NewController returns a controller periodically sending a canary admission request to the verify webhook, a dry run
+// update of the health lease carrying a nonce the webhook echoes back. The outcome and end to end latency of the
+// request are recorded in metrics and in the status of the WebhookHealth resource named after the Kyverno service.
+func NewController(
+	leaseClient coordinationv1client.LeaseInterface,
+	kyvernoClient versioned.Interface,
+	interval time.Duration,
+	timeout time.Duration,
+) controllers.Controller {
+	meter := otel.GetMeterProvider().Meter(metrics.MeterName)
+	durationMetric, err := meter.Float64Histogram(
+		"kyverno_webhook_canary_duration_seconds",
+		metric.WithDescription("can be used to track the end to end latencies (in seconds) of the canary admission requests Kyverno sends to its own webhook through the API server"),
+	)
+	if err != nil {
+		logger.Error(err, "Failed to create instrument, kyverno_webhook_canary_duration_seconds")
+	}
+	checksMetric, err := meter.Int64Counter(
+		"kyverno_webhook_canary_checks",
+		metric.WithDescription("can be used to track the number of canary admission requests Kyverno sent to its own webhook, and whether they were answered with the expected response"),
+	)
+	if err != nil {
+		logger.Error(err, "Failed to create instrument, kyverno_webhook_canary_checks_total")
+	}
+	return &controller{
+		leaseClient:    leaseClient,
+		kyvernoClient:  kyvernoClient,
+		interval:       interval,
+		timeout:        timeout,
+		durationMetric: durationMetric,
+		checksMetric:   checksMetric,
+	}
+}
+
+func (c *controller) Run(ctx context.Context, _ int) {
+	logger.Info("starting ...", "interval", c.interval)
+	defer logger.Info("stopped")
+	wait.UntilWithContext(ctx, func(ctx context.Context) {
+		c.check(ctx, logger)
+	}, c.interval)
+}
+
+func (c *controller) check(ctx context.Context, logger logr.Logger) {
+	now := metav1.Now()
+	latency, probeErr := c.probe(ctx)
+	result := "success"
+	if probeErr != nil {
+		result = "failure"
+		logger.Error(probeErr, "webhook canary request failed", "latency", latency)
+	} else {
+		logger.V(4).Info("webhook canary request succeeded", "latency", latency)
+	}
+	attributes := metric.WithAttributes(attribute.String("result", result))
+	if c.durationMetric != nil {
+		c.durationMetric.Record(ctx, latency.Seconds(), attributes)
+	}
+	if c.checksMetric != nil {
+		c.checksMetric.Add(ctx, 1, attributes)
+	}
+	if err := c.updateStatus(ctx, now, latency, probeErr); err != nil {
+		logger.Error(err, "failed to update webhook health status")
+	}
+}
+
+// probe sends a dry run update of the health lease with a new nonce and checks the webhook echoed it back,
+// it returns the latency of the update request
+func (c *controller) probe(ctx context.Context) (time.Duration, error) {
+	var latency time.Duration
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		lease, err := c.leaseClient.Get(ctx, leaseName, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		nonce := string(uuid.NewUUID())
+		canary := lease.DeepCopy()
+		if canary.Annotations == nil {
+			canary.Annotations = map[string]string{}
+		}
+		canary.Annotations[kyverno.AnnotationCanaryNonce] = nonce
+		delete(canary.Annotations, kyverno.AnnotationCanaryResponse)
+		ctx, cancel := context.WithTimeout(ctx, c.timeout)
+		defer cancel()
+		start := time.Now()
+		var updated *coordinationv1.Lease
+		updated, err = c.leaseClient.Update(ctx, canary, metav1.UpdateOptions{DryRun: []string{metav1.DryRunAll}})
+		latency = time.Since(start)
+		if err != nil {
+			return err
+		}
+		if updated.Annotations[kyverno.AnnotationCanaryResponse] != nonce {
+			return errNoCanaryResponse
+		}
+		return nil
+	})
+	return latency, err
+}
+
+func (c *controller) updateStatus(ctx context.Context, now metav1.Time, latency time.Duration, probeErr error) error {
+	name := config.KyvernoServiceName()
+	client := c.kyvernoClient.KyvernoV2alpha1().WebhookHealths()
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		latest, err := client.Get(ctx, name, metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			latest, err = client.Create(ctx, &kyvernov2alpha1.WebhookHealth{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: name,
+					Labels: map[string]string{
+						kyverno.LabelAppManagedBy: kyverno.ValueKyvernoApp,
+					},
+				},
+			}, metav1.CreateOptions{})
+		}
+		if err != nil {
+			return err
+		}
+		_, err = controllerutils.UpdateStatus(ctx, latest, client, func(health *kyvernov2alpha1.WebhookHealth) error {
+			health.Status.LastProbeTime = &now
+			health.Status.Latency = &metav1.Duration{Duration: latency}
+			if probeErr == nil {
+				health.Status.Healthy = true
+				health.Status.LastSuccessTime = &now
+				health.Status.ConsecutiveFailures = 0
+				health.Status.LastError = ""
+			} else {
+				health.Status.Healthy = false
+				health.Status.ConsecutiveFailures++
+				health.Status.LastError = probeErr.Error()
+			}
+			return nil
+		})
+		return err
+	})
+}
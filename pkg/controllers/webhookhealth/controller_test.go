@@ -0,0 +1,84 @@
+package webhookhealth
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/kyverno/kyverno/api/kyverno"
+	"github.com/kyverno/kyverno/pkg/client/clientset/versioned/fake"
+	"github.com/kyverno/kyverno/pkg/config"
+	"gotest.tools/assert"
+	coordinationv1 "k8s.io/api/coordination/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	clienttesting "k8s.io/client-go/testing"
+)
+
+func newLeaseClient(echo bool) *kubefake.Clientset {
+	client := kubefake.NewSimpleClientset(&coordinationv1.Lease{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      leaseName,
+			Namespace: config.KyvernoNamespace(),
+		},
+	})
+	// emulate the verify webhook answering dry run updates
+	client.PrependReactor("update", "leases", func(action clienttesting.Action) (bool, runtime.Object, error) {
+		lease := action.(clienttesting.UpdateAction).GetObject().(*coordinationv1.Lease).DeepCopy()
+		if echo {
+			lease.Annotations[kyverno.AnnotationCanaryResponse] = lease.Annotations[kyverno.AnnotationCanaryNonce]
+		}
+		return true, lease, nil
+	})
+	return client
+}
+
+func Test_controller_check(t *testing.T) {
+	tests := []struct {
+		name                string
+		echo                []bool
+		healthy             bool
+		consecutiveFailures int32
+		lastError           string
+	}{{
+		name:    "answered",
+		echo:    []bool{true},
+		healthy: true,
+	}, {
+		name:                "not answered",
+		echo:                []bool{false, false},
+		consecutiveFailures: 2,
+		lastError:           errNoCanaryResponse.Error(),
+	}, {
+		name:    "recovered",
+		echo:    []bool{false, true},
+		healthy: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kyvernoClient := fake.NewSimpleClientset()
+			for _, echo := range tt.echo {
+				leaseClient := newLeaseClient(echo).CoordinationV1().Leases(config.KyvernoNamespace())
+				c := NewController(leaseClient, kyvernoClient, time.Minute, time.Second).(*controller)
+				c.check(context.TODO(), logr.Discard())
+			}
+			health, err := kyvernoClient.KyvernoV2alpha1().WebhookHealths().Get(context.TODO(), config.KyvernoServiceName(), metav1.GetOptions{})
+			assert.NilError(t, err)
+			assert.Equal(t, health.Status.Healthy, tt.healthy)
+			assert.Equal(t, health.Status.ConsecutiveFailures, tt.consecutiveFailures)
+			assert.Equal(t, health.Status.LastError, tt.lastError)
+			assert.Assert(t, health.Status.LastProbeTime != nil)
+			assert.Assert(t, health.Status.Latency != nil)
+			assert.Equal(t, health.Status.LastSuccessTime != nil, tt.healthy)
+		})
+	}
+}
+
+func Test_controller_probe_missingLease(t *testing.T) {
+	leaseClient := kubefake.NewSimpleClientset().CoordinationV1().Leases(config.KyvernoNamespace())
+	c := NewController(leaseClient, fake.NewSimpleClientset(), time.Minute, time.Second).(*controller)
+	_, err := c.probe(context.TODO())
+	assert.ErrorContains(t, err, "not found")
+}
@@ -89,6 +89,9 @@ func (v *cosignVerifier) VerifySignature(ctx context.Context, opts images.Option
 func buildCosignOptions(ctx context.Context, opts images.Options) (*cosign.CheckOpts, error) {
 	var remoteOpts []remote.Option
 	var err error
+	if err := checkOffline(opts); err != nil {
+		return nil, err
+	}
 	signatureAlgorithmMap := map[string]crypto.Hash{
 		"":       crypto.SHA256,
 		"sha256": crypto.SHA256,
@@ -123,7 +126,7 @@ func buildCosignOptions(ctx context.Context, opts images.Options) (*cosign.Check
 			}
 		} else {
 			// this supports Kubernetes secrets and KMS
-			cosignOpts.SigVerifier, err = sigs.PublicKeyFromKeyRef(ctx, opts.Key)
+			cosignOpts.SigVerifier, err = verifierForKeyRef(ctx, opts.Key)
 			if err != nil {
 				return nil, fmt.Errorf("failed to load public key from %s: %w", opts.Key, err)
 			}
@@ -1,9 +1,76 @@
 package cosign
 
-// Register the provider-specific plugins
 import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	sigs "github.com/sigstore/cosign/v2/pkg/signature"
+	"github.com/sigstore/sigstore/pkg/signature"
+	// Register the provider-specific plugins
 	_ "github.com/sigstore/sigstore/pkg/signature/kms/aws"
 	_ "github.com/sigstore/sigstore/pkg/signature/kms/azure"
 	_ "github.com/sigstore/sigstore/pkg/signature/kms/gcp"
 	_ "github.com/sigstore/sigstore/pkg/signature/kms/hashivault"
 )
+
+// kmsVerifierTTL is the duration during which a verifier loaded from a KMS is reused
+const kmsVerifierTTL = 10 * time.Minute
+
+// kmsSchemes are the key reference schemes served by a Key Management System.
+// Credentials are resolved by the provider SDKs from the environment, this supports
+// workload identities (IRSA on AWS, workload identity on GCP and Azure) and Vault tokens.
+var kmsSchemes = []string{"awskms://", "gcpkms://", "azurekms://", "hashivault://"}
+
+// loadVerifier loads the verifier for a key reference, it is a variable to be replaced in tests
+var loadVerifier = sigs.PublicKeyFromKeyRef
+
+type kmsVerifier struct {
+	verifier signature.Verifier
+	expires  time.Time
+}
+
+var kmsVerifiers = struct {
+	sync.Mutex
+	entries map[string]kmsVerifier
+}{
+	entries: map[string]kmsVerifier{},
+}
+
+// IsKMSKeyRef checks whether the key reference points to a key stored in a Key Management System
+func IsKMSKeyRef(keyRef string) bool {
+	for _, scheme := range kmsSchemes {
+		if strings.HasPrefix(keyRef, scheme) {
+			return true
+		}
+	}
+	return false
+}
+
+// verifierForKeyRef loads the verifier for a key reference. Verifiers of KMS keys are cached
+// so that the KMS is not called for every image verification, other references (like Kubernetes
+// secrets) are loaded every time to pick up changes.
+func verifierForKeyRef(ctx context.Context, keyRef string) (signature.Verifier, error) {
+	if !IsKMSKeyRef(keyRef) {
+		return loadVerifier(ctx, keyRef)
+	}
+	now := time.Now()
+	kmsVerifiers.Lock()
+	entry, ok := kmsVerifiers.entries[keyRef]
+	kmsVerifiers.Unlock()
+	if ok && now.Before(entry.expires) {
+		return entry.verifier, nil
+	}
+	verifier, err := loadVerifier(ctx, keyRef)
+	if err != nil {
+		return nil, err
+	}
+	kmsVerifiers.Lock()
+	defer kmsVerifiers.Unlock()
+	kmsVerifiers.entries[keyRef] = kmsVerifier{
+		verifier: verifier,
+		expires:  now.Add(kmsVerifierTTL),
+	}
+	return verifier, nil
+}
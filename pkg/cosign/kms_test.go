@@ -0,0 +1,48 @@
+package cosign
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/sigstore/sigstore/pkg/signature"
+	"gotest.tools/assert"
+)
+
+func TestIsKMSKeyRef(t *testing.T) {
+	assert.Assert(t, IsKMSKeyRef("awskms:///arn:aws:kms:us-east-1:123456789012:alias/cosign"))
+	assert.Assert(t, IsKMSKeyRef("gcpkms://projects/p/locations/global/keyRings/r/cryptoKeys/k/versions/1"))
+	assert.Assert(t, IsKMSKeyRef("azurekms://vault.vault.azure.net/cosign"))
+	assert.Assert(t, IsKMSKeyRef("hashivault://cosign"))
+	assert.Assert(t, !IsKMSKeyRef("k8s://kyverno/cosign"))
+	assert.Assert(t, !IsKMSKeyRef("cosign.pub"))
+}
+
+func TestVerifierForKeyRef(t *testing.T) {
+	defer func(original func(context.Context, string) (signature.Verifier, error)) {
+		loadVerifier = original
+	}(loadVerifier)
+	verifier, err := signature.LoadED25519Verifier(make([]byte, 32))
+	assert.NilError(t, err)
+	calls := map[string]int{}
+	loadVerifier = func(_ context.Context, keyRef string) (signature.Verifier, error) {
+		calls[keyRef]++
+		if keyRef == "gcpkms://missing" {
+			return nil, errors.New("key not found")
+		}
+		return verifier, nil
+	}
+	for i := 0; i < 3; i++ {
+		v, err := verifierForKeyRef(context.TODO(), "awskms:///alias/cosign")
+		assert.NilError(t, err)
+		assert.Equal(t, v, signature.Verifier(verifier))
+		_, err = verifierForKeyRef(context.TODO(), "k8s://kyverno/cosign")
+		assert.NilError(t, err)
+		_, err = verifierForKeyRef(context.TODO(), "gcpkms://missing")
+		assert.ErrorContains(t, err, "key not found")
+	}
+	// kms keys are cached, errors and other references are not
+	assert.Equal(t, calls["awskms:///alias/cosign"], 1)
+	assert.Equal(t, calls["k8s://kyverno/cosign"], 3)
+	assert.Equal(t, calls["gcpkms://missing"], 3)
+}
@@ -0,0 +1,63 @@
+package cosign
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync/atomic"
+
+	"github.com/kyverno/kyverno/pkg/images"
+	"github.com/sigstore/cosign/v2/pkg/blob"
+	"github.com/sigstore/sigstore/pkg/tuf"
+)
+
+// publicGoodRekorURL is the address of the public good Rekor instance
+const publicGoodRekorURL = "https://rekor.sigstore.dev"
+
+// offline prevents image verification from contacting the public good sigstore instances
+var offline atomic.Bool
+
+// InitializeTUF initializes the sigstore TUF client used to fetch the trust roots (Fulcio roots,
+// Rekor and CT log public keys). The mirror defaults to the public good TUF repository and the
+// root, loaded from a file or an URL, defaults to the root embedded in the sigstore library.
+// In offline mode a mirror must be provided and the public good instances are never contacted.
+func InitializeTUF(ctx context.Context, mirror string, root string, offlineMode bool) error {
+	if offlineMode && (mirror == "" || isPublicGoodTUF(mirror)) {
+		return fmt.Errorf("a TUF mirror other than %s must be configured in offline mode", tuf.DefaultRemoteRoot)
+	}
+	offline.Store(offlineMode)
+	if mirror == "" && root == "" {
+		return nil
+	}
+	var rootBytes []byte
+	if root != "" {
+		data, err := blob.LoadFileOrURL(root)
+		if err != nil {
+			return fmt.Errorf("failed to load TUF root %s: %w", root, err)
+		}
+		rootBytes = data
+	}
+	if mirror == "" {
+		mirror = tuf.DefaultRemoteRoot
+	}
+	if err := tuf.Initialize(ctx, mirror, rootBytes); err != nil {
+		return fmt.Errorf("failed to initialize TUF client from %s: %w", mirror, err)
+	}
+	logger.V(2).Info("initialized TUF client", "mirror", mirror, "root", root, "offline", offlineMode)
+	return nil
+}
+
+// checkOffline returns an error if the verification options require a public good instance in offline mode
+func checkOffline(opts images.Options) error {
+	if !offline.Load() {
+		return nil
+	}
+	if !opts.IgnoreTlog && (opts.RekorURL == "" || strings.TrimSuffix(opts.RekorURL, "/") == publicGoodRekorURL) {
+		return fmt.Errorf("the public Rekor instance can't be used in offline mode, configure the URL of a private Rekor instance or ignore the transparency log")
+	}
+	return nil
+}
+
+func isPublicGoodTUF(mirror string) bool {
+	return strings.TrimSuffix(mirror, "/") == tuf.DefaultRemoteRoot
+}
@@ -0,0 +1,31 @@
+package cosign
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kyverno/kyverno/pkg/images"
+	"gotest.tools/assert"
+)
+
+func TestInitializeTUFOfflineRequiresMirror(t *testing.T) {
+	t.Cleanup(func() { offline.Store(false) })
+	err := InitializeTUF(context.TODO(), "", "", true)
+	assert.ErrorContains(t, err, "a TUF mirror other than https://tuf-repo-cdn.sigstore.dev must be configured in offline mode")
+	err = InitializeTUF(context.TODO(), "https://tuf-repo-cdn.sigstore.dev/", "", true)
+	assert.ErrorContains(t, err, "must be configured in offline mode")
+	assert.Equal(t, offline.Load(), false)
+	// nothing to initialize without mirror and root
+	assert.NilError(t, InitializeTUF(context.TODO(), "", "", false))
+}
+
+func TestCheckOffline(t *testing.T) {
+	t.Cleanup(func() { offline.Store(false) })
+	opts := images.Options{RekorURL: "https://rekor.sigstore.dev"}
+	assert.NilError(t, checkOffline(opts))
+	offline.Store(true)
+	assert.ErrorContains(t, checkOffline(opts), "the public Rekor instance can't be used in offline mode")
+	assert.ErrorContains(t, checkOffline(images.Options{RekorURL: "https://rekor.sigstore.dev/"}), "offline mode")
+	assert.NilError(t, checkOffline(images.Options{RekorURL: "https://rekor.example.com"}))
+	assert.NilError(t, checkOffline(images.Options{RekorURL: "https://rekor.sigstore.dev", IgnoreTlog: true}))
+}
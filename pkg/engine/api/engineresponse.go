@@ -19,12 +19,16 @@ type EngineResponse struct {
 	policy GenericPolicy
 	// namespaceLabels given by policy context
 	namespaceLabels map[string]string
+	// namespaceAnnotations given by policy context
+	namespaceAnnotations map[string]string
 	// PatchedResource is the resource patched with the engine action changes
 	PatchedResource unstructured.Unstructured
 	// PolicyResponse contains the engine policy response
 	PolicyResponse PolicyResponse
 	// stats contains engine statistics
 	stats ExecutionStats
+	// policyCacheRevision is the revision of the policy cache snapshot the policy was taken from
+	policyCacheRevision uint64
 }
 
 func resource(policyContext PolicyContext) unstructured.Unstructured {
@@ -40,7 +44,7 @@ func NewEngineResponseFromPolicyContext(policyContext PolicyContext) EngineRespo
 		resource(policyContext),
 		NewKyvernoPolicy(policyContext.Policy()),
 		policyContext.NamespaceLabels(),
-	)
+	).WithNamespaceAnnotations(policyContext.NamespaceAnnotations()).WithPolicyCacheRevision(policyContext.PolicyCacheRevision())
 }
 
 func NewEngineResponse(
@@ -71,6 +75,11 @@ func (r EngineResponse) WithStats(stats ExecutionStats) EngineResponse {
 	return r
 }
 
+// Stats returns the execution statistics of the policy
+func (er EngineResponse) Stats() ExecutionStats {
+	return er.stats
+}
+
 func (er EngineResponse) WithPatchedResource(patchedResource unstructured.Unstructured) EngineResponse {
 	er.PatchedResource = patchedResource
 	return er
@@ -85,6 +94,22 @@ func (er *EngineResponse) NamespaceLabels() map[string]string {
 	return er.namespaceLabels
 }
 
+func (er EngineResponse) WithNamespaceAnnotations(namespaceAnnotations map[string]string) EngineResponse {
+	er.namespaceAnnotations = namespaceAnnotations
+	return er
+}
+
+func (er EngineResponse) WithPolicyCacheRevision(policyCacheRevision uint64) EngineResponse {
+	er.policyCacheRevision = policyCacheRevision
+	return er
+}
+
+// PolicyCacheRevision returns the revision of the policy cache snapshot the policy was evaluated from,
+// it is zero when the policy was not taken from the policy cache
+func (er EngineResponse) PolicyCacheRevision() uint64 {
+	return er.policyCacheRevision
+}
+
 func (er *EngineResponse) Policy() GenericPolicy {
 	return er.policy
 }
@@ -146,6 +171,38 @@ func (er EngineResponse) GetPatches() []jsonpatch.JsonPatchOperation {
 	return patches
 }
 
+// AppliedPatch is a JSON patch operation applied to the resource along with the policy and rule that produced it
+type AppliedPatch struct {
+	// Policy is the policy key (`name` for cluster policies, `namespace/name` for policies)
+	Policy string `json:"policy"`
+	// Rule is the name of the mutate rule
+	Rule string `json:"rule"`
+	// Operation is the JSON patch operation
+	Operation string `json:"op"`
+	// Path is the JSON patch path
+	Path string `json:"path"`
+}
+
+// GetAppliedPatches returns the patches applied by the mutate rules along with their provenance
+func (er EngineResponse) GetAppliedPatches() []AppliedPatch {
+	var applied []AppliedPatch
+	policy := er.Policy().GetName()
+	if ns := er.Policy().GetNamespace(); ns != "" {
+		policy = ns + "/" + policy
+	}
+	for _, rule := range er.PolicyResponse.Rules {
+		for _, patch := range rule.Patches() {
+			applied = append(applied, AppliedPatch{
+				Policy:    policy,
+				Rule:      rule.Name(),
+				Operation: patch.Operation,
+				Path:      patch.Path,
+			})
+		}
+	}
+	return applied
+}
+
 // GetFailedRules returns failed rules
 func (er EngineResponse) GetFailedRules() []string {
 	return er.getRules(func(rule RuleResponse) bool { return rule.HasStatus(RuleStatusFail, RuleStatusError) })
@@ -198,6 +255,10 @@ func (er EngineResponse) GetValidationFailureAction() kyvernov1.ValidationFailur
 	if polType := pol.GetType(); polType == ValidatingAdmissionPolicyType {
 		return ""
 	}
+	// the namespace annotation takes precedence over the policy settings
+	if action, ok := kyvernov1.GetNamespaceValidationFailureAction(er.namespaceAnnotations, pol.GetName()); ok {
+		return action
+	}
 	spec := pol.GetPolicy().(kyvernov1.PolicyInterface).GetSpec()
 	for _, v := range spec.ValidationFailureActionOverrides {
 		if !v.Action.IsValid() {
@@ -223,3 +284,21 @@ func (er EngineResponse) GetValidationFailureAction() kyvernov1.ValidationFailur
 	}
 	return spec.ValidationFailureAction
 }
+
+// GetRollout returns the rollout of the policy, nil if the policy doesn't define one.
+// If the policy is of type ValidatingAdmissionPolicy, nil is returned.
+func (er EngineResponse) GetRollout() *kyvernov1.Rollout {
+	pol := er.Policy()
+	if polType := pol.GetType(); polType == ValidatingAdmissionPolicyType {
+		return nil
+	}
+	return pol.GetPolicy().(kyvernov1.PolicyInterface).GetSpec().Rollout
+}
+
+// GetRolloutKey returns the key used to select the requests enforced by the policy rollout,
+// the key identifies both the policy and the resource so that a given resource is consistently selected
+func (er EngineResponse) GetRolloutKey() string {
+	pol := er.Policy()
+	resource := er.Resource
+	return fmt.Sprintf("%s/%s/%s/%s/%s", pol.GetNamespace(), pol.GetName(), resource.GetKind(), resource.GetNamespace(), resource.GetName())
+}
@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"gomodules.xyz/jsonpatch/v2"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 )
@@ -681,10 +682,11 @@ func TestEngineResponse_GetValidationFailureAction(t *testing.T) {
 	resource := unstructured.Unstructured{}
 	resource.SetNamespace("foo")
 	type fields struct {
-		PatchedResource unstructured.Unstructured
-		GenericPolicy   GenericPolicy
-		PolicyResponse  PolicyResponse
-		namespaceLabels map[string]string
+		PatchedResource      unstructured.Unstructured
+		GenericPolicy        GenericPolicy
+		PolicyResponse       PolicyResponse
+		namespaceLabels      map[string]string
+		namespaceAnnotations map[string]string
 	}
 	tests := []struct {
 		name   string
@@ -892,13 +894,50 @@ func TestEngineResponse_GetValidationFailureAction(t *testing.T) {
 			}),
 		},
 		want: kyvernov1.Audit,
+	}, {
+		fields: fields{
+			PatchedResource: resource,
+			GenericPolicy: NewKyvernoPolicy(&kyvernov1.ClusterPolicy{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "require-labels",
+				},
+				Spec: kyvernov1.Spec{
+					ValidationFailureAction: kyvernov1.Audit,
+					ValidationFailureActionOverrides: []kyvernov1.ValidationFailureActionOverride{{
+						Action:     kyvernov1.Audit,
+						Namespaces: []string{"foo"},
+					}},
+				},
+			}),
+			namespaceAnnotations: map[string]string{
+				"kyverno.io/validation-failure-actions": "require-*=Enforce",
+			},
+		},
+		want: kyvernov1.Enforce,
+	}, {
+		fields: fields{
+			PatchedResource: resource,
+			GenericPolicy: NewKyvernoPolicy(&kyvernov1.ClusterPolicy{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "require-labels",
+				},
+				Spec: kyvernov1.Spec{
+					ValidationFailureAction: kyvernov1.Enforce,
+				},
+			}),
+			namespaceAnnotations: map[string]string{
+				"kyverno.io/validation-failure-actions": "disallow-latest=Audit",
+			},
+		},
+		want: kyvernov1.Enforce,
 	}}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			er := EngineResponse{
-				PatchedResource: tt.fields.PatchedResource,
-				PolicyResponse:  tt.fields.PolicyResponse,
-				namespaceLabels: tt.fields.namespaceLabels,
+				PatchedResource:      tt.fields.PatchedResource,
+				PolicyResponse:       tt.fields.PolicyResponse,
+				namespaceLabels:      tt.fields.namespaceLabels,
+				namespaceAnnotations: tt.fields.namespaceAnnotations,
 			}.WithPolicy(tt.fields.GenericPolicy)
 			if got := er.GetValidationFailureAction(); !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("EngineResponse.GetValidationFailureAction() = %v, want %v", got, tt.want)
@@ -1069,3 +1108,30 @@ func TestEngineResponse_GetResourceSpec(t *testing.T) {
 		})
 	}
 }
+
+func TestEngineResponse_GetAppliedPatches(t *testing.T) {
+	policy := NewKyvernoPolicy(&kyvernov1.Policy{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "foo",
+			Name:      "bar",
+		},
+	})
+	er := NewEngineResponse(unstructured.Unstructured{}, policy, nil)
+	er.PolicyResponse.Rules = []RuleResponse{
+		*RulePass("unpatched", Mutation, ""),
+		*RulePass("rule", Mutation, "").WithPatches(jsonpatch.JsonPatchOperation{
+			Operation: "add",
+			Path:      "/metadata/labels",
+			Value:     map[string]interface{}{"app": "bar"},
+		}),
+	}
+	want := []AppliedPatch{{
+		Policy:    "foo/bar",
+		Rule:      "rule",
+		Operation: "add",
+		Path:      "/metadata/labels",
+	}}
+	if got := er.GetAppliedPatches(); !reflect.DeepEqual(got, want) {
+		t.Errorf("EngineResponse.GetAppliedPatches() = %v, want %v", got, want)
+	}
+}
@@ -19,6 +19,8 @@ type PolicyContext interface {
 	AdmissionInfo() kyvernov1beta1.RequestInfo
 	Operation() kyvernov1.AdmissionOperation
 	NamespaceLabels() map[string]string
+	NamespaceAnnotations() map[string]string
+	PolicyCacheRevision() uint64
 	RequestResource() metav1.GroupVersionResource
 	ResourceKind() (schema.GroupVersionKind, string)
 	AdmissionOperation() bool
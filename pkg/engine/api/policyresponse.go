@@ -16,10 +16,17 @@ func (pr *PolicyResponse) Add(stats ExecutionStats, responses ...RuleResponse) {
 			pr.stats.rulesAppliedCount++
 		} else if status == RuleStatusError {
 			pr.stats.rulesErrorCount++
+		} else if status == RuleStatusSkip {
+			pr.stats.skippedRules = append(pr.stats.skippedRules, SkippedRule{Rule: response.Name(), Type: response.RuleType(), Reason: response.SkipReason()})
 		}
 	}
 }
 
+// AddNotMatched records a rule that did not match the resource, no rule response is added for such rules
+func (pr *PolicyResponse) AddNotMatched(rule string, ruleType RuleType, reason SkipReason) {
+	pr.stats.skippedRules = append(pr.stats.skippedRules, SkippedRule{Rule: rule, Type: ruleType, Reason: reason})
+}
+
 func NewPolicyResponse() PolicyResponse {
 	return PolicyResponse{}
 }
@@ -5,6 +5,9 @@ import (
 	"errors"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/kustomize/kyaml/openapi"
 )
 
 // NamespacedResourceResolver is an abstract interface used to resolve namespaced resources
@@ -21,6 +24,41 @@ type NamespacedResourceResolver[T any] interface {
 // ConfigmapResolver is an abstract interface used to resolve configmaps
 type ConfigmapResolver = NamespacedResourceResolver[*corev1.ConfigMap]
 
+// NamespacedResourceLister is an abstract interface used to list namespaced resources
+// Any implementation might exist, cache based, client based etc...
+type NamespacedResourceLister[T any] interface {
+	// List is used to list resources in a given namespace
+	List(
+		ctx context.Context,
+		namespace string,
+	) ([]T, error)
+}
+
+// PodLister is an abstract interface used to list pods
+type PodLister = NamespacedResourceLister[*corev1.Pod]
+
+// SchemaResolver is an abstract interface used to resolve the schema of custom resources,
+// it is used to merge the lists of custom resources when applying strategic merge patches
+type SchemaResolver interface {
+	// Get is used to resolve a schema given a group version kind, it returns nil when the kind has no schema
+	Get(
+		ctx context.Context,
+		gvk schema.GroupVersionKind,
+	) (*openapi.ResourceSchema, error)
+}
+
+// ParamsResolver is an abstract interface used to resolve the parameter resources of policies
+type ParamsResolver interface {
+	// Get is used to resolve a parameter resource given its group version kind, namespace and name,
+	// the namespace is ignored when the kind is cluster scoped
+	Get(
+		ctx context.Context,
+		gvk schema.GroupVersionKind,
+		namespace string,
+		name string,
+	) (*unstructured.Unstructured, error)
+}
+
 // namespacedResourceResolverChain represents a chain of NamespacedResourceResolver
 type namespacedResourceResolverChain[T any] []NamespacedResourceResolver[T]
 
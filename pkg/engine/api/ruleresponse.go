@@ -5,6 +5,7 @@ import (
 
 	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
 	pssutils "github.com/kyverno/kyverno/pkg/pss/utils"
+	"gomodules.xyz/jsonpatch/v2"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/pod-security-admission/api"
@@ -30,8 +31,12 @@ type RuleResponse struct {
 	message string
 	// status rule status
 	status RuleStatus
+	// skipReason is the reason why the rule was skipped (only if the status is skip)
+	skipReason SkipReason
 	// stats contains rule statistics
 	stats ExecutionStats
+	// timings contains the time spent in the different phases of the rule application
+	timings RuleTimings
 	// generatedResource is the generated by the generate rules of a policy
 	generatedResource unstructured.Unstructured
 	// patchedTarget is the patched resource for mutate.targets
@@ -44,6 +49,12 @@ type RuleResponse struct {
 	podSecurityChecks *PodSecurityChecks
 	// exception is the exception applied (if any)
 	exception *kyvernov2alpha1.PolicyException
+	// patches are the JSON patch operations applied to the resource by the rule
+	patches []jsonpatch.JsonPatchOperation
+	// severity overrides the severity of the policy in reports (only if this is a failed validation)
+	severity string
+	// properties are the custom properties of the rule result
+	properties map[string]string
 }
 
 func NewRuleResponse(name string, ruleType RuleType, msg string, status RuleStatus) *RuleResponse {
@@ -83,6 +94,11 @@ func (r RuleResponse) WithException(exception *kyvernov2alpha1.PolicyException)
 	return &r
 }
 
+func (r RuleResponse) WithSkipReason(reason SkipReason) *RuleResponse {
+	r.skipReason = reason
+	return &r
+}
+
 func (r RuleResponse) WithPodSecurityChecks(checks PodSecurityChecks) *RuleResponse {
 	r.podSecurityChecks = &checks
 	return &r
@@ -95,6 +111,21 @@ func (r RuleResponse) WithPatchedTarget(patchedTarget *unstructured.Unstructured
 	return &r
 }
 
+func (r RuleResponse) WithPatches(patches ...jsonpatch.JsonPatchOperation) *RuleResponse {
+	r.patches = patches
+	return &r
+}
+
+func (r RuleResponse) WithSeverity(severity string) *RuleResponse {
+	r.severity = severity
+	return &r
+}
+
+func (r RuleResponse) WithProperties(properties map[string]string) *RuleResponse {
+	r.properties = properties
+	return &r
+}
+
 func (r RuleResponse) WithGeneratedResource(resource unstructured.Unstructured) *RuleResponse {
 	r.generatedResource = resource
 	return &r
@@ -109,6 +140,15 @@ func (r *RuleResponse) Stats() ExecutionStats {
 	return r.stats
 }
 
+func (r RuleResponse) WithTimings(timings RuleTimings) *RuleResponse {
+	r.timings = timings
+	return &r
+}
+
+func (r *RuleResponse) Timings() RuleTimings {
+	return r.timings
+}
+
 func (r *RuleResponse) Exception() *kyvernov2alpha1.PolicyException {
 	return r.exception
 }
@@ -125,6 +165,19 @@ func (r *RuleResponse) PatchedTarget() (*unstructured.Unstructured, metav1.Group
 	return r.patchedTarget, r.patchedTargetParentResourceGVR, r.patchedTargetSubresourceName
 }
 
+func (r *RuleResponse) Patches() []jsonpatch.JsonPatchOperation {
+	return r.patches
+}
+
+// Severity returns the severity computed for the rule result, an empty severity means the policy severity applies
+func (r *RuleResponse) Severity() string {
+	return r.severity
+}
+
+func (r *RuleResponse) Properties() map[string]string {
+	return r.properties
+}
+
 func (r *RuleResponse) GeneratedResource() unstructured.Unstructured {
 	return r.generatedResource
 }
@@ -145,6 +198,18 @@ func (r *RuleResponse) Status() RuleStatus {
 	return r.status
 }
 
+// SkipReason returns the reason why the rule was skipped, SkipReasonUnknown if the rule was skipped
+// without recording a reason and an empty reason if the rule was not skipped
+func (r *RuleResponse) SkipReason() SkipReason {
+	if r.status != RuleStatusSkip {
+		return ""
+	}
+	if r.skipReason == "" {
+		return SkipReasonUnknown
+	}
+	return r.skipReason
+}
+
 // HasStatus checks if rule status is in a given list
 func (r *RuleResponse) HasStatus(status ...RuleStatus) bool {
 	for _, s := range status {
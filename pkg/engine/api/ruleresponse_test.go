@@ -2,6 +2,7 @@ package api
 
 import (
 	"testing"
+	"time"
 )
 
 func TestRuleResponse_String(t *testing.T) {
@@ -126,3 +127,46 @@ func TestRuleResponse_HasStatus(t *testing.T) {
 		})
 	}
 }
+
+func TestRuleResponse_WithTimings(t *testing.T) {
+	timings := RuleTimings{
+		ContextLoading: 2 * time.Millisecond,
+		Preconditions:  time.Millisecond,
+	}
+	rule := RulePass("test", Validation, "").WithTimings(timings)
+	if got := rule.Timings(); got != timings {
+		t.Errorf("RuleResponse.Timings() = %v, want %v", got, timings)
+	}
+	// timings are preserved when stats are set
+	withStats := rule.WithStats(NewExecutionStats(time.Now(), time.Now()))
+	if got := withStats.Timings(); got != timings {
+		t.Errorf("RuleResponse.Timings() = %v, want %v", got, timings)
+	}
+}
+
+func TestRuleResponse_SkipReason(t *testing.T) {
+	tests := []struct {
+		name string
+		rule *RuleResponse
+		want SkipReason
+	}{{
+		name: "pass",
+		rule: RulePass("test", Validation, "").WithSkipReason(SkipReasonPreconditions),
+		want: "",
+	}, {
+		name: "skip without reason",
+		rule: RuleSkip("test", Validation, ""),
+		want: SkipReasonUnknown,
+	}, {
+		name: "skip with reason",
+		rule: RuleSkip("test", Validation, "").WithSkipReason(SkipReasonPreconditions),
+		want: SkipReasonPreconditions,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.rule.SkipReason(); got != tt.want {
+				t.Errorf("RuleResponse.SkipReason() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
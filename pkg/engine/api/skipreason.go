@@ -0,0 +1,45 @@
+package api
+
+// SkipReason represents the reason why a rule was not applied to a resource
+type SkipReason string
+
+const (
+	// SkipReasonKindMismatch indicates that the resource kind is not matched by the rule
+	SkipReasonKindMismatch SkipReason = "KindMismatch"
+	// SkipReasonNamespaceMismatch indicates that the resource namespace is not matched by the rule or is not the policy namespace
+	SkipReasonNamespaceMismatch SkipReason = "NamespaceMismatch"
+	// SkipReasonNotMatched indicates that the resource is not matched by the other criteria of the rule match block
+	SkipReasonNotMatched SkipReason = "NotMatched"
+	// SkipReasonExcluded indicates that the resource is excluded by the rule exclude block
+	SkipReasonExcluded SkipReason = "Excluded"
+	// SkipReasonExcludedByConfiguration indicates that the request user or groups are excluded in the kyverno configuration
+	SkipReasonExcludedByConfiguration SkipReason = "ExcludedByConfiguration"
+	// SkipReasonPreconditions indicates that the rule (or mutate target) preconditions are not met
+	SkipReasonPreconditions SkipReason = "PreconditionsNotMet"
+	// SkipReasonCELPreconditions indicates that the rule CEL preconditions are not met
+	SkipReasonCELPreconditions SkipReason = "CELPreconditionsNotMet"
+	// SkipReasonConditionalAnchor indicates that a conditional or global anchor of a pattern is not satisfied
+	SkipReasonConditionalAnchor SkipReason = "ConditionalAnchor"
+	// SkipReasonForEachNotApplied indicates that none of the foreach elements were processed
+	SkipReasonForEachNotApplied SkipReason = "ForEachNotApplied"
+	// SkipReasonPolicyException indicates that a policy exception applies to the resource
+	SkipReasonPolicyException SkipReason = "PolicyException"
+	// SkipReasonInactive indicates that the policy or the rule is not active according to its schedule
+	SkipReasonInactive SkipReason = "Inactive"
+	// SkipReasonParamsNotFound indicates that the policy parameter resource was not found
+	SkipReasonParamsNotFound SkipReason = "ParamsNotFound"
+	// SkipReasonContextUnavailable indicates that a context entry destination is unavailable and its fallback skips the rule
+	SkipReasonContextUnavailable SkipReason = "ContextUnavailable"
+	// SkipReasonUnknown is used for skipped rules that don't record a reason
+	SkipReasonUnknown SkipReason = "Unknown"
+)
+
+// SkippedRule records a rule that was skipped or that did not match the resource
+type SkippedRule struct {
+	// Rule is the rule name
+	Rule string
+	// Type is the rule type
+	Type RuleType
+	// Reason is the reason why the rule was not applied
+	Reason SkipReason
+}
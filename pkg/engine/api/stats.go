@@ -31,12 +31,22 @@ func (s ExecutionStats) ProcessingTime() time.Duration {
 	return s.processingTime
 }
 
+// RuleTimings stores the time spent in the different phases of a rule application
+type RuleTimings struct {
+	// ContextLoading is the time required to load the rule context (including JMESPath variables)
+	ContextLoading time.Duration
+	// Preconditions is the time required to evaluate the rule preconditions
+	Preconditions time.Duration
+}
+
 // PolicyStats stores statistics for the single policy application
 type PolicyStats struct {
 	// rulesAppliedCount is the count of rules that were applied successfully
 	rulesAppliedCount int
 	// rulesErrorCount is the count of rules that with execution errors
 	rulesErrorCount int
+	// skippedRules records the rules that were skipped or did not match the resource, with the reason why
+	skippedRules []SkippedRule
 }
 
 func (ps *PolicyStats) RulesAppliedCount() int {
@@ -46,3 +56,11 @@ func (ps *PolicyStats) RulesAppliedCount() int {
 func (ps *PolicyStats) RulesErrorCount() int {
 	return ps.rulesErrorCount
 }
+
+func (ps *PolicyStats) RulesSkippedCount() int {
+	return len(ps.skippedRules)
+}
+
+func (ps *PolicyStats) SkippedRules() []SkippedRule {
+	return ps.skippedRules
+}
@@ -156,3 +156,29 @@ func TestPolicyStats_RulesErrorCount(t *testing.T) {
 		})
 	}
 }
+
+func TestPolicyStats_SkippedRules(t *testing.T) {
+	var pr PolicyResponse
+	pr.Add(NewExecutionStats(time.Now(), time.Now()), *RulePass("pass", Validation, ""))
+	pr.Add(NewExecutionStats(time.Now(), time.Now()), *RuleSkip("preconditions", Validation, "").WithSkipReason(SkipReasonPreconditions))
+	pr.Add(NewExecutionStats(time.Now(), time.Now()), *RuleSkip("unknown", Mutation, ""))
+	pr.AddNotMatched("kind", ImageVerify, SkipReasonKindMismatch)
+	want := []SkippedRule{
+		{Rule: "preconditions", Type: Validation, Reason: SkipReasonPreconditions},
+		{Rule: "unknown", Type: Mutation, Reason: SkipReasonUnknown},
+		{Rule: "kind", Type: ImageVerify, Reason: SkipReasonKindMismatch},
+	}
+	stats := pr.Stats()
+	if got := stats.SkippedRules(); !reflect.DeepEqual(got, want) {
+		t.Errorf("PolicyStats.SkippedRules() = %v, want %v", got, want)
+	}
+	if got := stats.RulesSkippedCount(); got != 3 {
+		t.Errorf("PolicyStats.RulesSkippedCount() = %v, want %v", got, 3)
+	}
+	if got := stats.RulesAppliedCount(); got != 1 {
+		t.Errorf("PolicyStats.RulesAppliedCount() = %v, want %v", got, 1)
+	}
+	if got := len(pr.Rules); got != 3 {
+		t.Errorf("len(PolicyResponse.Rules) = %v, want %v", got, 3)
+	}
+}
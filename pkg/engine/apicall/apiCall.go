@@ -9,7 +9,10 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"os"
+	"path/filepath"
+	"strconv"
 
 	"github.com/go-logr/logr"
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
@@ -87,6 +90,12 @@ func (a *apiCall) Store(data []byte) ([]byte, error) {
 
 func (a *apiCall) execute(ctx context.Context, call *kyvernov1.APICall) ([]byte, error) {
 	if call.URLPath != "" {
+		if call.List != nil {
+			if call.Method != "" && call.Method != "GET" {
+				return nil, fmt.Errorf("invalid request type %s for list APICall %s", call.Method, a.entry.Name)
+			}
+			return a.executeK8sListCall(ctx, call.URLPath, call.List)
+		}
 		return a.executeK8sAPICall(ctx, call.URLPath, call.Method, call.Data)
 	}
 
@@ -108,6 +117,97 @@ func (a *apiCall) executeK8sAPICall(ctx context.Context, path string, method kyv
 	return jsonData, nil
 }
 
+// listPage is the part of a Kubernetes list response needed to page through the results
+type listPage struct {
+	APIVersion string `json:"apiVersion,omitempty"`
+	Kind       string `json:"kind,omitempty"`
+	Metadata   struct {
+		ResourceVersion string `json:"resourceVersion,omitempty"`
+		Continue        string `json:"continue,omitempty"`
+	} `json:"metadata"`
+	Items []json.RawMessage `json:"items"`
+}
+
+// listResult is the list stored in the context, its items are projected when a projection is set
+type listResult struct {
+	APIVersion string `json:"apiVersion,omitempty"`
+	Kind       string `json:"kind,omitempty"`
+	Metadata   struct {
+		ResourceVersion string `json:"resourceVersion,omitempty"`
+	} `json:"metadata"`
+	Items []interface{} `json:"items"`
+}
+
+func (a *apiCall) executeK8sListCall(ctx context.Context, path string, list *kyvernov1.APICallList) ([]byte, error) {
+	u, err := url.Parse(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse URL path %s for APICall %s: %w", path, a.entry.Name, err)
+	}
+	query := u.Query()
+	if list.LabelSelector != "" {
+		query.Set("labelSelector", list.LabelSelector)
+	}
+	if list.FieldSelector != "" {
+		query.Set("fieldSelector", list.FieldSelector)
+	}
+	result := listResult{Items: []interface{}{}}
+	pages := 0
+	for {
+		limit := int64(0)
+		if list.PageSize != nil {
+			limit = *list.PageSize
+		}
+		if list.MaxItems != nil {
+			// don't request more items than needed
+			if remaining := *list.MaxItems - int64(len(result.Items)); limit == 0 || remaining < limit {
+				limit = remaining
+			}
+		}
+		if limit > 0 {
+			query.Set("limit", strconv.FormatInt(limit, 10))
+		}
+		u.RawQuery = query.Encode()
+		jsonData, err := a.client.RawAbsPath(ctx, u.String(), "GET", nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to GET resource with raw url\n: %s: %v", u.String(), err)
+		}
+		pages++
+		var page listPage
+		if err := json.Unmarshal(jsonData, &page); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal list response for APICall %s: %w", a.entry.Name, err)
+		}
+		if pages == 1 {
+			result.APIVersion = page.APIVersion
+			result.Kind = page.Kind
+			result.Metadata.ResourceVersion = page.Metadata.ResourceVersion
+		}
+		for _, item := range page.Items {
+			if list.MaxItems != nil && int64(len(result.Items)) >= *list.MaxItems {
+				break
+			}
+			if list.Projection == "" {
+				result.Items = append(result.Items, item)
+				continue
+			}
+			projected, err := a.applyJMESPathJSON(list.Projection, item)
+			if err != nil {
+				return nil, fmt.Errorf("failed to apply projection %s for APICall %s: %w", list.Projection, a.entry.Name, err)
+			}
+			result.Items = append(result.Items, projected)
+		}
+		if page.Metadata.Continue == "" || (list.MaxItems != nil && int64(len(result.Items)) >= *list.MaxItems) {
+			break
+		}
+		query.Set("continue", page.Metadata.Continue)
+	}
+	jsonData, err := json.Marshal(result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal list for APICall %s: %w", a.entry.Name, err)
+	}
+	a.logger.V(4).Info("executed list APICall", "name", a.entry.Name, "path", path, "pages", pages, "items", len(result.Items), "len", len(jsonData))
+	return jsonData, nil
+}
+
 func (a *apiCall) executeServiceCall(ctx context.Context, apiCall *kyvernov1.APICall) ([]byte, error) {
 	if apiCall.Service == nil {
 		return nil, fmt.Errorf("missing service for APICall %s", a.entry.Name)
@@ -177,8 +277,12 @@ func (a *apiCall) buildHTTPRequest(ctx context.Context, apiCall *kyvernov1.APICa
 	return nil, fmt.Errorf("invalid request type %s for APICall %s", apiCall.Method, a.entry.Name)
 }
 
+// serviceAccountTokenPath is the path of the service account token, on windows nodes the token is
+// mounted at the same location relative to the system drive
+var serviceAccountTokenPath = filepath.FromSlash("/var/run/secrets/kubernetes.io/serviceaccount/token")
+
 func (a *apiCall) getToken() string {
-	fileName := "/var/run/secrets/kubernetes.io/serviceaccount/token"
+	fileName := serviceAccountTokenPath
 	b, err := os.ReadFile(fileName)
 	if err != nil {
 		a.logger.Info("failed to read service account token", "path", fileName)
@@ -5,6 +5,7 @@ import (
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"testing"
 
 	"github.com/go-logr/logr"
@@ -14,6 +15,7 @@ import (
 	"github.com/kyverno/kyverno/pkg/engine/jmespath"
 	"gotest.tools/assert"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/utils/ptr"
 )
 
 var jp = jmespath.New(config.NewDefaultConfiguration(false))
@@ -147,3 +149,82 @@ func Test_servicePostRequest(t *testing.T) {
 	expectedResults := `{"images":["https://ghcr.io/tomcat/tomcat:9","https://ghcr.io/vault/vault:v3","https://ghcr.io/busybox/busybox:latest"]}`
 	assert.Equal(t, string(expectedResults)+"\n", string(data))
 }
+
+type fakeListClient struct {
+	paths []string
+	pages map[string]string
+}
+
+func (c *fakeListClient) RawAbsPath(ctx context.Context, path string, method string, dataReader io.Reader) ([]byte, error) {
+	c.paths = append(c.paths, path)
+	u, err := url.Parse(path)
+	if err != nil {
+		return nil, err
+	}
+	return []byte(c.pages[u.Query().Get("continue")]), nil
+}
+
+func Test_listRequest(t *testing.T) {
+	client := &fakeListClient{
+		pages: map[string]string{
+			"": `{
+				"apiVersion": "v1",
+				"kind": "PodList",
+				"metadata": {"resourceVersion": "100", "continue": "page2"},
+				"items": [
+					{"metadata": {"name": "pod-1", "namespace": "default"}},
+					{"metadata": {"name": "pod-2", "namespace": "default"}}
+				]
+			}`,
+			"page2": `{
+				"apiVersion": "v1",
+				"kind": "PodList",
+				"metadata": {"resourceVersion": "100"},
+				"items": [
+					{"metadata": {"name": "pod-3", "namespace": "default"}}
+				]
+			}`,
+		},
+	}
+	entry := kyvernov1.ContextEntry{
+		Name: "pods",
+		APICall: &kyvernov1.APICall{
+			URLPath: "/api/v1/pods",
+			List: &kyvernov1.APICallList{
+				LabelSelector: "app=nginx",
+				FieldSelector: "spec.nodeName=node-1",
+				PageSize:      ptr.To[int64](2),
+				Projection:    "metadata.name",
+			},
+		},
+	}
+	ctx := enginecontext.NewContext(jp)
+
+	call, err := New(logr.Discard(), jp, entry, ctx, client)
+	assert.NilError(t, err)
+	data, err := call.FetchAndLoad(context.TODO())
+	assert.NilError(t, err)
+	assert.Equal(t, `{"apiVersion":"v1","kind":"PodList","metadata":{"resourceVersion":"100"},"items":["pod-1","pod-2","pod-3"]}`, string(data))
+	assert.DeepEqual(t, []string{
+		"/api/v1/pods?fieldSelector=spec.nodeName%3Dnode-1&labelSelector=app%3Dnginx&limit=2",
+		"/api/v1/pods?continue=page2&fieldSelector=spec.nodeName%3Dnode-1&labelSelector=app%3Dnginx&limit=2",
+	}, client.paths)
+
+	client.paths = nil
+	entry.APICall.List.MaxItems = ptr.To[int64](1)
+	entry.APICall.JMESPath = "items | length(@)"
+	call, err = New(logr.Discard(), jp, entry, ctx, client)
+	assert.NilError(t, err)
+	data, err = call.FetchAndLoad(context.TODO())
+	assert.NilError(t, err)
+	assert.Equal(t, "1", string(data))
+	assert.DeepEqual(t, []string{
+		"/api/v1/pods?fieldSelector=spec.nodeName%3Dnode-1&labelSelector=app%3Dnginx&limit=1",
+	}, client.paths)
+
+	entry.APICall.Method = "POST"
+	call, err = New(logr.Discard(), jp, entry, ctx, client)
+	assert.NilError(t, err)
+	_, err = call.FetchAndLoad(context.TODO())
+	assert.ErrorContains(t, err, "invalid request type POST for list APICall pods")
+}
@@ -0,0 +1,250 @@
+package assertion
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/kyverno/kyverno/pkg/engine/jmespath"
+)
+
+var (
+	bindingName      = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+	bindingReference = regexp.MustCompile(`\$([A-Za-z_][A-Za-z0-9_]*)`)
+)
+
+// Failure describes an assertion that didn't hold
+type Failure struct {
+	// Path is the path of the failed assertion in the tree
+	Path string
+	// Message describes why the assertion failed
+	Message string
+}
+
+func (f Failure) String() string {
+	return fmt.Sprintf("failed at path %s: %s", f.Path, f.Message)
+}
+
+// Assert evaluates an assertion tree against a value and returns the assertions that didn't hold.
+// An error is returned when the tree can't be evaluated (invalid expressions or unknown bindings).
+func Assert(jp jmespath.Interface, tree interface{}, value interface{}) ([]Failure, error) {
+	tree, err := normalize(tree)
+	if err != nil {
+		return nil, err
+	}
+	value, err = normalize(value)
+	if err != nil {
+		return nil, err
+	}
+	return asserter{jp: jp}.assert("", tree, value, nil)
+}
+
+type asserter struct {
+	jp jmespath.Interface
+}
+
+func (a asserter) assert(path string, tree interface{}, value interface{}, bindings map[string]interface{}) ([]Failure, error) {
+	switch node := tree.(type) {
+	case map[string]interface{}:
+		return a.assertMap(path, node, value, bindings)
+	case []interface{}:
+		return a.assertSlice(path, node, value, bindings)
+	default:
+		return a.assertScalar(path, node, value, bindings)
+	}
+}
+
+func (a asserter) assertMap(path string, tree map[string]interface{}, value interface{}, bindings map[string]interface{}) ([]Failure, error) {
+	keys := make([]string, 0, len(tree))
+	for key := range tree {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	var failures []Failure
+	for _, key := range keys {
+		k := parseKey(key)
+		keyPath := path + "/" + k.name
+		var projected interface{}
+		if k.expression {
+			result, err := a.evaluate(k.name, value, bindings)
+			if err != nil {
+				return nil, fmt.Errorf("failed to evaluate %s: %w", keyPath, err)
+			}
+			projected = result
+		} else {
+			object, ok := value.(map[string]interface{})
+			if !ok {
+				return append(failures, Failure{Path: pathOrRoot(path), Message: fmt.Sprintf("expected an object, found %s", typeOf(value))}), nil
+			}
+			projected = object[k.name]
+		}
+		nested := bindings
+		if k.binding != "" {
+			nested = make(map[string]interface{}, len(bindings)+1)
+			for name, value := range bindings {
+				nested[name] = value
+			}
+			nested[k.binding] = projected
+		}
+		if k.foreach {
+			elements, ok := projected.([]interface{})
+			if !ok {
+				failures = append(failures, Failure{Path: keyPath, Message: fmt.Sprintf("expected an array, found %s", typeOf(projected))})
+				continue
+			}
+			for i, element := range elements {
+				elementFailures, err := a.assert(fmt.Sprintf("%s/%d", keyPath, i), tree[key], element, nested)
+				if err != nil {
+					return nil, err
+				}
+				failures = append(failures, elementFailures...)
+			}
+		} else {
+			keyFailures, err := a.assert(keyPath, tree[key], projected, nested)
+			if err != nil {
+				return nil, err
+			}
+			failures = append(failures, keyFailures...)
+		}
+	}
+	return failures, nil
+}
+
+func (a asserter) assertSlice(path string, tree []interface{}, value interface{}, bindings map[string]interface{}) ([]Failure, error) {
+	elements, ok := value.([]interface{})
+	if !ok {
+		return []Failure{{Path: pathOrRoot(path), Message: fmt.Sprintf("expected an array, found %s", typeOf(value))}}, nil
+	}
+	if len(elements) != len(tree) {
+		return []Failure{{Path: pathOrRoot(path), Message: fmt.Sprintf("expected an array of length %d, found length %d", len(tree), len(elements))}}, nil
+	}
+	var failures []Failure
+	for i := range tree {
+		elementFailures, err := a.assert(fmt.Sprintf("%s/%d", path, i), tree[i], elements[i], bindings)
+		if err != nil {
+			return nil, err
+		}
+		failures = append(failures, elementFailures...)
+	}
+	return failures, nil
+}
+
+func (a asserter) assertScalar(path string, tree interface{}, value interface{}, bindings map[string]interface{}) ([]Failure, error) {
+	expected := tree
+	if s, ok := tree.(string); ok && isExpression(s) {
+		result, err := a.evaluate(s[1:len(s)-1], value, bindings)
+		if err != nil {
+			return nil, fmt.Errorf("failed to evaluate %s: %w", pathOrRoot(path), err)
+		}
+		if expected, err = normalize(result); err != nil {
+			return nil, err
+		}
+	}
+	if !reflect.DeepEqual(expected, value) {
+		return []Failure{{Path: pathOrRoot(path), Message: fmt.Sprintf("expected %s, found %s", format(expected), format(value))}}, nil
+	}
+	return nil, nil
+}
+
+// evaluate runs a JMESPath expression against a value, bindings are injected as JSON literals
+func (a asserter) evaluate(expression string, value interface{}, bindings map[string]interface{}) (interface{}, error) {
+	var errs []string
+	expression = bindingReference.ReplaceAllStringFunc(expression, func(reference string) string {
+		binding, ok := bindings[reference[1:]]
+		if !ok {
+			errs = append(errs, fmt.Sprintf("unknown binding %s", reference))
+			return reference
+		}
+		literal, err := json.Marshal(binding)
+		if err != nil {
+			errs = append(errs, err.Error())
+			return reference
+		}
+		return "`" + strings.ReplaceAll(string(literal), "`", "\\`") + "`"
+	})
+	if len(errs) != 0 {
+		return nil, fmt.Errorf("%s", strings.Join(errs, ", "))
+	}
+	return a.jp.Search(expression, value)
+}
+
+type key struct {
+	name       string
+	expression bool
+	foreach    bool
+	binding    string
+}
+
+func parseKey(in string) key {
+	var k key
+	if strings.HasPrefix(in, "~") {
+		k.foreach = true
+		in = in[1:]
+	}
+	if i := strings.LastIndex(in, "->"); i != -1 && bindingName.MatchString(in[i+2:]) {
+		k.binding = in[i+2:]
+		in = in[:i]
+	}
+	if isExpression(in) {
+		k.expression = true
+		in = in[1 : len(in)-1]
+	}
+	k.name = in
+	return k
+}
+
+func isExpression(in string) bool {
+	return len(in) >= 2 && strings.HasPrefix(in, "(") && strings.HasSuffix(in, ")")
+}
+
+// normalize converts a value to its JSON representation so that numbers are compared consistently
+func normalize(in interface{}) (interface{}, error) {
+	if in == nil {
+		return nil, nil
+	}
+	data, err := json.Marshal(in)
+	if err != nil {
+		return nil, err
+	}
+	var out interface{}
+	if err := json.Unmarshal(data, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func pathOrRoot(path string) string {
+	if path == "" {
+		return "/"
+	}
+	return path
+}
+
+func typeOf(value interface{}) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case map[string]interface{}:
+		return "an object"
+	case []interface{}:
+		return "an array"
+	case string:
+		return "a string"
+	case float64:
+		return "a number"
+	case bool:
+		return "a boolean"
+	}
+	return fmt.Sprintf("%T", value)
+}
+
+func format(value interface{}) string {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(data)
+}
@@ -0,0 +1,93 @@
+package assertion
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/kyverno/kyverno/pkg/config"
+	"github.com/kyverno/kyverno/pkg/engine/jmespath"
+	"gotest.tools/assert"
+)
+
+var jp = jmespath.New(config.NewDefaultConfiguration(false))
+
+func TestAssert(t *testing.T) {
+	resource := []byte(`{
+		"metadata": {"name": "test", "labels": {"app": "nginx"}},
+		"spec": {
+			"replicas": 3,
+			"containers": [
+				{"name": "nginx", "image": "nginx:1.25", "ports": [{"containerPort": 80}]},
+				{"name": "sidecar", "image": "busybox:latest", "ports": []}
+			]
+		}
+	}`)
+	testCases := []struct {
+		name     string
+		tree     string
+		failures []Failure
+		wantErr  bool
+	}{{
+		name: "fields",
+		tree: `{"metadata": {"labels": {"app": "nginx"}}, "spec": {"replicas": 3}}`,
+	}, {
+		name:     "field mismatch",
+		tree:     `{"metadata": {"labels": {"app": "apache"}}}`,
+		failures: []Failure{{Path: "/metadata/labels/app", Message: `expected "apache", found "nginx"`}},
+	}, {
+		name:     "missing field",
+		tree:     `{"spec": {"paused": true}}`,
+		failures: []Failure{{Path: "/spec/paused", Message: `expected true, found null`}},
+	}, {
+		name: "expression key",
+		tree: "{\"spec\": {\"(replicas > `2`)\": true, \"(length(containers))\": 2}}",
+	}, {
+		name:     "expression key failure",
+		tree:     "{\"spec\": {\"(replicas > `5`)\": true}}",
+		failures: []Failure{{Path: "/spec/replicas > `5`", Message: `expected true, found false`}},
+	}, {
+		name: "expression leaf",
+		tree: `{"metadata": {"name": "(join('', ['te', 'st']))"}}`,
+	}, {
+		name:     "foreach",
+		tree:     "{\"spec\": {\"~containers\": {\"(ends_with(image, ':latest'))\": false}}}",
+		failures: []Failure{{Path: "/spec/containers/1/ends_with(image, ':latest')", Message: `expected false, found true`}},
+	}, {
+		name: "binding",
+		tree: "{\"spec->spec\": {\"~containers\": {\"(length($spec.containers) == `2` && $spec.replicas == `3`)\": true}}}",
+	}, {
+		name:    "binding used outside its scope",
+		tree:    "{\"spec\": {\"~containers\": {\"(name == $name)\": true}}}",
+		wantErr: true,
+	}, {
+		name:     "foreach on non array",
+		tree:     `{"metadata": {"~name": "test"}}`,
+		failures: []Failure{{Path: "/metadata/name", Message: `expected an array, found a string`}},
+	}, {
+		name:     "array length",
+		tree:     `{"spec": {"containers": [{"name": "nginx"}]}}`,
+		failures: []Failure{{Path: "/spec/containers", Message: `expected an array of length 1, found length 2`}},
+	}}
+	var value interface{}
+	assert.NilError(t, json.Unmarshal(resource, &value))
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var tree interface{}
+			assert.NilError(t, json.Unmarshal([]byte(tc.tree), &tree))
+			failures, err := Assert(jp, tree, value)
+			if tc.wantErr {
+				assert.Assert(t, err != nil)
+				return
+			}
+			assert.NilError(t, err)
+			assert.DeepEqual(t, failures, tc.failures)
+		})
+	}
+}
+
+func TestParseKey(t *testing.T) {
+	assert.Equal(t, parseKey("name"), key{name: "name"})
+	assert.Equal(t, parseKey("(a.b)"), key{name: "a.b", expression: true})
+	assert.Equal(t, parseKey("~containers->container"), key{name: "containers", foreach: true, binding: "container"})
+	assert.Equal(t, parseKey("(a->b)"), key{name: "a->b", expression: true})
+}
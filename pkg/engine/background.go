@@ -60,6 +60,11 @@ func (e *engine) filterRule(
 		ruleType = engineapi.Generation
 	}
 
+	// check if the policy and the rule are active
+	if ruleResp := e.checkSchedule(time.Now(), ruleType, policyContext.Policy(), rule); ruleResp != nil {
+		return ruleResp
+	}
+
 	// check if there is a corresponding policy exception
 	ruleResp := e.hasPolicyExceptions(logger, ruleType, policyContext, rule)
 	if ruleResp != nil {
@@ -124,5 +129,5 @@ func (e *engine) filterRule(
 	}
 
 	logger.V(4).Info("skip rule as preconditions are not met", "rule", rule.Name, "message", msg)
-	return engineapi.RuleSkip(rule.Name, ruleType, "")
+	return engineapi.RuleSkip(rule.Name, ruleType, "").WithSkipReason(engineapi.SkipReasonPreconditions)
 }
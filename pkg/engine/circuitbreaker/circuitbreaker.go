@@ -0,0 +1,120 @@
+package circuitbreaker
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrOpen is returned when the circuit of a destination is open
+var ErrOpen = errors.New("circuit breaker is open")
+
+// CircuitBreaker tracks the failures of the calls to external destinations (web services, image registries).
+// After a number of consecutive failures the circuit of a destination opens and calls are rejected without
+// being sent until the open duration elapses, then a single call is let through to probe the destination.
+type CircuitBreaker interface {
+	// Do runs fn unless the circuit of the destination is open, the error returned by fn is accounted for the destination
+	Do(destination string, fn func() error) error
+}
+
+type state struct {
+	failures  int
+	openUntil time.Time
+	probing   bool
+}
+
+type breaker struct {
+	lock         sync.Mutex
+	threshold    int
+	openDuration time.Duration
+	now          func() time.Time
+	states       map[string]*state
+}
+
+// New returns a circuit breaker opening after threshold consecutive failures for openDuration,
+// it returns nil when threshold is not positive
+func New(threshold int, openDuration time.Duration) CircuitBreaker {
+	if threshold <= 0 {
+		return nil
+	}
+	return newBreaker(threshold, openDuration, time.Now)
+}
+
+func newBreaker(threshold int, openDuration time.Duration, now func() time.Time) *breaker {
+	return &breaker{
+		threshold:    threshold,
+		openDuration: openDuration,
+		now:          now,
+		states:       map[string]*state{},
+	}
+}
+
+func (b *breaker) Do(destination string, fn func() error) error {
+	if err := b.acquire(destination); err != nil {
+		return err
+	}
+	err := fn()
+	b.release(destination, err)
+	return err
+}
+
+func (b *breaker) acquire(destination string) error {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	s := b.states[destination]
+	if s == nil || s.failures < b.threshold {
+		return nil
+	}
+	// the circuit is open, a single probe is allowed once the open duration elapsed
+	if s.probing || b.now().Before(s.openUntil) {
+		return ErrOpen
+	}
+	s.probing = true
+	return nil
+}
+
+func (b *breaker) release(destination string, err error) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	if err == nil {
+		delete(b.states, destination)
+		return
+	}
+	s := b.states[destination]
+	if s == nil {
+		s = &state{}
+		b.states[destination] = s
+	}
+	s.failures++
+	s.probing = false
+	if s.failures >= b.threshold {
+		s.openUntil = b.now().Add(b.openDuration)
+	}
+}
+
+type skipRecorderKey struct{}
+
+// WithSkipRecorder returns a context in which context loaders can ask for the rule to be skipped,
+// the returned function reports if a skip was recorded
+func WithSkipRecorder(ctx context.Context) (context.Context, func() bool) {
+	var skipped bool
+	var lock sync.Mutex
+	record := func() {
+		lock.Lock()
+		defer lock.Unlock()
+		skipped = true
+	}
+	return context.WithValue(ctx, skipRecorderKey{}, record), func() bool {
+		lock.Lock()
+		defer lock.Unlock()
+		return skipped
+	}
+}
+
+// RecordSkip records that the rule evaluated with ctx must be skipped
+func RecordSkip(ctx context.Context) {
+	if record, ok := ctx.Value(skipRecorderKey{}).(func()); ok {
+		record()
+	}
+}
@@ -0,0 +1,57 @@
+package circuitbreaker
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"gotest.tools/assert"
+)
+
+func TestNew(t *testing.T) {
+	assert.Assert(t, New(0, time.Minute) == nil)
+	assert.Assert(t, New(1, time.Minute) != nil)
+}
+
+func TestBreaker(t *testing.T) {
+	now := time.Now()
+	cb := newBreaker(2, time.Minute, func() time.Time { return now })
+	failure := errors.New("failure")
+	calls := 0
+	fail := func() error {
+		calls++
+		return failure
+	}
+	succeed := func() error {
+		calls++
+		return nil
+	}
+	// the circuit opens after two consecutive failures
+	assert.Equal(t, cb.Do("foo", fail), failure)
+	assert.Equal(t, cb.Do("foo", fail), failure)
+	assert.Equal(t, cb.Do("foo", fail), ErrOpen)
+	assert.Equal(t, calls, 2)
+	// other destinations are not affected
+	assert.NilError(t, cb.Do("bar", succeed))
+	assert.Equal(t, calls, 3)
+	// a failed probe opens the circuit again
+	now = now.Add(time.Minute)
+	assert.Equal(t, cb.Do("foo", fail), failure)
+	assert.Equal(t, cb.Do("foo", succeed), ErrOpen)
+	assert.Equal(t, calls, 4)
+	// a successful probe closes the circuit
+	now = now.Add(time.Minute)
+	assert.NilError(t, cb.Do("foo", succeed))
+	assert.NilError(t, cb.Do("foo", succeed))
+	assert.Equal(t, calls, 6)
+}
+
+func TestRecordSkip(t *testing.T) {
+	// recording without a recorder is a no-op
+	RecordSkip(context.TODO())
+	ctx, skipped := WithSkipRecorder(context.TODO())
+	assert.Assert(t, !skipped())
+	RecordSkip(ctx)
+	assert.Assert(t, skipped())
+}
@@ -75,6 +75,9 @@ type Interface interface {
 	// AddImageInfo adds image info to the context
 	AddImageInfo(info apiutils.ImageInfo, cfg config.Configuration) error
 
+	// AddPlatformDigests adds the digests of the platform manifests verified for an image at path platformDigests.<image>
+	AddPlatformDigests(image string, digests map[string]string) error
+
 	// AddImageInfos adds image infos to the context
 	AddImageInfos(resource *unstructured.Unstructured, cfg config.Configuration) error
 
@@ -286,6 +289,10 @@ func (ctx *context) AddImageInfo(info apiutils.ImageInfo, cfg config.Configurati
 	return addToContext(ctx, data, "image")
 }
 
+func (ctx *context) AddPlatformDigests(image string, digests map[string]string) error {
+	return addToContext(ctx, digests, "platformDigests", image)
+}
+
 func (ctx *context) AddImageInfos(resource *unstructured.Unstructured, cfg config.Configuration) error {
 	images, err := apiutils.ExtractImagesFromResource(*resource, nil, cfg)
 	if err != nil {
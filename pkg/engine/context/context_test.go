@@ -123,3 +123,30 @@ func Test_addResourceAndUserContext(t *testing.T) {
 		t.Error("expected result does not match")
 	}
 }
+
+func Test_AddPlatformDigests(t *testing.T) {
+	ctx := NewContext(jp)
+	if err := ctx.AddPlatformDigests("ghcr.io/acme/app:v1", map[string]string{"linux/amd64": "sha256:1"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := ctx.AddPlatformDigests("ghcr.io/acme/app:v1", map[string]string{"linux/arm64": "sha256:2"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := ctx.AddPlatformDigests("ghcr.io/acme/db:v1", map[string]string{"linux/amd64": "sha256:3"}); err != nil {
+		t.Fatal(err)
+	}
+	result, err := ctx.Query(`platformDigests."ghcr.io/acme/app:v1"."linux/arm64"`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(result, "sha256:2") {
+		t.Errorf("unexpected digest %v", result)
+	}
+	result, err = ctx.Query(`platformDigests."ghcr.io/acme/app:v1"."linux/amd64"`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(result, "sha256:1") {
+		t.Errorf("unexpected digest %v", result)
+	}
+}
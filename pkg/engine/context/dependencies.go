@@ -0,0 +1,215 @@
+package context
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	gojmespath "github.com/kyverno/go-jmespath"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/engine/variables/regex"
+)
+
+// SortEntries orders context entries so that every entry comes after the entries it references.
+// The original order is kept whenever it is valid, an error is returned if entries reference each other in a cycle.
+func SortEntries(entries []kyvernov1.ContextEntry) ([]kyvernov1.ContextEntry, error) {
+	if len(entries) < 2 {
+		return entries, nil
+	}
+	deps, err := entryDependencies(entries)
+	if err != nil {
+		return nil, err
+	}
+	// kahn's algorithm, always picking the ready entry with the lowest index to keep the order stable
+	inDegree := make([]int, len(entries))
+	dependents := make([][]int, len(entries))
+	for i, d := range deps {
+		inDegree[i] = len(d)
+		for _, j := range d {
+			dependents[j] = append(dependents[j], i)
+		}
+	}
+	var ready []int
+	for i := range entries {
+		if inDegree[i] == 0 {
+			ready = append(ready, i)
+		}
+	}
+	sorted := make([]kyvernov1.ContextEntry, 0, len(entries))
+	for len(ready) != 0 {
+		sort.Ints(ready)
+		i := ready[0]
+		ready = ready[1:]
+		sorted = append(sorted, entries[i])
+		for _, j := range dependents[i] {
+			inDegree[j]--
+			if inDegree[j] == 0 {
+				ready = append(ready, j)
+			}
+		}
+	}
+	if len(sorted) != len(entries) {
+		return nil, fmt.Errorf("circular reference between context entries: %s", describeCycle(entries, deps, inDegree))
+	}
+	return sorted, nil
+}
+
+// entryDependencies returns, for each entry, the indexes of the entries it depends on.
+func entryDependencies(entries []kyvernov1.ContextEntry) ([][]int, error) {
+	byName := map[string][]int{}
+	for i, entry := range entries {
+		name := rootName(entry.Name)
+		byName[name] = append(byName[name], i)
+	}
+	deps := make([][]int, len(entries))
+	for i, entry := range entries {
+		refs, err := entryReferences(entry)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse context entry %s: %w", entry.Name, err)
+		}
+		set := map[int]struct{}{}
+		for ref := range refs {
+			var previous, next []int
+			for _, j := range byName[ref] {
+				if j < i {
+					previous = append(previous, j)
+				} else if j > i {
+					next = append(next, j)
+				}
+			}
+			// an entry referencing a name defined before it keeps using the previous definitions,
+			// it only depends on the entries defined after it when there is no previous definition
+			if len(previous) != 0 {
+				next = nil
+			} else if rootName(entry.Name) == ref {
+				next = nil
+			}
+			for _, j := range append(previous, next...) {
+				set[j] = struct{}{}
+			}
+		}
+		// entries sharing the same name are resolved in the order they are declared
+		for _, j := range byName[rootName(entry.Name)] {
+			if j < i {
+				set[j] = struct{}{}
+			}
+		}
+		for j := range set {
+			deps[i] = append(deps[i], j)
+		}
+		sort.Ints(deps[i])
+	}
+	return deps, nil
+}
+
+// entryReferences returns the root names of the variables referenced by a context entry.
+func entryReferences(entry kyvernov1.ContextEntry) (map[string]struct{}, error) {
+	refs := map[string]struct{}{}
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return nil, err
+	}
+	var document interface{}
+	if err := json.Unmarshal(data, &document); err != nil {
+		return nil, err
+	}
+	collectVariableReferences(document, refs)
+	// a variable without a value is evaluated against the context
+	if entry.Variable != nil && entry.Variable.Value == nil && entry.Variable.JMESPath != "" {
+		collectExpressionReferences(entry.Variable.JMESPath, refs)
+	}
+	return refs, nil
+}
+
+func collectVariableReferences(document interface{}, refs map[string]struct{}) {
+	switch typed := document.(type) {
+	case map[string]interface{}:
+		for _, value := range typed {
+			collectVariableReferences(value, refs)
+		}
+	case []interface{}:
+		for _, value := range typed {
+			collectVariableReferences(value, refs)
+		}
+	case string:
+		for _, match := range regex.RegexVariables.FindAllStringSubmatch(regex.RemoveLiterals(typed), -1) {
+			variable := strings.TrimSuffix(strings.TrimPrefix(match[2], "{{"), "}}")
+			collectExpressionReferences(variable, refs)
+		}
+	}
+}
+
+func collectExpressionReferences(expression string, refs map[string]struct{}) {
+	expression = strings.TrimSpace(expression)
+	if expression == "" {
+		return
+	}
+	// expressions that can't be parsed are reported by the policy validation
+	ast, err := gojmespath.NewParser().Parse(expression)
+	if err != nil {
+		return
+	}
+	collectASTReferences(ast, true, refs)
+}
+
+// collectASTReferences collects the fields evaluated against the root of the context,
+// fields evaluated against the result of another expression are ignored.
+func collectASTReferences(node gojmespath.ASTNode, root bool, refs map[string]struct{}) {
+	switch node.NodeType {
+	case gojmespath.ASTField:
+		if root {
+			if name, ok := node.Value.(string); ok {
+				refs[name] = struct{}{}
+			}
+		}
+	case gojmespath.ASTSubexpression, gojmespath.ASTIndexExpression, gojmespath.ASTPipe,
+		gojmespath.ASTProjection, gojmespath.ASTValueProjection, gojmespath.ASTFilterProjection:
+		for i, child := range node.Children {
+			collectASTReferences(child, root && i == 0, refs)
+		}
+	case gojmespath.ASTExpRef, gojmespath.ASTLiteral, gojmespath.ASTCurrentNode, gojmespath.ASTIdentity:
+	default:
+		for _, child := range node.Children {
+			collectASTReferences(child, root, refs)
+		}
+	}
+}
+
+func describeCycle(entries []kyvernov1.ContextEntry, deps [][]int, inDegree []int) string {
+	// every unsorted entry depends on at least one other unsorted entry, following them leads to a cycle
+	start := -1
+	for i := range entries {
+		if inDegree[i] > 0 {
+			start = i
+			break
+		}
+	}
+	visited := map[int]int{}
+	var path []int
+	for current := start; current >= 0; {
+		if at, ok := visited[current]; ok {
+			path = append(path[at:], current)
+			break
+		}
+		visited[current] = len(path)
+		path = append(path, current)
+		next := -1
+		for _, j := range deps[current] {
+			if inDegree[j] > 0 {
+				next = j
+				break
+			}
+		}
+		current = next
+	}
+	names := make([]string, 0, len(path))
+	for _, i := range path {
+		names = append(names, entries[i].Name)
+	}
+	return strings.Join(names, " -> ")
+}
+
+func rootName(name string) string {
+	return strings.SplitN(name, ".", 2)[0]
+}
@@ -0,0 +1,107 @@
+package context
+
+import (
+	"testing"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"gotest.tools/assert"
+	apiextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+)
+
+func variableEntry(name, jmesPath, value string) kyvernov1.ContextEntry {
+	entry := kyvernov1.ContextEntry{
+		Name:     name,
+		Variable: &kyvernov1.Variable{JMESPath: jmesPath},
+	}
+	if value != "" {
+		entry.Variable.Value = &apiextv1.JSON{Raw: []byte(value)}
+	}
+	return entry
+}
+
+func entryNames(entries []kyvernov1.ContextEntry) []string {
+	var names []string
+	for _, entry := range entries {
+		names = append(names, entry.Name)
+	}
+	return names
+}
+
+func Test_SortEntries(t *testing.T) {
+	tests := []struct {
+		name    string
+		entries []kyvernov1.ContextEntry
+		want    []string
+		wantErr string
+	}{{
+		name: "keep order",
+		entries: []kyvernov1.ContextEntry{
+			variableEntry("a", "request.object.metadata.name", ""),
+			variableEntry("b", "a", ""),
+			variableEntry("c", "", `"{{ b }}"`),
+		},
+		want: []string{"a", "b", "c"},
+	}, {
+		name: "reorder",
+		entries: []kyvernov1.ContextEntry{
+			variableEntry("c", "", `"{{ b.name }}"`),
+			variableEntry("b", "to_upper(a)", ""),
+			variableEntry("a", "request.object.metadata.name", ""),
+			variableEntry("d", "request.name", ""),
+		},
+		want: []string{"a", "b", "c", "d"},
+	}, {
+		name: "reorder api call",
+		entries: []kyvernov1.ContextEntry{{
+			Name: "deployments",
+			APICall: &kyvernov1.APICall{
+				URLPath: "/apis/apps/v1/namespaces/{{ namespace }}/deployments",
+			},
+		},
+			variableEntry("namespace", "request.namespace", ""),
+		},
+		want: []string{"namespace", "deployments"},
+	}, {
+		name: "relative fields",
+		entries: []kyvernov1.ContextEntry{
+			variableEntry("a", "request.object.spec.containers[?b == 'foo'].c | [0]", ""),
+			variableEntry("b", "a", ""),
+			variableEntry("c", "request.name", ""),
+		},
+		want: []string{"a", "b", "c"},
+	}, {
+		name: "literals",
+		entries: []kyvernov1.ContextEntry{
+			variableEntry("a", "", `"{{#literal}}{{ b }}{{/literal}}"`),
+			variableEntry("b", "a", ""),
+		},
+		want: []string{"a", "b"},
+	}, {
+		name: "same name",
+		entries: []kyvernov1.ContextEntry{
+			variableEntry("a", "request.name", ""),
+			variableEntry("a", "to_upper(a)", ""),
+			variableEntry("b", "a", ""),
+		},
+		want: []string{"a", "a", "b"},
+	}, {
+		name: "cycle",
+		entries: []kyvernov1.ContextEntry{
+			variableEntry("a", "c", ""),
+			variableEntry("b", "a", ""),
+			variableEntry("c", "", `"{{ b }}"`),
+		},
+		wantErr: "circular reference between context entries: a -> c -> b -> a",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := SortEntries(tt.entries)
+			if tt.wantErr != "" {
+				assert.Error(t, err, tt.wantErr)
+			} else {
+				assert.NilError(t, err)
+				assert.DeepEqual(t, tt.want, entryNames(got))
+			}
+		})
+	}
+}
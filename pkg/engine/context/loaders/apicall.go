@@ -3,11 +3,13 @@ package loaders
 import (
 	"context"
 	"fmt"
+	"net/url"
 
 	"github.com/go-logr/logr"
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
 	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
 	"github.com/kyverno/kyverno/pkg/engine/apicall"
+	"github.com/kyverno/kyverno/pkg/engine/circuitbreaker"
 	enginecontext "github.com/kyverno/kyverno/pkg/engine/context"
 	"github.com/kyverno/kyverno/pkg/engine/jmespath"
 )
@@ -19,7 +21,9 @@ type apiLoader struct {
 	enginectx enginecontext.Interface
 	jp        jmespath.Interface
 	client    engineapi.RawClient
+	cb        circuitbreaker.CircuitBreaker
 	data      []byte
+	fallback  bool
 }
 
 func NewAPILoader(
@@ -29,6 +33,7 @@ func NewAPILoader(
 	enginectx enginecontext.Interface,
 	jp jmespath.Interface,
 	client engineapi.RawClient,
+	cb circuitbreaker.CircuitBreaker,
 ) enginecontext.Loader {
 	return &apiLoader{
 		ctx:       ctx,
@@ -37,11 +42,12 @@ func NewAPILoader(
 		enginectx: enginectx,
 		jp:        jp,
 		client:    client,
+		cb:        cb,
 	}
 }
 
 func (a *apiLoader) HasLoaded() bool {
-	return a.data != nil
+	return a.data != nil || a.fallback
 }
 
 func (a *apiLoader) LoadData() error {
@@ -50,8 +56,15 @@ func (a *apiLoader) LoadData() error {
 		return fmt.Errorf("failed to initiaize APICal: %w", err)
 	}
 	if a.data == nil {
-		var err error
-		if a.data, err = executor.Fetch(a.ctx); err != nil {
+		err := fetchWithCircuitBreaker(a.cb, a.destination(), func() error {
+			var err error
+			a.data, err = executor.Fetch(a.ctx)
+			return err
+		})
+		if err != nil {
+			if a.fallback, err = applyFallback(a.ctx, a.entry, a.enginectx, err); a.fallback {
+				return nil
+			}
 			return fmt.Errorf("failed to fetch data for APICall: %w", err)
 		}
 	}
@@ -60,3 +73,14 @@ func (a *apiLoader) LoadData() error {
 	}
 	return nil
 }
+
+// destination returns the host of the web service called, calls to the API server are not guarded by the circuit breaker
+func (a *apiLoader) destination() string {
+	if a.entry.APICall.Service == nil {
+		return ""
+	}
+	if u, err := url.Parse(a.entry.APICall.Service.URL); err == nil && u.Host != "" {
+		return u.Host
+	}
+	return a.entry.APICall.Service.URL
+}
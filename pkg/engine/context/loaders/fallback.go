@@ -0,0 +1,41 @@
+package loaders
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/engine/circuitbreaker"
+	enginecontext "github.com/kyverno/kyverno/pkg/engine/context"
+)
+
+// fetchWithCircuitBreaker runs fetch through the circuit breaker of the destination, if any
+func fetchWithCircuitBreaker(cb circuitbreaker.CircuitBreaker, destination string, fetch func() error) error {
+	if cb == nil || destination == "" {
+		return fetch()
+	}
+	err := cb.Do(destination, fetch)
+	if errors.Is(err, circuitbreaker.ErrOpen) {
+		return fmt.Errorf("destination %s is unavailable: %w", destination, err)
+	}
+	return err
+}
+
+// applyFallback applies the fallback of the context entry when the circuit of its destination is open,
+// it returns true when the default value of the fallback was stored in the context
+func applyFallback(ctx context.Context, entry kyvernov1.ContextEntry, enginectx enginecontext.Interface, err error) (bool, error) {
+	if !errors.Is(err, circuitbreaker.ErrOpen) || entry.Fallback == nil {
+		return false, err
+	}
+	if entry.Fallback.Default != nil {
+		if err := enginectx.AddContextEntry(entry.Name, entry.Fallback.Default.Raw); err != nil {
+			return false, fmt.Errorf("failed to add fallback data to context entry %s: %w", entry.Name, err)
+		}
+		return true, nil
+	}
+	if entry.Fallback.Skip {
+		circuitbreaker.RecordSkip(ctx)
+	}
+	return false, err
+}
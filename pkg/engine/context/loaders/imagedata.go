@@ -6,8 +6,10 @@ import (
 	"fmt"
 
 	"github.com/go-logr/logr"
+	"github.com/google/go-containerregistry/pkg/name"
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
 	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	"github.com/kyverno/kyverno/pkg/engine/circuitbreaker"
 	enginecontext "github.com/kyverno/kyverno/pkg/engine/context"
 	"github.com/kyverno/kyverno/pkg/engine/jmespath"
 	"github.com/kyverno/kyverno/pkg/engine/variables"
@@ -20,7 +22,9 @@ type imageDataLoader struct {
 	enginectx      enginecontext.Interface
 	jp             jmespath.Interface
 	rclientFactory engineapi.RegistryClientFactory
+	cb             circuitbreaker.CircuitBreaker
 	data           []byte
+	fallback       bool
 }
 
 func NewImageDataLoader(
@@ -30,6 +34,7 @@ func NewImageDataLoader(
 	enginectx enginecontext.Interface,
 	jp jmespath.Interface,
 	rclientFactory engineapi.RegistryClientFactory,
+	cb circuitbreaker.CircuitBreaker,
 ) enginecontext.Loader {
 	return &imageDataLoader{
 		ctx:            ctx,
@@ -38,6 +43,7 @@ func NewImageDataLoader(
 		enginectx:      enginectx,
 		jp:             jp,
 		rclientFactory: rclientFactory,
+		cb:             cb,
 	}
 }
 
@@ -46,13 +52,16 @@ func (idl *imageDataLoader) LoadData() error {
 }
 
 func (cml *imageDataLoader) HasLoaded() bool {
-	return cml.data != nil
+	return cml.data != nil || cml.fallback
 }
 
 func (idl *imageDataLoader) loadImageData() error {
 	if idl.data == nil {
 		imageData, err := idl.fetchImageData()
 		if err != nil {
+			if idl.fallback, err = applyFallback(idl.ctx, idl.entry, idl.enginectx, err); idl.fallback {
+				return nil
+			}
 			return err
 		}
 
@@ -91,7 +100,12 @@ func (idl *imageDataLoader) fetchImageData() (interface{}, error) {
 		return nil, fmt.Errorf("failed to get registry client %s: %v", entry.Name, err)
 	}
 
-	imageData, err := idl.fetchImageDataMap(client, refString)
+	var imageData interface{}
+	err = fetchWithCircuitBreaker(idl.cb, imageRegistry(refString), func() error {
+		var err error
+		imageData, err = idl.fetchImageDataMap(client, refString)
+		return err
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -150,3 +164,12 @@ func (idl *imageDataLoader) fetchImageDataMap(client engineapi.ImageDataClient,
 
 	return untyped, nil
 }
+
+// imageRegistry returns the registry of the image reference
+func imageRegistry(ref string) string {
+	parsed, err := name.ParseReference(ref)
+	if err != nil {
+		return ""
+	}
+	return parsed.Context().RegistryStr()
+}
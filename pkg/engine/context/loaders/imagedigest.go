@@ -0,0 +1,137 @@
+package loaders
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	"github.com/google/go-containerregistry/pkg/name"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	"github.com/kyverno/kyverno/pkg/engine/circuitbreaker"
+	enginecontext "github.com/kyverno/kyverno/pkg/engine/context"
+	"github.com/kyverno/kyverno/pkg/engine/digestcache"
+	"github.com/kyverno/kyverno/pkg/engine/variables"
+)
+
+type imageDigestLoader struct {
+	ctx            context.Context //nolint:containedctx
+	logger         logr.Logger
+	entry          kyvernov1.ContextEntry
+	enginectx      enginecontext.Interface
+	rclientFactory engineapi.RegistryClientFactory
+	cache          digestcache.Cache
+	cb             circuitbreaker.CircuitBreaker
+	data           []byte
+	fallback       bool
+}
+
+func NewImageDigestLoader(
+	ctx context.Context,
+	logger logr.Logger,
+	entry kyvernov1.ContextEntry,
+	enginectx enginecontext.Interface,
+	rclientFactory engineapi.RegistryClientFactory,
+	cache digestcache.Cache,
+	cb circuitbreaker.CircuitBreaker,
+) enginecontext.Loader {
+	return &imageDigestLoader{
+		ctx:            ctx,
+		logger:         logger,
+		entry:          entry,
+		enginectx:      enginectx,
+		rclientFactory: rclientFactory,
+		cache:          cache,
+		cb:             cb,
+	}
+}
+
+func (l *imageDigestLoader) HasLoaded() bool {
+	return l.data != nil || l.fallback
+}
+
+func (l *imageDigestLoader) LoadData() error {
+	if l.data == nil {
+		imageDigest, err := l.resolveImageDigest()
+		if err != nil {
+			if l.fallback, err = applyFallback(l.ctx, l.entry, l.enginectx, err); l.fallback {
+				return nil
+			}
+			return err
+		}
+		l.data, err = json.Marshal(imageDigest)
+		if err != nil {
+			return err
+		}
+	}
+	if err := l.enginectx.AddContextEntry(l.entry.Name, l.data); err != nil {
+		return fmt.Errorf("failed to add image digest to context: contextEntry: %v, error: %v", l.entry, err)
+	}
+	return nil
+}
+
+func (l *imageDigestLoader) resolveImageDigest() (map[string]interface{}, error) {
+	entry := l.entry
+	ref, err := variables.SubstituteAll(l.logger, l.enginectx, entry.ImageDigest.Reference)
+	if err != nil {
+		return nil, fmt.Errorf("failed to substitute variables in context entry %s %s: %v", entry.Name, entry.ImageDigest.Reference, err)
+	}
+	refString, ok := ref.(string)
+	if !ok {
+		return nil, fmt.Errorf("invalid image reference %s, image reference must be a string", ref)
+	}
+	parsed, err := name.ParseReference(refString)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse image reference %s in context entry %s: %v", refString, entry.Name, err)
+	}
+	var digest string
+	if d, ok := parsed.(name.Digest); ok {
+		// the reference is already pinned, no need to call the registry
+		digest = d.DigestStr()
+	} else {
+		key := imageDigestCacheKey(parsed, entry.ImageDigest.ImageRegistryCredentials)
+		if l.cache != nil {
+			digest, ok = l.cache.Get(key)
+		}
+		if !ok {
+			if digest, err = l.fetchDigest(refString); err != nil {
+				return nil, err
+			}
+			if l.cache != nil {
+				l.cache.Set(key, digest)
+			}
+		}
+	}
+	return map[string]interface{}{
+		"image":         refString,
+		"digest":        digest,
+		"resolvedImage": fmt.Sprintf("%s@%s", parsed.Context().Name(), digest),
+	}, nil
+}
+
+func (l *imageDigestLoader) fetchDigest(ref string) (string, error) {
+	client, err := l.rclientFactory.GetClient(l.ctx, l.entry.ImageDigest.ImageRegistryCredentials)
+	if err != nil {
+		return "", fmt.Errorf("failed to get registry client %s: %v", l.entry.Name, err)
+	}
+	var digest string
+	err = fetchWithCircuitBreaker(l.cb, imageRegistry(ref), func() error {
+		desc, err := client.FetchImageDescriptor(l.ctx, ref)
+		if err != nil {
+			return fmt.Errorf("failed to fetch image descriptor: %s, error: %v", ref, err)
+		}
+		digest = desc.Digest.String()
+		return nil
+	})
+	return digest, err
+}
+
+// imageDigestCacheKey returns the cache key of an image reference, the credentials are part of the key
+// so that a digest resolved with some credentials is not served to entries using other credentials
+func imageDigestCacheKey(ref name.Reference, creds *kyvernov1.ImageRegistryCredentials) string {
+	if creds == nil {
+		return ref.Name()
+	}
+	return fmt.Sprintf("%s|%t|%v|%v", ref.Name(), creds.AllowInsecureRegistry, creds.Providers, creds.Secrets)
+}
@@ -0,0 +1,89 @@
+package loaders
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	gcrremote "github.com/google/go-containerregistry/pkg/v1/remote"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/config"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	enginecontext "github.com/kyverno/kyverno/pkg/engine/context"
+	"github.com/kyverno/kyverno/pkg/engine/digestcache"
+	"github.com/kyverno/kyverno/pkg/engine/jmespath"
+	"gotest.tools/assert"
+)
+
+const testDigest = "sha256:b5ebfef3bbb53ec9bf8d5de2c3b9b5b0bde0c8e0c6d5c3e0f3ab4e0e7c1a1b2c"
+
+type fakeDigestClient struct {
+	engineapi.RegistryClient
+	calls int
+}
+
+func (c *fakeDigestClient) FetchImageDescriptor(_ context.Context, _ string) (*gcrremote.Descriptor, error) {
+	c.calls++
+	digest, err := v1.NewHash(testDigest)
+	if err != nil {
+		return nil, err
+	}
+	return &gcrremote.Descriptor{Descriptor: v1.Descriptor{Digest: digest}}, nil
+}
+
+type fakeDigestClientFactory struct {
+	client *fakeDigestClient
+}
+
+func (f fakeDigestClientFactory) GetClient(_ context.Context, _ *kyvernov1.ImageRegistryCredentials) (engineapi.RegistryClient, error) {
+	return f.client, nil
+}
+
+func Test_ImageDigestLoader(t *testing.T) {
+	jp := jmespath.New(config.NewDefaultConfiguration(false))
+	client := &fakeDigestClient{}
+	factory := fakeDigestClientFactory{client: client}
+	cache := digestcache.New(time.Minute, 0)
+	entry := kyvernov1.ContextEntry{
+		Name: "nginx",
+		ImageDigest: &kyvernov1.ImageDigest{
+			Reference: "{{ request.image }}",
+		},
+	}
+	for i := 0; i < 2; i++ {
+		ctx := enginecontext.NewContext(jp)
+		assert.NilError(t, ctx.AddContextEntry("request", []byte(`{"image":"nginx:1.25"}`)))
+		loader := NewImageDigestLoader(context.TODO(), logr.Discard(), entry, ctx, factory, cache, nil)
+		assert.NilError(t, loader.LoadData())
+		assert.Assert(t, loader.HasLoaded())
+		data, err := ctx.Query("nginx")
+		assert.NilError(t, err)
+		assert.DeepEqual(t, data, map[string]interface{}{
+			"image":         "nginx:1.25",
+			"digest":        testDigest,
+			"resolvedImage": "index.docker.io/library/nginx@" + testDigest,
+		})
+	}
+	// the second resolution is served by the cache
+	assert.Equal(t, client.calls, 1)
+
+	// pinned references are not resolved
+	ctx := enginecontext.NewContext(jp)
+	assert.NilError(t, ctx.AddContextEntry("request", []byte(`{"image":"ghcr.io/kyverno/kyverno@`+testDigest+`"}`)))
+	loader := NewImageDigestLoader(context.TODO(), logr.Discard(), entry, ctx, factory, nil, nil)
+	assert.NilError(t, loader.LoadData())
+	digest, err := ctx.Query("nginx.digest")
+	assert.NilError(t, err)
+	assert.Equal(t, digest, testDigest)
+	assert.Equal(t, client.calls, 1)
+}
+
+func Test_imageDigestCacheKey(t *testing.T) {
+	ref, err := name.ParseReference("nginx")
+	assert.NilError(t, err)
+	assert.Equal(t, imageDigestCacheKey(ref, nil), "index.docker.io/library/nginx:latest")
+	assert.Assert(t, imageDigestCacheKey(ref, nil) != imageDigestCacheKey(ref, &kyvernov1.ImageRegistryCredentials{Secrets: []string{"regcred"}}))
+}
@@ -0,0 +1,136 @@
+package loaders
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	enginecontext "github.com/kyverno/kyverno/pkg/engine/context"
+	"github.com/kyverno/kyverno/pkg/engine/variables"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// ResourceUsage is the data stored in the context for a resourceUsage context entry
+type ResourceUsage struct {
+	Namespace string              `json:"namespace"`
+	Pods      int                 `json:"pods"`
+	Requests  corev1.ResourceList `json:"requests"`
+	Limits    corev1.ResourceList `json:"limits"`
+}
+
+type resourceUsageLoader struct {
+	ctx       context.Context //nolint:containedctx
+	logger    logr.Logger
+	entry     kyvernov1.ContextEntry
+	lister    engineapi.PodLister
+	enginectx enginecontext.Interface
+	data      []byte
+}
+
+func NewResourceUsageLoader(
+	ctx context.Context,
+	logger logr.Logger,
+	entry kyvernov1.ContextEntry,
+	lister engineapi.PodLister,
+	enginectx enginecontext.Interface,
+) enginecontext.Loader {
+	return &resourceUsageLoader{
+		ctx:       ctx,
+		logger:    logger,
+		entry:     entry,
+		lister:    lister,
+		enginectx: enginectx,
+	}
+}
+
+func (rul *resourceUsageLoader) HasLoaded() bool {
+	return rul.data != nil
+}
+
+func (rul *resourceUsageLoader) LoadData() error {
+	if rul.lister == nil {
+		return fmt.Errorf("a PodLister is required")
+	}
+
+	if rul.data == nil {
+		data, err := rul.fetchResourceUsage()
+		if err != nil {
+			return fmt.Errorf("failed to retrieve resource usage for context entry %s: %v", rul.entry.Name, err)
+		}
+
+		rul.data = data
+	}
+
+	if err := rul.enginectx.AddContextEntry(rul.entry.Name, rul.data); err != nil {
+		return fmt.Errorf("failed to add resource usage for context entry %s: %v", rul.entry.Name, err)
+	}
+
+	return nil
+}
+
+func (rul *resourceUsageLoader) fetchResourceUsage() ([]byte, error) {
+	namespace, err := variables.SubstituteAll(rul.logger, rul.enginectx, rul.entry.ResourceUsage.Namespace)
+	if err != nil {
+		return nil, fmt.Errorf("failed to substitute variables in context %s resourceUsage.namespace %s: %v", rul.entry.Name, rul.entry.ResourceUsage.Namespace, err)
+	}
+	ns, ok := namespace.(string)
+	if !ok || ns == "" {
+		return nil, fmt.Errorf("invalid namespace %v in context %s resourceUsage.namespace", namespace, rul.entry.Name)
+	}
+	pods, err := rul.lister.List(rul.ctx, ns)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pods in namespace %s: %v", ns, err)
+	}
+	data, err := json.Marshal(SumResourceUsage(ns, pods))
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal resource usage of namespace %s: %v", ns, err)
+	}
+	return data, nil
+}
+
+// SumResourceUsage sums the requests and limits of the given pods, pods in a terminal phase are skipped.
+// The resources of a pod are computed the same way as the scheduler and resource quotas do,
+// the highest of the sum of the containers and of any init container, plus the pod overhead.
+func SumResourceUsage(namespace string, pods []*corev1.Pod) ResourceUsage {
+	usage := ResourceUsage{
+		Namespace: namespace,
+		Requests:  corev1.ResourceList{},
+		Limits:    corev1.ResourceList{},
+	}
+	for _, pod := range pods {
+		if pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+			continue
+		}
+		usage.Pods++
+		addResources(usage.Requests, podResources(pod, func(r corev1.ResourceRequirements) corev1.ResourceList { return r.Requests }))
+		addResources(usage.Limits, podResources(pod, func(r corev1.ResourceRequirements) corev1.ResourceList { return r.Limits }))
+	}
+	return usage
+}
+
+func podResources(pod *corev1.Pod, get func(corev1.ResourceRequirements) corev1.ResourceList) corev1.ResourceList {
+	resources := corev1.ResourceList{}
+	for _, container := range pod.Spec.Containers {
+		addResources(resources, get(container.Resources))
+	}
+	for _, container := range pod.Spec.InitContainers {
+		for name, quantity := range get(container.Resources) {
+			if current, ok := resources[name]; !ok || quantity.Cmp(current) > 0 {
+				resources[name] = quantity.DeepCopy()
+			}
+		}
+	}
+	addResources(resources, pod.Spec.Overhead)
+	return resources
+}
+
+func addResources(total corev1.ResourceList, resources corev1.ResourceList) {
+	for name, quantity := range resources {
+		current := total[name]
+		current.Add(quantity)
+		total[name] = current
+	}
+}
@@ -0,0 +1,101 @@
+package loaders
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/config"
+	enginecontext "github.com/kyverno/kyverno/pkg/engine/context"
+	"github.com/kyverno/kyverno/pkg/engine/jmespath"
+	"gotest.tools/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+type fakePodLister struct {
+	pods map[string][]*corev1.Pod
+}
+
+func (l fakePodLister) List(_ context.Context, namespace string) ([]*corev1.Pod, error) {
+	return l.pods[namespace], nil
+}
+
+func newPod(phase corev1.PodPhase, containers []corev1.ResourceRequirements, initContainers []corev1.ResourceRequirements) *corev1.Pod {
+	pod := &corev1.Pod{Status: corev1.PodStatus{Phase: phase}}
+	for _, resources := range containers {
+		pod.Spec.Containers = append(pod.Spec.Containers, corev1.Container{Resources: resources})
+	}
+	for _, resources := range initContainers {
+		pod.Spec.InitContainers = append(pod.Spec.InitContainers, corev1.Container{Resources: resources})
+	}
+	return pod
+}
+
+func resources(cpu, memory string) corev1.ResourceRequirements {
+	list := corev1.ResourceList{}
+	if cpu != "" {
+		list[corev1.ResourceCPU] = resource.MustParse(cpu)
+	}
+	if memory != "" {
+		list[corev1.ResourceMemory] = resource.MustParse(memory)
+	}
+	return corev1.ResourceRequirements{Requests: list, Limits: list}
+}
+
+func Test_SumResourceUsage(t *testing.T) {
+	overhead := newPod(corev1.PodRunning, []corev1.ResourceRequirements{resources("100m", "")}, nil)
+	overhead.Spec.Overhead = corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("50m")}
+	pods := []*corev1.Pod{
+		newPod(corev1.PodRunning, []corev1.ResourceRequirements{resources("500m", "128Mi"), resources("250m", "64Mi")}, nil),
+		newPod(corev1.PodPending, []corev1.ResourceRequirements{resources("1", "")}, []corev1.ResourceRequirements{resources("2", "256Mi")}),
+		newPod(corev1.PodSucceeded, []corev1.ResourceRequirements{resources("4", "1Gi")}, nil),
+		newPod(corev1.PodFailed, []corev1.ResourceRequirements{resources("4", "1Gi")}, nil),
+		overhead,
+	}
+	usage := SumResourceUsage("test", pods)
+	assert.Equal(t, usage.Namespace, "test")
+	assert.Equal(t, usage.Pods, 3)
+	cpu := usage.Requests[corev1.ResourceCPU]
+	assert.Equal(t, cpu.String(), "2900m")
+	memory := usage.Requests[corev1.ResourceMemory]
+	assert.Equal(t, memory.String(), "448Mi")
+	cpu = usage.Limits[corev1.ResourceCPU]
+	assert.Equal(t, cpu.String(), "2900m")
+}
+
+func Test_ResourceUsageLoader(t *testing.T) {
+	jp := jmespath.New(config.NewDefaultConfiguration(false))
+	ctx := enginecontext.NewContext(jp)
+	assert.NilError(t, ctx.AddContextEntry("request", []byte(`{"namespace":"test"}`)))
+	lister := fakePodLister{
+		pods: map[string][]*corev1.Pod{
+			"test": {
+				newPod(corev1.PodRunning, []corev1.ResourceRequirements{resources("500m", "128Mi")}, nil),
+				newPod(corev1.PodRunning, []corev1.ResourceRequirements{resources("1", "")}, nil),
+			},
+		},
+	}
+	entry := kyvernov1.ContextEntry{
+		Name: "usage",
+		ResourceUsage: &kyvernov1.ResourceUsage{
+			Namespace: "{{ request.namespace }}",
+		},
+	}
+	loader := NewResourceUsageLoader(context.TODO(), logr.Discard(), entry, lister, ctx)
+	assert.NilError(t, loader.LoadData())
+	assert.Assert(t, loader.HasLoaded())
+	pods, err := ctx.Query("usage.pods")
+	assert.NilError(t, err)
+	assert.Equal(t, pods, float64(2))
+	cpu, err := ctx.Query("usage.requests.cpu")
+	assert.NilError(t, err)
+	assert.Equal(t, cpu, "1500m")
+	limits, err := ctx.Query("usage.limits")
+	assert.NilError(t, err)
+	assert.DeepEqual(t, limits, map[string]interface{}{"cpu": "1500m", "memory": "128Mi"})
+
+	loader = NewResourceUsageLoader(context.TODO(), logr.Discard(), entry, nil, ctx)
+	assert.Error(t, loader.LoadData(), "a PodLister is required")
+}
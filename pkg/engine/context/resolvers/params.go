@@ -0,0 +1,112 @@
+package resolvers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/kyverno/kyverno/pkg/clients/dclient"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic/dynamicinformer"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/tools/cache"
+)
+
+// findParamsResource returns the resource of a parameter kind and whether it is namespaced
+func findParamsResource(client dclient.Interface, gvk schema.GroupVersionKind) (schema.GroupVersionResource, bool, error) {
+	resources, err := client.Discovery().FindResources(gvk.Group, gvk.Version, gvk.Kind, "")
+	if err != nil {
+		return schema.GroupVersionResource{}, false, err
+	}
+	for api, resource := range resources {
+		return api.GroupVersionResource(), resource.Namespaced, nil
+	}
+	return schema.GroupVersionResource{}, false, fmt.Errorf("failed to find resource for parameter kind %s", gvk)
+}
+
+type clientBasedParamsResolver struct {
+	client dclient.Interface
+}
+
+// NewClientBasedParamsResolver returns a params resolver getting the parameter resources from the API server
+func NewClientBasedParamsResolver(client dclient.Interface) (engineapi.ParamsResolver, error) {
+	if client == nil {
+		return nil, errors.New("client must not be nil")
+	}
+	return &clientBasedParamsResolver{client}, nil
+}
+
+func (c *clientBasedParamsResolver) Get(ctx context.Context, gvk schema.GroupVersionKind, namespace, name string) (*unstructured.Unstructured, error) {
+	_, namespaced, err := findParamsResource(c.client, gvk)
+	if err != nil {
+		return nil, err
+	}
+	if !namespaced {
+		namespace = ""
+	}
+	return c.client.GetResource(ctx, gvk.GroupVersion().String(), gvk.Kind, namespace, name)
+}
+
+type informerBasedParamsResolver struct {
+	client    dclient.Interface
+	factory   dynamicinformer.DynamicSharedInformerFactory
+	stopCh    <-chan struct{}
+	lock      sync.Mutex
+	informers map[schema.GroupVersionResource]informers.GenericInformer
+}
+
+// NewInformerBasedParamsResolver returns a params resolver reading the parameter resources from informer caches,
+// an informer is started the first time a parameter kind is resolved and runs until the context is cancelled
+func NewInformerBasedParamsResolver(ctx context.Context, client dclient.Interface, resyncPeriod time.Duration) (engineapi.ParamsResolver, error) {
+	if client == nil {
+		return nil, errors.New("client must not be nil")
+	}
+	return &informerBasedParamsResolver{
+		client:    client,
+		factory:   dynamicinformer.NewDynamicSharedInformerFactory(client.GetDynamicInterface(), resyncPeriod),
+		stopCh:    ctx.Done(),
+		informers: map[schema.GroupVersionResource]informers.GenericInformer{},
+	}, nil
+}
+
+func (i *informerBasedParamsResolver) Get(ctx context.Context, gvk schema.GroupVersionKind, namespace, name string) (*unstructured.Unstructured, error) {
+	gvr, namespaced, err := findParamsResource(i.client, gvk)
+	if err != nil {
+		return nil, err
+	}
+	informer := i.informer(gvr)
+	if !cache.WaitForCacheSync(ctx.Done(), informer.Informer().HasSynced) {
+		return nil, fmt.Errorf("failed to wait for cache sync of %s", gvr)
+	}
+	var obj runtime.Object
+	if namespaced {
+		obj, err = informer.Lister().ByNamespace(namespace).Get(name)
+	} else {
+		obj, err = informer.Lister().Get(name)
+	}
+	if err != nil {
+		return nil, err
+	}
+	param, ok := obj.(*unstructured.Unstructured)
+	if !ok {
+		return nil, fmt.Errorf("unexpected object type %T for %s", obj, gvr)
+	}
+	return param.DeepCopy(), nil
+}
+
+func (i *informerBasedParamsResolver) informer(gvr schema.GroupVersionResource) informers.GenericInformer {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+	if informer, ok := i.informers[gvr]; ok {
+		return informer
+	}
+	informer := i.factory.ForResource(gvr)
+	i.factory.Start(i.stopCh)
+	i.informers[gvr] = informer
+	return informer
+}
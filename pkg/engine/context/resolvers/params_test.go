@@ -0,0 +1,105 @@
+package resolvers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kyverno/kyverno/pkg/clients/dclient"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	"gotest.tools/assert"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// paramsDiscovery reports config maps as namespaced, the fake discovery client reports all resources as cluster scoped
+type paramsDiscovery struct {
+	dclient.IDiscovery
+}
+
+func (d paramsDiscovery) FindResources(group, version, kind, subresource string) (map[dclient.TopLevelApiDescription]metav1.APIResource, error) {
+	resources, err := d.IDiscovery.FindResources(group, version, kind, subresource)
+	for api, resource := range resources {
+		resource.Namespaced = kind == "ConfigMap"
+		resources[api] = resource
+	}
+	return resources, err
+}
+
+func newParamsFakeClient(t *testing.T) dclient.Interface {
+	configMap := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "ConfigMap",
+		"metadata": map[string]interface{}{
+			"name":      "params",
+			"namespace": "test",
+		},
+		"data": map[string]interface{}{
+			"replicas": "3",
+		},
+	}}
+	namespace := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "Namespace",
+		"metadata": map[string]interface{}{
+			"name": "params",
+		},
+	}}
+	client, err := dclient.NewFakeClient(
+		runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{
+			{Version: "v1", Resource: "configmaps"}: "ConfigMapList",
+			{Version: "v1", Resource: "namespaces"}: "NamespaceList",
+		},
+		configMap,
+		namespace,
+	)
+	assert.NilError(t, err)
+	client.SetDiscovery(paramsDiscovery{dclient.NewFakeDiscoveryClient(nil)})
+	return client
+}
+
+func testParamsResolver(t *testing.T, resolver engineapi.ParamsResolver) {
+	ctx := context.TODO()
+	configMaps := schema.GroupVersionKind{Version: "v1", Kind: "ConfigMap"}
+	namespaces := schema.GroupVersionKind{Version: "v1", Kind: "Namespace"}
+	// namespaced kind
+	param, err := resolver.Get(ctx, configMaps, "test", "params")
+	assert.NilError(t, err)
+	assert.Equal(t, param.GetName(), "params")
+	assert.Equal(t, param.Object["data"].(map[string]interface{})["replicas"], "3")
+	// namespaced kind in another namespace
+	_, err = resolver.Get(ctx, configMaps, "other", "params")
+	assert.Assert(t, apierrors.IsNotFound(err))
+	// the namespace is ignored for cluster scoped kinds
+	param, err = resolver.Get(ctx, namespaces, "test", "params")
+	assert.NilError(t, err)
+	assert.Equal(t, param.GetName(), "params")
+	// unknown kind
+	_, err = resolver.Get(ctx, schema.GroupVersionKind{Group: "example.com", Version: "v1", Kind: "Unknown"}, "test", "params")
+	assert.Assert(t, err != nil)
+}
+
+func Test_ClientBasedParamsResolver(t *testing.T) {
+	resolver, err := NewClientBasedParamsResolver(newParamsFakeClient(t))
+	assert.NilError(t, err)
+	testParamsResolver(t, resolver)
+}
+
+func Test_InformerBasedParamsResolver(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.TODO())
+	defer cancel()
+	resolver, err := NewInformerBasedParamsResolver(ctx, newParamsFakeClient(t), time.Minute)
+	assert.NilError(t, err)
+	testParamsResolver(t, resolver)
+}
+
+func TestNewParamsResolvers(t *testing.T) {
+	_, err := NewClientBasedParamsResolver(nil)
+	assert.Error(t, err, "client must not be nil")
+	_, err = NewInformerBasedParamsResolver(context.TODO(), nil, time.Minute)
+	assert.Error(t, err, "client must not be nil")
+}
@@ -0,0 +1,51 @@
+package resolvers
+
+import (
+	"context"
+	"errors"
+
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+)
+
+type informerBasedPodLister struct {
+	lister corev1listers.PodLister
+}
+
+func NewInformerBasedPodLister(lister corev1listers.PodLister) (engineapi.PodLister, error) {
+	if lister == nil {
+		return nil, errors.New("lister must not be nil")
+	}
+	return &informerBasedPodLister{lister}, nil
+}
+
+func (i *informerBasedPodLister) List(ctx context.Context, namespace string) ([]*corev1.Pod, error) {
+	return i.lister.Pods(namespace).List(labels.Everything())
+}
+
+type clientBasedPodLister struct {
+	kubeClient kubernetes.Interface
+}
+
+func NewClientBasedPodLister(client kubernetes.Interface) (engineapi.PodLister, error) {
+	if client == nil {
+		return nil, errors.New("client must not be nil")
+	}
+	return &clientBasedPodLister{client}, nil
+}
+
+func (c *clientBasedPodLister) List(ctx context.Context, namespace string) ([]*corev1.Pod, error) {
+	list, err := c.kubeClient.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	pods := make([]*corev1.Pod, 0, len(list.Items))
+	for i := range list.Items {
+		pods = append(pods, &list.Items[i])
+	}
+	return pods, nil
+}
@@ -0,0 +1,62 @@
+package resolvers
+
+import (
+	"context"
+	"testing"
+
+	"gotest.tools/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubeinformers "k8s.io/client-go/informers"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+)
+
+func createPods(ctx context.Context, client *kubefake.Clientset) error {
+	for _, pod := range []*corev1.Pod{
+		{ObjectMeta: metav1.ObjectMeta{Name: "pod-1", Namespace: namespace}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "pod-2", Namespace: namespace}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "pod-3", Namespace: "other"}},
+	} {
+		if _, err := client.CoreV1().Pods(pod.Namespace).Create(ctx, pod, metav1.CreateOptions{}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func Test_InformerBasedPodLister(t *testing.T) {
+	client := newEmptyFakeClient()
+	ctx := context.TODO()
+	err := createPods(ctx, client)
+	assert.NilError(t, err, "error while creating pods")
+	informer := kubeinformers.NewSharedInformerFactory(client, 0)
+	lister, err := NewInformerBasedPodLister(informer.Core().V1().Pods().Lister())
+	assert.NilError(t, err)
+	stop := make(chan struct{})
+	defer close(stop)
+	informer.Start(stop)
+	informer.WaitForCacheSync(stop)
+	pods, err := lister.List(ctx, namespace)
+	assert.NilError(t, err)
+	assert.Equal(t, len(pods), 2)
+}
+
+func Test_ClientBasedPodLister(t *testing.T) {
+	client := newEmptyFakeClient()
+	ctx := context.TODO()
+	err := createPods(ctx, client)
+	assert.NilError(t, err, "error while creating pods")
+	lister, err := NewClientBasedPodLister(client)
+	assert.NilError(t, err)
+	pods, err := lister.List(ctx, "other")
+	assert.NilError(t, err)
+	assert.Equal(t, len(pods), 1)
+	assert.Equal(t, pods[0].Name, "pod-3")
+}
+
+func TestNewPodListers(t *testing.T) {
+	_, err := NewInformerBasedPodLister(nil)
+	assert.Error(t, err, "lister must not be nil")
+	_, err = NewClientBasedPodLister(nil)
+	assert.Error(t, err, "client must not be nil")
+}
@@ -0,0 +1,89 @@
+package resolvers
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/kyverno/kyverno/pkg/clients/dclient"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	"github.com/kyverno/kyverno/pkg/engine/mutate/patch"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/kustomize/kyaml/openapi"
+)
+
+type cachedSchema struct {
+	schema  *openapi.ResourceSchema
+	expires time.Time
+}
+
+type clientBasedSchemaResolver struct {
+	client dclient.Interface
+	ttl    time.Duration
+	lock   sync.Mutex
+	cache  map[schema.GroupVersionKind]cachedSchema
+}
+
+// NewClientBasedSchemaResolver returns a schema resolver reading the schemas from custom resource definitions,
+// schemas are cached for the given duration
+func NewClientBasedSchemaResolver(client dclient.Interface, ttl time.Duration) (engineapi.SchemaResolver, error) {
+	if client == nil {
+		return nil, errors.New("client must not be nil")
+	}
+	return &clientBasedSchemaResolver{
+		client: client,
+		ttl:    ttl,
+		cache:  map[schema.GroupVersionKind]cachedSchema{},
+	}, nil
+}
+
+func (c *clientBasedSchemaResolver) Get(ctx context.Context, gvk schema.GroupVersionKind) (*openapi.ResourceSchema, error) {
+	c.lock.Lock()
+	cached, ok := c.cache[gvk]
+	c.lock.Unlock()
+	if ok && time.Now().Before(cached.expires) {
+		return cached.schema, nil
+	}
+	resolved, err := c.resolve(ctx, gvk)
+	if err != nil {
+		return nil, err
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.cache[gvk] = cachedSchema{
+		schema:  resolved,
+		expires: time.Now().Add(c.ttl),
+	}
+	return resolved, nil
+}
+
+func (c *clientBasedSchemaResolver) resolve(ctx context.Context, gvk schema.GroupVersionKind) (*openapi.ResourceSchema, error) {
+	if gvk.Group == "" {
+		return nil, nil
+	}
+	gvr, err := c.client.Discovery().GetGVRFromGVK(gvk)
+	if err != nil {
+		return nil, err
+	}
+	obj, err := c.client.GetResource(ctx, "apiextensions.k8s.io/v1", "CustomResourceDefinition", "", gvr.Resource+"."+gvr.Group)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var crd apiextensionsv1.CustomResourceDefinition
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, &crd); err != nil {
+		return nil, err
+	}
+	for _, version := range crd.Spec.Versions {
+		if version.Name == gvk.Version && version.Schema != nil {
+			return patch.NewSchema(version.Schema.OpenAPIV3Schema)
+		}
+	}
+	return nil, nil
+}
@@ -0,0 +1,101 @@
+package resolvers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kyverno/kyverno/pkg/clients/dclient"
+	"gotest.tools/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func newSchemaFakeClient(t *testing.T) dclient.Interface {
+	crd := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "apiextensions.k8s.io/v1",
+		"kind":       "CustomResourceDefinition",
+		"metadata": map[string]interface{}{
+			"name": "gateways.example.com",
+		},
+		"spec": map[string]interface{}{
+			"group": "example.com",
+			"names": map[string]interface{}{
+				"kind":   "Gateway",
+				"plural": "gateways",
+			},
+			"scope": "Namespaced",
+			"versions": []interface{}{
+				map[string]interface{}{
+					"name":    "v1",
+					"served":  true,
+					"storage": true,
+					"schema": map[string]interface{}{
+						"openAPIV3Schema": map[string]interface{}{
+							"type": "object",
+							"properties": map[string]interface{}{
+								"spec": map[string]interface{}{
+									"type": "object",
+									"properties": map[string]interface{}{
+										"listeners": map[string]interface{}{
+											"type":                       "array",
+											"x-kubernetes-list-type":     "map",
+											"x-kubernetes-list-map-keys": []interface{}{"name"},
+											"items": map[string]interface{}{
+												"type": "object",
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}}
+	crdGVR := schema.GroupVersionResource{Group: "apiextensions.k8s.io", Version: "v1", Resource: "customresourcedefinitions"}
+	client, err := dclient.NewFakeClient(
+		runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{crdGVR: "CustomResourceDefinitionList"},
+		crd,
+	)
+	assert.NilError(t, err)
+	client.SetDiscovery(dclient.NewFakeDiscoveryClient([]schema.GroupVersionResource{
+		crdGVR,
+		{Group: "example.com", Version: "v1", Resource: "gateways"},
+		{Group: "example.com", Version: "v1", Resource: "routes"},
+	}))
+	return client
+}
+
+func Test_ClientBasedSchemaResolver(t *testing.T) {
+	resolver, err := NewClientBasedSchemaResolver(newSchemaFakeClient(t), time.Minute)
+	assert.NilError(t, err)
+	ctx := context.TODO()
+	// custom resource
+	resolved, err := resolver.Get(ctx, schema.GroupVersionKind{Group: "example.com", Version: "v1", Kind: "Gateway"})
+	assert.NilError(t, err)
+	assert.Assert(t, resolved != nil)
+	strategy, keys := resolved.Lookup("spec", "listeners").PatchStrategyAndKeyList()
+	assert.Equal(t, strategy, "merge")
+	assert.DeepEqual(t, keys, []string{"name"})
+	// unknown version
+	resolved, err = resolver.Get(ctx, schema.GroupVersionKind{Group: "example.com", Version: "v2", Kind: "Gateway"})
+	assert.NilError(t, err)
+	assert.Assert(t, resolved == nil)
+	// no custom resource definition
+	resolved, err = resolver.Get(ctx, schema.GroupVersionKind{Group: "example.com", Version: "v1", Kind: "Route"})
+	assert.NilError(t, err)
+	assert.Assert(t, resolved == nil)
+	// core group
+	resolved, err = resolver.Get(ctx, schema.GroupVersionKind{Version: "v1", Kind: "ConfigMap"})
+	assert.NilError(t, err)
+	assert.Assert(t, resolved == nil)
+}
+
+func TestNewClientBasedSchemaResolver(t *testing.T) {
+	_, err := NewClientBasedSchemaResolver(nil, time.Minute)
+	assert.Error(t, err, "client must not be nil")
+}
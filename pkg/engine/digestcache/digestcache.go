@@ -0,0 +1,89 @@
+package digestcache
+
+import (
+	"sync"
+	"time"
+)
+
+// Cache stores the digests image references resolve to, entries expire after a TTL so that
+// moving tags are eventually resolved again.
+type Cache interface {
+	// Get returns the digest stored for key, if it did not expire
+	Get(key string) (string, bool)
+	// Set stores the digest for key
+	Set(key string, digest string)
+}
+
+type entry struct {
+	digest    string
+	expiresAt time.Time
+}
+
+type cache struct {
+	lock    sync.Mutex
+	ttl     time.Duration
+	maxSize int
+	now     func() time.Time
+	entries map[string]entry
+}
+
+// New returns a cache keeping digests for ttl, when maxSize is positive the cache holds at most maxSize entries.
+// It returns nil when ttl is not positive.
+func New(ttl time.Duration, maxSize int) Cache {
+	if ttl <= 0 {
+		return nil
+	}
+	return newCache(ttl, maxSize, time.Now)
+}
+
+func newCache(ttl time.Duration, maxSize int, now func() time.Time) *cache {
+	return &cache{
+		ttl:     ttl,
+		maxSize: maxSize,
+		now:     now,
+		entries: map[string]entry{},
+	}
+}
+
+func (c *cache) Get(key string) (string, bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	e, ok := c.entries[key]
+	if !ok {
+		return "", false
+	}
+	if !c.now().Before(e.expiresAt) {
+		delete(c.entries, key)
+		return "", false
+	}
+	return e.digest, true
+}
+
+func (c *cache) Set(key string, digest string) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	now := c.now()
+	if _, ok := c.entries[key]; !ok && c.maxSize > 0 && len(c.entries) >= c.maxSize {
+		c.evict(now)
+	}
+	c.entries[key] = entry{
+		digest:    digest,
+		expiresAt: now.Add(c.ttl),
+	}
+}
+
+// evict removes the expired entries, or the entry expiring first if none expired
+func (c *cache) evict(now time.Time) {
+	var oldest string
+	var oldestExpiresAt time.Time
+	for key, e := range c.entries {
+		if !now.Before(e.expiresAt) {
+			delete(c.entries, key)
+		} else if oldest == "" || e.expiresAt.Before(oldestExpiresAt) {
+			oldest, oldestExpiresAt = key, e.expiresAt
+		}
+	}
+	if len(c.entries) >= c.maxSize {
+		delete(c.entries, oldest)
+	}
+}
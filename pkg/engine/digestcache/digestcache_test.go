@@ -0,0 +1,53 @@
+package digestcache
+
+import (
+	"testing"
+	"time"
+
+	"gotest.tools/assert"
+)
+
+func Test_Disabled(t *testing.T) {
+	assert.Assert(t, New(0, 10) == nil)
+}
+
+func Test_Expiration(t *testing.T) {
+	now := time.Now()
+	c := newCache(time.Minute, 0, func() time.Time { return now })
+	c.Set("nginx:latest", "sha256:1")
+	digest, ok := c.Get("nginx:latest")
+	assert.Assert(t, ok)
+	assert.Equal(t, digest, "sha256:1")
+	_, ok = c.Get("nginx:stable")
+	assert.Assert(t, !ok)
+	now = now.Add(time.Minute)
+	_, ok = c.Get("nginx:latest")
+	assert.Assert(t, !ok)
+	assert.Equal(t, len(c.entries), 0)
+}
+
+func Test_MaxSize(t *testing.T) {
+	now := time.Now()
+	c := newCache(time.Minute, 2, func() time.Time { return now })
+	c.Set("a", "sha256:a")
+	now = now.Add(time.Second)
+	c.Set("b", "sha256:b")
+	now = now.Add(time.Second)
+	// updating an existing entry does not evict
+	c.Set("a", "sha256:a2")
+	assert.Equal(t, len(c.entries), 2)
+	// b expires first and is evicted
+	c.Set("c", "sha256:c")
+	assert.Equal(t, len(c.entries), 2)
+	_, ok := c.Get("b")
+	assert.Assert(t, !ok)
+	digest, ok := c.Get("a")
+	assert.Assert(t, ok)
+	assert.Equal(t, digest, "sha256:a2")
+	// expired entries are evicted first
+	now = now.Add(time.Minute)
+	c.Set("d", "sha256:d")
+	assert.Equal(t, len(c.entries), 1)
+	_, ok = c.Get("d")
+	assert.Assert(t, ok)
+}
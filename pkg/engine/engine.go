@@ -10,6 +10,7 @@ import (
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
 	"github.com/kyverno/kyverno/pkg/config"
 	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	"github.com/kyverno/kyverno/pkg/engine/circuitbreaker"
 	enginecontext "github.com/kyverno/kyverno/pkg/engine/context"
 	"github.com/kyverno/kyverno/pkg/engine/handlers"
 	"github.com/kyverno/kyverno/pkg/engine/internal"
@@ -18,6 +19,7 @@ import (
 	"github.com/kyverno/kyverno/pkg/imageverifycache"
 	"github.com/kyverno/kyverno/pkg/logging"
 	"github.com/kyverno/kyverno/pkg/metrics"
+	"github.com/kyverno/kyverno/pkg/profiling/rulestats"
 	"github.com/kyverno/kyverno/pkg/tracing"
 	stringutils "github.com/kyverno/kyverno/pkg/utils/strings"
 	"go.opentelemetry.io/otel"
@@ -35,10 +37,13 @@ type engine struct {
 	ivCache                  imageverifycache.Client
 	contextLoader            engineapi.ContextLoaderFactory
 	exceptionSelector        engineapi.PolicyExceptionSelector
+	schemaResolver           engineapi.SchemaResolver
+	paramsResolver           engineapi.ParamsResolver
 	imageSignatureRepository string
 	// metrics
 	resultCounter     metric.Int64Counter
 	durationHistogram metric.Float64Histogram
+	skipCounter       metric.Int64Counter
 }
 
 type handlerFactory = func() (handlers.Handler, error)
@@ -52,6 +57,8 @@ func NewEngine(
 	ivCache imageverifycache.Client,
 	contextLoader engineapi.ContextLoaderFactory,
 	exceptionSelector engineapi.PolicyExceptionSelector,
+	schemaResolver engineapi.SchemaResolver,
+	paramsResolver engineapi.ParamsResolver,
 	imageSignatureRepository string,
 ) engineapi.Engine {
 	meter := otel.GetMeterProvider().Meter(metrics.MeterName)
@@ -69,6 +76,13 @@ func NewEngine(
 	if err != nil {
 		logging.Error(err, "failed to register metric kyverno_policy_execution_duration_seconds")
 	}
+	skipCounter, err := meter.Int64Counter(
+		"kyverno_policy_rule_skips",
+		metric.WithDescription("can be used to track the rules that were skipped or did not match the resources, by skip reason"),
+	)
+	if err != nil {
+		logging.Error(err, "failed to register metric kyverno_policy_rule_skips")
+	}
 	return &engine{
 		configuration:            configuration,
 		metricsConfiguration:     metricsConfiguration,
@@ -78,9 +92,12 @@ func NewEngine(
 		ivCache:                  ivCache,
 		contextLoader:            contextLoader,
 		exceptionSelector:        exceptionSelector,
+		schemaResolver:           schemaResolver,
+		paramsResolver:           paramsResolver,
 		imageSignatureRepository: imageSignatureRepository,
 		resultCounter:            resultCounter,
 		durationHistogram:        durationHistogram,
+		skipCounter:              skipCounter,
 	}
 }
 
@@ -97,6 +114,7 @@ func (e *engine) Validate(
 	}
 	response = response.WithStats(engineapi.NewExecutionStats(startTime, time.Now()))
 	e.reportMetrics(ctx, logger, policyContext.Operation(), policyContext.AdmissionOperation(), response)
+	rulestats.Record(response)
 	return response
 }
 
@@ -115,6 +133,7 @@ func (e *engine) Mutate(
 	}
 	response = response.WithStats(engineapi.NewExecutionStats(startTime, time.Now()))
 	e.reportMetrics(ctx, logger, policyContext.Operation(), policyContext.AdmissionOperation(), response)
+	rulestats.Record(response)
 	return response
 }
 
@@ -131,6 +150,7 @@ func (e *engine) Generate(
 	}
 	response = response.WithStats(engineapi.NewExecutionStats(startTime, time.Now()))
 	e.reportMetrics(ctx, logger, policyContext.Operation(), policyContext.AdmissionOperation(), response)
+	rulestats.Record(response)
 	return response
 }
 
@@ -150,6 +170,7 @@ func (e *engine) VerifyAndPatchImages(
 	}
 	response = response.WithStats(engineapi.NewExecutionStats(startTime, time.Now()))
 	e.reportMetrics(ctx, logger, policyContext.Operation(), policyContext.AdmissionOperation(), response)
+	rulestats.Record(response)
 	return response, ivm
 }
 
@@ -166,6 +187,7 @@ func (e *engine) ApplyBackgroundChecks(
 	}
 	response = response.WithStats(engineapi.NewExecutionStats(startTime, time.Now()))
 	e.reportMetrics(ctx, logger, policyContext.Operation(), policyContext.AdmissionOperation(), response)
+	rulestats.Record(response)
 	return response
 }
 
@@ -175,6 +197,10 @@ func (e *engine) ContextLoader(
 ) engineapi.EngineContextLoader {
 	loader := e.contextLoader(policy, rule)
 	return func(ctx context.Context, contextEntries []kyvernov1.ContextEntry, jsonContext enginecontext.Interface) error {
+		contextEntries, err := enginecontext.SortEntries(contextEntries)
+		if err != nil {
+			return err
+		}
 		return loader.Load(
 			ctx,
 			e.jp,
@@ -240,8 +266,10 @@ func (e *engine) invokeRuleHandler(
 	resource unstructured.Unstructured,
 	rule kyvernov1.Rule,
 	ruleType engineapi.RuleType,
-) (unstructured.Unstructured, []engineapi.RuleResponse) {
-	return tracing.ChildSpan2(
+) (unstructured.Unstructured, []engineapi.RuleResponse, engineapi.SkipReason) {
+	// notMatched is the reason why the rule did not match the resource, only set for rules of the given type
+	var notMatched engineapi.SkipReason
+	patchedResource, responses := tracing.ChildSpan2(
 		ctx,
 		"pkg/engine",
 		fmt.Sprintf("RULE %s", rule.Name),
@@ -249,6 +277,9 @@ func (e *engine) invokeRuleHandler(
 			// check if resource and rule match
 			if err := e.matches(rule, policyContext, resource); err != nil {
 				logger.V(4).Info("rule not matched", "reason", err.Error())
+				if hasRuleType(rule, ruleType) {
+					notMatched = e.notMatchedReason(rule, policyContext, resource)
+				}
 				return resource, nil
 			}
 			if handlerFactory == nil {
@@ -256,10 +287,21 @@ func (e *engine) invokeRuleHandler(
 			} else if handler, err := handlerFactory(); err != nil {
 				return resource, handlers.WithError(rule, ruleType, "failed to instantiate handler", err)
 			} else if handler != nil {
+				// check if the policy and the rule are active
+				if ruleResp := e.checkSchedule(time.Now(), ruleType, policyContext.Policy(), rule); ruleResp != nil {
+					return resource, handlers.WithResponses(ruleResp)
+				}
 				// check if there's an exception
 				if ruleResp := e.hasPolicyExceptions(logger, ruleType, policyContext, rule); ruleResp != nil {
 					return resource, handlers.WithResponses(ruleResp)
 				}
+				// record the time spent loading the context and evaluating preconditions
+				var timings engineapi.RuleTimings
+				defer func() {
+					for i := range results {
+						results[i] = *results[i].WithTimings(timings)
+					}
+				}()
 				policyContext.JSONContext().Checkpoint()
 				defer func() {
 					policyContext.JSONContext().Restore()
@@ -269,9 +311,31 @@ func (e *engine) invokeRuleHandler(
 						}
 					}
 				}()
+				// load global values
+				if err := e.loadGlobalValues(policyContext); err != nil {
+					logger.Error(err, "failed to load global values")
+					return resource, handlers.WithError(rule, ruleType, "failed to load global values", err)
+				}
+				// load policy parameters
+				if found, err := e.loadParams(ctx, logger, policyContext); err != nil {
+					logger.Error(err, "failed to load policy parameters")
+					return resource, handlers.WithError(rule, ruleType, "failed to load policy parameters", err)
+				} else if !found {
+					return resource, handlers.WithSkip(rule, ruleType, "policy parameters not found", engineapi.SkipReasonParamsNotFound)
+				}
+				// context entries can skip the rule when their destination is unavailable
+				ctx, skipped := circuitbreaker.WithSkipRecorder(ctx)
+				defer func() {
+					if skipped() {
+						results = skipErrors(results)
+					}
+				}()
 				// load rule context
 				contextLoader := e.ContextLoader(policyContext.Policy(), rule)
-				if err := contextLoader(ctx, rule.Context, policyContext.JSONContext()); err != nil {
+				contextStart := time.Now()
+				err := contextLoader(ctx, rule.Context, policyContext.JSONContext())
+				timings.ContextLoading = time.Since(contextStart)
+				if err != nil {
 					if _, ok := err.(gojmespath.NotFoundError); ok {
 						logger.V(3).Info("failed to load context", "reason", err.Error())
 					} else {
@@ -280,18 +344,35 @@ func (e *engine) invokeRuleHandler(
 					return resource, handlers.WithError(rule, ruleType, "failed to load context", err)
 				}
 				// check preconditions
+				preconditionsStart := time.Now()
 				preconditionsPassed, msg, err := internal.CheckPreconditions(logger, policyContext.JSONContext(), rule.GetAnyAllConditions())
+				timings.Preconditions = time.Since(preconditionsStart)
 				if err != nil {
 					return resource, handlers.WithError(rule, ruleType, "failed to evaluate preconditions", err)
 				}
 				if !preconditionsPassed {
 					s := stringutils.JoinNonEmpty([]string{"preconditions not met", msg}, "; ")
-					return resource, handlers.WithSkip(rule, ruleType, s)
+					return resource, handlers.WithSkip(rule, ruleType, s, engineapi.SkipReasonPreconditions)
 				}
 				// process handler
-				return handler.Process(ctx, logger, policyContext, resource, rule, contextLoader)
+				patchedResource, results = handler.Process(ctx, logger, policyContext, resource, rule, contextLoader)
+				if ruleType == engineapi.Validation {
+					results = withReportFields(logger, policyContext.JSONContext(), rule, results)
+				}
+				return patchedResource, results
 			}
 			return resource, nil
 		},
 	)
+	return patchedResource, responses, notMatched
+}
+
+// skipErrors turns the rule errors into skips, it is used when the rule context is unavailable
+func skipErrors(responses []engineapi.RuleResponse) []engineapi.RuleResponse {
+	for i, response := range responses {
+		if response.Status() == engineapi.RuleStatusError {
+			responses[i] = *engineapi.RuleSkip(response.Name(), response.RuleType(), response.Message()).WithSkipReason(engineapi.SkipReasonContextUnavailable)
+		}
+	}
+	return responses
 }
@@ -92,6 +92,6 @@ func (e *engine) hasPolicyExceptions(
 		return engineapi.RuleError(rule.Name, ruleType, "failed to compute exception key", err)
 	} else {
 		logger.V(3).Info("policy rule skipped due to policy exception", "exception", key)
-		return engineapi.RuleSkip(rule.Name, ruleType, "rule skipped due to policy exception "+key).WithException(exception)
+		return engineapi.RuleSkip(rule.Name, ruleType, "rule skipped due to policy exception "+key).WithException(exception).WithSkipReason(engineapi.SkipReasonPolicyException)
 	}
 }
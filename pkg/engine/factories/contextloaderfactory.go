@@ -7,8 +7,10 @@ import (
 	"github.com/go-logr/logr"
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
 	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	"github.com/kyverno/kyverno/pkg/engine/circuitbreaker"
 	enginecontext "github.com/kyverno/kyverno/pkg/engine/context"
 	"github.com/kyverno/kyverno/pkg/engine/context/loaders"
+	"github.com/kyverno/kyverno/pkg/engine/digestcache"
 	"github.com/kyverno/kyverno/pkg/engine/jmespath"
 	"github.com/kyverno/kyverno/pkg/imageverifycache"
 	"github.com/kyverno/kyverno/pkg/logging"
@@ -36,10 +38,31 @@ func WithInitializer(initializer engineapi.Initializer) ContextLoaderFactoryOpti
 	}
 }
 
+func WithPodLister(podLister engineapi.PodLister) ContextLoaderFactoryOptions {
+	return func(cl *contextLoader) {
+		cl.podLister = podLister
+	}
+}
+
+func WithCircuitBreaker(cb circuitbreaker.CircuitBreaker) ContextLoaderFactoryOptions {
+	return func(cl *contextLoader) {
+		cl.circuitBreaker = cb
+	}
+}
+
+func WithImageDigestCache(cache digestcache.Cache) ContextLoaderFactoryOptions {
+	return func(cl *contextLoader) {
+		cl.digestCache = cache
+	}
+}
+
 type contextLoader struct {
-	logger       logr.Logger
-	cmResolver   engineapi.ConfigmapResolver
-	initializers []engineapi.Initializer
+	logger         logr.Logger
+	cmResolver     engineapi.ConfigmapResolver
+	podLister      engineapi.PodLister
+	circuitBreaker circuitbreaker.CircuitBreaker
+	digestCache    digestcache.Cache
+	initializers   []engineapi.Initializer
 }
 
 func (l *contextLoader) Load(
@@ -94,7 +117,7 @@ func (l *contextLoader) newLoader(
 		}
 	} else if entry.APICall != nil {
 		if client != nil {
-			ldr := loaders.NewAPILoader(ctx, l.logger, entry, jsonContext, jp, client)
+			ldr := loaders.NewAPILoader(ctx, l.logger, entry, jsonContext, jp, client, l.circuitBreaker)
 			return enginecontext.NewDeferredLoader(entry.Name, ldr, l.logger)
 		} else {
 			l.logger.Info("disabled loading of APICall context entry %s", entry.Name)
@@ -102,7 +125,7 @@ func (l *contextLoader) newLoader(
 		}
 	} else if entry.ImageRegistry != nil {
 		if rclientFactory != nil {
-			ldr := loaders.NewImageDataLoader(ctx, l.logger, entry, jsonContext, jp, rclientFactory)
+			ldr := loaders.NewImageDataLoader(ctx, l.logger, entry, jsonContext, jp, rclientFactory, l.circuitBreaker)
 			return enginecontext.NewDeferredLoader(entry.Name, ldr, l.logger)
 		} else {
 			l.logger.Info("disabled loading of ImageRegistry context entry %s", entry.Name)
@@ -111,6 +134,22 @@ func (l *contextLoader) newLoader(
 	} else if entry.Variable != nil {
 		ldr := loaders.NewVariableLoader(l.logger, entry, jsonContext, jp)
 		return enginecontext.NewDeferredLoader(entry.Name, ldr, l.logger)
+	} else if entry.ResourceUsage != nil {
+		if l.podLister != nil {
+			ldr := loaders.NewResourceUsageLoader(ctx, l.logger, entry, l.podLister, jsonContext)
+			return enginecontext.NewDeferredLoader(entry.Name, ldr, l.logger)
+		} else {
+			l.logger.Info("disabled loading of ResourceUsage context entry %s", entry.Name)
+			return nil, nil
+		}
+	} else if entry.ImageDigest != nil {
+		if rclientFactory != nil {
+			ldr := loaders.NewImageDigestLoader(ctx, l.logger, entry, jsonContext, rclientFactory, l.digestCache, l.circuitBreaker)
+			return enginecontext.NewDeferredLoader(entry.Name, ldr, l.logger)
+		} else {
+			l.logger.Info("disabled loading of ImageDigest context entry %s", entry.Name)
+			return nil, nil
+		}
 	}
-	return nil, fmt.Errorf("missing ConfigMap|APICall|ImageRegistry|Variable in context entry %s", entry.Name)
+	return nil, fmt.Errorf("missing ConfigMap|APICall|ImageRegistry|Variable|ResourceUsage|ImageDigest in context entry %s", entry.Name)
 }
@@ -83,7 +83,7 @@ func applyForEachMutate(name string, foreach []kyvernov1.ForEachMutation, resour
 }
 
 func applyPatches(name string, mergePatch apiextensions.JSON, jsonPatch string, resource unstructured.Unstructured, logger logr.Logger) (unstructured.Unstructured, error) {
-	patcher := mutate.NewPatcher(mergePatch, jsonPatch)
+	patcher := mutate.NewPatcher(mergePatch, jsonPatch, nil)
 	resourceBytes, err := resource.MarshalJSON()
 	if err != nil {
 		return resource, err
@@ -47,6 +47,8 @@ var (
 		imageverifycache.DisabledImageVerifyCache(),
 		factories.DefaultContextLoaderFactory(nil),
 		nil,
+		nil,
+		nil,
 		"",
 	)
 )
@@ -111,6 +113,8 @@ func FuzzVerifyImageAndPatchTest(f *testing.F) {
 			imageverifycache.DisabledImageVerifyCache(),
 			factories.DefaultContextLoaderFactory(nil),
 			nil,
+			nil,
+			nil,
 			"",
 		)
 
@@ -454,6 +458,8 @@ func FuzzMutateTest(f *testing.F) {
 			imageverifycache.DisabledImageVerifyCache(),
 			factories.DefaultContextLoaderFactory(nil),
 			nil,
+			nil,
+			nil,
 			"",
 		)
 		e.Mutate(
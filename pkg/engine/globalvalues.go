@@ -0,0 +1,21 @@
+package engine
+
+import (
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+)
+
+// globalValuesContextKey is the context key under which the cluster wide global values are made available
+const globalValuesContextKey = "global"
+
+// loadGlobalValues adds the global values of the configuration to the json context,
+// policies can then reference them with the `global.` variable prefix
+func (e *engine) loadGlobalValues(policyContext engineapi.PolicyContext) error {
+	if e.configuration == nil {
+		return nil
+	}
+	values := e.configuration.GetGlobalValues()
+	if len(values) == 0 {
+		return nil
+	}
+	return policyContext.JSONContext().AddVariable(globalValuesContextKey, values)
+}
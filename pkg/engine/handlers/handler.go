@@ -24,8 +24,8 @@ func WithError(rule kyvernov1.Rule, ruleType engineapi.RuleType, msg string, err
 	return WithResponses(engineapi.RuleError(rule.Name, ruleType, msg, err))
 }
 
-func WithSkip(rule kyvernov1.Rule, ruleType engineapi.RuleType, msg string) []engineapi.RuleResponse {
-	return WithResponses(engineapi.RuleSkip(rule.Name, ruleType, msg))
+func WithSkip(rule kyvernov1.Rule, ruleType engineapi.RuleType, msg string, reason engineapi.SkipReason) []engineapi.RuleResponse {
+	return WithResponses(engineapi.RuleSkip(rule.Name, ruleType, msg).WithSkipReason(reason))
 }
 
 func WithPass(rule kyvernov1.Rule, ruleType engineapi.RuleType, msg string) []engineapi.RuleResponse {
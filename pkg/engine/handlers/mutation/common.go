@@ -10,10 +10,12 @@ import (
 	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
 	"github.com/kyverno/kyverno/pkg/engine/internal"
 	"github.com/kyverno/kyverno/pkg/engine/mutate"
+	"github.com/kyverno/kyverno/pkg/engine/mutate/patch"
 	engineutils "github.com/kyverno/kyverno/pkg/engine/utils"
 	"github.com/kyverno/kyverno/pkg/utils/api"
 	datautils "github.com/kyverno/kyverno/pkg/utils/data"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/kustomize/kyaml/openapi"
 )
 
 type forEachMutator struct {
@@ -24,6 +26,7 @@ type forEachMutator struct {
 	resource      resourceInfo
 	nesting       int
 	contextLoader engineapi.EngineContextLoader
+	schema        *openapi.ResourceSchema
 }
 
 func (f *forEachMutator) mutateForEach(ctx context.Context) *mutate.Response {
@@ -122,11 +125,12 @@ func (f *forEachMutator) mutateElements(ctx context.Context, foreach kyvernov1.F
 				foreach:       nestedForEach,
 				nesting:       f.nesting + 1,
 				contextLoader: f.contextLoader,
+				schema:        f.schema,
 			}
 
 			mutateResp = m.mutateForEach(ctx)
 		} else {
-			mutateResp = mutate.ForEach(f.rule.Name, foreach, policyContext, patchedResource.unstructured, element, f.logger)
+			mutateResp = mutate.ForEach(f.rule.Name, foreach, policyContext, patchedResource.unstructured, element, f.schema, f.logger)
 		}
 
 		if mutateResp.Status == engineapi.RuleStatusFail || mutateResp.Status == engineapi.RuleStatusError {
@@ -145,6 +149,19 @@ func (f *forEachMutator) mutateElements(ctx context.Context, foreach kyvernov1.F
 	return mutate.NewResponse(engineapi.RuleStatusSkip, patchedResource.unstructured, "no patches applied")
 }
 
+// resolveSchema returns the schema used to merge the lists of a custom resource, it returns nil when the builtin schema applies
+func resolveSchema(ctx context.Context, logger logr.Logger, resolver engineapi.SchemaResolver, resource unstructured.Unstructured) *openapi.ResourceSchema {
+	if resolver == nil || patch.HasBuiltinSchema(resource.GetAPIVersion(), resource.GetKind()) {
+		return nil
+	}
+	schema, err := resolver.Get(ctx, resource.GroupVersionKind())
+	if err != nil {
+		logger.Error(err, "failed to resolve resource schema", "apiVersion", resource.GetAPIVersion(), "kind", resource.GetKind())
+		return nil
+	}
+	return schema
+}
+
 func buildRuleResponse(rule *kyvernov1.Rule, mutateResp *mutate.Response, info resourceInfo) *engineapi.RuleResponse {
 	message := mutateResp.Message
 	if mutateResp.Status == engineapi.RuleStatusPass {
@@ -2,6 +2,7 @@ package mutation
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/go-logr/logr"
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
@@ -14,14 +15,17 @@ import (
 )
 
 type mutateExistingHandler struct {
-	client engineapi.Client
+	client         engineapi.Client
+	schemaResolver engineapi.SchemaResolver
 }
 
 func NewMutateExistingHandler(
 	client engineapi.Client,
+	schemaResolver engineapi.SchemaResolver,
 ) (handlers.Handler, error) {
 	return mutateExistingHandler{
-		client: client,
+		client:         client,
+		schemaResolver: schemaResolver,
 	}, nil
 }
 
@@ -45,6 +49,13 @@ func (h mutateExistingHandler) Process(
 		if target.unstructured.Object == nil {
 			continue
 		}
+		// targets kinds can be resolved from variables, the status subresource is checked again here
+		if target.subresource == "status" && !rule.Mutation.IsStatusMutationAllowed() {
+			err := fmt.Errorf("the status subresource of %s/%s/%s cannot be mutated when allowStatusMutation is false", target.unstructured.GetKind(), target.unstructured.GetNamespace(), target.unstructured.GetName())
+			rr := engineapi.RuleError(rule.Name, engineapi.Mutation, "failed to mutate target", err)
+			responses = append(responses, *rr)
+			continue
+		}
 		policyContext := policyContext.Copy()
 		if err := policyContext.JSONContext().SetTargetResource(target.unstructured.Object); err != nil {
 			logger.Error(err, "failed to add target resource to the context")
@@ -65,11 +76,12 @@ func (h mutateExistingHandler) Process(
 		}
 		if !preconditionsPassed {
 			s := stringutils.JoinNonEmpty([]string{"preconditions not met", msg}, "; ")
-			rr := engineapi.RuleSkip(rule.Name, engineapi.Mutation, s)
+			rr := engineapi.RuleSkip(rule.Name, engineapi.Mutation, s).WithSkipReason(engineapi.SkipReasonPreconditions)
 			responses = append(responses, *rr)
 			continue
 		}
 
+		schema := resolveSchema(ctx, logger, h.schemaResolver, target.unstructured)
 		// logger.V(4).Info("apply rule to resource", "resource namespace", patchedResource.unstructured.GetNamespace(), "resource name", patchedResource.unstructured.GetName())
 		var mutateResp *mutate.Response
 		if rule.Mutation.ForEachMutation != nil {
@@ -81,10 +93,11 @@ func (h mutateExistingHandler) Process(
 				logger:        logger,
 				contextLoader: contextLoader,
 				nesting:       0,
+				schema:        schema,
 			}
 			mutateResp = m.mutateForEach(ctx)
 		} else {
-			mutateResp = mutate.Mutate(&rule, policyContext.JSONContext(), target.unstructured, logger)
+			mutateResp = mutate.Mutate(&rule, policyContext.JSONContext(), target.unstructured, schema, logger)
 		}
 		if ruleResponse := buildRuleResponse(&rule, mutateResp, target.resourceInfo); ruleResponse != nil {
 			responses = append(responses, *ruleResponse)
@@ -12,10 +12,16 @@ import (
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 )
 
-type mutateResourceHandler struct{}
+type mutateResourceHandler struct {
+	schemaResolver engineapi.SchemaResolver
+}
 
-func NewMutateResourceHandler() (handlers.Handler, error) {
-	return mutateResourceHandler{}, nil
+func NewMutateResourceHandler(
+	schemaResolver engineapi.SchemaResolver,
+) (handlers.Handler, error) {
+	return mutateResourceHandler{
+		schemaResolver: schemaResolver,
+	}, nil
 }
 
 func (h mutateResourceHandler) Process(
@@ -37,6 +43,7 @@ func (h mutateResourceHandler) Process(
 		subresource:       subresource,
 		parentResourceGVR: parentResourceGVR,
 	}
+	schema := resolveSchema(ctx, logger, h.schemaResolver, resource)
 	// logger.V(4).Info("apply rule to resource", "resource namespace", patchedResource.unstructured.GetNamespace(), "resource name", patchedResource.unstructured.GetName())
 	var mutateResp *mutate.Response
 	if rule.Mutation.ForEachMutation != nil {
@@ -48,10 +55,11 @@ func (h mutateResourceHandler) Process(
 			logger:        logger,
 			contextLoader: contextLoader,
 			nesting:       0,
+			schema:        schema,
 		}
 		mutateResp = m.mutateForEach(ctx)
 	} else {
-		mutateResp = mutate.Mutate(&rule, policyContext.JSONContext(), resource, logger)
+		mutateResp = mutate.Mutate(&rule, policyContext.JSONContext(), resource, schema, logger)
 	}
 	if mutateResp == nil {
 		return resource, nil
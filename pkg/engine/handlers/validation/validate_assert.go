@@ -0,0 +1,109 @@
+package validation
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/go-logr/logr"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	"github.com/kyverno/kyverno/pkg/engine/assertion"
+	"github.com/kyverno/kyverno/pkg/engine/handlers"
+	"github.com/kyverno/kyverno/pkg/engine/jmespath"
+	"github.com/kyverno/kyverno/pkg/engine/variables"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+type validateAssertHandler struct {
+	jp jmespath.Interface
+}
+
+func NewValidateAssertHandler(jp jmespath.Interface) (handlers.Handler, error) {
+	return validateAssertHandler{
+		jp: jp,
+	}, nil
+}
+
+func (h validateAssertHandler) Process(
+	ctx context.Context,
+	logger logr.Logger,
+	policyContext engineapi.PolicyContext,
+	resource unstructured.Unstructured,
+	rule kyvernov1.Rule,
+	_ engineapi.EngineContextLoader,
+) (unstructured.Unstructured, []engineapi.RuleResponse) {
+	if resource.Object == nil {
+		resource = policyContext.OldResource()
+	}
+	var failed []string
+	for i, check := range rule.Validation.Assert.All {
+		msg, err := h.check(logger, policyContext, resource, check)
+		if err != nil {
+			return resource, handlers.WithError(rule, engineapi.Validation, fmt.Sprintf("failed to evaluate assertion all[%d]", i), err)
+		}
+		if msg != "" {
+			failed = append(failed, fmt.Sprintf("all[%d] %s", i, msg))
+		}
+	}
+	if len(rule.Validation.Assert.Any) != 0 {
+		var anyFailed []string
+		for i, check := range rule.Validation.Assert.Any {
+			msg, err := h.check(logger, policyContext, resource, check)
+			if err != nil {
+				return resource, handlers.WithError(rule, engineapi.Validation, fmt.Sprintf("failed to evaluate assertion any[%d]", i), err)
+			}
+			if msg == "" {
+				anyFailed = nil
+				break
+			}
+			anyFailed = append(anyFailed, fmt.Sprintf("any[%d] %s", i, msg))
+		}
+		failed = append(failed, anyFailed...)
+	}
+	if len(failed) != 0 {
+		return resource, handlers.WithFail(rule, engineapi.Validation, buildAssertErrorMessage(logger, policyContext, rule, failed))
+	}
+	return resource, handlers.WithPass(rule, engineapi.Validation, fmt.Sprintf("Validation rule '%s' passed.", rule.Name))
+}
+
+// check evaluates an assertion check against the resource, it returns an empty message when the check passes
+func (h validateAssertHandler) check(logger logr.Logger, policyContext engineapi.PolicyContext, resource unstructured.Unstructured, check kyvernov1.AssertionCheck) (string, error) {
+	tree, err := variables.SubstituteAll(logger, policyContext.JSONContext(), check.GetCheck())
+	if err != nil {
+		return "", fmt.Errorf("variable substitution failed: %w", err)
+	}
+	failures, err := assertion.Assert(h.jp, tree, resource.Object)
+	if err != nil {
+		return "", err
+	}
+	if len(failures) == 0 {
+		return "", nil
+	}
+	var details []string
+	for _, failure := range failures {
+		details = append(details, failure.String())
+	}
+	msg := strings.Join(details, ", ")
+	if check.Message != "" {
+		msg = fmt.Sprintf("%s (%s)", check.Message, msg)
+	}
+	return msg, nil
+}
+
+func buildAssertErrorMessage(logger logr.Logger, policyContext engineapi.PolicyContext, rule kyvernov1.Rule, failed []string) string {
+	errStr := strings.Join(failed, "; ")
+	if rule.Validation.Message == "" {
+		return fmt.Sprintf("validation error: rule %s failed: %s", rule.Name, errStr)
+	}
+	msgRaw, err := variables.SubstituteAll(logger, policyContext.JSONContext(), rule.Validation.Message)
+	if err != nil {
+		logger.V(2).Info("failed to substitute variables in message", "error", err)
+		return fmt.Sprintf("validation error: rule %s failed: %s", rule.Name, errStr)
+	}
+	msg := msgRaw.(string)
+	if !strings.HasSuffix(msg, ".") {
+		msg = msg + "."
+	}
+	return fmt.Sprintf("validation error: %s rule %s failed: %s", msg, rule.Name, errStr)
+}
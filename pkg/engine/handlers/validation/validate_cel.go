@@ -2,6 +2,7 @@ package validation
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 
 	"github.com/go-logr/logr"
@@ -9,6 +10,8 @@ import (
 	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
 	"github.com/kyverno/kyverno/pkg/engine/handlers"
 	engineutils "github.com/kyverno/kyverno/pkg/engine/utils"
+	"github.com/kyverno/kyverno/pkg/utils/refcache"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -46,7 +49,7 @@ func (h validateCELHandler) Process(
 	oldResource := policyContext.OldResource()
 	gvr := schema.GroupVersionResource(policyContext.RequestResource())
 
-	var object, oldObject, versionedParams runtime.Object
+	var object, oldObject runtime.Object
 	object = resource.DeepCopyObject()
 	if oldResource.Object == nil {
 		oldObject = nil
@@ -54,15 +57,10 @@ func (h validateCELHandler) Process(
 		oldObject = oldResource.DeepCopyObject()
 	}
 
-	var expressions, messageExpressions, matchExpressions, auditExpressions []cel.ExpressionAccessor
-
-	validations := rule.Validation.CEL.Expressions
-	auditAnnotations := rule.Validation.CEL.AuditAnnotations
+	var versionedParams runtime.Object
 
 	// Get the parameter resource
-	hasParam := rule.Validation.CEL.HasParam()
-
-	if hasParam {
+	if rule.Validation.CEL.HasParam() {
 		paramKind := rule.Validation.CEL.GetParamKind()
 		paramRef := rule.Validation.CEL.GetParamRef()
 
@@ -84,6 +82,104 @@ func (h validateCELHandler) Process(
 		versionedParams = paramResource.DeepCopyObject()
 	}
 
+	validator, err := getCELValidator(rule)
+	if err != nil {
+		return resource, handlers.WithError(rule, engineapi.Validation, "Error while creating composited compiler", err)
+	}
+
+	admissionAttributes := admission.NewAttributesRecord(
+		object,
+		oldObject,
+		resource.GroupVersionKind(),
+		resource.GetNamespace(),
+		resource.GetName(),
+		gvr,
+		"",
+		admission.Operation(policyContext.Operation()),
+		nil,
+		false,
+		nil,
+	)
+	versionedAttr, _ := admission.NewVersionedAttributes(admissionAttributes, admissionAttributes.GetKind(), nil)
+	validateResult := validator.Validate(ctx, gvr, versionedAttr, versionedParams, nil, celconfig.RuntimeCELCostBudget, nil)
+
+	// the validator doesn't return any decision when the preconditions are not met
+	if len(rule.CELPreconditions) != 0 && len(validateResult.Decisions) == 0 {
+		return resource, handlers.WithSkip(rule, engineapi.Validation, "cel preconditions not met", engineapi.SkipReasonCELPreconditions)
+	}
+
+	for _, decision := range validateResult.Decisions {
+		switch decision.Action {
+		case validatingadmissionpolicy.ActionAdmit:
+			if decision.Evaluation == validatingadmissionpolicy.EvalError {
+				return resource, handlers.WithResponses(
+					engineapi.RuleError(rule.Name, engineapi.Validation, decision.Message, nil),
+				)
+			}
+		case validatingadmissionpolicy.ActionDeny:
+			return resource, handlers.WithResponses(
+				engineapi.RuleFail(rule.Name, engineapi.Validation, decision.Message),
+			)
+		}
+	}
+
+	msg := fmt.Sprintf("Validation rule '%s' passed.", rule.Name)
+	return resource, handlers.WithResponses(
+		engineapi.RulePass(rule.Name, engineapi.Validation, msg),
+	)
+}
+
+// celValidators stores the validators precompiled by CompileCEL, keyed by the CEL definition of the rule
+var celValidators = refcache.New[validatingadmissionpolicy.Validator]()
+
+// CompileCEL precompiles the validators of the CEL rules of owner, replacing the validators previously compiled for owner
+func CompileCEL(owner string, rules ...kyvernov1.Rule) {
+	validators := map[string]validatingadmissionpolicy.Validator{}
+	for _, rule := range rules {
+		if !rule.HasValidateCEL() {
+			continue
+		}
+		key, err := celKey(rule)
+		if err != nil {
+			continue
+		}
+		if validator, err := compileCEL(rule); err == nil {
+			validators[key] = validator
+		}
+	}
+	celValidators.Set(owner, validators)
+}
+
+// ReleaseCEL releases the validators precompiled for owner
+func ReleaseCEL(owner string) {
+	celValidators.Release(owner)
+}
+
+func celKey(rule kyvernov1.Rule) (string, error) {
+	key, err := json.Marshal(struct {
+		CEL           *kyvernov1.CEL                           `json:"cel"`
+		Preconditions []admissionregistrationv1.MatchCondition `json:"preconditions"`
+	}{rule.Validation.CEL, rule.CELPreconditions})
+	return string(key), err
+}
+
+// getCELValidator returns the precompiled validator of the rule, or compiles it if it was not precompiled
+func getCELValidator(rule kyvernov1.Rule) (validatingadmissionpolicy.Validator, error) {
+	if key, err := celKey(rule); err == nil {
+		if validator, ok := celValidators.Get(key); ok {
+			return validator, nil
+		}
+	}
+	return compileCEL(rule)
+}
+
+func compileCEL(rule kyvernov1.Rule) (validatingadmissionpolicy.Validator, error) {
+	var expressions, messageExpressions, matchExpressions, auditExpressions []cel.ExpressionAccessor
+
+	validations := rule.Validation.CEL.Expressions
+	auditAnnotations := rule.Validation.CEL.AuditAnnotations
+	hasParam := rule.Validation.CEL.HasParam()
+
 	for _, cel := range validations {
 		condition := &validatingadmissionpolicy.ValidationCondition{
 			Expression: cel.Expression,
@@ -118,7 +214,7 @@ func (h validateCELHandler) Process(
 
 	compositedCompiler, err := cel.NewCompositedCompiler(environment.MustBaseEnvSet(environment.DefaultCompatibilityVersion()))
 	if err != nil {
-		return resource, handlers.WithError(rule, engineapi.Validation, "Error while creating composited compiler", err)
+		return nil, err
 	}
 	filter := compositedCompiler.Compile(expressions, cel.OptionalVariableDeclarations{HasParams: hasParam, HasAuthorizer: false}, environment.StoredExpressions)
 	messageExpressionfilter := compositedCompiler.Compile(messageExpressions, cel.OptionalVariableDeclarations{HasParams: hasParam, HasAuthorizer: false}, environment.StoredExpressions)
@@ -127,41 +223,5 @@ func (h validateCELHandler) Process(
 
 	newMatcher := matchconditions.NewMatcher(matchConditionFilter, nil, "", "", "")
 
-	validator := validatingadmissionpolicy.NewValidator(filter, newMatcher, auditAnnotationFilter, messageExpressionfilter, nil)
-
-	admissionAttributes := admission.NewAttributesRecord(
-		object,
-		oldObject,
-		resource.GroupVersionKind(),
-		resource.GetNamespace(),
-		resource.GetName(),
-		gvr,
-		"",
-		admission.Operation(policyContext.Operation()),
-		nil,
-		false,
-		nil,
-	)
-	versionedAttr, _ := admission.NewVersionedAttributes(admissionAttributes, admissionAttributes.GetKind(), nil)
-	validateResult := validator.Validate(ctx, gvr, versionedAttr, versionedParams, nil, celconfig.RuntimeCELCostBudget, nil)
-
-	for _, decision := range validateResult.Decisions {
-		switch decision.Action {
-		case validatingadmissionpolicy.ActionAdmit:
-			if decision.Evaluation == validatingadmissionpolicy.EvalError {
-				return resource, handlers.WithResponses(
-					engineapi.RuleError(rule.Name, engineapi.Validation, decision.Message, nil),
-				)
-			}
-		case validatingadmissionpolicy.ActionDeny:
-			return resource, handlers.WithResponses(
-				engineapi.RuleFail(rule.Name, engineapi.Validation, decision.Message),
-			)
-		}
-	}
-
-	msg := fmt.Sprintf("Validation rule '%s' passed.", rule.Name)
-	return resource, handlers.WithResponses(
-		engineapi.RulePass(rule.Name, engineapi.Validation, msg),
-	)
+	return validatingadmissionpolicy.NewValidator(filter, newMatcher, auditAnnotationFilter, messageExpressionfilter, nil), nil
 }
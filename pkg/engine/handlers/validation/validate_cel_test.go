@@ -0,0 +1,39 @@
+package validation
+
+import (
+	"testing"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"gotest.tools/assert"
+	"k8s.io/api/admissionregistration/v1alpha1"
+)
+
+func Test_getCELValidator(t *testing.T) {
+	rule := kyvernov1.Rule{
+		Name: "test",
+		Validation: kyvernov1.Validation{
+			CEL: &kyvernov1.CEL{
+				Expressions: []v1alpha1.Validation{{Expression: "object.metadata.name != 'forbidden'"}},
+			},
+		},
+	}
+	CompileCEL("policy", rule)
+	defer ReleaseCEL("policy")
+	key, err := celKey(rule)
+	assert.NilError(t, err)
+	precompiled, ok := celValidators.Get(key)
+	assert.Assert(t, ok)
+	validator, err := getCELValidator(rule)
+	assert.NilError(t, err)
+	assert.Equal(t, validator, precompiled)
+	// a different definition is not precompiled
+	other := *rule.DeepCopy()
+	other.Validation.CEL.Expressions[0].Expression = "true"
+	otherKey, err := celKey(other)
+	assert.NilError(t, err)
+	_, ok = celValidators.Get(otherKey)
+	assert.Assert(t, !ok)
+	ReleaseCEL("policy")
+	_, ok = celValidators.Get(key)
+	assert.Assert(t, !ok)
+}
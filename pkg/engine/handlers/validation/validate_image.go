@@ -3,6 +3,8 @@ package validation
 import (
 	"context"
 	"fmt"
+	"sort"
+	"strings"
 
 	"github.com/go-logr/logr"
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
@@ -29,7 +31,7 @@ func NewValidateImageHandler(
 	if err != nil {
 		return nil, err
 	}
-	if len(ruleImages) == 0 {
+	if len(ruleImages) == 0 && !isStrict(rule) {
 		return nil, nil
 	}
 	return validateImageHandler{}, nil
@@ -43,36 +45,46 @@ func (h validateImageHandler) Process(
 	rule kyvernov1.Rule,
 	_ engineapi.EngineContextLoader,
 ) (unstructured.Unstructured, []engineapi.RuleResponse) {
-	for _, v := range rule.VerifyImages {
-		imageVerify := v.Convert()
-		for _, infoMap := range policyContext.JSONContext().ImageInfo() {
-			for name, imageInfo := range infoMap {
-				image := imageInfo.String()
-
+	strict := isStrict(rule)
+	var uncovered []string
+	for _, infoMap := range policyContext.JSONContext().ImageInfo() {
+		for name, imageInfo := range infoMap {
+			image := imageInfo.String()
+			covered := false
+			for _, v := range rule.VerifyImages {
+				imageVerify := v.Convert()
 				if !engineutils.ImageMatches(image, imageVerify.ImageReferences) {
 					logger.V(4).Info("image does not match", "imageReferences", imageVerify.ImageReferences)
-					return resource, nil
+					continue
 				}
-
+				covered = true
 				logger.V(4).Info("validating image", "image", image)
 				if err := validateImage(policyContext, imageVerify, name, imageInfo, logger); err != nil {
 					return resource, handlers.WithFail(rule, engineapi.ImageVerify, err.Error())
 				}
 			}
+			if !covered && strict {
+				uncovered = append(uncovered, image)
+			}
 		}
 	}
+	if len(uncovered) != 0 {
+		sort.Strings(uncovered)
+		msg := fmt.Sprintf("images not covered by the imageReferences of rule %s: %s", rule.Name, strings.Join(uncovered, ", "))
+		return resource, handlers.WithFail(rule, engineapi.ImageVerify, msg)
+	}
 	logger.V(4).Info("validated image", "rule", rule.Name)
 	return resource, handlers.WithPass(rule, engineapi.Validation, "image verified")
 }
 
 func validateImage(ctx engineapi.PolicyContext, imageVerify *kyvernov1.ImageVerification, name string, imageInfo apiutils.ImageInfo, log logr.Logger) error {
 	image := imageInfo.String()
-	if imageVerify.VerifyDigest && imageInfo.Digest == "" {
+	if (imageVerify.VerifyDigest || imageVerify.Strict) && imageInfo.Digest == "" {
 		log.V(2).Info("missing digest", "image", imageInfo.String())
 		return fmt.Errorf("missing digest for %s", image)
 	}
 	newResource := ctx.NewResource()
-	if imageVerify.Required && newResource.Object != nil {
+	if (imageVerify.Required || imageVerify.Strict) && newResource.Object != nil {
 		verified, err := engineutils.IsImageVerified(newResource, image, log)
 		if err != nil {
 			return err
@@ -83,3 +95,13 @@ func validateImage(ctx engineapi.PolicyContext, imageVerify *kyvernov1.ImageVeri
 	}
 	return nil
 }
+
+// isStrict returns true if one of the verifyImages entries of the rule requires all images to be covered
+func isStrict(rule kyvernov1.Rule) bool {
+	for _, v := range rule.VerifyImages {
+		if v.Strict {
+			return true
+		}
+	}
+	return false
+}
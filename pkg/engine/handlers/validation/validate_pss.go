@@ -4,17 +4,21 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strings"
 
 	"github.com/go-logr/logr"
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/autogen"
 	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
 	"github.com/kyverno/kyverno/pkg/engine/handlers"
 	"github.com/kyverno/kyverno/pkg/pss"
+	"github.com/kyverno/kyverno/pkg/utils/match"
 	appsv1 "k8s.io/api/apps/v1"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
 )
 
 type validatePssHandler struct{}
@@ -36,7 +40,11 @@ func (h validatePssHandler) Process(
 	if resource.Object == nil {
 		resource = policyContext.OldResource()
 	}
-	podSpec, metadata, err := getSpec(resource)
+	controllers, err := autogen.GetCustomControllers(policyContext.Policy().GetAnnotations())
+	if err != nil {
+		return resource, handlers.WithError(rule, engineapi.Validation, "Error while getting custom controllers", err)
+	}
+	podSpec, metadata, err := getSpec(resource, controllers)
 	if err != nil {
 		return resource, handlers.WithError(rule, engineapi.Validation, "Error while getting new resource", err)
 	}
@@ -66,7 +74,7 @@ func (h validatePssHandler) Process(
 	}
 }
 
-func getSpec(resource unstructured.Unstructured) (podSpec *corev1.PodSpec, metadata *metav1.ObjectMeta, err error) {
+func getSpec(resource unstructured.Unstructured, controllers []autogen.CustomController) (podSpec *corev1.PodSpec, metadata *metav1.ObjectMeta, err error) {
 	kind := resource.GetKind()
 
 	if kind == "DaemonSet" || kind == "Deployment" || kind == "Job" || kind == "StatefulSet" || kind == "ReplicaSet" || kind == "ReplicationController" {
@@ -110,9 +118,27 @@ func getSpec(resource unstructured.Unstructured) (podSpec *corev1.PodSpec, metad
 		podSpec = &pod.Spec
 		metadata = &pod.ObjectMeta
 		return podSpec, metadata, nil
+	} else {
+		for _, controller := range controllers {
+			if !match.CheckKind([]string{controller.Kind}, resource.GroupVersionKind(), "", false) {
+				continue
+			}
+			template, found, err := unstructured.NestedMap(resource.Object, strings.Split(controller.Path, ".")...)
+			if err != nil {
+				return nil, nil, err
+			}
+			if !found {
+				return nil, nil, fmt.Errorf("pod template not found at %s in %s", controller.Path, kind)
+			}
+			var podTemplate corev1.PodTemplateSpec
+			if err := runtime.DefaultUnstructuredConverter.FromUnstructured(template, &podTemplate); err != nil {
+				return nil, nil, err
+			}
+			return &podTemplate.Spec, &podTemplate.ObjectMeta, nil
+		}
 	}
-	if err != nil {
-		return nil, nil, err
+	if podSpec == nil {
+		return nil, nil, fmt.Errorf("unsupported kind %s", kind)
 	}
 	return podSpec, metadata, err
 }
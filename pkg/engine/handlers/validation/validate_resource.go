@@ -8,6 +8,7 @@ import (
 
 	"github.com/go-logr/logr"
 	gojmespath "github.com/kyverno/go-jmespath"
+	"github.com/kyverno/kyverno/api/kyverno"
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
 	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
 	"github.com/kyverno/kyverno/pkg/engine/handlers"
@@ -48,6 +49,7 @@ type validator struct {
 	anyAllConditions apiextensions.JSON
 	pattern          apiextensions.JSON
 	anyPattern       apiextensions.JSON
+	anyPatternMsgs   []string
 	deny             *kyvernov1.Deny
 	forEach          []kyvernov1.ForEachValidation
 	contextLoader    engineapi.EngineContextLoader
@@ -56,14 +58,15 @@ type validator struct {
 
 func newValidator(log logr.Logger, contextLoader engineapi.EngineContextLoader, ctx engineapi.PolicyContext, rule kyvernov1.Rule) *validator {
 	return &validator{
-		log:           log,
-		rule:          rule,
-		policyContext: ctx,
-		contextLoader: contextLoader,
-		pattern:       rule.Validation.GetPattern(),
-		anyPattern:    rule.Validation.GetAnyPattern(),
-		deny:          rule.Validation.Deny,
-		forEach:       rule.Validation.ForEachValidation,
+		log:            log,
+		rule:           rule,
+		policyContext:  ctx,
+		contextLoader:  contextLoader,
+		pattern:        rule.Validation.GetPattern(),
+		anyPattern:     rule.Validation.GetAnyPattern(),
+		anyPatternMsgs: rule.Validation.AnyPatternMessages,
+		deny:           rule.Validation.Deny,
+		forEach:        rule.Validation.ForEachValidation,
 	}
 }
 
@@ -92,6 +95,7 @@ func newForEachValidator(
 		anyAllConditions: anyAllConditions,
 		pattern:          foreach.GetPattern(),
 		anyPattern:       foreach.GetAnyPattern(),
+		anyPatternMsgs:   foreach.AnyPatternMessages,
 		deny:             foreach.Deny,
 		forEach:          nestedForEach,
 		nesting:          nesting,
@@ -108,7 +112,7 @@ func (v *validator) validate(ctx context.Context) *engineapi.RuleResponse {
 	}
 	if !preconditionsPassed {
 		s := stringutils.JoinNonEmpty([]string{"preconditions not met", msg}, "; ")
-		return engineapi.RuleSkip(v.rule.Name, engineapi.Validation, s)
+		return engineapi.RuleSkip(v.rule.Name, engineapi.Validation, s).WithSkipReason(engineapi.SkipReasonPreconditions)
 	}
 
 	if v.deny != nil {
@@ -151,7 +155,7 @@ func (v *validator) validateForEach(ctx context.Context) *engineapi.RuleResponse
 		if v.forEach == nil {
 			return nil
 		}
-		return engineapi.RuleSkip(v.rule.Name, engineapi.Validation, "rule skipped")
+		return engineapi.RuleSkip(v.rule.Name, engineapi.Validation, "rule skipped").WithSkipReason(engineapi.SkipReasonForEachNotApplied)
 	}
 	return engineapi.RulePass(v.rule.Name, engineapi.Validation, "rule passed")
 }
@@ -219,16 +223,30 @@ func (v *validator) loadContext(ctx context.Context) error {
 }
 
 func (v *validator) validateDeny() *engineapi.RuleResponse {
-	if deny, msg, err := internal.CheckDenyPreconditions(v.log, v.policyContext.JSONContext(), v.deny.GetAnyAllConditions()); err != nil {
+	if deny, msg, trace, err := internal.CheckDenyPreconditions(v.log, v.policyContext.JSONContext(), v.deny.GetAnyAllConditions()); err != nil {
 		return engineapi.RuleError(v.rule.Name, engineapi.Validation, "failed to check deny conditions", err)
 	} else {
+		evaluated := make([]string, 0, len(trace))
+		for _, t := range trace {
+			evaluated = append(evaluated, t.String())
+		}
+		v.log.V(4).Info("evaluated deny conditions", "deny", deny, "conditions", evaluated)
 		if deny {
-			return engineapi.RuleFail(v.rule.Name, engineapi.Validation, v.getDenyMessage(deny, msg))
+			msg := v.getDenyMessage(deny, msg)
+			if len(evaluated) != 0 && denyTraceEnabled(v.policyContext.Policy()) {
+				msg = fmt.Sprintf("%s (evaluated conditions: %s)", msg, strings.Join(evaluated, ", "))
+			}
+			return engineapi.RuleFail(v.rule.Name, engineapi.Validation, msg)
 		}
 		return engineapi.RulePass(v.rule.Name, engineapi.Validation, v.getDenyMessage(deny, msg))
 	}
 }
 
+// denyTraceEnabled returns true when the policy asks for evaluated deny conditions in failure messages
+func denyTraceEnabled(policy kyvernov1.PolicyInterface) bool {
+	return policy != nil && policy.GetAnnotations()[kyverno.AnnotationDenyTrace] == "true"
+}
+
 func (v *validator) getDenyMessage(deny bool, msg string) string {
 	if !deny {
 		return fmt.Sprintf("validation rule '%s' passed.", v.rule.Name)
@@ -274,7 +292,7 @@ func (v *validator) validatePatterns(resource unstructured.Unstructured) *engine
 				v.log.V(3).Info("validation error", "path", pe.Path, "error", err.Error())
 
 				if pe.Skip {
-					return engineapi.RuleSkip(v.rule.Name, engineapi.Validation, pe.Error())
+					return engineapi.RuleSkip(v.rule.Name, engineapi.Validation, pe.Error()).WithSkipReason(engineapi.SkipReasonConditionalAnchor)
 				}
 
 				if pe.Path == "" {
@@ -318,9 +336,9 @@ func (v *validator) validatePatterns(resource unstructured.Unstructured) *engine
 					skippedAnyPatternErrors = append(skippedAnyPatternErrors, patternErr)
 				} else {
 					if pe.Path == "" {
-						patternErr = fmt.Errorf("rule %s[%d] failed: %s", v.rule.Name, idx, err.Error())
+						patternErr = fmt.Errorf("rule %s[%d] failed: %s%s", v.rule.Name, idx, err.Error(), v.anyPatternMessage(idx))
 					} else {
-						patternErr = fmt.Errorf("rule %s[%d] failed at path %s", v.rule.Name, idx, pe.Path)
+						patternErr = fmt.Errorf("rule %s[%d] failed at path %s%s", v.rule.Name, idx, pe.Path, v.anyPatternMessage(idx))
 					}
 					failedAnyPatternsErrors = append(failedAnyPatternsErrors, patternErr)
 				}
@@ -334,7 +352,7 @@ func (v *validator) validatePatterns(resource unstructured.Unstructured) *engine
 				errorStr = append(errorStr, err.Error())
 			}
 			v.log.V(4).Info(fmt.Sprintf("Validation rule '%s' skipped. %s", v.rule.Name, errorStr))
-			return engineapi.RuleSkip(v.rule.Name, engineapi.Validation, strings.Join(errorStr, " "))
+			return engineapi.RuleSkip(v.rule.Name, engineapi.Validation, strings.Join(errorStr, " ")).WithSkipReason(engineapi.SkipReasonConditionalAnchor)
 		} else if len(failedAnyPatternsErrors) > 0 {
 			var errorStr []string
 			for _, err := range failedAnyPatternsErrors {
@@ -368,6 +386,27 @@ func deserializeAnyPattern(anyPattern apiextensions.JSON) ([]interface{}, error)
 	return res, nil
 }
 
+// anyPatternMessage returns the message of the anyPattern at index idx, formatted
+// to be appended to the pattern failure, or an empty string if it has none
+func (v *validator) anyPatternMessage(idx int) string {
+	if idx >= len(v.anyPatternMsgs) || v.anyPatternMsgs[idx] == "" {
+		return ""
+	}
+	msgRaw, err := variables.SubstituteAll(v.log, v.policyContext.JSONContext(), v.anyPatternMsgs[idx])
+	if err != nil {
+		v.log.V(2).Info("failed to substitute variables in anyPattern message", "error", err)
+		return ""
+	}
+	msg, ok := msgRaw.(string)
+	if !ok || msg == "" {
+		return ""
+	}
+	if !strings.HasSuffix(msg, ".") {
+		msg = msg + "."
+	}
+	return ": " + msg
+}
+
 func (v *validator) buildErrorMessage(err error, path string) string {
 	if v.rule.Validation.Message == "" {
 		if path != "" {
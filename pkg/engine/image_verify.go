@@ -46,7 +46,7 @@ func (e *engine) verifyAndPatchImages(
 				e.imageSignatureRepository,
 			)
 		}
-		resource, ruleResp := e.invokeRuleHandler(
+		resource, ruleResp, notMatched := e.invokeRuleHandler(
 			ctx,
 			logger,
 			handlerFactory,
@@ -57,6 +57,9 @@ func (e *engine) verifyAndPatchImages(
 		)
 		matchedResource = resource
 		resp.Add(engineapi.NewExecutionStats(startTime, time.Now()), ruleResp...)
+		if notMatched != "" {
+			resp.AddNotMatched(rule.Name, engineapi.ImageVerify, notMatched)
+		}
 		if applyRules == kyvernov1.ApplyOne && resp.RulesAppliedCount() > 0 {
 			break
 		}
@@ -194,6 +194,8 @@ func testVerifyAndPatchImages(
 		imageverifycache.DisabledImageVerifyCache(),
 		factories.DefaultContextLoaderFactory(cmResolver),
 		nil,
+		nil,
+		nil,
 		"",
 	)
 	return e.VerifyAndPatchImages(
@@ -9,6 +9,7 @@ import (
 	"strings"
 
 	"github.com/go-logr/logr"
+	gcrv1 "github.com/google/go-containerregistry/pkg/v1"
 	"github.com/kyverno/kyverno/api/kyverno"
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
 	"github.com/kyverno/kyverno/pkg/config"
@@ -244,7 +245,13 @@ func (iv *ImageVerifier) Verify(
 			continue
 		}
 
-		ruleResp, digest := iv.verifyImage(ctx, imageVerify, imageInfo, cfg)
+		var ruleResp *engineapi.RuleResponse
+		var digest string
+		if len(imageVerify.Platforms) > 0 {
+			ruleResp, digest = iv.verifyPlatforms(ctx, imageVerify, imageInfo, cfg)
+		} else {
+			ruleResp, digest = iv.verifyImage(ctx, imageVerify, imageInfo, cfg)
+		}
 
 		if imageVerify.MutateDigest {
 			patch, retrievedDigest, err := iv.handleMutateDigest(ctx, digest, imageInfo)
@@ -309,6 +316,83 @@ func (iv *ImageVerifier) verifyImage(
 	return iv.verifyAttestations(ctx, imageVerify, imageInfo)
 }
 
+// verifyPlatforms verifies the manifests of the selected platforms of a multi-arch image, and its index when configured.
+// It returns the digest of the index so that the image is pinned to the index the verified manifests belong to.
+func (iv *ImageVerifier) verifyPlatforms(
+	ctx context.Context,
+	imageVerify kyvernov1.ImageVerification,
+	imageInfo apiutils.ImageInfo,
+	cfg config.Configuration,
+) (*engineapi.RuleResponse, string) {
+	if len(imageVerify.Attestors) <= 0 && len(imageVerify.Attestations) <= 0 {
+		return nil, ""
+	}
+	image := imageInfo.String()
+	if !matchImageReferences(imageVerify.ImageReferences, image) {
+		return nil, ""
+	}
+	desc, err := iv.rclient.FetchImageDescriptor(ctx, image)
+	if err != nil {
+		return iv.handleRegistryErrors(image, err), ""
+	}
+	if !desc.MediaType.IsIndex() {
+		// single platform images are verified as is
+		return iv.verifyImage(ctx, imageVerify, imageInfo, cfg)
+	}
+	index, err := desc.ImageIndex()
+	if err != nil {
+		return iv.handleRegistryErrors(image, err), ""
+	}
+	manifest, err := index.IndexManifest()
+	if err != nil {
+		return iv.handleRegistryErrors(image, err), ""
+	}
+	digests := map[string]string{}
+	for _, platform := range imageVerify.Platforms {
+		digest, err := platformDigest(manifest, platform)
+		if err != nil {
+			return engineapi.RuleError(iv.rule.Name, engineapi.ImageVerify, fmt.Sprintf("invalid platform %s", platform), err), ""
+		}
+		if digest == "" {
+			msg := fmt.Sprintf("platform %s not found in image index %s", platform, image)
+			return engineapi.RuleFail(iv.rule.Name, engineapi.ImageVerify, msg), ""
+		}
+		platformInfo := imageInfo
+		platformInfo.Digest = digest
+		iv.logger.V(4).Info("verifying platform manifest", "image", image, "platform", platform, "digest", digest)
+		if ruleResp, _ := iv.verifyImage(ctx, imageVerify, platformInfo, cfg); ruleResp != nil && ruleResp.Status() != engineapi.RuleStatusPass {
+			return ruleResp, ""
+		}
+		digests[platform] = digest
+	}
+	if imageVerify.VerifyIndex {
+		indexInfo := imageInfo
+		indexInfo.Digest = desc.Digest.String()
+		if ruleResp, _ := iv.verifyImage(ctx, imageVerify, indexInfo, cfg); ruleResp != nil && ruleResp.Status() != engineapi.RuleStatusPass {
+			return ruleResp, ""
+		}
+	}
+	if err := iv.policyContext.JSONContext().AddPlatformDigests(image, digests); err != nil {
+		return engineapi.RuleError(iv.rule.Name, engineapi.ImageVerify, fmt.Sprintf("failed to add platform digests of %s to context", image), err), ""
+	}
+	msg := fmt.Sprintf("verified image signatures for %s on platforms %s", image, strings.Join(imageVerify.Platforms, ", "))
+	return engineapi.RulePass(iv.rule.Name, engineapi.ImageVerify, msg), desc.Digest.String()
+}
+
+// platformDigest returns the digest of the manifest matching the platform in an image index, empty if not found
+func platformDigest(manifest *gcrv1.IndexManifest, platform string) (string, error) {
+	spec, err := gcrv1.ParsePlatform(platform)
+	if err != nil {
+		return "", err
+	}
+	for _, m := range manifest.Manifests {
+		if m.Platform != nil && m.Platform.Satisfies(*spec) {
+			return m.Digest.String(), nil
+		}
+	}
+	return "", nil
+}
+
 func (iv *ImageVerifier) verifyAttestors(
 	ctx context.Context,
 	attestors []kyvernov1.AttestorSet,
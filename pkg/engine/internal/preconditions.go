@@ -19,11 +19,12 @@ func CheckPreconditions(logger logr.Logger, jsonContext enginecontext.Interface,
 	return variables.EvaluateConditions(logger, jsonContext, typeConditions)
 }
 
-func CheckDenyPreconditions(logger logr.Logger, jsonContext enginecontext.Interface, anyAllConditions apiextensions.JSON) (bool, string, error) {
+// CheckDenyPreconditions evaluates deny conditions, it returns the trace of the evaluated conditions along with the result
+func CheckDenyPreconditions(logger logr.Logger, jsonContext enginecontext.Interface, anyAllConditions apiextensions.JSON) (bool, string, []variables.ConditionTrace, error) {
 	typeConditions, err := utils.TransformConditions(anyAllConditions)
 	if err != nil {
-		return false, "", fmt.Errorf("failed to parse deny conditions: %w", err)
+		return false, "", nil, fmt.Errorf("failed to parse deny conditions: %w", err)
 	}
 
-	return variables.EvaluateConditions(logger, jsonContext, typeConditions)
+	return variables.EvaluateConditionsWithTrace(logger, jsonContext, typeConditions)
 }
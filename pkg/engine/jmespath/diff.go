@@ -0,0 +1,83 @@
+package jmespath
+
+import (
+	"reflect"
+
+	gojmespath "github.com/kyverno/go-jmespath"
+)
+
+// function names
+var (
+	changed = "changed"
+	added   = "added"
+	removed = "removed"
+)
+
+// getDiffValues returns the values found at path in the object and oldObject of the given request,
+// ok is false when the request doesn't carry both objects (only UPDATE requests do)
+func getDiffValues(f string, arguments []interface{}) (value interface{}, oldValue interface{}, ok bool, err error) {
+	request, err := validateArg(f, arguments, 0, reflect.Map)
+	if err != nil {
+		return nil, nil, false, err
+	}
+	path, err := validateArg(f, arguments, 1, reflect.String)
+	if err != nil {
+		return nil, nil, false, err
+	}
+	query, err := gojmespath.Compile(path.String())
+	if err != nil {
+		return nil, nil, false, formatError(genericError, f, err)
+	}
+	object, oldObject := request.MapIndex(reflect.ValueOf("object")), request.MapIndex(reflect.ValueOf("oldObject"))
+	if !isObject(object) || !isObject(oldObject) {
+		return nil, nil, false, nil
+	}
+	if value, err = searchDiffValue(query, object.Interface()); err != nil {
+		return nil, nil, false, formatError(genericError, f, err)
+	}
+	if oldValue, err = searchDiffValue(query, oldObject.Interface()); err != nil {
+		return nil, nil, false, formatError(genericError, f, err)
+	}
+	return value, oldValue, true, nil
+}
+
+// searchDiffValue returns nil when the path is not set in the object
+func searchDiffValue(query *gojmespath.JMESPath, object interface{}) (interface{}, error) {
+	value, err := query.Search(object)
+	if _, ok := err.(gojmespath.NotFoundError); ok {
+		return nil, nil
+	}
+	return value, err
+}
+
+func isObject(value reflect.Value) bool {
+	if !value.IsValid() {
+		return false
+	}
+	_, ok := value.Interface().(map[string]interface{})
+	return ok
+}
+
+func jpChanged(arguments []interface{}) (interface{}, error) {
+	value, oldValue, ok, err := getDiffValues(changed, arguments)
+	if err != nil || !ok {
+		return false, err
+	}
+	return !reflect.DeepEqual(value, oldValue), nil
+}
+
+func jpAdded(arguments []interface{}) (interface{}, error) {
+	value, oldValue, ok, err := getDiffValues(added, arguments)
+	if err != nil || !ok {
+		return false, err
+	}
+	return value != nil && oldValue == nil, nil
+}
+
+func jpRemoved(arguments []interface{}) (interface{}, error) {
+	value, oldValue, ok, err := getDiffValues(removed, arguments)
+	if err != nil || !ok {
+		return false, err
+	}
+	return value == nil && oldValue != nil, nil
+}
@@ -0,0 +1,54 @@
+package jmespath
+
+import (
+	"encoding/json"
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+func Test_Diff(t *testing.T) {
+	update := `{
+		"operation": "UPDATE",
+		"object": {"spec": {"replicas": 2, "selector": {"app": "foo"}, "paused": true}},
+		"oldObject": {"spec": {"replicas": 1, "selector": {"app": "foo"}, "strategy": "Recreate"}}
+	}`
+	create := `{"operation": "CREATE", "object": {"spec": {"replicas": 2}}, "oldObject": null}`
+	testCases := []struct {
+		query          string
+		request        string
+		expectedResult bool
+	}{
+		{query: "changed(@, 'spec.replicas')", request: update, expectedResult: true},
+		{query: "changed(@, 'spec.selector')", request: update, expectedResult: false},
+		{query: "changed(@, 'spec.paused')", request: update, expectedResult: true},
+		{query: "changed(@, 'spec.missing')", request: update, expectedResult: false},
+		{query: "changed(@, 'spec.replicas')", request: create, expectedResult: false},
+		{query: "added(@, 'spec.paused')", request: update, expectedResult: true},
+		{query: "added(@, 'spec.replicas')", request: update, expectedResult: false},
+		{query: "added(@, 'spec.replicas')", request: create, expectedResult: false},
+		{query: "removed(@, 'spec.strategy')", request: update, expectedResult: true},
+		{query: "removed(@, 'spec.paused')", request: update, expectedResult: false},
+		{query: "changed(@, 'spec.selector.*')", request: update, expectedResult: false},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.query, func(t *testing.T) {
+			var request interface{}
+			assert.NilError(t, json.Unmarshal([]byte(tc.request), &request))
+			query, err := newJMESPath(cfg, tc.query)
+			assert.NilError(t, err)
+			res, err := query.Search(request)
+			assert.NilError(t, err)
+			result, ok := res.(bool)
+			assert.Assert(t, ok)
+			assert.Equal(t, result, tc.expectedResult)
+		})
+	}
+}
+
+func Test_DiffInvalidPath(t *testing.T) {
+	query, err := newJMESPath(cfg, "changed(@, 'spec.[')")
+	assert.NilError(t, err)
+	_, err = query.Search(map[string]interface{}{"object": map[string]interface{}{}, "oldObject": map[string]interface{}{}})
+	assert.ErrorContains(t, err, "JMESPath function 'changed'")
+}
@@ -0,0 +1,116 @@
+package jmespath
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+
+	"sigs.k8s.io/yaml"
+)
+
+// function names
+var (
+	gzipDecompress = "gzip_decompress"
+	decodeContent  = "decode_content"
+)
+
+// maxDecompressedSize limits the size of decompressed content to protect against compression bombs
+const maxDecompressedSize = 16 << 20
+
+// content encodings supported by decode_content
+const (
+	encodingBase64 = "base64"
+	encodingGzip   = "gzip"
+	encodingJSON   = "json"
+	encodingYAML   = "yaml"
+)
+
+func jpGzipDecompress(arguments []interface{}) (interface{}, error) {
+	input, err := validateArg(gzipDecompress, arguments, 0, reflect.String)
+	if err != nil {
+		return nil, err
+	}
+	output, err := gunzip(input.String())
+	if err != nil {
+		return nil, formatError(genericError, gzipDecompress, err)
+	}
+	return output, nil
+}
+
+// jpDecodeContent applies the decoding steps in order, e.g. ['base64', 'gzip', 'yaml'] decodes a base64 encoded and gzipped YAML document
+func jpDecodeContent(arguments []interface{}) (interface{}, error) {
+	input, err := validateArg(decodeContent, arguments, 0, reflect.String)
+	if err != nil {
+		return nil, err
+	}
+	encodings, err := validateArg(decodeContent, arguments, 1, reflect.Slice)
+	if err != nil {
+		return nil, err
+	}
+	var content interface{} = input.String()
+	for i := 0; i < encodings.Len(); i++ {
+		encoding, ok := encodings.Index(i).Interface().(string)
+		if !ok {
+			return nil, formatError(invalidArgumentTypeError, decodeContent, 2, "Array of String")
+		}
+		data, ok := content.(string)
+		if !ok {
+			return nil, formatError(genericError, decodeContent, fmt.Errorf("cannot apply %s decoding to parsed content", encoding))
+		}
+		if content, err = decode(data, encoding); err != nil {
+			return nil, formatError(genericError, decodeContent, err)
+		}
+	}
+	return content, nil
+}
+
+func decode(data string, encoding string) (interface{}, error) {
+	switch encoding {
+	case encodingBase64:
+		decoded, err := base64.StdEncoding.DecodeString(data)
+		if err != nil {
+			return nil, err
+		}
+		return string(decoded), nil
+	case encodingGzip:
+		return gunzip(data)
+	case encodingJSON:
+		var output interface{}
+		if err := json.Unmarshal([]byte(data), &output); err != nil {
+			return nil, err
+		}
+		return output, nil
+	case encodingYAML:
+		jsonData, err := yaml.YAMLToJSON([]byte(data))
+		if err != nil {
+			return nil, err
+		}
+		var output interface{}
+		if err := json.Unmarshal(jsonData, &output); err != nil {
+			return nil, err
+		}
+		return output, nil
+	default:
+		return nil, fmt.Errorf("unsupported encoding %s, supported encodings are %s, %s, %s and %s", encoding, encodingBase64, encodingGzip, encodingJSON, encodingYAML)
+	}
+}
+
+func gunzip(data string) (string, error) {
+	reader, err := gzip.NewReader(bytes.NewReader([]byte(data)))
+	if err != nil {
+		return "", err
+	}
+	defer reader.Close()
+	output, err := io.ReadAll(io.LimitReader(reader, maxDecompressedSize+1))
+	if err != nil {
+		return "", err
+	}
+	if len(output) > maxDecompressedSize {
+		return "", fmt.Errorf("decompressed content exceeds %d bytes", maxDecompressedSize)
+	}
+	return string(output), nil
+}
@@ -0,0 +1,83 @@
+package jmespath
+
+import (
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+func Test_DecodeContent(t *testing.T) {
+	data := map[string]interface{}{
+		"data": map[string]interface{}{
+			"kubeconfig":    "YXBpVmVyc2lvbjogdjEKa2luZDogQ29uZmlnCmNsdXN0ZXJzOgotIG5hbWU6IHByb2QKICBjbHVzdGVyOgogICAgc2VydmVyOiBodHRwczovL3Byb2QuZXhhbXBsZS5jb20K",
+			"kubeconfig.gz": "H4sIAAAAAAACAy3KOw6AIBBF0X5W8TYgxHZa92BPYFQivwAaly8mdjc51xS/Sm0+J8Y90+mTYyw5bX4nG67WBzJNSCYKo9TsCPiBRwJN6j0aR++lsdbfo+QxsQRRNkd6AQ+NZPBjAAAA",
+			"config.json":   "eyJyZXBsaWNhcyI6IDN9",
+		},
+	}
+	testCases := []struct {
+		name           string
+		query          string
+		expectedResult interface{}
+	}{{
+		name:           "base64 yaml",
+		query:          "decode_content(data.kubeconfig, ['base64', 'yaml']).clusters[0].cluster.server",
+		expectedResult: "https://prod.example.com",
+	}, {
+		name:           "base64 gzip yaml",
+		query:          "decode_content(data.\"kubeconfig.gz\", ['base64', 'gzip', 'yaml']).clusters[0].name",
+		expectedResult: "prod",
+	}, {
+		name:           "base64 json",
+		query:          "decode_content(data.\"config.json\", ['base64', 'json']).replicas",
+		expectedResult: 3.0,
+	}, {
+		name:           "gzip_decompress",
+		query:          "parse_yaml(gzip_decompress(base64_decode(data.\"kubeconfig.gz\"))).kind",
+		expectedResult: "Config",
+	}, {
+		name:           "no encodings",
+		query:          "decode_content('foo', `[]`)",
+		expectedResult: "foo",
+	}}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			query, err := newJMESPath(cfg, tc.query)
+			assert.NilError(t, err)
+			res, err := query.Search(data)
+			assert.NilError(t, err)
+			assert.Equal(t, res, tc.expectedResult)
+		})
+	}
+}
+
+func Test_DecodeContentErrors(t *testing.T) {
+	testCases := []struct {
+		name  string
+		query string
+		err   string
+	}{{
+		name:  "unsupported encoding",
+		query: "decode_content('foo', ['rot13'])",
+		err:   "unsupported encoding rot13",
+	}, {
+		name:  "parsed content",
+		query: "decode_content('a: b', ['yaml', 'base64'])",
+		err:   "cannot apply base64 decoding to parsed content",
+	}, {
+		name:  "not gzip",
+		query: "gzip_decompress('foo')",
+		err:   "JMESPath function 'gzip_decompress'",
+	}, {
+		name:  "invalid encodings",
+		query: "decode_content('foo', `[1]`)",
+		err:   "argument #2 is not of type Array of String",
+	}}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			query, err := newJMESPath(cfg, tc.query)
+			assert.NilError(t, err)
+			_, err = query.Search(map[string]interface{}{})
+			assert.ErrorContains(t, err, tc.err)
+		})
+	}
+}
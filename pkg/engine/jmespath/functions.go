@@ -65,6 +65,8 @@ var (
 	pathCanonicalize       = "path_canonicalize"
 	truncate               = "truncate"
 	semverCompare          = "semver_compare"
+	semverOrder            = "semver_order"
+	semverSatisfies        = "semver_satisfies"
 	parseJson              = "parse_json"
 	parseYAML              = "parse_yaml"
 	lookup                 = "lookup"
@@ -398,6 +400,28 @@ func GetFunctions(configuration config.Configuration) []FunctionEntry {
 		},
 		ReturnType: []jpType{jpBool},
 		Note:       "compares two strings which comply with the semantic versioning schema and outputs a boolean response as to the position of the second relative to the first",
+	}, {
+		FunctionEntry: gojmespath.FunctionEntry{
+			Name: semverOrder,
+			Arguments: []argSpec{
+				{Types: []jpType{jpString}},
+				{Types: []jpType{jpString}},
+			},
+			Handler: jpSemverOrder,
+		},
+		ReturnType: []jpType{jpNumber},
+		Note:       "compares two semantic versions and returns -1, 0 or 1 when the first is lower than, equal to or greater than the second, a leading 'v' and missing minor or patch numbers are accepted",
+	}, {
+		FunctionEntry: gojmespath.FunctionEntry{
+			Name: semverSatisfies,
+			Arguments: []argSpec{
+				{Types: []jpType{jpString}},
+				{Types: []jpType{jpString}},
+			},
+			Handler: jpSemverSatisfies,
+		},
+		ReturnType: []jpType{jpBool},
+		Note:       "checks a semantic version satisfies a constraint like '>=1.25.0 <1.28.0' (comma separated conditions are also accepted), a leading 'v' and missing minor or patch numbers are accepted in the version and invalid versions are reported as errors",
 	}, {
 		FunctionEntry: gojmespath.FunctionEntry{
 			Name: parseJson,
@@ -418,6 +442,38 @@ func GetFunctions(configuration config.Configuration) []FunctionEntry {
 		},
 		ReturnType: []jpType{jpAny},
 		Note:       "decodes a valid YAML encoded string to the appropriate type provided it can be represented as JSON",
+	}, {
+		FunctionEntry: gojmespath.FunctionEntry{
+			Name: jsonSchemaValidate,
+			Arguments: []argSpec{
+				{Types: []jpType{jpAny}},
+				{Types: []jpType{jpObject}},
+			},
+			Handler: jpJSONSchemaValidate,
+		},
+		ReturnType: []jpType{jpArray},
+		Note:       "validates a value against an OpenAPI v3 / JSON schema object and returns the list of validation errors, the list is empty when the value is valid. It can be used with `parse_yaml` or `parse_json` to validate config files embedded in ConfigMaps",
+	}, {
+		FunctionEntry: gojmespath.FunctionEntry{
+			Name: gzipDecompress,
+			Arguments: []argSpec{
+				{Types: []jpType{jpString}},
+			},
+			Handler: jpGzipDecompress,
+		},
+		ReturnType: []jpType{jpString},
+		Note:       "decompresses gzip compressed content, typically the output of `base64_decode` on a Secret or ConfigMap binaryData field",
+	}, {
+		FunctionEntry: gojmespath.FunctionEntry{
+			Name: decodeContent,
+			Arguments: []argSpec{
+				{Types: []jpType{jpString}},
+				{Types: []jpType{jpArray}},
+			},
+			Handler: jpDecodeContent,
+		},
+		ReturnType: []jpType{jpAny},
+		Note:       "decodes nested content by applying the given decoding steps in order (base64, gzip, json or yaml), e.g. `decode_content(data.kubeconfig, ['base64', 'yaml'])` parses a kubeconfig stored in a Secret",
 	}, {
 		FunctionEntry: gojmespath.FunctionEntry{
 			Name: lookup,
@@ -580,6 +636,39 @@ func GetFunctions(configuration config.Configuration) []FunctionEntry {
 		},
 		ReturnType: []jpType{jpString},
 		Note:       "normalizes an image reference",
+	}, {
+		FunctionEntry: gojmespath.FunctionEntry{
+			Name: changed,
+			Arguments: []argSpec{
+				{Types: []jpType{jpObject}},
+				{Types: []jpType{jpString}},
+			},
+			Handler: jpChanged,
+		},
+		ReturnType: []jpType{jpBool},
+		Note:       "returns true if the value at the given path differs between the object and oldObject of the given request (UPDATE requests only), e.g. changed(request, 'spec.selector')",
+	}, {
+		FunctionEntry: gojmespath.FunctionEntry{
+			Name: added,
+			Arguments: []argSpec{
+				{Types: []jpType{jpObject}},
+				{Types: []jpType{jpString}},
+			},
+			Handler: jpAdded,
+		},
+		ReturnType: []jpType{jpBool},
+		Note:       "returns true if the given path is set in the object but not in the oldObject of the given request (UPDATE requests only)",
+	}, {
+		FunctionEntry: gojmespath.FunctionEntry{
+			Name: removed,
+			Arguments: []argSpec{
+				{Types: []jpType{jpObject}},
+				{Types: []jpType{jpString}},
+			},
+			Handler: jpRemoved,
+		},
+		ReturnType: []jpType{jpBool},
+		Note:       "returns true if the given path is set in the oldObject but not in the object of the given request (UPDATE requests only)",
 	}}
 }
 
@@ -979,6 +1068,50 @@ func jpSemverCompare(arguments []interface{}) (interface{}, error) {
 	return false, nil
 }
 
+func jpSemverOrder(arguments []interface{}) (interface{}, error) {
+	a, err := parseSemver(semverOrder, arguments, 0)
+	if err != nil {
+		return nil, err
+	}
+	b, err := parseSemver(semverOrder, arguments, 1)
+	if err != nil {
+		return nil, err
+	}
+	return a.Compare(b), nil
+}
+
+func jpSemverSatisfies(arguments []interface{}) (interface{}, error) {
+	version, err := parseSemver(semverSatisfies, arguments, 0)
+	if err != nil {
+		return nil, err
+	}
+	c, err := validateArg(semverSatisfies, arguments, 1, reflect.String)
+	if err != nil {
+		return nil, err
+	}
+	// conditions separated by commas must all be satisfied, the same as conditions separated by spaces
+	constraint := strings.Join(strings.Fields(strings.ReplaceAll(c.String(), ",", " ")), " ")
+	expectedRange, err := semver.ParseRange(constraint)
+	if err != nil {
+		return nil, formatError(genericError, semverSatisfies, err.Error())
+	}
+	return expectedRange(version), nil
+}
+
+// parseSemver parses the semantic version at the given index, tolerating a leading 'v' (Kubernetes versions,
+// image tags) and missing minor or patch numbers
+func parseSemver(f string, arguments []interface{}, index int) (semver.Version, error) {
+	v, err := validateArg(f, arguments, index, reflect.String)
+	if err != nil {
+		return semver.Version{}, err
+	}
+	version, err := semver.ParseTolerant(v.String())
+	if err != nil {
+		return semver.Version{}, formatError(genericError, f, fmt.Sprintf("argument #%d is not a semantic version: %s", index+1, err))
+	}
+	return version, nil
+}
+
 func jpParseJson(arguments []interface{}) (interface{}, error) {
 	input, err := validateArg(parseJson, arguments, 0, reflect.String)
 	if err != nil {
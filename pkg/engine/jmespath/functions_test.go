@@ -846,6 +846,98 @@ func Test_SemverCompare(t *testing.T) {
 	}
 }
 
+func Test_SemverOrder(t *testing.T) {
+	testCases := []struct {
+		jmesPath       string
+		expectedResult int
+		err            string
+	}{
+		{
+			jmesPath:       "semver_order('1.25.3','1.28.0')",
+			expectedResult: -1,
+		},
+		{
+			jmesPath:       "semver_order('v1.28','1.28.0')",
+			expectedResult: 0,
+		},
+		{
+			jmesPath:       "semver_order('1.28.0','1.28.0-rc.1')",
+			expectedResult: 1,
+		},
+		{
+			jmesPath: "semver_order('latest','1.28.0')",
+			err:      "JMESPath function 'semver_order': argument #1 is not a semantic version",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.jmesPath, func(t *testing.T) {
+			jp, err := newJMESPath(cfg, tc.jmesPath)
+			assert.NilError(t, err)
+
+			result, err := jp.Search("")
+			if tc.err != "" {
+				assert.ErrorContains(t, err, tc.err)
+				return
+			}
+			assert.NilError(t, err)
+
+			res, ok := result.(int)
+			assert.Assert(t, ok)
+			assert.Equal(t, res, tc.expectedResult)
+		})
+	}
+}
+
+func Test_SemverSatisfies(t *testing.T) {
+	testCases := []struct {
+		jmesPath       string
+		expectedResult bool
+		err            string
+	}{
+		{
+			jmesPath:       "semver_satisfies('1.26.4','>=1.25.0 <1.28.0')",
+			expectedResult: true,
+		},
+		{
+			jmesPath:       "semver_satisfies('v1.28.2','>=1.25.0 <1.28.0')",
+			expectedResult: false,
+		},
+		{
+			jmesPath:       "semver_satisfies('v1.27','>=1.25.0, <1.28.0')",
+			expectedResult: true,
+		},
+		{
+			jmesPath:       "semver_satisfies('2.1.5','<2.0.0 || >=3.0.0')",
+			expectedResult: false,
+		},
+		{
+			jmesPath: "semver_satisfies('latest','>=1.25.0')",
+			err:      "JMESPath function 'semver_satisfies': argument #1 is not a semantic version",
+		},
+		{
+			jmesPath: "semver_satisfies('1.25.0','>=foo')",
+			err:      "JMESPath function 'semver_satisfies': ",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.jmesPath, func(t *testing.T) {
+			jp, err := newJMESPath(cfg, tc.jmesPath)
+			assert.NilError(t, err)
+
+			result, err := jp.Search("")
+			if tc.err != "" {
+				assert.ErrorContains(t, err, tc.err)
+				return
+			}
+			assert.NilError(t, err)
+
+			res, ok := result.(bool)
+			assert.Assert(t, ok)
+			assert.Equal(t, res, tc.expectedResult)
+		})
+	}
+}
+
 func Test_Lookup(t *testing.T) {
 	testCases := []struct {
 		collection     string
@@ -1,6 +1,10 @@
 package jmespath
 
-import "github.com/kyverno/kyverno/pkg/config"
+import (
+	gojmespath "github.com/kyverno/go-jmespath"
+	"github.com/kyverno/kyverno/pkg/config"
+	"github.com/kyverno/kyverno/pkg/utils/refcache"
+)
 
 type Query interface {
 	Search(interface{}) (interface{}, error)
@@ -11,17 +15,31 @@ type Interface interface {
 	Search(string, interface{}) (interface{}, error)
 }
 
+// Compiler precompiles queries, precompiled queries are reused by Query and Search until released
+type Compiler interface {
+	// Compile precompiles the queries of owner, replacing the queries previously compiled for owner.
+	// Invalid queries are ignored, the error is reported when the query is used.
+	Compile(owner string, queries ...string)
+	// Release releases the queries compiled for owner
+	Release(owner string)
+}
+
 type implementation struct {
 	configuration config.Configuration
+	compiled      *refcache.Cache[*gojmespath.JMESPath]
 }
 
 func New(configuration config.Configuration) Interface {
 	return implementation{
 		configuration: configuration,
+		compiled:      refcache.New[*gojmespath.JMESPath](),
 	}
 }
 
 func (i implementation) Query(query string) (Query, error) {
+	if compiled, ok := i.compiled.Get(query); ok {
+		return compiled, nil
+	}
 	return newJMESPath(i.configuration, query)
 }
 
@@ -32,3 +50,20 @@ func (i implementation) Search(query string, data interface{}) (interface{}, err
 		return query.Search(data)
 	}
 }
+
+func (i implementation) Compile(owner string, queries ...string) {
+	compiled := make(map[string]*gojmespath.JMESPath, len(queries))
+	for _, query := range queries {
+		if _, ok := compiled[query]; ok {
+			continue
+		}
+		if jp, err := newJMESPath(i.configuration, query); err == nil {
+			compiled[query] = jp
+		}
+	}
+	i.compiled.Set(owner, compiled)
+}
+
+func (i implementation) Release(owner string) {
+	i.compiled.Release(owner)
+}
@@ -0,0 +1,31 @@
+package jmespath
+
+import (
+	"testing"
+
+	"github.com/kyverno/kyverno/pkg/config"
+	"gotest.tools/assert"
+)
+
+func TestCompile(t *testing.T) {
+	jp := New(config.NewDefaultConfiguration(false))
+	compiler, ok := jp.(Compiler)
+	assert.Assert(t, ok)
+	compiler.Compile("policy", "request.object.metadata.name", "to_upper(request.operation)", "invalid[")
+	query, err := jp.Query("to_upper(request.operation)")
+	assert.NilError(t, err)
+	// precompiled queries are returned as is
+	precompiled, err := jp.Query("to_upper(request.operation)")
+	assert.NilError(t, err)
+	assert.Equal(t, query, precompiled)
+	result, err := query.Search(map[string]interface{}{"request": map[string]interface{}{"operation": "create"}})
+	assert.NilError(t, err)
+	assert.Equal(t, result, "CREATE")
+	// invalid queries are not precompiled and still fail
+	_, err = jp.Query("invalid[")
+	assert.Assert(t, err != nil)
+	compiler.Release("policy")
+	released, err := jp.Query("to_upper(request.operation)")
+	assert.NilError(t, err)
+	assert.Assert(t, released != query)
+}
@@ -0,0 +1,38 @@
+package jmespath
+
+import (
+	"encoding/json"
+	"reflect"
+
+	"k8s.io/kube-openapi/pkg/validation/spec"
+	"k8s.io/kube-openapi/pkg/validation/strfmt"
+	"k8s.io/kube-openapi/pkg/validation/validate"
+)
+
+// function names
+var (
+	jsonSchemaValidate = "json_schema_validate"
+)
+
+// jpJSONSchemaValidate validates a value against an OpenAPI v3 / JSON schema and returns the validation errors,
+// the value is valid when the returned list is empty
+func jpJSONSchemaValidate(arguments []interface{}) (interface{}, error) {
+	schemaArg, err := validateArg(jsonSchemaValidate, arguments, 1, reflect.Map)
+	if err != nil {
+		return nil, err
+	}
+	data, err := json.Marshal(schemaArg.Interface())
+	if err != nil {
+		return nil, formatError(genericError, jsonSchemaValidate, err)
+	}
+	var schema spec.Schema
+	if err := json.Unmarshal(data, &schema); err != nil {
+		return nil, formatError(genericError, jsonSchemaValidate, err)
+	}
+	result := validate.NewSchemaValidator(&schema, nil, "", strfmt.Default).Validate(arguments[0])
+	errs := []interface{}{}
+	for _, err := range result.Errors {
+		errs = append(errs, err.Error())
+	}
+	return errs, nil
+}
@@ -0,0 +1,73 @@
+package jmespath
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+func Test_JSONSchemaValidate(t *testing.T) {
+	schema := `{
+		"type": "object",
+		"required": ["replicas", "image"],
+		"properties": {
+			"replicas": {"type": "integer", "minimum": 1},
+			"image": {"type": "string", "pattern": "^registry.io/"},
+			"pullPolicy": {"type": "string", "enum": ["Always", "IfNotPresent"]}
+		}
+	}`
+	testCases := []struct {
+		name           string
+		query          string
+		expectedErrors []string
+	}{{
+		name:  "valid yaml",
+		query: "json_schema_validate(parse_yaml('replicas: 2\nimage: registry.io/app'), schema)",
+	}, {
+		name:           "invalid yaml",
+		query:          "json_schema_validate(parse_yaml('replicas: 0\nimage: docker.io/app\npullPolicy: Never'), schema)",
+		expectedErrors: []string{"replicas", "image", "pullPolicy"},
+	}, {
+		name:           "missing fields in json",
+		query:          "json_schema_validate(parse_json('{\"replicas\": 1.5}'), schema)",
+		expectedErrors: []string{"replicas", "image"},
+	}, {
+		name:           "not an object",
+		query:          "json_schema_validate('foo', schema)",
+		expectedErrors: []string{"object"},
+	}}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var data map[string]interface{}
+			assert.NilError(t, json.Unmarshal([]byte(`{"schema": `+schema+`}`), &data))
+			query, err := newJMESPath(cfg, tc.query)
+			assert.NilError(t, err)
+			res, err := query.Search(data)
+			assert.NilError(t, err)
+			errs, ok := res.([]interface{})
+			assert.Assert(t, ok)
+			assert.Equal(t, len(errs), len(tc.expectedErrors), errs)
+			for i, expected := range tc.expectedErrors {
+				assert.Assert(t, contains(errs, expected), "error %d: %s not found in %v", i, expected, errs)
+			}
+		})
+	}
+}
+
+func Test_JSONSchemaValidateInvalidSchema(t *testing.T) {
+	query, err := newJMESPath(cfg, "json_schema_validate(`{}`, `{\"type\": 1}`)")
+	assert.NilError(t, err)
+	_, err = query.Search(nil)
+	assert.ErrorContains(t, err, "JMESPath function 'json_schema_validate'")
+}
+
+func contains(errs []interface{}, expected string) bool {
+	for _, err := range errs {
+		if s, ok := err.(string); ok && strings.Contains(s, expected) {
+			return true
+		}
+	}
+	return false
+}
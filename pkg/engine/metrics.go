@@ -19,7 +19,7 @@ func (e *engine) reportMetrics(
 	admissionOperation bool,
 	response engineapi.EngineResponse,
 ) {
-	if e.resultCounter == nil && e.durationHistogram == nil {
+	if e.resultCounter == nil && e.durationHistogram == nil && e.skipCounter == nil {
 		return
 	}
 	policy := response.Policy().GetPolicy().(kyvernov1.PolicyInterface)
@@ -92,5 +92,26 @@ func (e *engine) reportMetrics(
 				e.durationHistogram.Record(ctx, rule.Stats().ProcessingTime().Seconds(), metric.WithAttributes(commonLabels...))
 			}
 		}
+		if e.skipCounter != nil {
+			executionCause := metrics.AdmissionRequest
+			if !admissionOperation {
+				executionCause = metrics.BackgroundScan
+			}
+			stats := response.PolicyResponse.Stats()
+			for _, skipped := range stats.SkippedRules() {
+				commonLabels := []attribute.KeyValue{
+					attribute.String("policy_type", string(policyType)),
+					attribute.String("policy_namespace", namespace),
+					attribute.String("policy_name", name),
+					attribute.String("resource_kind", resourceKind),
+					attribute.String("resource_request_operation", strings.ToLower(string(operation))),
+					attribute.String("rule_name", skipped.Rule),
+					attribute.String("rule_type", strings.ToLower(string(skipped.Type))),
+					attribute.String("rule_execution_cause", string(executionCause)),
+					attribute.String("skip_reason", string(skipped.Reason)),
+				}
+				e.skipCounter.Add(ctx, 1, metric.WithAttributes(commonLabels...))
+			}
+		}
 	}
 }
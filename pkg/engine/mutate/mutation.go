@@ -14,6 +14,7 @@ import (
 	datautils "github.com/kyverno/kyverno/pkg/utils/data"
 	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/kustomize/kyaml/openapi"
 )
 
 type Response struct {
@@ -37,13 +38,13 @@ func NewErrorResponse(msg string, err error) *Response {
 	return NewResponse(engineapi.RuleStatusError, unstructured.Unstructured{}, msg)
 }
 
-func Mutate(rule *kyvernov1.Rule, ctx context.Interface, resource unstructured.Unstructured, logger logr.Logger) *Response {
+func Mutate(rule *kyvernov1.Rule, ctx context.Interface, resource unstructured.Unstructured, schema *openapi.ResourceSchema, logger logr.Logger) *Response {
 	updatedRule, err := variables.SubstituteAllInRule(logger, ctx, *rule)
 	if err != nil {
 		return NewErrorResponse("variable substitution failed", err)
 	}
 	m := updatedRule.Mutation
-	patcher := NewPatcher(m.GetPatchStrategicMerge(), m.PatchesJSON6902)
+	patcher := NewPatcher(m.GetPatchStrategicMerge(), m.PatchesJSON6902, schema)
 	if patcher == nil {
 		return NewErrorResponse("empty mutate rule", nil)
 	}
@@ -73,13 +74,13 @@ func Mutate(rule *kyvernov1.Rule, ctx context.Interface, resource unstructured.U
 	return NewResponse(engineapi.RuleStatusPass, resource, "resource patched")
 }
 
-func ForEach(name string, foreach kyvernov1.ForEachMutation, policyContext engineapi.PolicyContext, resource unstructured.Unstructured, element interface{}, logger logr.Logger) *Response {
+func ForEach(name string, foreach kyvernov1.ForEachMutation, policyContext engineapi.PolicyContext, resource unstructured.Unstructured, element interface{}, schema *openapi.ResourceSchema, logger logr.Logger) *Response {
 	ctx := policyContext.JSONContext()
 	fe, err := substituteAllInForEach(foreach, ctx, logger)
 	if err != nil {
 		return NewErrorResponse("variable substitution failed", err)
 	}
-	patcher := NewPatcher(fe.GetPatchStrategicMerge(), fe.PatchesJSON6902)
+	patcher := NewPatcher(fe.GetPatchStrategicMerge(), fe.PatchesJSON6902, schema)
 	if patcher == nil {
 		return NewErrorResponse("empty mutate rule", nil)
 	}
@@ -127,9 +128,10 @@ func substituteAllInForEach(fe kyvernov1.ForEachMutation, ctx context.Interface,
 	return &updatedForEach, nil
 }
 
-func NewPatcher(strategicMergePatch apiextensions.JSON, jsonPatch string) patch.Patcher {
+// NewPatcher returns the patcher of a mutation, schema is used by strategic merge patches and can be nil
+func NewPatcher(strategicMergePatch apiextensions.JSON, jsonPatch string, schema *openapi.ResourceSchema) patch.Patcher {
 	if strategicMergePatch != nil {
-		return patch.NewPatchStrategicMerge(strategicMergePatch)
+		return patch.NewPatchStrategicMerge(strategicMergePatch, schema)
 	}
 	if len(jsonPatch) > 0 {
 		return patch.NewPatchesJSON6902(jsonPatch)
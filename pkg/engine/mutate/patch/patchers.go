@@ -3,6 +3,7 @@ package patch
 import (
 	"github.com/go-logr/logr"
 	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
+	"sigs.k8s.io/kustomize/kyaml/openapi"
 )
 
 type (
@@ -16,17 +17,19 @@ type Patcher interface {
 
 // patchStrategicMergeHandler
 type patchStrategicMergeHandler struct {
-	patch apiextensions.JSON
+	patch  apiextensions.JSON
+	schema *openapi.ResourceSchema
 }
 
-func NewPatchStrategicMerge(patch apiextensions.JSON) Patcher {
+func NewPatchStrategicMerge(patch apiextensions.JSON, schema *openapi.ResourceSchema) Patcher {
 	return patchStrategicMergeHandler{
-		patch: patch,
+		patch:  patch,
+		schema: schema,
 	}
 }
 
 func (h patchStrategicMergeHandler) Patch(logger logr.Logger, resource resource) (resource, error) {
-	return ProcessStrategicMergePatch(logger, h.patch, resource)
+	return ProcessStrategicMergePatch(logger, h.patch, resource, h.schema)
 }
 
 // patchesJSON6902Handler
@@ -9,7 +9,7 @@ import (
 )
 
 func Test_GeneratePatches(t *testing.T) {
-	out, err := strategicMergePatch(logr.Discard(), string(baseBytes), string(overlayBytes))
+	out, err := strategicMergePatch(logr.Discard(), string(baseBytes), string(overlayBytes), nil)
 	assert.NoError(t, err)
 	expectedPatches := map[string]bool{
 		`{"op":"add","path":"/spec/template/spec/initContainers","value":[{"command":["echo $(WORDPRESS_SERVICE)","echo $(MYSQL_SERVICE)"],"image":"debian","name":"init-command"}]}`:                                                                                                                                                                                                                                                    true,
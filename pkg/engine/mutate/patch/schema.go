@@ -0,0 +1,88 @@
+package patch
+
+import (
+	"encoding/json"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/kube-openapi/pkg/validation/spec"
+	"sigs.k8s.io/kustomize/kyaml/openapi"
+	"sigs.k8s.io/kustomize/kyaml/yaml"
+)
+
+const (
+	listTypeExtension      = "x-kubernetes-list-type"
+	listMapKeysExtension   = "x-kubernetes-list-map-keys"
+	patchStrategyExtension = "x-kubernetes-patch-strategy"
+	patchMergeKeyExtension = "x-kubernetes-patch-merge-key"
+)
+
+// HasBuiltinSchema returns true when the strategic merge patch already knows the schema of a kind
+func HasBuiltinSchema(apiVersion, kind string) bool {
+	return openapi.SchemaForResourceType(yaml.TypeMeta{APIVersion: apiVersion, Kind: kind}) != nil
+}
+
+// NewSchema converts the structural schema of a custom resource to a schema usable by the strategic merge patch,
+// lists declared with x-kubernetes-list-type map are merged using their x-kubernetes-list-map-keys and lists
+// declared with x-kubernetes-list-type set are merged by value, other lists are replaced
+func NewSchema(props *apiextensionsv1.JSONSchemaProps) (*openapi.ResourceSchema, error) {
+	if props == nil {
+		return nil, nil
+	}
+	data, err := json.Marshal(props)
+	if err != nil {
+		return nil, err
+	}
+	var schema spec.Schema
+	if err := json.Unmarshal(data, &schema); err != nil {
+		return nil, err
+	}
+	addMergeStrategies(&schema)
+	// custom resource schemas don't describe metadata, use the object meta schema instead
+	if metadata, ok := schema.Properties["metadata"]; ok && len(metadata.Properties) == 0 {
+		if objectMeta := objectMetaSchema(); objectMeta != nil {
+			schema.Properties["metadata"] = *objectMeta
+		}
+	}
+	return &openapi.ResourceSchema{Schema: &schema}, nil
+}
+
+func addMergeStrategies(schema *spec.Schema) {
+	for name, property := range schema.Properties {
+		addMergeStrategies(&property)
+		schema.Properties[name] = property
+	}
+	if schema.AdditionalProperties != nil && schema.AdditionalProperties.Schema != nil {
+		addMergeStrategies(schema.AdditionalProperties.Schema)
+	}
+	if schema.Items == nil || schema.Items.Schema == nil {
+		return
+	}
+	addMergeStrategies(schema.Items.Schema)
+	listType, _ := schema.Extensions.GetString(listTypeExtension)
+	switch listType {
+	case "map":
+		keys, _ := schema.Extensions.GetStringSlice(listMapKeysExtension)
+		if len(keys) == 0 {
+			return
+		}
+		schema.AddExtension(patchStrategyExtension, "merge")
+		schema.AddExtension(patchMergeKeyExtension, keys[0])
+	case "set":
+		// only lists of scalars can be merged by value
+		if schema.Items.Schema.Type.Contains("object") || schema.Items.Schema.Type.Contains("array") {
+			return
+		}
+		schema.AddExtension(patchStrategyExtension, "merge")
+	}
+}
+
+func objectMetaSchema() *spec.Schema {
+	configMap := openapi.SchemaForResourceType(yaml.TypeMeta{APIVersion: "v1", Kind: "ConfigMap"})
+	if configMap == nil {
+		return nil
+	}
+	if metadata := configMap.Field("metadata"); metadata != nil {
+		return metadata.Schema
+	}
+	return nil
+}
@@ -0,0 +1,146 @@
+package patch
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"gotest.tools/assert"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+)
+
+var crdSchema = []byte(`{
+  "type": "object",
+  "properties": {
+    "metadata": {
+      "type": "object"
+    },
+    "spec": {
+      "type": "object",
+      "properties": {
+        "listeners": {
+          "type": "array",
+          "x-kubernetes-list-type": "map",
+          "x-kubernetes-list-map-keys": ["name"],
+          "items": {
+            "type": "object",
+            "properties": {
+              "name": {"type": "string"},
+              "port": {"type": "integer"},
+              "hostnames": {
+                "type": "array",
+                "x-kubernetes-list-type": "set",
+                "items": {"type": "string"}
+              }
+            }
+          }
+        },
+        "ports": {
+          "type": "array",
+          "x-kubernetes-list-type": "map",
+          "x-kubernetes-list-map-keys": ["port", "protocol"],
+          "items": {
+            "type": "object",
+            "properties": {
+              "port": {"type": "integer"},
+              "protocol": {"type": "string"},
+              "name": {"type": "string"}
+            }
+          }
+        },
+        "args": {
+          "type": "array",
+          "items": {"type": "string"}
+        }
+      }
+    }
+  }
+}`)
+
+func Test_strategicMergePatch_Schema(t *testing.T) {
+	var props apiextensionsv1.JSONSchemaProps
+	assert.NilError(t, json.Unmarshal(crdSchema, &props))
+	schema, err := NewSchema(&props)
+	assert.NilError(t, err)
+	testCases := []struct {
+		name     string
+		resource string
+		patch    string
+		expected string
+	}{{
+		name:     "list map merged by key",
+		resource: `{"apiVersion":"example.com/v1","kind":"Gateway","metadata":{"name":"gw"},"spec":{"listeners":[{"name":"http","port":80},{"name":"https","port":443}]}}`,
+		patch:    `{"spec":{"listeners":[{"name":"https","port":8443}]}}`,
+		expected: `{"apiVersion":"example.com/v1","kind":"Gateway","metadata":{"name":"gw"},"spec":{"listeners":[{"name":"https","port":8443},{"name":"http","port":80}]}}`,
+	}, {
+		name:     "list map with new key",
+		resource: `{"apiVersion":"example.com/v1","kind":"Gateway","metadata":{"name":"gw"},"spec":{"listeners":[{"name":"http","port":80}]}}`,
+		patch:    `{"spec":{"listeners":[{"name":"https","port":443}]}}`,
+		expected: `{"apiVersion":"example.com/v1","kind":"Gateway","metadata":{"name":"gw"},"spec":{"listeners":[{"name":"https","port":443},{"name":"http","port":80}]}}`,
+	}, {
+		name:     "list map with multiple keys",
+		resource: `{"apiVersion":"example.com/v1","kind":"Gateway","metadata":{"name":"gw"},"spec":{"ports":[{"port":53,"protocol":"TCP","name":"dns-tcp"},{"port":53,"protocol":"UDP","name":"dns-udp"}]}}`,
+		patch:    `{"spec":{"ports":[{"port":53,"protocol":"UDP","name":"dns"}]}}`,
+		expected: `{"apiVersion":"example.com/v1","kind":"Gateway","metadata":{"name":"gw"},"spec":{"ports":[{"port":53,"protocol":"TCP","name":"dns-tcp"},{"port":53,"protocol":"UDP","name":"dns"}]}}`,
+	}, {
+		name:     "nested set merged by value",
+		resource: `{"apiVersion":"example.com/v1","kind":"Gateway","metadata":{"name":"gw"},"spec":{"listeners":[{"name":"http","hostnames":["a.example.com"]}]}}`,
+		patch:    `{"spec":{"listeners":[{"name":"http","hostnames":["b.example.com"]}]}}`,
+		expected: `{"apiVersion":"example.com/v1","kind":"Gateway","metadata":{"name":"gw"},"spec":{"listeners":[{"name":"http","hostnames":["b.example.com","a.example.com"]}]}}`,
+	}, {
+		name:     "atomic list replaced",
+		resource: `{"apiVersion":"example.com/v1","kind":"Gateway","metadata":{"name":"gw"},"spec":{"args":["--a","--b"]}}`,
+		patch:    `{"spec":{"args":["--c"]}}`,
+		expected: `{"apiVersion":"example.com/v1","kind":"Gateway","metadata":{"name":"gw"},"spec":{"args":["--c"]}}`,
+	}, {
+		name:     "metadata uses the object meta schema",
+		resource: `{"apiVersion":"example.com/v1","kind":"Gateway","metadata":{"name":"gw","ownerReferences":[{"apiVersion":"v1","kind":"ConfigMap","name":"a","uid":"1"}]}}`,
+		patch:    `{"metadata":{"ownerReferences":[{"apiVersion":"v1","kind":"ConfigMap","name":"b","uid":"2"}]}}`,
+		expected: `{"apiVersion":"example.com/v1","kind":"Gateway","metadata":{"name":"gw","ownerReferences":[{"apiVersion":"v1","kind":"ConfigMap","name":"b","uid":"2"},{"apiVersion":"v1","kind":"ConfigMap","name":"a","uid":"1"}]}}`,
+	}, {
+		name:     "anchors are supported",
+		resource: `{"apiVersion":"example.com/v1","kind":"Gateway","metadata":{"name":"gw"},"spec":{"listeners":[{"name":"http","port":80},{"name":"https","port":443}]}}`,
+		patch:    `{"spec":{"listeners":[{"(name)":"https","port":8443}]}}`,
+		expected: `{"apiVersion":"example.com/v1","kind":"Gateway","metadata":{"name":"gw"},"spec":{"listeners":[{"name":"https","port":8443},{"name":"http","port":80}]}}`,
+	}}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			out, err := strategicMergePatch(logr.Discard(), test.resource, test.patch, schema)
+			assert.NilError(t, err)
+			assert.DeepEqual(t, toJSON(t, []byte(test.expected)), toJSON(t, out))
+		})
+	}
+}
+
+func Test_strategicMergePatch_NoSchema(t *testing.T) {
+	resource := `{"apiVersion":"example.com/v1","kind":"Gateway","metadata":{"name":"gw"},"spec":{"listeners":[{"name":"http","port":80},{"name":"https","port":443}]}}`
+	patch := `{"spec":{"listeners":[{"name":"https","port":8443}]}}`
+	expected := `{"apiVersion":"example.com/v1","kind":"Gateway","metadata":{"name":"gw"},"spec":{"listeners":[{"name":"https","port":8443}]}}`
+	out, err := strategicMergePatch(logr.Discard(), resource, patch, nil)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, toJSON(t, []byte(expected)), toJSON(t, out))
+}
+
+func Test_HasBuiltinSchema(t *testing.T) {
+	assert.Equal(t, HasBuiltinSchema("v1", "Pod"), true)
+	assert.Equal(t, HasBuiltinSchema("apps/v1", "Deployment"), true)
+	assert.Equal(t, HasBuiltinSchema("example.com/v1", "Gateway"), false)
+}
+
+func Test_NewSchema(t *testing.T) {
+	schema, err := NewSchema(nil)
+	assert.NilError(t, err)
+	assert.Assert(t, schema == nil)
+	var props apiextensionsv1.JSONSchemaProps
+	assert.NilError(t, json.Unmarshal(crdSchema, &props))
+	schema, err = NewSchema(&props)
+	assert.NilError(t, err)
+	strategy, keys := schema.Lookup("spec", "ports").PatchStrategyAndKeyList()
+	assert.Equal(t, strategy, "merge")
+	assert.DeepEqual(t, keys, []string{"port", "protocol"})
+	strategy, keys = schema.Lookup("spec", "listeners", "[]", "hostnames").PatchStrategyAndKeyList()
+	assert.Equal(t, strategy, "merge")
+	assert.DeepEqual(t, keys, []string{})
+	strategy, _ = schema.Lookup("spec", "args").PatchStrategyAndKeyList()
+	assert.Equal(t, strategy, "")
+}
@@ -8,17 +8,21 @@ import (
 	"github.com/go-logr/logr"
 	"sigs.k8s.io/kustomize/api/filters/patchstrategicmerge"
 	filtersutil "sigs.k8s.io/kustomize/kyaml/filtersutil"
+	"sigs.k8s.io/kustomize/kyaml/kio"
+	"sigs.k8s.io/kustomize/kyaml/openapi"
 	yaml "sigs.k8s.io/kustomize/kyaml/yaml"
+	"sigs.k8s.io/kustomize/kyaml/yaml/merge2"
+	"sigs.k8s.io/kustomize/kyaml/yaml/walk"
 )
 
-// ProcessStrategicMergePatch ...
-func ProcessStrategicMergePatch(logger logr.Logger, overlay interface{}, resource resource) (resource, error) {
+// ProcessStrategicMergePatch applies a strategic merge patch, schema is used to merge the lists of custom resources and can be nil
+func ProcessStrategicMergePatch(logger logr.Logger, overlay interface{}, resource resource, schema *openapi.ResourceSchema) (resource, error) {
 	overlayBytes, err := json.Marshal(overlay)
 	if err != nil {
 		logger.Error(err, "failed to marshal resource")
 		return nil, err
 	}
-	patchedBytes, err := strategicMergePatch(logger, string(resource), string(overlayBytes))
+	patchedBytes, err := strategicMergePatch(logger, string(resource), string(overlayBytes), schema)
 	if err != nil {
 		logger.Error(err, "failed to apply patchStrategicMerge")
 		return nil, err
@@ -26,7 +30,21 @@ func ProcessStrategicMergePatch(logger logr.Logger, overlay interface{}, resourc
 	return patchedBytes, nil
 }
 
-func strategicMergePatch(logger logr.Logger, base, overlay string) ([]byte, error) {
+// ResolveAnchors returns the strategic merge patch with its anchors resolved against the resource, as it is merged into the resource.
+// A ConditionError or GlobalConditionError is returned when the conditions of the patch are not met by the resource.
+func ResolveAnchors(logger logr.Logger, overlay interface{}, resource resource) (interface{}, error) {
+	overlayBytes, err := json.Marshal(overlay)
+	if err != nil {
+		return nil, err
+	}
+	preprocessedYaml, err := preProcessStrategicMergePatch(logger, string(overlayBytes), string(resource))
+	if err != nil {
+		return nil, err
+	}
+	return convertRNodeToInterface(preprocessedYaml)
+}
+
+func strategicMergePatch(logger logr.Logger, base, overlay string, schema *openapi.ResourceSchema) ([]byte, error) {
 	preprocessedYaml, err := preProcessStrategicMergePatch(logger, overlay, base)
 	if err != nil {
 		_, isConditionError := err.(ConditionError)
@@ -43,9 +61,15 @@ func strategicMergePatch(logger logr.Logger, base, overlay string) ([]byte, erro
 
 	patchStr, _ := preprocessedYaml.String()
 	logger.V(3).Info("applying strategic merge patch", "patch", patchStr)
-	f := patchstrategicmerge.Filter{
+	var f kio.Filter = patchstrategicmerge.Filter{
 		Patch: preprocessedYaml,
 	}
+	if schema != nil {
+		f = schemaMergeFilter{
+			patch:  preprocessedYaml,
+			schema: schema,
+		}
+	}
 
 	baseObj := buffer{Buffer: bytes.NewBufferString(base)}
 	err = filtersutil.ApplyToJSON(f, baseObj)
@@ -53,6 +77,33 @@ func strategicMergePatch(logger logr.Logger, base, overlay string) ([]byte, erro
 	return baseObj.Bytes(), err
 }
 
+// schemaMergeFilter does a strategic merge patch using the given schema instead of the builtin one
+type schemaMergeFilter struct {
+	patch  *yaml.RNode
+	schema *openapi.ResourceSchema
+}
+
+func (f schemaMergeFilter) Filter(nodes []*yaml.RNode) ([]*yaml.RNode, error) {
+	var result []*yaml.RNode
+	for i := range nodes {
+		r, err := walk.Walker{
+			Sources: []*yaml.RNode{nodes[i], f.patch},
+			Schema:  f.schema,
+			Visitor: merge2.Merger{},
+			MergeOptions: yaml.MergeOptions{
+				ListIncreaseDirection: yaml.MergeOptionsListPrepend,
+			},
+		}.Walk()
+		if err != nil {
+			return nil, err
+		}
+		if r != nil {
+			result = append(result, r)
+		}
+	}
+	return result, nil
+}
+
 func preProcessStrategicMergePatch(logger logr.Logger, pattern, resource string) (*yaml.RNode, error) {
 	patternNode := yaml.MustParse(pattern)
 	resourceNode := yaml.MustParse(resource)
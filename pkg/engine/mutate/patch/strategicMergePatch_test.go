@@ -164,7 +164,7 @@ func TestMergePatch(t *testing.T) {
 
 	for i, test := range testCases {
 		t.Logf("Running test %d...", i+1)
-		out, err := strategicMergePatch(logr.Discard(), string(test.rawResource), string(test.rawPolicy))
+		out, err := strategicMergePatch(logr.Discard(), string(test.rawResource), string(test.rawPolicy), nil)
 		assert.NilError(t, err)
 		assert.DeepEqual(t, toJSON(t, test.expected), toJSON(t, out))
 	}
@@ -249,7 +249,7 @@ func Test_PolicyDeserilize(t *testing.T) {
 	patchString, err := json.Marshal(overlayPatches)
 	assert.NilError(t, err)
 
-	out, err := strategicMergePatch(logr.Discard(), string(baseBytes), string(patchString))
+	out, err := strategicMergePatch(logr.Discard(), string(baseBytes), string(patchString), nil)
 	assert.NilError(t, err)
 
 	var ep unstructured.Unstructured
@@ -263,3 +263,49 @@ func Test_PolicyDeserilize(t *testing.T) {
 		t.FailNow()
 	}
 }
+
+func Test_ResolveAnchors(t *testing.T) {
+	overlay := `{"metadata":{"labels":{"+(team)":"platform"}},"spec":{"containers":[{"(image)":"nginx*","imagePullPolicy":"Always"}]}}`
+	tests := []struct {
+		name     string
+		overlay  string
+		resource string
+		want     string
+		wantErr  bool
+	}{{
+		name:     "add if not present",
+		overlay:  overlay,
+		resource: `{"kind":"Pod","metadata":{"name":"test"},"spec":{"containers":[{"name":"nginx","image":"nginx"}]}}`,
+		want:     `{"metadata":{"labels":{"team":"platform"}},"spec":{"containers":[{"name":"nginx","imagePullPolicy":"Always"}]}}`,
+	}, {
+		name:     "already present",
+		overlay:  overlay,
+		resource: `{"kind":"Pod","metadata":{"name":"test","labels":{"team":"apps"}},"spec":{"containers":[{"name":"nginx","image":"nginx"}]}}`,
+		want:     `{"spec":{"containers":[{"name":"nginx","imagePullPolicy":"Always"}]}}`,
+	}, {
+		name:     "no matching list element",
+		overlay:  overlay,
+		resource: `{"kind":"Pod","metadata":{"name":"test"},"spec":{"containers":[{"name":"busybox","image":"busybox"}]}}`,
+		want:     `{"metadata":{"labels":{"team":"platform"}}}`,
+	}, {
+		name:     "condition not met",
+		overlay:  `{"metadata":{"(name)":"prod-*","labels":{"team":"platform"}}}`,
+		resource: `{"kind":"Pod","metadata":{"name":"test"}}`,
+		wantErr:  true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var patch interface{}
+			assert.NilError(t, json.Unmarshal([]byte(tt.overlay), &patch))
+			resolved, err := ResolveAnchors(logr.Discard(), patch, []byte(tt.resource))
+			if tt.wantErr {
+				assert.Assert(t, isConditionError(err) || isGlobalConditionError(err))
+				return
+			}
+			assert.NilError(t, err)
+			data, err := json.Marshal(resolved)
+			assert.NilError(t, err)
+			assertnew.JSONEq(t, tt.want, string(data))
+		})
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/kyverno/kyverno/pkg/engine/handlers"
 	"github.com/kyverno/kyverno/pkg/engine/handlers/mutation"
 	"github.com/kyverno/kyverno/pkg/engine/internal"
+	"gomodules.xyz/jsonpatch/v2"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 )
 
@@ -36,11 +37,12 @@ func (e *engine) mutate(
 				return nil, nil
 			}
 			if !policyContext.AdmissionOperation() && rule.IsMutateExisting() {
-				return mutation.NewMutateExistingHandler(e.client)
+				return mutation.NewMutateExistingHandler(e.client, e.schemaResolver)
 			}
-			return mutation.NewMutateResourceHandler()
+			return mutation.NewMutateResourceHandler(e.schemaResolver)
 		}
-		resource, ruleResp := e.invokeRuleHandler(
+		previous := matchedResource
+		resource, ruleResp, notMatched := e.invokeRuleHandler(
 			ctx,
 			logger,
 			handlerFactory,
@@ -50,10 +52,43 @@ func (e *engine) mutate(
 			engineapi.Mutation,
 		)
 		matchedResource = resource
+		ruleResp = withRulePatches(logger, previous, resource, ruleResp)
 		resp.Add(engineapi.NewExecutionStats(startTime, time.Now()), ruleResp...)
+		if notMatched != "" {
+			resp.AddNotMatched(rule.Name, engineapi.Mutation, notMatched)
+		}
 		if applyRules == kyvernov1.ApplyOne && resp.RulesAppliedCount() > 0 {
 			break
 		}
 	}
 	return resp, matchedResource
 }
+
+// withRulePatches records the patches applied by a rule in its successful response
+func withRulePatches(logger logr.Logger, previous, resource unstructured.Unstructured, ruleResp []engineapi.RuleResponse) []engineapi.RuleResponse {
+	for i := range ruleResp {
+		if ruleResp[i].Status() != engineapi.RuleStatusPass {
+			continue
+		}
+		previousBytes, err := previous.MarshalJSON()
+		if err != nil {
+			logger.Error(err, "failed to marshal resource")
+			return ruleResp
+		}
+		resourceBytes, err := resource.MarshalJSON()
+		if err != nil {
+			logger.Error(err, "failed to marshal patched resource")
+			return ruleResp
+		}
+		patches, err := jsonpatch.CreatePatch(previousBytes, resourceBytes)
+		if err != nil {
+			logger.Error(err, "failed to compute rule patches")
+			return ruleResp
+		}
+		if len(patches) != 0 {
+			ruleResp[i] = *ruleResp[i].WithPatches(patches...)
+		}
+		return ruleResp
+	}
+	return ruleResp
+}
@@ -42,6 +42,8 @@ func testMutate(
 		imageverifycache.DisabledImageVerifyCache(),
 		contextLoader,
 		nil,
+		nil,
+		nil,
 		"",
 	)
 	return e.Mutate(
@@ -1999,6 +2001,18 @@ func Test_RuleSelectorMutate(t *testing.T) {
 		require.Equal(t, expected, er.PatchedResource)
 	}
 
+	require.Equal(t, []engineapi.AppliedPatch{{
+		Policy:    "add-label",
+		Rule:      "add-app-label",
+		Operation: "add",
+		Path:      "/metadata/labels",
+	}, {
+		Policy:    "add-label",
+		Rule:      "add-appname-label",
+		Operation: "add",
+		Path:      "/metadata/labels/appname",
+	}}, er.GetAppliedPatches())
+
 	applyOne := kyverno.ApplyOne
 	policyContext.Policy().GetSpec().ApplyRules = &applyOne
 
@@ -2398,3 +2412,109 @@ func Test_SpecialCharacters(t *testing.T) {
 		})
 	}
 }
+
+func Test_mutate_existing_status(t *testing.T) {
+	policyRaw := []byte(`{
+		"apiVersion": "kyverno.io/v1",
+		"kind": "ClusterPolicy",
+		"metadata": {
+			"name": "mark-non-compliant"
+		},
+		"spec": {
+			"rules": [
+				{
+					"name": "set-condition",
+					"match": {
+						"any": [
+							{
+								"resources": {
+									"kinds": [
+										"ConfigMap"
+									]
+								}
+							}
+						]
+					},
+					"mutate": {
+						"targets": [
+							{
+								"apiVersion": "apps/v1",
+								"kind": "Deployment/status",
+								"name": "example",
+								"namespace": "staging"
+							}
+						],
+						"patchStrategicMerge": {
+							"status": {
+								"conditions": [
+									{
+										"type": "Compliant",
+										"status": "False"
+									}
+								]
+							}
+						}
+					}
+				}
+			]
+		}
+	}`)
+	trigger := loadUnstructured(t, []byte(`{
+		"apiVersion": "v1",
+		"kind": "ConfigMap",
+		"metadata": {
+			"name": "dictionary",
+			"namespace": "staging"
+		}
+	}`))
+	target := loadUnstructured(t, []byte(`{
+		"apiVersion": "apps/v1",
+		"kind": "Deployment",
+		"metadata": {
+			"name": "example",
+			"namespace": "staging"
+		}
+	}`))
+	allowed, disallowed := true, false
+	tests := []struct {
+		name                string
+		allowStatusMutation *bool
+		want                engineapi.RuleStatus
+	}{{
+		name: "allowed by default",
+		want: engineapi.RuleStatusPass,
+	}, {
+		name:                "allowed",
+		allowStatusMutation: &allowed,
+		want:                engineapi.RuleStatusPass,
+	}, {
+		name:                "not allowed",
+		allowStatusMutation: &disallowed,
+		want:                engineapi.RuleStatusError,
+	}}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			policy := loadResource[kyverno.ClusterPolicy](t, policyRaw)
+			policy.Spec.Rules[0].Mutation.AllowStatusMutation = test.allowStatusMutation
+			gvrToListKind := map[schema.GroupVersionResource]string{
+				{Group: "apps", Version: "v1", Resource: "deployments"}: "DeploymentList",
+			}
+			dclient, err := client.NewFakeClient(runtime.NewScheme(), gvrToListKind, target.DeepCopy())
+			require.NoError(t, err)
+			dclient.SetDiscovery(client.NewFakeDiscoveryClient(nil))
+			er := testMutate(context.TODO(), dclient, registryclient.NewOrDie(), createContext(t, &policy, trigger, kyverno.Create), nil)
+			require.Len(t, er.PolicyResponse.Rules, 1)
+			rr := er.PolicyResponse.Rules[0]
+			require.Equal(t, test.want, rr.Status(), rr.Message())
+			if test.want != engineapi.RuleStatusPass {
+				return
+			}
+			patched, _, subresource := rr.PatchedTarget()
+			require.NotNil(t, patched)
+			require.Equal(t, "status", subresource)
+			conditions, _, err := unstructured.NestedSlice(patched.Object, "status", "conditions")
+			require.NoError(t, err)
+			require.Equal(t, []interface{}{map[string]interface{}{"type": "Compliant", "status": "False"}}, conditions)
+		})
+	}
+}
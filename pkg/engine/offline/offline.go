@@ -0,0 +1,186 @@
+// Package offline evaluates resources against Kyverno policies without a cluster.
+//
+// It is meant to be embedded in programs such as admission simulators or IDE plugins:
+// context entries are resolved from the data registered with the evaluator (config maps and variables),
+// api calls and image registry lookups are never performed.
+package offline
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov1beta1 "github.com/kyverno/kyverno/api/kyverno/v1beta1"
+	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	"github.com/kyverno/kyverno/pkg/autogen"
+	"github.com/kyverno/kyverno/pkg/config"
+	"github.com/kyverno/kyverno/pkg/engine"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	"github.com/kyverno/kyverno/pkg/engine/factories"
+	"github.com/kyverno/kyverno/pkg/engine/jmespath"
+	"github.com/kyverno/kyverno/pkg/imageverifycache"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// Request describes the admission of a resource to evaluate
+type Request struct {
+	// Resource is the new resource, it is empty for DELETE operations
+	Resource unstructured.Unstructured
+	// OldResource is the existing resource, it is set for UPDATE and DELETE operations
+	OldResource unstructured.Unstructured
+	// Operation defaults to CREATE, or DELETE when only the old resource is set
+	Operation kyvernov1.AdmissionOperation
+	// UserInfo holds the roles, cluster roles and user info of the requester
+	UserInfo kyvernov1beta1.RequestInfo
+	// NamespaceLabels are the labels of the namespace of the resource
+	NamespaceLabels map[string]string
+	// Variables are added to the context in addition to the variables registered with the evaluator
+	Variables map[string]interface{}
+}
+
+// Response holds the engine responses of a policy evaluated against a resource
+type Response struct {
+	// Mutation is the response of mutate rules, the mutated resource is Mutation.PatchedResource
+	Mutation engineapi.EngineResponse
+	// Validation is the response of validate rules, evaluated against the mutated resource
+	Validation engineapi.EngineResponse
+}
+
+// Evaluator evaluates resources against policies
+type Evaluator interface {
+	// Evaluate applies mutate and validate rules of the policy to the resource described by the request
+	Evaluate(ctx context.Context, policy kyvernov1.PolicyInterface, request Request) (Response, error)
+}
+
+// Option configures an Evaluator
+type Option func(*evaluator)
+
+// WithConfiguration sets the kyverno configuration, the default configuration is used otherwise
+func WithConfiguration(configuration config.Configuration) Option {
+	return func(e *evaluator) {
+		e.configuration = configuration
+	}
+}
+
+// WithConfigMaps registers config maps used to resolve configMap context entries
+func WithConfigMaps(configMaps ...*corev1.ConfigMap) Option {
+	return func(e *evaluator) {
+		for _, cm := range configMaps {
+			e.configMaps.add(cm)
+		}
+	}
+}
+
+// WithVariables registers variables added to the context of every evaluation,
+// registering the name of an apiCall or imageRegistry context entry provides its data
+func WithVariables(variables map[string]interface{}) Option {
+	return func(e *evaluator) {
+		for key, value := range variables {
+			e.variables[key] = value
+		}
+	}
+}
+
+// WithExceptions registers policy exceptions
+func WithExceptions(exceptions ...*kyvernov2alpha1.PolicyException) Option {
+	return func(e *evaluator) {
+		e.exceptions = append(e.exceptions, exceptions...)
+	}
+}
+
+type evaluator struct {
+	configuration config.Configuration
+	configMaps    configMapResolver
+	variables     map[string]interface{}
+	exceptions    exceptionSelector
+	jp            jmespath.Interface
+	engine        engineapi.Engine
+}
+
+// New creates an Evaluator
+func New(opts ...Option) Evaluator {
+	e := &evaluator{
+		configMaps: configMapResolver{},
+		variables:  map[string]interface{}{},
+	}
+	for _, opt := range opts {
+		opt(e)
+	}
+	if e.configuration == nil {
+		e.configuration = config.NewDefaultConfiguration(false)
+	}
+	e.jp = jmespath.New(e.configuration)
+	// a nil selector disables exceptions, a typed nil would not
+	var selector engineapi.PolicyExceptionSelector
+	if len(e.exceptions) > 0 {
+		selector = e.exceptions
+	}
+	e.engine = engine.NewEngine(
+		e.configuration,
+		config.NewDefaultMetricsConfiguration(),
+		e.jp,
+		nil,
+		nil,
+		imageverifycache.DisabledImageVerifyCache(),
+		factories.DefaultContextLoaderFactory(e.configMaps),
+		selector,
+		nil,
+		nil,
+		"",
+	)
+	return e
+}
+
+func (e *evaluator) Evaluate(ctx context.Context, policy kyvernov1.PolicyInterface, request Request) (Response, error) {
+	if policy == nil {
+		return Response{}, errors.New("policy must not be nil")
+	}
+	resource, operation := request.Resource, request.Operation
+	if operation == "" {
+		operation = kyvernov1.Create
+		if resource.Object == nil && request.OldResource.Object != nil {
+			operation = kyvernov1.Delete
+		}
+	}
+	if operation == kyvernov1.Delete {
+		resource = request.OldResource
+	}
+	if resource.Object == nil {
+		return Response{}, errors.New("resource must not be empty")
+	}
+	policyContext, err := engine.NewPolicyContext(e.jp, resource, operation, &request.UserInfo, e.configuration)
+	if err != nil {
+		return Response{}, fmt.Errorf("failed to create policy context: %w", err)
+	}
+	if operation == kyvernov1.Update && request.OldResource.Object != nil {
+		if err := policyContext.JSONContext().AddOldResource(request.OldResource.Object); err != nil {
+			return Response{}, fmt.Errorf("failed to add old resource to context: %w", err)
+		}
+		policyContext = policyContext.WithOldResource(request.OldResource)
+	}
+	policyContext = policyContext.
+		WithPolicy(policy).
+		WithNamespaceLabels(request.NamespaceLabels).
+		WithResourceKind(resource.GroupVersionKind(), "")
+	for _, variables := range []map[string]interface{}{e.variables, request.Variables} {
+		for key, value := range variables {
+			if err := policyContext.JSONContext().AddVariable(key, value); err != nil {
+				return Response{}, fmt.Errorf("failed to add variable %s to context: %w", key, err)
+			}
+		}
+	}
+	var response Response
+	response.Mutation = e.engine.Mutate(ctx, policyContext)
+	for _, rule := range autogen.ComputeRules(policy) {
+		if rule.HasValidate() {
+			if operation != kyvernov1.Delete {
+				policyContext = policyContext.WithNewResource(response.Mutation.PatchedResource)
+			}
+			response.Validation = e.engine.Validate(ctx, policyContext)
+			break
+		}
+	}
+	return response, nil
+}
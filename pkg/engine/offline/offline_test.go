@@ -0,0 +1,190 @@
+package offline
+
+import (
+	"context"
+	"testing"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	kyvernov2beta1 "github.com/kyverno/kyverno/api/kyverno/v2beta1"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	kubeutils "github.com/kyverno/kyverno/pkg/utils/kube"
+	yamlutils "github.com/kyverno/kyverno/pkg/utils/yaml"
+	"gotest.tools/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func loadPolicy(t *testing.T, data string) kyvernov1.PolicyInterface {
+	policies, _, err := yamlutils.GetPolicy([]byte(data))
+	assert.NilError(t, err)
+	assert.Equal(t, len(policies), 1)
+	return policies[0]
+}
+
+func loadResource(t *testing.T, data string) unstructured.Unstructured {
+	resource, err := kubeutils.BytesToUnstructured([]byte(data))
+	assert.NilError(t, err)
+	return *resource
+}
+
+func ruleStatuses(response engineapi.EngineResponse) map[string]engineapi.RuleStatus {
+	out := map[string]engineapi.RuleStatus{}
+	for _, rule := range response.PolicyResponse.Rules {
+		out[rule.Name()] = rule.Status()
+	}
+	return out
+}
+
+const pod = `{
+  "apiVersion": "v1",
+  "kind": "Pod",
+  "metadata": { "name": "nginx", "namespace": "default" },
+  "spec": { "containers": [ { "name": "nginx", "image": "registry.io/nginx:1.12" } ] }
+}`
+
+const validatePolicy = `
+apiVersion: kyverno.io/v1
+kind: ClusterPolicy
+metadata:
+  name: allowed-registries
+spec:
+  rules:
+  - name: check-registry
+    match:
+      any:
+      - resources:
+          kinds:
+          - Pod
+    context:
+    - name: settings
+      configMap:
+        name: settings
+        namespace: kyverno
+    - name: owners
+      apiCall:
+        urlPath: /api/v1/namespaces/default
+        jmesPath: metadata.labels.owner
+    validate:
+      message: registry is not allowed
+      deny:
+        conditions:
+          any:
+          - key: "{{ request.object.spec.containers[0].image }}"
+            operator: NotEquals
+            value: "{{ settings.data.registry }}/*"
+          - key: "{{ owners }}"
+            operator: NotEquals
+            value: team-a
+`
+
+func Test_Evaluate_validate(t *testing.T) {
+	settings := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "settings", Namespace: "kyverno"},
+		Data:       map[string]string{"registry": "registry.io"},
+	}
+	tests := []struct {
+		name      string
+		variables map[string]interface{}
+		request   map[string]interface{}
+		want      engineapi.RuleStatus
+	}{{
+		name:      "pass",
+		variables: map[string]interface{}{"owners": "team-a"},
+		want:      engineapi.RuleStatusPass,
+	}, {
+		name:      "fail",
+		variables: map[string]interface{}{"owners": "team-b"},
+		want:      engineapi.RuleStatusFail,
+	}, {
+		name:      "request variables override evaluator variables",
+		variables: map[string]interface{}{"owners": "team-b"},
+		request:   map[string]interface{}{"owners": "team-a"},
+		want:      engineapi.RuleStatusPass,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			evaluator := New(WithConfigMaps(settings), WithVariables(tt.variables))
+			response, err := evaluator.Evaluate(context.TODO(), loadPolicy(t, validatePolicy), Request{
+				Resource:  loadResource(t, pod),
+				Variables: tt.request,
+			})
+			assert.NilError(t, err)
+			assert.DeepEqual(t, ruleStatuses(response.Validation), map[string]engineapi.RuleStatus{"check-registry": tt.want})
+		})
+	}
+}
+
+const mutateAndValidatePolicy = `
+apiVersion: kyverno.io/v1
+kind: ClusterPolicy
+metadata:
+  name: team-label
+spec:
+  rules:
+  - name: add-label
+    match:
+      any:
+      - resources:
+          kinds:
+          - Pod
+    mutate:
+      patchStrategicMerge:
+        metadata:
+          labels:
+            team: platform
+  - name: require-label
+    match:
+      any:
+      - resources:
+          kinds:
+          - Pod
+    validate:
+      message: team label is required
+      pattern:
+        metadata:
+          labels:
+            team: "?*"
+`
+
+func Test_Evaluate_mutate(t *testing.T) {
+	response, err := New().Evaluate(context.TODO(), loadPolicy(t, mutateAndValidatePolicy), Request{
+		Resource: loadResource(t, pod),
+	})
+	assert.NilError(t, err)
+	assert.DeepEqual(t, ruleStatuses(response.Mutation), map[string]engineapi.RuleStatus{"add-label": engineapi.RuleStatusPass})
+	assert.Equal(t, response.Mutation.PatchedResource.GetLabels()["team"], "platform")
+	// validation runs against the mutated resource
+	assert.DeepEqual(t, ruleStatuses(response.Validation), map[string]engineapi.RuleStatus{"require-label": engineapi.RuleStatusPass})
+}
+
+func Test_Evaluate_exceptions(t *testing.T) {
+	exception := &kyvernov2alpha1.PolicyException{
+		ObjectMeta: metav1.ObjectMeta{Name: "nginx", Namespace: "default"},
+		Spec: kyvernov2alpha1.PolicyExceptionSpec{
+			Exceptions: []kyvernov2alpha1.Exception{{
+				PolicyName: "allowed-registries",
+				RuleNames:  []string{"check-registry"},
+			}},
+			Match: kyvernov2beta1.MatchResources{
+				Any: kyvernov1.ResourceFilters{{
+					ResourceDescription: kyvernov1.ResourceDescription{Kinds: []string{"Pod"}, Names: []string{"nginx"}},
+				}},
+			},
+		},
+	}
+	evaluator := New(WithExceptions(exception), WithVariables(map[string]interface{}{"owners": "team-b"}))
+	response, err := evaluator.Evaluate(context.TODO(), loadPolicy(t, validatePolicy), Request{
+		Resource: loadResource(t, pod),
+	})
+	assert.NilError(t, err)
+	assert.DeepEqual(t, ruleStatuses(response.Validation), map[string]engineapi.RuleStatus{"check-registry": engineapi.RuleStatusSkip})
+}
+
+func Test_Evaluate_invalid(t *testing.T) {
+	_, err := New().Evaluate(context.TODO(), nil, Request{Resource: loadResource(t, pod)})
+	assert.Error(t, err, "policy must not be nil")
+	_, err = New().Evaluate(context.TODO(), loadPolicy(t, validatePolicy), Request{})
+	assert.Error(t, err, "resource must not be empty")
+}
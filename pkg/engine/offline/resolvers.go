@@ -0,0 +1,37 @@
+package offline
+
+import (
+	"context"
+
+	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// configMapResolver resolves config maps registered with the evaluator
+type configMapResolver map[string]*corev1.ConfigMap
+
+func (r configMapResolver) add(cm *corev1.ConfigMap) {
+	r[cm.GetNamespace()+"/"+cm.GetName()] = cm
+}
+
+func (r configMapResolver) Get(_ context.Context, namespace, name string) (*corev1.ConfigMap, error) {
+	if cm, ok := r[namespace+"/"+name]; ok {
+		return cm, nil
+	}
+	return nil, apierrors.NewNotFound(corev1.Resource("configmaps"), name)
+}
+
+// exceptionSelector selects policy exceptions registered with the evaluator
+type exceptionSelector []*kyvernov2alpha1.PolicyException
+
+func (s exceptionSelector) List(selector labels.Selector) ([]*kyvernov2alpha1.PolicyException, error) {
+	var out []*kyvernov2alpha1.PolicyException
+	for _, exception := range s {
+		if selector.Matches(labels.Set(exception.GetLabels())) {
+			out = append(out, exception)
+		}
+	}
+	return out, nil
+}
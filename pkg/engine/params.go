@@ -0,0 +1,86 @@
+package engine
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	"github.com/kyverno/kyverno/pkg/engine/variables"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// paramsContextKey is the context key under which the policy parameter resource is made available
+const paramsContextKey = "params"
+
+// loadParams resolves the parameter resource referenced by the policy and adds it to the json context.
+// It returns false when the parameter resource was not found and the rules should be skipped.
+func (e *engine) loadParams(
+	ctx context.Context,
+	logger logr.Logger,
+	policyContext engineapi.PolicyContext,
+) (bool, error) {
+	spec := policyContext.Policy().GetSpec()
+	if !spec.HasParams() {
+		return true, nil
+	}
+	if e.paramsResolver == nil {
+		return false, fmt.Errorf("a params resolver is required to resolve policy parameters")
+	}
+	gv, err := schema.ParseGroupVersion(spec.ParamKind.APIVersion)
+	if err != nil {
+		return false, fmt.Errorf("failed to parse paramKind.apiVersion %s: %w", spec.ParamKind.APIVersion, err)
+	}
+	jsonContext := policyContext.JSONContext()
+	name, err := variables.SubstituteAll(logger, jsonContext, spec.ParamRef.Name)
+	if err != nil {
+		return false, fmt.Errorf("failed to substitute variables in paramRef.name %s: %w", spec.ParamRef.Name, err)
+	}
+	namespace, err := variables.SubstituteAll(logger, jsonContext, spec.ParamRef.Namespace)
+	if err != nil {
+		return false, fmt.Errorf("failed to substitute variables in paramRef.namespace %s: %w", spec.ParamRef.Namespace, err)
+	}
+	nameString, ok := name.(string)
+	if !ok {
+		return false, fmt.Errorf("invalid paramRef.name %v, name must be a string", name)
+	}
+	namespaceString, ok := namespace.(string)
+	if !ok {
+		return false, fmt.Errorf("invalid paramRef.namespace %v, namespace must be a string", namespace)
+	}
+	if namespaceString == "" {
+		namespaceString = paramsNamespace(policyContext)
+	}
+	// the namespace is ignored by the resolver when the parameter kind is cluster scoped
+	param, err := e.paramsResolver.Get(ctx, gv.WithKind(spec.ParamKind.Kind), namespaceString, nameString)
+	if err != nil {
+		if apierrors.IsNotFound(err) && spec.ParamRef.GetParameterNotFoundAction() == kyvernov1.AllowAction {
+			logger.V(3).Info("parameter resource not found", "kind", spec.ParamKind.Kind, "namespace", namespaceString, "name", nameString)
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to get parameter resource %s %s/%s: %w", spec.ParamKind.Kind, namespaceString, nameString, err)
+	}
+	raw, err := json.Marshal(param.Object)
+	if err != nil {
+		return false, fmt.Errorf("failed to marshal parameter resource %s %s/%s: %w", spec.ParamKind.Kind, namespaceString, nameString, err)
+	}
+	if err := jsonContext.AddContextEntry(paramsContextKey, raw); err != nil {
+		return false, fmt.Errorf("failed to add parameter resource to the context: %w", err)
+	}
+	return true, nil
+}
+
+// paramsNamespace returns the namespace used to resolve a parameter resource when the policy doesn't set one
+func paramsNamespace(policyContext engineapi.PolicyContext) string {
+	newResource, oldResource := policyContext.NewResource(), policyContext.OldResource()
+	if namespace := newResource.GetNamespace(); namespace != "" {
+		return namespace
+	}
+	if namespace := oldResource.GetNamespace(); namespace != "" {
+		return namespace
+	}
+	return policyContext.Policy().GetNamespace()
+}
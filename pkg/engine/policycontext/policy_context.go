@@ -51,8 +51,14 @@ type PolicyContext struct {
 	// namespaceLabels stores the label of namespace to be processed by namespace selector
 	namespaceLabels map[string]string
 
+	// namespaceAnnotations stores the annotations of the namespace, they can override the validation failure action of policies
+	namespaceAnnotations map[string]string
+
 	// admissionOperation represents if the caller is from the webhook server
 	admissionOperation bool
+
+	// policyCacheRevision is the revision of the policy cache snapshot the policy was taken from
+	policyCacheRevision uint64
 }
 
 // engineapi.PolicyContext interface
@@ -97,6 +103,14 @@ func (c *PolicyContext) NamespaceLabels() map[string]string {
 	return c.namespaceLabels
 }
 
+func (c *PolicyContext) NamespaceAnnotations() map[string]string {
+	return c.namespaceAnnotations
+}
+
+func (c *PolicyContext) PolicyCacheRevision() uint64 {
+	return c.policyCacheRevision
+}
+
 func (c *PolicyContext) AdmissionOperation() bool {
 	return c.admissionOperation
 }
@@ -131,6 +145,18 @@ func (c *PolicyContext) WithNamespaceLabels(namespaceLabels map[string]string) *
 	return copy
 }
 
+func (c *PolicyContext) WithNamespaceAnnotations(namespaceAnnotations map[string]string) *PolicyContext {
+	copy := c.copy()
+	copy.namespaceAnnotations = namespaceAnnotations
+	return copy
+}
+
+func (c *PolicyContext) WithPolicyCacheRevision(policyCacheRevision uint64) *PolicyContext {
+	copy := c.copy()
+	copy.policyCacheRevision = policyCacheRevision
+	return copy
+}
+
 func (c *PolicyContext) WithAdmissionInfo(admissionInfo kyvernov1beta1.RequestInfo) *PolicyContext {
 	copy := c.copy()
 	copy.admissionInfo = admissionInfo
@@ -0,0 +1,102 @@
+package precompile
+
+import (
+	"encoding/json"
+	"strings"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/autogen"
+	"github.com/kyverno/kyverno/pkg/engine/handlers/validation"
+	"github.com/kyverno/kyverno/pkg/engine/jmespath"
+	"github.com/kyverno/kyverno/pkg/engine/variables/regex"
+	"github.com/kyverno/kyverno/pkg/policycache"
+)
+
+// jmesPathFields are the rule fields holding a JMESPath expression without braces
+var jmesPathFields = map[string]struct{}{
+	"jmesPath": {},
+	"list":     {},
+}
+
+type compiler struct {
+	jp jmespath.Compiler
+}
+
+// New returns a policy cache compiler precompiling JMESPath queries (when jp supports it) and CEL validators of policies
+func New(jp jmespath.Interface) policycache.Compiler {
+	c := compiler{}
+	if jpCompiler, ok := jp.(jmespath.Compiler); ok {
+		c.jp = jpCompiler
+	}
+	return c
+}
+
+func (c compiler) Compile(key string, policy kyvernov1.PolicyInterface) {
+	rules := autogen.ComputeRules(policy)
+	if c.jp != nil {
+		var queries []string
+		for _, rule := range rules {
+			queries = append(queries, Queries(rule)...)
+		}
+		c.jp.Compile(key, queries...)
+	}
+	validation.CompileCEL(key, rules...)
+}
+
+func (c compiler) Release(key string) {
+	if c.jp != nil {
+		c.jp.Release(key)
+	}
+	validation.ReleaseCEL(key)
+}
+
+// Queries returns the JMESPath queries used by a rule, either as variables or in JMESPath fields
+func Queries(rule kyvernov1.Rule) []string {
+	data, err := json.Marshal(rule)
+	if err != nil {
+		return nil
+	}
+	var document interface{}
+	if err := json.Unmarshal(data, &document); err != nil {
+		return nil
+	}
+	var queries []string
+	var walk func(string, interface{})
+	walk = func(field string, value interface{}) {
+		switch typed := value.(type) {
+		case map[string]interface{}:
+			for k, v := range typed {
+				// keys can hold variables too (e.g. in patterns)
+				queries = append(queries, variables(k)...)
+				walk(k, v)
+			}
+		case []interface{}:
+			for _, v := range typed {
+				walk(field, v)
+			}
+		case string:
+			queries = append(queries, variables(typed)...)
+			if _, ok := jmesPathFields[field]; ok && !regex.RegexVariables.MatchString(typed) {
+				if query := strings.TrimSpace(typed); query != "" {
+					queries = append(queries, query)
+				}
+			}
+		}
+	}
+	walk("", document)
+	return queries
+}
+
+// variables returns the queries of the variables in value, the way they are resolved when substituting variables
+func variables(value string) []string {
+	var queries []string
+	for _, match := range regex.RegexVariables.FindAllStringSubmatch(regex.RemoveLiterals(value), -1) {
+		query := strings.TrimSpace(strings.TrimSuffix(strings.TrimPrefix(match[2], "{{"), "}}"))
+		// @ is replaced by the path of the current element at runtime
+		if query == "" || query == "@" {
+			continue
+		}
+		queries = append(queries, query)
+	}
+	return queries
+}
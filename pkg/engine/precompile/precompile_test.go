@@ -0,0 +1,82 @@
+package precompile
+
+import (
+	"encoding/json"
+	"sort"
+	"testing"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/config"
+	"github.com/kyverno/kyverno/pkg/engine/jmespath"
+	"gotest.tools/assert"
+)
+
+var policy = []byte(`{
+  "apiVersion": "kyverno.io/v1",
+  "kind": "ClusterPolicy",
+  "metadata": {
+    "name": "test"
+  },
+  "spec": {
+    "rules": [{
+      "name": "test",
+      "match": {
+        "any": [{
+          "resources": {
+            "kinds": ["ConfigMap"]
+          }
+        }]
+      },
+      "context": [{
+        "name": "size",
+        "variable": {
+          "jmesPath": "length(request.object.data)"
+        }
+      }],
+      "preconditions": {
+        "all": [{
+          "key": "{{ request.operation }}",
+          "operator": "NotEquals",
+          "value": "DELETE"
+        }]
+      },
+      "validate": {
+        "message": "{{ size }} entries, {{@}} is not allowed",
+        "foreach": [{
+          "list": "request.object.metadata.labels | keys(@)",
+          "deny": {}
+        }]
+      }
+    }]
+  }
+}`)
+
+func TestQueries(t *testing.T) {
+	var p kyvernov1.ClusterPolicy
+	assert.NilError(t, json.Unmarshal(policy, &p))
+	queries := Queries(p.Spec.Rules[0])
+	sort.Strings(queries)
+	assert.DeepEqual(t, queries, []string{
+		"length(request.object.data)",
+		"request.object.metadata.labels | keys(@)",
+		"request.operation",
+		"size",
+	})
+}
+
+func TestCompiler(t *testing.T) {
+	var p kyvernov1.ClusterPolicy
+	assert.NilError(t, json.Unmarshal(policy, &p))
+	jp := jmespath.New(config.NewDefaultConfiguration(false))
+	compiler := New(jp)
+	compiler.Compile("test", &p)
+	first, err := jp.Query("length(request.object.data)")
+	assert.NilError(t, err)
+	second, err := jp.Query("length(request.object.data)")
+	assert.NilError(t, err)
+	assert.Equal(t, first, second)
+	compiler.Release("test")
+	third, err := jp.Query("length(request.object.data)")
+	assert.NilError(t, err)
+	assert.Assert(t, first != third)
+}
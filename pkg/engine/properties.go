@@ -0,0 +1,74 @@
+package engine
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	enginecontext "github.com/kyverno/kyverno/pkg/engine/context"
+	"github.com/kyverno/kyverno/pkg/engine/variables"
+)
+
+// withReportFields computes the severity and custom properties of a validate rule and sets them on the rule results.
+// The severity is only set on failed results, properties are set on passed, failed and warned results.
+func withReportFields(
+	logger logr.Logger,
+	jsonContext enginecontext.Interface,
+	rule kyvernov1.Rule,
+	results []engineapi.RuleResponse,
+) []engineapi.RuleResponse {
+	if rule.Validation.Severity == "" && len(rule.Validation.Properties) == 0 {
+		return results
+	}
+	var severity string
+	var properties map[string]string
+	for i := range results {
+		if !results[i].HasStatus(engineapi.RuleStatusPass, engineapi.RuleStatusFail, engineapi.RuleStatusWarn) {
+			continue
+		}
+		if properties == nil && len(rule.Validation.Properties) != 0 {
+			properties = make(map[string]string, len(rule.Validation.Properties))
+			for key, value := range rule.Validation.Properties {
+				value, err := substituteString(logger, jsonContext, value)
+				if err != nil {
+					logger.Error(err, "failed to substitute variables in property", "property", key)
+					continue
+				}
+				properties[key] = value
+			}
+		}
+		if properties != nil {
+			results[i] = *results[i].WithProperties(properties)
+		}
+		if results[i].Status() == engineapi.RuleStatusFail && rule.Validation.Severity != "" {
+			if severity == "" {
+				value, err := substituteString(logger, jsonContext, rule.Validation.Severity)
+				if err != nil {
+					logger.Error(err, "failed to substitute variables in severity")
+					continue
+				}
+				severity = value
+			}
+			results[i] = *results[i].WithSeverity(severity)
+		}
+	}
+	return results
+}
+
+// substituteString substitutes the variables of a value, results of other types than string are JSON encoded
+func substituteString(logger logr.Logger, jsonContext enginecontext.Interface, value string) (string, error) {
+	substituted, err := variables.SubstituteAll(logger, jsonContext, value)
+	if err != nil {
+		return "", fmt.Errorf("failed to substitute variables in %s: %w", value, err)
+	}
+	if s, ok := substituted.(string); ok {
+		return s, nil
+	}
+	data, err := json.Marshal(substituted)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
@@ -0,0 +1,34 @@
+package engine
+
+import (
+	"fmt"
+	"time"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+)
+
+// checkSchedule checks whether the policy and the rule are active at the given time.
+// It returns a skip response when one of them is outside of its schedule, nil when both are active.
+func (e *engine) checkSchedule(
+	now time.Time,
+	ruleType engineapi.RuleType,
+	policy kyvernov1.PolicyInterface,
+	rule kyvernov1.Rule,
+) *engineapi.RuleResponse {
+	location := time.UTC
+	if e.configuration != nil && e.configuration.GetScheduleTimeZone() != nil {
+		location = e.configuration.GetScheduleTimeZone()
+	}
+	if active, err := policy.GetSpec().Schedule.IsActive(now, location); err != nil {
+		return engineapi.RuleError(rule.Name, ruleType, "failed to evaluate policy schedule", err)
+	} else if !active {
+		return engineapi.RuleSkip(rule.Name, ruleType, fmt.Sprintf("policy %s is not active at %s", policy.GetName(), now.In(location).Format(time.RFC3339))).WithSkipReason(engineapi.SkipReasonInactive)
+	}
+	if active, err := rule.Schedule.IsActive(now, location); err != nil {
+		return engineapi.RuleError(rule.Name, ruleType, "failed to evaluate rule schedule", err)
+	} else if !active {
+		return engineapi.RuleSkip(rule.Name, ruleType, fmt.Sprintf("rule %s is not active at %s", rule.Name, now.In(location).Format(time.RFC3339))).WithSkipReason(engineapi.SkipReasonInactive)
+	}
+	return nil
+}
@@ -0,0 +1,96 @@
+package engine
+
+import (
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	matchutils "github.com/kyverno/kyverno/pkg/utils/match"
+	"github.com/kyverno/kyverno/pkg/utils/wildcard"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// hasRuleType returns true if the rule is processed by the handlers of the given rule type
+func hasRuleType(rule kyvernov1.Rule, ruleType engineapi.RuleType) bool {
+	switch ruleType {
+	case engineapi.Mutation:
+		return rule.HasMutate()
+	case engineapi.Validation:
+		return rule.HasValidate() || rule.HasVerifyImageChecks()
+	case engineapi.ImageVerify:
+		return rule.HasVerifyImages()
+	case engineapi.Generation:
+		return rule.HasGenerate()
+	}
+	return false
+}
+
+// notMatchedReason classifies why the rule did not match the resource, the checks are ordered from
+// the cheapest to the most expensive and the rule is only matched again (without its exclude block)
+// when the kinds and namespaces of the match block are satisfied
+func (e *engine) notMatchedReason(
+	rule kyvernov1.Rule,
+	policyContext engineapi.PolicyContext,
+	resource unstructured.Unstructured,
+) engineapi.SkipReason {
+	if policyContext.AdmissionOperation() {
+		request := policyContext.AdmissionInfo()
+		if e.configuration.IsExcluded(request.AdmissionUserInfo.Username, request.AdmissionUserInfo.Groups, request.Roles, request.ClusterRoles) {
+			return engineapi.SkipReasonExcludedByConfiguration
+		}
+	}
+	if resource.Object == nil {
+		resource = policyContext.OldResource()
+	}
+	if namespace := policyContext.Policy().GetNamespace(); namespace != "" && namespace != resource.GetNamespace() {
+		return engineapi.SkipReasonNamespaceMismatch
+	}
+	gvk, subresource := policyContext.ResourceKind()
+	filters, all := matchFilters(rule.MatchResources)
+	kindMatched, namespaceMatched := all, all
+	for _, filter := range filters {
+		kind := len(filter.Kinds) == 0 || matchutils.CheckKind(filter.Kinds, gvk, subresource, true)
+		namespace := len(filter.Namespaces) == 0 || matchesNamespace(filter.Namespaces, resource)
+		if all {
+			kindMatched = kindMatched && kind
+			namespaceMatched = namespaceMatched && kind && namespace
+		} else {
+			kindMatched = kindMatched || kind
+			namespaceMatched = namespaceMatched || (kind && namespace)
+		}
+	}
+	if !kindMatched {
+		return engineapi.SkipReasonKindMismatch
+	}
+	if !namespaceMatched {
+		return engineapi.SkipReasonNamespaceMismatch
+	}
+	withoutExclude := rule
+	withoutExclude.ExcludeResources = kyvernov1.MatchResources{}
+	if err := e.matches(withoutExclude, policyContext, resource); err == nil {
+		return engineapi.SkipReasonExcluded
+	}
+	return engineapi.SkipReasonNotMatched
+}
+
+// matchFilters returns the filters of a match block and whether all of them must be satisfied
+func matchFilters(match kyvernov1.MatchResources) ([]kyvernov1.ResourceFilter, bool) {
+	if len(match.Any) > 0 {
+		return match.Any, false
+	}
+	if len(match.All) > 0 {
+		return match.All, true
+	}
+	return []kyvernov1.ResourceFilter{{UserInfo: match.UserInfo, ResourceDescription: match.ResourceDescription}}, false
+}
+
+func matchesNamespace(namespaces []string, resource unstructured.Unstructured) bool {
+	namespace := resource.GetNamespace()
+	if resource.GetKind() == "Namespace" {
+		namespace = resource.GetName()
+	}
+	for _, pattern := range namespaces {
+		if wildcard.Match(pattern, namespace) {
+			return true
+		}
+	}
+	return false
+}
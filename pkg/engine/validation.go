@@ -26,6 +26,8 @@ func (e *engine) validate(
 	policyContext.JSONContext().Checkpoint()
 	defer policyContext.JSONContext().Restore()
 
+	// rules are evaluated sequentially, they share the json context of the policy and
+	// applyRules: One stops at the first applied rule, callers evaluate policies concurrently instead
 	for _, rule := range autogen.ComputeRules(policy) {
 		startTime := time.Now()
 		logger := internal.LoggerWithRule(logger, rule)
@@ -39,6 +41,7 @@ func (e *engine) validate(
 				hasVerifyManifest := rule.HasVerifyManifests()
 				hasValidatePss := rule.HasValidatePodSecurity()
 				hasValidateCEL := rule.HasValidateCEL()
+				hasValidateAssert := rule.HasValidateAssert()
 				if hasVerifyManifest {
 					return validation.NewValidateManifestHandler(
 						policyContext,
@@ -48,6 +51,8 @@ func (e *engine) validate(
 					return validation.NewValidatePssHandler()
 				} else if hasValidateCEL {
 					return validation.NewValidateCELHandler(e.client)
+				} else if hasValidateAssert {
+					return validation.NewValidateAssertHandler(e.jp)
 				} else {
 					return validation.NewValidateResourceHandler()
 				}
@@ -61,7 +66,7 @@ func (e *engine) validate(
 			}
 			return nil, nil
 		}
-		resource, ruleResp := e.invokeRuleHandler(
+		resource, ruleResp, notMatched := e.invokeRuleHandler(
 			ctx,
 			logger,
 			handlerFactory,
@@ -72,6 +77,9 @@ func (e *engine) validate(
 		)
 		matchedResource = resource
 		resp.Add(engineapi.NewExecutionStats(startTime, time.Now()), ruleResp...)
+		if notMatched != "" {
+			resp.AddNotMatched(rule.Name, engineapi.Validation, notMatched)
+		}
 		if applyRules == kyvernov1.ApplyOne && resp.RulesAppliedCount() > 0 {
 			break
 		}
@@ -3,9 +3,13 @@ package engine
 import (
 	"context"
 	"encoding/json"
+	"fmt"
+	"strconv"
 	"strings"
 	"testing"
+	"time"
 
+	kyvernoapi "github.com/kyverno/kyverno/api/kyverno"
 	kyverno "github.com/kyverno/kyverno/api/kyverno/v1"
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
 	kyvernov1beta1 "github.com/kyverno/kyverno/api/kyverno/v1beta1"
@@ -20,6 +24,7 @@ import (
 	kubeutils "github.com/kyverno/kyverno/pkg/utils/kube"
 	"gotest.tools/assert"
 	admissionv1 "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 )
 
@@ -42,6 +47,8 @@ func testValidate(
 		imageverifycache.DisabledImageVerifyCache(),
 		contextLoader,
 		nil,
+		nil,
+		nil,
 		"",
 	)
 	return e.Validate(
@@ -338,6 +345,171 @@ func TestValidate_Fail_anyPattern(t *testing.T) {
 	}
 }
 
+func TestValidate_Fail_anyPatternMessages(t *testing.T) {
+	rawPolicy := []byte(`
+	{
+		"apiVersion": "kyverno.io/v1",
+		"kind": "ClusterPolicy",
+		"metadata": {
+		   "name": "validate-namespace"
+		},
+		"spec": {
+		   "rules": [
+			  {
+				 "name": "check-default-namespace",
+				 "match": {
+					"resources": {
+					   "kinds": [
+						  "Pod"
+					   ]
+					}
+				 },
+				 "validate": {
+					"message": "A namespace is required",
+					"anyPattern": [
+					   {
+						  "metadata": {
+							 "namespace": "?*"
+						  }
+					   },
+					   {
+						  "metadata": {
+							 "labels": {
+								"team": "?*"
+							 }
+						  }
+					   }
+					],
+					"anyPatternMessages": [
+					   "pod {{request.object.metadata.name}} has no namespace",
+					   "the team label must be set."
+					]
+				 }
+			  }
+		   ]
+		}
+	 }
+	`)
+
+	rawResource := []byte(`
+	{
+		"apiVersion": "v1",
+		"kind": "Pod",
+		"metadata": {
+		   "name": "myapp-pod",
+		   "labels": {
+			  "app": "myapp"
+		   }
+		},
+		"spec": {
+		   "containers": [
+			  {
+				 "name": "nginx",
+				 "image": "nginx"
+			  }
+		   ]
+		}
+	 }
+	`)
+
+	var policy kyvernov1.ClusterPolicy
+	err := json.Unmarshal(rawPolicy, &policy)
+	assert.NilError(t, err)
+
+	resourceUnstructured, err := kubeutils.BytesToUnstructured(rawResource)
+	assert.NilError(t, err)
+	er := testValidate(context.TODO(), registryclient.NewOrDie(), newPolicyContext(t, *resourceUnstructured, kyvernov1.Create, nil).WithPolicy(&policy), cfg, nil)
+	assert.Assert(t, !er.IsSuccessful())
+
+	msgs := []string{"validation error: A namespace is required. rule check-default-namespace[0] failed at path /metadata/namespace/: pod myapp-pod has no namespace. rule check-default-namespace[1] failed at path /metadata/labels/team/: the team label must be set."}
+	assert.Equal(t, len(er.PolicyResponse.Rules), len(msgs))
+	for index, r := range er.PolicyResponse.Rules {
+		assert.Equal(t, r.Message(), msgs[index])
+	}
+}
+
+func TestValidate_SeverityAndProperties(t *testing.T) {
+	rawPolicy := []byte(`
+	{
+		"apiVersion": "kyverno.io/v1",
+		"kind": "ClusterPolicy",
+		"metadata": {
+		   "name": "require-team"
+		},
+		"spec": {
+		   "rules": [
+			  {
+				 "name": "check-team",
+				 "match": {
+					"resources": {
+					   "kinds": [
+						  "Pod"
+					   ]
+					}
+				 },
+				 "validate": {
+					"message": "The team label is required",
+					"severity": "{{ request.object.metadata.labels.env == 'prod' && 'critical' || 'low' }}",
+					"properties": {
+					   "env": "{{ request.object.metadata.labels.env }}",
+					   "category": "ownership"
+					},
+					"pattern": {
+					   "metadata": {
+						  "labels": {
+							 "team": "?*"
+						  }
+					   }
+					}
+				 }
+			  }
+		   ]
+		}
+	 }
+	`)
+	var policy kyvernov1.ClusterPolicy
+	err := json.Unmarshal(rawPolicy, &policy)
+	assert.NilError(t, err)
+
+	tests := []struct {
+		name        string
+		labels      string
+		status      engineapi.RuleStatus
+		severity    string
+		environment string
+	}{{
+		name:        "failed in prod",
+		labels:      `{"env": "prod"}`,
+		status:      engineapi.RuleStatusFail,
+		severity:    "critical",
+		environment: "prod",
+	}, {
+		name:        "failed in dev",
+		labels:      `{"env": "dev"}`,
+		status:      engineapi.RuleStatusFail,
+		severity:    "low",
+		environment: "dev",
+	}, {
+		name:        "passed",
+		labels:      `{"env": "prod", "team": "a"}`,
+		status:      engineapi.RuleStatusPass,
+		environment: "prod",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rawResource := []byte(`{"apiVersion": "v1", "kind": "Pod", "metadata": {"name": "myapp-pod", "labels": ` + tt.labels + `}}`)
+			resourceUnstructured, err := kubeutils.BytesToUnstructured(rawResource)
+			assert.NilError(t, err)
+			er := testValidate(context.TODO(), registryclient.NewOrDie(), newPolicyContext(t, *resourceUnstructured, kyvernov1.Create, nil).WithPolicy(&policy), cfg, nil)
+			assert.Equal(t, len(er.PolicyResponse.Rules), 1)
+			rule := er.PolicyResponse.Rules[0]
+			assert.Equal(t, rule.Status(), tt.status)
+			assert.Equal(t, rule.Severity(), tt.severity)
+			assert.DeepEqual(t, rule.Properties(), map[string]string{"env": tt.environment, "category": "ownership"})
+		})
+	}
+}
+
 func TestValidate_host_network_port(t *testing.T) {
 	rawPolicy := []byte(`
 	{
@@ -2098,6 +2270,67 @@ func Test_BlockLabelRemove(t *testing.T) {
 	}
 }
 
+func Test_denyChangedField(t *testing.T) {
+	testcases := []testCase{
+		{
+			description:   "Blocks updates changing an immutable field",
+			policy:        []byte(`{"apiVersion":"kyverno.io/v1","kind":"ClusterPolicy","metadata":{"name":"immutable-selector"},"spec":{"validationFailureAction":"Enforce","background":false,"rules":[{"name":"immutable-selector","match":{"any":[{"resources":{"kinds":["Deployment"]}}]},"validate":{"message":"spec.selector is immutable","deny":{"conditions":{"any":[{"key":"{{ changed(request, 'spec.selector') }}","operator":"Equals","value":true}]}}}}]}}`),
+			request:       []byte(`{"uid":"5c4a0a8c-1c1e-4c38-a1b6-9d1ad2a4a1c1","kind":{"group":"apps","version":"v1","kind":"Deployment"},"resource":{"group":"apps","version":"v1","resource":"deployments"},"name":"nginx","namespace":"default","operation":"UPDATE","userInfo":{"username":"kubernetes-admin"},"object":{"apiVersion":"apps/v1","kind":"Deployment","metadata":{"name":"nginx","namespace":"default"},"spec":{"replicas":1,"selector":{"matchLabels":{"app":"bar"}}}},"oldObject":{"apiVersion":"apps/v1","kind":"Deployment","metadata":{"name":"nginx","namespace":"default"},"spec":{"replicas":1,"selector":{"matchLabels":{"app":"nginx"}}}}}`),
+			requestDenied: true,
+		},
+		{
+			description:   "Allows updates not changing an immutable field",
+			policy:        []byte(`{"apiVersion":"kyverno.io/v1","kind":"ClusterPolicy","metadata":{"name":"immutable-selector"},"spec":{"validationFailureAction":"Enforce","background":false,"rules":[{"name":"immutable-selector","match":{"any":[{"resources":{"kinds":["Deployment"]}}]},"validate":{"message":"spec.selector is immutable","deny":{"conditions":{"any":[{"key":"{{ changed(request, 'spec.selector') }}","operator":"Equals","value":true}]}}}}]}}`),
+			request:       []byte(`{"uid":"5c4a0a8c-1c1e-4c38-a1b6-9d1ad2a4a1c1","kind":{"group":"apps","version":"v1","kind":"Deployment"},"resource":{"group":"apps","version":"v1","resource":"deployments"},"name":"nginx","namespace":"default","operation":"UPDATE","userInfo":{"username":"kubernetes-admin"},"object":{"apiVersion":"apps/v1","kind":"Deployment","metadata":{"name":"nginx","namespace":"default"},"spec":{"replicas":3,"selector":{"matchLabels":{"app":"nginx"}}}},"oldObject":{"apiVersion":"apps/v1","kind":"Deployment","metadata":{"name":"nginx","namespace":"default"},"spec":{"replicas":1,"selector":{"matchLabels":{"app":"nginx"}}}}}`),
+			requestDenied: false,
+		},
+		{
+			description:   "Allows creating resources",
+			policy:        []byte(`{"apiVersion":"kyverno.io/v1","kind":"ClusterPolicy","metadata":{"name":"immutable-selector"},"spec":{"validationFailureAction":"Enforce","background":false,"rules":[{"name":"immutable-selector","match":{"any":[{"resources":{"kinds":["Deployment"]}}]},"validate":{"message":"spec.selector is immutable","deny":{"conditions":{"any":[{"key":"{{ changed(request, 'spec.selector') }}","operator":"Equals","value":true}]}}}}]}}`),
+			request:       []byte(`{"uid":"5c4a0a8c-1c1e-4c38-a1b6-9d1ad2a4a1c1","kind":{"group":"apps","version":"v1","kind":"Deployment"},"resource":{"group":"apps","version":"v1","resource":"deployments"},"name":"nginx","namespace":"default","operation":"CREATE","userInfo":{"username":"kubernetes-admin"},"object":{"apiVersion":"apps/v1","kind":"Deployment","metadata":{"name":"nginx","namespace":"default"},"spec":{"replicas":1,"selector":{"matchLabels":{"app":"nginx"}}}},"oldObject":null}`),
+			requestDenied: false,
+		},
+	}
+
+	for _, testcase := range testcases {
+		var policy kyvernov1.ClusterPolicy
+		assert.NilError(t, json.Unmarshal(testcase.policy, &policy))
+		var request admissionv1.AdmissionRequest
+		assert.NilError(t, json.Unmarshal(testcase.request, &request))
+		newR, oldR, err := admissionutils.ExtractResources(nil, request)
+		assert.NilError(t, err)
+		pc := newPolicyContext(t, newR, kyverno.AdmissionOperation(request.Operation), nil).
+			WithPolicy(&policy).
+			WithOldResource(oldR)
+		assert.NilError(t, pc.JSONContext().AddOldResource(oldR.Object))
+		resp := testValidate(context.TODO(), registryclient.NewOrDie(), pc, cfg, nil)
+		assert.Equal(t, !resp.IsSuccessful(), testcase.requestDenied, testcase.description)
+	}
+}
+
+func Test_denyEmbeddedConfigSchema(t *testing.T) {
+	testcases := []testCase{
+		{
+			description:   "Blocks config maps with an invalid embedded config",
+			policy:        []byte(`{"apiVersion":"kyverno.io/v1","kind":"ClusterPolicy","metadata":{"name":"validate-app-config"},"spec":{"validationFailureAction":"Enforce","background":false,"rules":[{"name":"validate-config-yaml","match":{"any":[{"resources":{"kinds":["ConfigMap"],"selector":{"matchLabels":{"app.kubernetes.io/config":"true"}}}}]},"context":[{"name":"schema","variable":{"value":{"type":"object","required":["replicas"],"properties":{"replicas":{"type":"integer","minimum":1},"logLevel":{"type":"string","enum":["debug","info","error"]}}}}}],"validate":{"message":"config.yaml is invalid: {{ json_schema_validate(parse_yaml(request.object.data.\"config.yaml\"), schema) | join(', ', @) }}","deny":{"conditions":{"any":[{"key":"{{ length(json_schema_validate(parse_yaml(request.object.data.\"config.yaml\"), schema)) }}","operator":"GreaterThan","value":0}]}}}}]}}`),
+			request:       []byte(`{"uid":"7b0600b7-0258-4ecb-9666-c2839bd19612","kind":{"group":"","version":"v1","kind":"ConfigMap"},"resource":{"group":"","version":"v1","resource":"configmaps"},"name":"app","namespace":"default","operation":"CREATE","userInfo":{"username":"kubernetes-admin"},"object":{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"app","namespace":"default","labels":{"app.kubernetes.io/config":"true"}},"data":{"config.yaml":"replicas: 0\nlogLevel: trace\n"}},"oldObject":null}`),
+			userInfo:      []byte(`{}`),
+			requestDenied: true,
+		},
+		{
+			description:   "Allows config maps with a valid embedded config",
+			policy:        []byte(`{"apiVersion":"kyverno.io/v1","kind":"ClusterPolicy","metadata":{"name":"validate-app-config"},"spec":{"validationFailureAction":"Enforce","background":false,"rules":[{"name":"validate-config-yaml","match":{"any":[{"resources":{"kinds":["ConfigMap"],"selector":{"matchLabels":{"app.kubernetes.io/config":"true"}}}}]},"context":[{"name":"schema","variable":{"value":{"type":"object","required":["replicas"],"properties":{"replicas":{"type":"integer","minimum":1},"logLevel":{"type":"string","enum":["debug","info","error"]}}}}}],"validate":{"message":"config.yaml is invalid: {{ json_schema_validate(parse_yaml(request.object.data.\"config.yaml\"), schema) | join(', ', @) }}","deny":{"conditions":{"any":[{"key":"{{ length(json_schema_validate(parse_yaml(request.object.data.\"config.yaml\"), schema)) }}","operator":"GreaterThan","value":0}]}}}}]}}`),
+			request:       []byte(`{"uid":"7b0600b7-0258-4ecb-9666-c2839bd19612","kind":{"group":"","version":"v1","kind":"ConfigMap"},"resource":{"group":"","version":"v1","resource":"configmaps"},"name":"app","namespace":"default","operation":"CREATE","userInfo":{"username":"kubernetes-admin"},"object":{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"app","namespace":"default","labels":{"app.kubernetes.io/config":"true"}},"data":{"config.yaml":"replicas: 2\nlogLevel: info\n"}},"oldObject":null}`),
+			userInfo:      []byte(`{}`),
+			requestDenied: false,
+		},
+	}
+
+	for _, testcase := range testcases {
+		executeTest(t, testcase)
+	}
+}
+
 func Test_denyFeatureIssue744_BlockDelete(t *testing.T) {
 	testcases := []testCase{
 		{
@@ -3225,3 +3458,590 @@ func Test_ValidatePattern_anyPattern(t *testing.T) {
 		})
 	}
 }
+
+func Test_ValidateAssert(t *testing.T) {
+	rawPolicy := []byte(`{
+		"apiVersion": "kyverno.io/v1",
+		"kind": "ClusterPolicy",
+		"metadata": {"name": "check-containers"},
+		"spec": {
+			"validationFailureAction": "enforce",
+			"rules": [{
+				"name": "check-images",
+				"match": {"resources": {"kinds": ["Pod"]}},
+				"validate": {
+					"message": "Images must be pinned.",
+					"assert": {
+						"all": [{
+							"message": "latest tag is not allowed",
+							"check": {"spec": {"~containers": {"(ends_with(image, ':latest'))": false}}}
+						}],
+						"any": [
+							{"check": {"metadata": {"labels": {"app": "nginx"}}}},
+							{"check": {"metadata": {"(labels.team != null)": true}}}
+						]
+					}
+				}
+			}]
+		}
+	}`)
+	testCases := []struct {
+		description string
+		rawResource []byte
+		failed      bool
+		message     string
+	}{{
+		description: "pass",
+		rawResource: []byte(`{"apiVersion":"v1","kind":"Pod","metadata":{"name":"test","labels":{"team":"a"}},"spec":{"containers":[{"name":"nginx","image":"nginx:1.25"}]}}`),
+	}, {
+		description: "all fails",
+		rawResource: []byte(`{"apiVersion":"v1","kind":"Pod","metadata":{"name":"test","labels":{"app":"nginx"}},"spec":{"containers":[{"name":"nginx","image":"nginx:latest"}]}}`),
+		failed:      true,
+		message:     "validation error: Images must be pinned. rule check-images failed: all[0] latest tag is not allowed (failed at path /spec/containers/0/ends_with(image, ':latest'): expected false, found true)",
+	}, {
+		description: "any fails",
+		rawResource: []byte(`{"apiVersion":"v1","kind":"Pod","metadata":{"name":"test"},"spec":{"containers":[{"name":"nginx","image":"nginx:1.25"}]}}`),
+		failed:      true,
+		message:     `validation error: Images must be pinned. rule check-images failed: any[0] failed at path /metadata/labels: expected an object, found null; any[1] failed at path /metadata/labels.team != null: expected true, found false`,
+	}, {
+		description: "autogen",
+		rawResource: []byte(`{"apiVersion":"apps/v1","kind":"Deployment","metadata":{"name":"test"},"spec":{"template":{"metadata":{"labels":{"app":"nginx"}},"spec":{"containers":[{"name":"nginx","image":"nginx:latest"}]}}}}`),
+		failed:      true,
+		message:     "validation error: Images must be pinned. rule autogen-check-images failed: all[0] latest tag is not allowed (failed at path /spec/template/spec/containers/0/ends_with(image, ':latest'): expected false, found true)",
+	}}
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			var policy kyvernov1.ClusterPolicy
+			assert.NilError(t, json.Unmarshal(rawPolicy, &policy))
+			resourceUnstructured, err := kubeutils.BytesToUnstructured(tc.rawResource)
+			assert.NilError(t, err)
+			er := testValidate(context.TODO(), registryclient.NewOrDie(), newPolicyContext(t, *resourceUnstructured, kyvernov1.Create, nil).WithPolicy(&policy), cfg, nil)
+			if tc.failed {
+				assert.Assert(t, er.IsFailed())
+				assert.Equal(t, er.PolicyResponse.Rules[0].Message(), tc.message)
+			} else {
+				assert.Assert(t, er.IsSuccessful())
+			}
+		})
+	}
+}
+
+func Test_ValidateDenyTrace(t *testing.T) {
+	rawPolicy := []byte(`{
+		"apiVersion": "kyverno.io/v1",
+		"kind": "ClusterPolicy",
+		"metadata": {"name": "deny-replicas"},
+		"spec": {
+			"validationFailureAction": "enforce",
+			"rules": [{
+				"name": "max-replicas",
+				"match": {"resources": {"kinds": ["Deployment"]}},
+				"validate": {
+					"message": "too many replicas",
+					"deny": {
+						"conditions": {
+							"any": [
+								{"key": "{{ request.object.spec.replicas }}", "operator": "GreaterThan", "value": 3}
+							]
+						}
+					}
+				}
+			}]
+		}
+	}`)
+	rawResource := []byte(`{"apiVersion":"apps/v1","kind":"Deployment","metadata":{"name":"test"},"spec":{"replicas":5}}`)
+	for _, tc := range []struct {
+		description string
+		annotations map[string]string
+		message     string
+	}{{
+		description: "without trace",
+		message:     "too many replicas",
+	}, {
+		description: "with trace",
+		annotations: map[string]string{kyvernoapi.AnnotationDenyTrace: "true"},
+		message:     "too many replicas (evaluated conditions: any[0] 5 GreaterThan 3 = true)",
+	}} {
+		t.Run(tc.description, func(t *testing.T) {
+			var policy kyvernov1.ClusterPolicy
+			assert.NilError(t, json.Unmarshal(rawPolicy, &policy))
+			policy.SetAnnotations(tc.annotations)
+			resourceUnstructured, err := kubeutils.BytesToUnstructured(rawResource)
+			assert.NilError(t, err)
+			er := testValidate(context.TODO(), registryclient.NewOrDie(), newPolicyContext(t, *resourceUnstructured, kyvernov1.Create, nil).WithPolicy(&policy), cfg, nil)
+			assert.Assert(t, er.IsFailed())
+			assert.Equal(t, er.PolicyResponse.Rules[0].Message(), tc.message)
+		})
+	}
+}
+
+func Test_ValidateSchedule(t *testing.T) {
+	rawResource := []byte(`{"apiVersion":"v1","kind":"Pod","metadata":{"name":"test"},"spec":{"containers":[{"name":"nginx","image":"nginx:latest"}]}}`)
+	past := time.Now().Add(-time.Hour).UTC().Format(time.RFC3339)
+	future := time.Now().Add(time.Hour).UTC().Format(time.RFC3339)
+	testCases := []struct {
+		description    string
+		policySchedule string
+		ruleSchedule   string
+		status         engineapi.RuleStatus
+		message        string
+	}{{
+		description: "no schedule",
+		status:      engineapi.RuleStatusFail,
+	}, {
+		description:  "rule active",
+		ruleSchedule: `{"cron": "* * * * *", "notBefore": "` + past + `", "notAfter": "` + future + `"}`,
+		status:       engineapi.RuleStatusFail,
+	}, {
+		description:  "rule expired",
+		ruleSchedule: `{"notAfter": "` + past + `"}`,
+		status:       engineapi.RuleStatusSkip,
+		message:      "rule check-tag is not active at",
+	}, {
+		description:    "policy not yet active",
+		policySchedule: `{"notBefore": "` + future + `"}`,
+		ruleSchedule:   `{"cron": "* * * * *"}`,
+		status:         engineapi.RuleStatusSkip,
+		message:        "policy change-freeze is not active at",
+	}}
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			policySchedule, ruleSchedule := "", ""
+			if tc.policySchedule != "" {
+				policySchedule = `"schedule": ` + tc.policySchedule + `,`
+			}
+			if tc.ruleSchedule != "" {
+				ruleSchedule = `"schedule": ` + tc.ruleSchedule + `,`
+			}
+			rawPolicy := []byte(`{
+				"apiVersion": "kyverno.io/v1",
+				"kind": "ClusterPolicy",
+				"metadata": {"name": "change-freeze"},
+				"spec": {
+					` + policySchedule + `
+					"validationFailureAction": "Enforce",
+					"rules": [{
+						"name": "check-tag",
+						` + ruleSchedule + `
+						"match": {"any": [{"resources": {"kinds": ["Pod"]}}]},
+						"validate": {
+							"message": "latest tag is not allowed",
+							"pattern": {"spec": {"containers": [{"image": "!*:latest"}]}}
+						}
+					}]
+				}
+			}`)
+			var policy kyvernov1.ClusterPolicy
+			assert.NilError(t, json.Unmarshal(rawPolicy, &policy))
+			resourceUnstructured, err := kubeutils.BytesToUnstructured(rawResource)
+			assert.NilError(t, err)
+			er := testValidate(context.TODO(), registryclient.NewOrDie(), newPolicyContext(t, *resourceUnstructured, kyvernov1.Create, nil).WithPolicy(&policy), cfg, nil)
+			assert.Equal(t, len(er.PolicyResponse.Rules), 1)
+			assert.Equal(t, er.PolicyResponse.Rules[0].Status(), tc.status)
+			assert.Assert(t, strings.HasPrefix(er.PolicyResponse.Rules[0].Message(), tc.message), er.PolicyResponse.Rules[0].Message())
+		})
+	}
+}
+
+func Test_ValidateImageStrict(t *testing.T) {
+	digest := "sha256:b31bfb4d0213f254d361e0079deaaebefa4f82ba7aa76ef82e90b4935ad5b105"
+	signed := "ghcr.io/kyverno/test-verify-image@" + digest
+	testCases := []struct {
+		description string
+		images      []string
+		verified    []string
+		strict      bool
+		status      engineapi.RuleStatus
+		message     string
+	}{{
+		description: "not strict ignores uncovered images",
+		images:      []string{"nginx:latest", signed},
+		verified:    []string{signed},
+		status:      engineapi.RuleStatusPass,
+	}, {
+		description: "strict with all images covered and verified",
+		images:      []string{signed},
+		verified:    []string{signed},
+		strict:      true,
+		status:      engineapi.RuleStatusPass,
+	}, {
+		description: "strict with uncovered images",
+		images:      []string{"nginx:latest", signed},
+		verified:    []string{signed},
+		strict:      true,
+		status:      engineapi.RuleStatusFail,
+		message:     "images not covered by the imageReferences of rule verify-signature: docker.io/nginx:latest",
+	}, {
+		description: "strict with unverified image",
+		images:      []string{signed},
+		strict:      true,
+		status:      engineapi.RuleStatusFail,
+		message:     "unverified image " + signed,
+	}, {
+		description: "strict with missing digest",
+		images:      []string{"ghcr.io/kyverno/test-verify-image:latest"},
+		strict:      true,
+		status:      engineapi.RuleStatusFail,
+		message:     "missing digest for ghcr.io/kyverno/test-verify-image:latest",
+	}}
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			var containers []interface{}
+			for i, image := range tc.images {
+				containers = append(containers, map[string]interface{}{"name": fmt.Sprintf("container-%d", i), "image": image})
+			}
+			verified := map[string]bool{}
+			for _, image := range tc.verified {
+				verified[image] = true
+			}
+			annotation, err := json.Marshal(verified)
+			assert.NilError(t, err)
+			resource := unstructured.Unstructured{Object: map[string]interface{}{
+				"apiVersion": "v1",
+				"kind":       "Pod",
+				"metadata": map[string]interface{}{
+					"name":        "test",
+					"annotations": map[string]interface{}{kyvernoapi.AnnotationImageVerify: string(annotation)},
+				},
+				"spec": map[string]interface{}{"containers": containers},
+			}}
+			rawPolicy := []byte(`{
+				"apiVersion": "kyverno.io/v1",
+				"kind": "ClusterPolicy",
+				"metadata": {"name": "check-images"},
+				"spec": {
+					"validationFailureAction": "Enforce",
+					"rules": [{
+						"name": "verify-signature",
+						"match": {"any": [{"resources": {"kinds": ["Pod"]}}]},
+						"verifyImages": [{
+							"imageReferences": ["ghcr.io/kyverno/*"],
+							"mutateDigest": false,
+							"verifyDigest": false,
+							"required": true,
+							"strict": ` + strconv.FormatBool(tc.strict) + `
+						}]
+					}]
+				}
+			}`)
+			var policy kyvernov1.ClusterPolicy
+			assert.NilError(t, json.Unmarshal(rawPolicy, &policy))
+			er := testValidate(context.TODO(), registryclient.NewOrDie(), newPolicyContext(t, resource, kyvernov1.Create, nil).WithPolicy(&policy), cfg, nil)
+			assert.Equal(t, len(er.PolicyResponse.Rules), 1)
+			assert.Equal(t, er.PolicyResponse.Rules[0].Status(), tc.status, er.PolicyResponse.Rules[0].Message())
+			if tc.message != "" {
+				assert.Equal(t, er.PolicyResponse.Rules[0].Message(), tc.message)
+			}
+		})
+	}
+}
+
+func TestValidate_GlobalValues(t *testing.T) {
+	rawPolicy := []byte(`
+	{
+		"apiVersion": "kyverno.io/v1",
+		"kind": "ClusterPolicy",
+		"metadata": {
+			"name": "restrict-registries"
+		},
+		"spec": {
+			"rules": [
+				{
+					"name": "approved-registries",
+					"match": {
+						"any": [
+							{
+								"resources": {
+									"kinds": [
+										"Pod"
+									]
+								}
+							}
+						]
+					},
+					"validate": {
+						"message": "images must come from {{ join(', ', global.registries) }}",
+						"deny": {
+							"conditions": {
+								"any": [
+									{
+										"key": "{{ images.containers.*.registry }}",
+										"operator": "AnyNotIn",
+										"value": "{{ global.registries }}"
+									}
+								]
+							}
+						}
+					}
+				}
+			]
+		}
+	}`)
+	rawResource := []byte(`
+	{
+		"apiVersion": "v1",
+		"kind": "Pod",
+		"metadata": {
+			"name": "myapp-pod"
+		},
+		"spec": {
+			"containers": [
+				{
+					"name": "nginx",
+					"image": "docker.io/nginx"
+				}
+			]
+		}
+	}`)
+	var policy kyverno.ClusterPolicy
+	assert.NilError(t, json.Unmarshal(rawPolicy, &policy))
+	resourceUnstructured, err := kubeutils.BytesToUnstructured(rawResource)
+	assert.NilError(t, err)
+	configuration := config.NewDefaultConfiguration(false)
+	configuration.LoadGlobalValues(&corev1.ConfigMap{
+		Data: map[string]string{
+			"registries": "- ghcr.io\n- docker.io",
+		},
+	})
+	er := testValidate(context.TODO(), registryclient.NewOrDie(), newPolicyContext(t, *resourceUnstructured, kyverno.Create, nil).WithPolicy(&policy), configuration, nil)
+	assert.Equal(t, len(er.PolicyResponse.Rules), 1)
+	assert.Equal(t, er.PolicyResponse.Rules[0].Status(), engineapi.RuleStatusPass)
+
+	configuration.LoadGlobalValues(&corev1.ConfigMap{
+		Data: map[string]string{
+			"registries": "- ghcr.io",
+		},
+	})
+	er = testValidate(context.TODO(), registryclient.NewOrDie(), newPolicyContext(t, *resourceUnstructured, kyverno.Create, nil).WithPolicy(&policy), configuration, nil)
+	assert.Equal(t, len(er.PolicyResponse.Rules), 1)
+	assert.Equal(t, er.PolicyResponse.Rules[0].Status(), engineapi.RuleStatusFail)
+	assert.Equal(t, er.PolicyResponse.Rules[0].Message(), "images must come from ghcr.io")
+}
+
+func TestValidate_SkipReasons(t *testing.T) {
+	rawPolicy := []byte(`
+	{
+		"apiVersion": "kyverno.io/v1",
+		"kind": "ClusterPolicy",
+		"metadata": {
+			"name": "skip-reasons"
+		},
+		"spec": {
+			"rules": [
+				{
+					"name": "deployments",
+					"match": {
+						"any": [
+							{
+								"resources": {
+									"kinds": [
+										"Deployment"
+									]
+								}
+							}
+						]
+					},
+					"validate": {
+						"pattern": {
+							"metadata": {
+								"name": "?*"
+							}
+						}
+					}
+				},
+				{
+					"name": "other-namespace",
+					"match": {
+						"any": [
+							{
+								"resources": {
+									"kinds": [
+										"Pod"
+									],
+									"namespaces": [
+										"other"
+									]
+								}
+							}
+						]
+					},
+					"validate": {
+						"pattern": {
+							"metadata": {
+								"name": "?*"
+							}
+						}
+					}
+				},
+				{
+					"name": "excluded",
+					"match": {
+						"any": [
+							{
+								"resources": {
+									"kinds": [
+										"Pod"
+									]
+								}
+							}
+						]
+					},
+					"exclude": {
+						"any": [
+							{
+								"resources": {
+									"names": [
+										"myapp-*"
+									]
+								}
+							}
+						]
+					},
+					"validate": {
+						"pattern": {
+							"metadata": {
+								"name": "?*"
+							}
+						}
+					}
+				},
+				{
+					"name": "preconditions",
+					"match": {
+						"any": [
+							{
+								"resources": {
+									"kinds": [
+										"Pod"
+									]
+								}
+							}
+						]
+					},
+					"preconditions": {
+						"all": [
+							{
+								"key": "{{ request.object.metadata.name }}",
+								"operator": "Equals",
+								"value": "other"
+							}
+						]
+					},
+					"validate": {
+						"pattern": {
+							"metadata": {
+								"name": "?*"
+							}
+						}
+					}
+				},
+				{
+					"name": "applied",
+					"match": {
+						"any": [
+							{
+								"resources": {
+									"kinds": [
+										"Pod"
+									]
+								}
+							}
+						]
+					},
+					"validate": {
+						"pattern": {
+							"metadata": {
+								"name": "?*"
+							}
+						}
+					}
+				}
+			]
+		}
+	}`)
+	rawResource := []byte(`
+	{
+		"apiVersion": "v1",
+		"kind": "Pod",
+		"metadata": {
+			"name": "myapp-pod",
+			"namespace": "default"
+		},
+		"spec": {
+			"containers": [
+				{
+					"name": "nginx",
+					"image": "nginx"
+				}
+			]
+		}
+	}`)
+	var policy kyverno.ClusterPolicy
+	assert.NilError(t, json.Unmarshal(rawPolicy, &policy))
+	resourceUnstructured, err := kubeutils.BytesToUnstructured(rawResource)
+	assert.NilError(t, err)
+	er := testValidate(context.TODO(), registryclient.NewOrDie(), newPolicyContext(t, *resourceUnstructured, kyverno.Create, nil).WithPolicy(&policy), cfg, nil)
+	assert.Equal(t, len(er.PolicyResponse.Rules), 2)
+	assert.Equal(t, er.PolicyResponse.Rules[0].Status(), engineapi.RuleStatusSkip)
+	assert.Equal(t, er.PolicyResponse.Rules[0].SkipReason(), engineapi.SkipReasonPreconditions)
+	assert.Equal(t, er.PolicyResponse.Rules[1].Status(), engineapi.RuleStatusPass)
+	assert.Equal(t, er.PolicyResponse.Rules[1].SkipReason(), engineapi.SkipReason(""))
+	stats := er.PolicyResponse.Stats()
+	assert.DeepEqual(t, stats.SkippedRules(), []engineapi.SkippedRule{
+		{Rule: "deployments", Type: engineapi.Validation, Reason: engineapi.SkipReasonKindMismatch},
+		{Rule: "other-namespace", Type: engineapi.Validation, Reason: engineapi.SkipReasonNamespaceMismatch},
+		{Rule: "excluded", Type: engineapi.Validation, Reason: engineapi.SkipReasonExcluded},
+		{Rule: "preconditions", Type: engineapi.Validation, Reason: engineapi.SkipReasonPreconditions},
+	})
+}
+
+func Test_ValidatePodSecurity_CustomController(t *testing.T) {
+	rawPolicy := []byte(`{
+		"apiVersion": "kyverno.io/v1",
+		"kind": "ClusterPolicy",
+		"metadata": {
+			"name": "psa",
+			"annotations": {
+				"pod-policies.kyverno.io/autogen-custom-controllers": "argoproj.io/v1alpha1/Rollout=spec.template"
+			}
+		},
+		"spec": {
+			"rules": [{
+				"name": "baseline",
+				"match": {"resources": {"kinds": ["Pod"]}},
+				"validate": {"podSecurity": {"level": "baseline", "version": "latest"}}
+			}]
+		}
+	}`)
+	tests := []struct {
+		name   string
+		spec   string
+		status engineapi.RuleStatus
+	}{{
+		name:   "pass",
+		spec:   `{"containers": [{"name": "nginx", "image": "nginx"}]}`,
+		status: engineapi.RuleStatusPass,
+	}, {
+		name:   "fail",
+		spec:   `{"hostNetwork": true, "containers": [{"name": "nginx", "image": "nginx"}]}`,
+		status: engineapi.RuleStatusFail,
+	}}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			rawResource := []byte(`{
+				"apiVersion": "argoproj.io/v1alpha1",
+				"kind": "Rollout",
+				"metadata": {"name": "test", "namespace": "default"},
+				"spec": {"template": {"metadata": {"labels": {"app": "test"}}, "spec": ` + test.spec + `}}
+			}`)
+			var policy kyvernov1.ClusterPolicy
+			assert.NilError(t, json.Unmarshal(rawPolicy, &policy))
+			resourceUnstructured, err := kubeutils.BytesToUnstructured(rawResource)
+			assert.NilError(t, err)
+			er := testValidate(context.TODO(), registryclient.NewOrDie(), newPolicyContext(t, *resourceUnstructured, kyvernov1.Create, nil).WithPolicy(&policy), cfg, nil)
+			assert.Equal(t, len(er.PolicyResponse.Rules), 1)
+			assert.Equal(t, er.PolicyResponse.Rules[0].Name(), "autogen-rollout-baseline")
+			assert.Equal(t, er.PolicyResponse.Rules[0].Status(), test.status)
+		})
+	}
+}
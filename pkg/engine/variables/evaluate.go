@@ -1,6 +1,7 @@
 package variables
 
 import (
+	"encoding/json"
 	"fmt"
 
 	"github.com/go-logr/logr"
@@ -10,8 +11,64 @@ import (
 	stringutils "github.com/kyverno/kyverno/pkg/utils/strings"
 )
 
+// ConditionTrace records the evaluation of a condition with its substituted key and value
+type ConditionTrace struct {
+	// Block is the conditions block (any or all), it is empty for conditions declared without a block
+	Block string
+	// Index is the index of the condition in its block
+	Index    int
+	Key      interface{}
+	Operator kyvernov1.ConditionOperator
+	Value    interface{}
+	Result   bool
+}
+
+func (t ConditionTrace) String() string {
+	name := fmt.Sprintf("[%d]", t.Index)
+	if t.Block != "" {
+		name = t.Block + name
+	}
+	return fmt.Sprintf("%s %s %s %s = %t", name, formatTraceValue(t.Key), t.Operator, formatTraceValue(t.Value), t.Result)
+}
+
+func formatTraceValue(value interface{}) string {
+	if data, err := json.Marshal(value); err == nil {
+		return string(data)
+	}
+	return fmt.Sprint(value)
+}
+
+type tracer struct {
+	block  string
+	traces []ConditionTrace
+}
+
+func (t *tracer) record(index int, condition kyvernov1.Condition, key, value interface{}, result bool) {
+	if t != nil {
+		t.traces = append(t.traces, ConditionTrace{
+			Block:    t.block,
+			Index:    index,
+			Key:      key,
+			Operator: condition.Operator,
+			Value:    value,
+			Result:   result,
+		})
+	}
+}
+
+func (t *tracer) inBlock(block string) *tracer {
+	if t != nil {
+		t.block = block
+	}
+	return t
+}
+
 // Evaluate evaluates the condition
 func Evaluate(logger logr.Logger, ctx context.EvalInterface, condition kyvernov1.Condition) (bool, string, error) {
+	return evaluate(logger, ctx, condition, 0, nil)
+}
+
+func evaluate(logger logr.Logger, ctx context.EvalInterface, condition kyvernov1.Condition, index int, t *tracer) (bool, string, error) {
 	key, err := SubstituteAllInPreconditions(logger, ctx, condition.GetKey())
 	if err != nil {
 		return false, "", fmt.Errorf("failed to substitute variables in condition key: %w", err)
@@ -24,16 +81,30 @@ func Evaluate(logger logr.Logger, ctx context.EvalInterface, condition kyvernov1
 	if handler == nil {
 		return false, "", fmt.Errorf("failed to create handler for condition operator: %w", err)
 	}
-	return handler.Evaluate(key, value), condition.Message, nil
+	result := handler.Evaluate(key, value)
+	t.record(index, condition, key, value, result)
+	return result, condition.Message, nil
 }
 
 // EvaluateConditions evaluates all the conditions present in a slice, in a backwards compatible way
 func EvaluateConditions(log logr.Logger, ctx context.EvalInterface, conditions interface{}) (bool, string, error) {
+	return evaluateConditions(log, ctx, conditions, nil)
+}
+
+// EvaluateConditionsWithTrace evaluates conditions like EvaluateConditions and also returns the trace of the evaluated conditions,
+// conditions that were not evaluated because of short-circuiting don't appear in the trace
+func EvaluateConditionsWithTrace(log logr.Logger, ctx context.EvalInterface, conditions interface{}) (bool, string, []ConditionTrace, error) {
+	var t tracer
+	result, msg, err := evaluateConditions(log, ctx, conditions, &t)
+	return result, msg, t.traces, err
+}
+
+func evaluateConditions(log logr.Logger, ctx context.EvalInterface, conditions interface{}, t *tracer) (bool, string, error) {
 	switch typedConditions := conditions.(type) {
 	case kyvernov1.AnyAllConditions:
-		return evaluateAnyAllConditions(log, ctx, typedConditions)
+		return evaluateAnyAllConditions(log, ctx, typedConditions, t)
 	case []kyvernov1.Condition: // backwards compatibility
-		return evaluateOldConditions(log, ctx, typedConditions)
+		return evaluateOldConditions(log, ctx, typedConditions, t)
 	}
 	return false, "", fmt.Errorf("invalid condition")
 }
@@ -41,7 +112,7 @@ func EvaluateConditions(log logr.Logger, ctx context.EvalInterface, conditions i
 func EvaluateAnyAllConditions(log logr.Logger, ctx context.EvalInterface, conditions []kyvernov1.AnyAllConditions) (bool, string, error) {
 	var conditionTrueMessages []string
 	for _, c := range conditions {
-		if val, msg, err := evaluateAnyAllConditions(log, ctx, c); err != nil {
+		if val, msg, err := evaluateAnyAllConditions(log, ctx, c, nil); err != nil {
 			return false, "", err
 		} else if !val {
 			return false, msg, nil
@@ -54,7 +125,7 @@ func EvaluateAnyAllConditions(log logr.Logger, ctx context.EvalInterface, condit
 }
 
 // evaluateAnyAllConditions evaluates multiple conditions as a logical AND (all) or OR (any) operation depending on the conditions
-func evaluateAnyAllConditions(log logr.Logger, ctx context.EvalInterface, conditions kyvernov1.AnyAllConditions) (bool, string, error) {
+func evaluateAnyAllConditions(log logr.Logger, ctx context.EvalInterface, conditions kyvernov1.AnyAllConditions, t *tracer) (bool, string, error) {
 	anyConditions, allConditions := conditions.AnyConditions, conditions.AllConditions
 	anyConditionsResult, allConditionsResult := true, true
 	var conditionFalseMessages []string
@@ -63,8 +134,8 @@ func evaluateAnyAllConditions(log logr.Logger, ctx context.EvalInterface, condit
 	// update the anyConditionsResult if they are present
 	if anyConditions != nil {
 		anyConditionsResult = false
-		for _, condition := range anyConditions {
-			if val, msg, err := Evaluate(log, ctx, condition); err != nil {
+		for i, condition := range anyConditions {
+			if val, msg, err := evaluate(log, ctx, condition, i, t.inBlock("any")); err != nil {
 				return false, "", err
 			} else if val {
 				anyConditionsResult = true
@@ -81,8 +152,8 @@ func evaluateAnyAllConditions(log logr.Logger, ctx context.EvalInterface, condit
 	}
 
 	// update the allConditionsResult if they are present
-	for _, condition := range allConditions {
-		if val, msg, err := Evaluate(log, ctx, condition); err != nil {
+	for i, condition := range allConditions {
+		if val, msg, err := evaluate(log, ctx, condition, i, t.inBlock("all")); err != nil {
 			return false, "", err
 		} else if !val {
 			allConditionsResult = false
@@ -103,10 +174,10 @@ func evaluateAnyAllConditions(log logr.Logger, ctx context.EvalInterface, condit
 }
 
 // evaluateOldConditions evaluates multiple conditions when those conditions are provided in the old manner i.e. without 'any' or 'all'
-func evaluateOldConditions(log logr.Logger, ctx context.EvalInterface, conditions []kyvernov1.Condition) (bool, string, error) {
+func evaluateOldConditions(log logr.Logger, ctx context.EvalInterface, conditions []kyvernov1.Condition, t *tracer) (bool, string, error) {
 	var conditionTrueMessages []string
-	for _, condition := range conditions {
-		if val, msg, err := Evaluate(log, ctx, condition); err != nil {
+	for i, condition := range conditions {
+		if val, msg, err := evaluate(log, ctx, condition, i, t); err != nil {
 			return false, "", err
 		} else if !val {
 			return false, msg, nil
@@ -516,3 +516,48 @@ func Test_Condition_Messages(t *testing.T) {
 	assert.Equal(t, false, val)
 	assert.Contains(t, msg, "invalid name; invalid foo; invalid foo2")
 }
+
+func Test_EvaluateConditionsWithTrace(t *testing.T) {
+	resourceRaw := []byte(`{"metadata": {"name": "temp"}, "spec": {"replicas": 5}}`)
+	ctx := context.NewContext(jmespath.New(config.NewDefaultConfiguration(false)))
+	assert.Nil(t, context.AddResource(ctx, resourceRaw))
+	conditions := kyverno.AnyAllConditions{
+		AnyConditions: []kyverno.Condition{
+			{
+				RawKey:   kyverno.ToJSON("{{request.object.metadata.name}}"),
+				Operator: kyverno.ConditionOperators["Equal"],
+				RawValue: kyverno.ToJSON("other"),
+			},
+			{
+				RawKey:   kyverno.ToJSON("{{request.object.spec.replicas}}"),
+				Operator: kyverno.ConditionOperators["GreaterThan"],
+				RawValue: kyverno.ToJSON(3),
+			},
+			{
+				RawKey:   kyverno.ToJSON("{{request.object.spec.replicas}}"),
+				Operator: kyverno.ConditionOperators["Equals"],
+				RawValue: kyverno.ToJSON(1),
+			},
+		},
+		AllConditions: []kyverno.Condition{
+			{
+				RawKey:   kyverno.ToJSON("{{request.object.metadata.name}}"),
+				Operator: kyverno.ConditionOperators["NotEquals"],
+				RawValue: kyverno.ToJSON("temp"),
+			},
+		},
+	}
+	val, _, trace, err := EvaluateConditionsWithTrace(logr.Discard(), ctx, conditions)
+	assert.Nil(t, err)
+	assert.Equal(t, false, val)
+	var got []string
+	for _, t := range trace {
+		got = append(got, t.String())
+	}
+	// the any block short-circuits after the first condition that passes
+	assert.Equal(t, []string{
+		`any[0] "temp" Equal "other" = false`,
+		`any[1] 5 GreaterThan 3 = true`,
+		`all[0] "temp" NotEquals "temp" = false`,
+	}, got)
+}
@@ -17,6 +17,11 @@ func IsReference(value string) bool {
 	return len(groups) != 0
 }
 
+// RemoveLiterals returns the value without its literal blocks, it is used to look for variables outside of literal blocks
+func RemoveLiterals(value string) string {
+	return RegexLiterals.ReplaceAllString(value, "")
+}
+
 func ObjectHasVariables(object interface{}) error {
 	var err error
 	objectJSON, err := json.Marshal(object)
@@ -18,4 +18,7 @@ var (
 	RegexElementIndex = regexp.MustCompile(`{{\s*elementIndex\d*\s*}}`)
 
 	RegexVariableKey = regexp.MustCompile(`\{{(.*?)\}}`)
+
+	// RegexLiterals is the Regex for '{{#literal}}...{{/literal}}' blocks, their content is never substituted
+	RegexLiterals = regexp.MustCompile(`(?s)\{\{#literal\}\}(.*?)\{\{/literal\}\}`)
 )
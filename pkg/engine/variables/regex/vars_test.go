@@ -20,3 +20,9 @@ func Test_IsVariable(t *testing.T) {
 	assert.Equal(t, IsVariable("{{ foo {{foo2}} }}"), true)
 	assert.Equal(t, IsVariable("\\{{ foo }}"), false)
 }
+
+func Test_RemoveLiterals(t *testing.T) {
+	assert.Equal(t, RemoveLiterals("{{ foo }} {{#literal}}{{ .Values.name }}{{/literal}}"), "{{ foo }} ")
+	assert.Equal(t, RemoveLiterals("a{{#literal}}{{ x }}\n{{ y }}{{/literal}}b{{#literal}}{{ z }}{{/literal}}"), "ab")
+	assert.Equal(t, RemoveLiterals("{{#literal}}{{ x }}"), "{{#literal}}{{ x }}")
+}
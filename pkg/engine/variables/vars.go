@@ -229,7 +229,7 @@ func validateElementInForEach(log logr.Logger) jsonUtils.Action {
 		if !ok {
 			return data.Element, nil
 		}
-		vars := regex.RegexVariables.FindAllString(value, -1)
+		vars := regex.RegexVariables.FindAllString(regex.RemoveLiterals(value), -1)
 		for _, v := range vars {
 			initial := len(regex.RegexVariableInit.FindAllString(v, -1)) > 0
 
@@ -330,77 +330,120 @@ func substituteVariablesIfAny(log logr.Logger, ctx context.EvalInterface, vr Var
 		if !ok {
 			return data.Element, nil
 		}
+		if regex.RegexLiterals.MatchString(value) {
+			return substituteOutsideLiterals(log, ctx, vr, isDeleteRequest, data.Path, value)
+		}
+		return substituteString(log, ctx, vr, isDeleteRequest, data.Path, value)
+	})
+}
 
-		vars := regex.RegexVariables.FindAllString(value, -1)
-		for len(vars) > 0 {
-			originalPattern := value
-			for _, v := range vars {
-				initial := len(regex.RegexVariableInit.FindAllString(v, -1)) > 0
-				old := v
-
-				if !initial {
-					v = v[1:]
-				}
+// substituteOutsideLiterals substitutes variables in the parts of the value that are not in a literal block,
+// the content of literal blocks is kept verbatim and the literal markers are removed
+func substituteOutsideLiterals(log logr.Logger, ctx context.EvalInterface, vr VariableResolver, isDeleteRequest bool, dataPath string, value string) (interface{}, error) {
+	var builder strings.Builder
+	write := func(segment string) error {
+		if segment == "" {
+			return nil
+		}
+		substituted, err := substituteString(log, ctx, vr, isDeleteRequest, dataPath, segment)
+		if err != nil {
+			return err
+		}
+		if s, ok := substituted.(string); ok {
+			builder.WriteString(s)
+			return nil
+		}
+		data, err := json.Marshal(substituted)
+		if err != nil {
+			return fmt.Errorf("failed to resolve variables at path %s: %w", dataPath, err)
+		}
+		builder.WriteString(string(data))
+		return nil
+	}
+	last := 0
+	for _, match := range regex.RegexLiterals.FindAllStringSubmatchIndex(value, -1) {
+		if err := write(value[last:match[0]]); err != nil {
+			return nil, err
+		}
+		builder.WriteString(value[match[2]:match[3]])
+		last = match[1]
+	}
+	if err := write(value[last:]); err != nil {
+		return nil, err
+	}
+	return builder.String(), nil
+}
 
-				variable := replaceBracesAndTrimSpaces(v)
+func substituteString(log logr.Logger, ctx context.EvalInterface, vr VariableResolver, isDeleteRequest bool, dataPath string, value string) (interface{}, error) {
+	vars := regex.RegexVariables.FindAllString(value, -1)
+	for len(vars) > 0 {
+		originalPattern := value
+		for _, v := range vars {
+			initial := len(regex.RegexVariableInit.FindAllString(v, -1)) > 0
+			old := v
 
-				if variable == "@" {
-					pathPrefix := "target"
-					if _, err := ctx.Query("target"); err != nil {
-						pathPrefix = "request.object"
-					}
+			if !initial {
+				v = v[1:]
+			}
 
-					// Convert path to JMESPath for current identifier.
-					// Skip 2 elements (e.g. mutate.overlay | validate.pattern) plus "foreach" if it is part of the pointer.
-					// Prefix the pointer with pathPrefix.
-					val := jsonpointer.ParsePath(data.Path).SkipPast("foreach").SkipN(2).Prepend(strings.Split(pathPrefix, ".")...).JMESPath()
+			variable := replaceBracesAndTrimSpaces(v)
 
-					variable = strings.Replace(variable, "@", val, -1)
+			if variable == "@" {
+				pathPrefix := "target"
+				if _, err := ctx.Query("target"); err != nil {
+					pathPrefix = "request.object"
 				}
 
-				if isDeleteRequest {
-					variable = strings.ReplaceAll(variable, "request.object", "request.oldObject")
-				}
+				// Convert path to JMESPath for current identifier.
+				// Skip 2 elements (e.g. mutate.overlay | validate.pattern) plus "foreach" if it is part of the pointer.
+				// Prefix the pointer with pathPrefix.
+				val := jsonpointer.ParsePath(dataPath).SkipPast("foreach").SkipN(2).Prepend(strings.Split(pathPrefix, ".")...).JMESPath()
 
-				substitutedVar, err := vr(ctx, variable)
-				if err != nil {
-					switch err.(type) {
-					case context.InvalidVariableError, gojmespath.NotFoundError:
-						return nil, err
-					default:
-						return nil, fmt.Errorf("failed to resolve %v at path %s: %v", variable, data.Path, err)
-					}
-				}
+				variable = strings.Replace(variable, "@", val, -1)
+			}
 
-				log.V(3).Info("variable substituted", "variable", v, "value", substitutedVar, "path", data.Path)
+			if isDeleteRequest {
+				variable = strings.ReplaceAll(variable, "request.object", "request.oldObject")
+			}
 
-				if originalPattern == v {
-					return substitutedVar, nil
+			substitutedVar, err := vr(ctx, variable)
+			if err != nil {
+				switch err.(type) {
+				case context.InvalidVariableError, gojmespath.NotFoundError:
+					return nil, err
+				default:
+					return nil, fmt.Errorf("failed to resolve %v at path %s: %v", variable, dataPath, err)
 				}
+			}
 
-				prefix := ""
+			log.V(3).Info("variable substituted", "variable", v, "value", substitutedVar, "path", dataPath)
 
-				if !initial {
-					prefix = string(old[0])
-				}
+			if originalPattern == v {
+				return substitutedVar, nil
+			}
 
-				if value, err = substituteVarInPattern(prefix, originalPattern, v, substitutedVar); err != nil {
-					return nil, fmt.Errorf("failed to resolve %v at path %s: %s", variable, data.Path, err.Error())
-				}
+			prefix := ""
 
-				continue
+			if !initial {
+				prefix = string(old[0])
 			}
 
-			// check for nested variables in strings
-			vars = regex.RegexVariables.FindAllString(value, -1)
-		}
+			if value, err = substituteVarInPattern(prefix, originalPattern, v, substitutedVar); err != nil {
+				return nil, fmt.Errorf("failed to resolve %v at path %s: %s", variable, dataPath, err.Error())
+			}
 
-		for _, v := range regex.RegexEscpVariables.FindAllString(value, -1) {
-			value = strings.Replace(value, v, v[1:], -1)
+			continue
 		}
 
-		return value, nil
-	})
+		// check for nested variables in strings
+		vars = regex.RegexVariables.FindAllString(value, -1)
+	}
+
+	for _, v := range regex.RegexEscpVariables.FindAllString(value, -1) {
+		value = strings.Replace(value, v, v[1:], -1)
+	}
+
+	return value, nil
 }
 
 func isDeleteRequest(ctx context.EvalInterface) bool {
@@ -1199,3 +1199,40 @@ func Test_ReplaceAllVars(t *testing.T) {
 	result = ReplaceAllVars("{{ foo {{foo}} }}", func(s string) string { return "test" })
 	assert.Equal(t, result, "{{ foo test }}")
 }
+
+func Test_SubstituteLiterals(t *testing.T) {
+	resourceRaw := []byte(`{"metadata": {"name": "temp", "labels": {"replicas": 3}}}`)
+	ctx := context.NewContext(jp)
+	err := context.AddResource(ctx, resourceRaw)
+	assert.NilError(t, err)
+
+	testCases := []struct {
+		pattern  interface{}
+		expected interface{}
+	}{
+		{
+			pattern:  "{{#literal}}{{ .Values.name }}{{/literal}}",
+			expected: "{{ .Values.name }}",
+		},
+		{
+			pattern:  "name: {{ request.object.metadata.name }}, template: {{#literal}}{{ .Release.Name }}-{{ include \"chart\" . }}{{/literal}}",
+			expected: "name: temp, template: {{ .Release.Name }}-{{ include \"chart\" . }}",
+		},
+		{
+			pattern:  "expr: {{#literal}}sum(rate(http_requests_total{job=\"{{ $labels.job }}\"}[5m])){{/literal}}\nreplicas: {{ request.object.metadata.labels.replicas }}",
+			expected: "expr: sum(rate(http_requests_total{job=\"{{ $labels.job }}\"}[5m]))\nreplicas: 3",
+		},
+		{
+			pattern:  "{{ request.object.metadata.labels }}{{#literal}}{{ x }}{{/literal}}",
+			expected: `{"replicas":3}{{ x }}`,
+		},
+	}
+	for _, tc := range testCases {
+		actual, err := SubstituteAll(logr.Discard(), ctx, tc.pattern)
+		assert.NilError(t, err)
+		assert.DeepEqual(t, tc.expected, actual)
+	}
+
+	_, err = SubstituteAll(logr.Discard(), ctx, "{{ request.object.metadata.missing }} {{#literal}}{{ x }}{{/literal}}")
+	assert.ErrorContains(t, err, "request.object.metadata.missing")
+}
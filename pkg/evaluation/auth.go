@@ -0,0 +1,66 @@
+package evaluation
+
+import (
+	"context"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const authorizationHeader = "authorization"
+
+// TokenReviewer authenticates bearer tokens, it is implemented by the TokenReviews client of the Kubernetes API
+type TokenReviewer interface {
+	Create(context.Context, *authenticationv1.TokenReview, metav1.CreateOptions) (*authenticationv1.TokenReview, error)
+}
+
+type userKey struct{}
+
+func withUser(ctx context.Context, user authenticationv1.UserInfo) context.Context {
+	return context.WithValue(ctx, userKey{}, user)
+}
+
+func userFromContext(ctx context.Context) (authenticationv1.UserInfo, bool) {
+	user, ok := ctx.Value(userKey{}).(authenticationv1.UserInfo)
+	return user, ok
+}
+
+// authenticate returns an interceptor authenticating the bearer token of the calls with a TokenReview,
+// the authenticated user is passed to the handler in the call context
+func authenticate(reviewer TokenReviewer) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		token := bearerToken(ctx)
+		if token == "" {
+			return nil, status.Error(codes.Unauthenticated, "missing bearer token")
+		}
+		review, err := reviewer.Create(ctx, &authenticationv1.TokenReview{
+			Spec: authenticationv1.TokenReviewSpec{Token: token},
+		}, metav1.CreateOptions{})
+		if err != nil {
+			logger.Error(err, "failed to review token")
+			return nil, status.Error(codes.Unavailable, "failed to authenticate the request")
+		}
+		if !review.Status.Authenticated {
+			return nil, status.Error(codes.Unauthenticated, "invalid bearer token")
+		}
+		return handler(withUser(ctx, review.Status.User), req)
+	}
+}
+
+func bearerToken(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+	for _, value := range md.Get(authorizationHeader) {
+		if len(value) > len("bearer ") && strings.EqualFold(value[:len("bearer ")], "bearer ") {
+			return strings.TrimSpace(value[len("bearer "):])
+		}
+	}
+	return ""
+}
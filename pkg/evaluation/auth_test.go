@@ -0,0 +1,89 @@
+package evaluation
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"gotest.tools/assert"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type fakeReviewer struct {
+	tokens map[string]authenticationv1.UserInfo
+	err    error
+}
+
+func (r fakeReviewer) Create(_ context.Context, review *authenticationv1.TokenReview, _ metav1.CreateOptions) (*authenticationv1.TokenReview, error) {
+	if r.err != nil {
+		return nil, r.err
+	}
+	if user, ok := r.tokens[review.Spec.Token]; ok {
+		review.Status = authenticationv1.TokenReviewStatus{Authenticated: true, User: user}
+	}
+	return review, nil
+}
+
+func Test_authenticate(t *testing.T) {
+	alice := authenticationv1.UserInfo{Username: "alice", Groups: []string{"dev"}}
+	reviewer := fakeReviewer{tokens: map[string]authenticationv1.UserInfo{"valid": alice}}
+	tests := []struct {
+		name     string
+		reviewer TokenReviewer
+		md       metadata.MD
+		code     codes.Code
+		want     string
+	}{{
+		name:     "valid token",
+		reviewer: reviewer,
+		md:       metadata.Pairs("authorization", "Bearer valid"),
+		code:     codes.OK,
+		want:     "alice",
+	}, {
+		name:     "lower case scheme",
+		reviewer: reviewer,
+		md:       metadata.Pairs("authorization", "bearer valid"),
+		code:     codes.OK,
+		want:     "alice",
+	}, {
+		name:     "missing token",
+		reviewer: reviewer,
+		md:       metadata.MD{},
+		code:     codes.Unauthenticated,
+	}, {
+		name:     "not a bearer token",
+		reviewer: reviewer,
+		md:       metadata.Pairs("authorization", "Basic valid"),
+		code:     codes.Unauthenticated,
+	}, {
+		name:     "invalid token",
+		reviewer: reviewer,
+		md:       metadata.Pairs("authorization", "Bearer invalid"),
+		code:     codes.Unauthenticated,
+	}, {
+		name:     "review error",
+		reviewer: fakeReviewer{err: errors.New("unavailable")},
+		md:       metadata.Pairs("authorization", "Bearer valid"),
+		code:     codes.Unavailable,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got string
+			handler := func(ctx context.Context, _ interface{}) (interface{}, error) {
+				user, ok := userFromContext(ctx)
+				assert.Assert(t, ok)
+				got = user.Username
+				return nil, nil
+			}
+			ctx := metadata.NewIncomingContext(context.TODO(), tt.md)
+			_, err := authenticate(tt.reviewer)(ctx, nil, &grpc.UnaryServerInfo{}, handler)
+			assert.Equal(t, status.Code(err), tt.code)
+			assert.Equal(t, got, tt.want)
+		})
+	}
+}
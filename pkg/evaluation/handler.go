@@ -0,0 +1,227 @@
+package evaluation
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/config"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	"github.com/kyverno/kyverno/pkg/engine/jmespath"
+	"github.com/kyverno/kyverno/pkg/policycache"
+	"github.com/kyverno/kyverno/pkg/userinfo"
+	engineutils "github.com/kyverno/kyverno/pkg/utils/engine"
+	webhookutils "github.com/kyverno/kyverno/pkg/webhooks/utils"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/uuid"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+)
+
+// GVRResolver maps a kind to its resource
+type GVRResolver interface {
+	GetGVRFromGVK(schema.GroupVersionKind) (schema.GroupVersionResource, error)
+}
+
+type handler struct {
+	UnimplementedEvaluationServer
+	engine        engineapi.Engine
+	configuration config.Configuration
+	pcBuilder     webhookutils.PolicyContextBuilder
+	pCache        policycache.Cache
+	gvrResolver   GVRResolver
+	nsLister      corev1listers.NamespaceLister
+	rbLister      userinfo.RoleBindingLister
+	crbLister     userinfo.ClusterRoleBindingLister
+}
+
+// NewHandler creates an EvaluationServer evaluating requests against the policies of the policy cache,
+// requests go through the same mutate and validate steps as admission requests but have no side effect
+// (no events, reports or update requests).
+func NewHandler(
+	engine engineapi.Engine,
+	configuration config.Configuration,
+	jp jmespath.Interface,
+	pCache policycache.Cache,
+	gvrResolver GVRResolver,
+	nsLister corev1listers.NamespaceLister,
+	saLister corev1listers.ServiceAccountLister,
+	rbLister userinfo.RoleBindingLister,
+	crbLister userinfo.ClusterRoleBindingLister,
+) EvaluationServer {
+	return &handler{
+		engine:        engine,
+		configuration: configuration,
+		pcBuilder:     webhookutils.NewPolicyContextBuilder(configuration, jp, saLister),
+		pCache:        pCache,
+		gvrResolver:   gvrResolver,
+		nsLister:      nsLister,
+		rbLister:      rbLister,
+		crbLister:     crbLister,
+	}
+}
+
+func (h *handler) Evaluate(ctx context.Context, in *EvaluateRequest) (*EvaluateResponse, error) {
+	request, err := h.admissionRequest(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+	logger := logger.WithValues("kind", request.Kind.Kind, "namespace", request.Namespace, "name", request.Name, "operation", request.Operation)
+	roles, clusterRoles, err := userinfo.GetRoleRef(h.rbLister, h.crbLister, request.UserInfo)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to fetch roles of the requester: %v", err)
+	}
+	gvk := schema.GroupVersionKind(request.Kind)
+	policyContext, err := h.pcBuilder.Build(*request, roles, clusterRoles, gvk)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to create policy context: %v", err)
+	}
+	namespaceLabels := map[string]string{}
+	namespaceAnnotations := map[string]string{}
+	if request.Kind.Kind != "Namespace" && request.Namespace != "" {
+		namespaceLabels = engineutils.GetNamespaceSelectorsFromNamespaceLister(request.Kind.Kind, request.Namespace, h.nsLister, logger)
+		namespaceAnnotations = engineutils.GetNamespaceAnnotationsFromNamespaceLister(request.Kind.Kind, request.Namespace, h.nsLister, logger)
+	}
+	snapshot := h.pCache.Snapshot()
+	policyContext = policyContext.
+		WithNamespaceLabels(namespaceLabels).
+		WithNamespaceAnnotations(namespaceAnnotations).
+		WithPolicyCacheRevision(snapshot.Revision())
+	gvr := schema.GroupVersionResource(request.Resource)
+	operation := kyvernov1.AdmissionOperation(request.Operation)
+	var mutateResponses, validateResponses []engineapi.EngineResponse
+	if request.Operation != admissionv1.Delete {
+		for _, policy := range snapshot.GetPolicies(policycache.Mutate, gvr, "", request.Namespace, operation) {
+			response := h.engine.Mutate(ctx, policyContext.WithPolicy(policy))
+			mutateResponses = append(mutateResponses, response)
+			policyContext = policyContext.WithNewResource(response.PatchedResource)
+		}
+	}
+	failurePolicy := kyvernov1.Ignore
+	policies := snapshot.GetPolicies(policycache.ValidateEnforce, gvr, "", request.Namespace, operation)
+	policies = append(policies, snapshot.GetPolicies(policycache.ValidateAudit, gvr, "", request.Namespace, operation)...)
+	for _, policy := range policies {
+		if policy.GetSpec().GetFailurePolicy(ctx) == kyvernov1.Fail {
+			failurePolicy = kyvernov1.Fail
+		}
+		response := h.engine.Validate(ctx, policyContext.WithPolicy(policy))
+		if !response.IsNil() {
+			validateResponses = append(validateResponses, response)
+		}
+	}
+	out := &EvaluateResponse{
+		Allowed:  !webhookutils.BlockRequest(validateResponses, failurePolicy, logger),
+		Warnings: webhookutils.GetWarningMessages(validateResponses),
+	}
+	if !out.Allowed {
+		out.Message = webhookutils.GetBlockedMessages(validateResponses)
+	}
+	if request.Operation != admissionv1.Delete {
+		patched := policyContext.NewResource()
+		if out.PatchedResource, err = json.Marshal(patched.Object); err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to marshal patched resource: %v", err)
+		}
+	}
+	out.Results = append(ruleResults(mutateResponses...), ruleResults(validateResponses...)...)
+	return out, nil
+}
+
+// admissionRequest converts the evaluation request into the admission request the webhooks would receive,
+// the requester is the user authenticated by the server, the user info of the request is ignored
+func (h *handler) admissionRequest(ctx context.Context, in *EvaluateRequest) (*admissionv1.AdmissionRequest, error) {
+	user, ok := userFromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "request is not authenticated")
+	}
+	operation := admissionv1.Operation(strings.ToUpper(in.GetOperation()))
+	if operation == "" {
+		operation = admissionv1.Create
+	}
+	switch operation {
+	case admissionv1.Create, admissionv1.Update, admissionv1.Delete, admissionv1.Connect:
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "unsupported operation %s", in.GetOperation())
+	}
+	resource, err := decodeResource(in.GetResource())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to decode resource: %v", err)
+	}
+	oldResource, err := decodeResource(in.GetOldResource())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to decode old resource: %v", err)
+	}
+	object := resource
+	if operation == admissionv1.Delete {
+		object = oldResource
+	}
+	if object == nil {
+		return nil, status.Error(codes.InvalidArgument, "resource is required")
+	}
+	if operation == admissionv1.Update && oldResource == nil {
+		return nil, status.Error(codes.InvalidArgument, "old resource is required for UPDATE operations")
+	}
+	gvk := object.GroupVersionKind()
+	if gvk.Kind == "" {
+		return nil, status.Error(codes.InvalidArgument, "resource kind is required")
+	}
+	gvr, err := h.gvrResolver.GetGVRFromGVK(gvk)
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, "failed to find resource of kind %s: %v", gvk, err)
+	}
+	request := &admissionv1.AdmissionRequest{
+		UID:       types.UID(uuid.NewUUID()),
+		Kind:      metav1.GroupVersionKind(gvk),
+		Resource:  metav1.GroupVersionResource(gvr),
+		Name:      object.GetName(),
+		Namespace: object.GetNamespace(),
+		Operation: operation,
+		UserInfo:  user,
+	}
+	if resource != nil && operation != admissionv1.Delete {
+		request.Object = runtime.RawExtension{Raw: in.GetResource()}
+	}
+	if oldResource != nil && operation != admissionv1.Create {
+		request.OldObject = runtime.RawExtension{Raw: in.GetOldResource()}
+	}
+	return request, nil
+}
+
+func decodeResource(data []byte) (*unstructured.Unstructured, error) {
+	if len(data) == 0 {
+		return nil, nil
+	}
+	var resource unstructured.Unstructured
+	if err := resource.UnmarshalJSON(data); err != nil {
+		return nil, err
+	}
+	return &resource, nil
+}
+
+func ruleResults(responses ...engineapi.EngineResponse) []*RuleResult {
+	var results []*RuleResult
+	for _, response := range responses {
+		policy := response.Policy()
+		name := policy.GetName()
+		if policy.GetNamespace() != "" {
+			name = fmt.Sprintf("%s/%s", policy.GetNamespace(), name)
+		}
+		for _, rule := range response.PolicyResponse.Rules {
+			results = append(results, &RuleResult{
+				Policy:  name,
+				Rule:    rule.Name(),
+				Type:    string(rule.RuleType()),
+				Status:  string(rule.Status()),
+				Message: rule.Message(),
+			})
+		}
+	}
+	return results
+}
@@ -0,0 +1,199 @@
+package evaluation
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kyverno/kyverno/pkg/config"
+	"github.com/kyverno/kyverno/pkg/engine"
+	"github.com/kyverno/kyverno/pkg/engine/factories"
+	"github.com/kyverno/kyverno/pkg/engine/jmespath"
+	"github.com/kyverno/kyverno/pkg/imageverifycache"
+	"github.com/kyverno/kyverno/pkg/policycache"
+	yamlutils "github.com/kyverno/kyverno/pkg/utils/yaml"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gotest.tools/assert"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	kubeinformers "k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+type gvrResolver map[schema.GroupVersionKind]schema.GroupVersionResource
+
+func (r gvrResolver) GetGVRFromGVK(gvk schema.GroupVersionKind) (schema.GroupVersionResource, error) {
+	if gvr, ok := r[gvk]; ok {
+		return gvr, nil
+	}
+	return schema.GroupVersionResource{}, status.Error(codes.NotFound, "not found")
+}
+
+const policies = `
+apiVersion: kyverno.io/v1
+kind: ClusterPolicy
+metadata:
+  name: add-team
+spec:
+  rules:
+  - name: add-team
+    match:
+      any:
+      - resources:
+          kinds:
+          - Pod
+    mutate:
+      patchStrategicMerge:
+        metadata:
+          labels:
+            team: "{{ request.namespace }}-team"
+---
+apiVersion: kyverno.io/v1
+kind: ClusterPolicy
+metadata:
+  name: disallow-latest
+spec:
+  validationFailureAction: Enforce
+  rules:
+  - name: check-tag
+    match:
+      any:
+      - resources:
+          kinds:
+          - Pod
+    validate:
+      message: latest tag is not allowed
+      pattern:
+        spec:
+          containers:
+          - image: "!*:latest"
+`
+
+func newTestHandler(t *testing.T) EvaluationServer {
+	cfg := config.NewDefaultConfiguration(false)
+	jp := jmespath.New(cfg)
+	eng := engine.NewEngine(
+		cfg,
+		config.NewDefaultMetricsConfiguration(),
+		jp,
+		nil,
+		nil,
+		imageverifycache.DisabledImageVerifyCache(),
+		factories.DefaultContextLoaderFactory(nil),
+		nil,
+		nil,
+		nil,
+		"",
+	)
+	pCache := policycache.NewCache()
+	loaded, _, err := yamlutils.GetPolicy([]byte(policies))
+	assert.NilError(t, err)
+	for _, policy := range loaded {
+		assert.NilError(t, pCache.Set(policy.GetName(), policy, policycache.TestResourceFinder{}))
+	}
+	informers := kubeinformers.NewSharedInformerFactory(fake.NewSimpleClientset(), 0)
+	nsInformer := informers.Core().V1().Namespaces()
+	assert.NilError(t, nsInformer.Informer().GetIndexer().Add(&corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{Name: "apps", Labels: map[string]string{"env": "prod"}},
+	}))
+	return NewHandler(
+		eng,
+		cfg,
+		jp,
+		pCache,
+		gvrResolver{{Version: "v1", Kind: "Pod"}: {Version: "v1", Resource: "pods"}},
+		nsInformer.Lister(),
+		informers.Core().V1().ServiceAccounts().Lister(),
+		informers.Rbac().V1().RoleBindings().Lister(),
+		informers.Rbac().V1().ClusterRoleBindings().Lister(),
+	)
+}
+
+func pod(image string) []byte {
+	return []byte(`{"apiVersion":"v1","kind":"Pod","metadata":{"name":"nginx","namespace":"apps"},"spec":{"containers":[{"name":"nginx","image":"` + image + `"}]}}`)
+}
+
+func Test_Evaluate(t *testing.T) {
+	handler := newTestHandler(t)
+	tests := []struct {
+		name     string
+		request  *EvaluateRequest
+		allowed  bool
+		statuses map[string]string
+	}{{
+		name:    "allowed",
+		request: &EvaluateRequest{Resource: pod("nginx:1.25")},
+		allowed: true,
+		statuses: map[string]string{
+			"add-team/add-team":         "pass",
+			"disallow-latest/check-tag": "pass",
+		},
+	}, {
+		name:    "blocked",
+		request: &EvaluateRequest{Resource: pod("nginx:latest"), Operation: "create"},
+		allowed: false,
+		statuses: map[string]string{
+			"add-team/add-team":         "pass",
+			"disallow-latest/check-tag": "fail",
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response, err := handler.Evaluate(withUser(context.TODO(), authenticationv1.UserInfo{Username: "alice"}), tt.request)
+			assert.NilError(t, err)
+			assert.Equal(t, response.Allowed, tt.allowed)
+			assert.Equal(t, response.Message != "", !tt.allowed)
+			statuses := map[string]string{}
+			for _, result := range response.Results {
+				statuses[result.Policy+"/"+result.Rule] = result.Status
+			}
+			assert.DeepEqual(t, statuses, tt.statuses)
+			resource, err := decodeResource(response.PatchedResource)
+			assert.NilError(t, err)
+			assert.Equal(t, resource.GetLabels()["team"], "apps-team")
+		})
+	}
+}
+
+func Test_Evaluate_invalid(t *testing.T) {
+	handler := newTestHandler(t)
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		request *EvaluateRequest
+		code    codes.Code
+	}{{
+		name:    "not authenticated",
+		ctx:     context.TODO(),
+		request: &EvaluateRequest{Resource: pod("nginx"), UserInfo: &UserInfo{Username: "alice"}},
+		code:    codes.Unauthenticated,
+	}, {
+		name:    "missing resource",
+		request: &EvaluateRequest{},
+		code:    codes.InvalidArgument,
+	}, {
+		name:    "invalid operation",
+		request: &EvaluateRequest{Resource: pod("nginx"), Operation: "PATCH"},
+		code:    codes.InvalidArgument,
+	}, {
+		name:    "update without old resource",
+		request: &EvaluateRequest{Resource: pod("nginx"), Operation: "UPDATE"},
+		code:    codes.InvalidArgument,
+	}, {
+		name:    "unknown kind",
+		request: &EvaluateRequest{Resource: []byte(`{"apiVersion":"v1","kind":"Unknown","metadata":{"name":"x"}}`)},
+		code:    codes.NotFound,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := tt.ctx
+			if ctx == nil {
+				ctx = withUser(context.TODO(), authenticationv1.UserInfo{Username: "alice"})
+			}
+			_, err := handler.Evaluate(ctx, tt.request)
+			assert.Equal(t, status.Code(err), tt.code)
+		})
+	}
+}
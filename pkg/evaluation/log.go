@@ -0,0 +1,5 @@
+package evaluation
+
+import "github.com/kyverno/kyverno/pkg/logging"
+
+var logger = logging.WithName("evaluation")
@@ -0,0 +1,66 @@
+package evaluation
+
+import (
+	"crypto/tls"
+	"net"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+// TlsProvider returns the PEM encoded certificate and key served by the server
+type TlsProvider func() ([]byte, []byte, error)
+
+type Server interface {
+	// Run serves requests until the stop channel is closed
+	Run(<-chan struct{})
+}
+
+type server struct {
+	address string
+	server  *grpc.Server
+}
+
+// NewServer creates a gRPC server serving the evaluation handler over TLS on the given address,
+// calls are authenticated with the bearer token they carry using the token reviewer
+func NewServer(address string, handler EvaluationServer, tlsProvider TlsProvider, reviewer TokenReviewer) Server {
+	tlsConfig := &tls.Config{
+		GetCertificate: func(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+			certPem, keyPem, err := tlsProvider()
+			if err != nil {
+				return nil, err
+			}
+			pair, err := tls.X509KeyPair(certPem, keyPem)
+			if err != nil {
+				return nil, err
+			}
+			return &pair, nil
+		},
+		MinVersion: tls.VersionTLS12,
+	}
+	s := grpc.NewServer(
+		grpc.Creds(credentials.NewTLS(tlsConfig)),
+		grpc.UnaryInterceptor(authenticate(reviewer)),
+	)
+	RegisterEvaluationServer(s, handler)
+	return &server{
+		address: address,
+		server:  s,
+	}
+}
+
+func (s *server) Run(stopCh <-chan struct{}) {
+	listener, err := net.Listen("tcp", s.address)
+	if err != nil {
+		logger.Error(err, "failed to listen", "addr", s.address)
+		return
+	}
+	go func() {
+		logger.V(3).Info("started serving requests", "addr", s.address)
+		if err := s.server.Serve(listener); err != nil {
+			logger.Error(err, "failed to serve requests")
+		}
+	}()
+	<-stopCh
+	s.server.GracefulStop()
+}
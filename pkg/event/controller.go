@@ -12,13 +12,14 @@ import (
 	kyvernov2alpha1listers "github.com/kyverno/kyverno/pkg/client/listers/kyverno/v2alpha1"
 	"github.com/kyverno/kyverno/pkg/clients/dclient"
 	kubeutils "github.com/kyverno/kyverno/pkg/utils/kube"
-	corev1 "k8s.io/api/core/v1"
 	errors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/tools/events"
+	"k8s.io/client-go/util/flowcontrol"
 	"k8s.io/client-go/util/workqueue"
+	"k8s.io/utils/clock"
 )
 
 const (
@@ -54,6 +55,13 @@ type generator struct {
 
 	omitEvents []string
 
+	// verbosity controls which events are emitted
+	verbosity Verbosity
+	// aggregator deduplicates events, nil when aggregation is disabled
+	aggregator *aggregator
+	// rateLimiter limits the rate of events sent to the API server, nil when rate limiting is disabled
+	rateLimiter flowcontrol.RateLimiter
+
 	log logr.Logger
 }
 
@@ -76,6 +84,7 @@ func NewEventGenerator(
 	pInformer kyvernov1informers.PolicyInformer,
 	maxQueuedEvents int,
 	omitEvents []string,
+	throttle ThrottleConfig,
 	log logr.Logger,
 ) Controller {
 	gen := generator{
@@ -91,6 +100,7 @@ func NewEventGenerator(
 		omitEvents:             omitEvents,
 		log:                    log,
 	}
+	gen.setThrottling(throttle)
 	return &gen
 }
 
@@ -101,6 +111,7 @@ func NewEventCleanupGenerator(
 	clustercleanuppolInformer kyvernov2alpha1informers.ClusterCleanupPolicyInformer,
 	cleanuppolInformer kyvernov2alpha1informers.CleanupPolicyInformer,
 	maxQueuedEvents int,
+	throttle ThrottleConfig,
 	log logr.Logger,
 ) Controller {
 	gen := generator{
@@ -112,9 +123,20 @@ func NewEventCleanupGenerator(
 		maxQueuedEvents:         maxQueuedEvents,
 		log:                     log,
 	}
+	gen.setThrottling(throttle)
 	return &gen
 }
 
+func (gen *generator) setThrottling(throttle ThrottleConfig) {
+	gen.verbosity = throttle.Verbosity
+	if throttle.AggregationWindow > 0 {
+		gen.aggregator = newAggregator(throttle.AggregationWindow, clock.RealClock{})
+	}
+	if throttle.QPS > 0 {
+		gen.rateLimiter = flowcontrol.NewTokenBucketRateLimiter(throttle.QPS, throttle.Burst)
+	}
+}
+
 // Add queues an event for generation
 func (gen *generator) Add(infos ...Info) {
 	logger := gen.log
@@ -131,13 +153,20 @@ func (gen *generator) Add(infos ...Info) {
 			continue
 		}
 
-		shouldEmitEvent := true
+		shouldEmitEvent := gen.verbosity.allows(info.Reason)
+		if !shouldEmitEvent {
+			logger.V(6).Info("omitting event due to verbosity", "kind", info.Kind, "name", info.Name, "namespace", info.Namespace, "reason", info.Reason, "verbosity", gen.verbosity)
+		}
 		for _, eventReason := range gen.omitEvents {
 			if info.Reason == Reason(eventReason) {
 				shouldEmitEvent = false
 				logger.V(6).Info("omitting event", "kind", info.Kind, "name", info.Name, "namespace", info.Namespace, "reason", info.Reason)
 			}
 		}
+		if shouldEmitEvent && gen.aggregator != nil && !gen.aggregator.admit(info) {
+			shouldEmitEvent = false
+			logger.V(6).Info("aggregating duplicate event", "kind", info.Kind, "name", info.Name, "namespace", info.Namespace, "reason", info.Reason, "policy", info.Policy, "rule", info.Rule)
+		}
 
 		if shouldEmitEvent {
 			gen.queue.Add(info)
@@ -160,11 +189,34 @@ func (gen *generator) Run(ctx context.Context, workers int, waitGroup *sync.Wait
 			wait.UntilWithContext(ctx, gen.runWorker, time.Second)
 		}()
 	}
+	if gen.aggregator != nil {
+		waitGroup.Add(1)
+		go func() {
+			defer waitGroup.Done()
+			wait.UntilWithContext(ctx, gen.flushAggregatedEvents, gen.aggregator.window)
+		}()
+	}
 	<-ctx.Done()
 }
 
 func (gen *generator) runWorker(ctx context.Context) {
-	for gen.processNextWorkItem() {
+	for gen.processNextWorkItem(ctx) {
+	}
+}
+
+// flushAggregatedEvents queues aggregated events for duplicates dropped during expired aggregation windows
+func (gen *generator) flushAggregatedEvents(_ context.Context) {
+	infos := gen.aggregator.flush()
+	if len(infos) == 0 {
+		return
+	}
+	gen.log.V(3).Info("generating aggregated events", "count", len(infos))
+	if gen.queue.Len() > gen.maxQueuedEvents {
+		gen.log.V(2).Info("exceeds the event queue limit, dropping aggregated events", "maxQueuedEvents", gen.maxQueuedEvents, "current size", gen.queue.Len())
+		return
+	}
+	for _, info := range infos {
+		gen.queue.Add(info)
 	}
 }
 
@@ -188,12 +240,18 @@ func (gen *generator) handleErr(err error, key interface{}) {
 	}
 }
 
-func (gen *generator) processNextWorkItem() bool {
+func (gen *generator) processNextWorkItem(ctx context.Context) bool {
 	obj, shutdown := gen.queue.Get()
 	if shutdown {
 		return false
 	}
 	defer gen.queue.Done(obj)
+	if gen.rateLimiter != nil {
+		if err := gen.rateLimiter.Wait(ctx); err != nil {
+			gen.queue.Forget(obj)
+			return false
+		}
+	}
 	var key Info
 	var ok bool
 	if key, ok = obj.(Info); !ok {
@@ -249,12 +307,7 @@ func (gen *generator) syncHandler(key Info) error {
 	relatedObj = kubeutils.NewUnstructured(key.RelatedAPIVersion, key.RelatedKind, key.RelatedNamespace, key.RelatedName)
 
 	// set the event type based on reason
-	// if skip/pass, reason will be: NORMAL
-	// else reason will be: WARNING
-	eventType := corev1.EventTypeWarning
-	if key.Reason == PolicyApplied || key.Reason == PolicySkipped {
-		eventType = corev1.EventTypeNormal
-	}
+	eventType := eventType(key.Reason)
 
 	logger.V(3).Info("creating the event", "source", key.Source, "type", eventType, "resource", key.Resource())
 	// based on the source of event generation, use different event recorders
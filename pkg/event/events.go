@@ -3,6 +3,7 @@ package event
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
 	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
@@ -30,6 +31,8 @@ func NewPolicyFailEvent(source Source, reason Reason, engineResponse engineapi.E
 		Source:            source,
 		Message:           buildPolicyEventMessage(ruleResp, engineResponse.GetResourceSpec(), blocked),
 		Action:            action,
+		Policy:            policyKey(pol),
+		Rule:              ruleResp.Name(),
 	}
 }
 
@@ -60,6 +63,13 @@ func getPolicyKind(policy kyvernov1.PolicyInterface) string {
 	return "ClusterPolicy"
 }
 
+func policyKey(policy kyvernov1.PolicyInterface) string {
+	if policy.GetNamespace() != "" {
+		return policy.GetNamespace() + "/" + policy.GetName()
+	}
+	return policy.GetName()
+}
+
 func getCleanupPolicyKind(policy kyvernov2alpha1.CleanupPolicyInterface) string {
 	if policy.IsNamespaced() {
 		return "CleanupPolicy"
@@ -105,6 +115,7 @@ func NewPolicyAppliedEvent(source Source, engineResponse engineapi.EngineRespons
 		Source:            source,
 		Message:           bldr.String(),
 		Action:            action,
+		Policy:            policyKey(pol),
 	}
 }
 
@@ -125,6 +136,8 @@ func NewResourceViolationEvent(source Source, reason Reason, engineResponse engi
 		Source:    source,
 		Message:   bldr.String(),
 		Action:    ResourcePassed,
+		Policy:    policyKey(pol),
+		Rule:      ruleResp.Name(),
 	}
 }
 
@@ -139,6 +152,8 @@ func NewResourceGenerationEvent(policy, rule string, source Source, resource kyv
 		Reason:    PolicyApplied,
 		Message:   msg,
 		Action:    None,
+		Policy:    policy,
+		Rule:      rule,
 	}
 }
 
@@ -156,6 +171,8 @@ func NewBackgroundFailedEvent(err error, policy kyvernov1.PolicyInterface, rule
 		Reason:            PolicyError,
 		Message:           fmt.Sprintf("policy %s/%s error: %v", policy.GetName(), rule, err),
 		Action:            None,
+		Policy:            policyKey(policy),
+		Rule:              rule,
 	})
 
 	return events
@@ -184,6 +201,7 @@ func NewBackgroundSuccessEvent(source Source, policy kyvernov1.PolicyInterface,
 			Reason:            PolicyApplied,
 			Message:           msg,
 			Action:            action,
+			Policy:            policyKey(policy),
 		})
 	}
 
@@ -213,6 +231,8 @@ func NewPolicyExceptionEvents(engineResponse engineapi.EngineResponse, ruleResp
 		Message:           policyMessage,
 		Source:            source,
 		Action:            ResourcePassed,
+		Policy:            policyKey(pol),
+		Rule:              ruleResp.Name(),
 	}
 	exceptionEvent := Info{
 		Kind:              "PolicyException",
@@ -226,6 +246,8 @@ func NewPolicyExceptionEvents(engineResponse engineapi.EngineResponse, ruleResp
 		Message:           exceptionMessage,
 		Source:            source,
 		Action:            ResourcePassed,
+		Policy:            policyKey(pol),
+		Rule:              ruleResp.Name(),
 	}
 	return []Info{policyEvent, exceptionEvent}
 }
@@ -271,6 +293,47 @@ func NewFailedEvent(err error, policy, rule string, source Source, resource kyve
 		Reason:    PolicyError,
 		Message:   fmt.Sprintf("policy %s/%s error: %v", policy, rule, err),
 		Action:    None,
+		Policy:    policy,
+		Rule:      rule,
+	}
+}
+
+// NewLatencyBudgetExceededEvent returns a warning event on the policy when it took longer than its latency budget to evaluate an admission request
+func NewLatencyBudgetExceededEvent(source Source, engineResponse engineapi.EngineResponse, budget time.Duration) Info {
+	pol := engineResponse.Policy().GetPolicy().(kyvernov1.PolicyInterface)
+	resource := engineResponse.GetResourceSpec()
+	return Info{
+		Kind:              getPolicyKind(pol),
+		Name:              pol.GetName(),
+		Namespace:         pol.GetNamespace(),
+		RelatedAPIVersion: resource.APIVersion,
+		RelatedKind:       resource.Kind,
+		RelatedName:       resource.Name,
+		RelatedNamespace:  resource.Namespace,
+		Reason:            PolicyLatencyBudgetExceeded,
+		Source:            source,
+		Message:           fmt.Sprintf("policy evaluation took %s, exceeding the latency budget of %s", engineResponse.Stats().ProcessingTime(), budget),
+		Action:            None,
+		Policy:            policyKey(pol),
+	}
+}
+
+// NewGeneratedResourceDriftEvent returns a warning event on the policy when another field manager changed fields of a generated resource
+func NewGeneratedResourceDriftEvent(policy kyvernov1.PolicyInterface, rule string, resource kyvernov1.ResourceSpec, manager string, fields []string) Info {
+	return Info{
+		Kind:              getPolicyKind(policy),
+		Name:              policy.GetName(),
+		Namespace:         policy.GetNamespace(),
+		RelatedAPIVersion: resource.GetAPIVersion(),
+		RelatedKind:       resource.GetKind(),
+		RelatedName:       resource.GetName(),
+		RelatedNamespace:  resource.GetNamespace(),
+		Reason:            GeneratedResourceDrift,
+		Source:            GeneratePolicyController,
+		Message:           fmt.Sprintf("field manager %s changed fields set by rule %s, they were restored: %s", manager, rule, strings.Join(fields, ", ")),
+		Action:            None,
+		Policy:            policyKey(policy),
+		Rule:              rule,
 	}
 }
 
@@ -15,6 +15,9 @@ type Info struct {
 	Message           string
 	Action            Action
 	Source            Source
+	// Policy and Rule identify the policy rule that triggered the event, they are used to deduplicate events
+	Policy string
+	Rule   string
 }
 
 func (i *Info) Resource() string {
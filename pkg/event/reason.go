@@ -4,8 +4,10 @@ package event
 type Reason string
 
 const (
-	PolicyViolation Reason = "PolicyViolation"
-	PolicyApplied   Reason = "PolicyApplied"
-	PolicyError     Reason = "PolicyError"
-	PolicySkipped   Reason = "PolicySkipped"
+	PolicyViolation             Reason = "PolicyViolation"
+	PolicyApplied               Reason = "PolicyApplied"
+	PolicyError                 Reason = "PolicyError"
+	PolicySkipped               Reason = "PolicySkipped"
+	PolicyLatencyBudgetExceeded Reason = "PolicyLatencyBudgetExceeded"
+	GeneratedResourceDrift      Reason = "GeneratedResourceDrift"
 )
@@ -0,0 +1,158 @@
+package event
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/clock"
+)
+
+// Verbosity controls which events are emitted
+type Verbosity string
+
+const (
+	// VerbosityAll emits all events
+	VerbosityAll Verbosity = "all"
+	// VerbosityWarning only emits warning events (policy violations and errors)
+	VerbosityWarning Verbosity = "warning"
+	// VerbosityNone disables events
+	VerbosityNone Verbosity = "none"
+)
+
+// ParseVerbosity parses an events verbosity level
+func ParseVerbosity(value string) (Verbosity, error) {
+	switch Verbosity(value) {
+	case VerbosityAll, VerbosityWarning, VerbosityNone:
+		return Verbosity(value), nil
+	case "":
+		return VerbosityAll, nil
+	default:
+		return "", fmt.Errorf("invalid events verbosity %q, possible values are %s, %s and %s", value, VerbosityAll, VerbosityWarning, VerbosityNone)
+	}
+}
+
+// allows returns true if events with the given reason are emitted at this verbosity level
+func (v Verbosity) allows(reason Reason) bool {
+	switch v {
+	case VerbosityNone:
+		return false
+	case VerbosityWarning:
+		return eventType(reason) == corev1.EventTypeWarning
+	default:
+		return true
+	}
+}
+
+// ThrottleConfig configures how events are aggregated and rate limited before being sent to the API server
+type ThrottleConfig struct {
+	// Verbosity controls which events are emitted
+	Verbosity Verbosity
+	// AggregationWindow is the period during which duplicate events (same policy, rule and resource) are aggregated,
+	// the first event is emitted immediately and an aggregated event is emitted at the end of the window if duplicates were dropped.
+	// Aggregation is disabled when zero.
+	AggregationWindow time.Duration
+	// QPS is the max number of events sent to the API server per second, rate limiting is disabled when zero
+	QPS float32
+	// Burst is the max burst of events sent to the API server
+	Burst int
+}
+
+// eventKey identifies duplicate events
+type eventKey struct {
+	source           Source
+	reason           Reason
+	policy           string
+	rule             string
+	kind             string
+	namespace        string
+	name             string
+	relatedKind      string
+	relatedNamespace string
+	relatedName      string
+}
+
+func newEventKey(info Info) eventKey {
+	return eventKey{
+		source:           info.Source,
+		reason:           info.Reason,
+		policy:           info.Policy,
+		rule:             info.Rule,
+		kind:             info.Kind,
+		namespace:        info.Namespace,
+		name:             info.Name,
+		relatedKind:      info.RelatedKind,
+		relatedNamespace: info.RelatedNamespace,
+		relatedName:      info.RelatedName,
+	}
+}
+
+type aggregation struct {
+	// start is the time the first event was emitted
+	start time.Time
+	// count is the number of duplicate events dropped since the first event
+	count int
+	// last is the last duplicate event
+	last Info
+}
+
+// aggregator deduplicates events during a time window
+type aggregator struct {
+	lock    sync.Mutex
+	window  time.Duration
+	clock   clock.PassiveClock
+	entries map[eventKey]*aggregation
+}
+
+func newAggregator(window time.Duration, clock clock.PassiveClock) *aggregator {
+	return &aggregator{
+		window:  window,
+		clock:   clock,
+		entries: map[eventKey]*aggregation{},
+	}
+}
+
+// admit returns true if the event should be emitted, duplicates of an event already emitted in the current window are recorded and dropped
+func (a *aggregator) admit(info Info) bool {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	key := newEventKey(info)
+	now := a.clock.Now()
+	if entry, ok := a.entries[key]; ok && now.Sub(entry.start) < a.window {
+		entry.count++
+		entry.last = info
+		return false
+	}
+	a.entries[key] = &aggregation{start: now}
+	return true
+}
+
+// flush forgets entries whose window has expired and returns an aggregated event for each of them that recorded duplicates
+func (a *aggregator) flush() []Info {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	now := a.clock.Now()
+	var infos []Info
+	for key, entry := range a.entries {
+		if now.Sub(entry.start) < a.window {
+			continue
+		}
+		if entry.count > 0 {
+			info := entry.last
+			info.Message = fmt.Sprintf("%s (%d similar events aggregated over %s)", info.Message, entry.count, a.window)
+			infos = append(infos, info)
+		}
+		delete(a.entries, key)
+	}
+	return infos
+}
+
+func eventType(reason Reason) string {
+	// if skip/pass, reason will be: NORMAL
+	// else reason will be: WARNING
+	if reason == PolicyApplied || reason == PolicySkipped {
+		return corev1.EventTypeNormal
+	}
+	return corev1.EventTypeWarning
+}
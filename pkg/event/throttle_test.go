@@ -0,0 +1,89 @@
+package event
+
+import (
+	"testing"
+	"time"
+
+	"gotest.tools/assert"
+	clocktesting "k8s.io/utils/clock/testing"
+)
+
+func TestParseVerbosity(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    Verbosity
+		wantErr bool
+	}{
+		{value: "", want: VerbosityAll},
+		{value: "all", want: VerbosityAll},
+		{value: "warning", want: VerbosityWarning},
+		{value: "none", want: VerbosityNone},
+		{value: "debug", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := ParseVerbosity(tt.value)
+			if tt.wantErr {
+				assert.Assert(t, err != nil)
+			} else {
+				assert.NilError(t, err)
+				assert.Equal(t, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestVerbosityAllows(t *testing.T) {
+	tests := []struct {
+		verbosity Verbosity
+		reason    Reason
+		want      bool
+	}{
+		{verbosity: "", reason: PolicyApplied, want: true},
+		{verbosity: VerbosityAll, reason: PolicySkipped, want: true},
+		{verbosity: VerbosityWarning, reason: PolicyApplied, want: false},
+		{verbosity: VerbosityWarning, reason: PolicySkipped, want: false},
+		{verbosity: VerbosityWarning, reason: PolicyViolation, want: true},
+		{verbosity: VerbosityWarning, reason: PolicyError, want: true},
+		{verbosity: VerbosityNone, reason: PolicyError, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.verbosity)+"/"+string(tt.reason), func(t *testing.T) {
+			assert.Equal(t, tt.verbosity.allows(tt.reason), tt.want)
+		})
+	}
+}
+
+func TestAggregator(t *testing.T) {
+	clock := clocktesting.NewFakePassiveClock(time.Now())
+	aggregator := newAggregator(time.Minute, clock)
+	violation := Info{
+		Kind:        "ClusterPolicy",
+		Name:        "require-labels",
+		RelatedKind: "Pod",
+		RelatedName: "nginx",
+		Reason:      PolicyViolation,
+		Source:      PolicyController,
+		Policy:      "require-labels",
+		Rule:        "check-team",
+		Message:     "validation error",
+	}
+	otherRule := violation
+	otherRule.Rule = "check-owner"
+	// first events are emitted, duplicates are dropped
+	assert.Assert(t, aggregator.admit(violation))
+	assert.Assert(t, aggregator.admit(otherRule))
+	assert.Assert(t, !aggregator.admit(violation))
+	assert.Assert(t, !aggregator.admit(violation))
+	// nothing is flushed before the window expires
+	assert.Equal(t, len(aggregator.flush()), 0)
+	clock.SetTime(clock.Now().Add(time.Minute))
+	// an aggregated event is flushed for the rule with duplicates only
+	infos := aggregator.flush()
+	assert.Equal(t, len(infos), 1)
+	assert.Equal(t, infos[0].Rule, "check-team")
+	assert.Equal(t, infos[0].Message, "validation error (2 similar events aggregated over 1m0s)")
+	assert.Equal(t, len(aggregator.entries), 0)
+	// a new window starts with the next event
+	assert.Assert(t, aggregator.admit(violation))
+}
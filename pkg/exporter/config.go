@@ -0,0 +1,174 @@
+package exporter
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	defaultBatchSize      = 100
+	defaultFlushInterval  = 10 * time.Second
+	defaultMaxRetries     = 5
+	defaultQueueSize      = 10000
+	defaultDedupCacheSize = 10000
+)
+
+// Config is the results exporter configuration
+type Config struct {
+	// BatchSize is the max number of results sent to a sink at once
+	BatchSize int `json:"batchSize,omitempty"`
+	// FlushInterval is the max delay before buffered results are sent
+	FlushInterval Duration `json:"flushInterval,omitempty"`
+	// MaxRetries is the number of times sending a batch is retried before results are dropped, a negative value disables retries
+	MaxRetries int `json:"maxRetries,omitempty"`
+	// QueueSize is the max number of results buffered, results are dropped when the queue is full
+	QueueSize int `json:"queueSize,omitempty"`
+	// DedupCacheSize is the max number of resource, policy and rule combinations for which the last exported result is
+	// remembered, results identical to the last exported one are not exported again, a negative value disables deduplication
+	DedupCacheSize int `json:"dedupCacheSize,omitempty"`
+	// Sinks are the destinations results are exported to
+	Sinks []SinkConfig `json:"sinks,omitempty"`
+}
+
+// SinkConfig configures a single sink, exactly one of the sink types must be set
+type SinkConfig struct {
+	// Name identifies the sink in logs
+	Name          string               `json:"name"`
+	Webhook       *WebhookConfig       `json:"webhook,omitempty"`
+	Elasticsearch *ElasticsearchConfig `json:"elasticsearch,omitempty"`
+	S3            *S3Config            `json:"s3,omitempty"`
+	Kafka         *KafkaConfig         `json:"kafka,omitempty"`
+}
+
+// WebhookConfig sends results as a JSON array to an HTTP endpoint
+type WebhookConfig struct {
+	URL     string            `json:"url"`
+	Headers map[string]string `json:"headers,omitempty"`
+}
+
+// ElasticsearchConfig indexes results using the Elasticsearch bulk API
+type ElasticsearchConfig struct {
+	URL      string `json:"url"`
+	Index    string `json:"index"`
+	Username string `json:"username,omitempty"`
+	Password string `json:"password,omitempty"`
+	APIKey   string `json:"apiKey,omitempty"`
+}
+
+// S3Config stores each batch of results as a JSON lines object in a bucket,
+// credentials are resolved using the AWS default credentials chain
+type S3Config struct {
+	Bucket   string `json:"bucket"`
+	Region   string `json:"region"`
+	Prefix   string `json:"prefix,omitempty"`
+	Endpoint string `json:"endpoint,omitempty"`
+}
+
+// KafkaConfig produces results to a topic through a Kafka REST proxy (v2 API)
+type KafkaConfig struct {
+	RestProxyURL string            `json:"restProxyUrl"`
+	Topic        string            `json:"topic"`
+	Headers      map[string]string `json:"headers,omitempty"`
+}
+
+// Duration wraps time.Duration to support string values (e.g. 10s) in configuration files
+type Duration struct {
+	time.Duration
+}
+
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	var value string
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	duration, err := time.ParseDuration(value)
+	if err != nil {
+		return err
+	}
+	d.Duration = duration
+	return nil
+}
+
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return []byte(fmt.Sprintf("%q", d.String())), nil
+}
+
+// LoadConfig reads the exporter configuration from a YAML or JSON file
+func LoadConfig(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return ParseConfig(data)
+}
+
+// ParseConfig parses and validates the exporter configuration, applying defaults
+func ParseConfig(data []byte) (*Config, error) {
+	var config Config
+	if err := yaml.UnmarshalStrict(data, &config); err != nil {
+		return nil, err
+	}
+	if config.BatchSize <= 0 {
+		config.BatchSize = defaultBatchSize
+	}
+	if config.FlushInterval.Duration <= 0 {
+		config.FlushInterval.Duration = defaultFlushInterval
+	}
+	if config.MaxRetries < 0 {
+		config.MaxRetries = 0
+	} else if config.MaxRetries == 0 {
+		config.MaxRetries = defaultMaxRetries
+	}
+	if config.QueueSize <= 0 {
+		config.QueueSize = defaultQueueSize
+	}
+	if config.DedupCacheSize == 0 {
+		config.DedupCacheSize = defaultDedupCacheSize
+	}
+	if len(config.Sinks) == 0 {
+		return nil, errors.New("at least one sink must be configured")
+	}
+	for i, sink := range config.Sinks {
+		if err := sink.validate(); err != nil {
+			return nil, fmt.Errorf("invalid sink %d (%s): %w", i, sink.Name, err)
+		}
+	}
+	return &config, nil
+}
+
+func (s SinkConfig) validate() error {
+	count := 0
+	if s.Webhook != nil {
+		count++
+		if s.Webhook.URL == "" {
+			return errors.New("webhook.url is required")
+		}
+	}
+	if s.Elasticsearch != nil {
+		count++
+		if s.Elasticsearch.URL == "" || s.Elasticsearch.Index == "" {
+			return errors.New("elasticsearch.url and elasticsearch.index are required")
+		}
+	}
+	if s.S3 != nil {
+		count++
+		if s.S3.Bucket == "" || s.S3.Region == "" {
+			return errors.New("s3.bucket and s3.region are required")
+		}
+	}
+	if s.Kafka != nil {
+		count++
+		if s.Kafka.RestProxyURL == "" || s.Kafka.Topic == "" {
+			return errors.New("kafka.restProxyUrl and kafka.topic are required")
+		}
+	}
+	if count != 1 {
+		return errors.New("exactly one of webhook, elasticsearch, s3 or kafka must be set")
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+package exporter
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	policyreportv1alpha2 "github.com/kyverno/kyverno/api/policyreport/v1alpha2"
+)
+
+type elasticsearchSink struct {
+	client *http.Client
+	name   string
+	config ElasticsearchConfig
+}
+
+func newElasticsearchSink(client *http.Client, name string, config ElasticsearchConfig) Sink {
+	return &elasticsearchSink{
+		client: client,
+		name:   name,
+		config: config,
+	}
+}
+
+func (s *elasticsearchSink) Name() string {
+	return s.name
+}
+
+func (s *elasticsearchSink) Send(ctx context.Context, results []policyreportv1alpha2.PolicyReportResult) error {
+	// build a bulk request body, each document is preceded by an index action
+	var body bytes.Buffer
+	action, err := json.Marshal(map[string]interface{}{"index": map[string]string{"_index": s.config.Index}})
+	if err != nil {
+		return err
+	}
+	for _, result := range results {
+		document, err := json.Marshal(result)
+		if err != nil {
+			return err
+		}
+		body.Write(action)
+		body.WriteByte('\n')
+		body.Write(document)
+		body.WriteByte('\n')
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, strings.TrimSuffix(s.config.URL, "/")+"/_bulk", &body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-ndjson")
+	if s.config.APIKey != "" {
+		req.Header.Set("Authorization", "ApiKey "+s.config.APIKey)
+	} else if s.config.Username != "" {
+		req.SetBasicAuth(s.config.Username, s.config.Password)
+	}
+	return doRequest(s.client, req)
+}
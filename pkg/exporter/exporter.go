@@ -0,0 +1,192 @@
+package exporter
+
+import (
+	"context"
+	"encoding/json"
+	"hash/fnv"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	policyreportv1alpha2 "github.com/kyverno/kyverno/api/policyreport/v1alpha2"
+	"github.com/kyverno/kyverno/pkg/controllers"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/utils/lru"
+)
+
+const (
+	// Workers is the number of workers for this controller
+	Workers        = 1
+	ControllerName = "results-exporter"
+	requestTimeout = 30 * time.Second
+	retryDelay     = time.Second
+)
+
+// Exporter exports policy results to external sinks
+type Exporter interface {
+	// Export queues results to be sent to the configured sinks
+	Export(...policyreportv1alpha2.PolicyReportResult)
+}
+
+// Controller is an exporter that runs as a controller
+type Controller interface {
+	controllers.Controller
+	Exporter
+}
+
+type exporter struct {
+	config Config
+	sinks  []Sink
+	queue  chan policyreportv1alpha2.PolicyReportResult
+	// exported holds the hash of the last exported result per resource, policy and rule
+	exported *lru.Cache
+	lock     sync.Mutex
+}
+
+// NewExporter creates an exporter for the given configuration
+func NewExporter(ctx context.Context, config Config) (Controller, error) {
+	client := &http.Client{Timeout: requestTimeout}
+	var sinks []Sink
+	for _, sinkConfig := range config.Sinks {
+		sink, err := NewSink(ctx, client, sinkConfig)
+		if err != nil {
+			return nil, err
+		}
+		sinks = append(sinks, sink)
+	}
+	return newExporter(config, sinks...), nil
+}
+
+func newExporter(config Config, sinks ...Sink) *exporter {
+	e := &exporter{
+		config: config,
+		sinks:  sinks,
+		queue:  make(chan policyreportv1alpha2.PolicyReportResult, config.QueueSize),
+	}
+	if config.DedupCacheSize > 0 {
+		e.exported = lru.New(config.DedupCacheSize)
+	}
+	return e
+}
+
+func (e *exporter) Export(results ...policyreportv1alpha2.PolicyReportResult) {
+	for _, result := range results {
+		if e.isDuplicate(result) {
+			logger.V(4).Info("result already exported, skipping", "policy", result.Policy, "rule", result.Rule)
+			continue
+		}
+		select {
+		case e.queue <- result:
+		default:
+			logger.V(2).Info("results exporter queue is full, dropping result", "policy", result.Policy, "rule", result.Rule)
+		}
+	}
+}
+
+// isDuplicate returns true if the result is identical to the last result exported for the same resource, policy
+// and rule, otherwise the result is recorded as the last exported one
+func (e *exporter) isDuplicate(result policyreportv1alpha2.PolicyReportResult) bool {
+	if e.exported == nil {
+		return false
+	}
+	hash, err := resultHash(result)
+	if err != nil {
+		return false
+	}
+	key := result.Policy + "/" + result.Rule
+	for _, resource := range result.Resources {
+		key = string(resource.UID) + "/" + key
+	}
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	if last, ok := e.exported.Get(key); ok && last == hash {
+		return true
+	}
+	e.exported.Add(key, hash)
+	return false
+}
+
+// resultHash hashes the content of a result, the timestamp is ignored as it changes every time a resource is scanned
+func resultHash(result policyreportv1alpha2.PolicyReportResult) (uint64, error) {
+	result.Timestamp = metav1.Timestamp{}
+	data, err := json.Marshal(result)
+	if err != nil {
+		return 0, err
+	}
+	hash := fnv.New64a()
+	_, _ = hash.Write(data)
+	return hash.Sum64(), nil
+}
+
+func (e *exporter) Run(ctx context.Context, _ int) {
+	logger.Info("starting ...")
+	defer logger.Info("stopped")
+	ticker := time.NewTicker(e.config.FlushInterval.Duration)
+	defer ticker.Stop()
+	var batch []policyreportv1alpha2.PolicyReportResult
+	flush := func(ctx context.Context) {
+		if len(batch) > 0 {
+			e.send(ctx, batch)
+			batch = nil
+		}
+	}
+	for {
+		select {
+		case result := <-e.queue:
+			batch = append(batch, result)
+			if len(batch) >= e.config.BatchSize {
+				flush(ctx)
+			}
+		case <-ticker.C:
+			flush(ctx)
+		case <-ctx.Done():
+			// use a fresh context to try sending the remaining results
+			ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+			defer cancel()
+			flush(ctx)
+			return
+		}
+	}
+}
+
+// send sends the batch to all sinks concurrently, retrying failed sends with exponential backoff
+func (e *exporter) send(ctx context.Context, batch []policyreportv1alpha2.PolicyReportResult) {
+	var wg sync.WaitGroup
+	for _, sink := range e.sinks {
+		wg.Add(1)
+		go func(sink Sink) {
+			defer wg.Done()
+			logger := logger.WithValues("sink", sink.Name(), "results", len(batch))
+			if err := e.sendWithRetry(ctx, logger, sink, batch); err != nil {
+				logger.Error(err, "failed to export results, dropping batch")
+			} else {
+				logger.V(4).Info("results exported")
+			}
+		}(sink)
+	}
+	wg.Wait()
+}
+
+func (e *exporter) sendWithRetry(ctx context.Context, logger logr.Logger, sink Sink, batch []policyreportv1alpha2.PolicyReportResult) error {
+	backoff := wait.Backoff{
+		Duration: retryDelay,
+		Factor:   2,
+		Jitter:   0.1,
+		Steps:    e.config.MaxRetries + 1,
+	}
+	var lastErr error
+	err := wait.ExponentialBackoffWithContext(ctx, backoff, func(ctx context.Context) (bool, error) {
+		if err := sink.Send(ctx, batch); err != nil {
+			logger.V(3).Info("failed to export results", "error", err.Error())
+			lastErr = err
+			return false, nil
+		}
+		return true, nil
+	})
+	if err != nil && lastErr != nil {
+		return lastErr
+	}
+	return err
+}
@@ -0,0 +1,214 @@
+package exporter
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	policyreportv1alpha2 "github.com/kyverno/kyverno/api/policyreport/v1alpha2"
+	"gotest.tools/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+type fakeSink struct {
+	sync.Mutex
+	failures int
+	batches  [][]policyreportv1alpha2.PolicyReportResult
+}
+
+func (s *fakeSink) Name() string {
+	return "fake"
+}
+
+func (s *fakeSink) Send(_ context.Context, results []policyreportv1alpha2.PolicyReportResult) error {
+	s.Lock()
+	defer s.Unlock()
+	if s.failures > 0 {
+		s.failures--
+		return errors.New("failure")
+	}
+	s.batches = append(s.batches, results)
+	return nil
+}
+
+func (s *fakeSink) count() int {
+	s.Lock()
+	defer s.Unlock()
+	count := 0
+	for _, batch := range s.batches {
+		count += len(batch)
+	}
+	return count
+}
+
+func TestParseConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  string
+		wantErr bool
+	}{{
+		name:    "no sinks",
+		config:  `batchSize: 10`,
+		wantErr: true,
+	}, {
+		name: "webhook",
+		config: `
+flushInterval: 5s
+sinks:
+- name: audit
+  webhook:
+    url: http://localhost/results`,
+	}, {
+		name: "missing url",
+		config: `
+sinks:
+- name: audit
+  webhook: {}`,
+		wantErr: true,
+	}, {
+		name: "multiple types",
+		config: `
+sinks:
+- name: audit
+  webhook:
+    url: http://localhost/results
+  kafka:
+    restProxyUrl: http://localhost
+    topic: results`,
+		wantErr: true,
+	}, {
+		name: "unknown field",
+		config: `
+sinks:
+- name: audit
+  unknown: {}`,
+		wantErr: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config, err := ParseConfig([]byte(tt.config))
+			if tt.wantErr {
+				assert.Assert(t, err != nil)
+			} else {
+				assert.NilError(t, err)
+				assert.Equal(t, config.BatchSize, defaultBatchSize)
+				assert.Equal(t, config.MaxRetries, defaultMaxRetries)
+				assert.Equal(t, config.FlushInterval.Duration, 5*time.Second)
+			}
+		})
+	}
+}
+
+func TestExporter(t *testing.T) {
+	sink := &fakeSink{failures: 1}
+	exporter := newExporter(Config{
+		BatchSize:     2,
+		FlushInterval: Duration{time.Hour},
+		MaxRetries:    1,
+		QueueSize:     10,
+	}, sink)
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		exporter.Run(ctx, Workers)
+		close(done)
+	}()
+	exporter.Export(
+		policyreportv1alpha2.PolicyReportResult{Policy: "a"},
+		policyreportv1alpha2.PolicyReportResult{Policy: "b"},
+		policyreportv1alpha2.PolicyReportResult{Policy: "c"},
+	)
+	// the first batch is sent once full (after one retry), the remaining result is flushed on shutdown
+	assert.NilError(t, waitFor(func() bool { return sink.count() == 2 }))
+	cancel()
+	<-done
+	assert.Equal(t, sink.count(), 3)
+	assert.Equal(t, len(sink.batches), 2)
+}
+
+func TestExporterDeduplication(t *testing.T) {
+	newResult := func(uid types.UID, result policyreportv1alpha2.PolicyResult, seconds int64) policyreportv1alpha2.PolicyReportResult {
+		return policyreportv1alpha2.PolicyReportResult{
+			Policy:    "policy",
+			Rule:      "rule",
+			Result:    result,
+			Timestamp: metav1.Timestamp{Seconds: seconds},
+			Resources: []corev1.ObjectReference{{UID: uid}},
+		}
+	}
+	tests := []struct {
+		name      string
+		cacheSize int
+		want      int
+	}{{
+		name:      "enabled",
+		cacheSize: 10,
+		want:      4,
+	}, {
+		name:      "disabled",
+		cacheSize: -1,
+		want:      6,
+	}}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			exporter := newExporter(Config{QueueSize: 10, DedupCacheSize: test.cacheSize})
+			exporter.Export(
+				newResult("a", policyreportv1alpha2.StatusPass, 1),
+				// same result with a different timestamp
+				newResult("a", policyreportv1alpha2.StatusPass, 2),
+				// the result changed
+				newResult("a", policyreportv1alpha2.StatusFail, 3),
+				// the result changed back
+				newResult("a", policyreportv1alpha2.StatusPass, 4),
+				// same result for another resource
+				newResult("b", policyreportv1alpha2.StatusPass, 5),
+				newResult("b", policyreportv1alpha2.StatusPass, 6),
+			)
+			assert.Equal(t, len(exporter.queue), test.want)
+		})
+	}
+}
+
+func TestWebhookSink(t *testing.T) {
+	var received []policyreportv1alpha2.PolicyReportResult
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, r.Header.Get("Authorization"), "Bearer token")
+		assert.NilError(t, json.NewDecoder(r.Body).Decode(&received))
+	}))
+	defer server.Close()
+	sink := newWebhookSink(server.Client(), "test", WebhookConfig{
+		URL:     server.URL,
+		Headers: map[string]string{"Authorization": "Bearer token"},
+	})
+	err := sink.Send(context.Background(), []policyreportv1alpha2.PolicyReportResult{{Policy: "a", Rule: "b"}})
+	assert.NilError(t, err)
+	assert.Equal(t, len(received), 1)
+	assert.Equal(t, received[0].Policy, "a")
+}
+
+func TestWebhookSinkError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+	sink := newWebhookSink(server.Client(), "test", WebhookConfig{URL: server.URL})
+	err := sink.Send(context.Background(), []policyreportv1alpha2.PolicyReportResult{{Policy: "a"}})
+	assert.Assert(t, err != nil)
+}
+
+func waitFor(condition func() bool) error {
+	for i := 0; i < 100; i++ {
+		if condition() {
+			return nil
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+	return errors.New("timeout")
+}
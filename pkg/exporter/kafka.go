@@ -0,0 +1,59 @@
+package exporter
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+
+	policyreportv1alpha2 "github.com/kyverno/kyverno/api/policyreport/v1alpha2"
+)
+
+const kafkaContentType = "application/vnd.kafka.json.v2+json"
+
+type kafkaSink struct {
+	client *http.Client
+	name   string
+	config KafkaConfig
+}
+
+func newKafkaSink(client *http.Client, name string, config KafkaConfig) Sink {
+	return &kafkaSink{
+		client: client,
+		name:   name,
+		config: config,
+	}
+}
+
+func (s *kafkaSink) Name() string {
+	return s.name
+}
+
+type kafkaRecord struct {
+	Key   string                                  `json:"key,omitempty"`
+	Value policyreportv1alpha2.PolicyReportResult `json:"value"`
+}
+
+func (s *kafkaSink) Send(ctx context.Context, results []policyreportv1alpha2.PolicyReportResult) error {
+	records := make([]kafkaRecord, 0, len(results))
+	for _, result := range results {
+		// key records by policy so that results of the same policy land in the same partition
+		records = append(records, kafkaRecord{Key: result.Policy, Value: result})
+	}
+	body, err := json.Marshal(map[string]interface{}{"records": records})
+	if err != nil {
+		return err
+	}
+	endpoint := strings.TrimSuffix(s.config.RestProxyURL, "/") + "/topics/" + url.PathEscape(s.config.Topic)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", kafkaContentType)
+	for key, value := range s.config.Headers {
+		req.Header.Set(key, value)
+	}
+	return doRequest(s.client, req)
+}
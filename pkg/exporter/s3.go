@@ -0,0 +1,83 @@
+package exporter
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	policyreportv1alpha2 "github.com/kyverno/kyverno/api/policyreport/v1alpha2"
+	"k8s.io/apimachinery/pkg/util/uuid"
+)
+
+type s3Sink struct {
+	client      *http.Client
+	name        string
+	config      S3Config
+	credentials aws.CredentialsProvider
+	signer      *v4.Signer
+}
+
+func newS3Sink(ctx context.Context, client *http.Client, name string, config S3Config) (Sink, error) {
+	cfg, err := awsconfig.LoadDefaultConfig(ctx, awsconfig.WithRegion(config.Region))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load aws configuration: %w", err)
+	}
+	return &s3Sink{
+		client:      client,
+		name:        name,
+		config:      config,
+		credentials: cfg.Credentials,
+		signer:      v4.NewSigner(),
+	}, nil
+}
+
+func (s *s3Sink) Name() string {
+	return s.name
+}
+
+// objectURL returns the url of the object, path style addressing is used with custom endpoints
+func (s *s3Sink) objectURL(key string) string {
+	if s.config.Endpoint != "" {
+		return strings.TrimSuffix(s.config.Endpoint, "/") + "/" + url.PathEscape(s.config.Bucket) + "/" + key
+	}
+	return fmt.Sprintf("https://%s.s3.%s.amazonaws.com/%s", s.config.Bucket, s.config.Region, key)
+}
+
+func (s *s3Sink) Send(ctx context.Context, results []policyreportv1alpha2.PolicyReportResult) error {
+	var body bytes.Buffer
+	encoder := json.NewEncoder(&body)
+	for _, result := range results {
+		if err := encoder.Encode(result); err != nil {
+			return err
+		}
+	}
+	now := time.Now().UTC()
+	key := path.Join(s.config.Prefix, now.Format("2006/01/02"), fmt.Sprintf("%s-%s.jsonl", now.Format("150405"), uuid.NewUUID()))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, s.objectURL(key), bytes.NewReader(body.Bytes()))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-ndjson")
+	hash := sha256.Sum256(body.Bytes())
+	payloadHash := hex.EncodeToString(hash[:])
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+	credentials, err := s.credentials.Retrieve(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to retrieve aws credentials: %w", err)
+	}
+	if err := s.signer.SignHTTP(ctx, credentials, req, payloadHash, "s3", s.config.Region, now); err != nil {
+		return err
+	}
+	return doRequest(s.client, req)
+}
@@ -0,0 +1,48 @@
+package exporter
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+
+	policyreportv1alpha2 "github.com/kyverno/kyverno/api/policyreport/v1alpha2"
+)
+
+// Sink is a destination results are exported to
+type Sink interface {
+	// Name returns the sink name
+	Name() string
+	// Send sends a batch of results to the sink
+	Send(context.Context, []policyreportv1alpha2.PolicyReportResult) error
+}
+
+// NewSink creates a sink from its configuration
+func NewSink(ctx context.Context, client *http.Client, config SinkConfig) (Sink, error) {
+	switch {
+	case config.Webhook != nil:
+		return newWebhookSink(client, config.Name, *config.Webhook), nil
+	case config.Elasticsearch != nil:
+		return newElasticsearchSink(client, config.Name, *config.Elasticsearch), nil
+	case config.S3 != nil:
+		return newS3Sink(ctx, client, config.Name, *config.S3)
+	case config.Kafka != nil:
+		return newKafkaSink(client, config.Name, *config.Kafka), nil
+	}
+	return nil, fmt.Errorf("sink %s has no type configured", config.Name)
+}
+
+// doRequest sends an http request and returns an error if the response status is not successful
+func doRequest(client *http.Client, req *http.Request) error {
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("unexpected response status %d: %s", resp.StatusCode, string(bytes.TrimSpace(body)))
+	}
+	return nil
+}
@@ -0,0 +1,44 @@
+package exporter
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+
+	policyreportv1alpha2 "github.com/kyverno/kyverno/api/policyreport/v1alpha2"
+)
+
+type webhookSink struct {
+	client *http.Client
+	name   string
+	config WebhookConfig
+}
+
+func newWebhookSink(client *http.Client, name string, config WebhookConfig) Sink {
+	return &webhookSink{
+		client: client,
+		name:   name,
+		config: config,
+	}
+}
+
+func (s *webhookSink) Name() string {
+	return s.name
+}
+
+func (s *webhookSink) Send(ctx context.Context, results []policyreportv1alpha2.PolicyReportResult) error {
+	body, err := json.Marshal(results)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.config.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for key, value := range s.config.Headers {
+		req.Header.Set(key, value)
+	}
+	return doRequest(s.client, req)
+}
@@ -0,0 +1,94 @@
+package extauthz
+
+import (
+	"context"
+	"encoding/json"
+
+	authv3 "github.com/envoyproxy/go-control-plane/envoy/service/auth/v3"
+	typev3 "github.com/envoyproxy/go-control-plane/envoy/type/v3"
+	"github.com/go-logr/logr"
+	"github.com/kyverno/kyverno/pkg/jsonpayload"
+	rpcstatus "google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+const (
+	// Kind is the payload kind of check requests, policies select them with `json.kyverno.io/v1alpha1/CheckRequest`
+	Kind = "CheckRequest"
+	// NamespaceContextExtension is the context extension selecting the namespaced policies evaluated
+	// in addition to the cluster policies, it can be set per route in the envoy configuration
+	NamespaceContextExtension = "kyverno.io/namespace"
+	// NameContextExtension is the context extension naming the check requests of a route in the reports,
+	// results of routes without it are not reported
+	NameContextExtension = "kyverno.io/name"
+)
+
+// PayloadValidator validates the payloads built from check requests
+type PayloadValidator interface {
+	Validate(context.Context, logr.Logger, jsonpayload.Request) jsonpayload.Response
+}
+
+type handler struct {
+	authv3.UnimplementedAuthorizationServer
+	validator PayloadValidator
+}
+
+// NewHandler creates an envoy AuthorizationServer evaluating check requests against the validate rules
+// matching the `json.kyverno.io/v1alpha1/CheckRequest` kind, the attributes of the check request are available
+// to rules under `request.object.payload` with the field names of the envoy api (e.g. `request.object.payload.request.http.method`).
+func NewHandler(validator PayloadValidator) authv3.AuthorizationServer {
+	return &handler{
+		validator: validator,
+	}
+}
+
+func (h *handler) Check(ctx context.Context, in *authv3.CheckRequest) (*authv3.CheckResponse, error) {
+	attributes := in.GetAttributes()
+	if attributes == nil {
+		return nil, status.Error(codes.InvalidArgument, "attributes are required")
+	}
+	payload, err := toPayload(attributes)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to convert attributes: %v", err)
+	}
+	httpRequest := attributes.GetRequest().GetHttp()
+	request := jsonpayload.Request{
+		Kind:      Kind,
+		Name:      attributes.GetContextExtensions()[NameContextExtension],
+		Namespace: attributes.GetContextExtensions()[NamespaceContextExtension],
+		Payload:   payload,
+	}
+	logger := logger.WithValues("id", httpRequest.GetId(), "method", httpRequest.GetMethod(), "host", httpRequest.GetHost(), "path", httpRequest.GetPath())
+	response := h.validator.Validate(ctx, logger, request)
+	if response.Allowed {
+		return &authv3.CheckResponse{
+			Status:       &rpcstatus.Status{Code: int32(codes.OK)},
+			HttpResponse: &authv3.CheckResponse_OkResponse{OkResponse: &authv3.OkHttpResponse{}},
+		}, nil
+	}
+	logger.V(2).Info("denying request", "message", response.Message)
+	return &authv3.CheckResponse{
+		Status: &rpcstatus.Status{Code: int32(codes.PermissionDenied), Message: response.Message},
+		HttpResponse: &authv3.CheckResponse_DeniedResponse{
+			DeniedResponse: &authv3.DeniedHttpResponse{
+				Status: &typev3.HttpStatus{Code: typev3.StatusCode_Forbidden},
+				Body:   response.Message,
+			},
+		},
+	}, nil
+}
+
+// toPayload converts the attributes to their JSON representation, using the field names of the envoy api
+func toPayload(attributes *authv3.AttributeContext) (interface{}, error) {
+	data, err := protojson.MarshalOptions{UseProtoNames: true}.Marshal(attributes)
+	if err != nil {
+		return nil, err
+	}
+	var payload map[string]interface{}
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return nil, err
+	}
+	return payload, nil
+}
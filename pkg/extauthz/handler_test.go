@@ -0,0 +1,169 @@
+package extauthz
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	authv3 "github.com/envoyproxy/go-control-plane/envoy/service/auth/v3"
+	typev3 "github.com/envoyproxy/go-control-plane/envoy/type/v3"
+	"github.com/kyverno/kyverno/pkg/config"
+	"github.com/kyverno/kyverno/pkg/engine"
+	"github.com/kyverno/kyverno/pkg/engine/factories"
+	"github.com/kyverno/kyverno/pkg/engine/jmespath"
+	"github.com/kyverno/kyverno/pkg/imageverifycache"
+	"github.com/kyverno/kyverno/pkg/policycache"
+	yamlutils "github.com/kyverno/kyverno/pkg/utils/yaml"
+	webhookspayload "github.com/kyverno/kyverno/pkg/webhooks/payload"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gotest.tools/assert"
+)
+
+const policies = `
+apiVersion: kyverno.io/v1
+kind: ClusterPolicy
+metadata:
+  name: read-only-admin
+spec:
+  validationFailureAction: Enforce
+  background: false
+  rules:
+  - name: deny-admin-writes
+    match:
+      any:
+      - resources:
+          kinds:
+          - json.kyverno.io/v1alpha1/CheckRequest
+    validate:
+      message: "{{ request.object.payload.request.http.method }} is not allowed on {{ request.object.payload.request.http.path }}"
+      deny:
+        conditions:
+          all:
+          - key: "{{ starts_with(request.object.payload.request.http.path, '/admin') }}"
+            operator: Equals
+            value: true
+          - key: "{{ request.object.payload.request.http.method }}"
+            operator: AnyNotIn
+            value: [GET, HEAD]
+---
+apiVersion: kyverno.io/v1
+kind: Policy
+metadata:
+  name: require-tenant
+  namespace: tenant
+spec:
+  validationFailureAction: Enforce
+  background: false
+  rules:
+  - name: require-tenant-header
+    match:
+      any:
+      - resources:
+          kinds:
+          - json.kyverno.io/v1alpha1/CheckRequest
+    validate:
+      message: the x-tenant header is required
+      pattern:
+        payload:
+          request:
+            http:
+              headers:
+                x-tenant: "?*"
+`
+
+func newTestHandler(t *testing.T) authv3.AuthorizationServer {
+	cfg := config.NewDefaultConfiguration(false)
+	jp := jmespath.New(cfg)
+	eng := engine.NewEngine(
+		cfg,
+		config.NewDefaultMetricsConfiguration(),
+		jp,
+		nil,
+		nil,
+		imageverifycache.DisabledImageVerifyCache(),
+		factories.DefaultContextLoaderFactory(nil),
+		nil,
+		nil,
+		nil,
+		"",
+	)
+	pCache := policycache.NewCache()
+	policies, _, err := yamlutils.GetPolicy([]byte(policies))
+	assert.NilError(t, err)
+	for _, policy := range policies {
+		key := policy.GetName()
+		if policy.GetNamespace() != "" {
+			key = policy.GetNamespace() + "/" + key
+		}
+		assert.NilError(t, pCache.Set(key, policy, nil))
+	}
+	return NewHandler(webhookspayload.NewHandlers(eng, cfg, jp, pCache, nil))
+}
+
+func checkRequest(method, path string, headers map[string]string, contextExtensions map[string]string) *authv3.CheckRequest {
+	return &authv3.CheckRequest{
+		Attributes: &authv3.AttributeContext{
+			Request: &authv3.AttributeContext_Request{
+				Http: &authv3.AttributeContext_HttpRequest{
+					Id:      "1",
+					Method:  method,
+					Host:    "example.com",
+					Path:    path,
+					Headers: headers,
+				},
+			},
+			ContextExtensions: contextExtensions,
+		},
+	}
+}
+
+func Test_Check(t *testing.T) {
+	tests := []struct {
+		name    string
+		request *authv3.CheckRequest
+		allowed bool
+		message string
+	}{{
+		name:    "allowed",
+		request: checkRequest("POST", "/api/items", nil, nil),
+		allowed: true,
+	}, {
+		name:    "read admin",
+		request: checkRequest("GET", "/admin/users", nil, nil),
+		allowed: true,
+	}, {
+		name:    "write admin",
+		request: checkRequest("DELETE", "/admin/users", nil, nil),
+		message: "DELETE is not allowed on /admin/users",
+	}, {
+		name:    "namespaced policy",
+		request: checkRequest("GET", "/api/items", nil, map[string]string{NamespaceContextExtension: "tenant"}),
+		message: "the x-tenant header is required",
+	}, {
+		name:    "namespaced policy with header",
+		request: checkRequest("GET", "/api/items", map[string]string{"x-tenant": "acme"}, map[string]string{NamespaceContextExtension: "tenant"}),
+		allowed: true,
+	}}
+	handler := newTestHandler(t)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response, err := handler.Check(context.TODO(), tt.request)
+			assert.NilError(t, err)
+			if tt.allowed {
+				assert.Equal(t, response.GetStatus().GetCode(), int32(codes.OK))
+				assert.Assert(t, response.GetOkResponse() != nil)
+			} else {
+				assert.Equal(t, response.GetStatus().GetCode(), int32(codes.PermissionDenied))
+				assert.Equal(t, response.GetDeniedResponse().GetStatus().GetCode(), typev3.StatusCode_Forbidden)
+				assert.Assert(t, response.GetDeniedResponse().GetBody() != "")
+				assert.Assert(t, strings.Contains(response.GetStatus().GetMessage(), tt.message), response.GetStatus().GetMessage())
+			}
+		})
+	}
+}
+
+func Test_CheckWithoutAttributes(t *testing.T) {
+	_, err := newTestHandler(t).Check(context.TODO(), &authv3.CheckRequest{})
+	assert.Equal(t, status.Code(err), codes.InvalidArgument)
+}
@@ -0,0 +1,5 @@
+package extauthz
+
+import "github.com/kyverno/kyverno/pkg/logging"
+
+var logger = logging.WithName("extauthz")
@@ -0,0 +1,83 @@
+package extauthz
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"net"
+
+	authv3 "github.com/envoyproxy/go-control-plane/envoy/service/auth/v3"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+// TlsProvider returns the PEM encoded certificate and key served by the server
+type TlsProvider func() ([]byte, []byte, error)
+
+// ClientCAProvider returns the PEM encoded certificates of the authorities envoy client certificates are signed by
+type ClientCAProvider func() ([]byte, error)
+
+type Server interface {
+	// Run serves requests until the stop channel is closed
+	Run(<-chan struct{})
+}
+
+type server struct {
+	address string
+	server  *grpc.Server
+}
+
+// NewServer creates a gRPC server serving the envoy external authorization api over TLS on the given address,
+// envoy is authenticated with its client certificate, it must be signed by one of the client authorities
+func NewServer(address string, handler authv3.AuthorizationServer, tlsProvider TlsProvider, clientCAProvider ClientCAProvider) Server {
+	tlsConfig := &tls.Config{
+		// the client authorities are loaded for every handshake so that they can be rotated
+		GetConfigForClient: func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			certPem, keyPem, err := tlsProvider()
+			if err != nil {
+				return nil, err
+			}
+			pair, err := tls.X509KeyPair(certPem, keyPem)
+			if err != nil {
+				return nil, err
+			}
+			caPem, err := clientCAProvider()
+			if err != nil {
+				return nil, err
+			}
+			clientCAs := x509.NewCertPool()
+			if !clientCAs.AppendCertsFromPEM(caPem) {
+				return nil, errors.New("failed to load client authorities")
+			}
+			return &tls.Config{
+				Certificates: []tls.Certificate{pair},
+				ClientAuth:   tls.RequireAndVerifyClientCert,
+				ClientCAs:    clientCAs,
+				MinVersion:   tls.VersionTLS12,
+			}, nil
+		},
+		MinVersion: tls.VersionTLS12,
+	}
+	s := grpc.NewServer(grpc.Creds(credentials.NewTLS(tlsConfig)))
+	authv3.RegisterAuthorizationServer(s, handler)
+	return &server{
+		address: address,
+		server:  s,
+	}
+}
+
+func (s *server) Run(stopCh <-chan struct{}) {
+	listener, err := net.Listen("tcp", s.address)
+	if err != nil {
+		logger.Error(err, "failed to listen", "addr", s.address)
+		return
+	}
+	go func() {
+		logger.V(3).Info("started serving requests", "addr", s.address)
+		if err := s.server.Serve(listener); err != nil {
+			logger.Error(err, "failed to serve requests")
+		}
+	}()
+	<-stopCh
+	s.server.GracefulStop()
+}
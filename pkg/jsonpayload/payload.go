@@ -0,0 +1,89 @@
+// Package jsonpayload declares the kinds policies match to validate arbitrary JSON payloads
+// (terraform plans, cloud API payloads, ...) outside of admission requests.
+//
+// A payload of kind `TerraformPlan` is wrapped in a resource of kind `json.kyverno.io/v1alpha1/TerraformPlan`,
+// the payload itself is available to rules under `request.object.payload`.
+package jsonpayload
+
+import (
+	"errors"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+const (
+	// Group is the api group of the kinds matching JSON payloads
+	Group = "json.kyverno.io"
+	// Version is the api version of the kinds matching JSON payloads
+	Version = "v1alpha1"
+)
+
+// GroupVersion is the group version of the kinds matching JSON payloads
+var GroupVersion = schema.GroupVersion{Group: Group, Version: Version}
+
+// IsPayloadGroup returns true if the api group is the one of JSON payloads, such kinds are not served by the API server
+func IsPayloadGroup(group string) bool {
+	return group == Group
+}
+
+// GroupVersionResource returns the resource policies matching the payload kind are indexed with
+func GroupVersionResource(kind string) schema.GroupVersionResource {
+	return GroupVersion.WithResource(strings.ToLower(kind))
+}
+
+// Request is the body of a JSON payload validation request
+type Request struct {
+	// Kind is the declared kind of the payload, policies select it with `json.kyverno.io/v1alpha1/<kind>`
+	Kind string `json:"kind"`
+	// Name identifies the payload in the results
+	Name string `json:"name,omitempty"`
+	// Namespace selects the namespaced policies evaluated in addition to the cluster policies
+	Namespace string `json:"namespace,omitempty"`
+	// Payload is the JSON document to validate
+	Payload interface{} `json:"payload"`
+}
+
+// Validate checks the request declares a kind and carries a payload
+func (r Request) Validate() error {
+	if r.Kind == "" {
+		return errors.New("kind is required")
+	}
+	if strings.Contains(r.Kind, "/") {
+		return errors.New("kind must not contain a group or version")
+	}
+	if r.Payload == nil {
+		return errors.New("payload is required")
+	}
+	return nil
+}
+
+// Resource returns the resource wrapping the payload evaluated by the engine
+func (r Request) Resource() unstructured.Unstructured {
+	var resource unstructured.Unstructured
+	resource.SetAPIVersion(GroupVersion.String())
+	resource.SetKind(r.Kind)
+	resource.SetName(r.Name)
+	resource.SetNamespace(r.Namespace)
+	resource.Object["payload"] = r.Payload
+	return resource
+}
+
+// Response is the result of a JSON payload validation request
+type Response struct {
+	// Allowed is false when an enforced policy fails on the payload
+	Allowed bool `json:"allowed"`
+	// Message explains why the payload is not allowed
+	Message string `json:"message,omitempty"`
+	// Results lists the results of the rules evaluated
+	Results []Result `json:"results,omitempty"`
+}
+
+// Result is the result of a rule evaluated on a payload
+type Result struct {
+	Policy  string `json:"policy"`
+	Rule    string `json:"rule"`
+	Status  string `json:"status"`
+	Message string `json:"message,omitempty"`
+}
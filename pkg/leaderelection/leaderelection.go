@@ -3,7 +3,6 @@ package leaderelection
 import (
 	"context"
 	"fmt"
-	"os"
 	"sync/atomic"
 	"time"
 
@@ -15,7 +14,33 @@ import (
 
 const DefaultRetryPeriod = 2 * time.Second
 
-type Interface interface {
+// Config holds the leader election timings
+type Config struct {
+	// LeaseDuration is the duration non leader candidates wait before trying to acquire leadership,
+	// defaults to 6 times the retry period
+	LeaseDuration time.Duration
+	// RenewDeadline is the duration the leader retries refreshing leadership before giving it up,
+	// defaults to 5 times the retry period
+	RenewDeadline time.Duration
+	// RetryPeriod is the duration candidates wait between tries of actions, defaults to DefaultRetryPeriod
+	RetryPeriod time.Duration
+}
+
+func (c Config) withDefaults() Config {
+	if c.RetryPeriod <= 0 {
+		c.RetryPeriod = DefaultRetryPeriod
+	}
+	if c.LeaseDuration <= 0 {
+		c.LeaseDuration = 6 * c.RetryPeriod
+	}
+	if c.RenewDeadline <= 0 {
+		c.RenewDeadline = 5 * c.RetryPeriod
+	}
+	return c
+}
+
+// LeaderElector runs a leader election and reports the leadership state
+type LeaderElector interface {
 	// Run is a blocking call that runs a leader election
 	Run(ctx context.Context)
 
@@ -45,10 +70,13 @@ type config struct {
 	leaderElectionCfg leaderelection.LeaderElectionConfig
 	leaderElector     *leaderelection.LeaderElector
 	isLeader          int64
+	metrics           *metrics
 	log               logr.Logger
 }
 
-func New(log logr.Logger, name, namespace string, kubeClient kubernetes.Interface, id string, retryPeriod time.Duration, startWork func(context.Context), stopWork func()) (Interface, error) {
+// New creates a LeaderElector using a lease named after the leader election in the given namespace,
+// zero values in the config are defaulted.
+func New(log logr.Logger, name, namespace string, kubeClient kubernetes.Interface, id string, cfg Config, startWork func(context.Context), stopWork func()) (LeaderElector, error) {
 	lock, err := resourcelock.New(
 		resourcelock.LeasesResourceLock,
 		namespace,
@@ -71,15 +99,18 @@ func New(log logr.Logger, name, namespace string, kubeClient kubernetes.Interfac
 		stopWork:   stopWork,
 		log:        log.WithValues("id", lock.Identity()),
 	}
+	e.metrics = newMetrics(e, log)
+	cfg = cfg.withDefaults()
 	e.leaderElectionCfg = leaderelection.LeaderElectionConfig{
 		Lock:            e.lock,
 		ReleaseOnCancel: false,
-		LeaseDuration:   6 * retryPeriod,
-		RenewDeadline:   5 * retryPeriod,
-		RetryPeriod:     retryPeriod,
+		LeaseDuration:   cfg.LeaseDuration,
+		RenewDeadline:   cfg.RenewDeadline,
+		RetryPeriod:     cfg.RetryPeriod,
 		Callbacks: leaderelection.LeaderCallbacks{
 			OnStartedLeading: func(ctx context.Context) {
 				atomic.StoreInt64(&e.isLeader, 1)
+				e.metrics.recordTransition(ctx, transitionStarted)
 				e.log.Info("started leading")
 				if e.startWork != nil {
 					e.startWork(ctx)
@@ -87,12 +118,14 @@ func New(log logr.Logger, name, namespace string, kubeClient kubernetes.Interfac
 			},
 			OnStoppedLeading: func() {
 				atomic.StoreInt64(&e.isLeader, 0)
+				e.metrics.recordTransition(context.Background(), transitionStopped)
 				e.log.Info("leadership lost, stopped leading")
 				if e.stopWork != nil {
 					e.stopWork()
 				}
 			},
 			OnNewLeader: func(identity string) {
+				e.metrics.recordTransition(context.Background(), transitionNewLeader)
 				if identity == e.lock.Identity() {
 					e.log.Info("still leading")
 				} else {
@@ -103,8 +136,7 @@ func New(log logr.Logger, name, namespace string, kubeClient kubernetes.Interfac
 	}
 	e.leaderElector, err = leaderelection.NewLeaderElector(e.leaderElectionCfg)
 	if err != nil {
-		e.log.Error(err, "failed to create leaderElector")
-		os.Exit(1)
+		return nil, fmt.Errorf("error initializing leader elector: %s/%s: %w", namespace, name, err)
 	}
 	if e.leaderElectionCfg.WatchDog != nil {
 		e.leaderElectionCfg.WatchDog.SetLeaderElection(e.leaderElector)
@@ -0,0 +1,49 @@
+package leaderelection
+
+import (
+	"testing"
+	"time"
+
+	"gotest.tools/assert"
+)
+
+func TestConfig_withDefaults(t *testing.T) {
+	tests := []struct {
+		name   string
+		config Config
+		want   Config
+	}{{
+		name:   "empty",
+		config: Config{},
+		want: Config{
+			LeaseDuration: 12 * time.Second,
+			RenewDeadline: 10 * time.Second,
+			RetryPeriod:   2 * time.Second,
+		},
+	}, {
+		name:   "retry period",
+		config: Config{RetryPeriod: time.Second},
+		want: Config{
+			LeaseDuration: 6 * time.Second,
+			RenewDeadline: 5 * time.Second,
+			RetryPeriod:   time.Second,
+		},
+	}, {
+		name: "all set",
+		config: Config{
+			LeaseDuration: 30 * time.Second,
+			RenewDeadline: 20 * time.Second,
+			RetryPeriod:   5 * time.Second,
+		},
+		want: Config{
+			LeaseDuration: 30 * time.Second,
+			RenewDeadline: 20 * time.Second,
+			RetryPeriod:   5 * time.Second,
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.config.withDefaults(), tt.want)
+		})
+	}
+}
@@ -0,0 +1,81 @@
+package leaderelection
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	kyvernometrics "github.com/kyverno/kyverno/pkg/metrics"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+type transition string
+
+const (
+	// transitionStarted is recorded when this instance acquires leadership
+	transitionStarted transition = "started_leading"
+	// transitionStopped is recorded when this instance loses leadership
+	transitionStopped transition = "stopped_leading"
+	// transitionNewLeader is recorded when a new leader is observed
+	transitionNewLeader transition = "new_leader"
+)
+
+type metrics struct {
+	transitionsMetric metric.Int64Counter
+	isLeaderMetric    metric.Int64ObservableGauge
+	elector           *config
+}
+
+func newMetrics(elector *config, logger logr.Logger) *metrics {
+	m := &metrics{
+		elector: elector,
+	}
+	meter := otel.GetMeterProvider().Meter(kyvernometrics.MeterName)
+	transitionsMetric, err := meter.Int64Counter(
+		"kyverno_leader_election_transitions",
+		metric.WithDescription("can be used to track leadership transitions (started leading, stopped leading and new leader observed) of Kyverno instances"),
+	)
+	if err != nil {
+		logger.Error(err, "Failed to create instrument, kyverno_leader_election_transitions")
+	} else {
+		m.transitionsMetric = transitionsMetric
+	}
+	isLeaderMetric, err := meter.Int64ObservableGauge(
+		"kyverno_leader_election_is_leader",
+		metric.WithDescription("can be used to know if a Kyverno instance is currently the leader (1) or not (0)"),
+	)
+	if err != nil {
+		logger.Error(err, "Failed to create instrument, kyverno_leader_election_is_leader")
+	} else {
+		m.isLeaderMetric = isLeaderMetric
+		if _, err := meter.RegisterCallback(m.observeIsLeader, m.isLeaderMetric); err != nil {
+			logger.Error(err, "Failed to register callback")
+		}
+	}
+	return m
+}
+
+func (m *metrics) attributes() []attribute.KeyValue {
+	return []attribute.KeyValue{
+		attribute.String("lease_name", m.elector.name),
+		attribute.String("lease_namespace", m.elector.namespace),
+	}
+}
+
+func (m *metrics) recordTransition(ctx context.Context, transition transition) {
+	if m.transitionsMetric == nil {
+		return
+	}
+	attributes := append(m.attributes(), attribute.String("transition", string(transition)))
+	m.transitionsMetric.Add(ctx, 1, metric.WithAttributes(attributes...))
+}
+
+func (m *metrics) observeIsLeader(_ context.Context, observer metric.Observer) error {
+	var value int64
+	if m.elector.IsLeader() {
+		value = 1
+	}
+	observer.ObserveInt64(m.isLeaderMetric, value, metric.WithAttributes(m.attributes()...))
+	return nil
+}
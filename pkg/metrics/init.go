@@ -34,13 +34,14 @@ func InitMetrics(
 				endpoint,
 				transportCreds,
 				kubeClient,
+				metricsConfiguration,
 				logger,
 			)
 			if err != nil {
 				return nil, nil, nil, err
 			}
 		} else if otelProvider == "prometheus" {
-			meterProvider, metricsServerMux, err = NewPrometheusConfig(ctx, logger)
+			meterProvider, metricsServerMux, err = NewPrometheusConfig(ctx, metricsConfiguration, logger)
 			if err != nil {
 				return nil, nil, nil, err
 			}
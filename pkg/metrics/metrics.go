@@ -105,6 +105,7 @@ func NewOTLPGRPCConfig(
 	endpoint string,
 	certs string,
 	kubeClient kubernetes.Interface,
+	metricsConfiguration kconfig.MetricsConfiguration,
 	log logr.Logger,
 ) (metric.MeterProvider, error) {
 	options := []otlpmetricgrpc.Option{otlpmetricgrpc.WithEndpoint(endpoint), otlpmetricgrpc.WithAggregationSelector(aggregationSelector)}
@@ -145,12 +146,14 @@ func NewOTLPGRPCConfig(
 	provider := sdkmetric.NewMeterProvider(
 		sdkmetric.WithReader(reader),
 		sdkmetric.WithResource(res),
+		sdkmetric.WithView(buildViews(metricsConfiguration)...),
 	)
 	return provider, nil
 }
 
 func NewPrometheusConfig(
 	ctx context.Context,
+	metricsConfiguration kconfig.MetricsConfiguration,
 	log logr.Logger,
 ) (metric.MeterProvider, *http.ServeMux, error) {
 	res, err := resource.Merge(
@@ -178,6 +181,7 @@ func NewPrometheusConfig(
 	provider := sdkmetric.NewMeterProvider(
 		sdkmetric.WithReader(exporter),
 		sdkmetric.WithResource(res),
+		sdkmetric.WithView(buildViews(metricsConfiguration)...),
 	)
 	metricsServerMux := http.NewServeMux()
 	metricsServerMux.Handle(config.MetricsPath, promhttp.Handler())
@@ -0,0 +1,52 @@
+package metrics
+
+import (
+	kconfig "github.com/kyverno/kyverno/pkg/config"
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/aggregation"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// buildViews creates the views applying the metrics exposure configuration.
+// Views are created once with the meter provider, changes in the configuration require a restart.
+func buildViews(metricsConfiguration kconfig.MetricsConfiguration) []sdkmetric.View {
+	if metricsConfiguration == nil {
+		return nil
+	}
+	var views []sdkmetric.View
+	for name, exposure := range metricsConfiguration.GetMetricsExposure() {
+		views = append(views, newView(name, exposure))
+	}
+	return views
+}
+
+func newView(name string, exposure kconfig.MetricExposureConfig) sdkmetric.View {
+	return func(instrument sdkmetric.Instrument) (sdkmetric.Stream, bool) {
+		if instrument.Name != name {
+			return sdkmetric.Stream{}, false
+		}
+		stream := sdkmetric.Stream{
+			Name:        instrument.Name,
+			Description: instrument.Description,
+			Unit:        instrument.Unit,
+		}
+		if !exposure.IsEnabled() {
+			stream.Aggregation = aggregation.Drop{}
+			return stream, true
+		}
+		if len(exposure.DisabledLabelDimensions) > 0 {
+			disabled := sets.New(exposure.DisabledLabelDimensions...)
+			stream.AttributeFilter = func(kv attribute.KeyValue) bool {
+				return !disabled.Has(string(kv.Key))
+			}
+		}
+		if len(exposure.BucketBoundaries) > 0 && instrument.Kind == sdkmetric.InstrumentKindHistogram {
+			stream.Aggregation = aggregation.ExplicitBucketHistogram{
+				Boundaries: exposure.BucketBoundaries,
+				NoMinMax:   false,
+			}
+		}
+		return stream, true
+	}
+}
@@ -0,0 +1,74 @@
+package metrics
+
+import (
+	"context"
+	"testing"
+
+	kconfig "github.com/kyverno/kyverno/pkg/config"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"gotest.tools/assert"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func Test_buildViews(t *testing.T) {
+	metricsConfiguration := kconfig.NewDefaultMetricsConfiguration()
+	metricsConfiguration.Load(&corev1.ConfigMap{
+		Data: map[string]string{
+			"metricsExposure": `{
+				"kyverno_policy_results": {"disabledLabelDimensions": ["resource_namespace"]},
+				"kyverno_client_queries": {"enabled": false},
+				"kyverno_policy_execution_duration_seconds": {"bucketBoundaries": [0.5, 1]}
+			}`,
+		},
+	})
+	ctx := context.Background()
+	reader := sdkmetric.NewManualReader()
+	provider := sdkmetric.NewMeterProvider(
+		sdkmetric.WithReader(reader),
+		sdkmetric.WithView(buildViews(metricsConfiguration)...),
+	)
+	meter := provider.Meter(MeterName)
+	results, err := meter.Int64Counter("kyverno_policy_results")
+	assert.NilError(t, err)
+	queries, err := meter.Int64Counter("kyverno_client_queries")
+	assert.NilError(t, err)
+	duration, err := meter.Float64Histogram("kyverno_policy_execution_duration_seconds")
+	assert.NilError(t, err)
+	changes, err := meter.Int64Counter("kyverno_policy_changes")
+	assert.NilError(t, err)
+	attributes := metric.WithAttributes(
+		attribute.String("policy_name", "require-labels"),
+		attribute.String("resource_namespace", "default"),
+	)
+	results.Add(ctx, 1, attributes)
+	queries.Add(ctx, 1, attributes)
+	duration.Record(ctx, 0.1, attributes)
+	changes.Add(ctx, 1, attributes)
+	var data metricdata.ResourceMetrics
+	assert.NilError(t, reader.Collect(ctx, &data))
+	metrics := map[string]metricdata.Aggregation{}
+	for _, scope := range data.ScopeMetrics {
+		for _, m := range scope.Metrics {
+			metrics[m.Name] = m.Data
+		}
+	}
+	// disabled metric families are dropped
+	_, ok := metrics["kyverno_client_queries"]
+	assert.Assert(t, !ok)
+	// disabled label dimensions are removed
+	sum := metrics["kyverno_policy_results"].(metricdata.Sum[int64])
+	assert.Equal(t, len(sum.DataPoints), 1)
+	_, ok = sum.DataPoints[0].Attributes.Value("resource_namespace")
+	assert.Assert(t, !ok)
+	_, ok = sum.DataPoints[0].Attributes.Value("policy_name")
+	assert.Assert(t, ok)
+	// histogram buckets are overridden
+	histogram := metrics["kyverno_policy_execution_duration_seconds"].(metricdata.Histogram[float64])
+	assert.DeepEqual(t, histogram.DataPoints[0].Bounds, []float64{0.5, 1})
+	// metrics without configuration are left untouched
+	sum = metrics["kyverno_policy_changes"].(metricdata.Sum[int64])
+	assert.Equal(t, sum.DataPoints[0].Attributes.Len(), 2)
+}
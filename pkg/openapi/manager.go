@@ -147,6 +147,13 @@ func (o *manager) ValidatePolicyMutation(policy kyvernov1.PolicyInterface) error
 		if !ok {
 			continue
 		}
+		if schema := o.lookupSchema(kind); schema != nil && kind != "*" {
+			for _, rule := range rules {
+				if err := validatePatches(rule, kind, schema); err != nil {
+					return err
+				}
+			}
+		}
 		resource, _ := o.generateEmptyResource(d).(map[string]interface{})
 		if len(resource) == 0 {
 			o.logger.V(2).Info("unable to validate resource. OpenApi definition not found", "kind", kind)
@@ -213,3 +213,54 @@ func Test_Ingress(t *testing.T) {
 	definitionName, _ = o.gvkToDefinitionName.Get("networking.k8s.io/v1/Ingress")
 	assert.Equal(t, definitionName, "io.k8s.api.networking.v1.Ingress")
 }
+
+func Test_ValidatePolicyMutation_Patches(t *testing.T) {
+	tcs := []struct {
+		description string
+		policy      []byte
+		wantErr     string
+	}{{
+		description: "json patch removing an undeclared field",
+		policy:      []byte(`{"apiVersion":"kyverno.io/v1","kind":"ClusterPolicy","metadata":{"name":"p"},"spec":{"rules":[{"name":"r","match":{"any":[{"resources":{"kinds":["Service"]}}]},"mutate":{"patchesJson6902":"- op: remove\n  path: /spec/unknown"}}]}}`),
+		wantErr:     "mutate rule r: invalid patchesJson6902: path /spec/unknown targets field spec.unknown which is not declared in the schema of Service",
+	}, {
+		description: "json patch using a field name as an array index",
+		policy:      []byte(`{"apiVersion":"kyverno.io/v1","kind":"ClusterPolicy","metadata":{"name":"p"},"spec":{"rules":[{"name":"r","match":{"any":[{"resources":{"kinds":["Service"]}}]},"mutate":{"patchesJson6902":"- op: replace\n  path: /spec/ports/http/port\n  value: 80"}}]}}`),
+		wantErr:     "mutate rule r: invalid patchesJson6902: path /spec/ports/http/port uses http as an index of the array spec.ports",
+	}, {
+		description: "json patch traversing a primitive field",
+		policy:      []byte(`{"apiVersion":"kyverno.io/v1","kind":"ClusterPolicy","metadata":{"name":"p"},"spec":{"rules":[{"name":"r","match":{"any":[{"resources":{"kinds":["Service"]}}]},"mutate":{"patchesJson6902":"- op: add\n  path: /spec/type/value\n  value: foo"}}]}}`),
+		wantErr:     "mutate rule r: invalid patchesJson6902: path /spec/type/value targets a child of spec.type which is a string in the schema of Service",
+	}, {
+		description: "json patch with a value of the wrong shape",
+		policy:      []byte(`{"apiVersion":"kyverno.io/v1","kind":"ClusterPolicy","metadata":{"name":"p"},"spec":{"rules":[{"name":"r","match":{"any":[{"resources":{"kinds":["Service"]}}]},"mutate":{"patchesJson6902":"- op: add\n  path: /spec/ports\n  value: {\"port\": 80}"}}]}}`),
+		wantErr:     "mutate rule r: invalid patchesJson6902: field spec.ports expects an array in the schema of Service",
+	}, {
+		description: "json patch moving from an undeclared field",
+		policy:      []byte(`{"apiVersion":"kyverno.io/v1","kind":"ClusterPolicy","metadata":{"name":"p"},"spec":{"rules":[{"name":"r","match":{"any":[{"resources":{"kinds":["Service"]}}]},"mutate":{"patchesJson6902":"- op: move\n  from: /spec/unknown\n  path: /metadata/labels/foo"}}]}}`),
+		wantErr:     "mutate rule r: invalid patchesJson6902: path /spec/unknown targets field spec.unknown which is not declared in the schema of Service",
+	}, {
+		description: "valid json patches",
+		policy:      []byte(`{"apiVersion":"kyverno.io/v1","kind":"ClusterPolicy","metadata":{"name":"p"},"spec":{"rules":[{"name":"r","match":{"any":[{"resources":{"kinds":["Service"]}}]},"mutate":{"patchesJson6902":"- op: add\n  path: /metadata/labels/app~1name\n  value: foo\n- op: remove\n  path: /spec/ports/0/nodePort\n- op: add\n  path: /spec/ports/-\n  value: {\"port\": 80}"}}]}}`),
+	}, {
+		description: "strategic merge patch with an undeclared field in a foreach",
+		policy:      []byte(`{"apiVersion":"kyverno.io/v1","kind":"ClusterPolicy","metadata":{"name":"p"},"spec":{"rules":[{"name":"r","match":{"any":[{"resources":{"kinds":["Service"]}}]},"mutate":{"foreach":[{"list":"request.object.spec.ports","patchStrategicMerge":{"spec":{"ports":[{"(name)":"{{element.name}}","unknown":"foo"}]}}}]}}]}}`),
+		wantErr:     "mutate rule r: invalid patchStrategicMerge: field spec.ports[].unknown is not declared in the schema of Service",
+	}, {
+		description: "strategic merge patch with anchors and variables",
+		policy:      []byte(`{"apiVersion":"kyverno.io/v1","kind":"ClusterPolicy","metadata":{"name":"p"},"spec":{"rules":[{"name":"r","match":{"any":[{"resources":{"kinds":["Service"]}}]},"mutate":{"patchStrategicMerge":{"metadata":{"labels":{"+(app)":"{{request.object.metadata.name}}"}},"spec":{"ports":[{"(name)":"http","port":"{{port}}"}]}}}}]}}`),
+	}}
+	o, _ := NewManager(logr.Discard())
+	for _, tc := range tcs {
+		t.Run(tc.description, func(t *testing.T) {
+			policy := v1.ClusterPolicy{}
+			assert.NilError(t, json.Unmarshal(tc.policy, &policy))
+			err := o.ValidatePolicyMutation(&policy)
+			if tc.wantErr == "" {
+				assert.NilError(t, err)
+			} else {
+				assert.Error(t, err, tc.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,268 @@
+package openapi
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	jsonpatch "github.com/evanphx/json-patch/v5"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/engine/anchor"
+	"github.com/kyverno/kyverno/pkg/utils/api"
+	"k8s.io/kube-openapi/pkg/util/proto"
+	"sigs.k8s.io/yaml"
+)
+
+// lookupSchema returns the schema of a kind, nil when the kind is unknown
+func (o *manager) lookupSchema(kind string) proto.Schema {
+	definitionName, ok := o.gvkToDefinitionName.Get(kind)
+	if !ok {
+		return nil
+	}
+	if schema := o.models.LookupModel(definitionName); schema != nil {
+		return schema
+	}
+	schema, err := o.getCRDSchema(definitionName)
+	if err != nil {
+		return nil
+	}
+	return schema
+}
+
+// validatePatches checks the fields targeted by the patches of a mutate rule are declared in the schema of the kind,
+// it catches patches that the resource produced by forcing the mutation cannot reveal (removed or replaced paths,
+// invalid array indices, foreach patches)
+func validatePatches(rule kyvernov1.Rule, kind string, schema proto.Schema) error {
+	if err := validateMutationPatches(rule.Mutation.GetPatchStrategicMerge(), rule.Mutation.PatchesJSON6902, kind, schema); err != nil {
+		return fmt.Errorf("mutate rule %s: %w", rule.Name, err)
+	}
+	if err := validateForEachPatches(rule.Mutation.ForEachMutation, kind, schema); err != nil {
+		return fmt.Errorf("mutate rule %s: %w", rule.Name, err)
+	}
+	return nil
+}
+
+func validateForEachPatches(foreach []kyvernov1.ForEachMutation, kind string, schema proto.Schema) error {
+	for _, fe := range foreach {
+		if fe.ForEachMutation != nil {
+			nested, err := api.DeserializeJSONArray[kyvernov1.ForEachMutation](fe.ForEachMutation)
+			if err != nil {
+				return fmt.Errorf("failed to deserialize foreach: %w", err)
+			}
+			if err := validateForEachPatches(nested, kind, schema); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := validateMutationPatches(fe.GetPatchStrategicMerge(), fe.PatchesJSON6902, kind, schema); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func validateMutationPatches(patchStrategicMerge interface{}, patchesJSON6902 string, kind string, schema proto.Schema) error {
+	if patchStrategicMerge != nil {
+		if err := validateValue(schema, patchStrategicMerge, "", kind, true); err != nil {
+			return fmt.Errorf("invalid patchStrategicMerge: %w", err)
+		}
+	}
+	if patchesJSON6902 != "" {
+		if err := validateJSONPatches(schema, patchesJSON6902, kind); err != nil {
+			return fmt.Errorf("invalid patchesJson6902: %w", err)
+		}
+	}
+	return nil
+}
+
+func validateJSONPatches(schema proto.Schema, patches string, kind string) error {
+	raw := []byte(patches)
+	if !strings.HasPrefix(strings.TrimSpace(patches), "[") {
+		converted, err := yaml.YAMLToJSON(raw)
+		if err != nil {
+			// patches using variables may only be valid once substituted, they are checked at runtime
+			return nil
+		}
+		raw = converted
+	}
+	decoded, err := jsonpatch.DecodePatch(raw)
+	if err != nil {
+		return nil
+	}
+	for _, operation := range decoded {
+		path, err := operation.Path()
+		if err != nil {
+			continue
+		}
+		target, field, err := lookupPointer(schema, path, kind)
+		if err != nil {
+			return err
+		}
+		switch operation.Kind() {
+		case "add", "replace", "test":
+			value, err := operation.ValueInterface()
+			if err != nil {
+				continue
+			}
+			if err := validateValue(target, value, field, kind, false); err != nil {
+				return err
+			}
+		case "move", "copy":
+			from, err := operation.From()
+			if err != nil {
+				continue
+			}
+			if _, _, err := lookupPointer(schema, from, kind); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// lookupPointer returns the schema and the path of the field targeted by a JSON pointer,
+// the schema is nil when the field accepts any value
+func lookupPointer(schema proto.Schema, pointer string, kind string) (proto.Schema, string, error) {
+	if pointer == "" || pointer == "/" {
+		return schema, "", nil
+	}
+	field := ""
+	for _, token := range strings.Split(strings.TrimPrefix(pointer, "/"), "/") {
+		token = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+		schema = resolveSchema(schema)
+		if schema == nil {
+			return nil, field, nil
+		}
+		switch typed := schema.(type) {
+		case *proto.Kind:
+			if isVariable(token) || acceptsUnknownFields(typed) {
+				return nil, field, nil
+			}
+			next, ok := typed.Fields[token]
+			if !ok {
+				return nil, field, fmt.Errorf("path %s targets field %s which is not declared in the schema of %s", pointer, joinField(field, token), kind)
+			}
+			field = joinField(field, token)
+			schema = next
+		case *proto.Map:
+			field = joinField(field, token)
+			schema = typed.SubType
+		case *proto.Array:
+			if _, err := strconv.Atoi(token); err != nil && token != "-" && !isVariable(token) {
+				return nil, field, fmt.Errorf("path %s uses %s as an index of the array %s", pointer, token, field)
+			}
+			field += "[]"
+			schema = typed.SubType
+		case *proto.Primitive:
+			return nil, field, fmt.Errorf("path %s targets a child of %s which is a %s in the schema of %s", pointer, field, typed.Type, kind)
+		default:
+			return nil, field, nil
+		}
+	}
+	return schema, field, nil
+}
+
+// validateValue checks a patch value matches the schema, anchors are only allowed in strategic merge patches
+func validateValue(schema proto.Schema, value interface{}, field string, kind string, anchors bool) error {
+	schema = resolveSchema(schema)
+	if schema == nil || isVariableValue(value) || value == nil {
+		return nil
+	}
+	switch typed := schema.(type) {
+	case *proto.Kind:
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("field %s expects an object in the schema of %s", displayField(field), kind)
+		}
+		if acceptsUnknownFields(typed) {
+			return nil
+		}
+		for key, child := range object {
+			// strategic merge directives ($patch, $retainKeys, $setElementOrder/...) are not fields
+			if strings.HasPrefix(key, "$") || isVariable(key) {
+				continue
+			}
+			name := key
+			if anchors {
+				if a := anchor.Parse(key); a != nil {
+					name = a.Key()
+				}
+			}
+			next, ok := typed.Fields[name]
+			if !ok {
+				return fmt.Errorf("field %s is not declared in the schema of %s", joinField(field, name), kind)
+			}
+			if err := validateValue(next, child, joinField(field, name), kind, anchors); err != nil {
+				return err
+			}
+		}
+	case *proto.Map:
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("field %s expects an object in the schema of %s", displayField(field), kind)
+		}
+		for key, child := range object {
+			if err := validateValue(typed.SubType, child, joinField(field, key), kind, anchors); err != nil {
+				return err
+			}
+		}
+	case *proto.Array:
+		items, ok := value.([]interface{})
+		if !ok {
+			return fmt.Errorf("field %s expects an array in the schema of %s", displayField(field), kind)
+		}
+		for _, item := range items {
+			if err := validateValue(typed.SubType, item, field+"[]", kind, anchors); err != nil {
+				return err
+			}
+		}
+	case *proto.Primitive:
+		switch value.(type) {
+		case map[string]interface{}, []interface{}:
+			return fmt.Errorf("field %s expects a %s in the schema of %s", displayField(field), typed.Type, kind)
+		}
+	}
+	return nil
+}
+
+func resolveSchema(schema proto.Schema) proto.Schema {
+	for {
+		reference, ok := schema.(proto.Reference)
+		if !ok {
+			return schema
+		}
+		schema = reference.SubSchema()
+	}
+}
+
+// acceptsUnknownFields returns true when an object schema does not restrict its fields
+func acceptsUnknownFields(kind *proto.Kind) bool {
+	if len(kind.Fields) == 0 {
+		return true
+	}
+	preserve, _ := kind.GetExtensions()["x-kubernetes-preserve-unknown-fields"].(bool)
+	return preserve
+}
+
+func isVariable(value string) bool {
+	return strings.Contains(value, "{{")
+}
+
+func isVariableValue(value interface{}) bool {
+	s, ok := value.(string)
+	return ok && isVariable(s)
+}
+
+func joinField(parent, child string) string {
+	if parent == "" {
+		return child
+	}
+	return parent + "." + child
+}
+
+func displayField(field string) string {
+	if field == "" {
+		return "<root>"
+	}
+	return field
+}
@@ -0,0 +1,138 @@
+package policy
+
+import (
+	"context"
+	"time"
+
+	"github.com/kyverno/kyverno/api/kyverno"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/background/common"
+	generateutils "github.com/kyverno/kyverno/pkg/background/generate"
+	controllerutils "github.com/kyverno/kyverno/pkg/utils/controller"
+	kubeutils "github.com/kyverno/kyverno/pkg/utils/kube"
+	"go.uber.org/multierr"
+	"golang.org/x/exp/slices"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/tools/cache"
+)
+
+// downstreamCleanupTimeout is the delay after which the downstream cleanup finalizer is removed from a deleted policy
+// even if its generated resources could not be deleted, the policy deletion would be blocked forever otherwise
+const downstreamCleanupTimeout = 5 * time.Minute
+
+// deletesDownstreamOnPolicyDelete returns true if a generate rule of the policy does not orphan its generated resources
+func deletesDownstreamOnPolicyDelete(policy kyvernov1.PolicyInterface) bool {
+	for _, rule := range policy.GetSpec().Rules {
+		if rule.HasGenerate() && !rule.Generation.IsOrphanDownstreamOnPolicyDelete() {
+			return true
+		}
+	}
+	return false
+}
+
+func hasDownstreamCleanupFinalizer(policy kyvernov1.PolicyInterface) bool {
+	return slices.Contains(policy.GetFinalizers(), common.GenerateDownstreamCleanupFinalizer)
+}
+
+// needsFinalizerSync returns true if the downstream cleanup finalizer of the policy must be added, removed or processed
+func needsFinalizerSync(policy kyvernov1.PolicyInterface) bool {
+	if policy.GetDeletionTimestamp() != nil {
+		return hasDownstreamCleanupFinalizer(policy)
+	}
+	return hasDownstreamCleanupFinalizer(policy) != deletesDownstreamOnPolicyDelete(policy)
+}
+
+// syncFinalizer adds or removes the downstream cleanup finalizer depending on the generate rules of the policy,
+// when the policy is being deleted it deletes the generated resources before removing the finalizer.
+// The finalizer is removed anyway once the deletion is pending for longer than the downstream cleanup timeout.
+// It returns true if the policy is being deleted.
+func (pc *policyController) syncFinalizer(ctx context.Context, policy kyvernov1.PolicyInterface) (bool, error) {
+	deleting := policy.GetDeletionTimestamp() != nil
+	if !needsFinalizerSync(policy) {
+		return deleting, nil
+	}
+	if deleting {
+		pc.log.V(2).Info("deleting generated resources of deleted policy", "namespace", policy.GetNamespace(), "name", policy.GetName())
+		if err := pc.deleteDownstreams(ctx, policy); err != nil {
+			remaining := time.Until(policy.GetDeletionTimestamp().Add(pc.downstreamCleanupTimeout))
+			if remaining > 0 {
+				// retries are rate limited and eventually dropped, make sure the policy is processed again once the timeout expires
+				if key, err := cache.MetaNamespaceKeyFunc(policy); err == nil {
+					pc.queue.AddAfter(key, remaining)
+				}
+				return deleting, err
+			}
+			pc.log.Error(err, "failed to delete generated resources before timeout, removing finalizer and orphaning them", "namespace", policy.GetNamespace(), "name", policy.GetName(), "timeout", pc.downstreamCleanupTimeout)
+		}
+	}
+	var finalizers []string
+	for _, finalizer := range policy.GetFinalizers() {
+		if finalizer != common.GenerateDownstreamCleanupFinalizer {
+			finalizers = append(finalizers, finalizer)
+		}
+	}
+	if !deleting && deletesDownstreamOnPolicyDelete(policy) {
+		finalizers = append(finalizers, common.GenerateDownstreamCleanupFinalizer)
+	}
+	return deleting, pc.updateFinalizers(ctx, policy, finalizers)
+}
+
+func (pc *policyController) updateFinalizers(ctx context.Context, policy kyvernov1.PolicyInterface, finalizers []string) error {
+	var err error
+	if policy.GetNamespace() == "" {
+		_, err = controllerutils.Update(ctx, policy.(*kyvernov1.ClusterPolicy), pc.kyvernoClient.KyvernoV1().ClusterPolicies(), func(policy *kyvernov1.ClusterPolicy) error {
+			policy.SetFinalizers(finalizers)
+			return nil
+		})
+	} else {
+		_, err = controllerutils.Update(ctx, policy.(*kyvernov1.Policy), pc.kyvernoClient.KyvernoV1().Policies(policy.GetNamespace()), func(policy *kyvernov1.Policy) error {
+			policy.SetFinalizers(finalizers)
+			return nil
+		})
+	}
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+	return err
+}
+
+// deleteDownstreams deletes the resources generated by the rules that do not orphan them, resources are found with the labels set on generation
+func (pc *policyController) deleteDownstreams(ctx context.Context, policy kyvernov1.PolicyInterface) error {
+	var errs []error
+	for _, rule := range policy.GetSpec().Rules {
+		if !rule.HasGenerate() || rule.Generation.IsOrphanDownstreamOnPolicyDelete() {
+			continue
+		}
+		selector := map[string]string{
+			common.GeneratePolicyLabel:          policy.GetName(),
+			common.GeneratePolicyNamespaceLabel: policy.GetNamespace(),
+			common.GenerateRuleLabel:            rule.Name,
+			kyverno.LabelAppManagedBy:           kyverno.ValueKyvernoApp,
+		}
+		// resources are deleted by the client that generated them
+		client, err := generateutils.RuleClient(pc.log, pc.client, pc.impersonatingClient, pc.remoteClient, pc.configuration, policy, rule)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		deleteAll := func(apiVersion, kind string) {
+			downstreams, err := generateutils.FindDownstream(client, apiVersion, kind, selector)
+			if err != nil {
+				errs = append(errs, err)
+				return
+			}
+			for _, downstream := range downstreams.Items {
+				if err := client.DeleteResource(ctx, downstream.GetAPIVersion(), downstream.GetKind(), downstream.GetNamespace(), downstream.GetName(), false); err != nil && !apierrors.IsNotFound(err) {
+					errs = append(errs, err)
+				}
+			}
+		}
+		if rule.Generation.GetKind() != "" {
+			deleteAll(rule.Generation.GetAPIVersion(), rule.Generation.GetKind())
+		}
+		for _, kind := range rule.Generation.CloneList.Kinds {
+			deleteAll(kubeutils.GetKindFromGVK(kind))
+		}
+	}
+	return multierr.Combine(errs...)
+}
@@ -0,0 +1,195 @@
+package policy
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/kyverno/kyverno/api/kyverno"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/background/common"
+	"github.com/kyverno/kyverno/pkg/client/clientset/versioned/fake"
+	"github.com/kyverno/kyverno/pkg/clients/dclient"
+	"gotest.tools/assert"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/util/workqueue"
+)
+
+func Test_needsFinalizerSync(t *testing.T) {
+	orphan := false
+	newPolicy := func(orphanDownstream *bool, finalizers []string, deleted bool) *kyvernov1.ClusterPolicy {
+		policy := &kyvernov1.ClusterPolicy{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:       "test",
+				Finalizers: finalizers,
+			},
+			Spec: kyvernov1.Spec{
+				Rules: []kyvernov1.Rule{{
+					Name: "generate",
+					Generation: kyvernov1.Generation{
+						ResourceSpec: kyvernov1.ResourceSpec{
+							APIVersion: "v1",
+							Kind:       "ConfigMap",
+							Name:       "test",
+						},
+						OrphanDownstreamOnPolicyDelete: orphanDownstream,
+					},
+				}},
+			},
+		}
+		if deleted {
+			now := metav1.Now()
+			policy.SetDeletionTimestamp(&now)
+		}
+		return policy
+	}
+	finalizers := []string{common.GenerateDownstreamCleanupFinalizer}
+	tests := []struct {
+		name   string
+		policy *kyvernov1.ClusterPolicy
+		want   bool
+	}{{
+		name:   "orphan by default",
+		policy: newPolicy(nil, nil, false),
+		want:   false,
+	}, {
+		name:   "finalizer missing",
+		policy: newPolicy(&orphan, nil, false),
+		want:   true,
+	}, {
+		name:   "finalizer present",
+		policy: newPolicy(&orphan, finalizers, false),
+		want:   false,
+	}, {
+		name:   "finalizer no longer needed",
+		policy: newPolicy(nil, finalizers, false),
+		want:   true,
+	}, {
+		name:   "deleted with finalizer",
+		policy: newPolicy(&orphan, finalizers, true),
+		want:   true,
+	}, {
+		name:   "deleted without finalizer",
+		policy: newPolicy(&orphan, nil, true),
+		want:   false,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, needsFinalizerSync(tt.policy), tt.want)
+		})
+	}
+}
+
+// failingListClient fails to list resources of unknown kinds
+type failingListClient struct {
+	dclient.Interface
+}
+
+func (c failingListClient) ListResource(ctx context.Context, apiVersion, kind, namespace string, selector *metav1.LabelSelector) (*unstructured.UnstructuredList, error) {
+	if kind == "Unknown" {
+		return nil, errors.New("failed to list resources")
+	}
+	return c.Interface.ListResource(ctx, apiVersion, kind, namespace, selector)
+}
+
+func Test_syncFinalizerOnDelete(t *testing.T) {
+	orphan := false
+	newPolicy := func(kind string, deletedSince time.Duration, serviceAccount *kyvernov1.ServiceAccountReference) *kyvernov1.ClusterPolicy {
+		deleted := metav1.NewTime(time.Now().Add(-deletedSince))
+		return &kyvernov1.ClusterPolicy{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              "test",
+				Finalizers:        []string{common.GenerateDownstreamCleanupFinalizer},
+				DeletionTimestamp: &deleted,
+			},
+			Spec: kyvernov1.Spec{
+				Rules: []kyvernov1.Rule{{
+					Name: "generate",
+					Generation: kyvernov1.Generation{
+						ResourceSpec: kyvernov1.ResourceSpec{
+							APIVersion: "v1",
+							Kind:       kind,
+							Name:       "test",
+						},
+						OrphanDownstreamOnPolicyDelete: &orphan,
+						ServiceAccount:                 serviceAccount,
+					},
+				}},
+			},
+		}
+	}
+	downstream := &unstructured.Unstructured{}
+	downstream.SetAPIVersion("v1")
+	downstream.SetKind("ConfigMap")
+	downstream.SetNamespace("default")
+	downstream.SetName("test")
+	downstream.SetLabels(map[string]string{
+		common.GeneratePolicyLabel:          "test",
+		common.GeneratePolicyNamespaceLabel: "",
+		common.GenerateRuleLabel:            "generate",
+		kyverno.LabelAppManagedBy:           kyverno.ValueKyvernoApp,
+	})
+	tests := []struct {
+		name               string
+		policy             *kyvernov1.ClusterPolicy
+		wantErr            bool
+		wantFinalizer      bool
+		wantDownstreamGone bool
+	}{{
+		name:               "generated resources deleted",
+		policy:             newPolicy("ConfigMap", 0, nil),
+		wantDownstreamGone: true,
+	}, {
+		name:          "deletion failed before timeout",
+		policy:        newPolicy("Unknown", 0, nil),
+		wantErr:       true,
+		wantFinalizer: true,
+	}, {
+		name:   "deletion failed after timeout",
+		policy: newPolicy("Unknown", downstreamCleanupTimeout+time.Minute, nil),
+	}, {
+		// resources generated by a service account are never deleted with the kyverno client
+		name:          "service account without impersonation",
+		policy:        newPolicy("ConfigMap", 0, &kyvernov1.ServiceAccountReference{Name: "generator", Namespace: "default"}),
+		wantErr:       true,
+		wantFinalizer: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, err := dclient.NewFakeClient(
+				runtime.NewScheme(),
+				map[schema.GroupVersionResource]string{{Version: "v1", Resource: "configmaps"}: "ConfigMapList"},
+				downstream.DeepCopy(),
+			)
+			assert.NilError(t, err)
+			client.SetDiscovery(dclient.NewFakeDiscoveryClient(nil))
+			kyvernoClient := fake.NewSimpleClientset(tt.policy)
+			pc := &policyController{
+				client:                   failingListClient{client},
+				kyvernoClient:            kyvernoClient,
+				queue:                    workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter()),
+				log:                      logr.Discard(),
+				downstreamCleanupTimeout: downstreamCleanupTimeout,
+			}
+			defer pc.queue.ShutDown()
+			deleting, err := pc.syncFinalizer(context.TODO(), tt.policy)
+			assert.Assert(t, deleting)
+			if tt.wantErr {
+				assert.Assert(t, err != nil)
+			} else {
+				assert.NilError(t, err)
+			}
+			policy, err := kyvernoClient.KyvernoV1().ClusterPolicies().Get(context.TODO(), "test", metav1.GetOptions{})
+			assert.NilError(t, err)
+			assert.Equal(t, hasDownstreamCleanupFinalizer(policy), tt.wantFinalizer)
+			_, err = client.GetResource(context.TODO(), "v1", "ConfigMap", "default", "test")
+			assert.Equal(t, apierrors.IsNotFound(err), tt.wantDownstreamGone)
+		})
+	}
+}
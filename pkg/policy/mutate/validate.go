@@ -32,6 +32,10 @@ func NewMutateFactory(m kyvernov1.Mutation, client dclient.Interface, user strin
 
 // Validate validates the 'mutate' rule
 func (m *Mutate) Validate(ctx context.Context) (string, error) {
+	if path, err := m.validateStatusTargets(); err != nil {
+		return path, err
+	}
+
 	if m.hasForEach() {
 		if m.hasPatchStrategicMerge() || m.hasPatchesJSON6902() {
 			return "foreach", fmt.Errorf("only one of `foreach`, `patchStrategicMerge`, or `patchesJson6902` is allowed")
@@ -93,6 +97,28 @@ func (m *Mutate) hasPatchesJSON6902() bool {
 	return m.mutation.PatchesJSON6902 != ""
 }
 
+// validateStatusTargets ensures the status subresource is not targeted when status mutation is disabled
+func (m *Mutate) validateStatusTargets() (string, error) {
+	if len(m.mutation.Targets) == 0 {
+		if m.mutation.AllowStatusMutation != nil {
+			return "allowStatusMutation", fmt.Errorf("`allowStatusMutation` is only allowed with `targets`")
+		}
+		return "", nil
+	}
+	if m.mutation.IsStatusMutationAllowed() {
+		return "", nil
+	}
+	for i, target := range m.mutation.Targets {
+		if regex.IsVariable(target.Kind) {
+			continue
+		}
+		if _, _, _, sub := kubeutils.ParseKindSelector(target.Kind); sub == "status" {
+			return fmt.Sprintf("targets[%d].kind", i), fmt.Errorf("the status subresource cannot be targeted when `allowStatusMutation` is false")
+		}
+	}
+	return "", nil
+}
+
 func (m *Mutate) validateAuth(ctx context.Context, targets []kyvernov1.TargetResourceSpec) error {
 	var errs []error
 	for _, target := range targets {
@@ -0,0 +1,90 @@
+package mutate
+
+import (
+	"context"
+	"testing"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"gotest.tools/assert"
+)
+
+type fakeAuthChecker struct{}
+
+func (fakeAuthChecker) CanIUpdate(context.Context, string, string, string) (bool, error) {
+	return true, nil
+}
+
+func (fakeAuthChecker) CanIGet(context.Context, string, string, string) (bool, error) {
+	return true, nil
+}
+
+func Test_Validate_StatusTargets(t *testing.T) {
+	allowed, disallowed := true, false
+	target := func(kind string) kyvernov1.TargetResourceSpec {
+		return kyvernov1.TargetResourceSpec{
+			ResourceSpec: kyvernov1.ResourceSpec{
+				APIVersion: "example.com/v1",
+				Kind:       kind,
+			},
+		}
+	}
+	tests := []struct {
+		name     string
+		mutation kyvernov1.Mutation
+		wantPath string
+		wantErr  bool
+	}{{
+		name: "target without status",
+		mutation: kyvernov1.Mutation{
+			Targets: []kyvernov1.TargetResourceSpec{target("Widget")},
+		},
+	}, {
+		name: "status target allowed by default",
+		mutation: kyvernov1.Mutation{
+			Targets: []kyvernov1.TargetResourceSpec{target("Widget"), target("Widget/status")},
+		},
+	}, {
+		name: "status target not allowed",
+		mutation: kyvernov1.Mutation{
+			Targets:             []kyvernov1.TargetResourceSpec{target("Widget"), target("Widget/status")},
+			AllowStatusMutation: &disallowed,
+		},
+		wantPath: "targets[1].kind",
+		wantErr:  true,
+	}, {
+		name: "status target allowed",
+		mutation: kyvernov1.Mutation{
+			Targets:             []kyvernov1.TargetResourceSpec{target("Widget/status")},
+			AllowStatusMutation: &allowed,
+		},
+	}, {
+		name: "status target from variable not allowed",
+		mutation: kyvernov1.Mutation{
+			Targets:             []kyvernov1.TargetResourceSpec{target("{{ request.object.spec.kind }}")},
+			AllowStatusMutation: &disallowed,
+		},
+	}, {
+		name: "status target from variable",
+		mutation: kyvernov1.Mutation{
+			Targets: []kyvernov1.TargetResourceSpec{target("{{ request.object.spec.kind }}")},
+		},
+	}, {
+		name: "allowed without targets",
+		mutation: kyvernov1.Mutation{
+			AllowStatusMutation: &allowed,
+		},
+		wantPath: "allowStatusMutation",
+		wantErr:  true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &Mutate{
+				mutation:    tt.mutation,
+				authChecker: fakeAuthChecker{},
+			}
+			path, err := m.Validate(context.TODO())
+			assert.Equal(t, path, tt.wantPath)
+			assert.Equal(t, err != nil, tt.wantErr)
+		})
+	}
+}
@@ -54,6 +54,10 @@ type policyController struct {
 	kyvernoClient versioned.Interface
 	engine        engineapi.Engine
 
+	// impersonatingClient and remoteClient build the clients of generate rules acting as a service account or targeting a remote cluster
+	impersonatingClient dclient.ImpersonatingClientFactory
+	remoteClient        dclient.RemoteClientFactory
+
 	pInformer  kyvernov1informers.ClusterPolicyInformer
 	npInformer kyvernov1informers.PolicyInformer
 
@@ -82,6 +86,9 @@ type policyController struct {
 
 	reconcilePeriod time.Duration
 
+	// downstreamCleanupTimeout is the max duration generated resources are deleted for before a deleted policy is released
+	downstreamCleanupTimeout time.Duration
+
 	log logr.Logger
 
 	metricsConfig metrics.MetricsConfigManager
@@ -93,6 +100,8 @@ type policyController struct {
 func NewPolicyController(
 	kyvernoClient versioned.Interface,
 	client dclient.Interface,
+	impersonatingClient dclient.ImpersonatingClientFactory,
+	remoteClient dclient.RemoteClientFactory,
 	engine engineapi.Engine,
 	pInformer kyvernov1informers.ClusterPolicyInformer,
 	npInformer kyvernov1informers.PolicyInformer,
@@ -117,19 +126,23 @@ func NewPolicyController(
 	eventBroadcaster.StartRecordingToSink(stopCh)
 
 	pc := policyController{
-		client:          client,
-		kyvernoClient:   kyvernoClient,
-		engine:          engine,
-		pInformer:       pInformer,
-		npInformer:      npInformer,
-		eventGen:        eventGen,
-		eventRecorder:   eventBroadcaster.NewRecorder(scheme.Scheme, "policy_controller"),
-		queue:           workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "policy"),
-		configuration:   configuration,
-		reconcilePeriod: reconcilePeriod,
-		metricsConfig:   metricsConfig,
-		log:             log,
-		jp:              jp,
+		client:              client,
+		kyvernoClient:       kyvernoClient,
+		impersonatingClient: impersonatingClient,
+		remoteClient:        remoteClient,
+		engine:              engine,
+		pInformer:           pInformer,
+		npInformer:          npInformer,
+		eventGen:            eventGen,
+		eventRecorder:       eventBroadcaster.NewRecorder(scheme.Scheme, "policy_controller"),
+		queue:               workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "policy"),
+		configuration:       configuration,
+		reconcilePeriod:     reconcilePeriod,
+		metricsConfig:       metricsConfig,
+		log:                 log,
+		jp:                  jp,
+
+		downstreamCleanupTimeout: downstreamCleanupTimeout,
 	}
 
 	pc.pLister = pInformer.Lister()
@@ -164,6 +177,10 @@ func (pc *policyController) addPolicy(obj interface{}) {
 	p := castPolicy(obj)
 	logger.Info("policy created", "uid", p.GetUID(), "kind", p.GetKind(), "namespace", p.GetNamespace(), "name", p.GetName())
 
+	if needsFinalizerSync(p) {
+		pc.enqueuePolicy(p)
+	}
+
 	if !pc.canBackgroundProcess(p) {
 		return
 	}
@@ -176,6 +193,10 @@ func (pc *policyController) updatePolicy(old, cur interface{}) {
 	logger := pc.log
 	oldP := castPolicy(old)
 	curP := castPolicy(cur)
+	if needsFinalizerSync(curP) {
+		pc.enqueuePolicy(curP)
+	}
+
 	if !pc.canBackgroundProcess(curP) {
 		return
 	}
@@ -313,6 +334,10 @@ func (pc *policyController) syncPolicy(key string) error {
 		}
 		return err
 	} else {
+		if deleting, err := pc.syncFinalizer(context.TODO(), policy); err != nil || deleting {
+			return err
+		}
+
 		err = pc.handleMutate(key, policy)
 		if err != nil {
 			logger.Error(err, "failed to updateUR on mutate policy update")
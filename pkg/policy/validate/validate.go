@@ -5,8 +5,11 @@ import (
 	"fmt"
 
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	policyreportv1alpha2 "github.com/kyverno/kyverno/api/policyreport/v1alpha2"
 	"github.com/kyverno/kyverno/pkg/engine/anchor"
+	"github.com/kyverno/kyverno/pkg/engine/variables/regex"
 	"github.com/kyverno/kyverno/pkg/policy/common"
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
 )
 
 // Validate validates a 'validate' rule
@@ -60,6 +63,14 @@ func (v *Validate) Validate(ctx context.Context) (string, error) {
 		}
 	}
 
+	if err := validateAnyPatternMessages(v.rule.GetAnyPattern(), v.rule.AnyPatternMessages); err != nil {
+		return "anyPatternMessages", err
+	}
+
+	if err := validateSeverity(v.rule.Severity); err != nil {
+		return "severity", err
+	}
+
 	if v.rule.ForEachValidation != nil {
 		for _, foreach := range v.rule.ForEachValidation {
 			if err := v.validateForEach(foreach); err != nil {
@@ -112,17 +123,33 @@ func (v *Validate) Validate(ctx context.Context) (string, error) {
 		}
 	}
 
+	if v.rule.Assert != nil {
+		if len(v.rule.Assert.Any) == 0 && len(v.rule.Assert.All) == 0 {
+			return "assert", fmt.Errorf("one of assert.any or assert.all must be specified")
+		}
+		for i, check := range v.rule.Assert.Any {
+			if check.GetCheck() == nil {
+				return fmt.Sprintf("assert.any[%d].check", i), fmt.Errorf("check is required")
+			}
+		}
+		for i, check := range v.rule.Assert.All {
+			if check.GetCheck() == nil {
+				return fmt.Sprintf("assert.all[%d].check", i), fmt.Errorf("check is required")
+			}
+		}
+	}
+
 	return "", nil
 }
 
 func (v *Validate) validateElements() error {
 	count := validationElemCount(v.rule)
 	if count == 0 {
-		return fmt.Errorf("one of pattern, anyPattern, deny, foreach, cel must be specified")
+		return fmt.Errorf("one of pattern, anyPattern, deny, foreach, cel, assert must be specified")
 	}
 
 	if count > 1 {
-		return fmt.Errorf("only one of pattern, anyPattern, deny, foreach, cel can be specified")
+		return fmt.Errorf("only one of pattern, anyPattern, deny, foreach, cel, assert can be specified")
 	}
 
 	return nil
@@ -158,6 +185,10 @@ func validationElemCount(v *kyvernov1.Validation) int {
 		count++
 	}
 
+	if v.Assert != nil {
+		count++
+	}
+
 	if v.Manifests != nil && len(v.Manifests.Attestors) != 0 {
 		count++
 	}
@@ -179,6 +210,44 @@ func (v *Validate) validateForEach(foreach kyvernov1.ForEachValidation) error {
 		return fmt.Errorf("only one of pattern, anyPattern, deny, or a nested foreach can be specified")
 	}
 
+	if err := validateAnyPatternMessages(foreach.GetAnyPattern(), foreach.AnyPatternMessages); err != nil {
+		return fmt.Errorf("foreach.anyPatternMessages: %w", err)
+	}
+
+	return nil
+}
+
+// validateSeverity checks that a severity without variables is a valid report severity
+func validateSeverity(severity string) error {
+	if severity == "" || regex.IsVariable(severity) {
+		return nil
+	}
+	switch severity {
+	case policyreportv1alpha2.SeverityCritical,
+		policyreportv1alpha2.SeverityHigh,
+		policyreportv1alpha2.SeverityMedium,
+		policyreportv1alpha2.SeverityLow,
+		policyreportv1alpha2.SeverityInfo:
+		return nil
+	}
+	return fmt.Errorf("invalid severity %s, expected one of critical, high, medium, low or info", severity)
+}
+
+// validateAnyPatternMessages checks that every anyPattern message has a matching pattern
+func validateAnyPatternMessages(anyPattern apiextensions.JSON, messages []string) error {
+	if len(messages) == 0 {
+		return nil
+	}
+	if anyPattern == nil {
+		return fmt.Errorf("anyPatternMessages requires anyPattern")
+	}
+	patterns, ok := anyPattern.([]interface{})
+	if !ok {
+		return fmt.Errorf("failed to deserialize anyPattern, expect array")
+	}
+	if len(messages) > len(patterns) {
+		return fmt.Errorf("anyPatternMessages has %d messages for %d patterns", len(messages), len(patterns))
+	}
 	return nil
 }
 
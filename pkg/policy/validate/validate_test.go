@@ -380,3 +380,144 @@ func Test_Validate_Validate_Unsupported(t *testing.T) {
 	}
 
 }
+
+func Test_Validate_Assert(t *testing.T) {
+	testCases := []struct {
+		name          string
+		rawValidation []byte
+		path          string
+		wantErr       bool
+	}{{
+		name:          "valid",
+		rawValidation: []byte(`{"assert": {"all": [{"check": {"spec": {"replicas": 1}}}]}}`),
+	}, {
+		name:          "empty",
+		rawValidation: []byte(`{"assert": {}}`),
+		path:          "assert",
+		wantErr:       true,
+	}, {
+		name:          "missing check",
+		rawValidation: []byte(`{"assert": {"any": [{"message": "no check"}]}}`),
+		path:          "assert.any[0].check",
+		wantErr:       true,
+	}, {
+		name:          "with pattern",
+		rawValidation: []byte(`{"pattern": {"spec": {}}, "assert": {"all": [{"check": {"spec": {}}}]}}`),
+		wantErr:       true,
+	}}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var validation kyverno.Validation
+			assert.NilError(t, json.Unmarshal(tc.rawValidation, &validation))
+			path, err := NewValidateFactory(&validation).Validate(context.TODO())
+			assert.Equal(t, path, tc.path)
+			assert.Equal(t, err != nil, tc.wantErr)
+		})
+	}
+}
+
+func Test_Validate_AnyPatternMessages(t *testing.T) {
+	testCases := []struct {
+		name          string
+		rawValidation []byte
+		path          string
+		wantErr       bool
+	}{
+		{
+			name: "one message per pattern",
+			rawValidation: []byte(`{
+				"anyPattern": [{"metadata": {"namespace": "?*"}}, {"metadata": {"labels": {"team": "?*"}}}],
+				"anyPatternMessages": ["namespace is required", "team label is required"]
+			}`),
+		},
+		{
+			name: "fewer messages than patterns",
+			rawValidation: []byte(`{
+				"anyPattern": [{"metadata": {"namespace": "?*"}}, {"metadata": {"labels": {"team": "?*"}}}],
+				"anyPatternMessages": ["namespace is required"]
+			}`),
+		},
+		{
+			name: "more messages than patterns",
+			rawValidation: []byte(`{
+				"anyPattern": [{"metadata": {"namespace": "?*"}}],
+				"anyPatternMessages": ["namespace is required", "team label is required"]
+			}`),
+			path:    "anyPatternMessages",
+			wantErr: true,
+		},
+		{
+			name: "messages without anyPattern",
+			rawValidation: []byte(`{
+				"pattern": {"metadata": {"namespace": "?*"}},
+				"anyPatternMessages": ["namespace is required"]
+			}`),
+			path:    "anyPatternMessages",
+			wantErr: true,
+		},
+		{
+			name: "foreach with more messages than patterns",
+			rawValidation: []byte(`{
+				"foreach": [{
+					"list": "request.object.spec.containers",
+					"anyPattern": [{"name": "?*"}],
+					"anyPatternMessages": ["name is required", "image is required"]
+				}]
+			}`),
+			wantErr: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var validation kyverno.Validation
+			err := json.Unmarshal(tc.rawValidation, &validation)
+			assert.NilError(t, err)
+			path, err := NewValidateFactory(&validation).Validate(context.TODO())
+			assert.Equal(t, err != nil, tc.wantErr)
+			assert.Equal(t, path, tc.path)
+		})
+	}
+}
+
+func Test_Validate_Severity(t *testing.T) {
+	testCases := []struct {
+		name          string
+		rawValidation []byte
+		path          string
+		wantErr       bool
+	}{
+		{
+			name: "static severity",
+			rawValidation: []byte(`{
+				"pattern": {"metadata": {"namespace": "?*"}},
+				"severity": "high"
+			}`),
+		},
+		{
+			name: "severity with variables",
+			rawValidation: []byte(`{
+				"pattern": {"metadata": {"namespace": "?*"}},
+				"severity": "{{ request.namespace == 'prod' && 'critical' || 'low' }}"
+			}`),
+		},
+		{
+			name: "invalid severity",
+			rawValidation: []byte(`{
+				"pattern": {"metadata": {"namespace": "?*"}},
+				"severity": "urgent"
+			}`),
+			path:    "severity",
+			wantErr: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var validation kyverno.Validation
+			err := json.Unmarshal(tc.rawValidation, &validation)
+			assert.NilError(t, err)
+			path, err := NewValidateFactory(&validation).Validate(context.TODO())
+			assert.Equal(t, err != nil, tc.wantErr)
+			assert.Equal(t, path, tc.path)
+		})
+	}
+}
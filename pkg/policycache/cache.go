@@ -1,73 +1,158 @@
 package policycache
 
 import (
+	"github.com/kyverno/kyverno/api/kyverno"
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
 	"github.com/kyverno/kyverno/pkg/clients/dclient"
 	"github.com/kyverno/kyverno/pkg/utils/wildcard"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	corev1listers "k8s.io/client-go/listers/core/v1"
 )
 
 type ResourceFinder interface {
 	FindResources(group, version, kind, subresource string) (map[dclient.TopLevelApiDescription]metav1.APIResource, error)
 }
 
+// Snapshot is an immutable view of the policies stored in the cache, requests evaluate policies
+// from a single snapshot so that concurrent policy updates can't produce mixed results
+type Snapshot interface {
+	// Revision identifies the snapshot, it is incremented every time a policy is set or unset
+	Revision() uint64
+	// GetPolicies returns all policies that apply to a namespace and an operation, including cluster-wide policies
+	// If the namespace is empty, only cluster-wide policies are returned
+	// If the operation is empty, policies are returned regardless of the operations they apply to
+	GetPolicies(PolicyType, schema.GroupVersionResource, string, string, kyvernov1.AdmissionOperation) []kyvernov1.PolicyInterface
+}
+
 // Cache get method use for to get policy names and mostly use to test cache testcases
 type Cache interface {
 	// Set inserts a policy in the cache
 	Set(string, kyvernov1.PolicyInterface, ResourceFinder) error
 	// Unset removes a policy from the cache
 	Unset(string)
-	// GetPolicies returns all policies that apply to a namespace, including cluster-wide policies
-	// If the namespace is empty, only cluster-wide policies are returned
-	GetPolicies(PolicyType, schema.GroupVersionResource, string, string) []kyvernov1.PolicyInterface
+	// Snapshot returns the current policies, the snapshot is not affected by subsequent updates
+	Snapshot() Snapshot
+	// GetPolicies returns the policies of the current snapshot, see Snapshot.GetPolicies
+	GetPolicies(PolicyType, schema.GroupVersionResource, string, string, kyvernov1.AdmissionOperation) []kyvernov1.PolicyInterface
+}
+
+// Compiler precompiles the expressions of policies stored in the cache
+type Compiler interface {
+	// Compile precompiles the expressions of a policy, replacing the ones previously compiled for the same key
+	Compile(string, kyvernov1.PolicyInterface)
+	// Release releases the expressions compiled for a policy
+	Release(string)
 }
 
 type cache struct {
-	store store
+	store    *policyCache
+	compiler Compiler
+	nsLister corev1listers.NamespaceLister
 }
 
 // NewCache create a new Cache
 func NewCache() Cache {
+	return NewCacheWithCompiler(nil, nil)
+}
+
+// NewCacheWithCompiler create a new Cache precompiling policies expressions with the given compiler,
+// when a namespace lister is given the validation failure actions set by namespace annotations are honored
+func NewCacheWithCompiler(compiler Compiler, nsLister corev1listers.NamespaceLister) Cache {
 	return &cache{
-		store: newPolicyCache(),
+		store:    newPolicyCache(),
+		compiler: compiler,
+		nsLister: nsLister,
 	}
 }
 
 func (c *cache) Set(key string, policy kyvernov1.PolicyInterface, client ResourceFinder) error {
+	// compile before the policy becomes visible so that requests use precompiled expressions
+	if c.compiler != nil {
+		c.compiler.Compile(key, policy)
+	}
 	return c.store.set(key, policy, client)
 }
 
 func (c *cache) Unset(key string) {
 	c.store.unset(key)
+	if c.compiler != nil {
+		c.compiler.Release(key)
+	}
+}
+
+func (c *cache) Snapshot() Snapshot {
+	return &snapshot{
+		policies: c.store.snapshot(),
+		nsLister: c.nsLister,
+	}
 }
 
-func (c *cache) GetPolicies(pkey PolicyType, gvr schema.GroupVersionResource, subresource string, nspace string) []kyvernov1.PolicyInterface {
+func (c *cache) GetPolicies(pkey PolicyType, gvr schema.GroupVersionResource, subresource string, nspace string, operation kyvernov1.AdmissionOperation) []kyvernov1.PolicyInterface {
+	return c.Snapshot().GetPolicies(pkey, gvr, subresource, nspace, operation)
+}
+
+type snapshot struct {
+	policies *policyMap
+	nsLister corev1listers.NamespaceLister
+}
+
+func (s *snapshot) Revision() uint64 {
+	return s.policies.revision
+}
+
+func (s *snapshot) GetPolicies(pkey PolicyType, gvr schema.GroupVersionResource, subresource string, nspace string, operation kyvernov1.AdmissionOperation) []kyvernov1.PolicyInterface {
 	var result []kyvernov1.PolicyInterface
-	result = append(result, c.store.get(pkey, gvr, subresource, "")...)
+	result = append(result, s.policies.get(pkey, gvr, subresource, "", operation)...)
 	if nspace != "" {
-		result = append(result, c.store.get(pkey, gvr, subresource, nspace)...)
+		result = append(result, s.policies.get(pkey, gvr, subresource, nspace, operation)...)
 	}
 	// also get policies with ValidateEnforce
 	if pkey == ValidateAudit {
-		result = append(result, c.store.get(ValidateEnforce, gvr, subresource, "")...)
+		result = append(result, s.policies.get(ValidateEnforce, gvr, subresource, "", operation)...)
 	}
 	if pkey == ValidateAudit || pkey == ValidateEnforce {
-		result = filterPolicies(pkey, result, nspace)
+		nsAnnotations := s.getNamespaceAnnotations(nspace)
+		// the namespace can move any policy from one validation type to the other
+		if _, ok := nsAnnotations[kyverno.AnnotationValidationFailureActions]; ok {
+			switch pkey {
+			case ValidateAudit:
+				result = append(result, s.policies.get(ValidateEnforce, gvr, subresource, nspace, operation)...)
+			case ValidateEnforce:
+				result = append(result, s.policies.get(ValidateAudit, gvr, subresource, "", operation)...)
+				result = append(result, s.policies.get(ValidateAudit, gvr, subresource, nspace, operation)...)
+			}
+		}
+		result = filterPolicies(pkey, result, nspace, nsAnnotations)
 	}
 	return result
 }
 
+func (s *snapshot) getNamespaceAnnotations(nspace string) map[string]string {
+	if s.nsLister == nil || nspace == "" {
+		return nil
+	}
+	ns, err := s.nsLister.Get(nspace)
+	if err != nil {
+		if !apierrors.IsNotFound(err) {
+			logger.Error(err, "failed to get namespace", "name", nspace)
+		}
+		return nil
+	}
+	return ns.GetAnnotations()
+}
+
 // Filter cluster policies using validationFailureAction override
-func filterPolicies(pkey PolicyType, result []kyvernov1.PolicyInterface, nspace string) []kyvernov1.PolicyInterface {
+func filterPolicies(pkey PolicyType, result []kyvernov1.PolicyInterface, nspace string, nsAnnotations map[string]string) []kyvernov1.PolicyInterface {
 	var policies []kyvernov1.PolicyInterface
 	for _, policy := range result {
 		keepPolicy := true
 		switch pkey {
 		case ValidateAudit:
-			keepPolicy = checkValidationFailureActionOverrides(false, nspace, policy)
+			keepPolicy = checkValidationFailureActionOverrides(false, nspace, nsAnnotations, policy)
 		case ValidateEnforce:
-			keepPolicy = checkValidationFailureActionOverrides(true, nspace, policy)
+			keepPolicy = checkValidationFailureActionOverrides(true, nspace, nsAnnotations, policy)
 		}
 		// add policy to result
 		if keepPolicy {
@@ -77,7 +162,11 @@ func filterPolicies(pkey PolicyType, result []kyvernov1.PolicyInterface, nspace
 	return policies
 }
 
-func checkValidationFailureActionOverrides(enforce bool, ns string, policy kyvernov1.PolicyInterface) bool {
+func checkValidationFailureActionOverrides(enforce bool, ns string, nsAnnotations map[string]string, policy kyvernov1.PolicyInterface) bool {
+	// the namespace annotation takes precedence over the policy settings
+	if action, ok := kyvernov1.GetNamespaceValidationFailureAction(nsAnnotations, policy.GetName()); ok {
+		return action.Enforce() == enforce
+	}
 	validationFailureAction := policy.GetSpec().ValidationFailureAction
 	validationFailureActionOverrides := policy.GetSpec().ValidationFailureActionOverrides
 	if validationFailureAction.Enforce() != enforce && (ns == "" || len(validationFailureActionOverrides) == 0) {
@@ -8,6 +8,10 @@ import (
 	"github.com/kyverno/kyverno/pkg/autogen"
 	kubeutils "github.com/kyverno/kyverno/pkg/utils/kube"
 	"gotest.tools/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	corev1listers "k8s.io/client-go/listers/core/v1"
 	kubecache "k8s.io/client-go/tools/cache"
 )
 
@@ -35,15 +39,15 @@ func Test_All(t *testing.T) {
 			assert.NilError(t, err)
 			for gvr := range gvrs {
 				// get
-				mutate := pCache.get(Mutate, gvr.GroupVersionResource(), gvr.SubResource, "")
+				mutate := pCache.get(Mutate, gvr.GroupVersionResource(), gvr.SubResource, "", "")
 				if len(mutate) != 1 {
 					t.Errorf("expected 1 mutate policy, found %v", len(mutate))
 				}
-				validateEnforce := pCache.get(ValidateEnforce, gvr.GroupVersionResource(), gvr.SubResource, "")
+				validateEnforce := pCache.get(ValidateEnforce, gvr.GroupVersionResource(), gvr.SubResource, "", "")
 				if len(validateEnforce) != 1 {
 					t.Errorf("expected 1 validate policy, found %v", len(validateEnforce))
 				}
-				generate := pCache.get(Generate, gvr.GroupVersionResource(), gvr.SubResource, "")
+				generate := pCache.get(Generate, gvr.GroupVersionResource(), gvr.SubResource, "", "")
 				if len(generate) != 1 {
 					t.Errorf("expected 1 generate policy, found %v", len(generate))
 				}
@@ -53,7 +57,7 @@ func Test_All(t *testing.T) {
 
 	// remove
 	unsetPolicy(pCache, policy)
-	validateEnforce := pCache.get(ValidateEnforce, podsGVRS.GroupVersionResource(), "", "")
+	validateEnforce := pCache.get(ValidateEnforce, podsGVRS.GroupVersionResource(), "", "", "")
 	assert.Assert(t, len(validateEnforce) == 0)
 }
 
@@ -70,16 +74,16 @@ func Test_Add_Duplicate_Policy(t *testing.T) {
 			gvrs, err := finder.FindResources(group, version, kind, subresource)
 			assert.NilError(t, err)
 			for gvr := range gvrs {
-				mutate := pCache.get(Mutate, gvr.GroupVersionResource(), gvr.SubResource, "")
+				mutate := pCache.get(Mutate, gvr.GroupVersionResource(), gvr.SubResource, "", "")
 				if len(mutate) != 1 {
 					t.Errorf("expected 1 mutate policy, found %v", len(mutate))
 				}
 
-				validateEnforce := pCache.get(ValidateEnforce, gvr.GroupVersionResource(), gvr.SubResource, "")
+				validateEnforce := pCache.get(ValidateEnforce, gvr.GroupVersionResource(), gvr.SubResource, "", "")
 				if len(validateEnforce) != 1 {
 					t.Errorf("expected 1 validate policy, found %v", len(validateEnforce))
 				}
-				generate := pCache.get(Generate, gvr.GroupVersionResource(), gvr.SubResource, "")
+				generate := pCache.get(Generate, gvr.GroupVersionResource(), gvr.SubResource, "", "")
 				if len(generate) != 1 {
 					t.Errorf("expected 1 generate policy, found %v", len(generate))
 				}
@@ -103,12 +107,12 @@ func Test_Add_Validate_Audit(t *testing.T) {
 			gvrs, err := finder.FindResources(group, version, kind, subresource)
 			assert.NilError(t, err)
 			for gvr := range gvrs {
-				validateEnforce := pCache.get(ValidateEnforce, gvr.GroupVersionResource(), gvr.SubResource, "")
+				validateEnforce := pCache.get(ValidateEnforce, gvr.GroupVersionResource(), gvr.SubResource, "", "")
 				if len(validateEnforce) != 0 {
 					t.Errorf("expected 0 validate (enforce) policy, found %v", len(validateEnforce))
 				}
 
-				validateAudit := pCache.get(ValidateAudit, gvr.GroupVersionResource(), gvr.SubResource, "")
+				validateAudit := pCache.get(ValidateAudit, gvr.GroupVersionResource(), gvr.SubResource, "", "")
 				if len(validateAudit) != 1 {
 					t.Errorf("expected 1 validate (audit) policy, found %v", len(validateAudit))
 				}
@@ -122,20 +126,20 @@ func Test_Add_Remove(t *testing.T) {
 	policy := newPolicy(t)
 	finder := TestResourceFinder{}
 	setPolicy(t, pCache, policy, finder)
-	validateEnforce := pCache.get(ValidateEnforce, podsGVRS.GroupVersionResource(), "", "")
+	validateEnforce := pCache.get(ValidateEnforce, podsGVRS.GroupVersionResource(), "", "", "")
 	if len(validateEnforce) != 1 {
 		t.Errorf("expected 1 validate enforce policy, found %v", len(validateEnforce))
 	}
-	mutate := pCache.get(Mutate, podsGVRS.GroupVersionResource(), "", "")
+	mutate := pCache.get(Mutate, podsGVRS.GroupVersionResource(), "", "", "")
 	if len(mutate) != 1 {
 		t.Errorf("expected 1 mutate policy, found %v", len(mutate))
 	}
-	generate := pCache.get(Generate, podsGVRS.GroupVersionResource(), "", "")
+	generate := pCache.get(Generate, podsGVRS.GroupVersionResource(), "", "", "")
 	if len(generate) != 1 {
 		t.Errorf("expected 1 generate policy, found %v", len(generate))
 	}
 	unsetPolicy(pCache, policy)
-	deletedValidateEnforce := pCache.get(ValidateEnforce, podsGVRS.GroupVersionResource(), "", "")
+	deletedValidateEnforce := pCache.get(ValidateEnforce, podsGVRS.GroupVersionResource(), "", "", "")
 	if len(deletedValidateEnforce) != 0 {
 		t.Errorf("expected 0 validate enforce policy, found %v", len(deletedValidateEnforce))
 	}
@@ -146,20 +150,20 @@ func Test_Add_Remove_Any(t *testing.T) {
 	policy := newAnyPolicy(t)
 	finder := TestResourceFinder{}
 	setPolicy(t, pCache, policy, finder)
-	validateEnforce := pCache.get(ValidateEnforce, podsGVRS.GroupVersionResource(), "", "")
+	validateEnforce := pCache.get(ValidateEnforce, podsGVRS.GroupVersionResource(), "", "", "")
 	if len(validateEnforce) != 1 {
 		t.Errorf("expected 1 validate enforce policy, found %v", len(validateEnforce))
 	}
-	mutate := pCache.get(Mutate, podsGVRS.GroupVersionResource(), "", "")
+	mutate := pCache.get(Mutate, podsGVRS.GroupVersionResource(), "", "", "")
 	if len(mutate) != 1 {
 		t.Errorf("expected 1 mutate policy, found %v", len(mutate))
 	}
-	generate := pCache.get(Generate, podsGVRS.GroupVersionResource(), "", "")
+	generate := pCache.get(Generate, podsGVRS.GroupVersionResource(), "", "", "")
 	if len(generate) != 1 {
 		t.Errorf("expected 1 generate policy, found %v", len(generate))
 	}
 	unsetPolicy(pCache, policy)
-	deletedValidateEnforce := pCache.get(ValidateEnforce, podsGVRS.GroupVersionResource(), "", "")
+	deletedValidateEnforce := pCache.get(ValidateEnforce, podsGVRS.GroupVersionResource(), "", "", "")
 	if len(deletedValidateEnforce) != 0 {
 		t.Errorf("expected 0 validate enforce policy, found %v", len(deletedValidateEnforce))
 	}
@@ -901,15 +905,15 @@ func Test_Ns_All(t *testing.T) {
 			assert.NilError(t, err)
 			for gvr := range gvrs {
 				// get
-				mutate := pCache.get(Mutate, gvr.GroupVersionResource(), gvr.SubResource, nspace)
+				mutate := pCache.get(Mutate, gvr.GroupVersionResource(), gvr.SubResource, nspace, "")
 				if len(mutate) != 1 {
 					t.Errorf("expected 1 mutate policy, found %v", len(mutate))
 				}
-				validateEnforce := pCache.get(ValidateEnforce, gvr.GroupVersionResource(), gvr.SubResource, nspace)
+				validateEnforce := pCache.get(ValidateEnforce, gvr.GroupVersionResource(), gvr.SubResource, nspace, "")
 				if len(validateEnforce) != 1 {
 					t.Errorf("expected 1 validate policy, found %v", len(validateEnforce))
 				}
-				generate := pCache.get(Generate, gvr.GroupVersionResource(), gvr.SubResource, nspace)
+				generate := pCache.get(Generate, gvr.GroupVersionResource(), gvr.SubResource, nspace, "")
 				if len(generate) != 1 {
 					t.Errorf("expected 1 generate policy, found %v", len(generate))
 				}
@@ -918,7 +922,7 @@ func Test_Ns_All(t *testing.T) {
 	}
 	// remove
 	unsetPolicy(pCache, policy)
-	validateEnforce := pCache.get(ValidateEnforce, podsGVRS.GroupVersionResource(), "", nspace)
+	validateEnforce := pCache.get(ValidateEnforce, podsGVRS.GroupVersionResource(), "", nspace, "")
 	assert.Assert(t, len(validateEnforce) == 0)
 }
 
@@ -936,15 +940,15 @@ func Test_Ns_Add_Duplicate_Policy(t *testing.T) {
 			gvrs, err := finder.FindResources(group, version, kind, subresource)
 			assert.NilError(t, err)
 			for gvr := range gvrs {
-				mutate := pCache.get(Mutate, gvr.GroupVersionResource(), gvr.SubResource, nspace)
+				mutate := pCache.get(Mutate, gvr.GroupVersionResource(), gvr.SubResource, nspace, "")
 				if len(mutate) != 1 {
 					t.Errorf("expected 1 mutate policy, found %v", len(mutate))
 				}
-				validateEnforce := pCache.get(ValidateEnforce, gvr.GroupVersionResource(), gvr.SubResource, nspace)
+				validateEnforce := pCache.get(ValidateEnforce, gvr.GroupVersionResource(), gvr.SubResource, nspace, "")
 				if len(validateEnforce) != 1 {
 					t.Errorf("expected 1 validate policy, found %v", len(validateEnforce))
 				}
-				generate := pCache.get(Generate, gvr.GroupVersionResource(), gvr.SubResource, nspace)
+				generate := pCache.get(Generate, gvr.GroupVersionResource(), gvr.SubResource, nspace, "")
 				if len(generate) != 1 {
 					t.Errorf("expected 1 generate policy, found %v", len(generate))
 				}
@@ -969,12 +973,12 @@ func Test_Ns_Add_Validate_Audit(t *testing.T) {
 			gvrs, err := finder.FindResources(group, version, kind, subresource)
 			assert.NilError(t, err)
 			for gvr := range gvrs {
-				validateEnforce := pCache.get(ValidateEnforce, gvr.GroupVersionResource(), gvr.SubResource, nspace)
+				validateEnforce := pCache.get(ValidateEnforce, gvr.GroupVersionResource(), gvr.SubResource, nspace, "")
 				if len(validateEnforce) != 0 {
 					t.Errorf("expected 0 validate (enforce) policy, found %v", len(validateEnforce))
 				}
 
-				validateAudit := pCache.get(ValidateAudit, gvr.GroupVersionResource(), gvr.SubResource, nspace)
+				validateAudit := pCache.get(ValidateAudit, gvr.GroupVersionResource(), gvr.SubResource, nspace, "")
 				if len(validateAudit) != 1 {
 					t.Errorf("expected 1 validate (audit) policy, found %v", len(validateAudit))
 				}
@@ -989,12 +993,12 @@ func Test_Ns_Add_Remove(t *testing.T) {
 	finder := TestResourceFinder{}
 	nspace := policy.GetNamespace()
 	setPolicy(t, pCache, policy, finder)
-	validateEnforce := pCache.get(ValidateEnforce, podsGVRS.GroupVersionResource(), "", nspace)
+	validateEnforce := pCache.get(ValidateEnforce, podsGVRS.GroupVersionResource(), "", nspace, "")
 	if len(validateEnforce) != 1 {
 		t.Errorf("expected 1 validate enforce policy, found %v", len(validateEnforce))
 	}
 	unsetPolicy(pCache, policy)
-	deletedValidateEnforce := pCache.get(ValidateEnforce, podsGVRS.GroupVersionResource(), "", nspace)
+	deletedValidateEnforce := pCache.get(ValidateEnforce, podsGVRS.GroupVersionResource(), "", nspace, "")
 	if len(deletedValidateEnforce) != 0 {
 		t.Errorf("expected 0 validate enforce policy, found %v", len(deletedValidateEnforce))
 	}
@@ -1012,7 +1016,7 @@ func Test_GVk_Cache(t *testing.T) {
 			gvrs, err := finder.FindResources(group, version, kind, subresource)
 			assert.NilError(t, err)
 			for gvr := range gvrs {
-				generate := pCache.get(Generate, gvr.GroupVersionResource(), gvr.SubResource, "")
+				generate := pCache.get(Generate, gvr.GroupVersionResource(), gvr.SubResource, "", "")
 				if len(generate) != 1 {
 					t.Errorf("expected 1 generate policy, found %v", len(generate))
 				}
@@ -1026,12 +1030,12 @@ func Test_GVK_Add_Remove(t *testing.T) {
 	policy := newGVKPolicy(t)
 	finder := TestResourceFinder{}
 	setPolicy(t, pCache, policy, finder)
-	generate := pCache.get(Generate, clusterrolesGVRS.GroupVersionResource(), "", "")
+	generate := pCache.get(Generate, clusterrolesGVRS.GroupVersionResource(), "", "", "")
 	if len(generate) != 1 {
 		t.Errorf("expected 1 generate policy, found %v", len(generate))
 	}
 	unsetPolicy(pCache, policy)
-	deletedGenerate := pCache.get(Generate, clusterrolesGVRS.GroupVersionResource(), "", "")
+	deletedGenerate := pCache.get(Generate, clusterrolesGVRS.GroupVersionResource(), "", "", "")
 	if len(deletedGenerate) != 0 {
 		t.Errorf("expected 0 generate policy, found %v", len(deletedGenerate))
 	}
@@ -1050,7 +1054,7 @@ func Test_Add_Validate_Enforce(t *testing.T) {
 			gvrs, err := finder.FindResources(group, version, kind, subresource)
 			assert.NilError(t, err)
 			for gvr := range gvrs {
-				validateEnforce := pCache.get(ValidateEnforce, gvr.GroupVersionResource(), gvr.SubResource, nspace)
+				validateEnforce := pCache.get(ValidateEnforce, gvr.GroupVersionResource(), gvr.SubResource, nspace, "")
 				if len(validateEnforce) != 1 {
 					t.Errorf("expected 1 validate policy, found %v", len(validateEnforce))
 				}
@@ -1066,12 +1070,12 @@ func Test_Ns_Add_Remove_User(t *testing.T) {
 	finder := TestResourceFinder{}
 	// kind := "Deployment"
 	setPolicy(t, pCache, policy, finder)
-	validateEnforce := pCache.get(ValidateEnforce, deploymentsGVRS.GroupVersionResource(), "", nspace)
+	validateEnforce := pCache.get(ValidateEnforce, deploymentsGVRS.GroupVersionResource(), "", nspace, "")
 	if len(validateEnforce) != 1 {
 		t.Errorf("expected 1 validate enforce policy, found %v", len(validateEnforce))
 	}
 	unsetPolicy(pCache, policy)
-	deletedValidateEnforce := pCache.get(ValidateEnforce, deploymentsGVRS.GroupVersionResource(), "", nspace)
+	deletedValidateEnforce := pCache.get(ValidateEnforce, deploymentsGVRS.GroupVersionResource(), "", nspace, "")
 	if len(deletedValidateEnforce) != 0 {
 		t.Errorf("expected 0 validate enforce policy, found %v", len(deletedValidateEnforce))
 	}
@@ -1092,7 +1096,7 @@ func Test_Mutate_Policy(t *testing.T) {
 			assert.NilError(t, err)
 			for gvr := range gvrs {
 				// get
-				mutate := pCache.get(Mutate, gvr.GroupVersionResource(), gvr.SubResource, "")
+				mutate := pCache.get(Mutate, gvr.GroupVersionResource(), gvr.SubResource, "", "")
 				if len(mutate) != 1 {
 					t.Errorf("expected 1 mutate policy, found %v", len(mutate))
 				}
@@ -1114,7 +1118,7 @@ func Test_Generate_Policy(t *testing.T) {
 			assert.NilError(t, err)
 			for gvr := range gvrs {
 				// get
-				generate := pCache.get(Generate, gvr.GroupVersionResource(), gvr.SubResource, "")
+				generate := pCache.get(Generate, gvr.GroupVersionResource(), gvr.SubResource, "", "")
 				if len(generate) != 1 {
 					t.Errorf("expected 1 generate policy, found %v", len(generate))
 				}
@@ -1135,12 +1139,12 @@ func Test_NsMutate_Policy(t *testing.T) {
 	setPolicy(t, pCache, nspolicy, finder)
 	nspace := policy.GetNamespace()
 	// get
-	mutate := pCache.get(Mutate, statefulsetsGVRS.GroupVersionResource(), "", "")
+	mutate := pCache.get(Mutate, statefulsetsGVRS.GroupVersionResource(), "", "", "")
 	if len(mutate) != 1 {
 		t.Errorf("expected 1 mutate policy, found %v", len(mutate))
 	}
 	// get
-	nsMutate := pCache.get(Mutate, statefulsetsGVRS.GroupVersionResource(), "", nspace)
+	nsMutate := pCache.get(Mutate, statefulsetsGVRS.GroupVersionResource(), "", nspace, "")
 	if len(nsMutate) != 1 {
 		t.Errorf("expected 1 namespace mutate policy, found %v", len(nsMutate))
 	}
@@ -1153,21 +1157,21 @@ func Test_Validate_Enforce_Policy(t *testing.T) {
 	finder := TestResourceFinder{}
 	setPolicy(t, pCache, policy1, finder)
 	setPolicy(t, pCache, policy2, finder)
-	validateEnforce := pCache.get(ValidateEnforce, podsGVRS.GroupVersionResource(), "", "")
+	validateEnforce := pCache.get(ValidateEnforce, podsGVRS.GroupVersionResource(), "", "", "")
 	if len(validateEnforce) != 2 {
 		t.Errorf("adding: expected 2 validate enforce policy, found %v", len(validateEnforce))
 	}
-	validateAudit := pCache.get(ValidateAudit, podsGVRS.GroupVersionResource(), "", "")
+	validateAudit := pCache.get(ValidateAudit, podsGVRS.GroupVersionResource(), "", "", "")
 	if len(validateAudit) != 0 {
 		t.Errorf("adding: expected 0 validate audit policy, found %v", len(validateAudit))
 	}
 	unsetPolicy(pCache, policy1)
 	unsetPolicy(pCache, policy2)
-	validateEnforce = pCache.get(ValidateEnforce, podsGVRS.GroupVersionResource(), "", "")
+	validateEnforce = pCache.get(ValidateEnforce, podsGVRS.GroupVersionResource(), "", "", "")
 	if len(validateEnforce) != 0 {
 		t.Errorf("removing: expected 0 validate enforce policy, found %v", len(validateEnforce))
 	}
-	validateAudit = pCache.get(ValidateAudit, podsGVRS.GroupVersionResource(), "", "")
+	validateAudit = pCache.get(ValidateAudit, podsGVRS.GroupVersionResource(), "", "", "")
 	if len(validateAudit) != 0 {
 		t.Errorf("removing: expected 0 validate audit policy, found %v", len(validateAudit))
 	}
@@ -1179,23 +1183,23 @@ func Test_Get_Policies(t *testing.T) {
 	finder := TestResourceFinder{}
 	key, _ := kubecache.MetaNamespaceKeyFunc(policy)
 	cache.Set(key, policy, finder)
-	validateAudit := cache.GetPolicies(ValidateAudit, namespacesGVRS.GroupVersionResource(), "", "")
+	validateAudit := cache.GetPolicies(ValidateAudit, namespacesGVRS.GroupVersionResource(), "", "", "")
 	if len(validateAudit) != 0 {
 		t.Errorf("expected 0 validate audit policy, found %v", len(validateAudit))
 	}
-	validateAudit = cache.GetPolicies(ValidateAudit, podsGVRS.GroupVersionResource(), "", "test")
+	validateAudit = cache.GetPolicies(ValidateAudit, podsGVRS.GroupVersionResource(), "", "test", "")
 	if len(validateAudit) != 0 {
 		t.Errorf("expected 0 validate audit policy, found %v", len(validateAudit))
 	}
-	validateEnforce := cache.GetPolicies(ValidateEnforce, namespacesGVRS.GroupVersionResource(), "", "")
+	validateEnforce := cache.GetPolicies(ValidateEnforce, namespacesGVRS.GroupVersionResource(), "", "", "")
 	if len(validateEnforce) != 1 {
 		t.Errorf("expected 1 validate enforce policy, found %v", len(validateEnforce))
 	}
-	mutate := cache.GetPolicies(Mutate, podsGVRS.GroupVersionResource(), "", "")
+	mutate := cache.GetPolicies(Mutate, podsGVRS.GroupVersionResource(), "", "", "")
 	if len(mutate) != 1 {
 		t.Errorf("expected 1 mutate policy, found %v", len(mutate))
 	}
-	generate := cache.GetPolicies(Generate, podsGVRS.GroupVersionResource(), "", "")
+	generate := cache.GetPolicies(Generate, podsGVRS.GroupVersionResource(), "", "", "")
 	if len(generate) != 1 {
 		t.Errorf("expected 1 generate policy, found %v", len(generate))
 	}
@@ -1208,19 +1212,19 @@ func Test_Get_Policies_Ns(t *testing.T) {
 	key, _ := kubecache.MetaNamespaceKeyFunc(policy)
 	cache.Set(key, policy, finder)
 	nspace := policy.GetNamespace()
-	validateAudit := cache.GetPolicies(ValidateAudit, podsGVRS.GroupVersionResource(), "", nspace)
+	validateAudit := cache.GetPolicies(ValidateAudit, podsGVRS.GroupVersionResource(), "", nspace, "")
 	if len(validateAudit) != 0 {
 		t.Errorf("expected 0 validate audit policy, found %v", len(validateAudit))
 	}
-	validateEnforce := cache.GetPolicies(ValidateEnforce, podsGVRS.GroupVersionResource(), "", nspace)
+	validateEnforce := cache.GetPolicies(ValidateEnforce, podsGVRS.GroupVersionResource(), "", nspace, "")
 	if len(validateEnforce) != 1 {
 		t.Errorf("expected 1 validate enforce policy, found %v", len(validateEnforce))
 	}
-	mutate := cache.GetPolicies(Mutate, podsGVRS.GroupVersionResource(), "", nspace)
+	mutate := cache.GetPolicies(Mutate, podsGVRS.GroupVersionResource(), "", nspace, "")
 	if len(mutate) != 1 {
 		t.Errorf("expected 1 mutate policy, found %v", len(mutate))
 	}
-	generate := cache.GetPolicies(Generate, podsGVRS.GroupVersionResource(), "", nspace)
+	generate := cache.GetPolicies(Generate, podsGVRS.GroupVersionResource(), "", nspace, "")
 	if len(generate) != 1 {
 		t.Errorf("expected 1 generate policy, found %v", len(generate))
 	}
@@ -1235,28 +1239,244 @@ func Test_Get_Policies_Validate_Failure_Action_Overrides(t *testing.T) {
 	cache.Set(key1, policy1, finder)
 	key2, _ := kubecache.MetaNamespaceKeyFunc(policy2)
 	cache.Set(key2, policy2, finder)
-	validateAudit := cache.GetPolicies(ValidateAudit, podsGVRS.GroupVersionResource(), "", "")
+	validateAudit := cache.GetPolicies(ValidateAudit, podsGVRS.GroupVersionResource(), "", "", "")
 	if len(validateAudit) != 1 {
 		t.Errorf("expected 1 validate audit policy, found %v", len(validateAudit))
 	}
-	validateEnforce := cache.GetPolicies(ValidateEnforce, podsGVRS.GroupVersionResource(), "", "")
+	validateEnforce := cache.GetPolicies(ValidateEnforce, podsGVRS.GroupVersionResource(), "", "", "")
 	if len(validateEnforce) != 1 {
 		t.Errorf("expected 1 validate enforce policy, found %v", len(validateEnforce))
 	}
-	validateAudit = cache.GetPolicies(ValidateAudit, podsGVRS.GroupVersionResource(), "", "test")
+	validateAudit = cache.GetPolicies(ValidateAudit, podsGVRS.GroupVersionResource(), "", "test", "")
 	if len(validateAudit) != 2 {
 		t.Errorf("expected 2 validate audit policy, found %v", len(validateAudit))
 	}
-	validateEnforce = cache.GetPolicies(ValidateEnforce, podsGVRS.GroupVersionResource(), "", "test")
+	validateEnforce = cache.GetPolicies(ValidateEnforce, podsGVRS.GroupVersionResource(), "", "test", "")
 	if len(validateEnforce) != 0 {
 		t.Errorf("expected 0 validate enforce policy, found %v", len(validateEnforce))
 	}
-	validateAudit = cache.GetPolicies(ValidateAudit, podsGVRS.GroupVersionResource(), "", "default")
+	validateAudit = cache.GetPolicies(ValidateAudit, podsGVRS.GroupVersionResource(), "", "default", "")
 	if len(validateAudit) != 0 {
 		t.Errorf("expected 0 validate audit policy, found %v", len(validateAudit))
 	}
-	validateEnforce = cache.GetPolicies(ValidateEnforce, podsGVRS.GroupVersionResource(), "", "default")
+	validateEnforce = cache.GetPolicies(ValidateEnforce, podsGVRS.GroupVersionResource(), "", "default", "")
 	if len(validateEnforce) != 2 {
 		t.Errorf("expected 2 validate enforce policy, found %v", len(validateEnforce))
 	}
 }
+
+func newOperationsPolicy(t *testing.T) *kyvernov1.ClusterPolicy {
+	rawPolicy := []byte(`{
+		"metadata": {
+		  "name": "check-operations"
+		},
+		"spec": {
+		  "validationFailureAction": "enforce",
+		  "rules": [
+			{
+				"name": "on-create",
+				"match": {
+					"any": [
+						{
+							"resources": {
+								"kinds": ["Pod"],
+								"operations": ["CREATE"]
+							}
+						}
+					]
+				},
+				"validate": {
+					"pattern": {
+						"metadata": {
+							"labels": {
+								"app": "?*"
+							}
+						}
+					}
+				}
+			},
+			{
+				"name": "on-delete",
+				"match": {
+					"resources": {
+						"kinds": ["Namespace"],
+						"operations": ["DELETE"]
+					}
+				},
+				"validate": {
+					"deny": {}
+				}
+			},
+			{
+				"name": "on-all",
+				"match": {
+					"all": [
+						{
+							"resources": {
+								"kinds": ["Namespace"]
+							}
+						}
+					]
+				},
+				"mutate": {
+					"patchStrategicMerge": {
+						"metadata": {
+							"labels": {
+								"app": "test"
+							}
+						}
+					}
+				}
+			}
+		  ]
+		}
+	  }`)
+	var policy *kyvernov1.ClusterPolicy
+	err := json.Unmarshal(rawPolicy, &policy)
+	assert.NilError(t, err)
+	return policy
+}
+
+func Test_Get_Policies_Operations(t *testing.T) {
+	cache := NewCache()
+	policy := newOperationsPolicy(t)
+	finder := TestResourceFinder{}
+	key, _ := kubecache.MetaNamespaceKeyFunc(policy)
+	cache.Set(key, policy, finder)
+	assert.Equal(t, len(cache.GetPolicies(ValidateEnforce, podsGVRS.GroupVersionResource(), "", "", kyvernov1.Create)), 1)
+	assert.Equal(t, len(cache.GetPolicies(ValidateEnforce, podsGVRS.GroupVersionResource(), "", "", kyvernov1.Update)), 0)
+	assert.Equal(t, len(cache.GetPolicies(ValidateEnforce, podsGVRS.GroupVersionResource(), "", "", "")), 1)
+	assert.Equal(t, len(cache.GetPolicies(ValidateEnforce, namespacesGVRS.GroupVersionResource(), "", "", kyvernov1.Delete)), 1)
+	assert.Equal(t, len(cache.GetPolicies(ValidateEnforce, namespacesGVRS.GroupVersionResource(), "", "", kyvernov1.Create)), 0)
+	assert.Equal(t, len(cache.GetPolicies(Mutate, namespacesGVRS.GroupVersionResource(), "", "", kyvernov1.Update)), 1)
+	cache.Unset(key)
+	assert.Equal(t, len(cache.GetPolicies(ValidateEnforce, podsGVRS.GroupVersionResource(), "", "", kyvernov1.Create)), 0)
+	assert.Equal(t, len(cache.GetPolicies(Mutate, namespacesGVRS.GroupVersionResource(), "", "", "")), 0)
+}
+
+func Test_Compute_Operations(t *testing.T) {
+	filter := func(ops ...kyvernov1.AdmissionOperation) kyvernov1.ResourceFilter {
+		return kyvernov1.ResourceFilter{ResourceDescription: kyvernov1.ResourceDescription{Kinds: []string{"Pod"}, Operations: ops}}
+	}
+	testCases := []struct {
+		name  string
+		match kyvernov1.MatchResources
+		want  []kyvernov1.AdmissionOperation
+	}{{
+		name:  "resources without operations",
+		match: kyvernov1.MatchResources{ResourceDescription: kyvernov1.ResourceDescription{Kinds: []string{"Pod"}}},
+	}, {
+		name:  "resources with operations",
+		match: kyvernov1.MatchResources{ResourceDescription: kyvernov1.ResourceDescription{Kinds: []string{"Pod"}, Operations: []kyvernov1.AdmissionOperation{kyvernov1.Create}}},
+		want:  []kyvernov1.AdmissionOperation{kyvernov1.Create},
+	}, {
+		name:  "any is the union",
+		match: kyvernov1.MatchResources{Any: kyvernov1.ResourceFilters{filter(kyvernov1.Create), filter(kyvernov1.Update)}},
+		want:  []kyvernov1.AdmissionOperation{kyvernov1.Create, kyvernov1.Update},
+	}, {
+		name:  "any with a filter without operations",
+		match: kyvernov1.MatchResources{Any: kyvernov1.ResourceFilters{filter(kyvernov1.Create), filter()}},
+	}, {
+		name:  "all is the intersection",
+		match: kyvernov1.MatchResources{All: kyvernov1.ResourceFilters{filter(kyvernov1.Create, kyvernov1.Update), filter(kyvernov1.Update), filter()}},
+		want:  []kyvernov1.AdmissionOperation{kyvernov1.Update},
+	}}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := computeOperations(kyvernov1.Rule{MatchResources: tc.match})
+			if tc.want == nil {
+				assert.Assert(t, got == nil)
+			} else {
+				assert.DeepEqual(t, sets.List(sets.Set[kyvernov1.AdmissionOperation](got)), tc.want)
+			}
+		})
+	}
+}
+
+func Test_Namespace_Validation_Failure_Actions(t *testing.T) {
+	indexer := kubecache.NewIndexer(kubecache.MetaNamespaceKeyFunc, kubecache.Indexers{})
+	assert.NilError(t, indexer.Add(&corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "onboarded",
+			Annotations: map[string]string{
+				"kyverno.io/validation-failure-actions": "check-label-app-audit=Enforce,check-label-app-*=Audit",
+			},
+		},
+	}))
+	assert.NilError(t, indexer.Add(&corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "default",
+		},
+	}))
+	pCache := NewCacheWithCompiler(nil, corev1listers.NewNamespaceLister(indexer))
+	finder := TestResourceFinder{}
+	auditPolicy := newValidateAuditPolicy(t)
+	auditPolicy.Spec.ValidationFailureActionOverrides = nil
+	enforcePolicy := newValidateEnforcePolicy(t)
+	enforcePolicy.Spec.ValidationFailureActionOverrides = nil
+	for _, policy := range []kyvernov1.PolicyInterface{auditPolicy, enforcePolicy} {
+		key, _ := kubecache.MetaNamespaceKeyFunc(policy)
+		assert.NilError(t, pCache.Set(key, policy, finder))
+	}
+	names := func(policies []kyvernov1.PolicyInterface) []string {
+		var names []string
+		for _, policy := range policies {
+			names = append(names, policy.GetName())
+		}
+		return names
+	}
+	tests := []struct {
+		namespace string
+		enforce   []string
+		audit     []string
+	}{{
+		namespace: "default",
+		enforce:   []string{"check-label-app-enforce"},
+		audit:     []string{"check-label-app-audit"},
+	}, {
+		namespace: "onboarded",
+		enforce:   []string{"check-label-app-audit"},
+		audit:     []string{"check-label-app-enforce"},
+	}, {
+		namespace: "missing",
+		enforce:   []string{"check-label-app-enforce"},
+		audit:     []string{"check-label-app-audit"},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.namespace, func(t *testing.T) {
+			enforce := pCache.GetPolicies(ValidateEnforce, podsGVR, "", tt.namespace, "")
+			audit := pCache.GetPolicies(ValidateAudit, podsGVR, "", tt.namespace, "")
+			assert.DeepEqual(t, names(enforce), tt.enforce)
+			assert.DeepEqual(t, names(audit), tt.audit)
+		})
+	}
+}
+
+func Test_Snapshot(t *testing.T) {
+	cache := NewCache()
+	finder := TestResourceFinder{}
+	policy := newOperationsPolicy(t)
+	key, _ := kubecache.MetaNamespaceKeyFunc(policy)
+	empty := cache.Snapshot()
+	assert.NilError(t, cache.Set(key, policy, finder))
+	current := cache.Snapshot()
+	assert.Assert(t, current.Revision() > empty.Revision())
+	assert.Equal(t, len(empty.GetPolicies(ValidateEnforce, podsGVRS.GroupVersionResource(), "", "", kyvernov1.Create)), 0)
+	assert.Equal(t, len(current.GetPolicies(ValidateEnforce, podsGVRS.GroupVersionResource(), "", "", kyvernov1.Create)), 1)
+
+	// updates are not visible in snapshots taken before
+	generate := len(current.GetPolicies(Generate, podsGVRS.GroupVersionResource(), "", "", ""))
+	other := newPolicy(t)
+	otherKey, _ := kubecache.MetaNamespaceKeyFunc(other)
+	assert.NilError(t, cache.Set(otherKey, other, finder))
+	cache.Unset(key)
+	assert.Equal(t, len(current.GetPolicies(ValidateEnforce, podsGVRS.GroupVersionResource(), "", "", kyvernov1.Create)), 1)
+	assert.Equal(t, len(current.GetPolicies(Generate, podsGVRS.GroupVersionResource(), "", "", "")), generate)
+	assert.Equal(t, len(current.GetPolicies(Mutate, namespacesGVRS.GroupVersionResource(), "", "", kyvernov1.Update)), 1)
+	latest := cache.Snapshot()
+	assert.Assert(t, latest.Revision() > current.Revision())
+	for _, policy := range latest.GetPolicies(ValidateEnforce, podsGVRS.GroupVersionResource(), "", "", "") {
+		assert.Equal(t, policy.GetName(), other.GetName())
+	}
+	assert.Equal(t, cache.Snapshot().Revision(), latest.Revision())
+}
@@ -2,9 +2,11 @@ package policycache
 
 import (
 	"sync"
+	"sync/atomic"
 
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
 	"github.com/kyverno/kyverno/pkg/autogen"
+	"github.com/kyverno/kyverno/pkg/jsonpayload"
 	kubeutils "github.com/kyverno/kyverno/pkg/utils/kube"
 	"go.uber.org/multierr"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -17,42 +19,54 @@ type store interface {
 	set(string, kyvernov1.PolicyInterface, ResourceFinder) error
 	// unset removes a policy from the cache
 	unset(string)
-	// get finds policies that match a given type, gvr, subresource and namespace
-	get(PolicyType, schema.GroupVersionResource, string, string) []kyvernov1.PolicyInterface
+	// get finds policies that match a given type, gvr, subresource, namespace and operation
+	get(PolicyType, schema.GroupVersionResource, string, string, kyvernov1.AdmissionOperation) []kyvernov1.PolicyInterface
 }
 
+// policyCache is a copy-on-write store, every update publishes a new policy map
+// so that readers holding a previous map are not affected by the update
 type policyCache struct {
-	store store
-	lock  sync.RWMutex
+	// lock serializes updates, reads don't need to lock
+	lock    sync.Mutex
+	current atomic.Pointer[policyMap]
 }
 
-func newPolicyCache() store {
-	return &policyCache{
-		store: newPolicyMap(),
-	}
+func newPolicyCache() *policyCache {
+	pc := &policyCache{}
+	pc.current.Store(newPolicyMap())
+	return pc
 }
 
 func (pc *policyCache) set(key string, policy kyvernov1.PolicyInterface, client ResourceFinder) error {
 	pc.lock.Lock()
 	defer pc.lock.Unlock()
-	if err := pc.store.set(key, policy, client); err != nil {
+	next := pc.current.Load().clone()
+	err := next.set(key, policy, client)
+	// the policy is (partially) indexed even when discovery fails for some kinds
+	pc.current.Store(next)
+	if err != nil {
 		return err
 	}
-	logger.V(4).Info("policy is added to cache", "key", key)
+	logger.V(4).Info("policy is added to cache", "key", key, "revision", next.revision)
 	return nil
 }
 
 func (pc *policyCache) unset(key string) {
 	pc.lock.Lock()
 	defer pc.lock.Unlock()
-	pc.store.unset(key)
-	logger.V(4).Info("policy is removed from cache", "key", key)
+	next := pc.current.Load().clone()
+	next.unset(key)
+	pc.current.Store(next)
+	logger.V(4).Info("policy is removed from cache", "key", key, "revision", next.revision)
+}
+
+func (pc *policyCache) get(pkey PolicyType, gvr schema.GroupVersionResource, subresource string, nspace string, operation kyvernov1.AdmissionOperation) []kyvernov1.PolicyInterface {
+	return pc.current.Load().get(pkey, gvr, subresource, nspace, operation)
 }
 
-func (pc *policyCache) get(pkey PolicyType, gvr schema.GroupVersionResource, subresource string, nspace string) []kyvernov1.PolicyInterface {
-	pc.lock.RLock()
-	defer pc.lock.RUnlock()
-	return pc.store.get(pkey, gvr, subresource, nspace)
+// snapshot returns the current policy map, it must not be modified
+func (pc *policyCache) snapshot() *policyMap {
+	return pc.current.Load()
 }
 
 type policyKey struct {
@@ -64,19 +78,64 @@ type policyKey struct {
 
 var podsKey = policyKey{"", "v1", "pods", ""}
 
+// operations is the set of operations a policy applies to, a nil set means all operations
+type operations sets.Set[kyvernov1.AdmissionOperation]
+
+func (o operations) has(operation kyvernov1.AdmissionOperation) bool {
+	return o == nil || operation == "" || sets.Set[kyvernov1.AdmissionOperation](o).Has(operation)
+}
+
+func (o operations) union(other operations) operations {
+	if o == nil || other == nil {
+		return nil
+	}
+	return operations(sets.Set[kyvernov1.AdmissionOperation](o).Union(sets.Set[kyvernov1.AdmissionOperation](other)))
+}
+
+// indexEntry locates a policy in the index
+type indexEntry struct {
+	key        policyKey
+	policyType PolicyType
+	namespace  string
+}
+
 type policyMap struct {
+	// revision is incremented every time a policy map is cloned to be updated
+	revision uint64
 	// policies maps names to policy interfaces
 	policies map[string]kyvernov1.PolicyInterface
-	// kindType stores names of ClusterPolicies and Namespaced Policies.
-	// They are accessed first by GVRS then by PolicyType.
-	kindType map[policyKey]map[PolicyType]sets.Set[string]
+	// index stores names of ClusterPolicies and Namespaced Policies with the operations they apply to.
+	// They are accessed first by GVRS, then by PolicyType and then by namespace (empty for ClusterPolicies).
+	index map[policyKey]map[PolicyType]map[string]map[string]operations
+	// entries stores the index entries of every policy, it is used to remove policies from the index
+	entries map[string][]indexEntry
 }
 
 func newPolicyMap() *policyMap {
 	return &policyMap{
 		policies: map[string]kyvernov1.PolicyInterface{},
-		kindType: map[policyKey]map[PolicyType]sets.Set[string]{},
+		index:    map[policyKey]map[PolicyType]map[string]map[string]operations{},
+		entries:  map[string][]indexEntry{},
+	}
+}
+
+// clone returns a copy of the policy map with the next revision, the nested index maps are shared
+// and copied on write by insert and unset
+func (m *policyMap) clone() *policyMap {
+	return &policyMap{
+		revision: m.revision + 1,
+		policies: cloneMap(m.policies),
+		index:    cloneMap(m.index),
+		entries:  cloneMap(m.entries),
+	}
+}
+
+func cloneMap[K comparable, V any](m map[K]V) map[K]V {
+	out := make(map[K]V, len(m))
+	for k, v := range m {
+		out[k] = v
 	}
+	return out
 }
 
 func computeEnforcePolicy(spec *kyvernov1.Spec) bool {
@@ -91,26 +150,78 @@ func computeEnforcePolicy(spec *kyvernov1.Spec) bool {
 	return false
 }
 
-func set(set sets.Set[string], item string, value bool) sets.Set[string] {
-	if value {
-		return set.Insert(item)
-	} else {
-		return set.Delete(item)
+// computeOperations returns the operations a rule applies to, the result can be a superset of
+// the actual operations, the engine is responsible for precise matching
+func computeOperations(rule kyvernov1.Rule) operations {
+	match := rule.MatchResources
+	descriptionOperations := func(description kyvernov1.ResourceDescription) operations {
+		if len(description.Operations) == 0 {
+			return nil
+		}
+		return operations(sets.New(description.Operations...))
+	}
+	hasTopLevel := !match.ResourceDescription.IsEmpty() || len(match.ResourceDescription.Operations) != 0
+	switch {
+	case len(match.Any) != 0 && !hasTopLevel:
+		ops := operations(sets.New[kyvernov1.AdmissionOperation]())
+		for _, filter := range match.Any {
+			ops = ops.union(descriptionOperations(filter.ResourceDescription))
+		}
+		return ops
+	case len(match.All) != 0 && !hasTopLevel:
+		var ops operations
+		for _, filter := range match.All {
+			filterOps := descriptionOperations(filter.ResourceDescription)
+			if filterOps == nil {
+				continue
+			}
+			if ops == nil {
+				ops = filterOps
+			} else {
+				ops = operations(sets.Set[kyvernov1.AdmissionOperation](ops).Intersection(sets.Set[kyvernov1.AdmissionOperation](filterOps)))
+			}
+		}
+		return ops
+	case len(match.Any) == 0 && len(match.All) == 0:
+		return descriptionOperations(match.ResourceDescription)
 	}
+	return nil
 }
 
 func (m *policyMap) set(key string, policy kyvernov1.PolicyInterface, client ResourceFinder) error {
 	var errs []error
 	enforcePolicy := computeEnforcePolicy(policy.GetSpec())
+	namespace, _, err := kcache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		return err
+	}
+	m.unset(key)
 	m.policies[key] = policy
 	type state struct {
 		hasMutate, hasValidate, hasGenerate, hasVerifyImages, hasImagesValidationChecks bool
+		// operations each rule type applies to, aggregated across rules
+		mutate, validate, generate, verifyImages operations
+	}
+	add := func(has bool, ops operations, flag bool, current operations) (bool, operations) {
+		if !flag {
+			return has, current
+		}
+		if !has {
+			return true, ops
+		}
+		return true, current.union(ops)
 	}
 	kindStates := map[policyKey]state{}
 	for _, rule := range autogen.ComputeRules(policy) {
 		entries := sets.New[policyKey]()
 		for _, gvk := range rule.MatchResources.GetKinds() {
 			group, version, kind, subresource := kubeutils.ParseKindSelector(gvk)
+			// payload kinds are not served by the API server, they are indexed without discovery
+			if jsonpayload.IsPayloadGroup(group) {
+				gvr := jsonpayload.GroupVersionResource(kind)
+				entries.Insert(policyKey{Group: gvr.Group, Version: gvr.Version, Resource: gvr.Resource})
+				continue
+			}
 			gvrss, err := client.FindResources(group, version, kind, subresource)
 			if err != nil {
 				logger.Error(err, "failed to fetch resource group versions", "group", group, "version", version, "kind", kind)
@@ -136,6 +247,7 @@ func (m *policyMap) set(key string, policy kyvernov1.PolicyInterface, client Res
 					SubResource: "ephemeralcontainers",
 				})
 			}
+			ops := computeOperations(rule)
 			hasMutate := rule.HasMutate()
 			hasValidate := rule.HasValidate()
 			hasGenerate := rule.HasGenerate()
@@ -143,65 +255,89 @@ func (m *policyMap) set(key string, policy kyvernov1.PolicyInterface, client Res
 			hasImagesValidationChecks := rule.HasVerifyImageChecks()
 			for gvrs := range entries {
 				entry := kindStates[gvrs]
-				entry.hasMutate = entry.hasMutate || hasMutate
-				entry.hasValidate = entry.hasValidate || hasValidate
-				entry.hasGenerate = entry.hasGenerate || hasGenerate
-				entry.hasVerifyImages = entry.hasVerifyImages || hasVerifyImages
+				entry.hasMutate, entry.mutate = add(entry.hasMutate, ops, hasMutate, entry.mutate)
+				entry.hasValidate, entry.validate = add(entry.hasValidate, ops, hasValidate, entry.validate)
+				entry.hasGenerate, entry.generate = add(entry.hasGenerate, ops, hasGenerate, entry.generate)
+				entry.hasVerifyImages, entry.verifyImages = add(entry.hasVerifyImages, ops, hasVerifyImages, entry.verifyImages)
 				entry.hasImagesValidationChecks = entry.hasImagesValidationChecks || hasImagesValidationChecks
 				kindStates[gvrs] = entry
 			}
 		}
 	}
 	for gvrs, state := range kindStates {
-		if m.kindType[gvrs] == nil {
-			m.kindType[gvrs] = map[PolicyType]sets.Set[string]{
-				Mutate:               sets.New[string](),
-				ValidateEnforce:      sets.New[string](),
-				ValidateAudit:        sets.New[string](),
-				Generate:             sets.New[string](),
-				VerifyImagesMutate:   sets.New[string](),
-				VerifyImagesValidate: sets.New[string](),
-			}
+		if state.hasMutate {
+			m.insert(key, gvrs, Mutate, namespace, state.mutate)
+		}
+		if state.hasValidate && enforcePolicy {
+			m.insert(key, gvrs, ValidateEnforce, namespace, state.validate)
+		}
+		if state.hasValidate && !enforcePolicy {
+			m.insert(key, gvrs, ValidateAudit, namespace, state.validate)
+		}
+		if state.hasGenerate {
+			m.insert(key, gvrs, Generate, namespace, state.generate)
+		}
+		if state.hasVerifyImages {
+			m.insert(key, gvrs, VerifyImagesMutate, namespace, state.verifyImages)
+		}
+		if state.hasVerifyImages && state.hasImagesValidationChecks {
+			m.insert(key, gvrs, VerifyImagesValidate, namespace, state.verifyImages)
 		}
-		m.kindType[gvrs][Mutate] = set(m.kindType[gvrs][Mutate], key, state.hasMutate)
-		m.kindType[gvrs][ValidateEnforce] = set(m.kindType[gvrs][ValidateEnforce], key, state.hasValidate && enforcePolicy)
-		m.kindType[gvrs][ValidateAudit] = set(m.kindType[gvrs][ValidateAudit], key, state.hasValidate && !enforcePolicy)
-		m.kindType[gvrs][Generate] = set(m.kindType[gvrs][Generate], key, state.hasGenerate)
-		m.kindType[gvrs][VerifyImagesMutate] = set(m.kindType[gvrs][VerifyImagesMutate], key, state.hasVerifyImages)
-		m.kindType[gvrs][VerifyImagesValidate] = set(m.kindType[gvrs][VerifyImagesValidate], key, state.hasVerifyImages && state.hasImagesValidationChecks)
 	}
 	return multierr.Combine(errs...)
 }
 
+func (m *policyMap) insert(key string, gvrs policyKey, policyType PolicyType, namespace string, ops operations) {
+	// nested maps can be shared with previous revisions, they are copied before being modified
+	byType := cloneMap(m.index[gvrs])
+	byNamespace := cloneMap(byType[policyType])
+	policies := cloneMap(byNamespace[namespace])
+	policies[key] = ops
+	byNamespace[namespace] = policies
+	byType[policyType] = byNamespace
+	m.index[gvrs] = byType
+	m.entries[key] = append(m.entries[key], indexEntry{key: gvrs, policyType: policyType, namespace: namespace})
+}
+
 func (m *policyMap) unset(key string) {
 	delete(m.policies, key)
-	for gvrs := range m.kindType {
-		for policyType := range m.kindType[gvrs] {
-			m.kindType[gvrs][policyType] = m.kindType[gvrs][policyType].Delete(key)
+	for _, entry := range m.entries[key] {
+		byType := cloneMap(m.index[entry.key])
+		byNamespace := cloneMap(byType[entry.policyType])
+		policies := cloneMap(byNamespace[entry.namespace])
+		delete(policies, key)
+		// prune empty levels to keep the index proportional to the installed policies
+		if len(policies) != 0 {
+			byNamespace[entry.namespace] = policies
+		} else {
+			delete(byNamespace, entry.namespace)
+		}
+		if len(byNamespace) != 0 {
+			byType[entry.policyType] = byNamespace
+		} else {
+			delete(byType, entry.policyType)
+		}
+		if len(byType) != 0 {
+			m.index[entry.key] = byType
+		} else {
+			delete(m.index, entry.key)
 		}
 	}
+	delete(m.entries, key)
 }
 
-func (m *policyMap) get(key PolicyType, gvr schema.GroupVersionResource, subresource string, namespace string) []kyvernov1.PolicyInterface {
+func (m *policyMap) get(key PolicyType, gvr schema.GroupVersionResource, subresource string, namespace string, operation kyvernov1.AdmissionOperation) []kyvernov1.PolicyInterface {
 	var result []kyvernov1.PolicyInterface
 	pKey := policyKey{gvr.Group, gvr.Version, gvr.Resource, subresource}
-	for policyName := range m.kindType[pKey][key] {
-		ns, _, err := kcache.SplitMetaNamespaceKey(policyName)
-		if err != nil {
-			logger.Error(err, "failed to parse policy name", "policyName", policyName)
+	for policyName, ops := range m.index[pKey][key][namespace] {
+		if !ops.has(operation) {
+			continue
 		}
-		isNamespacedPolicy := ns != ""
 		policy := m.policies[policyName]
 		if policy == nil {
 			logger.Info("nil policy in the cache, this should not happen")
 		}
-		if !isNamespacedPolicy && namespace == "" {
-			result = append(result, policy)
-		} else {
-			if ns == namespace {
-				result = append(result, policy)
-			}
-		}
+		result = append(result, policy)
 	}
 	return result
 }
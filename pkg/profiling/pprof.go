@@ -8,10 +8,14 @@ import (
 
 	"github.com/go-logr/logr"
 	"github.com/kyverno/kyverno/pkg/logging"
+	"github.com/kyverno/kyverno/pkg/profiling/rulestats"
 )
 
 func Start(logger logr.Logger, address string) {
 	logger.Info("Enable profiling, see details at https://github.com/kyverno/kyverno/wiki/Profiling-Kyverno-on-Kubernetes")
+	// serve the per rule timings breakdown alongside pprof endpoints
+	rulestats.Enable()
+	http.Handle(rulestats.DebugPath, rulestats.Handler())
 	go func() {
 		s := http.Server{
 			Addr:              address,
@@ -0,0 +1,166 @@
+package rulestats
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+)
+
+// DebugPath is the path of the endpoint serving rule statistics on the profiling server
+const DebugPath = "/debug/kyverno/rules"
+
+var (
+	enabled  atomic.Bool
+	recorder = NewRecorder()
+)
+
+// Enable enables recording of rule statistics in the default recorder
+func Enable() {
+	enabled.Store(true)
+}
+
+// Record records the rules of an engine response in the default recorder, it's a noop unless Enable was called
+func Record(response engineapi.EngineResponse) {
+	if enabled.Load() {
+		recorder.Record(response)
+	}
+}
+
+// Handler returns an http handler serving the statistics of the default recorder
+func Handler() http.Handler {
+	return recorder
+}
+
+type key struct {
+	policy   string
+	rule     string
+	ruleType engineapi.RuleType
+}
+
+type stats struct {
+	count          int64
+	total          time.Duration
+	max            time.Duration
+	contextLoading time.Duration
+	preconditions  time.Duration
+}
+
+// RuleStats is the aggregated timing breakdown of a rule
+type RuleStats struct {
+	Policy         string             `json:"policy"`
+	Rule           string             `json:"rule"`
+	Type           engineapi.RuleType `json:"type"`
+	Count          int64              `json:"count"`
+	TotalTime      string             `json:"totalTime"`
+	AverageTime    string             `json:"averageTime"`
+	MaxTime        string             `json:"maxTime"`
+	ContextLoading string             `json:"contextLoadingTime"`
+	Preconditions  string             `json:"preconditionsTime"`
+}
+
+// Recorder aggregates rule timings from engine responses
+type Recorder struct {
+	lock  sync.Mutex
+	rules map[key]*stats
+}
+
+func NewRecorder() *Recorder {
+	return &Recorder{
+		rules: map[key]*stats{},
+	}
+}
+
+// Record aggregates the timings of the rules in the engine response
+func (r *Recorder) Record(response engineapi.EngineResponse) {
+	if len(response.PolicyResponse.Rules) == 0 {
+		return
+	}
+	policy := response.Policy().GetName()
+	if namespace := response.Policy().GetNamespace(); namespace != "" {
+		policy = namespace + "/" + policy
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	for _, rule := range response.PolicyResponse.Rules {
+		k := key{policy: policy, rule: rule.Name(), ruleType: rule.RuleType()}
+		s := r.rules[k]
+		if s == nil {
+			s = &stats{}
+			r.rules[k] = s
+		}
+		duration := rule.Stats().ProcessingTime()
+		s.count++
+		s.total += duration
+		if duration > s.max {
+			s.max = duration
+		}
+		s.contextLoading += rule.Timings().ContextLoading
+		s.preconditions += rule.Timings().Preconditions
+	}
+}
+
+// Stats returns the aggregated rule statistics, sorted by decreasing total time
+func (r *Recorder) Stats() []RuleStats {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	type entry struct {
+		key
+		*stats
+	}
+	entries := make([]entry, 0, len(r.rules))
+	for k, s := range r.rules {
+		entries = append(entries, entry{k, s})
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].total != entries[j].total {
+			return entries[i].total > entries[j].total
+		}
+		if entries[i].policy != entries[j].policy {
+			return entries[i].policy < entries[j].policy
+		}
+		return entries[i].rule < entries[j].rule
+	})
+	out := make([]RuleStats, 0, len(entries))
+	for _, e := range entries {
+		out = append(out, RuleStats{
+			Policy:         e.policy,
+			Rule:           e.rule,
+			Type:           e.ruleType,
+			Count:          e.count,
+			TotalTime:      e.total.String(),
+			AverageTime:    (e.total / time.Duration(e.count)).String(),
+			MaxTime:        e.max.String(),
+			ContextLoading: e.contextLoading.String(),
+			Preconditions:  e.preconditions.String(),
+		})
+	}
+	return out
+}
+
+// Reset clears the aggregated statistics
+func (r *Recorder) Reset() {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.rules = map[key]*stats{}
+}
+
+// ServeHTTP serves the aggregated statistics as json, a DELETE request resets them
+func (r *Recorder) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
+	switch request.Method {
+	case http.MethodGet:
+		writer.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(writer).Encode(r.Stats()); err != nil {
+			http.Error(writer, err.Error(), http.StatusInternalServerError)
+		}
+	case http.MethodDelete:
+		r.Reset()
+		writer.WriteHeader(http.StatusNoContent)
+	default:
+		writer.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
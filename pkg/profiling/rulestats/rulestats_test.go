@@ -0,0 +1,66 @@
+package rulestats
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	"gotest.tools/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func response(policy string, rules ...engineapi.RuleResponse) engineapi.EngineResponse {
+	return engineapi.NewEngineResponse(
+		unstructured.Unstructured{},
+		engineapi.NewKyvernoPolicy(&kyvernov1.ClusterPolicy{ObjectMeta: metav1.ObjectMeta{Name: policy}}),
+		nil,
+	).WithPolicyResponse(engineapi.PolicyResponse{Rules: rules})
+}
+
+func rule(name string, duration time.Duration, timings engineapi.RuleTimings) engineapi.RuleResponse {
+	start := time.Now()
+	return engineapi.RulePass(name, engineapi.Validation, "").WithTimings(timings).WithStats(engineapi.NewExecutionStats(start, start.Add(duration)))
+}
+
+func TestRecorder(t *testing.T) {
+	recorder := NewRecorder()
+	recorder.Record(response("fast", rule("a", time.Millisecond, engineapi.RuleTimings{})))
+	recorder.Record(response("slow", rule("b", 3*time.Millisecond, engineapi.RuleTimings{ContextLoading: 2 * time.Millisecond})))
+	recorder.Record(response("slow", rule("b", 5*time.Millisecond, engineapi.RuleTimings{Preconditions: time.Millisecond})))
+	stats := recorder.Stats()
+	assert.Equal(t, len(stats), 2)
+	assert.DeepEqual(t, stats[0], RuleStats{
+		Policy:         "slow",
+		Rule:           "b",
+		Type:           engineapi.Validation,
+		Count:          2,
+		TotalTime:      "8ms",
+		AverageTime:    "4ms",
+		MaxTime:        "5ms",
+		ContextLoading: "2ms",
+		Preconditions:  "1ms",
+	})
+	assert.Equal(t, stats[1].Policy, "fast")
+	assert.Equal(t, stats[1].Count, int64(1))
+}
+
+func TestRecorder_ServeHTTP(t *testing.T) {
+	recorder := NewRecorder()
+	recorder.Record(response("test", rule("a", time.Millisecond, engineapi.RuleTimings{})))
+	get := httptest.NewRecorder()
+	recorder.ServeHTTP(get, httptest.NewRequest(http.MethodGet, DebugPath, nil))
+	assert.Equal(t, get.Code, http.StatusOK)
+	var stats []RuleStats
+	assert.NilError(t, json.Unmarshal(get.Body.Bytes(), &stats))
+	assert.Equal(t, len(stats), 1)
+	assert.Equal(t, stats[0].Rule, "a")
+	del := httptest.NewRecorder()
+	recorder.ServeHTTP(del, httptest.NewRequest(http.MethodDelete, DebugPath, nil))
+	assert.Equal(t, del.Code, http.StatusNoContent)
+	assert.Equal(t, len(recorder.Stats()), 0)
+}
@@ -62,22 +62,26 @@ func evaluatePSS(level *api.LevelVersion, pod corev1.Pod) (results []pssutils.PS
 }
 
 func exemptKyvernoExclusion(defaultCheckResults, excludeCheckResults []pssutils.PSSCheckResult, exclude kyvernov1.PodSecurityStandard) []pssutils.PSSCheckResult {
-	defaultCheckResultsMap := make(map[string]pssutils.PSSCheckResult, len(defaultCheckResults))
-
-	for _, result := range defaultCheckResults {
-		defaultCheckResultsMap[result.ID] = result
-	}
-
+	exempted := make(map[string]struct{}, len(excludeCheckResults))
 	for _, excludeResult := range excludeCheckResults {
 		for _, checkID := range pssutils.PSS_controls_to_check_id[exclude.ControlName] {
 			if excludeResult.ID == checkID {
-				delete(defaultCheckResultsMap, checkID)
+				exempted[checkID] = struct{}{}
 			}
 		}
 	}
 
+	// keep the evaluation order so that failure messages are stable
 	var newDefaultCheckResults []pssutils.PSSCheckResult
-	for _, result := range defaultCheckResultsMap {
+	seen := make(map[string]struct{}, len(defaultCheckResults))
+	for _, result := range defaultCheckResults {
+		if _, ok := exempted[result.ID]; ok {
+			continue
+		}
+		if _, ok := seen[result.ID]; ok {
+			continue
+		}
+		seen[result.ID] = struct{}{}
 		newDefaultCheckResults = append(newDefaultCheckResults, result)
 	}
 
@@ -97,6 +101,10 @@ func parseVersion(rule *kyvernov1.PodSecurity) (*api.LevelVersion, error) {
 			return nil, err
 		}
 		apiVersion = api.MajorMinorVersion(parsedApiVersion.Major(), parsedApiVersion.Minor())
+		// versions newer than the ones known by the pod security admission library are evaluated as latest
+		if latestVersion := api.LatestVersion(); latestVersion.Older(apiVersion) {
+			apiVersion = latestVersion
+		}
 	}
 	return &api.LevelVersion{
 		Level:   rule.Level,
@@ -135,7 +143,7 @@ func EvaluatePod(rule *kyvernov1.PodSecurity, pod *corev1.Pod) (bool, []pssutils
 // GetPodWithMatchingContainers extracts matching container/pod info by the given exclude rule
 // and returns pod manifests containing spec and container info respectively
 func GetPodWithMatchingContainers(exclude kyvernov1.PodSecurityStandard, pod *corev1.Pod) (podSpec, matching *corev1.Pod) {
-	if len(exclude.Images) == 0 {
+	if len(exclude.Images) == 0 && len(exclude.ContainerNames) == 0 {
 		podSpec = pod.DeepCopy()
 		podSpec.Spec.Containers = []corev1.Container{{Name: "fake"}}
 		podSpec.Spec.InitContainers = nil
@@ -143,7 +151,6 @@ func GetPodWithMatchingContainers(exclude kyvernov1.PodSecurityStandard, pod *co
 		return podSpec, nil
 	}
 
-	matchingImages := exclude.Images
 	matching = &corev1.Pod{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      pod.GetName(),
@@ -151,18 +158,18 @@ func GetPodWithMatchingContainers(exclude kyvernov1.PodSecurityStandard, pod *co
 		},
 	}
 	for _, container := range pod.Spec.Containers {
-		if wildcard.CheckPatterns(matchingImages, container.Image) {
+		if containerMatches(exclude, container.Name, container.Image) {
 			matching.Spec.Containers = append(matching.Spec.Containers, container)
 		}
 	}
 	for _, container := range pod.Spec.InitContainers {
-		if wildcard.CheckPatterns(matchingImages, container.Image) {
+		if containerMatches(exclude, container.Name, container.Image) {
 			matching.Spec.InitContainers = append(matching.Spec.InitContainers, container)
 		}
 	}
 
 	for _, container := range pod.Spec.EphemeralContainers {
-		if wildcard.CheckPatterns(matchingImages, container.Image) {
+		if containerMatches(exclude, container.Name, container.Image) {
 			matching.Spec.EphemeralContainers = append(matching.Spec.EphemeralContainers, container)
 		}
 	}
@@ -170,6 +177,14 @@ func GetPodWithMatchingContainers(exclude kyvernov1.PodSecurityStandard, pod *co
 	return nil, matching
 }
 
+// containerMatches checks if a container is selected by the exclude images or container names
+func containerMatches(exclude kyvernov1.PodSecurityStandard, name, image string) bool {
+	if len(exclude.Images) != 0 && wildcard.CheckPatterns(exclude.Images, image) {
+		return true
+	}
+	return len(exclude.ContainerNames) != 0 && wildcard.CheckPatterns(exclude.ContainerNames, name)
+}
+
 // Get restrictedFields from Check.ID
 func GetRestrictedFields(check policy.Check) []pssutils.RestrictedField {
 	for _, control := range pssutils.PSS_controls_to_check_id {
@@ -182,10 +197,24 @@ func GetRestrictedFields(check policy.Check) []pssutils.RestrictedField {
 	return nil
 }
 
+// getControlName returns the Pod Security Standard control name for the given check ID
+func getControlName(checkID string) string {
+	for control, checkIDs := range pssutils.PSS_controls_to_check_id {
+		for _, id := range checkIDs {
+			if id == checkID {
+				return control
+			}
+		}
+	}
+	return checkID
+}
+
+// FormatChecksPrint formats the failed checks, each line contains the control name,
+// the reason and the detail of the failure
 func FormatChecksPrint(checks []pssutils.PSSCheckResult) string {
 	var str string
 	for _, check := range checks {
-		str += fmt.Sprintf("(%+v)\n", check.CheckResult)
+		str += fmt.Sprintf("(%s: %s: %s)\n", getControlName(check.ID), check.CheckResult.ForbiddenReason, check.CheckResult.ForbiddenDetail)
 	}
 	return str
 }
@@ -31,6 +31,8 @@ func Test_EvaluatePod(t *testing.T) {
 		restricted_seccompProfile,
 		restricted_capabilities,
 		wildcard_images,
+		container_names,
+		future_versions,
 	}
 
 	for _, test := range tests {
@@ -5214,6 +5216,209 @@ var wildcard_images = []testCase{
 	},
 }
 
+var container_names = []testCase{
+	{
+		name: "container_names_matches_container_name",
+		rawRule: []byte(`
+		{
+			"level": "baseline",
+			"version": "latest",
+			"exclude": [
+				{
+					"controlName": "Privileged Containers",
+					"containerNames": [
+						"istio-*"
+					]
+				}
+			]
+		}`),
+		rawPod: []byte(`
+		{
+			"kind": "Pod",
+			"metadata": {
+				"name": "test"
+			},
+			"spec": {
+				"containers": [
+					{
+						"name": "istio-proxy",
+						"image": "nginx",
+						"securityContext": {
+							"privileged": true
+						}
+					}
+				]
+			}
+		}`),
+		allowed: true,
+	},
+	{
+		name: "container_names_does_not_match_container_name",
+		rawRule: []byte(`
+		{
+			"level": "baseline",
+			"version": "latest",
+			"exclude": [
+				{
+					"controlName": "Privileged Containers",
+					"containerNames": [
+						"sidecar"
+					]
+				}
+			]
+		}`),
+		rawPod: []byte(`
+		{
+			"kind": "Pod",
+			"metadata": {
+				"name": "test"
+			},
+			"spec": {
+				"containers": [
+					{
+						"name": "istio-proxy",
+						"image": "nginx",
+						"securityContext": {
+							"privileged": true
+						}
+					}
+				]
+			}
+		}`),
+		allowed: false,
+	},
+	{
+		name: "container_names_or_images_matches_image",
+		rawRule: []byte(`
+		{
+			"level": "baseline",
+			"version": "latest",
+			"exclude": [
+				{
+					"controlName": "Privileged Containers",
+					"images": [
+						"nginx"
+					],
+					"containerNames": [
+						"sidecar"
+					]
+				}
+			]
+		}`),
+		rawPod: []byte(`
+		{
+			"kind": "Pod",
+			"metadata": {
+				"name": "test"
+			},
+			"spec": {
+				"containers": [
+					{
+						"name": "istio-proxy",
+						"image": "nginx",
+						"securityContext": {
+							"privileged": true
+						}
+					}
+				]
+			}
+		}`),
+		allowed: true,
+	},
+}
+
+var future_versions = []testCase{
+	{
+		name: "future_version_evaluated_as_latest",
+		rawRule: []byte(`
+		{
+			"level": "baseline",
+			"version": "v1.99"
+		}`),
+		rawPod: []byte(`
+		{
+			"kind": "Pod",
+			"metadata": {
+				"name": "test"
+			},
+			"spec": {
+				"containers": [
+					{
+						"name": "istio-proxy",
+						"image": "nginx",
+						"securityContext": {
+							"privileged": true
+						}
+					}
+				]
+			}
+		}`),
+		allowed: false,
+	},
+	{
+		name: "future_version_with_exclusion",
+		rawRule: []byte(`
+		{
+			"level": "baseline",
+			"version": "v1.99",
+			"exclude": [
+				{
+					"controlName": "Privileged Containers",
+					"containerNames": [
+						"istio-proxy"
+					]
+				}
+			]
+		}`),
+		rawPod: []byte(`
+		{
+			"kind": "Pod",
+			"metadata": {
+				"name": "test"
+			},
+			"spec": {
+				"containers": [
+					{
+						"name": "istio-proxy",
+						"image": "nginx",
+						"securityContext": {
+							"privileged": true
+						}
+					}
+				]
+			}
+		}`),
+		allowed: true,
+	},
+}
+
+func Test_FormatChecksPrint(t *testing.T) {
+	var pod corev1.Pod
+	err := json.Unmarshal([]byte(`
+	{
+		"kind": "Pod",
+		"metadata": {
+			"name": "test"
+		},
+		"spec": {
+			"containers": [
+				{
+					"name": "nginx",
+					"image": "nginx",
+					"securityContext": {
+						"privileged": true
+					}
+				}
+			]
+		}
+	}`), &pod)
+	assert.NilError(t, err)
+	allowed, checkResults, err := EvaluatePod(&kyvernov1.PodSecurity{Level: "baseline", Version: "latest"}, &pod)
+	assert.NilError(t, err)
+	assert.Assert(t, !allowed)
+	assert.Equal(t, FormatChecksPrint(checkResults), "(Privileged Containers: privileged: container \"nginx\" must not set securityContext.privileged=true)\n")
+}
+
 type testCase struct {
 	name    string
 	rawRule []byte
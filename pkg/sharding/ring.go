@@ -0,0 +1,36 @@
+package sharding
+
+import (
+	"hash/fnv"
+)
+
+// Owner returns the member owning the key using rendezvous (highest random weight) hashing.
+// Adding or removing a member only moves the keys owned by that member.
+func Owner(members []string, key string) string {
+	var owner string
+	var best uint64
+	for _, member := range members {
+		if weight := weight(member, key); owner == "" || weight > best || (weight == best && member < owner) {
+			owner, best = member, weight
+		}
+	}
+	return owner
+}
+
+func weight(member, key string) uint64 {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(member))
+	_, _ = h.Write([]byte{0})
+	_, _ = h.Write([]byte(key))
+	return mix(h.Sum64())
+}
+
+// mix spreads fnv hashes of similar inputs across the whole 64 bits space (splitmix64 finalizer)
+func mix(x uint64) uint64 {
+	x ^= x >> 30
+	x *= 0xbf58476d1ce4e5b9
+	x ^= x >> 27
+	x *= 0x94d049bb133111eb
+	x ^= x >> 31
+	return x
+}
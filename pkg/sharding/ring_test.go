@@ -0,0 +1,43 @@
+package sharding
+
+import (
+	"fmt"
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+func TestOwner(t *testing.T) {
+	assert.Equal(t, Owner(nil, "default/foo"), "")
+	assert.Equal(t, Owner([]string{"a"}, "default/foo"), "a")
+	members := []string{"kyverno-0", "kyverno-1", "kyverno-2"}
+	keys := make([]string, 3000)
+	for i := range keys {
+		keys[i] = fmt.Sprintf("namespace-%d/%d", i%50, i)
+	}
+	owners := map[string]string{}
+	counts := map[string]int{}
+	for _, key := range keys {
+		owner := Owner(members, key)
+		owners[key] = owner
+		counts[owner]++
+	}
+	// keys are spread across members
+	for _, member := range members {
+		assert.Assert(t, counts[member] > len(keys)/6, "member %s owns %d keys", member, counts[member])
+	}
+	// removing a member only moves its own keys
+	for _, key := range keys {
+		owner := Owner(members[:2], key)
+		if owners[key] != "kyverno-2" {
+			assert.Equal(t, owner, owners[key])
+		}
+	}
+	// adding a member only moves keys to the new member
+	for _, key := range keys {
+		owner := Owner(append(members, "kyverno-3"), key)
+		if owner != "kyverno-3" {
+			assert.Equal(t, owner, owners[key])
+		}
+	}
+}
@@ -0,0 +1,215 @@
+package sharding
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	controllerutils "github.com/kyverno/kyverno/pkg/utils/controller"
+	datautils "github.com/kyverno/kyverno/pkg/utils/data"
+	coordinationv1 "k8s.io/api/coordination/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/utils/clock"
+)
+
+const (
+	// LabelShardGroup is the label set on the leases of the members of a shard group
+	LabelShardGroup = "kyverno.io/shard-group"
+	// DefaultLeaseDuration is the default duration after which a member that stopped renewing its lease is removed
+	DefaultLeaseDuration = 30 * time.Second
+)
+
+// Sharder assigns keys to the members of a shard group
+type Sharder interface {
+	// Run registers this instance in the shard group and tracks members until the context is cancelled
+	Run(ctx context.Context)
+	// Owns returns true if the key is assigned to this instance
+	Owns(key string) bool
+	// AddHandler registers a handler called when the shard group members change
+	AddHandler(func())
+}
+
+type sharder struct {
+	client        controllerutils.ObjectListClient[*coordinationv1.Lease, *coordinationv1.LeaseList]
+	group         string
+	identity      string
+	leaseDuration time.Duration
+	clock         clock.PassiveClock
+
+	lock     sync.RWMutex
+	members  []string
+	handlers []func()
+}
+
+// NewSharder creates a Sharder coordinating the members of a shard group through one lease per member,
+// a member is removed from the group when its lease is not renewed for longer than the lease duration.
+func NewSharder(
+	client controllerutils.ObjectListClient[*coordinationv1.Lease, *coordinationv1.LeaseList],
+	group string,
+	identity string,
+	leaseDuration time.Duration,
+) Sharder {
+	if leaseDuration <= 0 {
+		leaseDuration = DefaultLeaseDuration
+	}
+	return &sharder{
+		client:        client,
+		group:         group,
+		identity:      identity,
+		leaseDuration: leaseDuration,
+		clock:         clock.RealClock{},
+	}
+}
+
+func (s *sharder) Run(ctx context.Context) {
+	logger := logger.WithValues("group", s.group, "identity", s.identity)
+	logger.Info("joining shard group")
+	ticker := time.NewTicker(s.leaseDuration / 3)
+	defer ticker.Stop()
+	for {
+		if err := s.sync(ctx); err != nil {
+			logger.Error(err, "failed to sync shard group members")
+		}
+		select {
+		case <-ctx.Done():
+			// release our lease so that other members take over our keys without waiting for it to expire
+			if err := s.client.Delete(context.Background(), s.leaseName(), metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+				logger.Error(err, "failed to delete lease")
+			}
+			logger.Info("left shard group")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *sharder) Owns(key string) bool {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	// until this instance has registered, keys are not owned by anyone,
+	// handlers are notified once members are known
+	if len(s.members) == 0 {
+		return false
+	}
+	return Owner(s.members, key) == s.identity
+}
+
+func (s *sharder) AddHandler(handler func()) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.handlers = append(s.handlers, handler)
+}
+
+func (s *sharder) leaseName() string {
+	return s.group + "-" + s.identity
+}
+
+// sync renews the lease of this instance and refreshes the list of active members
+func (s *sharder) sync(ctx context.Context) error {
+	if err := s.renew(ctx); err != nil {
+		return err
+	}
+	list, err := s.client.List(ctx, metav1.ListOptions{
+		LabelSelector: labels.SelectorFromSet(labels.Set{LabelShardGroup: s.group}).String(),
+	})
+	if err != nil {
+		return err
+	}
+	members := activeMembers(s.clock.Now(), list.Items...)
+	// the first active member acts as the group leader and deletes the leases of members that stopped renewing them,
+	// members that crashed or were deleted without leaving the group would otherwise leave their lease behind forever
+	if len(members) != 0 && members[0] == s.identity {
+		s.collectExpiredLeases(ctx, list.Items...)
+	}
+	s.lock.Lock()
+	changed := !datautils.DeepEqual(s.members, members)
+	if changed {
+		s.members = members
+	}
+	handlers := s.handlers
+	s.lock.Unlock()
+	if changed {
+		logger.Info("shard group members changed", "group", s.group, "members", members)
+		for _, handler := range handlers {
+			handler()
+		}
+	}
+	return nil
+}
+
+// collectExpiredLeases deletes expired leases, the deletion is conditioned on the resource version of the lease
+// so that a lease renewed in the meantime is kept
+func (s *sharder) collectExpiredLeases(ctx context.Context, leases ...coordinationv1.Lease) {
+	now := s.clock.Now()
+	for _, lease := range leases {
+		if isActive(now, lease) {
+			continue
+		}
+		resourceVersion := lease.ResourceVersion
+		err := s.client.Delete(ctx, lease.Name, metav1.DeleteOptions{
+			Preconditions: &metav1.Preconditions{ResourceVersion: &resourceVersion},
+		})
+		if err != nil && !apierrors.IsNotFound(err) && !apierrors.IsConflict(err) {
+			logger.Error(err, "failed to delete expired lease", "group", s.group, "lease", lease.Name)
+		} else if err == nil {
+			logger.V(2).Info("deleted expired lease", "group", s.group, "lease", lease.Name)
+		}
+	}
+}
+
+func (s *sharder) renew(ctx context.Context) error {
+	now := metav1.NewMicroTime(s.clock.Now())
+	duration := int32(s.leaseDuration.Seconds())
+	lease, err := s.client.Get(ctx, s.leaseName(), metav1.GetOptions{})
+	if err != nil {
+		if !apierrors.IsNotFound(err) {
+			return err
+		}
+		lease = &coordinationv1.Lease{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: s.leaseName(),
+				Labels: map[string]string{
+					LabelShardGroup: s.group,
+				},
+			},
+			Spec: coordinationv1.LeaseSpec{
+				HolderIdentity:       &s.identity,
+				LeaseDurationSeconds: &duration,
+				AcquireTime:          &now,
+				RenewTime:            &now,
+			},
+		}
+		_, err := s.client.Create(ctx, lease, metav1.CreateOptions{})
+		return err
+	}
+	lease = lease.DeepCopy()
+	lease.Spec.HolderIdentity = &s.identity
+	lease.Spec.LeaseDurationSeconds = &duration
+	lease.Spec.RenewTime = &now
+	_, err = s.client.Update(ctx, lease, metav1.UpdateOptions{})
+	return err
+}
+
+// activeMembers returns the sorted identities of the members whose lease has not expired
+func activeMembers(now time.Time, leases ...coordinationv1.Lease) []string {
+	var members []string
+	for _, lease := range leases {
+		if isActive(now, lease) {
+			members = append(members, *lease.Spec.HolderIdentity)
+		}
+	}
+	sort.Strings(members)
+	return members
+}
+
+// isActive returns true if the lease is held and has not expired
+func isActive(now time.Time, lease coordinationv1.Lease) bool {
+	spec := lease.Spec
+	if spec.HolderIdentity == nil || spec.RenewTime == nil || spec.LeaseDurationSeconds == nil {
+		return false
+	}
+	return now.Before(spec.RenewTime.Add(time.Duration(*spec.LeaseDurationSeconds) * time.Second))
+}
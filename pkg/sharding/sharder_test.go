@@ -0,0 +1,93 @@
+package sharding
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"gotest.tools/assert"
+	coordinationv1 "k8s.io/api/coordination/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	clocktesting "k8s.io/utils/clock/testing"
+)
+
+func newLease(group, identity string, renewTime time.Time) *coordinationv1.Lease {
+	duration := int32(30)
+	renew := metav1.NewMicroTime(renewTime)
+	return &coordinationv1.Lease{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      group + "-" + identity,
+			Namespace: "kyverno",
+			Labels:    map[string]string{LabelShardGroup: group},
+		},
+		Spec: coordinationv1.LeaseSpec{
+			HolderIdentity:       &identity,
+			LeaseDurationSeconds: &duration,
+			RenewTime:            &renew,
+		},
+	}
+}
+
+func TestSharder(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	client := fake.NewSimpleClientset(
+		newLease("scan", "kyverno-1", now),
+		newLease("scan", "kyverno-2", now.Add(-time.Minute)),
+		newLease("other", "kyverno-3", now),
+	)
+	s := NewSharder(client.CoordinationV1().Leases("kyverno"), "scan", "kyverno-0", 30*time.Second).(*sharder)
+	s.clock = clocktesting.NewFakePassiveClock(now)
+	notified := 0
+	s.AddHandler(func() { notified++ })
+	// nothing is owned before joining the group
+	assert.Assert(t, !s.Owns("default/foo"))
+	assert.NilError(t, s.sync(ctx))
+	// expired members and members of other groups are ignored
+	assert.DeepEqual(t, s.members, []string{"kyverno-0", "kyverno-1"})
+	assert.Equal(t, notified, 1)
+	_, err := client.CoordinationV1().Leases("kyverno").Get(ctx, "scan-kyverno-0", metav1.GetOptions{})
+	assert.NilError(t, err)
+	// the first member garbage collects expired leases of its group only
+	_, err = client.CoordinationV1().Leases("kyverno").Get(ctx, "scan-kyverno-2", metav1.GetOptions{})
+	assert.Assert(t, apierrors.IsNotFound(err))
+	_, err = client.CoordinationV1().Leases("kyverno").Get(ctx, "other-kyverno-3", metav1.GetOptions{})
+	assert.NilError(t, err)
+	for _, key := range []string{"default/foo", "default/bar", "kube-system/baz"} {
+		assert.Equal(t, s.Owns(key), Owner(s.members, key) == "kyverno-0")
+	}
+	// handlers are only notified when members change
+	assert.NilError(t, s.sync(ctx))
+	assert.Equal(t, notified, 1)
+	s.clock = clocktesting.NewFakePassiveClock(now.Add(time.Minute))
+	assert.NilError(t, s.sync(ctx))
+	assert.DeepEqual(t, s.members, []string{"kyverno-0"})
+	assert.Equal(t, notified, 2)
+	assert.Assert(t, s.Owns("default/foo"))
+}
+
+func TestSharderGarbageCollection(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	client := fake.NewSimpleClientset(
+		newLease("scan", "kyverno-0", now),
+		newLease("scan", "kyverno-2", now.Add(-time.Minute)),
+	)
+	// kyverno-1 is not the first member, it doesn't delete expired leases
+	s := NewSharder(client.CoordinationV1().Leases("kyverno"), "scan", "kyverno-1", 30*time.Second).(*sharder)
+	s.clock = clocktesting.NewFakePassiveClock(now)
+	assert.NilError(t, s.sync(ctx))
+	assert.DeepEqual(t, s.members, []string{"kyverno-0", "kyverno-1"})
+	_, err := client.CoordinationV1().Leases("kyverno").Get(ctx, "scan-kyverno-2", metav1.GetOptions{})
+	assert.NilError(t, err)
+	// kyverno-0 expired, kyverno-1 becomes the first member
+	s.clock = clocktesting.NewFakePassiveClock(now.Add(time.Minute))
+	assert.NilError(t, s.sync(ctx))
+	assert.DeepEqual(t, s.members, []string{"kyverno-1"})
+	list, err := client.CoordinationV1().Leases("kyverno").List(ctx, metav1.ListOptions{})
+	assert.NilError(t, err)
+	assert.Equal(t, len(list.Items), 1)
+	assert.Equal(t, list.Items[0].Name, "scan-kyverno-1")
+}
@@ -0,0 +1,95 @@
+package tls
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// KeyAlgorithm is the algorithm used to generate private keys
+type KeyAlgorithm string
+
+const (
+	// KeyAlgorithmRSA generates 2048 bits RSA keys
+	KeyAlgorithmRSA KeyAlgorithm = "RSA"
+	// KeyAlgorithmECDSA generates ECDSA keys on the P-256 curve
+	KeyAlgorithmECDSA KeyAlgorithm = "ECDSA"
+)
+
+// ParseKeyAlgorithm parses a key algorithm, an empty value defaults to RSA
+func ParseKeyAlgorithm(value string) (KeyAlgorithm, error) {
+	switch KeyAlgorithm(strings.ToUpper(value)) {
+	case "", KeyAlgorithmRSA:
+		return KeyAlgorithmRSA, nil
+	case KeyAlgorithmECDSA:
+		return KeyAlgorithmECDSA, nil
+	default:
+		return "", fmt.Errorf("invalid key algorithm %q, must be one of %s or %s", value, KeyAlgorithmRSA, KeyAlgorithmECDSA)
+	}
+}
+
+func (a KeyAlgorithm) generateKey() (crypto.Signer, error) {
+	switch a {
+	case KeyAlgorithmECDSA:
+		return ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	default:
+		return rsa.GenerateKey(rand.Reader, 2048)
+	}
+}
+
+// matches returns true if the given key was generated with the algorithm
+func (a KeyAlgorithm) matches(key crypto.Signer) bool {
+	switch key.(type) {
+	case *ecdsa.PrivateKey:
+		return a == KeyAlgorithmECDSA
+	case *rsa.PrivateKey:
+		return a != KeyAlgorithmECDSA
+	default:
+		return false
+	}
+}
+
+// CertificateConfig configures the generation and renewal of self-signed certificates
+type CertificateConfig struct {
+	// CAValidityDuration is the validity duration of CA certificates
+	CAValidityDuration time.Duration
+	// TLSValidityDuration is the validity duration of TLS certificates
+	TLSValidityDuration time.Duration
+	// RenewBefore is how long before expiry certificates are renewed
+	RenewBefore time.Duration
+	// KeyAlgorithm is the algorithm used to generate private keys
+	KeyAlgorithm KeyAlgorithm
+}
+
+// Validate returns an error if certificates would need to be renewed as soon as they are created
+func (c CertificateConfig) Validate() error {
+	c = c.withDefaults()
+	if c.RenewBefore >= c.TLSValidityDuration {
+		return fmt.Errorf("renewal window (%s) must be shorter than the TLS certificate validity (%s)", c.RenewBefore, c.TLSValidityDuration)
+	}
+	if c.RenewBefore >= c.CAValidityDuration {
+		return fmt.Errorf("renewal window (%s) must be shorter than the CA certificate validity (%s)", c.RenewBefore, c.CAValidityDuration)
+	}
+	return nil
+}
+
+func (c CertificateConfig) withDefaults() CertificateConfig {
+	if c.CAValidityDuration <= 0 {
+		c.CAValidityDuration = CAValidityDuration
+	}
+	if c.TLSValidityDuration <= 0 {
+		c.TLSValidityDuration = TLSValidityDuration
+	}
+	if c.RenewBefore <= 0 {
+		c.RenewBefore = RenewBefore
+	}
+	if c.KeyAlgorithm == "" {
+		c.KeyAlgorithm = KeyAlgorithmRSA
+	}
+	return c
+}
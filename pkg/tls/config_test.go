@@ -0,0 +1,63 @@
+package tls
+
+import (
+	"testing"
+	"time"
+
+	"gotest.tools/assert"
+)
+
+func TestParseKeyAlgorithm(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    KeyAlgorithm
+		wantErr bool
+	}{
+		{value: "", want: KeyAlgorithmRSA},
+		{value: "RSA", want: KeyAlgorithmRSA},
+		{value: "ecdsa", want: KeyAlgorithmECDSA},
+		{value: "ed25519", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := ParseKeyAlgorithm(tt.value)
+			if tt.wantErr {
+				assert.Assert(t, err != nil)
+			} else {
+				assert.NilError(t, err)
+				assert.Equal(t, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCertificateConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  CertificateConfig
+		wantErr bool
+	}{{
+		name: "defaults",
+	}, {
+		name:   "valid",
+		config: CertificateConfig{CAValidityDuration: 48 * time.Hour, TLSValidityDuration: 24 * time.Hour, RenewBefore: time.Hour},
+	}, {
+		name:    "renewal window longer than tls validity",
+		config:  CertificateConfig{TLSValidityDuration: 24 * time.Hour, RenewBefore: 24 * time.Hour},
+		wantErr: true,
+	}, {
+		name:    "renewal window longer than ca validity",
+		config:  CertificateConfig{CAValidityDuration: 24 * time.Hour, TLSValidityDuration: 12 * time.Hour, RenewBefore: 36 * time.Hour},
+		wantErr: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.config.Validate()
+			if tt.wantErr {
+				assert.Assert(t, err != nil)
+			} else {
+				assert.NilError(t, err)
+			}
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package tls
 
 import (
+	"crypto"
 	"crypto/rand"
 	"crypto/rsa"
 	"crypto/x509"
@@ -16,11 +17,12 @@ import (
 
 // generateCA creates the self-signed CA cert and private key
 // it will be used to sign the webhook server certificate
-func generateCA(key *rsa.PrivateKey, certValidityDuration time.Duration) (*rsa.PrivateKey, *x509.Certificate, error) {
+func generateCA(key crypto.Signer, certValidityDuration time.Duration, keyAlgorithm KeyAlgorithm) (crypto.Signer, *x509.Certificate, error) {
 	now := time.Now()
 	begin, end := now.Add(-1*time.Hour), now.Add(certValidityDuration)
-	if key == nil {
-		newKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	// the existing key is reused unless the key algorithm changed
+	if key == nil || !keyAlgorithm.matches(key) {
+		newKey, err := keyAlgorithm.generateKey()
 		if err != nil {
 			return nil, nil, err
 		}
@@ -33,7 +35,7 @@ func generateCA(key *rsa.PrivateKey, certValidityDuration time.Duration) (*rsa.P
 		},
 		NotBefore:             begin,
 		NotAfter:              end,
-		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment | x509.KeyUsageCertSign,
+		KeyUsage:              keyUsage(key) | x509.KeyUsageCertSign,
 		BasicConstraintsValid: true,
 		IsCA:                  true,
 	}
@@ -50,7 +52,7 @@ func generateCA(key *rsa.PrivateKey, certValidityDuration time.Duration) (*rsa.P
 
 // generateTLS takes the results of GenerateCACert and uses it to create the
 // PEM-encoded public certificate and private key, respectively
-func generateTLS(server string, caCert *x509.Certificate, caKey *rsa.PrivateKey, certValidityDuration time.Duration) (*rsa.PrivateKey, *x509.Certificate, error) {
+func generateTLS(server string, caCert *x509.Certificate, caKey crypto.Signer, certValidityDuration time.Duration, keyAlgorithm KeyAlgorithm) (crypto.Signer, *x509.Certificate, error) {
 	now := time.Now()
 	begin, end := now.Add(-1*time.Hour), now.Add(certValidityDuration)
 	dnsNames := []string{
@@ -75,6 +77,10 @@ func generateTLS(server string, caCert *x509.Certificate, caKey *rsa.PrivateKey,
 			ips = append(ips, ip)
 		}
 	}
+	key, err := keyAlgorithm.generateKey()
+	if err != nil {
+		return nil, nil, err
+	}
 	templ := &x509.Certificate{
 		SerialNumber: big.NewInt(1),
 		Subject: pkix.Name{
@@ -84,14 +90,10 @@ func generateTLS(server string, caCert *x509.Certificate, caKey *rsa.PrivateKey,
 		IPAddresses:           ips,
 		NotBefore:             begin,
 		NotAfter:              end,
-		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+		KeyUsage:              keyUsage(key),
 		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
 		BasicConstraintsValid: true,
 	}
-	key, err := rsa.GenerateKey(rand.Reader, 2048)
-	if err != nil {
-		return nil, nil, err
-	}
 	der, err := x509.CreateCertificate(rand.Reader, templ, caCert, key.Public(), caKey)
 	if err != nil {
 		logger.Error(err, "create certificate failed")
@@ -104,3 +106,11 @@ func generateTLS(server string, caCert *x509.Certificate, caKey *rsa.PrivateKey,
 	}
 	return key, cert, nil
 }
+
+// keyUsage returns the key usage allowed by the key, key encipherment only applies to RSA keys
+func keyUsage(key crypto.Signer) x509.KeyUsage {
+	if _, ok := key.(*rsa.PrivateKey); ok {
+		return x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment
+	}
+	return x509.KeyUsageDigitalSignature
+}
@@ -0,0 +1,73 @@
+package tls
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"gotest.tools/assert"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func Test_generateCertificates(t *testing.T) {
+	for _, algorithm := range []KeyAlgorithm{KeyAlgorithmRSA, KeyAlgorithmECDSA} {
+		t.Run(string(algorithm), func(t *testing.T) {
+			caKey, caCert, err := generateCA(nil, time.Hour, algorithm)
+			assert.NilError(t, err)
+			tlsKey, tlsCert, err := generateTLS("", caCert, caKey, time.Hour, algorithm)
+			assert.NilError(t, err)
+			assert.Assert(t, validateCert(time.Now(), tlsCert, caCert))
+			// keys survive a pem round trip
+			for _, key := range []crypto.Signer{caKey, tlsKey} {
+				raw, err := privateKeyToPem(key)
+				assert.NilError(t, err)
+				decoded, err := pemToPrivateKey(raw)
+				assert.NilError(t, err)
+				assert.Assert(t, algorithm.matches(decoded))
+			}
+			switch algorithm {
+			case KeyAlgorithmECDSA:
+				_, ok := tlsKey.(*ecdsa.PrivateKey)
+				assert.Assert(t, ok)
+			default:
+				_, ok := tlsKey.(*rsa.PrivateKey)
+				assert.Assert(t, ok)
+			}
+			// the CA key is reused unless the algorithm changes
+			renewedKey, _, err := generateCA(caKey, time.Hour, algorithm)
+			assert.NilError(t, err)
+			assert.Equal(t, renewedKey, caKey)
+			other := KeyAlgorithmECDSA
+			if algorithm == KeyAlgorithmECDSA {
+				other = KeyAlgorithmRSA
+			}
+			renewedKey, _, err = generateCA(caKey, time.Hour, other)
+			assert.NilError(t, err)
+			assert.Assert(t, other.matches(renewedKey))
+		})
+	}
+}
+
+func TestCertificatesStatus(t *testing.T) {
+	caKey, caCert, err := generateCA(nil, time.Hour, KeyAlgorithmECDSA)
+	assert.NilError(t, err)
+	_, tlsCert, err := generateTLS("", caCert, caKey, time.Hour, KeyAlgorithmECDSA)
+	assert.NilError(t, err)
+	data, err := CertificatesStatus(
+		&corev1.Secret{Data: map[string][]byte{corev1.TLSCertKey: certificateToPem(caCert)}},
+		&corev1.Secret{Data: map[string][]byte{corev1.TLSCertKey: certificateToPem(tlsCert)}},
+	)
+	assert.NilError(t, err)
+	var ca, tls []CertificateStatus
+	assert.NilError(t, json.Unmarshal([]byte(data[StatusCAKey]), &ca))
+	assert.NilError(t, json.Unmarshal([]byte(data[StatusTLSKey]), &tls))
+	assert.Equal(t, len(ca), 1)
+	assert.Equal(t, len(tls), 1)
+	assert.Equal(t, ca[0].KeyAlgorithm, "ECDSA")
+	assert.Equal(t, tls[0].Issuer, ca[0].Subject)
+	assert.Equal(t, tls[0].NotAfter, tlsCert.NotAfter.UTC())
+	assert.DeepEqual(t, tls[0].DNSNames, tlsCert.DNSNames)
+}
@@ -4,7 +4,6 @@ import (
 	"fmt"
 
 	"github.com/kyverno/kyverno/pkg/config"
-	corev1 "k8s.io/api/core/v1"
 	corev1listers "k8s.io/client-go/listers/core/v1"
 )
 
@@ -17,12 +16,7 @@ func ReadRootCASecret(client corev1listers.SecretNamespaceLister) ([]byte, error
 	if err != nil {
 		return nil, err
 	}
-	// try "tls.crt"
-	result := stlsca.Data[corev1.TLSCertKey]
-	// if not there, try old "rootCA.crt"
-	if len(result) == 0 {
-		result = stlsca.Data[rootCAKey]
-	}
+	result := caCertificateData(stlsca)
 	if len(result) == 0 {
 		return nil, fmt.Errorf("%s in secret %s/%s", ErrorsNotFound, config.KyvernoNamespace(), stlsca.Name)
 	}
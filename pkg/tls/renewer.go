@@ -2,7 +2,7 @@ package tls
 
 import (
 	"context"
-	"crypto/rsa"
+	"crypto"
 	"crypto/x509"
 	"fmt"
 	"time"
@@ -22,7 +22,10 @@ const (
 	CAValidityDuration = 365 * 24 * time.Hour
 	// TLSValidityDuration is the valid duration for TLS certificates
 	TLSValidityDuration = 150 * 24 * time.Hour
-	rootCAKey           = "rootCA.crt"
+	// RenewBefore is the default duration before expiry when certificates are renewed
+	RenewBefore = 5 * CertRenewalInterval
+	rootCAKey   = "rootCA.crt"
+	caCertKey   = "ca.crt"
 )
 
 type CertValidator interface {
@@ -43,8 +46,7 @@ type CertRenewer interface {
 type certRenewer struct {
 	client              controllerutils.ObjectClient[*corev1.Secret]
 	certRenewalInterval time.Duration
-	caValidityDuration  time.Duration
-	tlsValidityDuration time.Duration
+	config              CertificateConfig
 
 	// server is an IP address or domain name where Kyverno controller runs. Only required if out-of-cluster.
 	server string
@@ -53,16 +55,14 @@ type certRenewer struct {
 // NewCertRenewer returns an instance of CertRenewer
 func NewCertRenewer(
 	client controllerutils.ObjectClient[*corev1.Secret],
-	certRenewalInterval,
-	caValidityDuration,
-	tlsValidityDuration time.Duration,
+	certRenewalInterval time.Duration,
+	certConfig CertificateConfig,
 	server string,
 ) *certRenewer {
 	return &certRenewer{
 		client:              client,
 		certRenewalInterval: certRenewalInterval,
-		caValidityDuration:  caValidityDuration,
-		tlsValidityDuration: tlsValidityDuration,
+		config:              certConfig.withDefaults(),
 		server:              server,
 	}
 }
@@ -76,7 +76,7 @@ func (c *certRenewer) RenewCA(ctx context.Context) error {
 	}
 	now := time.Now()
 	certs = removeExpiredCertificates(now, certs...)
-	if !allCertificatesExpired(now.Add(5*c.certRenewalInterval), certs...) {
+	if !allCertificatesExpired(now.Add(c.config.RenewBefore), certs...) && (key == nil || c.config.KeyAlgorithm.matches(key)) {
 		logger.V(4).Info("CA certificate does not need to be renewed")
 		return nil
 	}
@@ -93,7 +93,7 @@ func (c *certRenewer) RenewCA(ctx context.Context) error {
 		}
 		return err
 	}
-	caKey, caCert, err := generateCA(key, c.caValidityDuration)
+	caKey, caCert, err := generateCA(key, c.config.CAValidityDuration, c.config.KeyAlgorithm)
 	if err != nil {
 		logger.Error(err, "failed to generate CA")
 		return err
@@ -114,13 +114,13 @@ func (c *certRenewer) RenewTLS(ctx context.Context) error {
 		logger.Error(err, "failed to read CA")
 		return err
 	}
-	secret, _, cert, err := c.decodeTLSSecret(ctx)
+	secret, key, cert, err := c.decodeTLSSecret(ctx)
 	if err != nil && !apierrors.IsNotFound(err) {
 		logger.Error(err, "failed to read TLS")
 		return err
 	}
 	now := time.Now()
-	if cert != nil && !allCertificatesExpired(now.Add(5*c.certRenewalInterval), cert) {
+	if cert != nil && !allCertificatesExpired(now.Add(c.config.RenewBefore), cert) && (key == nil || c.config.KeyAlgorithm.matches(key)) {
 		logger.V(4).Info("TLS certificate does not need to be renewed")
 		return nil
 	}
@@ -137,7 +137,7 @@ func (c *certRenewer) RenewTLS(ctx context.Context) error {
 		}
 		return err
 	}
-	tlsKey, tlsCert, err := generateTLS(c.server, caCerts[len(caCerts)-1], caKey, c.tlsValidityDuration)
+	tlsKey, tlsCert, err := generateTLS(c.server, caCerts[len(caCerts)-1], caKey, c.config.TLSValidityDuration, c.config.KeyAlgorithm)
 	if err != nil {
 		logger.Error(err, "failed to generate TLS")
 		return err
@@ -152,6 +152,9 @@ func (c *certRenewer) RenewTLS(ctx context.Context) error {
 
 // ValidateCert validates the CA Cert
 func (c *certRenewer) ValidateCert(ctx context.Context) (bool, error) {
+	if IsExternallyManaged() {
+		return c.validateExternalCert(ctx)
+	}
 	_, _, caCerts, err := c.decodeCASecret(ctx)
 	if err != nil {
 		return false, err
@@ -163,6 +166,26 @@ func (c *certRenewer) ValidateCert(ctx context.Context) (bool, error) {
 	return validateCert(time.Now(), cert, caCerts...), nil
 }
 
+// validateExternalCert validates certificates provided by an external issuer,
+// private keys are not decoded as they can use any algorithm and encoding
+func (c *certRenewer) validateExternalCert(ctx context.Context) (bool, error) {
+	caSecret, err := c.getSecret(ctx, GenerateRootCASecretName())
+	if err != nil {
+		return false, err
+	}
+	caCerts := pemToCertificates(caCertificateData(caSecret))
+	tlsSecret, err := c.getSecret(ctx, GenerateTLSPairSecretName())
+	if err != nil {
+		return false, err
+	}
+	certs := pemToCertificates(tlsSecret.Data[corev1.TLSCertKey])
+	if len(certs) == 0 {
+		return false, fmt.Errorf("certificate not found in secret %s/%s", config.KyvernoNamespace(), tlsSecret.Name)
+	}
+	// the serving certificate comes first, followed by intermediates if any
+	return validateCert(time.Now(), certs[0], append(caCerts, certs[1:]...)...), nil
+}
+
 func (c *certRenewer) getSecret(ctx context.Context, name string) (*corev1.Secret, error) {
 	if s, err := c.client.Get(ctx, name, metav1.GetOptions{}); err != nil {
 		return nil, err
@@ -171,7 +194,7 @@ func (c *certRenewer) getSecret(ctx context.Context, name string) (*corev1.Secre
 	}
 }
 
-func (c *certRenewer) decodeSecret(ctx context.Context, name string) (*corev1.Secret, *rsa.PrivateKey, []*x509.Certificate, error) {
+func (c *certRenewer) decodeSecret(ctx context.Context, name string) (*corev1.Secret, crypto.Signer, []*x509.Certificate, error) {
 	secret, err := c.getSecret(ctx, name)
 	if err != nil {
 		return nil, nil, nil, err
@@ -184,7 +207,7 @@ func (c *certRenewer) decodeSecret(ctx context.Context, name string) (*corev1.Se
 			certBytes = secret.Data[rootCAKey]
 		}
 	}
-	var key *rsa.PrivateKey
+	var key crypto.Signer
 	if keyBytes != nil {
 		usedkey, err := pemToPrivateKey(keyBytes)
 		if err != nil {
@@ -195,11 +218,11 @@ func (c *certRenewer) decodeSecret(ctx context.Context, name string) (*corev1.Se
 	return secret, key, pemToCertificates(certBytes), nil
 }
 
-func (c *certRenewer) decodeCASecret(ctx context.Context) (*corev1.Secret, *rsa.PrivateKey, []*x509.Certificate, error) {
+func (c *certRenewer) decodeCASecret(ctx context.Context) (*corev1.Secret, crypto.Signer, []*x509.Certificate, error) {
 	return c.decodeSecret(ctx, GenerateRootCASecretName())
 }
 
-func (c *certRenewer) decodeTLSSecret(ctx context.Context) (*corev1.Secret, *rsa.PrivateKey, *x509.Certificate, error) {
+func (c *certRenewer) decodeTLSSecret(ctx context.Context) (*corev1.Secret, crypto.Signer, *x509.Certificate, error) {
 	secret, key, certs, err := c.decodeSecret(ctx, GenerateTLSPairSecretName())
 	if err != nil {
 		return nil, nil, nil, err
@@ -213,7 +236,7 @@ func (c *certRenewer) decodeTLSSecret(ctx context.Context) (*corev1.Secret, *rsa
 	}
 }
 
-func (c *certRenewer) writeSecret(ctx context.Context, name string, key *rsa.PrivateKey, certs ...*x509.Certificate) error {
+func (c *certRenewer) writeSecret(ctx context.Context, name string, key crypto.Signer, certs ...*x509.Certificate) error {
 	logger := logger.WithValues("name", name, "namespace", config.KyvernoNamespace())
 	secret, err := c.getSecret(ctx, name)
 	if err != nil && !apierrors.IsNotFound(err) {
@@ -232,10 +255,15 @@ func (c *certRenewer) writeSecret(ctx context.Context, name string, key *rsa.Pri
 			Type: corev1.SecretTypeTLS,
 		}
 	}
+	keyPem, err := privateKeyToPem(key)
+	if err != nil {
+		logger.Error(err, "failed to encode private key")
+		return err
+	}
 	secret.Type = corev1.SecretTypeTLS
 	secret.Data = map[string][]byte{
 		corev1.TLSCertKey:       certificateToPem(certs...),
-		corev1.TLSPrivateKeyKey: privateKeyToPem(key),
+		corev1.TLSPrivateKeyKey: keyPem,
 	}
 	if secret.ResourceVersion == "" {
 		if _, err := c.client.Create(ctx, secret, metav1.CreateOptions{}); err != nil {
@@ -256,11 +284,11 @@ func (c *certRenewer) writeSecret(ctx context.Context, name string, key *rsa.Pri
 }
 
 // writeCASecret stores the CA cert in secret
-func (c *certRenewer) writeCASecret(ctx context.Context, key *rsa.PrivateKey, certs ...*x509.Certificate) error {
+func (c *certRenewer) writeCASecret(ctx context.Context, key crypto.Signer, certs ...*x509.Certificate) error {
 	return c.writeSecret(ctx, GenerateRootCASecretName(), key, certs...)
 }
 
 // writeTLSSecret Writes the pair of TLS certificate and key to the specified secret.
-func (c *certRenewer) writeTLSSecret(ctx context.Context, key *rsa.PrivateKey, cert *x509.Certificate) error {
+func (c *certRenewer) writeTLSSecret(ctx context.Context, key crypto.Signer, cert *x509.Certificate) error {
 	return c.writeSecret(ctx, GenerateTLSPairSecretName(), key, cert)
 }
@@ -0,0 +1,70 @@
+package tls
+
+import (
+	"encoding/json"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// StatusCAKey is the status configmap key holding the CA certificates chain
+	StatusCAKey = "ca"
+	// StatusTLSKey is the status configmap key holding the TLS certificate
+	StatusTLSKey = "tls"
+)
+
+// CertificateStatus describes a certificate
+type CertificateStatus struct {
+	Subject      string    `json:"subject"`
+	Issuer       string    `json:"issuer"`
+	SerialNumber string    `json:"serialNumber"`
+	KeyAlgorithm string    `json:"keyAlgorithm"`
+	NotBefore    time.Time `json:"notBefore"`
+	NotAfter     time.Time `json:"notAfter"`
+	DNSNames     []string  `json:"dnsNames,omitempty"`
+}
+
+func GenerateStatusConfigMapName() string {
+	return inClusterServiceName() + ".kyverno-tls-status"
+}
+
+// CertificatesStatus returns the status configmap data describing the certificates stored in the CA and TLS secrets
+func CertificatesStatus(caSecret, tlsSecret *corev1.Secret) (map[string]string, error) {
+	caStatus, err := certificatesStatus(caCertificateData(caSecret))
+	if err != nil {
+		return nil, err
+	}
+	var tlsData []byte
+	if tlsSecret != nil {
+		tlsData = tlsSecret.Data[corev1.TLSCertKey]
+	}
+	tlsStatus, err := certificatesStatus(tlsData)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]string{
+		StatusCAKey:  caStatus,
+		StatusTLSKey: tlsStatus,
+	}, nil
+}
+
+func certificatesStatus(data []byte) (string, error) {
+	status := []CertificateStatus{}
+	for _, cert := range pemToCertificates(data) {
+		status = append(status, CertificateStatus{
+			Subject:      cert.Subject.String(),
+			Issuer:       cert.Issuer.String(),
+			SerialNumber: cert.SerialNumber.String(),
+			KeyAlgorithm: cert.PublicKeyAlgorithm.String(),
+			NotBefore:    cert.NotBefore.UTC(),
+			NotAfter:     cert.NotAfter.UTC(),
+			DNSNames:     cert.DNSNames,
+		})
+	}
+	raw, err := json.Marshal(status)
+	if err != nil {
+		return "", err
+	}
+	return string(raw), nil
+}
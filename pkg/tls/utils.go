@@ -1,9 +1,12 @@
 package tls
 
 import (
+	"crypto"
+	"crypto/ecdsa"
 	"crypto/rsa"
 	"crypto/x509"
 	"encoding/pem"
+	"fmt"
 	"time"
 
 	"github.com/kyverno/kyverno/api/kyverno"
@@ -11,12 +14,27 @@ import (
 	corev1 "k8s.io/api/core/v1"
 )
 
-func privateKeyToPem(rsaKey *rsa.PrivateKey) []byte {
-	privateKey := &pem.Block{
-		Type:  "PRIVATE KEY",
-		Bytes: x509.MarshalPKCS1PrivateKey(rsaKey),
+func privateKeyToPem(key crypto.Signer) ([]byte, error) {
+	switch key := key.(type) {
+	case *rsa.PrivateKey:
+		privateKey := &pem.Block{
+			Type:  "PRIVATE KEY",
+			Bytes: x509.MarshalPKCS1PrivateKey(key),
+		}
+		return pem.EncodeToMemory(privateKey), nil
+	case *ecdsa.PrivateKey:
+		der, err := x509.MarshalECPrivateKey(key)
+		if err != nil {
+			return nil, err
+		}
+		privateKey := &pem.Block{
+			Type:  "EC PRIVATE KEY",
+			Bytes: der,
+		}
+		return pem.EncodeToMemory(privateKey), nil
+	default:
+		return nil, fmt.Errorf("unsupported private key type %T", key)
 	}
-	return pem.EncodeToMemory(privateKey)
 }
 
 func certificateToPem(certs ...*x509.Certificate) []byte {
@@ -31,9 +49,25 @@ func certificateToPem(certs ...*x509.Certificate) []byte {
 	return raw
 }
 
-func pemToPrivateKey(raw []byte) (*rsa.PrivateKey, error) {
+func pemToPrivateKey(raw []byte) (crypto.Signer, error) {
 	block, _ := pem.Decode(raw)
-	return x509.ParsePKCS1PrivateKey(block.Bytes)
+	if block == nil {
+		return nil, fmt.Errorf("failed to decode private key")
+	}
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	if key, err := x509.ParseECPrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	if signer, ok := key.(crypto.Signer); ok {
+		return signer, nil
+	}
+	return nil, fmt.Errorf("unsupported private key type %T", key)
 }
 
 func pemToCertificates(raw []byte) []*x509.Certificate {
@@ -101,10 +135,40 @@ func inClusterServiceName() string {
 	return config.KyvernoServiceName() + "." + config.KyvernoNamespace() + ".svc"
 }
 
+// IsExternallyManaged returns true when certificates are provided by an external issuer (cert-manager or the user)
+// in which case kyverno consumes the secrets but never generates nor renews them
+func IsExternallyManaged() bool {
+	return config.KyvernoTLSSecretName() != ""
+}
+
 func GenerateTLSPairSecretName() string {
+	if name := config.KyvernoTLSSecretName(); name != "" {
+		return name
+	}
 	return inClusterServiceName() + ".kyverno-tls-pair"
 }
 
 func GenerateRootCASecretName() string {
+	if name := config.KyvernoCASecretName(); name != "" {
+		return name
+	}
+	// secrets issued by cert-manager carry the CA in the ca.crt key
+	if name := config.KyvernoTLSSecretName(); name != "" {
+		return name
+	}
 	return inClusterServiceName() + ".kyverno-tls-ca"
 }
+
+// caCertificateData returns the CA certificates stored in a secret,
+// looking for "ca.crt" (cert-manager), then "tls.crt" and finally the old "rootCA.crt"
+func caCertificateData(secret *corev1.Secret) []byte {
+	if secret == nil {
+		return nil
+	}
+	for _, key := range []string{caCertKey, corev1.TLSCertKey, rootCAKey} {
+		if data := secret.Data[key]; len(data) != 0 {
+			return data
+		}
+	}
+	return nil
+}
@@ -0,0 +1,35 @@
+package tls
+
+import (
+	"testing"
+
+	"gotest.tools/assert"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func Test_caCertificateData(t *testing.T) {
+	tests := []struct {
+		name   string
+		secret *corev1.Secret
+		want   string
+	}{{
+		name: "nil secret",
+	}, {
+		name:   "cert-manager secret",
+		secret: &corev1.Secret{Data: map[string][]byte{"ca.crt": []byte("ca"), "tls.crt": []byte("tls")}},
+		want:   "ca",
+	}, {
+		name:   "kyverno secret",
+		secret: &corev1.Secret{Data: map[string][]byte{"tls.crt": []byte("tls"), "tls.key": []byte("key")}},
+		want:   "tls",
+	}, {
+		name:   "legacy secret",
+		secret: &corev1.Secret{Data: map[string][]byte{"rootCA.crt": []byte("root")}},
+		want:   "root",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, string(caCertificateData(tt.secret)), tt.want)
+		})
+	}
+}
@@ -1,7 +1,10 @@
 package admission
 
 import (
+	"errors"
+
 	admissionv1 "k8s.io/api/admission/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 )
@@ -18,6 +21,11 @@ func Response(uid types.UID, err error, warnings ...string) admissionv1.Admissio
 			Status:  metav1.StatusFailure,
 			Message: err.Error(),
 		}
+		// propagate machine readable details (causes) when the error carries them
+		var status apierrors.APIStatus
+		if errors.As(err, &status) {
+			response.Result.Details = status.Status().Details
+		}
 	}
 	response.Warnings = warnings
 	return response
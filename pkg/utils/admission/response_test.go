@@ -2,10 +2,12 @@ package admission
 
 import (
 	"errors"
+	"fmt"
 	"reflect"
 	"testing"
 
 	admissionv1 "k8s.io/api/admission/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -64,6 +66,28 @@ func TestResponse(t *testing.T) {
 			},
 			Warnings: []string{"foo", "bar"},
 		},
+	}, {
+		name: "error with causes",
+		args: args{
+			err: fmt.Errorf("wrapped: %w", &apierrors.StatusError{
+				ErrStatus: metav1.Status{
+					Message: "blocked",
+					Details: &metav1.StatusDetails{
+						Causes: []metav1.StatusCause{{Type: "PolicyViolation", Field: "/spec/", Message: "policy/rule: failed"}},
+					},
+				},
+			}),
+		},
+		want: admissionv1.AdmissionResponse{
+			Allowed: false,
+			Result: &metav1.Status{
+				Status:  metav1.StatusFailure,
+				Message: "wrapped: blocked",
+				Details: &metav1.StatusDetails{
+					Causes: []metav1.StatusCause{{Type: "PolicyViolation", Field: "/spec/", Message: "policy/rule: failed"}},
+				},
+			},
+		},
 	}}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -142,47 +142,54 @@ func BuildStandardExtractors(tags ...string) []imageExtractor {
 	return extractors
 }
 
-func lookupImageExtractor(kind string, configs kyvernov1.ImageExtractorConfigs) []imageExtractor {
-	if configs != nil {
-		if extractorConfigs, ok := configs[kind]; ok {
-			extractors := []imageExtractor{}
-			for _, c := range extractorConfigs {
-				fields := func(input []string) []string {
-					output := []string{}
-					for _, i := range input {
-						o := strings.Trim(i, " ")
-						if o != "" {
-							output = append(output, o)
-						}
-					}
-					return output
-				}(strings.Split(c.Path, "/"))
-				name := c.Name
-				if name == "" {
-					name = "custom"
-				}
-				value := c.Value
-				if value == "" {
-					value = fields[len(fields)-1]
-					fields = fields[:len(fields)-1]
+func lookupImageExtractor(kind string, configs kyvernov1.ImageExtractorConfigs, cfg config.Configuration) []imageExtractor {
+	if extractorConfigs, ok := configs[kind]; ok {
+		return buildImageExtractors(extractorConfigs)
+	}
+	if cfg != nil {
+		if extractorConfigs, ok := cfg.GetImageExtractors()[kind]; ok {
+			return buildImageExtractors(extractorConfigs)
+		}
+	}
+	return registeredExtractors[kind]
+}
+
+func buildImageExtractors(configs []kyvernov1.ImageExtractorConfig) []imageExtractor {
+	extractors := []imageExtractor{}
+	for _, c := range configs {
+		fields := func(input []string) []string {
+			output := []string{}
+			for _, i := range input {
+				o := strings.Trim(i, " ")
+				if o != "" {
+					output = append(output, o)
 				}
-				extractors = append(extractors, imageExtractor{
-					Fields:   fields,
-					Key:      c.Key,
-					Name:     name,
-					Value:    value,
-					JMESPath: c.JMESPath,
-				})
 			}
-			return extractors
+			return output
+		}(strings.Split(c.Path, "/"))
+		name := c.Name
+		if name == "" {
+			name = "custom"
+		}
+		value := c.Value
+		if value == "" {
+			value = fields[len(fields)-1]
+			fields = fields[:len(fields)-1]
 		}
+		extractors = append(extractors, imageExtractor{
+			Fields:   fields,
+			Key:      c.Key,
+			Name:     name,
+			Value:    value,
+			JMESPath: c.JMESPath,
+		})
 	}
-	return registeredExtractors[kind]
+	return extractors
 }
 
 func ExtractImagesFromResource(resource unstructured.Unstructured, configs kyvernov1.ImageExtractorConfigs, cfg config.Configuration) (map[string]map[string]ImageInfo, error) {
 	infos := map[string]map[string]ImageInfo{}
-	extractors := lookupImageExtractor(resource.GetKind(), configs)
+	extractors := lookupImageExtractor(resource.GetKind(), configs, cfg)
 	if extractors != nil && len(extractors) == 0 {
 		return nil, fmt.Errorf("no extractors found for %s", resource.GetKind())
 	}
@@ -8,6 +8,7 @@ import (
 	imageutils "github.com/kyverno/kyverno/pkg/utils/image"
 	kubeutils "github.com/kyverno/kyverno/pkg/utils/kube"
 	"gotest.tools/assert"
+	corev1 "k8s.io/api/core/v1"
 )
 
 var cfg = config.NewDefaultConfiguration(false)
@@ -248,3 +249,41 @@ func Test_extractImageInfo(t *testing.T) {
 		assert.DeepEqual(t, test.images, images)
 	}
 }
+
+func Test_extractImageInfoFromConfiguration(t *testing.T) {
+	cfg := config.NewDefaultConfiguration(false)
+	cfg.Load(&corev1.ConfigMap{
+		Data: map[string]string{
+			"imageExtractors": `{"Task": [{"path": "/spec/steps/*/image", "name": "steps", "key": "name"}]}`,
+		},
+	})
+	raw := []byte(`{"apiVersion":"tekton.dev/v1","kind":"Task","metadata":{"name":"build"},"spec":{"steps":[{"name":"build","image":"golang:1.20"}]}}`)
+	resource, err := kubeutils.BytesToUnstructured(raw)
+	assert.NilError(t, err)
+	want := map[string]map[string]ImageInfo{
+		"steps": {
+			"build": {
+				imageutils.ImageInfo{
+					Registry: "docker.io",
+					Name:     "golang",
+					Path:     "golang",
+					Tag:      "1.20",
+				},
+				"/spec/steps/0/image",
+			},
+		},
+	}
+	images, err := ExtractImagesFromResource(*resource, nil, cfg)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, want, images)
+	// rule extractors take precedence over the configuration
+	images, err = ExtractImagesFromResource(*resource, kyvernov1.ImageExtractorConfigs{
+		"Task": {{Path: "/spec/steps/*/image", Name: "custom-steps", Key: "name"}},
+	}, cfg)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, map[string]map[string]ImageInfo{"custom-steps": want["steps"]}, images)
+	// kinds without extractors have no images
+	images, err = ExtractImagesFromResource(*resource, nil, config.NewDefaultConfiguration(false))
+	assert.NilError(t, err)
+	assert.Equal(t, len(images), 0)
+}
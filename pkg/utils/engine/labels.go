@@ -19,3 +19,17 @@ func GetNamespaceSelectorsFromNamespaceLister(kind, namespaceOfResource string,
 	}
 	return namespaceLabels
 }
+
+// GetNamespaceAnnotationsFromNamespaceLister - extract the namespace annotations when namespace lister is passed
+func GetNamespaceAnnotationsFromNamespaceLister(kind, namespaceOfResource string, nsLister corev1listers.NamespaceLister, logger logr.Logger) map[string]string {
+	namespaceAnnotations := make(map[string]string)
+	if kind != "Namespace" && namespaceOfResource != "" {
+		namespaceObj, err := nsLister.Get(namespaceOfResource)
+		if err != nil {
+			logging.Error(err, "failed to get the namespace", "name", namespaceOfResource)
+			return namespaceAnnotations
+		}
+		return namespaceObj.DeepCopy().GetAnnotations()
+	}
+	return namespaceAnnotations
+}
@@ -15,10 +15,19 @@ func IsResponseSuccessful(engineReponses []engineapi.EngineResponse) bool {
 	return true
 }
 
-// BlockRequest returns true when:
+// RolloutEnforces returns false when the policy rollout runs in shadow mode or
+// the request is not part of the percentage of requests enforced by the rollout
+func RolloutEnforces(er engineapi.EngineResponse) bool {
+	return er.GetRollout().Enforces(er.GetRolloutKey())
+}
+
+// BlockRequest returns true when the policy rollout enforces the request and:
 // 1. a policy fails (i.e. creates a violation) and validationFailureAction is set to 'enforce'
 // 2. a policy has a processing error and failurePolicy is set to 'Fail`
 func BlockRequest(er engineapi.EngineResponse, failurePolicy kyvernov1.FailurePolicyType) bool {
+	if !RolloutEnforces(er) {
+		return false
+	}
 	if er.IsFailed() && er.GetValidationFailureAction().Enforce() {
 		return true
 	}
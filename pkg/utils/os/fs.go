@@ -0,0 +1,24 @@
+package os
+
+import (
+	"fmt"
+	"os"
+)
+
+// CheckWritableDir creates dir if needed and checks that files can be written in it
+func CheckWritableDir(dir string) error {
+	if dir == "" {
+		return fmt.Errorf("directory is not set")
+	}
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return err
+	}
+	file, err := os.CreateTemp(dir, ".kyverno-check-*")
+	if err != nil {
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+	return os.Remove(file.Name())
+}
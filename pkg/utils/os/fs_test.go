@@ -0,0 +1,25 @@
+package os
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckWritableDir(t *testing.T) {
+	dir := t.TempDir()
+	assert.NoError(t, CheckWritableDir(dir))
+	assert.NoError(t, CheckWritableDir(filepath.Join(dir, "nested", "dir")))
+	entries, err := os.ReadDir(dir)
+	assert.NoError(t, err)
+	assert.Len(t, entries, 1)
+	assert.Error(t, CheckWritableDir(""))
+	if runtime.GOOS != "windows" && os.Geteuid() != 0 {
+		readOnly := filepath.Join(dir, "read-only")
+		assert.NoError(t, os.Mkdir(readOnly, 0o500))
+		assert.Error(t, CheckWritableDir(readOnly))
+	}
+}
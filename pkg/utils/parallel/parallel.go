@@ -0,0 +1,59 @@
+package parallel
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// Map applies fn to every element of in using at most parallelism goroutines.
+// Results are returned in the same order as the input, regardless of completion order.
+// When parallelism is lower than 2, elements are processed sequentially in the calling goroutine.
+// A panic in fn is recovered in the worker goroutine and raised again in the calling goroutine
+// once all workers are done, remaining elements are not processed.
+func Map[T any, R any](parallelism int, in []T, fn func(int, T) R) []R {
+	out := make([]R, len(in))
+	if parallelism < 2 || len(in) < 2 {
+		for i := range in {
+			out[i] = fn(i, in[i])
+		}
+		return out
+	}
+	if parallelism > len(in) {
+		parallelism = len(in)
+	}
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	var once sync.Once
+	var failed atomic.Bool
+	var recovered any
+	apply := func(i int) {
+		defer func() {
+			if r := recover(); r != nil {
+				once.Do(func() { recovered = r })
+				failed.Store(true)
+			}
+		}()
+		out[i] = fn(i, in[i])
+	}
+	wg.Add(parallelism)
+	for w := 0; w < parallelism; w++ {
+		go func() {
+			defer wg.Done()
+			// keep draining the indexes after a panic so that the sender is not blocked
+			for i := range indexes {
+				if !failed.Load() {
+					apply(i)
+				}
+			}
+		}()
+	}
+	for i := range in {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+	if failed.Load() {
+		panic(recovered)
+	}
+	return out
+}
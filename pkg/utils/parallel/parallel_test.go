@@ -0,0 +1,52 @@
+package parallel
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"gotest.tools/assert"
+)
+
+func TestMap(t *testing.T) {
+	in := []int{5, 1, 4, 2, 3, 0}
+	for _, parallelism := range []int{0, 1, 2, 3, 10} {
+		var running, peak atomic.Int32
+		out := Map(parallelism, in, func(i int, v int) int {
+			current := running.Add(1)
+			defer running.Add(-1)
+			for {
+				p := peak.Load()
+				if current <= p || peak.CompareAndSwap(p, current) {
+					break
+				}
+			}
+			// make later inputs complete first
+			time.Sleep(time.Duration(v) * time.Millisecond)
+			return i * 10
+		})
+		assert.DeepEqual(t, out, []int{0, 10, 20, 30, 40, 50})
+		limit := int32(parallelism)
+		if limit < 1 {
+			limit = 1
+		}
+		assert.Assert(t, peak.Load() <= limit, "parallelism %d, peak %d", parallelism, peak.Load())
+	}
+}
+
+func TestMapPanic(t *testing.T) {
+	in := []int{0, 1, 2, 3, 4, 5}
+	for _, parallelism := range []int{1, 3} {
+		recovered := func() (r any) {
+			defer func() { r = recover() }()
+			Map(parallelism, in, func(i int, v int) int {
+				if v == 2 {
+					panic("boom")
+				}
+				return v
+			})
+			return nil
+		}()
+		assert.Equal(t, recovered, "boom", "parallelism %d", parallelism)
+	}
+}
@@ -0,0 +1,51 @@
+package policy
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/kyverno/kyverno/api/kyverno"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// reservedWebhookMetadataPrefix is the prefix of the webhook configuration labels and annotations managed by kyverno
+const reservedWebhookMetadataPrefix = "webhook.kyverno.io/"
+
+// GetWebhookAnnotations returns the annotations a policy declares with the webhook.kyverno.io/annotations annotation,
+// they are merged onto the webhook configurations the policy is registered in.
+func GetWebhookAnnotations(policy kyvernov1.PolicyInterface) (map[string]string, error) {
+	return getWebhookMetadata(policy, kyverno.AnnotationWebhookAnnotations, nil)
+}
+
+// GetWebhookLabels returns the labels a policy declares with the webhook.kyverno.io/labels annotation,
+// they are merged onto the webhook configurations the policy is registered in.
+func GetWebhookLabels(policy kyvernov1.PolicyInterface) (map[string]string, error) {
+	return getWebhookMetadata(policy, kyverno.AnnotationWebhookLabels, validation.IsValidLabelValue)
+}
+
+func getWebhookMetadata(policy kyvernov1.PolicyInterface, annotation string, validateValue func(string) []string) (map[string]string, error) {
+	value, ok := policy.GetAnnotations()[annotation]
+	if !ok {
+		return nil, nil
+	}
+	var metadata map[string]string
+	if err := json.Unmarshal([]byte(value), &metadata); err != nil {
+		return nil, fmt.Errorf("the value must be a JSON object of strings: %w", err)
+	}
+	for key, value := range metadata {
+		if strings.HasPrefix(key, reservedWebhookMetadataPrefix) {
+			return nil, fmt.Errorf("key %s is reserved", key)
+		}
+		if errs := validation.IsQualifiedName(key); len(errs) != 0 {
+			return nil, fmt.Errorf("invalid key %s: %s", key, strings.Join(errs, ", "))
+		}
+		if validateValue != nil {
+			if errs := validateValue(value); len(errs) != 0 {
+				return nil, fmt.Errorf("invalid value %s for key %s: %s", value, key, strings.Join(errs, ", "))
+			}
+		}
+	}
+	return metadata, nil
+}
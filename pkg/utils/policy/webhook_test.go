@@ -0,0 +1,66 @@
+package policy
+
+import (
+	"testing"
+
+	"github.com/kyverno/kyverno/api/kyverno"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"gotest.tools/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func Test_GetWebhookMetadata(t *testing.T) {
+	tests := []struct {
+		name            string
+		annotations     map[string]string
+		wantAnnotations map[string]string
+		wantLabels      map[string]string
+		wantErr         bool
+	}{{
+		name: "not set",
+	}, {
+		name: "annotations and labels",
+		annotations: map[string]string{
+			kyverno.AnnotationWebhookAnnotations: `{"argocd.argoproj.io/compare-options":"IgnoreExtraneous"}`,
+			kyverno.AnnotationWebhookLabels:      `{"cost-center":"platform"}`,
+		},
+		wantAnnotations: map[string]string{"argocd.argoproj.io/compare-options": "IgnoreExtraneous"},
+		wantLabels:      map[string]string{"cost-center": "platform"},
+	}, {
+		name: "not a json object",
+		annotations: map[string]string{
+			kyverno.AnnotationWebhookLabels: `cost-center=platform`,
+		},
+		wantErr: true,
+	}, {
+		name: "invalid label value",
+		annotations: map[string]string{
+			kyverno.AnnotationWebhookLabels: `{"cost-center":"platform team"}`,
+		},
+		wantErr: true,
+	}, {
+		name: "reserved key",
+		annotations: map[string]string{
+			kyverno.AnnotationWebhookAnnotations: `{"webhook.kyverno.io/owned-annotations":"foo"}`,
+		},
+		wantErr: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policy := &kyvernov1.ClusterPolicy{ObjectMeta: metav1.ObjectMeta{Annotations: tt.annotations}}
+			annotations, err := GetWebhookAnnotations(policy)
+			if tt.wantErr && err != nil {
+				return
+			}
+			assert.NilError(t, err)
+			labels, err := GetWebhookLabels(policy)
+			if tt.wantErr {
+				assert.Assert(t, err != nil)
+				return
+			}
+			assert.NilError(t, err)
+			assert.DeepEqual(t, annotations, tt.wantAnnotations)
+			assert.DeepEqual(t, labels, tt.wantLabels)
+		})
+	}
+}
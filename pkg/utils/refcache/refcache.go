@@ -0,0 +1,73 @@
+package refcache
+
+import "sync"
+
+// Cache stores values shared by several owners, a value is removed when no owner references it anymore.
+// It is safe for concurrent use.
+type Cache[V any] struct {
+	lock   sync.RWMutex
+	values map[string]V
+	refs   map[string]int
+	owners map[string][]string
+}
+
+func New[V any]() *Cache[V] {
+	return &Cache[V]{
+		values: map[string]V{},
+		refs:   map[string]int{},
+		owners: map[string][]string{},
+	}
+}
+
+// Get returns the value stored for key, if any
+func (c *Cache[V]) Get(key string) (V, bool) {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	value, ok := c.values[key]
+	return value, ok
+}
+
+// Set stores values for owner, replacing the values previously stored for the same owner
+func (c *Cache[V]) Set(owner string, values map[string]V) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.release(owner)
+	if len(values) == 0 {
+		return
+	}
+	keys := make([]string, 0, len(values))
+	for key, value := range values {
+		// values are shared, an existing value for the same key is kept
+		if _, ok := c.values[key]; !ok {
+			c.values[key] = value
+		}
+		c.refs[key]++
+		keys = append(keys, key)
+	}
+	c.owners[owner] = keys
+}
+
+// Release removes the values stored for owner that are not referenced by other owners
+func (c *Cache[V]) Release(owner string) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.release(owner)
+}
+
+// Len returns the number of values in the cache
+func (c *Cache[V]) Len() int {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	return len(c.values)
+}
+
+func (c *Cache[V]) release(owner string) {
+	for _, key := range c.owners[owner] {
+		c.refs[key]--
+		if c.refs[key] <= 0 {
+			delete(c.refs, key)
+			delete(c.values, key)
+		}
+	}
+	delete(c.owners, owner)
+}
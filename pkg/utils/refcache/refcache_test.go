@@ -0,0 +1,31 @@
+package refcache
+
+import (
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+func TestCache(t *testing.T) {
+	cache := New[int]()
+	cache.Set("a", map[string]int{"x": 1, "y": 2})
+	cache.Set("b", map[string]int{"y": 3, "z": 4})
+	assert.Equal(t, cache.Len(), 3)
+	// existing values are kept when shared
+	value, ok := cache.Get("y")
+	assert.Assert(t, ok)
+	assert.Equal(t, value, 2)
+	// replacing the values of an owner releases the previous ones
+	cache.Set("a", map[string]int{"w": 5})
+	_, ok = cache.Get("x")
+	assert.Assert(t, !ok)
+	_, ok = cache.Get("y")
+	assert.Assert(t, ok)
+	cache.Release("b")
+	_, ok = cache.Get("y")
+	assert.Assert(t, !ok)
+	assert.Equal(t, cache.Len(), 1)
+	cache.Release("a")
+	cache.Release("unknown")
+	assert.Equal(t, cache.Len(), 0)
+}
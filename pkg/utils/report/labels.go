@@ -32,6 +32,8 @@ const (
 	LabelPrefixPolicy        = LabelDomainPolicy + "/"
 	//	aggregated admission report label
 	LabelAggregatedReport = "audit.kyverno.io/report.aggregate"
+	//	summary policy report label
+	LabelSummaryReport = "audit.kyverno.io/report.summary"
 )
 
 func IsPolicyLabel(label string) bool {
@@ -61,3 +61,10 @@ func NewPolicyReport(namespace, name string, results ...policyreportv1alpha2.Pol
 	SetResults(report, results...)
 	return report
 }
+
+func NewSummaryPolicyReport(namespace, name string, summary policyreportv1alpha2.PolicyReportSummary) kyvernov1alpha2.ReportInterface {
+	report := NewPolicyReport(namespace, name)
+	controllerutils.SetLabel(report, LabelSummaryReport, "")
+	report.SetSummary(summary)
+	return report
+}
@@ -0,0 +1,63 @@
+package report
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+
+	kyvernov1alpha2 "github.com/kyverno/kyverno/api/kyverno/v1alpha2"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	"github.com/kyverno/kyverno/pkg/jsonpayload"
+	controllerutils "github.com/kyverno/kyverno/pkg/utils/controller"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/uuid"
+)
+
+// LabelPayloadKind records the kind of the payload a report belongs to, payloads are not stored in the cluster
+const LabelPayloadKind = "audit.kyverno.io/payload.kind"
+
+// PayloadUid returns the uid identifying the reports of a payload, payloads have no uid
+// so it is derived from the payload kind, namespace and name
+func PayloadUid(kind, namespace, name string) types.UID {
+	hash := sha256.Sum256([]byte(kind + "/" + namespace + "/" + name))
+	return types.UID(hex.EncodeToString(hash[:16]))
+}
+
+// NewPayloadReport creates an admission report with the given name for a payload
+func NewPayloadReport(kind, namespace, name, reportName string) kyvernov1alpha2.ReportInterface {
+	var resource unstructured.Unstructured
+	resource.SetUID(PayloadUid(kind, namespace, name))
+	resource.SetNamespace(namespace)
+	resource.SetName(name)
+	report := NewAdmissionReport(namespace, reportName, jsonpayload.GroupVersionResource(kind), resource)
+	controllerutils.SetLabel(report, LabelPayloadKind, kind)
+	return report
+}
+
+// BuildPayloadReport builds an intermediate admission report for a payload, it is aggregated with
+// the other reports of the same payload by the admission reports controller
+func BuildPayloadReport(resource unstructured.Unstructured, responses ...engineapi.EngineResponse) kyvernov1alpha2.ReportInterface {
+	report := NewPayloadReport(resource.GetKind(), resource.GetNamespace(), resource.GetName(), string(uuid.NewUUID()))
+	SetResponses(report, responses...)
+	return report
+}
+
+// IsPayloadReport returns true if the report belongs to a payload
+func IsPayloadReport(report metav1.Object) bool {
+	return jsonpayload.IsPayloadGroup(GetResourceGVR(report).Group)
+}
+
+// PayloadReference returns the reference to the payload a report belongs to,
+// payload reports have no owner so it is built from the report labels
+func PayloadReference(report kyvernov1alpha2.ReportInterface) corev1.ObjectReference {
+	namespace, name := GetResourceNamespaceAndName(report)
+	return corev1.ObjectReference{
+		APIVersion: jsonpayload.GroupVersion.String(),
+		Kind:       controllerutils.GetLabel(report, LabelPayloadKind),
+		Namespace:  namespace,
+		Name:       name,
+		UID:        GetResourceUid(report),
+	}
+}
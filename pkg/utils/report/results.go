@@ -1,6 +1,7 @@
 package report
 
 import (
+	"encoding/json"
 	"sort"
 	"strings"
 	"time"
@@ -10,6 +11,7 @@ import (
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
 	kyvernov1alpha2 "github.com/kyverno/kyverno/api/kyverno/v1alpha2"
 	policyreportv1alpha2 "github.com/kyverno/kyverno/api/policyreport/v1alpha2"
+	"github.com/kyverno/kyverno/pkg/config"
 	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
 	"golang.org/x/exp/slices"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -105,6 +107,15 @@ func EngineResponseToReportResults(response engineapi.EngineResponse) []policyre
 			Category: annotations[kyverno.AnnotationPolicyCategory],
 			Severity: SeverityFromString(annotations[kyverno.AnnotationPolicySeverity]),
 		}
+		if severity := SeverityFromString(ruleResult.Severity()); severity != "" {
+			result.Severity = severity
+		}
+		if properties := ruleResult.Properties(); len(properties) > 0 {
+			result.Properties = make(map[string]string, len(properties))
+			for key, value := range properties {
+				result.Properties[key] = value
+			}
+		}
 		pss := ruleResult.PodSecurityChecks()
 		if pss != nil {
 			var controls []string
@@ -115,12 +126,19 @@ func EngineResponseToReportResults(response engineapi.EngineResponse) []policyre
 			}
 			if len(controls) > 0 {
 				sort.Strings(controls)
-				result.Properties = map[string]string{
-					"standard": string(pss.Level),
-					"version":  pss.Version,
-					"controls": strings.Join(controls, ","),
+				if result.Properties == nil {
+					result.Properties = map[string]string{}
 				}
+				result.Properties["standard"] = string(pss.Level)
+				result.Properties["version"] = pss.Version
+				result.Properties["controls"] = strings.Join(controls, ",")
+			}
+		}
+		if reason := ruleResult.SkipReason(); reason != "" {
+			if result.Properties == nil {
+				result.Properties = map[string]string{}
 			}
+			result.Properties["skipReason"] = string(reason)
 		}
 		if result.Result == "fail" && !result.Scored {
 			result.Result = "warn"
@@ -154,6 +172,44 @@ func SplitResultsByPolicy(logger logr.Logger, results []policyreportv1alpha2.Pol
 	return resultsMap
 }
 
+// ShardResults splits results in shards holding at most maxCount results and at most maxSize bytes
+// of serialized results (a value <= 0 disables the corresponding limit).
+// Results are sorted first so that the same results always end up in the same shards.
+func ShardResults(results []policyreportv1alpha2.PolicyReportResult, maxCount, maxSize int) [][]policyreportv1alpha2.PolicyReportResult {
+	if len(results) == 0 {
+		return nil
+	}
+	SortReportResults(results)
+	var shards [][]policyreportv1alpha2.PolicyReportResult
+	start, size := 0, 0
+	for i := range results {
+		resultSize := 0
+		if maxSize > 0 {
+			if data, err := json.Marshal(results[i]); err == nil {
+				resultSize = len(data)
+			}
+		}
+		count := i - start
+		if count > 0 && ((maxCount > 0 && count >= maxCount) || (maxSize > 0 && size+resultSize > maxSize)) {
+			shards = append(shards, results[start:i])
+			start, size = i, 0
+		}
+		size += resultSize
+	}
+	return append(shards, results[start:])
+}
+
+// ExcludeResults removes the results excluded from reports by the configuration
+func ExcludeResults(configuration config.Configuration, namespace string, results []policyreportv1alpha2.PolicyReportResult) []policyreportv1alpha2.PolicyReportResult {
+	var filtered []policyreportv1alpha2.PolicyReportResult
+	for _, result := range results {
+		if !configuration.IsExcludedFromReports(result.Policy, result.Rule, namespace) {
+			filtered = append(filtered, result)
+		}
+	}
+	return filtered
+}
+
 func SetResults(report kyvernov1alpha2.ReportInterface, results ...policyreportv1alpha2.PolicyReportResult) {
 	SortReportResults(results)
 	report.SetResults(results)
@@ -0,0 +1,130 @@
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	policyreportv1alpha2 "github.com/kyverno/kyverno/api/policyreport/v1alpha2"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	"gotest.tools/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func newResults(count int) []policyreportv1alpha2.PolicyReportResult {
+	var results []policyreportv1alpha2.PolicyReportResult
+	for i := 0; i < count; i++ {
+		results = append(results, policyreportv1alpha2.PolicyReportResult{
+			Policy: "test",
+			Rule:   "test",
+			Result: policyreportv1alpha2.StatusPass,
+			Resources: []corev1.ObjectReference{{
+				UID: types.UID(fmt.Sprintf("uid-%03d", count-i)),
+			}},
+		})
+	}
+	return results
+}
+
+func TestShardResults(t *testing.T) {
+	data, err := json.Marshal(newResults(1)[0])
+	assert.NilError(t, err)
+	resultSize := len(data)
+	tests := []struct {
+		name     string
+		count    int
+		maxCount int
+		maxSize  int
+		want     []int
+	}{{
+		name: "empty",
+	}, {
+		name:  "no limits",
+		count: 10,
+		want:  []int{10},
+	}, {
+		name:     "count limit",
+		count:    10,
+		maxCount: 4,
+		want:     []int{4, 4, 2},
+	}, {
+		name:    "size limit",
+		count:   10,
+		maxSize: 3 * resultSize,
+		want:    []int{3, 3, 3, 1},
+	}, {
+		name:     "count and size limits",
+		count:    10,
+		maxCount: 2,
+		maxSize:  3 * resultSize,
+		want:     []int{2, 2, 2, 2, 2},
+	}, {
+		name:    "result bigger than size limit",
+		count:   2,
+		maxSize: 1,
+		want:    []int{1, 1},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			shards := ShardResults(newResults(tt.count), tt.maxCount, tt.maxSize)
+			var got []int
+			for _, shard := range shards {
+				got = append(got, len(shard))
+			}
+			assert.DeepEqual(t, got, tt.want)
+			// shards must be stable regardless of the input order
+			if len(shards) > 0 {
+				assert.Equal(t, shards[0][0].Resources[0].UID, types.UID("uid-001"))
+			}
+		})
+	}
+}
+
+func TestEngineResponseToReportResults_SkipReason(t *testing.T) {
+	policy := engineapi.NewKyvernoPolicy(&kyvernov1.ClusterPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test",
+		},
+	})
+	response := engineapi.NewEngineResponse(unstructured.Unstructured{}, policy, nil).WithPolicyResponse(engineapi.PolicyResponse{
+		Rules: []engineapi.RuleResponse{
+			*engineapi.RulePass("pass", engineapi.Validation, ""),
+			*engineapi.RuleSkip("skip", engineapi.Validation, "").WithSkipReason(engineapi.SkipReasonPreconditions),
+			*engineapi.RuleSkip("unknown", engineapi.Validation, ""),
+		},
+	})
+	results := EngineResponseToReportResults(response)
+	assert.Equal(t, len(results), 3)
+	assert.Assert(t, results[0].Properties == nil)
+	assert.DeepEqual(t, results[1].Properties, map[string]string{"skipReason": "PreconditionsNotMet"})
+	assert.DeepEqual(t, results[2].Properties, map[string]string{"skipReason": "Unknown"})
+}
+
+func TestEngineResponseToReportResults_SeverityAndProperties(t *testing.T) {
+	policy := engineapi.NewKyvernoPolicy(&kyvernov1.ClusterPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test",
+			Annotations: map[string]string{
+				"policies.kyverno.io/severity": "medium",
+			},
+		},
+	})
+	response := engineapi.NewEngineResponse(unstructured.Unstructured{}, policy, nil).WithPolicyResponse(engineapi.PolicyResponse{
+		Rules: []engineapi.RuleResponse{
+			*engineapi.RulePass("pass", engineapi.Validation, "").WithProperties(map[string]string{"team": "a"}),
+			*engineapi.RuleFail("fail", engineapi.Validation, "").WithSeverity("critical"),
+			*engineapi.RuleFail("invalid", engineapi.Validation, "").WithSeverity("urgent"),
+		},
+	})
+	results := EngineResponseToReportResults(response)
+	assert.Equal(t, len(results), 3)
+	assert.Equal(t, results[0].Severity, policyreportv1alpha2.PolicySeverity(policyreportv1alpha2.SeverityMedium))
+	assert.DeepEqual(t, results[0].Properties, map[string]string{"team": "a"})
+	assert.Equal(t, results[1].Severity, policyreportv1alpha2.PolicySeverity(policyreportv1alpha2.SeverityCritical))
+	assert.Assert(t, results[1].Properties == nil)
+	assert.Equal(t, results[2].Severity, policyreportv1alpha2.PolicySeverity(policyreportv1alpha2.SeverityMedium))
+}
@@ -0,0 +1,159 @@
+package constraint
+
+import (
+	"fmt"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	"github.com/kyverno/kyverno/pkg/utils/api"
+	"golang.org/x/exp/slices"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// Validate checks a namespaced policy against the cluster policy constraints selecting its namespace,
+// cluster policies are never constrained
+func Validate(policy kyvernov1.PolicyInterface, namespaceLabels map[string]string, constraints []*kyvernov2alpha1.ClusterPolicyConstraint) error {
+	if !policy.IsNamespaced() {
+		return nil
+	}
+	var errs field.ErrorList
+	for _, constraint := range constraints {
+		selected, err := selects(constraint, namespaceLabels)
+		if err != nil {
+			// an invalid selector constrains all namespaces, admins are expected to fix their constraint
+			errs = append(errs, field.Invalid(field.NewPath("metadata", "namespace"), policy.GetNamespace(), fmt.Sprintf("cluster policy constraint %s has an invalid namespace selector: %s", constraint.Name, err)))
+			continue
+		}
+		if selected {
+			errs = append(errs, validate(policy.GetSpec(), field.NewPath("spec"), constraint)...)
+		}
+	}
+	return errs.ToAggregate()
+}
+
+func selects(constraint *kyvernov2alpha1.ClusterPolicyConstraint, namespaceLabels map[string]string) (bool, error) {
+	if constraint.Spec.NamespaceSelector == nil {
+		return true, nil
+	}
+	selector, err := metav1.LabelSelectorAsSelector(constraint.Spec.NamespaceSelector)
+	if err != nil {
+		return false, err
+	}
+	return selector.Matches(labels.Set(namespaceLabels)), nil
+}
+
+func validate(spec *kyvernov1.Spec, path *field.Path, constraint *kyvernov2alpha1.ClusterPolicyConstraint) (errs field.ErrorList) {
+	forbidden := func(path *field.Path, what string) *field.Error {
+		return field.Forbidden(path, fmt.Sprintf("%s is not allowed by cluster policy constraint %s", what, constraint.Name))
+	}
+	if actions := constraint.Spec.ValidationFailureActions; len(actions) != 0 && (spec.HasValidate() || spec.HasVerifyImages()) {
+		allowed := slices.ContainsFunc(actions, func(action kyvernov2alpha1.ConstraintValidationFailureAction) bool {
+			return action.Allows(spec.ValidationFailureAction)
+		})
+		if !allowed {
+			errs = append(errs, forbidden(path.Child("validationFailureAction"), fmt.Sprintf("validation failure action %s", spec.ValidationFailureAction)))
+		}
+	}
+	for i, rule := range spec.Rules {
+		rulePath := path.Child("rules").Index(i)
+		if ruleTypes := constraint.Spec.RuleTypes; len(ruleTypes) != 0 {
+			for _, ruleType := range getRuleTypes(rule) {
+				if !slices.Contains(ruleTypes, ruleType) {
+					errs = append(errs, forbidden(rulePath, fmt.Sprintf("rule type %s", ruleType)))
+				}
+			}
+		}
+		if entryTypes := constraint.Spec.ContextEntryTypes; len(entryTypes) != 0 {
+			for _, entry := range getContextEntries(rule, rulePath) {
+				// entries of unknown type can't be checked against the allowed types, they are rejected
+				if entryType := getContextEntryType(entry.ContextEntry); entryType == "" {
+					errs = append(errs, forbidden(entry.path, "context entry of unknown type"))
+				} else if !slices.Contains(entryTypes, entryType) {
+					errs = append(errs, forbidden(entry.path, fmt.Sprintf("context entry type %s", entryType)))
+				}
+			}
+		}
+	}
+	return errs
+}
+
+func getRuleTypes(rule kyvernov1.Rule) []kyvernov2alpha1.ConstraintRuleType {
+	var ruleTypes []kyvernov2alpha1.ConstraintRuleType
+	if rule.HasMutate() {
+		if len(rule.Mutation.Targets) != 0 {
+			ruleTypes = append(ruleTypes, kyvernov2alpha1.ConstraintRuleMutateExisting)
+		} else {
+			ruleTypes = append(ruleTypes, kyvernov2alpha1.ConstraintRuleMutate)
+		}
+	}
+	if rule.HasValidate() {
+		ruleTypes = append(ruleTypes, kyvernov2alpha1.ConstraintRuleValidate)
+	}
+	if rule.HasGenerate() {
+		ruleTypes = append(ruleTypes, kyvernov2alpha1.ConstraintRuleGenerate)
+	}
+	if rule.HasVerifyImages() {
+		ruleTypes = append(ruleTypes, kyvernov2alpha1.ConstraintRuleVerifyImages)
+	}
+	return ruleTypes
+}
+
+func getContextEntryType(entry kyvernov1.ContextEntry) kyvernov2alpha1.ConstraintContextEntryType {
+	switch {
+	case entry.ConfigMap != nil:
+		return kyvernov2alpha1.ConstraintContextConfigMap
+	case entry.APICall != nil:
+		return kyvernov2alpha1.ConstraintContextAPICall
+	case entry.ImageRegistry != nil:
+		return kyvernov2alpha1.ConstraintContextImageRegistry
+	case entry.ImageDigest != nil:
+		return kyvernov2alpha1.ConstraintContextImageDigest
+	case entry.Variable != nil:
+		return kyvernov2alpha1.ConstraintContextVariable
+	case entry.ResourceUsage != nil:
+		return kyvernov2alpha1.ConstraintContextResourceUsage
+	}
+	return ""
+}
+
+type contextEntry struct {
+	kyvernov1.ContextEntry
+	path *field.Path
+}
+
+// getContextEntries returns the context entries of a rule, its mutate targets and its (nested) foreach declarations
+func getContextEntries(rule kyvernov1.Rule, path *field.Path) []contextEntry {
+	var entries []contextEntry
+	add := func(path *field.Path, context []kyvernov1.ContextEntry) {
+		for i, entry := range context {
+			entries = append(entries, contextEntry{ContextEntry: entry, path: path.Child("context").Index(i)})
+		}
+	}
+	add(path, rule.Context)
+	for i, target := range rule.Mutation.Targets {
+		add(path.Child("mutate", "targets").Index(i), target.Context)
+	}
+	var mutateForEach func(*field.Path, []kyvernov1.ForEachMutation)
+	mutateForEach = func(path *field.Path, foreach []kyvernov1.ForEachMutation) {
+		for i, fe := range foreach {
+			add(path.Index(i), fe.Context)
+			if nested, err := api.DeserializeJSONArray[kyvernov1.ForEachMutation](fe.ForEachMutation); err == nil {
+				mutateForEach(path.Index(i).Child("foreach"), nested)
+			}
+		}
+	}
+	mutateForEach(path.Child("mutate", "foreach"), rule.Mutation.ForEachMutation)
+	var validateForEach func(*field.Path, []kyvernov1.ForEachValidation)
+	validateForEach = func(path *field.Path, foreach []kyvernov1.ForEachValidation) {
+		for i, fe := range foreach {
+			add(path.Index(i), fe.Context)
+			if nested, err := api.DeserializeJSONArray[kyvernov1.ForEachValidation](fe.ForEachValidation); err == nil {
+				validateForEach(path.Index(i).Child("foreach"), nested)
+			}
+		}
+	}
+	validateForEach(path.Child("validate", "foreach"), rule.Validation.ForEachValidation)
+	return entries
+}
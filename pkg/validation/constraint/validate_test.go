@@ -0,0 +1,108 @@
+package constraint
+
+import (
+	"encoding/json"
+	"testing"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	"gotest.tools/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func Test_Validate(t *testing.T) {
+	allowValidate := &kyvernov2alpha1.ClusterPolicyConstraint{
+		ObjectMeta: metav1.ObjectMeta{Name: "allow-validate"},
+		Spec: kyvernov2alpha1.ClusterPolicyConstraintSpec{
+			RuleTypes:                []kyvernov2alpha1.ConstraintRuleType{kyvernov2alpha1.ConstraintRuleValidate},
+			ContextEntryTypes:        []kyvernov2alpha1.ConstraintContextEntryType{kyvernov2alpha1.ConstraintContextVariable},
+			ValidationFailureActions: []kyvernov2alpha1.ConstraintValidationFailureAction{kyvernov2alpha1.ConstraintAudit},
+		},
+	}
+	allowImageDigest := allowValidate.DeepCopy()
+	allowImageDigest.Spec.ContextEntryTypes = []kyvernov2alpha1.ConstraintContextEntryType{kyvernov2alpha1.ConstraintContextImageDigest}
+	tenantsOnly := allowValidate.DeepCopy()
+	tenantsOnly.Spec.NamespaceSelector = &metav1.LabelSelector{MatchLabels: map[string]string{"tenant": "true"}}
+	invalidSelector := allowValidate.DeepCopy()
+	invalidSelector.Spec.NamespaceSelector = &metav1.LabelSelector{
+		MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "tenant", Operator: "Invalid"}},
+	}
+	tc := []struct {
+		name        string
+		policy      string
+		labels      map[string]string
+		constraints []*kyvernov2alpha1.ClusterPolicyConstraint
+		wantErrs    int
+	}{{
+		name:        "cluster policies are not constrained",
+		policy:      `{"apiVersion":"kyverno.io/v1","kind":"ClusterPolicy","metadata":{"name":"test"},"spec":{"validationFailureAction":"Enforce","rules":[{"name":"generate","match":{"any":[{"resources":{"kinds":["Namespace"]}}]},"generate":{"kind":"ConfigMap","name":"test","namespace":"test","data":{"data":{"foo":"bar"}}}}]}}`,
+		constraints: []*kyvernov2alpha1.ClusterPolicyConstraint{allowValidate},
+	}, {
+		name:        "allowed validate rule",
+		policy:      `{"apiVersion":"kyverno.io/v1","kind":"Policy","metadata":{"name":"test","namespace":"test"},"spec":{"validationFailureAction":"Audit","rules":[{"name":"validate","context":[{"name":"foo","variable":{"value":"bar"}}],"match":{"any":[{"resources":{"kinds":["Pod"]}}]},"validate":{"pattern":{"metadata":{"labels":{"app":"?*"}}}}}]}}`,
+		constraints: []*kyvernov2alpha1.ClusterPolicyConstraint{allowValidate},
+	}, {
+		name:        "forbidden rule type and validation failure action",
+		policy:      `{"apiVersion":"kyverno.io/v1","kind":"Policy","metadata":{"name":"test","namespace":"test"},"spec":{"validationFailureAction":"Enforce","rules":[{"name":"validate","match":{"any":[{"resources":{"kinds":["Pod"]}}]},"validate":{"pattern":{"metadata":{"labels":{"app":"?*"}}}}},{"name":"mutate","match":{"any":[{"resources":{"kinds":["Pod"]}}]},"mutate":{"patchStrategicMerge":{"metadata":{"labels":{"app":"test"}}}}}]}}`,
+		constraints: []*kyvernov2alpha1.ClusterPolicyConstraint{allowValidate},
+		wantErrs:    2,
+	}, {
+		name:        "forbidden api call in nested foreach",
+		policy:      `{"apiVersion":"kyverno.io/v1","kind":"Policy","metadata":{"name":"test","namespace":"test"},"spec":{"validationFailureAction":"Audit","rules":[{"name":"validate","match":{"any":[{"resources":{"kinds":["Pod"]}}]},"validate":{"foreach":[{"list":"request.object.spec.containers","foreach":[{"list":"element.ports","context":[{"name":"services","apiCall":{"urlPath":"/api/v1/services"}}],"deny":{}}]}]}}]}}`,
+		constraints: []*kyvernov2alpha1.ClusterPolicyConstraint{allowValidate},
+		wantErrs:    1,
+	}, {
+		name:        "forbidden image digest",
+		policy:      `{"apiVersion":"kyverno.io/v1","kind":"Policy","metadata":{"name":"test","namespace":"test"},"spec":{"validationFailureAction":"Audit","rules":[{"name":"validate","context":[{"name":"digest","imageDigest":{"reference":"ghcr.io/kyverno/kyverno:latest"}}],"match":{"any":[{"resources":{"kinds":["Pod"]}}]},"validate":{"pattern":{"metadata":{"labels":{"app":"?*"}}}}}]}}`,
+		constraints: []*kyvernov2alpha1.ClusterPolicyConstraint{allowValidate},
+		wantErrs:    1,
+	}, {
+		name:        "allowed image digest",
+		policy:      `{"apiVersion":"kyverno.io/v1","kind":"Policy","metadata":{"name":"test","namespace":"test"},"spec":{"validationFailureAction":"Audit","rules":[{"name":"validate","context":[{"name":"digest","imageDigest":{"reference":"ghcr.io/kyverno/kyverno:latest"}}],"match":{"any":[{"resources":{"kinds":["Pod"]}}]},"validate":{"pattern":{"metadata":{"labels":{"app":"?*"}}}}}]}}`,
+		constraints: []*kyvernov2alpha1.ClusterPolicyConstraint{allowImageDigest},
+	}, {
+		name:        "context entry of unknown type",
+		policy:      `{"apiVersion":"kyverno.io/v1","kind":"Policy","metadata":{"name":"test","namespace":"test"},"spec":{"validationFailureAction":"Audit","rules":[{"name":"validate","context":[{"name":"foo"}],"match":{"any":[{"resources":{"kinds":["Pod"]}}]},"validate":{"pattern":{"metadata":{"labels":{"app":"?*"}}}}}]}}`,
+		constraints: []*kyvernov2alpha1.ClusterPolicyConstraint{allowValidate},
+		wantErrs:    1,
+	}, {
+		name:        "mutate existing rule",
+		policy:      `{"apiVersion":"kyverno.io/v1","kind":"Policy","metadata":{"name":"test","namespace":"test"},"spec":{"rules":[{"name":"mutate","match":{"any":[{"resources":{"kinds":["ConfigMap"]}}]},"mutate":{"targets":[{"apiVersion":"v1","kind":"Secret","name":"test","context":[{"name":"cm","configMap":{"name":"test","namespace":"test"}}]}],"patchStrategicMerge":{"metadata":{"labels":{"app":"test"}}}}}]}}`,
+		constraints: []*kyvernov2alpha1.ClusterPolicyConstraint{allowValidate},
+		wantErrs:    2,
+	}, {
+		name:        "namespace not selected",
+		policy:      `{"apiVersion":"kyverno.io/v1","kind":"Policy","metadata":{"name":"test","namespace":"test"},"spec":{"rules":[{"name":"mutate","match":{"any":[{"resources":{"kinds":["Pod"]}}]},"mutate":{"patchStrategicMerge":{"metadata":{"labels":{"app":"test"}}}}}]}}`,
+		labels:      map[string]string{"tenant": "false"},
+		constraints: []*kyvernov2alpha1.ClusterPolicyConstraint{tenantsOnly},
+	}, {
+		name:        "namespace selected",
+		policy:      `{"apiVersion":"kyverno.io/v1","kind":"Policy","metadata":{"name":"test","namespace":"test"},"spec":{"rules":[{"name":"mutate","match":{"any":[{"resources":{"kinds":["Pod"]}}]},"mutate":{"patchStrategicMerge":{"metadata":{"labels":{"app":"test"}}}}}]}}`,
+		labels:      map[string]string{"tenant": "true"},
+		constraints: []*kyvernov2alpha1.ClusterPolicyConstraint{tenantsOnly},
+		wantErrs:    1,
+	}, {
+		name:        "invalid namespace selector",
+		policy:      `{"apiVersion":"kyverno.io/v1","kind":"Policy","metadata":{"name":"test","namespace":"test"},"spec":{"validationFailureAction":"Audit","rules":[{"name":"validate","match":{"any":[{"resources":{"kinds":["Pod"]}}]},"validate":{"pattern":{"metadata":{"labels":{"app":"?*"}}}}}]}}`,
+		constraints: []*kyvernov2alpha1.ClusterPolicyConstraint{invalidSelector},
+		wantErrs:    1,
+	}}
+	for _, c := range tc {
+		t.Run(c.name, func(t *testing.T) {
+			var typeMeta metav1.TypeMeta
+			assert.NilError(t, json.Unmarshal([]byte(c.policy), &typeMeta))
+			var policy kyvernov1.PolicyInterface = &kyvernov1.Policy{}
+			if typeMeta.Kind == "ClusterPolicy" {
+				policy = &kyvernov1.ClusterPolicy{}
+			}
+			assert.NilError(t, json.Unmarshal([]byte(c.policy), policy))
+			err := Validate(policy, c.labels, c.constraints)
+			if c.wantErrs == 0 {
+				assert.NilError(t, err)
+			} else {
+				assert.Assert(t, err != nil)
+				assert.Equal(t, len(err.(interface{ Errors() []error }).Errors()), c.wantErrs)
+			}
+		})
+	}
+}
@@ -0,0 +1,59 @@
+package lint
+
+import (
+	"fmt"
+	"strings"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+func checkExpensiveContext(spec *kyvernov1.Spec, path *field.Path) []Warning {
+	var warnings []Warning
+	for i, rule := range spec.Rules {
+		rulePath := path.Child("rules").Index(i)
+		hasPreconditions := rule.RawAnyAllConditions != nil || len(rule.CELPreconditions) != 0
+		for j, entry := range rule.Context {
+			if entry.APICall == nil {
+				continue
+			}
+			entryPath := rulePath.Child("context").Index(j).Child("apiCall")
+			apiCall := entry.APICall
+			if apiCall.Service != nil {
+				if !hasPreconditions {
+					warnings = append(warnings, Warning{
+						Code:    ExpensiveServiceCall,
+						Rule:    rule.Name,
+						Path:    entryPath.Child("service").String(),
+						Message: fmt.Sprintf("context entry %s calls %s for every matching resource, consider adding preconditions to the rule", entry.Name, apiCall.Service.URL),
+					})
+				}
+				continue
+			}
+			if isClusterWideList(apiCall.URLPath) && (apiCall.List == nil || (apiCall.List.LabelSelector == "" && apiCall.List.FieldSelector == "")) {
+				warnings = append(warnings, Warning{
+					Code:    ExpensiveClusterWideList,
+					Rule:    rule.Name,
+					Path:    entryPath.Child("urlPath").String(),
+					Message: fmt.Sprintf("context entry %s lists %s across the cluster, consider restricting it to a namespace or using list selectors", entry.Name, apiCall.URLPath),
+				})
+			}
+		}
+	}
+	return warnings
+}
+
+// isClusterWideList returns true when a url path lists a resource collection without a namespace,
+// like /api/v1/pods or /apis/apps/v1/deployments
+func isClusterWideList(urlPath string) bool {
+	urlPath, _, _ = strings.Cut(urlPath, "?")
+	segments := strings.Split(strings.Trim(urlPath, "/"), "/")
+	switch {
+	case len(segments) == 3 && segments[0] == "api":
+		return segments[2] != "namespaces"
+	case len(segments) == 4 && segments[0] == "apis":
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,91 @@
+package lint
+
+import (
+	"fmt"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+func checkDeprecatedFields(spec *kyvernov1.Spec, path *field.Path) []Warning {
+	var warnings []Warning
+	if spec.ValidationFailureAction == "enforce" || spec.ValidationFailureAction == "audit" {
+		warnings = append(warnings, Warning{
+			Code:    DeprecatedValidationFailureAction,
+			Path:    path.Child("validationFailureAction").String(),
+			Message: "Validation failure actions enforce/audit are deprecated, use Enforce/Audit instead.",
+		})
+	}
+	for i, override := range spec.ValidationFailureActionOverrides {
+		if override.Action == "enforce" || override.Action == "audit" {
+			warnings = append(warnings, Warning{
+				Code:    DeprecatedValidationFailureAction,
+				Path:    path.Child("validationFailureActionOverrides").Index(i).Child("action").String(),
+				Message: "Validation failure actions enforce/audit are deprecated, use Enforce/Audit instead.",
+			})
+		}
+	}
+	for i, rule := range spec.Rules {
+		rulePath := path.Child("rules").Index(i)
+		warnings = append(warnings, checkDeprecatedResourceNames(rule.Name, rule.MatchResources, rulePath.Child("match"))...)
+		warnings = append(warnings, checkDeprecatedResourceNames(rule.Name, rule.ExcludeResources, rulePath.Child("exclude"))...)
+		for j, imageVerify := range rule.VerifyImages {
+			imageVerifyPath := rulePath.Child("verifyImages").Index(j)
+			deprecated := []struct {
+				name string
+				set  bool
+				use  string
+			}{
+				{"image", imageVerify.Image != "", "imageReferences"},
+				{"key", imageVerify.Key != "", "attestors with a keys attestor"},
+				{"roots", imageVerify.Roots != "", "attestors with a keyless attestor"},
+				{"subject", imageVerify.Subject != "", "attestors with a keyless attestor"},
+				{"issuer", imageVerify.Issuer != "", "attestors with a keyless attestor"},
+				{"additionalExtensions", len(imageVerify.AdditionalExtensions) != 0, "attestors with a keyless attestor"},
+			}
+			for _, deprecatedField := range deprecated {
+				if deprecatedField.set {
+					warnings = append(warnings, Warning{
+						Code:    DeprecatedImageVerificationField,
+						Rule:    rule.Name,
+						Path:    imageVerifyPath.Child(deprecatedField.name).String(),
+						Message: fmt.Sprintf("%s has been deprecated, use %s instead", deprecatedField.name, deprecatedField.use),
+					})
+				}
+			}
+			for k, attestation := range imageVerify.Attestations {
+				if attestation.PredicateType != "" {
+					warnings = append(warnings, Warning{
+						Code:    DeprecatedPredicateType,
+						Rule:    rule.Name,
+						Path:    imageVerifyPath.Child("attestations").Index(k).Child("predicateType").String(),
+						Message: fmt.Sprintf("predicateType has been deprecated use 'type: %s' instead of 'predicateType: %s'", attestation.PredicateType, attestation.PredicateType),
+					})
+				}
+			}
+		}
+	}
+	return warnings
+}
+
+func checkDeprecatedResourceNames(rule string, match kyvernov1.MatchResources, path *field.Path) []Warning {
+	var warnings []Warning
+	check := func(description kyvernov1.ResourceDescription, path *field.Path) {
+		if description.Name != "" {
+			warnings = append(warnings, Warning{
+				Code:    DeprecatedResourceName,
+				Rule:    rule,
+				Path:    path.Child("name").String(),
+				Message: "name has been deprecated, use names instead",
+			})
+		}
+	}
+	check(match.ResourceDescription, path.Child("resources"))
+	for i, filter := range match.Any {
+		check(filter.ResourceDescription, path.Child("any").Index(i).Child("resources"))
+	}
+	for i, filter := range match.All {
+		check(filter.ResourceDescription, path.Child("all").Index(i).Child("resources"))
+	}
+	return warnings
+}
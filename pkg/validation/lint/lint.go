@@ -0,0 +1,69 @@
+package lint
+
+import (
+	"fmt"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// Code identifies a lint check, codes are stable and can be used to filter warnings
+type Code string
+
+const (
+	// Deprecated fields
+	DeprecatedValidationFailureAction Code = "KL001"
+	DeprecatedResourceName            Code = "KL002"
+	DeprecatedImageVerificationField  Code = "KL003"
+	DeprecatedPredicateType           Code = "KL004"
+	// Unreachable rules
+	UnreachableExcludedRule Code = "KL101"
+	UnreachableShadowedRule Code = "KL102"
+	// Expensive context calls
+	ExpensiveClusterWideList Code = "KL201"
+	ExpensiveServiceCall     Code = "KL202"
+)
+
+// Warning is a finding of the linter, it doesn't prevent a policy from being admitted
+type Warning struct {
+	// Code identifies the check that produced the warning
+	Code Code `json:"code"`
+	// Rule is the name of the rule the warning applies to, it is empty for policy level warnings
+	Rule string `json:"rule,omitempty"`
+	// Path is the path of the offending field
+	Path string `json:"path"`
+	// Message describes the finding
+	Message string `json:"message"`
+}
+
+func (w Warning) String() string {
+	return fmt.Sprintf("[%s] %s: %s", w.Code, w.Path, w.Message)
+}
+
+type check func(*kyvernov1.Spec, *field.Path) []Warning
+
+var checks = []check{
+	checkDeprecatedFields,
+	checkUnreachableRules,
+	checkExpensiveContext,
+}
+
+// Lint runs all checks against a policy and returns the warnings ordered by check
+func Lint(policy kyvernov1.PolicyInterface) []Warning {
+	spec := policy.GetSpec()
+	path := field.NewPath("spec")
+	var warnings []Warning
+	for _, check := range checks {
+		warnings = append(warnings, check(spec, path)...)
+	}
+	return warnings
+}
+
+// Messages returns the string representations of the warnings
+func Messages(warnings ...Warning) []string {
+	var out []string
+	for _, warning := range warnings {
+		out = append(out, warning.String())
+	}
+	return out
+}
@@ -0,0 +1,256 @@
+package lint
+
+import (
+	"testing"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/utils/yaml"
+	"gotest.tools/assert"
+)
+
+func loadPolicy(t *testing.T, raw string) kyvernov1.PolicyInterface {
+	policies, _, err := yaml.GetPolicy([]byte(raw))
+	assert.NilError(t, err)
+	assert.Equal(t, len(policies), 1)
+	return policies[0]
+}
+
+func TestLint(t *testing.T) {
+	tests := []struct {
+		name     string
+		policy   string
+		expected []Warning
+	}{{
+		name: "no warnings",
+		policy: `
+apiVersion: kyverno.io/v1
+kind: ClusterPolicy
+metadata:
+  name: test
+spec:
+  validationFailureAction: Enforce
+  rules:
+  - name: check-team
+    match:
+      any:
+      - resources:
+          kinds:
+          - Pod
+    validate:
+      message: label team is required
+      pattern:
+        metadata:
+          labels:
+            team: "?*"
+`,
+	}, {
+		name: "deprecated fields",
+		policy: `
+apiVersion: kyverno.io/v1
+kind: ClusterPolicy
+metadata:
+  name: test
+spec:
+  validationFailureAction: enforce
+  rules:
+  - name: verify
+    match:
+      any:
+      - resources:
+          kinds:
+          - Pod
+          name: nginx
+    verifyImages:
+    - image: ghcr.io/*
+      key: KEY
+      attestations:
+      - predicateType: https://slsa.dev/provenance/v0.2
+`,
+		expected: []Warning{{
+			Code:    DeprecatedValidationFailureAction,
+			Path:    "spec.validationFailureAction",
+			Message: "Validation failure actions enforce/audit are deprecated, use Enforce/Audit instead.",
+		}, {
+			Code:    DeprecatedResourceName,
+			Rule:    "verify",
+			Path:    "spec.rules[0].match.any[0].resources.name",
+			Message: "name has been deprecated, use names instead",
+		}, {
+			Code:    DeprecatedImageVerificationField,
+			Rule:    "verify",
+			Path:    "spec.rules[0].verifyImages[0].image",
+			Message: "image has been deprecated, use imageReferences instead",
+		}, {
+			Code:    DeprecatedImageVerificationField,
+			Rule:    "verify",
+			Path:    "spec.rules[0].verifyImages[0].key",
+			Message: "key has been deprecated, use attestors with a keys attestor instead",
+		}, {
+			Code:    DeprecatedPredicateType,
+			Rule:    "verify",
+			Path:    "spec.rules[0].verifyImages[0].attestations[0].predicateType",
+			Message: "predicateType has been deprecated use 'type: https://slsa.dev/provenance/v0.2' instead of 'predicateType: https://slsa.dev/provenance/v0.2'",
+		}},
+	}, {
+		name: "excluded rule",
+		policy: `
+apiVersion: kyverno.io/v1
+kind: ClusterPolicy
+metadata:
+  name: test
+spec:
+  rules:
+  - name: excluded
+    match:
+      any:
+      - resources:
+          kinds:
+          - Pod
+          - Deployment
+    exclude:
+      any:
+      - resources:
+          kinds:
+          - "*"
+    validate:
+      message: label team is required
+      pattern:
+        metadata:
+          labels:
+            team: "?*"
+`,
+		expected: []Warning{{
+			Code:    UnreachableExcludedRule,
+			Rule:    "excluded",
+			Path:    "spec.rules[0].exclude",
+			Message: "the rule is never applied, all matched kinds [Pod Deployment] are excluded",
+		}},
+	}, {
+		name: "shadowed rule",
+		policy: `
+apiVersion: kyverno.io/v1
+kind: ClusterPolicy
+metadata:
+  name: test
+spec:
+  applyRules: One
+  rules:
+  - name: first
+    match:
+      any:
+      - resources:
+          kinds:
+          - Pod
+    validate:
+      message: label team is required
+      pattern:
+        metadata:
+          labels:
+            team: "?*"
+  - name: second
+    match:
+      any:
+      - resources:
+          kinds:
+          - Pod
+          namespaces:
+          - default
+    validate:
+      message: label app is required
+      pattern:
+        metadata:
+          labels:
+            app: "?*"
+`,
+		expected: []Warning{{
+			Code:    UnreachableShadowedRule,
+			Rule:    "second",
+			Path:    "spec.rules[1].match",
+			Message: "the rule is never applied, rule first applies to the same kinds first and applyRules is One",
+		}},
+	}, {
+		name: "expensive context",
+		policy: `
+apiVersion: kyverno.io/v1
+kind: ClusterPolicy
+metadata:
+  name: test
+spec:
+  rules:
+  - name: context
+    match:
+      any:
+      - resources:
+          kinds:
+          - Pod
+    context:
+    - name: pods
+      apiCall:
+        urlPath: /api/v1/pods
+    - name: namespacedPods
+      apiCall:
+        urlPath: /api/v1/namespaces/{{ request.namespace }}/pods
+    - name: selectedPods
+      apiCall:
+        urlPath: /api/v1/pods
+        list:
+          labelSelector: app=nginx
+    - name: deployments
+      apiCall:
+        urlPath: /apis/apps/v1/deployments
+    - name: service
+      apiCall:
+        service:
+          url: https://example.com
+    validate:
+      message: label team is required
+      pattern:
+        metadata:
+          labels:
+            team: "?*"
+`,
+		expected: []Warning{{
+			Code:    ExpensiveClusterWideList,
+			Rule:    "context",
+			Path:    "spec.rules[0].context[0].apiCall.urlPath",
+			Message: "context entry pods lists /api/v1/pods across the cluster, consider restricting it to a namespace or using list selectors",
+		}, {
+			Code:    ExpensiveClusterWideList,
+			Rule:    "context",
+			Path:    "spec.rules[0].context[3].apiCall.urlPath",
+			Message: "context entry deployments lists /apis/apps/v1/deployments across the cluster, consider restricting it to a namespace or using list selectors",
+		}, {
+			Code:    ExpensiveServiceCall,
+			Rule:    "context",
+			Path:    "spec.rules[0].context[4].apiCall.service",
+			Message: "context entry service calls https://example.com for every matching resource, consider adding preconditions to the rule",
+		}},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			warnings := Lint(loadPolicy(t, tt.policy))
+			assert.DeepEqual(t, warnings, tt.expected)
+		})
+	}
+}
+
+func TestWarning_String(t *testing.T) {
+	warning := Warning{
+		Code:    UnreachableExcludedRule,
+		Rule:    "test",
+		Path:    "spec.rules[0].exclude",
+		Message: "the rule is never applied",
+	}
+	assert.Equal(t, warning.String(), "[KL101] spec.rules[0].exclude: the rule is never applied")
+	assert.DeepEqual(t, Messages(warning), []string{"[KL101] spec.rules[0].exclude: the rule is never applied"})
+}
+
+func Test_isClusterWideList(t *testing.T) {
+	assert.Equal(t, isClusterWideList("/api/v1/pods"), true)
+	assert.Equal(t, isClusterWideList("/api/v1/pods?limit=10"), true)
+	assert.Equal(t, isClusterWideList("/apis/apps/v1/deployments"), true)
+	assert.Equal(t, isClusterWideList("/api/v1/namespaces"), false)
+	assert.Equal(t, isClusterWideList("/api/v1/namespaces/default/pods"), false)
+	assert.Equal(t, isClusterWideList("/apis/apps/v1/namespaces/default/deployments"), false)
+	assert.Equal(t, isClusterWideList("/api/v1/nodes/node-1"), false)
+}
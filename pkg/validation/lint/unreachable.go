@@ -0,0 +1,125 @@
+package lint
+
+import (
+	"fmt"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/utils/wildcard"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+func checkUnreachableRules(spec *kyvernov1.Spec, path *field.Path) []Warning {
+	var warnings []Warning
+	for i, rule := range spec.Rules {
+		rulePath := path.Child("rules").Index(i)
+		kinds := matchedKinds(rule.MatchResources)
+		if len(kinds) == 0 {
+			continue
+		}
+		if excluded := unconditionalKinds(rule.ExcludeResources); covers(excluded, kinds) {
+			warnings = append(warnings, Warning{
+				Code:    UnreachableExcludedRule,
+				Rule:    rule.Name,
+				Path:    rulePath.Child("exclude").String(),
+				Message: fmt.Sprintf("the rule is never applied, all matched kinds %v are excluded", kinds),
+			})
+			continue
+		}
+		// with applyRules set to One, processing stops after the first applied rule
+		if spec.GetApplyRules() != kyvernov1.ApplyOne {
+			continue
+		}
+		for _, previous := range spec.Rules[:i] {
+			if !sameRuleType(previous, rule) || !appliesUnconditionally(previous) {
+				continue
+			}
+			if covers(unconditionalKinds(previous.MatchResources), kinds) {
+				warnings = append(warnings, Warning{
+					Code:    UnreachableShadowedRule,
+					Rule:    rule.Name,
+					Path:    rulePath.Child("match").String(),
+					Message: fmt.Sprintf("the rule is never applied, rule %s applies to the same kinds first and applyRules is One", previous.Name),
+				})
+				break
+			}
+		}
+	}
+	return warnings
+}
+
+// matchedKinds returns all the kinds a match block can select, it returns nil when a filter doesn't declare kinds
+func matchedKinds(match kyvernov1.MatchResources) []string {
+	var kinds []string
+	if !match.ResourceDescription.IsEmpty() {
+		if len(match.Kinds) == 0 {
+			return nil
+		}
+		kinds = append(kinds, match.Kinds...)
+	}
+	for _, filters := range []kyvernov1.ResourceFilters{match.Any, match.All} {
+		for _, filter := range filters {
+			if len(filter.Kinds) == 0 {
+				return nil
+			}
+			kinds = append(kinds, filter.Kinds...)
+		}
+	}
+	return kinds
+}
+
+// unconditionalKinds returns the kinds a match block selects regardless of any other criteria
+func unconditionalKinds(match kyvernov1.MatchResources) []string {
+	var kinds []string
+	if onlyKinds(kyvernov1.ResourceFilter{UserInfo: match.UserInfo, ResourceDescription: match.ResourceDescription}) {
+		kinds = append(kinds, match.Kinds...)
+	}
+	for _, filter := range match.Any {
+		if onlyKinds(filter) {
+			kinds = append(kinds, filter.Kinds...)
+		}
+	}
+	// all filters must match, a single filter behaves like any
+	if len(match.All) == 1 && onlyKinds(match.All[0]) {
+		kinds = append(kinds, match.All[0].Kinds...)
+	}
+	return kinds
+}
+
+func onlyKinds(filter kyvernov1.ResourceFilter) bool {
+	description := filter.ResourceDescription
+	description.Kinds = nil
+	return len(filter.Kinds) != 0 && description.IsEmpty() && len(description.Operations) == 0 && filter.UserInfo.IsEmpty()
+}
+
+func covers(patterns []string, kinds []string) bool {
+	if len(patterns) == 0 {
+		return false
+	}
+	for _, kind := range kinds {
+		found := false
+		for _, pattern := range patterns {
+			if wildcard.Match(pattern, kind) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+func appliesUnconditionally(rule kyvernov1.Rule) bool {
+	return rule.RawAnyAllConditions == nil &&
+		len(rule.CELPreconditions) == 0 &&
+		rule.ExcludeResources.ResourceDescription.IsEmpty() &&
+		rule.ExcludeResources.UserInfo.IsEmpty() &&
+		len(rule.ExcludeResources.Any) == 0 &&
+		len(rule.ExcludeResources.All) == 0 &&
+		len(rule.MatchResources.All) == 0
+}
+
+func sameRuleType(a, b kyvernov1.Rule) bool {
+	return (a.HasValidate() && b.HasValidate()) || (a.HasMutate() && b.HasMutate())
+}
@@ -14,6 +14,7 @@ var forbidden = []*regexp.Regexp{
 	regexp.MustCompile(`[^\.](request.userInfo)\b`),
 	regexp.MustCompile(`[^\.](request.roles)\b`),
 	regexp.MustCompile(`[^\.](request.clusterRoles)\b`),
+	regexp.MustCompile(`[^\.](request.userAttributes)\b`),
 }
 
 // containsUserVariables returns error if variable that does not start from request.object
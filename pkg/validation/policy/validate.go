@@ -23,12 +23,15 @@ import (
 	enginecontext "github.com/kyverno/kyverno/pkg/engine/context"
 	"github.com/kyverno/kyverno/pkg/engine/variables"
 	"github.com/kyverno/kyverno/pkg/engine/variables/regex"
+	"github.com/kyverno/kyverno/pkg/jsonpayload"
 	"github.com/kyverno/kyverno/pkg/logging"
 	"github.com/kyverno/kyverno/pkg/openapi"
 	apiutils "github.com/kyverno/kyverno/pkg/utils/api"
 	datautils "github.com/kyverno/kyverno/pkg/utils/data"
 	kubeutils "github.com/kyverno/kyverno/pkg/utils/kube"
+	policyutils "github.com/kyverno/kyverno/pkg/utils/policy"
 	"github.com/kyverno/kyverno/pkg/utils/wildcard"
+	"github.com/kyverno/kyverno/pkg/validation/lint"
 	"golang.org/x/exp/slices"
 	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -39,10 +42,10 @@ import (
 )
 
 var (
-	allowedVariables                   = regexp.MustCompile(`request\.|serviceAccountName|serviceAccountNamespace|element|elementIndex|@|images|images\.|image\.|([a-z_0-9]+\()[^{}]`)
-	allowedVariablesBackground         = regexp.MustCompile(`request\.|element|elementIndex|@|images|images\.|image\.|([a-z_0-9]+\()[^{}]`)
-	allowedVariablesInTarget           = regexp.MustCompile(`request\.|serviceAccountName|serviceAccountNamespace|element|elementIndex|@|images|images\.|image\.|target\.|([a-z_0-9]+\()[^{}]`)
-	allowedVariablesBackgroundInTarget = regexp.MustCompile(`request\.|element|elementIndex|@|images|images\.|image\.|target\.|([a-z_0-9]+\()[^{}]`)
+	allowedVariables                   = regexp.MustCompile(`request\.|serviceAccountName|serviceAccountNamespace|element|elementIndex|@|images|images\.|image\.|platformDigests|([a-z_0-9]+\()[^{}]`)
+	allowedVariablesBackground         = regexp.MustCompile(`request\.|element|elementIndex|@|images|images\.|image\.|platformDigests|([a-z_0-9]+\()[^{}]`)
+	allowedVariablesInTarget           = regexp.MustCompile(`request\.|serviceAccountName|serviceAccountNamespace|element|elementIndex|@|images|images\.|image\.|platformDigests|target\.|([a-z_0-9]+\()[^{}]`)
+	allowedVariablesBackgroundInTarget = regexp.MustCompile(`request\.|element|elementIndex|@|images|images\.|image\.|platformDigests|target\.|([a-z_0-9]+\()[^{}]`)
 	// wildCardAllowedVariables represents regex for the allowed fields in wildcards
 	wildCardAllowedVariables = regexp.MustCompile(`\{\{\s*(request\.|serviceAccountName|serviceAccountNamespace)[^{}]*\}\}`)
 	errOperationForbidden    = errors.New("variables are forbidden in the path of a JSONPatch")
@@ -106,19 +109,6 @@ func validateJSONPatch(patch string, ruleIdx int) error {
 	return nil
 }
 
-func checkValidationFailureAction(spec *kyvernov1.Spec) []string {
-	msg := "Validation failure actions enforce/audit are deprecated, use Enforce/Audit instead."
-	if spec.ValidationFailureAction == "enforce" || spec.ValidationFailureAction == "audit" {
-		return []string{msg}
-	}
-	for _, override := range spec.ValidationFailureActionOverrides {
-		if override.Action == "enforce" || override.Action == "audit" {
-			return []string{msg}
-		}
-	}
-	return nil
-}
-
 // Validate checks the policy and rules declarations for required configurations
 func Validate(policy, oldPolicy kyvernov1.PolicyInterface, client dclient.Interface, mock bool, openApiManager openapi.Manager, username string) ([]string, error) {
 	var warnings []string
@@ -129,7 +119,8 @@ func Validate(policy, oldPolicy kyvernov1.PolicyInterface, client dclient.Interf
 		openapicontroller.NewController(client, openApiManager).CheckSync(context.TODO())
 	}
 
-	warnings = append(warnings, checkValidationFailureAction(spec)...)
+	warnings = append(warnings, lint.Messages(lint.Lint(policy)...)...)
+	warnings = append(warnings, checkUnresolvableVariables(policy)...)
 	var errs field.ErrorList
 	specPath := field.NewPath("spec")
 
@@ -174,6 +165,16 @@ func Validate(policy, oldPolicy kyvernov1.PolicyInterface, client dclient.Interf
 		return warnings, errs.ToAggregate()
 	}
 
+	if _, err := autogen.GetCustomControllers(policy.GetAnnotations()); err != nil {
+		return warnings, fmt.Errorf("invalid annotation %s: %w", kyverno.AnnotationAutogenCustomControllers, err)
+	}
+	if _, err := policyutils.GetWebhookAnnotations(policy); err != nil {
+		return warnings, fmt.Errorf("invalid annotation %s: %w", kyverno.AnnotationWebhookAnnotations, err)
+	}
+	if _, err := policyutils.GetWebhookLabels(policy); err != nil {
+		return warnings, fmt.Errorf("invalid annotation %s: %w", kyverno.AnnotationWebhookLabels, err)
+	}
+
 	if !policy.IsNamespaced() {
 		err := validateNamespaces(spec, specPath.Child("validationFailureActionOverrides"))
 		if err != nil {
@@ -359,10 +360,6 @@ func Validate(policy, oldPolicy kyvernov1.PolicyInterface, client dclient.Interf
 			checkForScaleSubresource(mutationJson, allKinds, &warnings)
 			checkForStatusSubresource(mutationJson, allKinds, &warnings)
 		}
-
-		if rule.HasVerifyImages() {
-			checkForDeprecatedFieldsInVerifyImages(rule, &warnings)
-		}
 	}
 	if !mock && (spec.SchemaValidation == nil || *spec.SchemaValidation) {
 		if err := openApiManager.ValidatePolicyMutation(policy); err != nil {
@@ -411,6 +408,7 @@ func hasInvalidVariables(policy kyvernov1.PolicyInterface, background bool) erro
 			for i := range ruleCopy.Mutation.Targets {
 				withTargetOnly.Mutation.Targets[i].ResourceSpec = ruleCopy.Mutation.Targets[i].ResourceSpec
 				ctx := buildContext(withTargetOnly, background, false)
+				addParamsVariables(policy.GetSpec(), ctx)
 				if _, err := variables.SubstituteAllInRule(logging.GlobalLogger(), ctx, *withTargetOnly); !variables.CheckNotFoundErr(err) {
 					return fmt.Errorf("invalid variables defined at mutate.targets[%d]: %s", i, err.Error())
 				}
@@ -418,6 +416,7 @@ func hasInvalidVariables(policy kyvernov1.PolicyInterface, background bool) erro
 		}
 
 		ctx := buildContext(ruleCopy, background, mutateTarget)
+		addParamsVariables(policy.GetSpec(), ctx)
 		if _, err := variables.SubstituteAllInRule(logging.GlobalLogger(), ctx, *ruleCopy); !variables.CheckNotFoundErr(err) {
 			return fmt.Errorf("variable substitution failed for rule %s: %s", ruleCopy.Name, err.Error())
 		}
@@ -480,7 +479,7 @@ func hasVariables(policy kyvernov1.PolicyInterface) ([][]string, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to serialize the policy: %v", err)
 	}
-	matches := regex.RegexVariables.FindAllStringSubmatch(string(policyRaw), -1)
+	matches := regex.RegexVariables.FindAllStringSubmatch(regex.RemoveLiterals(string(policyRaw)), -1)
 	return matches, nil
 }
 
@@ -573,6 +572,8 @@ func ruleWithoutPattern(ruleCopy *kyvernov1.Rule) *kyvernov1.Rule {
 func buildContext(rule *kyvernov1.Rule, background bool, target bool) *enginecontext.MockContext {
 	re := getAllowedVariables(background, target)
 	ctx := enginecontext.NewMockContext(re)
+	// global values are available to all rules
+	ctx.AddVariable("global*")
 	addContextVariables(rule.Context, ctx)
 	for _, fe := range rule.Validation.ForEachValidation {
 		addContextVariables(fe.Context, ctx)
@@ -602,7 +603,7 @@ func getAllowedVariables(background bool, target bool) *regexp.Regexp {
 
 func addContextVariables(entries []kyvernov1.ContextEntry, ctx *enginecontext.MockContext) {
 	for _, contextEntry := range entries {
-		if contextEntry.APICall != nil || contextEntry.ImageRegistry != nil || contextEntry.Variable != nil {
+		if contextEntry.APICall != nil || contextEntry.ImageRegistry != nil || contextEntry.Variable != nil || contextEntry.ResourceUsage != nil || contextEntry.ImageDigest != nil {
 			ctx.AddVariable(contextEntry.Name + "*")
 		}
 
@@ -615,6 +616,13 @@ func addContextVariables(entries []kyvernov1.ContextEntry, ctx *enginecontext.Mo
 	}
 }
 
+// addParamsVariables allows the policy parameters variable when the policy is parameterized
+func addParamsVariables(spec *kyvernov1.Spec, ctx *enginecontext.MockContext) {
+	if spec.HasParams() {
+		ctx.AddVariable("params*")
+	}
+}
+
 func validateElementInForEach(document apiextensions.JSON) error {
 	jsonByte, err := json.Marshal(document)
 	if err != nil {
@@ -955,29 +963,36 @@ func validateRuleContext(rule kyvernov1.Rule) error {
 		if entry.Name == "" {
 			return fmt.Errorf("a name is required for context entries")
 		}
-		for _, v := range []string{"images", "request", "serviceAccountName", "serviceAccountNamespace", "element", "elementIndex"} {
+		for _, v := range []string{"images", "platformDigests", "request", "serviceAccountName", "serviceAccountNamespace", "element", "elementIndex"} {
 			if entry.Name == v || strings.HasPrefix(entry.Name, v+".") {
 				return fmt.Errorf("entry name %s is invalid as it conflicts with a pre-defined variable %s", entry.Name, v)
 			}
 		}
 
 		var err error
-		if entry.ConfigMap != nil && entry.APICall == nil && entry.ImageRegistry == nil && entry.Variable == nil {
+		if entry.ConfigMap != nil && entry.APICall == nil && entry.ImageRegistry == nil && entry.Variable == nil && entry.ResourceUsage == nil && entry.ImageDigest == nil {
 			err = validateConfigMap(entry)
-		} else if entry.ConfigMap == nil && entry.APICall != nil && entry.ImageRegistry == nil && entry.Variable == nil {
+		} else if entry.ConfigMap == nil && entry.APICall != nil && entry.ImageRegistry == nil && entry.Variable == nil && entry.ResourceUsage == nil && entry.ImageDigest == nil {
 			err = validateAPICall(entry)
-		} else if entry.ConfigMap == nil && entry.APICall == nil && entry.ImageRegistry != nil && entry.Variable == nil {
+		} else if entry.ConfigMap == nil && entry.APICall == nil && entry.ImageRegistry != nil && entry.Variable == nil && entry.ResourceUsage == nil && entry.ImageDigest == nil {
 			err = validateImageRegistry(entry)
-		} else if entry.ConfigMap == nil && entry.APICall == nil && entry.ImageRegistry == nil && entry.Variable != nil {
+		} else if entry.ConfigMap == nil && entry.APICall == nil && entry.ImageRegistry == nil && entry.Variable != nil && entry.ResourceUsage == nil && entry.ImageDigest == nil {
 			err = validateVariable(entry)
+		} else if entry.ConfigMap == nil && entry.APICall == nil && entry.ImageRegistry == nil && entry.Variable == nil && entry.ResourceUsage != nil && entry.ImageDigest == nil {
+			err = validateResourceUsage(entry)
+		} else if entry.ConfigMap == nil && entry.APICall == nil && entry.ImageRegistry == nil && entry.Variable == nil && entry.ResourceUsage == nil && entry.ImageDigest != nil {
+			err = validateImageDigest(entry)
 		} else {
-			return fmt.Errorf("exactly one of configMap or apiCall or imageRegistry or variable is required for context entries")
+			return fmt.Errorf("exactly one of configMap or apiCall or imageRegistry or variable or resourceUsage or imageDigest is required for context entries")
 		}
 
 		if err != nil {
 			return err
 		}
 	}
+	if _, err := enginecontext.SortEntries(rule.Context); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -1073,6 +1088,41 @@ func validateAPICall(entry kyvernov1.ContextEntry) error {
 		}
 	}
 
+	if list := entry.APICall.List; list != nil {
+		if entry.APICall.URLPath == "" {
+			return fmt.Errorf("a URLPath is required for list API calls")
+		}
+		if entry.APICall.Method != "" && entry.APICall.Method != "GET" {
+			return fmt.Errorf("list API calls only support the GET method")
+		}
+		if list.PageSize != nil && *list.PageSize <= 0 {
+			return fmt.Errorf("list pageSize must be greater than zero")
+		}
+		if list.MaxItems != nil && *list.MaxItems <= 0 {
+			return fmt.Errorf("list maxItems must be greater than zero")
+		}
+		projection := variables.ReplaceAllVars(list.Projection, func(s string) string { return "kyvernojmespathvariable" })
+		if !strings.Contains(projection, "kyvernojmespathvariable") && list.Projection != "" {
+			if _, err := jmespath.NewParser().Parse(list.Projection); err != nil {
+				return fmt.Errorf("failed to parse list projection %s: %v", list.Projection, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func validateResourceUsage(entry kyvernov1.ContextEntry) error {
+	if entry.ResourceUsage.Namespace == "" {
+		return fmt.Errorf("a namespace is required for resourceUsage context entry")
+	}
+	return nil
+}
+
+func validateImageDigest(entry kyvernov1.ContextEntry) error {
+	if entry.ImageDigest.Reference == "" {
+		return fmt.Errorf("a reference is required for imageDigest context entry")
+	}
 	return nil
 }
 
@@ -1207,6 +1257,21 @@ func validateWildcard(kinds []string, background bool, rule kyvernov1.Rule) erro
 	return nil
 }
 
+// validPayloadKind verifies a kind matching JSON payloads, payloads are only validated on request
+// so they can't be mutated, generated or scanned in background
+func validPayloadKind(k, version, kind, subresource string, backgroundScanningEnabled, isValidationPolicy bool) error {
+	if version != jsonpayload.Version || kind == "*" || subresource != "" {
+		return fmt.Errorf("invalid JSON payload kind %s, expected %s/<kind>", k, jsonpayload.GroupVersion)
+	}
+	if !isValidationPolicy {
+		return fmt.Errorf("only validate rules can match the JSON payload kind %s", k)
+	}
+	if backgroundScanningEnabled {
+		return fmt.Errorf("background scan is not supported for the JSON payload kind %s, set spec.background to false", k)
+	}
+	return nil
+}
+
 // validKinds verifies if an API resource that matches 'kind' is valid kind
 // and found in the cache, returns error if not found. It also returns an error if background scanning
 // is enabled for a subresource.
@@ -1214,6 +1279,12 @@ func validKinds(kinds []string, mock, backgroundScanningEnabled, isValidationPol
 	if !mock {
 		for _, k := range kinds {
 			group, version, kind, subresource := kubeutils.ParseKindSelector(k)
+			if jsonpayload.IsPayloadGroup(group) {
+				if err := validPayloadKind(k, version, kind, subresource, backgroundScanningEnabled, isValidationPolicy); err != nil {
+					return err
+				}
+				continue
+			}
 			gvrss, err := client.Discovery().FindResources(group, version, kind, subresource)
 			if err != nil {
 				return fmt.Errorf("unable to convert GVK to GVR for kinds %s, err: %s", k, err)
@@ -1319,14 +1390,3 @@ func checkForStatusSubresource(ruleTypeJson []byte, allKinds []string, warnings
 		*warnings = append(*warnings, msg)
 	}
 }
-
-func checkForDeprecatedFieldsInVerifyImages(rule kyvernov1.Rule, warnings *[]string) {
-	for _, imageVerify := range rule.VerifyImages {
-		for _, attestation := range imageVerify.Attestations {
-			if attestation.PredicateType != "" {
-				msg := fmt.Sprintf("predicateType has been deprecated use 'type: %s' instead of 'predicateType: %s'", attestation.PredicateType, attestation.PredicateType)
-				*warnings = append(*warnings, msg)
-			}
-		}
-	}
-}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/go-logr/logr"
@@ -14,6 +15,7 @@ import (
 	apiextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/utils/ptr"
 )
 
 func Test_Validate_ResourceDescription_Empty(t *testing.T) {
@@ -1057,6 +1059,51 @@ func Test_Validate_ApiCall(t *testing.T) {
 			},
 			expectedResult: nil,
 		},
+		{
+			resource: kyverno.ContextEntry{
+				APICall: &kyverno.APICall{
+					URLPath: "/api/v1/pods",
+					List: &kyverno.APICallList{
+						FieldSelector: "spec.nodeName={{request.object.spec.nodeName}}",
+						PageSize:      ptr.To[int64](100),
+						Projection:    "metadata.name",
+					},
+				},
+			},
+			expectedResult: nil,
+		},
+		{
+			resource: kyverno.ContextEntry{
+				APICall: &kyverno.APICall{
+					URLPath: "/api/v1/pods",
+					Method:  "POST",
+					List:    &kyverno.APICallList{},
+				},
+			},
+			expectedResult: "list API calls only support the GET method",
+		},
+		{
+			resource: kyverno.ContextEntry{
+				APICall: &kyverno.APICall{
+					URLPath: "/api/v1/pods",
+					List: &kyverno.APICallList{
+						MaxItems: ptr.To[int64](0),
+					},
+				},
+			},
+			expectedResult: "list maxItems must be greater than zero",
+		},
+		{
+			resource: kyverno.ContextEntry{
+				APICall: &kyverno.APICall{
+					URLPath: "/api/v1/pods",
+					List: &kyverno.APICallList{
+						Projection: "metadata.[",
+					},
+				},
+			},
+			expectedResult: "failed to parse list projection metadata.[: SyntaxError: Incomplete expression",
+		},
 	}
 
 	for _, testCase := range testCases {
@@ -3295,3 +3342,141 @@ func Test_ImmutableGenerateFields(t *testing.T) {
 		assert.Assert(t, (err != nil) == test.expectedErr, test.name, err)
 	}
 }
+
+func Test_checkUnresolvableVariables(t *testing.T) {
+	rawPolicy := []byte(`{
+		"apiVersion": "kyverno.io/v1",
+		"kind": "ClusterPolicy",
+		"metadata": {"name": "variables"},
+		"spec": {
+			"rules": [{
+				"name": "generate-cm",
+				"match": {"any": [{"resources": {"kinds": ["Namespace"]}}]},
+				"context": [{"name": "cm", "configMap": {"name": "defaults", "namespace": "kyverno"}}],
+				"generate": {
+					"apiVersion": "v1",
+					"kind": "ConfigMap",
+					"name": "{{ request.object.metadata.name }}-{{ cm.data.suffix }}",
+					"namespace": "{{ request.object.metadata.name }}",
+					"data": {
+						"data": {
+							"values": "{{#literal}}{{ .Values.name }}{{/literal}}",
+							"owner": "{{ to_upper(owner.name) }}",
+							"team": "{{ length(request.object.metadata.labels) }} {{ teams[0] }}",
+							"domain": "{{ global.domain }}"
+						}
+					}
+				}
+			}]
+		}
+	}`)
+	var policy *kyverno.ClusterPolicy
+	err := json.Unmarshal(rawPolicy, &policy)
+	assert.NilError(t, err)
+
+	warnings := checkUnresolvableVariables(policy)
+	assert.DeepEqual(t, warnings, []string{
+		"rule generate-cm: variable {{ teams[0] }} references teams which is not a built-in variable or a context entry, it will not be resolvable",
+		"rule generate-cm: variable {{ to_upper(owner.name) }} references owner which is not a built-in variable or a context entry, it will not be resolvable",
+	})
+}
+
+func Test_Validate_GlobalValues(t *testing.T) {
+	rawPolicy := []byte(`{
+		"apiVersion": "kyverno.io/v1",
+		"kind": "ClusterPolicy",
+		"metadata": {"name": "restrict-registries"},
+		"spec": {
+			"background": true,
+			"rules": [{
+				"name": "approved-registries",
+				"match": {"any": [{"resources": {"kinds": ["Pod"]}}]},
+				"validate": {
+					"message": "images must come from {{ global.registries }}",
+					"deny": {
+						"conditions": {
+							"any": [{
+								"key": "{{ images.containers.*.registry }}",
+								"operator": "AnyNotIn",
+								"value": "{{ global.registries }}"
+							}]
+						}
+					}
+				}
+			}]
+		}
+	}`)
+	openApiManager, _ := openapi.NewManager(logr.Discard())
+	var policy *kyverno.ClusterPolicy
+	err := json.Unmarshal(rawPolicy, &policy)
+	assert.NilError(t, err)
+
+	warnings, err := Validate(policy, nil, nil, true, openApiManager, "admin")
+	assert.NilError(t, err)
+	for _, warning := range warnings {
+		assert.Assert(t, !strings.Contains(warning, "global"), warning)
+	}
+}
+
+func Test_validKinds_JSONPayload(t *testing.T) {
+	tests := []struct {
+		name       string
+		kind       string
+		background bool
+		validate   bool
+		wantErr    string
+	}{{
+		name:     "valid",
+		kind:     "json.kyverno.io/v1alpha1/TerraformPlan",
+		validate: true,
+	}, {
+		name:     "any version",
+		kind:     "json.kyverno.io/*/TerraformPlan",
+		validate: true,
+		wantErr:  "invalid JSON payload kind",
+	}, {
+		name:     "subresource",
+		kind:     "json.kyverno.io/v1alpha1/TerraformPlan/status",
+		validate: true,
+		wantErr:  "invalid JSON payload kind",
+	}, {
+		name:    "not a validate rule",
+		kind:    "json.kyverno.io/v1alpha1/TerraformPlan",
+		wantErr: "only validate rules",
+	}, {
+		name:       "background",
+		kind:       "json.kyverno.io/v1alpha1/TerraformPlan",
+		background: true,
+		validate:   true,
+		wantErr:    "background scan is not supported",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// payload kinds are never looked up in discovery
+			err := validKinds([]string{tt.kind}, false, tt.background, tt.validate, nil)
+			if tt.wantErr == "" {
+				assert.NilError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_validateRuleContext_CircularReference(t *testing.T) {
+	rule := kyverno.Rule{
+		Name: "test",
+		Context: []kyverno.ContextEntry{{
+			Name:     "first",
+			Variable: &kyverno.Variable{JMESPath: "second"},
+		}, {
+			Name:     "second",
+			Variable: &kyverno.Variable{JMESPath: "to_upper(first)"},
+		}},
+	}
+	err := validateRuleContext(rule)
+	assert.Error(t, err, "circular reference between context entries: first -> second -> first")
+
+	rule.Context[1].Variable.JMESPath = "request.object.metadata.name"
+	assert.NilError(t, validateRuleContext(rule))
+}
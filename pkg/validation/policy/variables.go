@@ -0,0 +1,139 @@
+package policy
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	gojmespath "github.com/kyverno/go-jmespath"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/engine/variables/regex"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+var builtinVariables = regexp.MustCompile(`^(request|element[0-9]*|elementIndex[0-9]*|images|image|serviceAccountName|serviceAccountNamespace|target|global)$`)
+
+// checkUnresolvableVariables returns warnings for the variables referencing a root that is neither
+// a built-in variable nor a context entry declared in the rule, such variables can't be resolved at runtime
+func checkUnresolvableVariables(policy kyvernov1.PolicyInterface) []string {
+	spec := policy.GetSpec()
+	var warnings []string
+	for _, rule := range spec.Rules {
+		ruleCopy := rule.DeepCopy()
+		// attestation conditions are evaluated against the attestation payload
+		for i, vi := range ruleCopy.VerifyImages {
+			for j := range vi.Attestations {
+				ruleCopy.VerifyImages[i].Attestations[j].Conditions = nil
+			}
+		}
+		data, err := json.Marshal(ruleCopy)
+		if err != nil {
+			continue
+		}
+		var document interface{}
+		if err := json.Unmarshal(data, &document); err != nil {
+			continue
+		}
+		known := sets.New[string]()
+		if spec.HasParams() {
+			known.Insert("params")
+		}
+		collectContextEntries(document, known)
+		unresolvable := map[string]string{}
+		walkStrings(document, func(value string) {
+			for _, match := range regex.RegexVariables.FindAllStringSubmatch(regex.RemoveLiterals(value), -1) {
+				variable := strings.TrimSpace(strings.TrimSuffix(strings.TrimPrefix(match[2], "{{"), "}}"))
+				if variable == "" || variable == "@" {
+					continue
+				}
+				for _, root := range variableRoots(variable) {
+					if !builtinVariables.MatchString(root) && !known.Has(root) {
+						unresolvable[variable] = root
+					}
+				}
+			}
+		})
+		variables := make([]string, 0, len(unresolvable))
+		for variable := range unresolvable {
+			variables = append(variables, variable)
+		}
+		sort.Strings(variables)
+		for _, variable := range variables {
+			warnings = append(warnings, fmt.Sprintf("rule %s: variable {{ %s }} references %s which is not a built-in variable or a context entry, it will not be resolvable", rule.Name, variable, unresolvable[variable]))
+		}
+	}
+	return warnings
+}
+
+// collectContextEntries collects the names of the context entries declared at any level of the document
+func collectContextEntries(document interface{}, names sets.Set[string]) {
+	switch typed := document.(type) {
+	case map[string]interface{}:
+		for key, value := range typed {
+			if entries, ok := value.([]interface{}); ok && key == "context" {
+				for _, entry := range entries {
+					if entry, ok := entry.(map[string]interface{}); ok {
+						if name, ok := entry["name"].(string); ok {
+							names.Insert(name)
+						}
+					}
+				}
+			}
+			collectContextEntries(value, names)
+		}
+	case []interface{}:
+		for _, value := range typed {
+			collectContextEntries(value, names)
+		}
+	}
+}
+
+func walkStrings(document interface{}, fn func(string)) {
+	switch typed := document.(type) {
+	case map[string]interface{}:
+		for key, value := range typed {
+			fn(key)
+			walkStrings(value, fn)
+		}
+	case []interface{}:
+		for _, value := range typed {
+			walkStrings(value, fn)
+		}
+	case string:
+		fn(typed)
+	}
+}
+
+// variableRoots returns the identifiers a variable looks up in the root of the context,
+// variables that can't be parsed are reported by the other policy checks
+func variableRoots(variable string) []string {
+	ast, err := gojmespath.NewParser().Parse(variable)
+	if err != nil {
+		return nil
+	}
+	var roots []string
+	var visit func(node gojmespath.ASTNode)
+	visit = func(node gojmespath.ASTNode) {
+		switch node.NodeType {
+		case gojmespath.ASTField:
+			if name, ok := node.Value.(string); ok {
+				roots = append(roots, name)
+			}
+		case gojmespath.ASTSubexpression, gojmespath.ASTIndexExpression, gojmespath.ASTPipe,
+			gojmespath.ASTProjection, gojmespath.ASTValueProjection, gojmespath.ASTFilterProjection, gojmespath.ASTFlatten:
+			// the right hand side is evaluated against the result of the left hand side
+			if len(node.Children) > 0 {
+				visit(node.Children[0])
+			}
+		case gojmespath.ASTFunctionExpression, gojmespath.ASTComparator, gojmespath.ASTOrExpression, gojmespath.ASTAndExpression,
+			gojmespath.ASTNotExpression, gojmespath.ASTMultiSelectList, gojmespath.ASTMultiSelectHash, gojmespath.ASTKeyValPair:
+			for _, child := range node.Children {
+				visit(child)
+			}
+		}
+	}
+	visit(ast)
+	return roots
+}
@@ -0,0 +1,51 @@
+package webhooks
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// AccessLogOptions holds the options to log admission requests
+type AccessLogOptions struct {
+	// SampleRate is the fraction (between 0 and 1) of admission requests logged, no request is sampled when not positive.
+	SampleRate float64
+	// SampleRatePerPath overrides SampleRate for specific webhook paths.
+	// A path also applies to its /ignore and /fail variants.
+	SampleRatePerPath map[string]float64
+	// LatencyThreshold logs all the requests slower than the threshold whatever the sample rate, disabled when not positive.
+	LatencyThreshold time.Duration
+}
+
+// SampleRateFor returns the sample rate for the given webhook path
+func (o AccessLogOptions) SampleRateFor(path string) float64 {
+	if rate, ok := o.SampleRatePerPath[path]; ok {
+		return rate
+	}
+	return o.SampleRate
+}
+
+// ParseSampleRatePerPath parses a comma separated list of path=rate pairs (e.g. /validate=0.1,/mutate=0.01)
+func ParseSampleRatePerPath(in string) (map[string]float64, error) {
+	out := map[string]float64{}
+	for _, entry := range strings.Split(in, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		path, value, ok := strings.Cut(entry, "=")
+		if !ok || !strings.HasPrefix(path, "/") {
+			return nil, fmt.Errorf("invalid sample rate %q, expected path=rate", entry)
+		}
+		rate, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid sample rate %q: %w", entry, err)
+		}
+		if rate < 0 || rate > 1 {
+			return nil, fmt.Errorf("invalid sample rate %q, expected a value between 0 and 1", entry)
+		}
+		out[path] = rate
+	}
+	return out, nil
+}
@@ -0,0 +1,20 @@
+package webhooks
+
+import (
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+func Test_ParseSampleRatePerPath(t *testing.T) {
+	rates, err := ParseSampleRatePerPath("/validate=0.1, /mutate=1,")
+	assert.NilError(t, err)
+	assert.DeepEqual(t, rates, map[string]float64{"/validate": 0.1, "/mutate": 1})
+	opts := AccessLogOptions{SampleRate: 0.01, SampleRatePerPath: rates}
+	assert.Equal(t, opts.SampleRateFor("/validate"), 0.1)
+	assert.Equal(t, opts.SampleRateFor("/policyvalidate"), 0.01)
+	for _, in := range []string{"validate=0.1", "/validate", "/validate=foo", "/validate=2"} {
+		_, err := ParseSampleRatePerPath(in)
+		assert.Assert(t, err != nil, in)
+	}
+}
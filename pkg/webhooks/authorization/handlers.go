@@ -0,0 +1,116 @@
+package authorization
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov1beta1 "github.com/kyverno/kyverno/api/kyverno/v1beta1"
+	"github.com/kyverno/kyverno/pkg/config"
+	"github.com/kyverno/kyverno/pkg/engine"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	"github.com/kyverno/kyverno/pkg/engine/jmespath"
+	"github.com/kyverno/kyverno/pkg/policycache"
+	"github.com/kyverno/kyverno/pkg/userinfo"
+	datautils "github.com/kyverno/kyverno/pkg/utils/data"
+	"github.com/kyverno/kyverno/pkg/webhooks"
+	webhookutils "github.com/kyverno/kyverno/pkg/webhooks/utils"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// subjectAccessReviewsGVR is the resource policies match to be evaluated by the authorization webhook
+var subjectAccessReviewsGVR = authorizationv1.SchemeGroupVersion.WithResource("subjectaccessreviews")
+
+type authorizationHandlers struct {
+	engine        engineapi.Engine
+	configuration config.Configuration
+	jp            jmespath.Interface
+	pCache        policycache.Cache
+	rbLister      userinfo.RoleBindingLister
+	crbLister     userinfo.ClusterRoleBindingLister
+}
+
+func NewHandlers(
+	engine engineapi.Engine,
+	configuration config.Configuration,
+	jp jmespath.Interface,
+	pCache policycache.Cache,
+	rbLister userinfo.RoleBindingLister,
+	crbLister userinfo.ClusterRoleBindingLister,
+) webhooks.AuthorizationHandlers {
+	return &authorizationHandlers{
+		engine:        engine,
+		configuration: configuration,
+		jp:            jp,
+		pCache:        pCache,
+		rbLister:      rbLister,
+		crbLister:     crbLister,
+	}
+}
+
+// Authorize evaluates the enforced cluster policies matching SubjectAccessReview resources against the review,
+// it denies the request when a policy fails and has no opinion otherwise, it never allows a request.
+func (h *authorizationHandlers) Authorize(ctx context.Context, logger logr.Logger, spec authorizationv1.SubjectAccessReviewSpec) authorizationv1.SubjectAccessReviewStatus {
+	policies := h.pCache.GetPolicies(policycache.ValidateEnforce, subjectAccessReviewsGVR, "", "", kyvernov1.Create)
+	if len(policies) == 0 {
+		return authorizationv1.SubjectAccessReviewStatus{}
+	}
+	policyContext, err := h.buildPolicyContext(spec)
+	if err != nil {
+		logger.Error(err, "failed to create policy context")
+		return authorizationv1.SubjectAccessReviewStatus{EvaluationError: err.Error()}
+	}
+	var responses []engineapi.EngineResponse
+	for _, policy := range policies {
+		response := h.engine.Validate(ctx, policyContext.WithPolicy(policy))
+		if !response.IsNil() {
+			responses = append(responses, response)
+		}
+	}
+	// a failing policy denies the request whatever its failure policy, errors are only reported
+	if webhookutils.BlockRequest(responses, kyvernov1.Ignore, logger) {
+		return authorizationv1.SubjectAccessReviewStatus{
+			Denied: true,
+			Reason: webhookutils.GetBlockedMessages(responses),
+		}
+	}
+	return authorizationv1.SubjectAccessReviewStatus{}
+}
+
+func (h *authorizationHandlers) buildPolicyContext(spec authorizationv1.SubjectAccessReviewSpec) (*engine.PolicyContext, error) {
+	review := authorizationv1.SubjectAccessReview{Spec: spec}
+	review.APIVersion, review.Kind = authorizationv1.SchemeGroupVersion.String(), "SubjectAccessReview"
+	object, err := datautils.ToMap(review)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert subject access review: %w", err)
+	}
+	resource := unstructured.Unstructured{Object: object}
+	userInfo := authenticationv1.UserInfo{
+		Username: spec.User,
+		UID:      spec.UID,
+		Groups:   h.configuration.ExpandGroups(spec.User, spec.Groups),
+	}
+	if len(spec.Extra) > 0 {
+		userInfo.Extra = map[string]authenticationv1.ExtraValue{}
+		for key, value := range spec.Extra {
+			userInfo.Extra[key] = authenticationv1.ExtraValue(value)
+		}
+	}
+	roles, clusterRoles, err := userinfo.GetRoleRef(h.rbLister, h.crbLister, userInfo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch roles of the requester: %w", err)
+	}
+	requestInfo := kyvernov1beta1.RequestInfo{
+		AdmissionUserInfo: userInfo,
+		Roles:             roles,
+		ClusterRoles:      clusterRoles,
+	}
+	policyContext, err := engine.NewPolicyContext(h.jp, resource, kyvernov1.Create, &requestInfo, h.configuration)
+	if err != nil {
+		return nil, err
+	}
+	return policyContext.WithResourceKind(resource.GroupVersionKind(), ""), nil
+}
@@ -0,0 +1,159 @@
+package authorization
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/kyverno/kyverno/pkg/clients/dclient"
+	"github.com/kyverno/kyverno/pkg/config"
+	"github.com/kyverno/kyverno/pkg/engine"
+	"github.com/kyverno/kyverno/pkg/engine/factories"
+	"github.com/kyverno/kyverno/pkg/engine/jmespath"
+	"github.com/kyverno/kyverno/pkg/imageverifycache"
+	"github.com/kyverno/kyverno/pkg/policycache"
+	yamlutils "github.com/kyverno/kyverno/pkg/utils/yaml"
+	"github.com/kyverno/kyverno/pkg/webhooks"
+	"gotest.tools/assert"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubeinformers "k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+const policy = `
+apiVersion: kyverno.io/v1
+kind: ClusterPolicy
+metadata:
+  name: interns-cannot-delete-secrets
+spec:
+  validationFailureAction: Enforce
+  background: false
+  rules:
+  - name: deny-delete-secrets
+    match:
+      any:
+      - resources:
+          kinds:
+          - SubjectAccessReview
+        subjects:
+        - kind: Group
+          name: interns
+    validate:
+      message: interns are not allowed to delete secrets
+      deny:
+        conditions:
+          all:
+          - key: "{{ request.object.spec.resourceAttributes.verb || '' }}"
+            operator: Equals
+            value: delete
+          - key: "{{ request.object.spec.resourceAttributes.resource || '' }}"
+            operator: Equals
+            value: secrets
+`
+
+type resourceFinder struct{}
+
+func (resourceFinder) FindResources(group, version, kind, subresource string) (map[dclient.TopLevelApiDescription]metav1.APIResource, error) {
+	return map[dclient.TopLevelApiDescription]metav1.APIResource{
+		{
+			GroupVersion: subjectAccessReviewsGVR.GroupVersion(),
+			Kind:         "SubjectAccessReview",
+			Resource:     subjectAccessReviewsGVR.Resource,
+		}: {},
+	}, nil
+}
+
+func newTestHandlers(t *testing.T, withPolicy bool) webhooks.AuthorizationHandlers {
+	cfg := config.NewDefaultConfiguration(false)
+	jp := jmespath.New(cfg)
+	eng := engine.NewEngine(
+		cfg,
+		config.NewDefaultMetricsConfiguration(),
+		jp,
+		nil,
+		nil,
+		imageverifycache.DisabledImageVerifyCache(),
+		factories.DefaultContextLoaderFactory(nil),
+		nil,
+		nil,
+		nil,
+		"",
+	)
+	pCache := policycache.NewCache()
+	if withPolicy {
+		policies, _, err := yamlutils.GetPolicy([]byte(policy))
+		assert.NilError(t, err)
+		assert.NilError(t, pCache.Set(policies[0].GetName(), policies[0], resourceFinder{}))
+	}
+	informers := kubeinformers.NewSharedInformerFactory(fake.NewSimpleClientset(), 0)
+	return NewHandlers(
+		eng,
+		cfg,
+		jp,
+		pCache,
+		informers.Rbac().V1().RoleBindings().Lister(),
+		informers.Rbac().V1().ClusterRoleBindings().Lister(),
+	)
+}
+
+func Test_Authorize(t *testing.T) {
+	tests := []struct {
+		name       string
+		withPolicy bool
+		spec       authorizationv1.SubjectAccessReviewSpec
+		denied     bool
+	}{{
+		name:       "denied",
+		withPolicy: true,
+		spec: authorizationv1.SubjectAccessReviewSpec{
+			User:               "bob",
+			Groups:             []string{"interns"},
+			ResourceAttributes: &authorizationv1.ResourceAttributes{Verb: "delete", Resource: "secrets", Namespace: "default"},
+		},
+		denied: true,
+	}, {
+		name:       "other verb",
+		withPolicy: true,
+		spec: authorizationv1.SubjectAccessReviewSpec{
+			User:               "bob",
+			Groups:             []string{"interns"},
+			ResourceAttributes: &authorizationv1.ResourceAttributes{Verb: "get", Resource: "secrets", Namespace: "default"},
+		},
+	}, {
+		name:       "other group",
+		withPolicy: true,
+		spec: authorizationv1.SubjectAccessReviewSpec{
+			User:               "alice",
+			Groups:             []string{"admins"},
+			ResourceAttributes: &authorizationv1.ResourceAttributes{Verb: "delete", Resource: "secrets", Namespace: "default"},
+		},
+	}, {
+		name:       "non resource request",
+		withPolicy: true,
+		spec: authorizationv1.SubjectAccessReviewSpec{
+			User:                  "bob",
+			Groups:                []string{"interns"},
+			NonResourceAttributes: &authorizationv1.NonResourceAttributes{Verb: "get", Path: "/healthz"},
+		},
+	}, {
+		name: "no policy",
+		spec: authorizationv1.SubjectAccessReviewSpec{
+			User:               "bob",
+			Groups:             []string{"interns"},
+			ResourceAttributes: &authorizationv1.ResourceAttributes{Verb: "delete", Resource: "secrets", Namespace: "default"},
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status := newTestHandlers(t, tt.withPolicy).Authorize(context.TODO(), logr.Discard(), tt.spec)
+			assert.Equal(t, status.Denied, tt.denied)
+			// the webhook never allows a request
+			assert.Equal(t, status.Allowed, false)
+			assert.Equal(t, status.EvaluationError, "")
+			if tt.denied {
+				assert.Assert(t, status.Reason != "")
+			}
+		})
+	}
+}
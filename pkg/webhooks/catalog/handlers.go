@@ -0,0 +1,78 @@
+package catalog
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/catalog"
+	kyvernov1listers "github.com/kyverno/kyverno/pkg/client/listers/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/webhooks"
+	"k8s.io/apimachinery/pkg/labels"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+)
+
+type catalogHandlers struct {
+	cpolLister kyvernov1listers.ClusterPolicyLister
+	polLister  kyvernov1listers.PolicyLister
+	nsLister   corev1listers.NamespaceLister
+}
+
+func NewHandlers(
+	cpolLister kyvernov1listers.ClusterPolicyLister,
+	polLister kyvernov1listers.PolicyLister,
+	nsLister corev1listers.NamespaceLister,
+) webhooks.CatalogHandlers {
+	return &catalogHandlers{
+		cpolLister: cpolLister,
+		polLister:  polLister,
+		nsLister:   nsLister,
+	}
+}
+
+// Catalog lists the installed policies, when a namespace is given the policies are restricted to the
+// ones that can match resources in the namespace and their mode is the one effective in the namespace
+func (h *catalogHandlers) Catalog(ctx context.Context, logger logr.Logger, namespace string) (catalog.Catalog, error) {
+	var policies []kyvernov1.PolicyInterface
+	cpols, err := h.cpolLister.List(labels.Everything())
+	if err != nil {
+		return catalog.Catalog{}, err
+	}
+	for _, cpol := range cpols {
+		policies = append(policies, cpol)
+	}
+	var ns *catalog.Namespace
+	if namespace == "" {
+		pols, err := h.polLister.List(labels.Everything())
+		if err != nil {
+			return catalog.Catalog{}, err
+		}
+		for _, pol := range pols {
+			policies = append(policies, pol)
+		}
+	} else {
+		object, err := h.nsLister.Get(namespace)
+		if err != nil {
+			return catalog.Catalog{}, err
+		}
+		ns = &catalog.Namespace{
+			Name:        object.GetName(),
+			Labels:      object.GetLabels(),
+			Annotations: object.GetAnnotations(),
+		}
+		// namespace labels must not be nil for namespace selectors to be evaluated
+		if ns.Labels == nil {
+			ns.Labels = map[string]string{}
+		}
+		pols, err := h.polLister.Policies(namespace).List(labels.Everything())
+		if err != nil {
+			return catalog.Catalog{}, err
+		}
+		for _, pol := range pols {
+			policies = append(policies, pol)
+		}
+	}
+	result := catalog.Build(ctx, policies, ns)
+	logger.V(4).Info("policy catalog built", "namespace", namespace, "policies", len(result.Policies))
+	return result, nil
+}
@@ -0,0 +1,57 @@
+package conversion
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	"github.com/kyverno/kyverno/pkg/webhooks"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+type conversionHandlers struct{}
+
+func NewHandlers() webhooks.ConversionHandlers {
+	return &conversionHandlers{}
+}
+
+// Convert converts the policies of a conversion review to the desired api version,
+// the whole review fails if one of the objects can't be converted
+func (h *conversionHandlers) Convert(ctx context.Context, logger logr.Logger, request apiextensionsv1.ConversionRequest) apiextensionsv1.ConversionResponse {
+	converted := make([]runtime.RawExtension, 0, len(request.Objects))
+	for _, object := range request.Objects {
+		raw, err := convert(object.Raw, request.DesiredAPIVersion)
+		if err != nil {
+			logger.Error(err, "failed to convert object")
+			return apiextensionsv1.ConversionResponse{
+				Result: metav1.Status{
+					Status:  metav1.StatusFailure,
+					Message: err.Error(),
+				},
+			}
+		}
+		converted = append(converted, runtime.RawExtension{Raw: raw})
+	}
+	return apiextensionsv1.ConversionResponse{
+		ConvertedObjects: converted,
+		Result: metav1.Status{
+			Status: metav1.StatusSuccess,
+		},
+	}
+}
+
+func convert(raw []byte, desiredAPIVersion string) ([]byte, error) {
+	var obj unstructured.Unstructured
+	if err := obj.UnmarshalJSON(raw); err != nil {
+		return nil, err
+	}
+	out, err := ConvertPolicy(&obj, desiredAPIVersion)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert %s %s: %w", obj.GetKind(), obj.GetName(), err)
+	}
+	return json.Marshal(out.Object)
+}
@@ -0,0 +1,206 @@
+package conversion
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/kyverno/kyverno/api/kyverno"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov2beta1 "github.com/kyverno/kyverno/api/kyverno/v2beta1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+var (
+	// v1SpecFields are the policy spec fields that don't exist in v2beta1
+	v1SpecFields = []string{"schedule", "rollout"}
+	// v1ImageVerificationFields are the deprecated image verification fields removed in v2beta1
+	v1ImageVerificationFields = []string{"image", "key", "roots", "subject", "issuer", "additionalExtensions", "annotations"}
+	// v1MatchFields are the match and exclude fields replaced by any/all resource filters in v2beta1
+	v1MatchFields = []string{"resources", "subjects", "roles", "clusterRoles"}
+)
+
+// conversionData holds the v1 fields that can't be represented in v2beta1,
+// it is stored in an annotation so that converting back to v1 is lossless
+type conversionData struct {
+	Spec         map[string]interface{}              `json:"spec,omitempty"`
+	VerifyImages map[string][]map[string]interface{} `json:"verifyImages,omitempty"`
+}
+
+// ConvertPolicy converts a ClusterPolicy or Policy to the desired api version
+func ConvertPolicy(obj *unstructured.Unstructured, desiredAPIVersion string) (*unstructured.Unstructured, error) {
+	if kind := obj.GetKind(); kind != "ClusterPolicy" && kind != "Policy" {
+		return nil, fmt.Errorf("unsupported kind %s", kind)
+	}
+	apiVersion := obj.GetAPIVersion()
+	if apiVersion == desiredAPIVersion {
+		return obj, nil
+	}
+	v1 := kyvernov1.SchemeGroupVersion.String()
+	v2beta1 := kyvernov2beta1.SchemeGroupVersion.String()
+	switch {
+	case apiVersion == v1 && desiredAPIVersion == v2beta1:
+		return convertV1ToV2beta1(obj)
+	case apiVersion == v2beta1 && desiredAPIVersion == v1:
+		return convertV2beta1ToV1(obj)
+	default:
+		return nil, fmt.Errorf("unsupported conversion from %s to %s", apiVersion, desiredAPIVersion)
+	}
+}
+
+func convertV1ToV2beta1(obj *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+	out := obj.DeepCopy()
+	out.SetAPIVersion(kyvernov2beta1.SchemeGroupVersion.String())
+	var data conversionData
+	spec, _ := out.Object["spec"].(map[string]interface{})
+	for _, field := range v1SpecFields {
+		if value, ok := spec[field]; ok {
+			if data.Spec == nil {
+				data.Spec = map[string]interface{}{}
+			}
+			data.Spec[field] = value
+			delete(spec, field)
+		}
+	}
+	rules, _ := spec["rules"].([]interface{})
+	for _, r := range rules {
+		rule, ok := r.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		convertMatchToV2beta1(rule, "match")
+		convertMatchToV2beta1(rule, "exclude")
+		rule["preconditions"] = convertConditionsToV2beta1(rule["preconditions"])
+		if rule["preconditions"] == nil {
+			delete(rule, "preconditions")
+		}
+		if validate, ok := rule["validate"].(map[string]interface{}); ok {
+			if deny, ok := validate["deny"].(map[string]interface{}); ok {
+				deny["conditions"] = convertConditionsToV2beta1(deny["conditions"])
+				if deny["conditions"] == nil {
+					delete(deny, "conditions")
+				}
+			}
+		}
+		verifyImages, _ := rule["verifyImages"].([]interface{})
+		var removed []map[string]interface{}
+		found := false
+		for _, v := range verifyImages {
+			var fields map[string]interface{}
+			if verifyImage, ok := v.(map[string]interface{}); ok {
+				for _, field := range v1ImageVerificationFields {
+					if value, ok := verifyImage[field]; ok {
+						if fields == nil {
+							fields = map[string]interface{}{}
+						}
+						fields[field] = value
+						delete(verifyImage, field)
+						found = true
+					}
+				}
+			}
+			removed = append(removed, fields)
+		}
+		if found {
+			if data.VerifyImages == nil {
+				data.VerifyImages = map[string][]map[string]interface{}{}
+			}
+			name, _ := rule["name"].(string)
+			data.VerifyImages[name] = removed
+		}
+	}
+	if data.Spec != nil || data.VerifyImages != nil {
+		raw, err := json.Marshal(data)
+		if err != nil {
+			return nil, err
+		}
+		annotations := out.GetAnnotations()
+		if annotations == nil {
+			annotations = map[string]string{}
+		}
+		annotations[kyverno.AnnotationConversionData] = string(raw)
+		out.SetAnnotations(annotations)
+	}
+	return out, nil
+}
+
+func convertV2beta1ToV1(obj *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+	out := obj.DeepCopy()
+	out.SetAPIVersion(kyvernov1.SchemeGroupVersion.String())
+	annotations := out.GetAnnotations()
+	raw, ok := annotations[kyverno.AnnotationConversionData]
+	if !ok {
+		return out, nil
+	}
+	var data conversionData
+	if err := json.Unmarshal([]byte(raw), &data); err != nil {
+		return nil, fmt.Errorf("failed to decode %s annotation: %w", kyverno.AnnotationConversionData, err)
+	}
+	delete(annotations, kyverno.AnnotationConversionData)
+	if len(annotations) == 0 {
+		annotations = nil
+	}
+	out.SetAnnotations(annotations)
+	spec, _ := out.Object["spec"].(map[string]interface{})
+	if spec == nil {
+		spec = map[string]interface{}{}
+		out.Object["spec"] = spec
+	}
+	for field, value := range data.Spec {
+		spec[field] = value
+	}
+	rules, _ := spec["rules"].([]interface{})
+	for _, r := range rules {
+		rule, ok := r.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		name, _ := rule["name"].(string)
+		removed, ok := data.VerifyImages[name]
+		if !ok {
+			continue
+		}
+		verifyImages, _ := rule["verifyImages"].([]interface{})
+		for i, v := range verifyImages {
+			verifyImage, ok := v.(map[string]interface{})
+			if !ok || i >= len(removed) {
+				continue
+			}
+			for field, value := range removed[i] {
+				verifyImage[field] = value
+			}
+		}
+	}
+	return out, nil
+}
+
+// convertMatchToV2beta1 moves the resource description and user info set directly on a v1 match or exclude
+// block to a single resource filter, v2beta1 only supports any/all resource filters
+func convertMatchToV2beta1(rule map[string]interface{}, key string) {
+	match, ok := rule[key].(map[string]interface{})
+	if !ok {
+		return
+	}
+	filter := map[string]interface{}{}
+	for _, field := range v1MatchFields {
+		if value, ok := match[field]; ok {
+			filter[field] = value
+			delete(match, field)
+		}
+	}
+	if len(filter) == 0 {
+		return
+	}
+	any, _ := match["any"].([]interface{})
+	match["any"] = append(any, filter)
+}
+
+// convertConditionsToV2beta1 converts a v1 list of conditions to the any/all form, all conditions of the list must pass
+func convertConditionsToV2beta1(conditions interface{}) interface{} {
+	if list, ok := conditions.([]interface{}); ok {
+		if len(list) == 0 {
+			return nil
+		}
+		return map[string]interface{}{"all": list}
+	}
+	return conditions
+}
@@ -0,0 +1,112 @@
+package conversion
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/kyverno/kyverno/api/kyverno"
+	kyvernov2beta1 "github.com/kyverno/kyverno/api/kyverno/v2beta1"
+	"gotest.tools/assert"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+const v1Policy = `{
+  "apiVersion": "kyverno.io/v1",
+  "kind": "ClusterPolicy",
+  "metadata": {"name": "test"},
+  "spec": {
+    "validationFailureAction": "Enforce",
+    "schedule": {"cron": "0 * * * *"},
+    "rules": [{
+      "name": "legacy",
+      "match": {"resources": {"kinds": ["Pod"]}, "subjects": [{"kind": "User", "name": "bob"}]},
+      "exclude": {"resources": {"namespaces": ["kube-system"]}},
+      "preconditions": [{"key": "{{ request.operation }}", "operator": "Equals", "value": "CREATE"}],
+      "validate": {"deny": {"conditions": [{"key": "a", "operator": "Equals", "value": "a"}]}}
+    }, {
+      "name": "images",
+      "match": {"any": [{"resources": {"kinds": ["Pod"]}}]},
+      "verifyImages": [{"image": "ghcr.io/*", "key": "KEY", "imageReferences": ["ghcr.io/*"]}, {"imageReferences": ["docker.io/*"]}]
+    }]
+  }
+}`
+
+func toUnstructured(t *testing.T, raw string) *unstructured.Unstructured {
+	var obj unstructured.Unstructured
+	assert.NilError(t, obj.UnmarshalJSON([]byte(raw)))
+	return &obj
+}
+
+func TestConvertPolicy(t *testing.T) {
+	in := toUnstructured(t, v1Policy)
+	out, err := ConvertPolicy(in, kyvernov2beta1.SchemeGroupVersion.String())
+	assert.NilError(t, err)
+	assert.Equal(t, out.GetAPIVersion(), "kyverno.io/v2beta1")
+	// the v2beta1 policy must be decodable
+	data, err := json.Marshal(out.Object)
+	assert.NilError(t, err)
+	var policy kyvernov2beta1.ClusterPolicy
+	assert.NilError(t, json.Unmarshal(data, &policy))
+	legacy := policy.Spec.Rules[0]
+	assert.Equal(t, len(legacy.MatchResources.Any), 1)
+	assert.DeepEqual(t, legacy.MatchResources.Any[0].Kinds, []string{"Pod"})
+	assert.Equal(t, len(legacy.MatchResources.Any[0].Subjects), 1)
+	assert.Equal(t, len(legacy.ExcludeResources.Any), 1)
+	assert.DeepEqual(t, legacy.ExcludeResources.Any[0].Namespaces, []string{"kube-system"})
+	assert.Equal(t, len(legacy.RawAnyAllConditions.AllConditions), 1)
+	assert.Equal(t, len(legacy.Validation.Deny.RawAnyAllConditions.AllConditions), 1)
+	_, found, _ := unstructured.NestedFieldNoCopy(out.Object, "spec", "schedule")
+	assert.Equal(t, found, false)
+	images, _, _ := unstructured.NestedSlice(out.Object, "spec", "rules")
+	verifyImage := images[1].(map[string]interface{})["verifyImages"].([]interface{})[0].(map[string]interface{})
+	_, found = verifyImage["image"]
+	assert.Equal(t, found, false)
+	assert.Assert(t, out.GetAnnotations()[kyverno.AnnotationConversionData] != "")
+	// the input is left untouched
+	assert.DeepEqual(t, in.Object, toUnstructured(t, v1Policy).Object)
+	// converting back restores the fields v2beta1 can't represent
+	back, err := ConvertPolicy(out, "kyverno.io/v1")
+	assert.NilError(t, err)
+	assert.Equal(t, back.GetAPIVersion(), "kyverno.io/v1")
+	assert.Assert(t, back.GetAnnotations() == nil)
+	schedule, found, _ := unstructured.NestedString(back.Object, "spec", "schedule", "cron")
+	assert.Equal(t, found, true)
+	assert.Equal(t, schedule, "0 * * * *")
+	rules, _, _ := unstructured.NestedSlice(back.Object, "spec", "rules")
+	verifyImages := rules[1].(map[string]interface{})["verifyImages"].([]interface{})
+	assert.Equal(t, verifyImages[0].(map[string]interface{})["image"], "ghcr.io/*")
+	assert.Equal(t, verifyImages[0].(map[string]interface{})["key"], "KEY")
+	_, found = verifyImages[1].(map[string]interface{})["image"]
+	assert.Equal(t, found, false)
+}
+
+func TestConvertPolicy_Errors(t *testing.T) {
+	_, err := ConvertPolicy(toUnstructured(t, `{"apiVersion":"kyverno.io/v1","kind":"ClusterPolicy","metadata":{"name":"test"}}`), "kyverno.io/v1alpha1")
+	assert.Error(t, err, "unsupported conversion from kyverno.io/v1 to kyverno.io/v1alpha1")
+	_, err = ConvertPolicy(toUnstructured(t, `{"apiVersion":"kyverno.io/v1","kind":"UpdateRequest","metadata":{"name":"test"}}`), "kyverno.io/v2beta1")
+	assert.Error(t, err, "unsupported kind UpdateRequest")
+	out, err := ConvertPolicy(toUnstructured(t, `{"apiVersion":"kyverno.io/v2beta1","kind":"Policy","metadata":{"name":"test"}}`), "kyverno.io/v2beta1")
+	assert.NilError(t, err)
+	assert.Equal(t, out.GetAPIVersion(), "kyverno.io/v2beta1")
+}
+
+func TestConvert(t *testing.T) {
+	handlers := NewHandlers()
+	response := handlers.Convert(context.TODO(), logr.Discard(), apiextensionsv1.ConversionRequest{
+		DesiredAPIVersion: "kyverno.io/v2beta1",
+		Objects:           []runtime.RawExtension{{Raw: []byte(v1Policy)}},
+	})
+	assert.Equal(t, response.Result.Status, metav1.StatusSuccess)
+	assert.Equal(t, len(response.ConvertedObjects), 1)
+	response = handlers.Convert(context.TODO(), logr.Discard(), apiextensionsv1.ConversionRequest{
+		DesiredAPIVersion: "kyverno.io/v2beta1",
+		Objects:           []runtime.RawExtension{{Raw: []byte(v1Policy)}, {Raw: []byte(`{`)}},
+	})
+	assert.Equal(t, response.Result.Status, metav1.StatusFailure)
+	assert.Equal(t, len(response.ConvertedObjects), 0)
+}
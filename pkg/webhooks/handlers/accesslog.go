@@ -0,0 +1,42 @@
+package handlers
+
+import (
+	"context"
+	"math/rand"
+	"time"
+
+	"github.com/go-logr/logr"
+)
+
+// WithAccessLog logs a sample of the admission requests and all the requests slower than the latency threshold,
+// the latency is measured from the time the request was received
+func (inner AdmissionHandler) WithAccessLog(sampleRate float64, latencyThreshold time.Duration) AdmissionHandler {
+	if sampleRate <= 0 && latencyThreshold <= 0 {
+		return inner
+	}
+	return inner.withAccessLog(sampleRate, latencyThreshold, rand.Float64) //nolint:gosec
+}
+
+func (inner AdmissionHandler) withAccessLog(sampleRate float64, latencyThreshold time.Duration, random func() float64) AdmissionHandler {
+	return func(ctx context.Context, logger logr.Logger, request AdmissionRequest, startTime time.Time) AdmissionResponse {
+		response := inner(ctx, logger, request, startTime)
+		latency := time.Since(startTime)
+		slow := latencyThreshold > 0 && latency >= latencyThreshold
+		if slow || (sampleRate > 0 && random() < sampleRate) {
+			logger.Info(
+				"admission request",
+				"uid", request.UID,
+				"kind", request.Kind.Kind,
+				"subresource", request.SubResource,
+				"namespace", request.Namespace,
+				"name", request.Name,
+				"operation", request.Operation,
+				"user", request.UserInfo.Username,
+				"allowed", response.Allowed,
+				"latency", latency.String(),
+				"slow", slow,
+			)
+		}
+		return response
+	}
+}
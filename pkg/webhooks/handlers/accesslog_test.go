@@ -0,0 +1,56 @@
+package handlers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/go-logr/logr/funcr"
+	"gotest.tools/assert"
+	admissionv1 "k8s.io/api/admission/v1"
+)
+
+func Test_WithAccessLog(t *testing.T) {
+	var inner AdmissionHandler = func(_ context.Context, _ logr.Logger, request AdmissionRequest, _ time.Time) AdmissionResponse {
+		return AdmissionResponse{UID: request.UID, Allowed: true}
+	}
+	tests := []struct {
+		name             string
+		sampleRate       float64
+		latencyThreshold time.Duration
+		random           float64
+		latency          time.Duration
+		logged           bool
+	}{{
+		name:       "sampled",
+		sampleRate: 0.1,
+		random:     0.05,
+		logged:     true,
+	}, {
+		name:       "not sampled",
+		sampleRate: 0.1,
+		random:     0.5,
+	}, {
+		name:             "slow request",
+		latencyThreshold: time.Second,
+		random:           0.5,
+		latency:          2 * time.Second,
+		logged:           true,
+	}, {
+		name:             "fast request",
+		latencyThreshold: time.Second,
+		random:           0,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var logs []string
+			logger := funcr.New(func(prefix, args string) { logs = append(logs, args) }, funcr.Options{})
+			handler := inner.withAccessLog(tt.sampleRate, tt.latencyThreshold, func() float64 { return tt.random })
+			request := AdmissionRequest{AdmissionRequest: admissionv1.AdmissionRequest{UID: "1", Operation: admissionv1.Create, Name: "test"}}
+			response := handler(context.TODO(), logger, request, time.Now().Add(-tt.latency))
+			assert.Equal(t, response.Allowed, true)
+			assert.Equal(t, len(logs) == 1, tt.logged, logs)
+		})
+	}
+}
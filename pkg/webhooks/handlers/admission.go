@@ -1,14 +1,12 @@
 package handlers
 
 import (
-	"encoding/json"
 	"errors"
 	"io"
 	"net/http"
 	"time"
 
 	"github.com/go-logr/logr"
-	admissionv1 "k8s.io/api/admission/v1"
 )
 
 func (inner AdmissionHandler) WithAdmission(logger logr.Logger) HttpHandler {
@@ -23,18 +21,18 @@ func (inner AdmissionHandler) withAdmission(logger logr.Logger) HttpHandler {
 			return
 		}
 		defer request.Body.Close()
-		body, err := io.ReadAll(request.Body)
-		if err != nil {
-			HttpError(request.Context(), writer, request, logger, err, http.StatusBadRequest)
+		// check the content type before reading the body
+		if !isJSONContentType(request.Header.Get("Content-Type")) {
+			HttpError(request.Context(), writer, request, logger, errors.New("invalid Content-Type"), http.StatusUnsupportedMediaType)
 			return
 		}
-		contentType := request.Header.Get("Content-Type")
-		if contentType != "application/json" {
-			HttpError(request.Context(), writer, request, logger, errors.New("invalid Content-Type"), http.StatusUnsupportedMediaType)
+		body, err := io.ReadAll(request.Body)
+		if err != nil {
+			HttpError(request.Context(), writer, request, logger, err, readErrorStatus(err))
 			return
 		}
-		var admissionReview admissionv1.AdmissionReview
-		if err := json.Unmarshal(body, &admissionReview); err != nil {
+		admissionReview, apiVersion, err := decodeAdmissionReview(body)
+		if err != nil {
 			HttpError(request.Context(), writer, request, logger, err, http.StatusExpectationFailed)
 			return
 		}
@@ -50,9 +48,14 @@ func (inner AdmissionHandler) withAdmission(logger logr.Logger) HttpHandler {
 		admissionRequest := AdmissionRequest{
 			AdmissionRequest: *admissionReview.Request,
 		}
-		admissionResponse := inner(request.Context(), logger, admissionRequest, startTime)
+		ctx := request.Context()
+		// the API server announces its timeout in the query parameters
+		if timeout, err := time.ParseDuration(request.URL.Query().Get("timeout")); err == nil {
+			ctx = withRequestTimeout(ctx, timeout)
+		}
+		admissionResponse := inner(ctx, logger, admissionRequest, startTime)
 		admissionReview.Response = &admissionResponse
-		responseJSON, err := json.Marshal(admissionReview)
+		responseJSON, err := encodeAdmissionReview(admissionReview, apiVersion)
 		if err != nil {
 			HttpError(request.Context(), writer, request, logger, err, http.StatusInternalServerError)
 			return
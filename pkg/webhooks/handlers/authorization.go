@@ -0,0 +1,79 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/go-logr/logr"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	authorizationv1beta1 "k8s.io/api/authorization/v1beta1"
+)
+
+// AuthorizationHandler decides on a subject access review sent by the API server to an authorization webhook
+type AuthorizationHandler func(context.Context, logr.Logger, authorizationv1.SubjectAccessReviewSpec) authorizationv1.SubjectAccessReviewStatus
+
+func (inner AuthorizationHandler) WithAuthorization(logger logr.Logger) HttpHandler {
+	return inner.withAuthorization(logger).WithMetrics(logger).WithTrace("AUTHORIZATION")
+}
+
+func (inner AuthorizationHandler) withAuthorization(logger logr.Logger) HttpHandler {
+	return func(writer http.ResponseWriter, request *http.Request) {
+		if request.Body == nil {
+			HttpError(request.Context(), writer, request, logger, errors.New("empty body"), http.StatusBadRequest)
+			return
+		}
+		defer request.Body.Close()
+		// check the content type before reading the body
+		if !isJSONContentType(request.Header.Get("Content-Type")) {
+			HttpError(request.Context(), writer, request, logger, errors.New("invalid Content-Type"), http.StatusUnsupportedMediaType)
+			return
+		}
+		body, err := io.ReadAll(request.Body)
+		if err != nil {
+			HttpError(request.Context(), writer, request, logger, err, readErrorStatus(err))
+			return
+		}
+		var review authorizationv1.SubjectAccessReview
+		if err := json.Unmarshal(body, &review); err != nil {
+			HttpError(request.Context(), writer, request, logger, err, http.StatusExpectationFailed)
+			return
+		}
+		// v1beta1 reviews only differ by the name of the groups field
+		if review.APIVersion == authorizationv1beta1.SchemeGroupVersion.String() {
+			var v1beta1Review authorizationv1beta1.SubjectAccessReview
+			if err := json.Unmarshal(body, &v1beta1Review); err != nil {
+				HttpError(request.Context(), writer, request, logger, err, http.StatusExpectationFailed)
+				return
+			}
+			review.Spec.Groups = v1beta1Review.Spec.Groups
+		}
+		logger := logger.WithValues("user", review.Spec.User, "groups", review.Spec.Groups)
+		if attributes := review.Spec.ResourceAttributes; attributes != nil {
+			logger = logger.WithValues("verb", attributes.Verb, "group", attributes.Group, "resource", attributes.Resource, "namespace", attributes.Namespace, "name", attributes.Name)
+		} else if attributes := review.Spec.NonResourceAttributes; attributes != nil {
+			logger = logger.WithValues("verb", attributes.Verb, "path", attributes.Path)
+		}
+		review.Status = inner(request.Context(), logger, review.Spec)
+		responseJSON, err := json.Marshal(struct {
+			APIVersion string                                    `json:"apiVersion"`
+			Kind       string                                    `json:"kind"`
+			Status     authorizationv1.SubjectAccessReviewStatus `json:"status"`
+		}{
+			APIVersion: review.APIVersion,
+			Kind:       review.Kind,
+			Status:     review.Status,
+		})
+		if err != nil {
+			HttpError(request.Context(), writer, request, logger, err, http.StatusInternalServerError)
+			return
+		}
+		writer.Header().Set("Content-Type", "application/json; charset=utf-8")
+		if _, err := writer.Write(responseJSON); err != nil {
+			HttpError(request.Context(), writer, request, logger, err, http.StatusInternalServerError)
+			return
+		}
+	}
+}
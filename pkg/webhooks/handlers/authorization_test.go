@@ -0,0 +1,81 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"gotest.tools/assert"
+	authorizationv1 "k8s.io/api/authorization/v1"
+)
+
+func Test_WithAuthorization(t *testing.T) {
+	var handler AuthorizationHandler = func(_ context.Context, _ logr.Logger, spec authorizationv1.SubjectAccessReviewSpec) authorizationv1.SubjectAccessReviewStatus {
+		for _, group := range spec.Groups {
+			if group == "interns" && spec.ResourceAttributes != nil && spec.ResourceAttributes.Verb == "delete" {
+				return authorizationv1.SubjectAccessReviewStatus{Denied: true, Reason: "denied"}
+			}
+		}
+		return authorizationv1.SubjectAccessReviewStatus{}
+	}
+	tests := []struct {
+		name        string
+		body        string
+		contentType string
+		code        int
+		apiVersion  string
+		denied      bool
+	}{{
+		name:        "v1",
+		body:        `{"apiVersion":"authorization.k8s.io/v1","kind":"SubjectAccessReview","spec":{"user":"bob","groups":["interns"],"resourceAttributes":{"verb":"delete","resource":"secrets"}}}`,
+		contentType: "application/json",
+		code:        http.StatusOK,
+		apiVersion:  "authorization.k8s.io/v1",
+		denied:      true,
+	}, {
+		name:        "v1beta1",
+		body:        `{"apiVersion":"authorization.k8s.io/v1beta1","kind":"SubjectAccessReview","spec":{"user":"bob","group":["interns"],"resourceAttributes":{"verb":"delete","resource":"secrets"}}}`,
+		contentType: "application/json",
+		code:        http.StatusOK,
+		apiVersion:  "authorization.k8s.io/v1beta1",
+		denied:      true,
+	}, {
+		name:        "no opinion",
+		body:        `{"apiVersion":"authorization.k8s.io/v1","kind":"SubjectAccessReview","spec":{"user":"bob","groups":["interns"],"resourceAttributes":{"verb":"get","resource":"secrets"}}}`,
+		contentType: "application/json",
+		code:        http.StatusOK,
+		apiVersion:  "authorization.k8s.io/v1",
+	}, {
+		name:        "invalid content type",
+		body:        `{}`,
+		contentType: "text/plain",
+		code:        http.StatusUnsupportedMediaType,
+	}, {
+		name:        "invalid body",
+		body:        `{`,
+		contentType: "application/json",
+		code:        http.StatusExpectationFailed,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request := httptest.NewRequest(http.MethodPost, "/authorize", strings.NewReader(tt.body))
+			request.Header.Set("Content-Type", tt.contentType)
+			recorder := httptest.NewRecorder()
+			handler.withAuthorization(logr.Discard())(recorder, request)
+			assert.Equal(t, recorder.Code, tt.code)
+			if tt.code != http.StatusOK {
+				return
+			}
+			var review authorizationv1.SubjectAccessReview
+			assert.NilError(t, json.Unmarshal(recorder.Body.Bytes(), &review))
+			assert.Equal(t, review.APIVersion, tt.apiVersion)
+			assert.Equal(t, review.Kind, "SubjectAccessReview")
+			assert.Equal(t, review.Status.Denied, tt.denied)
+			assert.Equal(t, review.Status.Allowed, false)
+		})
+	}
+}
@@ -0,0 +1,37 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-logr/logr"
+	"github.com/kyverno/kyverno/pkg/catalog"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+// Catalog serves the catalog of the installed policies, the optional namespace query parameter
+// restricts the catalog to the policies applying to the namespace
+func Catalog(logger logr.Logger, inner func(context.Context, string) (catalog.Catalog, error)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		namespace := r.URL.Query().Get("namespace")
+		result, err := inner(r.Context(), namespace)
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				http.Error(w, err.Error(), http.StatusNotFound)
+				return
+			}
+			logger.Error(err, "failed to build policy catalog", "namespace", namespace)
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		data, err := json.Marshal(result)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(data)
+	}
+}
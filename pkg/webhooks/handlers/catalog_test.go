@@ -0,0 +1,37 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/kyverno/kyverno/pkg/catalog"
+	"github.com/kyverno/kyverno/pkg/config"
+	"gotest.tools/assert"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func Test_Catalog(t *testing.T) {
+	handler := Catalog(logr.Discard(), func(_ context.Context, namespace string) (catalog.Catalog, error) {
+		if namespace == "missing" {
+			return catalog.Catalog{}, apierrors.NewNotFound(schema.GroupResource{Resource: "namespaces"}, namespace)
+		}
+		return catalog.Catalog{Namespace: namespace, Policies: []catalog.Policy{{Kind: "ClusterPolicy", Name: "require-labels"}}}, nil
+	})
+	recorder := httptest.NewRecorder()
+	handler(recorder, httptest.NewRequest(http.MethodGet, config.PolicyCatalogServicePath+"?namespace=apps", nil))
+	assert.Equal(t, recorder.Code, http.StatusOK)
+	assert.Equal(t, recorder.Header().Get("Content-Type"), "application/json")
+	var result catalog.Catalog
+	assert.NilError(t, json.Unmarshal(recorder.Body.Bytes(), &result))
+	assert.Equal(t, result.Namespace, "apps")
+	assert.Equal(t, result.Policies[0].Name, "require-labels")
+
+	recorder = httptest.NewRecorder()
+	handler(recorder, httptest.NewRequest(http.MethodGet, config.PolicyCatalogServicePath+"?namespace=missing", nil))
+	assert.Equal(t, recorder.Code, http.StatusNotFound)
+}
@@ -0,0 +1,58 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/kyverno/kyverno/pkg/config"
+	"gotest.tools/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func Test_ConfigStatus(t *testing.T) {
+	cfg := config.NewDefaultConfiguration(false)
+	cfg.Load(&corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "kyverno", Namespace: "kyverno", ResourceVersion: "7"},
+	})
+	recorder := httptest.NewRecorder()
+	ConfigStatus(cfg)(recorder, httptest.NewRequest(http.MethodGet, config.ConfigStatusPath, nil))
+	assert.Equal(t, recorder.Code, http.StatusOK)
+	assert.Equal(t, recorder.Header().Get("Content-Type"), "application/json")
+	var status config.Status
+	assert.NilError(t, json.Unmarshal(recorder.Body.Bytes(), &status))
+	assert.Equal(t, status.Source, "kyverno/kyverno")
+	assert.Equal(t, status.ResourceVersion, "7")
+	assert.Equal(t, status.Generation, int64(1))
+}
+
+func Test_WithConfigurableDump(t *testing.T) {
+	cfg := config.NewDefaultConfiguration(false)
+	calls := 0
+	var inner AdmissionHandler = func(_ context.Context, _ logr.Logger, request AdmissionRequest, _ time.Time) AdmissionResponse {
+		calls++
+		return AdmissionResponse{UID: request.UID, Allowed: true}
+	}
+	handler := inner.WithConfigurableDump(false, cfg)
+	dryRun := false
+	request := AdmissionRequest{}
+	request.UID = "1"
+	request.RequestKind = &metav1.GroupVersionKind{}
+	request.RequestResource = &metav1.GroupVersionResource{}
+	request.DryRun = &dryRun
+	response := handler(context.TODO(), logr.Discard(), request, time.Now())
+	assert.Assert(t, response.Allowed)
+	cfg.Load(&corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "kyverno", Namespace: "kyverno"},
+		Data:       map[string]string{"dumpPayload": "true"},
+	})
+	assert.Assert(t, cfg.GetDumpPayload())
+	response = handler(context.TODO(), logr.Discard(), request, time.Now())
+	assert.Assert(t, response.Allowed)
+	assert.Equal(t, calls, 2)
+}
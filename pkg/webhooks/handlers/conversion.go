@@ -0,0 +1,64 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/go-logr/logr"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+)
+
+// ConversionHandler converts the objects of a conversion review sent by the API server to a CRD conversion webhook
+type ConversionHandler func(context.Context, logr.Logger, apiextensionsv1.ConversionRequest) apiextensionsv1.ConversionResponse
+
+func (inner ConversionHandler) WithConversion(logger logr.Logger) HttpHandler {
+	return inner.withConversion(logger).WithMetrics(logger).WithTrace("CONVERSION")
+}
+
+func (inner ConversionHandler) withConversion(logger logr.Logger) HttpHandler {
+	return func(writer http.ResponseWriter, request *http.Request) {
+		if request.Body == nil {
+			HttpError(request.Context(), writer, request, logger, errors.New("empty body"), http.StatusBadRequest)
+			return
+		}
+		defer request.Body.Close()
+		// check the content type before reading the body
+		if !isJSONContentType(request.Header.Get("Content-Type")) {
+			HttpError(request.Context(), writer, request, logger, errors.New("invalid Content-Type"), http.StatusUnsupportedMediaType)
+			return
+		}
+		body, err := io.ReadAll(request.Body)
+		if err != nil {
+			HttpError(request.Context(), writer, request, logger, err, readErrorStatus(err))
+			return
+		}
+		var review apiextensionsv1.ConversionReview
+		if err := json.Unmarshal(body, &review); err != nil {
+			HttpError(request.Context(), writer, request, logger, err, http.StatusExpectationFailed)
+			return
+		}
+		if review.Request == nil {
+			HttpError(request.Context(), writer, request, logger, errors.New("empty conversion request"), http.StatusBadRequest)
+			return
+		}
+		logger := logger.WithValues("uid", review.Request.UID, "desiredAPIVersion", review.Request.DesiredAPIVersion, "objects", len(review.Request.Objects))
+		response := inner(request.Context(), logger, *review.Request)
+		// the response must carry the uid of the request
+		response.UID = review.Request.UID
+		review.Request = nil
+		review.Response = &response
+		responseJSON, err := json.Marshal(review)
+		if err != nil {
+			HttpError(request.Context(), writer, request, logger, err, http.StatusInternalServerError)
+			return
+		}
+		writer.Header().Set("Content-Type", "application/json; charset=utf-8")
+		if _, err := writer.Write(responseJSON); err != nil {
+			HttpError(request.Context(), writer, request, logger, err, http.StatusInternalServerError)
+			return
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"gotest.tools/assert"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func Test_WithConversion(t *testing.T) {
+	var handler ConversionHandler = func(_ context.Context, _ logr.Logger, request apiextensionsv1.ConversionRequest) apiextensionsv1.ConversionResponse {
+		return apiextensionsv1.ConversionResponse{
+			ConvertedObjects: []runtime.RawExtension{{Raw: []byte(`{"apiVersion":"` + request.DesiredAPIVersion + `"}`)}},
+			Result:           metav1.Status{Status: metav1.StatusSuccess},
+		}
+	}
+	tests := []struct {
+		name        string
+		body        string
+		contentType string
+		code        int
+	}{{
+		name:        "valid",
+		body:        `{"apiVersion":"apiextensions.k8s.io/v1","kind":"ConversionReview","request":{"uid":"123","desiredAPIVersion":"kyverno.io/v2beta1","objects":[{"apiVersion":"kyverno.io/v1"}]}}`,
+		contentType: "application/json",
+		code:        http.StatusOK,
+	}, {
+		name:        "no request",
+		body:        `{"apiVersion":"apiextensions.k8s.io/v1","kind":"ConversionReview"}`,
+		contentType: "application/json",
+		code:        http.StatusBadRequest,
+	}, {
+		name:        "invalid content type",
+		body:        `{}`,
+		contentType: "text/plain",
+		code:        http.StatusUnsupportedMediaType,
+	}, {
+		name:        "invalid body",
+		body:        `{`,
+		contentType: "application/json",
+		code:        http.StatusExpectationFailed,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request := httptest.NewRequest(http.MethodPost, "/convert", strings.NewReader(tt.body))
+			request.Header.Set("Content-Type", tt.contentType)
+			recorder := httptest.NewRecorder()
+			handler.withConversion(logr.Discard())(recorder, request)
+			assert.Equal(t, recorder.Code, tt.code)
+			if tt.code != http.StatusOK {
+				return
+			}
+			var review apiextensionsv1.ConversionReview
+			assert.NilError(t, json.Unmarshal(recorder.Body.Bytes(), &review))
+			assert.Equal(t, review.APIVersion, "apiextensions.k8s.io/v1")
+			assert.Equal(t, review.Kind, "ConversionReview")
+			assert.Assert(t, review.Request == nil)
+			assert.Assert(t, review.Response != nil)
+			assert.Equal(t, string(review.Response.UID), "123")
+			assert.Equal(t, review.Response.Result.Status, metav1.StatusSuccess)
+			assert.Equal(t, len(review.Response.ConvertedObjects), 1)
+			assert.Equal(t, string(review.Response.ConvertedObjects[0].Raw), `{"apiVersion":"kyverno.io/v2beta1"}`)
+		})
+	}
+}
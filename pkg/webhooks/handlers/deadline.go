@@ -0,0 +1,86 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	admissionutils "github.com/kyverno/kyverno/pkg/utils/admission"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+)
+
+type requestTimeoutKey struct{}
+
+// withRequestTimeout stores the timeout announced by the API server in the context
+func withRequestTimeout(ctx context.Context, timeout time.Duration) context.Context {
+	return context.WithValue(ctx, requestTimeoutKey{}, timeout)
+}
+
+// requestTimeout returns the timeout announced by the API server, if any
+func requestTimeout(ctx context.Context) (time.Duration, bool) {
+	timeout, ok := ctx.Value(requestTimeoutKey{}).(time.Duration)
+	return timeout, ok && timeout > 0
+}
+
+// detachedContext keeps the values of its parent but is not cancelled with it,
+// it allows the evaluation to continue after the http request is done, it is only cancelled when done is closed
+type detachedContext struct {
+	context.Context
+	done <-chan struct{}
+}
+
+func (detachedContext) Deadline() (time.Time, bool) { return time.Time{}, false }
+func (c detachedContext) Done() <-chan struct{}     { return c.done }
+
+func (c detachedContext) Err() error {
+	select {
+	case <-c.done:
+		return context.Canceled
+	default:
+		return nil
+	}
+}
+
+// WithDeadline responds before the API server times out, the response is the one the API server would apply
+// according to the webhook failure policy (allow for Ignore, deny for Fail).
+// The timeout announced by the API server is used when present, defaultTimeout otherwise, and the response is sent margin before it expires.
+// The evaluation is cancelled when the response is sent this way, work it would start once done (e.g. generate rules) is skipped.
+func (inner AdmissionHandler) WithDeadline(defaultTimeout time.Duration, margin time.Duration, failurePolicy admissionregistrationv1.FailurePolicyType) AdmissionHandler {
+	if margin <= 0 {
+		return inner
+	}
+	return inner.withDeadline(defaultTimeout, margin, failurePolicy).WithTrace("DEADLINE")
+}
+
+func (inner AdmissionHandler) withDeadline(defaultTimeout time.Duration, margin time.Duration, failurePolicy admissionregistrationv1.FailurePolicyType) AdmissionHandler {
+	return func(ctx context.Context, logger logr.Logger, request AdmissionRequest, startTime time.Time) AdmissionResponse {
+		timeout := defaultTimeout
+		if t, ok := requestTimeout(ctx); ok {
+			timeout = t
+		}
+		if timeout <= margin {
+			return inner(ctx, logger, request, startTime)
+		}
+		result := make(chan AdmissionResponse, 1)
+		cancel := make(chan struct{})
+		go func() {
+			result <- inner(detachedContext{Context: ctx, done: cancel}, logger, request, startTime)
+		}()
+		timer := time.NewTimer(time.Until(startTime.Add(timeout - margin)))
+		defer timer.Stop()
+		select {
+		case response := <-result:
+			return response
+		case <-timer.C:
+			logger.Info("admission request deadline reached, evaluation is cancelled", "timeout", timeout, "failurePolicy", failurePolicy)
+			close(cancel)
+			message := fmt.Sprintf("kyverno did not evaluate the request within %s", timeout-margin)
+			if failurePolicy == admissionregistrationv1.Fail {
+				return admissionutils.Response(request.UID, errors.New(message))
+			}
+			return admissionutils.ResponseSuccess(request.UID, message+", the request is allowed by the Ignore failure policy")
+		}
+	}
+}
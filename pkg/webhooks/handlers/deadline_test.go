@@ -0,0 +1,67 @@
+package handlers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"gotest.tools/assert"
+	admissionv1 "k8s.io/api/admission/v1"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+)
+
+func Test_WithDeadline(t *testing.T) {
+	tests := []struct {
+		name          string
+		delay         time.Duration
+		timeout       time.Duration
+		failurePolicy admissionregistrationv1.FailurePolicyType
+		allowed       bool
+		warnings      int
+		cancelled     bool
+	}{{
+		name:          "in time",
+		timeout:       time.Second,
+		failurePolicy: admissionregistrationv1.Fail,
+		allowed:       false,
+	}, {
+		name:          "late with ignore",
+		delay:         time.Second,
+		timeout:       200 * time.Millisecond,
+		failurePolicy: admissionregistrationv1.Ignore,
+		allowed:       true,
+		warnings:      1,
+		cancelled:     true,
+	}, {
+		name:          "late with fail",
+		delay:         time.Second,
+		timeout:       200 * time.Millisecond,
+		failurePolicy: admissionregistrationv1.Fail,
+		allowed:       false,
+		cancelled:     true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			done := make(chan error, 1)
+			var inner AdmissionHandler = func(ctx context.Context, _ logr.Logger, request AdmissionRequest, _ time.Time) AdmissionResponse {
+				time.Sleep(tt.delay)
+				done <- ctx.Err()
+				return AdmissionResponse{UID: request.UID, Allowed: false}
+			}
+			ctx, cancel := context.WithCancel(withRequestTimeout(context.TODO(), tt.timeout))
+			handler := inner.withDeadline(10*time.Second, 100*time.Millisecond, tt.failurePolicy)
+			request := AdmissionRequest{AdmissionRequest: admissionv1.AdmissionRequest{UID: "1"}}
+			response := handler(ctx, logr.Discard(), request, time.Now())
+			// the evaluation must survive the end of the http request but not the deadline
+			cancel()
+			assert.Equal(t, response.Allowed, tt.allowed)
+			assert.Equal(t, len(response.Warnings), tt.warnings)
+			if tt.cancelled {
+				assert.Equal(t, <-done, context.Canceled)
+			} else {
+				assert.NilError(t, <-done)
+			}
+		})
+	}
+}
@@ -0,0 +1,102 @@
+package handlers
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/kyverno/kyverno/pkg/metrics"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// inflightRequest is an admission request being evaluated, identical requests wait for its response
+type inflightRequest struct {
+	done      chan struct{}
+	response  AdmissionResponse
+	completed bool
+}
+
+// WithDeduplication coalesces identical admission requests received while one of them is evaluated,
+// the request is evaluated once and its response is returned to all of them (with their own UID).
+// Requests are identical when everything but their UID is equal (operation, resource, user, objects and options).
+func (inner AdmissionHandler) WithDeduplication(logger logr.Logger, enabled bool, attrs ...attribute.KeyValue) AdmissionHandler {
+	if !enabled {
+		return inner
+	}
+	return inner.withDeduplication(logger, attrs...).WithTrace("DEDUPLICATION")
+}
+
+func (inner AdmissionHandler) withDeduplication(logger logr.Logger, attrs ...attribute.KeyValue) AdmissionHandler {
+	meter := otel.GetMeterProvider().Meter(metrics.MeterName)
+	deduplicatedMetric, err := meter.Int64Counter(
+		"kyverno_admission_requests_deduplicated",
+		metric.WithDescription("can be used to track the number of admission requests answered with the response of an identical request evaluated concurrently"),
+	)
+	if err != nil {
+		logger.Error(err, "Failed to create instrument, kyverno_admission_requests_deduplicated_total")
+	}
+	var lock sync.Mutex
+	inflight := map[string]*inflightRequest{}
+	return func(ctx context.Context, logger logr.Logger, request AdmissionRequest, startTime time.Time) AdmissionResponse {
+		key, err := deduplicationKey(request)
+		if err != nil {
+			return inner(ctx, logger, request, startTime)
+		}
+		lock.Lock()
+		if leader, ok := inflight[key]; ok {
+			lock.Unlock()
+			select {
+			case <-leader.done:
+				if !leader.completed {
+					return inner(ctx, logger, request, startTime)
+				}
+				logger.V(4).Info("admission request deduplicated")
+				if deduplicatedMetric != nil {
+					attributes := []attribute.KeyValue{
+						attribute.String("resource_kind", request.Kind.Kind),
+						attribute.String("resource_namespace", request.Namespace),
+						attribute.String("resource_request_operation", strings.ToLower(string(request.Operation))),
+					}
+					deduplicatedMetric.Add(ctx, 1, metric.WithAttributes(append(attributes, attrs...)...))
+				}
+				response := *leader.response.DeepCopy()
+				response.UID = request.UID
+				return response
+			case <-ctx.Done():
+				return inner(ctx, logger, request, startTime)
+			}
+		}
+		current := &inflightRequest{done: make(chan struct{})}
+		inflight[key] = current
+		lock.Unlock()
+		defer func() {
+			lock.Lock()
+			delete(inflight, key)
+			lock.Unlock()
+			close(current.done)
+		}()
+		// identical requests rely on the evaluation, it must not be cancelled if this request is
+		current.response = inner(detachedContext{Context: ctx}, logger, request, startTime)
+		current.completed = true
+		return current.response
+	}
+}
+
+// deduplicationKey returns a hash of the admission request without its UID
+func deduplicationKey(request AdmissionRequest) (string, error) {
+	admissionRequest := request.AdmissionRequest
+	admissionRequest.UID = ""
+	data, err := json.Marshal(admissionRequest)
+	if err != nil {
+		return "", err
+	}
+	hash := sha256.Sum256(data)
+	return hex.EncodeToString(hash[:]), nil
+}
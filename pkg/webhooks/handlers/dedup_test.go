@@ -0,0 +1,82 @@
+package handlers
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"gotest.tools/assert"
+	admissionv1 "k8s.io/api/admission/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func newDedupRequest(uid string, object string) AdmissionRequest {
+	return AdmissionRequest{AdmissionRequest: admissionv1.AdmissionRequest{
+		UID:       types.UID(uid),
+		Operation: admissionv1.Create,
+		Namespace: "default",
+		Name:      "test",
+		Object:    runtime.RawExtension{Raw: []byte(object)},
+	}}
+}
+
+func Test_WithDeduplication(t *testing.T) {
+	var calls atomic.Int32
+	release := make(chan struct{})
+	var inner AdmissionHandler = func(_ context.Context, _ logr.Logger, request AdmissionRequest, _ time.Time) AdmissionResponse {
+		calls.Add(1)
+		<-release
+		return AdmissionResponse{UID: request.UID, Allowed: true, Warnings: []string{"evaluated"}}
+	}
+	handler := inner.withDeduplication(logr.Discard())
+	requests := []AdmissionRequest{
+		newDedupRequest("1", `{"kind":"Pod"}`),
+		newDedupRequest("2", `{"kind":"Pod"}`),
+		newDedupRequest("3", `{"kind":"Pod"}`),
+	}
+	responses := make([]AdmissionResponse, len(requests))
+	var wg sync.WaitGroup
+	// start the first request and wait for it to be evaluated before sending the identical ones
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		responses[0] = handler(context.TODO(), logr.Discard(), requests[0], time.Now())
+	}()
+	for calls.Load() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	for i := 1; i < len(requests); i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			responses[i] = handler(context.TODO(), logr.Discard(), requests[i], time.Now())
+		}(i)
+	}
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+	assert.Equal(t, calls.Load(), int32(1))
+	for i, response := range responses {
+		assert.Equal(t, response.UID, requests[i].UID)
+		assert.Assert(t, response.Allowed)
+		assert.DeepEqual(t, response.Warnings, []string{"evaluated"})
+	}
+	// requests received after the evaluation are evaluated again
+	handler(context.TODO(), logr.Discard(), requests[1], time.Now())
+	assert.Equal(t, calls.Load(), int32(2))
+}
+
+func Test_deduplicationKey(t *testing.T) {
+	key1, err := deduplicationKey(newDedupRequest("1", `{"kind":"Pod"}`))
+	assert.NilError(t, err)
+	key2, err := deduplicationKey(newDedupRequest("2", `{"kind":"Pod"}`))
+	assert.NilError(t, err)
+	key3, err := deduplicationKey(newDedupRequest("1", `{"kind":"Service"}`))
+	assert.NilError(t, err)
+	assert.Equal(t, key1, key2)
+	assert.Assert(t, key1 != key3)
+}
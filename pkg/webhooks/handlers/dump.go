@@ -6,7 +6,7 @@ import (
 	"time"
 
 	"github.com/go-logr/logr"
-	admissionutils "github.com/kyverno/kyverno/pkg/utils/admission"
+	"github.com/kyverno/kyverno/pkg/config"
 	kubeutils "github.com/kyverno/kyverno/pkg/utils/kube"
 	authenticationv1 "k8s.io/api/authentication/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -23,6 +23,24 @@ func (inner AdmissionHandler) WithDump(
 	return inner.withDump().WithTrace("DUMP")
 }
 
+// WithConfigurableDump dumps the payload when enabled or when the configuration enables it,
+// the configuration is checked on every request so that it can be changed without a restart
+func (inner AdmissionHandler) WithConfigurableDump(
+	enabled bool,
+	configuration config.Configuration,
+) AdmissionHandler {
+	if enabled || configuration == nil {
+		return inner.WithDump(enabled)
+	}
+	dump := inner.withDump().WithTrace("DUMP")
+	return func(ctx context.Context, logger logr.Logger, request AdmissionRequest, startTime time.Time) AdmissionResponse {
+		if configuration.GetDumpPayload() {
+			return dump(ctx, logger, request, startTime)
+		}
+		return inner(ctx, logger, request, startTime)
+	}
+}
+
 func (inner AdmissionHandler) withDump() AdmissionHandler {
 	return func(ctx context.Context, logger logr.Logger, request AdmissionRequest, startTime time.Time) AdmissionResponse {
 		response := inner(ctx, logger, request, startTime)
@@ -69,7 +87,7 @@ type admissionRequestPayload struct {
 func newAdmissionRequestPayload(
 	request AdmissionRequest,
 ) (*admissionRequestPayload, error) {
-	newResource, oldResource, err := admissionutils.ExtractResources(nil, request.AdmissionRequest)
+	newResource, oldResource, err := request.decodeResources()
 	if err != nil {
 		return nil, err
 	}
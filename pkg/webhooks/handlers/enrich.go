@@ -6,6 +6,7 @@ import (
 
 	"github.com/go-logr/logr"
 	"github.com/kyverno/kyverno/pkg/clients/dclient"
+	"github.com/kyverno/kyverno/pkg/config"
 	"github.com/kyverno/kyverno/pkg/userinfo"
 	admissionutils "github.com/kyverno/kyverno/pkg/utils/admission"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -19,12 +20,33 @@ func (inner AdmissionHandler) WithRoles(
 	return inner.withRoles(rbLister, crbLister).WithTrace("ROLES")
 }
 
+func (inner AdmissionHandler) WithGroups(
+	configuration config.Configuration,
+) AdmissionHandler {
+	return inner.withGroups(configuration).WithTrace("GROUPS")
+}
+
 func (inner AdmissionHandler) WithTopLevelGVK(
 	client dclient.IDiscovery,
 ) AdmissionHandler {
 	return inner.withTopLevelGVK(client).WithTrace("GVK")
 }
 
+func (inner AdmissionHandler) withGroups(
+	configuration config.Configuration,
+) AdmissionHandler {
+	return func(ctx context.Context, logger logr.Logger, request AdmissionRequest, startTime time.Time) AdmissionResponse {
+		groups := configuration.ExpandGroups(request.UserInfo.Username, request.UserInfo.Groups)
+		if len(groups) != len(request.UserInfo.Groups) {
+			request.UserInfo.Groups = groups
+			logger = logger.WithValues(
+				"groups", groups,
+			)
+		}
+		return inner(ctx, logger, request, startTime)
+	}
+}
+
 func (inner AdmissionHandler) withRoles(
 	rbLister rbacv1listers.RoleBindingLister,
 	crbLister rbacv1listers.ClusterRoleBindingLister,
@@ -11,10 +11,11 @@ import (
 	webhookutils "github.com/kyverno/kyverno/pkg/webhooks/utils"
 	admissionv1 "k8s.io/api/admission/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
+	corev1listers "k8s.io/client-go/listers/core/v1"
 )
 
-func (inner AdmissionHandler) WithFilter(configuration config.Configuration) AdmissionHandler {
-	return inner.withFilter(configuration).WithTrace("FILTER")
+func (inner AdmissionHandler) WithFilter(configuration config.Configuration, nsLister corev1listers.NamespaceLister) AdmissionHandler {
+	return inner.withFilter(configuration, nsLister).WithTrace("FILTER")
 }
 
 func (inner AdmissionHandler) WithOperationFilter(operations ...admissionv1.Operation) AdmissionHandler {
@@ -31,7 +32,7 @@ func filtered(ctx context.Context, logger logr.Logger, request AdmissionRequest,
 	return admissionutils.ResponseSuccess(request.UID)
 }
 
-func (inner AdmissionHandler) withFilter(c config.Configuration) AdmissionHandler {
+func (inner AdmissionHandler) withFilter(c config.Configuration, nsLister corev1listers.NamespaceLister) AdmissionHandler {
 	return func(ctx context.Context, logger logr.Logger, request AdmissionRequest, startTime time.Time) AdmissionResponse {
 		// filter by exclusions/inclusions
 		if c.IsExcluded(request.UserInfo.Username, request.UserInfo.Groups, request.Roles, request.ClusterRoles) {
@@ -41,6 +42,13 @@ func (inner AdmissionHandler) withFilter(c config.Configuration) AdmissionHandle
 		if c.ToFilter(request.GroupVersionKind, request.SubResource, request.Namespace, request.Name) {
 			return filtered(ctx, logger, request, "admission request filtered because it apears in configmap resource filters")
 		}
+		// filter by resource filter selectors, labels are only looked up when selectors are configured
+		if c.HasResourceFilterSelectors() {
+			namespaceLabels, objectLabels := requestLabels(logger, nsLister, &request)
+			if c.ToFilterRequest(request.GroupVersionKind, request.SubResource, string(request.Operation), request.Namespace, request.Name, namespaceLabels, objectLabels) {
+				return filtered(ctx, logger, request, "admission request filtered because it matches configmap resource filter selectors")
+			}
+		}
 		// filter kyverno resources
 		if webhookutils.ExcludeKyvernoResources(request.Kind.Kind) {
 			return filtered(ctx, logger, request, "admission request filtered because it is for a kyverno resource")
@@ -71,3 +79,29 @@ func (inner AdmissionHandler) withSubResourceFilter(subresources ...string) Admi
 		return filtered(ctx, logger, request, "admission request filtered because subresource is excluded")
 	}
 }
+
+// requestLabels returns the labels of the namespace and of the object of an admission request,
+// the object labels are taken from the old object for delete requests
+func requestLabels(logger logr.Logger, nsLister corev1listers.NamespaceLister, request *AdmissionRequest) (map[string]string, map[string]string) {
+	var objectLabels map[string]string
+	if newResource, oldResource, err := request.decodeResources(); err != nil {
+		logger.V(4).Info("failed to decode object", "error", err.Error())
+	} else if request.Operation == admissionv1.Delete {
+		objectLabels = oldResource.GetLabels()
+	} else {
+		objectLabels = newResource.GetLabels()
+	}
+	gvk := request.GroupVersionKind
+	if gvk.Group == "" && gvk.Version == "v1" && gvk.Kind == "Namespace" {
+		return objectLabels, objectLabels
+	}
+	if request.Namespace == "" || nsLister == nil {
+		return nil, objectLabels
+	}
+	namespace, err := nsLister.Get(request.Namespace)
+	if err != nil {
+		logger.V(4).Info("failed to get namespace", "namespace", request.Namespace, "error", err.Error())
+		return nil, objectLabels
+	}
+	return namespace.GetLabels(), objectLabels
+}
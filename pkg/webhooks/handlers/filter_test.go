@@ -0,0 +1,131 @@
+package handlers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/kyverno/kyverno/pkg/config"
+	admissionutils "github.com/kyverno/kyverno/pkg/utils/admission"
+	"gotest.tools/assert"
+	admissionv1 "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+)
+
+type countingNamespaceLister struct {
+	corev1listers.NamespaceLister
+	labels map[string]string
+	calls  int
+}
+
+func (l *countingNamespaceLister) Get(name string) (*corev1.Namespace, error) {
+	l.calls++
+	return &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: l.labels}}, nil
+}
+
+func (l *countingNamespaceLister) List(labels.Selector) ([]*corev1.Namespace, error) {
+	return nil, nil
+}
+
+func Test_withFilter(t *testing.T) {
+	object := []byte(`{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"test","namespace":"default","labels":{"skip":"true"}}}`)
+	tests := []struct {
+		name         string
+		data         map[string]string
+		wantFiltered bool
+		wantLookups  int
+	}{{
+		name:         "no selectors",
+		wantFiltered: false,
+		wantLookups:  0,
+	}, {
+		name:         "matching selector",
+		data:         map[string]string{"resourceFilterSelectors": `[{"objectSelector":{"matchLabels":{"skip":"true"}}}]`},
+		wantFiltered: true,
+		wantLookups:  1,
+	}, {
+		name:         "not matching selector",
+		data:         map[string]string{"resourceFilterSelectors": `[{"objectSelector":{"matchLabels":{"skip":"false"}}}]`},
+		wantFiltered: false,
+		wantLookups:  1,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			configuration := config.NewDefaultConfiguration(false)
+			configuration.Load(&corev1.ConfigMap{Data: tt.data})
+			nsLister := &countingNamespaceLister{labels: map[string]string{"env": "test"}}
+			called := false
+			var inner AdmissionHandler = func(_ context.Context, _ logr.Logger, request AdmissionRequest, _ time.Time) AdmissionResponse {
+				called = true
+				return admissionutils.ResponseSuccess(request.UID)
+			}
+			request := AdmissionRequest{
+				AdmissionRequest: admissionv1.AdmissionRequest{
+					Kind:      metav1.GroupVersionKind{Version: "v1", Kind: "ConfigMap"},
+					Operation: admissionv1.Create,
+					Namespace: "default",
+					Name:      "test",
+					Object:    runtime.RawExtension{Raw: object},
+				},
+				GroupVersionKind: schema.GroupVersionKind{Version: "v1", Kind: "ConfigMap"},
+			}
+			response := inner.withFilter(configuration, nsLister)(context.TODO(), logr.Discard(), request, time.Now())
+			assert.Assert(t, response.Allowed)
+			assert.Equal(t, called, !tt.wantFiltered)
+			assert.Equal(t, nsLister.calls, tt.wantLookups)
+		})
+	}
+}
+
+func Test_requestLabels(t *testing.T) {
+	object := []byte(`{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"test","namespace":"default","labels":{"state":"new"}}}`)
+	oldObject := []byte(`{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"test","namespace":"default","labels":{"state":"old"}}}`)
+	tests := []struct {
+		name      string
+		operation admissionv1.Operation
+		object    []byte
+		oldObject []byte
+		want      string
+	}{{
+		name:      "create",
+		operation: admissionv1.Create,
+		object:    object,
+		want:      "new",
+	}, {
+		name:      "update",
+		operation: admissionv1.Update,
+		object:    object,
+		oldObject: oldObject,
+		want:      "new",
+	}, {
+		name:      "delete",
+		operation: admissionv1.Delete,
+		oldObject: oldObject,
+		want:      "old",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nsLister := &countingNamespaceLister{labels: map[string]string{"env": "test"}}
+			request := AdmissionRequest{
+				AdmissionRequest: admissionv1.AdmissionRequest{
+					Operation: tt.operation,
+					Namespace: "default",
+					Object:    runtime.RawExtension{Raw: tt.object},
+					OldObject: runtime.RawExtension{Raw: tt.oldObject},
+				},
+				GroupVersionKind: schema.GroupVersionKind{Version: "v1", Kind: "ConfigMap"},
+			}
+			namespaceLabels, objectLabels := requestLabels(logr.Discard(), nsLister, &request)
+			assert.Equal(t, namespaceLabels["env"], "test")
+			assert.Equal(t, objectLabels["state"], tt.want)
+			// the decoded resources are kept on the request
+			assert.Assert(t, request.resources != nil)
+		})
+	}
+}
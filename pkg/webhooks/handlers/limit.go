@@ -0,0 +1,41 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"mime"
+	"net/http"
+)
+
+// WithMaxRequestBytes rejects requests with a body larger than limit bytes with a 413 status, no limit is applied when limit is not positive
+func (inner HttpHandler) WithMaxRequestBytes(limit int64) HttpHandler {
+	if limit <= 0 {
+		return inner
+	}
+	return func(writer http.ResponseWriter, request *http.Request) {
+		// fail early when the announced size is too large, the body is still limited for chunked requests
+		if request.ContentLength > limit {
+			http.Error(writer, fmt.Sprintf("request body too large, limit is %d bytes", limit), http.StatusRequestEntityTooLarge)
+			return
+		}
+		if request.Body != nil {
+			request.Body = http.MaxBytesReader(writer, request.Body, limit)
+		}
+		inner(writer, request)
+	}
+}
+
+// readErrorStatus returns the http status for an error returned when reading a request body
+func readErrorStatus(err error) int {
+	var maxBytesError *http.MaxBytesError
+	if errors.As(err, &maxBytesError) {
+		return http.StatusRequestEntityTooLarge
+	}
+	return http.StatusBadRequest
+}
+
+// isJSONContentType returns true if the content type is application/json, parameters (e.g. charset) are allowed
+func isJSONContentType(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	return err == nil && mediaType == "application/json"
+}
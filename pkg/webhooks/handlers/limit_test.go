@@ -0,0 +1,80 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"gotest.tools/assert"
+)
+
+func Test_WithMaxRequestBytes(t *testing.T) {
+	var inner AdmissionHandler = func(_ context.Context, _ logr.Logger, request AdmissionRequest, _ time.Time) AdmissionResponse {
+		return AdmissionResponse{UID: request.UID, Allowed: true}
+	}
+	body := `{"apiVersion":"admission.k8s.io/v1","kind":"AdmissionReview","request":{"uid":"1","operation":"CREATE","name":"test"}}`
+	tests := []struct {
+		name          string
+		limit         int64
+		contentType   string
+		contentLength int64
+		want          int
+	}{{
+		name:          "no limit",
+		limit:         0,
+		contentType:   "application/json",
+		contentLength: int64(len(body)),
+		want:          http.StatusOK,
+	}, {
+		name:          "within limit",
+		limit:         int64(len(body)),
+		contentType:   "application/json",
+		contentLength: int64(len(body)),
+		want:          http.StatusOK,
+	}, {
+		name:          "content length above limit",
+		limit:         16,
+		contentType:   "application/json",
+		contentLength: int64(len(body)),
+		want:          http.StatusRequestEntityTooLarge,
+	}, {
+		name:          "unknown content length above limit",
+		limit:         16,
+		contentType:   "application/json",
+		contentLength: -1,
+		want:          http.StatusRequestEntityTooLarge,
+	}, {
+		name:          "charset parameter",
+		limit:         0,
+		contentType:   "application/json; charset=utf-8",
+		contentLength: int64(len(body)),
+		want:          http.StatusOK,
+	}, {
+		name:          "invalid content type",
+		limit:         0,
+		contentType:   "text/plain",
+		contentLength: int64(len(body)),
+		want:          http.StatusUnsupportedMediaType,
+	}, {
+		name:          "missing content type",
+		limit:         0,
+		contentLength: int64(len(body)),
+		want:          http.StatusUnsupportedMediaType,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request := httptest.NewRequest(http.MethodPost, "/validate", strings.NewReader(body))
+			request.ContentLength = tt.contentLength
+			if tt.contentType != "" {
+				request.Header.Set("Content-Type", tt.contentType)
+			}
+			recorder := httptest.NewRecorder()
+			inner.withAdmission(logr.Discard()).WithMaxRequestBytes(tt.limit)(recorder, request)
+			assert.Equal(t, recorder.Code, tt.want)
+		})
+	}
+}
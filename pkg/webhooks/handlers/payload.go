@@ -0,0 +1,59 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/go-logr/logr"
+	"github.com/kyverno/kyverno/pkg/jsonpayload"
+)
+
+// PayloadHandler validates an arbitrary JSON payload sent to the json validation endpoint
+type PayloadHandler func(context.Context, logr.Logger, jsonpayload.Request) jsonpayload.Response
+
+func (inner PayloadHandler) WithPayload(logger logr.Logger) HttpHandler {
+	return inner.withPayload(logger).WithMetrics(logger).WithTrace("PAYLOAD")
+}
+
+func (inner PayloadHandler) withPayload(logger logr.Logger) HttpHandler {
+	return func(writer http.ResponseWriter, request *http.Request) {
+		if request.Body == nil {
+			HttpError(request.Context(), writer, request, logger, errors.New("empty body"), http.StatusBadRequest)
+			return
+		}
+		defer request.Body.Close()
+		// check the content type before reading the body
+		if !isJSONContentType(request.Header.Get("Content-Type")) {
+			HttpError(request.Context(), writer, request, logger, errors.New("invalid Content-Type"), http.StatusUnsupportedMediaType)
+			return
+		}
+		body, err := io.ReadAll(request.Body)
+		if err != nil {
+			HttpError(request.Context(), writer, request, logger, err, readErrorStatus(err))
+			return
+		}
+		var payloadRequest jsonpayload.Request
+		if err := json.Unmarshal(body, &payloadRequest); err != nil {
+			HttpError(request.Context(), writer, request, logger, err, http.StatusExpectationFailed)
+			return
+		}
+		if err := payloadRequest.Validate(); err != nil {
+			HttpError(request.Context(), writer, request, logger, err, http.StatusBadRequest)
+			return
+		}
+		logger := logger.WithValues("kind", payloadRequest.Kind, "namespace", payloadRequest.Namespace, "name", payloadRequest.Name)
+		responseJSON, err := json.Marshal(inner(request.Context(), logger, payloadRequest))
+		if err != nil {
+			HttpError(request.Context(), writer, request, logger, err, http.StatusInternalServerError)
+			return
+		}
+		writer.Header().Set("Content-Type", "application/json; charset=utf-8")
+		if _, err := writer.Write(responseJSON); err != nil {
+			HttpError(request.Context(), writer, request, logger, err, http.StatusInternalServerError)
+			return
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/kyverno/kyverno/pkg/jsonpayload"
+	"gotest.tools/assert"
+)
+
+func Test_WithPayload(t *testing.T) {
+	var handler PayloadHandler = func(_ context.Context, _ logr.Logger, request jsonpayload.Request) jsonpayload.Response {
+		payload, _ := request.Payload.(map[string]interface{})
+		if payload["public"] == true {
+			return jsonpayload.Response{Message: "public buckets are not allowed"}
+		}
+		return jsonpayload.Response{Allowed: true}
+	}
+	tests := []struct {
+		name        string
+		body        string
+		contentType string
+		code        int
+		allowed     bool
+	}{{
+		name:        "allowed",
+		body:        `{"kind":"Bucket","name":"logs","payload":{"public":false}}`,
+		contentType: "application/json",
+		code:        http.StatusOK,
+		allowed:     true,
+	}, {
+		name:        "denied",
+		body:        `{"kind":"Bucket","name":"logs","payload":{"public":true}}`,
+		contentType: "application/json",
+		code:        http.StatusOK,
+	}, {
+		name:        "missing kind",
+		body:        `{"payload":{}}`,
+		contentType: "application/json",
+		code:        http.StatusBadRequest,
+	}, {
+		name:        "missing payload",
+		body:        `{"kind":"Bucket"}`,
+		contentType: "application/json",
+		code:        http.StatusBadRequest,
+	}, {
+		name:        "invalid content type",
+		body:        `{}`,
+		contentType: "text/plain",
+		code:        http.StatusUnsupportedMediaType,
+	}, {
+		name:        "invalid body",
+		body:        `{`,
+		contentType: "application/json",
+		code:        http.StatusExpectationFailed,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request := httptest.NewRequest(http.MethodPost, "/json/validate", strings.NewReader(tt.body))
+			request.Header.Set("Content-Type", tt.contentType)
+			recorder := httptest.NewRecorder()
+			handler.withPayload(logr.Discard())(recorder, request)
+			assert.Equal(t, recorder.Code, tt.code)
+			if tt.code != http.StatusOK {
+				return
+			}
+			var response jsonpayload.Response
+			assert.NilError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+			assert.Equal(t, response.Allowed, tt.allowed)
+		})
+	}
+}
@@ -0,0 +1,36 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+func Test_Probe(t *testing.T) {
+	tests := []struct {
+		name  string
+		check func(context.Context) bool
+		want  int
+	}{{
+		name: "no check",
+		want: http.StatusOK,
+	}, {
+		name:  "check succeeds",
+		check: func(context.Context) bool { return true },
+		want:  http.StatusOK,
+	}, {
+		name:  "check fails",
+		check: func(context.Context) bool { return false },
+		want:  http.StatusInternalServerError,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			Probe(tt.check)(recorder, httptest.NewRequest(http.MethodGet, "/health/liveness", nil))
+			assert.Equal(t, recorder.Code, tt.want)
+		})
+	}
+}
@@ -19,20 +19,24 @@ const namespaceControllerUsername = "system:serviceaccount:kube-system:namespace
 
 var kyvernoUsernamePrefix = fmt.Sprintf("system:serviceaccount:%s:", config.KyvernoNamespace())
 
-func (inner AdmissionHandler) WithProtection(enabled bool) AdmissionHandler {
+func (inner AdmissionHandler) WithProtection(enabled bool, configuration config.Configuration) AdmissionHandler {
 	if !enabled {
 		return inner
 	}
-	return inner.withProtection().WithTrace("PROTECT")
+	return inner.withProtection(configuration).WithTrace("PROTECT")
 }
 
-func (inner AdmissionHandler) withProtection() AdmissionHandler {
+func (inner AdmissionHandler) withProtection(configuration config.Configuration) AdmissionHandler {
 	return func(ctx context.Context, logger logr.Logger, request AdmissionRequest, startTime time.Time) AdmissionResponse {
 		// Allows deletion of namespace containing managed resources
 		if request.Operation == admissionv1.Delete && request.UserInfo.Username == namespaceControllerUsername {
 			return inner(ctx, logger, request, startTime)
 		}
-		newResource, oldResource, err := admissionutils.ExtractResources(nil, request.AdmissionRequest)
+		// kyverno and allowed identities can modify managed resources
+		if strings.HasPrefix(request.UserInfo.Username, kyvernoUsernamePrefix) || configuration.IsAllowedToModifyManagedResources(request.UserInfo.Username, request.UserInfo.Groups) {
+			return inner(ctx, logger, request, startTime)
+		}
+		newResource, oldResource, err := request.decodeResources()
 		if err != nil {
 			logger.Error(err, "failed to extract resources")
 			return admissionutils.Response(request.UID, err)
@@ -40,10 +44,14 @@ func (inner AdmissionHandler) withProtection() AdmissionHandler {
 		for _, resource := range []unstructured.Unstructured{newResource, oldResource} {
 			resLabels := resource.GetLabels()
 			if resLabels[kyverno.LabelAppManagedBy] == kyverno.ValueKyvernoApp {
-				if !strings.HasPrefix(request.UserInfo.Username, kyvernoUsernamePrefix) {
-					logger.V(2).Info("access to the resource not authorized, this is a kyverno managed resource and should be altered only by kyverno")
-					return admissionutils.Response(request.UID, errors.New("A kyverno managed resource can only be modified by kyverno"))
+				if until := configuration.GetManagedResourcesBreakGlassUntil(); time.Now().Before(until) {
+					logger.Info("break-glass: allowing modification of a kyverno managed resource", "user", request.UserInfo.Username, "until", until)
+					response := inner(ctx, logger, request, startTime)
+					response.Warnings = append(response.Warnings, fmt.Sprintf("kyverno managed resource modified during break-glass window (until %s)", until.Format(time.RFC3339)))
+					return response
 				}
+				logger.V(2).Info("access to the resource not authorized, this is a kyverno managed resource and should be altered only by kyverno")
+				return admissionutils.Response(request.UID, errors.New("A kyverno managed resource can only be modified by kyverno"))
 			}
 		}
 		return inner(ctx, logger, request, startTime)
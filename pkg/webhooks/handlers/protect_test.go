@@ -0,0 +1,109 @@
+package handlers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/kyverno/kyverno/api/kyverno"
+	"github.com/kyverno/kyverno/pkg/config"
+	admissionutils "github.com/kyverno/kyverno/pkg/utils/admission"
+	"gotest.tools/assert"
+	admissionv1 "k8s.io/api/admission/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func Test_withProtection(t *testing.T) {
+	managed := []byte(`{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"test","namespace":"default","labels":{"app.kubernetes.io/managed-by":"kyverno"}}}`)
+	unmanaged := []byte(`{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"test","namespace":"default"}}`)
+	tests := []struct {
+		name        string
+		data        map[string]string
+		annotations map[string]string
+		username    string
+		groups      []string
+		object      []byte
+		wantAllowed bool
+		wantWarning bool
+	}{{
+		name:        "unmanaged resource",
+		username:    "alice",
+		object:      unmanaged,
+		wantAllowed: true,
+	}, {
+		name:        "managed resource",
+		username:    "alice",
+		object:      managed,
+		wantAllowed: false,
+	}, {
+		name:        "kyverno service account",
+		username:    "system:serviceaccount:" + config.KyvernoNamespace() + ":kyverno",
+		object:      managed,
+		wantAllowed: true,
+	}, {
+		name:        "allowed username",
+		data:        map[string]string{"managedResourcesAllowedUsernames": "bob,ali*"},
+		username:    "alice",
+		object:      managed,
+		wantAllowed: true,
+	}, {
+		name:        "allowed group",
+		data:        map[string]string{"managedResourcesAllowedGroups": "platform-admins"},
+		username:    "alice",
+		groups:      []string{"platform-admins"},
+		object:      managed,
+		wantAllowed: true,
+	}, {
+		name:        "allowed service account",
+		data:        map[string]string{"managedResourcesAllowedServiceAccounts": "argocd:*"},
+		username:    "system:serviceaccount:argocd:argocd-application-controller",
+		object:      managed,
+		wantAllowed: true,
+	}, {
+		name:        "active break-glass window",
+		annotations: map[string]string{kyverno.AnnotationManagedResourcesBreakGlass: time.Now().Add(time.Hour).Format(time.RFC3339)},
+		username:    "alice",
+		object:      managed,
+		wantAllowed: true,
+		wantWarning: true,
+	}, {
+		name:        "expired break-glass window",
+		annotations: map[string]string{kyverno.AnnotationManagedResourcesBreakGlass: time.Now().Add(-time.Hour).Format(time.RFC3339)},
+		username:    "alice",
+		object:      managed,
+		wantAllowed: false,
+	}, {
+		name:        "break-glass window too long",
+		annotations: map[string]string{kyverno.AnnotationManagedResourcesBreakGlass: time.Now().Add(48 * time.Hour).Format(time.RFC3339)},
+		username:    "alice",
+		object:      managed,
+		wantAllowed: false,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			configuration := config.NewDefaultConfiguration(false)
+			configuration.Load(&corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Annotations: tt.annotations},
+				Data:       tt.data,
+			})
+			var inner AdmissionHandler = func(_ context.Context, _ logr.Logger, request AdmissionRequest, _ time.Time) AdmissionResponse {
+				return admissionutils.ResponseSuccess(request.UID)
+			}
+			request := AdmissionRequest{
+				AdmissionRequest: admissionv1.AdmissionRequest{
+					Operation: admissionv1.Update,
+					UserInfo:  authenticationv1.UserInfo{Username: tt.username, Groups: tt.groups},
+					Object:    runtime.RawExtension{Raw: tt.object},
+					OldObject: runtime.RawExtension{Raw: tt.object},
+				},
+			}
+			response := inner.withProtection(configuration)(context.TODO(), logr.Discard(), request, time.Now())
+			assert.Equal(t, response.Allowed, tt.wantAllowed)
+			assert.Equal(t, len(response.Warnings) != 0, tt.wantWarning)
+		})
+	}
+}
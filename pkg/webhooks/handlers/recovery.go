@@ -0,0 +1,78 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"runtime/debug"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/kyverno/kyverno/pkg/metrics"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// WithRecovery recovers from panics raised while evaluating an admission request, the panic is logged with its stack trace
+// and the response is the one the API server would apply according to the webhook failure policy (allow for Ignore, deny for Fail).
+// Denied requests get an internal error status (500).
+func (inner AdmissionHandler) WithRecovery(logger logr.Logger, failurePolicy admissionregistrationv1.FailurePolicyType, attrs ...attribute.KeyValue) AdmissionHandler {
+	return inner.withRecovery(logger, failurePolicy, attrs...).WithTrace("RECOVERY")
+}
+
+func (inner AdmissionHandler) withRecovery(logger logr.Logger, failurePolicy admissionregistrationv1.FailurePolicyType, attrs ...attribute.KeyValue) AdmissionHandler {
+	meter := otel.GetMeterProvider().Meter(metrics.MeterName)
+	panicsMetric, err := meter.Int64Counter(
+		"kyverno_admission_panics",
+		metric.WithDescription("can be used to track the number of admission requests that caused a panic, the requests are answered according to the webhook failure policy"),
+	)
+	if err != nil {
+		logger.Error(err, "Failed to create instrument, kyverno_admission_panics_total")
+	}
+	return func(ctx context.Context, logger logr.Logger, request AdmissionRequest, startTime time.Time) (response AdmissionResponse) {
+		defer func() {
+			recovered := recover()
+			if recovered == nil {
+				return
+			}
+			logger.Error(fmt.Errorf("%v", recovered), "panic while processing admission request", "uid", request.UID, "failurePolicy", failurePolicy, "stack", string(debug.Stack()))
+			if panicsMetric != nil {
+				attributes := []attribute.KeyValue{
+					attribute.String("resource_kind", request.Kind.Kind),
+					attribute.String("resource_namespace", request.Namespace),
+					attribute.String("resource_request_operation", strings.ToLower(string(request.Operation))),
+					attribute.String("failure_policy", string(failurePolicy)),
+				}
+				panicsMetric.Add(ctx, 1, metric.WithAttributes(append(attributes, attrs...)...))
+			}
+			response = recoveryResponse(request, failurePolicy)
+		}()
+		return inner(ctx, logger, request, startTime)
+	}
+}
+
+// recoveryResponse returns the response to an admission request that caused a panic
+func recoveryResponse(request AdmissionRequest, failurePolicy admissionregistrationv1.FailurePolicyType) AdmissionResponse {
+	message := fmt.Sprintf("kyverno failed to process the request (uid %s) due to an internal error", request.UID)
+	if failurePolicy == admissionregistrationv1.Ignore {
+		return AdmissionResponse{
+			UID:      request.UID,
+			Allowed:  true,
+			Warnings: []string{message + ", the request is allowed by the Ignore failure policy"},
+		}
+	}
+	return AdmissionResponse{
+		UID:     request.UID,
+		Allowed: false,
+		Result: &metav1.Status{
+			Status:  metav1.StatusFailure,
+			Message: message,
+			Reason:  metav1.StatusReasonInternalError,
+			Code:    http.StatusInternalServerError,
+		},
+	}
+}
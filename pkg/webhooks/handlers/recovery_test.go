@@ -0,0 +1,62 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"gotest.tools/assert"
+	admissionv1 "k8s.io/api/admission/v1"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+)
+
+func Test_WithRecovery(t *testing.T) {
+	tests := []struct {
+		name          string
+		panics        bool
+		failurePolicy admissionregistrationv1.FailurePolicyType
+		allowed       bool
+		warnings      int
+		code          int32
+	}{{
+		name:          "no panic",
+		failurePolicy: admissionregistrationv1.Fail,
+		allowed:       true,
+	}, {
+		name:          "panic with fail",
+		panics:        true,
+		failurePolicy: admissionregistrationv1.Fail,
+		allowed:       false,
+		code:          http.StatusInternalServerError,
+	}, {
+		name:          "panic with ignore",
+		panics:        true,
+		failurePolicy: admissionregistrationv1.Ignore,
+		allowed:       true,
+		warnings:      1,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var inner AdmissionHandler = func(_ context.Context, _ logr.Logger, request AdmissionRequest, _ time.Time) AdmissionResponse {
+				if tt.panics {
+					var m map[string]string
+					m["boom"] = "boom"
+				}
+				return AdmissionResponse{UID: request.UID, Allowed: true}
+			}
+			handler := inner.withRecovery(logr.Discard(), tt.failurePolicy)
+			request := AdmissionRequest{AdmissionRequest: admissionv1.AdmissionRequest{UID: "1"}}
+			response := handler(context.TODO(), logr.Discard(), request, time.Now())
+			assert.Equal(t, response.UID, request.UID)
+			assert.Equal(t, response.Allowed, tt.allowed)
+			assert.Equal(t, len(response.Warnings), tt.warnings)
+			if tt.code != 0 {
+				assert.Equal(t, response.Result.Code, tt.code)
+			} else {
+				assert.Assert(t, response.Result == nil)
+			}
+		})
+	}
+}
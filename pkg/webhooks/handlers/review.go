@@ -0,0 +1,76 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+)
+
+// decodeAdmissionReview decodes a v1 or v1beta1 admission review, v1beta1 reviews are converted to v1.
+// It returns the api version of the review so that the response can be sent back in the same version.
+func decodeAdmissionReview(body []byte) (*admissionv1.AdmissionReview, string, error) {
+	var review admissionv1.AdmissionReview
+	if err := json.Unmarshal(body, &review); err != nil {
+		return nil, "", err
+	}
+	apiVersion := review.APIVersion
+	switch apiVersion {
+	case "", admissionv1.SchemeGroupVersion.String():
+	case admissionv1beta1.SchemeGroupVersion.String():
+		var v1beta1Review admissionv1beta1.AdmissionReview
+		if err := json.Unmarshal(body, &v1beta1Review); err != nil {
+			return nil, "", err
+		}
+		review = admissionv1.AdmissionReview{TypeMeta: v1beta1Review.TypeMeta}
+		if request := v1beta1Review.Request; request != nil {
+			review.Request = &admissionv1.AdmissionRequest{
+				UID:                request.UID,
+				Kind:               request.Kind,
+				Resource:           request.Resource,
+				SubResource:        request.SubResource,
+				RequestKind:        request.RequestKind,
+				RequestResource:    request.RequestResource,
+				RequestSubResource: request.RequestSubResource,
+				Name:               request.Name,
+				Namespace:          request.Namespace,
+				Operation:          admissionv1.Operation(request.Operation),
+				UserInfo:           request.UserInfo,
+				Object:             request.Object,
+				OldObject:          request.OldObject,
+				DryRun:             request.DryRun,
+				Options:            request.Options,
+			}
+		}
+	default:
+		return nil, "", fmt.Errorf("unsupported admission review version %s", apiVersion)
+	}
+	if review.Request == nil {
+		return nil, "", fmt.Errorf("admission review has no request")
+	}
+	return &review, apiVersion, nil
+}
+
+// encodeAdmissionReview encodes an admission review in the given api version
+func encodeAdmissionReview(review *admissionv1.AdmissionReview, apiVersion string) ([]byte, error) {
+	if apiVersion != admissionv1beta1.SchemeGroupVersion.String() {
+		return json.Marshal(review)
+	}
+	out := admissionv1beta1.AdmissionReview{TypeMeta: review.TypeMeta}
+	if response := review.Response; response != nil {
+		out.Response = &admissionv1beta1.AdmissionResponse{
+			UID:              response.UID,
+			Allowed:          response.Allowed,
+			Result:           response.Result,
+			Patch:            response.Patch,
+			AuditAnnotations: response.AuditAnnotations,
+			Warnings:         response.Warnings,
+		}
+		if response.PatchType != nil {
+			patchType := admissionv1beta1.PatchType(*response.PatchType)
+			out.Response.PatchType = &patchType
+		}
+	}
+	return json.Marshal(out)
+}
@@ -0,0 +1,82 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"gotest.tools/assert"
+	admissionv1 "k8s.io/api/admission/v1"
+	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+)
+
+func Test_decodeAdmissionReview(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       string
+		apiVersion string
+		wantErr    bool
+	}{{
+		name:       "v1",
+		body:       `{"apiVersion":"admission.k8s.io/v1","kind":"AdmissionReview","request":{"uid":"1","operation":"CREATE","name":"test"}}`,
+		apiVersion: "admission.k8s.io/v1",
+	}, {
+		name:       "v1beta1",
+		body:       `{"apiVersion":"admission.k8s.io/v1beta1","kind":"AdmissionReview","request":{"uid":"1","operation":"CREATE","name":"test"}}`,
+		apiVersion: "admission.k8s.io/v1beta1",
+	}, {
+		name:    "unsupported version",
+		body:    `{"apiVersion":"admission.k8s.io/v2","kind":"AdmissionReview","request":{"uid":"1"}}`,
+		wantErr: true,
+	}, {
+		name:    "no request",
+		body:    `{"apiVersion":"admission.k8s.io/v1","kind":"AdmissionReview"}`,
+		wantErr: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			review, apiVersion, err := decodeAdmissionReview([]byte(tt.body))
+			if tt.wantErr {
+				assert.Assert(t, err != nil)
+				return
+			}
+			assert.NilError(t, err)
+			assert.Equal(t, apiVersion, tt.apiVersion)
+			assert.Equal(t, string(review.Request.UID), "1")
+			assert.Equal(t, review.Request.Operation, admissionv1.Create)
+			assert.Equal(t, review.Request.Name, "test")
+		})
+	}
+}
+
+func Test_withAdmission_v1beta1(t *testing.T) {
+	patchType := admissionv1.PatchTypeJSONPatch
+	var handler AdmissionHandler = func(_ context.Context, _ logr.Logger, request AdmissionRequest, _ time.Time) AdmissionResponse {
+		return AdmissionResponse{
+			UID:       request.UID,
+			Allowed:   true,
+			Patch:     []byte(`[]`),
+			PatchType: &patchType,
+			Warnings:  []string{"warning"},
+		}
+	}
+	body := `{"apiVersion":"admission.k8s.io/v1beta1","kind":"AdmissionReview","request":{"uid":"1","operation":"CREATE","name":"test"}}`
+	request := httptest.NewRequest(http.MethodPost, "/validate", strings.NewReader(body))
+	request.Header.Set("Content-Type", "application/json")
+	recorder := httptest.NewRecorder()
+	handler.withAdmission(logr.Discard())(recorder, request)
+	assert.Equal(t, recorder.Code, http.StatusOK)
+	var review admissionv1beta1.AdmissionReview
+	assert.NilError(t, json.Unmarshal(recorder.Body.Bytes(), &review))
+	assert.Equal(t, review.APIVersion, "admission.k8s.io/v1beta1")
+	assert.Equal(t, review.Kind, "AdmissionReview")
+	assert.Equal(t, string(review.Response.UID), "1")
+	assert.Equal(t, review.Response.Allowed, true)
+	assert.Equal(t, *review.Response.PatchType, admissionv1beta1.PatchTypeJSONPatch)
+	assert.DeepEqual(t, review.Response.Warnings, []string{"warning"})
+}
@@ -6,7 +6,9 @@ import (
 	"time"
 
 	"github.com/go-logr/logr"
+	admissionutils "github.com/kyverno/kyverno/pkg/utils/admission"
 	admissionv1 "k8s.io/api/admission/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 )
 
@@ -22,6 +24,27 @@ type AdmissionRequest struct {
 
 	// GroupVersionKind is the top level GVK.
 	GroupVersionKind schema.GroupVersionKind
+
+	// resources holds the resources decoded from the request by a previous handler of the chain.
+	resources *requestResources
+}
+
+type requestResources struct {
+	newResource unstructured.Unstructured
+	oldResource unstructured.Unstructured
+}
+
+// decodeResources returns the new and old resources of the request, they are decoded once
+// and reused by the handlers called with the request afterwards.
+func (r *AdmissionRequest) decodeResources() (unstructured.Unstructured, unstructured.Unstructured, error) {
+	if r.resources == nil {
+		newResource, oldResource, err := admissionutils.ExtractResources(nil, r.AdmissionRequest)
+		if err != nil {
+			return newResource, oldResource, err
+		}
+		r.resources = &requestResources{newResource: newResource, oldResource: oldResource}
+	}
+	return r.resources.newResource, r.resources.oldResource, nil
 }
 
 type AdmissionResponse = admissionv1.AdmissionResponse
@@ -2,23 +2,48 @@ package handlers
 
 import (
 	"context"
+	"encoding/json"
 	"time"
 
 	"github.com/go-logr/logr"
+	"github.com/kyverno/kyverno/api/kyverno"
 	"github.com/kyverno/kyverno/pkg/config"
 	admissionutils "github.com/kyverno/kyverno/pkg/utils/admission"
 	jsonutils "github.com/kyverno/kyverno/pkg/utils/json"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 func Verify(ctx context.Context, logger logr.Logger, request AdmissionRequest, startTime time.Time) AdmissionResponse {
 	if request.Name != "kyverno-health" || request.Namespace != config.KyvernoNamespace() {
 		return admissionutils.ResponseSuccess(request.UID)
 	}
-	patch := jsonutils.NewPatchOperation("/metadata/annotations/"+"kyverno.io~1last-request-time", "replace", time.Now().Format(time.RFC3339))
-	bytes, err := patch.ToPatchBytes()
+	patches := [][]byte{}
+	patch, err := jsonutils.MarshalPatchOperation("/metadata/annotations/"+"kyverno.io~1last-request-time", "replace", time.Now().Format(time.RFC3339))
 	if err != nil {
 		logger.Error(err, "failed to build patch bytes")
 		return admissionutils.Response(request.UID, err)
 	}
-	return admissionutils.MutationResponse(request.UID, bytes)
+	patches = append(patches, patch)
+	// canary requests are dry run updates carrying a nonce, it is echoed back so that the sender
+	// can check the response really comes from the webhook
+	if nonce := canaryNonce(request); nonce != "" {
+		patch, err := jsonutils.MarshalPatchOperation("/metadata/annotations/"+"kyverno.io~1canary-response", "add", nonce)
+		if err != nil {
+			logger.Error(err, "failed to build patch bytes")
+			return admissionutils.Response(request.UID, err)
+		}
+		patches = append(patches, patch)
+	}
+	return admissionutils.MutationResponse(request.UID, jsonutils.JoinPatches(patches...))
+}
+
+func canaryNonce(request AdmissionRequest) string {
+	if request.DryRun == nil || !*request.DryRun {
+		return ""
+	}
+	var object metav1.PartialObjectMetadata
+	if err := json.Unmarshal(request.Object.Raw, &object); err != nil {
+		return ""
+	}
+	return object.GetAnnotations()[kyverno.AnnotationCanaryNonce]
 }
@@ -0,0 +1,57 @@
+package handlers
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/kyverno/kyverno/pkg/config"
+	"gotest.tools/assert"
+	admissionv1 "k8s.io/api/admission/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestVerify(t *testing.T) {
+	dryRun := true
+	lease := []byte(`{"metadata":{"name":"kyverno-health","annotations":{"kyverno.io/canary-nonce":"abc"}}}`)
+	tests := []struct {
+		name          string
+		namespace     string
+		dryRun        *bool
+		wantPatch     bool
+		wantEchoNonce bool
+	}{{
+		name:      "kyverno-health",
+		namespace: config.KyvernoNamespace(),
+		wantPatch: true,
+	}, {
+		name:          "kyverno-health",
+		namespace:     config.KyvernoNamespace(),
+		dryRun:        &dryRun,
+		wantPatch:     true,
+		wantEchoNonce: true,
+	}, {
+		name:      "other",
+		namespace: config.KyvernoNamespace(),
+		dryRun:    &dryRun,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request := AdmissionRequest{
+				AdmissionRequest: admissionv1.AdmissionRequest{
+					UID:       "uid",
+					Name:      tt.name,
+					Namespace: tt.namespace,
+					DryRun:    tt.dryRun,
+					Object:    runtime.RawExtension{Raw: lease},
+				},
+			}
+			response := Verify(context.TODO(), logr.Discard(), request, time.Now())
+			assert.Assert(t, response.Allowed)
+			assert.Equal(t, len(response.Patch) != 0, tt.wantPatch)
+			assert.Equal(t, strings.Contains(string(response.Patch), `"path":"/metadata/annotations/kyverno.io~1canary-response","op":"add","value":"abc"`), tt.wantEchoNonce)
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package webhooks
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// DefaultMaxRequestBytes is the default maximum size of an admission request body
+const DefaultMaxRequestBytes int64 = 10 * 1024 * 1024
+
+// RequestLimitOptions holds the options to limit the size of admission requests
+type RequestLimitOptions struct {
+	// MaxRequestBytes is the maximum size of a request body, no limit is applied when not positive.
+	MaxRequestBytes int64
+	// MaxRequestBytesPerPath overrides MaxRequestBytes for specific webhook paths.
+	// A path also applies to its /ignore and /fail variants.
+	MaxRequestBytesPerPath map[string]int64
+}
+
+// For returns the maximum request size for the given webhook path
+func (o RequestLimitOptions) For(path string) int64 {
+	if limit, ok := o.MaxRequestBytesPerPath[path]; ok {
+		return limit
+	}
+	return o.MaxRequestBytes
+}
+
+// ParseMaxRequestBytesPerPath parses a comma separated list of path=bytes pairs (e.g. /validate=1048576,/mutate=2097152)
+func ParseMaxRequestBytesPerPath(in string) (map[string]int64, error) {
+	out := map[string]int64{}
+	for _, entry := range strings.Split(in, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		path, value, ok := strings.Cut(entry, "=")
+		if !ok || !strings.HasPrefix(path, "/") {
+			return nil, fmt.Errorf("invalid request limit %q, expected path=bytes", entry)
+		}
+		limit, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid request limit %q: %w", entry, err)
+		}
+		out[path] = limit
+	}
+	return out, nil
+}
@@ -0,0 +1,89 @@
+package payload
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/config"
+	"github.com/kyverno/kyverno/pkg/engine"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	"github.com/kyverno/kyverno/pkg/engine/jmespath"
+	"github.com/kyverno/kyverno/pkg/jsonpayload"
+	"github.com/kyverno/kyverno/pkg/policycache"
+	"github.com/kyverno/kyverno/pkg/webhooks"
+	webhookutils "github.com/kyverno/kyverno/pkg/webhooks/utils"
+)
+
+type payloadHandlers struct {
+	engine        engineapi.Engine
+	configuration config.Configuration
+	jp            jmespath.Interface
+	pCache        policycache.Cache
+	reporter      Reporter
+}
+
+// NewHandlers returns the payload handlers, results are only recorded in reports when a reporter is given
+func NewHandlers(
+	engine engineapi.Engine,
+	configuration config.Configuration,
+	jp jmespath.Interface,
+	pCache policycache.Cache,
+	reporter Reporter,
+) webhooks.PayloadHandlers {
+	return &payloadHandlers{
+		engine:        engine,
+		configuration: configuration,
+		jp:            jp,
+		pCache:        pCache,
+		reporter:      reporter,
+	}
+}
+
+// Validate evaluates the validate rules of the policies matching the payload kind,
+// the payload is not allowed when an enforced policy fails, audit failures are only reported in the results.
+// When the handlers have a reporter, results of named payloads are also recorded in admission reports.
+func (h *payloadHandlers) Validate(ctx context.Context, logger logr.Logger, request jsonpayload.Request) jsonpayload.Response {
+	gvr := jsonpayload.GroupVersionResource(request.Kind)
+	snapshot := h.pCache.Snapshot()
+	policies := snapshot.GetPolicies(policycache.ValidateEnforce, gvr, "", request.Namespace, kyvernov1.Create)
+	policies = append(policies, snapshot.GetPolicies(policycache.ValidateAudit, gvr, "", request.Namespace, kyvernov1.Create)...)
+	if len(policies) == 0 {
+		return jsonpayload.Response{Allowed: true}
+	}
+	resource := request.Resource()
+	policyContext, err := engine.NewPolicyContext(h.jp, resource, kyvernov1.Create, nil, h.configuration)
+	if err != nil {
+		logger.Error(err, "failed to create policy context")
+		return jsonpayload.Response{Message: err.Error()}
+	}
+	policyContext = policyContext.WithResourceKind(resource.GroupVersionKind(), "").WithPolicyCacheRevision(snapshot.Revision())
+	var responses []engineapi.EngineResponse
+	var results []jsonpayload.Result
+	for _, policy := range policies {
+		response := h.engine.Validate(ctx, policyContext.WithPolicy(policy))
+		if response.IsNil() {
+			continue
+		}
+		responses = append(responses, response)
+		for _, rule := range response.PolicyResponse.Rules {
+			results = append(results, jsonpayload.Result{
+				Policy:  policy.GetName(),
+				Rule:    rule.Name(),
+				Status:  string(rule.Status()),
+				Message: rule.Message(),
+			})
+		}
+	}
+	if h.reporter != nil && request.Name != "" {
+		h.reporter.Report(logger, resource, responses...)
+	}
+	// a failing enforced policy denies the payload whatever its failure policy, errors are only reported
+	if webhookutils.BlockRequest(responses, kyvernov1.Ignore, logger) {
+		return jsonpayload.Response{
+			Message: webhookutils.GetBlockedMessages(responses),
+			Results: results,
+		}
+	}
+	return jsonpayload.Response{Allowed: true, Results: results}
+}
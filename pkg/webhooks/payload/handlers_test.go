@@ -0,0 +1,187 @@
+package payload
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	policyreportv1alpha2 "github.com/kyverno/kyverno/api/policyreport/v1alpha2"
+	"github.com/kyverno/kyverno/pkg/client/clientset/versioned/fake"
+	"github.com/kyverno/kyverno/pkg/config"
+	"github.com/kyverno/kyverno/pkg/engine"
+	"github.com/kyverno/kyverno/pkg/engine/factories"
+	"github.com/kyverno/kyverno/pkg/engine/jmespath"
+	"github.com/kyverno/kyverno/pkg/imageverifycache"
+	"github.com/kyverno/kyverno/pkg/jsonpayload"
+	"github.com/kyverno/kyverno/pkg/policycache"
+	reportutils "github.com/kyverno/kyverno/pkg/utils/report"
+	yamlutils "github.com/kyverno/kyverno/pkg/utils/yaml"
+	"gotest.tools/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const policies = `
+apiVersion: kyverno.io/v1
+kind: ClusterPolicy
+metadata:
+  name: private-buckets
+spec:
+  validationFailureAction: Enforce
+  background: false
+  rules:
+  - name: deny-public-buckets
+    match:
+      any:
+      - resources:
+          kinds:
+          - json.kyverno.io/v1alpha1/TerraformPlan
+    validate:
+      message: S3 buckets must not be public
+      foreach:
+      - list: request.object.payload.resource_changes[?type=='aws_s3_bucket']
+        deny:
+          conditions:
+            any:
+            - key: "{{ element.change.after.acl || '' }}"
+              operator: Equals
+              value: public-read
+---
+apiVersion: kyverno.io/v1
+kind: ClusterPolicy
+metadata:
+  name: tagged-buckets
+spec:
+  validationFailureAction: Audit
+  background: false
+  rules:
+  - name: require-tags
+    match:
+      any:
+      - resources:
+          kinds:
+          - json.kyverno.io/v1alpha1/TerraformPlan
+    validate:
+      message: S3 buckets must be tagged
+      foreach:
+      - list: request.object.payload.resource_changes[?type=='aws_s3_bucket']
+        pattern:
+          change:
+            after:
+              tags:
+                team: "?*"
+`
+
+func newTestHandlers(t *testing.T) *payloadHandlers {
+	cfg := config.NewDefaultConfiguration(false)
+	jp := jmespath.New(cfg)
+	eng := engine.NewEngine(
+		cfg,
+		config.NewDefaultMetricsConfiguration(),
+		jp,
+		nil,
+		nil,
+		imageverifycache.DisabledImageVerifyCache(),
+		factories.DefaultContextLoaderFactory(nil),
+		nil,
+		nil,
+		nil,
+		"",
+	)
+	pCache := policycache.NewCache()
+	policies, _, err := yamlutils.GetPolicy([]byte(policies))
+	assert.NilError(t, err)
+	for _, policy := range policies {
+		// payload kinds are indexed without discovery
+		assert.NilError(t, pCache.Set(policy.GetName(), policy, nil))
+	}
+	return NewHandlers(eng, cfg, jp, pCache, nil).(*payloadHandlers)
+}
+
+func bucket(acl string, tags map[string]interface{}) map[string]interface{} {
+	return map[string]interface{}{
+		"resource_changes": []interface{}{
+			map[string]interface{}{
+				"type": "aws_s3_bucket",
+				"change": map[string]interface{}{
+					"after": map[string]interface{}{"acl": acl, "tags": tags},
+				},
+			},
+		},
+	}
+}
+
+func Test_Validate(t *testing.T) {
+	tests := []struct {
+		name     string
+		request  jsonpayload.Request
+		allowed  bool
+		statuses map[string]string
+	}{{
+		name:     "allowed",
+		request:  jsonpayload.Request{Kind: "TerraformPlan", Name: "plan", Payload: bucket("private", map[string]interface{}{"team": "kyverno"})},
+		allowed:  true,
+		statuses: map[string]string{"deny-public-buckets": "pass", "require-tags": "pass"},
+	}, {
+		name:     "audit failure",
+		request:  jsonpayload.Request{Kind: "TerraformPlan", Name: "plan", Payload: bucket("private", nil)},
+		allowed:  true,
+		statuses: map[string]string{"deny-public-buckets": "pass", "require-tags": "fail"},
+	}, {
+		name:     "enforce failure",
+		request:  jsonpayload.Request{Kind: "TerraformPlan", Name: "plan", Payload: bucket("public-read", map[string]interface{}{"team": "kyverno"})},
+		statuses: map[string]string{"deny-public-buckets": "fail", "require-tags": "pass"},
+	}, {
+		name:    "other kind",
+		request: jsonpayload.Request{Kind: "Dockerfile", Name: "plan", Payload: bucket("public-read", nil)},
+		allowed: true,
+	}}
+	handlers := newTestHandlers(t)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response := handlers.Validate(context.TODO(), logr.Discard(), tt.request)
+			assert.Equal(t, response.Allowed, tt.allowed)
+			assert.Equal(t, response.Message != "", !tt.allowed)
+			statuses := map[string]string{}
+			for _, result := range response.Results {
+				statuses[result.Rule] = result.Status
+			}
+			if tt.statuses == nil {
+				assert.Equal(t, len(statuses), 0)
+			} else {
+				assert.DeepEqual(t, statuses, tt.statuses)
+			}
+		})
+	}
+}
+
+func Test_report(t *testing.T) {
+	handlers := newTestHandlers(t)
+	client := fake.NewSimpleClientset()
+	reporter := NewReporter(handlers.configuration, client, 1).(*reporter)
+	handlers.reporter = reporter
+	request := jsonpayload.Request{Kind: "TerraformPlan", Name: "plan", Namespace: "infra", Payload: bucket("private", nil)}
+	handlers.Validate(context.TODO(), logr.Discard(), request)
+	// the queue is full, the report of the second request is dropped
+	handlers.Validate(context.TODO(), logr.Discard(), request)
+	assert.Equal(t, len(reporter.queue), 1)
+	queued := <-reporter.queue
+	reporter.report(context.TODO(), queued.logger, queued.resource, queued.responses...)
+	list, err := client.KyvernoV1alpha2().AdmissionReports("infra").List(context.TODO(), metav1.ListOptions{})
+	assert.NilError(t, err)
+	assert.Equal(t, len(list.Items), 1)
+	report := &list.Items[0]
+	assert.Equal(t, reportutils.GetResourceUid(report), reportutils.PayloadUid("TerraformPlan", "infra", "plan"))
+	assert.Equal(t, reportutils.IsPayloadReport(report), true)
+	results := map[string]policyreportv1alpha2.PolicyResult{}
+	for _, result := range report.GetResults() {
+		results[result.Rule] = result.Result
+	}
+	assert.DeepEqual(t, results, map[string]policyreportv1alpha2.PolicyResult{
+		"deny-public-buckets": policyreportv1alpha2.StatusPass,
+		"require-tags":        policyreportv1alpha2.StatusFail,
+	})
+
+	// payloads without a name are not reported
+	handlers.Validate(context.TODO(), logr.Discard(), jsonpayload.Request{Kind: "TerraformPlan", Namespace: "infra", Payload: bucket("private", nil)})
+	assert.Equal(t, len(reporter.queue), 0)
+}
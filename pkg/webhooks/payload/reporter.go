@@ -0,0 +1,82 @@
+package payload
+
+import (
+	"context"
+	"sync"
+
+	"github.com/go-logr/logr"
+	"github.com/kyverno/kyverno/pkg/client/clientset/versioned"
+	"github.com/kyverno/kyverno/pkg/config"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	reportutils "github.com/kyverno/kyverno/pkg/utils/report"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// Reporter records the results of payloads in admission reports
+type Reporter interface {
+	// Report queues the creation of the report of a payload, it doesn't block and the report is dropped when the queue is full
+	Report(logger logr.Logger, resource unstructured.Unstructured, responses ...engineapi.EngineResponse)
+	// Run creates the queued reports with the given number of workers until the context is cancelled
+	Run(ctx context.Context, workers int)
+}
+
+type reportRequest struct {
+	logger    logr.Logger
+	resource  unstructured.Unstructured
+	responses []engineapi.EngineResponse
+}
+
+type reporter struct {
+	configuration config.Configuration
+	kyvernoClient versioned.Interface
+	queue         chan reportRequest
+}
+
+// NewReporter returns a reporter holding at most queueSize reports waiting to be created
+func NewReporter(configuration config.Configuration, kyvernoClient versioned.Interface, queueSize int) Reporter {
+	return &reporter{
+		configuration: configuration,
+		kyvernoClient: kyvernoClient,
+		queue:         make(chan reportRequest, queueSize),
+	}
+}
+
+func (r *reporter) Report(logger logr.Logger, resource unstructured.Unstructured, responses ...engineapi.EngineResponse) {
+	select {
+	case r.queue <- reportRequest{logger: logger, resource: resource, responses: responses}:
+	default:
+		logger.Info("report queue is full, dropping payload report", "kind", resource.GetKind(), "namespace", resource.GetNamespace(), "name", resource.GetName())
+	}
+}
+
+func (r *reporter) Run(ctx context.Context, workers int) {
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case request := <-r.queue:
+					r.report(ctx, request.logger, request.resource, request.responses...)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+// report creates an intermediate admission report for the payload, reports of the same payload
+// are aggregated by the reports controller like the reports of admission requests
+func (r *reporter) report(ctx context.Context, logger logr.Logger, resource unstructured.Unstructured, responses ...engineapi.EngineResponse) {
+	report := reportutils.BuildPayloadReport(resource, responses...)
+	reportutils.SetResults(report, reportutils.ExcludeResults(r.configuration, resource.GetNamespace(), report.GetResults())...)
+	if len(report.GetResults()) == 0 {
+		return
+	}
+	if _, err := reportutils.CreateReport(ctx, report, r.kyvernoClient); err != nil {
+		logger.Error(err, "failed to create report")
+	}
+}
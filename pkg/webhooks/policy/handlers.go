@@ -5,25 +5,41 @@ import (
 	"time"
 
 	"github.com/go-logr/logr"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov2alpha1listers "github.com/kyverno/kyverno/pkg/client/listers/kyverno/v2alpha1"
 	"github.com/kyverno/kyverno/pkg/clients/dclient"
 	"github.com/kyverno/kyverno/pkg/openapi"
 	admissionutils "github.com/kyverno/kyverno/pkg/utils/admission"
+	"github.com/kyverno/kyverno/pkg/validation/constraint"
 	policyvalidate "github.com/kyverno/kyverno/pkg/validation/policy"
 	"github.com/kyverno/kyverno/pkg/webhooks"
 	"github.com/kyverno/kyverno/pkg/webhooks/handlers"
+	admissionv1 "k8s.io/api/admission/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	corev1listers "k8s.io/client-go/listers/core/v1"
 )
 
 type policyHandlers struct {
 	client                       dclient.Interface
 	openApiManager               openapi.Manager
 	backgroungServiceAccountName string
+	constraintLister             kyvernov2alpha1listers.ClusterPolicyConstraintLister
+	nsLister                     corev1listers.NamespaceLister
 }
 
-func NewHandlers(client dclient.Interface, openApiManager openapi.Manager, serviceaccount string) webhooks.PolicyHandlers {
+func NewHandlers(
+	client dclient.Interface,
+	openApiManager openapi.Manager,
+	serviceaccount string,
+	constraintLister kyvernov2alpha1listers.ClusterPolicyConstraintLister,
+	nsLister corev1listers.NamespaceLister,
+) webhooks.PolicyHandlers {
 	return &policyHandlers{
 		client:                       client,
 		openApiManager:               openApiManager,
 		backgroungServiceAccountName: serviceaccount,
+		constraintLister:             constraintLister,
+		nsLister:                     nsLister,
 	}
 }
 
@@ -36,8 +52,30 @@ func (h *policyHandlers) Validate(ctx context.Context, logger logr.Logger, reque
 	warnings, err := policyvalidate.Validate(policy, oldPolicy, h.client, false, h.openApiManager, h.backgroungServiceAccountName)
 	if err != nil {
 		logger.Error(err, "policy validation errors")
+		return admissionutils.Response(request.UID, err, warnings...)
 	}
-	return admissionutils.Response(request.UID, err, warnings...)
+	if request.Operation != admissionv1.Delete && policy.IsNamespaced() {
+		if err := h.validateConstraints(policy); err != nil {
+			logger.Error(err, "policy constraint violations")
+			return admissionutils.Response(request.UID, err, warnings...)
+		}
+	}
+	return admissionutils.Response(request.UID, nil, warnings...)
+}
+
+func (h *policyHandlers) validateConstraints(policy kyvernov1.PolicyInterface) error {
+	constraints, err := h.constraintLister.List(labels.Everything())
+	if err != nil {
+		return err
+	}
+	if len(constraints) == 0 {
+		return nil
+	}
+	namespace, err := h.nsLister.Get(policy.GetNamespace())
+	if err != nil {
+		return err
+	}
+	return constraint.Validate(policy, namespace.GetLabels(), constraints)
 }
 
 func (h *policyHandlers) Mutate(_ context.Context, _ logr.Logger, request handlers.AdmissionRequest, _ time.Time) handlers.AdmissionResponse {
@@ -54,7 +54,7 @@ func NewFakeHandlers(ctx context.Context, policyCache policycache.Cache) webhook
 		urGenerator:    updaterequest.NewFake(),
 		eventGen:       event.NewFake(),
 		openApiManager: openapi.NewFake(),
-		pcBuilder:      webhookutils.NewPolicyContextBuilder(configuration, jp),
+		pcBuilder:      webhookutils.NewPolicyContextBuilder(configuration, jp, informers.Core().V1().ServiceAccounts().Lister()),
 		engine: engine.NewEngine(
 			configuration,
 			config.NewDefaultMetricsConfiguration(),
@@ -64,6 +64,8 @@ func NewFakeHandlers(ctx context.Context, policyCache policycache.Cache) webhook
 			imageverifycache.DisabledImageVerifyCache(),
 			factories.DefaultContextLoaderFactory(configMapResolver),
 			peLister,
+			nil,
+			nil,
 			"",
 		),
 	}
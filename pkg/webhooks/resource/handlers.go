@@ -2,7 +2,6 @@ package resource
 
 import (
 	"context"
-	"errors"
 	"time"
 
 	"github.com/go-logr/logr"
@@ -13,6 +12,7 @@ import (
 	kyvernov1beta1listers "github.com/kyverno/kyverno/pkg/client/listers/kyverno/v1beta1"
 	"github.com/kyverno/kyverno/pkg/clients/dclient"
 	"github.com/kyverno/kyverno/pkg/config"
+	"github.com/kyverno/kyverno/pkg/controllers/notification"
 	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
 	"github.com/kyverno/kyverno/pkg/engine/jmespath"
 	"github.com/kyverno/kyverno/pkg/event"
@@ -59,6 +59,9 @@ type resourceHandlers struct {
 
 	admissionReports             bool
 	backgroungServiceAccountName string
+	auditWarn                    bool
+	parallelism                  int
+	notifier                     notification.Notifier
 }
 
 func NewHandlers(
@@ -69,6 +72,7 @@ func NewHandlers(
 	metricsConfig metrics.MetricsConfigManager,
 	pCache policycache.Cache,
 	nsLister corev1listers.NamespaceLister,
+	saLister corev1listers.ServiceAccountLister,
 	urLister kyvernov1beta1listers.UpdateRequestNamespaceLister,
 	cpolInformer kyvernov1informers.ClusterPolicyInformer,
 	polInformer kyvernov1informers.PolicyInformer,
@@ -78,6 +82,9 @@ func NewHandlers(
 	admissionReports bool,
 	backgroungServiceAccountName string,
 	jp jmespath.Interface,
+	auditWarn bool,
+	parallelism int,
+	notifier notification.Notifier,
 ) webhooks.ResourceHandlers {
 	return &resourceHandlers{
 		engine:                       engine,
@@ -93,9 +100,12 @@ func NewHandlers(
 		urGenerator:                  urGenerator,
 		eventGen:                     eventGen,
 		openApiManager:               openApiManager,
-		pcBuilder:                    webhookutils.NewPolicyContextBuilder(configuration, jp),
+		pcBuilder:                    webhookutils.NewPolicyContextBuilder(configuration, jp, saLister),
 		admissionReports:             admissionReports,
 		backgroungServiceAccountName: backgroungServiceAccountName,
+		auditWarn:                    auditWarn,
+		parallelism:                  parallelism,
+		notifier:                     notifier,
 	}
 }
 
@@ -106,10 +116,15 @@ func (h *resourceHandlers) Validate(ctx context.Context, logger logr.Logger, req
 
 	// timestamp at which this admission request got triggered
 	gvr := schema.GroupVersionResource(request.Resource)
-	policies := filterPolicies(ctx, failurePolicy, h.pCache.GetPolicies(policycache.ValidateEnforce, gvr, request.SubResource, request.Namespace)...)
-	mutatePolicies := filterPolicies(ctx, failurePolicy, h.pCache.GetPolicies(policycache.Mutate, gvr, request.SubResource, request.Namespace)...)
-	generatePolicies := filterPolicies(ctx, failurePolicy, h.pCache.GetPolicies(policycache.Generate, gvr, request.SubResource, request.Namespace)...)
-	imageVerifyValidatePolicies := filterPolicies(ctx, failurePolicy, h.pCache.GetPolicies(policycache.VerifyImagesValidate, gvr, request.SubResource, request.Namespace)...)
+	operation := kyvernov1.AdmissionOperation(request.Operation)
+	// all policies are taken from the same snapshot, policy updates don't affect the request once started
+	snapshot := h.pCache.Snapshot()
+	policies := filterPolicies(ctx, failurePolicy, snapshot.GetPolicies(policycache.ValidateEnforce, gvr, request.SubResource, request.Namespace, operation)...)
+	// background policies are not filtered by operation, generate and mutate existing rules
+	// also need to react to operations their triggers don't match (cleaning up downstream resources for example)
+	mutatePolicies := filterPolicies(ctx, failurePolicy, snapshot.GetPolicies(policycache.Mutate, gvr, request.SubResource, request.Namespace, "")...)
+	generatePolicies := filterPolicies(ctx, failurePolicy, snapshot.GetPolicies(policycache.Generate, gvr, request.SubResource, request.Namespace, "")...)
+	imageVerifyValidatePolicies := filterPolicies(ctx, failurePolicy, snapshot.GetPolicies(policycache.VerifyImagesValidate, gvr, request.SubResource, request.Namespace, operation)...)
 	policies = append(policies, imageVerifyValidatePolicies...)
 
 	if len(policies) == 0 && len(mutatePolicies) == 0 && len(generatePolicies) == 0 {
@@ -124,18 +139,24 @@ func (h *resourceHandlers) Validate(ctx context.Context, logger logr.Logger, req
 	}
 
 	namespaceLabels := make(map[string]string)
+	namespaceAnnotations := make(map[string]string)
 	if request.Kind.Kind != "Namespace" && request.Namespace != "" {
 		namespaceLabels = engineutils.GetNamespaceSelectorsFromNamespaceLister(request.Kind.Kind, request.Namespace, h.nsLister, logger)
+		namespaceAnnotations = engineutils.GetNamespaceAnnotationsFromNamespaceLister(request.Kind.Kind, request.Namespace, h.nsLister, logger)
 	}
-	policyContext = policyContext.WithNamespaceLabels(namespaceLabels)
-	vh := validation.NewValidationHandler(logger, h.kyvernoClient, h.engine, h.pCache, h.pcBuilder, h.eventGen, h.admissionReports, h.metricsConfig, h.configuration)
+	policyContext = policyContext.
+		WithNamespaceLabels(namespaceLabels).
+		WithNamespaceAnnotations(namespaceAnnotations).
+		WithPolicyCacheRevision(snapshot.Revision())
+	vh := validation.NewValidationHandler(logger, h.kyvernoClient, h.engine, snapshot, h.pcBuilder, h.eventGen, h.admissionReports, h.metricsConfig, h.configuration, h.auditWarn, h.parallelism, h.notifier)
 
-	ok, msg, warnings := vh.HandleValidation(ctx, request, policies, policyContext, startTime)
-	if !ok {
+	warnings, err := vh.HandleValidation(ctx, request, policies, policyContext, startTime)
+	if err != nil {
 		logger.Info("admission request denied")
-		return admissionutils.Response(request.UID, errors.New(msg), warnings...)
+		return admissionutils.Response(request.UID, err, warnings...)
 	}
-	if !admissionutils.IsDryRun(request.AdmissionRequest) {
+	// the context is cancelled when a response was already sent on deadline, the request may have been denied
+	if !admissionutils.IsDryRun(request.AdmissionRequest) && ctx.Err() == nil {
 		go h.handleBackgroundApplies(ctx, logger, request.AdmissionRequest, policyContext, generatePolicies, mutatePolicies, startTime)
 	}
 	return admissionutils.ResponseSuccess(request.UID, warnings...)
@@ -146,8 +167,10 @@ func (h *resourceHandlers) Mutate(ctx context.Context, logger logr.Logger, reque
 	logger = logger.WithValues("kind", kind)
 	logger.V(4).Info("received an admission request in mutating webhook")
 	gvr := schema.GroupVersionResource(request.Resource)
-	mutatePolicies := filterPolicies(ctx, failurePolicy, h.pCache.GetPolicies(policycache.Mutate, gvr, request.SubResource, request.Namespace)...)
-	verifyImagesPolicies := filterPolicies(ctx, failurePolicy, h.pCache.GetPolicies(policycache.VerifyImagesMutate, gvr, request.SubResource, request.Namespace)...)
+	operation := kyvernov1.AdmissionOperation(request.Operation)
+	snapshot := h.pCache.Snapshot()
+	mutatePolicies := filterPolicies(ctx, failurePolicy, snapshot.GetPolicies(policycache.Mutate, gvr, request.SubResource, request.Namespace, operation)...)
+	verifyImagesPolicies := filterPolicies(ctx, failurePolicy, snapshot.GetPolicies(policycache.VerifyImagesMutate, gvr, request.SubResource, request.Namespace, operation)...)
 	if len(mutatePolicies) == 0 && len(verifyImagesPolicies) == 0 {
 		logger.V(4).Info("no policies matched mutate admission request")
 		return admissionutils.ResponseSuccess(request.UID)
@@ -158,7 +181,8 @@ func (h *resourceHandlers) Mutate(ctx context.Context, logger logr.Logger, reque
 		logger.Error(err, "failed to build policy context")
 		return admissionutils.Response(request.UID, err)
 	}
-	mh := mutation.NewMutationHandler(logger, h.engine, h.eventGen, h.openApiManager, h.nsLister, h.metricsConfig)
+	policyContext = policyContext.WithPolicyCacheRevision(snapshot.Revision())
+	mh := mutation.NewMutationHandler(logger, h.engine, h.eventGen, h.openApiManager, h.nsLister, h.metricsConfig, h.configuration)
 	mutatePatches, mutateWarnings, err := mh.HandleMutation(ctx, request.AdmissionRequest, mutatePolicies, policyContext, startTime)
 	if err != nil {
 		logger.Error(err, "mutation failed")
@@ -171,6 +195,7 @@ func (h *resourceHandlers) Mutate(ctx context.Context, logger logr.Logger, reque
 		logger.Error(err, "failed to build policy context")
 		return admissionutils.Response(request.UID, err)
 	}
+	policyContext = policyContext.WithPolicyCacheRevision(snapshot.Revision())
 	ivh := imageverification.NewImageVerificationHandler(logger, h.kyvernoClient, h.engine, h.eventGen, h.admissionReports, h.configuration, h.nsLister)
 	imagePatches, imageVerifyWarnings, err := ivh.Handle(ctx, newRequest, verifyImagesPolicies, policyContext)
 	if err != nil {
@@ -3,6 +3,7 @@ package resource
 import (
 	"context"
 	"encoding/json"
+	"strings"
 	"testing"
 	"time"
 
@@ -302,6 +303,79 @@ func Test_AdmissionResponseValid(t *testing.T) {
 	policyCache.Unset(key)
 }
 
+func Test_AdmissionResponseAuditWarn(t *testing.T) {
+	policyCache := policycache.NewCache()
+	logger := log.WithName("Test_AdmissionResponseAuditWarn")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	h := NewFakeHandlers(ctx, policyCache).(*resourceHandlers)
+	h.auditWarn = true
+
+	var auditPolicy kyverno.ClusterPolicy
+	err := json.Unmarshal([]byte(policyCheckLabel), &auditPolicy)
+	assert.NilError(t, err)
+
+	key := makeKey(&auditPolicy)
+	policyCache.Set(key, &auditPolicy, policycache.TestResourceFinder{})
+
+	request := handlers.AdmissionRequest{
+		AdmissionRequest: v1.AdmissionRequest{
+			Operation: v1.Create,
+			Kind:      metav1.GroupVersionKind{Group: "", Version: "v1", Kind: "Pod"},
+			Resource:  metav1.GroupVersionResource{Group: "", Version: "v1", Resource: "pods"},
+			Object: runtime.RawExtension{
+				Raw: []byte(pod),
+			},
+			RequestResource: &metav1.GroupVersionResource{Group: "", Version: "v1", Resource: "pods"},
+		},
+	}
+
+	response := h.Validate(ctx, logger, request, "", time.Now())
+	assert.Equal(t, response.Allowed, true)
+	assert.Equal(t, len(response.Warnings), 1)
+	assert.Assert(t, strings.HasPrefix(response.Warnings[0], "policy check-label-app."))
+
+	policyCache.Unset(key)
+}
+
+func Test_AdmissionResponseParallel(t *testing.T) {
+	policyCache := policycache.NewCache()
+	logger := log.WithName("Test_AdmissionResponseParallel")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	h := NewFakeHandlers(ctx, policyCache).(*resourceHandlers)
+	h.parallelism = 4
+
+	for _, name := range []string{"check-label-app-1", "check-label-app-2", "check-label-app-3"} {
+		var policy kyverno.ClusterPolicy
+		err := json.Unmarshal([]byte(policyCheckLabel), &policy)
+		assert.NilError(t, err)
+		policy.SetName(name)
+		policy.Spec.ValidationFailureAction = "Enforce"
+		policyCache.Set(makeKey(&policy), &policy, policycache.TestResourceFinder{})
+	}
+
+	request := handlers.AdmissionRequest{
+		AdmissionRequest: v1.AdmissionRequest{
+			Operation: v1.Create,
+			Kind:      metav1.GroupVersionKind{Group: "", Version: "v1", Kind: "Pod"},
+			Resource:  metav1.GroupVersionResource{Group: "", Version: "v1", Resource: "pods"},
+			Object: runtime.RawExtension{
+				Raw: []byte(pod),
+			},
+			RequestResource: &metav1.GroupVersionResource{Group: "", Version: "v1", Resource: "pods"},
+		},
+	}
+
+	response := h.Validate(ctx, logger, request, "", time.Now())
+	assert.Equal(t, response.Allowed, false)
+	assert.Equal(t, len(response.Result.Details.Causes), 3)
+}
+
 func Test_AdmissionResponseInvalid(t *testing.T) {
 	policyCache := policycache.NewCache()
 	logger := log.WithName("Test_AdmissionResponseInvalid")
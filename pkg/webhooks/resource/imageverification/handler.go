@@ -2,7 +2,6 @@ package imageverification
 
 import (
 	"context"
-	"errors"
 	"fmt"
 
 	"github.com/go-logr/logr"
@@ -67,9 +66,9 @@ func (h *imageVerificationHandler) Handle(
 	policies []kyvernov1.PolicyInterface,
 	policyContext *engine.PolicyContext,
 ) ([]byte, []string, error) {
-	ok, message, imagePatches, warnings := h.handleVerifyImages(ctx, h.log, request, policyContext, policies)
-	if !ok {
-		return nil, nil, errors.New(message)
+	imagePatches, warnings, err := h.handleVerifyImages(ctx, h.log, request, policyContext, policies)
+	if err != nil {
+		return nil, nil, err
 	}
 	h.log.V(6).Info("images verified", "patches", string(imagePatches), "warnings", warnings)
 	return imagePatches, warnings, nil
@@ -81,9 +80,9 @@ func (h *imageVerificationHandler) handleVerifyImages(
 	request admissionv1.AdmissionRequest,
 	policyContext *engine.PolicyContext,
 	policies []kyvernov1.PolicyInterface,
-) (bool, string, []byte, []string) {
+) ([]byte, []string, error) {
 	if len(policies) == 0 {
-		return true, "", nil, nil
+		return nil, nil, nil
 	}
 	var engineResponses []engineapi.EngineResponse
 	var patches []jsonpatch.JsonPatchOperation
@@ -102,7 +101,9 @@ func (h *imageVerificationHandler) handleVerifyImages(
 
 				policyContext := policyContext.WithPolicy(policy)
 				if request.Kind.Kind != "Namespace" && request.Namespace != "" {
-					policyContext = policyContext.WithNamespaceLabels(engineutils.GetNamespaceSelectorsFromNamespaceLister(request.Kind.Kind, request.Namespace, h.nsLister, h.log))
+					policyContext = policyContext.
+						WithNamespaceLabels(engineutils.GetNamespaceSelectorsFromNamespaceLister(request.Kind.Kind, request.Namespace, h.nsLister, h.log)).
+						WithNamespaceAnnotations(engineutils.GetNamespaceAnnotationsFromNamespaceLister(request.Kind.Kind, request.Namespace, h.nsLister, h.log))
 				}
 
 				resp, ivm := h.engine.VerifyAndPatchImages(ctx, policyContext)
@@ -117,12 +118,13 @@ func (h *imageVerificationHandler) handleVerifyImages(
 	}
 
 	blocked := webhookutils.BlockRequest(engineResponses, failurePolicy, logger)
+	webhookutils.RecordRolloutDecisions(ctx, logger, engineResponses)
 	events := webhookutils.GenerateEvents(engineResponses, blocked)
 	h.eventGen.Add(events...)
 
 	if blocked {
 		logger.V(4).Info("admission request blocked")
-		return false, webhookutils.GetBlockedMessages(engineResponses), nil, nil
+		return nil, nil, webhookutils.GetBlockedError(engineResponses)
 	}
 
 	if !verifiedImageData.IsEmpty() {
@@ -139,7 +141,7 @@ func (h *imageVerificationHandler) handleVerifyImages(
 	go h.handleAudit(ctx, policyContext.NewResource(), request, nil, engineResponses...)
 
 	warnings := webhookutils.GetWarningMessages(engineResponses)
-	return true, "", jsonutils.JoinPatches(patch.ConvertPatches(patches...)...), warnings
+	return jsonutils.JoinPatches(patch.ConvertPatches(patches...)...), warnings, nil
 }
 
 func hasAnnotations(context *engine.PolicyContext) bool {
@@ -174,6 +176,7 @@ func (v *imageVerificationHandler) handleAudit(
 		func(ctx context.Context, span trace.Span) {
 			if createReport {
 				report := reportutils.BuildAdmissionReport(resource, request, engineResponses...)
+				reportutils.SetResults(report, reportutils.ExcludeResults(v.cfg, resource.GetNamespace(), report.GetResults())...)
 				if len(report.GetResults()) > 0 {
 					_, err := reportutils.CreateReport(context.Background(), report, v.kyvernoClient)
 					if err != nil {
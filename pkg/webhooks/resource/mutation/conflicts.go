@@ -0,0 +1,155 @@
+package mutation
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/kyverno/kyverno/api/kyverno"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+)
+
+// mutationWrite is a patch operation applied by a mutate rule
+type mutationWrite struct {
+	policy     string
+	rule       string
+	precedence int
+	operation  string
+	path       string
+	value      interface{}
+}
+
+func (w mutationWrite) String() string {
+	return w.policy + "/" + w.rule
+}
+
+// mutationConflict describes two mutate rules setting the same path to different values,
+// the second write being applied after the first one
+type mutationConflict struct {
+	path   string
+	first  mutationWrite
+	second mutationWrite
+}
+
+// resolved returns true when the precedence declared by the policies decides which write wins
+func (c mutationConflict) resolved() bool {
+	return c.second.precedence > c.first.precedence
+}
+
+func (c mutationConflict) String() string {
+	return fmt.Sprintf("rules %s and %s set %s to different values", c.first, c.second, c.path)
+}
+
+// mutationPrecedence returns the precedence declared by a policy, policies with a higher precedence are applied last
+func mutationPrecedence(annotations map[string]string) int {
+	if value, ok := annotations[kyverno.AnnotationMutationPrecedence]; ok {
+		if precedence, err := strconv.Atoi(value); err == nil {
+			return precedence
+		}
+	}
+	return 0
+}
+
+// sortByPrecedence returns the policies ordered by ascending precedence, the order of policies with the same precedence is preserved
+func sortByPrecedence(policies []kyvernov1.PolicyInterface) []kyvernov1.PolicyInterface {
+	sorted := make([]kyvernov1.PolicyInterface, len(policies))
+	copy(sorted, policies)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return mutationPrecedence(sorted[i].GetAnnotations()) < mutationPrecedence(sorted[j].GetAnnotations())
+	})
+	return sorted
+}
+
+// detectConflicts returns the conflicts between the patches applied by the rules of the engine responses
+func detectConflicts(engineResponses []engineapi.EngineResponse) []mutationConflict {
+	var writes []mutationWrite
+	for _, engineResponse := range engineResponses {
+		policy := engineResponse.Policy()
+		key := policy.GetName()
+		if ns := policy.GetNamespace(); ns != "" {
+			key = ns + "/" + key
+		}
+		precedence := mutationPrecedence(policy.GetAnnotations())
+		for _, rule := range engineResponse.PolicyResponse.Rules {
+			for _, patch := range rule.Patches() {
+				writes = append(writes, mutationWrite{
+					policy:     key,
+					rule:       rule.Name(),
+					precedence: precedence,
+					operation:  patch.Operation,
+					path:       patch.Path,
+					value:      patch.Value,
+				})
+			}
+		}
+	}
+	var conflicts []mutationConflict
+	for i := range writes {
+		for j := i + 1; j < len(writes); j++ {
+			first, second := writes[i], writes[j]
+			if first.policy == second.policy && first.rule == second.rule {
+				continue
+			}
+			if path, ok := conflictingPath(first, second); ok {
+				conflicts = append(conflicts, mutationConflict{path: path, first: first, second: second})
+			}
+		}
+	}
+	return conflicts
+}
+
+// conflictingPath returns the path written by both operations if they set it to different values
+func conflictingPath(first, second mutationWrite) (string, bool) {
+	switch {
+	case first.path == second.path:
+		return first.path, first.operation == "remove" || second.operation == "remove" || !reflect.DeepEqual(first.value, second.value)
+	case strings.HasPrefix(second.path, first.path+"/"):
+		// the second write updates a value nested in the first one, it only conflicts if the value already existed
+		if first.operation == "remove" {
+			return "", false
+		}
+		value, found := lookup(first.value, strings.TrimPrefix(second.path, first.path+"/"))
+		if !found {
+			return "", false
+		}
+		return second.path, second.operation == "remove" || !reflect.DeepEqual(value, second.value)
+	case strings.HasPrefix(first.path, second.path+"/"):
+		// the second write overrides the parent of the first one
+		if first.operation == "remove" {
+			return "", false
+		}
+		if second.operation == "remove" {
+			return first.path, true
+		}
+		value, found := lookup(second.value, strings.TrimPrefix(first.path, second.path+"/"))
+		return first.path, !found || !reflect.DeepEqual(value, first.value)
+	}
+	return "", false
+}
+
+// lookup returns the value at the given JSON pointer (without the leading slash) in a decoded JSON document
+func lookup(document interface{}, pointer string) (interface{}, bool) {
+	for _, token := range strings.Split(pointer, "/") {
+		token = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+		switch typed := document.(type) {
+		case map[string]interface{}:
+			value, ok := typed[token]
+			if !ok {
+				return nil, false
+			}
+			document = value
+		case []interface{}:
+			index, err := strconv.Atoi(token)
+			if err != nil || index < 0 || index >= len(typed) {
+				return nil, false
+			}
+			document = typed[index]
+		default:
+			return nil, false
+		}
+	}
+	return document, true
+}
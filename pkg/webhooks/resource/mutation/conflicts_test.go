@@ -0,0 +1,129 @@
+package mutation
+
+import (
+	"testing"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	"gomodules.xyz/jsonpatch/v2"
+	"gotest.tools/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func newPolicy(name string, precedence string) kyvernov1.PolicyInterface {
+	policy := &kyvernov1.ClusterPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+		},
+	}
+	if precedence != "" {
+		policy.SetAnnotations(map[string]string{
+			"policies.kyverno.io/mutation-precedence": precedence,
+		})
+	}
+	return policy
+}
+
+func newResponse(policy kyvernov1.PolicyInterface, rule string, patches ...jsonpatch.JsonPatchOperation) engineapi.EngineResponse {
+	response := engineapi.NewEngineResponse(unstructured.Unstructured{}, engineapi.NewKyvernoPolicy(policy), nil)
+	response.PolicyResponse.Rules = []engineapi.RuleResponse{
+		*engineapi.RulePass(rule, engineapi.Mutation, "").WithPatches(patches...),
+	}
+	return response
+}
+
+func Test_sortByPrecedence(t *testing.T) {
+	policies := []kyvernov1.PolicyInterface{
+		newPolicy("high", "10"),
+		newPolicy("default", ""),
+		newPolicy("invalid", "foo"),
+		newPolicy("low", "-1"),
+	}
+	var names []string
+	for _, policy := range sortByPrecedence(policies) {
+		names = append(names, policy.GetName())
+	}
+	assert.DeepEqual(t, names, []string{"low", "default", "invalid", "high"})
+	assert.Equal(t, policies[0].GetName(), "high")
+}
+
+func Test_detectConflicts(t *testing.T) {
+	tests := []struct {
+		name      string
+		responses []engineapi.EngineResponse
+		want      []string
+		resolved  []bool
+	}{{
+		name: "different paths",
+		responses: []engineapi.EngineResponse{
+			newResponse(newPolicy("a", ""), "rule", jsonpatch.NewOperation("add", "/metadata/labels/a", "a")),
+			newResponse(newPolicy("b", ""), "rule", jsonpatch.NewOperation("add", "/metadata/labels/b", "b")),
+		},
+	}, {
+		name: "same path same value",
+		responses: []engineapi.EngineResponse{
+			newResponse(newPolicy("a", ""), "rule", jsonpatch.NewOperation("add", "/metadata/labels/a", "a")),
+			newResponse(newPolicy("b", ""), "rule", jsonpatch.NewOperation("add", "/metadata/labels/a", "a")),
+		},
+	}, {
+		name: "same path different values",
+		responses: []engineapi.EngineResponse{
+			newResponse(newPolicy("a", ""), "rule", jsonpatch.NewOperation("add", "/metadata/labels/a", "a")),
+			newResponse(newPolicy("b", ""), "rule", jsonpatch.NewOperation("replace", "/metadata/labels/a", "b")),
+		},
+		want:     []string{"rules a/rule and b/rule set /metadata/labels/a to different values"},
+		resolved: []bool{false},
+	}, {
+		name: "resolved by precedence",
+		responses: []engineapi.EngineResponse{
+			newResponse(newPolicy("a", ""), "rule", jsonpatch.NewOperation("add", "/metadata/labels/a", "a")),
+			newResponse(newPolicy("b", "1"), "rule", jsonpatch.NewOperation("replace", "/metadata/labels/a", "b")),
+		},
+		want:     []string{"rules a/rule and b/rule set /metadata/labels/a to different values"},
+		resolved: []bool{true},
+	}, {
+		name: "nested value updated",
+		responses: []engineapi.EngineResponse{
+			newResponse(newPolicy("a", ""), "rule", jsonpatch.NewOperation("add", "/metadata/labels", map[string]interface{}{"app": "a"})),
+			newResponse(newPolicy("b", ""), "rule", jsonpatch.NewOperation("replace", "/metadata/labels/app", "b")),
+		},
+		want:     []string{"rules a/rule and b/rule set /metadata/labels/app to different values"},
+		resolved: []bool{false},
+	}, {
+		name: "nested value added",
+		responses: []engineapi.EngineResponse{
+			newResponse(newPolicy("a", ""), "rule", jsonpatch.NewOperation("add", "/metadata/labels", map[string]interface{}{"app": "a"})),
+			newResponse(newPolicy("b", ""), "rule", jsonpatch.NewOperation("add", "/metadata/labels/team", "b")),
+		},
+	}, {
+		name: "parent removed",
+		responses: []engineapi.EngineResponse{
+			newResponse(newPolicy("a", ""), "rule", jsonpatch.NewOperation("add", "/metadata/labels/app", "a")),
+			newResponse(newPolicy("b", ""), "rule", jsonpatch.NewOperation("remove", "/metadata/labels", nil)),
+		},
+		want:     []string{"rules a/rule and b/rule set /metadata/labels/app to different values"},
+		resolved: []bool{false},
+	}, {
+		name: "same rule",
+		responses: []engineapi.EngineResponse{
+			newResponse(newPolicy("a", ""), "rule",
+				jsonpatch.NewOperation("add", "/metadata/labels/a", "a"),
+				jsonpatch.NewOperation("replace", "/metadata/labels/a", "b"),
+			),
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conflicts := detectConflicts(tt.responses)
+			var got []string
+			var resolved []bool
+			for _, conflict := range conflicts {
+				got = append(got, conflict.String())
+				resolved = append(resolved, conflict.resolved())
+			}
+			assert.DeepEqual(t, got, tt.want)
+			assert.DeepEqual(t, resolved, tt.resolved)
+		})
+	}
+}
@@ -2,11 +2,15 @@ package mutation
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/go-logr/logr"
+	"github.com/kyverno/kyverno/api/kyverno"
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/config"
 	"github.com/kyverno/kyverno/pkg/engine"
 	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
 	"github.com/kyverno/kyverno/pkg/engine/mutate/patch"
@@ -20,6 +24,7 @@ import (
 	"go.opentelemetry.io/otel/trace"
 	"gomodules.xyz/jsonpatch/v2"
 	admissionv1 "k8s.io/api/admission/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	corev1listers "k8s.io/client-go/listers/core/v1"
 )
 
@@ -37,6 +42,7 @@ func NewMutationHandler(
 	openApiManager openapi.ValidateInterface,
 	nsLister corev1listers.NamespaceLister,
 	metrics metrics.MetricsConfigManager,
+	configuration config.Configuration,
 ) MutationHandler {
 	return &mutationHandler{
 		log:            log,
@@ -45,6 +51,7 @@ func NewMutationHandler(
 		openApiManager: openApiManager,
 		nsLister:       nsLister,
 		metrics:        metrics,
+		configuration:  configuration,
 	}
 }
 
@@ -55,6 +62,7 @@ type mutationHandler struct {
 	openApiManager openapi.ValidateInterface
 	nsLister       corev1listers.NamespaceLister
 	metrics        metrics.MetricsConfigManager
+	configuration  config.Configuration
 }
 
 func (h *mutationHandler) HandleMutation(
@@ -64,30 +72,31 @@ func (h *mutationHandler) HandleMutation(
 	policyContext *engine.PolicyContext,
 	admissionRequestTimestamp time.Time,
 ) ([]byte, []string, error) {
-	mutatePatches, mutateEngineResponses, err := h.applyMutations(ctx, request, policies, policyContext)
+	mutatePatches, mutateEngineResponses, conflictWarnings, err := h.applyMutations(ctx, request, policies, policyContext)
 	if err != nil {
 		return nil, nil, err
 	}
 	h.log.V(6).Info("", "generated patches", string(mutatePatches))
-	return mutatePatches, webhookutils.GetWarningMessages(mutateEngineResponses), nil
+	return mutatePatches, append(webhookutils.GetWarningMessages(mutateEngineResponses), conflictWarnings...), nil
 }
 
 // applyMutations handles mutating webhook admission request
-// return value: generated patches, triggered policies, engine responses correspdonding to the triggered policies
+// return value: generated patches, engine responses correspdonding to the triggered policies, warnings about conflicting mutations
 func (v *mutationHandler) applyMutations(
 	ctx context.Context,
 	request admissionv1.AdmissionRequest,
 	policies []kyvernov1.PolicyInterface,
 	policyContext *engine.PolicyContext,
-) ([]byte, []engineapi.EngineResponse, error) {
+) ([]byte, []engineapi.EngineResponse, []string, error) {
 	if len(policies) == 0 {
-		return nil, nil, nil
+		return nil, nil, nil, nil
 	}
 
 	var patches []jsonpatch.JsonPatchOperation
 	var engineResponses []engineapi.EngineResponse
 
-	for _, policy := range policies {
+	// policies with a higher precedence are applied last so that their patches win
+	for _, policy := range sortByPrecedence(policies) {
 		spec := policy.GetSpec()
 		if !spec.HasMutate() {
 			continue
@@ -122,17 +131,41 @@ func (v *mutationHandler) applyMutations(
 			},
 		)
 		if err != nil {
-			return nil, nil, err
+			return nil, nil, nil, err
 		}
 	}
 
+	var warnings []string
+	for _, conflict := range detectConflicts(engineResponses) {
+		if conflict.resolved() {
+			v.log.V(2).Info("conflicting mutation resolved by precedence", "path", conflict.path, "rule", conflict.second.String(), "overridden", conflict.first.String())
+			continue
+		}
+		if v.configuration != nil && v.configuration.GetRejectMutationConflicts() {
+			return nil, nil, nil, fmt.Errorf("conflicting mutations: %s, set the %s annotation to declare which policy takes precedence", conflict, kyverno.AnnotationMutationPrecedence)
+		}
+		v.log.Info("conflicting mutations, the last one wins", "path", conflict.path, "rule", conflict.second.String(), "overridden", conflict.first.String())
+		warnings = append(warnings, fmt.Sprintf("conflicting mutations: %s", conflict))
+	}
+
+	webhookutils.RecordPolicyAdmissions(ctx, v.log, engineResponses, kyvernov1.Ignore, false)
 	events := webhookutils.GenerateEvents(engineResponses, false)
 	v.eventGen.Add(events...)
+	v.eventGen.Add(webhookutils.GenerateLatencyBudgetEvents(engineResponses)...)
 
 	logMutationResponse(patches, engineResponses, v.log)
 
+	if len(patches) != 0 && v.configuration != nil && v.configuration.GetAnnotateAppliedPatches() {
+		annotationPatches, err := appliedPatchesAnnotation(policyContext.NewResource(), engineResponses)
+		if err != nil {
+			v.log.Error(err, "failed to annotate applied patches")
+		} else {
+			patches = append(patches, annotationPatches...)
+		}
+	}
+
 	// patches holds all the successful patches, if no patch is created, it returns nil
-	return jsonutils.JoinPatches(patch.ConvertPatches(patches...)...), engineResponses, nil
+	return jsonutils.JoinPatches(patch.ConvertPatches(patches...)...), engineResponses, warnings, nil
 }
 
 func (h *mutationHandler) applyMutation(ctx context.Context, request admissionv1.AdmissionRequest, policyContext *engine.PolicyContext) (*engineapi.EngineResponse, []jsonpatch.JsonPatchOperation, error) {
@@ -167,3 +200,32 @@ func logMutationResponse(patches []jsonpatch.JsonPatchOperation, engineResponses
 		logger.Error(fmt.Errorf(webhookutils.GetErrorMsg(engineResponses)), "failed to apply mutation rules on the resource, reporting policy violation")
 	}
 }
+
+// appliedPatchesAnnotation returns the patches annotating the mutated resource with the provenance of the applied patches
+func appliedPatchesAnnotation(resource unstructured.Unstructured, engineResponses []engineapi.EngineResponse) ([]jsonpatch.JsonPatchOperation, error) {
+	var applied []engineapi.AppliedPatch
+	for _, engineResponse := range engineResponses {
+		applied = append(applied, engineResponse.GetAppliedPatches()...)
+	}
+	if len(applied) == 0 {
+		return nil, nil
+	}
+	data, err := json.Marshal(applied)
+	if err != nil {
+		return nil, err
+	}
+	var patches []jsonpatch.JsonPatchOperation
+	if resource.GetAnnotations() == nil {
+		patches = append(patches, jsonpatch.JsonPatchOperation{
+			Operation: "add",
+			Path:      "/metadata/annotations",
+			Value:     map[string]string{},
+		})
+	}
+	patches = append(patches, jsonpatch.JsonPatchOperation{
+		Operation: "add",
+		Path:      "/metadata/annotations/" + strings.ReplaceAll(kyverno.AnnotationAppliedPatches, "/", "~1"),
+		Value:     string(data),
+	})
+	return patches, nil
+}
@@ -0,0 +1,35 @@
+package validation
+
+import (
+	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	"github.com/kyverno/kyverno/pkg/controllers/notification"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+)
+
+// buildViolations returns a violation for every failed rule of the engine responses
+func buildViolations(action kyvernov2alpha1.NotificationAction, engineResponses ...engineapi.EngineResponse) []notification.Violation {
+	var violations []notification.Violation
+	for _, er := range engineResponses {
+		policy := er.Policy().GetName()
+		if ns := er.Policy().GetNamespace(); ns != "" {
+			policy = ns + "/" + policy
+		}
+		for _, rule := range er.PolicyResponse.Rules {
+			if rule.Status() != engineapi.RuleStatusFail {
+				continue
+			}
+			violations = append(violations, notification.Violation{
+				Action: action,
+				Policy: policy,
+				Rule:   rule.Name(),
+				Resource: notification.Resource{
+					Kind:      er.Resource.GetKind(),
+					Namespace: er.Resource.GetNamespace(),
+					Name:      er.Resource.GetName(),
+				},
+				Message: rule.Message(),
+			})
+		}
+	}
+	return violations
+}
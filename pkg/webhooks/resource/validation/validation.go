@@ -7,8 +7,10 @@ import (
 
 	"github.com/go-logr/logr"
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
 	"github.com/kyverno/kyverno/pkg/client/clientset/versioned"
 	"github.com/kyverno/kyverno/pkg/config"
+	"github.com/kyverno/kyverno/pkg/controllers/notification"
 	"github.com/kyverno/kyverno/pkg/engine"
 	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
 	"github.com/kyverno/kyverno/pkg/event"
@@ -16,6 +18,8 @@ import (
 	"github.com/kyverno/kyverno/pkg/policycache"
 	"github.com/kyverno/kyverno/pkg/tracing"
 	admissionutils "github.com/kyverno/kyverno/pkg/utils/admission"
+	engineutils "github.com/kyverno/kyverno/pkg/utils/engine"
+	"github.com/kyverno/kyverno/pkg/utils/parallel"
 	reportutils "github.com/kyverno/kyverno/pkg/utils/report"
 	"github.com/kyverno/kyverno/pkg/webhooks/handlers"
 	webhookutils "github.com/kyverno/kyverno/pkg/webhooks/utils"
@@ -29,19 +33,22 @@ type ValidationHandler interface {
 	// HandleValidation handles validating webhook admission request
 	// If there are no errors in validating rule we apply generation rules
 	// patchedResource is the (resource + patches) after applying mutation rules
-	HandleValidation(context.Context, handlers.AdmissionRequest, []kyvernov1.PolicyInterface, *engine.PolicyContext, time.Time) (bool, string, []string)
+	HandleValidation(context.Context, handlers.AdmissionRequest, []kyvernov1.PolicyInterface, *engine.PolicyContext, time.Time) ([]string, error)
 }
 
 func NewValidationHandler(
 	log logr.Logger,
 	kyvernoClient versioned.Interface,
 	engine engineapi.Engine,
-	pCache policycache.Cache,
+	pCache policycache.Snapshot,
 	pcBuilder webhookutils.PolicyContextBuilder,
 	eventGen event.Interface,
 	admissionReports bool,
 	metrics metrics.MetricsConfigManager,
 	cfg config.Configuration,
+	auditWarn bool,
+	parallelism int,
+	notifier notification.Notifier,
 ) ValidationHandler {
 	return &validationHandler{
 		log:              log,
@@ -53,6 +60,9 @@ func NewValidationHandler(
 		admissionReports: admissionReports,
 		metrics:          metrics,
 		cfg:              cfg,
+		auditWarn:        auditWarn,
+		parallelism:      parallelism,
+		notifier:         notifier,
 	}
 }
 
@@ -60,12 +70,15 @@ type validationHandler struct {
 	log              logr.Logger
 	kyvernoClient    versioned.Interface
 	engine           engineapi.Engine
-	pCache           policycache.Cache
+	pCache           policycache.Snapshot
 	pcBuilder        webhookutils.PolicyContextBuilder
 	eventGen         event.Interface
 	admissionReports bool
 	metrics          metrics.MetricsConfigManager
 	cfg              config.Configuration
+	auditWarn        bool
+	parallelism      int
+	notifier         notification.Notifier
 }
 
 func (v *validationHandler) HandleValidation(
@@ -74,56 +87,90 @@ func (v *validationHandler) HandleValidation(
 	policies []kyvernov1.PolicyInterface,
 	policyContext *engine.PolicyContext,
 	admissionRequestTimestamp time.Time,
-) (bool, string, []string) {
+) ([]string, error) {
 	resourceName := admissionutils.GetResourceName(request.AdmissionRequest)
 	logger := v.log.WithValues("action", "validate", "resource", resourceName, "operation", request.Operation, "gvk", request.Kind)
 
-	var engineResponses []engineapi.EngineResponse
 	failurePolicy := kyvernov1.Ignore
 	for _, policy := range policies {
+		if policy.GetSpec().GetFailurePolicy(ctx) == kyvernov1.Fail {
+			failurePolicy = kyvernov1.Fail
+		}
+	}
+	policyContexts, err := v.policyContexts(request, policyContext, policies)
+	if err != nil {
+		return nil, err
+	}
+	var engineResponses []engineapi.EngineResponse
+	// policies are the unit of concurrency, rules of a policy are evaluated sequentially by the engine
+	responses := parallel.Map(v.parallelism, policies, func(i int, policy kyvernov1.PolicyInterface) engineapi.EngineResponse {
+		var engineResponse engineapi.EngineResponse
 		tracing.ChildSpan(
 			ctx,
 			"pkg/webhooks/resource/validate",
 			fmt.Sprintf("POLICY %s/%s", policy.GetNamespace(), policy.GetName()),
 			func(ctx context.Context, span trace.Span) {
-				policyContext := policyContext.WithPolicy(policy)
-				if policy.GetSpec().GetFailurePolicy(ctx) == kyvernov1.Fail {
-					failurePolicy = kyvernov1.Fail
-				}
-
-				engineResponse := v.engine.Validate(ctx, policyContext)
-				if engineResponse.IsNil() {
-					// we get an empty response if old and new resources created the same response
-					// allow updates if resource update doesnt change the policy evaluation
-					return
-				}
-
-				engineResponses = append(engineResponses, engineResponse)
-				if !engineResponse.IsSuccessful() {
-					logger.V(2).Info("validation failed", "action", policy.GetSpec().ValidationFailureAction, "policy", policy.GetName(), "failed rules", engineResponse.GetFailedRules())
-					return
-				}
-
-				if len(engineResponse.GetSuccessRules()) > 0 {
-					logger.V(2).Info("validation passed", "policy", policy.GetName())
-				}
+				engineResponse = v.engine.Validate(ctx, policyContexts[i])
 			},
 		)
+		return engineResponse
+	})
+	// responses are assembled in policy order, regardless of the evaluation order
+	for i, engineResponse := range responses {
+		policy := policies[i]
+		if engineResponse.IsNil() {
+			// we get an empty response if old and new resources created the same response
+			// allow updates if resource update doesnt change the policy evaluation
+			continue
+		}
+
+		engineResponses = append(engineResponses, engineResponse)
+		if !engineResponse.IsSuccessful() {
+			logger.V(2).Info("validation failed", "action", policy.GetSpec().ValidationFailureAction, "policy", policy.GetName(), "failed rules", engineResponse.GetFailedRules())
+			continue
+		}
+
+		if len(engineResponse.GetSuccessRules()) > 0 {
+			logger.V(2).Info("validation passed", "policy", policy.GetName())
+		}
 	}
 
 	blocked := webhookutils.BlockRequest(engineResponses, failurePolicy, logger)
+	webhookutils.RecordRolloutDecisions(ctx, logger, engineResponses)
+	webhookutils.RecordPolicyAdmissions(ctx, logger, engineResponses, failurePolicy, blocked)
 	events := webhookutils.GenerateEvents(engineResponses, blocked)
 	v.eventGen.Add(events...)
+	v.eventGen.Add(webhookutils.GenerateLatencyBudgetEvents(engineResponses)...)
 
 	if blocked {
 		logger.V(4).Info("admission request blocked")
-		return false, webhookutils.GetBlockedMessages(engineResponses), nil
+		if v.notifier != nil && !admissionutils.IsDryRun(request.AdmissionRequest) {
+			var blocking []engineapi.EngineResponse
+			for _, er := range engineResponses {
+				if engineutils.BlockRequest(er, failurePolicy) {
+					blocking = append(blocking, er)
+				}
+			}
+			v.notifier.Notify(buildViolations(kyvernov2alpha1.NotificationEnforce, blocking...)...)
+		}
+		return nil, webhookutils.GetBlockedError(engineResponses)
 	}
 
-	go v.handleAudit(ctx, policyContext.NewResource(), request, policyContext.NamespaceLabels(), engineResponses...)
+	// audit policies are evaluated synchronously when their violations are returned as warnings
+	var auditResponses []engineapi.EngineResponse
+	if v.auditWarn {
+		responses, err := v.buildAuditResponses(ctx, policyContext.NewResource(), request, policyContext.NamespaceLabels(), policyContext.NamespaceAnnotations())
+		if err != nil {
+			logger.Error(err, "failed to build audit responses")
+		}
+		auditResponses = responses
+	}
+
+	go v.handleAudit(ctx, policyContext.NewResource(), request, policyContext.NamespaceLabels(), policyContext.NamespaceAnnotations(), auditResponses, engineResponses...)
 
 	warnings := webhookutils.GetWarningMessages(engineResponses)
-	return true, "", warnings
+	warnings = append(warnings, webhookutils.GetWarningMessages(auditResponses)...)
+	return warnings, nil
 }
 
 func (v *validationHandler) buildAuditResponses(
@@ -131,34 +178,70 @@ func (v *validationHandler) buildAuditResponses(
 	resource unstructured.Unstructured,
 	request handlers.AdmissionRequest,
 	namespaceLabels map[string]string,
+	namespaceAnnotations map[string]string,
 ) ([]engineapi.EngineResponse, error) {
 	gvr := schema.GroupVersionResource(request.Resource)
-	policies := v.pCache.GetPolicies(policycache.ValidateAudit, gvr, request.SubResource, request.Namespace)
+	policies := v.pCache.GetPolicies(policycache.ValidateAudit, gvr, request.SubResource, request.Namespace, kyvernov1.AdmissionOperation(request.Operation))
 	policyContext, err := v.pcBuilder.Build(request.AdmissionRequest, request.Roles, request.ClusterRoles, request.GroupVersionKind)
 	if err != nil {
 		return nil, err
 	}
-	var responses []engineapi.EngineResponse
-	for _, policy := range policies {
+	policyContext = policyContext.
+		WithNamespaceLabels(namespaceLabels).
+		WithNamespaceAnnotations(namespaceAnnotations).
+		WithPolicyCacheRevision(v.pCache.Revision())
+	policyContexts, err := v.policyContexts(request, policyContext, policies)
+	if err != nil {
+		return nil, err
+	}
+	responses := parallel.Map(v.parallelism, policies, func(i int, policy kyvernov1.PolicyInterface) engineapi.EngineResponse {
+		var response engineapi.EngineResponse
 		tracing.ChildSpan(
 			ctx,
 			"pkg/webhooks/resource/validate",
 			fmt.Sprintf("POLICY %s/%s", policy.GetNamespace(), policy.GetName()),
 			func(ctx context.Context, span trace.Span) {
-				policyContext := policyContext.WithPolicy(policy).WithNamespaceLabels(namespaceLabels)
-				response := v.engine.Validate(ctx, policyContext)
-				responses = append(responses, response)
+				response = v.engine.Validate(ctx, policyContexts[i])
 			},
 		)
-	}
+		return response
+	})
 	return responses, nil
 }
 
+// policyContexts returns a policy context for every policy.
+// When policies are evaluated concurrently, every policy context gets its own json context
+// (the json context is stateful and cannot be shared between goroutines).
+func (v *validationHandler) policyContexts(
+	request handlers.AdmissionRequest,
+	policyContext *engine.PolicyContext,
+	policies []kyvernov1.PolicyInterface,
+) ([]*engine.PolicyContext, error) {
+	policyContexts := make([]*engine.PolicyContext, 0, len(policies))
+	for i, policy := range policies {
+		current := policyContext
+		if v.parallelism > 1 && i > 0 {
+			built, err := v.pcBuilder.Build(request.AdmissionRequest, request.Roles, request.ClusterRoles, request.GroupVersionKind)
+			if err != nil {
+				return nil, err
+			}
+			current = built.
+				WithNamespaceLabels(policyContext.NamespaceLabels()).
+				WithNamespaceAnnotations(policyContext.NamespaceAnnotations()).
+				WithPolicyCacheRevision(policyContext.PolicyCacheRevision())
+		}
+		policyContexts = append(policyContexts, current.WithPolicy(policy))
+	}
+	return policyContexts, nil
+}
+
 func (v *validationHandler) handleAudit(
 	ctx context.Context,
 	resource unstructured.Unstructured,
 	request handlers.AdmissionRequest,
 	namespaceLabels map[string]string,
+	namespaceAnnotations map[string]string,
+	auditResponses []engineapi.EngineResponse,
 	engineResponses ...engineapi.EngineResponse,
 ) {
 	createReport := v.admissionReports
@@ -182,17 +265,26 @@ func (v *validationHandler) handleAudit(
 		"",
 		fmt.Sprintf("AUDIT %s %s", request.Operation, request.Kind),
 		func(ctx context.Context, span trace.Span) {
-			responses, err := v.buildAuditResponses(ctx, resource, request, namespaceLabels)
-			if err != nil {
-				v.log.Error(err, "failed to build audit responses")
+			responses := auditResponses
+			// audit responses have already been computed when audit warnings are enabled
+			if !v.auditWarn {
+				var err error
+				responses, err = v.buildAuditResponses(ctx, resource, request, namespaceLabels, namespaceAnnotations)
+				if err != nil {
+					v.log.Error(err, "failed to build audit responses")
+				}
 			}
 			events := webhookutils.GenerateEvents(responses, false)
 			v.eventGen.Add(events...)
+			if v.notifier != nil && !admissionutils.IsDryRun(request.AdmissionRequest) {
+				v.notifier.Notify(buildViolations(kyvernov2alpha1.NotificationAudit, responses...)...)
+			}
 			if createReport {
 				responses = append(responses, engineResponses...)
 				report := reportutils.BuildAdmissionReport(resource, request.AdmissionRequest, responses...)
+				reportutils.SetResults(report, reportutils.ExcludeResults(v.cfg, resource.GetNamespace(), report.GetResults())...)
 				if len(report.GetResults()) > 0 {
-					_, err = reportutils.CreateReport(ctx, report, v.kyvernoClient)
+					_, err := reportutils.CreateReport(ctx, report, v.kyvernoClient)
 					if err != nil {
 						v.log.Error(err, "failed to create report")
 					}
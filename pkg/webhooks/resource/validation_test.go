@@ -1064,6 +1064,8 @@ func TestValidate_failure_action_overrides(t *testing.T) {
 		imageverifycache.DisabledImageVerifyCache(),
 		factories.DefaultContextLoaderFactory(nil),
 		nil,
+		nil,
+		nil,
 		"",
 	)
 	for i, tc := range testcases {
@@ -1167,6 +1169,8 @@ func Test_RuleSelector(t *testing.T) {
 		imageverifycache.DisabledImageVerifyCache(),
 		factories.DefaultContextLoaderFactory(nil),
 		nil,
+		nil,
+		nil,
 		"",
 	)
 	resp := eng.Validate(
@@ -9,17 +9,25 @@ import (
 	"github.com/go-logr/logr"
 	"github.com/julienschmidt/httprouter"
 	"github.com/kyverno/kyverno/api/kyverno"
+	"github.com/kyverno/kyverno/pkg/catalog"
 	"github.com/kyverno/kyverno/pkg/clients/dclient"
 	"github.com/kyverno/kyverno/pkg/config"
+	"github.com/kyverno/kyverno/pkg/jsonpayload"
 	"github.com/kyverno/kyverno/pkg/logging"
 	"github.com/kyverno/kyverno/pkg/metrics"
 	"github.com/kyverno/kyverno/pkg/toggle"
 	controllerutils "github.com/kyverno/kyverno/pkg/utils/controller"
 	runtimeutils "github.com/kyverno/kyverno/pkg/utils/runtime"
 	"github.com/kyverno/kyverno/pkg/webhooks/handlers"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"go.opentelemetry.io/otel/attribute"
 	admissionv1 "k8s.io/api/admission/v1"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corev1listers "k8s.io/client-go/listers/core/v1"
 	rbacv1listers "k8s.io/client-go/listers/rbac/v1"
 )
 
@@ -29,6 +37,27 @@ type DebugModeOptions struct {
 	DumpPayload bool
 }
 
+// DeadlineOptions holds the options to respond to admission requests before the API server times out
+type DeadlineOptions struct {
+	// Timeout is the webhook timeout used when the API server doesn't announce it.
+	Timeout time.Duration
+	// Margin is the duration before the timeout at which the failure policy response is sent, the guard is disabled when not positive.
+	Margin time.Duration
+}
+
+// DeduplicationOptions holds the options to coalesce identical admission requests
+type DeduplicationOptions struct {
+	// Enabled evaluates identical admission requests received concurrently once and returns the response to all of them.
+	Enabled bool
+}
+
+// ProbeOptions holds the options to configure the probes listener
+type ProbeOptions struct {
+	// Address is the address of the plain HTTP listener serving the liveness, readiness and metrics endpoints.
+	// The probes are served by the TLS listener when empty.
+	Address string
+}
+
 type Server interface {
 	// Run TLS server in separate thread and returns control immediately
 	Run(<-chan struct{})
@@ -48,6 +77,26 @@ type PolicyHandlers interface {
 	Validate(context.Context, logr.Logger, handlers.AdmissionRequest, time.Time) admissionv1.AdmissionResponse
 }
 
+type AuthorizationHandlers interface {
+	// Authorize decides on subject access reviews sent by the API server
+	Authorize(context.Context, logr.Logger, authorizationv1.SubjectAccessReviewSpec) authorizationv1.SubjectAccessReviewStatus
+}
+
+type ConversionHandlers interface {
+	// Convert converts policies between the served api versions
+	Convert(context.Context, logr.Logger, apiextensionsv1.ConversionRequest) apiextensionsv1.ConversionResponse
+}
+
+type PayloadHandlers interface {
+	// Validate validates arbitrary json payloads against the policies matching their kind
+	Validate(context.Context, logr.Logger, jsonpayload.Request) jsonpayload.Response
+}
+
+type CatalogHandlers interface {
+	// Catalog lists the installed policies, restricted to the ones applying to the namespace when not empty
+	Catalog(context.Context, logr.Logger, string) (catalog.Catalog, error)
+}
+
 type ResourceHandlers interface {
 	// Mutate performs the mutation of kube resources
 	Mutate(context.Context, logr.Logger, handlers.AdmissionRequest, string, time.Time) admissionv1.AdmissionResponse
@@ -57,6 +106,7 @@ type ResourceHandlers interface {
 
 type server struct {
 	server      *http.Server
+	probeServer *http.Server
 	runtime     runtimeutils.Runtime
 	mwcClient   controllerutils.DeleteCollectionClient
 	vwcClient   controllerutils.DeleteCollectionClient
@@ -71,9 +121,18 @@ func NewServer(
 	policyHandlers PolicyHandlers,
 	resourceHandlers ResourceHandlers,
 	exceptionHandlers ExceptionHandlers,
+	authorizationHandlers AuthorizationHandlers,
+	conversionHandlers ConversionHandlers,
+	payloadHandlers PayloadHandlers,
+	catalogHandlers CatalogHandlers,
 	configuration config.Configuration,
 	metricsConfig metrics.MetricsConfigManager,
 	debugModeOpts DebugModeOptions,
+	requestLimits RequestLimitOptions,
+	accessLog AccessLogOptions,
+	deadline DeadlineOptions,
+	deduplication DeduplicationOptions,
+	probeOpts ProbeOptions,
 	tlsProvider TlsProvider,
 	mwcClient controllerutils.DeleteCollectionClient,
 	vwcClient controllerutils.DeleteCollectionClient,
@@ -81,6 +140,7 @@ func NewServer(
 	runtime runtimeutils.Runtime,
 	rbLister rbacv1listers.RoleBindingLister,
 	crbLister rbacv1listers.ClusterRoleBindingLister,
+	nsLister corev1listers.NamespaceLister,
 	discovery dclient.IDiscovery,
 ) Server {
 	mux := httprouter.New()
@@ -90,76 +150,154 @@ func NewServer(
 	verifyLogger := logger.WithName("verify")
 	registerWebhookHandlers(
 		mux,
+		resourceLogger.WithName("mutate"),
 		"MUTATE",
+		metrics.WebhookMutating,
 		config.MutatingWebhookServicePath,
+		deadline,
 		resourceHandlers.Mutate,
 		func(handler handlers.AdmissionHandler) handlers.HttpHandler {
 			return handler.
-				WithFilter(configuration).
-				WithProtection(toggle.FromContext(ctx).ProtectManagedResources()).
-				WithDump(debugModeOpts.DumpPayload).
+				WithFilter(configuration, nsLister).
+				WithProtection(toggle.FromContext(ctx).ProtectManagedResources(), configuration).
+				WithConfigurableDump(debugModeOpts.DumpPayload, configuration).
 				WithTopLevelGVK(discovery).
 				WithRoles(rbLister, crbLister).
+				WithGroups(configuration).
 				WithOperationFilter(admissionv1.Create, admissionv1.Update, admissionv1.Connect).
+				WithDeduplication(resourceLogger, deduplication.Enabled, metrics.WebhookMutating).
+				WithAccessLog(accessLog.SampleRateFor(config.MutatingWebhookServicePath), accessLog.LatencyThreshold).
 				WithMetrics(resourceLogger, metricsConfig.Config(), metrics.WebhookMutating).
-				WithAdmission(resourceLogger.WithName("mutate"))
+				WithAdmission(resourceLogger.WithName("mutate")).
+				WithMaxRequestBytes(requestLimits.For(config.MutatingWebhookServicePath))
 		},
 	)
 	registerWebhookHandlers(
 		mux,
+		resourceLogger.WithName("validate"),
 		"VALIDATE",
+		metrics.WebhookValidating,
 		config.ValidatingWebhookServicePath,
+		deadline,
 		resourceHandlers.Validate,
 		func(handler handlers.AdmissionHandler) handlers.HttpHandler {
 			return handler.
-				WithFilter(configuration).
-				WithProtection(toggle.FromContext(ctx).ProtectManagedResources()).
-				WithDump(debugModeOpts.DumpPayload).
+				WithFilter(configuration, nsLister).
+				WithProtection(toggle.FromContext(ctx).ProtectManagedResources(), configuration).
+				WithConfigurableDump(debugModeOpts.DumpPayload, configuration).
 				WithTopLevelGVK(discovery).
 				WithRoles(rbLister, crbLister).
+				WithGroups(configuration).
+				WithDeduplication(resourceLogger, deduplication.Enabled, metrics.WebhookValidating).
+				WithAccessLog(accessLog.SampleRateFor(config.ValidatingWebhookServicePath), accessLog.LatencyThreshold).
 				WithMetrics(resourceLogger, metricsConfig.Config(), metrics.WebhookValidating).
-				WithAdmission(resourceLogger.WithName("validate"))
+				WithAdmission(resourceLogger.WithName("validate")).
+				WithMaxRequestBytes(requestLimits.For(config.ValidatingWebhookServicePath))
 		},
 	)
 	mux.HandlerFunc(
 		"POST",
 		config.PolicyMutatingWebhookServicePath,
 		handlers.FromAdmissionFunc("MUTATE", policyHandlers.Mutate).
-			WithDump(debugModeOpts.DumpPayload).
+			WithRecovery(policyLogger.WithName("mutate"), admissionregistrationv1.Fail, metrics.WebhookMutating).
+			WithConfigurableDump(debugModeOpts.DumpPayload, configuration).
+			WithAccessLog(accessLog.SampleRateFor(config.PolicyMutatingWebhookServicePath), accessLog.LatencyThreshold).
 			WithMetrics(policyLogger, metricsConfig.Config(), metrics.WebhookMutating).
 			WithAdmission(policyLogger.WithName("mutate")).
+			WithMaxRequestBytes(requestLimits.For(config.PolicyMutatingWebhookServicePath)).
 			ToHandlerFunc(),
 	)
 	mux.HandlerFunc(
 		"POST",
 		config.PolicyValidatingWebhookServicePath,
 		handlers.FromAdmissionFunc("VALIDATE", policyHandlers.Validate).
-			WithDump(debugModeOpts.DumpPayload).
+			WithRecovery(policyLogger.WithName("validate"), admissionregistrationv1.Fail, metrics.WebhookValidating).
+			WithConfigurableDump(debugModeOpts.DumpPayload, configuration).
 			WithSubResourceFilter().
+			WithAccessLog(accessLog.SampleRateFor(config.PolicyValidatingWebhookServicePath), accessLog.LatencyThreshold).
 			WithMetrics(policyLogger, metricsConfig.Config(), metrics.WebhookValidating).
 			WithAdmission(policyLogger.WithName("validate")).
+			WithMaxRequestBytes(requestLimits.For(config.PolicyValidatingWebhookServicePath)).
 			ToHandlerFunc(),
 	)
 	mux.HandlerFunc(
 		"POST",
 		config.ExceptionValidatingWebhookServicePath,
 		handlers.FromAdmissionFunc("VALIDATE", exceptionHandlers.Validate).
-			WithDump(debugModeOpts.DumpPayload).
+			WithRecovery(exceptionLogger.WithName("validate"), admissionregistrationv1.Fail, metrics.WebhookValidating).
+			WithConfigurableDump(debugModeOpts.DumpPayload, configuration).
 			WithSubResourceFilter().
+			WithAccessLog(accessLog.SampleRateFor(config.ExceptionValidatingWebhookServicePath), accessLog.LatencyThreshold).
 			WithMetrics(exceptionLogger, metricsConfig.Config(), metrics.WebhookValidating).
 			WithAdmission(exceptionLogger.WithName("validate")).
+			WithMaxRequestBytes(requestLimits.For(config.ExceptionValidatingWebhookServicePath)).
 			ToHandlerFunc(),
 	)
 	mux.HandlerFunc(
 		"POST",
 		config.VerifyMutatingWebhookServicePath,
 		handlers.FromAdmissionFunc("VERIFY", handlers.Verify).
+			WithRecovery(verifyLogger.WithName("mutate"), admissionregistrationv1.Ignore, metrics.WebhookMutating).
+			WithAccessLog(accessLog.SampleRateFor(config.VerifyMutatingWebhookServicePath), accessLog.LatencyThreshold).
 			WithAdmission(verifyLogger.WithName("mutate")).
+			WithMaxRequestBytes(requestLimits.For(config.VerifyMutatingWebhookServicePath)).
 			ToHandlerFunc(),
 	)
-	mux.HandlerFunc("GET", config.LivenessServicePath, handlers.Probe(runtime.IsLive))
-	mux.HandlerFunc("GET", config.ReadinessServicePath, handlers.Probe(runtime.IsReady))
+	// the authorization webhook is only served when enabled
+	if authorizationHandlers != nil {
+		authorizationLogger := logger.WithName("authorization")
+		mux.HandlerFunc(
+			"POST",
+			config.AuthorizationWebhookServicePath,
+			handlers.AuthorizationHandler(authorizationHandlers.Authorize).
+				WithAuthorization(authorizationLogger).
+				WithMaxRequestBytes(requestLimits.For(config.AuthorizationWebhookServicePath)).
+				ToHandlerFunc(),
+		)
+	}
+	// the conversion webhook is only served when enabled
+	if conversionHandlers != nil {
+		conversionLogger := logger.WithName("conversion")
+		mux.HandlerFunc(
+			"POST",
+			config.ConversionWebhookServicePath,
+			handlers.ConversionHandler(conversionHandlers.Convert).
+				WithConversion(conversionLogger).
+				WithMaxRequestBytes(requestLimits.For(config.ConversionWebhookServicePath)).
+				ToHandlerFunc(),
+		)
+	}
+	// the json validation endpoint is only served when enabled
+	if payloadHandlers != nil {
+		payloadLogger := logger.WithName("payload")
+		mux.HandlerFunc(
+			"POST",
+			config.JSONValidationServicePath,
+			handlers.PayloadHandler(payloadHandlers.Validate).
+				WithPayload(payloadLogger).
+				WithMaxRequestBytes(requestLimits.For(config.JSONValidationServicePath)).
+				ToHandlerFunc(),
+		)
+	}
+	// the policy catalog endpoint is only served when enabled
+	if catalogHandlers != nil {
+		catalogLogger := logger.WithName("catalog")
+		mux.HandlerFunc(
+			"GET",
+			config.PolicyCatalogServicePath,
+			handlers.Catalog(catalogLogger, func(ctx context.Context, namespace string) (catalog.Catalog, error) {
+				return catalogHandlers.Catalog(ctx, catalogLogger, namespace)
+			}),
+		)
+	}
+	var probeServer *http.Server
+	if probeOpts.Address != "" {
+		probeServer = newProbeServer(probeOpts.Address, runtime, configuration)
+	} else {
+		registerProbeHandlers(mux, runtime, configuration)
+	}
 	return &server{
+		probeServer: probeServer,
 		server: &http.Server{
 			Addr: ":9443",
 			TLSConfig: &tls.Config{
@@ -200,6 +338,14 @@ func NewServer(
 }
 
 func (s *server) Run(stopCh <-chan struct{}) {
+	if s.probeServer != nil {
+		go func() {
+			logger.V(3).Info("started serving probes", "addr", s.probeServer.Addr)
+			if err := s.probeServer.ListenAndServe(); err != http.ErrServerClosed {
+				logger.Error(err, "failed to listen to probes")
+			}
+		}()
+	}
 	go func() {
 		logger.V(3).Info("started serving requests", "addr", s.server.Addr)
 		if err := s.server.ListenAndServeTLS("", ""); err != http.ErrServerClosed {
@@ -225,6 +371,15 @@ func (s *server) Stop() {
 			logger.Error(err, "server shut down failed")
 		}
 	}
+	// the probes listener is stopped last so that probes keep answering while requests drain
+	if s.probeServer != nil {
+		if err := s.probeServer.Shutdown(ctx); err != nil {
+			logger.Error(err, "shutting down probes server")
+			if err := s.probeServer.Close(); err != nil {
+				logger.Error(err, "probes server shut down failed")
+			}
+		}
+	}
 }
 
 func (s *server) cleanup(ctx context.Context) {
@@ -255,10 +410,36 @@ func (s *server) cleanup(ctx context.Context) {
 	}
 }
 
+func registerProbeHandlers(mux *httprouter.Router, runtime runtimeutils.Runtime, configuration config.Configuration) {
+	mux.HandlerFunc("GET", config.LivenessServicePath, handlers.Probe(runtime.IsLive))
+	mux.HandlerFunc("GET", config.ReadinessServicePath, handlers.Probe(runtime.IsReady))
+	mux.HandlerFunc("GET", config.ConfigStatusPath, handlers.ConfigStatus(configuration))
+}
+
+// newProbeServer creates the plain HTTP server serving the probes, config status and metrics endpoints,
+// kubelet probes and metrics scrapers don't need TLS and don't send client certificates
+func newProbeServer(addr string, runtime runtimeutils.Runtime, configuration config.Configuration) *http.Server {
+	mux := httprouter.New()
+	registerProbeHandlers(mux, runtime, configuration)
+	mux.Handler("GET", config.MetricsPath, promhttp.Handler())
+	return &http.Server{
+		Addr:              addr,
+		Handler:           mux,
+		ReadTimeout:       30 * time.Second,
+		WriteTimeout:      30 * time.Second,
+		ReadHeaderTimeout: 30 * time.Second,
+		IdleTimeout:       5 * time.Minute,
+		ErrorLog:          logging.StdLogger(logger.WithName("probes-server"), ""),
+	}
+}
+
 func registerWebhookHandlers(
 	mux *httprouter.Router,
+	logger logr.Logger,
 	name string,
+	webhook attribute.KeyValue,
 	basePath string,
+	deadline DeadlineOptions,
 	handlerFunc func(context.Context, logr.Logger, handlers.AdmissionRequest, string, time.Time) admissionv1.AdmissionResponse,
 	builder func(handler handlers.AdmissionHandler) handlers.HttpHandler,
 ) {
@@ -267,19 +448,19 @@ func registerWebhookHandlers(
 		func(ctx context.Context, logger logr.Logger, request handlers.AdmissionRequest, startTime time.Time) admissionv1.AdmissionResponse {
 			return handlerFunc(ctx, logger, request, "all", startTime)
 		},
-	)
+	).WithRecovery(logger, admissionregistrationv1.Fail, webhook).WithDeadline(deadline.Timeout, deadline.Margin, admissionregistrationv1.Fail)
 	ignore := handlers.FromAdmissionFunc(
 		name,
 		func(ctx context.Context, logger logr.Logger, request handlers.AdmissionRequest, startTime time.Time) admissionv1.AdmissionResponse {
 			return handlerFunc(ctx, logger, request, "ignore", startTime)
 		},
-	)
+	).WithRecovery(logger, admissionregistrationv1.Ignore, webhook).WithDeadline(deadline.Timeout, deadline.Margin, admissionregistrationv1.Ignore)
 	fail := handlers.FromAdmissionFunc(
 		name,
 		func(ctx context.Context, logger logr.Logger, request handlers.AdmissionRequest, startTime time.Time) admissionv1.AdmissionResponse {
 			return handlerFunc(ctx, logger, request, "fail", startTime)
 		},
-	)
+	).WithRecovery(logger, admissionregistrationv1.Fail, webhook).WithDeadline(deadline.Timeout, deadline.Margin, admissionregistrationv1.Fail)
 	mux.HandlerFunc("POST", basePath, builder(all).ToHandlerFunc())
 	mux.HandlerFunc("POST", basePath+"/ignore", builder(ignore).ToHandlerFunc())
 	mux.HandlerFunc("POST", basePath+"/fail", builder(fail).ToHandlerFunc())
@@ -2,14 +2,26 @@ package utils
 
 import (
 	"fmt"
+	"regexp"
 
 	"github.com/go-logr/logr"
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
 	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
 	engineutils "github.com/kyverno/kyverno/pkg/utils/engine"
 	"gopkg.in/yaml.v2"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+const (
+	// CausePolicyViolation is the status cause type used for failed rules
+	CausePolicyViolation metav1.CauseType = "PolicyViolation"
+	// CausePolicyError is the status cause type used for rules that could not be evaluated
+	CausePolicyError metav1.CauseType = "PolicyError"
+)
+
+var failedPathRegex = regexp.MustCompile(`failed at path (\S+)`)
+
 func getAction(hasViolations bool, i int) string {
 	action := "error"
 	if hasViolations {
@@ -60,3 +72,52 @@ func GetBlockedMessages(engineResponses []engineapi.EngineResponse) string {
 	msg := fmt.Sprintf("\n\nresource %s was blocked due to the following policies \n\n%s", resourceName, results)
 	return msg
 }
+
+// GetBlockedCauses returns a machine readable status cause for every rule with fail or error status,
+// the cause message has the form `<policy>/<rule>: <message>` and the cause field holds the failing path, if any
+func GetBlockedCauses(engineResponses []engineapi.EngineResponse) []metav1.StatusCause {
+	var causes []metav1.StatusCause
+	for _, er := range engineResponses {
+		for _, rule := range er.PolicyResponse.Rules {
+			var causeType metav1.CauseType
+			switch rule.Status() {
+			case engineapi.RuleStatusFail:
+				causeType = CausePolicyViolation
+			case engineapi.RuleStatusError:
+				causeType = CausePolicyError
+			default:
+				continue
+			}
+			cause := metav1.StatusCause{
+				Type:    causeType,
+				Message: fmt.Sprintf("%s/%s: %s", er.Policy().GetName(), rule.Name(), rule.Message()),
+			}
+			if match := failedPathRegex.FindStringSubmatch(rule.Message()); match != nil {
+				cause.Field = match[1]
+			}
+			causes = append(causes, cause)
+		}
+	}
+	return causes
+}
+
+// GetBlockedError returns an error describing why the request was blocked,
+// the error carries the blocked messages and the status causes returned by GetBlockedCauses
+func GetBlockedError(engineResponses []engineapi.EngineResponse) error {
+	var name, kind string
+	if len(engineResponses) != 0 {
+		name = engineResponses[0].Resource.GetName()
+		kind = engineResponses[0].Resource.GetKind()
+	}
+	return &apierrors.StatusError{
+		ErrStatus: metav1.Status{
+			Status:  metav1.StatusFailure,
+			Message: GetBlockedMessages(engineResponses),
+			Details: &metav1.StatusDetails{
+				Name:   name,
+				Kind:   kind,
+				Causes: GetBlockedCauses(engineResponses),
+			},
+		},
+	}
+}
@@ -1,12 +1,14 @@
 package utils
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/go-logr/logr"
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
 	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
 	"github.com/stretchr/testify/assert"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 )
@@ -62,6 +64,29 @@ func TestBlockRequest(t *testing.T) {
 			ValidationFailureAction: kyvernov1.Enforce,
 		},
 	})
+	shadowPolicy := engineapi.NewKyvernoPolicy(&kyvernov1.ClusterPolicy{
+		ObjectMeta: v1.ObjectMeta{
+			Name: "test",
+		},
+		Spec: kyvernov1.Spec{
+			ValidationFailureAction: kyvernov1.Enforce,
+			Rollout: &kyvernov1.Rollout{
+				Mode: kyvernov1.RolloutShadow,
+			},
+		},
+	})
+	zero := 0
+	disabledPolicy := engineapi.NewKyvernoPolicy(&kyvernov1.ClusterPolicy{
+		ObjectMeta: v1.ObjectMeta{
+			Name: "test",
+		},
+		Spec: kyvernov1.Spec{
+			ValidationFailureAction: kyvernov1.Enforce,
+			Rollout: &kyvernov1.Rollout{
+				Percentage: &zero,
+			},
+		},
+	})
 	resource := unstructured.Unstructured{
 		Object: map[string]interface{}{
 			"kind": "foo",
@@ -94,6 +119,48 @@ func TestBlockRequest(t *testing.T) {
 			log:           logr.Discard(),
 		},
 		want: true,
+	}, {
+		name: "failure - shadow",
+		args: args{
+			engineResponses: []engineapi.EngineResponse{
+				engineapi.NewEngineResponse(resource, shadowPolicy, nil).WithPolicyResponse(engineapi.PolicyResponse{
+					Rules: []engineapi.RuleResponse{
+						*engineapi.RuleFail("rule-fail", engineapi.Validation, "message fail"),
+					},
+				}),
+			},
+			failurePolicy: kyvernov1.Fail,
+			log:           logr.Discard(),
+		},
+		want: false,
+	}, {
+		name: "error - shadow",
+		args: args{
+			engineResponses: []engineapi.EngineResponse{
+				engineapi.NewEngineResponse(resource, shadowPolicy, nil).WithPolicyResponse(engineapi.PolicyResponse{
+					Rules: []engineapi.RuleResponse{
+						*engineapi.RuleError("rule-error", engineapi.Validation, "message error", nil),
+					},
+				}),
+			},
+			failurePolicy: kyvernov1.Fail,
+			log:           logr.Discard(),
+		},
+		want: false,
+	}, {
+		name: "failure - zero percent rollout",
+		args: args{
+			engineResponses: []engineapi.EngineResponse{
+				engineapi.NewEngineResponse(resource, disabledPolicy, nil).WithPolicyResponse(engineapi.PolicyResponse{
+					Rules: []engineapi.RuleResponse{
+						*engineapi.RuleFail("rule-fail", engineapi.Validation, "message fail"),
+					},
+				}),
+			},
+			failurePolicy: kyvernov1.Fail,
+			log:           logr.Discard(),
+		},
+		want: false,
 	}, {
 		name: "failure - audit",
 		args: args{
@@ -243,3 +310,48 @@ func TestGetBlockedMessages(t *testing.T) {
 		})
 	}
 }
+
+func TestGetBlockedCauses(t *testing.T) {
+	enforcePolicy := engineapi.NewKyvernoPolicy(&kyvernov1.ClusterPolicy{
+		ObjectMeta: v1.ObjectMeta{
+			Name: "test",
+		},
+		Spec: kyvernov1.Spec{
+			ValidationFailureAction: kyvernov1.Enforce,
+		},
+	})
+	resource := unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"kind": "foo",
+			"metadata": map[string]interface{}{
+				"namespace": "bar",
+				"name":      "baz",
+			},
+		},
+	}
+	engineResponses := []engineapi.EngineResponse{
+		engineapi.NewEngineResponse(resource, enforcePolicy, nil).WithPolicyResponse(engineapi.PolicyResponse{
+			Rules: []engineapi.RuleResponse{
+				*engineapi.RulePass("rule-pass", engineapi.Validation, "message pass"),
+				*engineapi.RuleFail("rule-fail", engineapi.Validation, "validation error: rule rule-fail failed at path /spec/containers/0/image/"),
+				*engineapi.RuleError("rule-error", engineapi.Validation, "message error", nil),
+			},
+		}),
+	}
+	want := []v1.StatusCause{{
+		Type:    CausePolicyViolation,
+		Field:   "/spec/containers/0/image/",
+		Message: "test/rule-fail: validation error: rule rule-fail failed at path /spec/containers/0/image/",
+	}, {
+		Type:    CausePolicyError,
+		Message: "test/rule-error: message error",
+	}}
+	assert.Equal(t, want, GetBlockedCauses(engineResponses))
+	err := GetBlockedError(engineResponses)
+	var status apierrors.APIStatus
+	assert.True(t, errors.As(err, &status))
+	assert.Equal(t, GetBlockedMessages(engineResponses), err.Error())
+	assert.Equal(t, "baz", status.Status().Details.Name)
+	assert.Equal(t, "foo", status.Status().Details.Kind)
+	assert.Equal(t, want, status.Status().Details.Causes)
+}
@@ -1,30 +1,43 @@
 package utils
 
 import (
+	"strings"
+
+	"github.com/go-logr/logr"
 	kyvernov1beta1 "github.com/kyverno/kyverno/api/kyverno/v1beta1"
 	"github.com/kyverno/kyverno/pkg/config"
 	"github.com/kyverno/kyverno/pkg/engine"
 	"github.com/kyverno/kyverno/pkg/engine/jmespath"
+	"github.com/kyverno/kyverno/pkg/logging"
 	admissionv1 "k8s.io/api/admission/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	corev1listers "k8s.io/client-go/listers/core/v1"
 )
 
+// userAttributesContextKey is the context key under which the attributes of the requester are made available
+const userAttributesContextKey = "request.userAttributes"
+
 type PolicyContextBuilder interface {
 	Build(admissionv1.AdmissionRequest, []string, []string, schema.GroupVersionKind) (*engine.PolicyContext, error)
 }
 
 type policyContextBuilder struct {
+	logger        logr.Logger
 	configuration config.Configuration
 	jp            jmespath.Interface
+	saLister      corev1listers.ServiceAccountLister
 }
 
 func NewPolicyContextBuilder(
 	configuration config.Configuration,
 	jp jmespath.Interface,
+	saLister corev1listers.ServiceAccountLister,
 ) PolicyContextBuilder {
 	return &policyContextBuilder{
+		logger:        logging.WithName("PolicyContextBuilder"),
 		configuration: configuration,
 		jp:            jp,
+		saLister:      saLister,
 	}
 }
 
@@ -34,5 +47,48 @@ func (b *policyContextBuilder) Build(request admissionv1.AdmissionRequest, roles
 		Roles:             roles,
 		ClusterRoles:      clusterRoles,
 	}
-	return engine.NewPolicyContextFromAdmissionRequest(b.jp, request, userRequestInfo, gvk, b.configuration)
+	policyContext, err := engine.NewPolicyContextFromAdmissionRequest(b.jp, request, userRequestInfo, gvk, b.configuration)
+	if err != nil {
+		return nil, err
+	}
+	if attributes := b.userAttributes(request); len(attributes) != 0 {
+		if err := policyContext.JSONContext().AddVariable(userAttributesContextKey, attributes); err != nil {
+			return nil, err
+		}
+	}
+	return policyContext, nil
+}
+
+// userAttributes resolves the attributes of the requester with the mappings of the configuration
+func (b *policyContextBuilder) userAttributes(request admissionv1.AdmissionRequest) map[string]string {
+	if b.configuration == nil {
+		return nil
+	}
+	attributes := b.configuration.GetUserAttributes()
+	if len(attributes) == 0 {
+		return nil
+	}
+	return attributes.Resolve(request.UserInfo.Username, request.UserInfo.Groups, func() map[string]string {
+		if b.saLister == nil {
+			return nil
+		}
+		namespace, name, ok := splitServiceAccountUsername(request.UserInfo.Username)
+		if !ok {
+			return nil
+		}
+		serviceAccount, err := b.saLister.ServiceAccounts(namespace).Get(name)
+		if err != nil {
+			b.logger.V(4).Info("failed to get the requesting service account", "namespace", namespace, "name", name, "error", err)
+			return nil
+		}
+		return serviceAccount.GetAnnotations()
+	})
+}
+
+func splitServiceAccountUsername(username string) (string, string, bool) {
+	parts := strings.Split(strings.TrimPrefix(username, "system:serviceaccount:"), ":")
+	if len(parts) != 2 || !strings.HasPrefix(username, "system:serviceaccount:") || parts[0] == "" || parts[1] == "" {
+		return "", "", false
+	}
+	return parts[0], parts[1], true
 }
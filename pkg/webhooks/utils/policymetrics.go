@@ -0,0 +1,103 @@
+package utils
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/kyverno/kyverno/api/kyverno"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	"github.com/kyverno/kyverno/pkg/event"
+	"github.com/kyverno/kyverno/pkg/metrics"
+	engineutils "github.com/kyverno/kyverno/pkg/utils/engine"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+var (
+	policyAdmissionOnce     sync.Once
+	policyAdmissionDuration metric.Float64Histogram
+	policyDenials           metric.Int64Counter
+)
+
+func getPolicyAdmissionInstruments(logger logr.Logger) (metric.Float64Histogram, metric.Int64Counter) {
+	policyAdmissionOnce.Do(func() {
+		meter := otel.GetMeterProvider().Meter(metrics.MeterName)
+		histogram, err := meter.Float64Histogram(
+			"kyverno_policy_admission_duration_seconds",
+			metric.WithDescription("can be used to track the latencies (in seconds) of the individual policy rules evaluating admission requests"),
+		)
+		if err != nil {
+			logger.Error(err, "Failed to create instrument, kyverno_policy_admission_duration_seconds")
+		} else {
+			policyAdmissionDuration = histogram
+		}
+		counter, err := meter.Int64Counter(
+			"kyverno_policy_denials",
+			metric.WithDescription("can be used to track the admission requests denied by the individual policy rules"),
+		)
+		if err != nil {
+			logger.Error(err, "Failed to create instrument, kyverno_policy_denials")
+		} else {
+			policyDenials = counter
+		}
+	})
+	return policyAdmissionDuration, policyDenials
+}
+
+// RecordPolicyAdmissions records the per policy admission durations and denials,
+// denials are only recorded for the rules of the policies blocking the request
+func RecordPolicyAdmissions(ctx context.Context, logger logr.Logger, engineResponses []engineapi.EngineResponse, failurePolicy kyvernov1.FailurePolicyType, blocked bool) {
+	histogram, counter := getPolicyAdmissionInstruments(logger)
+	for _, er := range engineResponses {
+		denied := blocked && engineutils.BlockRequest(er, failurePolicy)
+		for _, rule := range er.PolicyResponse.Rules {
+			attrs := metric.WithAttributes(
+				attribute.String("policy_name", er.Policy().GetName()),
+				attribute.String("policy_namespace", er.Policy().GetNamespace()),
+				attribute.String("rule_name", rule.Name()),
+				attribute.String("rule_result", string(rule.Status())),
+			)
+			if histogram != nil {
+				histogram.Record(ctx, rule.Stats().ProcessingTime().Seconds(), attrs)
+			}
+			if counter != nil && denied && (rule.Status() == engineapi.RuleStatusFail || rule.Status() == engineapi.RuleStatusError) {
+				counter.Add(ctx, 1, attrs)
+			}
+		}
+	}
+}
+
+// GetLatencyBudget returns the latency budget set on the policy with the policies.kyverno.io/latency-budget annotation
+func GetLatencyBudget(policy kyvernov1.PolicyInterface) (time.Duration, bool) {
+	value, ok := policy.GetAnnotations()[kyverno.AnnotationPolicyLatencyBudget]
+	if !ok {
+		return 0, false
+	}
+	budget, err := time.ParseDuration(value)
+	if err != nil || budget <= 0 {
+		return 0, false
+	}
+	return budget, true
+}
+
+// GenerateLatencyBudgetEvents generates warning events for the policies that took longer than their latency budget
+func GenerateLatencyBudgetEvents(engineResponses []engineapi.EngineResponse) []event.Info {
+	var events []event.Info
+	for _, er := range engineResponses {
+		if er.IsNil() {
+			continue
+		}
+		policy, ok := er.Policy().GetPolicy().(kyvernov1.PolicyInterface)
+		if !ok {
+			continue
+		}
+		if budget, ok := GetLatencyBudget(policy); ok && er.Stats().ProcessingTime() > budget {
+			events = append(events, event.NewLatencyBudgetExceededEvent(event.AdmissionController, er, budget))
+		}
+	}
+	return events
+}
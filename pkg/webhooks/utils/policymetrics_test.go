@@ -0,0 +1,64 @@
+package utils
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kyverno/kyverno/api/kyverno"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	"github.com/kyverno/kyverno/pkg/event"
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestGenerateLatencyBudgetEvents(t *testing.T) {
+	policy := func(budget string) engineapi.GenericPolicy {
+		pol := &kyvernov1.ClusterPolicy{ObjectMeta: v1.ObjectMeta{Name: "test"}}
+		if budget != "" {
+			pol.SetAnnotations(map[string]string{kyverno.AnnotationPolicyLatencyBudget: budget})
+		}
+		return engineapi.NewKyvernoPolicy(pol)
+	}
+	resource := unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"kind": "foo",
+			"metadata": map[string]interface{}{
+				"namespace": "bar",
+				"name":      "baz",
+			},
+		},
+	}
+	now := time.Now()
+	stats := engineapi.NewExecutionStats(now, now.Add(200*time.Millisecond))
+	tests := []struct {
+		name   string
+		budget string
+		want   int
+	}{{
+		name: "no budget",
+	}, {
+		name:   "invalid budget",
+		budget: "foo",
+	}, {
+		name:   "within budget",
+		budget: "1s",
+	}, {
+		name:   "budget exceeded",
+		budget: "100ms",
+		want:   1,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			er := engineapi.NewEngineResponse(resource, policy(tt.budget), nil).WithStats(stats)
+			events := GenerateLatencyBudgetEvents([]engineapi.EngineResponse{er})
+			assert.Len(t, events, tt.want)
+			for _, e := range events {
+				assert.Equal(t, event.PolicyLatencyBudgetExceeded, e.Reason)
+				assert.Equal(t, "test", e.Name)
+				assert.Equal(t, "baz", e.RelatedName)
+			}
+		})
+	}
+}
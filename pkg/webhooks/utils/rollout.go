@@ -0,0 +1,92 @@
+package utils
+
+import (
+	"context"
+	"sync"
+
+	"github.com/go-logr/logr"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	"github.com/kyverno/kyverno/pkg/metrics"
+	engineutils "github.com/kyverno/kyverno/pkg/utils/engine"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+const (
+	// RolloutDecisionEnforced is the decision recorded when the rollout enforces the policy
+	RolloutDecisionEnforced = "enforced"
+	// RolloutDecisionShadowed is the decision recorded when the policy runs in shadow mode
+	RolloutDecisionShadowed = "shadowed"
+	// RolloutDecisionSkipped is the decision recorded when the request is not part of the rollout percentage
+	RolloutDecisionSkipped = "skipped"
+)
+
+var (
+	rolloutOnce    sync.Once
+	rolloutCounter metric.Int64Counter
+)
+
+func getRolloutCounter(logger logr.Logger) metric.Int64Counter {
+	rolloutOnce.Do(func() {
+		meter := otel.GetMeterProvider().Meter(metrics.MeterName)
+		counter, err := meter.Int64Counter(
+			"kyverno_policy_rollout_decisions",
+			metric.WithDescription("can be used to track the requests enforced, shadowed or skipped by the rollout of a policy, and the results the policy would have produced"),
+		)
+		if err != nil {
+			logger.Error(err, "Failed to create instrument, kyverno_policy_rollout_decisions")
+			return
+		}
+		rolloutCounter = counter
+	})
+	return rolloutCounter
+}
+
+// GetRolloutDecision returns the decision taken by the rollout of the policy for the given response
+func GetRolloutDecision(er engineapi.EngineResponse) string {
+	rollout := er.GetRollout()
+	if rollout.IsShadow() {
+		return RolloutDecisionShadowed
+	}
+	if !engineutils.RolloutEnforces(er) {
+		return RolloutDecisionSkipped
+	}
+	return RolloutDecisionEnforced
+}
+
+// RecordRolloutDecisions increments the rollout counters of the policies defining a rollout
+func RecordRolloutDecisions(ctx context.Context, logger logr.Logger, engineResponses []engineapi.EngineResponse) {
+	for _, er := range engineResponses {
+		rollout := er.GetRollout()
+		if rollout == nil {
+			continue
+		}
+		decision := GetRolloutDecision(er)
+		if decision != RolloutDecisionEnforced && (er.IsFailed() || er.IsError()) {
+			logger.V(2).Info("policy not enforced by rollout", "policy", er.Policy().GetName(), "decision", decision)
+		}
+		counter := getRolloutCounter(logger)
+		if counter == nil {
+			continue
+		}
+		result := engineapi.RuleStatusPass
+		if er.IsError() {
+			result = engineapi.RuleStatusError
+		} else if er.IsFailed() {
+			result = engineapi.RuleStatusFail
+		}
+		mode := rollout.Mode
+		if mode == "" {
+			mode = kyvernov1.RolloutEnforce
+		}
+		counter.Add(ctx, 1, metric.WithAttributes(
+			attribute.String("policy_name", er.Policy().GetName()),
+			attribute.String("policy_namespace", er.Policy().GetNamespace()),
+			attribute.String("rollout_mode", string(mode)),
+			attribute.String("rollout_decision", decision),
+			attribute.String("policy_result", string(result)),
+		))
+	}
+}